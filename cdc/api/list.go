@@ -0,0 +1,119 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pingcap/errors"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+)
+
+const (
+	// listOpVarOffset is the query parameter of the index of the first item
+	// returned by a list endpoint.
+	listOpVarOffset = "offset"
+	// listOpVarLimit is the query parameter of the max number of the items
+	// returned by a list endpoint, 0 means no limit.
+	listOpVarLimit = "limit"
+	// listOpVarFields is the query parameter of the comma separated JSON
+	// fields of the items returned by a list endpoint, all the fields are
+	// returned if it is empty.
+	listOpVarFields = "fields"
+
+	// TotalCountHeader is the header of the number of all the items of a list
+	// endpoint, no matter how many of them are returned.
+	TotalCountHeader = "X-Total-Count"
+)
+
+// ListOptions are the pagination and field selection options of a list
+// endpoint.
+type ListOptions struct {
+	Offset int
+	Limit  int
+	Fields []string
+}
+
+// ParseListOptions parses the list options from the query parameters.
+func ParseListOptions(c *gin.Context) (*ListOptions, error) {
+	opts := &ListOptions{}
+	var err error
+	if offset := c.Query(listOpVarOffset); offset != "" {
+		opts.Offset, err = strconv.Atoi(offset)
+		if err != nil || opts.Offset < 0 {
+			return nil, cerror.ErrAPIInvalidParam.GenWithStack(
+				"invalid offset: %s", offset)
+		}
+	}
+	if limit := c.Query(listOpVarLimit); limit != "" {
+		opts.Limit, err = strconv.Atoi(limit)
+		if err != nil || opts.Limit < 0 {
+			return nil, cerror.ErrAPIInvalidParam.GenWithStack(
+				"invalid limit: %s", limit)
+		}
+	}
+	if fields := c.Query(listOpVarFields); fields != "" {
+		for _, field := range strings.Split(fields, ",") {
+			if field = strings.TrimSpace(field); field != "" {
+				opts.Fields = append(opts.Fields, field)
+			}
+		}
+	}
+	return opts, nil
+}
+
+// Page returns the range [start, end) of the items in the page among the
+// total items.
+func (o *ListOptions) Page(total int) (start, end int) {
+	start = o.Offset
+	if start > total {
+		start = total
+	}
+	end = total
+	// start+o.Limit may overflow.
+	if o.Limit > 0 && o.Limit < end-start {
+		end = start + o.Limit
+	}
+	return start, end
+}
+
+// SelectFields returns the JSON objects of the items with only the selected
+// fields, the items are returned as they are if no field is selected.
+func (o *ListOptions) SelectFields(items interface{}) (interface{}, error) {
+	if len(o.Fields) == 0 {
+		return items, nil
+	}
+	data, err := json.Marshal(items)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var objects []map[string]json.RawMessage
+	if err := json.Unmarshal(data, &objects); err != nil {
+		return nil, errors.Trace(err)
+	}
+	selected := make([]map[string]json.RawMessage, 0, len(objects))
+	for _, object := range objects {
+		s := make(map[string]json.RawMessage, len(o.Fields))
+		for _, field := range o.Fields {
+			if value, ok := object[field]; ok {
+				s[field] = value
+			}
+		}
+		selected = append(selected, s)
+	}
+	return selected, nil
+}
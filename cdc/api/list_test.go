@@ -0,0 +1,89 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseListOptions(t *testing.T) {
+	t.Parallel()
+
+	parse := func(query string) (*ListOptions, error) {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/changefeeds?"+query, nil)
+		return ParseListOptions(c)
+	}
+
+	opts, err := parse("")
+	require.Nil(t, err)
+	require.Equal(t, &ListOptions{}, opts)
+
+	opts, err = parse("offset=10&limit=5&fields=id, state,,")
+	require.Nil(t, err)
+	require.Equal(t, &ListOptions{Offset: 10, Limit: 5, Fields: []string{"id", "state"}}, opts)
+
+	_, err = parse("offset=-1")
+	require.Regexp(t, "invalid offset", err)
+	_, err = parse("limit=a")
+	require.Regexp(t, "invalid limit", err)
+}
+
+func TestListOptionsPage(t *testing.T) {
+	t.Parallel()
+
+	start, end := (&ListOptions{}).Page(10)
+	require.Equal(t, 0, start)
+	require.Equal(t, 10, end)
+	start, end = (&ListOptions{Offset: 3, Limit: 5}).Page(10)
+	require.Equal(t, 3, start)
+	require.Equal(t, 8, end)
+	start, end = (&ListOptions{Offset: 8, Limit: 5}).Page(10)
+	require.Equal(t, 8, start)
+	require.Equal(t, 10, end)
+	start, end = (&ListOptions{Offset: 20}).Page(10)
+	require.Equal(t, 10, start)
+	require.Equal(t, 10, end)
+	start, end = (&ListOptions{Offset: 3, Limit: math.MaxInt}).Page(10)
+	require.Equal(t, 3, start)
+	require.Equal(t, 10, end)
+}
+
+func TestListOptionsSelectFields(t *testing.T) {
+	t.Parallel()
+
+	type item struct {
+		ID    string `json:"id"`
+		State string `json:"state"`
+		Error string `json:"error"`
+	}
+	items := []item{{ID: "a", State: "normal", Error: "e"}}
+
+	selected, err := (&ListOptions{}).SelectFields(items)
+	require.Nil(t, err)
+	require.Equal(t, items, selected)
+
+	selected, err = (&ListOptions{Fields: []string{"id", "state", "unknown"}}).SelectFields(items)
+	require.Nil(t, err)
+	data, err := json.Marshal(selected)
+	require.Nil(t, err)
+	require.JSONEq(t, `[{"id":"a","state":"normal"}]`, string(data))
+}
@@ -14,7 +14,10 @@
 package middleware
 
 import (
+	"compress/gzip"
+	"net"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -29,6 +32,10 @@ import (
 // ClientVersionHeader is the header name of client version
 const ClientVersionHeader = "X-client-version"
 
+// ShowSecretsQueryKey is the query parameter to show the secrets, such as
+// the password in the sink uri, in API responses.
+const ShowSecretsQueryKey = "show_secrets"
+
 // LogMiddleware logs the api requests
 func LogMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -79,6 +86,69 @@ func ErrorHandleMiddleware() gin.HandlerFunc {
 	}
 }
 
+// GzipMiddleware compresses the response with gzip if the client accepts it,
+// which reduces the size of the responses of the list APIs a lot.
+func GzipMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !strings.Contains(c.GetHeader("Accept-Encoding"), "gzip") {
+			c.Next()
+			return
+		}
+		w := &gzipWriter{ResponseWriter: c.Writer}
+		c.Writer = w
+		c.Next()
+		if err := w.close(); err != nil {
+			log.Warn("failed to close the gzip writer", zap.Error(err))
+		}
+		c.Writer = w.ResponseWriter
+	}
+}
+
+// gzipWriter compresses the response body lazily, so that the responses
+// without a body are not touched.
+type gzipWriter struct {
+	gin.ResponseWriter
+	gz *gzip.Writer
+	// passthrough is true if the response is encoded by the handler.
+	passthrough bool
+}
+
+func (w *gzipWriter) Write(data []byte) (int, error) {
+	if w.passthrough {
+		return w.ResponseWriter.Write(data)
+	}
+	if w.gz == nil {
+		header := w.Header()
+		if header.Get("Content-Encoding") != "" {
+			w.passthrough = true
+			return w.ResponseWriter.Write(data)
+		}
+		header.Del("Content-Length")
+		header.Set("Content-Encoding", "gzip")
+		header.Add("Vary", "Accept-Encoding")
+		w.gz = gzip.NewWriter(w.ResponseWriter)
+	}
+	return w.gz.Write(data)
+}
+
+func (w *gzipWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+func (w *gzipWriter) Flush() {
+	if w.gz != nil {
+		_ = w.gz.Flush()
+	}
+	w.ResponseWriter.Flush()
+}
+
+func (w *gzipWriter) close() error {
+	if w.gz == nil {
+		return nil
+	}
+	return w.gz.Close()
+}
+
 // ForwardToOwnerMiddleware forward an request to owner if current server
 // is not owner, or handle it locally.
 func ForwardToOwnerMiddleware(p capture.Capture) gin.HandlerFunc {
@@ -109,3 +179,46 @@ func CheckServerReadyMiddleware(capture capture.Capture) gin.HandlerFunc {
 		}
 	}
 }
+
+// CheckShowSecretsMiddleware rejects the requests which ask for secrets unless
+// they are sent from the local host or authenticated by a client certificate.
+// A forwarded request is rejected too, because the capture receiving it can
+// not authorize the original caller, such requests must be sent to the owner
+// directly.
+func CheckShowSecretsMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Query(ShowSecretsQueryKey) != "true" {
+			c.Next()
+			return
+		}
+		if api.IsForwardedFromCapture(c) {
+			c.IndentedJSON(http.StatusForbidden,
+				model.NewHTTPError(errors.ErrAPIInvalidParam.GenWithStack(
+					"%s is not allowed for the requests forwarded to the owner, "+
+						"please send the request to the owner directly", ShowSecretsQueryKey)))
+			c.Abort()
+			return
+		}
+		if isAdminRequest(c.Request) {
+			c.Next()
+			return
+		}
+		c.IndentedJSON(http.StatusForbidden,
+			model.NewHTTPError(errors.ErrAPIInvalidParam.GenWithStack(
+				"%s is only allowed for requests from the local host "+
+					"or with a client certificate", ShowSecretsQueryKey)))
+		c.Abort()
+	}
+}
+
+func isAdminRequest(req *http.Request) bool {
+	if req.TLS != nil && len(req.TLS.VerifiedChains) > 0 {
+		return true
+	}
+	host, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		return false
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
@@ -14,7 +14,9 @@
 package middleware
 
 import (
+	"compress/gzip"
 	"context"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -57,3 +59,84 @@ func TestCheckServerReadyMiddleware(t *testing.T) {
 	router.ServeHTTP(w, req)
 	require.Equal(t, http.StatusOK, w.Code)
 }
+
+func TestCheckShowSecretsMiddleware(t *testing.T) {
+	router := gin.New()
+	router.Use(CheckShowSecretsMiddleware())
+	router.GET("/test", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	testCases := []struct {
+		url        string
+		remoteAddr string
+		header     string
+		code       int
+	}{
+		{"/test", "10.0.0.1:34567", "", http.StatusOK},
+		{"/test?show_secrets=false", "10.0.0.1:34567", "", http.StatusOK},
+		{"/test?show_secrets=true", "10.0.0.1:34567", "", http.StatusForbidden},
+		{"/test?show_secrets=true", "127.0.0.1:34567", "", http.StatusOK},
+		{"/test?show_secrets=true", "[::1]:34567", "", http.StatusOK},
+		{"/test?show_secrets=true", "10.0.0.1:34567", "capture-1", http.StatusForbidden},
+		{"/test?show_secrets=true", "127.0.0.1:34567", "capture-1", http.StatusForbidden},
+		{"/test?show_secrets=false", "10.0.0.1:34567", "capture-1", http.StatusOK},
+	}
+	for _, tc := range testCases {
+		w := httptest.NewRecorder()
+		req, err := http.NewRequestWithContext(context.Background(), "GET", tc.url, nil)
+		require.Nil(t, err)
+		req.RemoteAddr = tc.remoteAddr
+		if tc.header != "" {
+			req.Header.Set("TiCDC-ForwardFromCapture", tc.header)
+		}
+		router.ServeHTTP(w, req)
+		require.Equal(t, tc.code, w.Code, tc.url)
+	}
+}
+
+func TestGzipMiddleware(t *testing.T) {
+	t.Parallel()
+
+	router := gin.New()
+	router.Use(GzipMiddleware())
+	router.GET("/test", func(c *gin.Context) {
+		c.JSON(http.StatusOK, []string{"a", "b"})
+	})
+	router.GET("/empty", func(c *gin.Context) {
+		c.Status(http.StatusNoContent)
+	})
+
+	// the client does not accept gzip
+	w := httptest.NewRecorder()
+	req, err := http.NewRequestWithContext(context.Background(), "GET", "/test", nil)
+	require.Nil(t, err)
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Empty(t, w.Header().Get("Content-Encoding"))
+	require.JSONEq(t, `["a","b"]`, w.Body.String())
+
+	// the client accepts gzip
+	w = httptest.NewRecorder()
+	req, err = http.NewRequestWithContext(context.Background(), "GET", "/test", nil)
+	require.Nil(t, err)
+	req.Header.Set("Accept-Encoding", "gzip, deflate")
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "gzip", w.Header().Get("Content-Encoding"))
+	reader, err := gzip.NewReader(w.Body)
+	require.Nil(t, err)
+	body, err := io.ReadAll(reader)
+	require.Nil(t, err)
+	require.JSONEq(t, `["a","b"]`, string(body))
+
+	// the response without a body is not compressed
+	w = httptest.NewRecorder()
+	req, err = http.NewRequestWithContext(context.Background(), "GET", "/empty", nil)
+	require.Nil(t, err)
+	req.Header.Set("Accept-Encoding", "gzip")
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusNoContent, w.Code)
+	require.Empty(t, w.Header().Get("Content-Encoding"))
+	require.Zero(t, w.Body.Len())
+}
@@ -38,6 +38,7 @@ var httpBadRequestError = []*errors.Error{
 	cerror.ErrChangeFeedNotExists, cerror.ErrTargetTsBeforeStartTs, cerror.ErrTableIneligible,
 	cerror.ErrFilterRuleInvalid, cerror.ErrChangefeedUpdateRefused, cerror.ErrMySQLConnectionError,
 	cerror.ErrMySQLInvalidConfig, cerror.ErrCaptureNotExist, cerror.ErrSchedulerRequestFailed,
+	cerror.ErrSavepointAlreadyExists, cerror.ErrSavepointNotAllowed,
 }
 
 const (
@@ -130,6 +131,47 @@ func HandleOwnerBalance(
 	}
 }
 
+// HandleOwnerUpdateSinkURI updates the sink URI of a running changefeed
+func HandleOwnerUpdateSinkURI(
+	ctx context.Context, capture capture.Capture,
+	changefeedID model.ChangeFeedID, sinkURI string,
+) error {
+	// Use buffered channel to prevent blocking owner.
+	done := make(chan error, 1)
+	o, err := capture.GetOwner()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	o.UpdateSinkURI(changefeedID, sinkURI, done)
+	select {
+	case <-ctx.Done():
+		return errors.Trace(ctx.Err())
+	case err := <-done:
+		return errors.Trace(err)
+	}
+}
+
+// HandleOwnerCreateSavepoint creates a savepoint of a running changefeed,
+// the ts of the savepoint is filled if no error is returned.
+func HandleOwnerCreateSavepoint(
+	ctx context.Context, capture capture.Capture,
+	changefeedID model.ChangeFeedID, savepoint *model.Savepoint,
+) error {
+	// Use buffered channel to prevent blocking owner.
+	done := make(chan error, 1)
+	o, err := capture.GetOwner()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	o.CreateSavepoint(changefeedID, savepoint, done)
+	select {
+	case <-ctx.Done():
+		return errors.Trace(ctx.Err())
+	case err := <-done:
+		return errors.Trace(err)
+	}
+}
+
 // HandleOwnerScheduleTable schedule tables
 func HandleOwnerScheduleTable(
 	ctx context.Context, capture capture.Capture,
@@ -150,6 +192,12 @@ func HandleOwnerScheduleTable(
 	}
 }
 
+// IsForwardedFromCapture returns true if the request is forwarded from
+// another capture.
+func IsForwardedFromCapture(c *gin.Context) bool {
+	return len(c.GetHeader(forwardFromCapture)) != 0
+}
+
 // ForwardToOwner forwards an request to the owner
 func ForwardToOwner(c *gin.Context, p capture.Capture) {
 	ctx := c.Request.Context()
@@ -195,6 +243,11 @@ func ForwardToOwner(c *gin.Context, p capture.Capture) {
 		req.URL.Scheme = "http"
 	}
 	for k, v := range c.Request.Header {
+		// The response is compressed by this capture if the client accepts
+		// it, so the one from the owner is decompressed by the http client.
+		if k == "Accept-Encoding" {
+			continue
+		}
 		for _, vv := range v {
 			req.Header.Add(k, vv)
 		}
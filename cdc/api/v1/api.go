@@ -18,6 +18,7 @@ import (
 	"net/http"
 	"os"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/gin-gonic/gin"
@@ -75,6 +76,7 @@ func RegisterOpenAPIRoutes(router *gin.Engine, api OpenAPI) {
 
 	v1.Use(middleware.CheckServerReadyMiddleware(api.capture))
 	v1.Use(middleware.LogMiddleware())
+	v1.Use(middleware.GzipMiddleware())
 	v1.Use(middleware.ErrorHandleMiddleware())
 
 	// common API
@@ -120,12 +122,20 @@ func RegisterOpenAPIRoutes(router *gin.Engine, api OpenAPI) {
 // @Accept json
 // @Produce json
 // @Param state query string false "state"
+// @Param offset query integer false "offset"
+// @Param limit query integer false "limit"
+// @Param fields query string false "comma separated fields"
 // @Success 200 {array} model.ChangefeedCommonInfo
-// @Failure 500 {object} model.HTTPError
+// @Failure 500,400 {object} model.HTTPError
 // @Router /api/v1/changefeeds [get]
 func (h *OpenAPI) ListChangefeed(c *gin.Context) {
 	ctx := c.Request.Context()
 	state := c.Query(apiOpVarChangefeedState)
+	opts, err := api.ParseListOptions(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
 	// get all changefeed status
 	statuses, err := h.statusProvider().GetAllChangeFeedStatuses(ctx)
 	if err != nil {
@@ -184,7 +194,14 @@ func (h *OpenAPI) ListChangefeed(c *gin.Context) {
 
 		resps = append(resps, resp)
 	}
-	c.IndentedJSON(http.StatusOK, resps)
+	c.Header(api.TotalCountHeader, strconv.Itoa(len(resps)))
+	start, end := opts.Page(len(resps))
+	selected, err := opts.SelectFields(resps[start:end])
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.IndentedJSON(http.StatusOK, selected)
 }
 
 // GetChangefeed get detailed info of a changefeed
@@ -243,19 +260,23 @@ func (h *OpenAPI) GetChangefeed(c *gin.Context) {
 	}
 
 	changefeedDetail := &model.ChangefeedDetail{
-		UpstreamID:     info.UpstreamID,
-		Namespace:      changefeedID.Namespace,
-		ID:             changefeedID.ID,
-		SinkURI:        sinkURI,
-		CreateTime:     model.JSONTime(info.CreateTime),
-		StartTs:        info.StartTs,
-		TargetTs:       info.TargetTs,
-		CheckpointTSO:  status.CheckpointTs,
-		CheckpointTime: model.JSONTime(oracle.GetTimeFromTS(status.CheckpointTs)),
-		ResolvedTs:     status.ResolvedTs,
-		Engine:         info.Engine,
-		FeedState:      info.State,
-		TaskStatus:     taskStatus,
+		UpstreamID:       info.UpstreamID,
+		Namespace:        changefeedID.Namespace,
+		ID:               changefeedID.ID,
+		SinkURI:          sinkURI,
+		CreateTime:       model.JSONTime(info.CreateTime),
+		StartTs:          info.StartTs,
+		TargetTs:         info.TargetTs,
+		CheckpointTSO:    status.CheckpointTs,
+		CheckpointTime:   model.JSONTime(oracle.GetTimeFromTS(status.CheckpointTs)),
+		ResolvedTs:       status.ResolvedTs,
+		Engine:           info.Engine,
+		FeedState:        info.State,
+		TaskStatus:       taskStatus,
+		SinkSelfCheck:    status.SinkSelfCheck,
+		ConsumerGroupLag: status.ConsumerGroupLag,
+		Restart:          status.Restart,
+		SubState:         status.SubState,
 	}
 
 	c.IndentedJSON(http.StatusOK, changefeedDetail)
@@ -721,11 +742,19 @@ func (h *OpenAPI) GetProcessor(c *gin.Context) {
 // @Tags processor
 // @Accept json
 // @Produce json
+// @Param offset query integer false "offset"
+// @Param limit query integer false "limit"
+// @Param fields query string false "comma separated fields"
 // @Success 200 {array} model.ProcessorCommonInfo
 // @Failure 500,400 {object} model.HTTPError
 // @Router	/api/v1/processors [get]
 func (h *OpenAPI) ListProcessor(c *gin.Context) {
 	ctx := c.Request.Context()
+	opts, err := api.ParseListOptions(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
 	infos, err := h.statusProvider().GetProcessors(ctx)
 	if err != nil {
 		_ = c.Error(err)
@@ -739,7 +768,24 @@ func (h *OpenAPI) ListProcessor(c *gin.Context) {
 		}
 		resps[i] = resp
 	}
-	c.IndentedJSON(http.StatusOK, resps)
+	// Sort the processors so that the pages are stable.
+	sort.Slice(resps, func(i, j int) bool {
+		if resps[i].Namespace != resps[j].Namespace {
+			return resps[i].Namespace < resps[j].Namespace
+		}
+		if resps[i].CfID != resps[j].CfID {
+			return resps[i].CfID < resps[j].CfID
+		}
+		return resps[i].CaptureID < resps[j].CaptureID
+	})
+	c.Header(api.TotalCountHeader, strconv.Itoa(len(resps)))
+	start, end := opts.Page(len(resps))
+	selected, err := opts.SelectFields(resps[start:end])
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.IndentedJSON(http.StatusOK, selected)
 }
 
 // ListCapture lists all captures
@@ -748,11 +794,19 @@ func (h *OpenAPI) ListProcessor(c *gin.Context) {
 // @Tags capture
 // @Accept json
 // @Produce json
+// @Param offset query integer false "offset"
+// @Param limit query integer false "limit"
+// @Param fields query string false "comma separated fields"
 // @Success 200 {array} model.Capture
 // @Failure 500,400 {object} model.HTTPError
 // @Router	/api/v1/captures [get]
 func (h *OpenAPI) ListCapture(c *gin.Context) {
 	ctx := c.Request.Context()
+	opts, err := api.ParseListOptions(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
 	captureInfos, err := h.statusProvider().GetCaptures(ctx)
 	if err != nil {
 		_ = c.Error(err)
@@ -782,8 +836,18 @@ func (h *OpenAPI) ListCapture(c *gin.Context) {
 				ClusterID:     etcdClient.GetClusterID(),
 			})
 	}
+	sort.Slice(captures, func(i, j int) bool {
+		return captures[i].ID < captures[j].ID
+	})
 
-	c.IndentedJSON(http.StatusOK, captures)
+	c.Header(api.TotalCountHeader, strconv.Itoa(len(captures)))
+	start, end := opts.Page(len(captures))
+	selected, err := opts.SelectFields(captures[start:end])
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.IndentedJSON(http.StatusOK, selected)
 }
 
 // DrainCapture remove all tables at the given capture.
@@ -94,6 +94,21 @@ func (p *mockStatusProvider) IsHealthy(ctx context.Context) (bool, error) {
 	return args.Get(0).(bool), args.Error(1)
 }
 
+func (p *mockStatusProvider) GetChangeFeedLagBreakdown(ctx context.Context, changefeedID model.ChangeFeedID) (*model.LagBreakdown, error) {
+	args := p.Called(ctx, changefeedID)
+	return args.Get(0).(*model.LagBreakdown), args.Error(1)
+}
+
+func (p *mockStatusProvider) GetChangeFeedWarnings(ctx context.Context, changefeedID model.ChangeFeedID) ([]*model.RunningWarning, error) {
+	args := p.Called(ctx, changefeedID)
+	return args.Get(0).([]*model.RunningWarning), args.Error(1)
+}
+
+func (p *mockStatusProvider) GetChangeFeedSinkStatuses(ctx context.Context, changefeedID model.ChangeFeedID) ([]*model.SinkStatus, error) {
+	args := p.Called(ctx, changefeedID)
+	return args.Get(0).([]*model.SinkStatus), args.Error(1)
+}
+
 func newRouter(c capture.Capture, p owner.StatusProvider) *gin.Engine {
 	router := gin.New()
 	RegisterOpenAPIRoutes(router, NewOpenAPI4Test(c, p))
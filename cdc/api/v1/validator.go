@@ -147,6 +147,7 @@ func verifyCreateChangefeedConfig(
 		Engine:         sortEngine,
 		State:          model.StateNormal,
 		CreatorVersion: version.ReleaseVersion,
+		ConfigVersion:  config.CurrentReplicaConfigVersion,
 	}
 	f, err := filter.NewFilter(replicaConfig, "")
 	if err != nil {
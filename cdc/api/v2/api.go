@@ -17,22 +17,25 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/pingcap/tiflow/cdc/api/middleware"
 	"github.com/pingcap/tiflow/cdc/capture"
+	"github.com/pingcap/tiflow/pkg/monitoring"
 )
 
 // OpenAPIV2 provides CDC v2 APIs
 type OpenAPIV2 struct {
 	capture capture.Capture
 	helpers APIV2Helpers
+	// registry is the metrics registry of the server.
+	registry *monitoring.Registry
 }
 
 // NewOpenAPIV2 creates a new OpenAPIV2.
-func NewOpenAPIV2(c capture.Capture) OpenAPIV2 {
-	return OpenAPIV2{c, APIV2HelpersImpl{}}
+func NewOpenAPIV2(c capture.Capture, registry *monitoring.Registry) OpenAPIV2 {
+	return OpenAPIV2{capture: c, helpers: APIV2HelpersImpl{}, registry: registry}
 }
 
 // NewOpenAPIV2ForTest creates a new OpenAPIV2.
 func NewOpenAPIV2ForTest(c capture.Capture, h APIV2Helpers) OpenAPIV2 {
-	return OpenAPIV2{c, h}
+	return OpenAPIV2{capture: c, helpers: h, registry: monitoring.NewRegistry()}
 }
 
 // RegisterOpenAPIV2Routes registers routes for OpenAPI
@@ -41,21 +44,46 @@ func RegisterOpenAPIV2Routes(router *gin.Engine, api OpenAPIV2) {
 
 	v2.Use(middleware.CheckServerReadyMiddleware(api.capture))
 	v2.Use(middleware.LogMiddleware())
+	v2.Use(middleware.GzipMiddleware())
 	v2.Use(middleware.ErrorHandleMiddleware())
 
 	v2.GET("health", api.health)
 	v2.GET("status", api.serverStatus)
+	v2.GET("server_config", api.serverConfig)
 
 	// changefeed apis
 	changefeedGroup := v2.Group("/changefeeds")
+	changefeedGroup.Use(middleware.CheckShowSecretsMiddleware())
 	changefeedGroup.Use(middleware.ForwardToOwnerMiddleware(api.capture))
 	changefeedGroup.POST("", api.createChangefeed)
+	changefeedGroup.GET("", api.listChangeFeedSummaries)
 	changefeedGroup.PUT("/:changefeed_id", api.updateChangefeed)
 	changefeedGroup.DELETE("/:changefeed_id", api.deleteChangefeed)
 	changefeedGroup.GET("/:changefeed_id/meta_info", api.getChangeFeedMetaInfo)
+	changefeedGroup.GET("/:changefeed_id/lag", api.getChangeFeedLagBreakdown)
+	changefeedGroup.GET("/:changefeed_id/warnings", api.getChangeFeedWarnings)
+	changefeedGroup.GET("/:changefeed_id/sinks", api.getChangeFeedSinks)
+	changefeedGroup.GET("/:changefeed_id/state_history", api.getChangeFeedStateHistory)
+	changefeedGroup.POST("/:changefeed_id/savepoints", api.createSavepoint)
+	changefeedGroup.GET("/:changefeed_id/savepoints", api.listSavepoints)
 	changefeedGroup.POST("/:changefeed_id/resume", api.resumeChangefeed)
 	changefeedGroup.POST("/:changefeed_id/pause", api.pauseChangefeed)
 
+	// kafka topic apis, they are not forwarded to the owner because the
+	// topics are cached by the sinks on each capture.
+	kafkaTopicGroup := v2.Group("/kafka_topics")
+	kafkaTopicGroup.GET("/:changefeed_id", api.listKafkaTopics)
+	kafkaTopicGroup.DELETE("/:changefeed_id", api.invalidateKafkaTopics)
+	kafkaTopicGroup.POST("/:changefeed_id/check", api.checkKafkaTopics)
+
+	// upstream apis
+	upstreamGroup := v2.Group("/upstreams")
+	upstreamGroup.Use(middleware.ForwardToOwnerMiddleware(api.capture))
+	upstreamGroup.POST("", api.registerUpstream)
+	upstreamGroup.GET("", api.listUpstreams)
+	upstreamGroup.GET("/:upstream_id", api.getUpstream)
+	upstreamGroup.DELETE("/:upstream_id", api.deleteUpstream)
+
 	verifyTableGroup := v2.Group("/verify_table")
 	verifyTableGroup.Use(middleware.ForwardToOwnerMiddleware(api.capture))
 	verifyTableGroup.POST("", api.verifyTable)
@@ -67,6 +95,12 @@ func RegisterOpenAPIV2Routes(router *gin.Engine, api OpenAPIV2) {
 	unsafeGroup.POST("/resolve_lock", api.ResolveLock)
 	unsafeGroup.DELETE("/service_gc_safepoint", api.DeleteServiceGcSafePoint)
 
+	// monitoring apis, they are not forwarded to the owner because the
+	// metrics of each capture may differ during a rolling upgrade.
+	monitoringGroup := v2.Group("/monitoring")
+	monitoringGroup.GET("/grafana_dashboard", api.getGrafanaDashboard)
+	monitoringGroup.GET("/alert_rules", api.getAlertRules)
+
 	// common APIs
 	v2.POST("/tso", api.QueryTso)
 }
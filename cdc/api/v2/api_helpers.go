@@ -28,14 +28,16 @@ import (
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/cdc/owner"
 	"github.com/pingcap/tiflow/cdc/sink"
+	"github.com/pingcap/tiflow/cdc/sink/mq/dispatcher"
 	"github.com/pingcap/tiflow/pkg/config"
 	cerror "github.com/pingcap/tiflow/pkg/errors"
 	"github.com/pingcap/tiflow/pkg/filter"
+	"github.com/pingcap/tiflow/pkg/pdutil"
 	"github.com/pingcap/tiflow/pkg/security"
+	psink "github.com/pingcap/tiflow/pkg/sink"
 	"github.com/pingcap/tiflow/pkg/txnutil/gc"
 	"github.com/pingcap/tiflow/pkg/version"
 	"github.com/r3labs/diff"
-	"github.com/tikv/client-go/v2/oracle"
 	pd "github.com/tikv/pd/client"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
@@ -148,13 +150,25 @@ func (APIV2HelpersImpl) verifyCreateChangefeedConfig(
 	}
 
 	// verify start ts
-	if cfg.StartTs == 0 {
-		ts, logical, err := pdClient.GetTS(ctx)
-		if err != nil {
-			return nil, cerror.ErrPDEtcdAPIError.GenWithStackByArgs(
-				"fail to get ts from pd client")
+	cfg.StartTs, err = pdutil.ResolveStartTs(ctx, pdutil.NewPDTimeService(pdClient),
+		cfg.StartTs, cfg.StartTime, cfg.StartTimeZone)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	// tables matched by the table start ts rules may start earlier than
+	// the changefeed, so the GC safety is ensured at the min start ts.
+	minStartTs := cfg.StartTs
+	if cfg.ReplicaConfig != nil && cfg.ReplicaConfig.Filter != nil {
+		for _, rule := range cfg.ReplicaConfig.Filter.TableStartTs {
+			if cfg.TargetTs > 0 && rule.StartTs >= cfg.TargetTs {
+				return nil, cerror.ErrTargetTsBeforeStartTs.GenWithStackByArgs(
+					cfg.TargetTs, rule.StartTs)
+			}
+			if rule.StartTs != 0 && rule.StartTs < minStartTs {
+				minStartTs = rule.StartTs
+			}
 		}
-		cfg.StartTs = oracle.ComposeTS(ts, logical)
 	}
 
 	// Ensure the start ts is valid in the next 3600 seconds, aka 1 hour
@@ -164,7 +178,7 @@ func (APIV2HelpersImpl) verifyCreateChangefeedConfig(
 		pdClient,
 		ensureGCServiceID,
 		model.DefaultChangeFeedID(cfg.ID),
-		ensureTTL, cfg.StartTs); err != nil {
+		ensureTTL, minStartTs); err != nil {
 		if !cerror.ErrStartTsBeforeGC.Equal(err) {
 			return nil, cerror.ErrPDEtcdAPIError.Wrap(err)
 		}
@@ -189,31 +203,10 @@ func (APIV2HelpersImpl) verifyCreateChangefeedConfig(
 	if err != nil {
 		return nil, err
 	}
-	if !replicaCfg.EnableOldValue {
-		sinkURIParsed, err := url.Parse(cfg.SinkURI)
-		if err != nil {
-			return nil, cerror.WrapError(cerror.ErrSinkURIInvalid, err)
-		}
-
-		protocol := sinkURIParsed.Query().Get(config.ProtocolKey)
-		if protocol != "" {
-			replicaCfg.Sink.Protocol = protocol
-		}
-		for _, fp := range config.ForceEnableOldValueProtocols {
-			if replicaCfg.Sink.Protocol == fp {
-				log.Warn(
-					"Attempting to replicate without old value enabled. "+
-						"CDC will enable old value and continue.",
-					zap.String("protocol", replicaCfg.Sink.Protocol))
-				replicaCfg.EnableOldValue = true
-				break
-			}
-		}
-
-		if replicaCfg.ForceReplicate {
-			return nil, cerror.ErrOldValueNotEnabled.GenWithStackByArgs(
-				"if use force replicate, old value feature must be enabled")
-		}
+	// The old value required by the sink is enabled in ValidateAndAdjust.
+	if !replicaCfg.EnableOldValue && replicaCfg.ForceReplicate {
+		return nil, cerror.ErrOldValueNotEnabled.GenWithStackByArgs(
+			"if use force replicate, old value feature must be enabled")
 	}
 	f, err := filter.NewFilter(replicaCfg, "")
 	if err != nil {
@@ -227,6 +220,9 @@ func (APIV2HelpersImpl) verifyCreateChangefeedConfig(
 	if err != nil {
 		return nil, errors.Cause(err)
 	}
+	if err := verifyDispatchRules(replicaCfg, cfg.SinkURI, tableInfos); err != nil {
+		return nil, err
+	}
 	if !replicaCfg.ForceReplicate && !cfg.ReplicaConfig.IgnoreIneligibleTable {
 		if err != nil {
 			return nil, err
@@ -253,6 +249,7 @@ func (APIV2HelpersImpl) verifyCreateChangefeedConfig(
 		Config:         replicaCfg,
 		State:          model.StateNormal,
 		CreatorVersion: version.ReleaseVersion,
+		ConfigVersion:  config.CurrentReplicaConfigVersion,
 	}, nil
 }
 
@@ -315,6 +312,8 @@ func (APIV2HelpersImpl) verifyUpdateChangefeedConfig(
 	if cfg.ReplicaConfig != nil {
 		configUpdated = true
 		newInfo.Config = cfg.ReplicaConfig.ToInternalReplicaConfig()
+		// The URIs in the config returned by the API are masked.
+		newInfo.Config.RestoreMaskedData(oldInfo.Config)
 	}
 	if cfg.SinkURI != "" {
 		sinkURIUpdated = true
@@ -336,6 +335,9 @@ func (APIV2HelpersImpl) verifyUpdateChangefeedConfig(
 		return nil, nil, cerror.ErrChangefeedUpdateRefused.
 			GenWithStackByArgs(errors.Cause(err).Error())
 	}
+	if err := verifyDispatchRules(newInfo.Config, newInfo.SinkURI, tableInfos); err != nil {
+		return nil, nil, cerror.ErrChangefeedUpdateRefused.GenWithStackByCause(err)
+	}
 
 	if configUpdated || sinkURIUpdated {
 		log.Info("config or sink uri updated, check the compatibility",
@@ -462,3 +464,22 @@ func (h APIV2HelpersImpl) getVerfiedTables(replicaConfig *config.ReplicaConfig,
 		VerifyTables(f, storage, startTs)
 	return
 }
+
+// verifyDispatchRules verifies the partition dispatchers of an MQ sink against
+// the replicated tables, e.g. the partition columns must exist in the tables.
+func verifyDispatchRules(
+	replicaCfg *config.ReplicaConfig, sinkURI string, tableInfos []*model.TableInfo,
+) error {
+	uri, err := url.Parse(sinkURI)
+	if err != nil {
+		return cerror.WrapError(cerror.ErrSinkURIInvalid, err)
+	}
+	if !psink.IsMQScheme(strings.ToLower(uri.Scheme)) {
+		return nil
+	}
+	eventRouter, err := dispatcher.NewEventRouter(replicaCfg, "")
+	if err != nil {
+		return err
+	}
+	return eventRouter.VerifyTables(tableInfos)
+}
@@ -80,6 +80,13 @@ func TestVerifyCreateChangefeedConfig(t *testing.T) {
 	cfInfo, err = h.verifyCreateChangefeedConfig(ctx, cfg, pdClient, provider, "en", storage)
 	require.NotNil(t, err)
 	cfg.TargetTs = 6
+	// table start ts must be less than target ts
+	cfg.ReplicaConfig.Filter.TableStartTs = []TableStartTsRule{
+		{Matcher: []string{"test.t1"}, StartTs: 6},
+	}
+	cfInfo, err = h.verifyCreateChangefeedConfig(ctx, cfg, pdClient, provider, "en", storage)
+	require.True(t, cerror.ErrTargetTsBeforeStartTs.Equal(err))
+	cfg.ReplicaConfig.Filter.TableStartTs = nil
 	cfg.ReplicaConfig.EnableOldValue = false
 	cfg.SinkURI = "aaab://"
 	cfInfo, err = h.verifyCreateChangefeedConfig(ctx, cfg, pdClient, provider, "en", storage)
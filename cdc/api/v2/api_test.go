@@ -62,9 +62,14 @@ func (c *mockPDClient) Close() {}
 
 type mockStatusProvider struct {
 	owner.StatusProvider
-	changefeedStatus *model.ChangeFeedStatus
-	changefeedInfo   *model.ChangeFeedInfo
-	err              error
+	changefeedStatus   *model.ChangeFeedStatus
+	changefeedInfo     *model.ChangeFeedInfo
+	lagBreakdown       *model.LagBreakdown
+	warnings           []*model.RunningWarning
+	sinkStatuses       []*model.SinkStatus
+	changefeedInfos    map[model.ChangeFeedID]*model.ChangeFeedInfo
+	changefeedStatuses map[model.ChangeFeedID]*model.ChangeFeedStatus
+	err                error
 }
 
 // GetChangeFeedStatus returns a changefeeds' runtime status.
@@ -80,3 +85,36 @@ func (m *mockStatusProvider) GetChangeFeedInfo(ctx context.Context,
 ) (*model.ChangeFeedInfo, error) {
 	return m.changefeedInfo, m.err
 }
+
+// GetChangeFeedLagBreakdown returns a mock changefeeds' lag breakdown.
+func (m *mockStatusProvider) GetChangeFeedLagBreakdown(ctx context.Context,
+	changefeedID model.ChangeFeedID,
+) (*model.LagBreakdown, error) {
+	return m.lagBreakdown, m.err
+}
+
+func (m *mockStatusProvider) GetChangeFeedWarnings(ctx context.Context,
+	changefeedID model.ChangeFeedID,
+) ([]*model.RunningWarning, error) {
+	return m.warnings, m.err
+}
+
+func (m *mockStatusProvider) GetChangeFeedSinkStatuses(ctx context.Context,
+	changefeedID model.ChangeFeedID,
+) ([]*model.SinkStatus, error) {
+	return m.sinkStatuses, m.err
+}
+
+// GetAllChangeFeedStatuses returns mock changefeeds' runtime status.
+func (m *mockStatusProvider) GetAllChangeFeedStatuses(ctx context.Context) (
+	map[model.ChangeFeedID]*model.ChangeFeedStatus, error,
+) {
+	return m.changefeedStatuses, m.err
+}
+
+// GetAllChangeFeedInfo returns mock changefeeds' info.
+func (m *mockStatusProvider) GetAllChangeFeedInfo(ctx context.Context) (
+	map[model.ChangeFeedID]*model.ChangeFeedInfo, error,
+) {
+	return m.changefeedInfos, m.err
+}
@@ -16,6 +16,8 @@ package v2
 import (
 	"context"
 	"net/http"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -23,18 +25,25 @@ import (
 	"github.com/pingcap/errors"
 	"github.com/pingcap/log"
 	"github.com/pingcap/tiflow/cdc/api"
+	"github.com/pingcap/tiflow/cdc/api/middleware"
 	"github.com/pingcap/tiflow/cdc/capture"
 	"github.com/pingcap/tiflow/cdc/model"
 	cerror "github.com/pingcap/tiflow/pkg/errors"
 	"github.com/pingcap/tiflow/pkg/retry"
 	"github.com/pingcap/tiflow/pkg/security"
+	pmysql "github.com/pingcap/tiflow/pkg/sink/mysql"
 	"github.com/pingcap/tiflow/pkg/txnutil/gc"
 	"github.com/pingcap/tiflow/pkg/upstream"
 	"github.com/pingcap/tiflow/pkg/util"
+	"github.com/tikv/client-go/v2/oracle"
 	"go.uber.org/zap"
 )
 
-const apiOpVarChangefeedID = "changefeed_id"
+const (
+	apiOpVarChangefeedID = "changefeed_id"
+	// apiOpVarChangefeedState is the key of changefeed state in HTTP API
+	apiOpVarChangefeedState = "state"
+)
 
 // createChangefeed handles create changefeed request,
 // it returns the changefeed's changefeedInfo that it just created
@@ -46,6 +55,20 @@ func (h *OpenAPIV2) createChangefeed(c *gin.Context) {
 		_ = c.Error(cerror.WrapError(cerror.ErrAPIInvalidParam, err))
 		return
 	}
+	etcdClient, err := h.capture.GetEtcdClient()
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	if len(cfg.PDAddrs) == 0 && cfg.UpstreamID != 0 {
+		// Use the registered upstream.
+		upstreamInfo, err := etcdClient.GetUpstreamInfo(ctx, cfg.UpstreamID, model.DefaultNamespace)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+		cfg.PDConfig = toAPIUpstream(upstreamInfo, 0).PDConfig
+	}
 	if len(cfg.PDAddrs) == 0 {
 		up, err := getCaptureDefaultUpstream(h.capture)
 		if err != nil {
@@ -71,11 +94,6 @@ func (h *OpenAPIV2) createChangefeed(c *gin.Context) {
 		_ = c.Error(cerror.WrapError(cerror.ErrNewStore, err))
 		return
 	}
-	etcdClient, err := h.capture.GetEtcdClient()
-	if err != nil {
-		_ = c.Error(err)
-		return
-	}
 	// We should not close kvStorage since all kvStorage in cdc is the same one.
 	// defer kvStorage.Close()
 	// TODO: We should get a kvStorage from upstream instead of creating a new one
@@ -202,6 +220,8 @@ func (h *OpenAPIV2) verifyTable(c *gin.Context) {
 // Can only update a changefeed's: TargetTs, SinkURI,
 // ReplicaConfig, PDAddrs, CAPath, CertPath, KeyPath,
 // SyncPointEnabled, SyncPointInterval
+// Only the credentials of the SinkURI can be updated if the changefeed
+// is not stopped.
 func (h *OpenAPIV2) updateChangefeed(c *gin.Context) {
 	ctx := c.Request.Context()
 
@@ -218,8 +238,7 @@ func (h *OpenAPIV2) updateChangefeed(c *gin.Context) {
 		return
 	}
 	if oldCfInfo.State != model.StateStopped {
-		_ = c.Error(cerror.ErrChangefeedUpdateRefused.
-			GenWithStackByArgs("can only update changefeed config when it is stopped"))
+		h.updateSinkCredentials(c, changefeedID, oldCfInfo)
 		return
 	}
 	cfStatus, err := h.capture.StatusProvider().GetChangeFeedStatus(ctx, changefeedID)
@@ -297,6 +316,61 @@ func (h *OpenAPIV2) updateChangefeed(c *gin.Context) {
 	c.JSON(http.StatusOK, toAPIModel(newCfInfo, true))
 }
 
+// updateSinkCredentials updates the credentials of the sink URI of a running
+// changefeed, the sink re-establishes the connections to the downstream with
+// them, instead of restarting the changefeed.
+func (h *OpenAPIV2) updateSinkCredentials(
+	c *gin.Context, changefeedID model.ChangeFeedID, oldCfInfo *model.ChangeFeedInfo,
+) {
+	ctx := c.Request.Context()
+	refused := cerror.ErrChangefeedUpdateRefused.GenWithStackByArgs(
+		"can only update changefeed config when it is stopped, " +
+			"except the credentials of the sink uri")
+
+	updateCfConfig := &ChangefeedConfig{}
+	if err := c.ShouldBindJSON(updateCfConfig); err != nil {
+		_ = c.Error(refused)
+		return
+	}
+	if !isSinkCredentialsUpdate(updateCfConfig) ||
+		!pmysql.IsCredentialsOnlyChange(oldCfInfo.SinkURI, updateCfConfig.SinkURI) {
+		_ = c.Error(refused)
+		return
+	}
+
+	err := api.HandleOwnerUpdateSinkURI(ctx, h.capture, changefeedID,
+		updateCfConfig.SinkURI)
+	if err != nil {
+		_ = c.Error(errors.Trace(err))
+		return
+	}
+	log.Info("the credentials of the sink uri are updated",
+		zap.String("namespace", changefeedID.Namespace),
+		zap.String("changefeed", changefeedID.ID))
+
+	newCfInfo, err := oldCfInfo.Clone()
+	if err != nil {
+		_ = c.Error(errors.Trace(err))
+		return
+	}
+	newCfInfo.SinkURI = updateCfConfig.SinkURI
+	c.JSON(http.StatusOK, toAPIModel(newCfInfo, true))
+}
+
+// isSinkCredentialsUpdate returns true if only the sink uri is specified
+// in the update request.
+func isSinkCredentialsUpdate(cfg *ChangefeedConfig) bool {
+	return cfg.SinkURI != "" &&
+		cfg.TargetTs == 0 &&
+		cfg.Engine == "" &&
+		cfg.ReplicaConfig == nil &&
+		len(cfg.PDAddrs) == 0 &&
+		cfg.CAPath == "" &&
+		cfg.CertPath == "" &&
+		cfg.KeyPath == "" &&
+		len(cfg.CertAllowedCN) == 0
+}
+
 // deleteChangefeed handles delete changefeed request,
 func (h *OpenAPIV2) deleteChangefeed(c *gin.Context) {
 	ctx := c.Request.Context()
@@ -349,6 +423,63 @@ func (h *OpenAPIV2) deleteChangefeed(c *gin.Context) {
 	c.Status(http.StatusNoContent)
 }
 
+// listChangeFeedSummaries lists the ids, states and checkpoints of the
+// changefeeds, the state query parameter filters the changefeeds the same
+// way as the v1 API, and the offset, limit and fields query parameters page
+// and trim the summaries.
+func (h *OpenAPIV2) listChangeFeedSummaries(c *gin.Context) {
+	ctx := c.Request.Context()
+	state := c.Query(apiOpVarChangefeedState)
+	opts, err := api.ParseListOptions(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	statuses, err := h.capture.StatusProvider().GetAllChangeFeedStatuses(ctx)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	infos, err := h.capture.StatusProvider().GetAllChangeFeedInfo(ctx)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	summaries := make([]ChangefeedSummary, 0, len(infos))
+	for cfID, info := range infos {
+		if !info.State.IsNeeded(state) {
+			continue
+		}
+		summary := ChangefeedSummary{
+			Namespace: cfID.Namespace,
+			ID:        cfID.ID,
+			State:     info.State,
+		}
+		if status := statuses[cfID]; status != nil {
+			summary.CheckpointTs = status.CheckpointTs
+			summary.CheckpointTime = model.JSONTime(
+				oracle.GetTimeFromTS(status.CheckpointTs))
+		}
+		summaries = append(summaries, summary)
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		if summaries[i].Namespace != summaries[j].Namespace {
+			return summaries[i].Namespace < summaries[j].Namespace
+		}
+		return summaries[i].ID < summaries[j].ID
+	})
+
+	c.Header(api.TotalCountHeader, strconv.Itoa(len(summaries)))
+	start, end := opts.Page(len(summaries))
+	selected, err := opts.SelectFields(summaries[start:end])
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, selected)
+}
+
 // getChangeFeedMetaInfo returns the metaInfo of a changefeed
 func (h *OpenAPIV2) getChangeFeedMetaInfo(c *gin.Context) {
 	ctx := c.Request.Context()
@@ -364,7 +495,145 @@ func (h *OpenAPIV2) getChangeFeedMetaInfo(c *gin.Context) {
 		_ = c.Error(err)
 		return
 	}
-	c.JSON(http.StatusOK, toAPIModel(info, false))
+	showSecrets := c.Query(middleware.ShowSecretsQueryKey) == "true"
+	c.JSON(http.StatusOK, toAPIModel(info, !showSecrets))
+}
+
+// getChangeFeedLagBreakdown returns the replication lag of each stage of
+// a changefeed, so that users can tell which stage is the bottleneck.
+func (h *OpenAPIV2) getChangeFeedLagBreakdown(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	changefeedID := model.DefaultChangeFeedID(c.Param(apiOpVarChangefeedID))
+	if err := model.ValidateChangefeedID(changefeedID.ID); err != nil {
+		_ = c.Error(cerror.ErrAPIInvalidParam.GenWithStack("invalid changefeed_id: %s",
+			changefeedID.ID))
+		return
+	}
+	breakdown, err := h.capture.StatusProvider().
+		GetChangeFeedLagBreakdown(ctx, changefeedID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, toAPILagBreakdown(breakdown))
+}
+
+// getChangeFeedWarnings returns the recoverable anomalies of a changefeed
+// reported by its processors, with their error codes and counts.
+func (h *OpenAPIV2) getChangeFeedWarnings(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	changefeedID := model.DefaultChangeFeedID(c.Param(apiOpVarChangefeedID))
+	if err := model.ValidateChangefeedID(changefeedID.ID); err != nil {
+		_ = c.Error(cerror.ErrAPIInvalidParam.GenWithStack("invalid changefeed_id: %s",
+			changefeedID.ID))
+		return
+	}
+	warnings, err := h.capture.StatusProvider().
+		GetChangeFeedWarnings(ctx, changefeedID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, toAPIWarnings(warnings))
+}
+
+// getChangeFeedSinks returns the statuses of the primary sink and the extra
+// sinks of a changefeed, the checkpoint of the changefeed is the min one of
+// them.
+func (h *OpenAPIV2) getChangeFeedSinks(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	changefeedID := model.DefaultChangeFeedID(c.Param(apiOpVarChangefeedID))
+	if err := model.ValidateChangefeedID(changefeedID.ID); err != nil {
+		_ = c.Error(cerror.ErrAPIInvalidParam.GenWithStack("invalid changefeed_id: %s",
+			changefeedID.ID))
+		return
+	}
+	statuses, err := h.capture.StatusProvider().
+		GetChangeFeedSinkStatuses(ctx, changefeedID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, toAPISinkStatuses(statuses))
+}
+
+// getChangeFeedStateHistory returns the state of a changefeed and the latest
+// transitions of its state, so that the tools orchestrating the changefeeds
+// can tell what happened to it.
+func (h *OpenAPIV2) getChangeFeedStateHistory(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	changefeedID := model.DefaultChangeFeedID(c.Param(apiOpVarChangefeedID))
+	if err := model.ValidateChangefeedID(changefeedID.ID); err != nil {
+		_ = c.Error(cerror.ErrAPIInvalidParam.GenWithStack("invalid changefeed_id: %s",
+			changefeedID.ID))
+		return
+	}
+	info, err := h.capture.StatusProvider().GetChangeFeedInfo(ctx, changefeedID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, toAPIStateHistory(info))
+}
+
+// createSavepoint creates a savepoint of a running changefeed, the marker of
+// the savepoint is written to the downstream once all the data committed at
+// or before its ts is written, which is reported by listSavepoints.
+func (h *OpenAPIV2) createSavepoint(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	changefeedID := model.DefaultChangeFeedID(c.Param(apiOpVarChangefeedID))
+	if err := model.ValidateChangefeedID(changefeedID.ID); err != nil {
+		_ = c.Error(cerror.ErrAPIInvalidParam.GenWithStack("invalid changefeed_id: %s",
+			changefeedID.ID))
+		return
+	}
+	cfg := &SavepointConfig{}
+	if err := c.BindJSON(cfg); err != nil {
+		_ = c.Error(cerror.WrapError(cerror.ErrAPIInvalidParam, err))
+		return
+	}
+	// The name is used in the file names and the rows of the downstream,
+	// so it follows the rule of the changefeed id.
+	if err := model.ValidateChangefeedID(cfg.Name); err != nil {
+		_ = c.Error(cerror.ErrAPIInvalidParam.GenWithStack("invalid savepoint name: %s",
+			cfg.Name))
+		return
+	}
+
+	savepoint := &model.Savepoint{Name: cfg.Name}
+	if err := api.HandleOwnerCreateSavepoint(ctx, h.capture, changefeedID,
+		savepoint); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, toAPISavepoint(savepoint))
+}
+
+// listSavepoints returns the recent savepoints of a changefeed.
+func (h *OpenAPIV2) listSavepoints(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	changefeedID := model.DefaultChangeFeedID(c.Param(apiOpVarChangefeedID))
+	if err := model.ValidateChangefeedID(changefeedID.ID); err != nil {
+		_ = c.Error(cerror.ErrAPIInvalidParam.GenWithStack("invalid changefeed_id: %s",
+			changefeedID.ID))
+		return
+	}
+	status, err := h.capture.StatusProvider().GetChangeFeedStatus(ctx, changefeedID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	savepoints := make([]Savepoint, 0, len(status.Savepoints))
+	for _, savepoint := range status.Savepoints {
+		savepoints = append(savepoints, toAPISavepoint(savepoint))
+	}
+	c.JSON(http.StatusOK, savepoints)
 }
 
 // resumeChangefeed handles resume changefeed request.
@@ -504,6 +773,12 @@ func toAPIModel(info *model.ChangeFeedInfo, maskSinkURI bool) *ChangeFeedInfo {
 		}
 	}
 
+	cfg := info.Config
+	if maskSinkURI && cfg != nil {
+		cfg = cfg.Clone()
+		cfg.MaskSensitiveData()
+	}
+
 	apiInfoModel := &ChangeFeedInfo{
 		UpstreamID:     info.UpstreamID,
 		Namespace:      info.Namespace,
@@ -514,7 +789,7 @@ func toAPIModel(info *model.ChangeFeedInfo, maskSinkURI bool) *ChangeFeedInfo {
 		TargetTs:       info.TargetTs,
 		AdminJobType:   info.AdminJobType,
 		Engine:         info.Engine,
-		Config:         ToAPIReplicaConfig(info.Config),
+		Config:         ToAPIReplicaConfig(cfg),
 		State:          info.State,
 		Error:          runningError,
 		CreatorVersion: info.CreatorVersion,
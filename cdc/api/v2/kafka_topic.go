@@ -0,0 +1,85 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sink/mq/manager"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+)
+
+// The kafka topic apis work on the topic managers of the sinks on the capture
+// which receives the request, so they are not forwarded to the owner, and
+// should be sent to every capture running the changefeed.
+
+// listKafkaTopics returns the topics used by the kafka sinks of a changefeed
+// with the partition numbers cached on this capture.
+func (h *OpenAPIV2) listKafkaTopics(c *gin.Context) {
+	changefeedID := model.DefaultChangeFeedID(c.Param(apiOpVarChangefeedID))
+	if err := model.ValidateChangefeedID(changefeedID.ID); err != nil {
+		_ = c.Error(cerror.ErrAPIInvalidParam.GenWithStack("invalid changefeed_id: %s",
+			changefeedID.ID))
+		return
+	}
+	managers := manager.Lookup(changefeedID)
+	topicsList := make([]map[string]int32, 0, len(managers))
+	for _, m := range managers {
+		topicsList = append(topicsList, m.Topics())
+	}
+	c.JSON(http.StatusOK, toAPIKafkaTopics(topicsList...))
+}
+
+// invalidateKafkaTopics drops the cached topics of the kafka sinks of a
+// changefeed on this capture, and fetches them from the brokers again.
+func (h *OpenAPIV2) invalidateKafkaTopics(c *gin.Context) {
+	changefeedID := model.DefaultChangeFeedID(c.Param(apiOpVarChangefeedID))
+	if err := model.ValidateChangefeedID(changefeedID.ID); err != nil {
+		_ = c.Error(cerror.ErrAPIInvalidParam.GenWithStack("invalid changefeed_id: %s",
+			changefeedID.ID))
+		return
+	}
+	for _, m := range manager.Lookup(changefeedID) {
+		if err := m.Invalidate(); err != nil {
+			_ = c.Error(err)
+			return
+		}
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// checkKafkaTopics invalidates the cached topics of the kafka sinks of a
+// changefeed on this capture, and creates the topics deleted out-of-band
+// again. It returns the partition numbers of the topics after the check.
+func (h *OpenAPIV2) checkKafkaTopics(c *gin.Context) {
+	changefeedID := model.DefaultChangeFeedID(c.Param(apiOpVarChangefeedID))
+	if err := model.ValidateChangefeedID(changefeedID.ID); err != nil {
+		_ = c.Error(cerror.ErrAPIInvalidParam.GenWithStack("invalid changefeed_id: %s",
+			changefeedID.ID))
+		return
+	}
+	managers := manager.Lookup(changefeedID)
+	topicsList := make([]map[string]int32, 0, len(managers))
+	for _, m := range managers {
+		topics, err := m.CheckTopics()
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+		topicsList = append(topicsList, topics)
+	}
+	c.JSON(http.StatusOK, toAPIKafkaTopics(topicsList...))
+}
@@ -0,0 +1,95 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	mock_capture "github.com/pingcap/tiflow/cdc/capture/mock"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sink/mq/manager"
+	"github.com/pingcap/tiflow/pkg/sink/kafka"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKafkaTopics(t *testing.T) {
+	t.Parallel()
+
+	list := testCase{url: "/api/v2/kafka_topics/%s", method: "GET"}
+	invalidate := testCase{url: "/api/v2/kafka_topics/%s", method: "DELETE"}
+	check := testCase{url: "/api/v2/kafka_topics/%s/check", method: "POST"}
+	cp := mock_capture.NewMockCapture(gomock.NewController(t))
+	cp.EXPECT().IsReady().Return(true).AnyTimes()
+	// the apis are not forwarded to the owner.
+	cp.EXPECT().IsOwner().Return(false).AnyTimes()
+	router := newRouter(NewOpenAPIV2ForTest(cp, APIV2HelpersImpl{}))
+
+	doRequest := func(tc testCase, id string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequestWithContext(context.Background(),
+			tc.method, fmt.Sprintf(tc.url, id), nil)
+		router.ServeHTTP(w, req)
+		return w
+	}
+	decode := func(w *httptest.ResponseRecorder) []KafkaTopic {
+		var resp []KafkaTopic
+		require.Nil(t, json.NewDecoder(w.Body).Decode(&resp))
+		return resp
+	}
+
+	// invalid changefeed id
+	w := doRequest(list, "invalid%20id")
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	// no kafka sink of the changefeed on this capture
+	id := "test-kafka-topics-api"
+	w = doRequest(list, id)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Empty(t, decode(w))
+
+	adminClient := kafka.NewClusterAdminClientMockImpl()
+	defer func() { _ = adminClient.Close() }()
+	m, err := manager.NewKafkaTopicManager(model.DefaultChangeFeedID(id),
+		kafka.NewClientMockImpl(), adminClient, &kafka.AutoCreateTopicConfig{
+			AutoCreate:        true,
+			PartitionNum:      2,
+			ReplicationFactor: 1,
+		})
+	require.Nil(t, err)
+	defer m.Close()
+	_, err = m.CreateTopicAndWaitUntilVisible("topic-a")
+	require.Nil(t, err)
+
+	w = doRequest(list, id)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, []KafkaTopic{{Name: "topic-a", PartitionNum: 2}}, decode(w))
+
+	// the topic is deleted out-of-band.
+	require.Nil(t, adminClient.DeleteTopic("topic-a"))
+	w = doRequest(invalidate, id)
+	require.Equal(t, http.StatusNoContent, w.Code)
+	w = doRequest(list, id)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Empty(t, decode(w))
+
+	w = doRequest(check, id)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, []KafkaTopic{{Name: "topic-a", PartitionNum: 2}}, decode(w))
+}
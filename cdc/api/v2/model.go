@@ -15,6 +15,8 @@ package v2
 
 import (
 	"encoding/json"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/pingcap/errors"
@@ -80,32 +82,49 @@ type PDConfig struct {
 
 // ChangefeedConfig use by create changefeed api
 type ChangefeedConfig struct {
-	Namespace     string         `json:"namespace"`
-	ID            string         `json:"changefeed_id"`
-	StartTs       uint64         `json:"start_ts"`
+	Namespace string `json:"namespace"`
+	ID        string `json:"changefeed_id"`
+	StartTs   uint64 `json:"start_ts"`
+	// StartTime is the wall-clock start time of the changefeed in the
+	// layout of "2006-01-02 15:04:05", it is interpreted in StartTimeZone
+	// and is used only if StartTs is not specified.
+	StartTime     string         `json:"start_time,omitempty"`
+	StartTimeZone string         `json:"start_time_zone,omitempty"`
 	TargetTs      uint64         `json:"target_ts"`
 	SinkURI       string         `json:"sink_uri"`
 	Engine        string         `json:"engine"`
 	ReplicaConfig *ReplicaConfig `json:"replica_config"`
+	// UpstreamID is the id of a registered upstream, it is used
+	// if PDAddrs is not specified.
+	UpstreamID uint64 `json:"upstream_id,omitempty"`
 	PDConfig
 }
 
 // ReplicaConfig is a duplicate of  config.ReplicaConfig
 type ReplicaConfig struct {
-	MemoryQuota           uint64            `json:"memory_quota"`
-	CaseSensitive         bool              `json:"case_sensitive"`
-	EnableOldValue        bool              `json:"enable_old_value"`
-	ForceReplicate        bool              `json:"force_replicate"`
-	IgnoreIneligibleTable bool              `json:"ignore_ineligible_table"`
-	CheckGCSafePoint      bool              `json:"check_gc_safe_point"`
-	EnableSyncPoint       bool              `json:"enable_sync_point"`
-	BDRMode               bool              `json:"bdr_mode"`
-	SyncPointInterval     time.Duration     `json:"sync_point_interval"`
-	SyncPointRetention    time.Duration     `json:"sync_point_retention"`
-	Filter                *FilterConfig     `json:"filter"`
-	Mounter               *MounterConfig    `json:"mounter"`
-	Sink                  *SinkConfig       `json:"sink"`
-	Consistent            *ConsistentConfig `json:"consistent"`
+	MemoryQuota           uint64                  `json:"memory_quota"`
+	CaseSensitive         bool                    `json:"case_sensitive"`
+	EnableOldValue        bool                    `json:"enable_old_value"`
+	OldValueMode          string                  `json:"old_value_mode,omitempty"`
+	ForceReplicate        bool                    `json:"force_replicate"`
+	IgnoreIneligibleTable bool                    `json:"ignore_ineligible_table"`
+	CheckGCSafePoint      bool                    `json:"check_gc_safe_point"`
+	EnableSyncPoint       bool                    `json:"enable_sync_point"`
+	BDRMode               bool                    `json:"bdr_mode"`
+	SyncPointInterval     time.Duration           `json:"sync_point_interval"`
+	SyncPointRetention    time.Duration           `json:"sync_point_retention"`
+	Filter                *FilterConfig           `json:"filter"`
+	Mounter               *MounterConfig          `json:"mounter"`
+	Sink                  *SinkConfig             `json:"sink"`
+	Consistent            *ConsistentConfig       `json:"consistent"`
+	ConsistencyGroup      *ConsistencyGroupConfig `json:"consistency_group,omitempty"`
+	Metrics               *MetricsConfig          `json:"metrics,omitempty"`
+	DDLCoalesce           *DDLCoalesceConfig      `json:"ddl_coalesce,omitempty"`
+	RestartPolicy         *RestartPolicyConfig    `json:"restart_policy,omitempty"`
+	LifecycleHooks        *LifecycleHooksConfig   `json:"lifecycle_hooks,omitempty"`
+	SortEngine            string                  `json:"sort_engine,omitempty"`
+	SinkEngine            string                  `json:"sink_engine,omitempty"`
+	SortSpill             *SortSpillConfig        `json:"sort_spill,omitempty"`
 }
 
 // ToInternalReplicaConfig coverts *v2.ReplicaConfig into *config.ReplicaConfig
@@ -114,12 +133,15 @@ func (c *ReplicaConfig) ToInternalReplicaConfig() *config.ReplicaConfig {
 	res.MemoryQuota = c.MemoryQuota
 	res.CaseSensitive = c.CaseSensitive
 	res.EnableOldValue = c.EnableOldValue
+	res.OldValueMode = c.OldValueMode
 	res.ForceReplicate = c.ForceReplicate
 	res.CheckGCSafePoint = c.CheckGCSafePoint
 	res.EnableSyncPoint = c.EnableSyncPoint
 	res.SyncPointInterval = c.SyncPointInterval
 	res.SyncPointRetention = c.SyncPointRetention
 	res.BDRMode = c.BDRMode
+	res.SortEngine = c.SortEngine
+	res.SinkEngine = c.SinkEngine
 
 	if c.Filter != nil {
 		var mySQLReplicationRules *filter.MySQLReplicationRules
@@ -153,11 +175,27 @@ func (c *ReplicaConfig) ToInternalReplicaConfig() *config.ReplicaConfig {
 				efs[i] = ef.ToInternalEventFilterRule()
 			}
 		}
+		var tableStartTs []*config.TableStartTsRule
+		for _, rule := range c.Filter.TableStartTs {
+			tableStartTs = append(tableStartTs, &config.TableStartTsRule{
+				Matcher: rule.Matcher,
+				StartTs: rule.StartTs,
+			})
+		}
+		var tableAttributes *config.TableAttributesConfig
+		if c.Filter.TableAttributes != nil {
+			tableAttributes = &config.TableAttributesConfig{
+				PlacementPolicies: c.Filter.TableAttributes.PlacementPolicies,
+				CommentTags:       c.Filter.TableAttributes.CommentTags,
+			}
+		}
 		res.Filter = &config.FilterConfig{
 			Rules:                 c.Filter.Rules,
 			MySQLReplicationRules: mySQLReplicationRules,
 			IgnoreTxnStartTs:      c.Filter.IgnoreTxnStartTs,
 			EventFilters:          efs,
+			TableStartTs:          tableStartTs,
+			TableAttributes:       tableAttributes,
 		}
 	}
 	if c.Consistent != nil {
@@ -166,23 +204,101 @@ func (c *ReplicaConfig) ToInternalReplicaConfig() *config.ReplicaConfig {
 			MaxLogSize:        c.Consistent.MaxLogSize,
 			FlushIntervalInMs: c.Consistent.FlushIntervalInMs,
 			Storage:           c.Consistent.Storage,
+			Compression:       c.Consistent.Compression,
+		}
+	}
+	if c.ConsistencyGroup != nil {
+		res.ConsistencyGroup = &config.ConsistencyGroupConfig{
+			Name:    c.ConsistencyGroup.Name,
+			MaxSkew: c.ConsistencyGroup.MaxSkew,
+		}
+	}
+	if c.Metrics != nil {
+		res.Metrics = &config.MetricsConfig{
+			DisabledLabels: c.Metrics.DisabledLabels,
+			AggregateOnly:  c.Metrics.AggregateOnly,
+		}
+	}
+	if c.DDLCoalesce != nil {
+		res.DDLCoalesce = &config.DDLCoalesceConfig{
+			MaxBatchSize:     c.DDLCoalesce.MaxBatchSize,
+			MaxBatchInterval: c.DDLCoalesce.MaxBatchInterval,
+		}
+	}
+	if c.RestartPolicy != nil {
+		res.RestartPolicy = &config.RestartPolicyConfig{
+			MaxRestarts:      c.RestartPolicy.MaxRestarts,
+			Window:           c.RestartPolicy.Window,
+			BackoffBaseDelay: c.RestartPolicy.BackoffBaseDelay,
+			BackoffMaxDelay:  c.RestartPolicy.BackoffMaxDelay,
+			BackoffJitter:    c.RestartPolicy.BackoffJitter,
+		}
+	}
+	if c.LifecycleHooks != nil {
+		res.LifecycleHooks = &config.LifecycleHooksConfig{
+			FlushBeforePause:     c.LifecycleHooks.FlushBeforePause,
+			PauseFlushTimeout:    c.LifecycleHooks.PauseFlushTimeout,
+			CleanupOnRemove:      c.LifecycleHooks.CleanupOnRemove,
+			RemoveCleanupTimeout: c.LifecycleHooks.RemoveCleanupTimeout,
+			CleanupTargets:       c.LifecycleHooks.CleanupTargets,
+		}
+	}
+	if c.SortSpill != nil {
+		res.SortSpill = &config.SortSpillConfig{
+			Storage:     c.SortSpill.Storage,
+			ColdAfter:   c.SortSpill.ColdAfter,
+			SegmentSize: c.SortSpill.SegmentSize,
 		}
 	}
 	if c.Sink != nil {
 		var dispatchRules []*config.DispatchRule
 		for _, rule := range c.Sink.DispatchRules {
 			dispatchRules = append(dispatchRules, &config.DispatchRule{
-				Matcher:        rule.Matcher,
-				DispatcherRule: "",
-				PartitionRule:  rule.PartitionRule,
-				TopicRule:      rule.TopicRule,
+				Matcher:               rule.Matcher,
+				DispatcherRule:        "",
+				PartitionRule:         rule.PartitionRule,
+				TopicRule:             rule.TopicRule,
+				TopicPreset:           rule.TopicPreset,
+				PartitionNum:          rule.PartitionNum,
+				ReplicationFactor:     rule.ReplicationFactor,
+				PartitionColumns:      rule.PartitionColumns,
+				PartitionExpression:   rule.PartitionExpression,
+				PartitionIncludeTable: rule.PartitionIncludeTable,
 			})
 		}
 		var columnSelectors []*config.ColumnSelector
 		for _, selector := range c.Sink.ColumnSelectors {
 			columnSelectors = append(columnSelectors, &config.ColumnSelector{
-				Matcher: selector.Matcher,
-				Columns: selector.Columns,
+				Matcher:        selector.Matcher,
+				Columns:        selector.Columns,
+				ExcludeColumns: selector.ExcludeColumns,
+			})
+		}
+		var columnTransforms []*config.ColumnTransform
+		for _, transform := range c.Sink.ColumnTransforms {
+			columnTransforms = append(columnTransforms, &config.ColumnTransform{
+				Matcher: transform.Matcher,
+				Columns: transform.Columns,
+				Type:    transform.Type,
+				Length:  transform.Length,
+				Value:   transform.Value,
+				Salt:    transform.Salt,
+			})
+		}
+		var avroKeyRules []*config.AvroKeyRule
+		for _, rule := range c.Sink.AvroKeyRules {
+			avroKeyRules = append(avroKeyRules, &config.AvroKeyRule{
+				Matcher: rule.Matcher,
+				Columns: rule.Columns,
+			})
+		}
+		var extraSinks []*config.ExtraSinkConfig
+		for _, extra := range c.Sink.ExtraSinks {
+			extraSinks = append(extraSinks, &config.ExtraSinkConfig{
+				Name:       extra.Name,
+				SinkURI:    extra.SinkURI,
+				Protocol:   extra.Protocol,
+				SampleRate: extra.SampleRate,
 			})
 		}
 		var csvConfig *config.CSVConfig
@@ -194,6 +310,59 @@ func (c *ReplicaConfig) ToInternalReplicaConfig() *config.ReplicaConfig {
 				IncludeCommitTs: c.Sink.CSVConfig.IncludeCommitTs,
 			}
 		}
+		var parquetConfig *config.ParquetConfig
+		if c.Sink.ParquetConfig != nil {
+			parquetConfig = &config.ParquetConfig{
+				RowGroupSize: c.Sink.ParquetConfig.RowGroupSize,
+				Compression:  c.Sink.ParquetConfig.Compression,
+			}
+		}
+
+		var headers *config.HeadersConfig
+		if c.Sink.Headers != nil {
+			headers = &config.HeadersConfig{
+				CommitTs:        c.Sink.Headers.CommitTs,
+				SchemaVersion:   c.Sink.Headers.SchemaVersion,
+				ChangefeedID:    c.Sink.Headers.ChangefeedID,
+				EventType:       c.Sink.Headers.EventType,
+				SourceClusterID: c.Sink.Headers.SourceClusterID,
+				TxnMetadata:     c.Sink.Headers.TxnMetadata,
+			}
+		}
+
+		var clientIdentity *config.ClientIdentityConfig
+		if c.Sink.ClientIdentity != nil {
+			clientIdentity = &config.ClientIdentityConfig{
+				KafkaClientID: c.Sink.ClientIdentity.KafkaClientID,
+				HTTPUserAgent: c.Sink.ClientIdentity.HTTPUserAgent,
+			}
+		}
+
+		var consumerContracts []*config.ConsumerContract
+		for _, contract := range c.Sink.ConsumerContracts {
+			columns := make([]*config.ContractColumn, 0, len(contract.Columns))
+			for _, column := range contract.Columns {
+				columns = append(columns, &config.ContractColumn{
+					Name: column.Name,
+					Type: column.Type,
+				})
+			}
+			consumerContracts = append(consumerContracts, &config.ConsumerContract{
+				Topic:   contract.Topic,
+				Columns: columns,
+			})
+		}
+
+		var retryBudget *config.RetryBudgetConfig
+		if c.Sink.RetryBudget != nil {
+			retryBudget = &config.RetryBudgetConfig{
+				MaxAttempts:      c.Sink.RetryBudget.MaxAttempts,
+				MaxDuration:      c.Sink.RetryBudget.MaxDuration,
+				BackoffBaseDelay: c.Sink.RetryBudget.BackoffBaseDelay,
+				BackoffMaxDelay:  c.Sink.RetryBudget.BackoffMaxDelay,
+				OnExhausted:      c.Sink.RetryBudget.OnExhausted,
+			}
+		}
 
 		res.Sink = &config.SinkConfig{
 			DispatchRules:            dispatchRules,
@@ -206,6 +375,16 @@ func (c *ReplicaConfig) ToInternalReplicaConfig() *config.ReplicaConfig {
 			Terminator:               c.Sink.Terminator,
 			DateSeparator:            c.Sink.DateSeparator,
 			EnablePartitionSeparator: c.Sink.EnablePartitionSeparator,
+			RetryBudget:              retryBudget,
+			AvroKeyRules:             avroKeyRules,
+			ColumnTransforms:         columnTransforms,
+			TeeSinkURI:               c.Sink.TeeSinkURI,
+			ExtraSinks:               extraSinks,
+			ParquetConfig:            parquetConfig,
+			Headers:                  headers,
+			ConsumerContracts:        consumerContracts,
+			UpdateKeyChange:          c.Sink.UpdateKeyChange,
+			ClientIdentity:           clientIdentity,
 		}
 	}
 	if c.Mounter != nil {
@@ -223,6 +402,7 @@ func ToAPIReplicaConfig(c *config.ReplicaConfig) *ReplicaConfig {
 		MemoryQuota:           cloned.MemoryQuota,
 		CaseSensitive:         cloned.CaseSensitive,
 		EnableOldValue:        cloned.EnableOldValue,
+		OldValueMode:          cloned.OldValueMode,
 		ForceReplicate:        cloned.ForceReplicate,
 		IgnoreIneligibleTable: false,
 		CheckGCSafePoint:      cloned.CheckGCSafePoint,
@@ -230,6 +410,8 @@ func ToAPIReplicaConfig(c *config.ReplicaConfig) *ReplicaConfig {
 		SyncPointInterval:     cloned.SyncPointInterval,
 		SyncPointRetention:    cloned.SyncPointRetention,
 		BDRMode:               cloned.BDRMode,
+		SortEngine:            cloned.SortEngine,
+		SinkEngine:            cloned.SinkEngine,
 	}
 
 	if cloned.Filter != nil {
@@ -266,27 +448,77 @@ func ToAPIReplicaConfig(c *config.ReplicaConfig) *ReplicaConfig {
 			}
 		}
 
+		var tableStartTs []TableStartTsRule
+		for _, rule := range cloned.Filter.TableStartTs {
+			tableStartTs = append(tableStartTs, TableStartTsRule{
+				Matcher: rule.Matcher,
+				StartTs: rule.StartTs,
+			})
+		}
+		var tableAttributes *TableAttributesConfig
+		if cloned.Filter.TableAttributes != nil {
+			tableAttributes = &TableAttributesConfig{
+				PlacementPolicies: cloned.Filter.TableAttributes.PlacementPolicies,
+				CommentTags:       cloned.Filter.TableAttributes.CommentTags,
+			}
+		}
 		res.Filter = &FilterConfig{
 			MySQLReplicationRules: mySQLReplicationRules,
 			Rules:                 cloned.Filter.Rules,
 			IgnoreTxnStartTs:      cloned.Filter.IgnoreTxnStartTs,
 			EventFilters:          efs,
+			TableStartTs:          tableStartTs,
+			TableAttributes:       tableAttributes,
 		}
 	}
 	if cloned.Sink != nil {
 		var dispatchRules []*DispatchRule
 		for _, rule := range cloned.Sink.DispatchRules {
 			dispatchRules = append(dispatchRules, &DispatchRule{
-				Matcher:       rule.Matcher,
-				PartitionRule: rule.PartitionRule,
-				TopicRule:     rule.TopicRule,
+				Matcher:               rule.Matcher,
+				PartitionRule:         rule.PartitionRule,
+				TopicRule:             rule.TopicRule,
+				TopicPreset:           rule.TopicPreset,
+				PartitionNum:          rule.PartitionNum,
+				ReplicationFactor:     rule.ReplicationFactor,
+				PartitionColumns:      rule.PartitionColumns,
+				PartitionExpression:   rule.PartitionExpression,
+				PartitionIncludeTable: rule.PartitionIncludeTable,
 			})
 		}
 		var columnSelectors []*ColumnSelector
 		for _, selector := range cloned.Sink.ColumnSelectors {
 			columnSelectors = append(columnSelectors, &ColumnSelector{
-				Matcher: selector.Matcher,
-				Columns: selector.Columns,
+				Matcher:        selector.Matcher,
+				Columns:        selector.Columns,
+				ExcludeColumns: selector.ExcludeColumns,
+			})
+		}
+		var columnTransforms []*ColumnTransform
+		for _, transform := range cloned.Sink.ColumnTransforms {
+			columnTransforms = append(columnTransforms, &ColumnTransform{
+				Matcher: transform.Matcher,
+				Columns: transform.Columns,
+				Type:    transform.Type,
+				Length:  transform.Length,
+				Value:   transform.Value,
+				Salt:    transform.Salt,
+			})
+		}
+		var avroKeyRules []*AvroKeyRule
+		for _, rule := range cloned.Sink.AvroKeyRules {
+			avroKeyRules = append(avroKeyRules, &AvroKeyRule{
+				Matcher: rule.Matcher,
+				Columns: rule.Columns,
+			})
+		}
+		var extraSinks []*ExtraSinkConfig
+		for _, extra := range cloned.Sink.ExtraSinks {
+			extraSinks = append(extraSinks, &ExtraSinkConfig{
+				Name:       extra.Name,
+				SinkURI:    extra.SinkURI,
+				Protocol:   extra.Protocol,
+				SampleRate: extra.SampleRate,
 			})
 		}
 		var csvConfig *CSVConfig
@@ -298,6 +530,59 @@ func ToAPIReplicaConfig(c *config.ReplicaConfig) *ReplicaConfig {
 				IncludeCommitTs: cloned.Sink.CSVConfig.IncludeCommitTs,
 			}
 		}
+		var parquetConfig *ParquetConfig
+		if cloned.Sink.ParquetConfig != nil {
+			parquetConfig = &ParquetConfig{
+				RowGroupSize: cloned.Sink.ParquetConfig.RowGroupSize,
+				Compression:  cloned.Sink.ParquetConfig.Compression,
+			}
+		}
+
+		var headers *HeadersConfig
+		if cloned.Sink.Headers != nil {
+			headers = &HeadersConfig{
+				CommitTs:        cloned.Sink.Headers.CommitTs,
+				SchemaVersion:   cloned.Sink.Headers.SchemaVersion,
+				ChangefeedID:    cloned.Sink.Headers.ChangefeedID,
+				EventType:       cloned.Sink.Headers.EventType,
+				SourceClusterID: cloned.Sink.Headers.SourceClusterID,
+				TxnMetadata:     cloned.Sink.Headers.TxnMetadata,
+			}
+		}
+
+		var clientIdentity *ClientIdentityConfig
+		if cloned.Sink.ClientIdentity != nil {
+			clientIdentity = &ClientIdentityConfig{
+				KafkaClientID: cloned.Sink.ClientIdentity.KafkaClientID,
+				HTTPUserAgent: cloned.Sink.ClientIdentity.HTTPUserAgent,
+			}
+		}
+
+		var consumerContracts []*ConsumerContract
+		for _, contract := range cloned.Sink.ConsumerContracts {
+			columns := make([]*ContractColumn, 0, len(contract.Columns))
+			for _, column := range contract.Columns {
+				columns = append(columns, &ContractColumn{
+					Name: column.Name,
+					Type: column.Type,
+				})
+			}
+			consumerContracts = append(consumerContracts, &ConsumerContract{
+				Topic:   contract.Topic,
+				Columns: columns,
+			})
+		}
+
+		var retryBudget *RetryBudgetConfig
+		if cloned.Sink.RetryBudget != nil {
+			retryBudget = &RetryBudgetConfig{
+				MaxAttempts:      cloned.Sink.RetryBudget.MaxAttempts,
+				MaxDuration:      cloned.Sink.RetryBudget.MaxDuration,
+				BackoffBaseDelay: cloned.Sink.RetryBudget.BackoffBaseDelay,
+				BackoffMaxDelay:  cloned.Sink.RetryBudget.BackoffMaxDelay,
+				OnExhausted:      cloned.Sink.RetryBudget.OnExhausted,
+			}
+		}
 
 		res.Sink = &SinkConfig{
 			Protocol:                 cloned.Sink.Protocol,
@@ -310,6 +595,16 @@ func ToAPIReplicaConfig(c *config.ReplicaConfig) *ReplicaConfig {
 			Terminator:               cloned.Sink.Terminator,
 			DateSeparator:            cloned.Sink.DateSeparator,
 			EnablePartitionSeparator: cloned.Sink.EnablePartitionSeparator,
+			RetryBudget:              retryBudget,
+			AvroKeyRules:             avroKeyRules,
+			ColumnTransforms:         columnTransforms,
+			TeeSinkURI:               cloned.Sink.TeeSinkURI,
+			ExtraSinks:               extraSinks,
+			ParquetConfig:            parquetConfig,
+			Headers:                  headers,
+			ConsumerContracts:        consumerContracts,
+			UpdateKeyChange:          cloned.Sink.UpdateKeyChange,
+			ClientIdentity:           clientIdentity,
 		}
 	}
 	if cloned.Consistent != nil {
@@ -318,6 +613,50 @@ func ToAPIReplicaConfig(c *config.ReplicaConfig) *ReplicaConfig {
 			MaxLogSize:        cloned.Consistent.MaxLogSize,
 			FlushIntervalInMs: cloned.Consistent.FlushIntervalInMs,
 			Storage:           cloned.Consistent.Storage,
+			Compression:       cloned.Consistent.Compression,
+		}
+	}
+	if cloned.ConsistencyGroup != nil {
+		res.ConsistencyGroup = &ConsistencyGroupConfig{
+			Name:    cloned.ConsistencyGroup.Name,
+			MaxSkew: cloned.ConsistencyGroup.MaxSkew,
+		}
+	}
+	if cloned.Metrics != nil {
+		res.Metrics = &MetricsConfig{
+			DisabledLabels: cloned.Metrics.DisabledLabels,
+			AggregateOnly:  cloned.Metrics.AggregateOnly,
+		}
+	}
+	if cloned.DDLCoalesce != nil {
+		res.DDLCoalesce = &DDLCoalesceConfig{
+			MaxBatchSize:     cloned.DDLCoalesce.MaxBatchSize,
+			MaxBatchInterval: cloned.DDLCoalesce.MaxBatchInterval,
+		}
+	}
+	if cloned.RestartPolicy != nil {
+		res.RestartPolicy = &RestartPolicyConfig{
+			MaxRestarts:      cloned.RestartPolicy.MaxRestarts,
+			Window:           cloned.RestartPolicy.Window,
+			BackoffBaseDelay: cloned.RestartPolicy.BackoffBaseDelay,
+			BackoffMaxDelay:  cloned.RestartPolicy.BackoffMaxDelay,
+			BackoffJitter:    cloned.RestartPolicy.BackoffJitter,
+		}
+	}
+	if cloned.LifecycleHooks != nil {
+		res.LifecycleHooks = &LifecycleHooksConfig{
+			FlushBeforePause:     cloned.LifecycleHooks.FlushBeforePause,
+			PauseFlushTimeout:    cloned.LifecycleHooks.PauseFlushTimeout,
+			CleanupOnRemove:      cloned.LifecycleHooks.CleanupOnRemove,
+			RemoveCleanupTimeout: cloned.LifecycleHooks.RemoveCleanupTimeout,
+			CleanupTargets:       cloned.LifecycleHooks.CleanupTargets,
+		}
+	}
+	if cloned.SortSpill != nil {
+		res.SortSpill = &SortSpillConfig{
+			Storage:     cloned.SortSpill.Storage,
+			ColdAfter:   cloned.SortSpill.ColdAfter,
+			SegmentSize: cloned.SortSpill.SegmentSize,
 		}
 	}
 	if cloned.Mounter != nil {
@@ -333,6 +672,7 @@ func GetDefaultReplicaConfig() *ReplicaConfig {
 	return &ReplicaConfig{
 		CaseSensitive:      true,
 		EnableOldValue:     true,
+		OldValueMode:       config.OldValueModeAuto,
 		CheckGCSafePoint:   true,
 		EnableSyncPoint:    false,
 		SyncPointInterval:  10 * time.Second,
@@ -354,9 +694,25 @@ func GetDefaultReplicaConfig() *ReplicaConfig {
 // This is a duplicate of config.FilterConfig
 type FilterConfig struct {
 	*MySQLReplicationRules
-	Rules            []string          `json:"rules,omitempty"`
-	IgnoreTxnStartTs []uint64          `json:"ignore_txn_start_ts,omitempty"`
-	EventFilters     []EventFilterRule `json:"event_filters"`
+	Rules            []string               `json:"rules,omitempty"`
+	IgnoreTxnStartTs []uint64               `json:"ignore_txn_start_ts,omitempty"`
+	EventFilters     []EventFilterRule      `json:"event_filters"`
+	TableStartTs     []TableStartTsRule     `json:"table_start_ts,omitempty"`
+	TableAttributes  *TableAttributesConfig `json:"table_attributes,omitempty"`
+}
+
+// TableAttributesConfig selects the tables by their attributes in TiDB
+// This is a duplicate of config.TableAttributesConfig
+type TableAttributesConfig struct {
+	PlacementPolicies []string `json:"placement_policies,omitempty"`
+	CommentTags       []string `json:"comment_tags,omitempty"`
+}
+
+// TableStartTsRule specifies the start ts of the tables matched by Matcher
+// This is a duplicate of config.TableStartTsRule
+type TableStartTsRule struct {
+	Matcher []string `json:"matcher"`
+	StartTs uint64   `json:"start_ts"`
 }
 
 // MounterConfig represents mounter config for a changefeed
@@ -370,11 +726,13 @@ type EventFilterRule struct {
 	IgnoreEvent []string `json:"ignore_event"`
 	// regular expression
 	IgnoreSQL []string `toml:"ignore_sql" json:"ignore_sql"`
+	AllowDDL  []string `json:"allow_ddl,omitempty"`
 	// sql expression
 	IgnoreInsertValueExpr    string `json:"ignore_insert_value_expr"`
 	IgnoreUpdateNewValueExpr string `json:"ignore_update_new_value_expr"`
 	IgnoreUpdateOldValueExpr string `json:"ignore_update_old_value_expr"`
 	IgnoreDeleteValueExpr    string `json:"ignore_delete_value_expr"`
+	KeepValueExpr            string `json:"keep_value_expr,omitempty"`
 }
 
 // ToInternalEventFilterRule converts EventFilterRule to *config.EventFilterRule
@@ -386,6 +744,7 @@ func (e EventFilterRule) ToInternalEventFilterRule() *config.EventFilterRule {
 		IgnoreUpdateNewValueExpr: e.IgnoreUpdateNewValueExpr,
 		IgnoreUpdateOldValueExpr: e.IgnoreUpdateOldValueExpr,
 		IgnoreDeleteValueExpr:    e.IgnoreDeleteValueExpr,
+		KeepValueExpr:            e.KeepValueExpr,
 	}
 	if len(e.IgnoreEvent) != 0 {
 		res.IgnoreEvent = make([]bf.EventType, len(e.IgnoreEvent))
@@ -393,6 +752,12 @@ func (e EventFilterRule) ToInternalEventFilterRule() *config.EventFilterRule {
 			res.IgnoreEvent[i] = bf.EventType(et)
 		}
 	}
+	if len(e.AllowDDL) != 0 {
+		res.AllowDDL = make([]bf.EventType, len(e.AllowDDL))
+		for i, et := range e.AllowDDL {
+			res.AllowDDL[i] = bf.EventType(et)
+		}
+	}
 	return res
 }
 
@@ -403,6 +768,7 @@ func ToAPIEventFilterRule(er *config.EventFilterRule) EventFilterRule {
 		IgnoreUpdateNewValueExpr: er.IgnoreUpdateNewValueExpr,
 		IgnoreUpdateOldValueExpr: er.IgnoreUpdateOldValueExpr,
 		IgnoreDeleteValueExpr:    er.IgnoreDeleteValueExpr,
+		KeepValueExpr:            er.KeepValueExpr,
 	}
 	if len(er.Matcher) != 0 {
 		res.Matcher = make([]string, len(er.Matcher))
@@ -418,6 +784,12 @@ func ToAPIEventFilterRule(er *config.EventFilterRule) EventFilterRule {
 			res.IgnoreEvent[i] = string(et)
 		}
 	}
+	if len(er.AllowDDL) != 0 {
+		res.AllowDDL = make([]string, len(er.AllowDDL))
+		for i, et := range er.AllowDDL {
+			res.AllowDDL[i] = string(et)
+		}
+	}
 	return res
 }
 
@@ -445,16 +817,35 @@ type Table struct {
 // SinkConfig represents sink config for a changefeed
 // This is a duplicate of config.SinkConfig
 type SinkConfig struct {
-	Protocol                 string            `json:"protocol"`
-	SchemaRegistry           string            `json:"schema_registry"`
-	CSVConfig                *CSVConfig        `json:"csv"`
-	DispatchRules            []*DispatchRule   `json:"dispatchers,omitempty"`
-	ColumnSelectors          []*ColumnSelector `json:"column_selectors"`
-	TxnAtomicity             string            `json:"transaction_atomicity"`
-	EncoderConcurrency       int               `json:"encoder_concurrency"`
-	Terminator               string            `json:"terminator"`
-	DateSeparator            string            `json:"date_separator"`
-	EnablePartitionSeparator bool              `json:"enable_partition_separator"`
+	Protocol                 string                `json:"protocol"`
+	SchemaRegistry           string                `json:"schema_registry"`
+	CSVConfig                *CSVConfig            `json:"csv"`
+	DispatchRules            []*DispatchRule       `json:"dispatchers,omitempty"`
+	ColumnSelectors          []*ColumnSelector     `json:"column_selectors"`
+	TxnAtomicity             string                `json:"transaction_atomicity"`
+	EncoderConcurrency       int                   `json:"encoder_concurrency"`
+	Terminator               string                `json:"terminator"`
+	DateSeparator            string                `json:"date_separator"`
+	EnablePartitionSeparator bool                  `json:"enable_partition_separator"`
+	RetryBudget              *RetryBudgetConfig    `json:"retry_budget,omitempty"`
+	AvroKeyRules             []*AvroKeyRule        `json:"avro_key_rules,omitempty"`
+	ColumnTransforms         []*ColumnTransform    `json:"column_transforms,omitempty"`
+	TeeSinkURI               string                `json:"tee_sink_uri,omitempty"`
+	ExtraSinks               []*ExtraSinkConfig    `json:"extra_sinks,omitempty"`
+	ParquetConfig            *ParquetConfig        `json:"parquet,omitempty"`
+	Headers                  *HeadersConfig        `json:"headers,omitempty"`
+	ConsumerContracts        []*ConsumerContract   `json:"consumer_contracts,omitempty"`
+	UpdateKeyChange          string                `json:"update_key_change,omitempty"`
+	ClientIdentity           *ClientIdentityConfig `json:"client_identity,omitempty"`
+}
+
+// ExtraSinkConfig represents an extra sink of a changefeed
+// This is a duplicate of config.ExtraSinkConfig
+type ExtraSinkConfig struct {
+	Name       string  `json:"name"`
+	SinkURI    string  `json:"sink_uri"`
+	Protocol   string  `json:"protocol,omitempty"`
+	SampleRate float64 `json:"sample_rate,omitempty"`
 }
 
 // CSVConfig denotes the csv config
@@ -466,21 +857,96 @@ type CSVConfig struct {
 	IncludeCommitTs bool   `json:"include_commit_ts"`
 }
 
+// ParquetConfig denotes the parquet config
+// This is the same as config.ParquetConfig
+type ParquetConfig struct {
+	RowGroupSize int64  `json:"row_group_size"`
+	Compression  string `json:"compression"`
+}
+
+// HeadersConfig denotes the CDC metadata attached to the messages
+// This is the same as config.HeadersConfig
+type HeadersConfig struct {
+	CommitTs        bool `json:"commit_ts"`
+	SchemaVersion   bool `json:"schema_version"`
+	ChangefeedID    bool `json:"changefeed_id"`
+	EventType       bool `json:"event_type"`
+	SourceClusterID bool `json:"source_cluster_id"`
+	TxnMetadata     bool `json:"txn_metadata"`
+}
+
+// ClientIdentityConfig denotes how the changefeed identifies itself to the
+// downstream
+// This is the same as config.ClientIdentityConfig
+type ClientIdentityConfig struct {
+	KafkaClientID string `json:"kafka_client_id,omitempty"`
+	HTTPUserAgent string `json:"http_user_agent,omitempty"`
+}
+
+// ConsumerContract represents the contract of the consumers of a topic
+// This is a duplicate of config.ConsumerContract
+type ConsumerContract struct {
+	Topic   string            `json:"topic"`
+	Columns []*ContractColumn `json:"columns"`
+}
+
+// ContractColumn represents a column required by the consumers
+// This is a duplicate of config.ContractColumn
+type ContractColumn struct {
+	Name string `json:"name"`
+	Type string `json:"type,omitempty"`
+}
+
+// RetryBudgetConfig represents the retry budget of a sink
+// This is a duplicate of config.RetryBudgetConfig
+type RetryBudgetConfig struct {
+	MaxAttempts      uint64        `json:"max_attempts"`
+	MaxDuration      time.Duration `json:"max_duration"`
+	BackoffBaseDelay time.Duration `json:"backoff_base_delay"`
+	BackoffMaxDelay  time.Duration `json:"backoff_max_delay"`
+	OnExhausted      string        `json:"on_exhausted"`
+}
+
 // DispatchRule represents partition rule for a table
 // This is a duplicate of config.DispatchRule
 type DispatchRule struct {
-	Matcher       []string `json:"matcher,omitempty"`
-	PartitionRule string   `json:"partition"`
-	TopicRule     string   `json:"topic"`
+	Matcher               []string `json:"matcher,omitempty"`
+	PartitionRule         string   `json:"partition"`
+	TopicRule             string   `json:"topic"`
+	TopicPreset           string   `json:"topic_preset,omitempty"`
+	PartitionNum          int32    `json:"partition_num,omitempty"`
+	ReplicationFactor     int16    `json:"replication_factor,omitempty"`
+	PartitionColumns      []string `json:"partition_columns,omitempty"`
+	PartitionExpression   string   `json:"partition_expression,omitempty"`
+	PartitionIncludeTable bool     `json:"partition_include_table,omitempty"`
 }
 
 // ColumnSelector represents a column selector for a table.
 // This is a duplicate of config.ColumnSelector
 type ColumnSelector struct {
+	Matcher        []string `json:"matcher,omitempty"`
+	Columns        []string `json:"columns,omitempty"`
+	ExcludeColumns []string `json:"exclude_columns,omitempty"`
+}
+
+// AvroKeyRule represents the columns of the avro key for a table.
+// This is a duplicate of config.AvroKeyRule
+type AvroKeyRule struct {
 	Matcher []string `json:"matcher,omitempty"`
 	Columns []string `json:"columns,omitempty"`
 }
 
+// ColumnTransform transforms the values of the matched columns.
+// This is a duplicate of config.ColumnTransform
+type ColumnTransform struct {
+	Matcher []string `json:"matcher,omitempty"`
+	Columns []string `json:"columns,omitempty"`
+	Type    string   `json:"type"`
+	Length  int      `json:"length,omitempty"`
+	Value   string   `json:"value,omitempty"`
+	Salt    string   `json:"salt,omitempty"`
+}
+
 // ConsistentConfig represents replication consistency config for a changefeed
 // This is a duplicate of config.ConsistentConfig
 type ConsistentConfig struct {
@@ -488,6 +954,80 @@ type ConsistentConfig struct {
 	MaxLogSize        int64  `json:"max_log_size"`
 	FlushIntervalInMs int64  `json:"flush_interval"`
 	Storage           string `json:"storage"`
+	Compression       string `json:"compression,omitempty"`
+}
+
+// ConsistencyGroupConfig represents the consistency group of a changefeed
+// This is a duplicate of config.ConsistencyGroupConfig
+type ConsistencyGroupConfig struct {
+	Name    string        `json:"name"`
+	MaxSkew time.Duration `json:"max_skew"`
+}
+
+// MetricsConfig represents the labels of the metrics of a changefeed
+// This is a duplicate of config.MetricsConfig
+type MetricsConfig struct {
+	DisabledLabels []string `json:"disabled_labels,omitempty"`
+	AggregateOnly  bool     `json:"aggregate_only"`
+}
+
+// DDLCoalesceConfig represents the coalescing of the DDL jobs of a changefeed
+// This is a duplicate of config.DDLCoalesceConfig
+type DDLCoalesceConfig struct {
+	MaxBatchSize     int           `json:"max_batch_size"`
+	MaxBatchInterval time.Duration `json:"max_batch_interval"`
+}
+
+// RestartPolicyConfig represents the restart policy of a changefeed
+// This is a duplicate of config.RestartPolicyConfig
+type RestartPolicyConfig struct {
+	MaxRestarts      int           `json:"max_restarts"`
+	Window           time.Duration `json:"window"`
+	BackoffBaseDelay time.Duration `json:"backoff_base_delay"`
+	BackoffMaxDelay  time.Duration `json:"backoff_max_delay"`
+	BackoffJitter    float64       `json:"backoff_jitter"`
+}
+
+// LifecycleHooksConfig represents the hooks run before the state transitions
+// of a changefeed
+// This is a duplicate of config.LifecycleHooksConfig
+type LifecycleHooksConfig struct {
+	FlushBeforePause     bool          `json:"flush_before_pause"`
+	PauseFlushTimeout    time.Duration `json:"pause_flush_timeout"`
+	CleanupOnRemove      bool          `json:"cleanup_on_remove"`
+	RemoveCleanupTimeout time.Duration `json:"remove_cleanup_timeout"`
+	CleanupTargets       []string      `json:"cleanup_targets,omitempty"`
+}
+
+// SortSpillConfig represents the spill of the cold sorted events to an
+// external storage
+// This is a duplicate of config.SortSpillConfig
+type SortSpillConfig struct {
+	Storage     string        `json:"storage"`
+	ColdAfter   time.Duration `json:"cold_after"`
+	SegmentSize uint64        `json:"segment_size"`
+}
+
+// Upstream is a registered upstream TiDB cluster
+type Upstream struct {
+	ID uint64 `json:"id"`
+	PDConfig
+	// ChangefeedCount is the number of changefeeds replicating from the upstream
+	ChangefeedCount int `json:"changefeed_count"`
+}
+
+func toAPIUpstream(info *model.UpstreamInfo, changefeedCount int) Upstream {
+	return Upstream{
+		ID: info.ID,
+		PDConfig: PDConfig{
+			PDAddrs:       strings.Split(info.PDEndpoints, ","),
+			CAPath:        info.CAPath,
+			CertPath:      info.CertPath,
+			KeyPath:       info.KeyPath,
+			CertAllowedCN: info.CertAllowedCN,
+		},
+		ChangefeedCount: changefeedCount,
+	}
 }
 
 // EtcdData contains key/value pair of etcd data
@@ -574,3 +1114,168 @@ type UpstreamConfig struct {
 	ID uint64 `json:"id"`
 	PDConfig
 }
+
+// StageLag is the replication lag of one stage of the table pipeline.
+type StageLag struct {
+	TableID       int64   `json:"table_id"`
+	CheckpointTs  uint64  `json:"checkpoint_ts"`
+	ResolvedTs    uint64  `json:"resolved_ts"`
+	CheckpointLag float64 `json:"checkpoint_lag"`
+	ResolvedTsLag float64 `json:"resolved_ts_lag"`
+}
+
+// LagBreakdown is the replication lag of a changefeed broken down by stages.
+// Lags are in seconds.
+type LagBreakdown struct {
+	CurrentTs uint64              `json:"current_ts"`
+	Stages    map[string]StageLag `json:"stages"`
+}
+
+func toAPILagBreakdown(breakdown *model.LagBreakdown) *LagBreakdown {
+	res := &LagBreakdown{
+		CurrentTs: breakdown.CurrentTs,
+		Stages:    make(map[string]StageLag, len(breakdown.Stages)),
+	}
+	for stage, lag := range breakdown.Stages {
+		res.Stages[stage] = StageLag{
+			TableID:       lag.TableID,
+			CheckpointTs:  lag.CheckpointTs,
+			ResolvedTs:    lag.ResolvedTs,
+			CheckpointLag: lag.CheckpointLag,
+			ResolvedTsLag: lag.ResolvedTsLag,
+		}
+	}
+	return res
+}
+
+// ChangefeedWarning is a kind of recoverable anomaly of a changefeed.
+type ChangefeedWarning struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Count   uint64 `json:"count"`
+	// LastSeen is the unix time in milliseconds the anomaly is seen last.
+	LastSeen int64 `json:"last_seen"`
+}
+
+func toAPIWarnings(warnings []*model.RunningWarning) []ChangefeedWarning {
+	res := make([]ChangefeedWarning, 0, len(warnings))
+	for _, w := range warnings {
+		res = append(res, ChangefeedWarning{
+			Code:     w.Code,
+			Message:  w.Message,
+			Count:    w.Count,
+			LastSeen: w.LastSeen,
+		})
+	}
+	return res
+}
+
+// ChangefeedStateHistory is the state of a changefeed and the latest
+// transitions of its state, the oldest first.
+type ChangefeedStateHistory struct {
+	State       model.FeedState   `json:"state"`
+	Transitions []StateTransition `json:"transitions"`
+}
+
+// StateTransition is a transition of the state of a changefeed.
+type StateTransition struct {
+	From   model.FeedState `json:"from"`
+	To     model.FeedState `json:"to"`
+	Reason string          `json:"reason"`
+	Time   time.Time       `json:"time"`
+}
+
+func toAPIStateHistory(info *model.ChangeFeedInfo) ChangefeedStateHistory {
+	res := ChangefeedStateHistory{
+		State:       info.State,
+		Transitions: make([]StateTransition, 0, len(info.StateHistory)),
+	}
+	for _, t := range info.StateHistory {
+		res.Transitions = append(res.Transitions, StateTransition{
+			From:   t.From,
+			To:     t.To,
+			Reason: t.Reason,
+			Time:   t.Time,
+		})
+	}
+	return res
+}
+
+// SinkStatus is the replication status of one of the sinks of a changefeed.
+type SinkStatus struct {
+	Name         string `json:"name"`
+	SinkURI      string `json:"sink_uri"`
+	CheckpointTs uint64 `json:"checkpoint_ts"`
+}
+
+func toAPISinkStatuses(statuses []*model.SinkStatus) []SinkStatus {
+	res := make([]SinkStatus, 0, len(statuses))
+	for _, s := range statuses {
+		res = append(res, SinkStatus{
+			Name:         s.Name,
+			SinkURI:      s.SinkURI,
+			CheckpointTs: s.CheckpointTs,
+		})
+	}
+	return res
+}
+
+// KafkaTopic is a topic used by the kafka sinks of a changefeed, with the
+// partition number cached by TiCDC.
+type KafkaTopic struct {
+	Name         string `json:"name"`
+	PartitionNum int32  `json:"partition_num"`
+}
+
+// toAPIKafkaTopics merges the topics of the topic managers. The partitions of
+// a kafka topic can only be increased, so the largest number is the latest.
+func toAPIKafkaTopics(topicsList ...map[string]int32) []KafkaTopic {
+	merged := make(map[string]int32)
+	for _, topics := range topicsList {
+		for topic, partitionNum := range topics {
+			if partitionNum > merged[topic] {
+				merged[topic] = partitionNum
+			}
+		}
+	}
+	res := make([]KafkaTopic, 0, len(merged))
+	for topic, partitionNum := range merged {
+		res = append(res, KafkaTopic{Name: topic, PartitionNum: partitionNum})
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Name < res[j].Name })
+	return res
+}
+
+// SavepointConfig is the request body of creating a savepoint.
+type SavepointConfig struct {
+	Name string `json:"name"`
+}
+
+// Savepoint is a named consistent point of a changefeed, all the data
+// committed at or before its ts has been written to the downstream along
+// with a marker of it once it is done.
+type Savepoint struct {
+	Name       string    `json:"name"`
+	Ts         uint64    `json:"ts"`
+	CreateTime time.Time `json:"create_time"`
+	Done       bool      `json:"done"`
+}
+
+func toAPISavepoint(savepoint *model.Savepoint) Savepoint {
+	return Savepoint{
+		Name:       savepoint.Name,
+		Ts:         savepoint.Ts,
+		CreateTime: savepoint.CreateTime,
+		Done:       savepoint.Done,
+	}
+}
+
+// ChangefeedSummary is the brief of a changefeed, which is cheap to list
+// even if there are many changefeeds.
+type ChangefeedSummary struct {
+	Namespace      string          `json:"namespace"`
+	ID             string          `json:"id"`
+	State          model.FeedState `json:"state"`
+	CheckpointTs   uint64          `json:"checkpoint_tso"`
+	CheckpointTime model.JSONTime  `json:"checkpoint_time"`
+}
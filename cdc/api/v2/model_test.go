@@ -17,6 +17,7 @@ import (
 	"encoding/json"
 	"strings"
 	"testing"
+	"time"
 
 	bf "github.com/pingcap/tidb-tools/pkg/binlog-filter"
 	filter "github.com/pingcap/tidb/util/table-filter"
@@ -28,20 +29,49 @@ func TestToAPIReplicaConfig(t *testing.T) {
 	cfg := config.GetDefaultReplicaConfig()
 	cfg.EnableOldValue = false
 	cfg.CheckGCSafePoint = false
+	cfg.SortEngine = config.SortEngineMemory
+	cfg.SinkEngine = config.SinkEngineV2
+	cfg.DDLCoalesce = &config.DDLCoalesceConfig{
+		MaxBatchSize:     16,
+		MaxBatchInterval: time.Second,
+	}
+	cfg.RestartPolicy = &config.RestartPolicyConfig{
+		MaxRestarts:      5,
+		Window:           time.Hour,
+		BackoffBaseDelay: time.Second,
+		BackoffMaxDelay:  time.Minute,
+		BackoffJitter:    0.2,
+	}
+	cfg.LifecycleHooks = &config.LifecycleHooksConfig{
+		FlushBeforePause:     true,
+		PauseFlushTimeout:    time.Minute,
+		CleanupOnRemove:      true,
+		RemoveCleanupTimeout: time.Minute,
+		CleanupTargets:       []string{"topic-a", "topic-b"},
+	}
+	cfg.SortSpill = &config.SortSpillConfig{
+		Storage:     "s3://bucket/spill",
+		ColdAfter:   time.Hour,
+		SegmentSize: 1024,
+	}
 	cfg.Sink = &config.SinkConfig{
 		DispatchRules: []*config.DispatchRule{
 			{
-				Matcher:        []string{"a", "b", "c"},
-				DispatcherRule: "",
-				PartitionRule:  "rule",
-				TopicRule:      "topic",
+				Matcher:               []string{"a", "b", "c"},
+				DispatcherRule:        "",
+				PartitionRule:         "rule",
+				TopicRule:             "topic",
+				PartitionColumns:      []string{"id"},
+				PartitionExpression:   "lower(name)",
+				PartitionIncludeTable: true,
 			},
 		},
 		Protocol: "aaa",
 		ColumnSelectors: []*config.ColumnSelector{
 			{
-				Matcher: []string{"a", "b", "c"},
-				Columns: []string{"a", "b"},
+				Matcher:        []string{"a", "b", "c"},
+				Columns:        []string{"a", "b"},
+				ExcludeColumns: []string{"c"},
 			},
 		},
 		SchemaRegistry: "bbb",
@@ -74,13 +104,65 @@ func TestToAPIReplicaConfig(t *testing.T) {
 			Matcher:                  []string{"test.t1", "test.t2"},
 			IgnoreEvent:              []bf.EventType{bf.AllDML, bf.AllDDL, bf.AlterTable},
 			IgnoreSQL:                []string{"^DROP TABLE", "ADD COLUMN"},
+			AllowDDL:                 []bf.EventType{"add column"},
 			IgnoreInsertValueExpr:    "c >= 0",
 			IgnoreUpdateNewValueExpr: "age <= 55",
 			IgnoreUpdateOldValueExpr: "age >= 84",
 			IgnoreDeleteValueExpr:    "age > 20",
+			KeepValueExpr:            "status != 'internal'",
 		}},
+		TableStartTs: []*config.TableStartTsRule{{
+			Matcher: []string{"test.t3"},
+			StartTs: 418881574869139457,
+		}},
+		TableAttributes: &config.TableAttributesConfig{
+			PlacementPolicies: []string{"p1"},
+			CommentTags:       []string{"replication=analytics"},
+		},
 	}
 	cfg.Mounter = &config.MounterConfig{WorkerNum: 11}
+	cfg.ConsistencyGroup = &config.ConsistencyGroupConfig{
+		Name:    "group",
+		MaxSkew: 10 * time.Second,
+	}
+	cfg.Metrics = &config.MetricsConfig{
+		DisabledLabels: []string{config.MetricLabelTopic},
+	}
+	cfg.Sink.RetryBudget = &config.RetryBudgetConfig{
+		MaxAttempts:      5,
+		MaxDuration:      time.Minute,
+		BackoffBaseDelay: time.Second,
+		BackoffMaxDelay:  10 * time.Second,
+		OnExhausted:      config.RetryExhaustedFailed,
+	}
+	cfg.Sink.AvroKeyRules = []*config.AvroKeyRule{{
+		Matcher: []string{"test.t1"},
+		Columns: []string{"a", "b"},
+	}}
+	cfg.Sink.ColumnTransforms = []*config.ColumnTransform{{
+		Matcher: []string{"test.t1"},
+		Columns: []string{"phone"},
+		Type:    config.ColumnTransformHash,
+		Salt:    "salt",
+	}}
+	cfg.Sink.TeeSinkURI = "s3://bucket/archive"
+	cfg.Sink.ExtraSinks = []*config.ExtraSinkConfig{{
+		Name:       "kafka",
+		SinkURI:    "kafka://127.0.0.1:9092/topic",
+		Protocol:   "avro",
+		SampleRate: 0.01,
+	}}
+	cfg.Sink.ParquetConfig = config.NewDefaultParquetConfig()
+	cfg.Sink.Headers = &config.HeadersConfig{CommitTs: true, SourceClusterID: true, TxnMetadata: true}
+	cfg.Sink.ConsumerContracts = []*config.ConsumerContract{{
+		Topic:   "topic",
+		Columns: []*config.ContractColumn{{Name: "id", Type: "bigint"}},
+	}}
+	cfg.Sink.UpdateKeyChange = config.UpdateKeyChangeSplit
+	cfg.Sink.ClientIdentity = &config.ClientIdentityConfig{
+		KafkaClientID: "cdc_{namespace}_{changefeed}",
+		HTTPUserAgent: "ticdc/{changefeed}",
+	}
 	cfg2 := ToAPIReplicaConfig(cfg).ToInternalReplicaConfig()
 	require.Equal(t, "", cfg2.Sink.DispatchRules[0].DispatcherRule)
 	cfg.Sink.DispatchRules[0].DispatcherRule = ""
@@ -138,19 +220,23 @@ func TestEventFilterRuleConvert(t *testing.T) {
 				Matcher:                  []string{"test.t1", "test.t2"},
 				IgnoreEvent:              []bf.EventType{bf.AllDML, bf.AllDDL, bf.AlterTable},
 				IgnoreSQL:                []string{"^DROP TABLE", "ADD COLUMN"},
+				AllowDDL:                 []bf.EventType{"add column"},
 				IgnoreInsertValueExpr:    "c >= 0",
 				IgnoreUpdateNewValueExpr: "age <= 55",
 				IgnoreUpdateOldValueExpr: "age >= 84",
 				IgnoreDeleteValueExpr:    "age > 20",
+				KeepValueExpr:            "status != 'internal'",
 			},
 			apiRule: EventFilterRule{
 				Matcher:                  []string{"test.t1", "test.t2"},
 				IgnoreEvent:              []string{"all dml", "all ddl", "alter table"},
 				IgnoreSQL:                []string{"^DROP TABLE", "ADD COLUMN"},
+				AllowDDL:                 []string{"add column"},
 				IgnoreInsertValueExpr:    "c >= 0",
 				IgnoreUpdateNewValueExpr: "age <= 55",
 				IgnoreUpdateOldValueExpr: "age >= 84",
 				IgnoreDeleteValueExpr:    "age > 20",
+				KeepValueExpr:            "status != 'internal'",
 			},
 		},
 	}
@@ -159,3 +245,17 @@ func TestEventFilterRuleConvert(t *testing.T) {
 		require.Equal(t, c.inRule, c.apiRule.ToInternalEventFilterRule())
 	}
 }
+
+func TestToAPIKafkaTopics(t *testing.T) {
+	t.Parallel()
+
+	require.Empty(t, toAPIKafkaTopics())
+	require.Equal(t, []KafkaTopic{
+		{Name: "a", PartitionNum: 4},
+		{Name: "b", PartitionNum: 1},
+		{Name: "c", PartitionNum: 2},
+	}, toAPIKafkaTopics(
+		map[string]int32{"c": 2, "a": 3},
+		map[string]int32{"a": 4, "b": 1},
+	))
+}
@@ -0,0 +1,43 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pingcap/tiflow/pkg/monitoring"
+)
+
+// getGrafanaDashboard returns the Grafana dashboard matching the metrics of
+// this server.
+func (h *OpenAPIV2) getGrafanaDashboard(c *gin.Context) {
+	data, err := monitoring.GrafanaDashboard(h.registry)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.Data(http.StatusOK, "application/json; charset=utf-8", data)
+}
+
+// getAlertRules returns the Prometheus alert rules matching the metrics of
+// this server.
+func (h *OpenAPIV2) getAlertRules(c *gin.Context) {
+	data, err := monitoring.AlertRules(h.registry)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.Data(http.StatusOK, "application/x-yaml; charset=utf-8", data)
+}
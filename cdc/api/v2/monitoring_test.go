@@ -0,0 +1,50 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	mock_capture "github.com/pingcap/tiflow/cdc/capture/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetMonitoring(t *testing.T) {
+	helpers := NewMockAPIV2Helpers(gomock.NewController(t))
+	cp := mock_capture.NewMockCapture(gomock.NewController(t))
+	apiV2 := NewOpenAPIV2ForTest(cp, helpers)
+	router := newRouter(apiV2)
+	cp.EXPECT().IsReady().Return(true).AnyTimes()
+
+	dashboard := testCase{url: "/api/v2/monitoring/grafana_dashboard", method: "GET"}
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequestWithContext(context.Background(), dashboard.method, dashboard.url, nil)
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	resp := make(map[string]interface{})
+	require.Nil(t, json.NewDecoder(w.Body).Decode(&resp))
+	require.Contains(t, resp, "panels")
+
+	rules := testCase{url: "/api/v2/monitoring/alert_rules", method: "GET"}
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequestWithContext(context.Background(), rules.method, rules.url, nil)
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, w.Body.String(), "groups:")
+}
@@ -19,6 +19,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/config"
 	"github.com/pingcap/tiflow/pkg/version"
 )
 
@@ -44,3 +45,9 @@ func (h *OpenAPIV2) serverStatus(c *gin.Context) {
 	}
 	c.IndentedJSON(http.StatusOK, status)
 }
+
+// serverConfig returns the effective dynamic config of this server, which is
+// reloaded from the config file and etcd without restarting the server.
+func (h *OpenAPIV2) serverConfig(c *gin.Context) {
+	c.IndentedJSON(http.StatusOK, config.GetGlobalServerConfig().GetDynamicConfig())
+}
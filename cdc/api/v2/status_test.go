@@ -23,6 +23,7 @@ import (
 	"github.com/golang/mock/gomock"
 	mock_capture "github.com/pingcap/tiflow/cdc/capture/mock"
 	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/config"
 	mock_etcd "github.com/pingcap/tiflow/pkg/etcd/mock"
 	"github.com/stretchr/testify/require"
 )
@@ -57,3 +58,21 @@ func TestGetStatus(t *testing.T) {
 	require.Equal(t, "capture-id", resp.ID)
 	require.Equal(t, http.StatusOK, w.Code)
 }
+
+func TestGetServerConfig(t *testing.T) {
+	serverConfig := testCase{url: "/api/v2/server_config", method: "GET"}
+	helpers := NewMockAPIV2Helpers(gomock.NewController(t))
+	cp := mock_capture.NewMockCapture(gomock.NewController(t))
+	apiV2 := NewOpenAPIV2ForTest(cp, helpers)
+	router := newRouter(apiV2)
+	cp.EXPECT().IsReady().Return(true).AnyTimes()
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequestWithContext(context.Background(), serverConfig.method, serverConfig.url, nil)
+	router.ServeHTTP(w, req)
+	resp := config.DynamicConfig{}
+	err := json.NewDecoder(w.Body).Decode(&resp)
+	require.Nil(t, err)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, config.GetGlobalServerConfig().GetDynamicConfig(), &resp)
+}
@@ -0,0 +1,178 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/cdc/model"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"go.uber.org/zap"
+)
+
+const apiOpVarUpstreamID = "upstream_id"
+
+// registerUpstream registers an upstream TiDB cluster, so that changefeeds
+// can be created with the upstream id instead of the pd addresses.
+func (h *OpenAPIV2) registerUpstream(c *gin.Context) {
+	ctx := c.Request.Context()
+	cfg := &PDConfig{}
+	if err := c.BindJSON(cfg); err != nil {
+		_ = c.Error(cerror.WrapError(cerror.ErrAPIInvalidParam, err))
+		return
+	}
+	if len(cfg.PDAddrs) == 0 {
+		_ = c.Error(cerror.ErrAPIInvalidParam.GenWithStack("pd_addrs must be specified"))
+		return
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	pdClient, err := h.helpers.getPDClient(timeoutCtx, cfg.PDAddrs, cfg.toCredential())
+	if err != nil {
+		_ = c.Error(cerror.WrapError(cerror.ErrAPIGetPDClientFailed, err))
+		return
+	}
+	defer pdClient.Close()
+
+	etcdClient, err := h.capture.GetEtcdClient()
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	info := &model.UpstreamInfo{
+		ID:            pdClient.GetClusterID(ctx),
+		PDEndpoints:   strings.Join(cfg.PDAddrs, ","),
+		KeyPath:       cfg.KeyPath,
+		CertPath:      cfg.CertPath,
+		CAPath:        cfg.CAPath,
+		CertAllowedCN: cfg.CertAllowedCN,
+	}
+	if err := etcdClient.SaveUpstreamInfo(ctx, info, model.DefaultNamespace); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	log.Info("register upstream successfully",
+		zap.Uint64("upstreamID", info.ID),
+		zap.String("pdEndpoints", info.PDEndpoints))
+	c.JSON(http.StatusCreated, toAPIUpstream(info, 0))
+}
+
+// listUpstreams lists all registered upstreams.
+func (h *OpenAPIV2) listUpstreams(c *gin.Context) {
+	ctx := c.Request.Context()
+	etcdClient, err := h.capture.GetEtcdClient()
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	infos, err := etcdClient.GetAllUpstreamInfo(ctx, model.DefaultNamespace)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	changefeeds, err := etcdClient.GetAllChangeFeedInfo(ctx)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	counts := countChangefeedsByUpstream(changefeeds)
+	resp := make([]Upstream, 0, len(infos))
+	for _, info := range infos {
+		resp = append(resp, toAPIUpstream(info, counts[info.ID]))
+	}
+	sort.Slice(resp, func(i, j int) bool { return resp[i].ID < resp[j].ID })
+	c.JSON(http.StatusOK, resp)
+}
+
+// getUpstream returns a registered upstream.
+func (h *OpenAPIV2) getUpstream(c *gin.Context) {
+	ctx := c.Request.Context()
+	upstreamID, err := strconv.ParseUint(c.Param(apiOpVarUpstreamID), 10, 64)
+	if err != nil {
+		_ = c.Error(cerror.ErrAPIInvalidParam.GenWithStack(
+			"invalid upstream_id: %s", c.Param(apiOpVarUpstreamID)))
+		return
+	}
+	etcdClient, err := h.capture.GetEtcdClient()
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	info, err := etcdClient.GetUpstreamInfo(ctx, upstreamID, model.DefaultNamespace)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	changefeeds, err := etcdClient.GetAllChangeFeedInfo(ctx)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	counts := countChangefeedsByUpstream(changefeeds)
+	c.JSON(http.StatusOK, toAPIUpstream(info, counts[info.ID]))
+}
+
+// deleteUpstream deletes a registered upstream which is not used by
+// any changefeed.
+func (h *OpenAPIV2) deleteUpstream(c *gin.Context) {
+	ctx := c.Request.Context()
+	upstreamID, err := strconv.ParseUint(c.Param(apiOpVarUpstreamID), 10, 64)
+	if err != nil {
+		_ = c.Error(cerror.ErrAPIInvalidParam.GenWithStack(
+			"invalid upstream_id: %s", c.Param(apiOpVarUpstreamID)))
+		return
+	}
+	etcdClient, err := h.capture.GetEtcdClient()
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	changefeeds, err := etcdClient.GetAllChangeFeedInfo(ctx)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	if count := countChangefeedsByUpstream(changefeeds)[upstreamID]; count > 0 {
+		_ = c.Error(cerror.ErrAPIInvalidParam.GenWithStack(
+			"upstream %d is used by %d changefeeds", upstreamID, count))
+		return
+	}
+	if err := etcdClient.DeleteUpstreamInfo(ctx, upstreamID, model.DefaultNamespace); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	log.Info("delete upstream successfully", zap.Uint64("upstreamID", upstreamID))
+	c.JSON(http.StatusOK, &EmptyResponse{})
+}
+
+func countChangefeedsByUpstream(
+	changefeeds map[model.ChangeFeedID]*model.ChangeFeedInfo,
+) map[model.UpstreamID]int {
+	counts := make(map[model.UpstreamID]int)
+	for id, info := range changefeeds {
+		if id.Namespace != model.DefaultNamespace {
+			continue
+		}
+		counts[info.UpstreamID]++
+	}
+	return counts
+}
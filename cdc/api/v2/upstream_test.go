@@ -0,0 +1,134 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	mock_capture "github.com/pingcap/tiflow/cdc/capture/mock"
+	"github.com/pingcap/tiflow/cdc/model"
+	mock_etcd "github.com/pingcap/tiflow/pkg/etcd/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpstreamAPI(t *testing.T) {
+	t.Parallel()
+
+	pdClient := &mockPDClient{}
+	helpers := NewMockAPIV2Helpers(gomock.NewController(t))
+	cp := mock_capture.NewMockCapture(gomock.NewController(t))
+	etcdClient := mock_etcd.NewMockCDCEtcdClient(gomock.NewController(t))
+	apiV2 := NewOpenAPIV2ForTest(cp, helpers)
+	router := newRouter(apiV2)
+	cp.EXPECT().IsReady().Return(true).AnyTimes()
+	cp.EXPECT().IsOwner().Return(true).AnyTimes()
+	cp.EXPECT().GetEtcdClient().Return(etcdClient, nil).AnyTimes()
+
+	// register an upstream
+	helpers.EXPECT().getPDClient(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(pdClient, nil).Times(1)
+	upstreamInfo := &model.UpstreamInfo{
+		ID:          123,
+		PDEndpoints: "http://127.0.0.1:2379,http://127.0.0.2:2379",
+		CAPath:      "ca.pem",
+	}
+	etcdClient.EXPECT().
+		SaveUpstreamInfo(gomock.Any(), gomock.Any(), model.DefaultNamespace).
+		DoAndReturn(func(_ context.Context, info *model.UpstreamInfo, _ string) error {
+			require.Equal(t, upstreamInfo.ID, info.ID)
+			require.Equal(t, upstreamInfo.PDEndpoints, info.PDEndpoints)
+			require.Equal(t, upstreamInfo.CAPath, info.CAPath)
+			return nil
+		})
+	body, err := json.Marshal(&PDConfig{
+		PDAddrs: []string{"http://127.0.0.1:2379", "http://127.0.0.2:2379"},
+		CAPath:  "ca.pem",
+	})
+	require.Nil(t, err)
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequestWithContext(context.Background(),
+		"POST", "/api/v2/upstreams", bytes.NewReader(body))
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusCreated, w.Code)
+	resp := Upstream{}
+	require.Nil(t, json.NewDecoder(w.Body).Decode(&resp))
+	require.Equal(t, uint64(123), resp.ID)
+
+	// pd_addrs is required
+	body, err = json.Marshal(&PDConfig{})
+	require.Nil(t, err)
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequestWithContext(context.Background(),
+		"POST", "/api/v2/upstreams", bytes.NewReader(body))
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	// list upstreams
+	changefeeds := map[model.ChangeFeedID]*model.ChangeFeedInfo{
+		model.DefaultChangeFeedID("cf1"): {UpstreamID: 123},
+		model.DefaultChangeFeedID("cf2"): {UpstreamID: 123},
+	}
+	etcdClient.EXPECT().GetAllUpstreamInfo(gomock.Any(), model.DefaultNamespace).
+		Return(map[model.UpstreamID]*model.UpstreamInfo{123: upstreamInfo}, nil)
+	etcdClient.EXPECT().GetAllChangeFeedInfo(gomock.Any()).
+		Return(changefeeds, nil).AnyTimes()
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequestWithContext(context.Background(),
+		"GET", "/api/v2/upstreams", nil)
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	var list []Upstream
+	require.Nil(t, json.NewDecoder(w.Body).Decode(&list))
+	require.Len(t, list, 1)
+	require.Equal(t, 2, list[0].ChangefeedCount)
+	require.Len(t, list[0].PDAddrs, 2)
+
+	// get an upstream
+	etcdClient.EXPECT().GetUpstreamInfo(gomock.Any(), uint64(123), model.DefaultNamespace).
+		Return(upstreamInfo, nil)
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequestWithContext(context.Background(),
+		"GET", "/api/v2/upstreams/123", nil)
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	// invalid upstream id
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequestWithContext(context.Background(),
+		"GET", "/api/v2/upstreams/abc", nil)
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	// an upstream used by changefeeds can not be deleted
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequestWithContext(context.Background(),
+		"DELETE", "/api/v2/upstreams/123", nil)
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	// delete an unused upstream
+	etcdClient.EXPECT().DeleteUpstreamInfo(gomock.Any(), uint64(456), model.DefaultNamespace).
+		Return(nil)
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequestWithContext(context.Background(),
+		"DELETE", "/api/v2/upstreams/456", nil)
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+}
@@ -34,6 +34,7 @@ import (
 	cdcContext "github.com/pingcap/tiflow/pkg/context"
 	cerror "github.com/pingcap/tiflow/pkg/errors"
 	"github.com/pingcap/tiflow/pkg/etcd"
+	"github.com/pingcap/tiflow/pkg/initqueue"
 	"github.com/pingcap/tiflow/pkg/migrate"
 	"github.com/pingcap/tiflow/pkg/orchestrator"
 	"github.com/pingcap/tiflow/pkg/p2p"
@@ -246,7 +247,17 @@ func (c *captureImpl) reset(ctx context.Context) error {
 		_ = c.session.Close()
 	}
 	c.session = sess
-	c.election = newElection(sess, etcd.CaptureOwnerKey(c.EtcdClient.GetClusterID()))
+	ownerKeyPrefix := etcd.CaptureOwnerKey(c.EtcdClient.GetClusterID())
+	if c.config.Election != nil && c.config.Election.Mode == config.ElectionModeKubernetes {
+		// The session is still needed, the owner key written by the holder
+		// of the lease is bound to it.
+		c.election, err = newKubernetesElection(sess, ownerKeyPrefix, c.config.Election)
+		if err != nil {
+			return cerror.WrapError(cerror.ErrNewCaptureFailed, err)
+		}
+	} else {
+		c.election = newElection(sess, ownerKeyPrefix)
+	}
 
 	c.grpcService.Reset(nil)
 
@@ -335,6 +346,7 @@ func (c *captureImpl) run(stdCtx context.Context) error {
 		MessageRouter:     c.MessageRouter,
 		SorterSystem:      c.sorterSystem,
 		SortEngineFactory: c.sortEngineFactory,
+		InitQueue:         initqueue.New(config.GetGlobalServerConfig().ChangefeedInitConcurrency),
 	})
 
 	g.Go(func() error {
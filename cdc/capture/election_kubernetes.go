@@ -0,0 +1,528 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package capture
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/pkg/config"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/client/v3/concurrency"
+	"go.uber.org/zap"
+)
+
+const (
+	k8sServiceAccountDir = "/var/run/secrets/kubernetes.io/serviceaccount"
+	k8sLeaseAPIPath      = "/apis/coordination.k8s.io/v1/namespaces/%s/leases"
+	// k8sMicroTimeLayout is the layout of metav1.MicroTime.
+	k8sMicroTimeLayout = "2006-01-02T15:04:05.000000Z07:00"
+)
+
+var (
+	// errLeaseConflict means the lease is modified by others concurrently.
+	errLeaseConflict = errors.New("kubernetes lease conflict")
+	// errLeaseLost means the lease is no longer the one written by the
+	// holder, either its resourceVersion or its holder identity changed.
+	errLeaseLost = errors.New("kubernetes lease lost")
+)
+
+// k8sLease is the subset of coordination.k8s.io/v1 Lease used by the election.
+type k8sLease struct {
+	APIVersion string        `json:"apiVersion"`
+	Kind       string        `json:"kind"`
+	Metadata   k8sObjectMeta `json:"metadata"`
+	Spec       k8sLeaseSpec  `json:"spec"`
+}
+
+type k8sObjectMeta struct {
+	Name            string `json:"name"`
+	Namespace       string `json:"namespace,omitempty"`
+	ResourceVersion string `json:"resourceVersion,omitempty"`
+}
+
+type k8sLeaseSpec struct {
+	HolderIdentity       string `json:"holderIdentity,omitempty"`
+	LeaseDurationSeconds int32  `json:"leaseDurationSeconds,omitempty"`
+	AcquireTime          string `json:"acquireTime,omitempty"`
+	RenewTime            string `json:"renewTime,omitempty"`
+	LeaseTransitions     int32  `json:"leaseTransitions,omitempty"`
+}
+
+// expired returns true if the holder of the lease does not renew it in time.
+func (l *k8sLease) expired(now time.Time) bool {
+	if l.Spec.HolderIdentity == "" {
+		return true
+	}
+	renewTime, err := time.Parse(k8sMicroTimeLayout, l.Spec.RenewTime)
+	if err != nil {
+		return true
+	}
+	duration := time.Duration(l.Spec.LeaseDurationSeconds) * time.Second
+	return renewTime.Add(duration).Before(now)
+}
+
+// leaseClient reads and writes a Lease object by the Kubernetes REST API.
+type leaseClient struct {
+	httpClient *http.Client
+	// host is the address of the Kubernetes API server, eg, https://10.0.0.1:443
+	host      string
+	token     string
+	namespace string
+	name      string
+}
+
+// newInClusterLeaseClient creates a leaseClient with the service account
+// mounted into the pod.
+func newInClusterLeaseClient(cfg *config.ElectionConfig) (*leaseClient, error) {
+	host, port := os.Getenv("KUBERNETES_SERVICE_HOST"), os.Getenv("KUBERNETES_SERVICE_PORT")
+	if host == "" || port == "" {
+		return nil, errors.New("kubernetes election mode requires running in a pod, " +
+			"KUBERNETES_SERVICE_HOST and KUBERNETES_SERVICE_PORT are not set")
+	}
+	token, err := os.ReadFile(filepath.Join(k8sServiceAccountDir, "token"))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ca, err := os.ReadFile(filepath.Join(k8sServiceAccountDir, "ca.crt"))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(ca) {
+		return nil, errors.New("failed to load the kubernetes service account ca")
+	}
+	namespace := cfg.LeaseNamespace
+	if namespace == "" {
+		ns, err := os.ReadFile(filepath.Join(k8sServiceAccountDir, "namespace"))
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		namespace = strings.TrimSpace(string(ns))
+	}
+	return &leaseClient{
+		httpClient: &http.Client{
+			Transport: &http.Transport{
+				TLSClientConfig: &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12},
+			},
+			Timeout: time.Duration(cfg.RetryPeriod),
+		},
+		host:      "https://" + net.JoinHostPort(host, port),
+		token:     strings.TrimSpace(string(token)),
+		namespace: namespace,
+		name:      cfg.LeaseName,
+	}, nil
+}
+
+func (c *leaseClient) do(
+	ctx context.Context, method, url string, lease *k8sLease,
+) (*k8sLease, int, error) {
+	var body io.Reader
+	if lease != nil {
+		data, err := json.Marshal(lease)
+		if err != nil {
+			return nil, 0, errors.Trace(err)
+		}
+		body = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return nil, 0, errors.Trace(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, 0, errors.Trace(err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, resp.StatusCode, errors.Trace(err)
+	}
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusCreated:
+	case http.StatusNotFound:
+		return nil, resp.StatusCode, nil
+	case http.StatusConflict:
+		return nil, resp.StatusCode, errLeaseConflict
+	default:
+		return nil, resp.StatusCode, errors.Errorf(
+			"kubernetes api %s %s failed, status: %d, body: %s",
+			method, url, resp.StatusCode, string(data))
+	}
+	result := &k8sLease{}
+	if err := json.Unmarshal(data, result); err != nil {
+		return nil, resp.StatusCode, errors.Trace(err)
+	}
+	return result, resp.StatusCode, nil
+}
+
+func (c *leaseClient) collectionURL() string {
+	return c.host + fmt.Sprintf(k8sLeaseAPIPath, c.namespace)
+}
+
+// get returns the lease, or nil if the lease does not exist.
+func (c *leaseClient) get(ctx context.Context) (*k8sLease, error) {
+	lease, _, err := c.do(ctx, http.MethodGet, c.collectionURL()+"/"+c.name, nil)
+	return lease, err
+}
+
+// create creates the lease and returns the created one.
+func (c *leaseClient) create(ctx context.Context, lease *k8sLease) (*k8sLease, error) {
+	lease.APIVersion = "coordination.k8s.io/v1"
+	lease.Kind = "Lease"
+	lease.Metadata.Name = c.name
+	lease.Metadata.Namespace = c.namespace
+	result, _, err := c.do(ctx, http.MethodPost, c.collectionURL(), lease)
+	return result, err
+}
+
+// update replaces the lease and returns the updated one, errLeaseConflict
+// is returned if the resourceVersion of the lease is not the current one.
+func (c *leaseClient) update(ctx context.Context, lease *k8sLease) (*k8sLease, error) {
+	result, status, err := c.do(ctx, http.MethodPut, c.collectionURL()+"/"+c.name, lease)
+	if err == nil && status == http.StatusNotFound {
+		return nil, errLeaseConflict
+	}
+	return result, err
+}
+
+// ownerKey is the key in etcd which the other captures discover the owner
+// by, and which the owner fences its writes to etcd with.
+type ownerKey interface {
+	// put replaces the keys of the former owners with the one of the owner.
+	put(ctx context.Context, value string) error
+	// delete deletes the key of the owner.
+	delete(ctx context.Context) error
+}
+
+// etcdOwnerKey is the owner key in etcd bound to the session of the capture,
+// which is the same key as the one written by the etcd election.
+type etcdOwnerKey struct {
+	session *concurrency.Session
+	prefix  string
+	key     string
+}
+
+func newEtcdOwnerKey(session *concurrency.Session, prefix string) *etcdOwnerKey {
+	return &etcdOwnerKey{
+		session: session,
+		prefix:  prefix,
+		key:     fmt.Sprintf("%s/%x", prefix, session.Lease()),
+	}
+}
+
+// put implements ownerKey. The keys of the former owners are stale since
+// they have lost the lease, so they're deleted rather than waited for.
+func (k *etcdOwnerKey) put(ctx context.Context, value string) error {
+	client := k.session.Client()
+	resp, err := client.Get(ctx, k.prefix+"/", clientv3.WithPrefix(), clientv3.WithKeysOnly())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	ops := make([]clientv3.Op, 0, len(resp.Kvs)+1)
+	for _, kv := range resp.Kvs {
+		if string(kv.Key) != k.key {
+			ops = append(ops, clientv3.OpDelete(string(kv.Key)))
+		}
+	}
+	ops = append(ops, clientv3.OpPut(k.key, value, clientv3.WithLease(k.session.Lease())))
+	_, err = client.Txn(ctx).Then(ops...).Commit()
+	return errors.Trace(err)
+}
+
+// delete implements ownerKey.
+func (k *etcdOwnerKey) delete(ctx context.Context) error {
+	_, err := k.session.Client().Delete(ctx, k.key)
+	return errors.Trace(err)
+}
+
+// kubernetesElection elects the owner by a Kubernetes Lease object, the
+// captures don't campaign in etcd.
+//
+// The lease fences the owner by its resourceVersion and holder identity.
+// The holder keeps the lease it last wrote, and renews it by a conditional
+// update on that resourceVersion, so any write by others makes the renewal
+// fail with a conflict and the holder resigns at once. The owner key in
+// etcd is written only after the lease is checked to be unchanged.
+//
+// The etcd session of the capture is still used on this path: the captures
+// are registered in etcd with it, the other captures discover the owner by
+// the owner key in etcd, and the owner's writes to etcd are fenced by the
+// owner key, which is bound to the session. Deleting the owner key once the
+// lease is lost is what fences the etcd writes of a stale owner.
+type kubernetesElection struct {
+	ownerKey      ownerKey
+	client        *leaseClient
+	leaseDuration time.Duration
+	retryPeriod   time.Duration
+
+	// mu serializes operations on ownerKey and held, because the lease
+	// may be lost in the renewal goroutine.
+	mu sync.Mutex
+	// held is the lease last written by the holder, nil if it's not held.
+	held        *k8sLease
+	cancelRenew context.CancelFunc
+	renewWg     sync.WaitGroup
+}
+
+func newKubernetesElection(
+	session *concurrency.Session, prefix string, cfg *config.ElectionConfig,
+) (election, error) {
+	client, err := newInClusterLeaseClient(cfg)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return newKubernetesElectionWithClient(newEtcdOwnerKey(session, prefix), client, cfg), nil
+}
+
+func newKubernetesElectionWithClient(
+	ownerKey ownerKey, client *leaseClient, cfg *config.ElectionConfig,
+) *kubernetesElection {
+	return &kubernetesElection{
+		ownerKey:      ownerKey,
+		client:        client,
+		leaseDuration: time.Duration(cfg.LeaseDuration),
+		retryPeriod:   time.Duration(cfg.RetryPeriod),
+	}
+}
+
+// campaign blocks until the lease is acquired, and then writes the owner
+// key in etcd if the lease is still held.
+func (e *kubernetesElection) campaign(ctx context.Context, key string) error {
+	var lease *k8sLease
+	for {
+		var err error
+		lease, err = e.tryAcquire(ctx, key)
+		if err != nil {
+			log.Warn("acquire kubernetes lease failed",
+				zap.String("lease", e.client.name), zap.Error(err))
+		}
+		if lease != nil {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return errors.Trace(ctx.Err())
+		case <-time.After(e.retryPeriod):
+		}
+	}
+	log.Info("kubernetes lease acquired",
+		zap.String("lease", e.client.name), zap.String("holder", key),
+		zap.String("resourceVersion", lease.Metadata.ResourceVersion))
+
+	e.mu.Lock()
+	e.held = lease
+	e.mu.Unlock()
+	renewCtx, cancel := context.WithCancel(context.Background())
+	e.cancelRenew = cancel
+	e.renewWg.Add(1)
+	go func() {
+		defer e.renewWg.Done()
+		e.renewLoop(renewCtx, time.Now())
+	}()
+
+	e.mu.Lock()
+	err := e.checkHeld(ctx)
+	if err == nil {
+		err = e.ownerKey.put(ctx, key)
+	}
+	e.mu.Unlock()
+	if err != nil {
+		e.stopRenew()
+		return err
+	}
+	return nil
+}
+
+// resign releases the lease and deletes the owner key in etcd.
+func (e *kubernetesElection) resign(ctx context.Context) error {
+	e.stopRenew()
+	e.mu.Lock()
+	err := e.ownerKey.delete(ctx)
+	e.mu.Unlock()
+	return err
+}
+
+// stopRenew stops the renewal goroutine, which releases the lease on exit.
+func (e *kubernetesElection) stopRenew() {
+	if e.cancelRenew != nil {
+		e.cancelRenew()
+		e.renewWg.Wait()
+		e.cancelRenew = nil
+	}
+}
+
+// renewLoop renews the lease periodically. The owner key in etcd is deleted,
+// so that the writes of the owner are fenced, once the lease is modified by
+// others, or once it's not renewed in time and may be taken over by others.
+// The deadline leaves one retry period before the lease expires, because
+// the candidates take over the lease once it expires.
+func (e *kubernetesElection) renewLoop(ctx context.Context, renewed time.Time) {
+	defer e.release()
+	ticker := time.NewTicker(e.retryPeriod)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		now := time.Now()
+		err := e.renew(ctx, now)
+		if err == nil {
+			renewed = now
+			continue
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		if errors.Cause(err) != errLeaseLost &&
+			time.Since(renewed) < e.leaseDuration-e.retryPeriod {
+			log.Warn("renew kubernetes lease failed, retry later",
+				zap.String("lease", e.client.name), zap.Error(err))
+			continue
+		}
+		log.Warn("kubernetes lease lost, resign the owner",
+			zap.String("lease", e.client.name), zap.Error(err))
+		resignCtx, cancel := context.WithTimeout(context.Background(), e.leaseDuration)
+		e.mu.Lock()
+		e.held = nil
+		if err := e.ownerKey.delete(resignCtx); err != nil {
+			log.Warn("resign the owner failed", zap.Error(err))
+		}
+		e.mu.Unlock()
+		cancel()
+		return
+	}
+}
+
+// tryAcquire returns the lease if it's acquired by the holder, or nil if
+// it's held by others.
+func (e *kubernetesElection) tryAcquire(
+	ctx context.Context, holder string,
+) (*k8sLease, error) {
+	now := time.Now()
+	nowStr := now.UTC().Format(k8sMicroTimeLayout)
+	lease, err := e.client.get(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if lease == nil {
+		lease, err = e.client.create(ctx, &k8sLease{Spec: k8sLeaseSpec{
+			HolderIdentity:       holder,
+			LeaseDurationSeconds: int32(e.leaseDuration / time.Second),
+			AcquireTime:          nowStr,
+			RenewTime:            nowStr,
+		}})
+		if errors.Cause(err) == errLeaseConflict {
+			return nil, nil
+		}
+		return lease, errors.Trace(err)
+	}
+
+	if lease.Spec.HolderIdentity != holder {
+		if !lease.expired(now) {
+			return nil, nil
+		}
+		lease.Spec.LeaseTransitions++
+	}
+	lease.Spec.HolderIdentity = holder
+	lease.Spec.LeaseDurationSeconds = int32(e.leaseDuration / time.Second)
+	lease.Spec.AcquireTime = nowStr
+	lease.Spec.RenewTime = nowStr
+	lease, err = e.client.update(ctx, lease)
+	if errors.Cause(err) == errLeaseConflict {
+		return nil, nil
+	}
+	return lease, errors.Trace(err)
+}
+
+// renew renews the held lease on its resourceVersion, errLeaseLost is
+// returned if the lease is modified by others since it was last written.
+func (e *kubernetesElection) renew(ctx context.Context, now time.Time) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.held == nil {
+		return errLeaseLost
+	}
+	lease := *e.held
+	lease.Spec.RenewTime = now.UTC().Format(k8sMicroTimeLayout)
+	result, err := e.client.update(ctx, &lease)
+	if errors.Cause(err) == errLeaseConflict {
+		return errors.Trace(errLeaseLost)
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+	e.held = result
+	return nil
+}
+
+// checkHeld returns errLeaseLost if the current lease is not the one last
+// written by the holder. It must be called with mu held.
+func (e *kubernetesElection) checkHeld(ctx context.Context) error {
+	if e.held == nil {
+		return errors.Trace(errLeaseLost)
+	}
+	lease, err := e.client.get(ctx)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if lease == nil ||
+		lease.Metadata.ResourceVersion != e.held.Metadata.ResourceVersion ||
+		lease.Spec.HolderIdentity != e.held.Spec.HolderIdentity {
+		return errors.Trace(errLeaseLost)
+	}
+	return nil
+}
+
+// release gives up the lease if it is still the one last written by the
+// holder, so that other captures can acquire it without waiting for
+// expiration.
+func (e *kubernetesElection) release() {
+	e.mu.Lock()
+	held := e.held
+	e.held = nil
+	e.mu.Unlock()
+	if held == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), e.retryPeriod)
+	defer cancel()
+	lease := *held
+	lease.Spec.HolderIdentity = ""
+	if _, err := e.client.update(ctx, &lease); err != nil {
+		log.Warn("release kubernetes lease failed",
+			zap.String("lease", e.client.name), zap.Error(err))
+	}
+}
@@ -0,0 +1,254 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package capture
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/pingcap/tiflow/pkg/etcd"
+	"github.com/stretchr/testify/require"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/client/v3/concurrency"
+)
+
+// fakeLeaseServer is a minimal Kubernetes API server which serves one lease.
+type fakeLeaseServer struct {
+	mu      sync.Mutex
+	lease   *k8sLease
+	version int
+}
+
+func (s *fakeLeaseServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch r.Method {
+	case http.MethodGet:
+		if s.lease == nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+	case http.MethodPost, http.MethodPut:
+		lease := &k8sLease{}
+		if err := json.NewDecoder(r.Body).Decode(lease); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if r.Method == http.MethodPost && s.lease != nil {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+		if r.Method == http.MethodPut &&
+			(s.lease == nil || lease.Metadata.ResourceVersion != strconv.Itoa(s.version)) {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+		s.version++
+		lease.Metadata.ResourceVersion = strconv.Itoa(s.version)
+		s.lease = lease
+	}
+	_ = json.NewEncoder(w).Encode(s.lease)
+}
+
+func (s *fakeLeaseServer) holder() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.lease == nil {
+		return ""
+	}
+	return s.lease.Spec.HolderIdentity
+}
+
+// overwrite replaces the holder of the lease as another writer does.
+func (s *fakeLeaseServer) overwrite(holder string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.version++
+	s.lease.Metadata.ResourceVersion = strconv.Itoa(s.version)
+	s.lease.Spec.HolderIdentity = holder
+}
+
+type fakeOwnerKey struct {
+	mu            sync.Mutex
+	value         string
+	puts, deletes int
+}
+
+func (k *fakeOwnerKey) put(ctx context.Context, value string) error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.value = value
+	k.puts++
+	return nil
+}
+
+func (k *fakeOwnerKey) delete(ctx context.Context) error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.value = ""
+	k.deletes++
+	return nil
+}
+
+func (k *fakeOwnerKey) get() (string, int) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	return k.value, k.deletes
+}
+
+func newTestKubernetesElection(
+	url string, ownerKey ownerKey,
+) *kubernetesElection {
+	cfg := config.NewDefaultElectionConfig()
+	cfg.Mode = config.ElectionModeKubernetes
+	cfg.LeaseDuration = config.TomlDuration(time.Second)
+	cfg.RetryPeriod = config.TomlDuration(50 * time.Millisecond)
+	client := &leaseClient{
+		// Disable keep-alive to avoid leaking connection goroutines.
+		httpClient: &http.Client{Transport: &http.Transport{DisableKeepAlives: true}},
+		host:       url,
+		namespace:  "default",
+		name:       cfg.LeaseName,
+	}
+	return newKubernetesElectionWithClient(ownerKey, client, cfg)
+}
+
+func TestKubernetesElection(t *testing.T) {
+	t.Parallel()
+
+	server := &fakeLeaseServer{}
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+
+	key1, key2 := &fakeOwnerKey{}, &fakeOwnerKey{}
+	e1 := newTestKubernetesElection(httpServer.URL, key1)
+	e2 := newTestKubernetesElection(httpServer.URL, key2)
+
+	ctx := context.Background()
+	require.Nil(t, e1.campaign(ctx, "capture-1"))
+	require.Equal(t, "capture-1", server.holder())
+	require.Equal(t, "capture-1", key1.value)
+
+	// The second candidate is blocked until the lease is released.
+	ctx2, cancel := context.WithTimeout(ctx, 300*time.Millisecond)
+	defer cancel()
+	err := e2.campaign(ctx2, "capture-2")
+	require.Equal(t, context.DeadlineExceeded, errors.Cause(err))
+	require.Equal(t, 0, key2.puts)
+
+	require.Nil(t, e1.resign(ctx))
+	require.Equal(t, 1, key1.deletes)
+	require.Equal(t, "", server.holder())
+
+	require.Nil(t, e2.campaign(ctx, "capture-2"))
+	require.Equal(t, "capture-2", server.holder())
+	require.Equal(t, "capture-2", key2.value)
+	require.Nil(t, e2.resign(ctx))
+}
+
+func TestKubernetesElectionFenced(t *testing.T) {
+	t.Parallel()
+
+	server := &fakeLeaseServer{}
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+
+	key := &fakeOwnerKey{}
+	e := newTestKubernetesElection(httpServer.URL, key)
+	ctx := context.Background()
+	require.Nil(t, e.campaign(ctx, "capture-1"))
+	value, _ := key.get()
+	require.Equal(t, "capture-1", value)
+
+	// The lease is written by others, the holder resigns on the next
+	// renewal rather than after the lease duration.
+	server.overwrite("capture-2")
+	require.Eventually(t, func() bool {
+		value, deletes := key.get()
+		return value == "" && deletes == 1
+	}, 500*time.Millisecond, 10*time.Millisecond)
+	// The lease of the new holder is not released by the former one.
+	require.Nil(t, e.resign(ctx))
+	require.Equal(t, "capture-2", server.holder())
+	require.Equal(t, errLeaseLost, errors.Cause(e.checkHeld(ctx)))
+}
+
+func TestEtcdOwnerKey(t *testing.T) {
+	t.Parallel()
+
+	clientURL, etcdServer, err := etcd.SetupEmbedEtcd(t.TempDir())
+	require.Nil(t, err)
+	defer etcdServer.Close()
+	client, err := clientv3.New(clientv3.Config{
+		Endpoints:   []string{clientURL.String()},
+		DialTimeout: 3 * time.Second,
+	})
+	require.Nil(t, err)
+	defer client.Close()
+
+	ctx := context.Background()
+	prefix := etcd.CaptureOwnerKey(etcd.DefaultCDCClusterID)
+	ownerID := func() string {
+		resp, err := client.Get(ctx, prefix, clientv3.WithFirstCreate()...)
+		require.Nil(t, err)
+		if len(resp.Kvs) == 0 {
+			return ""
+		}
+		return string(resp.Kvs[0].Value)
+	}
+
+	sess1, err := concurrency.NewSession(client)
+	require.Nil(t, err)
+	defer sess1.Close()
+	sess2, err := concurrency.NewSession(client)
+	require.Nil(t, err)
+	defer sess2.Close()
+	key1, key2 := newEtcdOwnerKey(sess1, prefix), newEtcdOwnerKey(sess2, prefix)
+
+	require.Nil(t, key1.put(ctx, "capture-1"))
+	require.Equal(t, "capture-1", ownerID())
+	// The key of the former owner is replaced without waiting for it.
+	require.Nil(t, key2.put(ctx, "capture-2"))
+	require.Equal(t, "capture-2", ownerID())
+	resp, err := client.Get(ctx, prefix, clientv3.WithPrefix())
+	require.Nil(t, err)
+	require.Len(t, resp.Kvs, 1)
+
+	require.Nil(t, key2.delete(ctx))
+	require.Equal(t, "", ownerID())
+}
+
+func TestKubernetesLeaseExpired(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	lease := &k8sLease{Spec: k8sLeaseSpec{
+		HolderIdentity:       "capture-1",
+		LeaseDurationSeconds: 10,
+		RenewTime:            now.UTC().Format(k8sMicroTimeLayout),
+	}}
+	require.False(t, lease.expired(now.Add(5*time.Second)))
+	require.True(t, lease.expired(now.Add(11*time.Second)))
+
+	lease.Spec.HolderIdentity = ""
+	require.True(t, lease.expired(now))
+}
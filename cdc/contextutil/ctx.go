@@ -20,6 +20,8 @@ import (
 	"github.com/pingcap/errors"
 	"github.com/pingcap/tidb/kv"
 	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/pingcap/tiflow/pkg/initqueue"
 	"github.com/pingcap/tiflow/pkg/util"
 )
 
@@ -33,6 +35,8 @@ const (
 	ctxKeyTimezone     = ctxKey("timezone")
 	ctxKeyKVStorage    = ctxKey("kvStorage")
 	ctxKeyRole         = ctxKey("role")
+	ctxKeyMetrics      = ctxKey("metrics")
+	ctxKeyInitQueue    = ctxKey("initQueue")
 )
 
 // CaptureAddrFromCtx returns a capture ID stored in the specified context.
@@ -137,3 +141,32 @@ func RoleFromCtx(ctx context.Context) util.Role {
 func PutRoleInCtx(ctx context.Context, role util.Role) context.Context {
 	return context.WithValue(ctx, ctxKeyRole, role)
 }
+
+// MetricsConfigFromCtx returns the metrics config stored in the specified context.
+// It returns nil if there's no metrics config found, all the labels are enabled.
+func MetricsConfigFromCtx(ctx context.Context) *config.MetricsConfig {
+	cfg, ok := ctx.Value(ctxKeyMetrics).(*config.MetricsConfig)
+	if !ok {
+		return nil
+	}
+	return cfg
+}
+
+// PutMetricsConfigInCtx return a new child context with the specified metrics config stored.
+func PutMetricsConfigInCtx(ctx context.Context, cfg *config.MetricsConfig) context.Context {
+	return context.WithValue(ctx, ctxKeyMetrics, cfg)
+}
+
+// PutInitQueueInCtx returns a new child context with the queue limiting the
+// initializations of the changefeeds stored.
+func PutInitQueueInCtx(ctx context.Context, queue *initqueue.Queue) context.Context {
+	return context.WithValue(ctx, ctxKeyInitQueue, queue)
+}
+
+// InitQueueFromCtx returns the queue limiting the initializations of the
+// changefeeds stored in the specified context.
+// It returns nil if there's no queue found.
+func InitQueueFromCtx(ctx context.Context) *initqueue.Queue {
+	queue, _ := ctx.Value(ctxKeyInitQueue).(*initqueue.Queue)
+	return queue
+}
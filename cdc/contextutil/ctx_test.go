@@ -19,6 +19,8 @@ import (
 
 	"github.com/pingcap/tidb/store/mockstore"
 	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/pingcap/tiflow/pkg/initqueue"
 	"github.com/pingcap/tiflow/pkg/util"
 	"github.com/stretchr/testify/require"
 )
@@ -110,3 +112,19 @@ func TestKVStorageNotSet(t *testing.T) {
 	require.Nil(t, kvStorage)
 	require.NotNil(t, err)
 }
+
+func TestShouldReturnMetricsConfig(t *testing.T) {
+	cfg := &config.MetricsConfig{AggregateOnly: true}
+	ctx := PutMetricsConfigInCtx(context.Background(), cfg)
+	require.Equal(t, cfg, MetricsConfigFromCtx(ctx))
+	require.Nil(t, MetricsConfigFromCtx(context.Background()))
+}
+
+func TestShouldReturnInitQueue(t *testing.T) {
+	queue := initqueue.New(1)
+	ctx := PutInitQueueInCtx(context.Background(), queue)
+	require.Equal(t, queue, InitQueueFromCtx(ctx))
+	require.Nil(t, InitQueueFromCtx(context.Background()))
+	// the queue is not limited if it's absent in the capture.
+	require.Nil(t, InitQueueFromCtx(PutInitQueueInCtx(context.Background(), nil)))
+}
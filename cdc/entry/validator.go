@@ -43,7 +43,7 @@ func VerifyTables(
 	}
 
 	snap.IterTables(true, func(tableInfo *model.TableInfo) {
-		if f.ShouldIgnoreTable(tableInfo.TableName.Schema, tableInfo.TableName.Table) {
+		if f.ShouldIgnoreTableInfo(tableInfo) {
 			return
 		}
 		// Sequence is not supported yet, TiCDC needs to filter all sequence tables.
@@ -25,6 +25,7 @@ import (
 	v2 "github.com/pingcap/tiflow/cdc/api/v2"
 	"github.com/pingcap/tiflow/cdc/capture"
 	_ "github.com/pingcap/tiflow/docs/swagger" // use for OpenAPI online docs
+	"github.com/pingcap/tiflow/pkg/monitoring"
 	"github.com/pingcap/tiflow/pkg/util"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
@@ -36,7 +37,7 @@ import (
 func RegisterRoutes(
 	router *gin.Engine,
 	capture capture.Capture,
-	registry prometheus.Gatherer,
+	registry *monitoring.Registry,
 ) {
 	// online docs
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
@@ -44,7 +45,7 @@ func RegisterRoutes(
 	// Open API V1
 	v1.RegisterOpenAPIRoutes(router, v1.NewOpenAPI(capture))
 	// Open API V2
-	v2.RegisterOpenAPIV2Routes(router, v2.NewOpenAPIV2(capture))
+	v2.RegisterOpenAPIV2Routes(router, v2.NewOpenAPIV2(capture, registry))
 
 	// Owner API
 	owner.RegisterOwnerAPIRoutes(router, capture)
@@ -24,6 +24,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/pingcap/failpoint"
 	"github.com/pingcap/tiflow/cdc/capture"
+	"github.com/pingcap/tiflow/pkg/monitoring"
 	"github.com/stretchr/testify/require"
 )
 
@@ -38,7 +39,7 @@ func (a *testCase) String() string {
 
 func TestPProfPath(t *testing.T) {
 	router := gin.New()
-	RegisterRoutes(router, capture.NewCapture4Test(nil), nil)
+	RegisterRoutes(router, capture.NewCapture4Test(nil), monitoring.NewRegistry())
 
 	apis := []*testCase{
 		{"/debug/pprof/", http.MethodGet},
@@ -63,7 +64,7 @@ func TestPProfPath(t *testing.T) {
 
 func TestHandleFailpoint(t *testing.T) {
 	router := gin.New()
-	RegisterRoutes(router, capture.NewCapture4Test(nil), nil)
+	RegisterRoutes(router, capture.NewCapture4Test(nil), monitoring.NewRegistry())
 	fp := "github.com/pingcap/tiflow/cdc/TestHandleFailpoint"
 	uri := fmt.Sprintf("/debug/fail/%s", fp)
 	body := bytes.NewReader([]byte("return(true)"))
@@ -113,7 +113,7 @@ var (
 )
 
 // InitMetrics registers all metrics in the kv package
-func InitMetrics(registry *prometheus.Registry) {
+func InitMetrics(registry prometheus.Registerer) {
 	registry.MustRegister(eventFeedErrorCounter)
 	registry.MustRegister(scanRegionsDuration)
 	registry.MustRegister(eventSize)
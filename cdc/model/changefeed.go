@@ -146,6 +146,16 @@ type ChangeFeedInfo struct {
 	Error  *RunningError         `json:"error"`
 
 	CreatorVersion string `json:"creator-version"`
+	// ConfigVersion is the version of the persisted replica config, it's 0
+	// if the changefeed is created by a release without config versions.
+	ConfigVersion int `json:"config-version,omitempty"`
+	// StateHistory is the latest transitions of the state of the changefeed,
+	// see RecordStateTransition.
+	StateHistory []*StateTransition `json:"state-history,omitempty"`
+	// RemovalPending is true if the changefeed is being removed after the
+	// cleanup of the downstream, it's persisted so that the removal is
+	// resumed by the next owner.
+	RemovalPending bool `json:"removal-pending,omitempty"`
 }
 
 const changeFeedIDMaxLen = 128
@@ -194,6 +204,9 @@ func (info *ChangeFeedInfo) String() (str string) {
 	if err != nil {
 		log.Error("failed to marshal changefeed info", zap.Error(err))
 	}
+	if clone.Config != nil {
+		clone.Config.MaskSensitiveData()
+	}
 
 	str, err = clone.Marshal()
 	if err != nil {
@@ -212,12 +225,19 @@ func (info *ChangeFeedInfo) GetStartTs() uint64 {
 	return oracle.GoTimeToTS(info.CreateTime)
 }
 
-// GetCheckpointTs returns CheckpointTs if it's specified in ChangeFeedStatus, otherwise StartTs is returned.
+// GetCheckpointTs returns CheckpointTs if it's specified in ChangeFeedStatus, otherwise
+// the min of StartTs and the start ts of tables in the filter config is returned.
 func (info *ChangeFeedInfo) GetCheckpointTs(status *ChangeFeedStatus) uint64 {
 	if status != nil {
 		return status.CheckpointTs
 	}
-	return info.GetStartTs()
+	startTs := info.GetStartTs()
+	if info.Config != nil && info.Config.Filter != nil {
+		if ts := info.Config.Filter.MinTableStartTs(); ts != 0 && ts < startTs {
+			startTs = ts
+		}
+	}
+	return startTs
 }
 
 // GetTargetTs returns TargetTs if it's specified, otherwise MaxUint64 is returned.
@@ -236,12 +256,43 @@ func (info *ChangeFeedInfo) Marshal() (string, error) {
 
 // Unmarshal unmarshals into *ChangeFeedInfo from json marshal byte slice
 func (info *ChangeFeedInfo) Unmarshal(data []byte) error {
+	_, err := info.unmarshal(data)
+	return err
+}
+
+// unmarshal unmarshals the changefeed info, and migrates the replica config
+// persisted by an earlier release from its version to the current one. The
+// migrated config is persisted along with the current version next time the
+// info is written. It returns the descriptions of the applied migrations.
+func (info *ChangeFeedInfo) unmarshal(data []byte) ([]string, error) {
 	err := json.Unmarshal(data, &info)
 	if err != nil {
-		return errors.Annotatef(
+		return nil, errors.Annotatef(
 			cerror.WrapError(cerror.ErrUnmarshalFailed, err), "Unmarshal data: %v", data)
 	}
-	return nil
+	if info.Config == nil || info.ConfigVersion >= config.CurrentReplicaConfigVersion {
+		return nil, nil
+	}
+
+	var raw struct {
+		Config json.RawMessage `json:"config"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, cerror.WrapError(cerror.ErrUnmarshalFailed, err)
+	}
+	migrated, applied, err := config.MigrateReplicaConfig(raw.Config, info.ConfigVersion)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(applied) != 0 {
+		cfg := new(config.ReplicaConfig)
+		if err := json.Unmarshal(migrated, cfg); err != nil {
+			return nil, cerror.WrapError(cerror.ErrUnmarshalFailed, err)
+		}
+		info.Config = cfg
+	}
+	info.ConfigVersion = config.CurrentReplicaConfigVersion
+	return applied, nil
 }
 
 // Clone returns a cloned ChangeFeedInfo
@@ -275,6 +326,9 @@ func (info *ChangeFeedInfo) VerifyAndComplete() error {
 	if info.Config.Consistent == nil {
 		info.Config.Consistent = defaultConfig.Consistent
 	}
+	if info.Config.OldValueMode == "" {
+		info.Config.OldValueMode = defaultConfig.OldValueMode
+	}
 
 	return nil
 }
@@ -307,6 +361,18 @@ func (info *ChangeFeedInfo) FixIncompatible() {
 	}
 }
 
+// MigrateChangeFeedInfo unmarshals the changefeed info persisted by an
+// earlier release and migrates its replica config to the current version.
+// It returns the descriptions of the applied migrations.
+func MigrateChangeFeedInfo(data []byte) (*ChangeFeedInfo, []string, error) {
+	info := &ChangeFeedInfo{}
+	applied, err := info.unmarshal(data)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	return info, applied, nil
+}
+
 // fixState attempts to fix state loss from upgrading the old owner to the new owner.
 func (info *ChangeFeedInfo) fixState() {
 	// Notice: In the old owner we used AdminJobType field to determine if the task was paused or not,
@@ -0,0 +1,96 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import "time"
+
+// The states of a changefeed form a state machine, a changefeed is created
+// in the normal state and transits between the states as follows:
+//
+//	normal   -> error     an error occurs, it's restarted after a backoff if it's retryable
+//	normal   -> failed    a fast fail error occurs or the restart budget is exhausted
+//	normal   -> stopped   it's paused by the user
+//	normal   -> finished  it reaches its target ts
+//	error    -> normal    it's restarted after the backoff or resumed by the user
+//	error    -> failed    a fast fail error occurs or the restart budget is exhausted
+//	error    -> stopped   it's paused by the user
+//	failed   -> normal    it's resumed by the user
+//	stopped  -> normal    it's resumed by the user
+//	stopped  -> failed    its checkpoint is garbage collected
+//	finished -> normal    it's resumed by the user with a new target ts
+//
+// A changefeed in any state can be removed, removed is the terminal state,
+// the info of a removed changefeed is deleted along with its history. The
+// info persisted without a state is in the normal state.
+var feedStateTransitions = map[FeedState][]FeedState{
+	StateNormal:   {StateError, StateFailed, StateStopped, StateFinished, StateRemoved},
+	StateError:    {StateNormal, StateFailed, StateStopped, StateRemoved},
+	StateFailed:   {StateNormal, StateRemoved},
+	StateStopped:  {StateNormal, StateFailed, StateRemoved},
+	StateFinished: {StateNormal, StateRemoved},
+}
+
+// CanTransitTo returns whether the changefeed can transit from the state to
+// the next one, staying in the same state is always allowed.
+func (s FeedState) CanTransitTo(next FeedState) bool {
+	if s == "" {
+		s = StateNormal
+	}
+	if s == next {
+		return true
+	}
+	for _, state := range feedStateTransitions[s] {
+		if state == next {
+			return true
+		}
+	}
+	return false
+}
+
+// maxStateTransitions is the max number of the transitions kept in the
+// history of a changefeed, the oldest ones are dropped first.
+const maxStateTransitions = 32
+
+// StateTransition is a transition of the state of a changefeed.
+type StateTransition struct {
+	From   FeedState `json:"from"`
+	To     FeedState `json:"to"`
+	Reason string    `json:"reason"`
+	Time   time.Time `json:"time"`
+}
+
+// RecordStateTransition changes the state of the changefeed and appends the
+// transition to its history, nothing is recorded if the state isn't changed.
+func (info *ChangeFeedInfo) RecordStateTransition(
+	state FeedState, reason string, now time.Time,
+) {
+	from := info.State
+	if from == "" {
+		from = StateNormal
+	}
+	if from == state {
+		info.State = state
+		return
+	}
+	info.StateHistory = append(info.StateHistory, &StateTransition{
+		From:   from,
+		To:     state,
+		Reason: reason,
+		Time:   now,
+	})
+	if len(info.StateHistory) > maxStateTransitions {
+		info.StateHistory = info.StateHistory[len(info.StateHistory)-maxStateTransitions:]
+	}
+	info.State = state
+}
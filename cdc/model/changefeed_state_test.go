@@ -0,0 +1,60 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeedStateCanTransitTo(t *testing.T) {
+	t.Parallel()
+
+	require.True(t, StateNormal.CanTransitTo(StateStopped))
+	require.True(t, StateStopped.CanTransitTo(StateNormal))
+	require.True(t, StateStopped.CanTransitTo(StateStopped))
+	require.True(t, StateError.CanTransitTo(StateFailed))
+	require.True(t, FeedState("").CanTransitTo(StateError))
+	require.False(t, StateStopped.CanTransitTo(StateError))
+	require.False(t, StateFinished.CanTransitTo(StateStopped))
+	require.False(t, StateFailed.CanTransitTo(StateError))
+	require.False(t, StateRemoved.CanTransitTo(StateNormal))
+}
+
+func TestRecordStateTransition(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	info := &ChangeFeedInfo{}
+	info.RecordStateTransition(StateNormal, "created", now)
+	require.Equal(t, StateNormal, info.State)
+	require.Empty(t, info.StateHistory)
+
+	info.RecordStateTransition(StateStopped, "paused", now)
+	info.RecordStateTransition(StateStopped, "paused again", now)
+	require.Equal(t, StateStopped, info.State)
+	require.Equal(t, []*StateTransition{
+		{From: StateNormal, To: StateStopped, Reason: "paused", Time: now},
+	}, info.StateHistory)
+
+	for i := 0; i < maxStateTransitions; i++ {
+		info.RecordStateTransition(StateNormal, "resumed", now)
+		info.RecordStateTransition(StateStopped, "paused", now)
+	}
+	require.Len(t, info.StateHistory, maxStateTransitions)
+	require.Equal(t, "resumed", info.StateHistory[0].Reason)
+	require.Equal(t, StateStopped, info.StateHistory[maxStateTransitions-1].To)
+}
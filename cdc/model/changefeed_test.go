@@ -130,8 +130,8 @@ func TestFillV1(t *testing.T) {
 			},
 			Sink: &config.SinkConfig{
 				DispatchRules: []*config.DispatchRule{
-					{Matcher: []string{"test.tbl3"}, DispatcherRule: "ts"},
-					{Matcher: []string{"test.tbl4"}, DispatcherRule: "rowid"},
+					{Matcher: []string{"test.tbl3"}, PartitionRule: "ts"},
+					{Matcher: []string{"test.tbl4"}, PartitionRule: "rowid"},
 				},
 			},
 		},
@@ -749,6 +749,52 @@ func TestFixMemoryQuotaIncompatible(t *testing.T) {
 	}
 }
 
+func TestMigrateChangeFeedInfo(t *testing.T) {
+	t.Parallel()
+
+	data := []byte(`{"sink-uri":"kafka://127.0.0.1:9092/test","config":{"sink":{` +
+		`"dispatchers":[{"matcher":["test.t1"],"dispatcher":"ts"}]}}}`)
+	info, applied, err := MigrateChangeFeedInfo(data)
+	require.Nil(t, err)
+	require.Equal(t, []string{
+		"v2: rename sink.dispatchers.dispatcher to sink.dispatchers.partition",
+	}, applied)
+	require.Equal(t, config.CurrentReplicaConfigVersion, info.ConfigVersion)
+	require.Equal(t, "ts", info.Config.Sink.DispatchRules[0].PartitionRule)
+
+	// The migrated info doesn't need to be migrated again.
+	migrated, err := info.Marshal()
+	require.Nil(t, err)
+	_, applied, err = MigrateChangeFeedInfo([]byte(migrated))
+	require.Nil(t, err)
+	require.Empty(t, applied)
+
+	_, _, err = MigrateChangeFeedInfo([]byte("{"))
+	require.Regexp(t, ".*ErrUnmarshalFailed.*", err)
+}
+
+func TestUnmarshalConfigVersion(t *testing.T) {
+	t.Parallel()
+
+	// The config of an earlier version is migrated from the stored version.
+	info := &ChangeFeedInfo{}
+	err := info.Unmarshal([]byte(`{"sink-uri":"blackhole://","config":{"sink":{` +
+		`"dispatchers":[{"matcher":["test.t1"],"dispatcher":"ts"}]}}}`))
+	require.Nil(t, err)
+	require.Equal(t, config.CurrentReplicaConfigVersion, info.ConfigVersion)
+	require.Equal(t, "ts", info.Config.Sink.DispatchRules[0].PartitionRule)
+	require.Empty(t, info.Config.Sink.DispatchRules[0].DispatcherRule)
+
+	// The config of the current version is not migrated again.
+	info = &ChangeFeedInfo{}
+	err = info.Unmarshal([]byte(fmt.Sprintf(`{"sink-uri":"blackhole://","config":{"sink":{`+
+		`"dispatchers":[{"matcher":["test.t1"],"dispatcher":"ts"}]}},"config-version":%d}`,
+		config.CurrentReplicaConfigVersion)))
+	require.Nil(t, err)
+	require.Equal(t, "ts", info.Config.Sink.DispatchRules[0].DispatcherRule)
+	require.Empty(t, info.Config.Sink.DispatchRules[0].PartitionRule)
+}
+
 func TestChangeFeedInfoClone(t *testing.T) {
 	t.Parallel()
 
@@ -1009,4 +1055,14 @@ func TestGetTs(t *testing.T) {
 	require.Equal(t, info.GetCheckpointTs(nil), startTs)
 	status := &ChangeFeedStatus{CheckpointTs: checkpointTs}
 	require.Equal(t, info.GetCheckpointTs(status), checkpointTs)
+
+	// Tables may start earlier than the changefeed.
+	info.Config = config.GetDefaultReplicaConfig()
+	info.Config.Filter.TableStartTs = []*config.TableStartTsRule{
+		{Matcher: []string{"test.t1"}, StartTs: startTs - 1},
+		{Matcher: []string{"test.t2"}, StartTs: startTs + 1},
+	}
+	require.Equal(t, info.GetStartTs(), startTs)
+	require.Equal(t, info.GetCheckpointTs(nil), startTs-1)
+	require.Equal(t, info.GetCheckpointTs(status), checkpointTs)
 }
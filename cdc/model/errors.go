@@ -26,6 +26,16 @@ type RunningError struct {
 	Message string `json:"message"`
 }
 
+// RunningWarning represents a kind of recoverable anomaly of a changefeed,
+// the anomalies of the same code are counted in one warning.
+type RunningWarning struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Count   uint64 `json:"count"`
+	// LastSeen is the unix time in milliseconds the anomaly is seen last.
+	LastSeen int64 `json:"last-seen"`
+}
+
 // IsChangefeedUnRetryableError return true if a running error contains a changefeed not retry error.
 func (r RunningError) IsChangefeedUnRetryableError() bool {
 	return cerror.IsChangefeedUnRetryableError(errors.New(r.Message + r.Code))
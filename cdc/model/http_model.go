@@ -147,6 +147,16 @@ type ChangefeedDetail struct {
 	ErrorHis       []int64             `json:"error_history"`
 	CreatorVersion string              `json:"creator_version"`
 	TaskStatus     []CaptureTaskStatus `json:"task_status,omitempty"`
+	// SinkSelfCheck is the result of the sink self check,
+	// it is nil if the self check is disabled or not done yet.
+	SinkSelfCheck *SinkSelfCheckResult `json:"sink_self_check,omitempty"`
+	// ConsumerGroupLag is the lag of the downstream consumer group,
+	// it is nil if no consumer group is configured.
+	ConsumerGroupLag *ConsumerGroupLag `json:"consumer_group_lag,omitempty"`
+	// Restart is the status of the automatic restarts of the changefeed.
+	Restart *RestartStatus `json:"restart,omitempty"`
+	// SubState is the sub-state of the changefeed, like sink-paused.
+	SubState string `json:"sub_state,omitempty"`
 }
 
 // MarshalJSON use to marshal ChangefeedDetail
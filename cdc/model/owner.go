@@ -16,6 +16,7 @@ package model
 import (
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/pingcap/errors"
 	timodel "github.com/pingcap/tidb/parser/model"
@@ -91,6 +92,15 @@ type TaskPosition struct {
 	//
 	// Deprecated: only used in API. TODO: remove API usage.
 	Count uint64 `json:"count"`
+	// Warnings are the recoverable anomalies of the changefeed seen by
+	// the processor.
+	Warnings []*RunningWarning `json:"warnings,omitempty"`
+	// SinkCheckpoints are the checkpoints of the sinks of the processor
+	// keyed by the sink names, it's empty if there are no extra sinks.
+	SinkCheckpoints map[string]uint64 `json:"sink-checkpoints,omitempty"`
+	// SinkPaused is set if a sink of the processor is paused because the
+	// downstream is unavailable.
+	SinkPaused bool `json:"sink-paused,omitempty"`
 
 	// Error when error happens
 	Error *RunningError `json:"error"`
@@ -121,6 +131,17 @@ func (tp *TaskPosition) Clone() *TaskPosition {
 		CheckPointTs: tp.CheckPointTs,
 		ResolvedTs:   tp.ResolvedTs,
 		Count:        tp.Count,
+		SinkPaused:   tp.SinkPaused,
+	}
+	for _, w := range tp.Warnings {
+		warning := *w
+		ret.Warnings = append(ret.Warnings, &warning)
+	}
+	if tp.SinkCheckpoints != nil {
+		ret.SinkCheckpoints = make(map[string]uint64, len(tp.SinkCheckpoints))
+		for name, ts := range tp.SinkCheckpoints {
+			ret.SinkCheckpoints[name] = ts
+		}
 	}
 	if tp.Error != nil {
 		ret.Error = &RunningError{
@@ -262,6 +283,74 @@ type ChangeFeedStatus struct {
 	ResolvedTs   uint64       `json:"resolved-ts"`
 	CheckpointTs uint64       `json:"checkpoint-ts"`
 	AdminJobType AdminJobType `json:"admin-job-type"`
+	// SinkSelfCheck is the result of the last sink self check, it is nil if
+	// the self check is not enabled.
+	SinkSelfCheck *SinkSelfCheckResult `json:"sink-self-check,omitempty"`
+	// ConsumerGroupLag is the lag of the downstream consumer group, it is
+	// only filled when the status is queried from the owner.
+	ConsumerGroupLag *ConsumerGroupLag `json:"consumer-group-lag,omitempty"`
+	// Restart is the status of the automatic restarts of the changefeed, it
+	// is only filled when the status is queried from the owner.
+	Restart *RestartStatus `json:"restart,omitempty"`
+	// SubState is the sub-state of a normal changefeed, it is only filled
+	// when the status is queried from the owner, see the SubState* values.
+	SubState string `json:"sub-state,omitempty"`
+	// Savepoints are the savepoints of the changefeed in the order of
+	// their creation, the oldest done ones are dropped when there are
+	// too many.
+	Savepoints []*Savepoint `json:"savepoints,omitempty"`
+}
+
+// SubStateSinkPaused means a sink of the changefeed is paused because the
+// downstream is unavailable, the changefeed keeps running and is resumed
+// once the downstream is available.
+const SubStateSinkPaused = "sink-paused"
+
+// RestartStatus is the status of the automatic restarts of a changefeed in
+// the error state.
+type RestartStatus struct {
+	// Restarts is the number of the restarts in the window of the restart
+	// policy.
+	Restarts int `json:"restarts"`
+	// MaxRestarts is the max number of the restarts in the window, the
+	// changefeed fails if it's exceeded. 0 means no limit.
+	MaxRestarts int `json:"max-restarts"`
+	// BackoffInterval is the interval before the next restart.
+	BackoffInterval time.Duration `json:"backoff-interval"`
+	// NextRestartTime is the time of the next restart, it's nil if the
+	// changefeed is not waiting for a restart.
+	NextRestartTime *time.Time `json:"next-restart-time,omitempty"`
+}
+
+// Savepoint is a named consistent point of a changefeed requested by the
+// user. All the data committed at or before its Ts has been written to the
+// downstream along with a marker of it once it is done.
+type Savepoint struct {
+	Name       string    `json:"name"`
+	Ts         uint64    `json:"ts"`
+	CreateTime time.Time `json:"create-time"`
+	Done       bool      `json:"done"`
+}
+
+// ConsumerGroupLag is the lag of a downstream consumer group.
+type ConsumerGroupLag struct {
+	Group string `json:"group"`
+	// Lag is the number of messages not consumed by the group in all topics.
+	Lag        int64     `json:"lag"`
+	UpdateTime time.Time `json:"update-time"`
+}
+
+// SinkSelfCheckResult is the result of a sink self check, which reads the
+// messages produced by a changefeed back from the downstream.
+type SinkSelfCheckResult struct {
+	Passed    bool      `json:"passed"`
+	CheckTime time.Time `json:"check-time"`
+	// ConsumedMessages is the number of messages read back from the downstream.
+	ConsumedMessages int `json:"consumed-messages"`
+	// ConsumerLag is the number of messages produced but not read back when
+	// the self check finished, it is the baseline of the consumer lag.
+	ConsumerLag int64  `json:"consumer-lag"`
+	Message     string `json:"message,omitempty"`
 }
 
 // Marshal returns json encoded string of ChangeFeedStatus, only contains necessary fields stored in storage
@@ -283,6 +372,36 @@ type ProcInfoSnap struct {
 	CaptureID string       `json:"capture-id"`
 }
 
+// StageLag is the replication lag of one stage of the table pipeline.
+// It records the table that lags the most behind in the stage.
+type StageLag struct {
+	TableID      TableID `json:"table-id"`
+	CheckpointTs uint64  `json:"checkpoint-ts"`
+	ResolvedTs   uint64  `json:"resolved-ts"`
+	// CheckpointLag and ResolvedTsLag are in seconds.
+	CheckpointLag float64 `json:"checkpoint-lag"`
+	ResolvedTsLag float64 `json:"resolved-ts-lag"`
+}
+
+// LagBreakdown holds the replication lag of a changefeed broken down by
+// pipeline stages, e.g. puller, sorter, mounter and sink.
+type LagBreakdown struct {
+	// CurrentTs is the PD time when the stats are collected.
+	CurrentTs uint64              `json:"current-ts"`
+	Stages    map[string]StageLag `json:"stages"`
+}
+
+// SinkStatus is the replication status of one of the sinks of a changefeed.
+type SinkStatus struct {
+	Name string `json:"name"`
+	// SinkURI is masked, sensitive infos are not exposed.
+	SinkURI string `json:"sink-uri"`
+	// CheckpointTs is the checkpoint the sink has reached, it's not resumed
+	// from, the sinks of a changefeed always restart from the checkpoint of
+	// the changefeed, which is the min one of them.
+	CheckpointTs uint64 `json:"checkpoint-ts"`
+}
+
 // TableSet maintains a set of TableID.
 type TableSet struct {
 	memo map[TableID]struct{}
@@ -29,6 +29,15 @@ const (
 	syncPointBarrier
 	// finishBarrier denotes a barrier for changefeed finished.
 	finishBarrier
+	// tableStartBarrier denotes a barrier for tables which start later
+	// than the checkpoint of the changefeed.
+	tableStartBarrier
+	// consistencyGroupBarrier denotes a barrier for changefeeds in a
+	// consistency group, which bounds the skew of their checkpoints.
+	consistencyGroupBarrier
+	// savepointBarrier denotes a barrier for the savepoints requested by
+	// the user, it's the ts of the earliest pending savepoint.
+	savepointBarrier
 )
 
 // barriers stores some barrierType and barrierTs, and can calculate the min barrierTs
@@ -79,6 +88,18 @@ func (b *barriers) calcMin() (tp barrierType, barrierTs model.Ts) {
 	return
 }
 
+// MinExcept returns the min barrierTs of the barriers except the given type,
+// math.MaxUint64 is returned if there is no other barrier.
+func (b *barriers) MinExcept(tp barrierType) model.Ts {
+	minTs := uint64(math.MaxUint64)
+	for br, ts := range b.inner {
+		if br != tp && ts < minTs {
+			minTs = ts
+		}
+	}
+	return minTs
+}
+
 func (b *barriers) Remove(tp barrierType) {
 	delete(b.inner, tp)
 	b.dirty = true
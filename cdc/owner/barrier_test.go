@@ -85,3 +85,14 @@ func TestBarrierRandom(t *testing.T) {
 		require.Equal(t, expectedBarriers[tp], expectedMinTs)
 	}
 }
+
+func TestBarrierMinExcept(t *testing.T) {
+	b := newBarriers()
+	b.Update(ddlJobBarrier, 2)
+	require.Equal(t, uint64(math.MaxUint64), b.MinExcept(ddlJobBarrier))
+
+	b.Update(syncPointBarrier, 5)
+	b.Update(finishBarrier, 4)
+	require.Equal(t, uint64(4), b.MinExcept(ddlJobBarrier))
+	require.Equal(t, uint64(2), b.MinExcept(finishBarrier))
+}
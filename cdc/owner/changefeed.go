@@ -15,6 +15,7 @@ package owner
 
 import (
 	"context"
+	"sort"
 	"sync"
 	"time"
 
@@ -73,9 +74,18 @@ type changefeed struct {
 	scheduler scheduler.Scheduler
 	// barriers will be created when a changefeed is initialized
 	// and will be destroyed when a changefeed is closed.
-	barriers         *barriers
-	feedStateManager *feedStateManager
-	redoManager      redo.LogManager
+	barriers *barriers
+	// tableStartTs is not nil if some tables start at a ts different
+	// from the start ts of the changefeed.
+	tableStartTs *tableStartTs
+	// pendingSavepoints are the savepoints whose markers are not written
+	// yet in the order of their ts.
+	pendingSavepoints []*model.Savepoint
+	// consistencyGroupBarrierTs is set by the owner before each tick if
+	// the changefeed is in a consistency group, 0 means no barrier.
+	consistencyGroupBarrierTs model.Ts
+	feedStateManager          *feedStateManager
+	redoManager               redo.LogManager
 
 	schema      *schemaWrap4Owner
 	sink        DDLSink
@@ -95,6 +105,11 @@ type changefeed struct {
 	// ddlEventCache will be set to nil. ddlEventCache contains more than
 	// one event for a rename tables DDL job.
 	ddlEventCache []*model.DDLEvent
+	// ddlBatch is not nil when the changefeed is executing a batch of DDL
+	// jobs in one sink write, the barrier is held at the first job of the
+	// batch, and the jobs are popped from the ddlPuller once all of them are
+	// executed.
+	ddlBatch []*timodel.Job
 	// currentTables is the tables that the changefeed is watching.
 	// And it contains only the tables of the ddl that have been processed.
 	// The ones that have not been executed yet do not have.
@@ -120,6 +135,24 @@ type changefeed struct {
 	metricsChangefeedBarrierTsGauge prometheus.Gauge
 	metricsChangefeedTickDuration   prometheus.Observer
 
+	metricsStatusWriteCounter    prometheus.Counter
+	metricsStatusCoalesceCounter prometheus.Counter
+
+	metricsDDLQueueDepthGauge         prometheus.Gauge
+	metricsDDLBlockedDuration         prometheus.Observer
+	metricsSkippedIneligibleDDL       prometheus.Counter
+	metricsSkippedBDRModeDDL          prometheus.Counter
+	metricsSkippedBeforeTableStartDDL prometheus.Counter
+	metricsCoalescedDDL               prometheus.Counter
+	// ddlBlockedSince is the time the front ddl job starts to wait for the
+	// checkpoint to reach its barrier ts, it is zero if no job is waiting.
+	ddlBlockedSince time.Time
+
+	// coalesceStatus is set by the owner before each tick, it is true if
+	// the tick is not a status persist round and status updates that do
+	// not reach the barrier should be coalesced into the next round.
+	coalesceStatus bool
+
 	newDDLPuller func(ctx context.Context,
 		replicaConfig *config.ReplicaConfig,
 		up *upstream.Upstream,
@@ -158,6 +191,7 @@ func newChangefeed(
 	}
 	c.newScheduler = newScheduler
 	c.cfg = cfg
+	c.feedStateManager.newDownstreamCleanup = c.newDownstreamCleanup
 	return c
 }
 
@@ -284,6 +318,8 @@ func (c *changefeed) tick(ctx cdcContext.Context, captures map[model.CaptureID]*
 	if !c.sink.isInitialized() {
 		return nil
 	}
+	// The credentials of the sink URI may be updated online.
+	c.sink.updateCredentials(c.state.Info.SinkURI)
 	// This means that the cached DDL has been executed,
 	// and we need to use the latest tables.
 	if c.currentTables == nil {
@@ -295,7 +331,18 @@ func (c *changefeed) tick(ctx cdcContext.Context, captures map[model.CaptureID]*
 		)
 	}
 	c.sink.emitCheckpointTs(checkpointTs, c.currentTables)
+	// The changefeed is not replicating until the sink self check is done.
+	selfCheckResult, selfCheckDone := c.sink.selfCheck()
+	if selfCheckResult != nil {
+		c.updateSinkSelfCheck(selfCheckResult)
+	}
+	if !selfCheckDone {
+		return nil
+	}
 
+	if c.state.Info.Config.ConsistencyGroup != nil && c.consistencyGroupBarrierTs != 0 {
+		c.barriers.Update(consistencyGroupBarrier, c.consistencyGroupBarrierTs)
+	}
 	barrierTs, err := c.handleBarrier(ctx)
 	if err != nil {
 		return errors.Trace(err)
@@ -313,9 +360,17 @@ func (c *changefeed) tick(ctx cdcContext.Context, captures map[model.CaptureID]*
 		return nil
 	}
 
+	// Tables which start later than the checkpoint are not scheduled,
+	// they are added once the checkpoint reaches their start ts.
+	allPhysicalTables := c.schema.AllPhysicalTables()
+	if c.tableStartTs != nil && !c.tableStartTs.allStartedAt(c.state.Status.CheckpointTs) {
+		allPhysicalTables = c.schema.PhysicalTablesStartedAt(
+			c.state.Status.CheckpointTs, c.tableStartTs.get)
+	}
+
 	startTime := time.Now()
 	newCheckpointTs, newResolvedTs, err := c.scheduler.Tick(
-		ctx, c.state.Status.CheckpointTs, c.schema.AllPhysicalTables(), captures)
+		ctx, c.state.Status.CheckpointTs, allPhysicalTables, captures)
 	// metricsResolvedTs to store the min resolved ts among all tables and show it in metrics
 	metricsResolvedTs := newResolvedTs
 	costTime := time.Since(startTime)
@@ -403,7 +458,7 @@ func (c *changefeed) tick(ctx cdcContext.Context, captures map[model.CaptureID]*
 		}
 	})
 
-	c.updateStatus(newCheckpointTs, newResolvedTs)
+	c.updateStatus(newCheckpointTs, newResolvedTs, barrierTs)
 	c.updateMetrics(currentTs, newCheckpointTs, metricsResolvedTs)
 
 	return nil
@@ -493,6 +548,41 @@ LOOP:
 	}
 	c.barriers.Update(ddlJobBarrier, ddlStartTs)
 	c.barriers.Update(finishBarrier, c.state.Info.GetTargetTs())
+	c.tableStartTs, err = newTableStartTs(c.state.Info)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if c.tableStartTs != nil && !c.tableStartTs.allStartedAt(checkpointTs) {
+		c.barriers.Update(tableStartBarrier, c.tableStartTs.nextBarrierTs(checkpointTs))
+	}
+	if c.state.Info.Config.ConsistencyGroup != nil {
+		// Hold the changefeed at its checkpoint until the owner
+		// calculates the barrier of the consistency group.
+		c.barriers.Update(consistencyGroupBarrier, checkpointTs)
+	}
+	c.pendingSavepoints = nil
+	for _, savepoint := range c.state.Status.Savepoints {
+		if savepoint.Done {
+			continue
+		}
+		if savepoint.Ts < checkpointTs {
+			// It should not happen since the changefeed is held at the
+			// savepoint, the barrier can not fall behind the checkpoint.
+			log.Warn("savepoint is behind the checkpoint, skip it",
+				zap.String("namespace", c.id.Namespace),
+				zap.String("changefeed", c.id.ID),
+				zap.String("savepoint", savepoint.Name),
+				zap.Uint64("ts", savepoint.Ts),
+				zap.Uint64("checkpointTs", checkpointTs))
+			continue
+		}
+		sp := *savepoint
+		c.pendingSavepoints = append(c.pendingSavepoints, &sp)
+	}
+	sort.SliceStable(c.pendingSavepoints, func(i, j int) bool {
+		return c.pendingSavepoints[i].Ts < c.pendingSavepoints[j].Ts
+	})
+	c.updateSavepointBarrier()
 
 	c.schema, err = newSchemaWrap4Owner(c.upstream.KVStorage, ddlStartTs, c.state.Info.Config, c.id)
 	if err != nil {
@@ -503,6 +593,7 @@ LOOP:
 	// otherwise, the changefeed will loss tables that are needed to be replicated
 	// ref: https://github.com/pingcap/tiflow/issues/7682
 	c.ddlEventCache = nil
+	c.ddlBatch = nil
 	c.currentTables = nil
 
 	cancelCtx, cancel := cdcContext.WithCancel(ctx)
@@ -520,7 +611,7 @@ LOOP:
 	)
 
 	c.sink = c.newSink(c.id, c.state.Info, ctx.Throw)
-	c.sink.run(cancelCtx)
+	c.sink.run(contextutil.PutInitQueueInCtx(cancelCtx, ctx.GlobalVars().InitQueue))
 
 	c.ddlPuller, err = c.newDDLPuller(cancelCtx, c.state.Info.Config, c.upstream, ddlStartTs, c.id)
 	if err != nil {
@@ -591,6 +682,23 @@ func (c *changefeed) initMetrics() {
 		WithLabelValues(c.id.Namespace, c.id.ID)
 	c.metricsChangefeedTickDuration = changefeedTickDuration.
 		WithLabelValues(c.id.Namespace, c.id.ID)
+	c.metricsStatusWriteCounter = changefeedStatusPersistCounter.
+		WithLabelValues(c.id.Namespace, c.id.ID, statusPersistTypeWrite)
+	c.metricsStatusCoalesceCounter = changefeedStatusPersistCounter.
+		WithLabelValues(c.id.Namespace, c.id.ID, statusPersistTypeCoalesce)
+
+	c.metricsDDLQueueDepthGauge = changefeedDDLQueueDepthGauge.
+		WithLabelValues(c.id.Namespace, c.id.ID)
+	c.metricsDDLBlockedDuration = changefeedDDLBlockedDuration.
+		WithLabelValues(c.id.Namespace, c.id.ID)
+	c.metricsSkippedIneligibleDDL = changefeedSkippedDDLEventCounter.
+		WithLabelValues(c.id.Namespace, c.id.ID, skippedDDLReasonIneligible)
+	c.metricsSkippedBDRModeDDL = changefeedSkippedDDLEventCounter.
+		WithLabelValues(c.id.Namespace, c.id.ID, skippedDDLReasonBDRMode)
+	c.metricsSkippedBeforeTableStartDDL = changefeedSkippedDDLEventCounter.
+		WithLabelValues(c.id.Namespace, c.id.ID, skippedDDLReasonBeforeTableStart)
+	c.metricsCoalescedDDL = changefeedCoalescedDDLCounter.
+		WithLabelValues(c.id.Namespace, c.id.ID)
 }
 
 // releaseResources is idempotent.
@@ -632,6 +740,8 @@ func (c *changefeed) releaseResources(ctx cdcContext.Context) {
 	c.cleanupMetrics()
 	c.schema = nil
 	c.barriers = nil
+	c.tableStartTs = nil
+	c.pendingSavepoints = nil
 	c.initialized = false
 	c.isReleased = true
 
@@ -665,6 +775,47 @@ func (c *changefeed) cleanupMetrics() {
 
 	changefeedBarrierTsGauge.DeleteLabelValues(c.id.Namespace, c.id.ID)
 	c.metricsChangefeedBarrierTsGauge = nil
+
+	changefeedStatusPersistCounter.DeleteLabelValues(
+		c.id.Namespace, c.id.ID, statusPersistTypeWrite)
+	changefeedStatusPersistCounter.DeleteLabelValues(
+		c.id.Namespace, c.id.ID, statusPersistTypeCoalesce)
+	c.metricsStatusWriteCounter = nil
+	c.metricsStatusCoalesceCounter = nil
+
+	changefeedDDLQueueDepthGauge.DeleteLabelValues(c.id.Namespace, c.id.ID)
+	changefeedDDLBlockedDuration.DeleteLabelValues(c.id.Namespace, c.id.ID)
+	changefeedSkippedDDLEventCounter.DeleteLabelValues(
+		c.id.Namespace, c.id.ID, skippedDDLReasonIneligible)
+	changefeedSkippedDDLEventCounter.DeleteLabelValues(
+		c.id.Namespace, c.id.ID, skippedDDLReasonBDRMode)
+	changefeedSkippedDDLEventCounter.DeleteLabelValues(
+		c.id.Namespace, c.id.ID, skippedDDLReasonBeforeTableStart)
+	changefeedSkippedDDLEventCounter.DeleteLabelValues(
+		c.id.Namespace, c.id.ID, skippedDDLReasonFiltered)
+	c.metricsDDLQueueDepthGauge = nil
+	c.metricsDDLBlockedDuration = nil
+	c.metricsSkippedIneligibleDDL = nil
+	c.metricsSkippedBDRModeDDL = nil
+	c.metricsSkippedBeforeTableStartDDL = nil
+	changefeedCoalescedDDLCounter.DeleteLabelValues(c.id.Namespace, c.id.ID)
+	c.metricsCoalescedDDL = nil
+	c.ddlBlockedSince = time.Time{}
+}
+
+// newDownstreamCleanup returns the cleanup of the data written by the
+// changefeed to its sink, which is run before the removal of the changefeed
+// if it's enabled by the lifecycle hooks.
+func (c *changefeed) newDownstreamCleanup(
+	info *model.ChangeFeedInfo,
+) func(ctx context.Context) error {
+	cloned, cloneErr := info.Clone()
+	return func(ctx context.Context) error {
+		if cloneErr != nil {
+			return errors.Trace(cloneErr)
+		}
+		return cleanupDownstream(ctx, c.id, cloned)
+	}
 }
 
 // redoManagerCleanup cleanups redo logs if changefeed is removed and redo log is enabled
@@ -762,6 +913,7 @@ func (c *changefeed) handleBarrier(ctx cdcContext.Context) (uint64, error) {
 	barrierTp, barrierTs := c.barriers.Min()
 
 	c.metricsChangefeedBarrierTsGauge.Set(float64(oracle.ExtractPhysical(barrierTs)))
+	c.metricsDDLQueueDepthGauge.Set(float64(c.ddlPuller.PendingDDLCount()))
 
 	// It means:
 	//   1. All data before the barrierTs was sent to downstream.
@@ -774,6 +926,9 @@ func (c *changefeed) handleBarrier(ctx cdcContext.Context) (uint64, error) {
 
 	switch barrierTp {
 	case ddlJobBarrier:
+		if c.ddlBatch != nil {
+			return c.execDDLBatch(ctx, barrierTs)
+		}
 		ddlResolvedTs, ddlJob := c.ddlPuller.FrontDDL()
 		// ddlJob is nil means there is no ddl job in the queue
 		// and the ddlResolvedTs is updated by resolvedTs event.
@@ -806,8 +961,20 @@ func (c *changefeed) handleBarrier(ctx cdcContext.Context) (uint64, error) {
 		// [dml-1(ts=5), dml-2(ts=8), ddl-1(ts=11), ddl-2(ts=12)].
 		// We need to wait `checkpointTs == ddlResolvedTs(ts=11)` before execute ddl-1.
 		if !checkpointReachBarrier {
+			if c.ddlBlockedSince.IsZero() {
+				c.ddlBlockedSince = time.Now()
+			}
 			return barrierTs, nil
 		}
+		if !c.ddlBlockedSince.IsZero() {
+			c.metricsDDLBlockedDuration.Observe(time.Since(c.ddlBlockedSince).Seconds())
+			c.ddlBlockedSince = time.Time{}
+		}
+
+		if batch := c.batchDDLJobs(); len(batch) > 1 {
+			c.ddlBatch = batch
+			return c.execDDLBatch(ctx, barrierTs)
+		}
 
 		done, err := c.asyncExecDDLJob(ctx, ddlJob)
 		if err != nil {
@@ -827,11 +994,43 @@ func (c *changefeed) handleBarrier(ctx cdcContext.Context) (uint64, error) {
 		if !fullyBlocked {
 			return barrierTs, nil
 		}
-		nextSyncPointTs := oracle.GoTimeToTS(oracle.GetTimeFromTS(barrierTs).Add(c.state.Info.Config.SyncPointInterval))
+		nextSyncPointTs := pdutil.AddDurationToTs(barrierTs, c.state.Info.Config.SyncPointInterval)
 		if err := c.sink.emitSyncPoint(ctx, barrierTs); err != nil {
 			return 0, errors.Trace(err)
 		}
 		c.barriers.Update(syncPointBarrier, nextSyncPointTs)
+	case tableStartBarrier:
+		if !checkpointReachBarrier {
+			return barrierTs, nil
+		}
+		// All data before the barrierTs has been sent to downstream,
+		// the tables start at the barrierTs can be scheduled now.
+		if c.tableStartTs.allStartedAt(barrierTs) {
+			c.barriers.Remove(tableStartBarrier)
+		} else {
+			c.barriers.Update(tableStartBarrier, c.tableStartTs.nextBarrierTs(barrierTs))
+		}
+	case consistencyGroupBarrier:
+		// The barrier is advanced by the owner once the other
+		// changefeeds in the group catch up.
+		return barrierTs, nil
+	case savepointBarrier:
+		if !fullyBlocked {
+			return barrierTs, nil
+		}
+		// All data before and at the barrierTs has been sent to downstream,
+		// and no more data after it, the marker of the savepoint can be written.
+		savepoint := c.pendingSavepoints[0]
+		done, err := c.sink.emitSavepoint(ctx, savepoint)
+		if err != nil {
+			return 0, errors.Trace(err)
+		}
+		if !done {
+			return barrierTs, nil
+		}
+		c.pendingSavepoints = c.pendingSavepoints[1:]
+		c.finishSavepoint(savepoint.Name)
+		c.updateSavepointBarrier()
 	case finishBarrier:
 		if fullyBlocked {
 			c.feedStateManager.MarkFinished()
@@ -843,11 +1042,191 @@ func (c *changefeed) handleBarrier(ctx cdcContext.Context) (uint64, error) {
 	return barrierTs, nil
 }
 
+// maxSavepoints is the max number of the savepoints kept in the status of
+// a changefeed, and of the pending ones.
+const maxSavepoints = 16
+
+// createSavepoint picks the current barrier ts of the changefeed as the ts
+// of the savepoint, the changefeed is held at it until the marker of the
+// savepoint is written to the downstream.
+func (c *changefeed) createSavepoint(savepoint *model.Savepoint) error {
+	if !c.initialized || c.state.Status == nil {
+		return cerror.ErrSavepointNotAllowed.GenWithStackByArgs(
+			"the changefeed is not running")
+	}
+	if len(c.pendingSavepoints) >= maxSavepoints {
+		return cerror.ErrSavepointNotAllowed.GenWithStackByArgs(
+			"too many pending savepoints")
+	}
+	for _, sp := range c.state.Status.Savepoints {
+		if sp.Name == savepoint.Name {
+			return cerror.ErrSavepointAlreadyExists.GenWithStackByArgs(savepoint.Name)
+		}
+	}
+	for _, sp := range c.pendingSavepoints {
+		if sp.Name == savepoint.Name {
+			return cerror.ErrSavepointAlreadyExists.GenWithStackByArgs(savepoint.Name)
+		}
+	}
+
+	// The barrier ts never falls back, and the data after it has not been
+	// sent to downstream yet.
+	_, savepoint.Ts = c.barriers.Min()
+	savepoint.CreateTime = time.Now()
+	savepoint.Done = false
+	sp := *savepoint
+	c.pendingSavepoints = append(c.pendingSavepoints, &sp)
+	sort.SliceStable(c.pendingSavepoints, func(i, j int) bool {
+		return c.pendingSavepoints[i].Ts < c.pendingSavepoints[j].Ts
+	})
+	c.updateSavepointBarrier()
+
+	c.state.PatchStatus(func(status *model.ChangeFeedStatus) (*model.ChangeFeedStatus, bool, error) {
+		if status == nil {
+			return nil, false, nil
+		}
+		sp := *savepoint
+		status.Savepoints = append(status.Savepoints, &sp)
+		// Drop the oldest done savepoints if there are too many.
+		excess := len(status.Savepoints) - maxSavepoints
+		savepoints := status.Savepoints[:0]
+		for _, sp := range status.Savepoints {
+			if excess > 0 && sp.Done {
+				excess--
+				continue
+			}
+			savepoints = append(savepoints, sp)
+		}
+		status.Savepoints = savepoints
+		return status, true, nil
+	})
+	log.Info("savepoint is created",
+		zap.String("namespace", c.id.Namespace),
+		zap.String("changefeed", c.id.ID),
+		zap.String("savepoint", savepoint.Name),
+		zap.Uint64("ts", savepoint.Ts))
+	return nil
+}
+
+// finishSavepoint marks the savepoint as done in the changefeed status.
+func (c *changefeed) finishSavepoint(name string) {
+	c.state.PatchStatus(func(status *model.ChangeFeedStatus) (*model.ChangeFeedStatus, bool, error) {
+		if status == nil {
+			return nil, false, nil
+		}
+		for _, savepoint := range status.Savepoints {
+			if savepoint.Name == name && !savepoint.Done {
+				savepoint.Done = true
+				return status, true, nil
+			}
+		}
+		return status, false, nil
+	})
+	log.Info("savepoint is done",
+		zap.String("namespace", c.id.Namespace),
+		zap.String("changefeed", c.id.ID),
+		zap.String("savepoint", name))
+}
+
+// updateSavepointBarrier sets the savepoint barrier to the ts of the
+// earliest pending savepoint, it's removed if there is no one.
+func (c *changefeed) updateSavepointBarrier() {
+	if len(c.pendingSavepoints) == 0 {
+		c.barriers.Remove(savepointBarrier)
+		return
+	}
+	c.barriers.Update(savepointBarrier, c.pendingSavepoints[0].Ts)
+}
+
+// maxConcurrentDDLJobs is the max number of the DDL jobs executed together
+// by a concurrent sink if ddl-coalesce is not configured.
+const maxConcurrentDDLJobs = 64
+
+// earlyExecutableDDLActions are the DDL actions after which the DML events
+// of the old schema can still be written, so that they can be executed
+// ahead of the DML events committed before them.
+var earlyExecutableDDLActions = map[timodel.ActionType]struct{}{
+	timodel.ActionAddColumn:            {},
+	timodel.ActionAddColumns:           {},
+	timodel.ActionAddTablePartition:    {},
+	timodel.ActionAddIndex:             {},
+	timodel.ActionRenameIndex:          {},
+	timodel.ActionAlterIndexVisibility: {},
+	timodel.ActionModifyTableComment:   {},
+}
+
+// batchDDLJobs returns the pending DDL jobs which are executed together with
+// the front one in one sink write, it's nil unless ddl-coalesce is configured
+// or the sink executes the DDLs on independent tables concurrently.
+// The barrier is held at the front job, so the later ones are executed before
+// the DML events committed ahead of them, they must be early executable and
+// none of them is beyond the other barriers of the changefeed.
+func (c *changefeed) batchDDLJobs() []*timodel.Job {
+	cfg := c.state.Info.Config.DDLCoalesce
+	if cfg == nil && !c.sink.isConcurrent() {
+		return nil
+	}
+	maxBatchSize := maxConcurrentDDLJobs
+	if cfg != nil {
+		maxBatchSize = cfg.MaxBatchSize
+	}
+	jobs := c.ddlPuller.FrontDDLs(maxBatchSize)
+	if len(jobs) == 0 || jobs[0].BinlogInfo == nil {
+		return nil
+	}
+	limitTs := c.barriers.MinExcept(ddlJobBarrier)
+	if cfg != nil {
+		maxTs := pdutil.AddDurationToTs(jobs[0].BinlogInfo.FinishedTS, cfg.MaxBatchInterval)
+		if maxTs < limitTs {
+			limitTs = maxTs + 1
+		}
+	}
+	n := 1
+	for ; n < len(jobs); n++ {
+		job := jobs[n]
+		if !isEarlyExecutableDDLJob(job) || job.BinlogInfo.FinishedTS >= limitTs {
+			break
+		}
+	}
+	return jobs[:n]
+}
+
+func isEarlyExecutableDDLJob(job *timodel.Job) bool {
+	if job.BinlogInfo == nil {
+		return false
+	}
+	_, ok := earlyExecutableDDLActions[job.Type]
+	return ok
+}
+
+// execDDLBatch executes the DDL jobs chosen by batchDDLJobs in one sink
+// write at the barrier of the first job, and pops them once all of them are
+// executed.
+func (c *changefeed) execDDLBatch(ctx cdcContext.Context, barrierTs model.Ts) (uint64, error) {
+	done, err := c.asyncExecDDLJobs(ctx, c.ddlBatch)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	if !done {
+		return barrierTs, nil
+	}
+	for range c.ddlBatch {
+		c.lastDDLTs, _ = c.ddlPuller.PopFrontDDL()
+	}
+	c.metricsCoalescedDDL.Add(float64(len(c.ddlBatch) - 1))
+	log.Info("ddl jobs executed in a batch",
+		zap.String("namespace", c.id.Namespace),
+		zap.String("changefeed", c.id.ID),
+		zap.Int("count", len(c.ddlBatch)),
+		zap.Uint64("barrierTs", barrierTs),
+		zap.Uint64("lastDDLTs", c.lastDDLTs))
+	c.ddlBatch = nil
+	newDDLResolvedTs, _ := c.ddlPuller.FrontDDL()
+	c.barriers.Update(ddlJobBarrier, newDDLResolvedTs)
+	return barrierTs, nil
+}
+
 // asyncExecDDLJob execute ddl job asynchronously, it returns true if the jod is done.
-// 0. Build ddl events from job.
-// 1. Apply ddl job to c.schema.
-// 2. Emit ddl event to redo manager.
-// 3. Emit ddl event to ddl sink.
 func (c *changefeed) asyncExecDDLJob(ctx cdcContext.Context,
 	job *timodel.Job,
 ) (bool, error) {
@@ -856,34 +1235,49 @@ func (c *changefeed) asyncExecDDLJob(ctx cdcContext.Context,
 			zap.Any("job", job))
 		return true, nil
 	}
+	return c.asyncExecDDLJobs(ctx, []*timodel.Job{job})
+}
 
+// asyncExecDDLJobs execute ddl jobs asynchronously, it returns true if all the
+// jobs are done.
+// 0. Build ddl events from jobs.
+// 1. Apply ddl jobs to c.schema.
+// 2. Emit ddl events to redo manager.
+// 3. Emit ddl events to ddl sink.
+func (c *changefeed) asyncExecDDLJobs(ctx cdcContext.Context,
+	jobs []*timodel.Job,
+) (bool, error) {
 	if c.ddlEventCache == nil {
-		// We must build ddl events from job before we call c.schema.HandleDDL(job).
-		ddlEvents, err := c.schema.BuildDDLEvents(job)
-		if err != nil {
-			log.Error("build DDL event fail", zap.String("changefeed", c.id.ID),
-				zap.Any("job", job), zap.Error(err))
-			return false, errors.Trace(err)
-		}
-		c.ddlEventCache = ddlEvents
 		// We can't use the latest schema directly,
 		// we need to make sure we receive the ddl before we start or stop broadcasting checkpoint ts.
 		// So let's remember the tables before processing and cache the DDL.
-		c.currentTables = c.schema.AllTables()
+		currentTables := c.schema.AllTables()
+		ddlEvents := make([]*model.DDLEvent, 0, len(jobs))
+		for _, job := range jobs {
+			// We must build ddl events from job before we call c.schema.HandleDDL(job).
+			events, err := c.schema.BuildDDLEvents(job)
+			if err != nil {
+				log.Error("build DDL event fail", zap.String("changefeed", c.id.ID),
+					zap.Any("job", job), zap.Error(err))
+				return false, errors.Trace(err)
+			}
+			// we apply ddl to update changefeed schema here.
+			if err := c.schema.HandleDDL(job); err != nil {
+				return false, errors.Trace(err)
+			}
+			ddlEvents = append(ddlEvents, events...)
+		}
+		c.ddlEventCache = ddlEvents
+		c.currentTables = currentTables
 		checkpointTs := c.state.Status.CheckpointTs
 		// refresh checkpointTs and currentTables when a ddl job is received
 		c.sink.emitCheckpointTs(checkpointTs, c.currentTables)
-		// we apply ddl to update changefeed schema here.
-		err = c.schema.HandleDDL(job)
-		if err != nil {
-			return false, errors.Trace(err)
-		}
 		if c.redoManager.Enabled() {
 			for _, ddlEvent := range c.ddlEventCache {
 				// FIXME: seems it's not necessary to emit DDL to redo storage,
 				// because for a given redo meta with range (checkpointTs, resolvedTs],
 				// there must be no pending DDLs not flushed into DDL sink.
-				err = c.redoManager.EmitDDLEvent(ctx, ddlEvent)
+				err := c.redoManager.EmitDDLEvent(ctx, ddlEvent)
 				if err != nil {
 					return false, err
 				}
@@ -891,16 +1285,27 @@ func (c *changefeed) asyncExecDDLJob(ctx cdcContext.Context,
 		}
 	}
 
-	jobDone := true
-	for _, event := range c.ddlEventCache {
-		eventDone, err := c.asyncExecDDLEvent(ctx, event)
-		if err != nil {
-			return false, err
-		}
-		jobDone = jobDone && eventDone
+	// The events of the jobs, and of a job like `create tables` and `rename
+	// tables`, are emitted together, so that the ones on independent tables
+	// can be executed concurrently if the sink supports.
+	jobDone, err := c.asyncExecDDLEvents(ctx, c.ddlEventCache)
+	if err != nil {
+		return false, err
 	}
 
 	if jobDone {
+		// Events are executed repeatedly until the whole job is done,
+		// so the skipped ones are counted only once here.
+		for _, event := range c.ddlEventCache {
+			switch c.ddlSkipReason(event) {
+			case skippedDDLReasonIneligible:
+				c.metricsSkippedIneligibleDDL.Inc()
+			case skippedDDLReasonBDRMode:
+				c.metricsSkippedBDRModeDDL.Inc()
+			case skippedDDLReasonBeforeTableStart:
+				c.metricsSkippedBeforeTableStartDDL.Inc()
+			}
+		}
 		c.ddlEventCache = nil
 		// It has expired.
 		// We should use the latest table names now.
@@ -910,25 +1315,33 @@ func (c *changefeed) asyncExecDDLJob(ctx cdcContext.Context,
 	return jobDone, nil
 }
 
-func (c *changefeed) asyncExecDDLEvent(ctx cdcContext.Context,
-	ddlEvent *model.DDLEvent,
+func (c *changefeed) asyncExecDDLEvents(ctx cdcContext.Context,
+	ddlEvents []*model.DDLEvent,
 ) (done bool, err error) {
-	if ddlEvent.TableInfo != nil &&
-		c.schema.IsIneligibleTableID(ddlEvent.TableInfo.TableName.TableID) {
-		log.Warn("ignore the DDL event of ineligible table",
-			zap.String("changefeed", c.id.ID), zap.Any("event", ddlEvent))
-		return true, nil
+	events := make([]*model.DDLEvent, 0, len(ddlEvents))
+	for _, ddlEvent := range ddlEvents {
+		switch c.ddlSkipReason(ddlEvent) {
+		case skippedDDLReasonIneligible:
+			log.Warn("ignore the DDL event of ineligible table",
+				zap.String("changefeed", c.id.ID), zap.Any("event", ddlEvent))
+		case skippedDDLReasonBDRMode:
+			log.Info("ignore the DDL event in BDR mode",
+				zap.String("changefeed", c.id.ID),
+				zap.Any("ddl", ddlEvent.Query))
+		case skippedDDLReasonBeforeTableStart:
+			log.Info("ignore the DDL event committed before the start ts of the table",
+				zap.String("changefeed", c.id.ID),
+				zap.Uint64("commitTs", ddlEvent.CommitTs),
+				zap.Any("ddl", ddlEvent.Query))
+		default:
+			events = append(events, ddlEvent)
+		}
 	}
-
-	// check whether in bdr mode, if so, we need to skip all DDLs
-	if c.state.Info.Config.BDRMode {
-		log.Info("ignore the DDL event in BDR mode",
-			zap.String("changefeed", c.id.ID),
-			zap.Any("ddl", ddlEvent.Query))
+	if len(events) == 0 {
 		return true, nil
 	}
 
-	done, err = c.sink.emitDDLEvent(ctx, ddlEvent)
+	done, err = c.sink.emitDDLEvents(ctx, events)
 	if err != nil {
 		return false, err
 	}
@@ -936,6 +1349,30 @@ func (c *changefeed) asyncExecDDLEvent(ctx cdcContext.Context,
 	return done, nil
 }
 
+// ddlSkipReason returns the reason why the DDL event is not sent to
+// the sink, an empty string is returned if the event should be sent.
+// The DDLs dropped by the event filter never reach here, they are counted
+// as skippedDDLReasonFiltered when the schema builds the DDL events.
+func (c *changefeed) ddlSkipReason(ddlEvent *model.DDLEvent) string {
+	if ddlEvent.TableInfo != nil &&
+		c.schema.IsIneligibleTableID(ddlEvent.TableInfo.TableName.TableID) {
+		return skippedDDLReasonIneligible
+	}
+	// check whether in bdr mode, if so, we need to skip all DDLs
+	if c.state.Info.Config.BDRMode {
+		return skippedDDLReasonBDRMode
+	}
+	// The table starts at or after the DDL, the DDL is already applied to
+	// the table downstream.
+	if c.tableStartTs != nil && ddlEvent.TableInfo != nil &&
+		ddlEvent.TableInfo.TableName.Table != "" &&
+		ddlEvent.CommitTs <= c.tableStartTs.get(
+			ddlEvent.TableInfo.TableName.Schema, ddlEvent.TableInfo.TableName.Table) {
+		return skippedDDLReasonBeforeTableStart
+	}
+	return ""
+}
+
 func (c *changefeed) updateMetrics(currentTs int64, checkpointTs, resolvedTs model.Ts) {
 	phyCkpTs := oracle.ExtractPhysical(checkpointTs)
 	c.metricsChangefeedCheckpointTsGauge.Set(float64(phyCkpTs))
@@ -954,7 +1391,22 @@ func (c *changefeed) updateMetrics(currentTs int64, checkpointTs, resolvedTs mod
 	c.metricsCurrentPDTsGauge.Set(float64(currentTs))
 }
 
-func (c *changefeed) updateStatus(checkpointTs, resolvedTs model.Ts) {
+// updateStatus patches the changefeed status into etcd. To reduce etcd
+// writes, updates are coalesced until the next status persist round of the
+// owner unless the checkpoint reaches the barrier, because barriers are
+// handled based on the persisted status and delaying it would delay DDLs
+// and syncpoints.
+func (c *changefeed) updateStatus(checkpointTs, resolvedTs, barrierTs model.Ts) {
+	status := c.state.Status
+	if status != nil && status.CheckpointTs == checkpointTs && status.ResolvedTs == resolvedTs {
+		return
+	}
+	reachBarrier := checkpointTs == barrierTs
+	if c.coalesceStatus && !reachBarrier {
+		c.metricsStatusCoalesceCounter.Inc()
+		return
+	}
+	c.metricsStatusWriteCounter.Inc()
 	c.state.PatchStatus(func(status *model.ChangeFeedStatus) (*model.ChangeFeedStatus, bool, error) {
 		changed := false
 		if status == nil {
@@ -972,6 +1424,23 @@ func (c *changefeed) updateStatus(checkpointTs, resolvedTs model.Ts) {
 	})
 }
 
+// updateSinkSelfCheck records the result of the sink self check in the
+// changefeed status.
+func (c *changefeed) updateSinkSelfCheck(result *model.SinkSelfCheckResult) {
+	status := c.state.Status
+	if status == nil || (status.SinkSelfCheck != nil &&
+		status.SinkSelfCheck.CheckTime.Equal(result.CheckTime)) {
+		return
+	}
+	c.state.PatchStatus(func(status *model.ChangeFeedStatus) (*model.ChangeFeedStatus, bool, error) {
+		if status == nil {
+			return nil, false, nil
+		}
+		status.SinkSelfCheck = result
+		return status, true, nil
+	})
+}
+
 func (c *changefeed) Close(ctx cdcContext.Context) {
 	startTime := time.Now()
 	c.releaseResources(ctx)
@@ -33,8 +33,10 @@ import (
 	"github.com/pingcap/tiflow/cdc/scheduler"
 	"github.com/pingcap/tiflow/pkg/config"
 	cdcContext "github.com/pingcap/tiflow/pkg/context"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
 	"github.com/pingcap/tiflow/pkg/etcd"
 	"github.com/pingcap/tiflow/pkg/orchestrator"
+	"github.com/pingcap/tiflow/pkg/pdutil"
 	"github.com/pingcap/tiflow/pkg/txnutil/gc"
 	"github.com/pingcap/tiflow/pkg/upstream"
 	"github.com/stretchr/testify/require"
@@ -63,6 +65,17 @@ func (m *mockDDLPuller) PopFrontDDL() (uint64, *timodel.Job) {
 	return m.resolvedTs, nil
 }
 
+func (m *mockDDLPuller) PendingDDLCount() int {
+	return len(m.ddlQueue)
+}
+
+func (m *mockDDLPuller) FrontDDLs(limit int) []*timodel.Job {
+	if limit > len(m.ddlQueue) {
+		limit = len(m.ddlQueue)
+	}
+	return m.ddlQueue[:limit]
+}
+
 func (m *mockDDLPuller) Close() {}
 
 func (m *mockDDLPuller) Run(ctx context.Context) error {
@@ -80,6 +93,8 @@ type mockDDLSink struct {
 	recordDDLHistory bool
 	// a slice of DDL history, only for rename table
 	ddlHistory []string
+	// whether the DDL events emitted together are executed concurrently
+	concurrent bool
 	mu         struct {
 		sync.Mutex
 		checkpointTs  model.Ts
@@ -87,6 +102,7 @@ type mockDDLSink struct {
 	}
 	syncPoint    model.Ts
 	syncPointHis []model.Ts
+	savepointHis []string
 
 	wg sync.WaitGroup
 }
@@ -114,6 +130,22 @@ func (m *mockDDLSink) emitDDLEvent(ctx context.Context, ddl *model.DDLEvent) (bo
 	return m.ddlDone, nil
 }
 
+func (m *mockDDLSink) emitDDLEvents(ctx context.Context, ddls []*model.DDLEvent) (bool, error) {
+	done := true
+	for _, ddl := range ddls {
+		ddlDone, err := m.emitDDLEvent(ctx, ddl)
+		if err != nil {
+			return false, err
+		}
+		done = done && ddlDone
+	}
+	return done, nil
+}
+
+func (m *mockDDLSink) isConcurrent() bool {
+	return m.concurrent
+}
+
 func (m *mockDDLSink) emitSyncPoint(ctx context.Context, checkpointTs uint64) error {
 	if checkpointTs == m.syncPoint {
 		return nil
@@ -123,6 +155,11 @@ func (m *mockDDLSink) emitSyncPoint(ctx context.Context, checkpointTs uint64) er
 	return nil
 }
 
+func (m *mockDDLSink) emitSavepoint(ctx context.Context, savepoint *model.Savepoint) (bool, error) {
+	m.savepointHis = append(m.savepointHis, savepoint.Name)
+	return true, nil
+}
+
 func (m *mockDDLSink) emitCheckpointTs(ts uint64, tables []*model.TableInfo) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -145,6 +182,16 @@ func (m *mockDDLSink) isInitialized() bool {
 	return true
 }
 
+func (m *mockDDLSink) selfCheck() (*model.SinkSelfCheckResult, bool) {
+	return nil, true
+}
+
+func (m *mockDDLSink) consumerGroupLag() *model.ConsumerGroupLag {
+	return nil
+}
+
+func (m *mockDDLSink) updateCredentials(sinkURI string) {}
+
 func (m *mockDDLSink) Barrier(ctx context.Context) error {
 	return nil
 }
@@ -264,6 +311,40 @@ func TestInitialize(t *testing.T) {
 	require.Equal(t, cf.state.Status.CheckpointTs, ctx.ChangefeedVars().Info.StartTs)
 }
 
+func TestUpdateStatusCoalesce(t *testing.T) {
+	ctx := cdcContext.NewBackendContext4Test(true)
+	cf, captures, tester := createChangefeed4Test(ctx, t)
+	defer cf.Close(ctx)
+	// pre check
+	cf.Tick(ctx, captures)
+	tester.MustApplyPatches()
+	// initialize
+	cf.Tick(ctx, captures)
+	tester.MustApplyPatches()
+
+	startTs := ctx.ChangefeedVars().Info.StartTs
+	barrierTs := startTs + 100
+	cf.coalesceStatus = true
+
+	// Updates out of a persist round are coalesced.
+	cf.updateStatus(startTs+1, startTs+2, barrierTs)
+	tester.MustApplyPatches()
+	require.Equal(t, startTs, cf.state.Status.CheckpointTs)
+
+	// Updates that reach the barrier are persisted immediately.
+	cf.updateStatus(barrierTs, barrierTs, barrierTs)
+	tester.MustApplyPatches()
+	require.Equal(t, barrierTs, cf.state.Status.CheckpointTs)
+	require.Equal(t, barrierTs, cf.state.Status.ResolvedTs)
+
+	// Updates are persisted in the next persist round.
+	cf.coalesceStatus = false
+	cf.updateStatus(barrierTs+1, barrierTs+2, barrierTs+100)
+	tester.MustApplyPatches()
+	require.Equal(t, barrierTs+1, cf.state.Status.CheckpointTs)
+	require.Equal(t, barrierTs+2, cf.state.Status.ResolvedTs)
+}
+
 func TestChangefeedHandleError(t *testing.T) {
 	ctx := cdcContext.NewBackendContext4Test(true)
 	cf, captures, tester := createChangefeed4Test(ctx, t)
@@ -452,6 +533,46 @@ func TestSyncPoint(t *testing.T) {
 	require.GreaterOrEqual(t, len(mockDDLSink.syncPointHis), 5)
 }
 
+func TestSavepoint(t *testing.T) {
+	ctx := cdcContext.NewBackendContext4Test(true)
+	cf, captures, tester := createChangefeed4Test(ctx, t)
+	defer cf.Close(ctx)
+
+	// the changefeed is not initialized yet.
+	err := cf.createSavepoint(&model.Savepoint{Name: "sp1"})
+	require.True(t, cerror.ErrSavepointNotAllowed.Equal(err))
+
+	// pre check
+	cf.Tick(ctx, captures)
+	tester.MustApplyPatches()
+
+	// initialize
+	cf.Tick(ctx, captures)
+	tester.MustApplyPatches()
+
+	savepoint := &model.Savepoint{Name: "sp1"}
+	require.Nil(t, cf.createSavepoint(savepoint))
+	require.NotZero(t, savepoint.Ts)
+	err = cf.createSavepoint(&model.Savepoint{Name: "sp1"})
+	require.True(t, cerror.ErrSavepointAlreadyExists.Equal(err))
+	tester.MustApplyPatches()
+	require.Len(t, cf.state.Status.Savepoints, 1)
+	require.False(t, cf.state.Status.Savepoints[0].Done)
+
+	mockDDLPuller := cf.ddlPuller.(*mockDDLPuller)
+	mockDDLPuller.resolvedTs += 2000
+	for i := 0; i <= 10; i++ {
+		cf.Tick(ctx, captures)
+		tester.MustApplyPatches()
+	}
+	mockDDLSink := cf.sink.(*mockDDLSink)
+	require.Equal(t, []string{"sp1"}, mockDDLSink.savepointHis)
+	require.Len(t, cf.state.Status.Savepoints, 1)
+	require.True(t, cf.state.Status.Savepoints[0].Done)
+	require.Equal(t, savepoint.Ts, cf.state.Status.Savepoints[0].Ts)
+	require.Greater(t, cf.state.Status.CheckpointTs, savepoint.Ts)
+}
+
 func TestFinished(t *testing.T) {
 	ctx := cdcContext.NewBackendContext4Test(true)
 	ctx.ChangefeedVars().Info.TargetTs = ctx.ChangefeedVars().Info.StartTs + 1000
@@ -800,3 +921,268 @@ func TestBarrierAdvance(t *testing.T) {
 		require.Equal(t, mockDDLPuller.resolvedTs, barrier)
 	}
 }
+
+func TestDDLSkipReason(t *testing.T) {
+	ctx := cdcContext.NewBackendContext4Test(true)
+	cf, captures, tester := createChangefeed4Test(ctx, t)
+	defer cf.Close(ctx)
+	// pre check
+	cf.Tick(ctx, captures)
+	tester.MustApplyPatches()
+	// initialize
+	cf.Tick(ctx, captures)
+	tester.MustApplyPatches()
+
+	event := &model.DDLEvent{Query: "create database test1"}
+	require.Equal(t, "", cf.ddlSkipReason(event))
+	require.Equal(t, 0, cf.ddlPuller.PendingDDLCount())
+
+	// The DDLs committed before the start ts of the table are skipped.
+	cf.state.Info.Config.Filter.TableStartTs = []*config.TableStartTsRule{
+		{Matcher: []string{"test.t1"}, StartTs: 100},
+	}
+	var err error
+	cf.tableStartTs, err = newTableStartTs(cf.state.Info)
+	require.Nil(t, err)
+	tableEvent := &model.DDLEvent{
+		Query:     "alter table test.t1 add column c int",
+		CommitTs:  100,
+		TableInfo: &model.TableInfo{TableName: model.TableName{Schema: "test", Table: "t1"}},
+	}
+	require.Equal(t, skippedDDLReasonBeforeTableStart, cf.ddlSkipReason(tableEvent))
+	done, err := cf.asyncExecDDLEvents(ctx, []*model.DDLEvent{tableEvent})
+	require.Nil(t, err)
+	require.True(t, done)
+	tableEvent.CommitTs = 101
+	require.Equal(t, "", cf.ddlSkipReason(tableEvent))
+	// The other tables start at the start ts of the changefeed.
+	tableEvent.TableInfo.TableName.Table = "t2"
+	require.Equal(t, skippedDDLReasonBeforeTableStart, cf.ddlSkipReason(tableEvent))
+	tableEvent.CommitTs = cf.state.Info.GetStartTs() + 1
+	require.Equal(t, "", cf.ddlSkipReason(tableEvent))
+	require.Equal(t, "", cf.ddlSkipReason(event))
+
+	cf.state.Info.Config.BDRMode = true
+	require.Equal(t, skippedDDLReasonBDRMode, cf.ddlSkipReason(event))
+	done, err = cf.asyncExecDDLEvents(ctx, []*model.DDLEvent{event})
+	require.Nil(t, err)
+	require.True(t, done)
+}
+
+func TestCoalesceDDLJobs(t *testing.T) {
+	ctx := cdcContext.NewBackendContext4Test(true)
+	cf, captures, tester := createChangefeed4Test(ctx, t)
+	defer cf.Close(ctx)
+	// pre check
+	cf.Tick(ctx, captures)
+	tester.MustApplyPatches()
+	// initialize
+	cf.Tick(ctx, captures)
+	tester.MustApplyPatches()
+
+	startTs := cf.state.Info.StartTs
+	newJob := func(tp timodel.ActionType, tableID int64, d time.Duration) *timodel.Job {
+		return &timodel.Job{
+			Type:       tp,
+			TableID:    tableID,
+			BinlogInfo: &timodel.HistoryInfo{FinishedTS: pdutil.AddDurationToTs(startTs, d)},
+		}
+	}
+	mockDDLPuller := cf.ddlPuller.(*mockDDLPuller)
+	mockDDLPuller.ddlQueue = []*timodel.Job{
+		newJob(timodel.ActionDropTablePartition, 1, 100*time.Millisecond),
+		newJob(timodel.ActionAddTablePartition, 1, 200*time.Millisecond),
+		newJob(timodel.ActionAddTablePartition, 2, 300*time.Millisecond),
+		newJob(timodel.ActionAddTablePartition, 1, 400*time.Millisecond),
+	}
+	// The jobs are not batched for a sink which is not concurrent.
+	require.Nil(t, cf.batchDDLJobs())
+
+	cf.state.Info.Config.DDLCoalesce = &config.DDLCoalesceConfig{
+		MaxBatchSize:     3,
+		MaxBatchInterval: time.Second,
+	}
+	// The batch is bounded by the max batch size.
+	require.Len(t, cf.batchDDLJobs(), 3)
+
+	// The batch is bounded by the max batch interval.
+	cf.state.Info.Config.DDLCoalesce.MaxBatchInterval = 150 * time.Millisecond
+	require.Len(t, cf.batchDDLJobs(), 2)
+	cf.state.Info.Config.DDLCoalesce.MaxBatchInterval = time.Second
+
+	// The batch is bounded by the other barriers.
+	cf.barriers.Update(syncPointBarrier, mockDDLPuller.ddlQueue[1].BinlogInfo.FinishedTS)
+	require.Len(t, cf.batchDDLJobs(), 1)
+	cf.barriers.Remove(syncPointBarrier)
+
+	// The jobs which are not early executable end the batch.
+	mockDDLPuller.ddlQueue[2].Type = timodel.ActionDropTablePartition
+	require.Len(t, cf.batchDDLJobs(), 2)
+	mockDDLPuller.ddlQueue[1].Type = timodel.ActionRenameTable
+	require.Len(t, cf.batchDDLJobs(), 1)
+}
+
+func TestExecDDLBatch(t *testing.T) {
+	helper := entry.NewSchemaTestHelper(t)
+	defer helper.Close()
+	helper.DDL2Job("create database test0")
+	job := helper.DDL2Job("create table test0.t(id int primary key, v int)")
+	startTs := job.BinlogInfo.FinishedTS + 1000
+
+	ctx := cdcContext.NewContext4Test(context.Background(), true)
+	ctx.ChangefeedVars().Info.StartTs = startTs
+	ctx.ChangefeedVars().Info.Config.DDLCoalesce = &config.DDLCoalesceConfig{
+		MaxBatchSize:     16,
+		MaxBatchInterval: time.Second,
+	}
+	cf, captures, tester := createChangefeed4Test(ctx, t)
+	cf.upstream.KVStorage = helper.Storage()
+	defer cf.Close(ctx)
+	// pre check and initialize
+	for i := 0; i < 3; i++ {
+		cf.Tick(ctx, captures)
+		tester.MustApplyPatches()
+	}
+
+	mockDDLPuller := cf.ddlPuller.(*mockDDLPuller)
+	mockDDLSink := cf.sink.(*mockDDLSink)
+	mockDDLSink.recordDDLHistory = true
+	var queries []string
+	for i, query := range []string{
+		"alter table test0.t add index i1(v)",
+		"alter table test0.t add index i2(v)",
+		"alter table test0.t add column v2 int",
+	} {
+		job := helper.DDL2Job(query)
+		job.BinlogInfo.FinishedTS = startTs + uint64(i+1)*1000
+		mockDDLPuller.ddlQueue = append(mockDDLPuller.ddlQueue, job)
+		queries = append(queries, query)
+	}
+	firstTs := mockDDLPuller.ddlQueue[0].BinlogInfo.FinishedTS
+	mockDDLPuller.resolvedTs = startTs + 10000
+
+	// The ddl barrier is advanced to the first job.
+	_, err := cf.handleBarrier(ctx)
+	require.Nil(t, err)
+	cf.state.Status.CheckpointTs = firstTs
+	cf.state.Status.ResolvedTs = firstTs
+
+	// The jobs are written in one sink write at the barrier of the first job.
+	barrierTs, err := cf.handleBarrier(ctx)
+	require.Nil(t, err)
+	require.Equal(t, firstTs, barrierTs)
+	require.Equal(t, queries, mockDDLSink.ddlHistory)
+	require.Len(t, cf.ddlBatch, 3)
+	require.Len(t, mockDDLPuller.ddlQueue, 3)
+
+	// The jobs are popped once all of them are executed.
+	mockDDLSink.ddlDone = true
+	_, err = cf.handleBarrier(ctx)
+	require.Nil(t, err)
+	require.Empty(t, mockDDLPuller.ddlQueue)
+	require.Nil(t, cf.ddlBatch)
+	_, barrierTs = cf.barriers.Min()
+	require.Equal(t, mockDDLPuller.resolvedTs, barrierTs)
+}
+
+func TestConcurrentDDLJobs(t *testing.T) {
+	ctx := cdcContext.NewBackendContext4Test(true)
+	cf, captures, tester := createChangefeed4Test(ctx, t)
+	defer cf.Close(ctx)
+	// pre check
+	cf.Tick(ctx, captures)
+	tester.MustApplyPatches()
+	// initialize
+	cf.Tick(ctx, captures)
+	tester.MustApplyPatches()
+	cf.sink.(*mockDDLSink).concurrent = true
+
+	startTs := cf.state.Info.StartTs
+	newJob := func(tp timodel.ActionType, tableID int64, d time.Duration) *timodel.Job {
+		return &timodel.Job{
+			Type:       tp,
+			TableID:    tableID,
+			BinlogInfo: &timodel.HistoryInfo{FinishedTS: pdutil.AddDurationToTs(startTs, d)},
+		}
+	}
+	mockDDLPuller := cf.ddlPuller.(*mockDDLPuller)
+	mockDDLPuller.ddlQueue = []*timodel.Job{
+		newJob(timodel.ActionDropColumn, 1, 100*time.Millisecond),
+		newJob(timodel.ActionAddIndex, 2, 200*time.Millisecond),
+		newJob(timodel.ActionAddColumn, 3, 300*time.Millisecond),
+		newJob(timodel.ActionDropTable, 4, 400*time.Millisecond),
+		newJob(timodel.ActionAddIndex, 5, 500*time.Millisecond),
+	}
+	// The jobs after the front one are early executable.
+	require.Len(t, cf.batchDDLJobs(), 3)
+
+	// The jobs are bounded by the other barriers.
+	cf.barriers.Update(syncPointBarrier, mockDDLPuller.ddlQueue[2].BinlogInfo.FinishedTS)
+	require.Len(t, cf.batchDDLJobs(), 2)
+	cf.barriers.Remove(syncPointBarrier)
+
+	// The jobs which are not early executable are executed alone.
+	mockDDLPuller.ddlQueue[1].Type = timodel.ActionCreateTable
+	require.Len(t, cf.batchDDLJobs(), 1)
+}
+
+func TestExecConcurrentDDLJobs(t *testing.T) {
+	helper := entry.NewSchemaTestHelper(t)
+	defer helper.Close()
+	helper.DDL2Job("create database test0")
+	helper.DDL2Job("create table test0.t1(id int primary key, v int)")
+	job := helper.DDL2Job("create table test0.t2(id int primary key, v int)")
+	startTs := job.BinlogInfo.FinishedTS + 1000
+
+	ctx := cdcContext.NewContext4Test(context.Background(), true)
+	ctx.ChangefeedVars().Info.StartTs = startTs
+	cf, captures, tester := createChangefeed4Test(ctx, t)
+	cf.upstream.KVStorage = helper.Storage()
+	defer cf.Close(ctx)
+	// pre check and initialize
+	for i := 0; i < 3; i++ {
+		cf.Tick(ctx, captures)
+		tester.MustApplyPatches()
+	}
+
+	mockDDLPuller := cf.ddlPuller.(*mockDDLPuller)
+	mockDDLSink := cf.sink.(*mockDDLSink)
+	mockDDLSink.concurrent = true
+	mockDDLSink.recordDDLHistory = true
+	var queries []string
+	for i, query := range []string{
+		"alter table test0.t1 drop column v",
+		"alter table test0.t2 add index i1(v)",
+		"alter table test0.t2 add column v2 int",
+	} {
+		job := helper.DDL2Job(query)
+		job.BinlogInfo.FinishedTS = startTs + uint64(i+1)*1000
+		mockDDLPuller.ddlQueue = append(mockDDLPuller.ddlQueue, job)
+		queries = append(queries, query)
+	}
+	firstTs := mockDDLPuller.ddlQueue[0].BinlogInfo.FinishedTS
+	mockDDLPuller.resolvedTs = startTs + 10000
+
+	// The ddl barrier is advanced to the first job.
+	_, err := cf.handleBarrier(ctx)
+	require.Nil(t, err)
+	cf.state.Status.CheckpointTs = firstTs
+	cf.state.Status.ResolvedTs = firstTs
+
+	// The jobs are emitted together at the barrier of the first job.
+	barrierTs, err := cf.handleBarrier(ctx)
+	require.Nil(t, err)
+	require.Equal(t, firstTs, barrierTs)
+	require.Equal(t, queries, mockDDLSink.ddlHistory)
+	require.Len(t, cf.ddlBatch, 3)
+	require.Len(t, mockDDLPuller.ddlQueue, 3)
+
+	// The jobs are popped once all of them are executed.
+	mockDDLSink.ddlDone = true
+	_, err = cf.handleBarrier(ctx)
+	require.Nil(t, err)
+	require.Empty(t, mockDDLPuller.ddlQueue)
+	require.Nil(t, cf.ddlBatch)
+	_, barrierTs = cf.barriers.Min()
+	require.Equal(t, mockDDLPuller.resolvedTs, barrierTs)
+}
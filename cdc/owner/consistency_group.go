@@ -0,0 +1,83 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package owner
+
+import (
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/orchestrator"
+	"github.com/tikv/client-go/v2/oracle"
+)
+
+// consistencyGroupKey identifies a consistency group,
+// the group names are scoped by namespaces.
+type consistencyGroupKey struct {
+	namespace string
+	name      string
+}
+
+// calculateConsistencyGroupBarriers returns the consistency group barrierTs
+// of the changefeeds in consistency groups. A changefeed can not advance its
+// checkpoint more than its max skew over the min checkpoint of the group, so
+// the downstreams fed by the changefeeds can be compared at a common ts.
+// Only the normal changefeeds are taken into account, a stopped or failed
+// changefeed does not block the other ones.
+func calculateConsistencyGroupBarriers(
+	changefeeds map[model.ChangeFeedID]*orchestrator.ChangefeedReactorState,
+) map[model.ChangeFeedID]model.Ts {
+	minCheckpointTs := make(map[consistencyGroupKey]model.Ts)
+	for id, cf := range changefeeds {
+		key, ok := consistencyGroupOf(id, cf)
+		if !ok {
+			continue
+		}
+		checkpointTs := cf.Status.CheckpointTs
+		if ts, exist := minCheckpointTs[key]; !exist || checkpointTs < ts {
+			minCheckpointTs[key] = checkpointTs
+		}
+	}
+
+	barriers := make(map[model.ChangeFeedID]model.Ts)
+	for id, cf := range changefeeds {
+		key, ok := consistencyGroupOf(id, cf)
+		if !ok {
+			continue
+		}
+		maxSkew := cf.Info.Config.ConsistencyGroup.MaxSkew
+		barrierTs := oracle.GoTimeToTS(
+			oracle.GetTimeFromTS(minCheckpointTs[key]).Add(maxSkew))
+		// The barrierTs must not fall behind the checkpoint, it happens if a
+		// changefeed with a smaller checkpoint joins the group.
+		if barrierTs < cf.Status.CheckpointTs {
+			barrierTs = cf.Status.CheckpointTs
+		}
+		barriers[id] = barrierTs
+	}
+	return barriers
+}
+
+func consistencyGroupOf(
+	id model.ChangeFeedID, cf *orchestrator.ChangefeedReactorState,
+) (consistencyGroupKey, bool) {
+	if cf.Info == nil || cf.Status == nil ||
+		cf.Info.Config == nil || cf.Info.Config.ConsistencyGroup == nil {
+		return consistencyGroupKey{}, false
+	}
+	if cf.Info.State != model.StateNormal {
+		return consistencyGroupKey{}, false
+	}
+	return consistencyGroupKey{
+		namespace: id.Namespace,
+		name:      cf.Info.Config.ConsistencyGroup.Name,
+	}, true
+}
@@ -0,0 +1,81 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package owner
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/pingcap/tiflow/pkg/orchestrator"
+	"github.com/stretchr/testify/require"
+	"github.com/tikv/client-go/v2/oracle"
+)
+
+func newConsistencyGroupState(
+	group string, maxSkew time.Duration, state model.FeedState, checkpointTs model.Ts,
+) *orchestrator.ChangefeedReactorState {
+	cfg := config.GetDefaultReplicaConfig()
+	if group != "" {
+		cfg.ConsistencyGroup = &config.ConsistencyGroupConfig{
+			Name:    group,
+			MaxSkew: maxSkew,
+		}
+	}
+	return &orchestrator.ChangefeedReactorState{
+		Info:   &model.ChangeFeedInfo{Config: cfg, State: state},
+		Status: &model.ChangeFeedStatus{CheckpointTs: checkpointTs},
+	}
+}
+
+func TestCalculateConsistencyGroupBarriers(t *testing.T) {
+	t.Parallel()
+
+	base := oracle.GoTimeToTS(time.Now())
+	skew := 10 * time.Second
+	after := func(d time.Duration) model.Ts {
+		return oracle.GoTimeToTS(oracle.GetTimeFromTS(base).Add(d))
+	}
+
+	kafka := model.DefaultChangeFeedID("kafka")
+	tidb := model.DefaultChangeFeedID("tidb")
+	other := model.ChangeFeedID{Namespace: "other", ID: "tidb"}
+	stopped := model.DefaultChangeFeedID("stopped")
+	single := model.DefaultChangeFeedID("single")
+	changefeeds := map[model.ChangeFeedID]*orchestrator.ChangefeedReactorState{
+		kafka:   newConsistencyGroupState("dr", skew, model.StateNormal, after(5*time.Second)),
+		tidb:    newConsistencyGroupState("dr", 2*skew, model.StateNormal, base),
+		other:   newConsistencyGroupState("dr", skew, model.StateNormal, after(time.Minute)),
+		stopped: newConsistencyGroupState("dr", skew, model.StateStopped, 1),
+		single:  newConsistencyGroupState("", skew, model.StateNormal, 1),
+	}
+
+	barriers := calculateConsistencyGroupBarriers(changefeeds)
+	require.Len(t, barriers, 3)
+	// Each changefeed is bounded by its own max skew over the min checkpoint.
+	require.Equal(t, after(skew), barriers[kafka])
+	require.Equal(t, after(2*skew), barriers[tidb])
+	// Groups are scoped by namespaces.
+	require.Equal(t, after(time.Minute+skew), barriers[other])
+
+	// A changefeed with a smaller checkpoint joins the group,
+	// the barriers must not fall behind the checkpoints.
+	late := model.DefaultChangeFeedID("late")
+	changefeeds[late] = newConsistencyGroupState("dr", skew, model.StateNormal, 1)
+	barriers = calculateConsistencyGroupBarriers(changefeeds)
+	require.Equal(t, after(5*time.Second), barriers[kafka])
+	require.Equal(t, base, barriers[tidb])
+	require.Equal(t, oracle.GoTimeToTS(oracle.GetTimeFromTS(1).Add(skew)), barriers[late])
+}
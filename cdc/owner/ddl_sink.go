@@ -15,6 +15,7 @@ package owner
 
 import (
 	"context"
+	"net/url"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -33,7 +34,9 @@ import (
 	"github.com/pingcap/tiflow/cdc/sinkv2/ddlsink/factory"
 	"github.com/pingcap/tiflow/pkg/config"
 	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/initqueue"
 	"github.com/pingcap/tiflow/pkg/util"
+	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/zap"
 )
 
@@ -55,10 +58,31 @@ type DDLSink interface {
 	// the DDL event will be sent to another goroutine and execute to downstream
 	// the caller of this function can call again and again until a true returned
 	emitDDLEvent(ctx context.Context, ddl *model.DDLEvent) (bool, error)
+	// emitDDLEvents is like emitDDLEvent, but the events may be executed
+	// concurrently if they don't depend on each other and the sink supports.
+	// It returns true if all the events are executed.
+	emitDDLEvents(ctx context.Context, ddls []*model.DDLEvent) (bool, error)
+	// isConcurrent returns true if the sink executes the DDL events emitted
+	// together concurrently.
+	isConcurrent() bool
 	emitSyncPoint(ctx context.Context, checkpointTs uint64) error
+	// emitSavepoint writes the marker of the savepoint to downstream in
+	// another goroutine and returns true if it is written, the caller of
+	// this function can call again and again until a true returned.
+	emitSavepoint(ctx context.Context, savepoint *model.Savepoint) (bool, error)
 	// close the sink, cancel running goroutine.
 	close(ctx context.Context) error
 	isInitialized() bool
+	// selfCheck returns the result of the sink self check, and true if the
+	// self check is done or not required. It is only valid after the sink
+	// is initialized.
+	selfCheck() (*model.SinkSelfCheckResult, bool)
+	// consumerGroupLag returns the lag of the downstream consumer group,
+	// nil is returned if it is not available.
+	consumerGroupLag() *model.ConsumerGroupLag
+	// updateCredentials records the sink URI with the updated credentials,
+	// which is applied to the sink before the next DDL or checkpoint write.
+	updateCredentials(sinkURI string)
 }
 
 type ddlSinkImpl struct {
@@ -70,16 +94,37 @@ type ddlSinkImpl struct {
 		sync.Mutex
 		checkpointTs  model.Ts
 		currentTables []*model.TableInfo
+		// selfCheckResult is the result of the sink self check,
+		// it is nil if the self check is not done yet.
+		selfCheckResult *model.SinkSelfCheckResult
+		// sinkURI is the latest sink URI of the changefeed, its
+		// credentials may not be applied to the sink yet.
+		sinkURI string
+		// writtenSavepoints are the names of the savepoints whose markers
+		// are written but not reported by emitSavepoint yet.
+		writtenSavepoints map[string]bool
 	}
 	// ddlSentTsMap is used to check whether a ddl event in a ddl job has been
 	// sent to `ddlCh` successfully.
 	ddlSentTsMap map[*model.DDLEvent]model.Ts
 
-	ddlCh chan *model.DDLEvent
+	// ddlCh carries the DDL events to execute, more than one event is sent
+	// at a time only if the sink is able to execute them concurrently.
+	ddlCh chan []*model.DDLEvent
 	errCh chan error
+	// savepointCh carries the savepoints whose markers are to be written,
+	// savepointSent records the names of the ones sent to it.
+	savepointCh   chan *model.Savepoint
+	savepointSent map[string]bool
 
 	sinkV1 sinkv1.Sink
 	sinkV2 sinkv2.DDLEventSink
+	// extraSinks are the DDL sinks of the extra sinks of the changefeed,
+	// which the DDL events and the checkpoint ts are written to as well.
+	extraSinks []sinkv2.DDLEventSink
+	// selfChecker is not nil if the sink self check is required,
+	// the self check is done when the first checkpoint ts is written.
+	selfChecker sinkv2.SelfChecker
 	// `sinkInitHandler` can be helpful in unit testing.
 	sinkInitHandler ddlSinkInitHandler
 
@@ -95,12 +140,18 @@ type ddlSinkImpl struct {
 	info         *model.ChangeFeedInfo
 
 	reportErr func(err error)
+
+	// sinkType is the scheme of the sink uri, it labels the ddl execution metrics.
+	sinkType               string
+	metricsDDLExecDuration prometheus.Observer
 }
 
 func newDDLSink(changefeedID model.ChangeFeedID, info *model.ChangeFeedInfo, reportErr func(err error)) DDLSink {
 	res := &ddlSinkImpl{
 		ddlSentTsMap:    make(map[*model.DDLEvent]uint64),
-		ddlCh:           make(chan *model.DDLEvent, 1),
+		ddlCh:           make(chan []*model.DDLEvent, 1),
+		savepointCh:     make(chan *model.Savepoint, 1),
+		savepointSent:   make(map[string]bool),
 		sinkInitHandler: ddlSinkInitializer,
 		cancel:          func() {},
 
@@ -110,19 +161,56 @@ func newDDLSink(changefeedID model.ChangeFeedID, info *model.ChangeFeedInfo, rep
 		errCh:     make(chan error, defaultErrChSize),
 		reportErr: reportErr,
 	}
+	if u, err := url.Parse(info.SinkURI); err == nil {
+		res.sinkType = strings.ToLower(u.Scheme)
+	}
+	res.metricsDDLExecDuration = changefeedDDLExecDuration.
+		WithLabelValues(changefeedID.Namespace, changefeedID.ID, res.sinkType)
+	res.mu.sinkURI = info.SinkURI
+	res.mu.writtenSavepoints = make(map[string]bool)
 	res.initialized.Store(false)
 	return res
 }
 
+// cleanupDownstream deletes the cleanup targets of the changefeed in its sink
+// if the sink supports it, see sinkv2.Cleaner.
+func cleanupDownstream(ctx context.Context, changefeedID model.ChangeFeedID,
+	info *model.ChangeFeedInfo,
+) error {
+	ctx = contextutil.PutChangefeedIDInCtx(ctx, changefeedID)
+	ctx = contextutil.PutRoleInCtx(ctx, util.RoleOwner)
+	s, err := factory.New(ctx, info.SinkURI, info.Config)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer s.Close()
+	cleaner, ok := s.(sinkv2.Cleaner)
+	if !ok {
+		log.Info("the sink doesn't support the cleanup, skip it",
+			zap.String("namespace", changefeedID.Namespace),
+			zap.String("changefeed", changefeedID.ID))
+		return nil
+	}
+	return errors.Trace(cleaner.CleanUp(ctx, info.Config.LifecycleHooks.CleanupTargets))
+}
+
 type ddlSinkInitHandler func(ctx context.Context, a *ddlSinkImpl) error
 
 func ddlSinkInitializer(ctx context.Context, a *ddlSinkImpl) error {
 	ctx = contextutil.PutRoleInCtx(ctx, util.RoleOwner)
 	conf := config.GetGlobalServerConfig()
-	if !conf.Debug.EnableNewSink {
+	if !a.info.Config.IsNewSinkEnabled(conf.Debug) {
 		log.Info("Try to create ddlSink based on sinkV1",
 			zap.String("namespace", a.changefeedID.Namespace),
 			zap.String("changefeed", a.changefeedID.ID))
+		if a.info.Config.Sink.TeeSinkURI != "" {
+			return cerror.ErrSinkInvalidConfig.GenWithStack(
+				"tee-sink-uri is only supported by the new sink")
+		}
+		if len(a.info.Config.Sink.ExtraSinks) > 0 {
+			return cerror.ErrSinkInvalidConfig.GenWithStack(
+				"extra-sinks is only supported by the new sink")
+		}
 		s, err := sinkv1.New(ctx, a.changefeedID, a.info.SinkURI, a.info.Config, a.errCh)
 		if err != nil {
 			return errors.Trace(err)
@@ -137,6 +225,16 @@ func ddlSinkInitializer(ctx context.Context, a *ddlSinkImpl) error {
 			return errors.Trace(err)
 		}
 		a.sinkV2 = s
+		if checker, ok := s.(sinkv2.SelfChecker); ok && checker.SelfCheckEnabled() {
+			a.selfChecker = checker
+		}
+		for _, extra := range a.info.Config.Sink.ExtraSinks {
+			extraSink, err := factory.New(ctx, extra.SinkURI, a.info.Config.ExtraSinkReplicaConfig(extra))
+			if err != nil {
+				return errors.Trace(err)
+			}
+			a.extraSinks = append(a.extraSinks, extraSink)
+		}
 	}
 
 	if !a.info.Config.EnableSyncPoint {
@@ -158,6 +256,19 @@ func ddlSinkInitializer(ctx context.Context, a *ddlSinkImpl) error {
 	return nil
 }
 
+// initialize initializes the sink once it's admitted by the queue limiting
+// the initializations of the changefeeds on the capture.
+func (s *ddlSinkImpl) initialize(ctx context.Context) error {
+	if queue := contextutil.InitQueueFromCtx(ctx); queue != nil {
+		key := initqueue.Key{ChangefeedID: s.changefeedID, Role: util.RoleOwner}
+		if err := queue.Acquire(ctx, key); err != nil {
+			return err
+		}
+		defer queue.Release(key)
+	}
+	return s.sinkInitHandler(ctx, s)
+}
+
 func (s *ddlSinkImpl) run(ctx context.Context) {
 	ctx, s.cancel = context.WithCancel(ctx)
 
@@ -166,7 +277,7 @@ func (s *ddlSinkImpl) run(ctx context.Context) {
 		defer s.wg.Done()
 
 		start := time.Now()
-		if err := s.sinkInitHandler(ctx, s); err != nil {
+		if err := s.initialize(ctx); err != nil {
 			log.Warn("ddl sink initialize failed",
 				zap.String("namespace", s.changefeedID.Namespace),
 				zap.String("changefeed", s.changefeedID.ID),
@@ -184,6 +295,7 @@ func (s *ddlSinkImpl) run(ctx context.Context) {
 		ticker := time.NewTicker(time.Second)
 		defer ticker.Stop()
 		var lastCheckpointTs model.Ts
+		appliedSinkURI := s.info.SinkURI
 		for {
 			select {
 			case <-ctx.Done():
@@ -193,6 +305,16 @@ func (s *ddlSinkImpl) run(ctx context.Context) {
 				return
 			default:
 			}
+			s.mu.Lock()
+			sinkURI := s.mu.sinkURI
+			s.mu.Unlock()
+			if sinkURI != appliedSinkURI {
+				if err := s.applyCredentials(ctx, sinkURI); err != nil {
+					s.reportErr(err)
+					return
+				}
+				appliedSinkURI = sinkURI
+			}
 			// `ticker.C` and `ddlCh` may can be triggered at the same time, it
 			// does not matter which one emit first, since TiCDC allow DDL with
 			// CommitTs equal to the last CheckpointTs be emitted later.
@@ -212,41 +334,53 @@ func (s *ddlSinkImpl) run(ctx context.Context) {
 				tables := s.mu.currentTables
 				s.mu.Unlock()
 				lastCheckpointTs = checkpointTs
-				if s.sinkV1 != nil {
-					if err := s.sinkV1.EmitCheckpointTs(ctx,
-						checkpointTs, tables); err != nil {
-						s.reportErr(err)
-						return
-					}
-				} else {
-					if err := s.sinkV2.WriteCheckpointTs(ctx,
-						checkpointTs, tables); err != nil {
-						s.reportErr(err)
-						return
-					}
+				if err := s.writeCheckpointTs(ctx, checkpointTs, tables); err != nil {
+					s.reportErr(err)
+					return
 				}
 
-			case ddl := <-s.ddlCh:
-				var err error
-				ddl.Query, err = addSpecialComment(ddl.Query)
-				if err != nil {
-					log.Error("Add special comment failed",
-						zap.String("namespace", s.changefeedID.Namespace),
-						zap.String("changefeed", s.changefeedID.ID),
-						zap.Error(err),
-						zap.Any("ddl", ddl))
+			case savepoint := <-s.savepointCh:
+				s.mu.Lock()
+				tables := s.mu.currentTables
+				s.mu.Unlock()
+				if err := s.writeSavepoint(ctx, savepoint, tables); err != nil {
 					s.reportErr(err)
 					return
 				}
+				s.mu.Lock()
+				s.mu.writtenSavepoints[savepoint.Name] = true
+				s.mu.Unlock()
+
+			case ddls := <-s.ddlCh:
+				var err error
+				for _, ddl := range ddls {
+					ddl.Query, err = addSpecialComment(ddl.Query)
+					if err != nil {
+						log.Error("Add special comment failed",
+							zap.String("namespace", s.changefeedID.Namespace),
+							zap.String("changefeed", s.changefeedID.ID),
+							zap.Error(err),
+							zap.Any("ddl", ddl))
+						s.reportErr(err)
+						return
+					}
+				}
 				log.Info("begin emit ddl event",
 					zap.String("namespace", s.changefeedID.Namespace),
 					zap.String("changefeed", s.changefeedID.ID),
-					zap.Any("DDL", ddl))
+					zap.Any("DDL", ddls))
+				start := time.Now()
 				if s.sinkV1 != nil {
-					err = s.sinkV1.EmitDDLEvent(ctx, ddl)
+					err = s.sinkV1.EmitDDLEvent(ctx, ddls[0])
+				} else if len(ddls) == 1 {
+					err = s.sinkV2.WriteDDLEvent(ctx, ddls[0])
 				} else {
-					err = s.sinkV2.WriteDDLEvent(ctx, ddl)
+					err = s.sinkV2.(sinkv2.ConcurrentWriter).WriteDDLEvents(ctx, ddls)
 				}
+				if err == nil {
+					err = s.writeExtraSinksDDLEvents(ctx, ddls)
+				}
+				s.metricsDDLExecDuration.Observe(time.Since(start).Seconds())
 				failpoint.Inject("InjectChangefeedDDLError", func() {
 					err = cerror.ErrExecDDLFailed.GenWithStackByArgs()
 				})
@@ -255,11 +389,13 @@ func (s *ddlSinkImpl) run(ctx context.Context) {
 						zap.String("namespace", s.changefeedID.Namespace),
 						zap.String("changefeed", s.changefeedID.ID),
 						zap.Bool("ignored", err != nil),
-						zap.Any("ddl", ddl))
+						zap.Any("ddl", ddls))
 					// Force emitting checkpoint ts when a ddl event is finished.
 					// Otherwise, a kafka consumer may not execute that ddl event.
 					s.mu.Lock()
-					ddl.Done = true
+					for _, ddl := range ddls {
+						ddl.Done = true
+					}
 					checkpointTs := s.mu.checkpointTs
 					if checkpointTs == 0 || checkpointTs <= lastCheckpointTs {
 						s.mu.Unlock()
@@ -268,18 +404,9 @@ func (s *ddlSinkImpl) run(ctx context.Context) {
 					tables := s.mu.currentTables
 					s.mu.Unlock()
 					lastCheckpointTs = checkpointTs
-					if s.sinkV1 != nil {
-						if err := s.sinkV1.EmitCheckpointTs(ctx,
-							checkpointTs, tables); err != nil {
-							s.reportErr(err)
-							return
-						}
-					} else {
-						if err := s.sinkV2.WriteCheckpointTs(ctx,
-							checkpointTs, tables); err != nil {
-							s.reportErr(err)
-							return
-						}
+					if err := s.writeCheckpointTs(ctx, checkpointTs, tables); err != nil {
+						s.reportErr(err)
+						return
 					}
 					continue
 				}
@@ -289,7 +416,7 @@ func (s *ddlSinkImpl) run(ctx context.Context) {
 					zap.String("namespace", s.changefeedID.Namespace),
 					zap.String("changefeed", s.changefeedID.ID),
 					zap.Error(err),
-					zap.Any("ddl", ddl))
+					zap.Any("ddl", ddls))
 				s.reportErr(err)
 				return
 			}
@@ -297,6 +424,88 @@ func (s *ddlSinkImpl) run(ctx context.Context) {
 	}()
 }
 
+// writeExtraSinksDDLEvents writes the DDL events to the extra sinks. The
+// extra sinks are a fan-out of the changefeed rather than independent
+// replications, so an error of any of them fails the changefeed just like
+// one of the primary sink.
+func (s *ddlSinkImpl) writeExtraSinksDDLEvents(ctx context.Context, ddls []*model.DDLEvent) error {
+	for _, extra := range s.extraSinks {
+		for _, ddl := range ddls {
+			if err := extra.WriteDDLEvent(ctx, ddl); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// writeCheckpointTs writes the checkpoint ts to the sink and the extra sinks.
+func (s *ddlSinkImpl) writeCheckpointTs(
+	ctx context.Context, ts uint64, tables []*model.TableInfo,
+) error {
+	if err := s.writeSinkCheckpointTs(ctx, ts, tables); err != nil {
+		return err
+	}
+	for _, extra := range s.extraSinks {
+		if err := extra.WriteCheckpointTs(ctx, ts, tables); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeSinkCheckpointTs writes the checkpoint ts to the sink. The sink self
+// check is done along with the first write if it is required.
+func (s *ddlSinkImpl) writeSinkCheckpointTs(
+	ctx context.Context, ts uint64, tables []*model.TableInfo,
+) error {
+	if s.sinkV1 != nil {
+		return s.sinkV1.EmitCheckpointTs(ctx, ts, tables)
+	}
+	if s.selfChecker == nil {
+		return s.sinkV2.WriteCheckpointTs(ctx, ts, tables)
+	}
+	s.mu.Lock()
+	checked := s.mu.selfCheckResult != nil
+	s.mu.Unlock()
+	if checked {
+		return s.sinkV2.WriteCheckpointTs(ctx, ts, tables)
+	}
+
+	result, err := s.selfChecker.SelfCheck(ctx, ts, tables)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	log.Info("ddl sink self check finished",
+		zap.String("namespace", s.changefeedID.Namespace),
+		zap.String("changefeed", s.changefeedID.ID),
+		zap.Any("result", result))
+	s.mu.Lock()
+	s.mu.selfCheckResult = result
+	s.mu.Unlock()
+	if !result.Passed {
+		return cerror.ErrSinkSelfCheckFailed.GenWithStackByArgs(result.Message)
+	}
+	return nil
+}
+
+// writeSavepoint writes the marker of the savepoint to the sink, it's
+// skipped if the sink is not able to write it.
+func (s *ddlSinkImpl) writeSavepoint(
+	ctx context.Context, savepoint *model.Savepoint, tables []*model.TableInfo,
+) error {
+	writer, ok := s.sinkV2.(sinkv2.SavepointWriter)
+	if !ok {
+		log.Warn("the sink does not support savepoint markers, skip it",
+			zap.String("namespace", s.changefeedID.Namespace),
+			zap.String("changefeed", s.changefeedID.ID),
+			zap.String("savepoint", savepoint.Name),
+			zap.Uint64("ts", savepoint.Ts))
+		return nil
+	}
+	return writer.WriteSavepoint(ctx, savepoint, tables)
+}
+
 func (s *ddlSinkImpl) emitCheckpointTs(ts uint64, tables []*model.TableInfo) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -309,13 +518,43 @@ func (s *ddlSinkImpl) emitCheckpointTs(ts uint64, tables []*model.TableInfo) {
 // and CommitTs. So in emitDDLEvent, we get the DDL finished ts of an event
 // from a map in order to check whether that event is finished or not.
 func (s *ddlSinkImpl) emitDDLEvent(ctx context.Context, ddl *model.DDLEvent) (bool, error) {
+	return s.emitDDLBatch(ctx, []*model.DDLEvent{ddl})
+}
+
+// emitDDLEvents returns true if all the ddl events are already executed.
+// The events are sent to the sink as a batch if it is able to execute
+// them concurrently, otherwise they are sent one by one.
+func (s *ddlSinkImpl) emitDDLEvents(ctx context.Context, ddls []*model.DDLEvent) (bool, error) {
+	if s.isConcurrent() && len(ddls) > 1 {
+		return s.emitDDLBatch(ctx, ddls)
+	}
+	done := true
+	for _, ddl := range ddls {
+		ddlDone, err := s.emitDDLEvent(ctx, ddl)
+		if err != nil {
+			return false, err
+		}
+		done = done && ddlDone
+	}
+	return done, nil
+}
+
+func (s *ddlSinkImpl) isConcurrent() bool {
+	_, ok := s.sinkV2.(sinkv2.ConcurrentWriter)
+	return ok
+}
+
+func (s *ddlSinkImpl) emitDDLBatch(ctx context.Context, ddls []*model.DDLEvent) (bool, error) {
+	// The events of a batch are always sent and done together, so the
+	// first one stands for the batch.
+	ddl := ddls[0]
 	s.mu.Lock()
 	if ddl.Done {
 		// the DDL event is executed successfully, and done is true
 		log.Info("ddl already executed",
 			zap.String("namespace", s.changefeedID.Namespace),
 			zap.String("changefeed", s.changefeedID.ID),
-			zap.Any("DDL", ddl))
+			zap.Any("DDL", ddls))
 		delete(s.ddlSentTsMap, ddl)
 		s.mu.Unlock()
 		return true, nil
@@ -327,25 +566,26 @@ func (s *ddlSinkImpl) emitDDLEvent(ctx context.Context, ddl *model.DDLEvent) (bo
 		log.Debug("ddl is not finished yet",
 			zap.String("namespace", s.changefeedID.Namespace),
 			zap.String("changefeed", s.changefeedID.ID),
-			zap.Uint64("ddlSentTs", ddlSentTs), zap.Any("DDL", ddl))
+			zap.Uint64("ddlSentTs", ddlSentTs), zap.Any("DDL", ddls))
 		// the DDL event is executing and not finished yet, return false
 		return false, nil
 	}
 	select {
 	case <-ctx.Done():
 		return false, errors.Trace(ctx.Err())
-	case s.ddlCh <- ddl:
+	case s.ddlCh <- ddls:
 		s.ddlSentTsMap[ddl] = ddl.CommitTs
 		log.Info("ddl is sent",
 			zap.String("namespace", s.changefeedID.Namespace),
 			zap.String("changefeed", s.changefeedID.ID),
-			zap.Uint64("ddlSentTs", ddl.CommitTs))
+			zap.Uint64("ddlSentTs", ddl.CommitTs),
+			zap.Int("count", len(ddls)))
 	default:
 		log.Warn("ddl chan full, send it the next round",
 			zap.String("namespace", s.changefeedID.Namespace),
 			zap.String("changefeed", s.changefeedID.ID),
 			zap.Uint64("ddlSentTs", ddlSentTs),
-			zap.Any("DDL", ddl))
+			zap.Any("DDL", ddls))
 		// if this hit, we think that ddlCh is full,
 		// just return false and send the ddl in the next round.
 	}
@@ -361,6 +601,38 @@ func (s *ddlSinkImpl) emitSyncPoint(ctx context.Context, checkpointTs uint64) er
 	return s.syncPointStore.SinkSyncPoint(ctx, s.changefeedID, checkpointTs)
 }
 
+func (s *ddlSinkImpl) emitSavepoint(
+	ctx context.Context, savepoint *model.Savepoint,
+) (bool, error) {
+	s.mu.Lock()
+	if s.mu.writtenSavepoints[savepoint.Name] {
+		delete(s.mu.writtenSavepoints, savepoint.Name)
+		s.mu.Unlock()
+		delete(s.savepointSent, savepoint.Name)
+		return true, nil
+	}
+	s.mu.Unlock()
+
+	if s.savepointSent[savepoint.Name] {
+		// the marker is being written.
+		return false, nil
+	}
+	select {
+	case <-ctx.Done():
+		return false, errors.Trace(ctx.Err())
+	case s.savepointCh <- savepoint:
+		s.savepointSent[savepoint.Name] = true
+		log.Info("savepoint is sent",
+			zap.String("namespace", s.changefeedID.Namespace),
+			zap.String("changefeed", s.changefeedID.ID),
+			zap.String("savepoint", savepoint.Name),
+			zap.Uint64("ts", savepoint.Ts))
+	default:
+		// savepointCh is full, send it the next round.
+	}
+	return false, nil
+}
+
 func (s *ddlSinkImpl) close(ctx context.Context) (err error) {
 	s.cancel()
 	// they will both be nil if changefeed return an error in initializing
@@ -369,10 +641,20 @@ func (s *ddlSinkImpl) close(ctx context.Context) (err error) {
 	} else if s.sinkV2 != nil {
 		err = s.sinkV2.Close()
 	}
+	for _, extra := range s.extraSinks {
+		if closeErr := extra.Close(); closeErr != nil {
+			log.Warn("Failed to close the extra sink",
+				zap.String("namespace", s.changefeedID.Namespace),
+				zap.String("changefeed", s.changefeedID.ID),
+				zap.Error(closeErr))
+		}
+	}
 	if s.syncPointStore != nil {
 		err = s.syncPointStore.Close()
 	}
 	s.wg.Wait()
+	changefeedDDLExecDuration.DeleteLabelValues(
+		s.changefeedID.Namespace, s.changefeedID.ID, s.sinkType)
 	if err != nil && errors.Cause(err) != context.Canceled {
 		return err
 	}
@@ -383,6 +665,50 @@ func (s *ddlSinkImpl) isInitialized() bool {
 	return s.initialized.Load().(bool)
 }
 
+func (s *ddlSinkImpl) consumerGroupLag() *model.ConsumerGroupLag {
+	if !s.isInitialized() {
+		return nil
+	}
+	if reporter, ok := s.sinkV2.(sinkv2.ConsumerLagReporter); ok {
+		return reporter.ConsumerGroupLag()
+	}
+	return nil
+}
+
+func (s *ddlSinkImpl) updateCredentials(sinkURI string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.mu.sinkURI = sinkURI
+}
+
+// applyCredentials applies the credentials of the sink URI to the sink. It is
+// called between the writes, so no DDL is in flight.
+func (s *ddlSinkImpl) applyCredentials(ctx context.Context, sinkURI string) error {
+	updater, ok := s.sinkV2.(sinkv2.CredentialsUpdater)
+	if !ok {
+		return cerror.ErrSinkCredentialsNotUpdatable.GenWithStackByArgs(
+			"the ddl sink is not supported")
+	}
+	u, err := url.Parse(sinkURI)
+	if err != nil {
+		return cerror.WrapError(cerror.ErrSinkURIInvalid, err)
+	}
+	if err := updater.UpdateCredentials(ctx, u); err != nil {
+		return errors.Trace(err)
+	}
+	log.Info("ddl sink updated the credentials",
+		zap.String("namespace", s.changefeedID.Namespace),
+		zap.String("changefeed", s.changefeedID.ID),
+		zap.String("sinkURI", util.MaskSinkURIForLog(sinkURI)))
+	return nil
+}
+
+func (s *ddlSinkImpl) selfCheck() (*model.SinkSelfCheckResult, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mu.selfCheckResult, s.selfChecker == nil || s.mu.selfCheckResult != nil
+}
+
 // addSpecialComment translate tidb feature to comment
 func addSpecialComment(ddlQuery string) (string, error) {
 	stms, _, err := parser.New().ParseSQL(ddlQuery)
@@ -23,6 +23,7 @@ import (
 	"github.com/pingcap/errors"
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/cdc/sink"
+	"github.com/pingcap/tiflow/cdc/sinkv2/ddlsink"
 	cerror "github.com/pingcap/tiflow/pkg/errors"
 	"github.com/pingcap/tiflow/pkg/retry"
 	"github.com/stretchr/testify/require"
@@ -400,3 +401,78 @@ func TestAddSpecialComment(t *testing.T) {
 		_, _ = addSpecialComment("alter table t force, auto_increment = 12;alter table t force, auto_increment = 12;")
 	}, "invalid ddlQuery statement size")
 }
+
+type mockSelfCheckSink struct {
+	ddlsink.DDLEventSink
+	passed       bool
+	checkpointTs model.Ts
+}
+
+func (m *mockSelfCheckSink) WriteCheckpointTs(_ context.Context,
+	ts uint64, _ []*model.TableInfo,
+) error {
+	atomic.StoreUint64(&m.checkpointTs, ts)
+	return nil
+}
+
+func (m *mockSelfCheckSink) SelfCheckEnabled() bool {
+	return true
+}
+
+func (m *mockSelfCheckSink) SelfCheck(ctx context.Context,
+	ts uint64, tables []*model.TableInfo,
+) (*model.SinkSelfCheckResult, error) {
+	if err := m.WriteCheckpointTs(ctx, ts, tables); err != nil {
+		return nil, err
+	}
+	return &model.SinkSelfCheckResult{
+		Passed: m.passed, CheckTime: time.Now(), ConsumedMessages: 1,
+	}, nil
+}
+
+func (m *mockSelfCheckSink) Close() error {
+	return nil
+}
+
+func TestSelfCheck(t *testing.T) {
+	for _, passed := range []bool{true, false} {
+		var reportedErr atomic.Value
+		ddlSink := newDDLSink(model.DefaultChangeFeedID("changefeed-test"),
+			&model.ChangeFeedInfo{}, func(err error) { reportedErr.Store(err) })
+		mSink := &mockSelfCheckSink{passed: passed}
+		ddlSink.(*ddlSinkImpl).sinkInitHandler = func(ctx context.Context, s *ddlSinkImpl) error {
+			s.sinkV2 = mSink
+			s.selfChecker = mSink
+			return nil
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		ddlSink.run(ctx)
+		require.Eventually(t, ddlSink.isInitialized, 5*time.Second, 10*time.Millisecond)
+		result, done := ddlSink.selfCheck()
+		require.Nil(t, result)
+		require.False(t, done)
+
+		ddlSink.emitCheckpointTs(1, nil)
+		require.Eventually(t, func() bool {
+			_, done := ddlSink.selfCheck()
+			return done
+		}, 5*time.Second, 10*time.Millisecond)
+		result, _ = ddlSink.selfCheck()
+		require.Equal(t, passed, result.Passed)
+		require.Equal(t, uint64(1), atomic.LoadUint64(&mSink.checkpointTs))
+		if passed {
+			ddlSink.emitCheckpointTs(10, nil)
+			require.Eventually(t, func() bool {
+				return atomic.LoadUint64(&mSink.checkpointTs) == 10
+			}, 5*time.Second, 10*time.Millisecond)
+		} else {
+			require.Eventually(t, func() bool {
+				err, ok := reportedErr.Load().(error)
+				return ok && cerror.ErrSinkSelfCheckFailed.Equal(err)
+			}, 5*time.Second, 10*time.Millisecond)
+		}
+		cancel()
+		require.Nil(t, ddlSink.close(ctx))
+	}
+}
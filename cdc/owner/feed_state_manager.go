@@ -14,12 +14,14 @@
 package owner
 
 import (
+	"context"
 	"time"
 
 	"github.com/cenkalti/backoff/v4"
 	"github.com/pingcap/errors"
 	"github.com/pingcap/log"
 	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/config"
 	cerrors "github.com/pingcap/tiflow/pkg/errors"
 	"github.com/pingcap/tiflow/pkg/orchestrator"
 	"go.uber.org/zap"
@@ -56,6 +58,43 @@ type feedStateManager struct {
 	lastErrorTime   time.Time                   // time of last error for a changefeed
 	backoffInterval time.Duration               // the interval for restarting a changefeed in 'error' state
 	errBackoff      *backoff.ExponentialBackOff // an exponential backoff for restarting a changefeed
+
+	// restartPolicy is the restart policy configured for the changefeed,
+	// nil means the default backoff without a restart budget.
+	restartPolicy *config.RestartPolicyConfig
+	// restartHistory is the time of the restarts in the window of the
+	// restart policy.
+	restartHistory []time.Time
+
+	// lifecycleHooks is the hooks configured for the changefeed, nil means
+	// no hook is run before the state transitions.
+	lifecycleHooks *config.LifecycleHooksConfig
+	// pauseFlush is the flush waited by a pending pause, it's nil if no
+	// pause is waiting.
+	pauseFlush *pauseFlush
+	// removeCleanup is the cleanup of the downstream run by a pending
+	// removal, it's nil if no removal is waiting.
+	removeCleanup *removeCleanup
+	// removalQueued is true if the removal pending in the changefeed info
+	// is in the admin job queue.
+	removalQueued bool
+	// newDownstreamCleanup returns the cleanup of the data written by the
+	// changefeed to the downstream, nil means the cleanup is not supported.
+	newDownstreamCleanup func(info *model.ChangeFeedInfo) func(ctx context.Context) error
+}
+
+// pauseFlush is the flush waited by a pause, the changefeed is paused once
+// its checkpoint reaches targetTs or the deadline passes.
+type pauseFlush struct {
+	targetTs model.Ts
+	deadline time.Time
+}
+
+// removeCleanup is the cleanup of the downstream run in background before
+// removing the changefeed, err is set before done is closed.
+type removeCleanup struct {
+	done chan struct{}
+	err  error
 }
 
 // newFeedStateManager creates feedStateManager and initialize the exponential backoff
@@ -98,6 +137,62 @@ func newFeedStateManager4Test(
 	return f
 }
 
+// updateRestartPolicy applies the restart policy of the changefeed to the
+// backoff if it's changed.
+func (m *feedStateManager) updateRestartPolicy(policy *config.RestartPolicyConfig) {
+	if policy == nil && m.restartPolicy == nil {
+		return
+	}
+	if policy != nil && m.restartPolicy != nil && *policy == *m.restartPolicy {
+		return
+	}
+	if policy == nil {
+		m.restartPolicy = nil
+		policy = config.NewDefaultRestartPolicyConfig()
+	} else {
+		p := *policy
+		m.restartPolicy = &p
+	}
+	m.errBackoff.InitialInterval = policy.BackoffBaseDelay
+	m.errBackoff.MaxInterval = policy.BackoffMaxDelay
+	m.errBackoff.RandomizationFactor = policy.BackoffJitter
+	m.resetErrBackoff()
+}
+
+// restartBudgetExhausted removes the restarts out of the window from the
+// history, and returns whether the changefeed can't be restarted anymore.
+func (m *feedStateManager) restartBudgetExhausted(now time.Time) bool {
+	if m.restartPolicy == nil || m.restartPolicy.MaxRestarts <= 0 {
+		return false
+	}
+	i := 0
+	for i < len(m.restartHistory) && now.Sub(m.restartHistory[i]) >= m.restartPolicy.Window {
+		i++
+	}
+	m.restartHistory = m.restartHistory[i:]
+	return len(m.restartHistory) >= m.restartPolicy.MaxRestarts
+}
+
+// restartStatus returns the status of the automatic restarts of the changefeed.
+func (m *feedStateManager) restartStatus(now time.Time) *model.RestartStatus {
+	status := &model.RestartStatus{BackoffInterval: m.backoffInterval}
+	if m.restartPolicy != nil && m.restartPolicy.MaxRestarts > 0 {
+		status.MaxRestarts = m.restartPolicy.MaxRestarts
+		for _, t := range m.restartHistory {
+			if now.Sub(t) < m.restartPolicy.Window {
+				status.Restarts++
+			}
+		}
+	} else {
+		status.Restarts = len(m.restartHistory)
+	}
+	if m.lastErrorTime != time.Unix(0, 0) {
+		next := m.lastErrorTime.Add(m.backoffInterval)
+		status.NextRestartTime = &next
+	}
+	return status
+}
+
 // resetErrBackoff reset the backoff-related fields
 func (m *feedStateManager) resetErrBackoff() {
 	m.errBackoff.Reset()
@@ -127,9 +222,18 @@ func (m *feedStateManager) shiftStateWindow(state model.FeedState) {
 func (m *feedStateManager) Tick(state *orchestrator.ChangefeedReactorState) (adminJobPending bool) {
 	m.state = state
 	m.shouldBeRunning = true
+	if m.state.Info != nil && m.state.Info.Config != nil {
+		m.updateRestartPolicy(m.state.Info.Config.RestartPolicy)
+		m.lifecycleHooks = m.state.Info.Config.LifecycleHooks
+	}
+	if m.state.Info != nil && m.state.Info.RemovalPending && !m.removalQueued {
+		// The removal is started by the previous owner, resume it.
+		m.removalQueued = true
+		m.pushAdminJob(&model.AdminJob{CfID: m.state.ID, Type: model.AdminRemove})
+	}
 	defer func() {
 		if m.shouldBeRunning {
-			m.patchState(model.StateNormal)
+			m.patchState(model.StateNormal, "restarted after the error")
 		} else {
 			m.cleanUpInfos()
 		}
@@ -196,9 +300,12 @@ func (m *feedStateManager) handleAdminJob() (jobsPending bool) {
 	if job == nil || job.CfID != m.state.ID {
 		return false
 	}
-	log.Info("handle admin job",
-		zap.String("namespace", m.state.ID.Namespace),
-		zap.String("changefeed", m.state.ID.ID), zap.Any("job", job))
+	if m.pauseFlush == nil && m.removeCleanup == nil {
+		// the jobs waiting for the hooks are logged only once.
+		log.Info("handle admin job",
+			zap.String("namespace", m.state.ID.Namespace),
+			zap.String("changefeed", m.state.ID.ID), zap.Any("job", job))
+	}
 	switch job.Type {
 	case model.AdminStop:
 		switch m.state.Info.State {
@@ -210,9 +317,14 @@ func (m *feedStateManager) handleAdminJob() (jobsPending bool) {
 				zap.String("changefeedState", string(m.state.Info.State)), zap.Any("job", job))
 			return
 		}
+		if m.waitPauseFlush() {
+			// keep the changefeed running until the data is flushed.
+			m.deferAdminJob(job)
+			return
+		}
 		m.shouldBeRunning = false
 		jobsPending = true
-		m.patchState(model.StateStopped)
+		m.patchState(model.StateStopped, "paused by the user")
 	case model.AdminRemove:
 
 		switch m.state.Info.State {
@@ -225,6 +337,12 @@ func (m *feedStateManager) handleAdminJob() (jobsPending bool) {
 				zap.String("changefeedState", string(m.state.Info.State)), zap.Any("job", job))
 			return
 		}
+		if m.waitRemoveCleanup() {
+			m.deferAdminJob(job)
+			m.shouldBeRunning = false
+			jobsPending = true
+			return
+		}
 
 		m.shouldBeRunning = false
 		m.shouldBeRemoved = true
@@ -259,8 +377,10 @@ func (m *feedStateManager) handleAdminJob() (jobsPending bool) {
 		m.resetErrBackoff()
 		// The lastErrorTime also needs to be cleared before a fresh run.
 		m.lastErrorTime = time.Unix(0, 0)
+		// The restart budget is renewed by a manual resume.
+		m.restartHistory = nil
 		jobsPending = true
-		m.patchState(model.StateNormal)
+		m.patchState(model.StateNormal, "resumed by the user")
 
 		m.state.PatchInfo(func(info *model.ChangeFeedInfo) (*model.ChangeFeedInfo, bool, error) {
 			changed := false
@@ -311,7 +431,7 @@ func (m *feedStateManager) handleAdminJob() (jobsPending bool) {
 		}
 		m.shouldBeRunning = false
 		jobsPending = true
-		m.patchState(model.StateFinished)
+		m.patchState(model.StateFinished, "target ts reached")
 	default:
 		log.Warn("Unknown admin job", zap.Any("adminJob", job),
 			zap.String("namespace", m.state.ID.Namespace),
@@ -333,7 +453,130 @@ func (m *feedStateManager) pushAdminJob(job *model.AdminJob) {
 	m.adminJobQueue = append(m.adminJobQueue, job)
 }
 
-func (m *feedStateManager) patchState(feedState model.FeedState) {
+// deferAdminJob puts the job back to the front of the queue, it's handled
+// again in the next tick.
+func (m *feedStateManager) deferAdminJob(job *model.AdminJob) {
+	m.adminJobQueue = append([]*model.AdminJob{job}, m.adminJobQueue...)
+}
+
+// waitPauseFlush returns true if a pause must wait for the flush of the data
+// received before it. The changefeed keeps running until its checkpoint
+// reaches the resolved ts at the time of the pause, or the flush times out.
+func (m *feedStateManager) waitPauseFlush() bool {
+	hooks := m.lifecycleHooks
+	if hooks == nil || !hooks.FlushBeforePause ||
+		m.state.Info.State != model.StateNormal || m.state.Status == nil {
+		m.pauseFlush = nil
+		return false
+	}
+	now := time.Now()
+	if m.pauseFlush == nil {
+		m.pauseFlush = &pauseFlush{
+			targetTs: m.state.Status.ResolvedTs,
+			deadline: now.Add(hooks.PauseFlushTimeout),
+		}
+		log.Info("wait for the flush before pausing the changefeed",
+			zap.String("namespace", m.state.ID.Namespace),
+			zap.String("changefeed", m.state.ID.ID),
+			zap.Uint64("targetTs", m.pauseFlush.targetTs),
+			zap.Duration("timeout", hooks.PauseFlushTimeout))
+		return true
+	}
+	if m.state.Status.CheckpointTs >= m.pauseFlush.targetTs {
+		log.Info("the changefeed is flushed before the pause",
+			zap.String("namespace", m.state.ID.Namespace),
+			zap.String("changefeed", m.state.ID.ID),
+			zap.Uint64("checkpointTs", m.state.Status.CheckpointTs))
+		m.pauseFlush = nil
+		return false
+	}
+	if now.After(m.pauseFlush.deadline) {
+		log.Warn("the flush before the pause times out, pause the changefeed anyway",
+			zap.String("namespace", m.state.ID.Namespace),
+			zap.String("changefeed", m.state.ID.ID),
+			zap.Uint64("checkpointTs", m.state.Status.CheckpointTs),
+			zap.Uint64("targetTs", m.pauseFlush.targetTs))
+		m.pauseFlush = nil
+		return false
+	}
+	return true
+}
+
+// waitRemoveCleanup returns true if a removal must wait for the cleanup of
+// the downstream. A running changefeed is stopped before the cleanup, so that
+// it doesn't write the data being cleaned up, and it's removed once the
+// cleanup is done, even if the cleanup fails. The pending removal is recorded
+// in the changefeed info, the cleanup is run again by the next owner if the
+// owner changes before the removal.
+func (m *feedStateManager) waitRemoveCleanup() bool {
+	hooks := m.lifecycleHooks
+	if hooks == nil || !hooks.CleanupOnRemove || m.newDownstreamCleanup == nil {
+		return false
+	}
+	if m.removeCleanup == nil {
+		if !m.state.Info.RemovalPending {
+			m.removalQueued = true
+			m.state.PatchInfo(func(info *model.ChangeFeedInfo) (*model.ChangeFeedInfo, bool, error) {
+				if info == nil || info.RemovalPending {
+					return info, false, nil
+				}
+				info.RemovalPending = true
+				return info, true, nil
+			})
+		}
+		switch m.state.Info.State {
+		case model.StateNormal, model.StateError:
+			m.patchState(model.StateStopped,
+				"stopped to clean up the downstream before the removal")
+			return true
+		}
+		cleanup := m.newDownstreamCleanup(m.state.Info)
+		rc := &removeCleanup{done: make(chan struct{})}
+		go func(timeout time.Duration) {
+			ctx, cancel := context.WithTimeout(context.Background(), timeout)
+			defer cancel()
+			rc.err = cleanup(ctx)
+			close(rc.done)
+		}(hooks.RemoveCleanupTimeout)
+		m.removeCleanup = rc
+		log.Info("clean up the downstream before removing the changefeed",
+			zap.String("namespace", m.state.ID.Namespace),
+			zap.String("changefeed", m.state.ID.ID),
+			zap.Duration("timeout", hooks.RemoveCleanupTimeout))
+		return true
+	}
+	select {
+	case <-m.removeCleanup.done:
+	default:
+		return true
+	}
+	if err := m.removeCleanup.err; err != nil {
+		log.Warn("fail to clean up the downstream, remove the changefeed anyway",
+			zap.String("namespace", m.state.ID.Namespace),
+			zap.String("changefeed", m.state.ID.ID),
+			zap.Error(err))
+	} else {
+		log.Info("the downstream is cleaned up before the removal",
+			zap.String("namespace", m.state.ID.Namespace),
+			zap.String("changefeed", m.state.ID.ID))
+	}
+	m.removeCleanup = nil
+	return false
+}
+
+// patchState transits the changefeed to the state, the transition is recorded
+// in the history of the changefeed with the reason. A transition not allowed
+// by the state machine is ignored, see model.FeedState.CanTransitTo.
+func (m *feedStateManager) patchState(feedState model.FeedState, reason string) {
+	if m.state.Info != nil && !m.state.Info.State.CanTransitTo(feedState) {
+		log.Warn("the changefeed can not transit to the state",
+			zap.String("namespace", m.state.ID.Namespace),
+			zap.String("changefeed", m.state.ID.ID),
+			zap.String("changefeedState", string(m.state.Info.State)),
+			zap.String("nextState", string(feedState)),
+			zap.String("reason", reason))
+		return
+	}
 	var adminJobType model.AdminJobType
 	switch feedState {
 	case model.StateNormal:
@@ -363,7 +606,7 @@ func (m *feedStateManager) patchState(feedState model.FeedState) {
 			return nil, changed, nil
 		}
 		if info.State != feedState {
-			info.State = feedState
+			info.RecordStateTransition(feedState, reason, time.Now())
 			changed = true
 		}
 		if info.AdminJobType != adminJobType {
@@ -426,7 +669,24 @@ func (m *feedStateManager) handleError(errs ...*model.RunningError) {
 				return info, true, nil
 			})
 			m.shouldBeRunning = false
-			m.patchState(model.StateFailed)
+			m.patchState(model.StateFailed, "fast fail error "+err.Code)
+			return
+		}
+	}
+
+	// the changefeed is paused with the error as the report, it's resumed by
+	// the users after the cause is fixed.
+	for _, err := range errs {
+		if cerrors.IsChangefeedPauseErrorCode(errors.RFCErrorCode(err.Code)) {
+			m.state.PatchInfo(func(info *model.ChangeFeedInfo) (*model.ChangeFeedInfo, bool, error) {
+				if info == nil {
+					return nil, false, nil
+				}
+				info.Error = err
+				return info, true, nil
+			})
+			m.shouldBeRunning = false
+			m.patchState(model.StateStopped, "paused by error "+err.Code)
 			return
 		}
 	}
@@ -444,7 +704,7 @@ func (m *feedStateManager) handleError(errs ...*model.RunningError) {
 				return info, true, nil
 			})
 			m.shouldBeRunning = false
-			m.patchState(model.StateError)
+			m.patchState(model.StateError, "unretryable error "+err.Code)
 			return
 		}
 	}
@@ -482,8 +742,15 @@ func (m *feedStateManager) handleError(errs ...*model.RunningError) {
 
 	if time.Since(m.lastErrorTime) < m.backoffInterval {
 		m.shouldBeRunning = false
-		m.patchState(model.StateError)
+		m.patchState(model.StateError, "backoff after the error")
 	} else {
+		now := time.Now()
+		if m.restartBudgetExhausted(now) {
+			m.failByRestartBudget()
+			return
+		}
+		m.restartHistory = append(m.restartHistory, now)
+
 		oldBackoffInterval := m.backoffInterval
 		// NextBackOff will never return -1 because the backoff never stops
 		// with `MaxElapsedTime=0`
@@ -498,3 +765,32 @@ func (m *feedStateManager) handleError(errs ...*model.RunningError) {
 			zap.Duration("newInterval", m.backoffInterval))
 	}
 }
+
+// failByRestartBudget fails the changefeed because it has been restarted too
+// many times in the window of the restart policy.
+func (m *feedStateManager) failByRestartBudget() {
+	restarts, window := len(m.restartHistory), m.restartPolicy.Window
+	log.Warn("changefeed restart budget is exhausted, the changefeed is failed",
+		zap.String("namespace", m.state.ID.Namespace),
+		zap.String("changefeed", m.state.ID.ID),
+		zap.Int("restarts", restarts),
+		zap.Duration("window", window))
+	m.state.PatchInfo(func(info *model.ChangeFeedInfo) (*model.ChangeFeedInfo, bool, error) {
+		if info == nil {
+			return nil, false, nil
+		}
+		lastErr, addr := "", ""
+		if info.Error != nil {
+			lastErr, addr = info.Error.Message, info.Error.Addr
+		}
+		err := cerrors.ErrChangefeedRestartBudgetExhausted.GenWithStackByArgs(restarts, window, lastErr)
+		info.Error = &model.RunningError{
+			Addr:    addr,
+			Code:    string(cerrors.ErrChangefeedRestartBudgetExhausted.RFCCode()),
+			Message: err.Error(),
+		}
+		return info, true, nil
+	})
+	m.shouldBeRunning = false
+	m.patchState(model.StateFailed, "restart budget exhausted")
+}
@@ -14,10 +14,12 @@
 package owner
 
 import (
+	"context"
 	"fmt"
 	"testing"
 	"time"
 
+	"github.com/pingcap/errors"
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/pkg/config"
 	cdcContext "github.com/pingcap/tiflow/pkg/context"
@@ -325,6 +327,48 @@ func TestHandleFastFailError(t *testing.T) {
 	tester.MustApplyPatches()
 }
 
+func TestHandlePauseError(t *testing.T) {
+	ctx := cdcContext.NewBackendContext4Test(true)
+	manager := newFeedStateManager4Test(200, 1600, 0, 2.0)
+	state := orchestrator.NewChangefeedReactorState(etcd.DefaultCDCClusterID,
+		ctx.ChangefeedVars().ID)
+	tester := orchestrator.NewReactorStateTester(t, state, nil)
+	state.PatchInfo(func(info *model.ChangeFeedInfo) (*model.ChangeFeedInfo, bool, error) {
+		require.Nil(t, info)
+		return &model.ChangeFeedInfo{SinkURI: "123", Config: &config.ReplicaConfig{}}, true, nil
+	})
+	state.PatchStatus(func(status *model.ChangeFeedStatus) (*model.ChangeFeedStatus, bool, error) {
+		require.Nil(t, status)
+		return &model.ChangeFeedStatus{}, true, nil
+	})
+	tester.MustApplyPatches()
+	manager.Tick(state)
+	tester.MustApplyPatches()
+
+	// The changefeed is paused with the error as the report.
+	manager.handleError(&model.RunningError{
+		Addr:    ctx.GlobalVars().CaptureInfo.AdvertiseAddr,
+		Code:    "CDC:ErrConsumerContractViolated",
+		Message: "fake error for test",
+	})
+	tester.MustApplyPatches()
+	require.False(t, manager.ShouldRunning())
+	require.Equal(t, model.StateStopped, state.Info.State)
+	require.Equal(t, model.AdminStop, state.Info.AdminJobType)
+	require.Equal(t, "CDC:ErrConsumerContractViolated", state.Info.Error.Code)
+
+	// The paused changefeed is resumed by the users.
+	manager.PushAdminJob(&model.AdminJob{
+		CfID: ctx.ChangefeedVars().ID,
+		Type: model.AdminResume,
+	})
+	manager.Tick(state)
+	tester.MustApplyPatches()
+	require.True(t, manager.ShouldRunning())
+	require.Equal(t, model.StateNormal, state.Info.State)
+	require.Nil(t, state.Info.Error)
+}
+
 func TestChangefeedStatusNotExist(t *testing.T) {
 	changefeedInfo := `
 {
@@ -585,3 +629,282 @@ func TestBackoffNeverStops(t *testing.T) {
 		tester.MustApplyPatches()
 	}
 }
+
+func TestRestartBudgetExhausted(t *testing.T) {
+	ctx := cdcContext.NewBackendContext4Test(true)
+	manager := newFeedStateManager4Test(100, 100, 0, 1.0)
+	state := orchestrator.NewChangefeedReactorState(etcd.DefaultCDCClusterID,
+		ctx.ChangefeedVars().ID)
+	tester := orchestrator.NewReactorStateTester(t, state, nil)
+	state.PatchInfo(func(info *model.ChangeFeedInfo) (*model.ChangeFeedInfo, bool, error) {
+		require.Nil(t, info)
+		cfg := config.GetDefaultReplicaConfig()
+		cfg.RestartPolicy = &config.RestartPolicyConfig{
+			MaxRestarts:      3,
+			Window:           time.Hour,
+			BackoffBaseDelay: 50 * time.Millisecond,
+			BackoffMaxDelay:  50 * time.Millisecond,
+		}
+		return &model.ChangeFeedInfo{SinkURI: "123", Config: cfg}, true, nil
+	})
+	state.PatchStatus(func(status *model.ChangeFeedStatus) (*model.ChangeFeedStatus, bool, error) {
+		require.Nil(t, status)
+		return &model.ChangeFeedStatus{}, true, nil
+	})
+
+	tester.MustApplyPatches()
+	manager.Tick(state)
+	tester.MustApplyPatches()
+	require.Equal(t, 50*time.Millisecond, manager.backoffInterval)
+
+	reportError := func() {
+		state.PatchTaskPosition(ctx.GlobalVars().CaptureInfo.ID,
+			func(position *model.TaskPosition) (*model.TaskPosition, bool, error) {
+				return &model.TaskPosition{Error: &model.RunningError{
+					Addr:    ctx.GlobalVars().CaptureInfo.AdvertiseAddr,
+					Code:    "[CDC:ErrEtcdSessionDone]",
+					Message: "fake error for test",
+				}}, true, nil
+			})
+		tester.MustApplyPatches()
+		manager.Tick(state)
+		tester.MustApplyPatches()
+		require.False(t, manager.ShouldRunning())
+		require.Equal(t, model.StateError, state.Info.State)
+		require.NotNil(t, manager.restartStatus(time.Now()).NextRestartTime)
+		time.Sleep(50 * time.Millisecond)
+		manager.Tick(state)
+		tester.MustApplyPatches()
+	}
+
+	for i := 1; i <= 3; i++ {
+		reportError()
+		require.True(t, manager.ShouldRunning())
+		require.Equal(t, model.StateNormal, state.Info.State)
+		status := manager.restartStatus(time.Now())
+		require.Equal(t, i, status.Restarts)
+		require.Equal(t, 3, status.MaxRestarts)
+		require.Nil(t, status.NextRestartTime)
+	}
+
+	// the 4th restart exhausts the budget, the changefeed fails
+	reportError()
+	require.False(t, manager.ShouldRunning())
+	require.Equal(t, model.StateFailed, state.Info.State)
+	require.Equal(t, string(cerror.ErrChangefeedRestartBudgetExhausted.RFCCode()),
+		state.Info.Error.Code)
+	require.Contains(t, state.Info.Error.Message, "fake error for test")
+
+	// the budget is renewed by a manual resume
+	manager.PushAdminJob(&model.AdminJob{
+		CfID: ctx.ChangefeedVars().ID,
+		Type: model.AdminResume,
+	})
+	manager.Tick(state)
+	tester.MustApplyPatches()
+	require.True(t, manager.ShouldRunning())
+	require.Equal(t, model.StateNormal, state.Info.State)
+	require.Equal(t, 0, manager.restartStatus(time.Now()).Restarts)
+
+	// the restarts out of the window are not counted
+	manager.restartHistory = []time.Time{time.Now().Add(-2 * time.Hour)}
+	require.False(t, manager.restartBudgetExhausted(time.Now()))
+	require.Empty(t, manager.restartHistory)
+}
+
+func TestStateTransitionHistory(t *testing.T) {
+	ctx := cdcContext.NewBackendContext4Test(true)
+	manager := newFeedStateManager4Test(200, 1600, 0, 2.0)
+	state := orchestrator.NewChangefeedReactorState(etcd.DefaultCDCClusterID,
+		ctx.ChangefeedVars().ID)
+	tester := orchestrator.NewReactorStateTester(t, state, nil)
+	state.PatchInfo(func(info *model.ChangeFeedInfo) (*model.ChangeFeedInfo, bool, error) {
+		require.Nil(t, info)
+		return &model.ChangeFeedInfo{SinkURI: "123", Config: &config.ReplicaConfig{}}, true, nil
+	})
+	state.PatchStatus(func(status *model.ChangeFeedStatus) (*model.ChangeFeedStatus, bool, error) {
+		require.Nil(t, status)
+		return &model.ChangeFeedStatus{}, true, nil
+	})
+	tester.MustApplyPatches()
+	manager.Tick(state)
+	tester.MustApplyPatches()
+	require.Empty(t, state.Info.StateHistory)
+
+	manager.MarkFinished()
+	manager.Tick(state)
+	tester.MustApplyPatches()
+	require.Equal(t, model.StateFinished, state.Info.State)
+
+	// a finished changefeed can't be stopped
+	manager.patchState(model.StateStopped, "paused by the user")
+	tester.MustApplyPatches()
+	require.Equal(t, model.StateFinished, state.Info.State)
+	require.Equal(t, model.AdminFinish, state.Status.AdminJobType)
+
+	manager.PushAdminJob(&model.AdminJob{
+		CfID: ctx.ChangefeedVars().ID,
+		Type: model.AdminResume,
+	})
+	manager.Tick(state)
+	tester.MustApplyPatches()
+	require.Equal(t, model.StateNormal, state.Info.State)
+
+	require.Len(t, state.Info.StateHistory, 2)
+	require.Equal(t, model.StateNormal, state.Info.StateHistory[0].From)
+	require.Equal(t, model.StateFinished, state.Info.StateHistory[0].To)
+	require.Equal(t, "target ts reached", state.Info.StateHistory[0].Reason)
+	require.Equal(t, model.StateFinished, state.Info.StateHistory[1].From)
+	require.Equal(t, model.StateNormal, state.Info.StateHistory[1].To)
+	require.Equal(t, "resumed by the user", state.Info.StateHistory[1].Reason)
+}
+
+func TestFlushBeforePause(t *testing.T) {
+	ctx := cdcContext.NewBackendContext4Test(true)
+	manager := newFeedStateManager4Test(200, 1600, 0, 2.0)
+	state := orchestrator.NewChangefeedReactorState(etcd.DefaultCDCClusterID,
+		ctx.ChangefeedVars().ID)
+	tester := orchestrator.NewReactorStateTester(t, state, nil)
+	state.PatchInfo(func(info *model.ChangeFeedInfo) (*model.ChangeFeedInfo, bool, error) {
+		require.Nil(t, info)
+		cfg := config.GetDefaultReplicaConfig()
+		cfg.LifecycleHooks = &config.LifecycleHooksConfig{
+			FlushBeforePause:  true,
+			PauseFlushTimeout: time.Hour,
+		}
+		return &model.ChangeFeedInfo{SinkURI: "123", Config: cfg}, true, nil
+	})
+	state.PatchStatus(func(status *model.ChangeFeedStatus) (*model.ChangeFeedStatus, bool, error) {
+		require.Nil(t, status)
+		return &model.ChangeFeedStatus{CheckpointTs: 50, ResolvedTs: 100}, true, nil
+	})
+	tester.MustApplyPatches()
+	manager.Tick(state)
+	tester.MustApplyPatches()
+
+	// the changefeed keeps running until the data is flushed
+	manager.PushAdminJob(&model.AdminJob{
+		CfID: ctx.ChangefeedVars().ID,
+		Type: model.AdminStop,
+	})
+	require.False(t, manager.Tick(state))
+	tester.MustApplyPatches()
+	require.True(t, manager.ShouldRunning())
+	require.Equal(t, model.StateNormal, state.Info.State)
+	require.Equal(t, uint64(100), manager.pauseFlush.targetTs)
+
+	state.PatchStatus(func(status *model.ChangeFeedStatus) (*model.ChangeFeedStatus, bool, error) {
+		status.CheckpointTs = 80
+		status.ResolvedTs = 120
+		return status, true, nil
+	})
+	tester.MustApplyPatches()
+	manager.Tick(state)
+	tester.MustApplyPatches()
+	require.True(t, manager.ShouldRunning())
+
+	state.PatchStatus(func(status *model.ChangeFeedStatus) (*model.ChangeFeedStatus, bool, error) {
+		status.CheckpointTs = 100
+		return status, true, nil
+	})
+	tester.MustApplyPatches()
+	manager.Tick(state)
+	tester.MustApplyPatches()
+	require.False(t, manager.ShouldRunning())
+	require.Equal(t, model.StateStopped, state.Info.State)
+	require.Nil(t, manager.pauseFlush)
+
+	// the pause doesn't wait for the flush after the timeout
+	manager.PushAdminJob(&model.AdminJob{
+		CfID: ctx.ChangefeedVars().ID,
+		Type: model.AdminResume,
+	})
+	manager.Tick(state)
+	tester.MustApplyPatches()
+	require.Equal(t, model.StateNormal, state.Info.State)
+	manager.PushAdminJob(&model.AdminJob{
+		CfID: ctx.ChangefeedVars().ID,
+		Type: model.AdminStop,
+	})
+	manager.Tick(state)
+	tester.MustApplyPatches()
+	require.True(t, manager.ShouldRunning())
+	manager.pauseFlush.deadline = time.Now().Add(-time.Second)
+	manager.Tick(state)
+	tester.MustApplyPatches()
+	require.False(t, manager.ShouldRunning())
+	require.Equal(t, model.StateStopped, state.Info.State)
+}
+
+func TestCleanupBeforeRemove(t *testing.T) {
+	ctx := cdcContext.NewBackendContext4Test(true)
+	manager := newFeedStateManager4Test(200, 1600, 0, 2.0)
+	state := orchestrator.NewChangefeedReactorState(etcd.DefaultCDCClusterID,
+		ctx.ChangefeedVars().ID)
+	tester := orchestrator.NewReactorStateTester(t, state, nil)
+	state.PatchInfo(func(info *model.ChangeFeedInfo) (*model.ChangeFeedInfo, bool, error) {
+		require.Nil(t, info)
+		cfg := config.GetDefaultReplicaConfig()
+		cfg.LifecycleHooks = &config.LifecycleHooksConfig{
+			CleanupOnRemove:      true,
+			RemoveCleanupTimeout: time.Hour,
+			CleanupTargets:       []string{"topic"},
+		}
+		return &model.ChangeFeedInfo{SinkURI: "123", Config: cfg}, true, nil
+	})
+	state.PatchStatus(func(status *model.ChangeFeedStatus) (*model.ChangeFeedStatus, bool, error) {
+		require.Nil(t, status)
+		return &model.ChangeFeedStatus{}, true, nil
+	})
+	tester.MustApplyPatches()
+	manager.Tick(state)
+	tester.MustApplyPatches()
+
+	cleaned := make(chan struct{})
+	newDownstreamCleanup := func(info *model.ChangeFeedInfo) func(ctx context.Context) error {
+		require.Equal(t, "123", info.SinkURI)
+		return func(context.Context) error {
+			<-cleaned
+			return errors.New("fake error for test")
+		}
+	}
+	manager.newDownstreamCleanup = newDownstreamCleanup
+
+	// the changefeed is stopped before the cleanup
+	manager.PushAdminJob(&model.AdminJob{
+		CfID: ctx.ChangefeedVars().ID,
+		Type: model.AdminRemove,
+	})
+	require.True(t, manager.Tick(state))
+	tester.MustApplyPatches()
+	require.False(t, manager.ShouldRunning())
+	require.False(t, manager.ShouldRemoved())
+	require.Equal(t, model.StateStopped, state.Info.State)
+	require.True(t, state.Info.RemovalPending)
+
+	// the changefeed is kept until the cleanup is done
+	require.True(t, manager.Tick(state))
+	tester.MustApplyPatches()
+	require.NotNil(t, manager.removeCleanup)
+	require.True(t, manager.Tick(state))
+	tester.MustApplyPatches()
+	require.True(t, state.Exist())
+
+	// the removal is resumed by the next owner
+	nextManager := newFeedStateManager4Test(200, 1600, 0, 2.0)
+	nextManager.newDownstreamCleanup = newDownstreamCleanup
+	require.True(t, nextManager.Tick(state))
+	tester.MustApplyPatches()
+	require.NotNil(t, nextManager.removeCleanup)
+	require.False(t, nextManager.ShouldRunning())
+	require.True(t, state.Exist())
+
+	// the changefeed is removed even if the cleanup fails
+	close(cleaned)
+	require.Eventually(t, func() bool {
+		manager.Tick(state)
+		tester.MustApplyPatches()
+		return !state.Exist()
+	}, 5*time.Second, 10*time.Millisecond)
+	require.True(t, manager.ShouldRemoved())
+}
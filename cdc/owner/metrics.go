@@ -120,6 +120,74 @@ var (
 			Name:      "ignored_ddl_event_count",
 			Help:      "The total count of ddl events that are ignored in changefeed.",
 		}, []string{"namespace", "changefeed"})
+	changefeedStatusPersistCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "ticdc",
+			Subsystem: "owner",
+			Name:      "status_persist_count",
+			Help: "The total count of changefeed status updates, " +
+				"which are either written to etcd or coalesced.",
+		}, []string{"namespace", "changefeed", "type"})
+	changefeedSkippedDDLEventCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "ticdc",
+			Subsystem: "owner",
+			Name:      "skipped_ddl_event_count",
+			Help:      "The total count of ddl events that are not sent to the sink, by reason.",
+		}, []string{"namespace", "changefeed", "reason"})
+	changefeedDDLQueueDepthGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "ticdc",
+			Subsystem: "owner",
+			Name:      "ddl_queue_depth",
+			Help:      "The number of ddl jobs waiting to be executed in changefeeds",
+		}, []string{"namespace", "changefeed"})
+	changefeedDDLBlockedDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "ticdc",
+			Subsystem: "owner",
+			Name:      "ddl_blocked_duration",
+			Help: "Bucketed histogram of the time (s) a ddl job waits for " +
+				"the checkpoint to reach its barrier ts.",
+			Buckets: prometheus.ExponentialBuckets(0.01 /* 10 ms */, 2, 18),
+		}, []string{"namespace", "changefeed"})
+	changefeedCoalescedDDLCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "ticdc",
+			Subsystem: "owner",
+			Name:      "coalesced_ddl_job_count",
+			Help: "The total count of ddl jobs executed in the same sink write " +
+				"as an earlier one, without waiting for the checkpoint to reach their barrier ts.",
+		}, []string{"namespace", "changefeed"})
+	changefeedDDLExecDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "ticdc",
+			Subsystem: "owner",
+			Name:      "ddl_exec_duration",
+			Help:      "Bucketed histogram of the ddl execution time (s) in sinks.",
+			Buckets:   prometheus.ExponentialBuckets(0.01 /* 10 ms */, 2, 18),
+		}, []string{"namespace", "changefeed", "sink"})
+)
+
+const (
+	// skippedDDLReasonIneligible means the ddl event belongs to an ineligible table.
+	skippedDDLReasonIneligible = "ineligible"
+	// skippedDDLReasonBDRMode means ddl events are not replicated in bdr mode.
+	skippedDDLReasonBDRMode = "bdr-mode"
+	// skippedDDLReasonBeforeTableStart means the ddl event is committed
+	// at or before the start ts of its table.
+	skippedDDLReasonBeforeTableStart = "before-table-start"
+	// skippedDDLReasonFiltered means the ddl event is dropped by the event
+	// filter when it is built in the schema.
+	skippedDDLReasonFiltered = "filtered"
+)
+
+const (
+	// statusPersistTypeWrite means the status update is written to etcd.
+	statusPersistTypeWrite = "write"
+	// statusPersistTypeCoalesce means the status update is coalesced
+	// into a later write.
+	statusPersistTypeCoalesce = "coalesce"
 )
 
 const (
@@ -131,7 +199,7 @@ const (
 )
 
 // InitMetrics registers all metrics used in owner
-func InitMetrics(registry *prometheus.Registry) {
+func InitMetrics(registry prometheus.Registerer) {
 	registry.MustRegister(changefeedBarrierTsGauge)
 
 	registry.MustRegister(changefeedCheckpointTsGauge)
@@ -148,6 +216,12 @@ func InitMetrics(registry *prometheus.Registry) {
 	registry.MustRegister(changefeedTickDuration)
 	registry.MustRegister(changefeedCloseDuration)
 	registry.MustRegister(changefeedIgnoredDDLEventCounter)
+	registry.MustRegister(changefeedStatusPersistCounter)
+	registry.MustRegister(changefeedSkippedDDLEventCounter)
+	registry.MustRegister(changefeedDDLQueueDepthGauge)
+	registry.MustRegister(changefeedDDLBlockedDuration)
+	registry.MustRegister(changefeedCoalescedDDLCounter)
+	registry.MustRegister(changefeedDDLExecDuration)
 }
 
 // lagBucket returns the lag buckets for prometheus metric
@@ -51,6 +51,18 @@ func (mr *MockOwnerMockRecorder) AsyncStop() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AsyncStop", reflect.TypeOf((*MockOwner)(nil).AsyncStop))
 }
 
+// CreateSavepoint mocks base method.
+func (m *MockOwner) CreateSavepoint(cfID model.ChangeFeedID, savepoint *model.Savepoint, done chan<- error) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "CreateSavepoint", cfID, savepoint, done)
+}
+
+// CreateSavepoint indicates an expected call of CreateSavepoint.
+func (mr *MockOwnerMockRecorder) CreateSavepoint(cfID, savepoint, done interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSavepoint", reflect.TypeOf((*MockOwner)(nil).CreateSavepoint), cfID, savepoint, done)
+}
+
 // DrainCapture mocks base method.
 func (m *MockOwner) DrainCapture(query *scheduler.Query, done chan<- error) {
 	m.ctrl.T.Helper()
@@ -126,6 +138,18 @@ func (mr *MockOwnerMockRecorder) Tick(ctx, state interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Tick", reflect.TypeOf((*MockOwner)(nil).Tick), ctx, state)
 }
 
+// UpdateSinkURI mocks base method.
+func (m *MockOwner) UpdateSinkURI(cfID model.ChangeFeedID, sinkURI string, done chan<- error) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "UpdateSinkURI", cfID, sinkURI, done)
+}
+
+// UpdateSinkURI indicates an expected call of UpdateSinkURI.
+func (mr *MockOwnerMockRecorder) UpdateSinkURI(cfID, sinkURI, done interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSinkURI", reflect.TypeOf((*MockOwner)(nil).UpdateSinkURI), cfID, sinkURI, done)
+}
+
 // ValidateChangefeed mocks base method.
 func (m *MockOwner) ValidateChangefeed(info *model.ChangeFeedInfo) error {
 	m.ctrl.T.Helper()
@@ -110,6 +110,51 @@ func (mr *MockStatusProviderMockRecorder) GetChangeFeedInfo(ctx, changefeedID in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChangeFeedInfo", reflect.TypeOf((*MockStatusProvider)(nil).GetChangeFeedInfo), ctx, changefeedID)
 }
 
+// GetChangeFeedLagBreakdown mocks base method.
+func (m *MockStatusProvider) GetChangeFeedLagBreakdown(ctx context.Context, changefeedID model.ChangeFeedID) (*model.LagBreakdown, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChangeFeedLagBreakdown", ctx, changefeedID)
+	ret0, _ := ret[0].(*model.LagBreakdown)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChangeFeedLagBreakdown indicates an expected call of GetChangeFeedLagBreakdown.
+func (mr *MockStatusProviderMockRecorder) GetChangeFeedLagBreakdown(ctx, changefeedID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChangeFeedLagBreakdown", reflect.TypeOf((*MockStatusProvider)(nil).GetChangeFeedLagBreakdown), ctx, changefeedID)
+}
+
+// GetChangeFeedWarnings mocks base method.
+func (m *MockStatusProvider) GetChangeFeedWarnings(ctx context.Context, changefeedID model.ChangeFeedID) ([]*model.RunningWarning, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChangeFeedWarnings", ctx, changefeedID)
+	ret0, _ := ret[0].([]*model.RunningWarning)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChangeFeedWarnings indicates an expected call of GetChangeFeedWarnings.
+func (mr *MockStatusProviderMockRecorder) GetChangeFeedWarnings(ctx, changefeedID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChangeFeedWarnings", reflect.TypeOf((*MockStatusProvider)(nil).GetChangeFeedWarnings), ctx, changefeedID)
+}
+
+// GetChangeFeedSinkStatuses mocks base method.
+func (m *MockStatusProvider) GetChangeFeedSinkStatuses(ctx context.Context, changefeedID model.ChangeFeedID) ([]*model.SinkStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChangeFeedSinkStatuses", ctx, changefeedID)
+	ret0, _ := ret[0].([]*model.SinkStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChangeFeedSinkStatuses indicates an expected call of GetChangeFeedSinkStatuses.
+func (mr *MockStatusProviderMockRecorder) GetChangeFeedSinkStatuses(ctx, changefeedID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChangeFeedSinkStatuses", reflect.TypeOf((*MockStatusProvider)(nil).GetChangeFeedSinkStatuses), ctx, changefeedID)
+}
+
 // GetChangeFeedStatus mocks base method.
 func (m *MockStatusProvider) GetChangeFeedStatus(ctx context.Context, changefeedID model.ChangeFeedID) (*model.ChangeFeedStatus, error) {
 	m.ctrl.T.Helper()
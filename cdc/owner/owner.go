@@ -16,6 +16,7 @@ package owner
 import (
 	"context"
 	"io"
+	"math/rand"
 	"net/url"
 	"os"
 	"sync"
@@ -31,8 +32,11 @@ import (
 	cdcContext "github.com/pingcap/tiflow/pkg/context"
 	cerror "github.com/pingcap/tiflow/pkg/errors"
 	"github.com/pingcap/tiflow/pkg/orchestrator"
+	pmysql "github.com/pingcap/tiflow/pkg/sink/mysql"
 	"github.com/pingcap/tiflow/pkg/upstream"
+	"github.com/pingcap/tiflow/pkg/util"
 	"github.com/pingcap/tiflow/pkg/version"
+	"github.com/pingcap/tiflow/pkg/warning"
 	"go.uber.org/zap"
 	"golang.org/x/time/rate"
 )
@@ -47,6 +51,8 @@ const (
 	ownerJobTypeAdminJob
 	ownerJobTypeDebugInfo
 	ownerJobTypeQuery
+	ownerJobTypeUpdateSinkURI
+	ownerJobTypeCreateSavepoint
 )
 
 // versionInconsistentLogRate represents the rate of log output when there are
@@ -76,6 +82,12 @@ type ownerJob struct {
 	// for Admin Job only
 	AdminJob *model.AdminJob
 
+	// for UpdateSinkURI only
+	SinkURI string
+
+	// for CreateSavepoint only
+	Savepoint *model.Savepoint
+
 	// for debug info only
 	debugInfoWriter io.Writer
 
@@ -102,6 +114,8 @@ type Owner interface {
 	DrainCapture(query *scheduler.Query, done chan<- error)
 	WriteDebugInfo(w io.Writer, done chan<- error)
 	Query(query *Query, done chan<- error)
+	UpdateSinkURI(cfID model.ChangeFeedID, sinkURI string, done chan<- error)
+	CreateSavepoint(cfID model.ChangeFeedID, savepoint *model.Savepoint, done chan<- error)
 	ValidateChangefeed(info *model.ChangeFeedInfo) error
 	AsyncStop()
 }
@@ -141,6 +155,13 @@ type ownerImpl struct {
 	// TODO: remove these fields after the issue is resolved.
 	removedChangefeed map[model.ChangeFeedID]time.Time
 	removedSinkURI    map[url.URL]time.Time
+
+	// Changefeed status updates are persisted in shared rounds, so status
+	// writes of all changefeeds are committed together in batched etcd
+	// transactions instead of one transaction per changefeed.
+	statusPersistInterval time.Duration
+	statusPersistJitter   time.Duration
+	nextStatusPersistTime time.Time
 }
 
 // NewOwner creates a new Owner
@@ -148,6 +169,7 @@ func NewOwner(
 	upstreamManager *upstream.Manager,
 	cfg *config.SchedulerConfig,
 ) Owner {
+	serverCfg := config.GetGlobalServerConfig()
 	return &ownerImpl{
 		upstreamManager:   upstreamManager,
 		changefeeds:       make(map[model.ChangeFeedID]*changefeed),
@@ -157,6 +179,9 @@ func NewOwner(
 		removedChangefeed: make(map[model.ChangeFeedID]time.Time),
 		removedSinkURI:    make(map[url.URL]time.Time),
 		cfg:               cfg,
+
+		statusPersistInterval: time.Duration(serverCfg.CheckpointPersistInterval),
+		statusPersistJitter:   time.Duration(serverCfg.CheckpointPersistJitter),
 	}
 }
 
@@ -198,6 +223,8 @@ func (o *ownerImpl) Tick(stdCtx context.Context, rawState orchestrator.ReactorSt
 
 	// Tick all changefeeds.
 	ctx := stdCtx.(cdcContext.Context)
+	groupBarriers := calculateConsistencyGroupBarriers(state.Changefeeds)
+	coalesceStatus := !o.isStatusPersistRound(time.Now())
 	for changefeedID, changefeedState := range state.Changefeeds {
 		if changefeedState.Info == nil {
 			o.cleanUpChangefeed(changefeedState)
@@ -219,6 +246,8 @@ func (o *ownerImpl) Tick(stdCtx context.Context, rawState orchestrator.ReactorSt
 		ctx = cdcContext.WithChangefeedVars(ctx, &cdcContext.ChangefeedVars{
 			ID: changefeedID,
 		})
+		cfReactor.consistencyGroupBarrierTs = groupBarriers[changefeedID]
+		cfReactor.coalesceStatus = coalesceStatus
 		cfReactor.Tick(ctx, state.Captures)
 	}
 	o.changefeedTicked = true
@@ -313,6 +342,34 @@ func (o *ownerImpl) Query(query *Query, done chan<- error) {
 	})
 }
 
+// UpdateSinkURI updates the sink URI of a running changefeed, only the
+// credentials of the sink URI can be updated.
+// `done` must be buffered to prevent blocking owner.
+func (o *ownerImpl) UpdateSinkURI(
+	cfID model.ChangeFeedID, sinkURI string, done chan<- error,
+) {
+	o.pushOwnerJob(&ownerJob{
+		Tp:           ownerJobTypeUpdateSinkURI,
+		ChangefeedID: cfID,
+		SinkURI:      sinkURI,
+		done:         done,
+	})
+}
+
+// CreateSavepoint creates a savepoint of a running changefeed, the ts of
+// the savepoint is filled once done is closed without an error.
+// `done` must be buffered to prevent blocking owner.
+func (o *ownerImpl) CreateSavepoint(
+	cfID model.ChangeFeedID, savepoint *model.Savepoint, done chan<- error,
+) {
+	o.pushOwnerJob(&ownerJob{
+		Tp:           ownerJobTypeCreateSavepoint,
+		ChangefeedID: cfID,
+		Savepoint:    savepoint,
+		done:         done,
+	})
+}
+
 func (o *ownerImpl) ValidateChangefeed(info *model.ChangeFeedInfo) error {
 	o.ownerJobQueue.Lock()
 	defer o.ownerJobQueue.Unlock()
@@ -404,6 +461,24 @@ func (o *ownerImpl) cleanStaleMetrics() {
 	changefeedStatusGauge.Reset()
 }
 
+// isStatusPersistRound returns true if changefeeds should persist their
+// status in the current tick. A random jitter is added to each round to
+// avoid persisting in lockstep with other periodic etcd writes.
+func (o *ownerImpl) isStatusPersistRound(now time.Time) bool {
+	if o.statusPersistInterval <= 0 {
+		return true
+	}
+	if now.Before(o.nextStatusPersistTime) {
+		return false
+	}
+	interval := o.statusPersistInterval
+	if o.statusPersistJitter > 0 {
+		interval += time.Duration(rand.Int63n(int64(o.statusPersistJitter)))
+	}
+	o.nextStatusPersistTime = now.Add(interval)
+	return true
+}
+
 func (o *ownerImpl) updateMetrics() {
 	// Keep the value of prometheus expression `rate(counter)` = 1
 	// Please also change alert rule in ticdc.rules.yml when change the expression value.
@@ -559,6 +634,10 @@ func (o *ownerImpl) handleJobs(ctx context.Context) {
 			job.done <- o.handleQueries(job.query)
 		case ownerJobTypeDebugInfo:
 			// TODO: implement this function
+		case ownerJobTypeUpdateSinkURI:
+			job.done <- o.handleUpdateSinkURI(cfReactor, job.SinkURI)
+		case ownerJobTypeCreateSavepoint:
+			job.done <- cfReactor.createSavepoint(job.Savepoint)
 		}
 		close(job.done)
 	}
@@ -576,6 +655,28 @@ func (o *ownerImpl) handleJobs(ctx context.Context) {
 	}
 }
 
+func (o *ownerImpl) handleUpdateSinkURI(cfReactor *changefeed, sinkURI string) error {
+	if cfReactor.state == nil || cfReactor.state.Info == nil {
+		return cerror.ErrChangeFeedNotExists.GenWithStackByArgs(cfReactor.id)
+	}
+	if !pmysql.IsCredentialsOnlyChange(cfReactor.state.Info.SinkURI, sinkURI) {
+		return cerror.ErrSinkCredentialsNotUpdatable.GenWithStackByArgs(
+			"only the credentials of a MySQL compatible sink can be updated")
+	}
+	cfReactor.state.PatchInfo(
+		func(info *model.ChangeFeedInfo) (*model.ChangeFeedInfo, bool, error) {
+			if info == nil {
+				return nil, false, nil
+			}
+			info.SinkURI = sinkURI
+			return info, true, nil
+		})
+	log.Info("owner updates the credentials of the sink",
+		zap.String("namespace", cfReactor.id.Namespace),
+		zap.String("changefeed", cfReactor.id.ID))
+	return nil
+}
+
 func (o *ownerImpl) handleQueries(query *Query) error {
 	switch query.Tp {
 	case QueryAllChangeFeedStatuses:
@@ -591,6 +692,13 @@ func (o *ownerImpl) handleQueries(query *Query) error {
 			ret[cfID].ResolvedTs = cfReactor.state.Status.ResolvedTs
 			ret[cfID].CheckpointTs = cfReactor.state.Status.CheckpointTs
 			ret[cfID].AdminJobType = cfReactor.state.Status.AdminJobType
+			ret[cfID].SinkSelfCheck = cfReactor.state.Status.SinkSelfCheck
+			ret[cfID].Savepoints = cfReactor.state.Status.Savepoints
+			if cfReactor.sink != nil {
+				ret[cfID].ConsumerGroupLag = cfReactor.sink.consumerGroupLag()
+			}
+			ret[cfID].Restart = cfReactor.feedStateManager.restartStatus(time.Now())
+			ret[cfID].SubState = subState(cfReactor.state)
 		}
 		query.Data = ret
 	case QueryAllChangeFeedInfo:
@@ -665,10 +773,91 @@ func (o *ownerImpl) handleQueries(query *Query) error {
 		query.Data = ret
 	case QueryHealth:
 		query.Data = o.isHealthy()
+	case QueryLagBreakdown:
+		cfReactor, ok := o.changefeeds[query.ChangeFeedID]
+		if !ok || cfReactor.state == nil {
+			return cerror.ErrChangeFeedNotExists.GenWithStackByArgs(query.ChangeFeedID)
+		}
+		provider := cfReactor.GetInfoProvider()
+		if provider == nil {
+			// The scheduler has not been initialized yet.
+			return cerror.ErrChangeFeedNotExists.GenWithStackByArgs(query.ChangeFeedID)
+		}
+		ret, err := provider.GetLagBreakdown()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		query.Data = ret
+	case QueryWarnings:
+		cfReactor, ok := o.changefeeds[query.ChangeFeedID]
+		if !ok || cfReactor.state == nil {
+			return cerror.ErrChangeFeedNotExists.GenWithStackByArgs(query.ChangeFeedID)
+		}
+		warningsList := make([][]*model.RunningWarning, 0, len(cfReactor.state.TaskPositions))
+		for _, position := range cfReactor.state.TaskPositions {
+			warningsList = append(warningsList, position.Warnings)
+		}
+		query.Data = warning.Merge(warningsList...)
+	case QuerySinkStatuses:
+		cfReactor, ok := o.changefeeds[query.ChangeFeedID]
+		if !ok || cfReactor.state == nil || cfReactor.state.Info == nil {
+			return cerror.ErrChangeFeedNotExists.GenWithStackByArgs(query.ChangeFeedID)
+		}
+		query.Data = sinkStatuses(cfReactor.state)
 	}
 	return nil
 }
 
+// subState returns the sub-state of a normal changefeed reported by the
+// processors.
+func subState(state *orchestrator.ChangefeedReactorState) string {
+	if state.Info == nil || state.Info.State != model.StateNormal {
+		return ""
+	}
+	for _, position := range state.TaskPositions {
+		if position.SinkPaused {
+			return model.SubStateSinkPaused
+		}
+	}
+	return ""
+}
+
+// sinkStatuses returns the statuses of the primary sink and the extra sinks
+// of a changefeed. The checkpoint of a sink is the min one reported by the
+// processors, and it's never less than the checkpoint of the changefeed.
+func sinkStatuses(state *orchestrator.ChangefeedReactorState) []*model.SinkStatus {
+	var checkpointTs uint64
+	if state.Status != nil {
+		checkpointTs = state.Status.CheckpointTs
+	}
+	newStatus := func(name, sinkURI string) *model.SinkStatus {
+		status := &model.SinkStatus{
+			Name:         name,
+			SinkURI:      util.MaskSinkURIForLog(sinkURI),
+			CheckpointTs: checkpointTs,
+		}
+		var minTs uint64
+		for _, position := range state.TaskPositions {
+			ts, ok := position.SinkCheckpoints[name]
+			if ok && (minTs == 0 || ts < minTs) {
+				minTs = ts
+			}
+		}
+		if minTs > status.CheckpointTs {
+			status.CheckpointTs = minTs
+		}
+		return status
+	}
+
+	statuses := []*model.SinkStatus{newStatus(config.PrimarySinkName, state.Info.SinkURI)}
+	if state.Info.Config != nil && state.Info.Config.Sink != nil {
+		for _, extra := range state.Info.Config.Sink.ExtraSinks {
+			statuses = append(statuses, newStatus(extra.Name, extra.SinkURI))
+		}
+	}
+	return statuses
+}
+
 func (o *ownerImpl) isHealthy() bool {
 	if !o.changefeedTicked {
 		// Owner has not yet tick changefeeds, some changefeeds may be not
@@ -14,11 +14,18 @@
 package owner
 
 import (
+	"bytes"
+	"fmt"
+	"strings"
+
 	"github.com/pingcap/errors"
 	"github.com/pingcap/log"
+	"github.com/pingcap/tidb/executor"
 	tidbkv "github.com/pingcap/tidb/kv"
 	timeta "github.com/pingcap/tidb/meta"
+	"github.com/pingcap/tidb/meta/autoid"
 	timodel "github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/util/mock"
 	"github.com/pingcap/tiflow/cdc/entry/schema"
 	"github.com/pingcap/tiflow/cdc/kv"
 	"github.com/pingcap/tiflow/cdc/model"
@@ -39,6 +46,7 @@ type schemaWrap4Owner struct {
 	schemaVersion               int64
 	id                          model.ChangeFeedID
 	metricIgnoreDDLEventCounter prometheus.Counter
+	metricSkippedFilteredDDL    prometheus.Counter
 }
 
 func newSchemaWrap4Owner(
@@ -79,6 +87,8 @@ func newSchemaWrap4Owner(
 		id:             id,
 		metricIgnoreDDLEventCounter: changefeedIgnoredDDLEventCounter.
 			WithLabelValues(id.Namespace, id.ID),
+		metricSkippedFilteredDDL: changefeedSkippedDDLEventCounter.
+			WithLabelValues(id.Namespace, id.ID, skippedDDLReasonFiltered),
 	}, nil
 }
 
@@ -105,6 +115,30 @@ func (s *schemaWrap4Owner) AllPhysicalTables() []model.TableID {
 	return s.allPhysicalTablesCache
 }
 
+// PhysicalTablesStartedAt returns the physical tables that are being replicated
+// and whose start ts is not greater than ts.
+func (s *schemaWrap4Owner) PhysicalTablesStartedAt(
+	ts model.Ts, startTsOf func(schema, table string) model.Ts,
+) []model.TableID {
+	tables := make([]model.TableID, 0)
+	s.schemaSnapshot.IterTables(true, func(tblInfo *model.TableInfo) {
+		if s.shouldIgnoreTable(tblInfo) {
+			return
+		}
+		if startTsOf(tblInfo.TableName.Schema, tblInfo.TableName.Table) > ts {
+			return
+		}
+		if pi := tblInfo.GetPartitionInfo(); pi != nil {
+			for _, partition := range pi.Definitions {
+				tables = append(tables, partition.ID)
+			}
+		} else {
+			tables = append(tables, tblInfo.ID)
+		}
+	})
+	return tables
+}
+
 // AllTableNames returns table info of all tables that are being replicated.
 func (s *schemaWrap4Owner) AllTables() []*model.TableInfo {
 	tables := make([]*model.TableInfo, 0, len(s.allPhysicalTablesCache))
@@ -233,6 +267,9 @@ func (s *schemaWrap4Owner) BuildDDLEvents(
 			}
 		}
 		event.FromJob(job, preTableInfo, tableInfo)
+		if err = s.rewriteTableSelection(event); err != nil {
+			return nil, errors.Trace(err)
+		}
 		ddlEvents = append(ddlEvents, event)
 	}
 	// filter out ddl here
@@ -244,6 +281,7 @@ func (s *schemaWrap4Owner) BuildDDLEvents(
 		}
 		if ignored {
 			s.metricIgnoreDDLEventCounter.Inc()
+			s.metricSkippedFilteredDDL.Inc()
 			log.Info(
 				"DDL event ignored",
 				zap.String("namespace", s.id.Namespace),
@@ -259,10 +297,75 @@ func (s *schemaWrap4Owner) BuildDDLEvents(
 	return res, nil
 }
 
+// tableAttributeActions are the DDL actions which change the attributes of
+// a table that can select it into or out of a changefeed.
+var tableAttributeActions = map[timodel.ActionType]struct{}{
+	timodel.ActionModifyTableComment:  {},
+	timodel.ActionAlterTablePlacement: {},
+}
+
+// rewriteTableSelection turns a DDL that opts a table into or out of the
+// changefeed by its attributes into an explicit CREATE TABLE or DROP TABLE,
+// otherwise the downstream would receive the DDLs and DMLs of a table it
+// doesn't have, or the replication of the table would stop silently.
+// Note the rows written before the table is opted in are not replicated,
+// they must be snapshotted to the downstream, e.g. by dumpling.
+func (s *schemaWrap4Owner) rewriteTableSelection(event *model.DDLEvent) error {
+	if _, ok := tableAttributeActions[event.Type]; !ok {
+		return nil
+	}
+	if event.PreTableInfo == nil || event.TableInfo == nil || event.TableInfo.TableInfo == nil {
+		return nil
+	}
+	selectedBefore := !s.filter.ShouldIgnoreTableInfo(event.PreTableInfo)
+	selectedAfter := !s.filter.ShouldIgnoreTableInfo(event.TableInfo)
+	switch {
+	case !selectedBefore && selectedAfter:
+		query, err := showCreateTable(event.TableInfo)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		log.Warn("table is opted into the changefeed by its attributes, "+
+			"the rows written before must be snapshotted to the downstream",
+			zap.String("namespace", s.id.Namespace),
+			zap.String("changefeed", s.id.ID),
+			zap.Stringer("tableName", event.TableInfo.TableName),
+			zap.Uint64("commitTs", event.CommitTs))
+		event.Type = timodel.ActionCreateTable
+		event.Query = query
+		event.PreTableInfo = nil
+	case selectedBefore && !selectedAfter:
+		log.Warn("table is opted out of the changefeed by its attributes, "+
+			"it's dropped in the downstream",
+			zap.String("namespace", s.id.Namespace),
+			zap.String("changefeed", s.id.ID),
+			zap.Stringer("tableName", event.PreTableInfo.TableName),
+			zap.Uint64("commitTs", event.CommitTs))
+		event.Type = timodel.ActionDropTable
+		event.Query = fmt.Sprintf("DROP TABLE `%s`.`%s`",
+			event.PreTableInfo.TableName.Schema, event.PreTableInfo.TableName.Table)
+		// The filter checks the DDL by the table info, which is not selected
+		// anymore, so the table info before the change is used instead.
+		event.TableInfo = event.PreTableInfo
+		event.PreTableInfo = nil
+	}
+	return nil
+}
+
+// showCreateTable returns the CREATE TABLE statement of the table in one line.
+func showCreateTable(tableInfo *model.TableInfo) (string, error) {
+	result := bytes.NewBuffer(make([]byte, 0, 512))
+	err := executor.ConstructResultOfShowCreateTable(
+		mock.NewContext(), tableInfo.TableInfo, autoid.Allocators{}, result)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	query := strings.ReplaceAll(result.String(), "\n", "")
+	return strings.ReplaceAll(query, "  ", " "), nil
+}
+
 func (s *schemaWrap4Owner) shouldIgnoreTable(t *model.TableInfo) bool {
-	schemaName := t.TableName.Schema
-	tableName := t.TableName.Table
-	if s.filter.ShouldIgnoreTable(schemaName, tableName) {
+	if s.filter.ShouldIgnoreTableInfo(t) {
 		return true
 	}
 	if !t.IsEligible(s.config.ForceReplicate) {
@@ -25,6 +25,7 @@ import (
 	"github.com/pingcap/tiflow/cdc/entry"
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/pkg/config"
+	dto "github.com/prometheus/client_model/go"
 	"github.com/stretchr/testify/require"
 	"github.com/tikv/client-go/v2/oracle"
 )
@@ -553,8 +554,13 @@ func TestBuildIgnoredDDLJob(t *testing.T) {
 	// only replicate ddl event of test.tb1 and test.tb2
 	cfg.Filter.Rules = []string{"test.tb1", "test.tb2"}
 	schema, err := newSchemaWrap4Owner(helper.Storage(), ver.Ver,
-		cfg, dummyChangeFeedID)
+		cfg, model.DefaultChangeFeedID("test-build-ignored-ddl"))
 	require.Nil(t, err)
+	skipped := func() float64 {
+		m := &dto.Metric{}
+		require.Nil(t, schema.metricSkippedFilteredDDL.Write(m))
+		return m.GetCounter().GetValue()
+	}
 
 	// test case 1: Will not filter out create test.tb1 ddl.
 	job := helper.DDL2Job("create table test.tb1(id int primary key)")
@@ -596,4 +602,55 @@ func TestBuildIgnoredDDLJob(t *testing.T) {
 	events, err = schema.BuildDDLEvents(job)
 	require.Nil(t, err)
 	require.Len(t, events, 0)
+
+	// The filtered DDLs are counted as skipped.
+	require.Equal(t, float64(2), skipped())
+}
+
+func TestBuildDDLEventsByTableAttributes(t *testing.T) {
+	helper := entry.NewSchemaTestHelper(t)
+	defer helper.Close()
+	ver, err := helper.Storage().CurrentVersion(oracle.GlobalTxnScope)
+	require.Nil(t, err)
+	cfg := config.GetDefaultReplicaConfig()
+	cfg.Filter.TableAttributes = &config.TableAttributesConfig{CommentTags: []string{"cdc"}}
+	schema, err := newSchemaWrap4Owner(helper.Storage(), ver.Ver, cfg, dummyChangeFeedID)
+	require.Nil(t, err)
+
+	job := helper.DDL2Job("create table test.t1(id int primary key)")
+	events, err := schema.BuildDDLEvents(job)
+	require.Nil(t, err)
+	require.Len(t, events, 0)
+	require.Nil(t, schema.HandleDDL(job))
+	require.Len(t, schema.AllPhysicalTables(), 0)
+
+	// Tagging the table replicates it as a CREATE TABLE.
+	job = helper.DDL2Job("alter table test.t1 comment = 'cdc'")
+	events, err = schema.BuildDDLEvents(job)
+	require.Nil(t, err)
+	require.Len(t, events, 1)
+	require.Equal(t, timodel.ActionCreateTable, events[0].Type)
+	require.Regexp(t, "^CREATE TABLE `t1` \\(.*COMMENT='cdc'$", events[0].Query)
+	require.Nil(t, events[0].PreTableInfo)
+	require.Nil(t, schema.HandleDDL(job))
+	require.Len(t, schema.AllPhysicalTables(), 1)
+
+	// Changing the comment of a tagged table is replicated as it is.
+	job = helper.DDL2Job("alter table test.t1 comment = 'cdc orders'")
+	events, err = schema.BuildDDLEvents(job)
+	require.Nil(t, err)
+	require.Len(t, events, 1)
+	require.Equal(t, timodel.ActionModifyTableComment, events[0].Type)
+	require.Nil(t, schema.HandleDDL(job))
+
+	// Untagging the table replicates it as a DROP TABLE.
+	job = helper.DDL2Job("alter table test.t1 comment = 'orders'")
+	events, err = schema.BuildDDLEvents(job)
+	require.Nil(t, err)
+	require.Len(t, events, 1)
+	require.Equal(t, timodel.ActionDropTable, events[0].Type)
+	require.Equal(t, "DROP TABLE `test`.`t1`", events[0].Query)
+	require.Equal(t, "t1", events[0].TableInfo.TableName.Table)
+	require.Nil(t, schema.HandleDDL(job))
+	require.Len(t, schema.AllPhysicalTables(), 0)
 }
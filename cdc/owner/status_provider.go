@@ -47,6 +47,18 @@ type StatusProvider interface {
 
 	// IsHealthy return true if the cluster is healthy
 	IsHealthy(ctx context.Context) (bool, error)
+
+	// GetChangeFeedLagBreakdown returns the per-stage replication lag
+	// of a changefeed.
+	GetChangeFeedLagBreakdown(ctx context.Context, changefeedID model.ChangeFeedID) (*model.LagBreakdown, error)
+
+	// GetChangeFeedWarnings returns the warnings of a changefeed
+	// reported by its processors.
+	GetChangeFeedWarnings(ctx context.Context, changefeedID model.ChangeFeedID) ([]*model.RunningWarning, error)
+
+	// GetChangeFeedSinkStatuses returns the statuses of the primary sink
+	// and the extra sinks of a changefeed.
+	GetChangeFeedSinkStatuses(ctx context.Context, changefeedID model.ChangeFeedID) ([]*model.SinkStatus, error)
 }
 
 // QueryType is the type of different queries.
@@ -65,6 +77,12 @@ const (
 	QueryCaptures
 	// QueryHealth is the type of query cluster health info.
 	QueryHealth
+	// QueryLagBreakdown is the type of query changefeed lag breakdown.
+	QueryLagBreakdown
+	// QueryWarnings is the type of query changefeed warnings.
+	QueryWarnings
+	// QuerySinkStatuses is the type of query changefeed sink statuses.
+	QuerySinkStatuses
 )
 
 // Query wraps query command and return results.
@@ -169,6 +187,39 @@ func (p *ownerStatusProvider) IsHealthy(ctx context.Context) (bool, error) {
 	return query.Data.(bool), nil
 }
 
+func (p *ownerStatusProvider) GetChangeFeedLagBreakdown(ctx context.Context, changefeedID model.ChangeFeedID) (*model.LagBreakdown, error) {
+	query := &Query{
+		Tp:           QueryLagBreakdown,
+		ChangeFeedID: changefeedID,
+	}
+	if err := p.sendQueryToOwner(ctx, query); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return query.Data.(*model.LagBreakdown), nil
+}
+
+func (p *ownerStatusProvider) GetChangeFeedWarnings(ctx context.Context, changefeedID model.ChangeFeedID) ([]*model.RunningWarning, error) {
+	query := &Query{
+		Tp:           QueryWarnings,
+		ChangeFeedID: changefeedID,
+	}
+	if err := p.sendQueryToOwner(ctx, query); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return query.Data.([]*model.RunningWarning), nil
+}
+
+func (p *ownerStatusProvider) GetChangeFeedSinkStatuses(ctx context.Context, changefeedID model.ChangeFeedID) ([]*model.SinkStatus, error) {
+	query := &Query{
+		Tp:           QuerySinkStatuses,
+		ChangeFeedID: changefeedID,
+	}
+	if err := p.sendQueryToOwner(ctx, query); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return query.Data.([]*model.SinkStatus), nil
+}
+
 func (p *ownerStatusProvider) sendQueryToOwner(ctx context.Context, query *Query) error {
 	doneCh := make(chan error, 1)
 	p.owner.Query(query, doneCh)
@@ -0,0 +1,90 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package owner
+
+import (
+	"math"
+
+	"github.com/pingcap/errors"
+	tfilter "github.com/pingcap/tidb/util/table-filter"
+	"github.com/pingcap/tiflow/cdc/model"
+)
+
+// tableStartTs resolves the start ts of tables by the table start ts rules
+// of a changefeed. Tables not matched by any rule start at the start ts of
+// the changefeed, the first matched rule is used if there are several.
+type tableStartTs struct {
+	defaultStartTs model.Ts
+	filters        []tfilter.Filter
+	startTs        []model.Ts
+	maxStartTs     model.Ts
+}
+
+// newTableStartTs creates a tableStartTs, nil is returned if there is no
+// table start ts rule in the changefeed.
+func newTableStartTs(info *model.ChangeFeedInfo) (*tableStartTs, error) {
+	if info.Config == nil || info.Config.Filter == nil ||
+		len(info.Config.Filter.TableStartTs) == 0 {
+		return nil, nil
+	}
+	t := &tableStartTs{
+		defaultStartTs: info.GetStartTs(),
+		maxStartTs:     info.GetStartTs(),
+	}
+	for _, rule := range info.Config.Filter.TableStartTs {
+		f, err := tfilter.Parse(rule.Matcher)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if !info.Config.CaseSensitive {
+			f = tfilter.CaseInsensitive(f)
+		}
+		t.filters = append(t.filters, f)
+		t.startTs = append(t.startTs, rule.StartTs)
+		if rule.StartTs > t.maxStartTs {
+			t.maxStartTs = rule.StartTs
+		}
+	}
+	return t, nil
+}
+
+// get returns the start ts of the given table.
+func (t *tableStartTs) get(schema, table string) model.Ts {
+	for i, f := range t.filters {
+		if f.MatchTable(schema, table) {
+			return t.startTs[i]
+		}
+	}
+	return t.defaultStartTs
+}
+
+// allStartedAt returns true if all tables start at or before ts.
+func (t *tableStartTs) allStartedAt(ts model.Ts) bool {
+	return ts >= t.maxStartTs
+}
+
+// nextBarrierTs returns the min start ts which is greater than ts,
+// math.MaxUint64 is returned if there is no such start ts.
+func (t *tableStartTs) nextBarrierTs(ts model.Ts) model.Ts {
+	next := model.Ts(math.MaxUint64)
+	if t.defaultStartTs > ts {
+		next = t.defaultStartTs
+	}
+	for _, startTs := range t.startTs {
+		if startTs > ts && startTs < next {
+			next = startTs
+		}
+	}
+	return next
+}
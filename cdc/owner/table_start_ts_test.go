@@ -0,0 +1,75 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package owner
+
+import (
+	"math"
+	"testing"
+
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTableStartTs(t *testing.T) {
+	t.Parallel()
+
+	info := &model.ChangeFeedInfo{
+		StartTs: 100,
+		Config:  config.GetDefaultReplicaConfig(),
+	}
+	ts, err := newTableStartTs(info)
+	require.Nil(t, err)
+	require.Nil(t, ts)
+
+	info.Config.CaseSensitive = false
+	info.Config.Filter.TableStartTs = []*config.TableStartTsRule{
+		{Matcher: []string{"test.t1"}, StartTs: 50},
+		{Matcher: []string{"test.*"}, StartTs: 80},
+		{Matcher: []string{"test2.*"}, StartTs: 120},
+	}
+	ts, err = newTableStartTs(info)
+	require.Nil(t, err)
+	require.Equal(t, uint64(50), ts.get("test", "T1"))
+	require.Equal(t, uint64(80), ts.get("test", "t2"))
+	require.Equal(t, uint64(120), ts.get("test2", "t1"))
+	require.Equal(t, uint64(100), ts.get("test3", "t1"))
+
+	require.Equal(t, uint64(80), ts.nextBarrierTs(50))
+	require.Equal(t, uint64(100), ts.nextBarrierTs(80))
+	require.Equal(t, uint64(120), ts.nextBarrierTs(100))
+	require.Equal(t, uint64(math.MaxUint64), ts.nextBarrierTs(120))
+	require.False(t, ts.allStartedAt(100))
+	require.True(t, ts.allStartedAt(120))
+
+	// The changefeed starts from the min start ts.
+	require.Equal(t, uint64(50), info.GetCheckpointTs(nil))
+}
+
+func TestTableStartBarrier(t *testing.T) {
+	t.Parallel()
+
+	b := newBarriers()
+	b.Update(ddlJobBarrier, 200)
+	b.Update(finishBarrier, math.MaxUint64)
+	b.Update(tableStartBarrier, 80)
+	tp, ts := b.Min()
+	require.Equal(t, tableStartBarrier, tp)
+	require.Equal(t, uint64(80), ts)
+
+	b.Remove(tableStartBarrier)
+	tp, ts = b.Min()
+	require.Equal(t, ddlJobBarrier, tp)
+	require.Equal(t, uint64(200), ts)
+}
@@ -37,7 +37,7 @@ var (
 )
 
 // InitMetrics registers metrics the pipeline.
-func InitMetrics(registry *prometheus.Registry) {
+func InitMetrics(registry prometheus.Registerer) {
 	registry.MustRegister(SorterBatchReadSize)
 	registry.MustRegister(SorterBatchReadDuration)
 }
@@ -17,6 +17,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"reflect"
 	"strconv"
 	"sync"
 	"time"
@@ -37,17 +38,20 @@ import (
 	"github.com/pingcap/tiflow/cdc/scheduler"
 	sinkv1 "github.com/pingcap/tiflow/cdc/sink"
 	sinkmetric "github.com/pingcap/tiflow/cdc/sink/metrics"
+	"github.com/pingcap/tiflow/cdc/sinkv2/eventsink"
 	"github.com/pingcap/tiflow/cdc/sinkv2/eventsink/factory"
 	"github.com/pingcap/tiflow/pkg/config"
 	cdcContext "github.com/pingcap/tiflow/pkg/context"
 	cerror "github.com/pingcap/tiflow/pkg/errors"
 	"github.com/pingcap/tiflow/pkg/filter"
+	"github.com/pingcap/tiflow/pkg/initqueue"
 	"github.com/pingcap/tiflow/pkg/orchestrator"
 	"github.com/pingcap/tiflow/pkg/pdutil"
 	"github.com/pingcap/tiflow/pkg/retry"
 	"github.com/pingcap/tiflow/pkg/spanz"
 	"github.com/pingcap/tiflow/pkg/upstream"
 	"github.com/pingcap/tiflow/pkg/util"
+	"github.com/pingcap/tiflow/pkg/warning"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/tikv/client-go/v2/oracle"
 	"go.uber.org/zap"
@@ -56,6 +60,13 @@ import (
 const (
 	backoffBaseDelayInMs = 5
 	maxTries             = 3
+
+	// warningsPersistInterval is the minimal interval between two writes
+	// of the warnings into the task position.
+	warningsPersistInterval = 10 * time.Second
+	// sinkCheckpointsPersistInterval is the minimal interval between two
+	// writes of the sink checkpoints into the task position.
+	sinkCheckpointsPersistInterval = 5 * time.Second
 )
 
 type processor struct {
@@ -76,6 +87,9 @@ type processor struct {
 	tableSpans    *spanz.HashMap[tablepb.TablePipeline]
 	sinkV1        sinkv1.Sink
 	sinkV2Factory *factory.SinkFactory
+	// columnTransformer checks the column transforms against the tables
+	// before their table sinks are created, it's nil if there is none.
+	columnTransformer *eventsink.ColumnTransformer
 
 	// These fields are used to sinking data in pull-based mode.
 	sourceManager *sourcemanager.SourceManager
@@ -83,10 +97,22 @@ type processor struct {
 
 	redoManager redo.LogManager
 
+	// sinkURI is the sink URI which the sink is created or updated with.
+	sinkURI string
+	// lastWarningsPersistTime is the last time the warnings are persisted
+	// into the task position.
+	lastWarningsPersistTime time.Time
+	// lastSinkCheckpointsPersistTime is the last time the sink checkpoints
+	// are persisted into the task position.
+	lastSinkCheckpointsPersistTime time.Time
+
 	initialized bool
 	errCh       chan error
 	cancel      context.CancelFunc
 	wg          sync.WaitGroup
+	// initQueue is the queue which the processor waits in to be initialized,
+	// it's nil if the processor is not waiting.
+	initQueue *initqueue.Queue
 
 	lazyInit            func(ctx cdcContext.Context) error
 	createTablePipeline func(
@@ -210,6 +236,10 @@ func (p *processor) AddTableSpan(
 			zap.Bool("isPrepare", isPrepare))
 	}
 
+	if err := p.checkColumnTransforms(span.TableID); err != nil {
+		return false, errors.Trace(err)
+	}
+
 	if p.pullBasedSinking {
 		p.sinkManager.AddTable(
 			span, startTs, p.changefeed.Info.TargetTs)
@@ -688,6 +718,14 @@ func (p *processor) tick(ctx cdcContext.Context) error {
 	if err := p.lazyInit(ctx); err != nil {
 		return errors.Trace(err)
 	}
+	// the processor is not initialized until it's admitted by the queue.
+	if p.initQueue != nil {
+		return nil
+	}
+	p.updateSinkCredentials(ctx)
+	p.updateWarnings()
+	p.updateSinkPaused()
+	p.updateSinkCheckpoints()
 	p.pushResolvedTs2Table()
 
 	p.doGCSchemaStorage()
@@ -703,6 +741,129 @@ func (p *processor) tick(ctx cdcContext.Context) error {
 	return nil
 }
 
+// updateSinkCredentials applies the credentials of the sink URI to the sink
+// if the sink URI is updated while the changefeed is running. The owner only
+// allows the credentials of a running changefeed to be updated, so the sink
+// needn't be re-created. If the sink fails to apply them, the error is sent
+// to the error channel, and the changefeed is restarted with the new sink URI.
+func (p *processor) updateSinkCredentials(ctx cdcContext.Context) {
+	sinkURI := p.changefeed.Info.SinkURI
+	if sinkURI == p.sinkURI {
+		return
+	}
+	p.sinkURI = sinkURI
+	log.Info("processor updates the credentials of the sink",
+		zap.String("namespace", p.changefeedID.Namespace),
+		zap.String("changefeed", p.changefeedID.ID),
+		zap.String("sinkURI", util.MaskSinkURIForLog(sinkURI)))
+
+	stdCtx := contextutil.PutChangefeedIDInCtx(ctx, p.changefeedID)
+	stdCtx = contextutil.PutRoleInCtx(stdCtx, util.RoleProcessor)
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		var err error
+		switch {
+		case p.pullBasedSinking:
+			err = p.sinkManager.UpdateCredentials(stdCtx, sinkURI)
+		case p.sinkV2Factory != nil:
+			err = p.sinkV2Factory.UpdateCredentials(stdCtx, sinkURI)
+		default:
+			err = cerror.ErrSinkCredentialsNotUpdatable.GenWithStackByArgs(
+				"the sink of the processor is not supported")
+		}
+		if err != nil {
+			log.Warn("processor fails to update the credentials of the sink",
+				zap.String("namespace", p.changefeedID.Namespace),
+				zap.String("changefeed", p.changefeedID.ID),
+				zap.Error(err))
+			p.sendError(err)
+		}
+	}()
+}
+
+// updateWarnings persists the warnings recorded by the processor into the
+// task position, so that the owner is able to collect them. They are
+// persisted at most once per warningsPersistInterval to reduce etcd writes.
+func (p *processor) updateWarnings() {
+	if time.Since(p.lastWarningsPersistTime) < warningsPersistInterval {
+		return
+	}
+	warnings := warning.Warnings(p.changefeedID)
+	position := p.changefeed.TaskPositions[p.captureInfo.ID]
+	if len(warnings) == 0 ||
+		(position != nil && reflect.DeepEqual(position.Warnings, warnings)) {
+		return
+	}
+	p.lastWarningsPersistTime = time.Now()
+	p.changefeed.PatchTaskPosition(p.captureInfo.ID,
+		func(position *model.TaskPosition) (*model.TaskPosition, bool, error) {
+			if position == nil {
+				position = &model.TaskPosition{}
+			}
+			position.Warnings = warnings
+			return position, true, nil
+		})
+}
+
+// updateSinkPaused persists whether a sink of the processor is paused into
+// the task position, so that the owner reports the sink-paused sub-state of
+// the changefeed. It's only written when it changes.
+func (p *processor) updateSinkPaused() {
+	paused := warning.SinkPaused(p.changefeedID)
+	position := p.changefeed.TaskPositions[p.captureInfo.ID]
+	if (position == nil && !paused) || (position != nil && position.SinkPaused == paused) {
+		return
+	}
+	p.changefeed.PatchTaskPosition(p.captureInfo.ID,
+		func(position *model.TaskPosition) (*model.TaskPosition, bool, error) {
+			if position == nil {
+				position = &model.TaskPosition{}
+			}
+			if position.SinkPaused == paused {
+				return position, false, nil
+			}
+			position.SinkPaused = paused
+			return position, true, nil
+		})
+}
+
+// updateSinkCheckpoints persists the checkpoint of each sink into the task
+// position if the changefeed has extra sinks, so that the owner is able to
+// show the progress of the sinks. They are persisted at most once per
+// sinkCheckpointsPersistInterval to reduce etcd writes.
+func (p *processor) updateSinkCheckpoints() {
+	if time.Since(p.lastSinkCheckpointsPersistTime) < sinkCheckpointsPersistInterval {
+		return
+	}
+	var (
+		checkpoints map[string]model.Ts
+		ok          bool
+	)
+	switch {
+	case p.pullBasedSinking:
+		checkpoints, ok = p.sinkManager.SinkCheckpoints()
+	case p.sinkV2Factory != nil:
+		checkpoints, ok = p.sinkV2Factory.SinkCheckpoints()
+	}
+	if !ok {
+		return
+	}
+	position := p.changefeed.TaskPositions[p.captureInfo.ID]
+	if position != nil && reflect.DeepEqual(position.SinkCheckpoints, checkpoints) {
+		return
+	}
+	p.lastSinkCheckpointsPersistTime = time.Now()
+	p.changefeed.PatchTaskPosition(p.captureInfo.ID,
+		func(position *model.TaskPosition) (*model.TaskPosition, bool, error) {
+			if position == nil {
+				position = &model.TaskPosition{}
+			}
+			position.SinkCheckpoints = checkpoints
+			return position, true, nil
+		})
+}
+
 // checkChangefeedNormal checks if the changefeed is runnable.
 func (p *processor) checkChangefeedNormal() bool {
 	// check the state in this tick, make sure that the admin job type of the changefeed is not stopped
@@ -738,6 +899,17 @@ func (p *processor) lazyInitImpl(ctx cdcContext.Context) error {
 	if p.initialized {
 		return nil
 	}
+	if queue := ctx.GlobalVars().InitQueue; queue != nil {
+		key := initqueue.Key{ChangefeedID: p.changefeedID, Role: util.RoleProcessor}
+		if !queue.TryAcquire(key) {
+			p.initQueue = queue
+			return nil
+		}
+		defer func() {
+			queue.Release(key)
+			p.initQueue = nil
+		}()
+	}
 	ctx, cancel := cdcContext.WithCancel(ctx)
 	p.cancel = cancel
 	// We don't close this error channel, since it is only safe to close channel
@@ -779,6 +951,7 @@ func (p *processor) lazyInitImpl(ctx cdcContext.Context) error {
 
 	stdCtx := contextutil.PutChangefeedIDInCtx(ctx, p.changefeedID)
 	stdCtx = contextutil.PutRoleInCtx(stdCtx, util.RoleProcessor)
+	stdCtx = contextutil.PutCaptureAddrInCtx(stdCtx, p.captureInfo.AdvertiseAddr)
 
 	p.mg = entry.NewMounterGroup(p.schemaStorage,
 		p.changefeed.Info.Config.Mounter.WorkerNum,
@@ -800,6 +973,7 @@ func (p *processor) lazyInitImpl(ctx cdcContext.Context) error {
 	start := time.Now()
 	conf := config.GetGlobalServerConfig()
 	p.pullBasedSinking = conf.Debug.EnablePullBasedSink
+	p.sinkURI = p.changefeed.Info.SinkURI
 
 	redoManagerOpts := redo.NewProcessorManagerOptions(errCh)
 	p.redoManager, err = redo.NewManager(stdCtx, p.changefeed.Info.Config.Consistent, redoManagerOpts)
@@ -812,7 +986,8 @@ func (p *processor) lazyInitImpl(ctx cdcContext.Context) error {
 
 	if p.pullBasedSinking {
 		engineFactory := ctx.GlobalVars().SortEngineFactory
-		sortEngine, err := engineFactory.Create(p.changefeedID)
+		sortEngine, err := engineFactory.Create(p.changefeedID,
+			p.changefeed.Info.Config.SortEngine, p.changefeed.Info.Config.SortSpill)
 		if err != nil {
 			log.Info("Processor creates sort engine",
 				zap.String("namespace", p.changefeedID.Namespace),
@@ -837,7 +1012,7 @@ func (p *processor) lazyInitImpl(ctx cdcContext.Context) error {
 		// Bind them so that sourceManager can notify sinkManager.
 		p.sourceManager.OnResolve(p.sinkManager.UpdateReceivedSorterResolvedTs)
 	} else {
-		if !conf.Debug.EnableNewSink {
+		if !p.changefeed.Info.Config.IsNewSinkEnabled(conf.Debug) {
 			log.Info("Try to create sinkV1")
 			s, err := sinkv1.New(
 				stdCtx,
@@ -873,6 +1048,10 @@ func (p *processor) lazyInitImpl(ctx cdcContext.Context) error {
 				return errors.Trace(err)
 			}
 			p.sinkV2Factory = sinkV2Factory
+			p.columnTransformer, err = eventsink.NewColumnTransformer(p.changefeed.Info.Config)
+			if err != nil {
+				return errors.Trace(err)
+			}
 		}
 		log.Info("processor creates sink",
 			zap.String("namespace", p.changefeedID.Namespace),
@@ -1042,6 +1221,19 @@ func (p *processor) pushResolvedTs2Table() {
 	}
 }
 
+// checkColumnTransforms checks the column transforms against the schema of
+// the table before its table sink is created.
+func (p *processor) checkColumnTransforms(tableID model.TableID) error {
+	if p.columnTransformer == nil {
+		return nil
+	}
+	table, ok := p.schemaStorage.GetLastSnapshot().PhysicalTableByID(tableID)
+	if !ok {
+		return nil
+	}
+	return p.columnTransformer.CheckTable(table)
+}
+
 func (p *processor) getTableName(ctx context.Context, tableID model.TableID) string {
 	// FIXME: using GetLastSnapshot here would be confused and get the wrong table name
 	// after `rename table` DDL, since `rename table` keeps the tableID unchanged
@@ -1199,6 +1391,10 @@ func (p *processor) Close(ctx cdcContext.Context) error {
 	log.Info("processor closing ...",
 		zap.String("namespace", p.changefeedID.Namespace),
 		zap.String("changefeed", p.changefeedID.ID))
+	if p.initQueue != nil {
+		p.initQueue.Release(initqueue.Key{ChangefeedID: p.changefeedID, Role: util.RoleProcessor})
+		p.initQueue = nil
+	}
 	p.cancel()
 	if p.pullBasedSinking {
 		if p.sinkManager != nil {
@@ -1312,6 +1508,7 @@ func (p *processor) Close(ctx cdcContext.Context) error {
 	failpoint.Inject("processorStopDelay", nil)
 
 	p.cleanupMetrics()
+	warning.Remove(p.changefeedID)
 	log.Info("processor closed",
 		zap.String("namespace", p.changefeedID.Namespace),
 		zap.String("changefeed", p.changefeedID.ID))
@@ -59,6 +59,7 @@ func newProcessor4Test(
 	p.lazyInit = func(ctx cdcContext.Context) error {
 		p.agent = &mockAgent{executor: p}
 		p.sinkV1 = mocksink.NewNormalMockSink()
+		p.sinkURI = p.changefeed.Info.SinkURI
 		return nil
 	}
 	p.redoManager = redo.NewDisabledManager()
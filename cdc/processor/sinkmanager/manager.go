@@ -834,6 +834,17 @@ func (m *SinkManager) ReceivedEvents() int64 {
 	return totalReceivedEvents
 }
 
+// UpdateCredentials updates the credentials of the sink with the sink URI.
+func (m *SinkManager) UpdateCredentials(ctx context.Context, sinkURI string) error {
+	return m.sinkFactory.UpdateCredentials(ctx, sinkURI)
+}
+
+// SinkCheckpoints returns the checkpoint of each sink of the changefeed,
+// false is returned if there are no extra sinks.
+func (m *SinkManager) SinkCheckpoints() (map[string]model.Ts, bool) {
+	return m.sinkFactory.SinkCheckpoints()
+}
+
 // Close closes all workers.
 func (m *SinkManager) Close() error {
 	log.Info("Closing sink manager",
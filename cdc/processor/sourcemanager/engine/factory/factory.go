@@ -14,6 +14,7 @@
 package factory
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 	"sync"
@@ -22,14 +23,18 @@ import (
 
 	"github.com/cockroachdb/pebble"
 	"github.com/pingcap/log"
+	"github.com/pingcap/tidb/br/pkg/storage"
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/cdc/processor/sourcemanager/engine"
+	"github.com/pingcap/tiflow/cdc/processor/sourcemanager/engine/memory"
 	epebble "github.com/pingcap/tiflow/cdc/processor/sourcemanager/engine/pebble"
 	metrics "github.com/pingcap/tiflow/cdc/sorter"
 	"github.com/pingcap/tiflow/pkg/config"
 	dbMetrics "github.com/pingcap/tiflow/pkg/db"
+	"github.com/pingcap/tiflow/pkg/util"
 	"go.uber.org/atomic"
 	"go.uber.org/multierr"
+	"go.uber.org/zap"
 )
 
 type sortEngineType int
@@ -37,8 +42,11 @@ type sortEngineType int
 const (
 	// pebbleEngine details are in package document of pkg/sorter/pebble.
 	pebbleEngine sortEngineType = iota + 1
+	// memoryEngine details are in package document of engine/memory.
+	memoryEngine
 
-	metricsCollectInterval = 15 * time.Second
+	metricsCollectInterval    = 15 * time.Second
+	createSpillStorageTimeout = 10 * time.Second
 )
 
 var (
@@ -67,20 +75,39 @@ type SortEngineFactory struct {
 
 	// dbs is also readed in the background metrics collector.
 	dbInitialized *atomic.Bool
+
+	// memoryQuota bounds the events buffered by all the memory engines,
+	// it's the same as the memory quota of the sorter.
+	memoryQuota *memory.Quota
 }
 
 // Create creates a SortEngine. If an engine with same ID already exists,
-// it will be returned directly.
-func (f *SortEngineFactory) Create(ID model.ChangeFeedID) (e engine.SortEngine, err error) {
+// it will be returned directly. The sort engine of the changefeed takes
+// precedence over the engine type of the factory if it's not empty. The cold
+// events are spilled to an external storage if spill isn't nil, which is only
+// supported by the pebble engine. The memory engines share the memory quota
+// of the factory, and fail the changefeeds once it's exhausted.
+func (f *SortEngineFactory) Create(
+	ID model.ChangeFeedID, sortEngine string, spill *config.SortSpillConfig,
+) (e engine.SortEngine, err error) {
 	f.mu.Lock()
 	defer f.mu.Unlock()
 
-	switch f.engineType {
+	exists := false
+	if e, exists = f.engines[ID]; exists {
+		return e, nil
+	}
+
+	engineType := f.engineType
+	switch sortEngine {
+	case config.SortEnginePebble:
+		engineType = pebbleEngine
+	case config.SortEngineMemory:
+		engineType = memoryEngine
+	}
+
+	switch engineType {
 	case pebbleEngine:
-		exists := false
-		if e, exists = f.engines[ID]; exists {
-			return e, nil
-		}
 		if len(f.dbs) == 0 {
 			f.dbs, f.writeStalls, err = createPebbleDBs(f.dir, f.pebbleConfig, f.memQuotaInBytes)
 			if err != nil {
@@ -88,7 +115,29 @@ func (f *SortEngineFactory) Create(ID model.ChangeFeedID) (e engine.SortEngine,
 			}
 			f.dbInitialized.Store(true)
 		}
-		e = epebble.New(ID, f.dbs)
+		if spill == nil {
+			e = epebble.New(ID, f.dbs)
+		} else {
+			ctx, cancel := context.WithTimeout(context.Background(), createSpillStorageTimeout)
+			defer cancel()
+			var extStorage storage.ExternalStorage
+			extStorage, err = util.GetExternalStorageFromURI(ctx, spill.Storage)
+			if err != nil {
+				return
+			}
+			e = epebble.NewWithSpill(ID, f.dbs, extStorage, spill)
+		}
+		f.engines[ID] = e
+	case memoryEngine:
+		if spill != nil {
+			log.Warn("sort-spill is ignored by the memory sort engine",
+				zap.String("namespace", ID.Namespace),
+				zap.String("changefeed", ID.ID))
+		}
+		if f.memoryQuota == nil {
+			f.memoryQuota = memory.NewQuota(int64(f.memQuotaInBytes))
+		}
+		e = memory.NewWithQuota(context.Background(), f.memoryQuota)
 		f.engines[ID] = e
 	default:
 		log.Panic("not implemented")
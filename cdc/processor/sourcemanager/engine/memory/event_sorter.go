@@ -23,7 +23,9 @@ import (
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/cdc/processor/sourcemanager/engine"
 	"github.com/pingcap/tiflow/cdc/processor/tablepb"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
 	"github.com/pingcap/tiflow/pkg/spanz"
+	"go.uber.org/atomic"
 	"go.uber.org/zap"
 )
 
@@ -32,10 +34,41 @@ var (
 	_ engine.EventIterator = (*EventIter)(nil)
 )
 
+// Quota is the memory quota shared by EventSorters. The events buffered by
+// the EventSorters are accounted in it until they are cleaned.
+type Quota struct {
+	limit int64
+	used  atomic.Int64
+}
+
+// NewQuota creates a Quota of the given bytes.
+func NewQuota(limit int64) *Quota {
+	return &Quota{limit: limit}
+}
+
+// Used returns the bytes in use.
+func (q *Quota) Used() int64 {
+	return q.used.Load()
+}
+
+func (q *Quota) acquire(bytes int64) error {
+	if q.used.Add(bytes) > q.limit {
+		q.used.Sub(bytes)
+		return cerror.ErrMemorySortEngineQuotaExceeded.GenWithStackByArgs(q.limit)
+	}
+	return nil
+}
+
+func (q *Quota) release(bytes int64) {
+	q.used.Sub(bytes)
+}
+
 // EventSorter accepts out-of-order raw kv entries and output sorted entries.
 type EventSorter struct {
 	// Just like map[tablepb.Span]*tableSorter.
 	tables spanz.SyncMap
+	// quota is nil if the buffered events are unbounded.
+	quota *Quota
 
 	mu         sync.RWMutex
 	onResolves []func(tablepb.Span, model.Ts)
@@ -52,6 +85,12 @@ func New(_ context.Context) *EventSorter {
 	return &EventSorter{}
 }
 
+// NewWithQuota creates a new tableSorter whose buffered events are bounded
+// by the quota. Add fails if the events exceed the quota.
+func NewWithQuota(_ context.Context, quota *Quota) *EventSorter {
+	return &EventSorter{quota: quota}
+}
+
 // IsTableBased implements engine.SortEngine.
 func (s *EventSorter) IsTableBased() bool {
 	return true
@@ -66,9 +105,11 @@ func (s *EventSorter) AddTable(span tablepb.Span) {
 
 // RemoveTable implements engine.SortEngine.
 func (s *EventSorter) RemoveTable(span tablepb.Span) {
-	if _, exists := s.tables.LoadAndDelete(span); !exists {
+	value, exists := s.tables.LoadAndDelete(span)
+	if !exists {
 		log.Panic("remove an unexist table", zap.Stringer("span", &span))
 	}
+	s.release(value.(*tableSorter).clear())
 }
 
 // Add implements engine.SortEngine.
@@ -78,6 +119,15 @@ func (s *EventSorter) Add(span tablepb.Span, events ...*model.PolymorphicEvent)
 		log.Panic("add events into an unexist table", zap.Stringer("span", &span))
 	}
 
+	if s.quota != nil {
+		var bytes int64
+		for _, event := range events {
+			bytes += eventBytes(event)
+		}
+		if err := s.quota.acquire(bytes); err != nil {
+			return err
+		}
+	}
 	resolvedTs, hasNewResolved := value.(*tableSorter).add(events...)
 	if hasNewResolved {
 		s.mu.RLock()
@@ -131,7 +181,7 @@ func (s *EventSorter) CleanByTable(span tablepb.Span, upperBound engine.Position
 		log.Panic("clean an unexist table", zap.Stringer("span", &span))
 	}
 
-	value.(*tableSorter).clean(span, upperBound)
+	s.release(value.(*tableSorter).clean(span, upperBound))
 	return nil
 }
 
@@ -155,10 +205,20 @@ func (s *EventSorter) ReceivedEvents() int64 {
 
 // Close implements engine.SortEngine.
 func (s *EventSorter) Close() error {
+	s.tables.Range(func(_ tablepb.Span, value interface{}) bool {
+		s.release(value.(*tableSorter).clear())
+		return true
+	})
 	s.tables = spanz.SyncMap{}
 	return nil
 }
 
+func (s *EventSorter) release(bytes int64) {
+	if s.quota != nil {
+		s.quota.release(bytes)
+	}
+}
+
 // Next implements sorter.EventIterator.
 func (s *EventIter) Next() (event *model.PolymorphicEvent, txnFinished engine.Position, err error) {
 	if len(s.resolved) == 0 {
@@ -194,6 +254,8 @@ type tableSorter struct {
 	resolvedTs *model.Ts
 	unresolved eventHeap
 	resolved   []*model.PolymorphicEvent
+	// bytes is the size of the unresolved and resolved events.
+	bytes int64
 }
 
 func (s *tableSorter) add(events ...*model.PolymorphicEvent) (resolvedTs model.Ts, hasNewResolved bool) {
@@ -201,6 +263,7 @@ func (s *tableSorter) add(events ...*model.PolymorphicEvent) (resolvedTs model.T
 	defer s.mu.Unlock()
 
 	for _, event := range events {
+		s.bytes += eventBytes(event)
 		heap.Push(&s.unresolved, event)
 		if event.IsResolved() {
 			if s.resolvedTs == nil {
@@ -257,7 +320,8 @@ func (s *tableSorter) fetch(
 	return iter
 }
 
-func (s *tableSorter) clean(span tablepb.Span, upperBound engine.Position) {
+// clean removes the events up to the upper bound and returns their size.
+func (s *tableSorter) clean(span tablepb.Span, upperBound engine.Position) (bytes int64) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	if s.resolvedTs == nil || upperBound.CommitTs > *s.resolvedTs {
@@ -269,7 +333,30 @@ func (s *tableSorter) clean(span tablepb.Span, upperBound engine.Position) {
 		return x.CRTs > upperBound.CommitTs ||
 			x.CRTs == upperBound.CommitTs && x.StartTs > upperBound.StartTs
 	})
+	for _, event := range s.resolved[:startIdx] {
+		bytes += eventBytes(event)
+	}
+	s.bytes -= bytes
 	s.resolved = s.resolved[startIdx:]
+	return
+}
+
+// clear removes all the events and returns their size.
+func (s *tableSorter) clear() (bytes int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	bytes = s.bytes
+	s.bytes = 0
+	s.unresolved = nil
+	s.resolved = nil
+	return
+}
+
+func eventBytes(event *model.PolymorphicEvent) int64 {
+	if event.RawKV == nil || event.IsResolved() {
+		return 0
+	}
+	return event.RawKV.ApproximateDataSize()
 }
 
 func eventLess(i *model.PolymorphicEvent, j *model.PolymorphicEvent) bool {
@@ -115,6 +115,41 @@ func TestEventSorter(t *testing.T) {
 	}
 }
 
+func TestEventSorterQuota(t *testing.T) {
+	t.Parallel()
+
+	quota := NewQuota(10)
+	span1 := spanz.TableIDToComparableSpan(1)
+	span2 := spanz.TableIDToComparableSpan(2)
+	es := NewWithQuota(context.Background(), quota)
+	es.AddTable(span1)
+	es.AddTable(span2)
+
+	newEvent := func(crts uint64) *model.PolymorphicEvent {
+		return model.NewPolymorphicEvent(&model.RawKVEntry{
+			CRTs: crts, OpType: model.OpTypePut, Key: []byte("k"), Value: []byte("vvvv"),
+		})
+	}
+	require.Nil(t, es.Add(span1, newEvent(1), newEvent(2)))
+	require.Equal(t, int64(10), quota.Used())
+	// the resolved events take no quota.
+	require.Nil(t, es.Add(span1, model.NewResolvedPolymorphicEvent(0, 1)))
+	err := es.Add(span2, newEvent(1))
+	require.Regexp(t, "exceed the memory quota", err)
+	require.Equal(t, int64(10), quota.Used())
+
+	// the cleaned events release the quota.
+	require.Nil(t, es.CleanByTable(span1, engine.Position{CommitTs: 1, StartTs: 1}))
+	require.Equal(t, int64(5), quota.Used())
+	require.Nil(t, es.Add(span2, newEvent(1)))
+	require.Equal(t, int64(10), quota.Used())
+
+	es.RemoveTable(span2)
+	require.Equal(t, int64(5), quota.Used())
+	require.Nil(t, es.Close())
+	require.Equal(t, int64(0), quota.Used())
+}
+
 func TestEventLess(t *testing.T) {
 	t.Parallel()
 	testCases := []struct {
@@ -46,6 +46,8 @@ type EventSorter struct {
 	dbs          []*pebble.DB
 	channs       []*chann.Chann[eventWithTableID]
 	serde        encoding.MsgPackGenSerde
+	// spiller is nil if the cold events are never spilled.
+	spiller *spiller
 
 	// To manage background goroutines.
 	wg     sync.WaitGroup
@@ -65,6 +67,12 @@ type EventIter struct {
 	iter     *pebble.Iterator
 	headItem *model.PolymorphicEvent
 	serde    encoding.MsgPackGenSerde
+
+	// The spilled events are fetched before the local ones.
+	spiller                *spiller
+	spilled                []*spilledSegment
+	spillReader            *spilledReader
+	lowerBound, upperBound engine.Position
 }
 
 // New creates an EventSorter instance.
@@ -131,7 +139,8 @@ func (s *EventSorter) AddTable(span tablepb.Span) {
 // RemoveTable implements engine.SortEngine.
 func (s *EventSorter) RemoveTable(span tablepb.Span) {
 	s.mu.Lock()
-	if _, exists := s.tables.Get(span); !exists {
+	state, exists := s.tables.Get(span)
+	if !exists {
 		s.mu.Unlock()
 		log.Warn("remove an unexist table",
 			zap.String("namespace", s.changefeedID.Namespace),
@@ -141,6 +150,20 @@ func (s *EventSorter) RemoveTable(span tablepb.Span) {
 	}
 	s.tables.Delete(span)
 	s.mu.Unlock()
+
+	if s.spiller != nil {
+		// The spilled segments can't be fetched any more.
+		state.mu.Lock()
+		state.removed = true
+		spilled := state.spilled
+		state.spilled = nil
+		if state.spillReader != nil {
+			state.spillReader.close()
+			state.spillReader = nil
+		}
+		state.mu.Unlock()
+		s.deleteSpilled(spilled)
+	}
 }
 
 // Add implements engine.SortEngine.
@@ -232,8 +255,33 @@ func (s *EventSorter) FetchByTable(
 	}
 
 	db := s.dbs[getDB(span, len(s.dbs))]
-	iter := iterTable(db, state.uniqueID, span.TableID, lowerBound, upperBound)
-	return &EventIter{tableID: span.TableID, state: state, iter: iter, serde: s.serde}
+	if s.spiller == nil {
+		iter := iterTable(db, state.uniqueID, span.TableID, lowerBound, upperBound)
+		return &EventIter{tableID: span.TableID, state: state, iter: iter, serde: s.serde}
+	}
+
+	// Create the local iterator with mu held, so that the events of a segment
+	// spilled concurrently are either in the segment or in the iterator.
+	state.mu.RLock()
+	defer state.mu.RUnlock()
+	localLowerBound := lowerBound
+	if n := len(state.spilled); n > 0 && state.spilled[n-1].upper.Compare(lowerBound) >= 0 {
+		localLowerBound = state.spilled[n-1].upper.Next()
+	}
+	var iter *pebble.Iterator
+	if localLowerBound.Compare(upperBound) <= 0 {
+		iter = iterTable(db, state.uniqueID, span.TableID, localLowerBound, upperBound)
+	}
+	return &EventIter{
+		tableID:    span.TableID,
+		state:      state,
+		iter:       iter,
+		serde:      s.serde,
+		spiller:    s.spiller,
+		spilled:    state.spilledIn(lowerBound, upperBound),
+		lowerBound: lowerBound,
+		upperBound: upperBound,
+	}
 }
 
 // FetchAllTables implements engine.SortEngine.
@@ -317,6 +365,9 @@ func (s *EventSorter) Close() error {
 	s.mu.Unlock()
 
 	close(s.closed)
+	if s.spiller != nil {
+		s.spiller.cancel()
+	}
 	s.wg.Wait()
 	for _, ch := range s.channs {
 		ch.Close()
@@ -337,20 +388,19 @@ func (s *EventSorter) Close() error {
 		}
 		return true
 	})
+	if s.spiller != nil && err == nil {
+		s.spiller.removeAlive()
+	}
 	return err
 }
 
 // Next implements sorter.EventIterator.
 func (s *EventIter) Next() (event *model.PolymorphicEvent, pos engine.Position, err error) {
-	valid := s.iter != nil && s.iter.Valid()
-	var value []byte
-	for valid {
-		value, valid = s.iter.Value(), s.iter.Next()
-		event = &model.PolymorphicEvent{}
-		if _, err = s.serde.Unmarshal(event, value); err != nil {
+	for {
+		if event, err = s.nextEvent(); err != nil {
 			return
 		}
-		if s.headItem != nil {
+		if event == nil || s.headItem != nil {
 			break
 		}
 		s.headItem, event = event, nil
@@ -365,8 +415,69 @@ func (s *EventIter) Next() (event *model.PolymorphicEvent, pos engine.Position,
 	return
 }
 
+// nextEvent returns the next event, the spilled ones are streamed segment by
+// segment and returned first. It returns nil if there is no more event.
+func (s *EventIter) nextEvent() (event *model.PolymorphicEvent, err error) {
+	var (
+		pos   engine.Position
+		ok    bool
+		value []byte
+	)
+	for len(s.spilled) > 0 {
+		if s.spillReader == nil {
+			if s.spillReader, err = s.spiller.takeReader(s.state, s.spilled[0], s.lowerBound); err != nil {
+				return nil, err
+			}
+		}
+		if pos, ok, err = s.spillReader.peek(); err != nil {
+			return nil, err
+		}
+		if !ok {
+			s.spillReader.close()
+			s.spillReader = nil
+			s.spilled = s.spilled[1:]
+			continue
+		}
+		if pos.Compare(s.upperBound) > 0 {
+			// The later segments are after the upper bound too.
+			s.spiller.putReader(s.state, s.spillReader)
+			s.spillReader = nil
+			s.spilled = nil
+			break
+		}
+		if pos.Compare(s.lowerBound) < 0 {
+			if err = s.spillReader.skip(); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if value, err = s.spillReader.read(); err != nil {
+			return nil, err
+		}
+		event = &model.PolymorphicEvent{}
+		if _, err = s.serde.Unmarshal(event, value); err != nil {
+			return nil, err
+		}
+		return event, nil
+	}
+
+	if s.iter == nil || !s.iter.Valid() {
+		return nil, nil
+	}
+	event = &model.PolymorphicEvent{}
+	if _, err = s.serde.Unmarshal(event, s.iter.Value()); err != nil {
+		return
+	}
+	s.iter.Next()
+	return event, nil
+}
+
 // Close implements sorter.EventIterator.
 func (s *EventIter) Close() error {
+	if s.spillReader != nil {
+		s.spiller.putReader(s.state, s.spillReader)
+		s.spillReader = nil
+	}
 	if s.iter != nil {
 		return s.iter.Close()
 	}
@@ -391,6 +502,11 @@ type tableState struct {
 	// Following fields are protected by mu.
 	mu      sync.RWMutex
 	cleaned engine.Position
+	// spilled are the segments in the external storage, sorted by positions.
+	spilled []*spilledSegment
+	removed bool
+	// spillReader is kept by the last fetch of the spilled segments.
+	spillReader *spilledReader
 }
 
 func (s *EventSorter) handleEvents(
@@ -528,8 +644,14 @@ func (s *EventSorter) cleanTable(
 		toClean = engine.Position{CommitTs: math.MaxUint64, StartTs: math.MaxUint64 - 1}
 	}
 
-	state.mu.RLock()
-	defer state.mu.RUnlock()
+	var spilled []*spilledSegment
+	state.mu.Lock()
+	defer func() {
+		state.mu.Unlock()
+		if len(spilled) > 0 {
+			s.deleteSpilled(spilled)
+		}
+	}()
 
 	if state.cleaned.Compare(toClean) >= 0 {
 		return nil
@@ -547,6 +669,7 @@ func (s *EventSorter) cleanTable(
 	}
 
 	state.cleaned = toClean
+	spilled = state.popCleaned()
 	return nil
 }
 
@@ -0,0 +1,537 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pebble
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/tidb/br/pkg/storage"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/processor/sourcemanager/engine"
+	"github.com/pingcap/tiflow/cdc/processor/sourcemanager/engine/pebble/encoding"
+	"github.com/pingcap/tiflow/cdc/processor/tablepb"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/tikv/client-go/v2/oracle"
+	"go.uber.org/zap"
+)
+
+const (
+	spillCheckInterval = 10 * time.Second
+	spillFileTimeout   = 5 * time.Minute
+	// spillReadBufferSize is the size of the buffer to stream a spilled file.
+	spillReadBufferSize = 64 * 1024
+
+	// A sorter instance refreshes its alive file every spillAliveInterval.
+	// The files of an instance whose alive file isn't refreshed in
+	// spillAliveTTL, e.g. its capture crashed, are removed by the others.
+	spillAliveInterval = time.Minute
+	spillAliveTTL      = 10 * time.Minute
+	spillSweepInterval = 10 * time.Minute
+
+	spillFileSuffix  = ".spill"
+	spillAliveSuffix = ".alive"
+)
+
+// spiller moves the sorted but unsent events, which are older than coldAfter,
+// from the local pebble to an external storage. A table is spilled from its
+// oldest events, so its spilled segments are always before its local events.
+type spiller struct {
+	storage     storage.ExternalStorage
+	coldAfter   time.Duration
+	segmentSize int
+	// prefix distinguishes the files of different sorter instances. The
+	// files are put in the root of the storage, because the local storage
+	// can't create the parent directories of a file.
+	prefix string
+
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	// Following fields are only accessed by the spill loop.
+	lastAlive time.Time
+	lastSweep time.Time
+	// orphans are the instances found dead by the last sweep.
+	orphans map[string]struct{}
+}
+
+// spilledSegment is a file in the external storage, which contains the
+// events of a table in [lower, upper].
+type spilledSegment struct {
+	name         string
+	lower, upper engine.Position
+}
+
+// NewWithSpill creates an EventSorter instance, which spills the cold events
+// to the external storage.
+func NewWithSpill(
+	ID model.ChangeFeedID, dbs []*pebble.DB,
+	extStorage storage.ExternalStorage, cfg *config.SortSpillConfig,
+) *EventSorter {
+	s := New(ID, dbs)
+	ctx, cancel := context.WithCancel(context.Background())
+	s.spiller = &spiller{
+		storage:     extStorage,
+		coldAfter:   cfg.ColdAfter,
+		segmentSize: int(cfg.SegmentSize),
+		prefix: fmt.Sprintf("%s_%s_%d",
+			ID.Namespace, ID.ID, time.Now().UnixNano()),
+		ctx:     ctx,
+		cancel:  cancel,
+		orphans: make(map[string]struct{}),
+	}
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.spillLoop()
+	}()
+	return s
+}
+
+func (s *EventSorter) spillLoop() {
+	ticker := time.NewTicker(spillCheckInterval)
+	defer ticker.Stop()
+	for {
+		// The alive file must be written before any segment, otherwise the
+		// segments may be removed as orphans.
+		if err := s.spiller.keepAlive(time.Now()); err != nil {
+			log.Warn("failed to refresh the alive file of the spilled events",
+				zap.String("namespace", s.changefeedID.Namespace),
+				zap.String("changefeed", s.changefeedID.ID),
+				zap.Error(err))
+		} else if err := s.spiller.sweepOrphans(time.Now()); err != nil {
+			log.Warn("failed to remove the orphaned spilled files",
+				zap.String("namespace", s.changefeedID.Namespace),
+				zap.String("changefeed", s.changefeedID.ID),
+				zap.Error(err))
+		}
+
+		select {
+		case <-s.closed:
+			return
+		case <-ticker.C:
+		}
+		if time.Since(s.spiller.lastAlive) >= spillAliveTTL/2 {
+			// Don't spill if the alive file can't be refreshed for a long time.
+			continue
+		}
+
+		coldTs := oracle.GoTimeToTS(time.Now().Add(-s.spiller.coldAfter))
+		spans := make([]tablepb.Span, 0)
+		states := make([]*tableState, 0)
+		s.mu.RLock()
+		s.tables.Range(func(span tablepb.Span, state *tableState) bool {
+			spans = append(spans, span)
+			states = append(states, state)
+			return true
+		})
+		s.mu.RUnlock()
+
+		for i := range spans {
+			if err := s.spillTable(spans[i], states[i], coldTs); err != nil {
+				// The events are kept in the local pebble, so just retry later.
+				log.Warn("failed to spill events to the external storage",
+					zap.String("namespace", s.changefeedID.Namespace),
+					zap.String("changefeed", s.changefeedID.ID),
+					zap.Stringer("span", &spans[i]),
+					zap.Error(err))
+			}
+		}
+	}
+}
+
+// spillTable spills the sorted events of the table whose commit ts are not
+// greater than coldTs, segment by segment.
+func (s *EventSorter) spillTable(span tablepb.Span, state *tableState, coldTs model.Ts) error {
+	upperTs := state.sortedResolved.Load()
+	if upperTs > coldTs {
+		upperTs = coldTs
+	}
+	if upperTs == 0 {
+		return nil
+	}
+	upper := engine.Position{CommitTs: upperTs, StartTs: upperTs - 1}
+	db := s.dbs[getDB(span, len(s.dbs))]
+
+	for {
+		state.mu.RLock()
+		lower := state.spillLowerBound()
+		state.mu.RUnlock()
+		if lower.Compare(upper) > 0 {
+			return nil
+		}
+
+		segment, data := s.readSegment(db, state.uniqueID, span.TableID, lower, upper)
+		if segment == nil {
+			return nil
+		}
+		ctx, cancel := context.WithTimeout(s.spiller.ctx, spillFileTimeout)
+		err := s.spiller.storage.WriteFile(ctx, segment.name, data)
+		cancel()
+		if err != nil {
+			return errors.Trace(err)
+		}
+
+		state.mu.Lock()
+		registered := !state.removed && state.cleaned.Compare(segment.upper) < 0
+		if registered {
+			state.spilled = append(state.spilled, segment)
+		}
+		state.mu.Unlock()
+		if !registered {
+			// The events are sent or the table is removed during the spill.
+			s.deleteSpilled([]*spilledSegment{segment})
+			return nil
+		}
+
+		// Iterators created from now on skip the local events of the segment,
+		// so it's safe to delete them.
+		start := encoding.EncodeTsKey(
+			state.uniqueID, uint64(span.TableID), lower.CommitTs, lower.StartTs)
+		next := segment.upper.Next()
+		end := encoding.EncodeTsKey(
+			state.uniqueID, uint64(span.TableID), next.CommitTs, next.StartTs)
+		if err := db.DeleteRange(start, end, &pebble.WriteOptions{Sync: false}); err != nil {
+			return errors.Trace(err)
+		}
+		log.Info("spill events to the external storage",
+			zap.String("namespace", s.changefeedID.Namespace),
+			zap.String("changefeed", s.changefeedID.ID),
+			zap.Stringer("span", &span),
+			zap.String("file", segment.name),
+			zap.Int("size", len(data)))
+	}
+}
+
+// readSegment reads the events in [lower, upper] until the size of the
+// segment reaches the limit. It returns nil if there is no event. Each event
+// is prefixed by its commit ts, start ts and size, so that the events out of
+// a fetched range are skipped without being decoded.
+func (s *EventSorter) readSegment(
+	db *pebble.DB, uniqueID uint32, tableID model.TableID,
+	lower, upper engine.Position,
+) (*spilledSegment, []byte) {
+	iter := iterTable(db, uniqueID, tableID, lower, upper)
+	defer iter.Close()
+
+	var data []byte
+	segmentUpper := upper
+	lastCommitTs := uint64(0)
+	for ; iter.Valid(); iter.Next() {
+		_, _, startTs, commitTs := encoding.DecodeKey(iter.Key())
+		// Never split the events of a commit ts into different segments.
+		if len(data) >= s.spiller.segmentSize && commitTs != lastCommitTs {
+			segmentUpper = engine.Position{CommitTs: lastCommitTs, StartTs: lastCommitTs - 1}
+			break
+		}
+		value := iter.Value()
+		data = binary.AppendUvarint(data, commitTs)
+		data = binary.AppendUvarint(data, startTs)
+		data = binary.AppendUvarint(data, uint64(len(value)))
+		data = append(data, value...)
+		lastCommitTs = commitTs
+	}
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	return &spilledSegment{
+		name: fmt.Sprintf("%s_%d_%d_%d-%d%s", s.spiller.prefix, tableID,
+			uniqueID, lower.CommitTs, segmentUpper.CommitTs, spillFileSuffix),
+		lower: lower,
+		upper: segmentUpper,
+	}, data
+}
+
+// spilledReader streams the events of a spilled segment, so that only the
+// fetched events are decoded, one by one, and accounted by the sink memory
+// quota like the local ones. It's kept by the table after a fetch, so that
+// the next fetch of the table continues from where it stops instead of
+// reading the segment again.
+type spilledReader struct {
+	segment *spilledSegment
+	file    storage.ExternalFileReader
+	reader  *bufio.Reader
+
+	// last is the position of the last record which is skipped or read.
+	last engine.Position
+	// pending is the position of the next record whose header is read by
+	// peek, and pendingSize is its size.
+	pending     *engine.Position
+	pendingSize uint64
+}
+
+func (s *spiller) openSegment(segment *spilledSegment) (*spilledReader, error) {
+	// The reader lives across fetches, so it's not bounded by a timeout.
+	file, err := s.storage.Open(s.ctx, segment.name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &spilledReader{
+		segment: segment,
+		file:    file,
+		reader:  bufio.NewReaderSize(file, spillReadBufferSize),
+	}, nil
+}
+
+// peek returns the position of the next record, ok is false at the end of
+// the segment.
+func (r *spilledReader) peek() (pos engine.Position, ok bool, err error) {
+	if r.pending != nil {
+		return *r.pending, true, nil
+	}
+	pos.CommitTs, err = binary.ReadUvarint(r.reader)
+	if err == io.EOF {
+		return pos, false, nil
+	}
+	if err != nil {
+		return pos, false, r.corrupted(err)
+	}
+	if pos.StartTs, err = binary.ReadUvarint(r.reader); err != nil {
+		return pos, false, r.corrupted(err)
+	}
+	if r.pendingSize, err = binary.ReadUvarint(r.reader); err != nil {
+		return pos, false, r.corrupted(err)
+	}
+	r.pending = &pos
+	return pos, true, nil
+}
+
+// skip skips the record returned by peek.
+func (r *spilledReader) skip() error {
+	if _, err := r.reader.Discard(int(r.pendingSize)); err != nil {
+		return r.corrupted(err)
+	}
+	r.last, r.pending = *r.pending, nil
+	return nil
+}
+
+// read reads the value of the record returned by peek.
+func (r *spilledReader) read() ([]byte, error) {
+	value := make([]byte, r.pendingSize)
+	if _, err := io.ReadFull(r.reader, value); err != nil {
+		return nil, r.corrupted(err)
+	}
+	r.last, r.pending = *r.pending, nil
+	return value, nil
+}
+
+func (r *spilledReader) corrupted(err error) error {
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		return errors.Errorf("corrupted spilled file %s", r.segment.name)
+	}
+	return errors.Trace(err)
+}
+
+func (r *spilledReader) close() {
+	if err := r.file.Close(); err != nil {
+		log.Warn("failed to close the spilled file",
+			zap.String("file", r.segment.name), zap.Error(err))
+	}
+}
+
+// takeReader takes the reader kept by the table if it can read the segment
+// from lower, otherwise it opens the segment.
+func (s *spiller) takeReader(
+	state *tableState, segment *spilledSegment, lower engine.Position,
+) (*spilledReader, error) {
+	state.mu.Lock()
+	reader := state.spillReader
+	if reader != nil && reader.segment == segment && reader.last.Compare(lower) < 0 {
+		state.spillReader = nil
+		state.mu.Unlock()
+		return reader, nil
+	}
+	state.mu.Unlock()
+	return s.openSegment(segment)
+}
+
+// putReader keeps the reader in the table for the next fetch, unless the
+// segment is not needed anymore.
+func (s *spiller) putReader(state *tableState, reader *spilledReader) {
+	state.mu.Lock()
+	if state.removed || reader.segment.upper.Compare(state.cleaned) <= 0 {
+		state.mu.Unlock()
+		reader.close()
+		return
+	}
+	old := state.spillReader
+	state.spillReader = reader
+	state.mu.Unlock()
+	if old != nil {
+		old.close()
+	}
+}
+
+// keepAlive refreshes the alive file of the instance.
+func (s *spiller) keepAlive(now time.Time) error {
+	if now.Sub(s.lastAlive) < spillAliveInterval {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(s.ctx, spillFileTimeout)
+	defer cancel()
+	err := s.storage.WriteFile(ctx, s.prefix+spillAliveSuffix,
+		[]byte(strconv.FormatInt(now.UnixNano(), 10)))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	s.lastAlive = now
+	return nil
+}
+
+// sweepOrphans removes the files of the sorter instances which are not
+// alive, e.g. their captures crashed. An instance is removed only if it's
+// found dead by two sweeps in a row, because its alive file may be written
+// after it's listed by the first one.
+func (s *spiller) sweepOrphans(now time.Time) error {
+	if now.Sub(s.lastSweep) < spillSweepInterval {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(s.ctx, spillFileTimeout)
+	defer cancel()
+
+	files := make(map[string][]string)
+	aliveFiles := make(map[string]string)
+	err := s.storage.WalkDir(ctx, &storage.WalkOption{}, func(path string, _ int64) error {
+		var instance string
+		switch {
+		case strings.HasSuffix(path, spillAliveSuffix):
+			instance = strings.TrimSuffix(path, spillAliveSuffix)
+			aliveFiles[instance] = path
+		case strings.HasSuffix(path, spillFileSuffix):
+			instance = spillInstanceOf(path)
+		}
+		if instance != "" && instance != s.prefix {
+			files[instance] = append(files[instance], path)
+		}
+		return nil
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	orphans := make(map[string]struct{})
+	for instance, paths := range files {
+		if aliveFile, ok := aliveFiles[instance]; ok {
+			data, err := s.storage.ReadFile(ctx, aliveFile)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			aliveAt, err := strconv.ParseInt(string(data), 10, 64)
+			if err == nil && now.Sub(time.Unix(0, aliveAt)) < spillAliveTTL {
+				continue
+			}
+		}
+		if _, ok := s.orphans[instance]; !ok {
+			orphans[instance] = struct{}{}
+			continue
+		}
+		for _, path := range paths {
+			if err := s.storage.DeleteFile(ctx, path); err != nil {
+				return errors.Trace(err)
+			}
+		}
+		log.Info("remove the orphaned spilled files",
+			zap.String("instance", instance), zap.Int("files", len(paths)))
+	}
+	s.orphans = orphans
+	s.lastSweep = now
+	return nil
+}
+
+// spillInstanceOf returns the prefix of the instance which spills the file,
+// whose name is like prefix_tableID_uniqueID_lower-upper.spill.
+func spillInstanceOf(name string) string {
+	parts := strings.Split(strings.TrimSuffix(name, spillFileSuffix), "_")
+	if len(parts) < 4 {
+		return ""
+	}
+	return strings.Join(parts[:len(parts)-3], "_")
+}
+
+// removeAlive removes the alive file of the instance when it's closed, all
+// its segments are removed before.
+func (s *spiller) removeAlive() {
+	ctx, cancel := context.WithTimeout(context.Background(), spillFileTimeout)
+	defer cancel()
+	if err := s.storage.DeleteFile(ctx, s.prefix+spillAliveSuffix); err != nil {
+		log.Warn("failed to delete the alive file of the spilled events",
+			zap.String("file", s.prefix+spillAliveSuffix), zap.Error(err))
+	}
+}
+
+// deleteSpilled deletes the files of the segments, the failures are only
+// logged because the events in them are never read again.
+func (s *EventSorter) deleteSpilled(segments []*spilledSegment) {
+	for _, segment := range segments {
+		ctx, cancel := context.WithTimeout(context.Background(), spillFileTimeout)
+		err := s.spiller.storage.DeleteFile(ctx, segment.name)
+		cancel()
+		if err != nil {
+			log.Warn("failed to delete the spilled file",
+				zap.String("namespace", s.changefeedID.Namespace),
+				zap.String("changefeed", s.changefeedID.ID),
+				zap.String("file", segment.name),
+				zap.Error(err))
+		}
+	}
+}
+
+// spillLowerBound returns the position from which the local events can be
+// spilled. It must be called with mu held.
+func (t *tableState) spillLowerBound() engine.Position {
+	lower := t.cleaned.Next()
+	if n := len(t.spilled); n > 0 && t.spilled[n-1].upper.Compare(t.cleaned) > 0 {
+		lower = t.spilled[n-1].upper.Next()
+	}
+	return lower
+}
+
+// spilledIn returns the spilled segments overlapped with [lower, upper]. It
+// must be called with mu held.
+func (t *tableState) spilledIn(lower, upper engine.Position) []*spilledSegment {
+	segments := make([]*spilledSegment, 0, len(t.spilled))
+	for _, segment := range t.spilled {
+		if segment.upper.Compare(lower) >= 0 && segment.lower.Compare(upper) <= 0 {
+			segments = append(segments, segment)
+		}
+	}
+	return segments
+}
+
+// popCleaned removes the segments which are cleaned, and returns them. It
+// must be called with mu held.
+func (t *tableState) popCleaned() []*spilledSegment {
+	i := 0
+	for i < len(t.spilled) && t.spilled[i].upper.Compare(t.cleaned) <= 0 {
+		i++
+	}
+	cleaned := t.spilled[:i]
+	t.spilled = t.spilled[i:]
+	if t.spillReader != nil && t.spillReader.segment.upper.Compare(t.cleaned) <= 0 {
+		t.spillReader.close()
+		t.spillReader = nil
+	}
+	return cleaned
+}
@@ -0,0 +1,183 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pebble
+
+import (
+	"context"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/pingcap/tidb/br/pkg/storage"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/processor/sourcemanager/engine"
+	"github.com/pingcap/tiflow/cdc/processor/tablepb"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/pingcap/tiflow/pkg/spanz"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpillAndFetch(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), t.Name())
+	db, err := OpenPebble(1, dbPath, &config.DBConfig{Count: 1}, 1024*1024*10)
+	require.Nil(t, err)
+	defer func() { _ = db.Close() }()
+	extStorage, err := storage.NewLocalStorage(t.TempDir())
+	require.Nil(t, err)
+
+	cf := model.ChangeFeedID{Namespace: "default", ID: "test"}
+	s := NewWithSpill(cf, []*pebble.DB{db}, extStorage,
+		&config.SortSpillConfig{ColdAfter: time.Hour, SegmentSize: 1})
+	defer s.Close()
+
+	span := spanz.TableIDToComparableSpan(1)
+	s.AddTable(span)
+	resolvedTs := make(chan model.Ts, 1)
+	s.OnResolve(func(_ tablepb.Span, ts model.Ts) { resolvedTs <- ts })
+
+	for _, ts := range [][2]uint64{{1, 2}, {2, 4}, {3, 4}, {5, 6}} {
+		s.Add(span, model.NewPolymorphicEvent(&model.RawKVEntry{
+			OpType:  model.OpTypePut,
+			Key:     []byte{1},
+			StartTs: ts[0],
+			CRTs:    ts[1],
+		}))
+	}
+	s.Add(span, model.NewResolvedPolymorphicEvent(0, 6))
+	select {
+	case <-resolvedTs:
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "must get a resolved timestamp instead of timeout")
+	}
+
+	// The events whose commit ts are not greater than 4 are spilled into 2
+	// segments, and removed from the local pebble.
+	state, _ := s.tables.Get(span)
+	require.Nil(t, s.spillTable(span, state, 4))
+	require.Len(t, state.spilled, 2)
+	require.Equal(t, engine.Position{CommitTs: 2, StartTs: 1}, state.spilled[0].upper)
+	require.Equal(t, engine.Position{CommitTs: 4, StartTs: 3}, state.spilled[1].upper)
+	iter := iterTable(db, state.uniqueID, span.TableID,
+		engine.Position{}, engine.Position{CommitTs: 4, StartTs: 3})
+	require.False(t, iter.Valid())
+	require.Nil(t, iter.Close())
+
+	fetch := func(lower engine.Position) ([][2]uint64, []engine.Position) {
+		iter := s.FetchByTable(span, lower, engine.Position{CommitTs: 6, StartTs: 5})
+		defer iter.Close()
+		var events [][2]uint64
+		var positions []engine.Position
+		for {
+			event, pos, err := iter.Next()
+			require.Nil(t, err)
+			if event == nil {
+				return events, positions
+			}
+			events = append(events, [2]uint64{event.StartTs, event.CRTs})
+			positions = append(positions, pos)
+		}
+	}
+	events, positions := fetch(engine.Position{})
+	require.Equal(t, [][2]uint64{{1, 2}, {2, 4}, {3, 4}, {5, 6}}, events)
+	require.Equal(t, []engine.Position{
+		{CommitTs: 2, StartTs: 1},
+		{CommitTs: 4, StartTs: 2},
+		{CommitTs: 4, StartTs: 3},
+		{CommitTs: 6, StartTs: 5},
+	}, positions)
+	events, _ = fetch(engine.Position{CommitTs: 4, StartTs: 3})
+	require.Equal(t, [][2]uint64{{3, 4}, {5, 6}}, events)
+
+	// A partly read segment is kept by the table for the next fetch.
+	partial := s.FetchByTable(span, engine.Position{}, engine.Position{CommitTs: 4, StartTs: 2})
+	for _, expected := range [][2]uint64{{1, 2}, {2, 4}} {
+		event, _, err := partial.Next()
+		require.Nil(t, err)
+		require.Equal(t, expected, [2]uint64{event.StartTs, event.CRTs})
+	}
+	event, _, err := partial.Next()
+	require.Nil(t, err)
+	require.Nil(t, event)
+	require.Nil(t, partial.Close())
+	reader := state.spillReader
+	require.NotNil(t, reader)
+	require.Equal(t, state.spilled[1], reader.segment)
+	events, _ = fetch(engine.Position{CommitTs: 4, StartTs: 3})
+	require.Equal(t, [][2]uint64{{3, 4}, {5, 6}}, events)
+	require.Nil(t, state.spillReader)
+
+	// The spilled files are deleted after the events are sent.
+	names := []string{state.spilled[0].name, state.spilled[1].name}
+	require.Nil(t, s.CleanByTable(span, engine.Position{CommitTs: 2, StartTs: 1}))
+	require.Len(t, state.spilled, 1)
+	exists, err := extStorage.FileExists(context.Background(), names[0])
+	require.Nil(t, err)
+	require.False(t, exists)
+
+	s.RemoveTable(span)
+	require.Len(t, state.spilled, 0)
+	exists, err = extStorage.FileExists(context.Background(), names[1])
+	require.Nil(t, err)
+	require.False(t, exists)
+}
+
+func TestSweepOrphans(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	extStorage, err := storage.NewLocalStorage(t.TempDir())
+	require.Nil(t, err)
+	s := &spiller{
+		storage: extStorage,
+		prefix:  "default_self_3",
+		ctx:     ctx,
+		orphans: make(map[string]struct{}),
+	}
+
+	now := time.Now()
+	require.Nil(t, s.keepAlive(now))
+	files := map[string]string{
+		"default_self_3_1_1_1-2.spill": "",
+		"default_live_2_1_1_1-2.spill": "",
+		"default_live_2.alive":         strconv.FormatInt(now.UnixNano(), 10),
+		"default_dead_1_1_1_1-2.spill": "",
+		"default_dead_1.alive":         strconv.FormatInt(now.Add(-time.Hour).UnixNano(), 10),
+		"default_gone_1_1_1_1-2.spill": "",
+		"unrelated.txt":                "",
+	}
+	for name, content := range files {
+		require.Nil(t, extStorage.WriteFile(ctx, name, []byte(content)))
+	}
+
+	// The dead instances are only removed by the second sweep.
+	require.Nil(t, s.sweepOrphans(now))
+	for name := range files {
+		exists, err := extStorage.FileExists(ctx, name)
+		require.Nil(t, err)
+		require.True(t, exists, name)
+	}
+	now = now.Add(spillSweepInterval)
+	require.Nil(t, extStorage.WriteFile(ctx, "default_live_2.alive",
+		[]byte(strconv.FormatInt(now.UnixNano(), 10))))
+	require.Nil(t, s.sweepOrphans(now))
+	for name := range files {
+		exists, err := extStorage.FileExists(ctx, name)
+		require.Nil(t, err)
+		removed := strings.HasPrefix(name, "default_dead") || strings.HasPrefix(name, "default_gone")
+		require.Equal(t, !removed, exists, name)
+	}
+}
@@ -82,6 +82,8 @@ type ddlJobPullerImpl struct {
 	jobMetaColumnID           int64
 	outputCh                  chan *model.DDLJobEntry
 	metricDiscardedDDLCounter prometheus.Counter
+	// metricsConfig decides the labels of the metrics of the sorter.
+	metricsConfig *config.MetricsConfig
 }
 
 // Run starts the DDLJobPuller.
@@ -92,7 +94,8 @@ func (p *ddlJobPullerImpl) Run(ctx context.Context) error {
 		return errors.Trace(p.puller.Run(ctx))
 	})
 
-	rawDDLCh := memory.SortOutput(ctx, p.puller.Output())
+	rawDDLCh := memory.SortOutput(
+		contextutil.PutMetricsConfigInCtx(ctx, p.metricsConfig), p.puller.Output())
 	eg.Go(
 		func() error {
 			for {
@@ -498,6 +501,7 @@ func NewDDLJobPuller(
 		outputCh:  make(chan *model.DDLJobEntry, defaultPullerOutputChanSize),
 		metricDiscardedDDLCounter: discardedDDLCounter.
 			WithLabelValues(changefeed.Namespace, changefeed.ID),
+		metricsConfig: replicaConfig.Metrics,
 	}, nil
 }
 
@@ -509,6 +513,10 @@ type DDLPuller interface {
 	FrontDDL() (uint64, *timodel.Job)
 	// PopFrontDDL returns and pops the first DDL job in the internal queue
 	PopFrontDDL() (uint64, *timodel.Job)
+	// PendingDDLCount returns the number of DDL jobs in the internal queue
+	PendingDDLCount() int
+	// FrontDDLs returns at most limit DDL jobs at the front of the internal queue
+	FrontDDLs(limit int) []*timodel.Job
 	// Close closes the DDLPuller
 	Close()
 }
@@ -676,6 +684,25 @@ func (h *ddlPullerImpl) PopFrontDDL() (uint64, *timodel.Job) {
 	return job.BinlogInfo.FinishedTS, job
 }
 
+// PendingDDLCount returns the number of pending DDL jobs
+func (h *ddlPullerImpl) PendingDDLCount() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.pendingDDLJobs)
+}
+
+// FrontDDLs returns at most limit pending DDL jobs at the front of the pending list
+func (h *ddlPullerImpl) FrontDDLs(limit int) []*timodel.Job {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if limit > len(h.pendingDDLJobs) {
+		limit = len(h.pendingDDLJobs)
+	}
+	jobs := make([]*timodel.Job, limit)
+	copy(jobs, h.pendingDDLJobs[:limit])
+	return jobs
+}
+
 // Close the ddl puller, release all resources.
 func (h *ddlPullerImpl) Close() {
 	log.Info("close the ddl puller",
@@ -574,6 +574,10 @@ func TestDDLPuller(t *testing.T) {
 
 	// DDL could be processed with a delay, wait here for a pending DDL job is added
 	waitResolvedTsGrowing(t, p, 18)
+	jobs := p.FrontDDLs(2)
+	require.Len(t, jobs, 1)
+	require.Equal(t, jobs[0].ID, int64(2))
+	require.Empty(t, p.FrontDDLs(0))
 	resolvedTs, ddl = p.PopFrontDDL()
 	require.Equal(t, resolvedTs, uint64(18))
 	require.Equal(t, ddl.ID, int64(2))
@@ -72,7 +72,7 @@ var (
 )
 
 // InitMetrics registers all metrics in this file
-func InitMetrics(registry *prometheus.Registry) {
+func InitMetrics(registry prometheus.Registerer) {
 	registry.MustRegister(txnCollectCounter)
 	registry.MustRegister(missedRegionCollectCounter)
 	registry.MustRegister(pullerResolvedTsGauge)
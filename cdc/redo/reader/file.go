@@ -31,6 +31,7 @@ import (
 	"github.com/pingcap/tidb/br/pkg/storage"
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/cdc/redo/writer"
+	"github.com/pingcap/tiflow/pkg/compression"
 	cerror "github.com/pingcap/tiflow/pkg/errors"
 	"github.com/pingcap/tiflow/pkg/redo"
 	"go.uber.org/multierr"
@@ -121,7 +122,8 @@ func selectDownLoadFile(
 	files := []string{}
 	err := extStorage.WalkDir(ctx, &storage.WalkOption{},
 		func(path string, size int64) error {
-			fileName := filepath.Base(path)
+			// the log files may be compressed, see downLoadToLocal.
+			_, fileName := compression.FromFileName(filepath.Base(path))
 			_, fileType, err := redo.ParseLogFileName(fileName)
 			if err != nil {
 				return err
@@ -155,12 +157,21 @@ func downLoadToLocal(
 			if err != nil {
 				return cerror.WrapError(cerror.ErrS3StorageAPI, err)
 			}
+			// the compressed log files are decompressed by the codecs of
+			// their extensions.
+			codec, name := compression.FromFileName(f)
+			if codec != nil {
+				data, err = compression.Decompress(compression.ComponentRedo, codec, data)
+				if err != nil {
+					return err
+				}
+			}
 
 			err = os.MkdirAll(dir, redo.DefaultDirMode)
 			if err != nil {
 				return cerror.WrapError(cerror.ErrRedoFileOp, err)
 			}
-			path := filepath.Join(dir, f)
+			path := filepath.Join(dir, name)
 			err = os.WriteFile(path, data, redo.DefaultFileMode)
 			return cerror.WrapError(cerror.ErrRedoFileOp, err)
 		})
@@ -24,7 +24,9 @@ import (
 
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/cdc/redo/writer"
+	"github.com/pingcap/tiflow/pkg/compression"
 	"github.com/pingcap/tiflow/pkg/redo"
+	"github.com/pingcap/tiflow/pkg/util"
 	"github.com/pingcap/tiflow/pkg/uuid"
 	"github.com/stretchr/testify/require"
 	"golang.org/x/net/context"
@@ -250,3 +252,25 @@ func TestReaderOpenSelectedFiles(t *testing.T) {
 	}
 	time.Sleep(1001 * time.Millisecond)
 }
+
+func TestReaderDownLoadCompressedFiles(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	extStorage, err := util.GetExternalStorageFromURI(ctx, "file://"+t.TempDir())
+	require.Nil(t, err)
+	codec, err := compression.Get(compression.ZSTD)
+	require.Nil(t, err)
+	fileName := fmt.Sprintf(redo.RedoLogFileFormatV2, "cp",
+		"default", "test-cf", redo.RedoRowLogFileType, 11,
+		uuid.NewGenerator().NewString(), redo.LogEXT)
+	compressed, err := codec.Compress([]byte("redo log"))
+	require.Nil(t, err)
+	require.Nil(t, extStorage.WriteFile(ctx, fileName+codec.Extension(), compressed))
+
+	dir := t.TempDir()
+	require.Nil(t, downLoadToLocal(ctx, dir, extStorage, redo.RedoRowLogFileType))
+	data, err := os.ReadFile(filepath.Join(dir, fileName))
+	require.Nil(t, err)
+	require.Equal(t, "redo log", string(data))
+}
@@ -30,6 +30,7 @@ import (
 	"github.com/pingcap/tidb/br/pkg/storage"
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/cdc/redo/common"
+	"github.com/pingcap/tiflow/pkg/compression"
 	cerror "github.com/pingcap/tiflow/pkg/errors"
 	"github.com/pingcap/tiflow/pkg/fsutil"
 	"github.com/pingcap/tiflow/pkg/redo"
@@ -84,6 +85,9 @@ type FileWriterConfig struct {
 	// MaxLogSize is the maximum size of log in megabyte, defaults to defaultMaxLogSize.
 	MaxLogSize int64
 	Dir        string
+	// Compression is the compression codec of the log files uploaded to the
+	// external storage, the extension of the codec is appended to their names.
+	Compression string
 }
 
 // Option define the writerOptions
@@ -134,6 +138,9 @@ type Writer struct {
 	sync.RWMutex
 	uuidGenerator uuid.Generator
 	allocator     *fsutil.FileAllocator
+	// codec compresses the log files uploaded to the external storage, nil
+	// means they are not compressed.
+	codec compression.Codec
 
 	metricFsyncDuration    prometheus.Observer
 	metricFlushAllDuration prometheus.Observer
@@ -158,6 +165,10 @@ func NewWriter(ctx context.Context, cfg *FileWriterConfig, opts ...Option) (*Wri
 			return nil, err
 		}
 	}
+	codec, err := compression.Get(cfg.Compression)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrRedoConfigInvalid, err)
+	}
 
 	op := &writerOptions{}
 	for _, opt := range opts {
@@ -169,6 +180,7 @@ func NewWriter(ctx context.Context, cfg *FileWriterConfig, opts ...Option) (*Wri
 		op:        op,
 		uint64buf: make([]byte, 8),
 		storage:   extStorage,
+		codec:     codec,
 
 		metricFsyncDuration: common.RedoFsyncDurationHistogram.
 			WithLabelValues(cfg.ChangeFeedID.Namespace, cfg.ChangeFeedID.ID),
@@ -187,7 +199,7 @@ func NewWriter(ctx context.Context, cfg *FileWriterConfig, opts ...Option) (*Wri
 		return nil, cerror.WrapError(cerror.ErrRedoFileOp, errors.New("invalid redo dir path"))
 	}
 
-	err := os.MkdirAll(cfg.Dir, redo.DefaultDirMode)
+	err = os.MkdirAll(cfg.Dir, redo.DefaultDirMode)
 	if err != nil {
 		return nil, cerror.WrapError(cerror.ErrRedoFileOp,
 			errors.Annotatef(err, "can't make dir: %s for redo writing", cfg.Dir))
@@ -485,7 +497,7 @@ func (w *Writer) GC(checkPointTs uint64) error {
 		go func() {
 			var errs error
 			for _, f := range remove {
-				err := w.storage.DeleteFile(context.Background(), f.Name())
+				err := w.storage.DeleteFile(context.Background(), w.externalFileName(f.Name()))
 				errs = multierr.Append(errs, err)
 			}
 			if errs != nil {
@@ -607,14 +619,30 @@ func (w *Writer) flush() error {
 	return cerror.WrapError(cerror.ErrRedoFileOp, err)
 }
 
+// externalFileName returns the name of a log file in the external storage,
+// which ends with the extension of the compression codec.
+func (w *Writer) externalFileName(name string) string {
+	if w.codec == nil {
+		return name
+	}
+	return name + w.codec.Extension()
+}
+
 func (w *Writer) writeToS3(ctx context.Context, name string) error {
 	fileData, err := os.ReadFile(name)
 	if err != nil {
 		return cerror.WrapError(cerror.ErrRedoFileOp, err)
 	}
 
+	if w.codec != nil {
+		fileData, err = compression.Compress(compression.ComponentRedo, w.codec, fileData)
+		if err != nil {
+			return err
+		}
+	}
+
 	// Key in s3: aws.String(rs.options.Prefix + name), prefix should be changefeed name
-	err = w.storage.WriteFile(ctx, filepath.Base(name), fileData)
+	err = w.storage.WriteFile(ctx, w.externalFileName(filepath.Base(name)), fileData)
 	if err != nil {
 		return cerror.WrapError(cerror.ErrS3StorageAPI, err)
 	}
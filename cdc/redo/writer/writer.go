@@ -89,6 +89,7 @@ func NewRedoLogWriter(
 		URI:                *uri,
 		UseExternalStorage: redo.IsExternalStorage(scheme),
 		MaxLogSize:         cfg.MaxLogSize,
+		Compression:        cfg.Compression,
 	}
 
 	if lwCfg.UseExternalStorage {
@@ -121,6 +122,9 @@ type logWriterConfig struct {
 	// MaxLogSize is the maximum size of log in megabyte, defaults to defaultMaxLogSize.
 	MaxLogSize int64
 	Dir        string
+	// Compression is the compression codec of the log files uploaded to the
+	// external storage.
+	Compression string
 }
 
 // logWriter implement the RedoLogWriter interface
@@ -155,6 +159,7 @@ func newLogWriter(
 			UseExternalStorage: cfg.UseExternalStorage,
 			MaxLogSize:         cfg.MaxLogSize,
 			Dir:                cfg.Dir,
+			Compression:        cfg.Compression,
 		}
 		if lw.rowWriter, err = NewWriter(ctx, writerCfg, opts...); err != nil {
 			return
@@ -170,6 +175,7 @@ func newLogWriter(
 			UseExternalStorage: cfg.UseExternalStorage,
 			MaxLogSize:         cfg.MaxLogSize,
 			Dir:                cfg.Dir,
+			Compression:        cfg.Compression,
 		}
 		if lw.ddlWriter, err = NewWriter(ctx, writerCfg, opts...); err != nil {
 			return
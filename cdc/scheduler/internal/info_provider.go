@@ -26,4 +26,7 @@ type InfoProvider interface {
 
 	// GetTaskStatuses returns the task statuses.
 	GetTaskStatuses() (map[model.CaptureID]*model.TaskStatus, error)
+
+	// GetLagBreakdown returns the replication lag of each pipeline stage.
+	GetLagBreakdown() (*model.LagBreakdown, error)
 }
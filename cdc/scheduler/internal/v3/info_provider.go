@@ -48,3 +48,11 @@ func (c *coordinator) GetTaskStatuses() (map[model.CaptureID]*model.TaskStatus,
 	}
 	return tasks, nil
 }
+
+// GetLagBreakdown returns the replication lag of each pipeline stage.
+func (c *coordinator) GetLagBreakdown() (*model.LagBreakdown, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.replicationM.LagBreakdown(), nil
+}
@@ -21,7 +21,7 @@ import (
 )
 
 // InitMetrics registers all metrics used in scheduler
-func InitMetrics(registry *prometheus.Registry) {
+func InitMetrics(registry prometheus.Registerer) {
 	member.InitMetrics(registry)
 	replication.InitMetrics(registry)
 	scheduler.InitMetrics(registry)
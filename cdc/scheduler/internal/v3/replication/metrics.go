@@ -118,6 +118,20 @@ var (
 			Help:      "Histogram of the slowest table resolved ts lag of each stage",
 			Buckets:   prometheus.LinearBuckets(0.5, 0.5, 36),
 		}, []string{"namespace", "changefeed", "stage"})
+	stageCheckpointTsLagGaugeVec = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "ticdc",
+			Subsystem: "scheduler",
+			Name:      "stage_checkpoint_ts_lag",
+			Help:      "The max checkpoint ts lag of each stage among all tables",
+		}, []string{"namespace", "changefeed", "stage"})
+	stageResolvedTsLagGaugeVec = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "ticdc",
+			Subsystem: "scheduler",
+			Name:      "stage_resolved_ts_lag",
+			Help:      "The max resolved ts lag of each stage among all tables",
+		}, []string{"namespace", "changefeed", "stage"})
 	slowestTableRegionGaugeVec = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Namespace: "ticdc",
@@ -128,7 +142,7 @@ var (
 )
 
 // InitMetrics registers all metrics used in scheduler
-func InitMetrics(registry *prometheus.Registry) {
+func InitMetrics(registry prometheus.Registerer) {
 	registry.MustRegister(tableGauge)
 	registry.MustRegister(tableStateGauge)
 	registry.MustRegister(acceptScheduleTaskCounter)
@@ -144,4 +158,6 @@ func InitMetrics(registry *prometheus.Registry) {
 	registry.MustRegister(slowestTableStageCheckpointTsLagHistogramVec)
 	registry.MustRegister(slowestTableStageResolvedTsLagHistogramVec)
 	registry.MustRegister(slowestTableRegionGaugeVec)
+	registry.MustRegister(stageCheckpointTsLagGaugeVec)
+	registry.MustRegister(stageResolvedTsLagGaugeVec)
 }
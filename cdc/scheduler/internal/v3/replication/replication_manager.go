@@ -602,9 +602,62 @@ func (r *Manager) logSlowTableInfo(currentPDTime time.Time) {
 	}
 }
 
+// LagBreakdown returns the replication lag of each stage of table pipelines.
+// For every stage, the table that lags the most is reported.
+// Tables whose stats have not been collected yet are skipped.
+func (r *Manager) LagBreakdown() *model.LagBreakdown {
+	breakdown := &model.LagBreakdown{Stages: make(map[string]model.StageLag)}
+	r.spans.Ascend(func(span tablepb.Span, table *ReplicationSet) bool {
+		if table.Stats.CurrentTs > breakdown.CurrentTs {
+			breakdown.CurrentTs = table.Stats.CurrentTs
+		}
+		return true
+	})
+	if breakdown.CurrentTs == 0 {
+		return breakdown
+	}
+
+	phyCurrentTs := oracle.ExtractPhysical(breakdown.CurrentTs)
+	update := func(stage string, tableID model.TableID, checkpoint tablepb.Checkpoint) {
+		lag, ok := breakdown.Stages[stage]
+		if ok && lag.CheckpointTs <= checkpoint.CheckpointTs {
+			return
+		}
+		breakdown.Stages[stage] = model.StageLag{
+			TableID:      tableID,
+			CheckpointTs: checkpoint.CheckpointTs,
+			ResolvedTs:   checkpoint.ResolvedTs,
+			CheckpointLag: float64(
+				phyCurrentTs-oracle.ExtractPhysical(checkpoint.CheckpointTs)) / 1e3,
+			ResolvedTsLag: float64(
+				phyCurrentTs-oracle.ExtractPhysical(checkpoint.ResolvedTs)) / 1e3,
+		}
+	}
+	r.spans.Ascend(func(span tablepb.Span, table *ReplicationSet) bool {
+		if table.Stats.CurrentTs == 0 {
+			return true
+		}
+		for stage, checkpoint := range table.Stats.StageCheckpoints {
+			update(stage, span.TableID, checkpoint)
+		}
+		update("barrier", span.TableID, tablepb.Checkpoint{
+			CheckpointTs: table.Stats.BarrierTs,
+			ResolvedTs:   table.Stats.BarrierTs,
+		})
+		return true
+	})
+	return breakdown
+}
+
 // CollectMetrics collects metrics.
 func (r *Manager) CollectMetrics() {
 	cf := r.changefeedID
+	for stage, lag := range r.LagBreakdown().Stages {
+		stageCheckpointTsLagGaugeVec.
+			WithLabelValues(cf.Namespace, cf.ID, stage).Set(lag.CheckpointLag)
+		stageResolvedTsLagGaugeVec.
+			WithLabelValues(cf.Namespace, cf.ID, stage).Set(lag.ResolvedTsLag)
+	}
 	tableGauge.
 		WithLabelValues(cf.Namespace, cf.ID).Set(float64(r.spans.Len()))
 	if table, ok := r.spans.Get(r.slowestTableID); ok {
@@ -721,6 +774,8 @@ func (r *Manager) CleanMetrics() {
 	slowestTableStageCheckpointTsLagHistogramVec.Reset()
 	slowestTableStageResolvedTsLagHistogramVec.Reset()
 	slowestTableRegionGaugeVec.Reset()
+	stageCheckpointTsLagGaugeVec.Reset()
+	stageResolvedTsLagGaugeVec.Reset()
 }
 
 // SetReplicationSetForTests is only used in tests.
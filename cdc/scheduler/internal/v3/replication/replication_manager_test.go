@@ -22,6 +22,7 @@ import (
 	"github.com/pingcap/tiflow/cdc/scheduler/schedulepb"
 	"github.com/pingcap/tiflow/pkg/spanz"
 	"github.com/stretchr/testify/require"
+	"github.com/tikv/client-go/v2/oracle"
 )
 
 func TestReplicationManagerHandleAddTableTask(t *testing.T) {
@@ -852,3 +853,56 @@ func TestLogSlowTableInfo(t *testing.T) {
 	// make sure the slowTableHeap's capacity will not extend
 	require.Equal(t, cap(r.slowTableHeap), 8)
 }
+
+func TestReplicationManagerLagBreakdown(t *testing.T) {
+	t.Parallel()
+
+	r := NewReplicationManager(1, model.ChangeFeedID{})
+	// Stats are not collected yet.
+	r.spans.ReplaceOrInsert(spanz.TableIDToComparableSpan(1), &ReplicationSet{
+		Span:       spanz.TableIDToComparableSpan(1),
+		Checkpoint: tablepb.Checkpoint{CheckpointTs: 1},
+		State:      ReplicationSetStateReplicating,
+	})
+	breakdown := r.LagBreakdown()
+	require.Zero(t, breakdown.CurrentTs)
+	require.Empty(t, breakdown.Stages)
+
+	now := time.Now()
+	ts := func(lag time.Duration) model.Ts {
+		return oracle.GoTimeToTS(now.Add(-lag))
+	}
+	r.spans.ReplaceOrInsert(spanz.TableIDToComparableSpan(2), &ReplicationSet{
+		Span:  spanz.TableIDToComparableSpan(2),
+		State: ReplicationSetStateReplicating,
+		Stats: tablepb.Stats{
+			CurrentTs: ts(0),
+			BarrierTs: ts(time.Second),
+			StageCheckpoints: map[string]tablepb.Checkpoint{
+				"puller-egress": {CheckpointTs: ts(2 * time.Second), ResolvedTs: ts(time.Second)},
+				"sink":          {CheckpointTs: ts(10 * time.Second), ResolvedTs: ts(9 * time.Second)},
+			},
+		},
+	})
+	r.spans.ReplaceOrInsert(spanz.TableIDToComparableSpan(3), &ReplicationSet{
+		Span:  spanz.TableIDToComparableSpan(3),
+		State: ReplicationSetStateReplicating,
+		Stats: tablepb.Stats{
+			CurrentTs: ts(0),
+			BarrierTs: ts(time.Second),
+			StageCheckpoints: map[string]tablepb.Checkpoint{
+				"puller-egress": {CheckpointTs: ts(5 * time.Second), ResolvedTs: ts(4 * time.Second)},
+				"sink":          {CheckpointTs: ts(6 * time.Second), ResolvedTs: ts(5 * time.Second)},
+			},
+		},
+	})
+	breakdown = r.LagBreakdown()
+	require.Equal(t, ts(0), breakdown.CurrentTs)
+	require.Len(t, breakdown.Stages, 3)
+	require.EqualValues(t, 3, breakdown.Stages["puller-egress"].TableID)
+	require.InDelta(t, 5, breakdown.Stages["puller-egress"].CheckpointLag, 0.01)
+	require.InDelta(t, 4, breakdown.Stages["puller-egress"].ResolvedTsLag, 0.01)
+	require.EqualValues(t, 2, breakdown.Stages["sink"].TableID)
+	require.InDelta(t, 10, breakdown.Stages["sink"].CheckpointLag, 0.01)
+	require.InDelta(t, 1, breakdown.Stages["barrier"].CheckpointLag, 0.01)
+}
@@ -26,6 +26,6 @@ var scheduleTaskCounter = prometheus.NewCounterVec(
 	}, []string{"namespace", "changefeed", "scheduler", "task"})
 
 // InitMetrics registers all metrics used in scheduler
-func InitMetrics(registry *prometheus.Registry) {
+func InitMetrics(registry prometheus.Registerer) {
 	registry.MustRegister(scheduleTaskCounter)
 }
@@ -0,0 +1,178 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"runtime/debug"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/tidb/util/gctuner"
+	"github.com/pingcap/tidb/util/memory"
+	"github.com/pingcap/tiflow/pkg/config"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/etcd"
+	"github.com/pingcap/tiflow/pkg/logutil"
+	"go.uber.org/zap"
+)
+
+// configReloadInterval is the interval to check the config file and the
+// dynamic config key in etcd.
+const configReloadInterval = 5 * time.Second
+
+// configReloader reloads the dynamic part of the server config from the
+// config file and the dynamic config key in etcd, the keys in etcd override
+// the ones in the file. The new config is stored as the global server config
+// and applied without restarting the captures.
+type configReloader struct {
+	configFile string
+	readEtcd   func(ctx context.Context) ([]byte, error)
+
+	// fileConfig is the dynamic config from the config file. It's the one of
+	// the startup config until the file changes, so the command line flags
+	// keep taking effect.
+	fileConfig *config.DynamicConfig
+	fileData   []byte
+	etcdData   []byte
+}
+
+func newConfigReloader(configFile string, etcdClient etcd.CDCEtcdClient) *configReloader {
+	r := &configReloader{
+		configFile: configFile,
+		fileConfig: config.GetGlobalServerConfig().GetDynamicConfig(),
+	}
+	if etcdClient != nil {
+		key := etcd.DynamicConfigKey(etcdClient.GetClusterID())
+		r.readEtcd = func(ctx context.Context) ([]byte, error) {
+			resp, err := etcdClient.GetEtcdClient().Get(ctx, key)
+			if err != nil {
+				return nil, cerror.WrapError(cerror.ErrPDEtcdAPIError, err)
+			}
+			if len(resp.Kvs) == 0 {
+				return nil, nil
+			}
+			return resp.Kvs[0].Value, nil
+		}
+	}
+	if configFile != "" {
+		// The startup config has been decoded from the file.
+		r.fileData, _ = os.ReadFile(configFile)
+	}
+	return r
+}
+
+func (r *configReloader) run(ctx context.Context) error {
+	applyGCTuner(config.GetGlobalServerConfig().GCTuner)
+
+	ticker := time.NewTicker(configReloadInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			if err := r.reload(ctx); err != nil {
+				log.Warn("fail to reload the dynamic server config", zap.Error(err))
+			}
+		}
+	}
+}
+
+// reload reloads the dynamic config if the config file or the dynamic config
+// key in etcd changes. An invalid config is reported once and ignored until
+// it changes again.
+func (r *configReloader) reload(ctx context.Context) error {
+	var fileData, etcdData []byte
+	if r.configFile != "" {
+		data, err := os.ReadFile(r.configFile)
+		if err != nil {
+			return cerror.WrapError(cerror.ErrInvalidServerOption, err)
+		}
+		fileData = data
+	}
+	if r.readEtcd != nil {
+		data, err := r.readEtcd(ctx)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		etcdData = data
+	}
+	fileChanged := !bytes.Equal(fileData, r.fileData)
+	if !fileChanged && bytes.Equal(etcdData, r.etcdData) {
+		return nil
+	}
+	r.fileData, r.etcdData = fileData, etcdData
+
+	if fileChanged {
+		cfg := config.GetDefaultServerConfig()
+		if _, err := toml.Decode(string(fileData), cfg); err != nil {
+			return cerror.WrapError(cerror.ErrInvalidServerOption, err)
+		}
+		r.fileConfig = cfg.GetDynamicConfig()
+	}
+	dynamicConfig := r.fileConfig.Clone()
+	if len(etcdData) > 0 {
+		if err := dynamicConfig.Merge(etcdData); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
+	oldCfg := config.GetGlobalServerConfig()
+	newCfg, err := oldCfg.WithDynamicConfig(dynamicConfig)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if newCfg.LogLevel != oldCfg.LogLevel {
+		if err := logutil.SetLogLevel(newCfg.LogLevel); err != nil {
+			return cerror.WrapError(cerror.ErrInvalidServerOption, err)
+		}
+	}
+	if oldCfg.GCTuner == nil || *newCfg.GCTuner != *oldCfg.GCTuner {
+		applyGCTuner(newCfg.GCTuner)
+	}
+	config.StoreGlobalServerConfig(newCfg)
+	log.Info("dynamic server config reloaded", zap.Any("config", dynamicConfig))
+	return nil
+}
+
+// applyGCTuner enables the GC tuner with the threshold of the given
+// percentage of the total memory, or disables it and restores GOGC.
+func applyGCTuner(cfg *config.GCTunerConfig) {
+	if cfg == nil || cfg.MemoryThresholdPercentage == 0 {
+		if gctuner.EnableGOGCTuner.Load() {
+			gctuner.EnableGOGCTuner.Store(false)
+			gctuner.Tuning(0)
+			debug.SetGCPercent(getGOGCFromEnv())
+			log.Info("GC tuner disabled")
+		}
+		return
+	}
+	total, err := memory.MemTotal()
+	if err != nil {
+		log.Warn("fail to get the total memory, GC tuner is not changed", zap.Error(err))
+		return
+	}
+	threshold := total * uint64(cfg.MemoryThresholdPercentage) / 100
+	gctuner.EnableGOGCTuner.Store(true)
+	gctuner.SetMinGCPercent(cfg.MinGCPercent)
+	gctuner.Tuning(threshold)
+	log.Info("GC tuner enabled",
+		zap.Uint64("threshold", threshold),
+		zap.Uint32("minGCPercent", cfg.MinGCPercent))
+}
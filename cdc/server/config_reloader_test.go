@@ -0,0 +1,80 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigReloader(t *testing.T) {
+	oldCfg := config.GetGlobalServerConfig()
+	defer config.StoreGlobalServerConfig(oldCfg)
+	cfg := config.GetDefaultServerConfig()
+	cfg.Sorter.SortDir = "/data/tmp/sorter"
+	config.StoreGlobalServerConfig(cfg)
+
+	configFile := filepath.Join(t.TempDir(), "server.toml")
+	require.Nil(t, os.WriteFile(configFile, []byte(`per-table-memory-quota = 1024`), 0o644))
+	var etcdData []byte
+	r := newConfigReloader(configFile, nil)
+	r.readEtcd = func(ctx context.Context) ([]byte, error) {
+		return etcdData, nil
+	}
+
+	// the startup config is kept if nothing changes.
+	ctx := context.Background()
+	require.Nil(t, r.reload(ctx))
+	require.Same(t, cfg, config.GetGlobalServerConfig())
+
+	require.Nil(t, os.WriteFile(configFile, []byte(`
+per-table-memory-quota = 2048
+addr = "127.0.0.1:8301"
+[sorter]
+max-memory-percentage = 20
+[gc-tuner]
+memory-threshold-percentage = 60
+`), 0o644))
+	require.Nil(t, r.reload(ctx))
+	newCfg := config.GetGlobalServerConfig()
+	require.Equal(t, uint64(2048), newCfg.PerTableMemoryQuota)
+	require.Equal(t, 60, newCfg.GCTuner.MemoryThresholdPercentage)
+	// the static configs are not reloaded.
+	require.Equal(t, cfg.Addr, newCfg.Addr)
+	require.Equal(t, cfg.Sorter.MaxMemoryPercentage, newCfg.Sorter.MaxMemoryPercentage)
+	require.Equal(t, "/data/tmp/sorter", newCfg.Sorter.SortDir)
+
+	// the keys in etcd override the ones in the file.
+	etcdData = []byte(`{"gc-tuner":{"memory-threshold-percentage":70}}`)
+	require.Nil(t, r.reload(ctx))
+	newCfg = config.GetGlobalServerConfig()
+	require.Equal(t, uint64(2048), newCfg.PerTableMemoryQuota)
+	require.Equal(t, 70, newCfg.GCTuner.MemoryThresholdPercentage)
+
+	// an invalid config is ignored.
+	etcdData = []byte(`{"gc-tuner":{"memory-threshold-percentage":100}}`)
+	require.Regexp(t, "memory-threshold-percentage", r.reload(ctx))
+	require.Same(t, newCfg, config.GetGlobalServerConfig())
+	require.Nil(t, r.reload(ctx))
+	require.Same(t, newCfg, config.GetGlobalServerConfig())
+
+	etcdData = nil
+	require.Nil(t, r.reload(ctx))
+	require.Equal(t, 60, config.GetGlobalServerConfig().GCTuner.MemoryThresholdPercentage)
+}
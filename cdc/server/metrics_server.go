@@ -50,19 +50,24 @@ var (
 
 // RecordGoRuntimeSettings records GOGC settings.
 func RecordGoRuntimeSettings() {
+	goGC.Set(float64(getGOGCFromEnv()))
+
+	maxProcs := runtime.GOMAXPROCS(0)
+	goMaxProcs.Set(float64(maxProcs))
+}
+
+// getGOGCFromEnv returns the GOGC value set by the environment variable.
+func getGOGCFromEnv() int {
 	// The default GOGC value is 100. See debug.SetGCPercent.
 	gogcValue := 100
 	if val, err := strconv.Atoi(os.Getenv("GOGC")); err == nil {
 		gogcValue = val
 	}
-	goGC.Set(float64(gogcValue))
-
-	maxProcs := runtime.GOMAXPROCS(0)
-	goMaxProcs.Set(float64(maxProcs))
+	return gogcValue
 }
 
 // initServerMetrics registers all metrics used in processor
-func initServerMetrics(registry *prometheus.Registry) {
+func initServerMetrics(registry prometheus.Registerer) {
 	registry.MustRegister(etcdHealthCheckDuration)
 	registry.MustRegister(goGC)
 	registry.MustRegister(goMaxProcs)
@@ -84,6 +84,9 @@ type server struct {
 	statusServer *http.Server
 	etcdClient   etcd.CDCEtcdClient
 	pdEndpoints  []string
+	// configFile is the path of the config file, the dynamic part of the
+	// config is reloaded from it.
+	configFile string
 
 	tableActorSystem *system.System
 
@@ -94,7 +97,7 @@ type server struct {
 }
 
 // New creates a server instance.
-func New(pdEndpoints []string) (*server, error) {
+func New(pdEndpoints []string, configFile string) (*server, error) {
 	conf := config.GetGlobalServerConfig()
 
 	// This is to make communication between nodes possible.
@@ -121,6 +124,7 @@ func New(pdEndpoints []string) (*server, error) {
 
 	s := &server{
 		pdEndpoints: pdEndpoints,
+		configFile:  configFile,
 		grpcService: p2p.NewServerWrapper(debugConfig.Messages.ToMessageServerConfig()),
 		tcpServer:   tcpServer,
 
@@ -360,6 +364,10 @@ func (s *server) run(ctx context.Context) (err error) {
 		return s.etcdHealthChecker(cctx)
 	})
 
+	wg.Go(func() error {
+		return newConfigReloader(s.configFile, s.etcdClient).run(cctx)
+	})
+
 	wg.Go(func() error {
 		return kv.RunWorkerPool(cctx)
 	})
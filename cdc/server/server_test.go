@@ -182,7 +182,7 @@ func TestServerTLSWithoutCommonName(t *testing.T) {
 	conf.Security = securityCfg
 	config.StoreGlobalServerConfig(conf)
 
-	server, err := New([]string{"https://127.0.0.1:2379"})
+	server, err := New([]string{"https://127.0.0.1:2379"}, "")
 	cp := capture.NewCapture4Test(nil)
 	ctrl := gomock.NewController(t)
 	etcdClient := mock_etcd.NewMockCDCEtcdClient(ctrl)
@@ -269,7 +269,7 @@ func TestServerTLSWithCommonNameAndRotate(t *testing.T) {
 	conf.Security = securityCfg
 	config.StoreGlobalServerConfig(conf)
 
-	server, err := New([]string{"https://127.0.0.1:2379"})
+	server, err := New([]string{"https://127.0.0.1:2379"}, "")
 	cp := capture.NewCapture4Test(nil)
 	ctrl := gomock.NewController(t)
 	etcdClient := mock_etcd.NewMockCDCEtcdClient(ctrl)
@@ -25,9 +25,11 @@ import (
 	"github.com/linkedin/goavro/v2"
 	"github.com/pingcap/errors"
 	"github.com/pingcap/log"
+	timodel "github.com/pingcap/tidb/parser/model"
 	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tidb/types"
 	"github.com/pingcap/tidb/util/rowcodec"
+	filter "github.com/pingcap/tidb/util/table-filter"
 	"github.com/pingcap/tiflow/cdc/contextutil"
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/cdc/sink/codec"
@@ -48,6 +50,17 @@ type BatchEncoder struct {
 	enableTiDBExtension        bool
 	decimalHandlingMode        string
 	bigintUnsignedHandlingMode string
+	nameSanitizationMode       string
+	namespaceMode              string
+	nullHandlingMode           string
+	keyMode                    string
+	keyRules                   []*keyRule
+}
+
+// keyRule selects the columns of the avro key for the matched tables.
+type keyRule struct {
+	filter  filter.Filter
+	columns []string
 }
 
 type avroEncodeResult struct {
@@ -119,11 +132,117 @@ func (a *BatchEncoder) EncodeCheckpointEvent(ts uint64) (*common.Message, error)
 	return nil, nil
 }
 
-// EncodeDDLEvent is no-op now
+// EncodeDDLEvent is no-op now, the DDL events are encoded by
+// EncodeDDLEventForTopic.
 func (a *BatchEncoder) EncodeDDLEvent(e *model.DDLEvent) (*common.Message, error) {
 	return nil, nil
 }
 
+// The headers of the schema change markers.
+const (
+	// schemaChangeSubjectHeader is the header of the subject of the value
+	// schema registered for the DDL.
+	schemaChangeSubjectHeader = "ticdc-avro-schema-subject"
+	// schemaChangeIDHeader is the header of the registry ID of the value
+	// schema registered for the DDL.
+	schemaChangeIDHeader = "ticdc-avro-schema-id"
+	// schemaChangeQueryHeader is the header of the query of the DDL.
+	schemaChangeQueryHeader = "ticdc-ddl-query"
+)
+
+// EncodeDDLEventForTopic implements the codec.DDLEventTopicEncoder interface.
+// It registers the value schema of the new version of the table, and returns
+// a schema change marker, which has the name of the table as the key, so that
+// the compacted topics accept it, and a nil value, so that the avro value
+// deserializers skip it. The consumers must recognize the markers by their
+// headers before deserializing the keys, and react to the evolution. The DDLs which don't change the schema of a table in the
+// topic, like dropping or renaming the table, are skipped, the schema of a
+// renamed table is registered by its first row in the new topic.
+func (a *BatchEncoder) EncodeDDLEventForTopic(
+	ctx context.Context, topic string, e *model.DDLEvent,
+) (*common.Message, error) {
+	if !hasNewTableSchema(e) {
+		return nil, nil
+	}
+	topic = sanitizeTopic(topic)
+	cols, colInfos := tableColumns(e.TableInfo)
+	if len(cols) == 0 {
+		return nil, nil
+	}
+	_, registryID, err := a.valueSchemaManager.GetCachedOrRegister(
+		ctx,
+		topic,
+		e.TableInfo.Version,
+		a.schemaGenerator(&e.TableInfo.TableName, cols, colInfos, a.enableTiDBExtension),
+	)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	key := []byte(e.TableInfo.TableName.String())
+	message := common.NewDDLMsg(config.ProtocolAvro, key, nil, e)
+	message.Headers = append(message.Headers,
+		common.MessageHeader{
+			Key:   schemaChangeSubjectHeader,
+			Value: []byte(a.valueSchemaManager.topicNameToSchemaSubject(topic)),
+		},
+		common.MessageHeader{
+			Key:   schemaChangeIDHeader,
+			Value: []byte(strconv.Itoa(registryID)),
+		},
+		common.MessageHeader{
+			Key:   schemaChangeQueryHeader,
+			Value: []byte(e.Query),
+		},
+	)
+	return message, nil
+}
+
+// hasNewTableSchema returns whether the DDL changes the schema of a table
+// which stays in its topic.
+func hasNewTableSchema(e *model.DDLEvent) bool {
+	if e.TableInfo == nil || e.TableInfo.TableInfo == nil ||
+		e.TableInfo.TableName.Table == "" || e.TableInfo.IsView() {
+		return false
+	}
+	switch e.Type {
+	case timodel.ActionDropTable, timodel.ActionDropView, timodel.ActionDropSchema,
+		timodel.ActionRenameTable, timodel.ActionRenameTables:
+		return false
+	}
+	if e.PreTableInfo != nil && e.PreTableInfo.TableName.Table != "" &&
+		(e.PreTableInfo.TableName.Schema != e.TableInfo.TableName.Schema ||
+			e.PreTableInfo.TableName.Table != e.TableInfo.TableName.Table) {
+		return false
+	}
+	return true
+}
+
+// tableColumns returns the columns of the table without values, which are
+// laid out like the columns of its row changed events.
+func tableColumns(ti *model.TableInfo) ([]*model.Column, []rowcodec.ColInfo) {
+	_, _, colInfos := ti.GetRowColInfos()
+	cols := make([]*model.Column, len(ti.RowColumnsOffset))
+	for _, colInfo := range ti.Columns {
+		offset, ok := ti.RowColumnsOffset[colInfo.ID]
+		if !ok {
+			continue
+		}
+		defaultValue := colInfo.GetDefaultValue()
+		if defaultValue == nil {
+			defaultValue = colInfo.GetOriginDefaultValue()
+		}
+		cols[offset] = &model.Column{
+			Name:    colInfo.Name.O,
+			Type:    colInfo.GetType(),
+			Charset: colInfo.GetCharset(),
+			Default: types.NewDatum(defaultValue).GetValue(),
+			Flag:    ti.ColumnsFlag[colInfo.ID],
+		}
+	}
+	return cols, colInfos
+}
+
 // Build Messages
 func (a *BatchEncoder) Build() (messages []*common.Message) {
 	result := a.result
@@ -150,7 +269,11 @@ func (a *BatchEncoder) avroEncode(
 		operation           string
 	)
 	if isKey {
-		cols, colInfos = e.HandleKeyColInfos()
+		var err error
+		cols, colInfos, err = a.keyColumns(e)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
 		enableTiDBExtension = false
 		schemaManager = a.keySchemaManager
 	} else {
@@ -171,31 +294,15 @@ func (a *BatchEncoder) avroEncode(
 	if len(cols) == 0 {
 		return nil, nil
 	}
-
-	namespace := getAvroNamespace(a.namespace, e.Table)
-
-	schemaGen := func() (string, error) {
-		schema, err := rowToAvroSchema(
-			namespace,
-			e.Table.Table,
-			cols,
-			colInfos,
-			enableTiDBExtension,
-			a.decimalHandlingMode,
-			a.bigintUnsignedHandlingMode,
-		)
-		if err != nil {
-			log.Error("AvroEventBatchEncoder: generating schema failed", zap.Error(err))
-			return "", errors.Trace(err)
-		}
-		return schema, nil
+	if isKey && a.keyMode == common.KeyModeSingle {
+		return a.avroEncodeSingleKey(ctx, e, topic, cols, colInfos)
 	}
 
 	avroCodec, registryID, err := schemaManager.GetCachedOrRegister(
 		ctx,
 		topic,
 		e.TableInfo.Version,
-		schemaGen,
+		a.schemaGenerator(e.Table, cols, colInfos, enableTiDBExtension),
 	)
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -209,6 +316,7 @@ func (a *BatchEncoder) avroEncode(
 		enableTiDBExtension,
 		a.decimalHandlingMode,
 		a.bigintUnsignedHandlingMode,
+		a.nullHandlingMode,
 	)
 	if err != nil {
 		log.Error("AvroEventBatchEncoder: converting to native failed", zap.Error(err))
@@ -227,6 +335,138 @@ func (a *BatchEncoder) avroEncode(
 	}, nil
 }
 
+// schemaGenerator returns the generator of the avro schema of the columns.
+func (a *BatchEncoder) schemaGenerator(
+	tableName *model.TableName,
+	cols []*model.Column,
+	colInfos []rowcodec.ColInfo,
+	enableTiDBExtension bool,
+) SchemaGenerator {
+	return func() (string, error) {
+		// The names are checked only when the schema is generated, the data
+		// of the row can't be encoded without the schema.
+		if a.nameSanitizationMode == common.NameSanitizationModeError {
+			if err := a.checkNames(tableName, cols); err != nil {
+				return "", errors.Trace(err)
+			}
+		}
+		schema, err := rowToAvroSchema(
+			a.getNamespace(tableName),
+			tableName.Table,
+			cols,
+			colInfos,
+			enableTiDBExtension,
+			a.decimalHandlingMode,
+			a.bigintUnsignedHandlingMode,
+			a.nullHandlingMode,
+		)
+		if err != nil {
+			log.Error("AvroEventBatchEncoder: generating schema failed", zap.Error(err))
+			return "", errors.Trace(err)
+		}
+		return schema, nil
+	}
+}
+
+// keyColumns returns the columns of the avro key of the row, which are the
+// handle key columns unless they are customized by the key rules.
+func (a *BatchEncoder) keyColumns(
+	e *model.RowChangedEvent,
+) ([]*model.Column, []rowcodec.ColInfo, error) {
+	for _, rule := range a.keyRules {
+		if !rule.filter.MatchTable(e.Table.Schema, e.Table.Table) {
+			continue
+		}
+		cols := e.Columns
+		if e.IsDelete() {
+			cols = e.PreColumns
+		}
+		keyCols := make([]*model.Column, 0, len(rule.columns))
+		keyColInfos := make([]rowcodec.ColInfo, 0, len(rule.columns))
+		for _, name := range rule.columns {
+			found := false
+			for i, col := range cols {
+				if col != nil && strings.EqualFold(col.Name, name) {
+					keyCols = append(keyCols, col)
+					keyColInfos = append(keyColInfos, e.ColInfos[i])
+					found = true
+					break
+				}
+			}
+			if !found {
+				return nil, nil, cerror.ErrAvroEncodeFailed.GenWithStack(
+					"avro key column %s not found in table %s", name, e.Table)
+			}
+		}
+		return keyCols, keyColInfos, nil
+	}
+	cols, colInfos := e.HandleKeyColInfos()
+	return cols, colInfos, nil
+}
+
+// avroEncodeSingleKey encodes the avro key as the value of its only column
+// instead of a record, which is expected by some Kafka Connect connectors.
+func (a *BatchEncoder) avroEncodeSingleKey(
+	ctx context.Context,
+	e *model.RowChangedEvent,
+	topic string,
+	cols []*model.Column,
+	colInfos []rowcodec.ColInfo,
+) (*avroEncodeResult, error) {
+	if len(cols) != 1 {
+		return nil, cerror.ErrAvroEncodeFailed.GenWithStack(
+			"the avro key of table %s has %d columns, but the single key mode "+
+				"requires exactly one", e.Table, len(cols))
+	}
+	col, ft := cols[0], colInfos[0].Ft
+	avroType, err := columnToAvroSchema(
+		col, ft, a.decimalHandlingMode, a.bigintUnsignedHandlingMode)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	schemaGen := func() (string, error) {
+		if a.nameSanitizationMode == common.NameSanitizationModeError {
+			if err := a.checkNames(e.Table, cols); err != nil {
+				return "", errors.Trace(err)
+			}
+		}
+		schema, err := json.Marshal(avroType)
+		if err != nil {
+			return "", cerror.WrapError(cerror.ErrAvroMarshalFailed, err)
+		}
+		return string(schema), nil
+	}
+
+	avroCodec, registryID, err := a.keySchemaManager.GetCachedOrRegister(
+		ctx,
+		topic,
+		e.TableInfo.Version,
+		schemaGen,
+	)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	data, _, err := columnToAvroData(
+		col, ft, a.decimalHandlingMode, a.bigintUnsignedHandlingMode)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	// The key can't be a union, the null value is encoded as the zero value.
+	if data == nil {
+		data = nullSentinel(avroType)
+	}
+	bin, err := avroCodec.BinaryFromNative(nil, data)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrAvroEncodeToBinary, err)
+	}
+	return &avroEncodeResult{
+		data:       bin,
+		registryID: registryID,
+	}, nil
+}
+
 type avroSchemaTop struct {
 	Tp        string                   `json:"type"`
 	Name      string                   `json:"name"`
@@ -332,6 +572,53 @@ func escapeEnumAndSetOptions(option string) string {
 	return option
 }
 
+// isLegalName returns whether the name is permitted by avro without escaping.
+func isLegalName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i, c := range name {
+		if i == 0 && (c >= '0' && c <= '9') {
+			return false
+		}
+		if !(c == '_' ||
+			('a' <= c && c <= 'z') ||
+			('A' <= c && c <= 'Z') ||
+			('0' <= c && c <= '9')) {
+			return false
+		}
+	}
+	return true
+}
+
+// checkNames checks the names used in the avro schema of the table, which
+// are escaped silently in the mangle mode.
+func (a *BatchEncoder) checkNames(tableName *model.TableName, cols []*model.Column) error {
+	names := []string{tableName.Schema, tableName.Table}
+	if a.namespaceMode != common.NamespaceModeSchema {
+		names = append(names, a.namespace)
+	}
+	for _, col := range cols {
+		if col != nil {
+			names = append(names, col.Name)
+		}
+	}
+	for _, name := range names {
+		if !isLegalName(name) {
+			return cerror.ErrAvroIllegalName.GenWithStackByArgs(name)
+		}
+	}
+	return nil
+}
+
+// getNamespace returns the avro namespace of the table.
+func (a *BatchEncoder) getNamespace(tableName *model.TableName) string {
+	if a.namespaceMode == common.NamespaceModeSchema {
+		return sanitizeName(tableName.Schema)
+	}
+	return getAvroNamespace(a.namespace, tableName)
+}
+
 func getAvroNamespace(namespace string, tableName *model.TableName) string {
 	return sanitizeName(namespace) + "." + sanitizeName(tableName.Schema)
 }
@@ -357,6 +644,7 @@ func rowToAvroSchema(
 	enableTiDBExtension bool,
 	decimalHandlingMode string,
 	bigintUnsignedHandlingMode string,
+	nullHandlingMode string,
 ) (string, error) {
 	top := avroSchemaTop{
 		Tp:        "record",
@@ -392,15 +680,19 @@ func rowToAvroSchema(
 		}
 		// goavro doesn't support set default value for logical type
 		// https://github.com/linkedin/goavro/issues/202
+		// The nullable columns are encoded as unions only in the union mode,
+		// the null values are encoded as the zero values otherwise.
+		nullable := col.Flag.IsNullable() &&
+			nullHandlingMode != common.NullHandlingModeSentinel
 		if _, ok := avroType.(avroLogicalTypeSchema); ok {
-			if col.Flag.IsNullable() {
+			if nullable {
 				field["type"] = []interface{}{"null", avroType}
 				field["default"] = nil
 			} else {
 				field["type"] = avroType
 			}
 		} else {
-			if col.Flag.IsNullable() {
+			if nullable {
 				// https://stackoverflow.com/questions/22938124/avro-field-default-values
 				if defaultValue == nil {
 					field["type"] = []interface{}{"null", avroType}
@@ -452,6 +744,7 @@ func rowToAvroData(
 	enableTiDBExtension bool,
 	decimalHandlingMode string,
 	bigintUnsignedHandlingMode string,
+	nullHandlingMode string,
 ) (map[string]interface{}, error) {
 	ret := make(map[string]interface{}, len(cols))
 	for i, col := range cols {
@@ -468,10 +761,24 @@ func rowToAvroData(
 			return nil, err
 		}
 
-		// https://pkg.go.dev/github.com/linkedin/goavro/v2#Union
-		if col.Flag.IsNullable() {
+		switch {
+		case !col.Flag.IsNullable():
+			ret[sanitizeName(col.Name)] = data
+		case nullHandlingMode != common.NullHandlingModeSentinel:
+			// https://pkg.go.dev/github.com/linkedin/goavro/v2#Union
 			ret[sanitizeName(col.Name)] = goavro.Union(str, data)
-		} else {
+		case data == nil:
+			avroType, err := columnToAvroSchema(
+				col,
+				colInfos[i].Ft,
+				decimalHandlingMode,
+				bigintUnsignedHandlingMode,
+			)
+			if err != nil {
+				return nil, err
+			}
+			ret[sanitizeName(col.Name)] = nullSentinel(avroType)
+		default:
 			ret[sanitizeName(col.Name)] = data
 		}
 	}
@@ -618,6 +925,28 @@ func columnToAvroSchema(
 	}
 }
 
+// nullSentinel returns the zero value of the avro type, which stands for the
+// null values in the sentinel null handling mode.
+func nullSentinel(avroType interface{}) interface{} {
+	switch t := avroType.(type) {
+	case avroLogicalTypeSchema:
+		// decimal is the only logical type.
+		return new(big.Rat)
+	case avroSchema:
+		switch t.Type {
+		case "int":
+			return int32(0)
+		case "long":
+			return int64(0)
+		case "double":
+			return float64(0)
+		case "bytes":
+			return []byte{}
+		}
+	}
+	return ""
+}
+
 func columnToAvroData(
 	col *model.Column,
 	ft *types.FieldType,
@@ -784,6 +1113,7 @@ type batchEncoderBuilder struct {
 	config             *common.Config
 	keySchemaManager   *schemaManager
 	valueSchemaManager *schemaManager
+	keyRules           []*keyRule
 }
 
 const (
@@ -813,11 +1143,21 @@ func NewBatchEncoderBuilder(ctx context.Context, config *common.Config) (codec.E
 		return nil, errors.Trace(err)
 	}
 
+	keyRules := make([]*keyRule, 0, len(config.AvroKeyRules))
+	for _, rule := range config.AvroKeyRules {
+		f, err := filter.Parse(rule.Matcher)
+		if err != nil {
+			return nil, cerror.WrapError(cerror.ErrCodecInvalidConfig, err)
+		}
+		keyRules = append(keyRules, &keyRule{filter: f, columns: rule.Columns})
+	}
+
 	return &batchEncoderBuilder{
 		namespace:          contextutil.ChangefeedIDFromCtx(ctx).Namespace,
 		config:             config,
 		keySchemaManager:   keySchemaManager,
 		valueSchemaManager: valueSchemaManager,
+		keyRules:           keyRules,
 	}, nil
 }
 
@@ -831,6 +1171,11 @@ func (b *batchEncoderBuilder) Build() codec.EventBatchEncoder {
 	encoder.enableTiDBExtension = b.config.EnableTiDBExtension
 	encoder.decimalHandlingMode = b.config.AvroDecimalHandlingMode
 	encoder.bigintUnsignedHandlingMode = b.config.AvroBigintUnsignedHandlingMode
+	encoder.nameSanitizationMode = b.config.AvroNameSanitizationMode
+	encoder.namespaceMode = b.config.AvroNamespaceMode
+	encoder.nullHandlingMode = b.config.AvroNullHandlingMode
+	encoder.keyMode = b.config.AvroKeyMode
+	encoder.keyRules = b.keyRules
 
 	return encoder
 }
@@ -18,14 +18,18 @@ import (
 	"context"
 	"encoding/json"
 	"math/big"
+	"strconv"
 	"testing"
 
 	"github.com/linkedin/goavro/v2"
+	timodel "github.com/pingcap/tidb/parser/model"
 	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tidb/types"
 	"github.com/pingcap/tidb/util/rowcodec"
+	filter "github.com/pingcap/tidb/util/table-filter"
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/cdc/sink/codec/common"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
 	"github.com/stretchr/testify/require"
 )
 
@@ -659,6 +663,7 @@ func TestRowToAvroSchema(t *testing.T) {
 		false,
 		"precise",
 		"long",
+		"union",
 	)
 	require.NoError(t, err)
 	require.Equal(t, expectedSchemaWithoutExtension, indentJSON(schema))
@@ -673,6 +678,7 @@ func TestRowToAvroSchema(t *testing.T) {
 		true,
 		"precise",
 		"long",
+		"union",
 	)
 	require.NoError(t, err)
 	require.Equal(t, expectedSchemaWithExtension, indentJSON(schema))
@@ -697,7 +703,7 @@ func TestRowToAvroData(t *testing.T) {
 		colInfos = append(colInfos, v.colInfo)
 	}
 
-	data, err := rowToAvroData(cols, colInfos, 417318403368288260, "c", false, "precise", "long")
+	data, err := rowToAvroData(cols, colInfos, 417318403368288260, "c", false, "precise", "long", "union")
 	require.NoError(t, err)
 	_, exists := data["_tidb_commit_ts"]
 	require.False(t, exists)
@@ -706,7 +712,7 @@ func TestRowToAvroData(t *testing.T) {
 	_, exists = data["_tidb_commit_physical_time"]
 	require.False(t, exists)
 
-	data, err = rowToAvroData(cols, colInfos, 417318403368288260, "c", true, "precise", "long")
+	data, err = rowToAvroData(cols, colInfos, 417318403368288260, "c", true, "precise", "long", "union")
 	require.NoError(t, err)
 	v, exists := data["_tidb_commit_ts"]
 	require.True(t, exists)
@@ -719,6 +725,130 @@ func TestRowToAvroData(t *testing.T) {
 	require.Equal(t, "c", v.(string))
 }
 
+func TestRowToAvroSentinel(t *testing.T) {
+	t.Parallel()
+
+	table := model.TableName{
+		Schema: "testdb",
+		Table:  "rowtoavrosentinel",
+	}
+	namespace := getAvroNamespace(model.DefaultNamespace, &table)
+	cols := make([]*model.Column, 0)
+	colInfos := make([]rowcodec.ColInfo, 0)
+
+	for _, v := range avroTestColumns {
+		colNew := v.col
+		colNew.Name = colNew.Name + "nullable"
+		colNew.Value = nil
+		colNew.Flag.SetIsNullable()
+		cols = append(cols, &colNew)
+		colInfos = append(colInfos, v.colInfo)
+	}
+
+	schema, err := rowToAvroSchema(
+		namespace,
+		table.Table,
+		cols,
+		colInfos,
+		false,
+		"precise",
+		"long",
+		"sentinel",
+	)
+	require.NoError(t, err)
+	require.NotContains(t, schema, `"null"`)
+	avroCodec, err := goavro.NewCodec(schema)
+	require.NoError(t, err)
+
+	data, err := rowToAvroData(cols, colInfos, 417318403368288260, "c", false, "precise", "long", "sentinel")
+	require.NoError(t, err)
+	for _, v := range data {
+		require.NotNil(t, v)
+	}
+	_, err = avroCodec.BinaryFromNative(nil, data)
+	require.NoError(t, err)
+}
+
+func TestAvroKeyColumns(t *testing.T) {
+	t.Parallel()
+
+	f, err := filter.Parse([]string{"testdb.t1"})
+	require.NoError(t, err)
+	encoder := &BatchEncoder{keyRules: []*keyRule{{filter: f, columns: []string{"B", "a"}}}}
+
+	cols := []*model.Column{
+		{Name: "id", Value: int64(1), Type: mysql.TypeLong, Flag: model.HandleKeyFlag},
+		{Name: "a", Value: int64(2), Type: mysql.TypeLong},
+		{Name: "b", Value: "b", Type: mysql.TypeVarchar},
+	}
+	colInfos := []rowcodec.ColInfo{
+		{ID: 1, Ft: types.NewFieldType(mysql.TypeLong)},
+		{ID: 2, Ft: types.NewFieldType(mysql.TypeLong)},
+		{ID: 3, Ft: types.NewFieldType(mysql.TypeVarchar)},
+	}
+	event := &model.RowChangedEvent{
+		Table:    &model.TableName{Schema: "testdb", Table: "t1"},
+		Columns:  cols,
+		ColInfos: colInfos,
+	}
+
+	keyCols, keyColInfos, err := encoder.keyColumns(event)
+	require.NoError(t, err)
+	require.Equal(t, []*model.Column{cols[2], cols[1]}, keyCols)
+	require.Equal(t, []rowcodec.ColInfo{colInfos[2], colInfos[1]}, keyColInfos)
+
+	// The handle key is used for the tables which match no rules.
+	event.Table = &model.TableName{Schema: "testdb", Table: "t2"}
+	keyCols, _, err = encoder.keyColumns(event)
+	require.NoError(t, err)
+	require.Equal(t, []*model.Column{cols[0]}, keyCols)
+
+	event.Table = &model.TableName{Schema: "testdb", Table: "t1"}
+	encoder.keyRules[0].columns = []string{"c"}
+	_, _, err = encoder.keyColumns(event)
+	require.True(t, cerror.ErrAvroEncodeFailed.Equal(err))
+
+	// The single key mode requires exactly one key column.
+	encoder.keyRules[0].columns = []string{"a", "b"}
+	encoder.keyMode = common.KeyModeSingle
+	_, err = encoder.avroEncode(context.Background(), event, "default", true)
+	require.True(t, cerror.ErrAvroEncodeFailed.Equal(err))
+}
+
+func TestAvroEncodeSingleKey(t *testing.T) {
+	encoder, err := setupEncoderAndSchemaRegistry(false, "precise", "long")
+	require.NoError(t, err)
+	defer teardownEncoderAndSchemaRegistry()
+	encoder.keyMode = common.KeyModeSingle
+
+	event := &model.RowChangedEvent{
+		CommitTs: 417318403368288260,
+		Table:    &model.TableName{Schema: "testdb", Table: "singlekey"},
+		TableInfo: &model.TableInfo{
+			TableName: model.TableName{Schema: "testdb", Table: "singlekey"},
+		},
+		Columns: []*model.Column{{
+			Name:  "id",
+			Value: int64(1),
+			Type:  mysql.TypeLonglong,
+			Flag:  model.HandleKeyFlag,
+		}},
+		ColInfos: []rowcodec.ColInfo{{
+			ID:         1,
+			IsPKHandle: true,
+			Ft:         types.NewFieldType(mysql.TypeLonglong),
+		}},
+	}
+
+	r, err := encoder.avroEncode(context.Background(), event, "default", true)
+	require.NoError(t, err)
+	avroCodec, err := goavro.NewCodec(`{"type":"long"}`)
+	require.NoError(t, err)
+	res, _, err := avroCodec.NativeFromBinary(r.data)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), res)
+}
+
 func TestAvroEncode(t *testing.T) {
 	encoder, err := setupEncoderAndSchemaRegistry(true, "precise", "long")
 	require.NoError(t, err)
@@ -787,6 +917,7 @@ func TestAvroEncode(t *testing.T) {
 		false,
 		"precise",
 		"long",
+		"union",
 	)
 	require.NoError(t, err)
 	avroKeyCodec, err := goavro.NewCodec(keySchema)
@@ -811,6 +942,7 @@ func TestAvroEncode(t *testing.T) {
 		true,
 		"precise",
 		"long",
+		"union",
 	)
 	require.NoError(t, err)
 	avroValueCodec, err := goavro.NewCodec(valueSchema)
@@ -904,6 +1036,27 @@ func TestGetAvroNamespace(t *testing.T) {
 	)
 }
 
+func TestCheckNames(t *testing.T) {
+	t.Parallel()
+
+	encoder := &BatchEncoder{namespace: model.DefaultNamespace}
+	table := &model.TableName{Schema: "normalSchema", Table: "normalTable"}
+	cols := []*model.Column{{Name: "normalColumn"}, nil}
+	require.NoError(t, encoder.checkNames(table, cols))
+
+	cols = append(cols, &model.Column{Name: "1Column"})
+	err := encoder.checkNames(table, cols)
+	require.True(t, cerror.ErrAvroIllegalName.Equal(err))
+
+	encoder.namespace = "N-amespace"
+	err = encoder.checkNames(table, cols[:1])
+	require.True(t, cerror.ErrAvroIllegalName.Equal(err))
+	// The changefeed namespace is not used in the schema namespace mode.
+	encoder.namespaceMode = common.NamespaceModeSchema
+	require.NoError(t, encoder.checkNames(table, cols[:1]))
+	require.Equal(t, "normalSchema", encoder.getNamespace(table))
+}
+
 func TestArvoAppendRowChangedEventWithCallback(t *testing.T) {
 	t.Parallel()
 
@@ -950,3 +1103,77 @@ func TestArvoAppendRowChangedEventWithCallback(t *testing.T) {
 		require.Equal(t, expected, count, "expected one callback be called")
 	}
 }
+
+func TestAvroEncodeDDLEventForTopic(t *testing.T) {
+	encoder, err := setupEncoderAndSchemaRegistry(false, "precise", "long")
+	require.NoError(t, err)
+	defer teardownEncoderAndSchemaRegistry()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	tableInfo := model.WrapTableInfo(1, "testdb", 417318403368288260, &timodel.TableInfo{
+		ID:         10,
+		Name:       timodel.NewCIStr("t"),
+		PKIsHandle: true,
+		Columns: []*timodel.ColumnInfo{
+			{
+				ID:        1,
+				Name:      timodel.NewCIStr("id"),
+				Offset:    0,
+				FieldType: *setFlag(types.NewFieldType(mysql.TypeLong), mysql.PriKeyFlag|mysql.NotNullFlag),
+				State:     timodel.StatePublic,
+			},
+			{
+				ID:        2,
+				Name:      timodel.NewCIStr("name"),
+				Offset:    1,
+				FieldType: *types.NewFieldType(mysql.TypeVarchar),
+				State:     timodel.StatePublic,
+			},
+		},
+	})
+	ddl := &model.DDLEvent{
+		CommitTs:  417318403368288260,
+		TableInfo: tableInfo,
+		Query:     "ALTER TABLE testdb.t ADD COLUMN name VARCHAR(10)",
+		Type:      timodel.ActionAddColumn,
+	}
+	message, err := encoder.EncodeDDLEventForTopic(ctx, "default", ddl)
+	require.NoError(t, err)
+	require.Equal(t, "testdb.t", string(message.Key))
+	require.Nil(t, message.Value)
+	require.Equal(t, model.MessageTypeDDL, message.Type)
+	require.Len(t, message.Headers, 3)
+	require.Equal(t, schemaChangeSubjectHeader, message.Headers[0].Key)
+	require.Equal(t, "default-value", string(message.Headers[0].Value))
+	require.Equal(t, schemaChangeIDHeader, message.Headers[1].Key)
+	require.Equal(t, schemaChangeQueryHeader, message.Headers[2].Key)
+	require.Equal(t, ddl.Query, string(message.Headers[2].Value))
+
+	// the rows of the same version are encoded in the registered schema.
+	cols, colInfos := tableColumns(tableInfo)
+	require.Len(t, cols, 2)
+	require.True(t, cols[0].Flag.IsHandleKey())
+	cols[0].Value = int64(1)
+	cols[1].Value = "a"
+	r, err := encoder.avroEncode(ctx, &model.RowChangedEvent{
+		CommitTs:  417318403368288261,
+		Table:     &tableInfo.TableName,
+		TableInfo: tableInfo,
+		Columns:   cols,
+		ColInfos:  colInfos,
+	}, "default", false)
+	require.NoError(t, err)
+	require.Equal(t, string(message.Headers[1].Value), strconv.Itoa(r.registryID))
+
+	ddl.Type = timodel.ActionDropTable
+	message, err = encoder.EncodeDDLEventForTopic(ctx, "default", ddl)
+	require.NoError(t, err)
+	require.Nil(t, message)
+
+	ddl.Type = timodel.ActionRenameTable
+	message, err = encoder.EncodeDDLEventForTopic(ctx, "default", ddl)
+	require.NoError(t, err)
+	require.Nil(t, message)
+}
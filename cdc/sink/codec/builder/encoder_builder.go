@@ -19,11 +19,15 @@ import (
 	"github.com/pingcap/tiflow/cdc/sink/codec"
 	"github.com/pingcap/tiflow/cdc/sink/codec/avro"
 	"github.com/pingcap/tiflow/cdc/sink/codec/canal"
+	"github.com/pingcap/tiflow/cdc/sink/codec/cloudevents"
 	"github.com/pingcap/tiflow/cdc/sink/codec/common"
 	"github.com/pingcap/tiflow/cdc/sink/codec/craft"
 	"github.com/pingcap/tiflow/cdc/sink/codec/csv"
+	"github.com/pingcap/tiflow/cdc/sink/codec/debezium"
+	"github.com/pingcap/tiflow/cdc/sink/codec/jsonschema"
 	"github.com/pingcap/tiflow/cdc/sink/codec/maxwell"
 	"github.com/pingcap/tiflow/cdc/sink/codec/open"
+	"github.com/pingcap/tiflow/cdc/sink/codec/protobuf"
 	"github.com/pingcap/tiflow/pkg/config"
 	cerror "github.com/pingcap/tiflow/pkg/errors"
 )
@@ -32,7 +36,7 @@ import (
 func NewEventBatchEncoderBuilder(ctx context.Context, c *common.Config) (codec.EncoderBuilder, error) {
 	switch c.Protocol {
 	case config.ProtocolDefault, config.ProtocolOpen:
-		return open.NewBatchEncoderBuilder(c), nil
+		return jsonschema.NewBatchEncoderBuilder(open.NewBatchEncoderBuilder(c), c)
 	case config.ProtocolCanal:
 		return canal.NewBatchEncoderBuilder(), nil
 	case config.ProtocolAvro:
@@ -40,11 +44,17 @@ func NewEventBatchEncoderBuilder(ctx context.Context, c *common.Config) (codec.E
 	case config.ProtocolMaxwell:
 		return maxwell.NewBatchEncoderBuilder(), nil
 	case config.ProtocolCanalJSON:
-		return canal.NewJSONBatchEncoderBuilder(c), nil
+		return jsonschema.NewBatchEncoderBuilder(canal.NewJSONBatchEncoderBuilder(c), c)
 	case config.ProtocolCraft:
 		return craft.NewBatchEncoderBuilder(c), nil
 	case config.ProtocolCsv:
 		return csv.NewBatchEncoderBuilder(c), nil
+	case config.ProtocolDebezium:
+		return debezium.NewBatchEncoderBuilder(c), nil
+	case config.ProtocolCloudEvents:
+		return cloudevents.NewBatchEncoderBuilder(c), nil
+	case config.ProtocolProtobuf:
+		return protobuf.NewBatchEncoderBuilder(ctx, c)
 	default:
 		return nil, cerror.ErrSinkUnknownProtocol.GenWithStackByArgs(c.Protocol)
 	}
@@ -20,6 +20,7 @@ import (
 	"github.com/pingcap/log"
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/cdc/sink/codec"
+	"github.com/pingcap/tiflow/cdc/sink/codec/internal"
 	cerror "github.com/pingcap/tiflow/pkg/errors"
 	"go.uber.org/zap"
 )
@@ -30,18 +31,21 @@ type batchDecoder struct {
 	msg                 canalJSONMessageInterface
 	enableTiDBExtension bool
 	terminator          string
+	strict              bool
 }
 
 // NewBatchDecoder return a decoder for canal-json
 func NewBatchDecoder(data []byte,
 	enableTiDBExtension bool,
 	terminator string,
+	opts ...codec.DecoderOption,
 ) codec.EventBatchDecoder {
 	return &batchDecoder{
 		data:                data,
 		msg:                 nil,
 		enableTiDBExtension: enableTiDBExtension,
 		terminator:          terminator,
+		strict:              codec.NewDecoderOptions(opts...).Strict,
 	}
 }
 
@@ -76,7 +80,7 @@ func (b *batchDecoder) HasNext() (model.MessageType, bool, error) {
 		return model.MessageTypeUnknown, false, nil
 	}
 
-	if err := json.Unmarshal(encodedData, msg); err != nil {
+	if err := b.unmarshal(encodedData, msg); err != nil {
 		log.Error("canal-json decoder unmarshal data failed",
 			zap.Error(err), zap.ByteString("data", encodedData))
 		return model.MessageTypeUnknown, false, err
@@ -123,7 +127,7 @@ func (b *batchDecoder) NextResolvedEvent() (uint64, error) {
 	}
 
 	withExtensionEvent, ok := b.msg.(*canalJSONMessageWithTiDBExtension)
-	if !ok {
+	if !ok || withExtensionEvent.Extensions == nil {
 		log.Error("canal-json resolved event message should have tidb extension, but not found",
 			zap.Any("msg", b.msg))
 		return 0, cerror.ErrCanalDecodeFailed.
@@ -132,3 +136,17 @@ func (b *batchDecoder) NextResolvedEvent() (uint64, error) {
 	b.msg = nil
 	return withExtensionEvent.Extensions.WatermarkTs, nil
 }
+
+// unmarshal decodes the encoded data into msg, unknown fields and trailing
+// data are rejected in strict mode.
+func (b *batchDecoder) unmarshal(encodedData []byte, msg canalJSONMessageInterface) error {
+	if !b.strict {
+		return json.Unmarshal(encodedData, msg)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(encodedData))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(msg); err != nil {
+		return cerror.WrapError(cerror.ErrCodecStrictDecodeFailed, err, err.Error())
+	}
+	return internal.CheckTrailingData(encodedData, decoder.InputOffset())
+}
@@ -18,8 +18,10 @@ import (
 	"testing"
 
 	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sink/codec"
 	"github.com/pingcap/tiflow/cdc/sink/codec/common"
 	"github.com/pingcap/tiflow/pkg/config"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
 	"github.com/stretchr/testify/require"
 )
 
@@ -142,3 +144,79 @@ func TestCanalJSONBatchDecoderWithTerminator(t *testing.T) {
 	}
 	require.Equal(t, 3, cnt)
 }
+
+func TestCanalJSONBatchDecoderStrict(t *testing.T) {
+	t.Parallel()
+
+	encoder := newJSONBatchEncoder(&common.Config{EnableTiDBExtension: true})
+	err := encoder.AppendRowChangedEvent(context.Background(), "", testCaseInsert, nil)
+	require.Nil(t, err)
+	value := encoder.Build()[0].Value
+
+	decoder := NewBatchDecoder(value, true, "", codec.WithStrictDecode(true))
+	_, hasNext, err := decoder.HasNext()
+	require.Nil(t, err)
+	require.True(t, hasNext)
+	_, err = decoder.NextRowChangedEvent()
+	require.Nil(t, err)
+
+	// the tidb extension is an unknown field if it is not enabled
+	decoder = NewBatchDecoder(value, false, "", codec.WithStrictDecode(true))
+	_, _, err = decoder.HasNext()
+	require.True(t, cerror.ErrCodecStrictDecodeFailed.Equal(err), err)
+
+	// trailing data
+	decoder = NewBatchDecoder(append(value, " {}"...), true, "", codec.WithStrictDecode(true))
+	_, _, err = decoder.HasNext()
+	require.True(t, cerror.ErrCodecStrictDecodeFailed.Equal(err), err)
+
+	// malformed columns are rejected instead of panicking
+	decoder = NewBatchDecoder([]byte(`{"type":"INSERT","sqlType":{"a":-7},`+
+		`"mysqlType":{"a":"bit"},"data":[{"a":1}]}`), false, "")
+	_, hasNext, err = decoder.HasNext()
+	require.Nil(t, err)
+	require.True(t, hasNext)
+	_, err = decoder.NextRowChangedEvent()
+	require.True(t, cerror.ErrCodecDecode.Equal(err), err)
+}
+
+func FuzzCanalJSONBatchDecoder(f *testing.F) {
+	for _, enable := range []bool{false, true} {
+		encoder := newJSONBatchEncoder(&common.Config{EnableTiDBExtension: enable})
+		err := encoder.AppendRowChangedEvent(context.Background(), "", testCaseInsert, nil)
+		require.Nil(f, err)
+		f.Add(encoder.Build()[0].Value, enable)
+		msg, err := encoder.EncodeDDLEvent(testCaseDDL)
+		require.Nil(f, err)
+		f.Add(msg.Value, enable)
+		msg, err = encoder.EncodeCheckpointEvent(1)
+		require.Nil(f, err)
+		if msg != nil {
+			f.Add(msg.Value, enable)
+		}
+	}
+	f.Fuzz(func(t *testing.T, data []byte, enableTiDBExtension bool) {
+		// The decoder must never panic on malformed messages.
+		for _, strict := range []bool{false, true} {
+			decoder := NewBatchDecoder(data, enableTiDBExtension, "\n",
+				codec.WithStrictDecode(strict))
+			for {
+				tp, hasNext, err := decoder.HasNext()
+				if err != nil || !hasNext {
+					break
+				}
+				switch tp {
+				case model.MessageTypeRow:
+					_, err = decoder.NextRowChangedEvent()
+				case model.MessageTypeDDL:
+					_, err = decoder.NextDDLEvent()
+				case model.MessageTypeResolved:
+					_, err = decoder.NextResolvedEvent()
+				}
+				if err != nil {
+					break
+				}
+			}
+		}
+	})
+}
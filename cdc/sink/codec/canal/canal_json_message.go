@@ -85,14 +85,14 @@ func (c *JSONMessage) getQuery() string {
 }
 
 func (c *JSONMessage) getOld() map[string]interface{} {
-	if c.Old == nil {
+	if len(c.Old) == 0 {
 		return nil
 	}
 	return c.Old[0]
 }
 
 func (c *JSONMessage) getData() map[string]interface{} {
-	if c.Data == nil {
+	if len(c.Data) == 0 {
 		return nil
 	}
 	return c.Data[0]
@@ -145,6 +145,9 @@ type canalJSONMessageWithTiDBExtension struct {
 }
 
 func (c *canalJSONMessageWithTiDBExtension) getCommitTs() uint64 {
+	if c.Extensions == nil {
+		return 0
+	}
 	return c.Extensions.CommitTs
 }
 
@@ -208,7 +211,11 @@ func canalJSONColumnMap2RowChangeColumns(cols map[string]interface{}, mysqlType
 		}
 		mysqlTypeStr = trimUnsignedFromMySQLType(mysqlTypeStr)
 		mysqlType := types.StrToType(mysqlTypeStr)
-		col := internal.NewColumn(value, mysqlType).ToCanalJSONFormatColumn(name, internal.JavaSQLType(javaType))
+		col, err := internal.NewColumn(value, mysqlType).
+			ToCanalJSONFormatColumn(name, internal.JavaSQLType(javaType))
+		if err != nil {
+			return nil, err
+		}
 		result = append(result, col)
 	}
 	if len(result) == 0 {
@@ -0,0 +1,171 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudevents
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sink/codec"
+	"github.com/pingcap/tiflow/cdc/sink/codec/common"
+	"github.com/pingcap/tiflow/pkg/config"
+)
+
+// BatchEncoder encodes the row changed events and the DDL events in the
+// structured JSON format of CloudEvents 1.0, so that they can be consumed
+// by the pipelines of CloudEvents directly. Each event is encoded into a
+// message.
+type BatchEncoder struct {
+	// source is the source of the events, formatted as
+	// /ticdc/{namespace}/{changefeed}.
+	source string
+	// the symbol separating two lines
+	terminator []byte
+	messages   []*common.Message
+	// txnRows counts the row events with the same ID in the current
+	// transaction of each table.
+	txnRows map[model.TableName]*txnRowIDs
+}
+
+// txnRowIDs counts the row events with the same ID in a transaction.
+type txnRowIDs struct {
+	commitTs uint64
+	counts   map[string]int
+}
+
+// newBatchEncoder creates a new BatchEncoder.
+func newBatchEncoder(config *common.Config) codec.EventBatchEncoder {
+	return &BatchEncoder{
+		source: fmt.Sprintf("/ticdc/%s/%s",
+			config.ChangefeedID.Namespace, config.ChangefeedID.ID),
+		terminator: []byte(config.Terminator),
+		txnRows:    make(map[model.TableName]*txnRowIDs),
+	}
+}
+
+// AppendRowChangedEvent implements the EventBatchEncoder interface
+func (d *BatchEncoder) AppendRowChangedEvent(
+	_ context.Context,
+	_ string,
+	e *model.RowChangedEvent,
+	callback func(),
+) error {
+	ev, err := newRowEvent(d.source, e)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	ev.ID = d.uniqueRowID(e, ev.ID)
+	m, err := d.newMessage(ev, model.MessageTypeRow, e.CommitTs)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	m.Schema = &e.Table.Schema
+	m.Table = &e.Table.Table
+	m.Callback = callback
+	m.IncRowsCount()
+	d.messages = append(d.messages, m)
+	return nil
+}
+
+// uniqueRowID makes the ID of the row event unique. The identical row events
+// in a transaction, e.g. the same rows inserted into a table without a
+// primary key, have the same ID, so the n-th of them is suffixed by n. The
+// rows of a table are encoded in the same order after the changefeed
+// restarts, so the IDs are still deterministic.
+func (d *BatchEncoder) uniqueRowID(e *model.RowChangedEvent, id string) string {
+	txn, ok := d.txnRows[*e.Table]
+	if !ok || txn.commitTs != e.CommitTs {
+		txn = &txnRowIDs{commitTs: e.CommitTs, counts: make(map[string]int)}
+		d.txnRows[*e.Table] = txn
+	}
+	n := txn.counts[id]
+	txn.counts[id] = n + 1
+	if n == 0 {
+		return id
+	}
+	return fmt.Sprintf("%s-%d", id, n)
+}
+
+// EncodeCheckpointEvent implements the EventBatchEncoder interface. The
+// checkpoints aren't meaningful to the consumers of CloudEvents, so nothing
+// is encoded.
+func (d *BatchEncoder) EncodeCheckpointEvent(_ uint64) (*common.Message, error) {
+	return nil, nil
+}
+
+// EncodeDDLEvent implements the EventBatchEncoder interface
+func (d *BatchEncoder) EncodeDDLEvent(e *model.DDLEvent) (*common.Message, error) {
+	ev, err := newDDLEvent(d.source, e)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	m, err := d.newMessage(ev, model.MessageTypeDDL, e.CommitTs)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if e.TableInfo != nil {
+		m.Schema = &e.TableInfo.TableName.Schema
+		m.Table = &e.TableInfo.TableName.Table
+	}
+	return m, nil
+}
+
+// newMessage encodes the event into a message, the content type header is
+// attached as the Kafka protocol binding of CloudEvents requires.
+func (d *BatchEncoder) newMessage(
+	ev *event, messageType model.MessageType, commitTs uint64,
+) (*common.Message, error) {
+	value, err := ev.encode()
+	if err != nil {
+		return nil, err
+	}
+	if len(d.terminator) > 0 {
+		value = append(value, d.terminator...)
+	}
+	return &common.Message{
+		Value:    value,
+		Ts:       commitTs,
+		Type:     messageType,
+		Protocol: config.ProtocolCloudEvents,
+		Headers: []common.MessageHeader{
+			{Key: contentTypeHeader, Value: []byte(contentType)},
+		},
+	}, nil
+}
+
+// Build implements the EventBatchEncoder interface
+func (d *BatchEncoder) Build() []*common.Message {
+	if len(d.messages) == 0 {
+		return nil
+	}
+	result := d.messages
+	d.messages = nil
+	return result
+}
+
+type batchEncoderBuilder struct {
+	config *common.Config
+}
+
+// NewBatchEncoderBuilder creates a cloudevents batchEncoderBuilder.
+func NewBatchEncoderBuilder(config *common.Config) codec.EncoderBuilder {
+	return &batchEncoderBuilder{config: config}
+}
+
+// Build a `BatchEncoder`
+func (b *batchEncoderBuilder) Build() codec.EventBatchEncoder {
+	return newBatchEncoder(b.config)
+}
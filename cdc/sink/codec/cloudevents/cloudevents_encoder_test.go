@@ -0,0 +1,149 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudevents
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	timodel "github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sink/codec/common"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeRowChangedEvent(t *testing.T) {
+	t.Parallel()
+
+	c := common.NewConfig(config.ProtocolCloudEvents)
+	c.ChangefeedID = model.DefaultChangeFeedID("test")
+	encoder := NewBatchEncoderBuilder(c).Build()
+
+	row := &model.RowChangedEvent{
+		CommitTs: 435661838416609281,
+		Table:    &model.TableName{Schema: "db", Table: "t"},
+		PreColumns: []*model.Column{
+			{Name: "id", Type: mysql.TypeLong, Value: int64(1), Flag: model.HandleKeyFlag},
+			{Name: "name", Type: mysql.TypeVarchar, Value: []byte("a")},
+		},
+		Columns: []*model.Column{
+			{Name: "id", Type: mysql.TypeLong, Value: int64(1), Flag: model.HandleKeyFlag},
+			{Name: "name", Type: mysql.TypeVarchar, Value: []byte("b")},
+		},
+	}
+	count := 0
+	err := encoder.AppendRowChangedEvent(context.Background(), "", row, func() { count++ })
+	require.Nil(t, err)
+	messages := encoder.Build()
+	require.Len(t, messages, 1)
+	require.Nil(t, encoder.Build())
+	require.Equal(t, 1, messages[0].GetRowsCount())
+	require.Equal(t, model.MessageTypeRow, messages[0].Type)
+	require.Equal(t, []common.MessageHeader{
+		{Key: "content-type", Value: []byte("application/cloudevents+json")},
+	}, messages[0].Headers)
+	messages[0].Callback()
+	require.Equal(t, 1, count)
+
+	var ev map[string]interface{}
+	require.Nil(t, json.Unmarshal(messages[0].Value, &ev))
+	require.Regexp(t, "^435661838416609281-[0-9a-f]{40}$", ev["id"])
+	delete(ev, "id")
+	require.Equal(t, map[string]interface{}{
+		"specversion":     "1.0",
+		"source":          "/ticdc/default/test",
+		"type":            "com.pingcap.ticdc.row.update",
+		"subject":         "db.t",
+		"time":            "2022-08-31T03:53:43.745Z",
+		"datacontenttype": "application/json",
+		"data": map[string]interface{}{
+			"schema":    "db",
+			"table":     "t",
+			"commit_ts": float64(435661838416609281),
+			"before":    map[string]interface{}{"id": float64(1), "name": "a"},
+			"after":     map[string]interface{}{"id": float64(1), "name": "b"},
+		},
+	}, ev)
+
+	// the IDs of the same events are the same after the changefeed restarts.
+	replayed := NewBatchEncoderBuilder(c).Build()
+	err = replayed.AppendRowChangedEvent(context.Background(), "", row, nil)
+	require.Nil(t, err)
+	again := replayed.Build()
+	require.Equal(t, messages[0].Value, again[0].Value)
+
+	// the identical events in a transaction have different IDs.
+	err = replayed.AppendRowChangedEvent(context.Background(), "", row, nil)
+	require.Nil(t, err)
+	again = replayed.Build()
+	var dup map[string]interface{}
+	require.Nil(t, json.Unmarshal(again[0].Value, &dup))
+	require.Regexp(t, "^435661838416609281-[0-9a-f]{40}-1$", dup["id"])
+
+	row.PreColumns = nil
+	err = encoder.AppendRowChangedEvent(context.Background(), "", row, nil)
+	require.Nil(t, err)
+	messages = encoder.Build()
+	ev = nil
+	require.Nil(t, json.Unmarshal(messages[0].Value, &ev))
+	require.Equal(t, "com.pingcap.ticdc.row.insert", ev["type"])
+	require.NotContains(t, ev["data"], "before")
+}
+
+func TestEncodeDDLEvent(t *testing.T) {
+	t.Parallel()
+
+	c := common.NewConfig(config.ProtocolCloudEvents)
+	c.ChangefeedID = model.DefaultChangeFeedID("test")
+	encoder := NewBatchEncoderBuilder(c).Build()
+
+	ddl := &model.DDLEvent{
+		CommitTs: 435661838416609281,
+		Query:    "create table t(id int primary key)",
+		Type:     timodel.ActionCreateTable,
+		TableInfo: &model.TableInfo{
+			TableName: model.TableName{Schema: "db", Table: "t"},
+		},
+	}
+	msg, err := encoder.EncodeDDLEvent(ddl)
+	require.Nil(t, err)
+	require.Equal(t, model.MessageTypeDDL, msg.Type)
+	require.Equal(t, "db", *msg.Schema)
+	require.Equal(t, "t", *msg.Table)
+
+	var ev struct {
+		Type    string                 `json:"type"`
+		Subject string                 `json:"subject"`
+		Data    map[string]interface{} `json:"data"`
+	}
+	require.Nil(t, json.Unmarshal(msg.Value, &ev))
+	require.Equal(t, "com.pingcap.ticdc.ddl", ev.Type)
+	require.Equal(t, "db.t", ev.Subject)
+	require.Equal(t, "create table t(id int primary key)", ev.Data["query"])
+	require.Equal(t, "create table", ev.Data["ddl_type"])
+
+	ddl.TableInfo.TableName.Table = ""
+	ddl.Type = timodel.ActionCreateSchema
+	msg, err = encoder.EncodeDDLEvent(ddl)
+	require.Nil(t, err)
+	require.Nil(t, json.Unmarshal(msg.Value, &ev))
+	require.Equal(t, "db", ev.Subject)
+
+	msg, err = encoder.EncodeCheckpointEvent(435661838416609281)
+	require.Nil(t, err)
+	require.Nil(t, msg)
+}
@@ -0,0 +1,171 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudevents
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/pingcap/tidb/util/rowcodec"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sink/codec/common"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/tikv/client-go/v2/oracle"
+)
+
+const (
+	// specVersion is the version of the CloudEvents specification.
+	specVersion = "1.0"
+	// contentTypeHeader is the header of the content type of the messages.
+	contentTypeHeader = "content-type"
+	// contentType is the content type of the messages in the structured
+	// mode of CloudEvents.
+	contentType = "application/cloudevents+json"
+	// dataContentType is the content type of the data of the events.
+	dataContentType = "application/json"
+
+	typePrefix = "com.pingcap.ticdc."
+	typeInsert = typePrefix + "row.insert"
+	typeUpdate = typePrefix + "row.update"
+	typeDelete = typePrefix + "row.delete"
+	typeDDL    = typePrefix + "ddl"
+)
+
+// event is a CloudEvent in the structured JSON format.
+type event struct {
+	SpecVersion     string          `json:"specversion"`
+	ID              string          `json:"id"`
+	Source          string          `json:"source"`
+	Type            string          `json:"type"`
+	Subject         string          `json:"subject,omitempty"`
+	Time            string          `json:"time"`
+	DataContentType string          `json:"datacontenttype"`
+	Data            json.RawMessage `json:"data"`
+}
+
+// rowData is the data of the row changed events.
+type rowData struct {
+	Schema   string                 `json:"schema"`
+	Table    string                 `json:"table"`
+	CommitTs uint64                 `json:"commit_ts"`
+	Before   map[string]interface{} `json:"before,omitempty"`
+	After    map[string]interface{} `json:"after,omitempty"`
+}
+
+// ddlData is the data of the DDL events.
+type ddlData struct {
+	Schema   string `json:"schema"`
+	Table    string `json:"table,omitempty"`
+	CommitTs uint64 `json:"commit_ts"`
+	Query    string `json:"query"`
+	DDLType  string `json:"ddl_type"`
+}
+
+// newEvent creates a CloudEvent of the data. The ID is derived from the
+// commit ts and the content of the event, so that the events sent again
+// after the changefeed restarts can be deduplicated by the consumers. The
+// identical row events in a transaction share the ID, see uniqueRowID.
+func newEvent(
+	source, eventType, subject string, commitTs uint64, data interface{},
+) (*event, error) {
+	value, err := json.Marshal(data)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrCloudEventsEncodeFailed, err)
+	}
+	h := sha1.New()
+	h.Write([]byte(eventType))
+	h.Write([]byte(subject))
+	h.Write(value)
+	return &event{
+		SpecVersion:     specVersion,
+		ID:              fmt.Sprintf("%d-%s", commitTs, hex.EncodeToString(h.Sum(nil))),
+		Source:          source,
+		Type:            eventType,
+		Subject:         subject,
+		Time:            oracle.GetTimeFromTS(commitTs).UTC().Format(time.RFC3339Nano),
+		DataContentType: dataContentType,
+		Data:            value,
+	}, nil
+}
+
+// newRowEvent converts the row changed event to a CloudEvent, the subject
+// is the name of the table like `schema.table`.
+func newRowEvent(source string, e *model.RowChangedEvent) (*event, error) {
+	data := &rowData{
+		Schema:   e.Table.Schema,
+		Table:    e.Table.Table,
+		CommitTs: e.CommitTs,
+	}
+	var err error
+	if data.Before, err = formatColumns(e.PreColumns, e.ColInfos); err != nil {
+		return nil, err
+	}
+	if data.After, err = formatColumns(e.Columns, e.ColInfos); err != nil {
+		return nil, err
+	}
+	eventType := typeUpdate
+	switch {
+	case e.IsInsert():
+		eventType = typeInsert
+	case e.IsDelete():
+		eventType = typeDelete
+	}
+	return newEvent(source, eventType, e.Table.String(), e.CommitTs, data)
+}
+
+// newDDLEvent converts the DDL event to a CloudEvent, the subject is the
+// name of the table or the schema changed by the DDL.
+func newDDLEvent(source string, e *model.DDLEvent) (*event, error) {
+	data := &ddlData{
+		CommitTs: e.CommitTs,
+		Query:    e.Query,
+		DDLType:  e.Type.String(),
+	}
+	var subject string
+	if e.TableInfo != nil {
+		data.Schema = e.TableInfo.TableName.Schema
+		data.Table = e.TableInfo.TableName.Table
+		subject = data.Schema
+		if data.Table != "" {
+			subject = e.TableInfo.TableName.String()
+		}
+	}
+	return newEvent(source, typeDDL, subject, e.CommitTs, data)
+}
+
+func (e *event) encode() ([]byte, error) {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrCloudEventsEncodeFailed, err)
+	}
+	return data, nil
+}
+
+// formatColumns formats the columns to a JSON object, the column infos are
+// optional. It returns nil if there are no columns.
+func formatColumns(
+	cols []*model.Column, colInfos []rowcodec.ColInfo,
+) (map[string]interface{}, error) {
+	if len(cols) == 0 {
+		return nil, nil
+	}
+	fields, err := common.FormatColumns(cols, colInfos)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrCloudEventsEncodeFailed, err)
+	}
+	return fields, nil
+}
@@ -0,0 +1,101 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/rowcodec"
+	"github.com/pingcap/tiflow/cdc/model"
+)
+
+// FormatColumns formats the columns to the fields of a JSON object keyed by
+// the column names, the column infos are optional.
+func FormatColumns(
+	cols []*model.Column, colInfos []rowcodec.ColInfo,
+) (map[string]interface{}, error) {
+	fields := make(map[string]interface{}, len(cols))
+	for i, col := range cols {
+		if col == nil {
+			continue
+		}
+		var ft *types.FieldType
+		if i < len(colInfos) {
+			ft = colInfos[i].Ft
+		}
+		value, err := FormatColumnValue(col, ft)
+		if err != nil {
+			return nil, err
+		}
+		fields[col.Name] = value
+	}
+	return fields, nil
+}
+
+// FormatColumnValue formats the value of a column to be marshalled to JSON,
+// like the MySQL connector of Debezium with decimal.handling.mode=string and
+// binary.handling.mode=base64. The temporal values are kept as the strings
+// formatted by TiDB. The field type is optional, the enum, set and bit
+// values are kept as numbers without it.
+func FormatColumnValue(col *model.Column, ft *types.FieldType) (interface{}, error) {
+	if col.Value == nil {
+		return nil, nil
+	}
+	switch col.Type {
+	case mysql.TypeVarchar, mysql.TypeString, mysql.TypeVarString,
+		mysql.TypeTinyBlob, mysql.TypeBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob:
+		v, ok := col.Value.([]byte)
+		if !ok {
+			return col.Value, nil
+		}
+		// []byte is marshalled to base64 strings.
+		if col.Flag.IsBinary() {
+			return v, nil
+		}
+		return string(v), nil
+	case mysql.TypeEnum:
+		v, ok := col.Value.(uint64)
+		if !ok || ft == nil {
+			return col.Value, nil
+		}
+		enum, err := types.ParseEnumValue(ft.GetElems(), v)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return enum.Name, nil
+	case mysql.TypeSet:
+		v, ok := col.Value.(uint64)
+		if !ok || ft == nil {
+			return col.Value, nil
+		}
+		set, err := types.ParseSetValue(ft.GetElems(), v)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return set.Name, nil
+	case mysql.TypeBit:
+		v, ok := col.Value.(uint64)
+		if !ok {
+			return col.Value, nil
+		}
+		// BIT(1) is a boolean.
+		if ft != nil && ft.GetFlen() == 1 {
+			return v != 0, nil
+		}
+		return []byte(types.NewBinaryLiteralFromUint(v, -1)), nil
+	default:
+		return col.Value, nil
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatColumnValue(t *testing.T) {
+	t.Parallel()
+
+	enumFt := types.NewFieldType(mysql.TypeEnum)
+	enumFt.SetElems([]string{"a", "b"})
+	setFt := types.NewFieldType(mysql.TypeSet)
+	setFt.SetElems([]string{"a", "b"})
+	bitFt := types.NewFieldType(mysql.TypeBit)
+	bitFt.SetFlen(1)
+
+	testCases := []struct {
+		col      *model.Column
+		ft       *types.FieldType
+		expected interface{}
+	}{
+		{&model.Column{Type: mysql.TypeLong, Value: nil}, nil, nil},
+		{&model.Column{Type: mysql.TypeLonglong, Value: uint64(1)}, nil, uint64(1)},
+		{&model.Column{Type: mysql.TypeNewDecimal, Value: "1.23"}, nil, "1.23"},
+		{&model.Column{Type: mysql.TypeDatetime, Value: "2023-01-01 00:00:00"}, nil, "2023-01-01 00:00:00"},
+		{&model.Column{Type: mysql.TypeVarchar, Value: []byte("a")}, nil, "a"},
+		{
+			&model.Column{Type: mysql.TypeBlob, Value: []byte{1, 2}, Flag: model.BinaryFlag},
+			nil, []byte{1, 2},
+		},
+		{&model.Column{Type: mysql.TypeEnum, Value: uint64(2)}, enumFt, "b"},
+		{&model.Column{Type: mysql.TypeSet, Value: uint64(3)}, setFt, "a,b"},
+		{&model.Column{Type: mysql.TypeBit, Value: uint64(1)}, bitFt, true},
+		{&model.Column{Type: mysql.TypeBit, Value: uint64(1)}, nil, []byte{1}},
+	}
+	for _, tc := range testCases {
+		value, err := FormatColumnValue(tc.col, tc.ft)
+		require.Nil(t, err)
+		require.Equal(t, tc.expected, value)
+	}
+}
@@ -0,0 +1,169 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"math"
+	"sync"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/pkg/compression"
+	"go.uber.org/zap"
+)
+
+const (
+	// CompressionNone means the messages are not compressed.
+	CompressionNone = compression.None
+	// CompressionGZIP means the messages are compressed by gzip.
+	CompressionGZIP = compression.GZIP
+	// CompressionSnappy means the messages are compressed by snappy.
+	CompressionSnappy = compression.Snappy
+	// CompressionLZ4 means the messages are compressed by lz4.
+	CompressionLZ4 = compression.LZ4
+	// CompressionZSTD means the messages are compressed by zstd.
+	CompressionZSTD = compression.ZSTD
+)
+
+const (
+	// measureThreshold is the ratio of the limit, the messages whose
+	// estimated size exceeds it are measured, since the estimation may be
+	// not accurate enough to tell whether they are within the limit.
+	measureThreshold = 0.8
+	// measureSampleInterval means one of this number of the messages far
+	// below the limit is measured, to keep the ratio learned up to date.
+	measureSampleInterval = 16
+)
+
+// normalizeCompression returns the name of the registered codec, the unknown
+// compressions are treated as none, the same as the Kafka producer does.
+func normalizeCompression(name string) string {
+	codec, err := compression.Get(name)
+	if err != nil {
+		return CompressionNone
+	}
+	return codec.Name()
+}
+
+// CompressedSize returns the size of the data compressed by the compression,
+// which is the same as the one used by the Kafka producer. It only estimates
+// the size, so the compression metrics are not observed, which are observed
+// when the messages are really compressed.
+func CompressedSize(name string, data []byte) (int, error) {
+	codec, err := compression.Get(normalizeCompression(name))
+	if err != nil {
+		return 0, err
+	}
+	compressed, err := codec.Compress(data)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	return len(compressed), nil
+}
+
+// SizeEstimator estimates the size of the messages after compressed, it
+// learns the compression ratio from the messages measured. It's shared by
+// the encoders of a sink, so it's safe for concurrent use.
+type SizeEstimator struct {
+	compression string
+
+	mu sync.Mutex
+	// ratio is the compressed size divided by the uncompressed size.
+	ratio float64
+	// unmeasured is the number of the messages verified without measured
+	// since the last sample.
+	unmeasured int
+}
+
+// NewSizeEstimator creates a SizeEstimator of the compression.
+func NewSizeEstimator(compression string) *SizeEstimator {
+	return &SizeEstimator{
+		compression: normalizeCompression(compression),
+		// assume no compression at the beginning, so it never underestimates
+		// the size until the ratio is learned.
+		ratio: 1,
+	}
+}
+
+// Compressed returns whether the messages are compressed.
+func (e *SizeEstimator) Compressed() bool {
+	return e != nil && e.compression != CompressionNone
+}
+
+// Estimate returns the estimated size of a message of the uncompressed size
+// after compressed.
+func (e *SizeEstimator) Estimate(size int) int {
+	if !e.Compressed() {
+		return size
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return int(math.Ceil(float64(size) * e.ratio))
+}
+
+// Verify returns the size of the message after compressed, to be checked
+// against the limit. Compressing a message is expensive, so the message is
+// measured only if its estimated size is close to the limit, or it's
+// sampled to learn the ratio, otherwise the estimated size is returned.
+func (e *SizeEstimator) Verify(m *Message, limit int) int {
+	if !e.Compressed() {
+		return m.Length()
+	}
+	e.mu.Lock()
+	estimated := int(math.Ceil(float64(m.Length()) * e.ratio))
+	measure := float64(estimated) > float64(limit)*measureThreshold
+	if !measure {
+		e.unmeasured++
+		measure = e.unmeasured >= measureSampleInterval
+	}
+	if measure {
+		e.unmeasured = 0
+	}
+	e.mu.Unlock()
+
+	if !measure {
+		return estimated
+	}
+	return e.Measure(m)
+}
+
+// Measure compresses the key and value of the message and returns the
+// size of the message after compressed, the ratio is learned from it. The
+// ratio increases at once if the message is less compressible than
+// expected, so the batches shrink immediately, and it decreases slowly.
+func (e *SizeEstimator) Measure(m *Message) int {
+	if !e.Compressed() {
+		return m.Length()
+	}
+	data := make([]byte, 0, len(m.Key)+len(m.Value))
+	data = append(data, m.Key...)
+	data = append(data, m.Value...)
+	compressed, err := CompressedSize(e.compression, data)
+	if err != nil {
+		log.Warn("failed to compress the message to measure its size",
+			zap.String("compression", e.compression), zap.Error(err))
+		return m.Length()
+	}
+	size := compressed + MaxRecordOverhead
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	ratio := float64(size) / float64(m.Length())
+	if ratio > e.ratio {
+		e.ratio = ratio
+	} else {
+		e.ratio = e.ratio*0.8 + ratio*0.2
+	}
+	return size
+}
@@ -0,0 +1,91 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompressedSize(t *testing.T) {
+	t.Parallel()
+
+	data := bytes.Repeat([]byte("compressible data "), 1024)
+	for _, compression := range []string{
+		CompressionGZIP, CompressionSnappy, CompressionLZ4, CompressionZSTD, " ZSTD ",
+	} {
+		size, err := CompressedSize(compression, data)
+		require.NoError(t, err)
+		require.Greater(t, size, 0, compression)
+		require.Less(t, size, len(data)/10, compression)
+	}
+	for _, compression := range []string{"", CompressionNone, "unknown"} {
+		size, err := CompressedSize(compression, data)
+		require.NoError(t, err)
+		require.Equal(t, len(data), size, compression)
+	}
+}
+
+func TestSizeEstimator(t *testing.T) {
+	t.Parallel()
+
+	var nilEstimator *SizeEstimator
+	require.False(t, nilEstimator.Compressed())
+	require.Equal(t, 100, nilEstimator.Estimate(100))
+	require.False(t, NewSizeEstimator(CompressionNone).Compressed())
+
+	e := NewSizeEstimator(CompressionZSTD)
+	require.True(t, e.Compressed())
+	// never underestimates before any message is measured.
+	require.Equal(t, 1000, e.Estimate(1000))
+
+	compressible := &Message{Value: bytes.Repeat([]byte("a"), 64*1024)}
+	size := e.Measure(compressible)
+	require.Less(t, size, compressible.Length()/10)
+	estimated := e.Estimate(compressible.Length())
+	require.Less(t, estimated, compressible.Length())
+	require.Greater(t, estimated, size)
+
+	// the ratio grows at once for an incompressible message.
+	random := make([]byte, 64*1024)
+	rand.New(rand.NewSource(0)).Read(random)
+	incompressible := &Message{Value: random}
+	size = e.Measure(incompressible)
+	require.Greater(t, size, len(random))
+	require.GreaterOrEqual(t, e.Estimate(incompressible.Length()), size)
+}
+
+func TestSizeEstimatorVerify(t *testing.T) {
+	t.Parallel()
+
+	msg := &Message{Value: bytes.Repeat([]byte("a"), 64*1024)}
+	require.Equal(t, msg.Length(), NewSizeEstimator(CompressionNone).Verify(msg, 1))
+
+	// the messages far below the limit are not measured until sampled.
+	e := NewSizeEstimator(CompressionZSTD)
+	limit := msg.Length() * 2
+	for i := 0; i < measureSampleInterval-1; i++ {
+		require.Equal(t, msg.Length(), e.Verify(msg, limit))
+	}
+	size := e.Verify(msg, limit)
+	require.Less(t, size, msg.Length()/10)
+	require.Less(t, e.Estimate(msg.Length()), msg.Length())
+
+	// the messages close to the limit are always measured.
+	e = NewSizeEstimator(CompressionZSTD)
+	require.Equal(t, size, e.Verify(msg, msg.Length()))
+}
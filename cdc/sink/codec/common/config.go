@@ -18,6 +18,7 @@ import (
 	"strconv"
 
 	"github.com/pingcap/errors"
+	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/pkg/config"
 	cerror "github.com/pingcap/tiflow/pkg/errors"
 )
@@ -32,6 +33,12 @@ type Config struct {
 	// control batch behavior, only for `open-protocol` and `craft` at the moment.
 	MaxMessageBytes int
 	MaxBatchSize    int
+	// Compression is the compression of the Kafka producer, the size of a
+	// message is accounted after compressed if it's set.
+	Compression string
+	// ChangefeedID is the changefeed the encoder belongs to, it's used to
+	// record the warnings of the encoder.
+	ChangefeedID model.ChangeFeedID
 
 	// canal-json only
 	EnableTiDBExtension bool
@@ -40,6 +47,11 @@ type Config struct {
 	AvroSchemaRegistry             string
 	AvroDecimalHandlingMode        string
 	AvroBigintUnsignedHandlingMode string
+	AvroNameSanitizationMode       string
+	AvroNamespaceMode              string
+	AvroNullHandlingMode           string
+	AvroKeyMode                    string
+	AvroKeyRules                   []*config.AvroKeyRule
 
 	// for sinking to cloud storage
 	Delimiter       string
@@ -61,6 +73,10 @@ func NewConfig(protocol config.Protocol) *Config {
 		AvroSchemaRegistry:             "",
 		AvroDecimalHandlingMode:        "precise",
 		AvroBigintUnsignedHandlingMode: "long",
+		AvroNameSanitizationMode:       "mangle",
+		AvroNamespaceMode:              "default",
+		AvroNullHandlingMode:           "union",
+		AvroKeyMode:                    "composite",
 	}
 }
 
@@ -68,8 +84,13 @@ const (
 	codecOPTEnableTiDBExtension            = "enable-tidb-extension"
 	codecOPTMaxBatchSize                   = "max-batch-size"
 	codecOPTMaxMessageBytes                = "max-message-bytes"
+	codecOPTCompression                    = "compression"
 	codecOPTAvroDecimalHandlingMode        = "avro-decimal-handling-mode"
 	codecOPTAvroBigintUnsignedHandlingMode = "avro-bigint-unsigned-handling-mode"
+	codecOPTAvroNameSanitizationMode       = "avro-name-sanitization-mode"
+	codecOPTAvroNamespaceMode              = "avro-namespace-mode"
+	codecOPTAvroNullHandlingMode           = "avro-null-handling-mode"
+	codecOPTAvroKeyMode                    = "avro-key-mode"
 	codecOPTAvroSchemaRegistry             = "schema-registry"
 )
 
@@ -82,6 +103,25 @@ const (
 	BigintUnsignedHandlingModeString = "string"
 	// BigintUnsignedHandlingModeLong is the long mode for unsigned bigint handling
 	BigintUnsignedHandlingModeLong = "long"
+	// NameSanitizationModeMangle replaces the illegal chars of avro names
+	NameSanitizationModeMangle = "mangle"
+	// NameSanitizationModeError reports an error for illegal avro names
+	NameSanitizationModeError = "error"
+	// NamespaceModeDefault derives the avro namespace from the changefeed
+	// namespace and the schema name
+	NamespaceModeDefault = "default"
+	// NamespaceModeSchema derives the avro namespace from the schema name only
+	NamespaceModeSchema = "schema"
+	// NullHandlingModeUnion encodes nullable columns as unions with null
+	NullHandlingModeUnion = "union"
+	// NullHandlingModeSentinel encodes nullable columns as their types, and
+	// the null values as the zero values of the types
+	NullHandlingModeSentinel = "sentinel"
+	// KeyModeComposite encodes the avro key as a record of the key columns
+	KeyModeComposite = "composite"
+	// KeyModeSingle encodes the avro key as the value of its only column,
+	// which is required by some Kafka Connect connectors
+	KeyModeSingle = "single"
 )
 
 // Apply fill the Config
@@ -111,6 +151,10 @@ func (c *Config) Apply(sinkURI *url.URL, config *config.ReplicaConfig) error {
 		c.MaxMessageBytes = a
 	}
 
+	if s := params.Get(codecOPTCompression); s != "" {
+		c.Compression = s
+	}
+
 	if s := params.Get(codecOPTAvroDecimalHandlingMode); s != "" {
 		c.AvroDecimalHandlingMode = s
 	}
@@ -119,11 +163,28 @@ func (c *Config) Apply(sinkURI *url.URL, config *config.ReplicaConfig) error {
 		c.AvroBigintUnsignedHandlingMode = s
 	}
 
+	if s := params.Get(codecOPTAvroNameSanitizationMode); s != "" {
+		c.AvroNameSanitizationMode = s
+	}
+
+	if s := params.Get(codecOPTAvroNamespaceMode); s != "" {
+		c.AvroNamespaceMode = s
+	}
+
+	if s := params.Get(codecOPTAvroNullHandlingMode); s != "" {
+		c.AvroNullHandlingMode = s
+	}
+
+	if s := params.Get(codecOPTAvroKeyMode); s != "" {
+		c.AvroKeyMode = s
+	}
+
 	if config.Sink != nil && config.Sink.SchemaRegistry != "" {
 		c.AvroSchemaRegistry = config.Sink.SchemaRegistry
 	}
 
 	if config.Sink != nil {
+		c.AvroKeyRules = config.Sink.AvroKeyRules
 		c.Terminator = config.Sink.Terminator
 		if config.Sink.CSVConfig != nil {
 			c.Delimiter = config.Sink.CSVConfig.Delimiter
@@ -145,9 +206,17 @@ func (c *Config) WithMaxMessageBytes(bytes int) *Config {
 // Validate the Config
 func (c *Config) Validate() error {
 	if c.EnableTiDBExtension &&
-		!(c.Protocol == config.ProtocolCanalJSON || c.Protocol == config.ProtocolAvro) {
+		!(c.Protocol == config.ProtocolCanalJSON || c.Protocol == config.ProtocolAvro ||
+			c.Protocol == config.ProtocolProtobuf) {
+		return cerror.ErrCodecInvalidConfig.GenWithStack(
+			`enable-tidb-extension only supports canal-json/avro/protobuf protocol`,
+		)
+	}
+
+	if c.Protocol == config.ProtocolProtobuf && c.AvroSchemaRegistry == "" {
 		return cerror.ErrCodecInvalidConfig.GenWithStack(
-			`enable-tidb-extension only supports canal-json/avro protocol`,
+			`Protobuf protocol requires parameter "%s"`,
+			codecOPTAvroSchemaRegistry,
 		)
 	}
 
@@ -178,6 +247,46 @@ func (c *Config) Validate() error {
 				BigintUnsignedHandlingModeString,
 			)
 		}
+
+		if c.AvroNameSanitizationMode != NameSanitizationModeMangle &&
+			c.AvroNameSanitizationMode != NameSanitizationModeError {
+			return cerror.ErrCodecInvalidConfig.GenWithStack(
+				`%s value could only be "%s" or "%s"`,
+				codecOPTAvroNameSanitizationMode,
+				NameSanitizationModeMangle,
+				NameSanitizationModeError,
+			)
+		}
+
+		if c.AvroNamespaceMode != NamespaceModeDefault &&
+			c.AvroNamespaceMode != NamespaceModeSchema {
+			return cerror.ErrCodecInvalidConfig.GenWithStack(
+				`%s value could only be "%s" or "%s"`,
+				codecOPTAvroNamespaceMode,
+				NamespaceModeDefault,
+				NamespaceModeSchema,
+			)
+		}
+
+		if c.AvroNullHandlingMode != NullHandlingModeUnion &&
+			c.AvroNullHandlingMode != NullHandlingModeSentinel {
+			return cerror.ErrCodecInvalidConfig.GenWithStack(
+				`%s value could only be "%s" or "%s"`,
+				codecOPTAvroNullHandlingMode,
+				NullHandlingModeUnion,
+				NullHandlingModeSentinel,
+			)
+		}
+
+		if c.AvroKeyMode != KeyModeComposite &&
+			c.AvroKeyMode != KeyModeSingle {
+			return cerror.ErrCodecInvalidConfig.GenWithStack(
+				`%s value could only be "%s" or "%s"`,
+				codecOPTAvroKeyMode,
+				KeyModeComposite,
+				KeyModeSingle,
+			)
+		}
 	}
 
 	if c.MaxMessageBytes <= 0 {
@@ -32,6 +32,7 @@ func TestNewConfig(t *testing.T) {
 	require.Equal(t, "precise", c.AvroDecimalHandlingMode)
 	require.Equal(t, "long", c.AvroBigintUnsignedHandlingMode)
 	require.Equal(t, "", c.AvroSchemaRegistry)
+	require.Equal(t, "", c.Compression)
 }
 
 func TestConfigApplyValidate(t *testing.T) {
@@ -59,6 +60,14 @@ func TestConfigApplyValidate(t *testing.T) {
 	err = c.Validate()
 	require.NoError(t, err)
 
+	// compression
+	uri = "kafka://127.0.0.1:9092/abc?protocol=canal-json&compression=zstd"
+	sinkURI, err = url.Parse(uri)
+	require.NoError(t, err)
+	err = c.Apply(sinkURI, replicaConfig)
+	require.NoError(t, err)
+	require.Equal(t, "zstd", c.Compression)
+
 	uri = "kafka://127.0.0.1:9092/abc?protocol=canal-json&enable-tidb-extension=a"
 	sinkURI, err = url.Parse(uri)
 	require.NoError(t, err)
@@ -80,7 +89,7 @@ func TestConfigApplyValidate(t *testing.T) {
 	require.True(t, c.EnableTiDBExtension)
 
 	err = c.Validate()
-	require.ErrorContains(t, err, "enable-tidb-extension only supports canal-json/avro protocol")
+	require.ErrorContains(t, err, "enable-tidb-extension only supports canal-json/avro/protobuf protocol")
 
 	// avro
 	uri = "kafka://127.0.0.1:9092/abc?protocol=avro"
@@ -108,6 +117,14 @@ func TestConfigApplyValidate(t *testing.T) {
 	err = c.Validate()
 	require.NoError(t, err)
 
+	// protobuf
+	c = NewConfig(config.ProtocolProtobuf)
+	replicaConfig.Sink.SchemaRegistry = ""
+	require.NoError(t, c.Apply(sinkURI, replicaConfig))
+	err = c.Validate()
+	require.ErrorContains(t, err, `Protobuf protocol requires parameter "schema-registry"`)
+	replicaConfig.Sink.SchemaRegistry = "this-is-a-uri"
+
 	// avro-decimal-handling-mode
 	c = NewConfig(config.ProtocolAvro)
 	require.Equal(t, "precise", c.AvroDecimalHandlingMode)
@@ -168,6 +185,41 @@ func TestConfigApplyValidate(t *testing.T) {
 		`bigint-unsigned-handling-mode value could only be "long" or "string"`,
 	)
 
+	// avro-name-sanitization-mode, avro-namespace-mode and avro-null-handling-mode
+	c = NewConfig(config.ProtocolAvro)
+	require.Equal(t, "mangle", c.AvroNameSanitizationMode)
+	require.Equal(t, "default", c.AvroNamespaceMode)
+	require.Equal(t, "union", c.AvroNullHandlingMode)
+	require.Equal(t, "composite", c.AvroKeyMode)
+
+	uri = "kafka://127.0.0.1:9092/abc?protocol=avro&avro-name-sanitization-mode=error" +
+		"&avro-namespace-mode=schema&avro-null-handling-mode=sentinel&avro-key-mode=single"
+	sinkURI, err = url.Parse(uri)
+	require.NoError(t, err)
+
+	err = c.Apply(sinkURI, replicaConfig)
+	require.NoError(t, err)
+	require.Equal(t, "error", c.AvroNameSanitizationMode)
+	require.Equal(t, "schema", c.AvroNamespaceMode)
+	require.Equal(t, "sentinel", c.AvroNullHandlingMode)
+	require.Equal(t, "single", c.AvroKeyMode)
+
+	err = c.Validate()
+	require.NoError(t, err)
+
+	uri = "kafka://127.0.0.1:9092/abc?protocol=avro&avro-null-handling-mode=invalid"
+	sinkURI, err = url.Parse(uri)
+	require.NoError(t, err)
+
+	err = c.Apply(sinkURI, replicaConfig)
+	require.NoError(t, err)
+	err = c.Validate()
+	require.ErrorContains(
+		t,
+		err,
+		`avro-null-handling-mode value could only be "union" or "sentinel"`,
+	)
+
 	// Illegal max-message-bytes.
 	uri = "kafka://127.0.0.1:9092/abc?kafka-version=2.6.0&max-message-bytes=a"
 	sinkURI, err = url.Parse(uri)
@@ -0,0 +1,223 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"strconv"
+
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/config"
+)
+
+const (
+	// CommitTsHeader is the header of the commit ts of the events.
+	CommitTsHeader = "ticdc-commit-ts"
+	// SchemaVersionHeader is the header of the version of the table schema
+	// of the events.
+	SchemaVersionHeader = "ticdc-schema-version"
+	// ChangefeedIDHeader is the header of the changefeed, which is formatted
+	// as namespace/id.
+	ChangefeedIDHeader = "ticdc-changefeed-id"
+	// EventTypeHeader is the header of the type of the events.
+	EventTypeHeader = "ticdc-event-type"
+	// SourceClusterIDHeader is the header of the source ID of the upstream.
+	SourceClusterIDHeader = "ticdc-source-cluster-id"
+	// TxnStartTsHeader is the header of the start ts of the upstream
+	// transaction of the events.
+	TxnStartTsHeader = "ticdc-txn-start-ts"
+	// TxnCommitTsHeader is the header of the commit ts of the upstream
+	// transaction of the events.
+	TxnCommitTsHeader = "ticdc-txn-commit-ts"
+	// TxnRowCountHeader is the header of the approximate number of the rows
+	// changed by the upstream transaction in the table of the events.
+	TxnRowCountHeader = "ticdc-txn-row-count"
+	// SavepointHeader is the header of the name of the savepoint, it's only
+	// attached to the markers of the savepoints.
+	SavepointHeader = "ticdc-savepoint"
+)
+
+// The values of EventTypeHeader. EventTypeRow is used if the rows of a
+// message are of different types.
+const (
+	EventTypeInsert    = "insert"
+	EventTypeUpdate    = "update"
+	EventTypeDelete    = "delete"
+	EventTypeRow       = "row"
+	EventTypeDDL       = "ddl"
+	EventTypeResolved  = "resolved"
+	EventTypeSavepoint = "savepoint"
+)
+
+// MetadataHeaders attaches the CDC metadata chosen by the headers config to
+// the messages, so that the downstream consumers can route and filter them
+// without decoding the payloads.
+type MetadataHeaders struct {
+	config       *config.HeadersConfig
+	changefeedID string
+	sourceID     string
+}
+
+// NewMetadataHeaders creates a MetadataHeaders, it returns nil if no
+// metadata is chosen.
+func NewMetadataHeaders(
+	cfg *config.HeadersConfig, changefeedID model.ChangeFeedID, sourceID uint64,
+) *MetadataHeaders {
+	if cfg == nil || *cfg == (config.HeadersConfig{}) {
+		return nil
+	}
+	return &MetadataHeaders{
+		config:       cfg,
+		changefeedID: changefeedID.Namespace + "/" + changefeedID.ID,
+		sourceID:     strconv.FormatUint(sourceID, 10),
+	}
+}
+
+// txnMetadata is the metadata of the upstream transaction of a message.
+type txnMetadata struct {
+	startTs  uint64
+	commitTs uint64
+	// rowCount is 0 if it's unknown.
+	rowCount int
+}
+
+// TxnMetadata returns whether the metadata of the upstream transactions is
+// attached.
+func (h *MetadataHeaders) TxnMetadata() bool {
+	return h != nil && h.config.TxnMetadata
+}
+
+// StampRows attaches the metadata of the rows encoded into the message. The
+// schema version is attached only if all the rows share the same one, and
+// the transaction metadata is attached only if all the rows belong to the
+// same transaction, txnRowCount is the approximate row count of it.
+func (h *MetadataHeaders) StampRows(
+	message *Message, rows []*model.RowChangedEvent, txnRowCount int,
+) {
+	eventType := ""
+	var schemaVersion uint64
+	var txn *txnMetadata
+	if len(rows) > 0 {
+		txn = &txnMetadata{
+			startTs:  rows[0].StartTs,
+			commitTs: rows[0].CommitTs,
+			rowCount: txnRowCount,
+		}
+	}
+	for i, row := range rows {
+		rowType := rowEventType(row)
+		var version uint64
+		if row.TableInfo != nil {
+			version = row.TableInfo.Version
+		}
+		if i == 0 {
+			eventType, schemaVersion = rowType, version
+			continue
+		}
+		if rowType != eventType {
+			eventType = EventTypeRow
+		}
+		if version != schemaVersion {
+			schemaVersion = 0
+		}
+		if txn != nil && (row.StartTs != txn.startTs || row.CommitTs != txn.commitTs) {
+			txn = nil
+		}
+	}
+	if eventType == "" {
+		eventType = EventTypeRow
+	}
+	h.stamp(message, eventType, schemaVersion, txn)
+}
+
+// StampDDL attaches the metadata of the DDL to the message.
+func (h *MetadataHeaders) StampDDL(message *Message, ddl *model.DDLEvent) {
+	var schemaVersion uint64
+	if ddl.TableInfo != nil {
+		schemaVersion = ddl.TableInfo.Version
+	}
+	h.stamp(message, EventTypeDDL, schemaVersion,
+		&txnMetadata{startTs: ddl.StartTs, commitTs: ddl.CommitTs})
+}
+
+// StampResolved attaches the metadata of the checkpoint to the message.
+func (h *MetadataHeaders) StampResolved(message *Message) {
+	h.stamp(message, EventTypeResolved, 0, nil)
+}
+
+// StampSavepoint attaches the name of the savepoint to its marker, which is a
+// checkpoint message at the ts of the savepoint, so that the consumers can
+// tell it from the other checkpoints. The name is attached even if h is nil.
+func (h *MetadataHeaders) StampSavepoint(message *Message, name string) {
+	message.Headers = append(message.Headers, MessageHeader{
+		Key: SavepointHeader, Value: []byte(name),
+	})
+	if h != nil {
+		h.stamp(message, EventTypeSavepoint, 0, nil)
+	}
+}
+
+// stamp attaches the metadata to the message, the schema version is skipped
+// if it's 0, and the transaction metadata is skipped if it's nil.
+func (h *MetadataHeaders) stamp(
+	message *Message, eventType string, schemaVersion uint64, txn *txnMetadata,
+) {
+	if h.config.CommitTs {
+		message.Headers = append(message.Headers, MessageHeader{
+			Key: CommitTsHeader, Value: []byte(strconv.FormatUint(message.Ts, 10)),
+		})
+	}
+	if h.config.SchemaVersion && schemaVersion != 0 {
+		message.Headers = append(message.Headers, MessageHeader{
+			Key: SchemaVersionHeader, Value: []byte(strconv.FormatUint(schemaVersion, 10)),
+		})
+	}
+	if h.config.ChangefeedID {
+		message.Headers = append(message.Headers, MessageHeader{
+			Key: ChangefeedIDHeader, Value: []byte(h.changefeedID),
+		})
+	}
+	if h.config.EventType {
+		message.Headers = append(message.Headers, MessageHeader{
+			Key: EventTypeHeader, Value: []byte(eventType),
+		})
+	}
+	if h.config.SourceClusterID || h.config.TxnMetadata {
+		message.Headers = append(message.Headers, MessageHeader{
+			Key: SourceClusterIDHeader, Value: []byte(h.sourceID),
+		})
+	}
+	if h.config.TxnMetadata && txn != nil {
+		message.Headers = append(message.Headers, MessageHeader{
+			Key: TxnStartTsHeader, Value: []byte(strconv.FormatUint(txn.startTs, 10)),
+		}, MessageHeader{
+			Key: TxnCommitTsHeader, Value: []byte(strconv.FormatUint(txn.commitTs, 10)),
+		})
+		if txn.rowCount > 0 {
+			message.Headers = append(message.Headers, MessageHeader{
+				Key: TxnRowCountHeader, Value: []byte(strconv.Itoa(txn.rowCount)),
+			})
+		}
+	}
+}
+
+func rowEventType(row *model.RowChangedEvent) string {
+	switch {
+	case row.IsInsert():
+		return EventTypeInsert
+	case row.IsDelete():
+		return EventTypeDelete
+	default:
+		return EventTypeUpdate
+	}
+}
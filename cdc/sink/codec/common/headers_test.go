@@ -0,0 +1,136 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"testing"
+
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetadataHeaders(t *testing.T) {
+	t.Parallel()
+
+	id := model.DefaultChangeFeedID("test")
+	require.Nil(t, NewMetadataHeaders(nil, id, 1))
+	require.Nil(t, NewMetadataHeaders(&config.HeadersConfig{}, id, 1))
+
+	h := NewMetadataHeaders(&config.HeadersConfig{
+		CommitTs:        true,
+		SchemaVersion:   true,
+		ChangefeedID:    true,
+		EventType:       true,
+		SourceClusterID: true,
+	}, id, 7)
+	column := []*model.Column{{Name: "a", Value: 1}}
+	insert := &model.RowChangedEvent{
+		CommitTs:  100,
+		TableInfo: &model.TableInfo{Version: 10},
+		Columns:   column,
+	}
+	message := &Message{Ts: 100}
+	h.StampRows(message, []*model.RowChangedEvent{insert}, 0)
+	require.Equal(t, []MessageHeader{
+		{Key: CommitTsHeader, Value: []byte("100")},
+		{Key: SchemaVersionHeader, Value: []byte("10")},
+		{Key: ChangefeedIDHeader, Value: []byte("default/test")},
+		{Key: EventTypeHeader, Value: []byte(EventTypeInsert)},
+		{Key: SourceClusterIDHeader, Value: []byte("7")},
+	}, message.Headers)
+
+	// The rows of different types and schema versions.
+	remove := &model.RowChangedEvent{
+		CommitTs:   100,
+		TableInfo:  &model.TableInfo{Version: 11},
+		PreColumns: column,
+	}
+	message = &Message{Ts: 100}
+	h.StampRows(message, []*model.RowChangedEvent{insert, remove}, 0)
+	require.Len(t, message.Headers, 4)
+	require.Equal(t, MessageHeader{Key: EventTypeHeader, Value: []byte(EventTypeRow)},
+		message.Headers[2])
+
+	message = &Message{Ts: 200}
+	h.StampDDL(message, &model.DDLEvent{TableInfo: &model.TableInfo{Version: 12}})
+	require.Equal(t, []byte("12"), message.Headers[1].Value)
+	require.Equal(t, []byte(EventTypeDDL), message.Headers[3].Value)
+
+	// Only the chosen metadata is attached.
+	h = NewMetadataHeaders(&config.HeadersConfig{EventType: true}, id, 7)
+	message = &Message{Ts: 300}
+	h.StampResolved(message)
+	require.Equal(t, []MessageHeader{
+		{Key: EventTypeHeader, Value: []byte(EventTypeResolved)},
+	}, message.Headers)
+
+	// The name of the savepoint is always attached to its marker.
+	message = &Message{Ts: 400}
+	h.StampSavepoint(message, "sp1")
+	require.Equal(t, []MessageHeader{
+		{Key: SavepointHeader, Value: []byte("sp1")},
+		{Key: EventTypeHeader, Value: []byte(EventTypeSavepoint)},
+	}, message.Headers)
+	h = nil
+	message = &Message{Ts: 400}
+	h.StampSavepoint(message, "sp1")
+	require.Equal(t, []MessageHeader{
+		{Key: SavepointHeader, Value: []byte("sp1")},
+	}, message.Headers)
+}
+
+func TestMetadataHeadersTxnMetadata(t *testing.T) {
+	t.Parallel()
+
+	id := model.DefaultChangeFeedID("test")
+	require.False(t, (*MetadataHeaders)(nil).TxnMetadata())
+	h := NewMetadataHeaders(&config.HeadersConfig{TxnMetadata: true}, id, 7)
+	require.True(t, h.TxnMetadata())
+
+	column := []*model.Column{{Name: "a", Value: 1}}
+	row1 := &model.RowChangedEvent{StartTs: 90, CommitTs: 100, Columns: column}
+	row2 := &model.RowChangedEvent{StartTs: 90, CommitTs: 100, PreColumns: column}
+	message := &Message{Ts: 100}
+	h.StampRows(message, []*model.RowChangedEvent{row1, row2}, 3)
+	require.Equal(t, []MessageHeader{
+		{Key: SourceClusterIDHeader, Value: []byte("7")},
+		{Key: TxnStartTsHeader, Value: []byte("90")},
+		{Key: TxnCommitTsHeader, Value: []byte("100")},
+		{Key: TxnRowCountHeader, Value: []byte("3")},
+	}, message.Headers)
+
+	// The rows of different transactions.
+	row3 := &model.RowChangedEvent{StartTs: 95, CommitTs: 100, Columns: column}
+	message = &Message{Ts: 100}
+	h.StampRows(message, []*model.RowChangedEvent{row1, row3}, 2)
+	require.Equal(t, []MessageHeader{
+		{Key: SourceClusterIDHeader, Value: []byte("7")},
+	}, message.Headers)
+
+	// The row count of a DDL is unknown.
+	message = &Message{Ts: 200}
+	h.StampDDL(message, &model.DDLEvent{StartTs: 190, CommitTs: 200})
+	require.Equal(t, []MessageHeader{
+		{Key: SourceClusterIDHeader, Value: []byte("7")},
+		{Key: TxnStartTsHeader, Value: []byte("190")},
+		{Key: TxnCommitTsHeader, Value: []byte("200")},
+	}, message.Headers)
+
+	message = &Message{Ts: 300}
+	h.StampResolved(message)
+	require.Equal(t, []MessageHeader{
+		{Key: SourceClusterIDHeader, Value: []byte("7")},
+	}, message.Headers)
+}
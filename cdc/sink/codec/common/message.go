@@ -40,13 +40,23 @@ type Message struct {
 	Protocol  config.Protocol   // protocol
 	rowsCount int               // rows in one Message
 	Callback  func()            // Callback function will be called when the message is sent to the sink.
+	Headers   []MessageHeader   // Headers are attached to the message if the sink supports.
+}
+
+// MessageHeader is a key-value pair attached to a Message.
+type MessageHeader struct {
+	Key   string
+	Value []byte
 }
 
 // Length returns the expected size of the Kafka message
-// We didn't append any `Headers` when send the message, so ignore the calculations related to it.
-// If `ProducerMessage` Headers fields used, this method should also adjust.
 func (m *Message) Length() int {
-	return len(m.Key) + len(m.Value) + MaxRecordOverhead
+	length := len(m.Key) + len(m.Value) + MaxRecordOverhead
+	for _, header := range m.Headers {
+		// the key and the value of a header are both prefixed by a varint length.
+		length += len(header.Key) + len(header.Value) + 2*binary.MaxVarintLen32
+	}
+	return length
 }
 
 // PhysicalTime returns physical time part of Ts in time.Time
@@ -0,0 +1,134 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"fmt"
+
+	"github.com/pingcap/tiflow/cdc/model"
+)
+
+// SequenceHeader is the header of the sequence position of a row or a DDL in
+// a message, a message carries one for each of its rows in order. The value
+// is "{table-id}:{commit-ts}:{index}:{prev-commit-ts}:{prev-index}".
+const SequenceHeader = "ticdc-sequence"
+
+// SequencePosition is the position of a row in the stream of its table and
+// partition, or of a DDL in the stream of its topic.
+type SequencePosition struct {
+	CommitTs uint64
+	// Index is the index of the row among the ones of the stream committed
+	// at CommitTs, it starts from 1.
+	Index uint64
+}
+
+// Less returns whether the position is before the other one.
+func (p SequencePosition) Less(other SequencePosition) bool {
+	return p.CommitTs < other.CommitTs ||
+		(p.CommitTs == other.CommitTs && p.Index < other.Index)
+}
+
+type sequenceStream struct {
+	topic     string
+	partition int32
+	tableID   int64
+}
+
+// Sequencer stamps the rows and the DDLs sent by a sink with their sequence
+// positions, so that the downstream consumers can detect the gaps and the
+// duplicates cheaply. The rows are sequenced per physical table and
+// partition, and the DDLs per topic, with table ID 0.
+//
+// A position is derived from the upstream rather than counted by the sink:
+// it's the commit ts of the row and its index among the rows of the stream
+// committed at that ts. A sink always resumes from the checkpoint of the
+// changefeed, i.e. from a commit ts, and the events after it are emitted in
+// the same order every time, so a replayed row gets the same position on any
+// capture after any restart, and a position is never reused by another row.
+//
+// Every position also carries the previous one sent in the stream by the
+// sink, which is 0:0 for the first one after the sink starts. A consumer
+// keeps the last position applied per stream, then a row is a duplicate if
+// its position is not after the last one, and there is a gap if its previous
+// position is neither the last one nor 0:0. There is no gap after 0:0, since
+// the sink resumes from a checkpoint the consumer has already passed.
+//
+// The Sequencer is not thread-safe, it must be used by the goroutine which
+// orders the messages.
+type Sequencer struct {
+	last map[sequenceStream]SequencePosition
+}
+
+// NewSequencer creates a sequencer.
+func NewSequencer() *Sequencer {
+	return &Sequencer{last: make(map[sequenceStream]SequencePosition)}
+}
+
+// StampRows attaches the positions of the rows encoded in the message, which
+// is sent to the partition of the topic. The messages must be stamped in the
+// order they are sent.
+func (s *Sequencer) StampRows(
+	message *Message, topic string, partition int32, rows []*model.RowChangedEvent,
+) {
+	for _, row := range rows {
+		var tableID int64
+		if row.Table != nil {
+			tableID = row.Table.TableID
+		}
+		s.stamp(message, sequenceStream{
+			topic: topic, partition: partition, tableID: tableID,
+		}, row.CommitTs)
+	}
+}
+
+// StampDDL attaches the position of the DDL encoded in the message, which
+// is sent to the topic.
+func (s *Sequencer) StampDDL(message *Message, topic string, commitTs uint64) {
+	s.stamp(message, sequenceStream{topic: topic, partition: -1}, commitTs)
+}
+
+func (s *Sequencer) stamp(message *Message, stream sequenceStream, commitTs uint64) {
+	prev := s.last[stream]
+	pos := SequencePosition{CommitTs: commitTs, Index: 1}
+	if prev.CommitTs == commitTs {
+		pos.Index = prev.Index + 1
+	}
+	s.last[stream] = pos
+	message.Headers = append(message.Headers, MessageHeader{
+		Key: SequenceHeader,
+		Value: []byte(fmt.Sprintf("%d:%d:%d:%d:%d", stream.tableID,
+			pos.CommitTs, pos.Index, prev.CommitTs, prev.Index)),
+	})
+}
+
+// Reset forgets the positions of the rows of the table, the next row of the
+// table in every stream is stamped as the first one after the sink starts.
+// It's called when the rows of the table are emitted again from a former
+// checkpoint.
+func (s *Sequencer) Reset(tableID int64) {
+	for stream := range s.last {
+		if stream.tableID == tableID {
+			delete(s.last, stream)
+		}
+	}
+}
+
+// ParseSequenceHeader parses the value of a sequence header.
+func ParseSequenceHeader(value []byte) (
+	tableID int64, pos SequencePosition, prev SequencePosition, err error,
+) {
+	_, err = fmt.Sscanf(string(value), "%d:%d:%d:%d:%d", &tableID,
+		&pos.CommitTs, &pos.Index, &prev.CommitTs, &prev.Index)
+	return
+}
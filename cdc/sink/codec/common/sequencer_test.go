@@ -0,0 +1,76 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"testing"
+
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSequencer(t *testing.T) {
+	t.Parallel()
+
+	newRow := func(tableID int64, commitTs uint64) *model.RowChangedEvent {
+		return &model.RowChangedEvent{
+			Table:    &model.TableName{TableID: tableID},
+			CommitTs: commitTs,
+		}
+	}
+	positions := func(message *Message) []string {
+		var result []string
+		for _, header := range message.Headers {
+			require.Equal(t, SequenceHeader, header.Key)
+			result = append(result, string(header.Value))
+		}
+		return result
+	}
+
+	s := NewSequencer()
+	message := &Message{}
+	s.StampRows(message, "t", 0, []*model.RowChangedEvent{
+		newRow(1, 10), newRow(1, 10), newRow(2, 10), newRow(1, 11),
+	})
+	require.Equal(t, []string{
+		"1:10:1:0:0", "1:10:2:10:1", "2:10:1:0:0", "1:11:1:10:2",
+	}, positions(message))
+
+	// The streams of the other partitions are independent.
+	message = &Message{}
+	s.StampRows(message, "t", 1, []*model.RowChangedEvent{newRow(1, 11)})
+	require.Equal(t, []string{"1:11:1:0:0"}, positions(message))
+
+	// The rows replayed after a reset get the same positions.
+	s.Reset(1)
+	message = &Message{}
+	s.StampRows(message, "t", 0, []*model.RowChangedEvent{
+		newRow(1, 11), newRow(2, 11),
+	})
+	require.Equal(t, []string{"1:11:1:0:0", "2:11:1:10:1"}, positions(message))
+
+	// The DDLs are sequenced per topic.
+	message = &Message{}
+	s.StampDDL(message, "t", 12)
+	s.StampDDL(message, "t", 12)
+	require.Equal(t, []string{"0:12:1:0:0", "0:12:2:12:1"}, positions(message))
+
+	tableID, pos, prev, err := ParseSequenceHeader(message.Headers[1].Value)
+	require.Nil(t, err)
+	require.Equal(t, int64(0), tableID)
+	require.Equal(t, SequencePosition{CommitTs: 12, Index: 2}, pos)
+	require.Equal(t, SequencePosition{CommitTs: 12, Index: 1}, prev)
+	require.True(t, prev.Less(pos))
+	require.False(t, pos.Less(pos))
+}
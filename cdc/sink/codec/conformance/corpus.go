@@ -0,0 +1,316 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conformance
+
+import (
+	"math"
+
+	timodel "github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tiflow/cdc/model"
+)
+
+// ColumnTypes are the column types which TiDB may emit in a row changed
+// event, the corpus contains at least one column of each type.
+var ColumnTypes = []byte{
+	mysql.TypeTiny, mysql.TypeShort, mysql.TypeInt24, mysql.TypeLong, mysql.TypeLonglong,
+	mysql.TypeFloat, mysql.TypeDouble, mysql.TypeNewDecimal, mysql.TypeBit, mysql.TypeYear,
+	mysql.TypeDate, mysql.TypeDatetime, mysql.TypeTimestamp, mysql.TypeDuration,
+	mysql.TypeVarchar, mysql.TypeString, mysql.TypeVarString,
+	mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob, mysql.TypeBlob,
+	mysql.TypeEnum, mysql.TypeSet, mysql.TypeJSON,
+}
+
+// RowCase is a named batch of row changed events which are encoded together.
+type RowCase struct {
+	Name string
+	Rows []*model.RowChangedEvent
+}
+
+// DDLCase is a named DDL event.
+type DDLCase struct {
+	Name string
+	DDL  *model.DDLEvent
+}
+
+// Corpus is the golden events of the conformance suite.
+type Corpus struct {
+	Rows       []RowCase
+	DDLs       []DDLCase
+	ResolvedTs []uint64
+}
+
+// NewCorpus returns a new copy of the golden corpus, the caller is free to
+// modify it, e.g. to remove the cases a protocol doesn't support.
+func NewCorpus() *Corpus {
+	return &Corpus{
+		Rows:       newRowCases(),
+		DDLs:       newDDLCases(),
+		ResolvedTs: []uint64{0, 1, 424316592563683329, math.MaxInt64, math.MaxUint64},
+	}
+}
+
+const (
+	testSchema = "conformance"
+	baseTs     = uint64(439563932640575489)
+)
+
+func handleFlag() model.ColumnFlagType {
+	return model.HandleKeyFlag | model.PrimaryKeyFlag
+}
+
+func binaryFlag() model.ColumnFlagType {
+	return model.BinaryFlag | model.NullableFlag
+}
+
+func unsignedFlag() model.ColumnFlagType {
+	return model.UnsignedFlag | model.NullableFlag
+}
+
+// allTypesColumns returns a column of each type in ColumnTypes with a
+// typical value, and the signed integers with their minimum values.
+func allTypesColumns(id int64) []*model.Column {
+	return []*model.Column{
+		{Name: "id", Type: mysql.TypeLonglong, Flag: handleFlag(), Value: id},
+		{Name: "c_tinyint", Type: mysql.TypeTiny, Flag: model.NullableFlag, Value: int64(math.MinInt8)},
+		{Name: "c_smallint", Type: mysql.TypeShort, Flag: model.NullableFlag, Value: int64(math.MinInt16)},
+		{Name: "c_mediumint", Type: mysql.TypeInt24, Flag: model.NullableFlag, Value: int64(-8388608)},
+		{Name: "c_int", Type: mysql.TypeLong, Flag: model.NullableFlag, Value: int64(math.MinInt32)},
+		{Name: "c_bigint", Type: mysql.TypeLonglong, Flag: model.NullableFlag, Value: int64(math.MinInt64)},
+		{Name: "c_float", Type: mysql.TypeFloat, Flag: model.NullableFlag, Value: float32(-3.25)},
+		{Name: "c_double", Type: mysql.TypeDouble, Flag: model.NullableFlag, Value: float64(2.5e100)},
+		{Name: "c_decimal", Type: mysql.TypeNewDecimal, Flag: model.NullableFlag, Value: "-12345678901234567890.1234567890"},
+		{Name: "c_bit", Type: mysql.TypeBit, Flag: unsignedFlag(), Value: uint64(0b1010_0101)},
+		{Name: "c_year", Type: mysql.TypeYear, Flag: model.NullableFlag, Value: int64(2155)},
+		{Name: "c_date", Type: mysql.TypeDate, Flag: binaryFlag(), Value: "1000-01-01"},
+		{Name: "c_datetime", Type: mysql.TypeDatetime, Flag: binaryFlag(), Value: "9999-12-31 23:59:59.999999"},
+		{Name: "c_timestamp", Type: mysql.TypeTimestamp, Flag: binaryFlag(), Value: "1970-01-01 00:00:01.000000"},
+		{Name: "c_time", Type: mysql.TypeDuration, Flag: binaryFlag(), Value: "-838:59:59"},
+		{Name: "c_varchar", Type: mysql.TypeVarchar, Flag: model.NullableFlag, Value: []byte("varchar")},
+		{Name: "c_char", Type: mysql.TypeString, Flag: model.NullableFlag, Value: []byte("char")},
+		{Name: "c_varbinary", Type: mysql.TypeVarString, Flag: binaryFlag(), Value: []byte{0x00, 0x01, 0x7f, 0x80, 0xff}},
+		{Name: "c_tinyblob", Type: mysql.TypeTinyBlob, Flag: binaryFlag(), Value: []byte{0xff, 0xfe}},
+		{Name: "c_mediumblob", Type: mysql.TypeMediumBlob, Flag: binaryFlag(), Value: []byte("medium\x00blob")},
+		{Name: "c_longblob", Type: mysql.TypeLongBlob, Flag: binaryFlag(), Value: []byte("long blob")},
+		{Name: "c_blob", Type: mysql.TypeBlob, Flag: binaryFlag(), Value: []byte{0xde, 0xad, 0xbe, 0xef}},
+		{Name: "c_enum", Type: mysql.TypeEnum, Flag: model.NullableFlag, Value: uint64(2)},
+		{Name: "c_set", Type: mysql.TypeSet, Flag: model.NullableFlag, Value: uint64(5)},
+		{Name: "c_json", Type: mysql.TypeJSON, Flag: binaryFlag(), Value: `{"key": [1, "两", null, true]}`},
+	}
+}
+
+// maxValuesColumns returns the columns of the integers with their maximum
+// values, including the unsigned ones.
+func maxValuesColumns(id int64) []*model.Column {
+	return []*model.Column{
+		{Name: "id", Type: mysql.TypeLonglong, Flag: handleFlag(), Value: id},
+		{Name: "c_tinyint", Type: mysql.TypeTiny, Flag: model.NullableFlag, Value: int64(math.MaxInt8)},
+		{Name: "c_smallint", Type: mysql.TypeShort, Flag: model.NullableFlag, Value: int64(math.MaxInt16)},
+		{Name: "c_mediumint", Type: mysql.TypeInt24, Flag: model.NullableFlag, Value: int64(8388607)},
+		{Name: "c_int", Type: mysql.TypeLong, Flag: model.NullableFlag, Value: int64(math.MaxInt32)},
+		{Name: "c_bigint", Type: mysql.TypeLonglong, Flag: model.NullableFlag, Value: int64(math.MaxInt64)},
+		{Name: "c_tinyint_unsigned", Type: mysql.TypeTiny, Flag: unsignedFlag(), Value: uint64(math.MaxUint8)},
+		{Name: "c_smallint_unsigned", Type: mysql.TypeShort, Flag: unsignedFlag(), Value: uint64(math.MaxUint16)},
+		{Name: "c_mediumint_unsigned", Type: mysql.TypeInt24, Flag: unsignedFlag(), Value: uint64(16777215)},
+		{Name: "c_int_unsigned", Type: mysql.TypeLong, Flag: unsignedFlag(), Value: uint64(math.MaxUint32)},
+		{Name: "c_bigint_unsigned", Type: mysql.TypeLonglong, Flag: unsignedFlag(), Value: uint64(math.MaxUint64)},
+		{Name: "c_year", Type: mysql.TypeYear, Flag: model.NullableFlag, Value: int64(0)},
+		{Name: "c_float", Type: mysql.TypeFloat, Flag: model.NullableFlag, Value: float32(0)},
+		{Name: "c_double", Type: mysql.TypeDouble, Flag: model.NullableFlag, Value: float64(-0.5)},
+		{Name: "c_decimal", Type: mysql.TypeNewDecimal, Flag: model.NullableFlag, Value: "0"},
+	}
+}
+
+// nullColumns returns the columns of allTypesColumns with NULL values.
+func nullColumns(id int64) []*model.Column {
+	cols := allTypesColumns(id)
+	for _, col := range cols[1:] {
+		col.Value = nil
+	}
+	return cols
+}
+
+// charsetColumns returns the string columns with the given value in a
+// utf8mb4 column, a binary column and a blob column.
+func charsetColumns(id int64, value string) []*model.Column {
+	return []*model.Column{
+		{Name: "id", Type: mysql.TypeLonglong, Flag: handleFlag(), Value: id},
+		{Name: "c_utf8mb4", Type: mysql.TypeVarchar, Flag: model.NullableFlag, Value: []byte(value)},
+		{Name: "c_char", Type: mysql.TypeString, Flag: model.NullableFlag, Value: []byte(value)},
+		{Name: "c_binary", Type: mysql.TypeVarString, Flag: binaryFlag(), Value: []byte(value)},
+		{Name: "c_text", Type: mysql.TypeBlob, Flag: model.NullableFlag, Value: []byte(value)},
+	}
+}
+
+// charsetValues are the corner cases of strings, all of them are valid
+// utf8mb4, the bytes which are not valid utf-8 are covered by the binary
+// columns of allTypesColumns.
+var charsetValues = []struct {
+	name  string
+	value string
+}{
+	{"empty", ""},
+	{"space", " \t \u3000"},
+	{"control", "\x00\x01\a\b\f\n\r\v\x1b\x7f"},
+	{"quote", `'single' "double" ` + "`back`" + ` \backslash\ \\ \n`},
+	{"latin1", "àéîõü ÿ ßæø ¡¿ ©®"},
+	{"cjk", "中文 日本語 한국어"},
+	{"emoji", "😀👍🏽👨‍👩‍👧 🇨🇳"},
+	{"combining", "e\u0301 a\u030a n\u0303"},
+	{"rtl", "עברית العربية"},
+	{"non-character", "\ufeff\ufffd\uffff\U0010ffff"},
+	{"json-like", `{"a":1,"b":[null]}`},
+	{"sql-like", "'; DROP TABLE t; --"},
+}
+
+func newRowCases() []RowCase {
+	table := &model.TableName{Schema: testSchema, Table: "all_types", TableID: 100}
+	ts := baseTs
+	nextTs := func() uint64 {
+		ts++
+		return ts
+	}
+	cases := []RowCase{{
+		Name: "insert all types",
+		Rows: []*model.RowChangedEvent{{
+			CommitTs: nextTs(), Table: table,
+			Columns: allTypesColumns(1),
+		}},
+	}, {
+		Name: "update all types",
+		Rows: []*model.RowChangedEvent{{
+			CommitTs: nextTs(), Table: table,
+			PreColumns: nullColumns(2),
+			Columns:    allTypesColumns(2),
+		}},
+	}, {
+		Name: "delete all types",
+		Rows: []*model.RowChangedEvent{{
+			CommitTs: nextTs(), Table: table,
+			PreColumns: allTypesColumns(3),
+		}},
+	}, {
+		Name: "insert null values",
+		Rows: []*model.RowChangedEvent{{
+			CommitTs: nextTs(), Table: table,
+			Columns: nullColumns(4),
+		}},
+	}, {
+		Name: "insert max values",
+		Rows: []*model.RowChangedEvent{{
+			CommitTs: nextTs(), Table: table,
+			Columns: maxValuesColumns(5),
+		}},
+	}, {
+		Name: "insert into partition",
+		Rows: []*model.RowChangedEvent{{
+			CommitTs: nextTs(),
+			Table: &model.TableName{
+				Schema: testSchema, Table: "partitioned", TableID: 102, IsPartition: true,
+			},
+			Columns: maxValuesColumns(6),
+		}},
+	}}
+
+	charsetTable := &model.TableName{Schema: testSchema, Table: "charset", TableID: 101}
+	batch := RowCase{Name: "batch of charsets"}
+	for i, v := range charsetValues {
+		id := int64(i + 1)
+		cases = append(cases, RowCase{
+			Name: "charset " + v.name,
+			Rows: []*model.RowChangedEvent{{
+				CommitTs: nextTs(), Table: charsetTable,
+				Columns: charsetColumns(id, v.value),
+			}},
+		})
+		batch.Rows = append(batch.Rows, &model.RowChangedEvent{
+			CommitTs: nextTs(), Table: charsetTable,
+			PreColumns: charsetColumns(id, v.value),
+			Columns:    charsetColumns(id, v.value+v.value),
+		})
+	}
+	return append(cases, batch)
+}
+
+func newDDLCases() []DDLCase {
+	ts := baseTs + 1000
+	newDDL := func(tp timodel.ActionType, table, query string) *model.DDLEvent {
+		ts++
+		return &model.DDLEvent{
+			CommitTs: ts,
+			TableInfo: &model.TableInfo{
+				TableName: model.TableName{Schema: testSchema, Table: table},
+			},
+			Query: query,
+			Type:  tp,
+		}
+	}
+	return []DDLCase{
+		{"create schema", newDDL(timodel.ActionCreateSchema, "",
+			"CREATE DATABASE `conformance` CHARACTER SET utf8mb4 COLLATE utf8mb4_bin")},
+		{"modify schema charset", newDDL(timodel.ActionModifySchemaCharsetAndCollate, "",
+			"ALTER DATABASE `conformance` CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci")},
+		{"create table", newDDL(timodel.ActionCreateTable, "t1",
+			"CREATE TABLE `conformance`.`t1` (`id` BIGINT PRIMARY KEY, "+
+				"`c` VARCHAR(255) CHARACTER SET utf8mb4 COLLATE utf8mb4_bin COMMENT '中文😀''\\\\')")},
+		{"create table like", newDDL(timodel.ActionCreateTable, "t2",
+			"CREATE TABLE `conformance`.`t2` LIKE `conformance`.`t1`")},
+		{"create partitioned table", newDDL(timodel.ActionCreateTable, "t3",
+			"CREATE TABLE `conformance`.`t3` (`id` INT) PARTITION BY RANGE (`id`) "+
+				"(PARTITION `p0` VALUES LESS THAN (100), PARTITION `p1` VALUES LESS THAN MAXVALUE)")},
+		{"create view", newDDL(timodel.ActionCreateView, "v1",
+			"CREATE VIEW `conformance`.`v1` AS SELECT * FROM `conformance`.`t1`")},
+		{"add column", newDDL(timodel.ActionAddColumn, "t1",
+			"ALTER TABLE `conformance`.`t1` ADD COLUMN `c2` JSON")},
+		{"add columns", newDDL(timodel.ActionAddColumns, "t1",
+			"ALTER TABLE `conformance`.`t1` ADD COLUMN `c3` INT, ADD COLUMN `c4` BLOB")},
+		{"drop column", newDDL(timodel.ActionDropColumn, "t1",
+			"ALTER TABLE `conformance`.`t1` DROP COLUMN `c3`")},
+		{"modify column", newDDL(timodel.ActionModifyColumn, "t1",
+			"ALTER TABLE `conformance`.`t1` MODIFY COLUMN `c4` LONGBLOB")},
+		{"set default value", newDDL(timodel.ActionSetDefaultValue, "t1",
+			"ALTER TABLE `conformance`.`t1` ALTER COLUMN `c2` SET DEFAULT (JSON_ARRAY())")},
+		{"add index", newDDL(timodel.ActionAddIndex, "t1",
+			"CREATE INDEX `idx_c` ON `conformance`.`t1` (`c`)")},
+		{"rename index", newDDL(timodel.ActionRenameIndex, "t1",
+			"ALTER TABLE `conformance`.`t1` RENAME INDEX `idx_c` TO `idx_c1`")},
+		{"drop index", newDDL(timodel.ActionDropIndex, "t1",
+			"DROP INDEX `idx_c1` ON `conformance`.`t1`")},
+		{"add primary key", newDDL(timodel.ActionAddPrimaryKey, "t2",
+			"ALTER TABLE `conformance`.`t2` ADD PRIMARY KEY (`id`)")},
+		{"modify table comment", newDDL(timodel.ActionModifyTableComment, "t1",
+			"ALTER TABLE `conformance`.`t1` COMMENT = 'comment with \\'quote\\' and 表'")},
+		{"modify table charset", newDDL(timodel.ActionModifyTableCharsetAndCollate, "t1",
+			"ALTER TABLE `conformance`.`t1` CHARACTER SET gbk COLLATE gbk_bin")},
+		{"add partition", newDDL(timodel.ActionAddTablePartition, "t3",
+			"ALTER TABLE `conformance`.`t3` REORGANIZE PARTITION `p1` INTO "+
+				"(PARTITION `p1` VALUES LESS THAN (200), PARTITION `p2` VALUES LESS THAN MAXVALUE)")},
+		{"truncate partition", newDDL(timodel.ActionTruncateTablePartition, "t3",
+			"ALTER TABLE `conformance`.`t3` TRUNCATE PARTITION `p0`")},
+		{"drop partition", newDDL(timodel.ActionDropTablePartition, "t3",
+			"ALTER TABLE `conformance`.`t3` DROP PARTITION `p0`")},
+		{"rename table", newDDL(timodel.ActionRenameTable, "t2_new",
+			"RENAME TABLE `conformance`.`t2` TO `conformance`.`t2_new`")},
+		{"rename table with special characters", newDDL(timodel.ActionRenameTable, "表 `x`",
+			"RENAME TABLE `conformance`.`t2_new` TO `conformance`.`表 ``x```")},
+		{"truncate table", newDDL(timodel.ActionTruncateTable, "t1",
+			"TRUNCATE TABLE `conformance`.`t1`")},
+		{"drop view", newDDL(timodel.ActionDropView, "v1",
+			"DROP VIEW `conformance`.`v1`")},
+		{"drop table", newDDL(timodel.ActionDropTable, "t1",
+			"DROP TABLE `conformance`.`t1`")},
+		{"drop schema", newDDL(timodel.ActionDropSchema, "",
+			"DROP DATABASE `conformance`")},
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conformance
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCorpusCoversAllColumnTypes(t *testing.T) {
+	t.Parallel()
+
+	covered := make(map[byte]bool)
+	names := make(map[string]bool)
+	corpus := NewCorpus()
+	for _, cs := range corpus.Rows {
+		require.False(t, names[cs.Name], "duplicated case %s", cs.Name)
+		names[cs.Name] = true
+		for _, row := range cs.Rows {
+			for _, col := range append(row.Columns, row.PreColumns...) {
+				if col.Value != nil {
+					covered[col.Type] = true
+				}
+			}
+		}
+	}
+	for _, tp := range ColumnTypes {
+		require.True(t, covered[tp], "column type %d is not covered", tp)
+	}
+	for _, cs := range corpus.DDLs {
+		require.False(t, names[cs.Name], "duplicated case %s", cs.Name)
+		names[cs.Name] = true
+	}
+}
+
+func TestNewCorpusReturnsCopy(t *testing.T) {
+	t.Parallel()
+
+	corpus := NewCorpus()
+	corpus.Rows[0].Rows[0].Columns[0].Value = nil
+	require.NotNil(t, NewCorpus().Rows[0].Rows[0].Columns[0].Value)
+}
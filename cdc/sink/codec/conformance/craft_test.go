@@ -0,0 +1,47 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conformance_test
+
+import (
+	"testing"
+
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sink/codec"
+	"github.com/pingcap/tiflow/cdc/sink/codec/common"
+	"github.com/pingcap/tiflow/cdc/sink/codec/conformance"
+	"github.com/pingcap/tiflow/cdc/sink/codec/craft"
+	"github.com/pingcap/tiflow/pkg/config"
+)
+
+func TestCraftConformance(t *testing.T) {
+	cfg := common.NewConfig(config.ProtocolCraft).WithMaxMessageBytes(1024 * 1024)
+	cfg.MaxBatchSize = 4
+	suite := conformance.NewSuite()
+	// craft encodes the values of the float columns as float64.
+	for _, cs := range suite.Corpus.Rows {
+		for _, row := range cs.Rows {
+			for _, cols := range [][]*model.Column{row.Columns, row.PreColumns} {
+				for _, col := range cols {
+					if v, ok := col.Value.(float32); ok {
+						col.Value = float64(v)
+					}
+				}
+			}
+		}
+	}
+	suite.Run(t, craft.NewBatchEncoderBuilder(cfg),
+		func(_ []byte, value []byte) (codec.EventBatchDecoder, error) {
+			return craft.NewBatchDecoderWithAllocator(value, craft.NewSliceAllocator(64))
+		})
+}
@@ -0,0 +1,22 @@
+module github.com/pingcap/tiflow/cdc/sink/codec/conformance
+
+go 1.19
+
+// github.com/pingcap/tiflow is required at the commit the suite is released
+// with, it's added by make conformance_pin together with the indirect
+// requirements and go.sum before the suite is tagged. The tests in this
+// repository use the working tree through a go.work, see make
+// conformance_test.
+require (
+	github.com/pingcap/tidb/parser v0.0.0-20221201110602-e307642d9fa5
+	github.com/stretchr/testify v1.8.0
+)
+
+// Fix CVE-2020-26160.
+replace github.com/dgrijalva/jwt-go v3.2.0+incompatible => github.com/golang-jwt/jwt v3.2.2+incompatible
+
+// Fix https://github.com/pingcap/tiflow/issues/4961
+replace github.com/benbjohnson/clock v1.3.0 => github.com/benbjohnson/clock v1.1.0
+
+// copy from TiDB
+replace go.opencensus.io => go.opencensus.io v0.23.1-0.20220331163232-052120675fac
@@ -0,0 +1,33 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conformance_test
+
+import (
+	"testing"
+
+	"github.com/pingcap/tiflow/cdc/sink/codec"
+	"github.com/pingcap/tiflow/cdc/sink/codec/common"
+	"github.com/pingcap/tiflow/cdc/sink/codec/conformance"
+	"github.com/pingcap/tiflow/cdc/sink/codec/open"
+	"github.com/pingcap/tiflow/pkg/config"
+)
+
+func TestOpenProtocolConformance(t *testing.T) {
+	cfg := common.NewConfig(config.ProtocolOpen).WithMaxMessageBytes(1024 * 1024)
+	cfg.MaxBatchSize = 4
+	conformance.NewSuite().Run(t, open.NewBatchEncoderBuilder(cfg),
+		func(key []byte, value []byte) (codec.EventBatchDecoder, error) {
+			return open.NewBatchDecoder(key, value, codec.WithStrictDecode(true))
+		})
+}
@@ -0,0 +1,270 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package conformance is a test kit of the protocols of TiCDC. It contains a
+// golden corpus of events, which covers all the column types, the corner
+// cases of charsets and the varieties of DDLs, and the assertions that the
+// events are decoded as they are encoded.
+//
+// An encoder implementation runs Suite.Run with its decoder. A third-party
+// decoder runs Suite.Encode with the encoder of TiCDC it's compatible with,
+// then Suite.Verify with itself, so the compatibility can be validated
+// whenever TiCDC is upgraded.
+//
+// The suite is published as the separate module
+// github.com/pingcap/tiflow/cdc/sink/codec/conformance. It requires the
+// tiflow it's released with, so a decoder out of TiCDC requires the suite at
+// the version of TiCDC to be validated against and gets the matching
+// encoders. Within this repository the suite is tested against the working
+// tree through a go.work, see the conformance_test target of the Makefile.
+package conformance
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sink/codec"
+	"github.com/stretchr/testify/require"
+)
+
+// NewDecoderFunc creates a decoder of an encoded message.
+type NewDecoderFunc func(key, value []byte) (codec.EventBatchDecoder, error)
+
+// Message is an encoded message of a case in the corpus with the events
+// it's expected to be decoded to.
+type Message struct {
+	// Case is the name of the case.
+	Case  string
+	Key   []byte
+	Value []byte
+
+	Rows       []*model.RowChangedEvent
+	DDL        *model.DDLEvent
+	ResolvedTs uint64
+	Type       model.MessageType
+}
+
+// Suite is the conformance suite of a protocol.
+type Suite struct {
+	Corpus *Corpus
+	// NormalizeValue converts the value of a column before comparing,
+	// so that the representations of the same value are equal. It's
+	// NormalizeValue by default.
+	NormalizeValue func(col *model.Column) any
+}
+
+// NewSuite creates a Suite with the golden corpus.
+func NewSuite() *Suite {
+	return &Suite{
+		Corpus:         NewCorpus(),
+		NormalizeValue: NormalizeValue,
+	}
+}
+
+// NormalizeValue converts the value of a column into a string, the bytes
+// are converted as they are and the numbers are formatted, so that the
+// values in different types are equal if they are the same number.
+func NormalizeValue(col *model.Column) any {
+	switch v := col.Value.(type) {
+	case nil:
+		return nil
+	case []byte:
+		return string(v)
+	case string:
+		return v
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// Run encodes the corpus by the encoder, then verifies the messages are
+// decoded as expected by the decoder.
+func (s *Suite) Run(
+	t *testing.T, encoderBuilder codec.EncoderBuilder, newDecoder NewDecoderFunc,
+) {
+	s.Verify(t, s.Encode(t, encoderBuilder), newDecoder)
+}
+
+// Encode encodes the corpus by the encoder. A DDL which the encoder doesn't
+// emit is skipped.
+func (s *Suite) Encode(t *testing.T, encoderBuilder codec.EncoderBuilder) []*Message {
+	var messages []*Message
+	for _, cs := range s.Corpus.Rows {
+		encoder := encoderBuilder.Build()
+		for _, row := range cs.Rows {
+			err := encoder.AppendRowChangedEvent(context.Background(), "", row, nil)
+			require.NoError(t, err, "case %s", cs.Name)
+		}
+		msgs := encoder.Build()
+		require.NotEmpty(t, msgs, "case %s", cs.Name)
+		for i, msg := range msgs {
+			m := &Message{
+				Case:  cs.Name,
+				Key:   msg.Key,
+				Value: msg.Value,
+				Type:  model.MessageTypeRow,
+			}
+			// all the rows are expected in the first message, since the
+			// rows may be batched into messages in any way.
+			if i == 0 {
+				m.Rows = cs.Rows
+			}
+			messages = append(messages, m)
+		}
+	}
+	for _, cs := range s.Corpus.DDLs {
+		msg, err := encoderBuilder.Build().EncodeDDLEvent(cs.DDL)
+		require.NoError(t, err, "case %s", cs.Name)
+		if msg == nil {
+			continue
+		}
+		messages = append(messages, &Message{
+			Case:  cs.Name,
+			Key:   msg.Key,
+			Value: msg.Value,
+			DDL:   cs.DDL,
+			Type:  model.MessageTypeDDL,
+		})
+	}
+	for _, ts := range s.Corpus.ResolvedTs {
+		msg, err := encoderBuilder.Build().EncodeCheckpointEvent(ts)
+		require.NoError(t, err, "resolved ts %d", ts)
+		if msg == nil {
+			continue
+		}
+		messages = append(messages, &Message{
+			Case:       fmt.Sprintf("resolved ts %d", ts),
+			Key:        msg.Key,
+			Value:      msg.Value,
+			ResolvedTs: ts,
+			Type:       model.MessageTypeResolved,
+		})
+	}
+	return messages
+}
+
+// Verify verifies the messages are decoded as expected by the decoder.
+func (s *Suite) Verify(t *testing.T, messages []*Message, newDecoder NewDecoderFunc) {
+	// the rows of a case may be encoded into several messages,
+	// they are verified together.
+	var (
+		rowCase  string
+		expected []*model.RowChangedEvent
+		actual   []*model.RowChangedEvent
+	)
+	verifyRows := func() {
+		if rowCase == "" {
+			return
+		}
+		t.Run(rowCase, func(t *testing.T) {
+			require.Len(t, actual, len(expected))
+			for i := range expected {
+				s.assertRowEqual(t, expected[i], actual[i])
+			}
+		})
+		rowCase, expected, actual = "", nil, nil
+	}
+
+	for _, m := range messages {
+		if m.Type != model.MessageTypeRow || m.Case != rowCase {
+			verifyRows()
+		}
+		decoder, err := newDecoder(m.Key, m.Value)
+		require.NoError(t, err, "case %s", m.Case)
+		switch m.Type {
+		case model.MessageTypeRow:
+			rowCase = m.Case
+			expected = append(expected, m.Rows...)
+			actual = append(actual, decodeRows(t, m.Case, decoder)...)
+		case model.MessageTypeDDL:
+			t.Run(m.Case, func(t *testing.T) {
+				tp, hasNext, err := decoder.HasNext()
+				require.NoError(t, err)
+				require.True(t, hasNext)
+				require.Equal(t, model.MessageTypeDDL, tp)
+				ddl, err := decoder.NextDDLEvent()
+				require.NoError(t, err)
+				assertDDLEqual(t, m.DDL, ddl)
+			})
+		case model.MessageTypeResolved:
+			t.Run(m.Case, func(t *testing.T) {
+				tp, hasNext, err := decoder.HasNext()
+				require.NoError(t, err)
+				require.True(t, hasNext)
+				require.Equal(t, model.MessageTypeResolved, tp)
+				ts, err := decoder.NextResolvedEvent()
+				require.NoError(t, err)
+				require.Equal(t, m.ResolvedTs, ts)
+			})
+		}
+	}
+	verifyRows()
+}
+
+func decodeRows(t *testing.T, name string, decoder codec.EventBatchDecoder) []*model.RowChangedEvent {
+	var rows []*model.RowChangedEvent
+	for {
+		tp, hasNext, err := decoder.HasNext()
+		require.NoError(t, err, "case %s", name)
+		if !hasNext {
+			return rows
+		}
+		require.Equal(t, model.MessageTypeRow, tp, "case %s", name)
+		row, err := decoder.NextRowChangedEvent()
+		require.NoError(t, err, "case %s", name)
+		rows = append(rows, row)
+	}
+}
+
+func (s *Suite) assertRowEqual(t *testing.T, expected, actual *model.RowChangedEvent) {
+	require.Equal(t, expected.CommitTs, actual.CommitTs)
+	require.Equal(t, expected.Table.Schema, actual.Table.Schema)
+	require.Equal(t, expected.Table.Table, actual.Table.Table)
+	require.Equal(t, expected.IsDelete(), actual.IsDelete())
+	s.assertColumnsEqual(t, expected.Columns, actual.Columns)
+	s.assertColumnsEqual(t, expected.PreColumns, actual.PreColumns)
+}
+
+// assertColumnsEqual compares the columns by their names, since the order
+// of the columns may not be kept by a protocol.
+func (s *Suite) assertColumnsEqual(t *testing.T, expected, actual []*model.Column) {
+	actualColumns := make(map[string]*model.Column, len(actual))
+	for _, col := range actual {
+		if col != nil {
+			actualColumns[col.Name] = col
+		}
+	}
+	var count int
+	for _, col := range expected {
+		if col == nil {
+			continue
+		}
+		count++
+		actualCol, ok := actualColumns[col.Name]
+		require.True(t, ok, "column %s is missing", col.Name)
+		require.Equal(t, col.Type, actualCol.Type, "column %s", col.Name)
+		require.Equal(t, s.NormalizeValue(col), s.NormalizeValue(actualCol),
+			"column %s", col.Name)
+	}
+	require.Len(t, actualColumns, count)
+}
+
+func assertDDLEqual(t *testing.T, expected, actual *model.DDLEvent) {
+	require.Equal(t, expected.CommitTs, actual.CommitTs)
+	require.Equal(t, expected.TableInfo.TableName.Schema, actual.TableInfo.TableName.Schema)
+	require.Equal(t, expected.TableInfo.TableName.Table, actual.TableInfo.TableName.Table)
+	require.Equal(t, expected.Query, actual.Query)
+	require.Equal(t, expected.Type, actual.Type)
+}
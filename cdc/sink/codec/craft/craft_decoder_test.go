@@ -0,0 +1,115 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package craft
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sink/codec/common"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/stretchr/testify/require"
+)
+
+func encodeTestMessages(t require.TestingT) [][]byte {
+	encoder := NewBatchEncoderBuilder(common.NewConfig(config.ProtocolCraft)).Build()
+	err := encoder.AppendRowChangedEvent(context.Background(), "", &model.RowChangedEvent{
+		CommitTs: 1,
+		Table:    &model.TableName{Schema: "a", Table: "b"},
+		Columns: []*model.Column{{
+			Name:  "col1",
+			Type:  mysql.TypeVarchar,
+			Value: []byte("aa"),
+		}, {
+			Name:  "col2",
+			Type:  mysql.TypeLong,
+			Value: int64(1),
+		}},
+	}, nil)
+	require.Nil(t, err)
+	var result [][]byte
+	for _, msg := range encoder.Build() {
+		result = append(result, msg.Value)
+	}
+	msg, err := encoder.EncodeDDLEvent(&model.DDLEvent{
+		CommitTs: 2,
+		TableInfo: &model.TableInfo{
+			TableName: model.TableName{Schema: "a", Table: "b"},
+		},
+		Query: "create table a.b(col1 varchar(10), col2 int)",
+	})
+	require.Nil(t, err)
+	result = append(result, msg.Value)
+	msg, err = encoder.EncodeCheckpointEvent(3)
+	require.Nil(t, err)
+	return append(result, msg.Value)
+}
+
+// decodeAll decodes all events in the message, and returns the first error.
+func decodeAll(bits []byte) error {
+	decoder, err := newBatchDecoder(bits)
+	if err != nil {
+		return err
+	}
+	for {
+		tp, hasNext, err := decoder.HasNext()
+		if err != nil {
+			return err
+		}
+		if !hasNext {
+			return nil
+		}
+		switch tp {
+		case model.MessageTypeRow:
+			_, err = decoder.NextRowChangedEvent()
+		case model.MessageTypeDDL:
+			_, err = decoder.NextDDLEvent()
+		default:
+			_, err = decoder.NextResolvedEvent()
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func TestDecodeTruncatedMessage(t *testing.T) {
+	t.Parallel()
+
+	for _, bits := range encodeTestMessages(t) {
+		require.Nil(t, decodeAll(bits))
+		for i := 0; i < len(bits); i++ {
+			// The message is truncated from the head, since size tables
+			// are at the end of the message.
+			require.NotPanics(t, func() {
+				_ = decodeAll(bits[i:])
+			})
+			require.NotPanics(t, func() {
+				_ = decodeAll(bits[:i])
+			})
+		}
+	}
+}
+
+func FuzzCraftDecoder(f *testing.F) {
+	for _, bits := range encodeTestMessages(f) {
+		f.Add(bits)
+	}
+	f.Fuzz(func(t *testing.T, bits []byte) {
+		// The decoder must never panic on malformed messages.
+		_ = decodeAll(bits)
+	})
+}
@@ -16,10 +16,12 @@ package craft
 import (
 	"context"
 
+	"github.com/pingcap/log"
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/cdc/sink/codec"
 	"github.com/pingcap/tiflow/cdc/sink/codec/common"
 	"github.com/pingcap/tiflow/pkg/config"
+	"go.uber.org/zap"
 )
 
 // BatchEncoder encodes the events into the byte of a batch into craft binary format.
@@ -32,6 +34,10 @@ type BatchEncoder struct {
 	MaxMessageBytes int
 	MaxBatchSize    int
 
+	// sizeEstimator accounts the size of the messages after compressed,
+	// it's shared by the encoders built by the same builder.
+	sizeEstimator *common.SizeEstimator
+
 	allocator *SliceAllocator
 }
 
@@ -53,7 +59,8 @@ func (e *BatchEncoder) AppendRowChangedEvent(
 	if callback != nil {
 		e.callbackBuf = append(e.callbackBuf, callback)
 	}
-	if size > e.MaxMessageBytes || rows >= e.MaxBatchSize {
+	if size > e.MaxMessageBytes || rows >= e.MaxBatchSize ||
+		e.sizeEstimator.Estimate(size) > e.MaxMessageBytes {
 		e.flush()
 	}
 	return nil
@@ -94,6 +101,16 @@ func (e *BatchEncoder) flush() {
 		}
 		e.callbackBuf = make([]func(), 0)
 	}
+	// The rows are encoded in columns, so the message can not be split
+	// like the open protocol does. The size estimator learns from it, so
+	// the following batches are smaller.
+	if e.sizeEstimator.Compressed() {
+		if size := e.sizeEstimator.Verify(message, e.MaxMessageBytes); size > e.MaxMessageBytes {
+			log.Warn("Craft message is too large after compressed",
+				zap.Int("max-message-size", e.MaxMessageBytes), zap.Int("length", size),
+				zap.Int("rows", rowsCnt), zap.String("schema", schema), zap.String("table", table))
+		}
+	}
 	e.messageBuf = append(e.messageBuf, message)
 }
 
@@ -106,7 +123,8 @@ func NewBatchEncoder() codec.EventBatchEncoder {
 }
 
 type batchEncoderBuilder struct {
-	config *common.Config
+	config        *common.Config
+	sizeEstimator *common.SizeEstimator
 }
 
 // Build a BatchEncoder
@@ -114,12 +132,16 @@ func (b *batchEncoderBuilder) Build() codec.EventBatchEncoder {
 	encoder := NewBatchEncoder()
 	encoder.(*BatchEncoder).MaxMessageBytes = b.config.MaxMessageBytes
 	encoder.(*BatchEncoder).MaxBatchSize = b.config.MaxBatchSize
+	encoder.(*BatchEncoder).sizeEstimator = b.sizeEstimator
 	return encoder
 }
 
 // NewBatchEncoderBuilder creates a craft batchEncoderBuilder.
 func NewBatchEncoderBuilder(config *common.Config) codec.EncoderBuilder {
-	return &batchEncoderBuilder{config: config}
+	return &batchEncoderBuilder{
+		config:        config,
+		sizeEstimator: common.NewSizeEstimator(config.Compression),
+	}
 }
 
 // NewBatchEncoderWithAllocator creates a new BatchEncoder with given allocator.
@@ -15,6 +15,7 @@ package craft
 
 import (
 	"context"
+	"math/rand"
 	"testing"
 
 	"github.com/pingcap/tidb/parser/mysql"
@@ -52,6 +53,43 @@ func TestCraftMaxMessageBytes(t *testing.T) {
 	}
 }
 
+func TestCraftCompressedMaxMessageBytes(t *testing.T) {
+	t.Parallel()
+
+	random := rand.New(rand.NewSource(0))
+	var events []*model.RowChangedEvent
+	for i := 0; i < 100; i++ {
+		value := make([]byte, 256)
+		random.Read(value)
+		events = append(events, &model.RowChangedEvent{
+			CommitTs: 1,
+			Table:    &model.TableName{Schema: "a", Table: "b"},
+			Columns: []*model.Column{{
+				Name:  "col1",
+				Type:  mysql.TypeBlob,
+				Value: value,
+			}},
+		})
+	}
+	build := func(compression string) (*BatchEncoder, []*common.Message) {
+		cfg := common.NewConfig(config.ProtocolCraft).WithMaxMessageBytes(4096)
+		cfg.Compression = compression
+		encoder := NewBatchEncoderBuilder(cfg).Build().(*BatchEncoder)
+		for _, event := range events {
+			err := encoder.AppendRowChangedEvent(context.Background(), "", event, nil)
+			require.Nil(t, err)
+		}
+		return encoder, encoder.Build()
+	}
+
+	_, uncompressed := build("")
+	encoder, compressed := build("lz4")
+	// the random data is not compressible, so the ratio learned is larger
+	// than 1 and the batches are smaller.
+	require.Greater(t, encoder.sizeEstimator.Estimate(1000), 1000)
+	require.GreaterOrEqual(t, len(compressed), len(uncompressed))
+}
+
 func TestCraftMaxBatchSize(t *testing.T) {
 	t.Parallel()
 	cfg := common.NewConfig(config.ProtocolCraft).WithMaxMessageBytes(10485760)
@@ -15,6 +15,7 @@ package craft
 
 import (
 	"encoding/binary"
+	"fmt"
 	"math"
 	"unsafe"
 
@@ -40,7 +41,7 @@ func decodeUint8(bits []byte) ([]byte, byte, error) {
 
 func decodeVarint(bits []byte) ([]byte, int64, error) {
 	x, rd := binary.Varint(bits)
-	if rd < 0 {
+	if rd <= 0 {
 		return bits, 0, cerror.ErrCraftCodecInvalidData.GenWithStack("invalid varint data")
 	}
 	return bits[rd:], x, nil
@@ -48,7 +49,7 @@ func decodeVarint(bits []byte) ([]byte, int64, error) {
 
 func decodeUvarint(bits []byte) ([]byte, uint64, error) {
 	x, rd := binary.Uvarint(bits)
-	if rd < 0 {
+	if rd <= 0 {
 		return bits, 0, cerror.ErrCraftCodecInvalidData.GenWithStack("invalid uvarint data")
 	}
 	return bits[rd:], x, nil
@@ -144,8 +145,32 @@ func decodeString(bits []byte) ([]byte, string, error) {
 	return bits, "", errors.Trace(err)
 }
 
+// checkChunkSize checks whether a chunk of the given size can be decoded from
+// bits, every element of a chunk takes at least one byte.
+func checkChunkSize(bits []byte, size int) error {
+	if size < 0 || size > len(bits) {
+		return cerror.ErrCodecTruncatedPayload.GenWithStackByArgs(
+			fmt.Sprintf("chunk of %d elements requires at least %d bytes, but only %d bytes left",
+				size, size, len(bits)))
+	}
+	return nil
+}
+
+// checkElementLength checks whether an element of the given length can be
+// decoded from bits.
+func checkElementLength(bits []byte, length int) error {
+	if length < 0 || length > len(bits) {
+		return cerror.ErrCodecTruncatedPayload.GenWithStackByArgs(
+			fmt.Sprintf("element requires %d bytes, but only %d bytes left", length, len(bits)))
+	}
+	return nil
+}
+
 // Chunk decoders
 func decodeStringChunk(bits []byte, size int, allocator *SliceAllocator) ([]byte, []string, error) {
+	if err := checkChunkSize(bits, size); err != nil {
+		return bits, nil, err
+	}
 	larray := allocator.intSlice(size)
 	newBits := bits
 	var bl int
@@ -160,6 +185,9 @@ func decodeStringChunk(bits []byte, size int, allocator *SliceAllocator) ([]byte
 
 	data := allocator.stringSlice(size)
 	for i := 0; i < size; i++ {
+		if err := checkElementLength(newBits, larray[i]); err != nil {
+			return bits, nil, err
+		}
 		data[i] = unsafeBytesToString(newBits[:larray[i]])
 		newBits = newBits[larray[i]:]
 	}
@@ -167,6 +195,9 @@ func decodeStringChunk(bits []byte, size int, allocator *SliceAllocator) ([]byte
 }
 
 func decodeNullableStringChunk(bits []byte, size int, allocator *SliceAllocator) ([]byte, []*string, error) {
+	if err := checkChunkSize(bits, size); err != nil {
+		return bits, nil, err
+	}
 	larray := allocator.intSlice(size)
 	newBits := bits
 	var bl int
@@ -184,6 +215,9 @@ func decodeNullableStringChunk(bits []byte, size int, allocator *SliceAllocator)
 		if larray[i] == -1 {
 			continue
 		}
+		if err := checkElementLength(newBits, larray[i]); err != nil {
+			return bits, nil, err
+		}
 		s := unsafeBytesToString(newBits[:larray[i]])
 		data[i] = &s
 		newBits = newBits[larray[i]:]
@@ -197,6 +231,9 @@ func decodeBytesChunk(bits []byte, size int, allocator *SliceAllocator) ([]byte,
 }
 
 func doDecodeBytesChunk(bits []byte, size int, lengthDecoder func([]byte) ([]byte, int, error), allocator *SliceAllocator) ([]byte, [][]byte, error) {
+	if err := checkChunkSize(bits, size); err != nil {
+		return bits, nil, err
+	}
 	larray := allocator.intSlice(size)
 	newBits := bits
 	var bl int
@@ -212,6 +249,9 @@ func doDecodeBytesChunk(bits []byte, size int, lengthDecoder func([]byte) ([]byt
 	data := allocator.bytesSlice(size)
 	for i := 0; i < size; i++ {
 		if larray[i] != -1 {
+			if err := checkElementLength(newBits, larray[i]); err != nil {
+				return bits, nil, err
+			}
 			data[i] = newBits[:larray[i]]
 			newBits = newBits[larray[i]:]
 		}
@@ -224,6 +264,9 @@ func decodeNullableBytesChunk(bits []byte, size int, allocator *SliceAllocator)
 }
 
 func decodeVarintChunk(bits []byte, size int, allocator *SliceAllocator) ([]byte, []int64, error) {
+	if err := checkChunkSize(bits, size); err != nil {
+		return bits, nil, err
+	}
 	array := allocator.int64Slice(size)
 	newBits := bits
 	var i64 int64
@@ -239,6 +282,9 @@ func decodeVarintChunk(bits []byte, size int, allocator *SliceAllocator) ([]byte
 }
 
 func decodeUvarintChunk(bits []byte, size int, allocator *SliceAllocator) ([]byte, []uint64, error) {
+	if err := checkChunkSize(bits, size); err != nil {
+		return bits, nil, err
+	}
 	array := allocator.uint64Slice(size)
 	newBits := bits
 	var u64 uint64
@@ -254,7 +300,13 @@ func decodeUvarintChunk(bits []byte, size int, allocator *SliceAllocator) ([]byt
 }
 
 func decodeDeltaVarintChunk(bits []byte, size int, allocator *SliceAllocator) ([]byte, []int64, error) {
+	if err := checkChunkSize(bits, size); err != nil {
+		return bits, nil, err
+	}
 	array := allocator.int64Slice(size)
+	if size == 0 {
+		return bits, array, nil
+	}
 	newBits := bits
 	var err error
 	newBits, array[0], err = decodeVarint(newBits)
@@ -272,7 +324,13 @@ func decodeDeltaVarintChunk(bits []byte, size int, allocator *SliceAllocator) ([
 }
 
 func decodeDeltaUvarintChunk(bits []byte, size int, allocator *SliceAllocator) ([]byte, []uint64, error) {
+	if err := checkChunkSize(bits, size); err != nil {
+		return bits, nil, err
+	}
 	array := allocator.uint64Slice(size)
+	if size == 0 {
+		return bits, array, nil
+	}
 	newBits := bits
 	var err error
 	newBits, array[0], err = decodeUvarint(newBits)
@@ -291,13 +349,19 @@ func decodeDeltaUvarintChunk(bits []byte, size int, allocator *SliceAllocator) (
 
 // size tables are always at end of serialized data, there is no unread bytes to return
 func decodeSizeTables(bits []byte, allocator *SliceAllocator) (int, [][]int64, error) {
-	nb, size, _ := decodeUvarintReversedLength(bits)
+	nb, size, err := decodeUvarintReversedLength(bits)
+	if err != nil {
+		return 0, nil, errors.Trace(err)
+	}
 	sizeOffset := len(bits) - nb
 	tablesOffset := sizeOffset - size
+	if tablesOffset < 0 {
+		return 0, nil, cerror.ErrCodecTruncatedPayload.GenWithStackByArgs(
+			fmt.Sprintf("size tables require %d bytes, but only %d bytes left", size, sizeOffset))
+	}
 	tables := bits[tablesOffset:sizeOffset]
 
 	tableSize := size + nb
-	var err error
 	var table []int64
 	result := make([][]int64, 0, 1)
 	for len(tables) > 0 {
@@ -381,6 +445,11 @@ func NewMessageDecoder(bits []byte, allocator *SliceAllocator) (*MessageDecoder,
 		return nil, errors.Trace(err)
 	}
 
+	if len(sizeTables) < columnGroupSizeTableStartIndex ||
+		len(sizeTables[metaSizeTableIndex]) <= maxMetaSizeIndex {
+		return nil, cerror.ErrCraftCodecInvalidData.GenWithStack("size tables not found")
+	}
+
 	// truncate tailing size tables
 	bits = bits[:len(bits)-sizeTablesSize]
 
@@ -394,6 +463,9 @@ func NewMessageDecoder(bits []byte, allocator *SliceAllocator) (*MessageDecoder,
 	// start offset of last body element
 	start := 0
 	for i, size := range bodySizeTable {
+		if size < 0 || int64(start)+size > int64(len(bits)) {
+			return nil, cerror.ErrCraftCodecInvalidData.GenWithStack("invalid body size")
+		}
 		bodyOffsetTable[i] = start
 		start += int(size)
 	}
@@ -403,6 +475,14 @@ func NewMessageDecoder(bits []byte, allocator *SliceAllocator) (*MessageDecoder,
 	// get meta data size table which contains size of headers and term dictionary
 	metaSizeTable := sizeTables[metaSizeTableIndex]
 
+	headerSize, termDictionarySize := metaSizeTable[headerSizeIndex], metaSizeTable[termDictionarySizeIndex]
+	if headerSize < 0 || termDictionarySize < 0 ||
+		int64(start)+headerSize+termDictionarySize > int64(len(bits)) {
+		return nil, cerror.ErrCodecTruncatedPayload.GenWithStackByArgs(
+			fmt.Sprintf("message requires %d bytes, but only %d bytes left",
+				int64(start)+headerSize+termDictionarySize, len(bits)))
+	}
+
 	var dict *termDictionary
 	termDictionaryOffset := int(metaSizeTable[headerSizeIndex]) + start
 	if metaSizeTable[termDictionarySizeIndex] > 0 {
@@ -462,10 +542,21 @@ func (d *MessageDecoder) DDLEvent(index int) (pmodel.ActionType, string, error)
 // RowChangedEvent decode a row changeded event
 func (d *MessageDecoder) RowChangedEvent(index int) (preColumns, columns *columnGroup, err error) {
 	bits := d.bodyBits(index)
+	if columnGroupSizeTableStartIndex+index >= len(d.sizeTables) {
+		return nil, nil, cerror.ErrCraftCodecInvalidData.GenWithStack("column group size table not found")
+	}
 	columnGroupSizeTable := d.sizeTables[columnGroupSizeTableStartIndex+index]
 	columnGroupIndex := 0
 	for len(bits) > 0 {
+		if columnGroupIndex >= len(columnGroupSizeTable) {
+			return nil, nil, cerror.ErrCraftCodecInvalidData.GenWithStack("column group size not found")
+		}
 		columnGroupSize := columnGroupSizeTable[columnGroupIndex]
+		if columnGroupSize < 0 || columnGroupSize > int64(len(bits)) {
+			return nil, nil, cerror.ErrCodecTruncatedPayload.GenWithStackByArgs(
+				fmt.Sprintf("column group requires %d bytes, but only %d bytes left",
+					columnGroupSize, len(bits)))
+		}
 		columnGroup, err := decodeColumnGroup(bits[:columnGroupSize], d.allocator, d.dict)
 		bits = bits[columnGroupSize:]
 		columnGroupIndex++
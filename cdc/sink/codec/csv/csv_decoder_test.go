@@ -85,3 +85,46 @@ func TestCSVBatchDecoder(t *testing.T) {
 	_, hasNext, _ := decoder.HasNext()
 	require.False(t, hasNext)
 }
+
+func FuzzCSVBatchDecoder(f *testing.F) {
+	f.Add([]byte(`"I","employee","hr",433305438660591626,101,"Smith"` + "\n"))
+	f.Add([]byte(`"D","employee","hr",433305438660591629,\N,"Smith"` + "\n"))
+	tableInfo := &model.TableInfo{
+		TableName: model.TableName{Schema: "hr", Table: "employee"},
+		TableInfo: &timodel.TableInfo{
+			Name: timodel.NewCIStr("employee"),
+			Columns: []*timodel.ColumnInfo{
+				{
+					Name:      timodel.NewCIStr("Id"),
+					FieldType: *types.NewFieldType(mysql.TypeInt24),
+				},
+				{
+					Name:      timodel.NewCIStr("LastName"),
+					FieldType: *types.NewFieldType(mysql.TypeVarchar),
+				},
+			},
+		},
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		// The decoder must never panic on malformed messages.
+		decoder, err := NewBatchDecoder(context.Background(), &common.Config{
+			Delimiter:       ",",
+			Quote:           "\"",
+			Terminator:      "\n",
+			NullString:      "\\N",
+			IncludeCommitTs: true,
+		}, tableInfo, data)
+		if err != nil {
+			return
+		}
+		for {
+			_, hasNext, err := decoder.HasNext()
+			if err != nil || !hasNext {
+				return
+			}
+			if _, err := decoder.NextRowChangedEvent(); err != nil {
+				return
+			}
+		}
+	})
+}
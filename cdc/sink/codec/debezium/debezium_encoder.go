@@ -0,0 +1,116 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package debezium
+
+import (
+	"context"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sink/codec"
+	"github.com/pingcap/tiflow/cdc/sink/codec/common"
+	"github.com/pingcap/tiflow/pkg/config"
+)
+
+// BatchEncoder encodes the row changed events in the envelope of Debezium,
+// so that the consumers built for Debezium can consume them directly. Each
+// row is encoded into a message, keyed by its handle key columns.
+type BatchEncoder struct {
+	name string
+	// the symbol separating two lines
+	terminator []byte
+	messages   []*common.Message
+}
+
+// newBatchEncoder creates a new BatchEncoder.
+func newBatchEncoder(config *common.Config) codec.EventBatchEncoder {
+	return &BatchEncoder{
+		name:       config.ChangefeedID.ID,
+		terminator: []byte(config.Terminator),
+	}
+}
+
+// AppendRowChangedEvent implements the EventBatchEncoder interface
+func (d *BatchEncoder) AppendRowChangedEvent(
+	_ context.Context,
+	_ string,
+	e *model.RowChangedEvent,
+	callback func(),
+) error {
+	key, err := encodeKey(e)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	msg, err := newMessage(e, d.name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	value, err := msg.encode()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(d.terminator) > 0 {
+		value = append(value, d.terminator...)
+	}
+	m := &common.Message{
+		Key:      key,
+		Value:    value,
+		Ts:       e.CommitTs,
+		Schema:   &e.Table.Schema,
+		Table:    &e.Table.Table,
+		Type:     model.MessageTypeRow,
+		Protocol: config.ProtocolDebezium,
+		Callback: callback,
+	}
+	m.IncRowsCount()
+	d.messages = append(d.messages, m)
+	return nil
+}
+
+// EncodeCheckpointEvent implements the EventBatchEncoder interface. Debezium
+// has no checkpoint event, so nothing is encoded.
+func (d *BatchEncoder) EncodeCheckpointEvent(_ uint64) (*common.Message, error) {
+	return nil, nil
+}
+
+// EncodeDDLEvent implements the EventBatchEncoder interface. The schema
+// changes of Debezium are sent to a dedicated topic in another format, which
+// the consumers of the data topics can't handle, so the DDLs are skipped.
+func (d *BatchEncoder) EncodeDDLEvent(_ *model.DDLEvent) (*common.Message, error) {
+	return nil, nil
+}
+
+// Build implements the EventBatchEncoder interface
+func (d *BatchEncoder) Build() []*common.Message {
+	if len(d.messages) == 0 {
+		return nil
+	}
+	result := d.messages
+	d.messages = nil
+	return result
+}
+
+type batchEncoderBuilder struct {
+	config *common.Config
+}
+
+// NewBatchEncoderBuilder creates a debezium batchEncoderBuilder.
+func NewBatchEncoderBuilder(config *common.Config) codec.EncoderBuilder {
+	return &batchEncoderBuilder{config: config}
+}
+
+// Build a `BatchEncoder`
+func (b *batchEncoderBuilder) Build() codec.EventBatchEncoder {
+	return newBatchEncoder(b.config)
+}
@@ -0,0 +1,100 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package debezium
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/rowcodec"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sink/codec/common"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeRowChangedEvent(t *testing.T) {
+	t.Parallel()
+
+	c := common.NewConfig(config.ProtocolDebezium)
+	c.ChangefeedID = model.DefaultChangeFeedID("test")
+	encoder := NewBatchEncoderBuilder(c).Build()
+
+	row := &model.RowChangedEvent{
+		CommitTs: 435661838416609281,
+		Table:    &model.TableName{Schema: "db", Table: "t"},
+		PreColumns: []*model.Column{
+			{Name: "id", Type: mysql.TypeLong, Value: int64(1), Flag: model.HandleKeyFlag},
+			{Name: "name", Type: mysql.TypeVarchar, Value: []byte("a")},
+		},
+		Columns: []*model.Column{
+			{Name: "id", Type: mysql.TypeLong, Value: int64(1), Flag: model.HandleKeyFlag},
+			{Name: "name", Type: mysql.TypeVarchar, Value: []byte("b")},
+		},
+		ColInfos: []rowcodec.ColInfo{
+			{Ft: types.NewFieldType(mysql.TypeLong)},
+			{Ft: types.NewFieldType(mysql.TypeVarchar)},
+		},
+	}
+	count := 0
+	err := encoder.AppendRowChangedEvent(context.Background(), "", row, func() { count++ })
+	require.Nil(t, err)
+	messages := encoder.Build()
+	require.Len(t, messages, 1)
+	require.Nil(t, encoder.Build())
+	require.Equal(t, 1, messages[0].GetRowsCount())
+	require.Equal(t, `{"id":1}`, string(messages[0].Key))
+	messages[0].Callback()
+	require.Equal(t, 1, count)
+
+	var value map[string]interface{}
+	require.Nil(t, json.Unmarshal(messages[0].Value, &value))
+	require.Equal(t, "u", value["op"])
+	require.Equal(t, map[string]interface{}{"id": float64(1), "name": "a"}, value["before"])
+	require.Equal(t, map[string]interface{}{"id": float64(1), "name": "b"}, value["after"])
+	require.Nil(t, value["transaction"])
+	source := value["source"].(map[string]interface{})
+	require.Equal(t, "tidb", source["connector"])
+	require.Equal(t, "test", source["name"])
+	require.Equal(t, "db", source["db"])
+	require.Equal(t, "t", source["table"])
+	require.Equal(t, "false", source["snapshot"])
+	require.Equal(t, float64(1661918023745), source["ts_ms"])
+
+	// Delete events have no after image, and the keyless tables have no key.
+	row = &model.RowChangedEvent{
+		CommitTs:   1,
+		Table:      &model.TableName{Schema: "db", Table: "t"},
+		PreColumns: []*model.Column{{Name: "name", Type: mysql.TypeVarchar, Value: []byte("a")}},
+	}
+	require.Nil(t, encoder.AppendRowChangedEvent(context.Background(), "", row, nil))
+	messages = encoder.Build()
+	require.Len(t, messages, 1)
+	require.Nil(t, messages[0].Key)
+	value = nil
+	require.Nil(t, json.Unmarshal(messages[0].Value, &value))
+	require.Equal(t, "d", value["op"])
+	require.Nil(t, value["after"])
+
+	// DDLs and checkpoints are skipped.
+	msg, err := encoder.EncodeDDLEvent(&model.DDLEvent{CommitTs: 1, Query: "create table t(a int)"})
+	require.Nil(t, err)
+	require.Nil(t, msg)
+	msg, err = encoder.EncodeCheckpointEvent(1)
+	require.Nil(t, err)
+	require.Nil(t, msg)
+}
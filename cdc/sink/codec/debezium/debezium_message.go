@@ -0,0 +1,137 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package debezium
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/pingcap/tidb/util/rowcodec"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sink/codec/common"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/version"
+	"github.com/tikv/client-go/v2/oracle"
+)
+
+const (
+	// connector is the name of the connector in the source of the messages.
+	connector = "tidb"
+
+	opCreate = "c"
+	opUpdate = "u"
+	opDelete = "d"
+)
+
+// message is the envelope of Debezium. The schema of it isn't included, like
+// the JSON converter of Kafka Connect does with schemas.enable=false.
+type message struct {
+	Before map[string]interface{} `json:"before"`
+	After  map[string]interface{} `json:"after"`
+	Source *source                `json:"source"`
+	Op     string                 `json:"op"`
+	// TsMs is the time when the message is encoded.
+	TsMs        int64       `json:"ts_ms"`
+	Transaction interface{} `json:"transaction"`
+}
+
+// source is the metadata of the source of an event, the fields specific
+// to MySQL binlogs are replaced by the commit ts of TiDB.
+type source struct {
+	Version   string `json:"version"`
+	Connector string `json:"connector"`
+	Name      string `json:"name"`
+	// TsMs is the physical time of the commit ts.
+	TsMs     int64  `json:"ts_ms"`
+	Snapshot string `json:"snapshot"`
+	DB       string `json:"db"`
+	Table    string `json:"table"`
+	CommitTs uint64 `json:"commit_ts"`
+}
+
+// newMessage converts the row changed event to a Debezium envelope, name
+// is the logical name of the source, i.e. the changefeed.
+func newMessage(e *model.RowChangedEvent, name string) (*message, error) {
+	m := &message{
+		Source: &source{
+			Version:   version.ReleaseVersion,
+			Connector: connector,
+			Name:      name,
+			TsMs:      oracle.ExtractPhysical(e.CommitTs),
+			Snapshot:  "false",
+			DB:        e.Table.Schema,
+			Table:     e.Table.Table,
+			CommitTs:  e.CommitTs,
+		},
+		TsMs: time.Now().UnixMilli(),
+	}
+	var err error
+	switch {
+	case e.IsInsert():
+		m.Op = opCreate
+	case e.IsDelete():
+		m.Op = opDelete
+	default:
+		m.Op = opUpdate
+	}
+	if len(e.PreColumns) > 0 {
+		if m.Before, err = formatColumns(e.PreColumns, e.ColInfos); err != nil {
+			return nil, err
+		}
+	}
+	if len(e.Columns) > 0 {
+		if m.After, err = formatColumns(e.Columns, e.ColInfos); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+func (m *message) encode() ([]byte, error) {
+	data, err := json.Marshal(m)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrDebeziumEncodeFailed, err)
+	}
+	return data, nil
+}
+
+// encodeKey encodes the handle key columns of the event as the key of the
+// message, the key is nil if the table has no handle key.
+func encodeKey(e *model.RowChangedEvent) ([]byte, error) {
+	cols, colInfos := e.HandleKeyColInfos()
+	if len(cols) == 0 {
+		return nil, nil
+	}
+	key, err := formatColumns(cols, colInfos)
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(key)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrDebeziumEncodeFailed, err)
+	}
+	return data, nil
+}
+
+// formatColumns formats the columns to the fields of a Debezium struct, the
+// column infos are optional.
+func formatColumns(
+	cols []*model.Column, colInfos []rowcodec.ColInfo,
+) (map[string]interface{}, error) {
+	fields, err := common.FormatColumns(cols, colInfos)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrDebeziumEncodeFailed, err)
+	}
+	return fields, nil
+}
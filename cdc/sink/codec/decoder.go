@@ -30,3 +30,29 @@ type EventBatchDecoder interface {
 	// NextDDLEvent returns the next DDL event if exists
 	NextDDLEvent() (*model.DDLEvent, error)
 }
+
+// DecoderOptions contains the options of an EventBatchDecoder.
+type DecoderOptions struct {
+	// Strict makes the decoder reject messages with unknown fields or
+	// trailing bytes, instead of ignoring them.
+	Strict bool
+}
+
+// DecoderOption sets an option of an EventBatchDecoder.
+type DecoderOption func(*DecoderOptions)
+
+// WithStrictDecode enables or disables the strict decode mode.
+func WithStrictDecode(strict bool) DecoderOption {
+	return func(o *DecoderOptions) {
+		o.Strict = strict
+	}
+}
+
+// NewDecoderOptions creates DecoderOptions from the given options.
+func NewDecoderOptions(opts ...DecoderOption) *DecoderOptions {
+	o := &DecoderOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
@@ -40,6 +40,16 @@ type EventBatchEncoder interface {
 	Build() []*common.Message
 }
 
+// DDLEventTopicEncoder is implemented by the encoders which need the topic
+// to encode the DDL events, like avro registers the schemas of the topics.
+// The MQ DDL sink prefers it to EncodeDDLEvent.
+type DDLEventTopicEncoder interface {
+	// EncodeDDLEventForTopic encodes the DDL event sent to the topic.
+	EncodeDDLEventForTopic(
+		ctx context.Context, topic string, e *model.DDLEvent,
+	) (*common.Message, error)
+}
+
 // EncoderBuilder builds encoder with context.
 type EncoderBuilder interface {
 	Build() EventBatchEncoder
@@ -167,6 +167,11 @@ func newFuture(topic string, partition int32, events ...*eventsink.RowChangeCall
 	}
 }
 
+// Events returns the events encoded into the messages of the future.
+func (p *future) Events() []*eventsink.RowChangeCallbackableEvent {
+	return p.events
+}
+
 // Ready waits until the response is ready, should be called before consuming the future.
 func (p *future) Ready(ctx context.Context) error {
 	select {
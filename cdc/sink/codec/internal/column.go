@@ -21,6 +21,7 @@ import (
 	"github.com/pingcap/log"
 	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tiflow/cdc/model"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
 	"go.uber.org/zap"
 	"golang.org/x/text/encoding/charmap"
 )
@@ -103,63 +104,76 @@ func (c *Column) ToRowChangeColumn(name string) *model.Column {
 }
 
 // ToCanalJSONFormatColumn converts from a codec column to a row changed column in canal-json format.
-func (c *Column) ToCanalJSONFormatColumn(name string, javaType JavaSQLType) *model.Column {
+func (c *Column) ToCanalJSONFormatColumn(name string, javaType JavaSQLType) (*model.Column, error) {
 	col := new(model.Column)
 	col.Type = c.Type
 	col.Flag = c.Flag
 	col.Name = name
 	col.Value = c.Value
 	if c.Value == nil {
-		return col
+		return col, nil
 	}
 
 	value, ok := col.Value.(string)
 	if !ok {
-		log.Panic("canal-json encoded message should have type in `string`")
+		return nil, cerror.ErrCodecDecode.GenWithStack(
+			"canal-json encoded message should have type in `string`, column: %s", name)
 	}
 
 	if javaType == JavaSQLTypeBIT {
 		val, err := strconv.ParseUint(value, 10, 64)
 		if err != nil {
-			log.Panic("invalid column value for bit", zap.Any("col", c), zap.Error(err))
+			return nil, cerror.WrapError(cerror.ErrCodecDecode, err)
 		}
 		col.Value = val
-		return col
+		return col, nil
 	}
 
 	if javaType != JavaSQLTypeBLOB {
 		col.Value = value
-		return col
+		return col, nil
 	}
 
 	// when encoding the `JavaSQLTypeBLOB`, use `ISO8859_1` decoder, now reverse it back.
 	encoder := charmap.ISO8859_1.NewEncoder()
 	value, err := encoder.String(value)
 	if err != nil {
-		log.Panic("invalid column value, please report a bug", zap.Any("col", c), zap.Error(err))
+		return nil, cerror.WrapError(cerror.ErrCodecDecode, err)
 	}
 
 	col.Value = value
-	return col
+	return col, nil
 }
 
 // FormatColumn formats a codec column.
-func FormatColumn(c Column) Column {
+func FormatColumn(c Column) (Column, error) {
 	switch c.Type {
+	case mysql.TypeString, mysql.TypeVarString, mysql.TypeVarchar:
+		// validate the value here, so that ToRowChangeColumn never fails.
+		s, ok := c.Value.(string)
+		if !ok && c.Value != nil {
+			return c, cerror.ErrCodecDecode.GenWithStack(
+				"invalid column value, string is expected, type: %d", c.Type)
+		}
+		if ok && c.Flag.IsBinary() {
+			if _, err := strconv.Unquote("\"" + s + "\""); err != nil {
+				return c, cerror.WrapError(cerror.ErrCodecDecode, err)
+			}
+		}
 	case mysql.TypeTinyBlob, mysql.TypeMediumBlob,
 		mysql.TypeLongBlob, mysql.TypeBlob:
 		if s, ok := c.Value.(string); ok {
 			var err error
 			c.Value, err = base64.StdEncoding.DecodeString(s)
 			if err != nil {
-				log.Panic("invalid column value, please report a bug", zap.Any("col", c), zap.Error(err))
+				return c, cerror.WrapError(cerror.ErrCodecDecode, err)
 			}
 		}
 	case mysql.TypeFloat, mysql.TypeDouble:
 		if s, ok := c.Value.(json.Number); ok {
 			f64, err := s.Float64()
 			if err != nil {
-				log.Panic("invalid column value, please report a bug", zap.Any("col", c), zap.Error(err))
+				return c, cerror.WrapError(cerror.ErrCodecDecode, err)
 			}
 			c.Value = f64
 		}
@@ -172,7 +186,7 @@ func FormatColumn(c Column) Column {
 				c.Value, err = strconv.ParseInt(s.String(), 10, 64)
 			}
 			if err != nil {
-				log.Panic("invalid column value, please report a bug", zap.Any("col", c), zap.Error(err))
+				return c, cerror.WrapError(cerror.ErrCodecDecode, err)
 			}
 		} else if f, ok := c.Value.(float64); ok {
 			if c.Flag.IsUnsigned() {
@@ -185,10 +199,10 @@ func FormatColumn(c Column) Column {
 		if s, ok := c.Value.(json.Number); ok {
 			intNum, err := s.Int64()
 			if err != nil {
-				log.Panic("invalid column value, please report a bug", zap.Any("col", c), zap.Error(err))
+				return c, cerror.WrapError(cerror.ErrCodecDecode, err)
 			}
 			c.Value = uint64(intNum)
 		}
 	}
-	return c
+	return c, nil
 }
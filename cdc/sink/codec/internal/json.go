@@ -0,0 +1,45 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+)
+
+// UnmarshalJSON decodes the json data into v. In strict mode, unknown fields
+// and trailing data are rejected.
+func UnmarshalJSON(data []byte, v interface{}, strict bool) error {
+	if !strict {
+		return cerror.WrapError(cerror.ErrUnmarshalFailed, json.Unmarshal(data, v))
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(v); err != nil {
+		return cerror.WrapError(cerror.ErrCodecStrictDecodeFailed, err, err.Error())
+	}
+	return CheckTrailingData(data, decoder.InputOffset())
+}
+
+// CheckTrailingData returns an error if there is any non-space byte in data
+// after the given offset.
+func CheckTrailingData(data []byte, offset int64) error {
+	if offset < int64(len(data)) && len(bytes.TrimSpace(data[offset:])) > 0 {
+		return cerror.ErrCodecStrictDecodeFailed.GenWithStackByArgs(
+			"unexpected trailing data")
+	}
+	return nil
+}
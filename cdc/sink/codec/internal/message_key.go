@@ -38,5 +38,11 @@ func (m *MessageKey) Encode() ([]byte, error) {
 
 // Decode codes a message key from a byte slice.
 func (m *MessageKey) Decode(data []byte) error {
-	return cerror.WrapError(cerror.ErrUnmarshalFailed, json.Unmarshal(data, m))
+	return UnmarshalJSON(data, m, false)
+}
+
+// DecodeStrict codes a message key from a byte slice, unknown fields and
+// trailing data are rejected.
+func (m *MessageKey) DecodeStrict(data []byte) error {
+	return UnmarshalJSON(data, m, true)
 }
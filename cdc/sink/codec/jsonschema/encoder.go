@@ -0,0 +1,274 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonschema
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"strconv"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sink/codec"
+	"github.com/pingcap/tiflow/cdc/sink/codec/common"
+	"github.com/pingcap/tiflow/pkg/config"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// SchemaIDHeader is the header of the registry IDs of the JSON Schemas of
+// the message values. A row message references the schemas of the tables of
+// its rows, which are registered to the subjects "<topic>-<schema>.<table>",
+// an open protocol message carries the IDs of its rows in order, separated
+// by commas. A DDL message references the schema of the protocol, which is
+// registered to the subject "<topic>-value".
+const SchemaIDHeader = "ticdc-json-schema-id"
+
+// BatchEncoder wraps the canal-json or open protocol encoder. It registers
+// the JSON Schemas of the messages for the topics, validates the outgoing
+// messages against the schemas, and references the schemas by the
+// SchemaIDHeader. The payloads are not changed, so the consumers which
+// don't use the registry are not affected.
+type BatchEncoder struct {
+	inner codec.EventBatchEncoder
+	// probe encodes each row alone, so that the row is validated before it's
+	// appended to the batch of the inner encoder.
+	probe      codec.EventBatchEncoder
+	protocol   config.Protocol
+	terminator []byte
+	schema     *schema
+	schemaText string
+	tables     *tableSchemaCache
+	registry   *SchemaRegistry
+
+	// schemaIDs are the registry IDs of the schemas of the rows appended
+	// since the last Build, in order.
+	schemaIDs []int
+}
+
+// EncodeCheckpointEvent implements the EventBatchEncoder interface. The
+// checkpoint messages are broadcast to all the topics, so they are validated
+// but not stamped.
+func (e *BatchEncoder) EncodeCheckpointEvent(ts uint64) (*common.Message, error) {
+	message, err := e.inner.EncodeCheckpointEvent(ts)
+	if err != nil || message == nil {
+		return message, err
+	}
+	if err := e.validateMessage(e.schema, message); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return message, nil
+}
+
+// AppendRowChangedEvent implements the EventBatchEncoder interface. The row
+// is validated against the schema of its table, and it's not appended if
+// it's invalid.
+func (e *BatchEncoder) AppendRowChangedEvent(
+	ctx context.Context,
+	topic string,
+	row *model.RowChangedEvent,
+	callback func(),
+) error {
+	table, err := e.tables.get(row)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := e.probe.AppendRowChangedEvent(ctx, topic, row, nil); err != nil {
+		return errors.Trace(err)
+	}
+	for _, message := range e.probe.Build() {
+		if err := e.validateMessage(table.schema, message); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	schemaID, err := e.registry.getCachedOrRegister(
+		ctx, tableSubject(topic, row.Table), table.text)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := e.inner.AppendRowChangedEvent(ctx, topic, row, callback); err != nil {
+		return errors.Trace(err)
+	}
+	e.schemaIDs = append(e.schemaIDs, schemaID)
+	return nil
+}
+
+// EncodeDDLEvent implements the EventBatchEncoder interface, the message is
+// validated but not stamped since the topic is unknown.
+func (e *BatchEncoder) EncodeDDLEvent(ddl *model.DDLEvent) (*common.Message, error) {
+	message, err := e.inner.EncodeDDLEvent(ddl)
+	if err != nil || message == nil {
+		return message, err
+	}
+	if err := e.validateMessage(e.schema, message); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return message, nil
+}
+
+// EncodeDDLEventForTopic implements the codec.DDLEventTopicEncoder interface.
+func (e *BatchEncoder) EncodeDDLEventForTopic(
+	ctx context.Context, topic string, ddl *model.DDLEvent,
+) (*common.Message, error) {
+	message, err := e.EncodeDDLEvent(ddl)
+	if err != nil || message == nil {
+		return message, err
+	}
+	schemaID, err := e.registry.getCachedOrRegister(ctx, valueSubject(topic), e.schemaText)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	stampSchemaID(message, strconv.Itoa(schemaID))
+	return message, nil
+}
+
+// Build implements the EventBatchEncoder interface, the messages are stamped
+// with the registry IDs of the schemas of their rows.
+func (e *BatchEncoder) Build() []*common.Message {
+	messages := e.inner.Build()
+	schemaIDs := e.schemaIDs
+	for _, message := range messages {
+		n := 1
+		if e.protocol != config.ProtocolCanalJSON {
+			events, err := splitOpenProtocolEvents(message.Value)
+			if err != nil {
+				log.Panic("invalid open protocol message, please report a bug",
+					zap.Error(err))
+			}
+			n = len(events)
+		}
+		if n > len(schemaIDs) {
+			log.Panic("more rows than appended in the messages, please report a bug",
+				zap.Int("rows", n), zap.Int("schemaIDs", len(schemaIDs)))
+		}
+		ids := make([]string, 0, n)
+		for _, id := range schemaIDs[:n] {
+			ids = append(ids, strconv.Itoa(id))
+		}
+		schemaIDs = schemaIDs[n:]
+		stampSchemaID(message, strings.Join(ids, ","))
+	}
+	e.schemaIDs = e.schemaIDs[:0]
+	return messages
+}
+
+// validateMessage validates the value of the message against the schema.
+// The value of an open protocol message is a batch of the length-prefixed
+// events, and the value of a canal-json message may end with the terminator.
+func (e *BatchEncoder) validateMessage(s *schema, message *common.Message) error {
+	if e.protocol == config.ProtocolCanalJSON {
+		return s.validateJSON(bytes.TrimSuffix(message.Value, e.terminator))
+	}
+	events, err := splitOpenProtocolEvents(message.Value)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, event := range events {
+		if err := s.validateJSON(event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// splitOpenProtocolEvents splits the value of an open protocol message into
+// the events, the empty values of the resolved events are skipped.
+func splitOpenProtocolEvents(value []byte) ([][]byte, error) {
+	var events [][]byte
+	for len(value) > 0 {
+		if len(value) < 8 {
+			return nil, cerror.ErrJSONSchemaValidateFailed.GenWithStack(
+				"truncated length of the event in the open protocol message")
+		}
+		length := binary.BigEndian.Uint64(value[:8])
+		value = value[8:]
+		if uint64(len(value)) < length {
+			return nil, cerror.ErrJSONSchemaValidateFailed.GenWithStack(
+				"truncated event in the open protocol message")
+		}
+		if length > 0 {
+			events = append(events, value[:length])
+		}
+		value = value[length:]
+	}
+	return events, nil
+}
+
+func stampSchemaID(message *common.Message, schemaIDs string) {
+	message.Headers = append(message.Headers, common.MessageHeader{
+		Key:   SchemaIDHeader,
+		Value: []byte(schemaIDs),
+	})
+}
+
+type batchEncoderBuilder struct {
+	inner      codec.EncoderBuilder
+	protocol   config.Protocol
+	terminator []byte
+	schema     *schema
+	schemaText string
+	tables     *tableSchemaCache
+	registry   *SchemaRegistry
+}
+
+// NewBatchEncoderBuilder wraps the builder of the canal-json or open
+// protocol encoders, so that the encoders register and validate the JSON
+// Schemas in the schema registry of the config. The builder is returned
+// as it is if the registry is not configured.
+func NewBatchEncoderBuilder(
+	inner codec.EncoderBuilder, c *common.Config,
+) (codec.EncoderBuilder, error) {
+	if c.AvroSchemaRegistry == "" {
+		return inner, nil
+	}
+	schemaText := schemaOfProtocol(c.Protocol)
+	if schemaText == "" {
+		return nil, cerror.ErrCodecInvalidConfig.GenWithStack(
+			"JSON schema is not supported by the protocol %s", c.Protocol)
+	}
+	s, err := parseSchema(schemaText)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	registry, err := NewSchemaRegistry(c.AvroSchemaRegistry)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &batchEncoderBuilder{
+		inner:      inner,
+		protocol:   c.Protocol,
+		terminator: []byte(c.Terminator),
+		schema:     s,
+		schemaText: schemaText,
+		tables:     newTableSchemaCache(c.Protocol),
+		registry:   registry,
+	}, nil
+}
+
+// Build a JSON Schema BatchEncoder.
+func (b *batchEncoderBuilder) Build() codec.EventBatchEncoder {
+	return &BatchEncoder{
+		inner:      b.inner.Build(),
+		probe:      b.inner.Build(),
+		protocol:   b.protocol,
+		terminator: b.terminator,
+		schema:     b.schema,
+		schemaText: b.schemaText,
+		tables:     b.tables,
+		registry:   b.registry,
+	}
+}
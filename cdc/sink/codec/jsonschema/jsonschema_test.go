@@ -0,0 +1,290 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonschema
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	timodel "github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sink/codec"
+	"github.com/pingcap/tiflow/cdc/sink/codec/canal"
+	"github.com/pingcap/tiflow/cdc/sink/codec/common"
+	"github.com/pingcap/tiflow/cdc/sink/codec/open"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/stretchr/testify/require"
+)
+
+// newMockRegistry starts a schema registry which allocates an ID for each
+// distinct schema, and records the registered subjects.
+func newMockRegistry(t *testing.T) (*httptest.Server, func() []string) {
+	var (
+		mu       sync.Mutex
+		ids      = make(map[string]int)
+		subjects []string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		var req registerRequest
+		if r.Method != http.MethodPost || !strings.HasSuffix(r.URL.Path, "/versions") ||
+			json.NewDecoder(r.Body).Decode(&req) != nil || req.SchemaType != schemaType {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			return
+		}
+		if _, err := parseSchema(req.Schema); err != nil {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			return
+		}
+		id, ok := ids[req.Schema]
+		if !ok {
+			id = len(ids) + 1
+			ids[req.Schema] = id
+		}
+		subjects = append(subjects, strings.TrimSuffix(
+			strings.TrimPrefix(r.URL.Path, "/subjects/"), "/versions"))
+		_ = json.NewEncoder(w).Encode(&registerResponse{ID: id})
+	}))
+	t.Cleanup(server.Close)
+	return server, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string{}, subjects...)
+	}
+}
+
+func newTestRow(id int64) *model.RowChangedEvent {
+	return &model.RowChangedEvent{
+		CommitTs: 417318403368288260,
+		Table:    &model.TableName{Schema: "test", Table: "t"},
+		Columns: []*model.Column{
+			{
+				Name: "id", Type: mysql.TypeLong, Value: id,
+				Flag: model.HandleKeyFlag | model.PrimaryKeyFlag,
+			},
+			{Name: "name", Type: mysql.TypeVarchar, Value: []byte("a")},
+			{Name: "note", Type: mysql.TypeVarchar, Value: nil, Flag: model.NullableFlag},
+		},
+	}
+}
+
+func newTestDDL() *model.DDLEvent {
+	return &model.DDLEvent{
+		CommitTs: 417318403368288260,
+		TableInfo: &model.TableInfo{
+			TableName: model.TableName{Schema: "test", Table: "t"},
+		},
+		Query: "ALTER TABLE test.t ADD COLUMN age INT",
+		Type:  timodel.ActionAddColumn,
+	}
+}
+
+func schemaIDOf(t *testing.T, message *common.Message) string {
+	for _, header := range message.Headers {
+		if header.Key == SchemaIDHeader {
+			return string(header.Value)
+		}
+	}
+	t.Fatalf("no schema ID header in the message")
+	return ""
+}
+
+func TestValidateCanalJSON(t *testing.T) {
+	t.Parallel()
+
+	s, err := parseSchema(canalJSONSchema)
+	require.Nil(t, err)
+
+	valid := `{"id":0,"database":"test","table":"t","pkNames":["id"],"isDdl":false,` +
+		`"type":"INSERT","es":1,"ts":2,"sql":"","sqlType":{"id":4},` +
+		`"mysqlType":{"id":"int"},"data":[{"id":"1","note":null}],"old":null,` +
+		`"_tidb":{"commitTs":417318403368288260}}`
+	require.Nil(t, s.validateJSON([]byte(valid)))
+
+	err = s.validateJSON([]byte(`{"id":0}`))
+	require.Regexp(t, `\$\.database is required`, err)
+	err = s.validateJSON([]byte(strings.Replace(valid, `"id":"1"`, `"id":1`, 1)))
+	require.Regexp(t, `\$\.data\[0\]\.id is integer, which should be string or null`, err)
+	err = s.validateJSON([]byte(strings.Replace(valid, `"es":1`, `"es":1.5`, 1)))
+	require.Regexp(t, `\$\.es is number, which should be integer`, err)
+	err = s.validateJSON([]byte(valid + `{}`))
+	require.Regexp(t, "unexpected data after the JSON document", err)
+	err = s.validateJSON([]byte(valid[:10]))
+	require.Regexp(t, "ErrJSONSchemaValidateFailed", err)
+}
+
+func TestValidateOpenProtocol(t *testing.T) {
+	t.Parallel()
+
+	s, err := parseSchema(openProtocolSchema)
+	require.Nil(t, err)
+
+	require.Nil(t, s.validateJSON([]byte(`{"u":{"id":{"t":3,"h":true,"f":11,"v":1}}}`)))
+	require.Nil(t, s.validateJSON([]byte(`{"q":"DROP TABLE t","t":4}`)))
+	err = s.validateJSON([]byte(`{"d":{"id":{"t":3,"v":1}}}`))
+	require.Regexp(t, `\$\.d\.id\.f is required`, err)
+	err = s.validateJSON([]byte(`{"p":{"id":{"t":3,"h":1,"f":11,"v":1}}}`))
+	require.Regexp(t, `\$\.p\.id\.h is integer, which should be boolean`, err)
+
+	_, err = parseSchema(`{"properties":{"a":{"$ref":"#/definitions/b"}}}`)
+	require.Regexp(t, "undefined \\$ref", err)
+}
+
+func TestNoRegistry(t *testing.T) {
+	t.Parallel()
+
+	cfg := common.NewConfig(config.ProtocolCanalJSON)
+	inner := canal.NewJSONBatchEncoderBuilder(cfg)
+	builder, err := NewBatchEncoderBuilder(inner, cfg)
+	require.Nil(t, err)
+	require.Equal(t, inner, builder)
+
+	cfg = common.NewConfig(config.ProtocolCsv)
+	cfg.AvroSchemaRegistry = "http://127.0.0.1:8081"
+	_, err = NewBatchEncoderBuilder(inner, cfg)
+	require.Regexp(t, "JSON schema is not supported", err)
+}
+
+func TestCanalJSONEncoder(t *testing.T) {
+	t.Parallel()
+
+	server, subjects := newMockRegistry(t)
+	cfg := common.NewConfig(config.ProtocolCanalJSON)
+	cfg.AvroSchemaRegistry = server.URL
+	cfg.EnableTiDBExtension = true
+	cfg.Terminator = "\r\n"
+	builder, err := NewBatchEncoderBuilder(canal.NewJSONBatchEncoderBuilder(cfg), cfg)
+	require.Nil(t, err)
+	encoder := builder.Build()
+
+	ctx := context.Background()
+	for i := int64(1); i <= 2; i++ {
+		require.Nil(t, encoder.AppendRowChangedEvent(ctx, "topic1", newTestRow(i), nil))
+	}
+	messages := encoder.Build()
+	require.Len(t, messages, 2)
+	for _, message := range messages {
+		require.Equal(t, "1", schemaIDOf(t, message))
+	}
+	require.Equal(t, []string{"topic1-test.t"}, subjects())
+
+	message, err := encoder.(codec.DDLEventTopicEncoder).EncodeDDLEventForTopic(ctx, "topic2", newTestDDL())
+	require.Nil(t, err)
+	require.Equal(t, "2", schemaIDOf(t, message))
+	require.Equal(t, []string{"topic1-test.t", "topic2-value"}, subjects())
+
+	message, err = encoder.EncodeCheckpointEvent(417318403368288260)
+	require.Nil(t, err)
+	require.NotNil(t, message)
+	require.Empty(t, message.Headers)
+}
+
+func TestOpenProtocolEncoder(t *testing.T) {
+	t.Parallel()
+
+	server, subjects := newMockRegistry(t)
+	cfg := common.NewConfig(config.ProtocolOpen)
+	cfg.AvroSchemaRegistry = server.URL
+	builder, err := NewBatchEncoderBuilder(open.NewBatchEncoderBuilder(cfg), cfg)
+	require.Nil(t, err)
+	encoder := builder.Build()
+
+	ctx := context.Background()
+	for i := int64(1); i <= 3; i++ {
+		require.Nil(t, encoder.AppendRowChangedEvent(ctx, "topic", newTestRow(i), nil))
+	}
+	messages := encoder.Build()
+	require.Len(t, messages, 1)
+	require.Equal(t, 3, messages[0].GetRowsCount())
+	require.Equal(t, "1,1,1", schemaIDOf(t, messages[0]))
+
+	message, err := encoder.(codec.DDLEventTopicEncoder).EncodeDDLEventForTopic(ctx, "topic", newTestDDL())
+	require.Nil(t, err)
+	require.Equal(t, "2", schemaIDOf(t, message))
+	require.Equal(t, []string{"topic-test.t", "topic-value"}, subjects())
+
+	_, err = encoder.EncodeCheckpointEvent(417318403368288260)
+	require.Nil(t, err)
+}
+
+func TestInvalidRowRejected(t *testing.T) {
+	t.Parallel()
+
+	server, subjects := newMockRegistry(t)
+	cfg := common.NewConfig(config.ProtocolOpen)
+	cfg.AvroSchemaRegistry = server.URL
+	builder, err := NewBatchEncoderBuilder(open.NewBatchEncoderBuilder(cfg), cfg)
+	require.Nil(t, err)
+	encoder := builder.Build()
+
+	ctx := context.Background()
+	require.Nil(t, encoder.AppendRowChangedEvent(ctx, "topic", newTestRow(1), nil))
+	// the value of the INT column is a string, which violates the schema of
+	// the table, so the row is rejected and not appended to the batch.
+	row := newTestRow(2)
+	row.Columns[0].Value = "2"
+	callbacks := 0
+	err = encoder.AppendRowChangedEvent(ctx, "topic", row, func() { callbacks++ })
+	require.Regexp(t, `\$\.u\.id\.v is string, which should be integer`, err)
+
+	messages := encoder.Build()
+	require.Len(t, messages, 1)
+	require.Equal(t, 1, messages[0].GetRowsCount())
+	require.Equal(t, "1", schemaIDOf(t, messages[0]))
+	require.Equal(t, []string{"topic-test.t"}, subjects())
+	require.Equal(t, 0, callbacks)
+}
+
+func TestTableSchema(t *testing.T) {
+	t.Parallel()
+
+	cache := newTableSchemaCache(config.ProtocolCanalJSON)
+	row := newTestRow(1)
+	row.TableInfo = &model.TableInfo{Version: 1}
+	s, err := cache.get(row)
+	require.Nil(t, err)
+
+	valid := `{"id":1,"database":"test","table":"t","pkNames":["id"],"isDdl":false,` +
+		`"type":"INSERT","es":1,"ts":2,"sql":"","sqlType":{"id":4},` +
+		`"mysqlType":{"id":"int"},"data":[{"id":"1","name":"a","note":null}],"old":null}`
+	require.Nil(t, s.schema.validateJSON([]byte(valid)))
+	err = s.schema.validateJSON([]byte(strings.Replace(valid, `"name":"a"`, `"name":null`, 1)))
+	require.Regexp(t, `\$\.data\[0\]\.name is null, which should be string`, err)
+	err = s.schema.validateJSON([]byte(strings.Replace(valid, `"table":"t"`, `"table":"t2"`, 1)))
+	require.Regexp(t, `\$\.table is "t2", which should be one of \["t"\]`, err)
+
+	// the schema is cached until the table version changes.
+	cached, err := cache.get(newTestRow(2))
+	require.Nil(t, err)
+	require.NotEqual(t, s, cached)
+	row.Columns = append(row.Columns, &model.Column{
+		Name: "age", Type: mysql.TypeLong, Value: int64(1),
+	})
+	row.TableInfo = &model.TableInfo{Version: 2}
+	s2, err := cache.get(row)
+	require.Nil(t, err)
+	require.NotEqual(t, s.text, s2.text)
+	err = s2.schema.validateJSON([]byte(strings.Replace(valid, `"note":null`, `"age":null`, 1)))
+	require.Regexp(t, `\$\.data\[0\]\.age is null, which should be string`, err)
+	s3, err := cache.get(row)
+	require.Nil(t, err)
+	require.Equal(t, s2, s3)
+}
@@ -0,0 +1,438 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonschema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/config"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+)
+
+// canalJSONSchema is the JSON Schema of the canal-json messages, including
+// the DDL messages and the watermark messages of the TiDB extension.
+const canalJSONSchema = `{
+  "$schema": "http://json-schema.org/draft-07/schema#",
+  "title": "TiCDC canal-json message",
+  "type": "object",
+  "properties": {
+    "id": {"type": "integer"},
+    "database": {"type": "string"},
+    "table": {"type": "string"},
+    "pkNames": {"type": ["array", "null"], "items": {"type": "string"}},
+    "isDdl": {"type": "boolean"},
+    "type": {"type": "string"},
+    "es": {"type": "integer"},
+    "ts": {"type": "integer"},
+    "sql": {"type": "string"},
+    "sqlType": {"type": ["object", "null"], "additionalProperties": {"type": "integer"}},
+    "mysqlType": {"type": ["object", "null"], "additionalProperties": {"type": "string"}},
+    "data": {
+      "type": ["array", "null"],
+      "items": {"type": "object", "additionalProperties": {"type": ["string", "null"]}}
+    },
+    "old": {
+      "type": ["array", "null"],
+      "items": {"type": "object", "additionalProperties": {"type": ["string", "null"]}}
+    },
+    "_tidb": {
+      "type": ["object", "null"],
+      "properties": {
+        "commitTs": {"type": "integer"},
+        "watermarkTs": {"type": "integer"}
+      }
+    }
+  },
+  "required": [
+    "id", "database", "table", "pkNames", "isDdl", "type",
+    "es", "ts", "sql", "sqlType", "mysqlType", "data", "old"
+  ]
+}`
+
+// openProtocolSchema is the JSON Schema of the events in the values of the
+// open protocol messages. A row event has the columns in "u", "p" or "d",
+// and a DDL event has the query in "q" and the action type in "t".
+const openProtocolSchema = `{
+  "$schema": "http://json-schema.org/draft-07/schema#",
+  "title": "TiCDC open protocol event",
+  "type": "object",
+  "properties": {
+    "u": {"$ref": "#/definitions/columns"},
+    "p": {"$ref": "#/definitions/columns"},
+    "d": {"$ref": "#/definitions/columns"},
+    "q": {"type": "string"},
+    "t": {"type": "integer"}
+  },
+  "definitions": {
+    "columns": {
+      "type": "object",
+      "additionalProperties": {
+        "type": "object",
+        "properties": {
+          "t": {"type": "integer"},
+          "h": {"type": "boolean"},
+          "f": {"type": "integer"},
+          "v": {}
+        },
+        "required": ["t", "f", "v"]
+      }
+    }
+  }
+}`
+
+// schemaTypes is the "type" keyword, which is a type name or an array of
+// type names.
+type schemaTypes []string
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (t *schemaTypes) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		*t = schemaTypes{name}
+		return nil
+	}
+	var names []string
+	if err := json.Unmarshal(data, &names); err != nil {
+		return errors.Trace(err)
+	}
+	*t = names
+	return nil
+}
+
+// schema is the subset of the JSON Schema keywords used by the schemas of
+// the messages, the "$ref"s can only refer to the definitions of the root.
+type schema struct {
+	Ref                  string             `json:"$ref"`
+	Types                schemaTypes        `json:"type"`
+	Enum                 []interface{}      `json:"enum"`
+	Properties           map[string]*schema `json:"properties"`
+	Required             []string           `json:"required"`
+	Items                *schema            `json:"items"`
+	AdditionalProperties *schema            `json:"additionalProperties"`
+	Definitions          map[string]*schema `json:"definitions"`
+}
+
+const definitionsRefPrefix = "#/definitions/"
+
+// parseSchema parses the schema and resolves its "$ref"s.
+func parseSchema(text string) (*schema, error) {
+	s := &schema{}
+	decoder := json.NewDecoder(strings.NewReader(text))
+	decoder.UseNumber()
+	if err := decoder.Decode(s); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := s.resolve(s.Definitions); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return s, nil
+}
+
+func (s *schema) resolve(definitions map[string]*schema) error {
+	if s.Ref != "" {
+		if !strings.HasPrefix(s.Ref, definitionsRefPrefix) {
+			return errors.Errorf("unsupported $ref %s", s.Ref)
+		}
+		def, ok := definitions[strings.TrimPrefix(s.Ref, definitionsRefPrefix)]
+		if !ok {
+			return errors.Errorf("undefined $ref %s", s.Ref)
+		}
+		*s = *def
+		return s.resolve(definitions)
+	}
+	for _, child := range s.children() {
+		if err := child.resolve(definitions); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *schema) children() []*schema {
+	children := make([]*schema, 0, len(s.Properties)+len(s.Definitions)+2)
+	for _, child := range s.Properties {
+		children = append(children, child)
+	}
+	for _, child := range s.Definitions {
+		children = append(children, child)
+	}
+	if s.Items != nil {
+		children = append(children, s.Items)
+	}
+	if s.AdditionalProperties != nil {
+		children = append(children, s.AdditionalProperties)
+	}
+	return children
+}
+
+// validateJSON validates the JSON document against the schema.
+func (s *schema) validateJSON(data []byte) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var v interface{}
+	if err := decoder.Decode(&v); err != nil {
+		return cerror.WrapError(cerror.ErrJSONSchemaValidateFailed, err)
+	}
+	if decoder.More() {
+		return cerror.ErrJSONSchemaValidateFailed.GenWithStack(
+			"unexpected data after the JSON document")
+	}
+	return s.validate("$", v)
+}
+
+func (s *schema) validate(path string, v interface{}) error {
+	if len(s.Types) > 0 && !s.matchType(v) {
+		return cerror.ErrJSONSchemaValidateFailed.GenWithStack(
+			"%s is %s, which should be %s", path, typeOf(v), strings.Join(s.Types, " or "))
+	}
+	if len(s.Enum) > 0 && !s.matchEnum(v) {
+		value, _ := json.Marshal(v)
+		enum, _ := json.Marshal(s.Enum)
+		return cerror.ErrJSONSchemaValidateFailed.GenWithStack(
+			"%s is %s, which should be one of %s", path, value, enum)
+	}
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for _, name := range s.Required {
+			if _, ok := v[name]; !ok {
+				return cerror.ErrJSONSchemaValidateFailed.GenWithStack(
+					"%s.%s is required", path, name)
+			}
+		}
+		names := make([]string, 0, len(v))
+		for name := range v {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			child, ok := s.Properties[name]
+			if !ok {
+				child = s.AdditionalProperties
+			}
+			if child == nil {
+				continue
+			}
+			if err := child.validate(path+"."+name, v[name]); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		if s.Items == nil {
+			return nil
+		}
+		for i, item := range v {
+			if err := s.Items.validate(fmt.Sprintf("%s[%d]", path, i), item); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (s *schema) matchType(v interface{}) bool {
+	tp := typeOf(v)
+	for _, t := range s.Types {
+		if t == tp || (t == "number" && tp == "integer") {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *schema) matchEnum(v interface{}) bool {
+	for _, e := range s.Enum {
+		if reflect.DeepEqual(e, v) {
+			return true
+		}
+	}
+	return false
+}
+
+// typeOf returns the JSON Schema type of a value decoded with UseNumber.
+func typeOf(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case json.Number:
+		if strings.ContainsAny(v.String(), ".eE") {
+			return "number"
+		}
+		return "integer"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	}
+	return fmt.Sprintf("%T", v)
+}
+
+// schemaOfProtocol returns the JSON Schema of the protocol, it returns an
+// empty text if the protocol is not supported.
+func schemaOfProtocol(protocol config.Protocol) string {
+	switch protocol {
+	case config.ProtocolCanalJSON:
+		return canalJSONSchema
+	case config.ProtocolDefault, config.ProtocolOpen:
+		return openProtocolSchema
+	}
+	return ""
+}
+
+// tableSchema is the JSON Schema of the row messages of a table version.
+type tableSchema struct {
+	version uint64
+	schema  *schema
+	text    string
+}
+
+// tableSchemaCache caches the schemas of the latest versions of the tables,
+// it's shared by the encoders of a builder.
+type tableSchemaCache struct {
+	protocol config.Protocol
+
+	mu      sync.Mutex
+	schemas map[model.TableName]*tableSchema
+}
+
+func newTableSchemaCache(protocol config.Protocol) *tableSchemaCache {
+	return &tableSchemaCache{
+		protocol: protocol,
+		schemas:  make(map[model.TableName]*tableSchema),
+	}
+}
+
+// get returns the schema of the table version of the row, the schema of
+// the previous version of the table is replaced.
+func (c *tableSchemaCache) get(row *model.RowChangedEvent) (*tableSchema, error) {
+	var version uint64
+	if row.TableInfo != nil {
+		version = row.TableInfo.Version
+	}
+	name := model.TableName{Schema: row.Table.Schema, Table: row.Table.Table}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if s, ok := c.schemas[name]; ok && s.version == version {
+		return s, nil
+	}
+	text, err := rowSchemaOf(c.protocol, row)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	parsed, err := parseSchema(text)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	s := &tableSchema{version: version, schema: parsed, text: text}
+	c.schemas[name] = s
+	return s, nil
+}
+
+// rowSchemaOf returns the JSON Schema of the row messages of the table of
+// the row, which types the columns of the table by their MySQL types on
+// top of the schema of the protocol. The columns are not required and the
+// other columns are allowed, so that the schemas of a table evolve
+// compatibly in the registry when columns are added or dropped.
+func rowSchemaOf(protocol config.Protocol, row *model.RowChangedEvent) (string, error) {
+	columns := row.Columns
+	if len(columns) == 0 {
+		columns = row.PreColumns
+	}
+	var root map[string]interface{}
+	if err := json.Unmarshal([]byte(schemaOfProtocol(protocol)), &root); err != nil {
+		return "", errors.Trace(err)
+	}
+	root["title"] = fmt.Sprintf("%s of %s", root["title"], row.Table.QuoteString())
+
+	properties := make(map[string]interface{}, len(columns))
+	switch protocol {
+	case config.ProtocolCanalJSON:
+		// all the values are formatted as the strings by canal-json.
+		for _, col := range columns {
+			if col != nil {
+				properties[col.Name] = map[string]interface{}{
+					"type": jsonTypesOf(col, "string"),
+				}
+			}
+		}
+		rootProperties := root["properties"].(map[string]interface{})
+		rootProperties["database"] = map[string]interface{}{
+			"type": "string", "enum": []string{row.Table.Schema},
+		}
+		rootProperties["table"] = map[string]interface{}{
+			"type": "string", "enum": []string{row.Table.Table},
+		}
+		for _, name := range []string{"data", "old"} {
+			items := rootProperties[name].(map[string]interface{})["items"]
+			items.(map[string]interface{})["properties"] = properties
+		}
+	default:
+		for _, col := range columns {
+			if col != nil {
+				properties[col.Name] = map[string]interface{}{
+					"type": "object",
+					"properties": map[string]interface{}{
+						"t": map[string]interface{}{"type": "integer", "enum": []int{int(col.Type)}},
+						"h": map[string]interface{}{"type": "boolean"},
+						"f": map[string]interface{}{"type": "integer"},
+						"v": map[string]interface{}{"type": jsonTypesOf(col, openProtocolTypeOf(col.Type))},
+					},
+					"required": []string{"t", "f", "v"},
+				}
+			}
+		}
+		definitions := root["definitions"].(map[string]interface{})
+		definitions["columns"].(map[string]interface{})["properties"] = properties
+	}
+	text, err := json.Marshal(root)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return string(text), nil
+}
+
+// jsonTypesOf returns the JSON Schema types of the values of the column, a
+// nullable column may be null.
+func jsonTypesOf(col *model.Column, tp string) []string {
+	if col.Flag.IsNullable() {
+		return []string{tp, "null"}
+	}
+	return []string{tp}
+}
+
+// openProtocolTypeOf returns the JSON Schema type of the values of the
+// MySQL type in the open protocol, which encodes the values as they are
+// decoded from TiKV.
+func openProtocolTypeOf(tp byte) string {
+	switch tp {
+	case mysql.TypeTiny, mysql.TypeShort, mysql.TypeInt24, mysql.TypeLong,
+		mysql.TypeLonglong, mysql.TypeYear, mysql.TypeBit, mysql.TypeEnum, mysql.TypeSet:
+		return "integer"
+	case mysql.TypeFloat, mysql.TypeDouble:
+		return "number"
+	}
+	return "string"
+}
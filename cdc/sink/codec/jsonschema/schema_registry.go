@@ -0,0 +1,196 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonschema
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/cdc/model"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/httputil"
+	"github.com/pingcap/tiflow/pkg/retry"
+	"go.uber.org/zap"
+)
+
+const (
+	// schemaType is the type of the schemas in the schema registry.
+	schemaType = "JSON"
+	// valueSubjectSuffix is the suffix of the subjects of the message values
+	// by the TopicNameStrategy.
+	valueSubjectSuffix = "-value"
+	// recordSubjectSeparator separates the topic and the record name in the
+	// subjects by the TopicRecordNameStrategy.
+	recordSubjectSeparator = "-"
+
+	registryBackoffBaseDelayInMs = 500
+	registryBackoffMaxDelayInMs  = 30 * 1000
+	registryMaxTries             = 10
+)
+
+type registerRequest struct {
+	Schema     string `json:"schema"`
+	SchemaType string `json:"schemaType"`
+}
+
+type registerResponse struct {
+	ID int `json:"id"`
+}
+
+type registeredSchema struct {
+	text string
+	id   int
+}
+
+// SchemaRegistry registers the JSON Schemas of the messages to the
+// Confluent Schema Registry or Karapace. The schemas of the DDL messages
+// are registered to the subjects named by the TopicNameStrategy, and the
+// schemas of the row messages of the tables are registered to the subjects
+// named by the TopicRecordNameStrategy, whose record names are
+// "<schema>.<table>".
+type SchemaRegistry struct {
+	registryURL string
+	client      *httputil.Client
+
+	mu sync.Mutex
+	// registered are the latest schemas registered to the subjects.
+	registered map[string]registeredSchema
+}
+
+// NewSchemaRegistry creates a SchemaRegistry of the registry URL.
+func NewSchemaRegistry(registryURL string) (*SchemaRegistry, error) {
+	client, err := httputil.NewClient(nil)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &SchemaRegistry{
+		registryURL: strings.TrimRight(registryURL, "/"),
+		client:      client,
+		registered:  make(map[string]registeredSchema),
+	}, nil
+}
+
+// valueSubject returns the subject of the schemas of the DDL messages of
+// the topic.
+func valueSubject(topic string) string {
+	return topic + valueSubjectSuffix
+}
+
+// tableSubject returns the subject of the schemas of the row messages of
+// the table in the topic.
+func tableSubject(topic string, table *model.TableName) string {
+	return topic + recordSubjectSeparator + table.Schema + "." + table.Table
+}
+
+// getCachedOrRegister returns the registry ID of the schema of the subject,
+// the schema is registered the first time, and again when it changes.
+// Registering a schema registered before returns the same ID, so it's safe
+// to register again after the restarts.
+func (r *SchemaRegistry) getCachedOrRegister(
+	ctx context.Context, subject string, schemaText string,
+) (int, error) {
+	r.mu.Lock()
+	registered, ok := r.registered[subject]
+	r.mu.Unlock()
+	if ok && registered.text == schemaText {
+		return registered.id, nil
+	}
+
+	payload, err := json.Marshal(&registerRequest{Schema: schemaText, SchemaType: schemaType})
+	if err != nil {
+		return 0, cerror.WrapError(cerror.ErrJSONSchemaAPIError, err)
+	}
+	uri := r.registryURL + "/subjects/" + url.PathEscape(subject) + "/versions"
+	body, err := r.request(ctx, uri, payload)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	var resp registerResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return 0, cerror.WrapError(cerror.ErrJSONSchemaAPIError, err)
+	}
+	if resp.ID == 0 {
+		return 0, cerror.ErrJSONSchemaAPIError.GenWithStack(
+			"illegal schema ID %d returned from the registry", resp.ID)
+	}
+	log.Info("Registered JSON schema",
+		zap.String("subject", subject),
+		zap.Int("registryID", resp.ID))
+
+	r.mu.Lock()
+	r.registered[subject] = registeredSchema{text: schemaText, id: resp.ID}
+	r.mu.Unlock()
+	return resp.ID, nil
+}
+
+// request posts the payload to the registry, it's retried unless the
+// registry rejects it.
+func (r *SchemaRegistry) request(ctx context.Context, uri string, payload []byte) ([]byte, error) {
+	var body []byte
+	err := retry.Do(ctx, func() error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, uri, bytes.NewReader(payload))
+		if err != nil {
+			return errors.Trace(err)
+		}
+		req.Header.Add("Accept", "application/vnd.schemaregistry.v1+json, "+
+			"application/vnd.schemaregistry+json, application/json")
+		req.Header.Add("Content-Type", "application/vnd.schemaregistry.v1+json")
+		resp, err := r.client.Do(req)
+		if err != nil {
+			log.Warn("JSON schema registry request failed",
+				zap.String("uri", uri), zap.Error(err))
+			return cerror.WrapError(cerror.ErrJSONSchemaAPIError, err)
+		}
+		defer resp.Body.Close()
+		body, err = io.ReadAll(resp.Body)
+		if err != nil {
+			return cerror.WrapError(cerror.ErrJSONSchemaAPIError, err)
+		}
+		if resp.StatusCode/100 == 2 {
+			return nil
+		}
+		err = cerror.ErrJSONSchemaAPIError.GenWithStack(
+			"the registry responded to POST %s with HTTP status %d: %s",
+			uri, resp.StatusCode, body)
+		// The 4xx errors like 409 for the incompatible schemas are not
+		// recoverable.
+		if resp.StatusCode/100 == 4 {
+			return backoffPermanent{err}
+		}
+		return err
+	}, retry.WithBackoffBaseDelay(registryBackoffBaseDelayInMs),
+		retry.WithBackoffMaxDelay(registryBackoffMaxDelayInMs),
+		retry.WithMaxTries(registryMaxTries),
+		retry.WithIsRetryableErr(func(err error) bool {
+			_, ok := err.(backoffPermanent)
+			return !ok
+		}))
+	if p, ok := err.(backoffPermanent); ok {
+		err = p.error
+	}
+	return body, err
+}
+
+// backoffPermanent marks an error not retryable.
+type backoffPermanent struct {
+	error
+}
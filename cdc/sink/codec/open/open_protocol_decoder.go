@@ -15,6 +15,7 @@ package open
 
 import (
 	"encoding/binary"
+	"fmt"
 
 	"github.com/pingcap/errors"
 	"github.com/pingcap/tiflow/cdc/model"
@@ -27,13 +28,17 @@ import (
 type BatchMixedDecoder struct {
 	mixedBytes []byte
 	nextKey    *internal.MessageKey
-	nextKeyLen uint64
+	strict     bool
 }
 
 // HasNext implements the EventBatchDecoder interface
 func (b *BatchMixedDecoder) HasNext() (model.MessageType, bool, error) {
-	if !b.hasNext() {
-		return 0, false, nil
+	if b.nextKey != nil {
+		return b.nextKey.Type, true, nil
+	}
+	hasNext, err := b.hasNext()
+	if err != nil || !hasNext {
+		return 0, false, err
 	}
 	if err := b.decodeNextKey(); err != nil {
 		return 0, false, err
@@ -48,12 +53,13 @@ func (b *BatchMixedDecoder) NextResolvedEvent() (uint64, error) {
 			return 0, err
 		}
 	}
-	b.mixedBytes = b.mixedBytes[b.nextKeyLen+8:]
 	if b.nextKey.Type != model.MessageTypeResolved {
 		return 0, cerror.ErrOpenProtocolCodecInvalidData.GenWithStack("not found resolved event message")
 	}
-	valueLen := binary.BigEndian.Uint64(b.mixedBytes[:8])
-	b.mixedBytes = b.mixedBytes[valueLen+8:]
+	// drop value bytes
+	if _, err := b.nextValue(); err != nil {
+		return 0, err
+	}
 	resolvedTs := b.nextKey.Ts
 	b.nextKey = nil
 	return resolvedTs, nil
@@ -66,15 +72,15 @@ func (b *BatchMixedDecoder) NextRowChangedEvent() (*model.RowChangedEvent, error
 			return nil, err
 		}
 	}
-	b.mixedBytes = b.mixedBytes[b.nextKeyLen+8:]
 	if b.nextKey.Type != model.MessageTypeRow {
 		return nil, cerror.ErrOpenProtocolCodecInvalidData.GenWithStack("not found row event message")
 	}
-	valueLen := binary.BigEndian.Uint64(b.mixedBytes[:8])
-	value := b.mixedBytes[8 : valueLen+8]
-	b.mixedBytes = b.mixedBytes[valueLen+8:]
+	value, err := b.nextValue()
+	if err != nil {
+		return nil, err
+	}
 	rowMsg := new(messageRow)
-	if err := rowMsg.decode(value); err != nil {
+	if err := rowMsg.decode(value, b.strict); err != nil {
 		return nil, errors.Trace(err)
 	}
 	rowEvent := msgToRowChange(b.nextKey, rowMsg)
@@ -89,15 +95,15 @@ func (b *BatchMixedDecoder) NextDDLEvent() (*model.DDLEvent, error) {
 			return nil, err
 		}
 	}
-	b.mixedBytes = b.mixedBytes[b.nextKeyLen+8:]
 	if b.nextKey.Type != model.MessageTypeDDL {
 		return nil, cerror.ErrOpenProtocolCodecInvalidData.GenWithStack("not found ddl event message")
 	}
-	valueLen := binary.BigEndian.Uint64(b.mixedBytes[:8])
-	value := b.mixedBytes[8 : valueLen+8]
-	b.mixedBytes = b.mixedBytes[valueLen+8:]
+	value, err := b.nextValue()
+	if err != nil {
+		return nil, err
+	}
 	ddlMsg := new(messageDDL)
-	if err := ddlMsg.decode(value); err != nil {
+	if err := ddlMsg.decode(value, b.strict); err != nil {
 		return nil, errors.Trace(err)
 	}
 	ddlEvent := msgToDDLEvent(b.nextKey, ddlMsg)
@@ -105,36 +111,54 @@ func (b *BatchMixedDecoder) NextDDLEvent() (*model.DDLEvent, error) {
 	return ddlEvent, nil
 }
 
-func (b *BatchMixedDecoder) hasNext() bool {
-	return len(b.mixedBytes) > 0
-}
-
 func (b *BatchMixedDecoder) decodeNextKey() error {
-	keyLen := binary.BigEndian.Uint64(b.mixedBytes[:8])
-	key := b.mixedBytes[8 : keyLen+8]
-	// drop value bytes
+	key, rest, err := readLengthPrefixed(b.mixedBytes, "key")
+	if err != nil {
+		return err
+	}
 	msgKey := new(internal.MessageKey)
-	err := msgKey.Decode(key)
+	if b.strict {
+		err = msgKey.DecodeStrict(key)
+	} else {
+		err = msgKey.Decode(key)
+	}
 	if err != nil {
 		return errors.Trace(err)
 	}
+	b.mixedBytes = rest
 	b.nextKey = msgKey
-	b.nextKeyLen = keyLen
 	return nil
 }
 
+func (b *BatchMixedDecoder) nextValue() ([]byte, error) {
+	value, rest, err := readLengthPrefixed(b.mixedBytes, "value")
+	if err != nil {
+		return nil, err
+	}
+	b.mixedBytes = rest
+	return value, nil
+}
+
+func (b *BatchMixedDecoder) hasNext() (bool, error) {
+	return len(b.mixedBytes) > 0, nil
+}
+
 // BatchDecoder decodes the byte of a batch into the original messages.
 type BatchDecoder struct {
 	keyBytes   []byte
 	valueBytes []byte
 	nextKey    *internal.MessageKey
-	nextKeyLen uint64
+	strict     bool
 }
 
 // HasNext implements the EventBatchDecoder interface
 func (b *BatchDecoder) HasNext() (model.MessageType, bool, error) {
-	if !b.hasNext() {
-		return 0, false, nil
+	if b.nextKey != nil {
+		return b.nextKey.Type, true, nil
+	}
+	hasNext, err := b.hasNext()
+	if err != nil || !hasNext {
+		return 0, false, err
 	}
 	if err := b.decodeNextKey(); err != nil {
 		return 0, false, err
@@ -149,12 +173,13 @@ func (b *BatchDecoder) NextResolvedEvent() (uint64, error) {
 			return 0, err
 		}
 	}
-	b.keyBytes = b.keyBytes[b.nextKeyLen+8:]
 	if b.nextKey.Type != model.MessageTypeResolved {
 		return 0, cerror.ErrOpenProtocolCodecInvalidData.GenWithStack("not found resolved event message")
 	}
-	valueLen := binary.BigEndian.Uint64(b.valueBytes[:8])
-	b.valueBytes = b.valueBytes[valueLen+8:]
+	// drop value bytes
+	if _, err := b.nextValue(); err != nil {
+		return 0, err
+	}
 	resolvedTs := b.nextKey.Ts
 	b.nextKey = nil
 	return resolvedTs, nil
@@ -167,15 +192,15 @@ func (b *BatchDecoder) NextRowChangedEvent() (*model.RowChangedEvent, error) {
 			return nil, err
 		}
 	}
-	b.keyBytes = b.keyBytes[b.nextKeyLen+8:]
 	if b.nextKey.Type != model.MessageTypeRow {
 		return nil, cerror.ErrOpenProtocolCodecInvalidData.GenWithStack("not found row event message")
 	}
-	valueLen := binary.BigEndian.Uint64(b.valueBytes[:8])
-	value := b.valueBytes[8 : valueLen+8]
-	b.valueBytes = b.valueBytes[valueLen+8:]
+	value, err := b.nextValue()
+	if err != nil {
+		return nil, err
+	}
 	rowMsg := new(messageRow)
-	if err := rowMsg.decode(value); err != nil {
+	if err := rowMsg.decode(value, b.strict); err != nil {
 		return nil, errors.Trace(err)
 	}
 	rowEvent := msgToRowChange(b.nextKey, rowMsg)
@@ -190,15 +215,15 @@ func (b *BatchDecoder) NextDDLEvent() (*model.DDLEvent, error) {
 			return nil, err
 		}
 	}
-	b.keyBytes = b.keyBytes[b.nextKeyLen+8:]
 	if b.nextKey.Type != model.MessageTypeDDL {
 		return nil, cerror.ErrOpenProtocolCodecInvalidData.GenWithStack("not found ddl event message")
 	}
-	valueLen := binary.BigEndian.Uint64(b.valueBytes[:8])
-	value := b.valueBytes[8 : valueLen+8]
-	b.valueBytes = b.valueBytes[valueLen+8:]
+	value, err := b.nextValue()
+	if err != nil {
+		return nil, err
+	}
 	ddlMsg := new(messageDDL)
-	if err := ddlMsg.decode(value); err != nil {
+	if err := ddlMsg.decode(value, b.strict); err != nil {
 		return nil, errors.Trace(err)
 	}
 	ddlEvent := msgToDDLEvent(b.nextKey, ddlMsg)
@@ -206,38 +231,83 @@ func (b *BatchDecoder) NextDDLEvent() (*model.DDLEvent, error) {
 	return ddlEvent, nil
 }
 
-func (b *BatchDecoder) hasNext() bool {
-	return len(b.keyBytes) > 0 && len(b.valueBytes) > 0
-}
-
 func (b *BatchDecoder) decodeNextKey() error {
-	keyLen := binary.BigEndian.Uint64(b.keyBytes[:8])
-	key := b.keyBytes[8 : keyLen+8]
+	key, rest, err := readLengthPrefixed(b.keyBytes, "key")
+	if err != nil {
+		return err
+	}
 	msgKey := new(internal.MessageKey)
-	err := msgKey.Decode(key)
+	if b.strict {
+		err = msgKey.DecodeStrict(key)
+	} else {
+		err = msgKey.Decode(key)
+	}
 	if err != nil {
 		return errors.Trace(err)
 	}
+	b.keyBytes = rest
 	b.nextKey = msgKey
-	b.nextKeyLen = keyLen
 	return nil
 }
 
+func (b *BatchDecoder) nextValue() ([]byte, error) {
+	value, rest, err := readLengthPrefixed(b.valueBytes, "value")
+	if err != nil {
+		return nil, err
+	}
+	b.valueBytes = rest
+	return value, nil
+}
+
+func (b *BatchDecoder) hasNext() (bool, error) {
+	hasKey, hasValue := len(b.keyBytes) > 0, len(b.valueBytes) > 0
+	if b.strict && hasKey != hasValue {
+		return false, cerror.ErrCodecStrictDecodeFailed.GenWithStackByArgs(
+			"the numbers of keys and values mismatch")
+	}
+	return hasKey && hasValue, nil
+}
+
+// readLengthPrefixed reads a payload prefixed by its 8 bytes length from data,
+// and returns the payload and the remaining bytes.
+func readLengthPrefixed(data []byte, name string) ([]byte, []byte, error) {
+	if len(data) < 8 {
+		return nil, nil, cerror.ErrCodecTruncatedPayload.GenWithStackByArgs(
+			fmt.Sprintf("%s length requires 8 bytes, but only %d bytes left", name, len(data)))
+	}
+	length := binary.BigEndian.Uint64(data[:8])
+	data = data[8:]
+	if length > uint64(len(data)) {
+		return nil, nil, cerror.ErrCodecTruncatedPayload.GenWithStackByArgs(
+			fmt.Sprintf("%s requires %d bytes, but only %d bytes left", name, length, len(data)))
+	}
+	return data[:length], data[length:], nil
+}
+
 // NewBatchDecoder creates a new BatchDecoder.
-func NewBatchDecoder(key []byte, value []byte) (codec.EventBatchDecoder, error) {
+func NewBatchDecoder(
+	key []byte, value []byte, opts ...codec.DecoderOption,
+) (codec.EventBatchDecoder, error) {
+	if len(key) < 8 {
+		return nil, cerror.ErrCodecTruncatedPayload.GenWithStackByArgs(
+			fmt.Sprintf("key format version requires 8 bytes, but only %d bytes left", len(key)))
+	}
 	version := binary.BigEndian.Uint64(key[:8])
 	key = key[8:]
 	if version != codec.BatchVersion1 {
 		return nil, cerror.ErrOpenProtocolCodecInvalidData.GenWithStack("unexpected key format version")
 	}
+	options := codec.NewDecoderOptions(opts...)
 	// if only decode one byte slice, we choose MixedDecoder
 	if len(key) > 0 && len(value) == 0 {
 		return &BatchMixedDecoder{
 			mixedBytes: key,
+			strict:     options.Strict,
 		}, nil
 	}
 	return &BatchDecoder{
 		keyBytes:   key,
 		valueBytes: value,
+		strict:     options.Strict,
 	}, nil
 }
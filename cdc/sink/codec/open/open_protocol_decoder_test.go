@@ -0,0 +1,155 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package open
+
+import (
+	"context"
+	"encoding/binary"
+	"testing"
+
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sink/codec"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func encodeTestMessages(t require.TestingT) [][2][]byte {
+	encoder := NewBatchEncoder()
+	err := encoder.AppendRowChangedEvent(context.Background(), "", &model.RowChangedEvent{
+		CommitTs: 1,
+		Table:    &model.TableName{Schema: "a", Table: "b"},
+		Columns: []*model.Column{{
+			Name:  "col1",
+			Type:  mysql.TypeVarchar,
+			Value: []byte("aa"),
+		}},
+	}, nil)
+	require.Nil(t, err)
+	var result [][2][]byte
+	for _, msg := range encoder.Build() {
+		result = append(result, [2][]byte{msg.Key, msg.Value})
+	}
+	msg, err := encoder.EncodeDDLEvent(&model.DDLEvent{
+		CommitTs: 2,
+		TableInfo: &model.TableInfo{
+			TableName: model.TableName{Schema: "a", Table: "b"},
+		},
+		Query: "create table a.b(col1 varchar(10))",
+	})
+	require.Nil(t, err)
+	result = append(result, [2][]byte{msg.Key, msg.Value})
+	msg, err = encoder.EncodeCheckpointEvent(3)
+	require.Nil(t, err)
+	result = append(result, [2][]byte{msg.Key, msg.Value})
+	return result
+}
+
+// decodeAll decodes all events in the message, and returns the first error.
+func decodeAll(key, value []byte, opts ...codec.DecoderOption) error {
+	decoder, err := NewBatchDecoder(key, value, opts...)
+	if err != nil {
+		return err
+	}
+	for {
+		tp, hasNext, err := decoder.HasNext()
+		if err != nil {
+			return err
+		}
+		if !hasNext {
+			return nil
+		}
+		switch tp {
+		case model.MessageTypeRow:
+			_, err = decoder.NextRowChangedEvent()
+		case model.MessageTypeDDL:
+			_, err = decoder.NextDDLEvent()
+		case model.MessageTypeResolved:
+			_, err = decoder.NextResolvedEvent()
+		default:
+			// skip the unknown event by decoding it as a resolved event.
+			_, err = decoder.NextResolvedEvent()
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func TestDecodeTruncatedPayload(t *testing.T) {
+	t.Parallel()
+
+	for _, msg := range encodeTestMessages(t) {
+		key, value := msg[0], msg[1]
+		require.Nil(t, decodeAll(key, value))
+
+		err := decodeAll(key[:4], value)
+		require.True(t, cerror.ErrCodecTruncatedPayload.Equal(err), err)
+		err = decodeAll(key[:len(key)-1], value)
+		require.True(t, cerror.ErrCodecTruncatedPayload.Equal(err), err)
+		err = decodeAll(key, value[:len(value)-1])
+		require.True(t, cerror.ErrCodecTruncatedPayload.Equal(err), err)
+	}
+}
+
+func TestDecodeStrict(t *testing.T) {
+	t.Parallel()
+
+	appendPayload := func(data []byte, payload string) []byte {
+		var length [8]byte
+		binary.BigEndian.PutUint64(length[:], uint64(len(payload)))
+		data = append(data, length[:]...)
+		return append(data, payload...)
+	}
+	version := make([]byte, 8)
+	binary.BigEndian.PutUint64(version, codec.BatchVersion1)
+
+	// unknown fields in the key
+	key := appendPayload(append([]byte{}, version...), `{"ts":1,"t":2,"unknown":1}`)
+	value := appendPayload(nil, `{}`)
+	require.Nil(t, decodeAll(key, value))
+	err := decodeAll(key, value, codec.WithStrictDecode(true))
+	require.True(t, cerror.ErrCodecStrictDecodeFailed.Equal(err), err)
+
+	// unknown fields in the value
+	key = appendPayload(append([]byte{}, version...), `{"ts":1,"scm":"a","tbl":"b","t":1}`)
+	value = appendPayload(nil, `{"u":{},"x":{}}`)
+	require.Nil(t, decodeAll(key, value))
+	err = decodeAll(key, value, codec.WithStrictDecode(true))
+	require.True(t, cerror.ErrCodecStrictDecodeFailed.Equal(err), err)
+
+	// trailing data in the value
+	value = appendPayload(nil, `{"u":{}} {}`)
+	require.Nil(t, decodeAll(key, value))
+	err = decodeAll(key, value, codec.WithStrictDecode(true))
+	require.True(t, cerror.ErrCodecStrictDecodeFailed.Equal(err), err)
+
+	// the numbers of keys and values mismatch
+	value = appendPayload(appendPayload(nil, `{"u":{}}`), `{"u":{}}`)
+	require.Nil(t, decodeAll(key, value))
+	err = decodeAll(key, value, codec.WithStrictDecode(true))
+	require.True(t, cerror.ErrCodecStrictDecodeFailed.Equal(err), err)
+}
+
+func FuzzOpenProtocolDecoder(f *testing.F) {
+	for _, msg := range encodeTestMessages(f) {
+		f.Add(msg[0], msg[1])
+		f.Add(append(append([]byte{}, msg[0]...), msg[1]...), []byte{})
+	}
+	f.Fuzz(func(t *testing.T, key []byte, value []byte) {
+		// The decoder must never panic on malformed messages.
+		_ = decodeAll(key, value)
+		_ = decodeAll(key, value, codec.WithStrictDecode(true))
+	})
+}
@@ -17,6 +17,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/binary"
+	"fmt"
 
 	"github.com/pingcap/errors"
 	"github.com/pingcap/log"
@@ -25,9 +26,14 @@ import (
 	"github.com/pingcap/tiflow/cdc/sink/codec/common"
 	"github.com/pingcap/tiflow/pkg/config"
 	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/warning"
 	"go.uber.org/zap"
 )
 
+// versionHeadLength is the length of the version head at the beginning of
+// the key of a batch.
+const versionHeadLength = 8
+
 // BatchEncoder encodes the events into the byte of a batch into.
 type BatchEncoder struct {
 	messageBuf   []*common.Message
@@ -37,6 +43,13 @@ type BatchEncoder struct {
 	// configs
 	MaxMessageBytes int
 	MaxBatchSize    int
+	// ChangefeedID is the changefeed the encoder belongs to, the warnings
+	// of it are recorded under the changefeed.
+	ChangefeedID model.ChangeFeedID
+
+	// sizeEstimator accounts the size of the messages after compressed,
+	// it's shared by the encoders built by the same builder.
+	sizeEstimator *common.SizeEstimator
 }
 
 // AppendRowChangedEvent implements the EventBatchEncoder interface
@@ -70,9 +83,17 @@ func (d *BatchEncoder) AppendRowChangedEvent(
 		return cerror.ErrOpenProtocolCodecRowTooLarge.GenWithStackByArgs()
 	}
 
+	// The producer limits the size of the messages before compressed, and
+	// the broker limits the size after compressed, the size of the batch
+	// must be within both of them.
+	var batchSize int
+	if len(d.messageBuf) != 0 {
+		batchSize = d.messageBuf[len(d.messageBuf)-1].Length() + len(key) + len(value) + 16
+	}
 	if len(d.messageBuf) == 0 ||
 		d.curBatchSize >= d.MaxBatchSize ||
-		d.messageBuf[len(d.messageBuf)-1].Length()+len(key)+len(value)+16 > d.MaxMessageBytes {
+		batchSize > d.MaxMessageBytes ||
+		d.sizeEstimator.Estimate(batchSize) > d.MaxMessageBytes {
 		// Before we create a new message, we should handle the previous callbacks.
 		d.tryBuildCallback()
 		versionHead := make([]byte, 8)
@@ -163,10 +184,68 @@ func (d *BatchEncoder) EncodeCheckpointEvent(ts uint64) (*common.Message, error)
 func (d *BatchEncoder) Build() (messages []*common.Message) {
 	d.tryBuildCallback()
 	ret := d.messageBuf
+	if d.sizeEstimator.Compressed() {
+		ret = make([]*common.Message, 0, len(d.messageBuf))
+		for _, msg := range d.messageBuf {
+			ret = append(ret, d.shrink(msg)...)
+		}
+	}
 	d.messageBuf = make([]*common.Message, 0)
 	return ret
 }
 
+// shrink verifies the size of the message after compressed, and splits it
+// into smaller ones if it's larger than MaxMessageBytes. The size estimator
+// learns from the messages measured, so the following batches are smaller.
+func (d *BatchEncoder) shrink(msg *common.Message) []*common.Message {
+	size := d.sizeEstimator.Verify(msg, d.MaxMessageBytes)
+	if size <= d.MaxMessageBytes {
+		return []*common.Message{msg}
+	}
+	if msg.GetRowsCount() <= 1 {
+		log.Warn("Single message is too large after compressed",
+			zap.Int("max-message-size", d.MaxMessageBytes), zap.Int("length", size),
+			zap.Stringp("schema", msg.Schema), zap.Stringp("table", msg.Table))
+		return []*common.Message{msg}
+	}
+	warning.Record(d.ChangefeedID, cerror.ErrOpenProtocolCodecBatchTooLarge,
+		fmt.Sprintf("the batch of %d rows is %d bytes after compressed, larger than max-message-bytes %d",
+			msg.GetRowsCount(), size, d.MaxMessageBytes))
+	first, second := splitMessage(msg)
+	return append(d.shrink(first), d.shrink(second)...)
+}
+
+// splitMessage splits a message of batched rows into two messages, each of
+// them has half of the rows. The callback is kept by the second one, since
+// it must be called after all the rows are sent.
+func splitMessage(msg *common.Message) (*common.Message, *common.Message) {
+	rows := msg.GetRowsCount()
+	half := rows / 2
+	keyOffset := entriesOffset(msg.Key, versionHeadLength, half)
+	valueOffset := entriesOffset(msg.Value, 0, half)
+
+	first := *msg
+	first.Key = msg.Key[:keyOffset:keyOffset]
+	first.Value = msg.Value[:valueOffset:valueOffset]
+	first.Callback = nil
+	first.SetRowsCount(half)
+
+	second := *msg
+	second.Key = append(append([]byte{}, msg.Key[:versionHeadLength]...), msg.Key[keyOffset:]...)
+	second.Value = msg.Value[valueOffset:]
+	second.SetRowsCount(rows - half)
+	return &first, &second
+}
+
+// entriesOffset returns the offset after n length-prefixed entries
+// starting from the offset.
+func entriesOffset(data []byte, offset int, n int) int {
+	for i := 0; i < n; i++ {
+		offset += 8 + int(binary.BigEndian.Uint64(data[offset:]))
+	}
+	return offset
+}
+
 // tryBuildCallback will collect all the callbacks into one message's callback.
 func (d *BatchEncoder) tryBuildCallback() {
 	if len(d.messageBuf) != 0 && len(d.callbackBuff) != 0 {
@@ -182,7 +261,8 @@ func (d *BatchEncoder) tryBuildCallback() {
 }
 
 type batchEncoderBuilder struct {
-	config *common.Config
+	config        *common.Config
+	sizeEstimator *common.SizeEstimator
 }
 
 // Build a BatchEncoder
@@ -190,13 +270,18 @@ func (b *batchEncoderBuilder) Build() codec.EventBatchEncoder {
 	encoder := NewBatchEncoder()
 	encoder.(*BatchEncoder).MaxMessageBytes = b.config.MaxMessageBytes
 	encoder.(*BatchEncoder).MaxBatchSize = b.config.MaxBatchSize
+	encoder.(*BatchEncoder).ChangefeedID = b.config.ChangefeedID
+	encoder.(*BatchEncoder).sizeEstimator = b.sizeEstimator
 
 	return encoder
 }
 
 // NewBatchEncoderBuilder creates an open-protocol batchEncoderBuilder.
 func NewBatchEncoderBuilder(config *common.Config) codec.EncoderBuilder {
-	return &batchEncoderBuilder{config: config}
+	return &batchEncoderBuilder{
+		config:        config,
+		sizeEstimator: common.NewSizeEstimator(config.Compression),
+	}
 }
 
 // NewBatchEncoder creates a new BatchEncoder.
@@ -15,10 +15,12 @@ package open
 
 import (
 	"context"
+	"math/rand"
 	"testing"
 
 	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sink/codec"
 	"github.com/pingcap/tiflow/cdc/sink/codec/common"
 	"github.com/pingcap/tiflow/cdc/sink/codec/internal"
 	"github.com/pingcap/tiflow/pkg/config"
@@ -206,5 +208,90 @@ func TestOpenProtocolBatchCodec(t *testing.T) {
 	config := common.NewConfig(config.ProtocolOpen).WithMaxMessageBytes(8192)
 	config.MaxBatchSize = 64
 	tester := internal.NewDefaultBatchTester()
-	tester.TestBatchCodec(t, NewBatchEncoderBuilder(config), NewBatchDecoder)
+	for _, strict := range []bool{false, true} {
+		tester.TestBatchCodec(t, NewBatchEncoderBuilder(config),
+			func(key []byte, value []byte) (codec.EventBatchDecoder, error) {
+				return NewBatchDecoder(key, value, codec.WithStrictDecode(strict))
+			})
+	}
+}
+
+func TestOpenProtocolShrinkCompressedBatch(t *testing.T) {
+	t.Parallel()
+
+	cfg := common.NewConfig(config.ProtocolOpen)
+	cfg.MaxBatchSize = 16
+	cfg.Compression = "lz4"
+	encoder := NewBatchEncoderBuilder(cfg).Build().(*BatchEncoder)
+
+	random := rand.New(rand.NewSource(0))
+	count := 0
+	var rows []*model.RowChangedEvent
+	for i := 0; i < 10; i++ {
+		value := make([]byte, 1024)
+		random.Read(value)
+		row := &model.RowChangedEvent{
+			CommitTs: uint64(i + 1),
+			Table:    &model.TableName{Schema: "a", Table: "b"},
+			Columns: []*model.Column{{
+				Name:  "col1",
+				Type:  mysql.TypeBlob,
+				Value: value,
+			}},
+		}
+		rows = append(rows, row)
+		err := encoder.AppendRowChangedEvent(context.Background(), "", row, func() { count++ })
+		require.NoError(t, err)
+	}
+
+	// the rows fit in one message before compressed, but the message
+	// exceeds the limit after compressed, since the data is random.
+	msgs := encoder.messageBuf
+	require.Len(t, msgs, 1)
+	encoder.MaxMessageBytes = msgs[0].Length() / 3
+	msgs = encoder.Build()
+	require.Greater(t, len(msgs), 3)
+
+	var decoded []*model.RowChangedEvent
+	for i, msg := range msgs {
+		require.LessOrEqual(t, encoder.sizeEstimator.Measure(msg), encoder.MaxMessageBytes)
+		if i != len(msgs)-1 {
+			require.Nil(t, msg.Callback)
+		}
+		decoder, err := NewBatchDecoder(msg.Key, msg.Value)
+		require.NoError(t, err)
+		for {
+			_, hasNext, err := decoder.HasNext()
+			require.NoError(t, err)
+			if !hasNext {
+				break
+			}
+			row, err := decoder.NextRowChangedEvent()
+			require.NoError(t, err)
+			decoded = append(decoded, row)
+		}
+		require.Equal(t, msg.GetRowsCount(), len(decoded)-countBefore(msgs[:i]))
+	}
+	require.Len(t, decoded, len(rows))
+	for i, row := range decoded {
+		require.Equal(t, rows[i].CommitTs, row.CommitTs)
+		require.Equal(t, rows[i].Columns[0].Value, row.Columns[0].Value)
+	}
+	msgs[len(msgs)-1].Callback()
+	require.Equal(t, len(rows), count)
+
+	// the following batches are built within the limit at once.
+	for _, row := range rows {
+		err := encoder.AppendRowChangedEvent(context.Background(), "", row, nil)
+		require.NoError(t, err)
+	}
+	require.Greater(t, len(encoder.Build()), 3)
+}
+
+func countBefore(msgs []*common.Message) int {
+	count := 0
+	for _, msg := range msgs {
+		count += msg.GetRowsCount()
+	}
+	return count
 }
@@ -19,6 +19,7 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/pingcap/errors"
 	timodel "github.com/pingcap/tidb/parser/model"
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/cdc/sink/codec/internal"
@@ -36,21 +37,38 @@ func (m *messageRow) encode() ([]byte, error) {
 	return data, cerror.WrapError(cerror.ErrMarshalFailed, err)
 }
 
-func (m *messageRow) decode(data []byte) error {
+func (m *messageRow) decode(data []byte, strict bool) error {
 	decoder := json.NewDecoder(bytes.NewReader(data))
 	decoder.UseNumber()
+	if strict {
+		decoder.DisallowUnknownFields()
+	}
 	err := decoder.Decode(m)
 	if err != nil {
+		if strict {
+			return cerror.WrapError(cerror.ErrCodecStrictDecodeFailed, err, err.Error())
+		}
 		return cerror.WrapError(cerror.ErrUnmarshalFailed, err)
 	}
+	if strict {
+		if err := internal.CheckTrailingData(data, decoder.InputOffset()); err != nil {
+			return err
+		}
+	}
 	for colName, column := range m.Update {
-		m.Update[colName] = internal.FormatColumn(column)
+		if m.Update[colName], err = internal.FormatColumn(column); err != nil {
+			return errors.Trace(err)
+		}
 	}
 	for colName, column := range m.Delete {
-		m.Delete[colName] = internal.FormatColumn(column)
+		if m.Delete[colName], err = internal.FormatColumn(column); err != nil {
+			return errors.Trace(err)
+		}
 	}
 	for colName, column := range m.PreColumns {
-		m.PreColumns[colName] = internal.FormatColumn(column)
+		if m.PreColumns[colName], err = internal.FormatColumn(column); err != nil {
+			return errors.Trace(err)
+		}
 	}
 	return nil
 }
@@ -65,8 +83,8 @@ func (m *messageDDL) encode() ([]byte, error) {
 	return data, cerror.WrapError(cerror.ErrMarshalFailed, err)
 }
 
-func (m *messageDDL) decode(data []byte) error {
-	return cerror.WrapError(cerror.ErrUnmarshalFailed, json.Unmarshal(data, m))
+func (m *messageDDL) decode(data []byte, strict bool) error {
+	return internal.UnmarshalJSON(data, m, strict)
 }
 
 func newResolvedMessage(ts uint64) *internal.MessageKey {
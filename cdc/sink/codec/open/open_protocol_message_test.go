@@ -31,7 +31,7 @@ func TestFormatCol(t *testing.T) {
 	rowEncode, err := row.encode()
 	require.Nil(t, err)
 	row2 := new(messageRow)
-	err = row2.decode(rowEncode)
+	err = row2.decode(rowEncode, false)
 	require.Nil(t, err)
 	require.Equal(t, row, row2)
 
@@ -42,7 +42,7 @@ func TestFormatCol(t *testing.T) {
 	rowEncode, err = row.encode()
 	require.Nil(t, err)
 	row2 = new(messageRow)
-	err = row2.decode(rowEncode)
+	err = row2.decode(rowEncode, false)
 	require.Nil(t, err)
 	require.Equal(t, row, row2)
 }
@@ -60,7 +60,7 @@ func TestNonBinaryStringCol(t *testing.T) {
 	rowEncode, err := row.encode()
 	require.Nil(t, err)
 	row2 := new(messageRow)
-	err = row2.decode(rowEncode)
+	err = row2.decode(rowEncode, false)
 	require.Nil(t, err)
 	require.Equal(t, row, row2)
 	mqCol2 := row2.Update["test"]
@@ -83,7 +83,7 @@ func TestVarBinaryCol(t *testing.T) {
 	rowEncode, err := row.encode()
 	require.Nil(t, err)
 	row2 := new(messageRow)
-	err = row2.decode(rowEncode)
+	err = row2.decode(rowEncode, false)
 	require.Nil(t, err)
 	require.Equal(t, row, row2)
 	mqCol2 := row2.Update["test"]
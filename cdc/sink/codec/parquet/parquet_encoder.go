@@ -0,0 +1,434 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquet
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pingcap/tidb/parser/charset"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/config"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	pq "github.com/xitongsys/parquet-go/parquet"
+	"github.com/xitongsys/parquet-go/source"
+	"github.com/xitongsys/parquet-go/writer"
+)
+
+const (
+	// OpColumn is the operation of a row change, which is I, U or D like
+	// the csv protocol.
+	OpColumn = "_tidb_op"
+	// CommitTsColumn is the commit ts of a row change.
+	CommitTsColumn = "_tidb_commit_ts"
+
+	dateLayout     = "2006-01-02"
+	dateTimeLayout = "2006-01-02 15:04:05.999999999"
+)
+
+// Encoder encodes the row changed events of a table in parquet files, each
+// file is encoded as a whole since the metadata of a parquet file is written
+// in its footer.
+type Encoder struct {
+	rowGroupSize int64
+	compression  pq.CompressionCodec
+}
+
+// NewEncoder creates an Encoder, the default config is used if cfg is nil.
+func NewEncoder(cfg *config.ParquetConfig) (*Encoder, error) {
+	if cfg == nil {
+		cfg = config.NewDefaultParquetConfig()
+	}
+	e := &Encoder{rowGroupSize: cfg.RowGroupSize}
+	if e.rowGroupSize <= 0 {
+		e.rowGroupSize = config.DefaultParquetRowGroupSize
+	}
+	switch strings.ToLower(cfg.Compression) {
+	case "none":
+		e.compression = pq.CompressionCodec_UNCOMPRESSED
+	case "", "snappy":
+		e.compression = pq.CompressionCodec_SNAPPY
+	case "gzip":
+		e.compression = pq.CompressionCodec_GZIP
+	case "zstd":
+		e.compression = pq.CompressionCodec_ZSTD
+	default:
+		return nil, cerror.ErrParquetEncodeFailed.GenWithStack(
+			"unsupported compression %s", cfg.Compression)
+	}
+	return e, nil
+}
+
+// Encode encodes the row changed events of a table in a parquet file. An
+// update is encoded as its new row and a delete is encoded as its old row,
+// OpColumn tells them apart.
+func (e *Encoder) Encode(tableInfo *model.TableInfo, rows []*model.RowChangedEvent) ([]byte, error) {
+	columns := newColumns(tableInfo)
+	metadata := make([]string, 0, len(columns))
+	for _, col := range columns {
+		metadata = append(metadata, col.metadata())
+	}
+
+	f := &bufferFile{}
+	w, err := writer.NewCSVWriter(metadata, f, 1)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrParquetEncodeFailed, err)
+	}
+	w.RowGroupSize = e.rowGroupSize
+	w.CompressionType = e.compression
+	for _, row := range rows {
+		record, err := encodeRow(columns, row)
+		if err != nil {
+			return nil, err
+		}
+		if err := w.Write(record); err != nil {
+			return nil, cerror.WrapError(cerror.ErrParquetEncodeFailed, err)
+		}
+	}
+	if err := w.WriteStop(); err != nil {
+		return nil, cerror.WrapError(cerror.ErrParquetEncodeFailed, err)
+	}
+	return f.Bytes(), nil
+}
+
+// column is a column of the parquet files. The columns of the TiDB table are
+// optional, and the columns of the TiCDC metadata are required.
+type column struct {
+	name          string
+	physicalType  string
+	convertedType string
+	// precision and scale are only set for the DECIMAL columns.
+	precision int
+	scale     int
+	optional  bool
+	// ft is the TiDB type of the column, it's nil for the columns of the
+	// TiCDC metadata.
+	ft *types.FieldType
+}
+
+// newColumns returns the columns of the parquet files of a TiDB table, which
+// are the visible columns of the table followed by OpColumn and
+// CommitTsColumn.
+func newColumns(tableInfo *model.TableInfo) []*column {
+	columns := make([]*column, 0, len(tableInfo.Columns)+2)
+	for _, col := range tableInfo.Columns {
+		if !model.IsColCDCVisible(col) {
+			continue
+		}
+		ft := col.FieldType.Clone()
+		physicalType, convertedType := columnType(ft)
+		c := &column{
+			name:          col.Name.O,
+			physicalType:  physicalType,
+			convertedType: convertedType,
+			optional:      true,
+			ft:            ft,
+		}
+		if convertedType == "DECIMAL" {
+			c.precision, c.scale = ft.GetFlen(), ft.GetDecimal()
+			if c.precision <= 0 || c.precision > mysql.MaxDecimalWidth {
+				c.precision = mysql.MaxDecimalWidth
+			}
+			if c.scale < 0 {
+				c.scale = 0
+			}
+		}
+		columns = append(columns, c)
+	}
+	return append(columns,
+		&column{name: OpColumn, physicalType: "BYTE_ARRAY", convertedType: "UTF8"},
+		&column{name: CommitTsColumn, physicalType: "INT64"})
+}
+
+// columnType returns the parquet physical type and converted type of a TiDB
+// column. The unsigned BIGINT columns are UINT_64, the DECIMAL columns are
+// DECIMAL in BYTE_ARRAY, and the types without an equivalent, such as TIME,
+// JSON, ENUM and SET, are strings. Both
+// DATETIME and TIMESTAMP are TIMESTAMP_MICROS, whose values are the ones in
+// the time zone of the changefeed.
+func columnType(ft *types.FieldType) (string, string) {
+	unsigned := mysql.HasUnsignedFlag(ft.GetFlag())
+	switch ft.GetType() {
+	case mysql.TypeTiny, mysql.TypeShort, mysql.TypeInt24, mysql.TypeYear:
+		return "INT32", ""
+	case mysql.TypeLong:
+		if unsigned {
+			return "INT64", ""
+		}
+		return "INT32", ""
+	case mysql.TypeLonglong:
+		if unsigned {
+			return "INT64", "UINT_64"
+		}
+		return "INT64", ""
+	case mysql.TypeBit:
+		return "INT64", "UINT_64"
+	case mysql.TypeFloat:
+		return "FLOAT", ""
+	case mysql.TypeDouble:
+		return "DOUBLE", ""
+	case mysql.TypeNewDecimal:
+		return "BYTE_ARRAY", "DECIMAL"
+	case mysql.TypeDate:
+		return "INT32", "DATE"
+	case mysql.TypeDatetime, mysql.TypeTimestamp:
+		return "INT64", "TIMESTAMP_MICROS"
+	case mysql.TypeVarchar, mysql.TypeString, mysql.TypeVarString, mysql.TypeTinyBlob,
+		mysql.TypeMediumBlob, mysql.TypeLongBlob, mysql.TypeBlob:
+		if ft.GetCharset() == charset.CharsetBin {
+			return "BYTE_ARRAY", ""
+		}
+		return "BYTE_ARRAY", "UTF8"
+	default:
+		return "BYTE_ARRAY", "UTF8"
+	}
+}
+
+// metadata returns the metadata of the column in the form of the tags of
+// parquet-go, the separators of the tags in the name are replaced by
+// underscores.
+func (c *column) metadata() string {
+	name := strings.NewReplacer(",", "_", "=", "_", "\t", "_").Replace(c.name)
+	md := fmt.Sprintf("name=%s, type=%s", name, c.physicalType)
+	if c.convertedType != "" {
+		md += ", convertedtype=" + c.convertedType
+	}
+	if c.convertedType == "DECIMAL" {
+		md += fmt.Sprintf(", precision=%d, scale=%d", c.precision, c.scale)
+	}
+	if c.optional {
+		md += ", repetitiontype=OPTIONAL"
+	}
+	return md
+}
+
+// encodeRow encodes a row changed event to a parquet record, whose values
+// are in the same order as the columns.
+func encodeRow(columns []*column, row *model.RowChangedEvent) ([]interface{}, error) {
+	op, cols := "I", row.Columns
+	if row.IsDelete() {
+		op, cols = "D", row.PreColumns
+	} else if row.IsUpdate() {
+		op = "U"
+	}
+	values := make(map[string]*model.Column, len(cols))
+	for _, col := range cols {
+		// column could be nil in a condition described in
+		// https://github.com/pingcap/tiflow/issues/6198#issuecomment-1191132951
+		if col != nil {
+			values[col.Name] = col
+		}
+	}
+
+	record := make([]interface{}, 0, len(columns))
+	for _, c := range columns {
+		switch c.name {
+		case OpColumn:
+			record = append(record, op)
+			continue
+		case CommitTsColumn:
+			record = append(record, int64(row.CommitTs))
+			continue
+		}
+		var value interface{}
+		if col, ok := values[c.name]; ok && col.Value != nil {
+			var err error
+			value, err = c.value(col.Value)
+			if err != nil {
+				return nil, err
+			}
+		}
+		record = append(record, value)
+	}
+	return record, nil
+}
+
+// value converts the value of a TiDB column to the parquet value of the
+// column. The malformed dates, such as the zero dates, are converted to null.
+func (c *column) value(value interface{}) (interface{}, error) {
+	switch {
+	case c.convertedType == "DECIMAL":
+		return c.decimalValue(value)
+	case c.convertedType == "DATE" || c.convertedType == "TIMESTAMP_MICROS":
+		layout := dateTimeLayout
+		if c.convertedType == "DATE" {
+			layout = dateLayout
+		}
+		t, err := time.Parse(layout, fmt.Sprint(value))
+		if err != nil {
+			return nil, nil
+		}
+		if c.convertedType == "DATE" {
+			return int32(t.Unix() / (24 * 60 * 60)), nil
+		}
+		return t.UnixMicro(), nil
+	case c.physicalType == "INT32":
+		n, err := toInt64(value)
+		return int32(n), err
+	case c.physicalType == "INT64":
+		return toInt64(value)
+	case c.physicalType == "FLOAT":
+		switch v := value.(type) {
+		case float32:
+			return v, nil
+		case float64:
+			return float32(v), nil
+		}
+	case c.physicalType == "DOUBLE":
+		switch v := value.(type) {
+		case float32:
+			return float64(v), nil
+		case float64:
+			return v, nil
+		}
+	case c.convertedType == "":
+		// binary
+		switch v := value.(type) {
+		case []byte:
+			return string(v), nil
+		case string:
+			return v, nil
+		}
+	default:
+		return stringValue(c.ft, value)
+	}
+	return nil, cerror.ErrParquetEncodeFailed.GenWithStack(
+		"unexpected value %v(%T) of column %s with type %s", value, value, c.name, c.physicalType)
+}
+
+// decimalValue converts the value of a DECIMAL column to its unscaled value in
+// big-endian two's complement, which is how parquet stores DECIMAL in
+// BYTE_ARRAY.
+func (c *column) decimalValue(value interface{}) (string, error) {
+	s, err := stringValue(c.ft, value)
+	if err != nil {
+		return "", err
+	}
+	intPart, fracPart := s, ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		intPart, fracPart = s[:i], s[i+1:]
+	}
+	if len(fracPart) > c.scale {
+		if strings.TrimRight(fracPart[c.scale:], "0") != "" {
+			return "", cerror.ErrParquetEncodeFailed.GenWithStack(
+				"decimal value %s of column %s exceeds scale %d", s, c.name, c.scale)
+		}
+		fracPart = fracPart[:c.scale]
+	}
+	fracPart += strings.Repeat("0", c.scale-len(fracPart))
+	unscaled, ok := new(big.Int).SetString(intPart+fracPart, 10)
+	if !ok {
+		return "", cerror.ErrParquetEncodeFailed.GenWithStack(
+			"unexpected decimal value %s of column %s", s, c.name)
+	}
+	return string(twosComplement(unscaled)), nil
+}
+
+// twosComplement returns the shortest big-endian two's complement of n.
+func twosComplement(n *big.Int) []byte {
+	negative := n.Sign() < 0
+	if negative {
+		// the bits of n are the inverted bits of -n-1.
+		n = new(big.Int).Not(n)
+	}
+	b := n.Bytes()
+	if len(b) == 0 || b[0]&0x80 != 0 {
+		b = append([]byte{0}, b...)
+	}
+	if negative {
+		for i := range b {
+			b[i] = ^b[i]
+		}
+	}
+	return b
+}
+
+func toInt64(value interface{}) (int64, error) {
+	switch v := value.(type) {
+	case int64:
+		return v, nil
+	case uint64:
+		return int64(v), nil
+	case string:
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return 0, cerror.WrapError(cerror.ErrParquetEncodeFailed, err)
+		}
+		return n, nil
+	}
+	return 0, cerror.ErrParquetEncodeFailed.GenWithStack("unexpected integer value %v(%T)", value, value)
+}
+
+// stringValue converts a value to a string, the ENUM and SET values are
+// converted to their names.
+func stringValue(ft *types.FieldType, value interface{}) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case []byte:
+		return string(v), nil
+	case uint64:
+		if ft == nil {
+			break
+		}
+		switch ft.GetType() {
+		case mysql.TypeEnum:
+			enumVar, err := types.ParseEnumValue(ft.GetElems(), v)
+			if err != nil {
+				return "", cerror.WrapError(cerror.ErrParquetEncodeFailed, err)
+			}
+			return enumVar.Name, nil
+		case mysql.TypeSet:
+			setVar, err := types.ParseSetValue(ft.GetElems(), v)
+			if err != nil {
+				return "", cerror.WrapError(cerror.ErrParquetEncodeFailed, err)
+			}
+			return setVar.Name, nil
+		}
+	}
+	return fmt.Sprint(value), nil
+}
+
+// bufferFile is an in-memory parquet file which can only be written.
+type bufferFile struct {
+	bytes.Buffer
+}
+
+var _ source.ParquetFile = (*bufferFile)(nil)
+
+// Seek implements the source.ParquetFile interface.
+func (f *bufferFile) Seek(offset int64, whence int) (int64, error) {
+	return 0, cerror.ErrParquetEncodeFailed.GenWithStack("seek is not supported")
+}
+
+// Close implements the source.ParquetFile interface.
+func (f *bufferFile) Close() error {
+	return nil
+}
+
+// Open implements the source.ParquetFile interface.
+func (f *bufferFile) Open(name string) (source.ParquetFile, error) {
+	return nil, cerror.ErrParquetEncodeFailed.GenWithStack("open is not supported")
+}
+
+// Create implements the source.ParquetFile interface.
+func (f *bufferFile) Create(name string) (source.ParquetFile, error) {
+	return nil, cerror.ErrParquetEncodeFailed.GenWithStack("create is not supported")
+}
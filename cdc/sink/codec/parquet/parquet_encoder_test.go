@@ -0,0 +1,240 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquet
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"testing"
+
+	timodel "github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/stretchr/testify/require"
+	"github.com/xitongsys/parquet-go/reader"
+	"github.com/xitongsys/parquet-go/source"
+)
+
+func newTestTableInfo() *model.TableInfo {
+	newColumn := func(id int64, name string, tp byte, flag uint) *timodel.ColumnInfo {
+		ft := types.NewFieldType(tp)
+		ft.AddFlag(flag)
+		return &timodel.ColumnInfo{
+			ID: id, Name: timodel.NewCIStr(name), State: timodel.StatePublic, FieldType: *ft,
+		}
+	}
+	return model.WrapTableInfo(1, "test", 1, &timodel.TableInfo{
+		ID:   100,
+		Name: timodel.NewCIStr("t"),
+		Columns: []*timodel.ColumnInfo{
+			newColumn(1, "id", mysql.TypeLong, mysql.PriKeyFlag),
+			newColumn(2, "big", mysql.TypeLonglong, mysql.UnsignedFlag),
+			newColumn(3, "price", mysql.TypeDouble, 0),
+			newColumn(4, "dt", mysql.TypeDatetime, 0),
+			newColumn(5, "d", mysql.TypeDate, 0),
+			newColumn(6, "a,b", mysql.TypeVarchar, 0),
+			newDecimalColumn(7, "amount", 10, 2),
+		},
+	})
+}
+
+func newDecimalColumn(id int64, name string, flen, decimal int) *timodel.ColumnInfo {
+	ft := types.NewFieldType(mysql.TypeNewDecimal)
+	ft.SetFlen(flen)
+	ft.SetDecimal(decimal)
+	return &timodel.ColumnInfo{
+		ID: id, Name: timodel.NewCIStr(name), State: timodel.StatePublic, FieldType: *ft,
+	}
+}
+
+func TestColumns(t *testing.T) {
+	t.Parallel()
+
+	var metadata []string
+	for _, col := range newColumns(newTestTableInfo()) {
+		metadata = append(metadata, col.metadata())
+	}
+	require.Equal(t, []string{
+		"name=id, type=INT32, repetitiontype=OPTIONAL",
+		"name=big, type=INT64, convertedtype=UINT_64, repetitiontype=OPTIONAL",
+		"name=price, type=DOUBLE, repetitiontype=OPTIONAL",
+		"name=dt, type=INT64, convertedtype=TIMESTAMP_MICROS, repetitiontype=OPTIONAL",
+		"name=d, type=INT32, convertedtype=DATE, repetitiontype=OPTIONAL",
+		"name=a_b, type=BYTE_ARRAY, convertedtype=UTF8, repetitiontype=OPTIONAL",
+		"name=amount, type=BYTE_ARRAY, convertedtype=DECIMAL, precision=10, scale=2, repetitiontype=OPTIONAL",
+		"name=_tidb_op, type=BYTE_ARRAY, convertedtype=UTF8",
+		"name=_tidb_commit_ts, type=INT64",
+	}, metadata)
+}
+
+func TestEncodeRow(t *testing.T) {
+	t.Parallel()
+
+	columns := newColumns(newTestTableInfo())
+	cols := []*model.Column{
+		{Name: "id", Value: int64(1)},
+		{Name: "big", Value: uint64(1 << 63)},
+		{Name: "price", Value: float64(1.5)},
+		{Name: "dt", Value: "1970-01-01 00:00:01.5"},
+		{Name: "d", Value: "1970-01-03"},
+		{Name: "a,b", Value: []byte("abc")},
+		{Name: "amount", Value: "-1.50"},
+	}
+	record, err := encodeRow(columns, &model.RowChangedEvent{CommitTs: 10, Columns: cols})
+	require.Nil(t, err)
+	require.Equal(t, []interface{}{
+		int32(1), int64(-1 << 63), float64(1.5), int64(1500000), int32(2), "abc", "\xff\x6a", "I", int64(10),
+	}, record)
+
+	// a delete is encoded as its old row, the malformed dates are null.
+	cols[4].Value = "0000-00-00"
+	record, err = encodeRow(columns, &model.RowChangedEvent{
+		CommitTs: 11, PreColumns: []*model.Column{cols[0], nil, cols[4]},
+	})
+	require.Nil(t, err)
+	require.Equal(t, []interface{}{
+		int32(1), nil, nil, nil, nil, nil, nil, "D", int64(11),
+	}, record)
+
+	_, err = encodeRow(columns, &model.RowChangedEvent{
+		Columns: []*model.Column{{Name: "price", Value: "abc"}},
+	})
+	require.Regexp(t, ".*unexpected value abc.*", err)
+
+	_, err = encodeRow(columns, &model.RowChangedEvent{
+		Columns: []*model.Column{{Name: "amount", Value: "1.125"}},
+	})
+	require.Regexp(t, ".*exceeds scale 2.*", err)
+}
+
+func TestTwosComplement(t *testing.T) {
+	t.Parallel()
+
+	for n, expected := range map[int64][]byte{
+		0:    {0x00},
+		1:    {0x01},
+		127:  {0x7f},
+		128:  {0x00, 0x80},
+		-1:   {0xff},
+		-128: {0x80},
+		-129: {0xff, 0x7f},
+		-150: {0xff, 0x6a},
+	} {
+		require.Equal(t, expected, twosComplement(big.NewInt(n)), n)
+	}
+}
+
+func TestEncode(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewEncoder(&config.ParquetConfig{Compression: "brotli"})
+	require.Regexp(t, ".*unsupported compression brotli.*", err)
+
+	for _, compression := range []string{"none", "snappy", "gzip", "zstd"} {
+		e, err := NewEncoder(&config.ParquetConfig{Compression: compression})
+		require.Nil(t, err)
+		data, err := e.Encode(newTestTableInfo(), []*model.RowChangedEvent{
+			{CommitTs: 10, Columns: []*model.Column{{Name: "id", Value: int64(1)}}},
+			{CommitTs: 11, Columns: []*model.Column{{Name: "id", Value: int64(2)}}},
+		})
+		require.Nil(t, err)
+		require.True(t, bytes.HasPrefix(data, []byte("PAR1")))
+		require.True(t, bytes.HasSuffix(data, []byte("PAR1")))
+	}
+}
+
+// readerFile is an in-memory parquet file which can only be read.
+type readerFile struct {
+	*bytes.Reader
+	data []byte
+}
+
+func newReaderFile(data []byte) *readerFile {
+	return &readerFile{Reader: bytes.NewReader(data), data: data}
+}
+
+func (f *readerFile) Write(p []byte) (int, error) {
+	return 0, errors.New("write is not supported")
+}
+
+func (f *readerFile) Close() error {
+	return nil
+}
+
+func (f *readerFile) Open(name string) (source.ParquetFile, error) {
+	return newReaderFile(f.data), nil
+}
+
+func (f *readerFile) Create(name string) (source.ParquetFile, error) {
+	return nil, errors.New("create is not supported")
+}
+
+func TestEncodeRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	e, err := NewEncoder(nil)
+	require.Nil(t, err)
+	data, err := e.Encode(newTestTableInfo(), []*model.RowChangedEvent{
+		{CommitTs: 10, Columns: []*model.Column{
+			{Name: "id", Value: int64(1)},
+			{Name: "big", Value: uint64(1 << 63)},
+			{Name: "price", Value: float64(1.5)},
+			{Name: "dt", Value: "1970-01-01 00:00:01.5"},
+			{Name: "d", Value: "1970-01-03"},
+			{Name: "a,b", Value: []byte("abc")},
+			{Name: "amount", Value: "12345678.90"},
+		}},
+		{CommitTs: 11, PreColumns: []*model.Column{
+			{Name: "id", Value: int64(2)},
+			{Name: "amount", Value: "-0.01"},
+		}},
+	})
+	require.Nil(t, err)
+
+	r, err := reader.NewParquetColumnReader(newReaderFile(data), 1)
+	require.Nil(t, err)
+	defer r.ReadStop()
+	require.Equal(t, int64(2), r.GetNumRows())
+
+	decodeDecimal := func(value interface{}) string {
+		b := []byte(value.(string))
+		n := new(big.Int).SetBytes(b)
+		if b[0]&0x80 != 0 {
+			n.Sub(n, new(big.Int).Lsh(big.NewInt(1), uint(len(b)*8)))
+		}
+		return n.String()
+	}
+	expected := [][]interface{}{
+		{int32(1), int32(2)},
+		{int64(-1 << 63), nil},
+		{float64(1.5), nil},
+		{int64(1500000), nil},
+		{int32(2), nil},
+		{"abc", nil},
+		{"1234567890", "-1"},
+		{"I", "D"},
+		{int64(10), int64(11)},
+	}
+	for i, values := range expected {
+		actual, _, _, err := r.ReadColumnByIndex(int64(i), 2)
+		require.Nil(t, err)
+		if i == 6 {
+			actual = []interface{}{decodeDecimal(actual[0]), decodeDecimal(actual[1])}
+		}
+		require.Equal(t, values, actual, i)
+	}
+}
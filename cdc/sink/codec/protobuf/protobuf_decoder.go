@@ -0,0 +1,295 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protobuf
+
+import (
+	"context"
+	"encoding/binary"
+	"math"
+
+	"github.com/pingcap/errors"
+	timodel "github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sink/codec"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// BatchDecoder decodes a protobuf message, whose schemas are looked up in
+// the schema registry. A message holds only one event.
+type BatchDecoder struct {
+	ctx      context.Context
+	registry *SchemaRegistry
+	key      []byte
+	value    []byte
+
+	msgType model.MessageType
+	done    bool
+}
+
+// NewBatchDecoder creates a protobuf BatchDecoder of the key and the value
+// of a message.
+func NewBatchDecoder(
+	ctx context.Context, registry *SchemaRegistry, key, value []byte,
+) codec.EventBatchDecoder {
+	return &BatchDecoder{
+		ctx:      ctx,
+		registry: registry,
+		key:      key,
+		value:    value,
+	}
+}
+
+// HasNext implements the EventBatchDecoder interface.
+func (d *BatchDecoder) HasNext() (model.MessageType, bool, error) {
+	if d.done {
+		return model.MessageTypeUnknown, false, nil
+	}
+	if len(d.value) == 0 {
+		// a tombstone of a deleted row.
+		d.msgType = model.MessageTypeRow
+		return d.msgType, true, nil
+	}
+	registryID, _, err := parseEnvelope(d.value)
+	if err != nil {
+		return model.MessageTypeUnknown, false, errors.Trace(err)
+	}
+	if registryID != 0 {
+		d.msgType = model.MessageTypeRow
+		return d.msgType, true, nil
+	}
+	switch d.value[envelopeHeaderSize] {
+	case controlKindResolved:
+		d.msgType = model.MessageTypeResolved
+	case controlKindDDL:
+		d.msgType = model.MessageTypeDDL
+	default:
+		return model.MessageTypeUnknown, false, cerror.ErrCodecDecode.GenWithStack(
+			"unknown kind %d of the control message", d.value[envelopeHeaderSize])
+	}
+	return d.msgType, true, nil
+}
+
+// NextResolvedEvent implements the EventBatchDecoder interface.
+func (d *BatchDecoder) NextResolvedEvent() (uint64, error) {
+	if d.done || d.msgType != model.MessageTypeResolved {
+		return 0, cerror.ErrCodecDecode.GenWithStack("not found resolved event message")
+	}
+	d.done = true
+	payload := d.value[envelopeHeaderSize+1:]
+	if len(payload) != 8 {
+		return 0, cerror.ErrCodecDecode.GenWithStack("illegal resolved event message")
+	}
+	return binary.BigEndian.Uint64(payload), nil
+}
+
+// NextDDLEvent implements the EventBatchDecoder interface.
+func (d *BatchDecoder) NextDDLEvent() (*model.DDLEvent, error) {
+	if d.done || d.msgType != model.MessageTypeDDL {
+		return nil, cerror.ErrCodecDecode.GenWithStack("not found ddl event message")
+	}
+	d.done = true
+	ddl := &model.DDLEvent{TableInfo: &model.TableInfo{}}
+	b := d.value[envelopeHeaderSize+1:]
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return nil, cerror.WrapError(cerror.ErrCodecDecode, protowire.ParseError(n))
+		}
+		b = b[n:]
+		switch {
+		case num == ddlCommitTsFieldNumber && typ == protowire.VarintType:
+			ddl.CommitTs, n = protowire.ConsumeVarint(b)
+		case num == ddlSchemaFieldNumber && typ == protowire.BytesType:
+			ddl.TableInfo.TableName.Schema, n = protowire.ConsumeString(b)
+		case num == ddlTableFieldNumber && typ == protowire.BytesType:
+			ddl.TableInfo.TableName.Table, n = protowire.ConsumeString(b)
+		case num == ddlQueryFieldNumber && typ == protowire.BytesType:
+			ddl.Query, n = protowire.ConsumeString(b)
+		case num == ddlTypeFieldNumber && typ == protowire.VarintType:
+			var v uint64
+			v, n = protowire.ConsumeVarint(b)
+			ddl.Type = timodel.ActionType(v)
+		default:
+			n = protowire.ConsumeFieldValue(num, typ, b)
+		}
+		if n < 0 {
+			return nil, cerror.WrapError(cerror.ErrCodecDecode, protowire.ParseError(n))
+		}
+		b = b[n:]
+	}
+	return ddl, nil
+}
+
+// NextRowChangedEvent implements the EventBatchDecoder interface.
+// Without the TiDB extension, the commit ts of the rows is unknown, and the
+// updated rows are decoded as inserted ones.
+func (d *BatchDecoder) NextRowChangedEvent() (*model.RowChangedEvent, error) {
+	if d.done || d.msgType != model.MessageTypeRow {
+		return nil, cerror.ErrCodecDecode.GenWithStack("not found row changed event message")
+	}
+	d.done = true
+
+	if len(d.value) == 0 {
+		schema, keyCols, _, _, err := d.decode(d.key)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return &model.RowChangedEvent{
+			Table:      &model.TableName{Schema: schema.schema, Table: schema.table},
+			PreColumns: keyCols,
+		}, nil
+	}
+
+	schema, cols, op, commitTs, err := d.decode(d.value)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	row := &model.RowChangedEvent{
+		CommitTs: commitTs,
+		Table:    &model.TableName{Schema: schema.schema, Table: schema.table},
+	}
+	if op == deleteOperation {
+		row.PreColumns = cols
+	} else {
+		row.Columns = cols
+	}
+	return row, nil
+}
+
+// decode decodes the columns of a message, and the TiDB extension fields if
+// they are present.
+func (d *BatchDecoder) decode(
+	data []byte,
+) (*tableSchema, []*model.Column, string, uint64, error) {
+	registryID, b, err := parseEnvelope(data)
+	if err != nil {
+		return nil, nil, "", 0, errors.Trace(err)
+	}
+	schema, err := d.registry.lookup(d.ctx, registryID)
+	if err != nil {
+		return nil, nil, "", 0, errors.Trace(err)
+	}
+	fields := make(map[protowire.Number]*field, len(schema.fields))
+	for _, f := range schema.fields {
+		fields[f.number] = f
+	}
+
+	var (
+		cols     []*model.Column
+		op       string
+		commitTs uint64
+	)
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return nil, nil, "", 0, cerror.WrapError(cerror.ErrCodecDecode, protowire.ParseError(n))
+		}
+		b = b[n:]
+		switch f, ok := fields[num]; {
+		case ok:
+			var col *model.Column
+			col, n = consumeColumn(f, typ, b)
+			if col != nil {
+				cols = append(cols, col)
+			}
+		case num == opFieldNumber && typ == protowire.BytesType:
+			op, n = protowire.ConsumeString(b)
+		case num == commitTsFieldNumber && typ == protowire.VarintType:
+			commitTs, n = protowire.ConsumeVarint(b)
+		default:
+			n = protowire.ConsumeFieldValue(num, typ, b)
+		}
+		if n < 0 {
+			return nil, nil, "", 0, cerror.WrapError(cerror.ErrCodecDecode, protowire.ParseError(n))
+		}
+		b = b[n:]
+	}
+	return schema, cols, op, commitTs, nil
+}
+
+// consumeColumn consumes the value of the field as a column, the column is
+// nil if the wire type doesn't match the field.
+func consumeColumn(f *field, typ protowire.Type, b []byte) (*model.Column, int) {
+	col := &model.Column{Name: f.name, Type: f.mysqlType, Flag: f.flag}
+	n := -1
+	switch {
+	case f.protoType == typeSint64 && typ == protowire.VarintType:
+		var v uint64
+		v, n = protowire.ConsumeVarint(b)
+		col.Value = protowire.DecodeZigZag(v)
+	case f.protoType == typeUint64 && typ == protowire.VarintType:
+		col.Value, n = protowire.ConsumeVarint(b)
+	case f.protoType == typeFloat && typ == protowire.Fixed32Type:
+		var v uint32
+		v, n = protowire.ConsumeFixed32(b)
+		col.Value = float64(math.Float32frombits(v))
+	case f.protoType == typeDouble && typ == protowire.Fixed64Type:
+		var v uint64
+		v, n = protowire.ConsumeFixed64(b)
+		col.Value = math.Float64frombits(v)
+	case (f.protoType == typeString || f.protoType == typeBytes) && typ == protowire.BytesType:
+		var v []byte
+		v, n = protowire.ConsumeBytes(b)
+		if isCharOrBlob(f.mysqlType) {
+			// the values of the character and the binary columns are
+			// []byte in the row changed events.
+			col.Value = append([]byte(nil), v...)
+		} else {
+			col.Value = string(v)
+		}
+	default:
+		return nil, protowire.ConsumeFieldValue(f.number, typ, b)
+	}
+	return col, n
+}
+
+func isCharOrBlob(tp byte) bool {
+	switch tp {
+	case mysql.TypeVarchar, mysql.TypeString, mysql.TypeVarString,
+		mysql.TypeTinyBlob, mysql.TypeBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob:
+		return true
+	}
+	return false
+}
+
+// parseEnvelope returns the schema ID and the payload of a message in the
+// wire format of the schema registry, the message indexes are skipped.
+func parseEnvelope(data []byte) (int, []byte, error) {
+	if len(data) < envelopeHeaderSize+1 || data[0] != magicByte {
+		return 0, nil, cerror.ErrCodecDecode.GenWithStack(
+			"the message is not in the wire format of the schema registry")
+	}
+	registryID := int(binary.BigEndian.Uint32(data[1:envelopeHeaderSize]))
+	b := data[envelopeHeaderSize:]
+	if registryID == 0 {
+		return 0, b, nil
+	}
+	// the message indexes are a zigzag varint of the count followed by the
+	// indexes, a single 0 means the first message.
+	count, n := protowire.ConsumeVarint(b)
+	if n < 0 {
+		return 0, nil, cerror.WrapError(cerror.ErrCodecDecode, protowire.ParseError(n))
+	}
+	b = b[n:]
+	for i := int64(0); i < protowire.DecodeZigZag(count); i++ {
+		_, n = protowire.ConsumeVarint(b)
+		if n < 0 {
+			return 0, nil, cerror.WrapError(cerror.ErrCodecDecode, protowire.ParseError(n))
+		}
+		b = b[n:]
+	}
+	return registryID, b, nil
+}
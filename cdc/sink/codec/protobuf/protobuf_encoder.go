@@ -0,0 +1,389 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protobuf
+
+import (
+	"context"
+	"encoding/binary"
+	"math"
+	"strconv"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/util/rowcodec"
+	"github.com/pingcap/tiflow/cdc/contextutil"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sink/codec"
+	"github.com/pingcap/tiflow/cdc/sink/codec/common"
+	"github.com/pingcap/tiflow/pkg/config"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+const (
+	// magicByte is the first byte of the messages in the wire format of
+	// the Confluent Schema Registry.
+	magicByte = uint8(0)
+	// envelopeHeaderSize is the size of the magic byte and the schema ID.
+	envelopeHeaderSize = 5
+
+	keySchemaSuffix   = "-key"
+	valueSchemaSuffix = "-value"
+)
+
+// The operations in the TiDB extension field.
+const (
+	insertOperation = "c"
+	updateOperation = "u"
+	deleteOperation = "d"
+)
+
+// The kinds of the control messages, which are sent only if the TiDB
+// extension is enabled. A control message has the schema ID 0, which is
+// never allocated by the schema registry.
+const (
+	controlKindResolved = uint8(1)
+	controlKindDDL      = uint8(2)
+)
+
+// The field numbers of the DDL control messages.
+const (
+	ddlCommitTsFieldNumber protowire.Number = iota + 1
+	ddlSchemaFieldNumber
+	ddlTableFieldNumber
+	ddlQueryFieldNumber
+	ddlTypeFieldNumber
+)
+
+// BatchEncoder encodes the row changed events to protobuf messages, whose
+// schemas are registered in the schema registry.
+type BatchEncoder struct {
+	namespace           string
+	registry            *SchemaRegistry
+	enableTiDBExtension bool
+
+	messages []*common.Message
+}
+
+// AppendRowChangedEvent implements the EventBatchEncoder interface.
+// The key is the handle key columns, and the value is the columns of the
+// row. The value of a deleted row is nil as a tombstone, unless the TiDB
+// extension is enabled, in which case it's the columns before the deletion.
+func (e *BatchEncoder) AppendRowChangedEvent(
+	ctx context.Context,
+	topic string,
+	ev *model.RowChangedEvent,
+	callback func(),
+) error {
+	var tiSchemaID uint64
+	if ev.TableInfo != nil {
+		tiSchemaID = ev.TableInfo.Version
+	}
+
+	keyCols, keyColInfos := handleKeyColumns(ev)
+	var key []byte
+	if len(keyCols) > 0 {
+		var err error
+		key, err = e.encode(ctx, topic+keySchemaSuffix, keyMessageName, tiSchemaID,
+			ev, keyCols, keyColInfos, false, "")
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+
+	var value []byte
+	if !ev.IsDelete() || e.enableTiDBExtension {
+		cols, op := ev.Columns, insertOperation
+		if ev.IsDelete() {
+			cols, op = ev.PreColumns, deleteOperation
+		} else if ev.IsUpdate() {
+			op = updateOperation
+		}
+		var err error
+		value, err = e.encode(ctx, topic+valueSchemaSuffix, valueMessageName, tiSchemaID,
+			ev, cols, ev.ColInfos, e.enableTiDBExtension, op)
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+
+	message := common.NewMsg(
+		config.ProtocolProtobuf,
+		key,
+		value,
+		ev.CommitTs,
+		model.MessageTypeRow,
+		&ev.Table.Schema,
+		&ev.Table.Table,
+	)
+	message.Callback = callback
+	message.IncRowsCount()
+	e.messages = append(e.messages, message)
+	return nil
+}
+
+// EncodeCheckpointEvent implements the EventBatchEncoder interface.
+// The resolved events are sent only if the TiDB extension is enabled.
+func (e *BatchEncoder) EncodeCheckpointEvent(ts uint64) (*common.Message, error) {
+	if !e.enableTiDBExtension {
+		return nil, nil
+	}
+	value := controlHeader(controlKindResolved)
+	value = binary.BigEndian.AppendUint64(value, ts)
+	return common.NewResolvedMsg(config.ProtocolProtobuf, nil, value, ts), nil
+}
+
+// EncodeDDLEvent implements the EventBatchEncoder interface.
+// The DDL events are sent only if the TiDB extension is enabled, the
+// consumers without it learn the changes of the tables from the schemas.
+func (e *BatchEncoder) EncodeDDLEvent(ev *model.DDLEvent) (*common.Message, error) {
+	if !e.enableTiDBExtension {
+		return nil, nil
+	}
+	value := controlHeader(controlKindDDL)
+	value = protowire.AppendTag(value, ddlCommitTsFieldNumber, protowire.VarintType)
+	value = protowire.AppendVarint(value, ev.CommitTs)
+	value = protowire.AppendTag(value, ddlSchemaFieldNumber, protowire.BytesType)
+	value = protowire.AppendString(value, ev.TableInfo.TableName.Schema)
+	value = protowire.AppendTag(value, ddlTableFieldNumber, protowire.BytesType)
+	value = protowire.AppendString(value, ev.TableInfo.TableName.Table)
+	value = protowire.AppendTag(value, ddlQueryFieldNumber, protowire.BytesType)
+	value = protowire.AppendString(value, ev.Query)
+	value = protowire.AppendTag(value, ddlTypeFieldNumber, protowire.VarintType)
+	value = protowire.AppendVarint(value, uint64(ev.Type))
+	return common.NewDDLMsg(config.ProtocolProtobuf, nil, value, ev), nil
+}
+
+// Build implements the EventBatchEncoder interface.
+func (e *BatchEncoder) Build() []*common.Message {
+	messages := e.messages
+	e.messages = nil
+	return messages
+}
+
+// encode encodes the columns to a message in the wire format of the schema
+// registry, the schema of the columns is registered if it's changed.
+func (e *BatchEncoder) encode(
+	ctx context.Context,
+	subject string,
+	messageName string,
+	tiSchemaID uint64,
+	ev *model.RowChangedEvent,
+	cols []*model.Column,
+	colInfos []rowcodec.ColInfo,
+	enableTiDBExtension bool,
+	op string,
+) ([]byte, error) {
+	schema, registryID, err := e.registry.getCachedOrRegister(ctx, subject, tiSchemaID,
+		func() (*tableSchema, string, error) {
+			s, err := newTableSchema(ev.Table, cols, colInfos, enableTiDBExtension)
+			if err != nil {
+				return nil, "", errors.Trace(err)
+			}
+			return s, s.text(messageName, e.namespace), nil
+		})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	data := make([]byte, 0, envelopeHeaderSize+1)
+	data = append(data, magicByte)
+	data = binary.BigEndian.AppendUint32(data, uint32(registryID))
+	// The message indexes of the first message in the schema, which is
+	// encoded as a single 0 by the Confluent serializers.
+	data = append(data, 0)
+
+	// the fields are the non-nil columns in order.
+	i := 0
+	for _, col := range cols {
+		if col == nil {
+			continue
+		}
+		if i >= len(schema.fields) {
+			return nil, cerror.ErrProtobufEncodeFailed.GenWithStack(
+				"the columns of table %s don't match the registered schema", ev.Table)
+		}
+		data, err = appendColumn(data, schema.fields[i], col)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		i++
+	}
+	if enableTiDBExtension {
+		data = protowire.AppendTag(data, opFieldNumber, protowire.BytesType)
+		data = protowire.AppendString(data, op)
+		data = protowire.AppendTag(data, commitTsFieldNumber, protowire.VarintType)
+		data = protowire.AppendVarint(data, ev.CommitTs)
+	}
+	return data, nil
+}
+
+// appendColumn appends the value of the column as the field, the field is
+// absent if the value is NULL.
+func appendColumn(b []byte, f *field, col *model.Column) ([]byte, error) {
+	if col.Value == nil {
+		return b, nil
+	}
+	switch f.protoType {
+	case typeSint64:
+		v, err := toInt64(col)
+		if err != nil {
+			return nil, err
+		}
+		b = protowire.AppendTag(b, f.number, protowire.VarintType)
+		return protowire.AppendVarint(b, protowire.EncodeZigZag(v)), nil
+	case typeUint64:
+		v, err := toUint64(col)
+		if err != nil {
+			return nil, err
+		}
+		b = protowire.AppendTag(b, f.number, protowire.VarintType)
+		return protowire.AppendVarint(b, v), nil
+	case typeFloat:
+		v, err := toFloat64(col)
+		if err != nil {
+			return nil, err
+		}
+		b = protowire.AppendTag(b, f.number, protowire.Fixed32Type)
+		return protowire.AppendFixed32(b, math.Float32bits(float32(v))), nil
+	case typeDouble:
+		v, err := toFloat64(col)
+		if err != nil {
+			return nil, err
+		}
+		b = protowire.AppendTag(b, f.number, protowire.Fixed64Type)
+		return protowire.AppendFixed64(b, math.Float64bits(v)), nil
+	case typeString, typeBytes:
+		b = protowire.AppendTag(b, f.number, protowire.BytesType)
+		switch v := col.Value.(type) {
+		case string:
+			return protowire.AppendString(b, v), nil
+		case []byte:
+			return protowire.AppendBytes(b, v), nil
+		}
+	}
+	return nil, cerror.ErrProtobufEncodeFailed.GenWithStack(
+		"unexpected value %v of column %s in %s", col.Value, col.Name, f.protoType)
+}
+
+func toInt64(col *model.Column) (int64, error) {
+	switch v := col.Value.(type) {
+	case int64:
+		return v, nil
+	case string:
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return 0, cerror.WrapError(cerror.ErrProtobufEncodeFailed, err)
+		}
+		return n, nil
+	}
+	return 0, cerror.ErrProtobufEncodeFailed.GenWithStack(
+		"unexpected value %v of column %s", col.Value, col.Name)
+}
+
+func toUint64(col *model.Column) (uint64, error) {
+	switch v := col.Value.(type) {
+	case uint64:
+		return v, nil
+	case int64:
+		return uint64(v), nil
+	case string:
+		n, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			return 0, cerror.WrapError(cerror.ErrProtobufEncodeFailed, err)
+		}
+		return n, nil
+	}
+	return 0, cerror.ErrProtobufEncodeFailed.GenWithStack(
+		"unexpected value %v of column %s", col.Value, col.Name)
+}
+
+func toFloat64(col *model.Column) (float64, error) {
+	switch v := col.Value.(type) {
+	case float64:
+		return v, nil
+	case float32:
+		return float64(v), nil
+	case string:
+		n, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return 0, cerror.WrapError(cerror.ErrProtobufEncodeFailed, err)
+		}
+		return n, nil
+	}
+	return 0, cerror.ErrProtobufEncodeFailed.GenWithStack(
+		"unexpected value %v of column %s", col.Value, col.Name)
+}
+
+// handleKeyColumns returns the handle key columns of the row, the column
+// infos are absent if the row has none.
+func handleKeyColumns(ev *model.RowChangedEvent) ([]*model.Column, []rowcodec.ColInfo) {
+	cols := ev.Columns
+	if ev.IsDelete() {
+		cols = ev.PreColumns
+	}
+	var (
+		keyCols     []*model.Column
+		keyColInfos []rowcodec.ColInfo
+	)
+	for i, col := range cols {
+		if col == nil || !col.Flag.IsHandleKey() {
+			continue
+		}
+		keyCols = append(keyCols, col)
+		if i < len(ev.ColInfos) {
+			keyColInfos = append(keyColInfos, ev.ColInfos[i])
+		}
+	}
+	if len(keyColInfos) != len(keyCols) {
+		keyColInfos = nil
+	}
+	return keyCols, keyColInfos
+}
+
+// controlHeader returns the header of a control message.
+func controlHeader(kind uint8) []byte {
+	header := make([]byte, envelopeHeaderSize, envelopeHeaderSize+1)
+	header[0] = magicByte
+	return append(header, kind)
+}
+
+type batchEncoderBuilder struct {
+	namespace string
+	config    *common.Config
+	registry  *SchemaRegistry
+}
+
+// NewBatchEncoderBuilder creates a protobuf batchEncoderBuilder.
+func NewBatchEncoderBuilder(
+	ctx context.Context, config *common.Config,
+) (codec.EncoderBuilder, error) {
+	registry, err := NewSchemaRegistry(config.AvroSchemaRegistry)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &batchEncoderBuilder{
+		namespace: contextutil.ChangefeedIDFromCtx(ctx).Namespace,
+		config:    config,
+		registry:  registry,
+	}, nil
+}
+
+// Build a protobuf BatchEncoder.
+func (b *batchEncoderBuilder) Build() codec.EventBatchEncoder {
+	return &BatchEncoder{
+		namespace:           b.namespace,
+		registry:            b.registry,
+		enableTiDBExtension: b.config.EnableTiDBExtension,
+	}
+}
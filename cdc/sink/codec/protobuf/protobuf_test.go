@@ -0,0 +1,283 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protobuf
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	timodel "github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/util/rowcodec"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sink/codec/common"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/atomic"
+)
+
+// newMockRegistry starts a schema registry which allocates an ID for each
+// distinct schema.
+func newMockRegistry(t *testing.T) *httptest.Server {
+	var (
+		mu  sync.Mutex
+		ids = make(map[string]int)
+		all []string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch {
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/versions"):
+			var req registerRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil ||
+				req.SchemaType != schemaType {
+				w.WriteHeader(http.StatusUnprocessableEntity)
+				return
+			}
+			id, ok := ids[req.Schema]
+			if !ok {
+				all = append(all, req.Schema)
+				id = len(all)
+				ids[req.Schema] = id
+			}
+			_ = json.NewEncoder(w).Encode(&registerResponse{ID: id})
+		case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/schemas/ids/"):
+			var id int
+			_, err := fmt.Sscanf(r.URL.Path, "/schemas/ids/%d", &id)
+			if err != nil || id <= 0 || id > len(all) {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(&schemaResponse{Schema: all[id-1], SchemaType: schemaType})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func newTestEncoder(
+	t *testing.T, registryURL string, enableTiDBExtension bool,
+) *BatchEncoder {
+	cfg := common.NewConfig(config.ProtocolProtobuf)
+	cfg.AvroSchemaRegistry = registryURL
+	cfg.EnableTiDBExtension = enableTiDBExtension
+	builder, err := NewBatchEncoderBuilder(context.Background(), cfg)
+	require.Nil(t, err)
+	return builder.Build().(*BatchEncoder)
+}
+
+func TestTableSchemaText(t *testing.T) {
+	t.Parallel()
+
+	cols := []*model.Column{
+		{Name: "id", Type: mysql.TypeLonglong, Flag: model.HandleKeyFlag | model.PrimaryKeyFlag},
+		nil,
+		{Name: "1st name", Type: mysql.TypeVarchar},
+		{Name: "_tidb_op", Type: mysql.TypeBlob, Flag: model.BinaryFlag},
+		{Name: "price", Type: mysql.TypeNewDecimal},
+	}
+	colInfos := []rowcodec.ColInfo{{ID: 1}, {ID: 2}, {ID: 3}, {ID: 5}, {ID: 7}}
+	s, err := newTableSchema(&model.TableName{Schema: "test", Table: "t"}, cols, colInfos, true)
+	require.Nil(t, err)
+	text := s.text(valueMessageName, "default")
+	require.Equal(t, `syntax = "proto3";
+
+package com.pingcap.ticdc.default.test.t;
+
+// schema: test
+// table: t
+message Value {
+  optional sint64 id = 1; // type: 8, flag: 10, name: id
+  optional string _1st_name = 3; // type: 15, flag: 0, name: 1st name
+  optional bytes _tidb_op_5 = 5; // type: 252, flag: 1, name: _tidb_op
+  optional string price = 7; // type: 246, flag: 0, name: price
+  optional string _tidb_op = 536870910;
+  optional uint64 _tidb_commit_ts = 536870911;
+}
+`, text)
+
+	parsed, err := parseTableSchema(text)
+	require.Nil(t, err)
+	require.Equal(t, s, parsed)
+
+	_, err = parseTableSchema("syntax = \"proto3\";\nmessage Value {}\n")
+	require.Regexp(t, "not generated by TiCDC", err)
+
+	_, err = newTableSchema(&model.TableName{Schema: "test", Table: "t"},
+		[]*model.Column{{Name: "g", Type: mysql.TypeGeometry}}, nil, false)
+	require.Regexp(t, "unsupported type", err)
+}
+
+func TestEncodeDecodeRows(t *testing.T) {
+	t.Parallel()
+
+	server := newMockRegistry(t)
+	ctx := context.Background()
+	registry, err := NewSchemaRegistry(server.URL)
+	require.Nil(t, err)
+
+	table := &model.TableName{Schema: "test", Table: "t"}
+	colInfos := []rowcodec.ColInfo{{ID: 1}, {ID: 2}, {ID: 3}, {ID: 4}, {ID: 5}, {ID: 6}}
+	newColumns := func(id int64, name string) []*model.Column {
+		return []*model.Column{
+			{Name: "id", Type: mysql.TypeLonglong, Flag: model.HandleKeyFlag | model.PrimaryKeyFlag, Value: id},
+			{Name: "name", Type: mysql.TypeVarchar, Value: []byte(name)},
+			{Name: "score", Type: mysql.TypeFloat, Value: float64(1.5)},
+			{Name: "count", Type: mysql.TypeLong, Flag: model.UnsignedFlag, Value: uint64(7)},
+			{Name: "price", Type: mysql.TypeNewDecimal, Value: "12.30"},
+			{Name: "memo", Type: mysql.TypeVarchar, Value: nil},
+		}
+	}
+	insert := &model.RowChangedEvent{
+		CommitTs: 417318403368288260,
+		Table:    table,
+		Columns:  newColumns(-1, "a"),
+		ColInfos: colInfos,
+	}
+	update := &model.RowChangedEvent{
+		CommitTs:   417318403368288261,
+		Table:      table,
+		PreColumns: newColumns(-1, "a"),
+		Columns:    newColumns(-1, "b"),
+		ColInfos:   colInfos,
+	}
+	del := &model.RowChangedEvent{
+		CommitTs:   417318403368288262,
+		Table:      table,
+		PreColumns: newColumns(-1, "b"),
+		ColInfos:   colInfos,
+	}
+	expected := newColumns(-1, "a")[:5]
+
+	for _, enableTiDBExtension := range []bool{false, true} {
+		encoder := newTestEncoder(t, server.URL, enableTiDBExtension)
+		for _, ev := range []*model.RowChangedEvent{insert, update, del} {
+			require.Nil(t, encoder.AppendRowChangedEvent(ctx, "topic", ev, nil))
+		}
+		messages := encoder.Build()
+		require.Len(t, messages, 3)
+
+		decoded := make([]*model.RowChangedEvent, 0, len(messages))
+		for _, m := range messages {
+			decoder := NewBatchDecoder(ctx, registry, m.Key, m.Value)
+			tp, hasNext, err := decoder.HasNext()
+			require.Nil(t, err)
+			require.True(t, hasNext)
+			require.Equal(t, model.MessageTypeRow, tp)
+			row, err := decoder.NextRowChangedEvent()
+			require.Nil(t, err)
+			require.Equal(t, table, row.Table)
+			decoded = append(decoded, row)
+			_, hasNext, err = decoder.HasNext()
+			require.Nil(t, err)
+			require.False(t, hasNext)
+		}
+
+		require.Equal(t, expected, decoded[0].Columns)
+		expected[1].Value = []byte("b")
+		require.Equal(t, expected, decoded[1].Columns)
+		expected[1].Value = []byte("a")
+		if enableTiDBExtension {
+			require.Equal(t, insert.CommitTs, decoded[0].CommitTs)
+			require.Equal(t, update.CommitTs, decoded[1].CommitTs)
+			require.Equal(t, del.CommitTs, decoded[2].CommitTs)
+			require.True(t, decoded[2].IsDelete())
+			require.Len(t, decoded[2].PreColumns, 5)
+		} else {
+			require.Zero(t, decoded[0].CommitTs)
+			require.Nil(t, messages[2].Value)
+			require.True(t, decoded[2].IsDelete())
+			require.Equal(t, expected[:1], decoded[2].PreColumns)
+		}
+	}
+}
+
+func TestEncodeDecodeControlMessages(t *testing.T) {
+	t.Parallel()
+
+	server := newMockRegistry(t)
+	ctx := context.Background()
+	registry, err := NewSchemaRegistry(server.URL)
+	require.Nil(t, err)
+
+	encoder := newTestEncoder(t, server.URL, false)
+	m, err := encoder.EncodeCheckpointEvent(417318403368288260)
+	require.Nil(t, err)
+	require.Nil(t, m)
+	m, err = encoder.EncodeDDLEvent(&model.DDLEvent{})
+	require.Nil(t, err)
+	require.Nil(t, m)
+
+	encoder = newTestEncoder(t, server.URL, true)
+	m, err = encoder.EncodeCheckpointEvent(417318403368288260)
+	require.Nil(t, err)
+	decoder := NewBatchDecoder(ctx, registry, m.Key, m.Value)
+	tp, hasNext, err := decoder.HasNext()
+	require.Nil(t, err)
+	require.True(t, hasNext)
+	require.Equal(t, model.MessageTypeResolved, tp)
+	ts, err := decoder.NextResolvedEvent()
+	require.Nil(t, err)
+	require.Equal(t, uint64(417318403368288260), ts)
+
+	ddl := &model.DDLEvent{
+		CommitTs: 417318403368288261,
+		TableInfo: &model.TableInfo{
+			TableName: model.TableName{Schema: "test", Table: "t"},
+		},
+		Query: "ALTER TABLE test.t ADD COLUMN c INT",
+		Type:  timodel.ActionAddColumn,
+	}
+	m, err = encoder.EncodeDDLEvent(ddl)
+	require.Nil(t, err)
+	decoder = NewBatchDecoder(ctx, registry, m.Key, m.Value)
+	tp, hasNext, err = decoder.HasNext()
+	require.Nil(t, err)
+	require.True(t, hasNext)
+	require.Equal(t, model.MessageTypeDDL, tp)
+	decoded, err := decoder.NextDDLEvent()
+	require.Nil(t, err)
+	require.Equal(t, ddl.CommitTs, decoded.CommitTs)
+	require.Equal(t, ddl.TableInfo.TableName, decoded.TableInfo.TableName)
+	require.Equal(t, ddl.Query, decoded.Query)
+	require.Equal(t, ddl.Type, decoded.Type)
+}
+
+func TestRegistryRejected(t *testing.T) {
+	t.Parallel()
+
+	requests := atomic.NewInt32(0)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Inc()
+		w.WriteHeader(http.StatusConflict)
+	}))
+	defer server.Close()
+
+	encoder := newTestEncoder(t, server.URL, false)
+	err := encoder.AppendRowChangedEvent(context.Background(), "topic", &model.RowChangedEvent{
+		Table:   &model.TableName{Schema: "test", Table: "t"},
+		Columns: []*model.Column{{Name: "id", Type: mysql.TypeLong, Value: int64(1)}},
+	}, nil)
+	require.Regexp(t, "HTTP status 409", err)
+	require.Equal(t, int32(1), requests.Load())
+}
@@ -0,0 +1,266 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protobuf
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/util/rowcodec"
+	"github.com/pingcap/tiflow/cdc/model"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// The scalar types of protobuf which the columns are encoded in.
+const (
+	typeSint64 = "sint64"
+	typeUint64 = "uint64"
+	typeFloat  = "float"
+	typeDouble = "double"
+	typeString = "string"
+	typeBytes  = "bytes"
+)
+
+// The field numbers of the TiDB extension fields, which are the largest
+// ones, so that they never conflict with the column IDs.
+const (
+	opFieldNumber       protowire.Number = protowire.MaxValidNumber - 1
+	commitTsFieldNumber protowire.Number = protowire.MaxValidNumber
+)
+
+const (
+	opFieldName       = "_tidb_op"
+	commitTsFieldName = "_tidb_commit_ts"
+
+	keyMessageName   = "Key"
+	valueMessageName = "Value"
+	packagePrefix    = "com.pingcap.ticdc"
+)
+
+// field is a field of the message of a table schema, which is a column of
+// the table.
+type field struct {
+	// name is the name of the column, the name of the field is sanitized.
+	name      string
+	number    protowire.Number
+	protoType string
+	mysqlType byte
+	flag      model.ColumnFlagType
+}
+
+// tableSchema is the protobuf schema of the key or the value of a table. The
+// fields are numbered by the column IDs, which are never reused by TiDB, so
+// the schemas changed by the DDLs are always compatible with the old ones
+// in the protobuf way: the added columns are unknown fields to the old
+// consumers, and the dropped columns are missing fields to the new ones.
+type tableSchema struct {
+	schema string
+	table  string
+	fields []*field
+	// enableTiDBExtension indicates whether the message has the TiDB
+	// extension fields.
+	enableTiDBExtension bool
+}
+
+// newTableSchema creates the schema of the columns, the columns are numbered
+// by their positions if the column infos are absent.
+func newTableSchema(
+	table *model.TableName,
+	cols []*model.Column,
+	colInfos []rowcodec.ColInfo,
+	enableTiDBExtension bool,
+) (*tableSchema, error) {
+	s := &tableSchema{
+		schema:              table.Schema,
+		table:               table.Table,
+		fields:              make([]*field, 0, len(cols)),
+		enableTiDBExtension: enableTiDBExtension,
+	}
+	for i, col := range cols {
+		if col == nil {
+			continue
+		}
+		number := protowire.Number(i + 1)
+		if i < len(colInfos) {
+			number = protowire.Number(colInfos[i].ID)
+		}
+		if !number.IsValid() || number >= opFieldNumber {
+			return nil, cerror.ErrProtobufEncodeFailed.GenWithStack(
+				"the ID %d of column %s can't be used as a field number", number, col.Name)
+		}
+		protoType, err := protoTypeOf(col)
+		if err != nil {
+			return nil, err
+		}
+		s.fields = append(s.fields, &field{
+			name:      col.Name,
+			number:    number,
+			protoType: protoType,
+			mysqlType: col.Type,
+			flag:      col.Flag,
+		})
+	}
+	return s, nil
+}
+
+// protoTypeOf returns the protobuf type which the values of the column are
+// encoded in. The types can't be represented exactly, like decimals and
+// temporal types, are encoded in strings formatted by TiDB. The enums and
+// the sets are encoded in their indexes.
+func protoTypeOf(col *model.Column) (string, error) {
+	switch col.Type {
+	case mysql.TypeTiny, mysql.TypeShort, mysql.TypeInt24, mysql.TypeLong,
+		mysql.TypeLonglong, mysql.TypeYear:
+		if col.Flag.IsUnsigned() {
+			return typeUint64, nil
+		}
+		return typeSint64, nil
+	case mysql.TypeBit, mysql.TypeEnum, mysql.TypeSet:
+		return typeUint64, nil
+	case mysql.TypeFloat:
+		return typeFloat, nil
+	case mysql.TypeDouble:
+		return typeDouble, nil
+	case mysql.TypeVarchar, mysql.TypeString, mysql.TypeVarString,
+		mysql.TypeTinyBlob, mysql.TypeBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob:
+		if col.Flag.IsBinary() {
+			return typeBytes, nil
+		}
+		return typeString, nil
+	case mysql.TypeNewDecimal, mysql.TypeDate, mysql.TypeDatetime, mysql.TypeNewDate,
+		mysql.TypeTimestamp, mysql.TypeDuration, mysql.TypeJSON:
+		return typeString, nil
+	default:
+		return "", cerror.ErrProtobufEncodeFailed.GenWithStack(
+			"unsupported type %d of column %s", col.Type, col.Name)
+	}
+}
+
+// text returns the schema in the protobuf language, which is registered in
+// the schema registry. The original names and the types of the columns are
+// kept in the comments, so that the decoder can restore the columns.
+func (s *tableSchema) text(messageName, namespace string) string {
+	var sb strings.Builder
+	sb.WriteString("syntax = \"proto3\";\n\n")
+	fmt.Fprintf(&sb, "package %s.%s.%s.%s;\n\n", packagePrefix,
+		sanitizeName(namespace), sanitizeName(s.schema), sanitizeName(s.table))
+	fmt.Fprintf(&sb, "// schema: %s\n", s.schema)
+	fmt.Fprintf(&sb, "// table: %s\n", s.table)
+	fmt.Fprintf(&sb, "message %s {\n", messageName)
+	names := make(map[string]struct{}, len(s.fields))
+	for _, f := range s.fields {
+		name := sanitizeName(f.name)
+		if _, ok := names[name]; ok || strings.HasPrefix(name, "_tidb_") {
+			name = fmt.Sprintf("%s_%d", name, f.number)
+		}
+		names[name] = struct{}{}
+		fmt.Fprintf(&sb, "  optional %s %s = %d; // type: %d, flag: %d, name: %s\n",
+			f.protoType, name, f.number, f.mysqlType, f.flag, f.name)
+	}
+	if s.enableTiDBExtension {
+		fmt.Fprintf(&sb, "  optional %s %s = %d;\n", typeString, opFieldName, opFieldNumber)
+		fmt.Fprintf(&sb, "  optional %s %s = %d;\n", typeUint64, commitTsFieldName, commitTsFieldNumber)
+	}
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+var fieldRE = regexp.MustCompile(
+	`^optional (\w+) \w+ = (\d+);(?: // type: (\d+), flag: (\d+), name: (.*))?$`)
+
+// parseTableSchema parses the schema generated by tableSchema.text.
+func parseTableSchema(text string) (*tableSchema, error) {
+	s := &tableSchema{}
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if v, ok := cutPrefix(line, "// schema: "); ok {
+			s.schema = v
+			continue
+		}
+		if v, ok := cutPrefix(line, "// table: "); ok {
+			s.table = v
+			continue
+		}
+		matches := fieldRE.FindStringSubmatch(line)
+		if matches == nil {
+			continue
+		}
+		number, err := strconv.ParseInt(matches[2], 10, 32)
+		if err != nil {
+			return nil, cerror.WrapError(cerror.ErrCodecDecode, err)
+		}
+		if protowire.Number(number) >= opFieldNumber {
+			s.enableTiDBExtension = true
+			continue
+		}
+		if matches[3] == "" {
+			return nil, cerror.ErrCodecDecode.GenWithStack(
+				"the column of field %d is unknown", number)
+		}
+		mysqlType, err := strconv.ParseUint(matches[3], 10, 8)
+		if err != nil {
+			return nil, cerror.WrapError(cerror.ErrCodecDecode, err)
+		}
+		flag, err := strconv.ParseUint(matches[4], 10, 64)
+		if err != nil {
+			return nil, cerror.WrapError(cerror.ErrCodecDecode, err)
+		}
+		s.fields = append(s.fields, &field{
+			name:      matches[5],
+			number:    protowire.Number(number),
+			protoType: matches[1],
+			mysqlType: byte(mysqlType),
+			flag:      model.ColumnFlagType(flag),
+		})
+	}
+	if s.table == "" && s.schema == "" {
+		return nil, cerror.ErrCodecDecode.GenWithStack(
+			"the schema is not generated by TiCDC")
+	}
+	return s, nil
+}
+
+func cutPrefix(s, prefix string) (string, bool) {
+	if !strings.HasPrefix(s, prefix) {
+		return s, false
+	}
+	return s[len(prefix):], true
+}
+
+// sanitizeName replaces the characters not allowed in the identifiers of
+// protobuf with underscores, an identifier can't start with a digit either.
+func sanitizeName(name string) string {
+	var sb strings.Builder
+	for i, c := range name {
+		switch {
+		case c == '_' || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z'):
+			sb.WriteRune(c)
+		case '0' <= c && c <= '9':
+			if i == 0 {
+				sb.WriteRune('_')
+			}
+			sb.WriteRune(c)
+		default:
+			sb.WriteRune('_')
+		}
+	}
+	if sb.Len() == 0 {
+		return "_"
+	}
+	return sb.String()
+}
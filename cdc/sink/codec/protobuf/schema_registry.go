@@ -0,0 +1,238 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protobuf
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/httputil"
+	"github.com/pingcap/tiflow/pkg/retry"
+	"go.uber.org/zap"
+)
+
+const (
+	// schemaType is the type of the schemas in the schema registry.
+	schemaType = "PROTOBUF"
+
+	registryBackoffBaseDelayInMs = 500
+	registryBackoffMaxDelayInMs  = 30 * 1000
+	registryMaxTries             = 10
+)
+
+type registerRequest struct {
+	Schema     string `json:"schema"`
+	SchemaType string `json:"schemaType"`
+}
+
+type registerResponse struct {
+	ID int `json:"id"`
+}
+
+type schemaResponse struct {
+	Schema     string `json:"schema"`
+	SchemaType string `json:"schemaType"`
+}
+
+// registeredSchema is the schema registered for a version of a table.
+type registeredSchema struct {
+	tiSchemaID uint64
+	registryID int
+	schema     *tableSchema
+}
+
+// SchemaRegistry registers the protobuf schemas of the tables to the
+// Confluent Schema Registry with the TopicNameStrategy, and looks up the
+// schemas of the registry IDs for the decoders.
+type SchemaRegistry struct {
+	registryURL string
+	client      *httputil.Client
+
+	mu sync.Mutex
+	// registered are the schemas registered by the subjects.
+	registered map[string]*registeredSchema
+	// schemas are the schemas looked up by the registry IDs.
+	schemas map[int]*tableSchema
+}
+
+// NewSchemaRegistry creates a SchemaRegistry of the registry URL.
+func NewSchemaRegistry(registryURL string) (*SchemaRegistry, error) {
+	client, err := httputil.NewClient(nil)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &SchemaRegistry{
+		registryURL: strings.TrimRight(registryURL, "/"),
+		client:      client,
+		registered:  make(map[string]*registeredSchema),
+		schemas:     make(map[int]*tableSchema),
+	}, nil
+}
+
+// getCachedOrRegister returns the registered schema of the subject if the
+// version of the table isn't changed, otherwise the schema generated by
+// schemaGen is registered. Registering a schema registered before returns
+// the same ID, so it's safe to register again after the restarts.
+func (r *SchemaRegistry) getCachedOrRegister(
+	ctx context.Context,
+	subject string,
+	tiSchemaID uint64,
+	schemaGen func() (*tableSchema, string, error),
+) (*tableSchema, int, error) {
+	r.mu.Lock()
+	entry, ok := r.registered[subject]
+	r.mu.Unlock()
+	if ok && entry.tiSchemaID == tiSchemaID {
+		return entry.schema, entry.registryID, nil
+	}
+
+	schema, text, err := schemaGen()
+	if err != nil {
+		return nil, 0, errors.Trace(err)
+	}
+	payload, err := json.Marshal(&registerRequest{Schema: text, SchemaType: schemaType})
+	if err != nil {
+		return nil, 0, cerror.WrapError(cerror.ErrProtobufSchemaAPIError, err)
+	}
+	uri := r.registryURL + "/subjects/" + url.PathEscape(subject) + "/versions"
+	body, err := r.request(ctx, http.MethodPost, uri, payload)
+	if err != nil {
+		return nil, 0, errors.Trace(err)
+	}
+	var resp registerResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, 0, cerror.WrapError(cerror.ErrProtobufSchemaAPIError, err)
+	}
+	if resp.ID == 0 {
+		return nil, 0, cerror.ErrProtobufSchemaAPIError.GenWithStack(
+			"illegal schema ID %d returned from the registry", resp.ID)
+	}
+	log.Info("Registered protobuf schema",
+		zap.String("subject", subject),
+		zap.Uint64("tiSchemaID", tiSchemaID),
+		zap.Int("registryID", resp.ID))
+
+	r.mu.Lock()
+	r.registered[subject] = &registeredSchema{
+		tiSchemaID: tiSchemaID,
+		registryID: resp.ID,
+		schema:     schema,
+	}
+	r.mu.Unlock()
+	return schema, resp.ID, nil
+}
+
+// lookup returns the schema of the registry ID.
+func (r *SchemaRegistry) lookup(ctx context.Context, registryID int) (*tableSchema, error) {
+	r.mu.Lock()
+	schema, ok := r.schemas[registryID]
+	r.mu.Unlock()
+	if ok {
+		return schema, nil
+	}
+
+	uri := fmt.Sprintf("%s/schemas/ids/%d", r.registryURL, registryID)
+	body, err := r.request(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var resp schemaResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, cerror.WrapError(cerror.ErrProtobufSchemaAPIError, err)
+	}
+	if resp.SchemaType != schemaType {
+		return nil, cerror.ErrProtobufSchemaAPIError.GenWithStack(
+			"the type of schema %d is %s instead of %s", registryID, resp.SchemaType, schemaType)
+	}
+	schema, err = parseTableSchema(resp.Schema)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	r.mu.Lock()
+	r.schemas[registryID] = schema
+	r.mu.Unlock()
+	return schema, nil
+}
+
+// request sends the request to the registry, it's retried unless the
+// registry rejects it.
+func (r *SchemaRegistry) request(
+	ctx context.Context, method, uri string, payload []byte,
+) ([]byte, error) {
+	var body []byte
+	err := retry.Do(ctx, func() error {
+		var reader io.Reader
+		if payload != nil {
+			reader = bytes.NewReader(payload)
+		}
+		req, err := http.NewRequestWithContext(ctx, method, uri, reader)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		req.Header.Add("Accept", "application/vnd.schemaregistry.v1+json, "+
+			"application/vnd.schemaregistry+json, application/json")
+		if payload != nil {
+			req.Header.Add("Content-Type", "application/vnd.schemaregistry.v1+json")
+		}
+		resp, err := r.client.Do(req)
+		if err != nil {
+			log.Warn("Protobuf schema registry request failed",
+				zap.String("uri", uri), zap.Error(err))
+			return cerror.WrapError(cerror.ErrProtobufSchemaAPIError, err)
+		}
+		defer resp.Body.Close()
+		body, err = io.ReadAll(resp.Body)
+		if err != nil {
+			return cerror.WrapError(cerror.ErrProtobufSchemaAPIError, err)
+		}
+		if resp.StatusCode/100 == 2 {
+			return nil
+		}
+		err = cerror.ErrProtobufSchemaAPIError.GenWithStack(
+			"the registry responded to %s %s with HTTP status %d: %s",
+			method, uri, resp.StatusCode, body)
+		// The 4xx errors like 409 for the incompatible schemas are not
+		// recoverable.
+		if resp.StatusCode/100 == 4 {
+			return backoffPermanent{err}
+		}
+		return err
+	}, retry.WithBackoffBaseDelay(registryBackoffBaseDelayInMs),
+		retry.WithBackoffMaxDelay(registryBackoffMaxDelayInMs),
+		retry.WithMaxTries(registryMaxTries),
+		retry.WithIsRetryableErr(func(err error) bool {
+			_, ok := err.(backoffPermanent)
+			return !ok
+		}))
+	if p, ok := err.(backoffPermanent); ok {
+		err = p.error
+	}
+	return body, err
+}
+
+// backoffPermanent marks an error not retryable.
+type backoffPermanent struct {
+	error
+}
@@ -0,0 +1,116 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package contract
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pingcap/log"
+	timodel "github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/config"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// Checker checks the DDLs against the consumer contracts of the topics, so
+// that the breaking DDLs never reach the consumers.
+type Checker struct {
+	contracts map[string][]*config.ContractColumn
+}
+
+// NewChecker creates a Checker, it returns nil if there is no contract.
+func NewChecker(contracts []*config.ConsumerContract) *Checker {
+	if len(contracts) == 0 {
+		return nil
+	}
+	c := &Checker{contracts: make(map[string][]*config.ContractColumn, len(contracts))}
+	for _, contract := range contracts {
+		c.contracts[contract.Topic] = contract.Columns
+	}
+	return c
+}
+
+// Check returns an error reporting the violations if the DDL drops or
+// changes the type of a column required by the contract of the topic, the
+// error pauses the changefeed. Only the columns satisfying the contract
+// before the DDL are checked, so the other tables sent to the topic are not
+// affected.
+func (c *Checker) Check(topic string, ddl *model.DDLEvent) error {
+	columns, ok := c.contracts[topic]
+	if !ok || ddl.PreTableInfo == nil || ddl.PreTableInfo.TableInfo == nil {
+		return nil
+	}
+	table := ddl.PreTableInfo.TableName.QuoteString()
+	var violations []string
+	for _, required := range columns {
+		before := findColumn(ddl.PreTableInfo, required.Name)
+		if before == nil || !matchType(&before.FieldType, required.Type) {
+			continue
+		}
+		var after *timodel.ColumnInfo
+		if ddl.TableInfo != nil && ddl.TableInfo.TableInfo != nil {
+			after = findColumn(ddl.TableInfo, required.Name)
+		}
+		switch {
+		case after == nil:
+			violations = append(violations, fmt.Sprintf(
+				"required column %s of %s is dropped", before.Name.O, table))
+		case !matchType(&after.FieldType, required.Type):
+			violations = append(violations, fmt.Sprintf(
+				"type of required column %s of %s is changed from %s to %s, but %s is required",
+				before.Name.O, table, before.FieldType.CompactStr(),
+				after.FieldType.CompactStr(), required.Type))
+		}
+	}
+	if len(violations) == 0 {
+		return nil
+	}
+	log.Error("DDL violates the consumer contract",
+		zap.String("topic", topic),
+		zap.String("query", ddl.Query),
+		zap.Uint64("commitTs", ddl.CommitTs),
+		zap.Strings("violations", violations))
+	return cerror.ErrConsumerContractViolated.GenWithStackByArgs(
+		ddl.Query, topic, strings.Join(violations, "; "))
+}
+
+// findColumn returns the column of the table by the case-insensitive name.
+func findColumn(tableInfo *model.TableInfo, name string) *timodel.ColumnInfo {
+	name = strings.ToLower(name)
+	for _, col := range tableInfo.Columns {
+		if col.Name.L == name {
+			return col
+		}
+	}
+	return nil
+}
+
+// matchType returns whether the type of the column is the required one. The
+// length is only compared if the required type has one.
+func matchType(ft *types.FieldType, required string) bool {
+	required = strings.ToLower(strings.ReplaceAll(required, " ", ""))
+	if required == "" {
+		return true
+	}
+	actual := strings.ToLower(strings.ReplaceAll(ft.CompactStr(), " ", ""))
+	if !strings.Contains(required, "(") {
+		if i := strings.Index(actual, "("); i >= 0 {
+			actual = actual[:i]
+		}
+	}
+	return actual == required
+}
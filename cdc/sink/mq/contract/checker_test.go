@@ -0,0 +1,108 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package contract
+
+import (
+	"testing"
+
+	timodel "github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/config"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func newContractTableInfo(columns map[string]*types.FieldType) *model.TableInfo {
+	ti := &timodel.TableInfo{Name: timodel.NewCIStr("t")}
+	for name, ft := range columns {
+		ti.Columns = append(ti.Columns, &timodel.ColumnInfo{
+			Name: timodel.NewCIStr(name), FieldType: *ft,
+		})
+	}
+	return model.WrapTableInfo(1, "test", 1, ti)
+}
+
+func TestChecker(t *testing.T) {
+	t.Parallel()
+
+	require.Nil(t, NewChecker(nil))
+	c := NewChecker([]*config.ConsumerContract{{
+		Topic: "topic",
+		Columns: []*config.ContractColumn{
+			{Name: "ID", Type: "int"},
+			{Name: "name", Type: "varchar(32)"},
+			{Name: "remark"},
+		},
+	}})
+
+	intType := types.NewFieldType(mysql.TypeLong)
+	varchar32 := types.NewFieldType(mysql.TypeVarchar)
+	varchar32.SetFlen(32)
+	varchar64 := types.NewFieldType(mysql.TypeVarchar)
+	varchar64.SetFlen(64)
+	before := newContractTableInfo(map[string]*types.FieldType{
+		"id": intType, "name": varchar32, "remark": varchar32,
+	})
+
+	// The compatible DDLs and the DDLs of the other topics are accepted.
+	ddl := &model.DDLEvent{
+		Query:        "alter table t modify remark varchar(64)",
+		PreTableInfo: before,
+		TableInfo: newContractTableInfo(map[string]*types.FieldType{
+			"id": intType, "name": varchar32, "remark": varchar64,
+		}),
+	}
+	require.Nil(t, c.Check("topic", ddl))
+	ddl = &model.DDLEvent{
+		Query:        "alter table t drop column id",
+		PreTableInfo: before,
+		TableInfo: newContractTableInfo(map[string]*types.FieldType{
+			"name": varchar32, "remark": varchar32,
+		}),
+	}
+	require.Nil(t, c.Check("other", ddl))
+	require.Nil(t, c.Check("topic", &model.DDLEvent{TableInfo: before}))
+
+	// Dropping or retyping the required columns is rejected.
+	err := c.Check("topic", ddl)
+	require.True(t, cerror.ErrConsumerContractViolated.Equal(err))
+	require.True(t, cerror.IsChangefeedPauseError(err))
+	require.Regexp(t, "required column id of `test`.`t` is dropped", err)
+	ddl = &model.DDLEvent{
+		Query:        "alter table t modify name varchar(64)",
+		PreTableInfo: before,
+		TableInfo: newContractTableInfo(map[string]*types.FieldType{
+			"id": intType, "name": varchar64, "remark": varchar32,
+		}),
+	}
+	err = c.Check("topic", ddl)
+	require.True(t, cerror.ErrConsumerContractViolated.Equal(err))
+	require.True(t, cerror.IsChangefeedPauseError(err))
+	require.Regexp(t, "type of required column name of `test`.`t` is changed "+
+		"from varchar\\(32\\) to varchar\\(64\\)", err)
+
+	// The tables not satisfying the contract before are not checked.
+	ddl = &model.DDLEvent{
+		Query: "alter table t drop column name",
+		PreTableInfo: newContractTableInfo(map[string]*types.FieldType{
+			"id": varchar32, "name": varchar64,
+		}),
+		TableInfo: newContractTableInfo(map[string]*types.FieldType{
+			"id": varchar32,
+		}),
+	}
+	require.Nil(t, c.Check("topic", ddl))
+}
@@ -15,6 +15,7 @@ package dispatcher
 
 import (
 	"strings"
+	"sync"
 
 	"github.com/pingcap/log"
 	filter "github.com/pingcap/tidb/util/table-filter"
@@ -44,6 +45,8 @@ const (
 	partitionDispatchRuleTS
 	partitionDispatchRuleTable
 	partitionDispatchRuleIndexValue
+	partitionDispatchRuleColumns
+	partitionDispatchRuleExpression
 )
 
 func (r *partitionDispatchRule) fromString(rule string) {
@@ -59,9 +62,13 @@ func (r *partitionDispatchRule) fromString(rule string) {
 		log.Warn("rowid is deprecated, please use index-value instead.")
 	case "index-value":
 		*r = partitionDispatchRuleIndexValue
+	case "columns":
+		*r = partitionDispatchRuleColumns
+	case "expression":
+		*r = partitionDispatchRuleExpression
 	default:
 		*r = partitionDispatchRuleDefault
-		log.Warn("the partition dispatch rule is not default/ts/table/index-value," +
+		log.Warn("the partition dispatch rule is not default/ts/table/index-value/columns/expression," +
 			" use the default rule instead.")
 	}
 }
@@ -73,8 +80,19 @@ type EventRouter struct {
 	rules        []struct {
 		partitionDispatcher partition.Dispatcher
 		topicDispatcher     topic.Dispatcher
+		override            *topicOverride
 		filter.Filter
 	}
+	// topicOverrides records the overrides of the rules by the topics
+	// they dispatched the events to, topic name -> *topicOverride.
+	topicOverrides sync.Map
+}
+
+// topicOverride is the partition number and the replication factor of a
+// dispatch rule used to create its topics.
+type topicOverride struct {
+	partitionNum      int32
+	replicationFactor int16
 }
 
 // NewEventRouter creates a new EventRouter.
@@ -90,6 +108,7 @@ func NewEventRouter(cfg *config.ReplicaConfig, defaultTopic string) (*EventRoute
 	rules := make([]struct {
 		partitionDispatcher partition.Dispatcher
 		topicDispatcher     topic.Dispatcher
+		override            *topicOverride
 		filter.Filter
 	}, 0, len(ruleConfigs))
 
@@ -102,16 +121,27 @@ func NewEventRouter(cfg *config.ReplicaConfig, defaultTopic string) (*EventRoute
 			f = filter.CaseInsensitive(f)
 		}
 
-		d := getPartitionDispatcher(ruleConfig, cfg.EnableOldValue)
+		d, err := getPartitionDispatcher(ruleConfig, cfg.EnableOldValue)
+		if err != nil {
+			return nil, err
+		}
 		t, err := getTopicDispatcher(ruleConfig, defaultTopic, cfg.Sink.Protocol)
 		if err != nil {
 			return nil, err
 		}
+		var o *topicOverride
+		if ruleConfig.PartitionNum > 0 || ruleConfig.ReplicationFactor > 0 {
+			o = &topicOverride{
+				partitionNum:      ruleConfig.PartitionNum,
+				replicationFactor: ruleConfig.ReplicationFactor,
+			}
+		}
 		rules = append(rules, struct {
 			partitionDispatcher partition.Dispatcher
 			topicDispatcher     topic.Dispatcher
+			override            *topicOverride
 			filter.Filter
-		}{partitionDispatcher: d, topicDispatcher: t, Filter: f})
+		}{partitionDispatcher: d, topicDispatcher: t, override: o, Filter: f})
 	}
 
 	return &EventRouter{
@@ -122,8 +152,7 @@ func NewEventRouter(cfg *config.ReplicaConfig, defaultTopic string) (*EventRoute
 
 // GetTopicForRowChange returns the target topic for row changes.
 func (s *EventRouter) GetTopicForRowChange(row *model.RowChangedEvent) string {
-	topicDispatcher, _ := s.matchDispatcher(row.Table.Schema, row.Table.Table)
-	return topicDispatcher.Substitute(row.Table.Schema, row.Table.Table)
+	return s.substitute(row.Table.Schema, row.Table.Table)
 }
 
 // GetTopicForDDL returns the target topic for DDL.
@@ -143,8 +172,7 @@ func (s *EventRouter) GetTopicForDDL(ddl *model.DDLEvent) string {
 		table = ddl.TableInfo.TableName.Table
 	}
 
-	topicDispatcher, _ := s.matchDispatcher(schema, table)
-	return topicDispatcher.Substitute(schema, table)
+	return s.substitute(schema, table)
 }
 
 // GetPartitionForRowChange returns the target partition for row changes.
@@ -161,6 +189,22 @@ func (s *EventRouter) GetPartitionForRowChange(
 	)
 }
 
+// VerifyTables checks whether the partition dispatchers matching the tables
+// can dispatch their rows, e.g. the partition columns must exist in the tables.
+func (s *EventRouter) VerifyTables(tableInfos []*model.TableInfo) error {
+	for _, tableInfo := range tableInfos {
+		_, partitionDispatcher := s.matchDispatcher(
+			tableInfo.TableName.Schema, tableInfo.TableName.Table,
+		)
+		if v, ok := partitionDispatcher.(partition.TableVerifier); ok {
+			if err := v.VerifyTable(tableInfo); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 // GetDLLDispatchRuleByProtocol returns the DDL
 // distribution rule according to the protocol.
 func (s *EventRouter) GetDLLDispatchRuleByProtocol(
@@ -178,13 +222,11 @@ func (s *EventRouter) GetActiveTopics(activeTables []model.TableName) []string {
 	topics := make([]string, 0)
 	topicsMap := make(map[string]bool, len(activeTables))
 	for _, table := range activeTables {
-		topicDispatcher, _ := s.matchDispatcher(table.Schema, table.Table)
-		topicName := topicDispatcher.Substitute(table.Schema, table.Table)
+		topicName := s.substitute(table.Schema, table.Table)
 		if topicName == s.defaultTopic {
 			log.Debug("topic name corresponding to the table is the same as the default topic name",
 				zap.String("table", table.String()),
 				zap.String("defaultTopic", s.defaultTopic),
-				zap.String("topicDispatcherExpression", topicDispatcher.String()),
 			)
 		}
 		if !topicsMap[topicName] {
@@ -206,6 +248,40 @@ func (s *EventRouter) GetDefaultTopic() string {
 	return s.defaultTopic
 }
 
+// TopicOverride returns the partition number and the replication factor
+// of the dispatch rule which dispatched the events to the topic, they are
+// used when the topic is auto-created, zero means no override.
+// The default topic is created with the ones in the sink URI.
+func (s *EventRouter) TopicOverride(topicName string) (int32, int16) {
+	v, ok := s.topicOverrides.Load(topicName)
+	if !ok {
+		return 0, 0
+	}
+	o := v.(*topicOverride)
+	return o.partitionNum, o.replicationFactor
+}
+
+// substitute returns the target topic of the table, and records the
+// overrides of the matched rule by the topic.
+func (s *EventRouter) substitute(schema, table string) string {
+	for _, rule := range s.rules {
+		if !rule.MatchTable(schema, table) {
+			continue
+		}
+		topicName := rule.topicDispatcher.Substitute(schema, table)
+		if rule.override != nil && topicName != s.defaultTopic {
+			// If several rules dispatch the events to a topic, the first
+			// one recorded decides the overrides of the topic.
+			if _, ok := s.topicOverrides.Load(topicName); !ok {
+				s.topicOverrides.LoadOrStore(topicName, rule.override)
+			}
+		}
+		return topicName
+	}
+	log.Panic("the dispatch rule must cover all tables")
+	return ""
+}
+
 // matchDispatcher returns the target topic dispatcher and partition dispatcher if a
 // row changed event matches a specific table filter.
 func (s *EventRouter) matchDispatcher(
@@ -224,7 +300,7 @@ func (s *EventRouter) matchDispatcher(
 // getPartitionDispatcher returns the partition dispatcher for a specific partition rule.
 func getPartitionDispatcher(
 	ruleConfig *config.DispatchRule, enableOldValue bool,
-) partition.Dispatcher {
+) (partition.Dispatcher, error) {
 	var (
 		d    partition.Dispatcher
 		rule partitionDispatchRule
@@ -238,6 +314,16 @@ func getPartitionDispatcher(
 				"switching on the old value, so please use caution!")
 		}
 		d = partition.NewIndexValueDispatcher()
+	case partitionDispatchRuleColumns:
+		d = partition.NewColumnsDispatcher(
+			ruleConfig.PartitionColumns, ruleConfig.PartitionIncludeTable)
+	case partitionDispatchRuleExpression:
+		var err error
+		d, err = partition.NewExpressionDispatcher(
+			ruleConfig.PartitionExpression, ruleConfig.PartitionIncludeTable)
+		if err != nil {
+			return nil, err
+		}
 	case partitionDispatchRuleTS:
 		d = partition.NewTsDispatcher()
 	case partitionDispatchRuleTable:
@@ -246,7 +332,7 @@ func getPartitionDispatcher(
 		d = partition.NewDefaultDispatcher(enableOldValue)
 	}
 
-	return d
+	return d, nil
 }
 
 // getTopicDispatcher returns the topic dispatcher for a specific topic rule (aka topic expression).
@@ -266,7 +352,9 @@ func getTopicDispatcher(
 			return nil, cerror.WrapError(cerror.ErrKafkaInvalidConfig, err)
 		}
 
-		if p == config.ProtocolAvro {
+		// The schemas are registered by the topic names, so a topic can
+		// only carry the events of one table.
+		if p == config.ProtocolAvro || p == config.ProtocolProtobuf {
 			err := topicExpr.ValidateForAvro()
 			if err != nil {
 				return nil, err
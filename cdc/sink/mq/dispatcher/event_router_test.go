@@ -17,6 +17,8 @@ import (
 	"testing"
 
 	timodel "github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/types"
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/cdc/sink/mq/dispatcher/partition"
 	"github.com/pingcap/tiflow/cdc/sink/mq/dispatcher/topic"
@@ -487,3 +489,88 @@ func TestGetTopicForDDL(t *testing.T) {
 		require.Equal(t, test.expectedTopic, d.GetTopicForDDL(test.ddl))
 	}
 }
+
+func TestTopicOverride(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.GetDefaultReplicaConfig()
+	cfg.Sink.DispatchRules = []*config.DispatchRule{
+		{Matcher: []string{"test1.*"}, TopicRule: "{schema}_{table}"},
+		{Matcher: []string{"test2.*"}, TopicRule: "{schema}", PartitionNum: 6},
+		{Matcher: []string{"test3.*"}, TopicRule: "", ReplicationFactor: 3},
+	}
+	d, err := NewEventRouter(cfg, "test")
+	require.Nil(t, err)
+
+	// The overrides are unknown until the rule dispatches events to the topic.
+	partitionNum, replicationFactor := d.TopicOverride("test2")
+	require.Equal(t, int32(0), partitionNum)
+	require.Equal(t, int16(0), replicationFactor)
+
+	topics := d.GetActiveTopics([]model.TableName{
+		{Schema: "test1", Table: "t1"},
+		{Schema: "test2", Table: "t2"},
+		{Schema: "test3", Table: "t3"},
+	})
+	require.Equal(t, []string{"test1_t1", "test2", "test"}, topics)
+
+	partitionNum, replicationFactor = d.TopicOverride("test2")
+	require.Equal(t, int32(6), partitionNum)
+	require.Equal(t, int16(0), replicationFactor)
+	// The {schema} rule doesn't override the topics of the other rules.
+	partitionNum, replicationFactor = d.TopicOverride("test1_t1")
+	require.Equal(t, int32(0), partitionNum)
+	require.Equal(t, int16(0), replicationFactor)
+	// The default topic is created with the settings of the sink URI.
+	partitionNum, replicationFactor = d.TopicOverride("test")
+	require.Equal(t, int32(0), partitionNum)
+	require.Equal(t, int16(0), replicationFactor)
+}
+
+func TestEventRouterColumnsAndExpression(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.GetDefaultReplicaConfig()
+	cfg.Sink.DispatchRules = []*config.DispatchRule{
+		{
+			Matcher:          []string{"test_columns.*"},
+			PartitionRule:    "columns",
+			PartitionColumns: []string{"id"},
+		},
+		{
+			Matcher:             []string{"test_expression.*"},
+			PartitionRule:       "expression",
+			PartitionExpression: "lower(name)",
+		},
+	}
+	d, err := NewEventRouter(cfg, "test")
+	require.Nil(t, err)
+	_, partitionDispatcher := d.matchDispatcher("test_columns", "t")
+	require.IsType(t, &partition.ColumnsDispatcher{}, partitionDispatcher)
+	_, partitionDispatcher = d.matchDispatcher("test_expression", "t")
+	require.IsType(t, &partition.ExpressionDispatcher{}, partitionDispatcher)
+
+	newTableInfo := func(schema, table string, columns ...string) *model.TableInfo {
+		ti := &timodel.TableInfo{Name: timodel.NewCIStr(table)}
+		for i, name := range columns {
+			ti.Columns = append(ti.Columns, &timodel.ColumnInfo{
+				ID: int64(i + 1), Name: timodel.NewCIStr(name), Offset: i,
+				State: timodel.StatePublic, FieldType: *types.NewFieldType(mysql.TypeVarchar),
+			})
+		}
+		return model.WrapTableInfo(1, schema, 1, ti)
+	}
+	require.Nil(t, d.VerifyTables([]*model.TableInfo{
+		newTableInfo("test_columns", "t", "ID", "name"),
+		newTableInfo("test_expression", "t", "id", "name"),
+		newTableInfo("test", "t"),
+	}))
+	err = d.VerifyTables([]*model.TableInfo{newTableInfo("test_columns", "t", "name")})
+	require.Regexp(t, ".*cannot find partition column 'id' from table 'test_columns.t'.*", err)
+	err = d.VerifyTables([]*model.TableInfo{newTableInfo("test_expression", "t", "id")})
+	require.Regexp(t, ".*Cannot find column 'name'.*", err)
+
+	cfg.Sink.DispatchRules[1].PartitionExpression = "lower(name"
+	_, err = NewEventRouter(cfg, "test")
+	require.Regexp(t, ".*invalid partition expression.*", err)
+}
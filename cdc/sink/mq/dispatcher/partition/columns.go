@@ -0,0 +1,101 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package partition
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/pingcap/tiflow/cdc/model"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/hash"
+)
+
+// ColumnsDispatcher is a partition dispatcher which dispatches events based on
+// the values of the given columns. The columns are looked up by their names,
+// so the partition of a row doesn't change when other columns are added,
+// dropped or reordered. The schema and table names are hashed only if
+// includeTable is set, otherwise the partition doesn't change after the table
+// is renamed.
+type ColumnsDispatcher struct {
+	hasher       *hash.PositionInertia
+	lock         sync.Mutex
+	columns      []string
+	includeTable bool
+}
+
+// NewColumnsDispatcher creates a ColumnsDispatcher.
+func NewColumnsDispatcher(columns []string, includeTable bool) *ColumnsDispatcher {
+	return &ColumnsDispatcher{
+		hasher:       hash.NewPositionInertia(),
+		columns:      columns,
+		includeTable: includeTable,
+	}
+}
+
+// VerifyTable implements the TableVerifier interface, all the columns must
+// exist in the table.
+func (r *ColumnsDispatcher) VerifyTable(tableInfo *model.TableInfo) error {
+	for _, name := range r.columns {
+		found := false
+		for _, col := range tableInfo.Columns {
+			if strings.EqualFold(col.Name.O, name) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return cerror.ErrPartitionColumnNotFound.GenWithStackByArgs(
+				name, tableInfo.TableName.String())
+		}
+	}
+	return nil
+}
+
+// DispatchRowChangedEvent returns the target partition to which
+// a row changed event should be dispatched.
+func (r *ColumnsDispatcher) DispatchRowChangedEvent(row *model.RowChangedEvent, partitionNum int32) int32 {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.hasher.Reset()
+	if r.includeTable {
+		r.hasher.Write([]byte(row.Table.Schema), []byte(row.Table.Table))
+	}
+
+	dispatchCols := row.Columns
+	if len(row.Columns) == 0 {
+		dispatchCols = row.PreColumns
+	}
+	for _, name := range r.columns {
+		// A missing column is hashed as null, so the rows written before
+		// the column is added stay in the same partition.
+		var value interface{}
+		if col := findColumn(dispatchCols, name); col != nil {
+			value = col.Value
+		}
+		r.hasher.Write([]byte(name), []byte(model.ColumnValueString(value)))
+	}
+	return int32(r.hasher.Sum32() % uint32(partitionNum))
+}
+
+// findColumn returns the column of the name, the column names are case
+// insensitive.
+func findColumn(cols []*model.Column, name string) *model.Column {
+	for _, col := range cols {
+		if col != nil && strings.EqualFold(col.Name, name) {
+			return col
+		}
+	}
+	return nil
+}
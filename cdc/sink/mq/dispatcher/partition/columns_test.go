@@ -0,0 +1,80 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package partition
+
+import (
+	"testing"
+
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestColumnsDispatcher(t *testing.T) {
+	t.Parallel()
+
+	table := &model.TableName{Schema: "test", Table: "t1"}
+	p := NewColumnsDispatcher([]string{"ID", "region"}, false)
+	partition := p.DispatchRowChangedEvent(&model.RowChangedEvent{
+		Table: table,
+		Columns: []*model.Column{
+			{Name: "id", Value: 1},
+			{Name: "region", Value: "us"},
+			{Name: "name", Value: "a"},
+		},
+	}, 16)
+
+	// The partition doesn't change with the other columns.
+	require.Equal(t, partition, p.DispatchRowChangedEvent(&model.RowChangedEvent{
+		Table: table,
+		Columns: []*model.Column{
+			{Name: "added", Value: 2},
+			{Name: "region", Value: "us"},
+			{Name: "id", Value: 1},
+		},
+	}, 16))
+	// The partition doesn't change after the table is renamed.
+	require.Equal(t, partition, p.DispatchRowChangedEvent(&model.RowChangedEvent{
+		Table: &model.TableName{Schema: "test", Table: "t2"},
+		Columns: []*model.Column{
+			{Name: "id", Value: 1},
+			{Name: "region", Value: "us"},
+		},
+	}, 16))
+	// The deleted rows are dispatched by the old values.
+	require.Equal(t, partition, p.DispatchRowChangedEvent(&model.RowChangedEvent{
+		Table: table,
+		PreColumns: []*model.Column{
+			{Name: "id", Value: 1},
+			{Name: "region", Value: "us"},
+		},
+	}, 16))
+
+	// A missing column is hashed as null.
+	require.Equal(t, p.DispatchRowChangedEvent(&model.RowChangedEvent{
+		Table:   table,
+		Columns: []*model.Column{{Name: "id", Value: 1}},
+	}, 16), p.DispatchRowChangedEvent(&model.RowChangedEvent{
+		Table:   table,
+		Columns: []*model.Column{{Name: "id", Value: 1}, {Name: "region", Value: nil}},
+	}, 16))
+}
+
+func TestColumnsDispatcherVerifyTable(t *testing.T) {
+	t.Parallel()
+
+	tableInfo := newTestTableInfo(t, "id", "region")
+	require.Nil(t, NewColumnsDispatcher([]string{"ID", "region"}, true).VerifyTable(tableInfo))
+	err := NewColumnsDispatcher([]string{"id", "reigon"}, true).VerifyTable(tableInfo)
+	require.Regexp(t, ".*cannot find partition column 'reigon' from table 'test.t1'.*", err)
+}
@@ -23,3 +23,10 @@ type Dispatcher interface {
 	// Concurrency Note: This method is thread-safe.
 	DispatchRowChangedEvent(row *model.RowChangedEvent, partitionNum int32) int32
 }
+
+// TableVerifier is implemented by the dispatchers which depend on the columns
+// of the tables, it checks whether the dispatcher can dispatch the rows of a
+// table.
+type TableVerifier interface {
+	VerifyTable(tableInfo *model.TableInfo) error
+}
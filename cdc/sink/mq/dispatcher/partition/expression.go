@@ -0,0 +1,83 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package partition
+
+import (
+	"sync"
+
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/cdc/model"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/filter"
+	"github.com/pingcap/tiflow/pkg/hash"
+	"go.uber.org/zap"
+)
+
+// ExpressionDispatcher is a partition dispatcher which dispatches events based
+// on the result of a SQL expression evaluated over the row values, e.g.
+// `lower(concat(region, '-', substr(user_id, 1, 4)))`. The expression is
+// evaluated by the same engine as the expression filters. The schema and
+// table names are hashed only if includeTable is set, otherwise the partition
+// doesn't change after the table is renamed.
+type ExpressionDispatcher struct {
+	hasher       *hash.PositionInertia
+	lock         sync.Mutex
+	expr         *filter.RowExpr
+	includeTable bool
+}
+
+// NewExpressionDispatcher creates an ExpressionDispatcher, it returns an error
+// if the syntax of the expression is invalid.
+func NewExpressionDispatcher(expr string, includeTable bool) (*ExpressionDispatcher, error) {
+	e, err := filter.NewRowExpr(expr)
+	if err != nil {
+		return nil, cerror.ErrPartitionExpressionInvalid.GenWithStackByArgs(expr, err.Error())
+	}
+	return &ExpressionDispatcher{
+		hasher:       hash.NewPositionInertia(),
+		expr:         e,
+		includeTable: includeTable,
+	}, nil
+}
+
+// VerifyTable implements the TableVerifier interface, the columns referred
+// by the expression must exist in the table.
+func (r *ExpressionDispatcher) VerifyTable(tableInfo *model.TableInfo) error {
+	return r.expr.Verify(tableInfo)
+}
+
+// DispatchRowChangedEvent returns the target partition to which
+// a row changed event should be dispatched. A row whose expression fails to
+// be evaluated is dispatched as if the result is null.
+func (r *ExpressionDispatcher) DispatchRowChangedEvent(row *model.RowChangedEvent, partitionNum int32) int32 {
+	value := model.ColumnValueString(nil)
+	d, err := r.expr.Eval(row)
+	if err == nil && !d.IsNull() {
+		value, err = d.ToString()
+	}
+	if err != nil {
+		log.Warn("fail to evaluate the partition expression, dispatch the row as null",
+			zap.Stringer("table", row.Table), zap.Error(err))
+		value = model.ColumnValueString(nil)
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.hasher.Reset()
+	if r.includeTable {
+		r.hasher.Write([]byte(row.Table.Schema), []byte(row.Table.Table))
+	}
+	r.hasher.Write([]byte(value))
+	return int32(r.hasher.Sum32() % uint32(partitionNum))
+}
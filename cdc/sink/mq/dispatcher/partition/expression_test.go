@@ -0,0 +1,124 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package partition
+
+import (
+	"testing"
+
+	timodel "github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestTableInfo returns the info of table test.t1 with the given columns,
+// the column `id` is an INT and the others are VARCHAR.
+func newTestTableInfo(t *testing.T, columns ...string) *model.TableInfo {
+	t.Helper()
+
+	ti := &timodel.TableInfo{ID: 1, Name: timodel.NewCIStr("t1")}
+	for i, name := range columns {
+		ft := types.NewFieldType(mysql.TypeVarchar)
+		if name == "id" {
+			ft = types.NewFieldType(mysql.TypeLong)
+		}
+		ti.Columns = append(ti.Columns, &timodel.ColumnInfo{
+			ID: int64(i + 1), Name: timodel.NewCIStr(name), Offset: i,
+			State: timodel.StatePublic, FieldType: *ft,
+		})
+	}
+	return model.WrapTableInfo(1, "test", 1, ti)
+}
+
+func TestExpressionDispatcher(t *testing.T) {
+	t.Parallel()
+
+	tableInfo := newTestTableInfo(t, "id", "email")
+	table := &tableInfo.TableName
+	p, err := NewExpressionDispatcher("lower(email)", false)
+	require.Nil(t, err)
+	partition := p.DispatchRowChangedEvent(&model.RowChangedEvent{
+		Table:     table,
+		TableInfo: tableInfo,
+		Columns:   []*model.Column{{Name: "id", Value: int64(1)}, {Name: "email", Value: "A@B.com"}},
+	}, 16)
+	require.Equal(t, partition, p.DispatchRowChangedEvent(&model.RowChangedEvent{
+		Table:     table,
+		TableInfo: tableInfo,
+		Columns:   []*model.Column{{Name: "email", Value: "a@b.COM"}, {Name: "id", Value: int64(2)}},
+	}, 16))
+	// The deleted rows are dispatched by the old values.
+	require.Equal(t, partition, p.DispatchRowChangedEvent(&model.RowChangedEvent{
+		Table:      table,
+		TableInfo:  tableInfo,
+		PreColumns: []*model.Column{{Name: "email", Value: "a@b.com"}},
+	}, 16))
+
+	// The partition doesn't change after the columns are reordered and the
+	// table is renamed.
+	renamed := newTestTableInfo(t, "email", "name", "id")
+	renamed.TableName.Table = "t2"
+	renamed.Version = 2
+	require.Equal(t, partition, p.DispatchRowChangedEvent(&model.RowChangedEvent{
+		Table:     &renamed.TableName,
+		TableInfo: renamed,
+		Columns:   []*model.Column{{Name: "email", Value: "a@B.com"}, {Name: "name", Value: "a"}},
+	}, 16))
+
+	// A null result is hashed as null, like a row failing to be evaluated.
+	require.Equal(t, p.DispatchRowChangedEvent(&model.RowChangedEvent{
+		Table:     table,
+		TableInfo: tableInfo,
+		Columns:   []*model.Column{{Name: "id", Value: int64(1)}},
+	}, 16), p.DispatchRowChangedEvent(&model.RowChangedEvent{
+		Table:   table,
+		Columns: []*model.Column{{Name: "email", Value: "a@b.com"}},
+	}, 16))
+}
+
+func TestExpressionDispatcherFunctions(t *testing.T) {
+	t.Parallel()
+
+	tableInfo := newTestTableInfo(t, "id", "region")
+	row := &model.RowChangedEvent{
+		Table:     &tableInfo.TableName,
+		TableInfo: tableInfo,
+		Columns:   []*model.Column{{Name: "id", Value: int64(12345)}, {Name: "region", Value: " US "}},
+	}
+	for expr, equivalent := range map[string]string{
+		"concat(trim(region), '-', substr(id, 2, 3))": "'US-234'",
+		"id % 16":         "9",
+		"lower(`region`)": "' us '",
+	} {
+		p, err := NewExpressionDispatcher(expr, true)
+		require.Nil(t, err)
+		expected, err := NewExpressionDispatcher(equivalent, true)
+		require.Nil(t, err)
+		require.Equal(t, expected.DispatchRowChangedEvent(row, 16), p.DispatchRowChangedEvent(row, 16), expr)
+	}
+}
+
+func TestExpressionDispatcherVerifyTable(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewExpressionDispatcher("lower(email", false)
+	require.Regexp(t, ".*invalid partition expression 'lower\\(email'.*", err)
+
+	p, err := NewExpressionDispatcher("lower(email)", false)
+	require.Nil(t, err)
+	require.Nil(t, p.VerifyTable(newTestTableInfo(t, "id", "email")))
+	err = p.VerifyTable(newTestTableInfo(t, "id", "mail"))
+	require.Regexp(t, ".*Cannot find column 'email' from table 'test.t1'.*", err)
+}
@@ -48,18 +48,26 @@ func (s *StaticTopicDispatcher) String() string {
 // dynamically to the target topics.
 type DynamicTopicDispatcher struct {
 	expression Expression
+	// template is the parsed expression, which is parsed only once because
+	// the topics of all the rows are substituted by it.
+	template template
 }
 
 // NewDynamicTopicDispatcher creates a DynamicTopicDispatcher.
 func NewDynamicTopicDispatcher(topicExpr Expression) *DynamicTopicDispatcher {
+	t, err := topicExpr.parse()
+	if err != nil {
+		t = legacyTemplate(string(topicExpr))
+	}
 	return &DynamicTopicDispatcher{
 		expression: topicExpr,
+		template:   t,
 	}
 }
 
 // Substitute converts schema/table name in a topic expression to kafka topic name.
 func (d *DynamicTopicDispatcher) Substitute(schema, table string) string {
-	return d.expression.Substitute(schema, table)
+	return d.template.substitute(schema, table)
 }
 
 func (d *DynamicTopicDispatcher) String() string {
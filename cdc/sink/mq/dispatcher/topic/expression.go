@@ -14,73 +14,82 @@
 package topic
 
 import (
+	"hash/fnv"
 	"regexp"
+	"strconv"
+	"strings"
 
 	"github.com/pingcap/tiflow/pkg/errors"
 )
 
 var (
-	// topicNameRE is used to match a valid topic expression
-	topicNameRE = regexp.MustCompile(
-		`^[A-Za-z0-9\._\-]*\{schema\}([A-Za-z0-9\._\-]*\{table\})?[A-Za-z0-9\._\-]*$`,
-	)
 	// kafkaForbidRE is used to reject the characters which are forbidden in kafka topic name
 	kafkaForbidRE = regexp.MustCompile(`[^a-zA-Z0-9\._\-]`)
-	// schemaRE is used to match substring '{schema}' in topic expression
-	schemaRE = regexp.MustCompile(`\{schema\}`)
-	// tableRE is used to match substring '{table}' in topic expression
-	tableRE = regexp.MustCompile(`\{table\}`)
-	// avro has different topic name pattern requirements, '{schema}' and '{table}' placeholders
-	// are necessary
-	avroTopicNameRE = regexp.MustCompile(
-		`^[A-Za-z0-9\._\-]*\{schema\}[A-Za-z0-9\._\-]*\{table\}[A-Za-z0-9\._\-]*$`,
-	)
+	// placeholderRE is used to match the placeholders in topic expression
+	placeholderRE = regexp.MustCompile(`\{[^{}]*\}`)
+	// legacyPlaceholderRE is used to match substring '{schema}' or '{table}' in topic expression
+	legacyPlaceholderRE = regexp.MustCompile(`\{schema\}|\{table\}`)
+	// literalRE is used to match the static parts of topic expression
+	literalRE = regexp.MustCompile(`^[A-Za-z0-9\._\-]*$`)
 )
 
 // The max length of kafka topic name is 249.
 // See https://github.com/apache/kafka/blob/trunk/clients/src/main/java/org/apache/kafka/common/internals/Topic.java#L35
 const kafkaTopicNameMaxLength = 249
 
+// maxHashBuckets is the max number of the buckets of the {hash:N} placeholder.
+const maxHashBuckets = 10000
+
 // Expression represent a kafka topic expression.
-// The expression should be in form of: [prefix]{schema}[middle][{table}][suffix]
-// prefix/suffix/middle are optional and should match the regex of [A-Za-z0-9\._\-]*
-// {table} can also be optional.
+// The expression consists of static parts, which should match the regex of
+// [A-Za-z0-9\._\-]*, and at least one placeholder in form of
+// {name[:arg][|filter[:arg]]...}. The placeholders are:
+//   - {schema} and {table}, the names of the schema and the table.
+//   - {hash:N}, the bucket in [0, N) of the hash of the schema and table names,
+//     which spreads the tables across N topics deterministically.
+//
+// The filters of {schema} and {table} are:
+//   - lower and upper, which convert the name to lower or upper case letters.
+//   - replace:S, which replaces the characters forbidden in kafka topic name
+//     with S instead of underscore '_'.
+//
+// For example, cdc_{schema|lower}.{table|replace:-} and cdc_{hash:16}.
 type Expression string
 
 // Validate checks whether a kafka topic name is valid or not.
 func (e Expression) Validate() error {
-	// validate the topic expression
-	if ok := topicNameRE.MatchString(string(e)); !ok {
-		return errors.ErrKafkaInvalidTopicExpression.GenWithStackByArgs()
-	}
-
-	return nil
+	_, err := e.parse()
+	return err
 }
 
-// ValidateForAvro checks whether topic pattern is {schema}_{table}, the only allowed
+// ValidateForAvro checks whether topic pattern contains {schema} and {table},
+// so that a topic only carries the events of one table.
 func (e Expression) ValidateForAvro() error {
-	if ok := avroTopicNameRE.MatchString(string(e)); !ok {
+	t, err := e.parse()
+	if err != nil {
+		return err
+	}
+	if !t.has(placeholderSchema) || !t.has(placeholderTable) {
 		return errors.ErrKafkaInvalidTopicExpression.GenWithStackByArgs(
 			"topic rule for Avro must contain {schema} and {table}",
 		)
 	}
-
 	return nil
 }
 
 // Substitute converts schema/table name in a topic expression to kafka topic name.
 // When doing conversion, the special characters other than [A-Za-z0-9\._\-] in schema/table
-// will be substituted for underscore '_'.
+// will be substituted for underscore '_', unless the replace filter is used.
 func (e Expression) Substitute(schema, table string) string {
-	// some of the special characters will be replaced with '_'
-	replacedSchema := kafkaForbidRE.ReplaceAllString(schema, "_")
-	replacedTable := kafkaForbidRE.ReplaceAllString(table, "_")
-
-	topicExpr := string(e)
-	// doing the real conversion things
-	topicName := schemaRE.ReplaceAllString(topicExpr, replacedSchema)
-	topicName = tableRE.ReplaceAllString(topicName, replacedTable)
+	t, err := e.parse()
+	if err != nil {
+		t = legacyTemplate(string(e))
+	}
+	return t.substitute(schema, table)
+}
 
+// normalizeTopicName makes the topic name valid for kafka.
+func normalizeTopicName(topicName string) string {
 	// topicName will be truncated if it exceed the limit.
 	// And topicName '.' and '..' are also invalid, replace them with '_'.
 	//    See https://github.com/apache/kafka/blob/trunk/clients/src/main/java/org/apache/kafka/common/internals/Topic.java#L46
@@ -94,3 +103,170 @@ func (e Expression) Substitute(schema, table string) string {
 		return topicName
 	}
 }
+
+type placeholderKind int
+
+const (
+	placeholderNone placeholderKind = iota
+	placeholderSchema
+	placeholderTable
+	placeholderHash
+)
+
+// segment is a static part or a placeholder of a topic expression.
+type segment struct {
+	kind    placeholderKind
+	literal string
+	// buckets is the number of the buckets of {hash:N}.
+	buckets uint32
+	// lower and upper are the case filters of {schema} and {table}.
+	lower, upper bool
+	// replacement replaces the characters forbidden in kafka topic name.
+	replacement string
+}
+
+// template is a parsed topic expression.
+type template []segment
+
+// parse parses the topic expression into a template.
+func (e Expression) parse() (template, error) {
+	expr := string(e)
+	var t template
+	hasPlaceholder := false
+	last := 0
+	for _, loc := range placeholderRE.FindAllStringIndex(expr, -1) {
+		if !literalRE.MatchString(expr[last:loc[0]]) {
+			return nil, errors.ErrKafkaInvalidTopicExpression.GenWithStackByArgs()
+		}
+		if last < loc[0] {
+			t = append(t, segment{literal: expr[last:loc[0]]})
+		}
+		seg, err := parsePlaceholder(expr[loc[0]+1 : loc[1]-1])
+		if err != nil {
+			return nil, err
+		}
+		t = append(t, seg)
+		hasPlaceholder = true
+		last = loc[1]
+	}
+	if !literalRE.MatchString(expr[last:]) || !hasPlaceholder {
+		return nil, errors.ErrKafkaInvalidTopicExpression.GenWithStackByArgs()
+	}
+	if last < len(expr) {
+		t = append(t, segment{literal: expr[last:]})
+	}
+	return t, nil
+}
+
+// parsePlaceholder parses a placeholder without the braces.
+func parsePlaceholder(placeholder string) (segment, error) {
+	invalid := func(reason string) error {
+		return errors.ErrKafkaInvalidTopicExpression.GenWithStack(
+			"invalid topic expression, {%s}: %s", placeholder, reason)
+	}
+
+	parts := strings.Split(placeholder, "|")
+	name, arg, hasArg := strings.Cut(parts[0], ":")
+	seg := segment{replacement: "_"}
+	switch name {
+	case "schema", "table":
+		if hasArg {
+			return seg, invalid("unexpected argument")
+		}
+		seg.kind = placeholderSchema
+		if name == "table" {
+			seg.kind = placeholderTable
+		}
+	case "hash":
+		n, err := strconv.ParseUint(arg, 10, 32)
+		if err != nil || n == 0 || n > maxHashBuckets {
+			return seg, invalid("the number of buckets must be in [1, " +
+				strconv.Itoa(maxHashBuckets) + "]")
+		}
+		if len(parts) > 1 {
+			return seg, invalid("hash can't be filtered")
+		}
+		seg.kind, seg.buckets = placeholderHash, uint32(n)
+		return seg, nil
+	default:
+		return seg, invalid("unknown placeholder")
+	}
+
+	for _, filter := range parts[1:] {
+		filterName, filterArg, hasFilterArg := strings.Cut(filter, ":")
+		switch {
+		case filterName == "lower" && !hasFilterArg:
+			seg.lower, seg.upper = true, false
+		case filterName == "upper" && !hasFilterArg:
+			seg.lower, seg.upper = false, true
+		case filterName == "replace" && hasFilterArg && literalRE.MatchString(filterArg):
+			seg.replacement = filterArg
+		default:
+			return seg, invalid("invalid filter '" + filter + "'")
+		}
+	}
+	return seg, nil
+}
+
+func (t template) has(kind placeholderKind) bool {
+	for _, seg := range t {
+		if seg.kind == kind {
+			return true
+		}
+	}
+	return false
+}
+
+func (t template) substitute(schema, table string) string {
+	var b strings.Builder
+	for _, seg := range t {
+		var name string
+		switch seg.kind {
+		case placeholderNone:
+			b.WriteString(seg.literal)
+			continue
+		case placeholderHash:
+			b.WriteString(strconv.FormatUint(uint64(hashBucket(schema, table, seg.buckets)), 10))
+			continue
+		case placeholderSchema:
+			name = schema
+		case placeholderTable:
+			name = table
+		}
+		if seg.lower {
+			name = strings.ToLower(name)
+		} else if seg.upper {
+			name = strings.ToUpper(name)
+		}
+		b.WriteString(kafkaForbidRE.ReplaceAllString(name, seg.replacement))
+	}
+	return normalizeTopicName(b.String())
+}
+
+// hashBucket returns the bucket of the table, which only depends on the
+// schema and table names.
+func hashBucket(schema, table string, buckets uint32) uint32 {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(schema))
+	_, _ = h.Write([]byte{0})
+	_, _ = h.Write([]byte(table))
+	return h.Sum32() % buckets
+}
+
+// legacyTemplate returns the template of an invalid expression, in which
+// only {schema} and {table} are placeholders. The expression isn't validated
+// if the protocol isn't specified, so it's converted like before.
+func legacyTemplate(expr string) template {
+	var t template
+	last := 0
+	for _, loc := range legacyPlaceholderRE.FindAllStringIndex(expr, -1) {
+		kind := placeholderSchema
+		if expr[loc[0]:loc[1]] == "{table}" {
+			kind = placeholderTable
+		}
+		t = append(t, segment{literal: expr[last:loc[0]]},
+			segment{kind: kind, replacement: "_"})
+		last = loc[1]
+	}
+	return append(t, segment{literal: expr[last:]})
+}
@@ -227,6 +227,56 @@ func TestSubstituteTopicExpression(t *testing.T) {
 	}
 }
 
+func TestSubstituteTopicTemplate(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		expression string
+		schema     string
+		table      string
+		expected   string
+	}{
+		{expression: "{schema|lower}_{table|upper}", schema: "Hello", table: "World", expected: "hello_WORLD"},
+		{expression: "{table}.{schema}", schema: "hello", table: "world", expected: "world.hello"},
+		{expression: "{table}", schema: "hello", table: "world", expected: "world"},
+		{expression: "cdc.{schema|replace:-}", schema: "a b!c", expected: "cdc.a-b-c"},
+		{expression: "cdc.{schema|replace:}", schema: "a b!c", expected: "cdc.abc"},
+		{expression: "cdc.{schema|lower|replace:.}", schema: "A B", expected: "cdc.a.b"},
+		{expression: "prefix_{hash:1}_suffix", schema: "hello", table: "world", expected: "prefix_0_suffix"},
+	}
+	for _, tc := range cases {
+		topicExpr := Expression(tc.expression)
+		require.Nil(t, topicExpr.Validate(), tc.expression)
+		require.Equal(t, tc.expected, topicExpr.Substitute(tc.schema, tc.table), tc.expression)
+		require.Equal(t, tc.expected,
+			NewDynamicTopicDispatcher(topicExpr).Substitute(tc.schema, tc.table), tc.expression)
+	}
+
+	// The hash buckets are deterministic and bounded.
+	topicExpr := Expression("cdc_{hash:8}")
+	buckets := make(map[string]struct{})
+	for i := 0; i < 1000; i++ {
+		table := fmt.Sprintf("t%d", i)
+		topicName := topicExpr.Substitute("test", table)
+		require.Equal(t, topicName, topicExpr.Substitute("test", table))
+		buckets[topicName] = struct{}{}
+	}
+	require.Len(t, buckets, 8)
+	require.Contains(t, buckets, "cdc_0")
+	require.Contains(t, buckets, "cdc_7")
+
+	for _, expr := range []string{
+		"cdc", "{hash}", "{hash:0}", "{hash:10001}", "{hash:8|lower}",
+		"{schema:1}", "{schema|title}", "{schema|replace:!}", "{schema|lower:1}",
+	} {
+		require.Regexp(t, ".*invalid topic expression.*", Expression(expr).Validate(), expr)
+	}
+
+	require.Nil(t, Expression("{schema|lower}_{table}_{hash:4}").ValidateForAvro())
+	require.Regexp(t, ".*must contain \\{schema\\} and \\{table\\}.*",
+		Expression("cdc_{hash:4}").ValidateForAvro())
+}
+
 // cmd: go test -run='^$' -bench '^(BenchmarkSubstitute)$' github.com/pingcap/tiflow/cdc/sink/dispatcher/topic
 // goos: linux
 // goarch: amd64
@@ -23,35 +23,48 @@ import (
 	"github.com/Shopify/sarama"
 	"github.com/pingcap/errors"
 	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/cdc/model"
 	cerror "github.com/pingcap/tiflow/pkg/errors"
 	"github.com/pingcap/tiflow/pkg/retry"
 	"github.com/pingcap/tiflow/pkg/sink/kafka"
+	"github.com/pingcap/tiflow/pkg/warning"
 	"go.uber.org/atomic"
 	"go.uber.org/zap"
 )
 
+// maxMetadataStaleness is how long the cached partitions of a topic are
+// still used after the metadata of the cluster fails to be refreshed.
+const maxMetadataStaleness = 5 * time.Minute
+
 // kafkaTopicManager is a manager for kafka topics.
 type kafkaTopicManager struct {
+	changefeedID model.ChangeFeedID
+
 	client kafka.Client
 	admin  kafka.ClusterAdminClient
 
 	cfg *kafka.AutoCreateTopicConfig
 
 	topics sync.Map
+	// usedTopics are the topics used by the sink, the cache may also
+	// contain the other topics in the cluster.
+	usedTopics sync.Map
 
 	lastMetadataRefresh atomic.Int64
 }
 
 // NewKafkaTopicManager creates a new topic manager.
 func NewKafkaTopicManager(
+	changefeedID model.ChangeFeedID,
 	client kafka.Client,
 	admin kafka.ClusterAdminClient,
 	cfg *kafka.AutoCreateTopicConfig,
 ) (*kafkaTopicManager, error) {
 	mgr := &kafkaTopicManager{
-		client: client,
-		admin:  admin,
-		cfg:    cfg,
+		changefeedID: changefeedID,
+		client:       client,
+		admin:        admin,
+		cfg:          cfg,
 	}
 
 	// do an initial metadata fetching using ListTopics
@@ -59,6 +72,7 @@ func NewKafkaTopicManager(
 	if err != nil {
 		return nil, err
 	}
+	register(changefeedID, mgr)
 
 	return mgr, nil
 }
@@ -67,11 +81,20 @@ func NewKafkaTopicManager(
 // It may also try to update the topics' information maintained by manager.
 func (m *kafkaTopicManager) GetPartitionNum(topic string) (int32, error) {
 	err := m.tryRefreshMeta()
+	partitions, ok := m.topics.Load(topic)
 	if err != nil {
-		return 0, errors.Trace(err)
+		staleness := time.Since(time.Unix(m.lastMetadataRefresh.Load(), 0))
+		if !ok || staleness > maxMetadataStaleness {
+			return 0, errors.Trace(err)
+		}
+		// The partitions of a known topic rarely change, so the stale
+		// metadata is used for a while until the next refresh succeeds.
+		warning.Record(m.changefeedID, cerror.ErrKafkaRefreshTopicMetadata,
+			fmt.Sprintf("use the partitions of topic %s refreshed %s ago: %s",
+				topic, staleness.Truncate(time.Second), err))
 	}
-
-	if partitions, ok := m.topics.Load(topic); ok {
+	if ok {
+		m.usedTopics.Store(topic, struct{}{})
 		return partitions.(int32), nil
 	}
 
@@ -273,18 +296,19 @@ func (m *kafkaTopicManager) createTopic(topicName string) (int32, error) {
 				"and %s not found", topicName))
 	}
 
+	partitionNum, replicationFactor := m.cfg.TopicDetail(topicName)
 	start := time.Now()
 	err = m.admin.CreateTopic(topicName, &sarama.TopicDetail{
-		NumPartitions:     m.cfg.PartitionNum,
-		ReplicationFactor: m.cfg.ReplicationFactor,
+		NumPartitions:     partitionNum,
+		ReplicationFactor: replicationFactor,
 	}, false)
 	// Ignore the already exists error because it's not harmful.
 	if err != nil && !strings.Contains(err.Error(), sarama.ErrTopicAlreadyExists.Error()) {
 		log.Error(
 			"Kafka admin client create the topic failed",
 			zap.String("topic", topicName),
-			zap.Int32("partitionNumber", m.cfg.PartitionNum),
-			zap.Int16("replicationFactor", m.cfg.ReplicationFactor),
+			zap.Int32("partitionNumber", partitionNum),
+			zap.Int16("replicationFactor", replicationFactor),
 			zap.Error(err),
 			zap.Duration("duration", time.Since(start)),
 		)
@@ -294,13 +318,13 @@ func (m *kafkaTopicManager) createTopic(topicName string) (int32, error) {
 	log.Info(
 		"Kafka admin client create the topic success",
 		zap.String("topic", topicName),
-		zap.Int32("partitionNumber", m.cfg.PartitionNum),
-		zap.Int16("replicationFactor", m.cfg.ReplicationFactor),
+		zap.Int32("partitionNumber", partitionNum),
+		zap.Int16("replicationFactor", replicationFactor),
 		zap.Duration("duration", time.Since(start)),
 	)
-	m.tryUpdatePartitionsAndLogging(topicName, m.cfg.PartitionNum)
+	m.tryUpdatePartitionsAndLogging(topicName, partitionNum)
 
-	return m.cfg.PartitionNum, nil
+	return partitionNum, nil
 }
 
 // CreateTopicAndWaitUntilVisible wraps createTopic and waitUntilTopicVisible together.
@@ -314,6 +338,63 @@ func (m *kafkaTopicManager) CreateTopicAndWaitUntilVisible(topicName string) (in
 	if err != nil {
 		return 0, errors.Trace(err)
 	}
+	m.usedTopics.Store(topicName, struct{}{})
 
 	return partitionNum, nil
 }
+
+// Topics returns the cached partition numbers of the topics used by the sink.
+func (m *kafkaTopicManager) Topics() map[string]int32 {
+	topics := make(map[string]int32)
+	m.usedTopics.Range(func(key, _ any) bool {
+		topic := key.(string)
+		if partitions, ok := m.topics.Load(topic); ok {
+			topics[topic] = partitions.(int32)
+		}
+		return true
+	})
+	return topics
+}
+
+// Invalidate drops the cached topics and lists them from the brokers again.
+// The admin client is used instead of the metadata of the client, which may
+// be stale.
+func (m *kafkaTopicManager) Invalidate() error {
+	m.topics.Range(func(key, _ any) bool {
+		m.topics.Delete(key)
+		return true
+	})
+	log.Info("topic cache is invalidated",
+		zap.String("namespace", m.changefeedID.Namespace),
+		zap.String("changefeed", m.changefeedID.ID))
+	return m.listTopics()
+}
+
+// CheckTopics invalidates the cache and gets the partition numbers of the
+// topics used by the sink, the missing topics are created again.
+func (m *kafkaTopicManager) CheckTopics() (map[string]int32, error) {
+	if err := m.Invalidate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	topics := make(map[string]int32)
+	var err error
+	m.usedTopics.Range(func(key, _ any) bool {
+		topic := key.(string)
+		var partitionNum int32
+		partitionNum, err = m.GetPartitionNum(topic)
+		if err != nil {
+			return false
+		}
+		topics[topic] = partitionNum
+		return true
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return topics, nil
+}
+
+// Close unregisters the manager.
+func (m *kafkaTopicManager) Close() {
+	unregister(m.changefeedID, m)
+}
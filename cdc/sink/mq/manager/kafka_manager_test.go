@@ -17,6 +17,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/pkg/sink/kafka"
 	"github.com/stretchr/testify/require"
 )
@@ -35,7 +37,7 @@ func TestPartitions(t *testing.T) {
 		ReplicationFactor: 1,
 	}
 
-	manager, err := NewKafkaTopicManager(client, adminClient, cfg)
+	manager, err := NewKafkaTopicManager(model.DefaultChangeFeedID("test"), client, adminClient, cfg)
 	require.Nil(t, err)
 	partitionsNum, err := manager.GetPartitionNum(
 		kafka.DefaultMockTopicName)
@@ -57,7 +59,7 @@ func TestTryRefreshMeta(t *testing.T) {
 		ReplicationFactor: 1,
 	}
 
-	manager, err := NewKafkaTopicManager(client, adminClient, cfg)
+	manager, err := NewKafkaTopicManager(model.DefaultChangeFeedID("test"), client, adminClient, cfg)
 	require.Nil(t, err)
 	partitionsNum, err := manager.GetPartitionNum(
 		kafka.DefaultMockTopicName)
@@ -79,6 +81,54 @@ func TestTryRefreshMeta(t *testing.T) {
 	require.Equal(t, int32(4), partitionsNum)
 }
 
+// failingClient fails to fetch the metadata of the topics.
+type failingClient struct {
+	*kafka.ClientMockImpl
+}
+
+func (c *failingClient) Topics() ([]string, error) {
+	return nil, errors.New("kafka cluster is unavailable")
+}
+
+func TestStaleMetadata(t *testing.T) {
+	t.Parallel()
+
+	client := kafka.NewClientMockImpl()
+	adminClient := kafka.NewClusterAdminClientMockImpl()
+	defer func(adminClient *kafka.ClusterAdminClientMockImpl) {
+		_ = adminClient.Close()
+	}(adminClient)
+	cfg := &kafka.AutoCreateTopicConfig{
+		AutoCreate:        true,
+		PartitionNum:      2,
+		ReplicationFactor: 1,
+	}
+
+	manager, err := NewKafkaTopicManager(model.DefaultChangeFeedID("test"), client, adminClient, cfg)
+	require.Nil(t, err)
+	partitionsNum, err := manager.GetPartitionNum(
+		kafka.DefaultMockTopicName)
+	require.Nil(t, err)
+	require.Equal(t, int32(3), partitionsNum)
+
+	// The cached partitions are used for a while if the refresh fails.
+	manager.client = &failingClient{ClientMockImpl: client}
+	manager.lastMetadataRefresh.Store(time.Now().Add(-2 * time.Minute).Unix())
+	partitionsNum, err = manager.GetPartitionNum(
+		kafka.DefaultMockTopicName)
+	require.Nil(t, err)
+	require.Equal(t, int32(3), partitionsNum)
+
+	// The unknown topics are not created.
+	_, err = manager.GetPartitionNum("unknown")
+	require.Regexp(t, "kafka cluster is unavailable", err)
+
+	// The cached partitions are too stale to be used.
+	manager.lastMetadataRefresh.Store(time.Now().Add(-maxMetadataStaleness - time.Minute).Unix())
+	_, err = manager.GetPartitionNum(kafka.DefaultMockTopicName)
+	require.Regexp(t, "kafka cluster is unavailable", err)
+}
+
 func TestCreateTopic(t *testing.T) {
 	t.Parallel()
 
@@ -94,7 +144,7 @@ func TestCreateTopic(t *testing.T) {
 		ReplicationFactor: 1,
 	}
 
-	manager, err := NewKafkaTopicManager(client, adminClient, cfg)
+	manager, err := NewKafkaTopicManager(model.DefaultChangeFeedID("test"), client, adminClient, cfg)
 	require.Nil(t, err)
 	partitionNum, err := manager.createTopic(kafka.DefaultMockTopicName)
 	require.Nil(t, err)
@@ -109,7 +159,7 @@ func TestCreateTopic(t *testing.T) {
 
 	// Try to create a topic without auto create.
 	cfg.AutoCreate = false
-	manager, err = NewKafkaTopicManager(client, adminClient, cfg)
+	manager, err = NewKafkaTopicManager(model.DefaultChangeFeedID("test"), client, adminClient, cfg)
 	require.Nil(t, err)
 	_, err = manager.createTopic("new-topic2")
 	require.Regexp(
@@ -125,7 +175,7 @@ func TestCreateTopic(t *testing.T) {
 		PartitionNum:      2,
 		ReplicationFactor: 4,
 	}
-	manager, err = NewKafkaTopicManager(client, adminClient, cfg)
+	manager, err = NewKafkaTopicManager(model.DefaultChangeFeedID("test"), client, adminClient, cfg)
 	require.Nil(t, err)
 	_, err = manager.createTopic("new-topic-failed")
 	require.Regexp(
@@ -149,7 +199,7 @@ func TestCreateTopicWithDelay(t *testing.T) {
 		ReplicationFactor: 1,
 	}
 
-	manager, err := NewKafkaTopicManager(client, adminClient, cfg)
+	manager, err := NewKafkaTopicManager(model.DefaultChangeFeedID("test"), client, adminClient, cfg)
 	require.Nil(t, err)
 	partitionNum, err := manager.createTopic("new_topic")
 	require.Nil(t, err)
@@ -159,3 +209,51 @@ func TestCreateTopicWithDelay(t *testing.T) {
 	require.Nil(t, err)
 	require.Equal(t, int32(2), partitionNum)
 }
+
+func TestInvalidateAndCheckTopics(t *testing.T) {
+	t.Parallel()
+
+	client := kafka.NewClientMockImpl()
+	adminClient := kafka.NewClusterAdminClientMockImpl()
+	defer func(adminClient *kafka.ClusterAdminClientMockImpl) {
+		_ = adminClient.Close()
+	}(adminClient)
+	cfg := &kafka.AutoCreateTopicConfig{
+		AutoCreate:        true,
+		PartitionNum:      2,
+		ReplicationFactor: 1,
+	}
+
+	changefeedID := model.DefaultChangeFeedID("test-invalidate-and-check")
+	manager, err := NewKafkaTopicManager(changefeedID, client, adminClient, cfg)
+	require.Nil(t, err)
+	require.Equal(t, []TopicManager{manager}, Lookup(changefeedID))
+	// Only the topics used by the sink are returned.
+	require.Empty(t, manager.Topics())
+	_, err = manager.CreateTopicAndWaitUntilVisible("new-topic")
+	require.Nil(t, err)
+	require.Equal(t, map[string]int32{"new-topic": 2}, manager.Topics())
+
+	// The topic is deleted out-of-band, but it's still in the cache.
+	require.Nil(t, adminClient.DeleteTopic("new-topic"))
+	require.Equal(t, map[string]int32{"new-topic": 2}, manager.Topics())
+	require.Nil(t, manager.Invalidate())
+	require.Empty(t, manager.Topics())
+
+	topics, err := manager.CheckTopics()
+	require.Nil(t, err)
+	require.Equal(t, map[string]int32{"new-topic": 2}, topics)
+	require.Equal(t, map[string]int32{"new-topic": 2}, manager.Topics())
+	listed, err := adminClient.ListTopics()
+	require.Nil(t, err)
+	require.Contains(t, listed, "new-topic")
+
+	// The topic can't be created again without auto create.
+	cfg.AutoCreate = false
+	require.Nil(t, adminClient.DeleteTopic("new-topic"))
+	_, err = manager.CheckTopics()
+	require.Regexp(t, "`auto-create-topic` is false, and new-topic not found", err)
+
+	manager.Close()
+	require.Empty(t, Lookup(changefeedID))
+}
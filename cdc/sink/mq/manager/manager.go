@@ -21,4 +21,18 @@ type TopicManager interface {
 	GetPartitionNum(topic string) (int32, error)
 	// CreateTopicAndWaitUntilVisible creates the topic and wait for the topic completion.
 	CreateTopicAndWaitUntilVisible(topicName string) (int32, error)
+	// Topics returns the cached partition numbers of the topics used by the
+	// sink, which are believed to exist.
+	Topics() map[string]int32
+	// Invalidate drops the cached topics and fetches them from the brokers
+	// again, so that the topics deleted or re-partitioned out-of-band are
+	// noticed.
+	Invalidate() error
+	// CheckTopics invalidates the cache and makes sure the topics used by
+	// the sink exist, the deleted ones are created again if the
+	// `auto-create-topic` is enabled. It returns the partition numbers of
+	// the topics.
+	CheckTopics() (map[string]int32, error)
+	// Close unregisters the manager, it doesn't close the clients.
+	Close()
 }
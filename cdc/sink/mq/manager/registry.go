@@ -0,0 +1,63 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manager
+
+import (
+	"sync"
+
+	"github.com/pingcap/tiflow/cdc/model"
+)
+
+// registry holds the topic managers of the sinks on this capture, so that
+// they can be inspected and refreshed by the open api.
+var registry = struct {
+	sync.Mutex
+	managers map[model.ChangeFeedID][]TopicManager
+}{
+	managers: make(map[model.ChangeFeedID][]TopicManager),
+}
+
+func register(changefeedID model.ChangeFeedID, m TopicManager) {
+	registry.Lock()
+	defer registry.Unlock()
+	registry.managers[changefeedID] = append(registry.managers[changefeedID], m)
+}
+
+func unregister(changefeedID model.ChangeFeedID, m TopicManager) {
+	registry.Lock()
+	defer registry.Unlock()
+	managers := registry.managers[changefeedID]
+	for i, registered := range managers {
+		if registered == m {
+			managers = append(managers[:i:i], managers[i+1:]...)
+			break
+		}
+	}
+	if len(managers) == 0 {
+		delete(registry.managers, changefeedID)
+		return
+	}
+	registry.managers[changefeedID] = managers
+}
+
+// Lookup returns the topic managers of the changefeed on this capture, the
+// owner and the processor of a changefeed have their own managers.
+func Lookup(changefeedID model.ChangeFeedID) []TopicManager {
+	registry.Lock()
+	defer registry.Unlock()
+	managers := registry.managers[changefeedID]
+	ret := make([]TopicManager, len(managers))
+	copy(ret, managers)
+	return ret
+}
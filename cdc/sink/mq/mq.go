@@ -27,6 +27,7 @@ import (
 	"github.com/pingcap/tiflow/cdc/sink/codec/builder"
 	"github.com/pingcap/tiflow/cdc/sink/codec/common"
 	"github.com/pingcap/tiflow/cdc/sink/metrics"
+	"github.com/pingcap/tiflow/cdc/sink/mq/contract"
 	"github.com/pingcap/tiflow/cdc/sink/mq/dispatcher"
 	"github.com/pingcap/tiflow/cdc/sink/mq/manager"
 	"github.com/pingcap/tiflow/cdc/sink/mq/producer"
@@ -51,8 +52,11 @@ type mqSink struct {
 	encoderBuilder codec.EncoderBuilder
 	protocol       config.Protocol
 
-	topicManager         manager.TopicManager
-	flushWorker          *flushWorker
+	topicManager manager.TopicManager
+	flushWorker  *flushWorker
+	// contracts checks the DDLs against the consumer contracts of the
+	// topics, it is nil if there is no contract.
+	contracts            *contract.Checker
 	tableCheckpointTsMap sync.Map
 	resolvedBuffer       *chann.Chann[resolvedTsEvent]
 
@@ -66,8 +70,8 @@ func newMqSink(
 	ctx context.Context,
 	topicManager manager.TopicManager,
 	mqProducer producer.Producer,
-	defaultTopic string,
-	replicaConfig *config.ReplicaConfig, encoderConfig *common.Config,
+	eventRouter *dispatcher.EventRouter,
+	encoderConfig *common.Config,
 	errCh chan error,
 	changefeedID model.ChangeFeedID,
 ) (*mqSink, error) {
@@ -76,11 +80,6 @@ func newMqSink(
 		return nil, cerror.WrapError(cerror.ErrKafkaInvalidConfig, err)
 	}
 
-	eventRouter, err := dispatcher.NewEventRouter(replicaConfig, defaultTopic)
-	if err != nil {
-		return nil, errors.Trace(err)
-	}
-
 	captureAddr := contextutil.CaptureAddrFromCtx(ctx)
 	role := contextutil.RoleFromCtx(ctx)
 
@@ -281,8 +280,23 @@ func (k *mqSink) EmitCheckpointTs(ctx context.Context, ts uint64, tables []*mode
 // EmitDDLEvent sends a DDL event to the default topic or the table's corresponding topic.
 // Concurrency Note: EmitDDLEvent is thread-safe.
 func (k *mqSink) EmitDDLEvent(ctx context.Context, ddl *model.DDLEvent) error {
+	topic := k.eventRouter.GetTopicForDDL(ddl)
+	// The breaking DDL is rejected before anything of it is sent.
+	if k.contracts != nil {
+		if err := k.contracts.Check(topic, ddl); err != nil {
+			return errors.Trace(err)
+		}
+	}
 	encoder := k.encoderBuilder.Build()
-	msg, err := encoder.EncodeDDLEvent(ddl)
+	var (
+		msg *common.Message
+		err error
+	)
+	if e, ok := encoder.(codec.DDLEventTopicEncoder); ok {
+		msg, err = e.EncodeDDLEventForTopic(ctx, topic, ddl)
+	} else {
+		msg, err = encoder.EncodeDDLEvent(ddl)
+	}
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -290,7 +304,6 @@ func (k *mqSink) EmitDDLEvent(ctx context.Context, ddl *model.DDLEvent) error {
 		return nil
 	}
 
-	topic := k.eventRouter.GetTopicForDDL(ddl)
 	partitionRule := k.eventRouter.GetDLLDispatchRuleByProtocol(k.protocol)
 	k.statistics.AddDDLCount()
 	log.Debug("emit ddl event",
@@ -335,6 +348,7 @@ func (k *mqSink) Close(_ context.Context) error {
 	// We need to close it asynchronously.
 	// Otherwise, we might get stuck with it in an unhealthy state of kafka.
 	go k.mqProducer.Close()
+	k.topicManager.Close()
 	return nil
 }
 
@@ -397,7 +411,13 @@ func NewKafkaSaramaSink(ctx context.Context, sinkURI *url.URL,
 		return nil, errors.Trace(err)
 	}
 
-	adminClient, err := kafka.NewAdminClientImpl(options.BrokerEndpoints, saramaConfig)
+	newAdminClient, newClient := kafka.NewAdminClientImpl, kafka.NewClientImpl
+	if options.ShareClient {
+		newAdminClient = pkafka.NewSharedAdminClientCreator(options)
+		newClient = pkafka.NewSharedClientCreator(options)
+	}
+
+	adminClient, err := newAdminClient(options.BrokerEndpoints, saramaConfig)
 	if err != nil {
 		return nil, cerror.WrapError(cerror.ErrKafkaNewSaramaProducer, err)
 	}
@@ -423,6 +443,7 @@ func NewKafkaSaramaSink(ctx context.Context, sinkURI *url.URL,
 	if err := encoderConfig.Apply(sinkURI, replicaConfig); err != nil {
 		return nil, cerror.WrapError(cerror.ErrKafkaInvalidConfig, err)
 	}
+	encoderConfig.ChangefeedID = changefeedID
 	// always set encoder's `MaxMessageBytes` equal to producer's `MaxMessageBytes`
 	// to prevent that the encoder generate batched message too large then cause producer meet `message too large`
 	encoderConfig = encoderConfig.WithMaxMessageBytes(saramaConfig.Producer.MaxMessageBytes)
@@ -431,21 +452,32 @@ func NewKafkaSaramaSink(ctx context.Context, sinkURI *url.URL,
 		return nil, cerror.WrapError(cerror.ErrKafkaInvalidConfig, err)
 	}
 
-	client, err := kafka.NewClientImpl(options.BrokerEndpoints, saramaConfig)
+	eventRouter, err := dispatcher.NewEventRouter(replicaConfig, topic)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	client, err := newClient(options.BrokerEndpoints, saramaConfig)
 	if err != nil {
 		return nil, cerror.WrapError(cerror.ErrKafkaNewSaramaProducer, err)
 	}
 
+	topicCfg := options.DeriveTopicConfig()
+	// The topics are created with the overrides of the rules dispatching
+	// the events to them.
+	topicCfg.Overrides = eventRouter
 	topicManager, err := manager.NewKafkaTopicManager(
+		changefeedID,
 		client,
 		adminClient,
-		options.DeriveTopicConfig(),
+		topicCfg,
 	)
 	if err != nil {
 		return nil, cerror.WrapError(cerror.ErrKafkaNewSaramaProducer, err)
 	}
 
 	if _, err := topicManager.CreateTopicAndWaitUntilVisible(topic); err != nil {
+		topicManager.Close()
 		return nil, cerror.WrapError(cerror.ErrKafkaCreateTopic, err)
 	}
 
@@ -459,6 +491,7 @@ func NewKafkaSaramaSink(ctx context.Context, sinkURI *url.URL,
 		changefeedID,
 	)
 	if err != nil {
+		topicManager.Close()
 		return nil, errors.Trace(err)
 	}
 
@@ -466,14 +499,15 @@ func NewKafkaSaramaSink(ctx context.Context, sinkURI *url.URL,
 		ctx,
 		topicManager,
 		sProducer,
-		topic,
-		replicaConfig,
+		eventRouter,
 		encoderConfig,
 		errCh,
 		changefeedID,
 	)
 	if err != nil {
+		topicManager.Close()
 		return nil, errors.Trace(err)
 	}
+	sink.contracts = contract.NewChecker(replicaConfig.Sink.ConsumerContracts)
 	return sink, nil
 }
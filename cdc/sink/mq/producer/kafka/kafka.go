@@ -28,6 +28,7 @@ import (
 	"github.com/pingcap/tiflow/cdc/contextutil"
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/cdc/sink/codec/common"
+	"github.com/pingcap/tiflow/pkg/compression"
 	cerror "github.com/pingcap/tiflow/pkg/errors"
 	"github.com/pingcap/tiflow/pkg/sink/kafka"
 	"github.com/pingcap/tiflow/pkg/util"
@@ -73,6 +74,10 @@ type kafkaSaramaProducer struct {
 
 	role util.Role
 	id   model.ChangeFeedID
+
+	// payloadCodec compresses the message values if the codec is not
+	// supported by the Kafka protocol, see kafka.PayloadCodec.
+	payloadCodec compression.Codec
 }
 
 type kafkaProducerClosingFlag = int32
@@ -103,10 +108,15 @@ func (k *kafkaSaramaProducer) AsyncSendMessage(
 		failpoint.Return(nil)
 	})
 
+	value, headers, err := kafka.CompressPayload(k.payloadCodec, message.Value, nil)
+	if err != nil {
+		return errors.Trace(err)
+	}
 	msg := &sarama.ProducerMessage{
 		Topic:     topic,
 		Key:       sarama.ByteEncoder(message.Key),
-		Value:     sarama.ByteEncoder(message.Value),
+		Value:     sarama.ByteEncoder(value),
+		Headers:   headers,
 		Partition: partition,
 	}
 	k.mu.Lock()
@@ -135,7 +145,11 @@ func (k *kafkaSaramaProducer) SyncBroadcastMessage(
 	case <-k.closeCh:
 		return nil
 	default:
-		err := k.syncProducer.SendMessages(topic, partitionsNum, message.Key, message.Value)
+		value, headers, err := kafka.CompressPayload(k.payloadCodec, message.Value, nil)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		err = k.syncProducer.SendMessages(topic, partitionsNum, message.Key, value, headers)
 		return cerror.WrapError(cerror.ErrKafkaSendMessage, err)
 	}
 }
@@ -321,7 +335,7 @@ func NewKafkaSaramaProducer(
 	changefeedID model.ChangeFeedID,
 ) (*kafkaSaramaProducer, error) {
 	role := contextutil.RoleFromCtx(ctx)
-	log.Info("Starting kafka sarama producer ...", zap.Any("options", options),
+	log.Info("Starting kafka sarama producer ...", zap.Object("options", options),
 		zap.String("namespace", changefeedID.Namespace),
 		zap.String("changefeed", changefeedID.ID), zap.Any("role", role))
 
@@ -335,7 +349,8 @@ func NewKafkaSaramaProducer(
 		return nil, cerror.WrapError(cerror.ErrKafkaNewSaramaProducer, err)
 	}
 
-	runSaramaMetricsMonitor(ctx, saramaConfig.MetricRegistry, changefeedID, role, admin)
+	runSaramaMetricsMonitor(ctx, saramaConfig.MetricRegistry, changefeedID, role, admin,
+		kafka.RecordBatchCodec(options.Compression))
 
 	k := &kafkaSaramaProducer{
 		admin:         admin,
@@ -348,6 +363,8 @@ func NewKafkaSaramaProducer(
 
 		id:   changefeedID,
 		role: role,
+
+		payloadCodec: kafka.PayloadCodec(options.Compression),
 	}
 	go func() {
 		if err := k.run(ctx); err != nil && errors.Cause(err) != context.Canceled {
@@ -20,6 +20,7 @@ import (
 
 	"github.com/pingcap/log"
 	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/compression"
 	"github.com/pingcap/tiflow/pkg/sink/kafka"
 	"github.com/pingcap/tiflow/pkg/util"
 	"github.com/prometheus/client_golang/prometheus"
@@ -141,7 +142,7 @@ var (
 )
 
 // InitMetrics registers all metrics in this file
-func InitMetrics(registry *prometheus.Registry) {
+func InitMetrics(registry prometheus.Registerer) {
 	registry.MustRegister(batchSizeGauge)
 	registry.MustRegister(recordSendRateGauge)
 	registry.MustRegister(recordPerRequestGauge)
@@ -184,6 +185,13 @@ type saramaMetricsMonitor struct {
 	admin    kafka.ClusterAdminClient
 
 	brokers map[int32]struct{}
+
+	// batchCodec is the codec compressing the record batches in sarama, it's
+	// nil if the record batches are not compressed.
+	batchCodec compression.Codec
+	// compressedBatches is the number of the record batches whose
+	// compression ratios are observed.
+	compressedBatches int64
 }
 
 // collectMetrics collect all monitored metrics
@@ -216,9 +224,17 @@ func (sm *saramaMetricsMonitor) collectProducerMetrics() {
 
 	compressionRatioMetric := sm.registry.Get(compressionRatioMetricName)
 	if histogram, ok := compressionRatioMetric.(metrics.Histogram); ok {
+		snapshot := histogram.Snapshot()
 		compressionRatioGauge.
 			WithLabelValues(sm.changefeedID.Namespace, sm.changefeedID.ID).
-			Set(histogram.Snapshot().Mean())
+			Set(snapshot.Mean())
+		// sarama records the ratio times 100 of each record batch it
+		// compresses.
+		if sm.batchCodec != nil && snapshot.Count() > sm.compressedBatches {
+			compression.ObserveRatio(compression.ComponentKafka,
+				sm.batchCodec.Name(), snapshot.Mean()/100)
+		}
+		sm.compressedBatches = snapshot.Count()
 	}
 }
 
@@ -316,6 +332,7 @@ func runSaramaMetricsMonitor(ctx context.Context,
 	registry metrics.Registry,
 	changefeedID model.ChangeFeedID,
 	role util.Role, admin kafka.ClusterAdminClient,
+	batchCodec compression.Codec,
 ) {
 	monitor := &saramaMetricsMonitor{
 		changefeedID: changefeedID,
@@ -323,6 +340,7 @@ func runSaramaMetricsMonitor(ctx context.Context,
 		registry:     registry,
 		admin:        admin,
 		brokers:      make(map[int32]struct{}),
+		batchCodec:   batchCodec,
 	}
 
 	ticker := time.NewTicker(flushMetricsInterval)
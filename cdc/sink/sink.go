@@ -126,6 +126,7 @@ func init() {
 		return mq.NewKafkaSaramaSink(ctx, sinkURI, config, errCh, changefeedID)
 	}
 	sinkIniterMap["kafka+ssl"] = sinkIniterMap["kafka"]
+	sinkIniterMap["kafka+srv"] = sinkIniterMap["kafka"]
 }
 
 // New creates a new sink with the sink-uri
@@ -48,7 +48,7 @@ func Validate(ctx context.Context, sinkURI string, cfg *config.ReplicaConfig) er
 	errCh := make(chan error)
 	ctx, cancel := context.WithCancel(contextutil.PutRoleInCtx(ctx, util.RoleClient))
 	conf := config.GetGlobalServerConfig()
-	if !conf.Debug.EnableNewSink {
+	if !cfg.IsNewSinkEnabled(conf.Debug) {
 		var s Sink
 		s, err = New(ctx, model.DefaultChangeFeedID("sink-verify"), sinkURI, cfg, errCh)
 		if err != nil {
@@ -0,0 +1,96 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clickhouse
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/cdc/contextutil"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sinkv2/ddlsink"
+	"github.com/pingcap/tiflow/cdc/sinkv2/metrics"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/pingcap/tiflow/pkg/sink"
+	"github.com/pingcap/tiflow/pkg/sink/clickhouse"
+	"go.uber.org/zap"
+)
+
+// Assert DDLEventSink implementation
+var _ ddlsink.DDLEventSink = (*ddlSink)(nil)
+
+type ddlSink struct {
+	// id indicates which changefeed this sink belongs to.
+	id model.ChangeFeedID
+	// statistic is used to record the DDL metrics
+	statistics *metrics.Statistics
+	client     *clickhouse.Client
+}
+
+// NewClickHouseDDLSink creates a ddl sink for ClickHouse.
+func NewClickHouseDDLSink(
+	ctx context.Context, sinkURI *url.URL, replicaConfig *config.ReplicaConfig,
+) (*ddlSink, error) {
+	cfg := clickhouse.NewConfig()
+	if err := cfg.Apply(sinkURI); err != nil {
+		return nil, err
+	}
+	cfg.ApplyClientIdentity(replicaConfig.Sink.ClientIdentity,
+		contextutil.ChangefeedIDFromCtx(ctx), contextutil.CaptureAddrFromCtx(ctx))
+	client, err := clickhouse.NewClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &ddlSink{
+		id:         contextutil.ChangefeedIDFromCtx(ctx),
+		statistics: metrics.NewStatistics(ctx, sink.RowSink),
+		client:     client,
+	}, nil
+}
+
+// WriteDDLEvent translates the DDL to the ClickHouse statements and executes
+// them, the DDLs which can't be translated are skipped.
+func (d *ddlSink) WriteDDLEvent(ctx context.Context, ddl *model.DDLEvent) error {
+	queries, err := clickhouse.BuildDDLs(ddl)
+	if err != nil {
+		return err
+	}
+	return d.statistics.RecordDDLExecution(func() error {
+		for _, query := range queries {
+			if err := d.client.Exec(ctx, query); err != nil {
+				return err
+			}
+			log.Info("Execute DDL succeeded",
+				zap.String("namespace", d.id.Namespace),
+				zap.String("changefeed", d.id.ID),
+				zap.String("query", query))
+		}
+		return nil
+	})
+}
+
+// WriteCheckpointTs does nothing, ClickHouse doesn't record the checkpoint.
+func (d *ddlSink) WriteCheckpointTs(_ context.Context, _ uint64, _ []*model.TableInfo) error {
+	return nil
+}
+
+// Close closes the sink.
+func (d *ddlSink) Close() error {
+	d.client.Close()
+	if d.statistics != nil {
+		d.statistics.Close()
+	}
+	return nil
+}
@@ -18,35 +18,52 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/url"
+	"strings"
 
 	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
 	"github.com/pingcap/tidb/br/pkg/storage"
 	"github.com/pingcap/tiflow/cdc/contextutil"
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/cdc/sinkv2/ddlsink"
 	"github.com/pingcap/tiflow/cdc/sinkv2/metrics"
+	"github.com/pingcap/tiflow/pkg/config"
 	"github.com/pingcap/tiflow/pkg/sink"
 	"github.com/pingcap/tiflow/pkg/sink/cloudstorage"
+	"github.com/pingcap/tiflow/pkg/sink/schemahistory"
 	"github.com/pingcap/tiflow/pkg/util"
+	"go.uber.org/zap"
 )
 
 // Assert DDLEventSink implementation
 var _ ddlsink.DDLEventSink = (*ddlSink)(nil)
 
+// Assert SavepointWriter implementation
+var _ ddlsink.SavepointWriter = (*ddlSink)(nil)
+
+// Assert Cleaner implementation
+var _ ddlsink.Cleaner = (*ddlSink)(nil)
+
 type ddlSink struct {
 	// id indicates which changefeed this sink belongs to.
 	id model.ChangeFeedID
 	// statistic is used to record the DDL metrics
 	statistics *metrics.Statistics
 	storage    storage.ExternalStorage
+	// history maintains the schema history under the directory set by
+	// `schema-history-dir`, it is nil if the directory is not set.
+	history *schemahistory.History
 }
 
 // NewCloudStorageDDLSink creates a ddl sink for cloud storage.
-func NewCloudStorageDDLSink(ctx context.Context, sinkURI *url.URL) (*ddlSink, error) {
+func NewCloudStorageDDLSink(ctx context.Context,
+	sinkURI *url.URL, replicaConfig *config.ReplicaConfig,
+) (*ddlSink, error) {
 	storage, err := util.GetExternalStorageFromURI(ctx, sinkURI.String())
 	if err != nil {
 		return nil, err
 	}
+	storage = cloudstorage.NewRetryStorage(storage, replicaConfig.Sink.RetryBudget)
 
 	changefeedID := contextutil.ChangefeedIDFromCtx(ctx)
 	d := &ddlSink{
@@ -54,6 +71,10 @@ func NewCloudStorageDDLSink(ctx context.Context, sinkURI *url.URL) (*ddlSink, er
 		storage:    storage,
 		statistics: metrics.NewStatistics(ctx, sink.TxnSink),
 	}
+	if dir := sinkURI.Query().Get("schema-history-dir"); dir != "" {
+		d.history = schemahistory.New(changefeedID,
+			schemahistory.NewStorageWriter(storage, dir))
+	}
 
 	return d, nil
 }
@@ -68,6 +89,11 @@ func (d *ddlSink) WriteDDLEvent(ctx context.Context, ddl *model.DDLEvent) error
 	if ddl.TableInfo.TableInfo == nil {
 		return nil
 	}
+	if d.history != nil {
+		if err := d.history.OnDDL(ctx, ddl); err != nil {
+			return errors.Trace(err)
+		}
+	}
 
 	def.FromDDLEvent(ddl)
 	encodedDef, err := json.MarshalIndent(def, "", "    ")
@@ -91,6 +117,11 @@ func (d *ddlSink) WriteDDLEvent(ctx context.Context, ddl *model.DDLEvent) error
 func (d *ddlSink) WriteCheckpointTs(ctx context.Context,
 	ts uint64, tables []*model.TableInfo,
 ) error {
+	if d.history != nil {
+		if err := d.history.OnCheckpoint(ctx, ts, tables); err != nil {
+			return errors.Trace(err)
+		}
+	}
 	ckpt, err := json.Marshal(map[string]uint64{"checkpoint-ts": ts})
 	if err != nil {
 		return errors.Trace(err)
@@ -99,6 +130,53 @@ func (d *ddlSink) WriteCheckpointTs(ctx context.Context,
 	return errors.Trace(err)
 }
 
+// WriteSavepoint writes the manifest of the savepoint to
+// savepoint/{name}.json, after the checkpoint at its ts is written.
+func (d *ddlSink) WriteSavepoint(ctx context.Context,
+	savepoint *model.Savepoint, tables []*model.TableInfo,
+) error {
+	if err := d.WriteCheckpointTs(ctx, savepoint.Ts, tables); err != nil {
+		return errors.Trace(err)
+	}
+	manifest, err := json.Marshal(savepoint)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	path := fmt.Sprintf("savepoint/%s.json", savepoint.Name)
+	err = d.storage.WriteFile(ctx, path, manifest)
+	return errors.Trace(err)
+}
+
+// CleanUp implements the ddlsink.Cleaner interface, the targets are the
+// files or the directories relative to the storage path of the sink, all the
+// files under the directories are deleted.
+func (d *ddlSink) CleanUp(ctx context.Context, targets []string) error {
+	var files []string
+	err := d.storage.WalkDir(ctx, &storage.WalkOption{}, func(path string, _ int64) error {
+		for _, target := range targets {
+			target = strings.Trim(target, "/")
+			if path == target || strings.HasPrefix(path, target+"/") {
+				files = append(files, path)
+				break
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, file := range files {
+		if err := d.storage.DeleteFile(ctx, file); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	log.Info("cloud storage files deleted in the cleanup",
+		zap.String("namespace", d.id.Namespace),
+		zap.String("changefeed", d.id.ID),
+		zap.Int("files", len(files)))
+	return nil
+}
+
 func (d *ddlSink) Close() error {
 	if d.statistics != nil {
 		d.statistics.Close()
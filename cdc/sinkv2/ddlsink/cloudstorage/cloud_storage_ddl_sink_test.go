@@ -25,6 +25,7 @@ import (
 	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tidb/parser/types"
 	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/config"
 	"github.com/stretchr/testify/require"
 )
 
@@ -35,7 +36,7 @@ func TestWriteDDLEvent(t *testing.T) {
 	uri := fmt.Sprintf("file:///%s", parentDir)
 	sinkURI, err := url.Parse(uri)
 	require.Nil(t, err)
-	sink, err := NewCloudStorageDDLSink(ctx, sinkURI)
+	sink, err := NewCloudStorageDDLSink(ctx, sinkURI, config.GetDefaultReplicaConfig())
 	require.Nil(t, err)
 
 	ddlEvent := &model.DDLEvent{
@@ -99,7 +100,7 @@ func TestWriteCheckpointTs(t *testing.T) {
 	uri := fmt.Sprintf("file:///%s", parentDir)
 	sinkURI, err := url.Parse(uri)
 	require.Nil(t, err)
-	sink, err := NewCloudStorageDDLSink(ctx, sinkURI)
+	sink, err := NewCloudStorageDDLSink(ctx, sinkURI, config.GetDefaultReplicaConfig())
 	require.Nil(t, err)
 	tables := []*model.TableInfo{
 		{
@@ -132,3 +133,30 @@ func TestWriteCheckpointTs(t *testing.T) {
 	require.Nil(t, err)
 	require.JSONEq(t, `{"checkpoint-ts":100}`, string(metadata))
 }
+
+func TestCleanUp(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	parentDir := t.TempDir()
+	uri := fmt.Sprintf("file:///%s", parentDir)
+	sinkURI, err := url.Parse(uri)
+	require.Nil(t, err)
+	sink, err := NewCloudStorageDDLSink(ctx, sinkURI, config.GetDefaultReplicaConfig())
+	require.Nil(t, err)
+
+	err = sink.WriteSavepoint(ctx, &model.Savepoint{Name: "sp", Ts: 100}, nil)
+	require.Nil(t, err)
+	_, err = os.Stat(path.Join(parentDir, "savepoint/sp.json"))
+	require.Nil(t, err)
+
+	require.Nil(t, sink.CleanUp(ctx, []string{"/savepoint/"}))
+	_, err = os.Stat(path.Join(parentDir, "savepoint/sp.json"))
+	require.True(t, os.IsNotExist(err))
+	// The files out of the targets are kept.
+	_, err = os.Stat(path.Join(parentDir, "metadata"))
+	require.Nil(t, err)
+
+	require.Nil(t, sink.CleanUp(ctx, []string{"metadata"}))
+	_, err = os.Stat(path.Join(parentDir, "metadata"))
+	require.True(t, os.IsNotExist(err))
+}
@@ -15,6 +15,7 @@ package ddlsink
 
 import (
 	"context"
+	"net/url"
 
 	"github.com/pingcap/tiflow/cdc/model"
 )
@@ -31,3 +32,62 @@ type DDLEventSink interface {
 	// Close closes the sink.
 	Close() error
 }
+
+// SelfChecker is implemented by the DDLEventSink which is able to verify
+// the messages it produced by reading them back from the downstream.
+type SelfChecker interface {
+	// SelfCheckEnabled returns true if the self check is enabled.
+	SelfCheckEnabled() bool
+	// SelfCheck writes a checkpoint timestamp to the sink like
+	// WriteCheckpointTs, and reads the produced messages back to verify them.
+	// An error is returned if the self check can not be done, a failed
+	// check is reported by the result.
+	SelfCheck(ctx context.Context, ts uint64,
+		tables []*model.TableInfo) (*model.SinkSelfCheckResult, error)
+}
+
+// ConsumerLagReporter is implemented by the DDLEventSink which is able to
+// report the lag of the downstream consumer group.
+type ConsumerLagReporter interface {
+	// ConsumerGroupLag returns the last collected lag of the consumer group,
+	// nil is returned if it is not collected.
+	ConsumerGroupLag() *model.ConsumerGroupLag
+}
+
+// CredentialsUpdater is implemented by the DDLEventSink which is able to
+// update the credentials of the downstream without being recreated.
+type CredentialsUpdater interface {
+	// UpdateCredentials re-establishes the connections to the downstream with
+	// the credentials in the sink URI, the other parts of which must not change.
+	// Note: It must not be called concurrently with the writes.
+	UpdateCredentials(ctx context.Context, sinkURI *url.URL) error
+}
+
+// ConcurrentWriter is implemented by the DDLEventSink which is able to
+// execute the DDL events on independent tables concurrently.
+type ConcurrentWriter interface {
+	// WriteDDLEvents writes the DDL events to the sink, the ones depending
+	// on each other are executed in their order in ddls.
+	// Note: This is a synchronous and thread-safe method.
+	WriteDDLEvents(ctx context.Context, ddls []*model.DDLEvent) error
+}
+
+// SavepointWriter is implemented by the DDLEventSink which is able to write
+// the markers of the savepoints to the downstream.
+type SavepointWriter interface {
+	// WriteSavepoint writes the marker of the savepoint, all the data
+	// committed at or before its ts has been written to the downstream.
+	// Note: It must not be called concurrently with the writes.
+	WriteSavepoint(ctx context.Context, savepoint *model.Savepoint,
+		tables []*model.TableInfo) error
+}
+
+// Cleaner is implemented by the DDLEventSink which is able to delete the
+// data written by the changefeed before it's removed.
+type Cleaner interface {
+	// CleanUp deletes the targets in the downstream, which are configured
+	// explicitly by the lifecycle hooks. Nothing out of the targets is
+	// deleted, since the downstream may be shared with other writers.
+	// Note: It must not be called concurrently with the writes.
+	CleanUp(ctx context.Context, targets []string) error
+}
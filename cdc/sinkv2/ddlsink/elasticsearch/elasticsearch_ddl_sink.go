@@ -0,0 +1,100 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elasticsearch
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/cdc/contextutil"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sinkv2/ddlsink"
+	"github.com/pingcap/tiflow/cdc/sinkv2/metrics"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/pingcap/tiflow/pkg/sink"
+	"github.com/pingcap/tiflow/pkg/sink/elasticsearch"
+	"go.uber.org/zap"
+)
+
+// Assert DDLEventSink implementation
+var _ ddlsink.DDLEventSink = (*ddlSink)(nil)
+
+type ddlSink struct {
+	// id indicates which changefeed this sink belongs to.
+	id model.ChangeFeedID
+	// statistic is used to record the DDL metrics
+	statistics *metrics.Statistics
+	cfg        *elasticsearch.Config
+	client     *elasticsearch.Client
+}
+
+// NewElasticsearchDDLSink creates a ddl sink for Elasticsearch.
+func NewElasticsearchDDLSink(
+	ctx context.Context, sinkURI *url.URL, replicaConfig *config.ReplicaConfig,
+) (*ddlSink, error) {
+	cfg := elasticsearch.NewConfig()
+	if err := cfg.Apply(sinkURI); err != nil {
+		return nil, err
+	}
+	cfg.ApplyClientIdentity(replicaConfig.Sink.ClientIdentity,
+		contextutil.ChangefeedIDFromCtx(ctx), contextutil.CaptureAddrFromCtx(ctx))
+	client, err := elasticsearch.NewClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &ddlSink{
+		id:         contextutil.ChangefeedIDFromCtx(ctx),
+		statistics: metrics.NewStatistics(ctx, sink.RowSink),
+		cfg:        cfg,
+		client:     client,
+	}, nil
+}
+
+// WriteDDLEvent translates the DDL to the Elasticsearch requests and sends
+// them, the DDLs which can't be translated are skipped.
+func (d *ddlSink) WriteDDLEvent(ctx context.Context, ddl *model.DDLEvent) error {
+	reqs, err := elasticsearch.BuildDDLRequests(d.cfg, ddl)
+	if err != nil {
+		return err
+	}
+	return d.statistics.RecordDDLExecution(func() error {
+		for _, req := range reqs {
+			if err := d.client.Do(ctx, req); err != nil {
+				return err
+			}
+			log.Info("Execute DDL succeeded",
+				zap.String("namespace", d.id.Namespace),
+				zap.String("changefeed", d.id.ID),
+				zap.String("query", ddl.Query),
+				zap.String("method", req.Method),
+				zap.String("path", req.Path))
+		}
+		return nil
+	})
+}
+
+// WriteCheckpointTs does nothing, Elasticsearch doesn't record the checkpoint.
+func (d *ddlSink) WriteCheckpointTs(_ context.Context, _ uint64, _ []*model.TableInfo) error {
+	return nil
+}
+
+// Close closes the sink.
+func (d *ddlSink) Close() error {
+	d.client.Close()
+	if d.statistics != nil {
+		d.statistics.Close()
+	}
+	return nil
+}
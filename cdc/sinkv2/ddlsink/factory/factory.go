@@ -17,13 +17,17 @@ import (
 	"context"
 	"strings"
 
+	"github.com/pingcap/tiflow/cdc/contextutil"
 	"github.com/pingcap/tiflow/cdc/sink/mq/producer/kafka"
 	"github.com/pingcap/tiflow/cdc/sinkv2/ddlsink"
 	"github.com/pingcap/tiflow/cdc/sinkv2/ddlsink/blackhole"
+	"github.com/pingcap/tiflow/cdc/sinkv2/ddlsink/clickhouse"
 	"github.com/pingcap/tiflow/cdc/sinkv2/ddlsink/cloudstorage"
+	"github.com/pingcap/tiflow/cdc/sinkv2/ddlsink/elasticsearch"
 	"github.com/pingcap/tiflow/cdc/sinkv2/ddlsink/mq"
 	"github.com/pingcap/tiflow/cdc/sinkv2/ddlsink/mq/ddlproducer"
 	"github.com/pingcap/tiflow/cdc/sinkv2/ddlsink/mysql"
+	"github.com/pingcap/tiflow/cdc/sinkv2/ddlsink/webhook"
 	"github.com/pingcap/tiflow/pkg/config"
 	cerror "github.com/pingcap/tiflow/pkg/errors"
 	"github.com/pingcap/tiflow/pkg/sink"
@@ -40,9 +44,11 @@ func New(
 	if err != nil {
 		return nil, err
 	}
+	// The metrics config controls the labels of the metrics of the sinks.
+	ctx = contextutil.PutMetricsConfigInCtx(ctx, cfg.Metrics)
 	schema := strings.ToLower(sinkURI.Scheme)
 	switch schema {
-	case sink.KafkaScheme, sink.KafkaSSLScheme:
+	case sink.KafkaScheme, sink.KafkaSSLScheme, sink.KafkaSRVScheme:
 		return mq.NewKafkaDDLSink(ctx, sinkURI, cfg,
 			kafka.NewAdminClientImpl, kafka.NewClientImpl, ddlproducer.NewKafkaDDLProducer)
 	case sink.BlackHoleScheme:
@@ -50,7 +56,13 @@ func New(
 	case sink.MySQLSSLScheme, sink.MySQLScheme, sink.TiDBScheme, sink.TiDBSSLScheme:
 		return mysql.NewMySQLDDLSink(ctx, sinkURI, cfg, pmysql.CreateMySQLDBConn)
 	case sink.S3Scheme, sink.FileScheme, sink.GCSScheme, sink.GSScheme, sink.AzblobScheme, sink.AzureScheme, sink.CloudStorageNoopScheme:
-		return cloudstorage.NewCloudStorageDDLSink(ctx, sinkURI)
+		return cloudstorage.NewCloudStorageDDLSink(ctx, sinkURI, cfg)
+	case sink.ClickHouseScheme, sink.ClickHouseSSLScheme:
+		return clickhouse.NewClickHouseDDLSink(ctx, sinkURI, cfg)
+	case sink.ElasticsearchScheme, sink.ElasticsearchSSLScheme:
+		return elasticsearch.NewElasticsearchDDLSink(ctx, sinkURI, cfg)
+	case sink.WebhookScheme, sink.WebhookSSLScheme:
+		return webhook.NewWebhookDDLSink(ctx, sinkURI, cfg)
 	default:
 		return nil,
 			cerror.ErrSinkURIInvalid.GenWithStack("the sink scheme (%s) is not supported", schema)
@@ -36,4 +36,4 @@ type DDLProducer interface {
 
 // Factory is a function to create a producer.
 type Factory func(ctx context.Context, client kafka.Client,
-	adminClient kafka.ClusterAdminClient) (DDLProducer, error)
+	adminClient kafka.ClusterAdminClient, options *kafka.Options) (DDLProducer, error)
@@ -30,7 +30,7 @@ type MockDDLProducer struct {
 
 // NewMockDDLProducer creates a mock producer.
 func NewMockDDLProducer(_ context.Context, _ kafka.Client,
-	_ kafka.ClusterAdminClient,
+	_ kafka.ClusterAdminClient, _ *kafka.Options,
 ) (DDLProducer, error) {
 	return &MockDDLProducer{
 		events: make(map[mqv1.TopicPartitionKey][]*common.Message),
@@ -18,12 +18,15 @@ import (
 	"sync"
 	"time"
 
+	"github.com/Shopify/sarama"
 	"github.com/pingcap/errors"
 	"github.com/pingcap/log"
 	"github.com/pingcap/tiflow/cdc/contextutil"
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/cdc/sink/codec/common"
 	collector "github.com/pingcap/tiflow/cdc/sinkv2/metrics/mq/kafka"
+	"github.com/pingcap/tiflow/pkg/compression"
+	"github.com/pingcap/tiflow/pkg/config"
 	cerror "github.com/pingcap/tiflow/pkg/errors"
 	pkafka "github.com/pingcap/tiflow/pkg/sink/kafka"
 	"github.com/pingcap/tiflow/pkg/util"
@@ -50,11 +53,17 @@ type kafkaDDLProducer struct {
 	// closed is used to indicate whether the producer is closed.
 	// We also use it to guard against double closes.
 	closed bool
+	// payloadCodec compresses the message values if the codec is not
+	// supported by the Kafka protocol, see pkafka.PayloadCodec.
+	payloadCodec compression.Codec
+	// retryBudget escalates the errors of the messages whose retries are
+	// exhausted, see pkafka.EscalateSendError.
+	retryBudget *config.RetryBudgetConfig
 }
 
 // NewKafkaDDLProducer creates a new kafka producer for replicating DDL.
 func NewKafkaDDLProducer(ctx context.Context, client pkafka.Client,
-	adminClient pkafka.ClusterAdminClient,
+	adminClient pkafka.ClusterAdminClient, options *pkafka.Options,
 ) (DDLProducer, error) {
 	changefeedID := contextutil.ChangefeedIDFromCtx(ctx)
 
@@ -80,8 +89,12 @@ func NewKafkaDDLProducer(ctx context.Context, client pkafka.Client,
 		}()
 		return nil, cerror.WrapError(cerror.ErrKafkaNewSaramaProducer, err)
 	}
+	var batchCodec compression.Codec
+	if options != nil {
+		batchCodec = pkafka.RecordBatchCodec(options.Compression)
+	}
 	collector := collector.New(changefeedID, util.RoleOwner,
-		adminClient, client.MetricRegistry())
+		adminClient, client.MetricRegistry(), batchCodec)
 
 	p := &kafkaDDLProducer{
 		id:           changefeedID,
@@ -90,6 +103,10 @@ func NewKafkaDDLProducer(ctx context.Context, client pkafka.Client,
 		syncProducer: syncProducer,
 		closed:       false,
 	}
+	if options != nil {
+		p.payloadCodec = pkafka.PayloadCodec(options.Compression)
+		p.retryBudget = options.RetryBudget
+	}
 
 	// Start collecting metrics.
 	go p.collector.Run(ctx)
@@ -111,8 +128,15 @@ func (k *kafkaDDLProducer) SyncBroadcastMessage(ctx context.Context, topic strin
 	case <-ctx.Done():
 		return ctx.Err()
 	default:
-		err := k.syncProducer.SendMessages(topic, totalPartitionsNum, message.Key, message.Value)
-		return cerror.WrapError(cerror.ErrKafkaSendMessage, err)
+		value, headers, err := pkafka.CompressPayload(
+			k.payloadCodec, message.Value, recordHeaders(message.Headers))
+		if err != nil {
+			return errors.Trace(err)
+		}
+		err = k.syncProducer.SendMessages(topic, totalPartitionsNum,
+			message.Key, value, headers)
+		return pkafka.EscalateSendError(k.retryBudget,
+			cerror.WrapError(cerror.ErrKafkaSendMessage, err))
 	}
 }
 
@@ -130,9 +154,28 @@ func (k *kafkaDDLProducer) SyncSendMessage(ctx context.Context, topic string,
 	case <-ctx.Done():
 		return errors.Trace(ctx.Err())
 	default:
-		err := k.syncProducer.SendMessage(topic, partitionNum, message.Key, message.Value)
-		return cerror.WrapError(cerror.ErrKafkaSendMessage, err)
+		value, headers, err := pkafka.CompressPayload(
+			k.payloadCodec, message.Value, recordHeaders(message.Headers))
+		if err != nil {
+			return errors.Trace(err)
+		}
+		err = k.syncProducer.SendMessage(topic, partitionNum,
+			message.Key, value, headers)
+		return pkafka.EscalateSendError(k.retryBudget,
+			cerror.WrapError(cerror.ErrKafkaSendMessage, err))
+	}
+}
+
+// recordHeaders converts the headers of a message to the kafka record headers.
+func recordHeaders(headers []common.MessageHeader) []sarama.RecordHeader {
+	if len(headers) == 0 {
+		return nil
+	}
+	res := make([]sarama.RecordHeader, 0, len(headers))
+	for _, header := range headers {
+		res = append(res, sarama.RecordHeader{Key: []byte(header.Key), Value: header.Value})
 	}
+	return res
 }
 
 func (k *kafkaDDLProducer) Close() {
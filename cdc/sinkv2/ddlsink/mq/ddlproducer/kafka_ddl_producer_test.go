@@ -81,7 +81,7 @@ func TestSyncBroadcastMessage(t *testing.T) {
 	require.Nil(t, err)
 	adminClient, err := kafka.NewMockAdminClient(options.BrokerEndpoints, saramaConfig)
 	require.Nil(t, err)
-	p, err := NewKafkaDDLProducer(ctx, client, adminClient)
+	p, err := NewKafkaDDLProducer(ctx, client, adminClient, nil)
 	require.Nil(t, err)
 
 	err = p.SyncBroadcastMessage(ctx, topic,
@@ -111,7 +111,7 @@ func TestSyncSendMessage(t *testing.T) {
 	require.Nil(t, err)
 	adminClient, err := kafka.NewMockAdminClient(options.BrokerEndpoints, saramaConfig)
 	require.Nil(t, err)
-	p, err := NewKafkaDDLProducer(ctx, client, adminClient)
+	p, err := NewKafkaDDLProducer(ctx, client, adminClient, nil)
 	require.Nil(t, err)
 
 	err = p.SyncSendMessage(ctx, topic, 0, &common.Message{Ts: 417318403368288260})
@@ -143,7 +143,7 @@ func TestProducerSendMsgFailed(t *testing.T) {
 
 	adminClient, err := kafka.NewMockAdminClient(options.BrokerEndpoints, saramaConfig)
 	require.Nil(t, err)
-	p, err := NewKafkaDDLProducer(ctx, client, adminClient)
+	p, err := NewKafkaDDLProducer(ctx, client, adminClient, nil)
 	require.Nil(t, err)
 	defer p.Close()
 
@@ -168,7 +168,7 @@ func TestProducerDoubleClose(t *testing.T) {
 	require.Nil(t, err)
 	adminClient, err := kafka.NewMockAdminClient(options.BrokerEndpoints, saramaConfig)
 	require.Nil(t, err)
-	p, err := NewKafkaDDLProducer(ctx, client, adminClient)
+	p, err := NewKafkaDDLProducer(ctx, client, adminClient, nil)
 	require.Nil(t, err)
 
 	p.Close()
@@ -20,13 +20,19 @@ import (
 
 	"github.com/pingcap/errors"
 	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/cdc/contextutil"
+	"github.com/pingcap/tiflow/cdc/sink/codec/common"
+	"github.com/pingcap/tiflow/cdc/sink/mq/contract"
 	"github.com/pingcap/tiflow/cdc/sink/mq/dispatcher"
 	"github.com/pingcap/tiflow/cdc/sink/mq/producer/kafka"
 	"github.com/pingcap/tiflow/cdc/sinkv2/ddlsink/mq/ddlproducer"
+	"github.com/pingcap/tiflow/cdc/sinkv2/eventsink/tee"
+	collector "github.com/pingcap/tiflow/cdc/sinkv2/metrics/mq/kafka"
 	"github.com/pingcap/tiflow/cdc/sinkv2/util"
 	"github.com/pingcap/tiflow/pkg/config"
 	cerror "github.com/pingcap/tiflow/pkg/errors"
 	pkafka "github.com/pingcap/tiflow/pkg/sink/kafka"
+	"github.com/pingcap/tiflow/pkg/sink/schemahistory"
 	"go.uber.org/zap"
 )
 
@@ -48,10 +54,20 @@ func NewKafkaDDLSink(
 	if err := options.Apply(sinkURI); err != nil {
 		return nil, cerror.WrapError(cerror.ErrKafkaInvalidConfig, err)
 	}
+	options.RetryBudget = replicaConfig.Sink.RetryBudget
+	if options.ClientID == "" && replicaConfig.Sink.ClientIdentity != nil {
+		options.ClientID = replicaConfig.Sink.ClientIdentity.KafkaClientID
+	}
 	saramaConfig, err := pkafka.NewSaramaConfig(ctx, options)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	// The shared clients replace the ones created by the creators, they are
+	// shared with the other changefeeds connecting to the same cluster.
+	if options.ShareClient {
+		adminClientCreator = pkafka.NewSharedAdminClientCreator(options)
+		clientCreator = pkafka.NewSharedClientCreator(options)
+	}
 
 	adminClient, err := adminClientCreator(options.BrokerEndpoints, saramaConfig)
 	if err != nil {
@@ -84,8 +100,8 @@ func NewKafkaDDLSink(
 
 	start := time.Now()
 	log.Info("Try to create a DDL sink producer",
-		zap.Any("options", options))
-	p, err := producerCreator(ctx, client, adminClient)
+		zap.Object("options", options))
+	p, err := producerCreator(ctx, client, adminClient, options)
 	log.Info("DDL sink producer client created", zap.Duration("duration", time.Since(start)))
 	if err != nil {
 		return nil, cerror.WrapError(cerror.ErrKafkaNewSaramaProducer, err)
@@ -98,31 +114,94 @@ func NewKafkaDDLSink(
 		}
 	}()
 
+	changefeedID := contextutil.ChangefeedIDFromCtx(ctx)
+	eventRouter, err := dispatcher.NewEventRouter(replicaConfig, topic)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	topicCfg := options.DeriveTopicConfig()
+	// The topics are created with the overrides of the rules dispatching
+	// the events to them.
+	topicCfg.Overrides = eventRouter
 	topicManager, err := util.GetTopicManagerAndTryCreateTopic(
+		changefeedID,
 		topic,
-		options.DeriveTopicConfig(),
+		topicCfg,
 		client,
 		adminClient,
 	)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-
-	eventRouter, err := dispatcher.NewEventRouter(replicaConfig, topic)
-	if err != nil {
-		return nil, errors.Trace(err)
-	}
+	// Preventing the topic manager from being left registered when error occurs.
+	defer func() {
+		if err != nil {
+			topicManager.Close()
+		}
+	}()
 
 	encoderConfig, err := util.GetEncoderConfig(sinkURI, protocol, replicaConfig,
 		saramaConfig.Producer.MaxMessageBytes)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	encoderConfig.ChangefeedID = changefeedID
+
+	// The schema history is not archived, it's not a part of the stream.
+	historyProducer := p
+	var archive *tee.Archive
+	if replicaConfig.Sink.TeeSinkURI != "" {
+		// There is only one owner at a time, so the DDL archive has a fixed
+		// directory.
+		archive, err = tee.NewArchive(ctx, replicaConfig.Sink.TeeSinkURI,
+			"ddl", changefeedID, protocol)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		p = &archivingProducer{DDLProducer: p, archive: archive}
+	}
 
 	s, err := newDDLSink(ctx, p, topicManager, eventRouter, encoderConfig)
 	if err != nil {
+		if archive != nil {
+			_ = archive.Close()
+		}
 		return nil, errors.Trace(err)
 	}
+	s.archive = archive
+	if options.SelfCheck {
+		// The client is closed by the producer.
+		s.selfChecker = &selfChecker{client: client, encoderConfig: encoderConfig}
+	}
+	if options.ConsumerGroup != "" {
+		// The clients are closed by the producer.
+		s.lagCollector = collector.NewConsumerLagCollector(s.id,
+			options.ConsumerGroup, client, adminClient, replicaConfig.Metrics)
+		go s.lagCollector.Run(ctx)
+	}
+	if options.WarmUp {
+		// The client is closed by the producer.
+		s.warmUpClient = client
+	}
+	// The client is closed by the producer.
+	s.adminClient = adminClient
+	if options.SequenceNumber {
+		s.sequencer = common.NewSequencer()
+	}
+	s.headers = common.NewMetadataHeaders(replicaConfig.Sink.Headers,
+		s.id, replicaConfig.Sink.TiDBSourceID)
+	s.contracts = contract.NewChecker(replicaConfig.Sink.ConsumerContracts)
+	if options.SchemaHistoryTopic != "" {
+		err = createSchemaHistoryTopic(adminClient, options.SchemaHistoryTopic, options)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		s.history = schemahistory.New(s.id, &kafkaSchemaHistoryWriter{
+			topic:    options.SchemaHistoryTopic,
+			producer: historyProducer,
+		})
+	}
 
 	return s, nil
 }
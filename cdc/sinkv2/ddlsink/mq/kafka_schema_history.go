@@ -0,0 +1,106 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mq
+
+import (
+	"context"
+
+	"github.com/Shopify/sarama"
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/cdc/sink/codec/common"
+	"github.com/pingcap/tiflow/cdc/sink/mq/dispatcher"
+	"github.com/pingcap/tiflow/cdc/sinkv2/ddlsink/mq/ddlproducer"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/sink/cloudstorage"
+	pkafka "github.com/pingcap/tiflow/pkg/sink/kafka"
+	"github.com/pingcap/tiflow/pkg/sink/schemahistory"
+	"go.uber.org/zap"
+)
+
+const (
+	cleanupPolicyConfigName = "cleanup.policy"
+	cleanupPolicyCompact    = "compact"
+)
+
+// Assert schemahistory.Writer implementation
+var _ schemahistory.Writer = (*kafkaSchemaHistoryWriter)(nil)
+
+// kafkaSchemaHistoryWriter writes the schema history to a compacted topic
+// with only one partition, so that the records are totally ordered and a
+// consumer can rebuild the whole history by reading the topic from the
+// beginning.
+type kafkaSchemaHistoryWriter struct {
+	topic    string
+	producer ddlproducer.DDLProducer
+}
+
+func (w *kafkaSchemaHistoryWriter) send(ctx context.Context, key, value []byte) error {
+	return w.producer.SyncSendMessage(ctx, w.topic, dispatcher.PartitionZero,
+		&common.Message{Key: key, Value: value})
+}
+
+// WriteRecord implements schemahistory.Writer.
+func (w *kafkaSchemaHistoryWriter) WriteRecord(
+	ctx context.Context, def *cloudstorage.TableDefinition,
+) error {
+	key, value, err := schemahistory.EncodeRecord(def)
+	if err != nil {
+		return err
+	}
+	return w.send(ctx, key, value)
+}
+
+// WriteCheckpoint implements schemahistory.Writer.
+func (w *kafkaSchemaHistoryWriter) WriteCheckpoint(ctx context.Context, ts uint64) error {
+	key, value, err := schemahistory.EncodeCheckpoint(ts)
+	if err != nil {
+		return err
+	}
+	return w.send(ctx, key, value)
+}
+
+// createSchemaHistoryTopic creates the compacted topic of the schema history
+// if it doesn't exist. An existing topic is used as it is, but a warning is
+// logged if it's not compacted, since the old records may be deleted.
+func createSchemaHistoryTopic(
+	adminClient pkafka.ClusterAdminClient, topic string, options *pkafka.Options,
+) error {
+	topics, err := adminClient.ListTopics()
+	if err != nil {
+		return cerror.WrapError(cerror.ErrKafkaNewSaramaProducer, err)
+	}
+	if detail, ok := topics[topic]; ok {
+		policy := detail.ConfigEntries[cleanupPolicyConfigName]
+		if policy == nil || *policy != cleanupPolicyCompact {
+			log.Warn("the schema history topic is not compacted, "+
+				"the old schemas may be deleted by the retention policy",
+				zap.String("topic", topic))
+		}
+		return nil
+	}
+
+	compact := cleanupPolicyCompact
+	err = adminClient.CreateTopic(topic, &sarama.TopicDetail{
+		NumPartitions:     1,
+		ReplicationFactor: options.ReplicationFactor,
+		ConfigEntries: map[string]*string{
+			cleanupPolicyConfigName: &compact,
+		},
+	}, false)
+	if err != nil && !pkafka.IsTopicExistsError(err) {
+		return cerror.WrapError(cerror.ErrKafkaCreateTopic, err)
+	}
+	log.Info("schema history topic created", zap.String("topic", topic))
+	return nil
+}
@@ -0,0 +1,201 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mq
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sink/codec"
+	"github.com/pingcap/tiflow/cdc/sink/codec/canal"
+	"github.com/pingcap/tiflow/cdc/sink/codec/common"
+	"github.com/pingcap/tiflow/cdc/sink/codec/open"
+	"github.com/pingcap/tiflow/cdc/sinkv2/ddlsink"
+	"github.com/pingcap/tiflow/pkg/config"
+	pkafka "github.com/pingcap/tiflow/pkg/sink/kafka"
+	"go.uber.org/zap"
+)
+
+const (
+	// selfCheckMaxMessages is the max number of messages read back
+	// from the downstream to find the checkpoint message.
+	selfCheckMaxMessages = 16
+	// selfCheckTimeout is the max time to read the messages back.
+	selfCheckTimeout = 10 * time.Second
+	// selfCheckPartition is the partition the produced messages are read from,
+	// the checkpoint message is broadcast to all partitions of a topic.
+	selfCheckPartition = int32(0)
+)
+
+// Assert SelfChecker implementation
+var _ ddlsink.SelfChecker = (*ddlSink)(nil)
+
+// selfChecker reads the messages produced by the sink back with a
+// loopback consumer, to verify the encoding round trip, the topic ACLs and
+// to record the consumer lag baseline.
+type selfChecker struct {
+	client        pkafka.Client
+	encoderConfig *common.Config
+}
+
+// SelfCheckEnabled implements the ddlsink.SelfChecker interface.
+func (k *ddlSink) SelfCheckEnabled() bool {
+	return k.selfChecker != nil
+}
+
+// SelfCheck implements the ddlsink.SelfChecker interface.
+func (k *ddlSink) SelfCheck(
+	ctx context.Context, ts uint64, tables []*model.TableInfo,
+) (*model.SinkSelfCheckResult, error) {
+	if k.selfChecker == nil {
+		return nil, errors.New("self check is not enabled")
+	}
+	result := &model.SinkSelfCheckResult{CheckTime: time.Now()}
+	topics := k.getCheckpointTopics(tables)
+	if len(topics) == 0 {
+		topics = []string{k.eventRouter.GetDefaultTopic()}
+	}
+	topic := topics[0]
+
+	before, err := k.selfChecker.client.GetOffset(topic, selfCheckPartition, sarama.OffsetNewest)
+	if err != nil {
+		result.Message = fmt.Sprintf("failed to get the offset of topic %s: %s", topic, err)
+		return result, nil
+	}
+	if err := k.WriteCheckpointTs(ctx, ts, tables); err != nil {
+		return nil, errors.Trace(err)
+	}
+	after, err := k.selfChecker.client.GetOffset(topic, selfCheckPartition, sarama.OffsetNewest)
+	if err != nil {
+		result.Message = fmt.Sprintf("failed to get the offset of topic %s: %s", topic, err)
+		return result, nil
+	}
+	if after <= before {
+		// Some protocols do not produce checkpoint messages,
+		// e.g. canal-json without the TiDB extension.
+		result.Passed = true
+		result.Message = "no checkpoint message is produced, round trip is not verified"
+		return result, nil
+	}
+
+	count := int(after - before)
+	if count > selfCheckMaxMessages {
+		count = selfCheckMaxMessages
+	}
+	readCtx, cancel := context.WithTimeout(ctx, selfCheckTimeout)
+	defer cancel()
+	messages, err := k.selfChecker.client.ReadMessages(
+		readCtx, topic, selfCheckPartition, before, count)
+	if err != nil {
+		result.Message = fmt.Sprintf("failed to read messages from topic %s: %s", topic, err)
+		return result, nil
+	}
+	result.ConsumedMessages = len(messages)
+	if len(messages) > 0 {
+		result.ConsumerLag = after - (messages[len(messages)-1].Offset + 1)
+	}
+
+	found, err := k.selfChecker.findCheckpoint(messages, ts)
+	if err != nil {
+		result.Message = fmt.Sprintf("failed to decode messages from topic %s: %s", topic, err)
+		return result, nil
+	}
+	if !found {
+		result.Message = fmt.Sprintf("checkpoint %d is not found in topic %s", ts, topic)
+		return result, nil
+	}
+	result.Passed = true
+	log.Info("Kafka sink self check passed",
+		zap.String("namespace", k.id.Namespace),
+		zap.String("changefeed", k.id.ID),
+		zap.String("topic", topic),
+		zap.Uint64("checkpointTs", ts),
+		zap.Int("consumedMessages", result.ConsumedMessages),
+		zap.Int64("consumerLag", result.ConsumerLag))
+	return result, nil
+}
+
+// findCheckpoint decodes the messages and returns true if there is
+// a resolved event of the given ts. Messages of protocols without a decoder
+// are only read back, so true is returned for them.
+func (c *selfChecker) findCheckpoint(
+	messages []*sarama.ConsumerMessage, ts uint64,
+) (bool, error) {
+	for _, msg := range messages {
+		var (
+			decoder codec.EventBatchDecoder
+			err     error
+		)
+		switch c.encoderConfig.Protocol {
+		case config.ProtocolOpen, config.ProtocolDefault:
+			decoder, err = open.NewBatchDecoder(msg.Key, msg.Value,
+				codec.WithStrictDecode(true))
+		case config.ProtocolCanalJSON:
+			decoder = canal.NewBatchDecoder(msg.Value,
+				c.encoderConfig.EnableTiDBExtension, c.encoderConfig.Terminator,
+				codec.WithStrictDecode(true))
+		default:
+			return true, nil
+		}
+		if err != nil {
+			return false, errors.Trace(err)
+		}
+		found, err := hasResolvedEvent(decoder, ts)
+		if err != nil {
+			return false, errors.Trace(err)
+		}
+		if found {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// hasResolvedEvent returns true if the decoder has a resolved event of the given ts.
+func hasResolvedEvent(decoder codec.EventBatchDecoder, ts uint64) (bool, error) {
+	for {
+		tp, hasNext, err := decoder.HasNext()
+		if err != nil {
+			return false, errors.Trace(err)
+		}
+		if !hasNext {
+			return false, nil
+		}
+		switch tp {
+		case model.MessageTypeResolved:
+			resolvedTs, err := decoder.NextResolvedEvent()
+			if err != nil {
+				return false, errors.Trace(err)
+			}
+			if resolvedTs == ts {
+				return true, nil
+			}
+		case model.MessageTypeRow:
+			if _, err := decoder.NextRowChangedEvent(); err != nil {
+				return false, errors.Trace(err)
+			}
+		case model.MessageTypeDDL:
+			if _, err := decoder.NextDDLEvent(); err != nil {
+				return false, errors.Trace(err)
+			}
+		default:
+			return false, errors.Errorf("unknown message type %d", tp)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mq
+
+import (
+	"context"
+
+	"github.com/pingcap/tiflow/cdc/sink/codec/common"
+	"github.com/pingcap/tiflow/cdc/sinkv2/ddlsink/mq/ddlproducer"
+	"github.com/pingcap/tiflow/cdc/sinkv2/eventsink/tee"
+)
+
+// archivingProducer archives the messages sent by the producer successfully,
+// a message is only reported as sent once it's archived, so that the
+// checkpoint of the changefeed doesn't pass the DDLs which are not archived.
+type archivingProducer struct {
+	ddlproducer.DDLProducer
+	archive *tee.Archive
+}
+
+// SyncBroadcastMessage implements ddlproducer.DDLProducer.
+func (p *archivingProducer) SyncBroadcastMessage(
+	ctx context.Context, topic string, totalPartitionsNum int32, message *common.Message,
+) error {
+	if err := p.DDLProducer.SyncBroadcastMessage(
+		ctx, topic, totalPartitionsNum, message); err != nil {
+		return err
+	}
+	return p.archive.Append(ctx, topic, 0, true, message)
+}
+
+// SyncSendMessage implements ddlproducer.DDLProducer.
+func (p *archivingProducer) SyncSendMessage(
+	ctx context.Context, topic string, partitionNum int32, message *common.Message,
+) error {
+	if err := p.DDLProducer.SyncSendMessage(ctx, topic, partitionNum, message); err != nil {
+		return err
+	}
+	return p.archive.Append(ctx, topic, partitionNum, false, message)
+}
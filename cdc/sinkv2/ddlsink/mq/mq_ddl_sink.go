@@ -15,7 +15,10 @@ package mq
 
 import (
 	"context"
+	"strings"
+	"time"
 
+	"github.com/Shopify/sarama"
 	"github.com/pingcap/errors"
 	"github.com/pingcap/log"
 	"github.com/pingcap/tiflow/cdc/contextutil"
@@ -23,20 +26,34 @@ import (
 	"github.com/pingcap/tiflow/cdc/sink/codec"
 	"github.com/pingcap/tiflow/cdc/sink/codec/builder"
 	"github.com/pingcap/tiflow/cdc/sink/codec/common"
+	"github.com/pingcap/tiflow/cdc/sink/mq/contract"
 	"github.com/pingcap/tiflow/cdc/sink/mq/dispatcher"
 	"github.com/pingcap/tiflow/cdc/sink/mq/manager"
 	"github.com/pingcap/tiflow/cdc/sinkv2/ddlsink"
 	"github.com/pingcap/tiflow/cdc/sinkv2/ddlsink/mq/ddlproducer"
+	"github.com/pingcap/tiflow/cdc/sinkv2/eventsink/tee"
 	"github.com/pingcap/tiflow/cdc/sinkv2/metrics"
+	collector "github.com/pingcap/tiflow/cdc/sinkv2/metrics/mq/kafka"
 	"github.com/pingcap/tiflow/pkg/config"
 	cerror "github.com/pingcap/tiflow/pkg/errors"
 	"github.com/pingcap/tiflow/pkg/sink"
+	pkafka "github.com/pingcap/tiflow/pkg/sink/kafka"
+	"github.com/pingcap/tiflow/pkg/sink/schemahistory"
 	"go.uber.org/zap"
 )
 
 // Assert DDLEventSink implementation
 var _ ddlsink.DDLEventSink = (*ddlSink)(nil)
 
+// Assert ConsumerLagReporter implementation
+var _ ddlsink.ConsumerLagReporter = (*ddlSink)(nil)
+
+// Assert SavepointWriter implementation
+var _ ddlsink.SavepointWriter = (*ddlSink)(nil)
+
+// Assert Cleaner implementation
+var _ ddlsink.Cleaner = (*ddlSink)(nil)
+
 type ddlSink struct {
 	// id indicates which processor (changefeed) this sink belongs to.
 	id model.ChangeFeedID
@@ -54,6 +71,34 @@ type ddlSink struct {
 	producer ddlproducer.DDLProducer
 	// statistics is used to record DDL metrics.
 	statistics *metrics.Statistics
+	// selfChecker reads the produced messages back to verify the sink,
+	// it is nil if the self check is disabled.
+	selfChecker *selfChecker
+	// lagCollector polls the lag of the downstream consumer group,
+	// it is nil if no consumer group is configured.
+	lagCollector *collector.ConsumerLagCollector
+	// history maintains the schema history in a dedicated topic,
+	// it is nil if no schema history topic is configured.
+	history *schemahistory.History
+	// warmUpClient warms up the topics of all the tables before the first
+	// checkpoint is emitted, it is nil if the warm-up is disabled or done.
+	warmUpClient pkafka.Client
+	// sequencer stamps the DDL messages with sequence numbers, the
+	// checkpoints are not sequenced, it is nil if the sequence numbers are
+	// disabled.
+	sequencer *common.Sequencer
+	// headers attaches the CDC metadata to the messages,
+	// it is nil if no metadata is attached.
+	headers *common.MetadataHeaders
+	// contracts checks the DDLs against the consumer contracts of the
+	// topics, it is nil if there is no contract.
+	contracts *contract.Checker
+	// adminClient deletes the topics in the cleanup, it is nil if the
+	// topics can't be deleted.
+	adminClient pkafka.ClusterAdminClient
+	// archive archives the messages sent by the producer, it is nil if the
+	// tee sink is disabled.
+	archive *tee.Archive
 }
 
 func newDDLSink(ctx context.Context,
@@ -83,8 +128,30 @@ func newDDLSink(ctx context.Context,
 }
 
 func (k *ddlSink) WriteDDLEvent(ctx context.Context, ddl *model.DDLEvent) error {
+	topic := k.eventRouter.GetTopicForDDL(ddl)
+	// The breaking DDL is rejected before anything of it is sent.
+	if k.contracts != nil {
+		if err := k.contracts.Check(topic, ddl); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	// The schema is recorded before the DDL is emitted, so that a consumer
+	// can always find it when it sees the DDL or the data after it.
+	if k.history != nil {
+		if err := k.history.OnDDL(ctx, ddl); err != nil {
+			return errors.Trace(err)
+		}
+	}
 	encoder := k.encoderBuilder.Build()
-	msg, err := encoder.EncodeDDLEvent(ddl)
+	var (
+		msg *common.Message
+		err error
+	)
+	if e, ok := encoder.(codec.DDLEventTopicEncoder); ok {
+		msg, err = e.EncodeDDLEventForTopic(ctx, topic, ddl)
+	} else {
+		msg, err = encoder.EncodeDDLEvent(ddl)
+	}
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -97,13 +164,18 @@ func (k *ddlSink) WriteDDLEvent(ctx context.Context, ddl *model.DDLEvent) error
 		return nil
 	}
 
-	topic := k.eventRouter.GetTopicForDDL(ddl)
 	partitionRule := k.eventRouter.GetDLLDispatchRuleByProtocol(k.protocol)
 	log.Debug("Emit ddl event",
 		zap.Uint64("commitTs", ddl.CommitTs),
 		zap.String("query", ddl.Query),
 		zap.String("namespace", k.id.Namespace),
 		zap.String("changefeed", k.id.ID))
+	if k.headers != nil {
+		k.headers.StampDDL(msg, ddl)
+	}
+	if k.sequencer != nil {
+		k.sequencer.StampDDL(msg, topic, ddl.CommitTs)
+	}
 	if partitionRule == dispatcher.PartitionAll {
 		partitionNum, err := k.topicManager.GetPartitionNum(topic)
 		if err != nil {
@@ -112,7 +184,10 @@ func (k *ddlSink) WriteDDLEvent(ctx context.Context, ddl *model.DDLEvent) error
 		err = k.statistics.RecordDDLExecution(func() error {
 			return k.producer.SyncBroadcastMessage(ctx, topic, partitionNum, msg)
 		})
-		return errors.Trace(err)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		return nil
 	}
 	// Notice: We must call GetPartitionNum here,
 	// which will be responsible for automatically creating topics when they don't exist.
@@ -125,12 +200,34 @@ func (k *ddlSink) WriteDDLEvent(ctx context.Context, ddl *model.DDLEvent) error
 	err = k.statistics.RecordDDLExecution(func() error {
 		return k.producer.SyncSendMessage(ctx, topic, dispatcher.PartitionZero, msg)
 	})
-	return errors.Trace(err)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return nil
 }
 
 func (k *ddlSink) WriteCheckpointTs(ctx context.Context,
 	ts uint64, tables []*model.TableInfo,
 ) error {
+	return k.writeCheckpoint(ctx, ts, tables, "")
+}
+
+// writeCheckpoint broadcasts the checkpoint to all the partitions of the
+// topics, it's the marker of the savepoint if savepoint is not empty.
+func (k *ddlSink) writeCheckpoint(ctx context.Context,
+	ts uint64, tables []*model.TableInfo, savepoint string,
+) error {
+	if k.warmUpClient != nil {
+		if err := k.warmUp(tables); err != nil {
+			return errors.Trace(err)
+		}
+		k.warmUpClient = nil
+	}
+	if k.history != nil {
+		if err := k.history.OnCheckpoint(ctx, ts, tables); err != nil {
+			return errors.Trace(err)
+		}
+	}
 	encoder := k.encoderBuilder.Build()
 	msg, err := encoder.EncodeCheckpointEvent(ts)
 	if err != nil {
@@ -139,6 +236,11 @@ func (k *ddlSink) WriteCheckpointTs(ctx context.Context,
 	if msg == nil {
 		return nil
 	}
+	if savepoint != "" {
+		k.headers.StampSavepoint(msg, savepoint)
+	} else if k.headers != nil {
+		k.headers.StampResolved(msg)
+	}
 	// NOTICE: When there are no tables to replicate,
 	// we need to send checkpoint ts to the default topic.
 	// This will be compatible with the old behavior.
@@ -150,19 +252,19 @@ func (k *ddlSink) WriteCheckpointTs(ctx context.Context,
 		}
 		log.Debug("Emit checkpointTs to default topic",
 			zap.String("topic", topic), zap.Uint64("checkpointTs", ts))
+		k.monitorConsumerLag(topic, partitionNum)
 		err = k.producer.SyncBroadcastMessage(ctx, topic, partitionNum, msg)
-		return errors.Trace(err)
-	}
-	var tableNames []model.TableName
-	for _, table := range tables {
-		tableNames = append(tableNames, table.TableName)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		return nil
 	}
-	topics := k.eventRouter.GetActiveTopics(tableNames)
-	for _, topic := range topics {
+	for _, topic := range k.getCheckpointTopics(tables) {
 		partitionNum, err := k.topicManager.GetPartitionNum(topic)
 		if err != nil {
 			return errors.Trace(err)
 		}
+		k.monitorConsumerLag(topic, partitionNum)
 		err = k.producer.SyncBroadcastMessage(ctx, topic, partitionNum, msg)
 		if err != nil {
 			return errors.Trace(err)
@@ -171,7 +273,102 @@ func (k *ddlSink) WriteCheckpointTs(ctx context.Context,
 	return nil
 }
 
+// WriteSavepoint broadcasts the checkpoint at the ts of the savepoint to all
+// the partitions of the topics, it's the marker of the savepoint since the
+// protocols have no dedicated message for it. The marker carries the name of
+// the savepoint in the ticdc-savepoint header, so that the consumers can
+// tell it from the other checkpoints, and the consumers unaware of it just
+// see a checkpoint.
+func (k *ddlSink) WriteSavepoint(ctx context.Context,
+	savepoint *model.Savepoint, tables []*model.TableInfo,
+) error {
+	log.Info("Emit the savepoint marker",
+		zap.String("namespace", k.id.Namespace),
+		zap.String("changefeed", k.id.ID),
+		zap.String("savepoint", savepoint.Name),
+		zap.Uint64("ts", savepoint.Ts))
+	return k.writeCheckpoint(ctx, savepoint.Ts, tables, savepoint.Name)
+}
+
+// CleanUp implements the ddlsink.Cleaner interface, the targets are the
+// names of the topics to be deleted.
+func (k *ddlSink) CleanUp(ctx context.Context, targets []string) error {
+	if k.adminClient == nil {
+		return nil
+	}
+	for _, topic := range targets {
+		if err := ctx.Err(); err != nil {
+			return errors.Trace(err)
+		}
+		err := k.adminClient.DeleteTopic(topic)
+		if err != nil && !strings.Contains(err.Error(), sarama.ErrUnknownTopicOrPartition.Error()) {
+			return cerror.WrapError(cerror.ErrKafkaDeleteTopic, err)
+		}
+		log.Info("Kafka topic deleted in the cleanup",
+			zap.String("namespace", k.id.Namespace),
+			zap.String("changefeed", k.id.ID),
+			zap.String("topic", topic))
+	}
+	return nil
+}
+
+// getCheckpointTopics returns the topics which the checkpoint ts of
+// the given tables is sent to.
+func (k *ddlSink) getCheckpointTopics(tables []*model.TableInfo) []string {
+	if len(tables) == 0 {
+		return []string{k.eventRouter.GetDefaultTopic()}
+	}
+	var tableNames []model.TableName
+	for _, table := range tables {
+		tableNames = append(tableNames, table.TableName)
+	}
+	return k.eventRouter.GetActiveTopics(tableNames)
+}
+
+// warmUp creates the topics of the tables if they don't exist, fetches
+// their metadata and connects to the leaders of their partitions, so the
+// checkpoint can be broadcast without a burst of metadata requests.
+func (k *ddlSink) warmUp(tables []*model.TableInfo) error {
+	start := time.Now()
+	topics := k.getCheckpointTopics(tables)
+	for _, topic := range topics {
+		if _, err := k.topicManager.GetPartitionNum(topic); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if err := k.warmUpClient.WarmUp(topics); err != nil {
+		return cerror.WrapError(cerror.ErrKafkaNewSaramaProducer, err)
+	}
+	log.Info("Kafka topics warmed up",
+		zap.String("namespace", k.id.Namespace),
+		zap.String("changefeed", k.id.ID),
+		zap.Int("topics", len(topics)),
+		zap.Duration("duration", time.Since(start)))
+	return nil
+}
+
+// monitorConsumerLag adds the topic to the consumer lag collector. The
+// checkpoint ts is sent to all topics of the changefeed, so all of them
+// are monitored.
+func (k *ddlSink) monitorConsumerLag(topic string, partitionNum int32) {
+	if k.lagCollector != nil {
+		k.lagCollector.AddTopic(topic, partitionNum)
+	}
+}
+
+// ConsumerGroupLag implements the ddlsink.ConsumerLagReporter interface.
+func (k *ddlSink) ConsumerGroupLag() *model.ConsumerGroupLag {
+	if k.lagCollector == nil {
+		return nil
+	}
+	return k.lagCollector.Lag()
+}
+
 func (k *ddlSink) Close() error {
 	k.producer.Close()
+	k.topicManager.Close()
+	if k.archive != nil {
+		return k.archive.Close()
+	}
 	return nil
 }
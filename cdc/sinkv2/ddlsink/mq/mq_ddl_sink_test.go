@@ -22,6 +22,7 @@ import (
 	"github.com/Shopify/sarama"
 	mm "github.com/pingcap/tidb/parser/model"
 	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sink/codec/common"
 	mqv1 "github.com/pingcap/tiflow/cdc/sink/mq"
 	"github.com/pingcap/tiflow/cdc/sinkv2/ddlsink/mq/ddlproducer"
 	"github.com/pingcap/tiflow/pkg/config"
@@ -224,6 +225,45 @@ func TestWriteCheckpointTsToDefaultTopic(t *testing.T) {
 	}), 1)
 }
 
+func TestWriteSavepoint(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	leader, topic := initBroker(t, kafka.DefaultMockPartitionNum)
+	defer leader.Close()
+	uriTemplate := "kafka://%s/%s?kafka-version=0.9.0.0&max-batch-size=1" +
+		"&max-message-bytes=1048576&partition-num=1" +
+		"&kafka-client-id=unit-test&auto-create-topic=false&compression=gzip" +
+		"&protocol=canal-json&enable-tidb-extension=true"
+	uri := fmt.Sprintf(uriTemplate, leader.Addr(), topic)
+
+	sinkURI, err := url.Parse(uri)
+	require.Nil(t, err)
+	replicaConfig := config.GetDefaultReplicaConfig()
+	require.Nil(t, replicaConfig.ValidateAndAdjust(sinkURI))
+
+	s, err := NewKafkaDDLSink(ctx, sinkURI, replicaConfig,
+		kafka.NewMockAdminClient, kafka.NewMockClient,
+		ddlproducer.NewMockDDLProducer)
+	require.Nil(t, err)
+	require.NotNil(t, s)
+
+	savepoint := &model.Savepoint{Name: "sp1", Ts: 417318403368288260}
+	err = s.WriteSavepoint(ctx, savepoint, nil)
+	require.Nil(t, err)
+
+	// The marker is broadcast to all the partitions with the savepoint name.
+	messages := s.producer.(*ddlproducer.MockDDLProducer).GetAllEvents()
+	require.Len(t, messages, 3)
+	for _, msg := range messages {
+		require.Equal(t, []common.MessageHeader{
+			{Key: common.SavepointHeader, Value: []byte("sp1")},
+		}, msg.Headers)
+	}
+}
+
 func TestWriteCheckpointTsToTableTopics(t *testing.T) {
 	t.Parallel()
 
@@ -343,3 +383,166 @@ func TestWriteCheckpointTsWhenCanalJsonTiDBExtensionIsDisable(t *testing.T) {
 	require.Len(t, s.producer.(*ddlproducer.MockDDLProducer).GetAllEvents(),
 		0, "No topic and partition should be broadcast")
 }
+
+// loopbackClient reads the messages sent by the mock DDL producer back.
+type loopbackClient struct {
+	kafka.Client
+	producer *ddlproducer.MockDDLProducer
+}
+
+func (c *loopbackClient) GetOffset(topic string, partitionID int32, _ int64) (int64, error) {
+	return int64(len(c.producer.GetEvents(mqv1.TopicPartitionKey{
+		Topic: topic, Partition: partitionID,
+	}))), nil
+}
+
+func (c *loopbackClient) ReadMessages(_ context.Context, topic string,
+	partitionID int32, offset int64, count int,
+) ([]*sarama.ConsumerMessage, error) {
+	events := c.producer.GetEvents(mqv1.TopicPartitionKey{
+		Topic: topic, Partition: partitionID,
+	})
+	var messages []*sarama.ConsumerMessage
+	for i := offset; i < int64(len(events)) && len(messages) < count; i++ {
+		messages = append(messages, &sarama.ConsumerMessage{
+			Topic: topic, Partition: partitionID, Offset: i,
+			Key: events[i].Key, Value: events[i].Value,
+		})
+	}
+	return messages, nil
+}
+
+func TestSelfCheck(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	leader, topic := initBroker(t, kafka.DefaultMockPartitionNum)
+	defer leader.Close()
+	uriTemplate := "kafka://%s/%s?kafka-version=0.9.0.0&max-batch-size=1" +
+		"&max-message-bytes=1048576&partition-num=1" +
+		"&kafka-client-id=unit-test&auto-create-topic=false&compression=gzip" +
+		"&protocol=%s&self-check=true"
+	for _, protocol := range []string{
+		"open-protocol", "canal-json&enable-tidb-extension=true", "canal-json",
+	} {
+		sinkURI, err := url.Parse(fmt.Sprintf(uriTemplate, leader.Addr(), topic, protocol))
+		require.Nil(t, err)
+		replicaConfig := config.GetDefaultReplicaConfig()
+		require.Nil(t, replicaConfig.ValidateAndAdjust(sinkURI))
+
+		s, err := NewKafkaDDLSink(ctx, sinkURI, replicaConfig,
+			kafka.NewMockAdminClient, kafka.NewMockClient,
+			ddlproducer.NewMockDDLProducer)
+		require.Nil(t, err)
+		require.True(t, s.SelfCheckEnabled())
+		s.selfChecker.client = &loopbackClient{
+			Client:   s.selfChecker.client,
+			producer: s.producer.(*ddlproducer.MockDDLProducer),
+		}
+
+		checkpointTs := uint64(417318403368288260)
+		result, err := s.SelfCheck(ctx, checkpointTs, nil)
+		require.Nil(t, err)
+		require.True(t, result.Passed, result.Message)
+		if protocol == "canal-json" {
+			// No checkpoint message is produced without the TiDB extension.
+			require.Equal(t, 0, result.ConsumedMessages)
+		} else {
+			require.Equal(t, 1, result.ConsumedMessages)
+			require.Equal(t, int64(0), result.ConsumerLag)
+		}
+	}
+}
+
+func TestWarmUp(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	leader, topic := initBroker(t, kafka.DefaultMockPartitionNum)
+	defer leader.Close()
+	uriTemplate := "kafka://%s/%s?kafka-version=0.9.0.0&max-batch-size=1" +
+		"&max-message-bytes=1048576&partition-num=1" +
+		"&kafka-client-id=unit-test&auto-create-topic=true&compression=gzip" +
+		"&protocol=open-protocol&warm-up=true"
+	uri := fmt.Sprintf(uriTemplate, leader.Addr(), topic)
+
+	sinkURI, err := url.Parse(uri)
+	require.Nil(t, err)
+	replicaConfig := config.GetDefaultReplicaConfig()
+	require.Nil(t, replicaConfig.ValidateAndAdjust(sinkURI))
+	replicaConfig.Sink.DispatchRules = []*config.DispatchRule{
+		{
+			Matcher:   []string{"*.*"},
+			TopicRule: "{schema}_{table}",
+		},
+	}
+
+	s, err := NewKafkaDDLSink(ctx, sinkURI, replicaConfig,
+		kafka.NewMockAdminClient, kafka.NewMockClient,
+		ddlproducer.NewMockDDLProducer)
+	require.Nil(t, err)
+	client, ok := s.warmUpClient.(*kafka.ClientMockImpl)
+	require.True(t, ok)
+
+	tables := []*model.TableInfo{
+		{TableName: model.TableName{Schema: "cdc", Table: "person"}},
+		{TableName: model.TableName{Schema: "cdc", Table: "person1"}},
+	}
+	err = s.WriteCheckpointTs(ctx, uint64(417318403368288260), tables)
+	require.Nil(t, err)
+	require.ElementsMatch(t, []string{"mock_topic", "cdc_person", "cdc_person1"},
+		client.WarmedUpTopics)
+	require.Nil(t, s.warmUpClient)
+
+	// The topics are warmed up only once.
+	err = s.WriteCheckpointTs(ctx, uint64(417318403368288261), tables)
+	require.Nil(t, err)
+	require.Len(t, client.WarmedUpTopics, 3)
+}
+
+func TestCleanUp(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	leader, topic := initBroker(t, kafka.DefaultMockPartitionNum)
+	defer leader.Close()
+	uriTemplate := "kafka://%s/%s?kafka-version=0.9.0.0&max-batch-size=1" +
+		"&max-message-bytes=1048576&partition-num=1" +
+		"&kafka-client-id=unit-test&auto-create-topic=false&compression=gzip&protocol=open-protocol"
+	uri := fmt.Sprintf(uriTemplate, leader.Addr(), topic)
+
+	sinkURI, err := url.Parse(uri)
+	require.Nil(t, err)
+	replicaConfig := config.GetDefaultReplicaConfig()
+	require.Nil(t, replicaConfig.ValidateAndAdjust(sinkURI))
+
+	s, err := NewKafkaDDLSink(ctx, sinkURI, replicaConfig,
+		kafka.NewMockAdminClient, kafka.NewMockClient,
+		ddlproducer.NewMockDDLProducer)
+	require.Nil(t, err)
+	require.NotNil(t, s)
+
+	adminClient := s.adminClient.(*kafka.ClusterAdminClientMockImpl)
+	topics, err := adminClient.ListTopics()
+	require.Nil(t, err)
+	require.Contains(t, topics, topic)
+	err = adminClient.CreateTopic("other",
+		&sarama.TopicDetail{NumPartitions: 1, ReplicationFactor: 1}, false)
+	require.Nil(t, err)
+
+	require.Nil(t, s.CleanUp(ctx, []string{topic}))
+	topics, err = adminClient.ListTopics()
+	require.Nil(t, err)
+	require.NotContains(t, topics, topic)
+	// The topics out of the targets are kept.
+	require.Contains(t, topics, "other")
+
+	// The topics already deleted are skipped.
+	require.Nil(t, s.CleanUp(ctx, []string{topic}))
+}
@@ -0,0 +1,113 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"strings"
+
+	timodel "github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tiflow/cdc/model"
+)
+
+// ddlResources is the schemas and tables a DDL event touches. The DDL events
+// touching the same resources must be executed in order, while the others
+// can be executed concurrently.
+type ddlResources struct {
+	// global is true if the DDL may touch anything in the downstream, e.g.
+	// the DDLs without table info and the views referring to other tables.
+	global bool
+	// schemas are the schemas changed by the DDL itself.
+	schemas map[string]struct{}
+	// tables are the tables changed by the DDL, and sharedSchemas are the
+	// schemas of them.
+	tables        map[string]struct{}
+	sharedSchemas map[string]struct{}
+}
+
+func newDDLResources(ddl *model.DDLEvent) *ddlResources {
+	r := &ddlResources{
+		schemas:       make(map[string]struct{}),
+		tables:        make(map[string]struct{}),
+		sharedSchemas: make(map[string]struct{}),
+	}
+	if ddl.TableInfo == nil {
+		r.global = true
+		return r
+	}
+	switch ddl.Type {
+	case timodel.ActionCreateView, timodel.ActionDropView:
+		r.global = true
+	case timodel.ActionCreateSchema, timodel.ActionDropSchema,
+		timodel.ActionModifySchemaCharsetAndCollate:
+		r.schemas[strings.ToLower(ddl.TableInfo.TableName.Schema)] = struct{}{}
+	default:
+		r.addTable(ddl.TableInfo.TableName)
+		if ddl.PreTableInfo != nil {
+			r.addTable(ddl.PreTableInfo.TableName)
+		}
+	}
+	return r
+}
+
+func (r *ddlResources) addTable(name model.TableName) {
+	// Table names are compared case-insensitively, it may introduce
+	// some needless dependencies but never misses one.
+	schema := strings.ToLower(name.Schema)
+	r.tables[schema+"."+strings.ToLower(name.Table)] = struct{}{}
+	r.sharedSchemas[schema] = struct{}{}
+}
+
+// conflictWith returns true if the DDLs of the two resources must be
+// executed in order.
+func (r *ddlResources) conflictWith(o *ddlResources) bool {
+	if r.global || o.global {
+		return true
+	}
+	for schema := range r.schemas {
+		if _, ok := o.schemas[schema]; ok {
+			return true
+		}
+		if _, ok := o.sharedSchemas[schema]; ok {
+			return true
+		}
+	}
+	for schema := range o.schemas {
+		if _, ok := r.sharedSchemas[schema]; ok {
+			return true
+		}
+	}
+	for table := range r.tables {
+		if _, ok := o.tables[table]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// buildDDLDependencies returns the indexes of the preceding DDL events each
+// DDL event depends on.
+func buildDDLDependencies(ddls []*model.DDLEvent) [][]int {
+	resources := make([]*ddlResources, 0, len(ddls))
+	deps := make([][]int, len(ddls))
+	for i, ddl := range ddls {
+		r := newDDLResources(ddl)
+		for j := 0; j < i; j++ {
+			if r.conflictWith(resources[j]) {
+				deps[i] = append(deps[i], j)
+			}
+		}
+		resources = append(resources, r)
+	}
+	return deps
+}
@@ -0,0 +1,118 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"net/url"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	timodel "github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tiflow/cdc/contextutil"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/config"
+	pmysql "github.com/pingcap/tiflow/pkg/sink/mysql"
+	"github.com/stretchr/testify/require"
+)
+
+func newTableDDL(tp timodel.ActionType, schema, table string) *model.DDLEvent {
+	return &model.DDLEvent{
+		TableInfo: &model.TableInfo{
+			TableName: model.TableName{Schema: schema, Table: table},
+		},
+		Type: tp,
+	}
+}
+
+func TestBuildDDLDependencies(t *testing.T) {
+	t.Parallel()
+
+	rename := newTableDDL(timodel.ActionRenameTable, "test", "t4")
+	rename.PreTableInfo = &model.TableInfo{
+		TableName: model.TableName{Schema: "test", Table: "t1"},
+	}
+	ddls := []*model.DDLEvent{
+		newTableDDL(timodel.ActionCreateTable, "test", "t1"),
+		newTableDDL(timodel.ActionCreateTable, "test", "t2"),
+		newTableDDL(timodel.ActionAddColumn, "test", "T1"),
+		newTableDDL(timodel.ActionCreateSchema, "test2", ""),
+		newTableDDL(timodel.ActionCreateTable, "test2", "t1"),
+		rename,
+		newTableDDL(timodel.ActionCreateTable, "test", "t3"),
+		{Type: timodel.ActionCreateTable},
+	}
+	deps := buildDDLDependencies(ddls)
+	require.Equal(t, [][]int{
+		nil,
+		nil,
+		{0},
+		nil,
+		{3},
+		{0, 2},
+		nil,
+		{0, 1, 2, 3, 4, 5, 6},
+	}, deps)
+}
+
+func TestWriteDDLEvents(t *testing.T) {
+	t.Parallel()
+
+	dbIndex := 0
+	mockGetDBConn := func(ctx context.Context, dsnStr string) (*sql.DB, error) {
+		defer func() {
+			dbIndex++
+		}()
+		if dbIndex == 0 {
+			// test db
+			db, err := pmysql.MockTestDB(true)
+			require.Nil(t, err)
+			return db, nil
+		}
+		// normal db, the DDLs on the same table are executed in order.
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		require.Nil(t, err)
+		for _, query := range []string{
+			"CREATE TABLE test.t1(id int)",
+			"ALTER TABLE test.t1 ADD COLUMN a int",
+		} {
+			mock.ExpectBegin()
+			mock.ExpectExec("USE `test`;").WillReturnResult(sqlmock.NewResult(1, 1))
+			mock.ExpectExec(query).WillReturnResult(sqlmock.NewResult(1, 1))
+			mock.ExpectCommit()
+		}
+		mock.ExpectClose()
+		return db, nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	contextutil.PutChangefeedIDInCtx(ctx, model.DefaultChangeFeedID("test-changefeed"))
+	sinkURI, err := url.Parse("mysql://127.0.0.1:4000?ddl-concurrency=4")
+	require.Nil(t, err)
+	sink, err := NewMySQLDDLSink(ctx, sinkURI, config.GetDefaultReplicaConfig(), mockGetDBConn)
+	require.Nil(t, err)
+	require.Equal(t, 4, sink.cfg.DDLConcurrency)
+
+	ddl1 := newTableDDL(timodel.ActionCreateTable, "test", "t1")
+	ddl1.Query = "CREATE TABLE test.t1(id int)"
+	ddl2 := newTableDDL(timodel.ActionAddColumn, "test", "t1")
+	ddl2.Query = "ALTER TABLE test.t1 ADD COLUMN a int"
+	err = sink.WriteDDLEvents(ctx, []*model.DDLEvent{ddl1, ddl2})
+	require.Nil(t, err)
+
+	err = sink.Close()
+	require.Nil(t, err)
+}
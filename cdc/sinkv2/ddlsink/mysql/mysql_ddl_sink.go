@@ -17,6 +17,7 @@ import (
 	"context"
 	"database/sql"
 	"net/url"
+	"sync"
 	"time"
 
 	"github.com/pingcap/errors"
@@ -35,6 +36,8 @@ import (
 	"github.com/pingcap/tiflow/pkg/sink"
 	pmysql "github.com/pingcap/tiflow/pkg/sink/mysql"
 	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+	"golang.org/x/sync/semaphore"
 )
 
 const (
@@ -45,17 +48,30 @@ const (
 )
 
 // Assert DDLEventSink implementation
-var _ ddlsink.DDLEventSink = (*mysqlDDLSink)(nil)
+var (
+	_ ddlsink.DDLEventSink       = (*mysqlDDLSink)(nil)
+	_ ddlsink.CredentialsUpdater = (*mysqlDDLSink)(nil)
+	_ ddlsink.ConcurrentWriter   = (*mysqlDDLSink)(nil)
+)
 
 type mysqlDDLSink struct {
 	// id indicates which processor (changefeed) this sink belongs to.
 	id model.ChangeFeedID
+	// mu protects db and cfg, which are swapped when the credentials are
+	// updated, from the DDLs being executed concurrently.
+	mu sync.RWMutex
 	// db is the database connection.
 	db  *sql.DB
 	cfg *pmysql.Config
+	// replicaConfig and dbConnFactory are used to re-establish the
+	// connection when the credentials are updated.
+	replicaConfig *config.ReplicaConfig
+	dbConnFactory pmysql.Factory
 	// statistics is the statistics of this sink.
 	// We use it to record the DDL count.
 	statistics *metrics.Statistics
+	// savepointTableCreated is true once the savepoint table is created.
+	savepointTableCreated bool
 }
 
 // NewMySQLDDLSink creates a new mysqlDDLSink.
@@ -83,10 +99,12 @@ func NewMySQLDDLSink(
 	}
 
 	m := &mysqlDDLSink{
-		id:         changefeedID,
-		db:         db,
-		cfg:        cfg,
-		statistics: metrics.NewStatistics(ctx, sink.TxnSink),
+		id:            changefeedID,
+		db:            db,
+		cfg:           cfg,
+		replicaConfig: replicaConfig,
+		dbConnFactory: dbConnFactory,
+		statistics:    metrics.NewStatistics(ctx, sink.TxnSink),
 	}
 
 	log.Info("MySQL DDL sink is created",
@@ -95,13 +113,83 @@ func NewMySQLDDLSink(
 	return m, nil
 }
 
+// UpdateCredentials re-establishes the connection with the credentials in
+// the sink URI, and closes the connection with the old ones. It waits for
+// the DDLs being executed with the old connection to finish.
+func (m *mysqlDDLSink) UpdateCredentials(ctx context.Context, sinkURI *url.URL) error {
+	cfg := pmysql.NewConfig()
+	err := cfg.Apply(ctx, m.id, sinkURI, m.replicaConfig)
+	if err != nil {
+		return err
+	}
+	dsnStr, err := pmysql.GenerateDSN(ctx, sinkURI, cfg, m.dbConnFactory)
+	if err != nil {
+		return err
+	}
+	db, err := m.dbConnFactory(ctx, dsnStr)
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	oldDB := m.db
+	m.db = db
+	m.cfg = cfg
+	m.mu.Unlock()
+	if err := oldDB.Close(); err != nil {
+		log.Warn("failed to close the connection with the old credentials",
+			zap.String("namespace", m.id.Namespace),
+			zap.String("changefeed", m.id.ID),
+			zap.Error(err))
+	}
+	log.Info("MySQL DDL sink switched to the connection with the updated credentials",
+		zap.String("namespace", m.id.Namespace),
+		zap.String("changefeed", m.id.ID))
+	return nil
+}
+
 func (m *mysqlDDLSink) WriteDDLEvent(ctx context.Context, ddl *model.DDLEvent) error {
 	err := m.execDDLWithMaxRetries(ctx, ddl)
 	return errors.Trace(err)
 }
 
+// WriteDDLEvents executes the DDL events on independent tables concurrently,
+// at most DDLConcurrency ones at a time, and the ones depending on each other
+// in their order in ddls.
+func (m *mysqlDDLSink) WriteDDLEvents(ctx context.Context, ddls []*model.DDLEvent) error {
+	deps := buildDDLDependencies(ddls)
+	done := make([]chan struct{}, len(ddls))
+	for i := range done {
+		done[i] = make(chan struct{})
+	}
+	sem := semaphore.NewWeighted(int64(m.config().DDLConcurrency))
+	g, ctx := errgroup.WithContext(ctx)
+	for i := range ddls {
+		i := i
+		g.Go(func() error {
+			for _, j := range deps[i] {
+				select {
+				case <-ctx.Done():
+					return errors.Trace(ctx.Err())
+				case <-done[j]:
+				}
+			}
+			if err := sem.Acquire(ctx, 1); err != nil {
+				return errors.Trace(err)
+			}
+			defer sem.Release(1)
+			if err := m.execDDLWithMaxRetries(ctx, ddls[i]); err != nil {
+				return err
+			}
+			close(done[i])
+			return nil
+		})
+	}
+	return errors.Trace(g.Wait())
+}
+
 func (m *mysqlDDLSink) execDDLWithMaxRetries(ctx context.Context, ddl *model.DDLEvent) error {
-	return retry.Do(ctx, func() error {
+	op := func() error {
 		err := m.statistics.RecordDDLExecution(func() error { return m.execDDL(ctx, ddl) })
 		if err != nil {
 			if errorutil.IsIgnorableMySQLDDLError(err) {
@@ -122,13 +210,29 @@ func (m *mysqlDDLSink) execDDLWithMaxRetries(ctx context.Context, ddl *model.DDL
 			return err
 		}
 		return nil
-	}, retry.WithBackoffBaseDelay(pmysql.BackoffBaseDelay.Milliseconds()),
+	}
+	budget := m.config().RetryBudget
+	opts := append([]retry.Option{
+		retry.WithBackoffBaseDelay(pmysql.BackoffBaseDelay.Milliseconds()),
 		retry.WithBackoffMaxDelay(pmysql.BackoffMaxDelay.Milliseconds()),
 		retry.WithMaxTries(defaultDDLMaxRetry),
-		retry.WithIsRetryableErr(cerror.IsRetryableError))
+		retry.WithIsRetryableErr(cerror.IsRetryableError),
+	}, budget.RetryOptions()...)
+	return budget.Escalate(retry.Do(ctx, op, opts...))
+}
+
+// config returns the current config of the sink.
+func (m *mysqlDDLSink) config() *pmysql.Config {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.cfg
 }
 
 func (m *mysqlDDLSink) execDDL(pctx context.Context, ddl *model.DDLEvent) error {
+	// The connection is not swapped until the DDL is executed.
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
 	writeTimeout, _ := time.ParseDuration(m.cfg.WriteTimeout)
 	writeTimeout += networkDriftDuration
 	ctx, cancelFunc := context.WithTimeout(pctx, writeTimeout)
@@ -205,6 +309,8 @@ func (m *mysqlDDLSink) WriteCheckpointTs(_ context.Context, _ uint64, _ []*model
 
 // Close closes the database connection.
 func (m *mysqlDDLSink) Close() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	if err := m.db.Close(); err != nil {
 		return errors.Trace(err)
 	}
@@ -0,0 +1,87 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"context"
+
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sinkv2/ddlsink"
+	"github.com/pingcap/tiflow/pkg/config"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"go.uber.org/zap"
+)
+
+const (
+	// savepointSchemaName is the name of database where the savepoint table sits.
+	savepointSchemaName = "tidb_cdc"
+	// savepointTableName is the name of table where the savepoint markers sit.
+	savepointTableName = "savepoint_v1"
+)
+
+// Assert SavepointWriter implementation
+var _ ddlsink.SavepointWriter = (*mysqlDDLSink)(nil)
+
+// WriteSavepoint inserts a row of the savepoint into the savepoint table,
+// which is created if it does not exist.
+func (m *mysqlDDLSink) WriteSavepoint(ctx context.Context,
+	savepoint *model.Savepoint, _ []*model.TableInfo,
+) error {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if !m.savepointTableCreated {
+		if err := m.createSavepointTable(ctx); err != nil {
+			return err
+		}
+		m.savepointTableCreated = true
+	}
+
+	query := "INSERT IGNORE INTO " + savepointSchemaName + "." + savepointTableName +
+		" (ticdc_cluster_id, namespace, changefeed, name, primary_ts) VALUES (?,?,?,?,?)"
+	_, err := m.db.ExecContext(ctx, query, config.GetGlobalServerConfig().ClusterID,
+		m.id.Namespace, m.id.ID, savepoint.Name, savepoint.Ts)
+	if err != nil {
+		return cerror.WrapError(cerror.ErrMySQLTxnError, err)
+	}
+	log.Info("MySQL DDL sink wrote the savepoint",
+		zap.String("namespace", m.id.Namespace),
+		zap.String("changefeed", m.id.ID),
+		zap.String("savepoint", savepoint.Name),
+		zap.Uint64("ts", savepoint.Ts))
+	return nil
+}
+
+func (m *mysqlDDLSink) createSavepointTable(ctx context.Context) error {
+	queries := []string{
+		"CREATE DATABASE IF NOT EXISTS " + savepointSchemaName,
+		`CREATE TABLE IF NOT EXISTS ` + savepointSchemaName + "." + savepointTableName + `
+	(
+		ticdc_cluster_id varchar(64),
+		namespace varchar(64),
+		changefeed varchar(255),
+		name varchar(255),
+		primary_ts varchar(18),
+		created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		PRIMARY KEY (ticdc_cluster_id, namespace, changefeed, name)
+	)`,
+	}
+	for _, query := range queries {
+		if _, err := m.db.ExecContext(ctx, query); err != nil {
+			return cerror.WrapError(cerror.ErrMySQLTxnError, err)
+		}
+	}
+	return nil
+}
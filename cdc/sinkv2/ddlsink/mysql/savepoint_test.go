@@ -0,0 +1,76 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"net/url"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/pingcap/tiflow/cdc/contextutil"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/config"
+	pmysql "github.com/pingcap/tiflow/pkg/sink/mysql"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteSavepoint(t *testing.T) {
+	t.Parallel()
+
+	dbIndex := 0
+	mockGetDBConn := func(ctx context.Context, dsnStr string) (*sql.DB, error) {
+		defer func() {
+			dbIndex++
+		}()
+		if dbIndex == 0 {
+			// test db
+			db, err := pmysql.MockTestDB(true)
+			require.Nil(t, err)
+			return db, nil
+		}
+		// normal db, the savepoint table is created only once.
+		db, mock, err := sqlmock.New()
+		require.Nil(t, err)
+		mock.ExpectExec("CREATE DATABASE IF NOT EXISTS tidb_cdc").
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec("CREATE TABLE IF NOT EXISTS tidb_cdc.savepoint_v1").
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec("INSERT IGNORE INTO tidb_cdc.savepoint_v1").
+			WithArgs(sqlmock.AnyArg(), "default", "test-changefeed", "sp1", uint64(100)).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec("INSERT IGNORE INTO tidb_cdc.savepoint_v1").
+			WithArgs(sqlmock.AnyArg(), "default", "test-changefeed", "sp2", uint64(200)).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectClose()
+		return db, nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ctx = contextutil.PutChangefeedIDInCtx(ctx, model.DefaultChangeFeedID("test-changefeed"))
+	sinkURI, err := url.Parse("mysql://127.0.0.1:4000")
+	require.Nil(t, err)
+	sink, err := NewMySQLDDLSink(ctx, sinkURI, config.GetDefaultReplicaConfig(), mockGetDBConn)
+	require.Nil(t, err)
+
+	err = sink.WriteSavepoint(ctx, &model.Savepoint{Name: "sp1", Ts: 100}, nil)
+	require.Nil(t, err)
+	err = sink.WriteSavepoint(ctx, &model.Savepoint{Name: "sp2", Ts: 200}, nil)
+	require.Nil(t, err)
+
+	err = sink.Close()
+	require.Nil(t, err)
+}
@@ -0,0 +1,133 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/cdc/contextutil"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sink/codec"
+	"github.com/pingcap/tiflow/cdc/sink/codec/builder"
+	"github.com/pingcap/tiflow/cdc/sink/codec/common"
+	"github.com/pingcap/tiflow/cdc/sinkv2/ddlsink"
+	"github.com/pingcap/tiflow/cdc/sinkv2/metrics"
+	"github.com/pingcap/tiflow/cdc/sinkv2/util"
+	"github.com/pingcap/tiflow/pkg/config"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/sink"
+	"github.com/pingcap/tiflow/pkg/sink/webhook"
+	"go.uber.org/zap"
+)
+
+// Assert DDLEventSink implementation
+var _ ddlsink.DDLEventSink = (*ddlSink)(nil)
+
+type ddlSink struct {
+	// id indicates which changefeed this sink belongs to.
+	id model.ChangeFeedID
+	// statistic is used to record the DDL metrics
+	statistics *metrics.Statistics
+	protocol   config.Protocol
+	encoder    codec.EventBatchEncoder
+	client     *webhook.Client
+}
+
+// NewWebhookDDLSink creates a ddl sink for the webhook.
+func NewWebhookDDLSink(
+	ctx context.Context,
+	sinkURI *url.URL,
+	replicaConfig *config.ReplicaConfig,
+) (*ddlSink, error) {
+	cfg := webhook.NewConfig()
+	if err := cfg.Apply(sinkURI); err != nil {
+		return nil, err
+	}
+	protocol, err := util.GetProtocol(replicaConfig.Sink.Protocol)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	changefeedID := contextutil.ChangefeedIDFromCtx(ctx)
+	if identity := replicaConfig.Sink.ClientIdentity; identity != nil {
+		cfg.UserAgent = config.ExpandClientIdentity(identity.HTTPUserAgent,
+			changefeedID.Namespace, changefeedID.ID, contextutil.CaptureAddrFromCtx(ctx))
+	}
+	encoderConfig, err := util.GetEncoderConfig(sinkURI, protocol, replicaConfig,
+		config.DefaultMaxMessageBytes)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	encoderConfig.ChangefeedID = changefeedID
+	encoderBuilder, err := builder.NewEventBatchEncoderBuilder(ctx, encoderConfig)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrWebhookInvalidConfig, err)
+	}
+	client, err := webhook.NewClient(changefeedID, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &ddlSink{
+		id:         changefeedID,
+		statistics: metrics.NewStatistics(ctx, sink.RowSink),
+		protocol:   protocol,
+		encoder:    encoderBuilder.Build(),
+		client:     client,
+	}, nil
+}
+
+// WriteDDLEvent encodes the DDL and posts it to the endpoint, the DDLs which
+// are not encoded by the protocol are skipped.
+func (d *ddlSink) WriteDDLEvent(ctx context.Context, ddl *model.DDLEvent) error {
+	msg, err := d.encoder.EncodeDDLEvent(ddl)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if msg == nil {
+		log.Info("Skip DDL which is not encoded by the protocol",
+			zap.String("namespace", d.id.Namespace),
+			zap.String("changefeed", d.id.ID),
+			zap.String("protocol", d.protocol.String()),
+			zap.String("query", ddl.Query))
+		return nil
+	}
+	return d.statistics.RecordDDLExecution(func() error {
+		for _, req := range webhook.BuildRequests(d.protocol, []*common.Message{msg}) {
+			if err := d.client.Send(ctx, req); err != nil {
+				return err
+			}
+		}
+		log.Info("Execute DDL succeeded",
+			zap.String("namespace", d.id.Namespace),
+			zap.String("changefeed", d.id.ID),
+			zap.String("query", ddl.Query))
+		return nil
+	})
+}
+
+// WriteCheckpointTs does nothing, the webhook doesn't receive the checkpoint.
+func (d *ddlSink) WriteCheckpointTs(_ context.Context, _ uint64, _ []*model.TableInfo) error {
+	return nil
+}
+
+// Close closes the sink.
+func (d *ddlSink) Close() error {
+	d.client.Close()
+	if d.statistics != nil {
+		d.statistics.Close()
+	}
+	return nil
+}
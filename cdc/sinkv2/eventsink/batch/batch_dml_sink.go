@@ -0,0 +1,199 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package batch
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/cdc/contextutil"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sinkv2/eventsink"
+	"github.com/pingcap/tiflow/cdc/sinkv2/metrics"
+	"github.com/pingcap/tiflow/cdc/sinkv2/tablesink/state"
+	"github.com/pingcap/tiflow/pkg/chann"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/pingcap/tiflow/pkg/sink"
+	"go.uber.org/zap"
+)
+
+// Assert EventSink[E event.TableEvent] implementation
+var _ eventsink.EventSink[*model.RowChangedEvent] = (*DMLSink)(nil)
+
+// Writer encodes the rows into batches and writes the batches to the
+// downstream of a DMLSink.
+type Writer interface {
+	// BatchKey returns the key of the batch which the row is added to, the
+	// rows of different keys are written separately.
+	BatchKey(row *model.RowChangedEvent) model.TableName
+	// Encode appends the row to the body of its batch.
+	Encode(buf *bytes.Buffer, row *model.RowChangedEvent) error
+	// Write writes the body of a batch to the downstream.
+	Write(ctx context.Context, key model.TableName, body []byte) error
+	// Close closes the writer.
+	Close()
+}
+
+// rowBatch buffers the encoded rows of a batch.
+type rowBatch struct {
+	buf    bytes.Buffer
+	events []*eventsink.RowChangeCallbackableEvent
+}
+
+// DMLSink batches the row changed events by the keys given by its Writer,
+// a batch is written when it's full or the flush interval elapses.
+type DMLSink struct {
+	name          string
+	changefeedID  model.ChangeFeedID
+	writer        Writer
+	batchSize     int
+	flushInterval time.Duration
+	statistics    *metrics.Statistics
+	// eventCh is an unbounded channel to hold the events.
+	eventCh *chann.Chann[*eventsink.RowChangeCallbackableEvent]
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewDMLSink creates a DMLSink writing by the writer, the name is the name
+// of the sink in the logs.
+func NewDMLSink(
+	ctx context.Context, name string, writer Writer,
+	batchSize int, flushInterval time.Duration, errCh chan error,
+) *DMLSink {
+	ctx, cancel := context.WithCancel(ctx)
+	s := &DMLSink{
+		name:          name,
+		changefeedID:  contextutil.ChangefeedIDFromCtx(ctx),
+		writer:        writer,
+		batchSize:     batchSize,
+		flushInterval: flushInterval,
+		statistics:    metrics.NewStatistics(ctx, sink.RowSink),
+		eventCh:       chann.New[*eventsink.RowChangeCallbackableEvent](),
+		cancel:        cancel,
+	}
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		if err := s.run(ctx); err != nil && errors.Cause(err) != context.Canceled {
+			select {
+			case <-ctx.Done():
+			case errCh <- err:
+			default:
+				log.Error("Error channel is full in batch sink",
+					zap.String("sink", s.name),
+					zap.String("namespace", s.changefeedID.Namespace),
+					zap.String("changefeed", s.changefeedID.ID),
+					zap.Error(err))
+			}
+		}
+	}()
+	return s
+}
+
+// WriteEvents writes events to the sink.
+// This is an asynchronously and thread-safe method.
+func (s *DMLSink) WriteEvents(rows ...*eventsink.RowChangeCallbackableEvent) error {
+	for _, row := range rows {
+		// This never be blocked because this is an unbounded channel.
+		s.eventCh.In() <- row
+	}
+	return nil
+}
+
+func (s *DMLSink) run(ctx context.Context) error {
+	ticker := time.NewTicker(s.flushInterval)
+	defer ticker.Stop()
+
+	batches := make(map[model.TableName]*rowBatch)
+	for {
+		select {
+		case <-ctx.Done():
+			return errors.Trace(ctx.Err())
+		case <-ticker.C:
+			for key, batch := range batches {
+				if err := s.flush(ctx, key, batch); err != nil {
+					return err
+				}
+				delete(batches, key)
+			}
+		case row, ok := <-s.eventCh.Out():
+			if !ok {
+				return nil
+			}
+			if row.GetTableSinkState() != state.TableSinkSinking {
+				// The table where the event comes from is in stopping, so it's safe
+				// to drop the event directly.
+				row.Callback()
+				continue
+			}
+			key := s.writer.BatchKey(row.Event)
+			batch, ok := batches[key]
+			if !ok {
+				batch = &rowBatch{}
+				batches[key] = batch
+			}
+			if err := s.writer.Encode(&batch.buf, row.Event); err != nil {
+				return err
+			}
+			batch.events = append(batch.events, row)
+			if len(batch.events) >= s.batchSize {
+				if err := s.flush(ctx, key, batch); err != nil {
+					return err
+				}
+				delete(batches, key)
+			}
+		}
+	}
+}
+
+// flush writes the batch and acknowledges its events.
+func (s *DMLSink) flush(ctx context.Context, key model.TableName, batch *rowBatch) error {
+	err := s.statistics.RecordBatchExecution(func() (int, error) {
+		if err := s.writer.Write(ctx, key, batch.buf.Bytes()); err != nil {
+			return 0, err
+		}
+		return len(batch.events), nil
+	})
+	if err != nil {
+		return err
+	}
+	for _, event := range batch.events {
+		s.statistics.ObserveRows(event.Event)
+		s.statistics.ObserveEventAge(config.MetricLabelTable, event.Event.Table.String(),
+			event.Event.CommitTs)
+		event.Callback()
+	}
+	return nil
+}
+
+// Close closes the sink.
+func (s *DMLSink) Close() error {
+	s.cancel()
+	s.wg.Wait()
+	s.eventCh.Close()
+	// We must finish consuming the data here,
+	// otherwise it will cause the channel to not close properly.
+	for range s.eventCh.Out() {
+		// Do nothing. We do not care about the data.
+	}
+	s.writer.Close()
+	s.statistics.Close()
+	return nil
+}
@@ -0,0 +1,120 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package batch
+
+import (
+	"bytes"
+	"context"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sinkv2/eventsink"
+	"github.com/pingcap/tiflow/cdc/sinkv2/tablesink/state"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/atomic"
+)
+
+// mockWriter batches the rows per table and records the written batches.
+type mockWriter struct {
+	mu       sync.Mutex
+	batches  map[model.TableName][]string
+	writeErr error
+}
+
+func (w *mockWriter) BatchKey(row *model.RowChangedEvent) model.TableName {
+	return *row.Table
+}
+
+func (w *mockWriter) Encode(buf *bytes.Buffer, row *model.RowChangedEvent) error {
+	buf.WriteString(strconv.FormatUint(row.CommitTs, 10) + "\n")
+	return nil
+}
+
+func (w *mockWriter) Write(_ context.Context, key model.TableName, body []byte) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.writeErr != nil {
+		return w.writeErr
+	}
+	w.batches[key] = append(w.batches[key], string(body))
+	return nil
+}
+
+func (w *mockWriter) Close() {}
+
+func newEvent(
+	table string, commitTs uint64, sinkState state.TableSinkState, acked *atomic.Int64,
+) *eventsink.RowChangeCallbackableEvent {
+	return &eventsink.RowChangeCallbackableEvent{
+		Event: &model.RowChangedEvent{
+			CommitTs: commitTs,
+			Table:    &model.TableName{Schema: "test", Table: table},
+		},
+		Callback:  func() { acked.Inc() },
+		SinkState: &sinkState,
+	}
+}
+
+func TestWriteEvents(t *testing.T) {
+	t.Parallel()
+
+	w := &mockWriter{batches: make(map[model.TableName][]string)}
+	errCh := make(chan error, 1)
+	s := NewDMLSink(context.Background(), "test", w, 2, 100*time.Millisecond, errCh)
+	defer s.Close()
+
+	var acked atomic.Int64
+	// the batch of t1 is written once it's full, the one of t2 is written
+	// after the flush interval, and the row of the stopping table is dropped.
+	require.Nil(t, s.WriteEvents(
+		newEvent("t1", 1, state.TableSinkSinking, &acked),
+		newEvent("t2", 2, state.TableSinkSinking, &acked),
+		newEvent("t3", 3, state.TableSinkStopping, &acked),
+		newEvent("t1", 4, state.TableSinkSinking, &acked),
+	))
+	require.Eventually(t, func() bool {
+		return acked.Load() == 4
+	}, 5*time.Second, 10*time.Millisecond)
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	require.Equal(t, map[model.TableName][]string{
+		{Schema: "test", Table: "t1"}: {"1\n4\n"},
+		{Schema: "test", Table: "t2"}: {"2\n"},
+	}, w.batches)
+	require.Len(t, errCh, 0)
+}
+
+func TestWriteEventsError(t *testing.T) {
+	t.Parallel()
+
+	w := &mockWriter{batches: make(map[model.TableName][]string), writeErr: errors.New("write failed")}
+	errCh := make(chan error, 1)
+	s := NewDMLSink(context.Background(), "test", w, 1, time.Minute, errCh)
+	defer s.Close()
+
+	var acked atomic.Int64
+	require.Nil(t, s.WriteEvents(newEvent("t1", 1, state.TableSinkSinking, &acked)))
+	select {
+	case err := <-errCh:
+		require.Regexp(t, "write failed", err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the error is not reported")
+	}
+	require.Equal(t, int64(0), acked.Load())
+}
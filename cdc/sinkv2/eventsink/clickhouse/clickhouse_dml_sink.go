@@ -0,0 +1,68 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clickhouse
+
+import (
+	"bytes"
+	"context"
+	"net/url"
+
+	"github.com/pingcap/tiflow/cdc/contextutil"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sinkv2/eventsink/batch"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/pingcap/tiflow/pkg/sink/clickhouse"
+)
+
+// writer inserts the batches into the ReplacingMergeTree tables, the rows
+// are batched per table.
+type writer struct {
+	client *clickhouse.Client
+}
+
+func (w *writer) BatchKey(row *model.RowChangedEvent) model.TableName {
+	return *row.Table
+}
+
+func (w *writer) Encode(buf *bytes.Buffer, row *model.RowChangedEvent) error {
+	return clickhouse.EncodeRow(buf, row)
+}
+
+func (w *writer) Write(ctx context.Context, table model.TableName, body []byte) error {
+	return w.client.Insert(ctx, table.Schema, table.Table, body)
+}
+
+func (w *writer) Close() {
+	w.client.Close()
+}
+
+// NewClickHouseSink creates a ClickHouse sink. It writes the row changed
+// events to the ReplacingMergeTree tables, the rows are batched per table
+// and inserted when the batch is full or the flush interval elapses.
+func NewClickHouseSink(
+	ctx context.Context, sinkURI *url.URL, replicaConfig *config.ReplicaConfig, errCh chan error,
+) (*batch.DMLSink, error) {
+	cfg := clickhouse.NewConfig()
+	if err := cfg.Apply(sinkURI); err != nil {
+		return nil, err
+	}
+	cfg.ApplyClientIdentity(replicaConfig.Sink.ClientIdentity,
+		contextutil.ChangefeedIDFromCtx(ctx), contextutil.CaptureAddrFromCtx(ctx))
+	client, err := clickhouse.NewClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return batch.NewDMLSink(ctx, "ClickHouse", &writer{client: client},
+		cfg.BatchSize, cfg.FlushInterval, errCh), nil
+}
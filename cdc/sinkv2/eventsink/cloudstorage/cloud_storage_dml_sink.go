@@ -21,16 +21,20 @@ import (
 	"github.com/pingcap/errors"
 	"github.com/pingcap/tiflow/cdc/contextutil"
 	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sink/codec"
 	"github.com/pingcap/tiflow/cdc/sink/codec/builder"
 	"github.com/pingcap/tiflow/cdc/sink/codec/common"
+	"github.com/pingcap/tiflow/cdc/sink/codec/parquet"
 	"github.com/pingcap/tiflow/cdc/sinkv2/eventsink"
 	"github.com/pingcap/tiflow/cdc/sinkv2/metrics"
 	"github.com/pingcap/tiflow/cdc/sinkv2/tablesink/state"
 	"github.com/pingcap/tiflow/cdc/sinkv2/util"
+	"github.com/pingcap/tiflow/pkg/compression"
 	"github.com/pingcap/tiflow/pkg/config"
 	cerror "github.com/pingcap/tiflow/pkg/errors"
 	"github.com/pingcap/tiflow/pkg/sink"
 	"github.com/pingcap/tiflow/pkg/sink/cloudstorage"
+	"github.com/pingcap/tiflow/pkg/sink/iceberg"
 	putil "github.com/pingcap/tiflow/pkg/util"
 )
 
@@ -99,24 +103,52 @@ func NewCloudStorageSink(ctx context.Context,
 	if err != nil {
 		return nil, err
 	}
+	storage = cloudstorage.NewRetryStorage(storage, replicaConfig.Sink.RetryBudget)
+
+	// the Iceberg tables are written in Avro data files regardless of the
+	// protocol, so no encoder is needed.
+	var encoderBuilder codec.EncoderBuilder
+	var parquetEncoder *parquet.Encoder
+	ext := iceberg.FileExtension
+	if cfg.TableFormat != cloudstorage.TableFormatIceberg {
+		// fetch protocol from replicaConfig defined by changefeed config file.
+		protocol, err := util.GetProtocol(replicaConfig.Sink.Protocol)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
 
-	// fetch protocol from replicaConfig defined by changefeed config file.
-	protocol, err := util.GetProtocol(replicaConfig.Sink.Protocol)
-	if err != nil {
-		return nil, errors.Trace(err)
-	}
-
-	// get cloud storage file extension according to the specific protocol.
-	ext := util.GetFileExtension(protocol)
-	// the last param maxMsgBytes is mainly to limit the size of a single message for
-	// batch protocols in mq scenario. In cloud storage sink, we just set it to max int.
-	encoderConfig, err := util.GetEncoderConfig(sinkURI, protocol, replicaConfig, math.MaxInt)
-	if err != nil {
-		return nil, errors.Trace(err)
-	}
-	encoderBuilder, err := builder.NewEventBatchEncoderBuilder(ctx, encoderConfig)
-	if err != nil {
-		return nil, cerror.WrapError(cerror.ErrCloudStorageInvalidConfig, err)
+		// get cloud storage file extension according to the specific protocol,
+		// followed by the one of the compression.
+		ext = util.GetFileExtension(protocol)
+		codec, err := compression.Get(cfg.Compression)
+		if err != nil {
+			return nil, cerror.WrapError(cerror.ErrCloudStorageInvalidConfig, err)
+		}
+		if protocol == config.ProtocolParquet && codec.Name() != compression.None {
+			return nil, cerror.ErrCloudStorageInvalidConfig.GenWithStack(
+				"compression is not supported by the protocol %s, use the compression of parquet instead",
+				protocol)
+		}
+		ext += codec.Extension()
+		if protocol == config.ProtocolParquet {
+			// a parquet file is encoded as a whole by the dml workers, so no
+			// encoder is needed either.
+			parquetEncoder, err = parquet.NewEncoder(replicaConfig.Sink.ParquetConfig)
+			if err != nil {
+				return nil, cerror.WrapError(cerror.ErrCloudStorageInvalidConfig, err)
+			}
+		} else {
+			// the last param maxMsgBytes is mainly to limit the size of a single message for
+			// batch protocols in mq scenario. In cloud storage sink, we just set it to max int.
+			encoderConfig, err := util.GetEncoderConfig(sinkURI, protocol, replicaConfig, math.MaxInt)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			encoderBuilder, err = builder.NewEventBatchEncoderBuilder(ctx, encoderConfig)
+			if err != nil {
+				return nil, cerror.WrapError(cerror.ErrCloudStorageInvalidConfig, err)
+			}
+		}
 	}
 
 	changefeedID := contextutil.ChangefeedIDFromCtx(ctx)
@@ -124,11 +156,15 @@ func NewCloudStorageSink(ctx context.Context,
 	s.defragmenter = newDefragmenter(ctx)
 	orderedCh := s.defragmenter.orderedOut()
 	s.statistics = metrics.NewStatistics(ctx, sink.TxnSink)
-	s.writer = newDMLWriter(ctx, changefeedID, storage, cfg, ext, s.statistics, orderedCh, errCh)
+	s.writer = newDMLWriter(ctx, changefeedID, storage, cfg, ext, parquetEncoder,
+		s.statistics, orderedCh, errCh)
 
 	// create a group of encoding workers.
 	for i := 0; i < defaultEncodingConcurrency; i++ {
-		encoder := encoderBuilder.Build()
+		var encoder codec.EventBatchEncoder
+		if encoderBuilder != nil {
+			encoder = encoderBuilder.Build()
+		}
 		w := newEncodingWorker(i+1, changefeedID, encoder, s.msgCh, s.defragmenter, errCh)
 		w.run(ctx)
 		s.encodingWorkers = append(s.encodingWorkers, w)
@@ -25,12 +25,15 @@ import (
 	"github.com/pingcap/log"
 	"github.com/pingcap/tidb/br/pkg/storage"
 	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sink/codec/parquet"
 	"github.com/pingcap/tiflow/cdc/sinkv2/metrics"
 	mcloudstorage "github.com/pingcap/tiflow/cdc/sinkv2/metrics/cloudstorage"
 	"github.com/pingcap/tiflow/engine/pkg/clock"
 	"github.com/pingcap/tiflow/pkg/chann"
+	"github.com/pingcap/tiflow/pkg/compression"
 	"github.com/pingcap/tiflow/pkg/config"
 	"github.com/pingcap/tiflow/pkg/sink/cloudstorage"
+	"github.com/pingcap/tiflow/pkg/sink/iceberg"
 	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/zap"
 )
@@ -49,7 +52,12 @@ type dmlWorker struct {
 	// fileIndex maintains a mapping of <table, indexWithDate>.
 	fileIndex map[versionedTable]*indexWithDate
 	// fileSize maintains a mapping of <table, file size>.
-	fileSize         map[versionedTable]uint64
+	fileSize map[versionedTable]uint64
+	// icebergTables maintains a mapping of <table name, Iceberg table> if
+	// the table format is Iceberg, the versions and the physical tables of a
+	// TiDB table share the same Iceberg table.
+	icebergTables    map[model.TableName]*iceberg.Table
+	icebergCatalog   *iceberg.Catalog
 	wg               sync.WaitGroup
 	isClosed         uint64
 	errCh            chan<- error
@@ -59,6 +67,9 @@ type dmlWorker struct {
 	bufferPool       sync.Pool
 	metricWriteBytes prometheus.Gauge
 	metricFileCount  prometheus.Gauge
+	// parquetEncoder encodes the data files if the protocol is parquet, the
+	// events aren't encoded by the encoding workers in this case.
+	parquetEncoder *parquet.Encoder
 }
 
 type tableEventsMap struct {
@@ -93,6 +104,7 @@ func newDMLWorker(
 	storage storage.ExternalStorage,
 	config *cloudstorage.Config,
 	extension string,
+	parquetEncoder *parquet.Encoder,
 	statistics *metrics.Statistics,
 	errCh chan<- error,
 ) *dmlWorker {
@@ -105,6 +117,7 @@ func newDMLWorker(
 		flushNotifyCh: make(chan flushTask, 1),
 		fileIndex:     make(map[versionedTable]*indexWithDate),
 		fileSize:      make(map[versionedTable]uint64),
+		icebergTables: make(map[model.TableName]*iceberg.Table),
 		extension:     extension,
 		errCh:         errCh,
 		statistics:    statistics,
@@ -116,6 +129,7 @@ func newDMLWorker(
 		},
 		metricWriteBytes: mcloudstorage.CloudStorageWriteBytesGauge.WithLabelValues(changefeedID.Namespace, changefeedID.ID),
 		metricFileCount:  mcloudstorage.CloudStorageFileCountGauge.WithLabelValues(changefeedID.Namespace, changefeedID.ID),
+		parquetEncoder:   parquetEncoder,
 	}
 
 	return d
@@ -156,6 +170,14 @@ func (d *dmlWorker) backgroundFlushMsgs(ctx context.Context) {
 						continue
 					}
 
+					if d.config.TableFormat == cloudstorage.TableFormatIceberg {
+						if err := d.writeIcebergTable(ctx, table, tbl.tableInfo, events); err != nil {
+							d.errCh <- err
+							return
+						}
+						continue
+					}
+
 					// generate scheme.json file before generating the first data file if necessary
 					err := d.writeSchemaFile(ctx, table, tbl.tableInfo)
 					if err != nil {
@@ -164,7 +186,7 @@ func (d *dmlWorker) backgroundFlushMsgs(ctx context.Context) {
 					}
 
 					path := d.generateDataFilePath(table)
-					err = d.writeDataFile(ctx, path, events)
+					err = d.writeDataFile(ctx, path, tbl.tableInfo, events)
 					if err != nil {
 						d.errCh <- err
 						return
@@ -219,17 +241,37 @@ func (d *dmlWorker) writeSchemaFile(
 	return nil
 }
 
-func (d *dmlWorker) writeDataFile(ctx context.Context, path string, events []eventFragment) error {
+func (d *dmlWorker) writeDataFile(
+	ctx context.Context,
+	path string,
+	tableInfo *model.TableInfo,
+	events []eventFragment,
+) error {
 	var callbacks []func()
 
+	var stats *cloudstorage.FileStats
+	if d.config.EnableFileStats {
+		stats = &cloudstorage.FileStats{}
+	}
 	rowsCnt := 0
 	buf := d.bufferPool.Get().(*bytes.Buffer)
 	defer d.bufferPool.Put(buf)
 	buf.Reset()
 
+	var rows []*model.RowChangedEvent
 	for _, frag := range events {
 		msgs := frag.encodedMsgs
 		d.statistics.ObserveRows(frag.event.Event.Rows...)
+		if stats != nil {
+			for _, row := range frag.event.Event.Rows {
+				stats.ObserveRow(row)
+			}
+		}
+		if d.parquetEncoder != nil {
+			rows = append(rows, frag.event.Event.Rows...)
+			callbacks = append(callbacks, frag.event.Callback)
+			continue
+		}
 		for _, msg := range msgs {
 			d.metricWriteBytes.Add(float64(len(msg.Value)))
 			rowsCnt += msg.GetRowsCount()
@@ -237,8 +279,30 @@ func (d *dmlWorker) writeDataFile(ctx context.Context, path string, events []eve
 			callbacks = append(callbacks, msg.Callback)
 		}
 	}
+	// a parquet file is encoded as a whole, since its metadata is written
+	// in the footer.
+	if d.parquetEncoder != nil {
+		data, err := d.parquetEncoder.Encode(tableInfo, rows)
+		if err != nil {
+			return err
+		}
+		d.metricWriteBytes.Add(float64(len(data)))
+		rowsCnt = len(rows)
+		buf.Write(data)
+	}
+	data := buf.Bytes()
+	if d.config.Compression != compression.None {
+		codec, err := compression.Get(d.config.Compression)
+		if err != nil {
+			return err
+		}
+		data, err = compression.Compress(compression.ComponentStorageSink, codec, data)
+		if err != nil {
+			return err
+		}
+	}
 	if err := d.statistics.RecordBatchExecution(func() (int, error) {
-		err := d.storage.WriteFile(ctx, path, buf.Bytes())
+		err := d.storage.WriteFile(ctx, path, data)
 		if err != nil {
 			return 0, err
 		}
@@ -248,12 +312,79 @@ func (d *dmlWorker) writeDataFile(ctx context.Context, path string, events []eve
 	}
 	d.metricFileCount.Add(1)
 
+	// The statistics file is written after the data file, so that it
+	// always describes a complete data file.
+	if stats != nil {
+		encodedStats, err := json.Marshal(stats)
+		if err != nil {
+			return err
+		}
+		err = d.storage.WriteFile(ctx, cloudstorage.GenerateFileStatsPath(path), encodedStats)
+		if err != nil {
+			return err
+		}
+	}
+
 	for _, cb := range callbacks {
 		if cb != nil {
 			cb()
 		}
 	}
+	for _, frag := range events {
+		d.statistics.ObserveEventAge(config.MetricLabelTable,
+			frag.TableName.String(), frag.event.Event.CommitTs)
+	}
+
+	return nil
+}
+
+// writeIcebergTable commits the rows of the events to the Iceberg table of
+// the table, whose schema evolves to the version of the table. The events
+// are acknowledged once they are committed.
+func (d *dmlWorker) writeIcebergTable(
+	ctx context.Context,
+	table versionedTable,
+	tableInfo *model.TableInfo,
+	events []eventFragment,
+) error {
+	if d.icebergCatalog == nil {
+		catalog, err := iceberg.NewCatalog(d.config.IcebergCatalogURI)
+		if err != nil {
+			return err
+		}
+		d.icebergCatalog = catalog
+	}
+	name := model.TableName{Schema: table.Schema, Table: table.Table}
+	t, ok := d.icebergTables[name]
+	if !ok {
+		var err error
+		t, err = iceberg.OpenTable(ctx, d.storage, d.config.StorageURI,
+			d.icebergCatalog, tableInfo, int64(d.config.FileSize))
+		if err != nil {
+			return err
+		}
+		d.icebergTables[name] = t
+	} else if err := t.UpdateSchema(ctx, tableInfo); err != nil {
+		return err
+	}
 
+	var rows []*model.RowChangedEvent
+	for _, frag := range events {
+		d.statistics.ObserveRows(frag.event.Event.Rows...)
+		rows = append(rows, frag.event.Event.Rows...)
+	}
+	if err := d.statistics.RecordBatchExecution(func() (int, error) {
+		return t.Append(ctx, rows)
+	}); err != nil {
+		return err
+	}
+	d.metricFileCount.Add(1)
+
+	for _, frag := range events {
+		frag.event.Callback()
+		d.statistics.ObserveEventAge(config.MetricLabelTable,
+			frag.TableName.String(), frag.event.Event.CommitTs)
+	}
 	return nil
 }
 
@@ -329,6 +460,14 @@ func (d *dmlWorker) backgroundDispatchTasks(ctx context.Context, ch *chann.Chann
 						d.fileSize[table] += uint64(len(msg.Value))
 					}
 				}
+				// the events aren't encoded for the Iceberg tables and the
+				// parquet files, so the file size is estimated by the sizes
+				// of the rows.
+				if d.config.TableFormat == cloudstorage.TableFormatIceberg || d.parquetEncoder != nil {
+					for _, row := range frag.event.Event.Rows {
+						d.fileSize[table] += uint64(row.ApproximateBytes())
+					}
+				}
 				// if the file size exceeds the upper limit, emit the flush task containing the table
 				// as soon as possible.
 				if d.fileSize[table] > uint64(d.config.FileSize) {
@@ -14,25 +14,31 @@ package cloudstorage
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/http/httptest"
 	"net/url"
 	"os"
 	"path"
 	"testing"
 	"time"
 
+	"github.com/pingcap/tidb/br/pkg/storage"
 	timodel "github.com/pingcap/tidb/parser/model"
 	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tidb/parser/types"
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/cdc/sink/codec/common"
+	"github.com/pingcap/tiflow/cdc/sink/codec/parquet"
 	"github.com/pingcap/tiflow/cdc/sinkv2/eventsink"
 	"github.com/pingcap/tiflow/cdc/sinkv2/metrics"
 	"github.com/pingcap/tiflow/engine/pkg/clock"
 	"github.com/pingcap/tiflow/pkg/chann"
+	"github.com/pingcap/tiflow/pkg/compression"
 	"github.com/pingcap/tiflow/pkg/config"
 	"github.com/pingcap/tiflow/pkg/sink"
 	"github.com/pingcap/tiflow/pkg/sink/cloudstorage"
+	"github.com/pingcap/tiflow/pkg/sink/iceberg"
 	"github.com/pingcap/tiflow/pkg/util"
 	"github.com/stretchr/testify/require"
 )
@@ -50,7 +56,7 @@ func testDMLWorker(ctx context.Context, t *testing.T, dir string) *dmlWorker {
 
 	statistics := metrics.NewStatistics(ctx, sink.TxnSink)
 	d := newDMLWorker(1, model.DefaultChangeFeedID("dml-worker-test"), storage,
-		cfg, ".json", statistics, errCh)
+		cfg, ".json", nil, statistics, errCh)
 	return d
 }
 
@@ -207,3 +213,176 @@ func TestDMLWorkerRun(t *testing.T) {
 		// drain the fragCh
 	}
 }
+
+func TestDMLWorkerWriteFileStats(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	d := testDMLWorker(ctx, t, t.TempDir())
+	d.config.EnableFileStats = true
+
+	var events []eventFragment
+	for i := 0; i < 3; i++ {
+		events = append(events, eventFragment{
+			event: &eventsink.TxnCallbackableEvent{
+				Event: &model.SingleTableTxn{
+					CommitTs: uint64(100 + i),
+					Rows: []*model.RowChangedEvent{{
+						CommitTs: uint64(100 + i),
+						Columns: []*model.Column{
+							{Name: "id", Value: int64(10 - i), Flag: model.HandleKeyFlag},
+						},
+					}},
+				},
+			},
+			encodedMsgs: []*common.Message{{Value: []byte("{}\r\n")}},
+		})
+	}
+	dataPath := "test/table1/99/CDC000001.json"
+	require.Nil(t, d.writeDataFile(ctx, dataPath, nil, events))
+
+	content, err := d.storage.ReadFile(ctx, cloudstorage.GenerateFileStatsPath(dataPath))
+	require.Nil(t, err)
+	var stats cloudstorage.FileStats
+	require.Nil(t, json.Unmarshal(content, &stats))
+	require.Equal(t, 3, stats.RowCount)
+	require.Equal(t, uint64(100), stats.MinCommitTs)
+	require.Equal(t, uint64(102), stats.MaxCommitTs)
+	require.Equal(t, []string{"id"}, stats.KeyColumns)
+	// the numbers are decoded as float64 from JSON.
+	require.Equal(t, []interface{}{float64(8)}, stats.MinKey)
+	require.Equal(t, []interface{}{float64(10)}, stats.MaxKey)
+	d.close()
+}
+
+func TestDMLWorkerWriteIcebergTable(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	d := testDMLWorker(ctx, t, t.TempDir())
+	catalog := iceberg.NewMockCatalog()
+	server := httptest.NewServer(catalog)
+	defer server.Close()
+	d.config.TableFormat = cloudstorage.TableFormatIceberg
+	d.config.IcebergCatalogURI = server.URL
+
+	table := versionedTable{
+		TableName: model.TableName{Schema: "test", Table: "table1", TableID: 100},
+		version:   99,
+	}
+	tableInfo := model.WrapTableInfo(1, "test", 99, &timodel.TableInfo{
+		ID:   100,
+		Name: timodel.NewCIStr("table1"),
+		Columns: []*timodel.ColumnInfo{
+			{
+				ID: 1, Name: timodel.NewCIStr("id"), State: timodel.StatePublic,
+				FieldType: *types.NewFieldType(mysql.TypeLonglong),
+			},
+		},
+	})
+	var acked int
+	var events []eventFragment
+	for i := 0; i < 3; i++ {
+		events = append(events, eventFragment{
+			versionedTable: table,
+			event: &eventsink.TxnCallbackableEvent{
+				Event: &model.SingleTableTxn{
+					TableInfo: tableInfo,
+					CommitTs:  uint64(100 + i),
+					Rows: []*model.RowChangedEvent{{
+						CommitTs: uint64(100 + i),
+						Columns:  []*model.Column{{Name: "id", Value: int64(i)}},
+					}},
+				},
+				Callback: func() { acked++ },
+			},
+		})
+	}
+	require.Nil(t, d.writeIcebergTable(ctx, table, tableInfo, events[:2]))
+	require.Nil(t, d.writeIcebergTable(ctx, table, tableInfo, events[2:]))
+	require.Equal(t, 3, acked)
+	require.Len(t, d.icebergTables, 1)
+	require.Equal(t, 2, catalog.Commits())
+
+	var dataFiles int
+	err := d.storage.WalkDir(ctx, &storage.WalkOption{SubDir: "test/table1/data"},
+		func(path string, size int64) error {
+			dataFiles++
+			return nil
+		})
+	require.Nil(t, err)
+	require.Equal(t, 2, dataFiles)
+	d.close()
+}
+
+func TestDMLWorkerWriteParquetFile(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	d := testDMLWorker(ctx, t, t.TempDir())
+	var err error
+	d.parquetEncoder, err = parquet.NewEncoder(nil)
+	require.Nil(t, err)
+
+	tableInfo := model.WrapTableInfo(1, "test", 99, &timodel.TableInfo{
+		ID:   100,
+		Name: timodel.NewCIStr("table1"),
+		Columns: []*timodel.ColumnInfo{
+			{
+				ID: 1, Name: timodel.NewCIStr("id"), State: timodel.StatePublic,
+				FieldType: *types.NewFieldType(mysql.TypeLonglong),
+			},
+		},
+	})
+	var acked int
+	var events []eventFragment
+	for i := 0; i < 3; i++ {
+		events = append(events, eventFragment{
+			event: &eventsink.TxnCallbackableEvent{
+				Event: &model.SingleTableTxn{
+					TableInfo: tableInfo,
+					CommitTs:  uint64(100 + i),
+					Rows: []*model.RowChangedEvent{{
+						CommitTs: uint64(100 + i),
+						Columns:  []*model.Column{{Name: "id", Value: int64(i)}},
+					}},
+				},
+				Callback: func() { acked++ },
+			},
+		})
+	}
+	dataPath := "test/table1/99/CDC000001.parquet"
+	require.Nil(t, d.writeDataFile(ctx, dataPath, tableInfo, events))
+	require.Equal(t, 3, acked)
+
+	content, err := d.storage.ReadFile(ctx, dataPath)
+	require.Nil(t, err)
+	require.Equal(t, "PAR1", string(content[:4]))
+	require.Equal(t, "PAR1", string(content[len(content)-4:]))
+	d.close()
+}
+
+func TestDMLWorkerWriteCompressedFile(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	d := testDMLWorker(ctx, t, t.TempDir())
+	d.config.Compression = compression.GZIP
+
+	events := []eventFragment{{
+		event: &eventsink.TxnCallbackableEvent{
+			Event: &model.SingleTableTxn{
+				CommitTs: 100,
+				Rows:     []*model.RowChangedEvent{{CommitTs: 100}},
+			},
+		},
+		encodedMsgs: []*common.Message{{Value: []byte("{\"id\":1}\r\n")}},
+	}}
+	dataPath := "test/table1/99/CDC000001.json.gz"
+	require.Nil(t, d.writeDataFile(ctx, dataPath, nil, events))
+
+	content, err := d.storage.ReadFile(ctx, dataPath)
+	require.Nil(t, err)
+	codec, trimmed := compression.FromFileName(dataPath)
+	require.Equal(t, "test/table1/99/CDC000001.json", trimmed)
+	decompressed, err := codec.Decompress(content)
+	require.Nil(t, err)
+	require.Equal(t, "{\"id\":1}\r\n", string(decompressed))
+	d.close()
+}
@@ -18,6 +18,7 @@ import (
 
 	"github.com/pingcap/tidb/br/pkg/storage"
 	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sink/codec/parquet"
 	"github.com/pingcap/tiflow/cdc/sinkv2/metrics"
 	"github.com/pingcap/tiflow/pkg/chann"
 	"github.com/pingcap/tiflow/pkg/hash"
@@ -33,6 +34,7 @@ type dmlWriter struct {
 	storage        storage.ExternalStorage
 	config         *cloudstorage.Config
 	extension      string
+	parquetEncoder *parquet.Encoder
 	wg             sync.WaitGroup
 	inputCh        <-chan eventFragment
 	errCh          chan<- error
@@ -43,6 +45,7 @@ func newDMLWriter(ctx context.Context,
 	storage storage.ExternalStorage,
 	config *cloudstorage.Config,
 	extension string,
+	parquetEncoder *parquet.Encoder,
 	statistics *metrics.Statistics,
 	inputCh <-chan eventFragment,
 	errCh chan<- error,
@@ -53,6 +56,7 @@ func newDMLWriter(ctx context.Context,
 		hasher:         hash.NewPositionInertia(),
 		config:         config,
 		extension:      extension,
+		parquetEncoder: parquetEncoder,
 		inputCh:        inputCh,
 		errCh:          errCh,
 	}
@@ -64,7 +68,7 @@ func newDMLWriter(ctx context.Context,
 	}()
 
 	for i := 0; i < config.WorkerCount; i++ {
-		d := newDMLWorker(i, changefeedID, storage, w.config, extension, statistics, errCh)
+		d := newDMLWorker(i, changefeedID, storage, w.config, extension, parquetEncoder, statistics, errCh)
 		w.workerChannels[i] = chann.New[eventFragment]()
 		d.run(ctx, w.workerChannels[i])
 		w.workers = append(w.workers, d)
@@ -80,6 +80,13 @@ func (w *encodingWorker) run(ctx context.Context) {
 }
 
 func (w *encodingWorker) encodeEvents(ctx context.Context, frag eventFragment) error {
+	// the events are written to the Iceberg tables or the parquet files
+	// without being encoded.
+	if w.encoder == nil {
+		w.defragmenter.registerFrag(frag)
+		return nil
+	}
+
 	var err error
 	length := len(frag.event.Event.Rows)
 
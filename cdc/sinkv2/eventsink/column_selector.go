@@ -0,0 +1,190 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventsink
+
+import (
+	"github.com/pingcap/tidb/util/rowcodec"
+	filter "github.com/pingcap/tidb/util/table-filter"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/config"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+)
+
+// Assert Appender[E TableEvent] implementation
+var (
+	_ Appender[*model.RowChangedEvent] = (*ColumnSelectAppender[*model.RowChangedEvent])(nil)
+	_ Appender[*model.SingleTableTxn]  = (*ColumnSelectAppender[*model.SingleTableTxn])(nil)
+)
+
+// ColumnSelectors are the parsed column selectors of a changefeed, the first
+// selector matching the table of a row is used. It's shared by the table
+// sinks of a changefeed.
+type ColumnSelectors struct {
+	selectors []*columnSelector
+}
+
+type columnSelector struct {
+	tableFilter filter.Filter
+	config      *config.ColumnSelector
+}
+
+// NewColumnSelectors parses the column selectors of the replica config.
+func NewColumnSelectors(cfg *config.ReplicaConfig) (*ColumnSelectors, error) {
+	selectors := make([]*columnSelector, 0, len(cfg.Sink.ColumnSelectors))
+	for _, selector := range cfg.Sink.ColumnSelectors {
+		f, err := filter.Parse(selector.Matcher)
+		if err != nil {
+			return nil, cerror.WrapError(cerror.ErrSinkInvalidConfig, err)
+		}
+		if !cfg.CaseSensitive {
+			f = filter.CaseInsensitive(f)
+		}
+		selectors = append(selectors, &columnSelector{
+			tableFilter: f,
+			config:      selector,
+		})
+	}
+	return &ColumnSelectors{selectors: selectors}, nil
+}
+
+// match returns the selector of the table, nil if no selector matches it.
+func (s *ColumnSelectors) match(table *model.TableName) *columnSelector {
+	for _, selector := range s.selectors {
+		if selector.tableFilter.MatchTable(table.Schema, table.Table) {
+			return selector
+		}
+	}
+	return nil
+}
+
+// ColumnSelectAppender drops the columns which are not selected by the column
+// selectors before the rows are appended by the wrapped appender. The handle
+// key columns are always kept, and the rows without any selected column are
+// dropped. The rows of the caller are never changed, a row is copied if some
+// of its columns are dropped.
+type ColumnSelectAppender[E TableEvent] struct {
+	inner     Appender[E]
+	selectors *ColumnSelectors
+}
+
+// NewColumnSelectAppender creates a ColumnSelectAppender wrapping the appender.
+func NewColumnSelectAppender[E TableEvent](
+	inner Appender[E], selectors *ColumnSelectors,
+) *ColumnSelectAppender[E] {
+	return &ColumnSelectAppender[E]{inner: inner, selectors: selectors}
+}
+
+// Append appends the rows with the selected columns to the buffer.
+func (a *ColumnSelectAppender[E]) Append(buffer []E, rows ...*model.RowChangedEvent) []E {
+	var selected []*model.RowChangedEvent
+	for i, row := range rows {
+		newRow := a.selectColumns(row)
+		if newRow == row && selected == nil {
+			continue
+		}
+		// Copy the rows lazily, the slice of the caller must not be changed.
+		if selected == nil {
+			selected = append(make([]*model.RowChangedEvent, 0, len(rows)), rows[:i]...)
+		}
+		if newRow != nil {
+			selected = append(selected, newRow)
+		}
+	}
+	if selected != nil {
+		rows = selected
+	}
+	return a.inner.Append(buffer, rows...)
+}
+
+// selectColumns returns the row with the selected columns, it returns the
+// row itself if all the columns are selected, and nil if none is selected.
+func (a *ColumnSelectAppender[E]) selectColumns(row *model.RowChangedEvent) *model.RowChangedEvent {
+	if row.Table == nil {
+		return row
+	}
+	selector := a.selectors.match(row.Table)
+	if selector == nil {
+		return row
+	}
+
+	// The columns and the pre-columns have the same layout, which is the
+	// one of the column infos and the index columns.
+	cols := row.Columns
+	if len(cols) == 0 {
+		cols = row.PreColumns
+	}
+	keep := make([]bool, len(cols))
+	kept := 0
+	for i, col := range cols {
+		if col == nil || col.Flag.IsHandleKey() || selector.config.SelectColumn(col.Name) {
+			keep[i] = true
+			kept++
+		}
+	}
+	switch kept {
+	case len(cols):
+		return row
+	case 0:
+		return nil
+	}
+
+	newRow := *row
+	newRow.Columns = selectByLayout(row.Columns, keep)
+	newRow.PreColumns = selectByLayout(row.PreColumns, keep)
+	if len(row.ColInfos) == len(keep) {
+		newRow.ColInfos = selectByLayout(row.ColInfos, keep)
+	}
+	// The offsets of the index columns are moved, and the indexes with
+	// dropped columns are dropped.
+	offsets := make([]int, len(keep))
+	next := 0
+	for i := range keep {
+		offsets[i] = -1
+		if keep[i] {
+			offsets[i] = next
+			next++
+		}
+	}
+	newRow.IndexColumns = nil
+	for _, index := range row.IndexColumns {
+		newIndex := make([]int, 0, len(index))
+		for _, offset := range index {
+			if offset < 0 || offset >= len(offsets) || offsets[offset] < 0 {
+				newIndex = nil
+				break
+			}
+			newIndex = append(newIndex, offsets[offset])
+		}
+		if newIndex != nil {
+			newRow.IndexColumns = append(newRow.IndexColumns, newIndex)
+		}
+	}
+	return &newRow
+}
+
+// selectByLayout returns the elements which are kept, the slice is returned
+// as it is if it doesn't have the layout of keep, e.g. the empty pre-columns
+// of an insert.
+func selectByLayout[T *model.Column | rowcodec.ColInfo](s []T, keep []bool) []T {
+	if len(s) != len(keep) {
+		return s
+	}
+	selected := make([]T, 0, len(s))
+	for i, v := range s {
+		if keep[i] {
+			selected = append(selected, v)
+		}
+	}
+	return selected
+}
@@ -0,0 +1,101 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventsink
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb/util/rowcodec"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/stretchr/testify/require"
+)
+
+func newColumnSelectTestRow(table string, pre bool) *model.RowChangedEvent {
+	cols := []*model.Column{
+		{Name: "id", Value: 1, Flag: model.HandleKeyFlag | model.PrimaryKeyFlag},
+		{Name: "name", Value: "a"},
+		{Name: "phone", Value: "123"},
+		{Name: "avatar_blob", Value: []byte("b")},
+	}
+	row := &model.RowChangedEvent{
+		Table:        &model.TableName{Schema: "test", Table: table},
+		ColInfos:     []rowcodec.ColInfo{{ID: 1}, {ID: 2}, {ID: 3}, {ID: 4}},
+		IndexColumns: [][]int{{0}, {1, 2}, {1}},
+		Columns:      cols,
+	}
+	if pre {
+		row.PreColumns = cols
+	}
+	return row
+}
+
+func TestColumnSelectAppender(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.GetDefaultReplicaConfig()
+	cfg.Sink.ColumnSelectors = []*config.ColumnSelector{
+		{Matcher: []string{"test.users"}, ExcludeColumns: []string{"phone", "*_blob"}},
+		{Matcher: []string{"test.ids"}, Columns: []string{"!*"}},
+	}
+	selectors, err := NewColumnSelectors(cfg)
+	require.Nil(t, err)
+	appender := NewColumnSelectAppender[*model.RowChangedEvent](&RowChangeEventAppender{}, selectors)
+
+	rows := []*model.RowChangedEvent{
+		newColumnSelectTestRow("other", false),
+		newColumnSelectTestRow("users", true),
+		newColumnSelectTestRow("ids", false),
+	}
+	buffer := appender.Append(nil, rows...)
+	require.Len(t, buffer, 3)
+	// The rows of the other tables are kept as they are.
+	require.Same(t, rows[0], buffer[0])
+
+	users := buffer[1]
+	require.NotSame(t, rows[1], users)
+	require.Equal(t, []string{"id", "name"}, []string{users.Columns[0].Name, users.Columns[1].Name})
+	require.Len(t, users.Columns, 2)
+	require.Len(t, users.PreColumns, 2)
+	require.Equal(t, []rowcodec.ColInfo{{ID: 1}, {ID: 2}}, users.ColInfos)
+	require.Equal(t, [][]int{{0}, {1}}, users.IndexColumns)
+	// The handle key columns are always kept.
+	require.Len(t, buffer[2].Columns, 1)
+	require.Equal(t, "id", buffer[2].Columns[0].Name)
+
+	// The rows of the caller are not changed.
+	require.Len(t, rows, 3)
+	require.Len(t, rows[1].Columns, 4)
+	require.Len(t, rows[1].ColInfos, 4)
+	require.Equal(t, [][]int{{0}, {1, 2}, {1}}, rows[1].IndexColumns)
+}
+
+func TestColumnSelectAppenderDropsRows(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.GetDefaultReplicaConfig()
+	cfg.Sink.ColumnSelectors = []*config.ColumnSelector{
+		{Matcher: []string{"test.*"}, Columns: []string{"!*"}},
+	}
+	selectors, err := NewColumnSelectors(cfg)
+	require.Nil(t, err)
+	appender := NewColumnSelectAppender[*model.SingleTableTxn](&TxnEventAppender{}, selectors)
+
+	row := &model.RowChangedEvent{
+		Table:   &model.TableName{Schema: "test", Table: "t"},
+		Columns: []*model.Column{{Name: "v", Value: 1}},
+	}
+	buffer := appender.Append(nil, row)
+	require.Len(t, buffer, 0)
+}
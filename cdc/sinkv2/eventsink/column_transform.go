@@ -0,0 +1,274 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventsink
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+
+	"github.com/pingcap/tidb/parser/mysql"
+	filter "github.com/pingcap/tidb/util/table-filter"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/config"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+)
+
+// ColumnTransformer transforms the values of the columns by the column
+// transforms of a changefeed, e.g. to mask the sensitive values before they
+// are encoded by the workers of the sinks. The first transform matching the
+// table and the column of a value is applied. The rows of the callers are
+// never changed, because they may be shared by the other sinks. It's safe
+// for concurrent use.
+type ColumnTransformer struct {
+	transforms []*columnTransform
+}
+
+type columnTransform struct {
+	tableFilter filter.Filter
+	config      *config.ColumnTransform
+}
+
+// NewColumnTransformer creates a ColumnTransformer by the column transforms
+// of the replica config, it returns nil if there is no column transform.
+func NewColumnTransformer(cfg *config.ReplicaConfig) (*ColumnTransformer, error) {
+	if len(cfg.Sink.ColumnTransforms) == 0 {
+		return nil, nil
+	}
+	transforms := make([]*columnTransform, 0, len(cfg.Sink.ColumnTransforms))
+	for _, transform := range cfg.Sink.ColumnTransforms {
+		f, err := filter.Parse(transform.Matcher)
+		if err != nil {
+			return nil, cerror.WrapError(cerror.ErrSinkInvalidConfig, err)
+		}
+		if !cfg.CaseSensitive {
+			f = filter.CaseInsensitive(f)
+		}
+		transforms = append(transforms, &columnTransform{
+			tableFilter: f,
+			config:      transform,
+		})
+	}
+	return &ColumnTransformer{transforms: transforms}, nil
+}
+
+// CheckTable checks the column transforms against the schema of the table
+// before its table sink is created. The handle key columns can't be
+// transformed, since the rows are located by them in the downstream, and
+// the values of fixed must be valid values of the matched columns.
+func (c *ColumnTransformer) CheckTable(table *model.TableInfo) error {
+	for _, transform := range c.transforms {
+		if !transform.tableFilter.MatchTable(table.TableName.Schema, table.TableName.Table) {
+			continue
+		}
+		for _, col := range table.Columns {
+			if !transform.config.MatchColumn(col.Name.O) {
+				continue
+			}
+			flag := table.ColumnsFlag[col.ID]
+			if flag.IsHandleKey() {
+				return cerror.ErrSinkInvalidConfig.GenWithStack(
+					"column-transforms %v can't transform the handle key column %s of the table %s",
+					transform.config.Matcher, col.Name.O, table.TableName.String())
+			}
+			if transform.config.Type != config.ColumnTransformFixed {
+				continue
+			}
+			_, err := fixedValue(col.GetType(), flag.IsUnsigned(), transform.config.Value)
+			if err != nil {
+				return cerror.ErrSinkInvalidConfig.GenWithStack(
+					"value %s of column-transforms %v is invalid for the column %s of the table %s: %s",
+					transform.config.Value, transform.config.Matcher, col.Name.O,
+					table.TableName.String(), err)
+			}
+		}
+	}
+	return nil
+}
+
+// TransformTxn returns the txn with the transformed rows, it returns the txn
+// itself if no value is transformed.
+func (c *ColumnTransformer) TransformTxn(txn *model.SingleTableTxn) *model.SingleTableTxn {
+	var rows []*model.RowChangedEvent
+	for i, row := range txn.Rows {
+		newRow := c.Transform(row)
+		if newRow == row && rows == nil {
+			continue
+		}
+		if rows == nil {
+			rows = append(make([]*model.RowChangedEvent, 0, len(txn.Rows)), txn.Rows[:i]...)
+		}
+		rows = append(rows, newRow)
+	}
+	if rows == nil {
+		return txn
+	}
+	newTxn := *txn
+	newTxn.Rows = rows
+	return &newTxn
+}
+
+// Transform returns the row with the transformed values, it returns the row
+// itself if no value is transformed.
+func (c *ColumnTransformer) Transform(row *model.RowChangedEvent) *model.RowChangedEvent {
+	if row.Table == nil {
+		return row
+	}
+	var transforms []*columnTransform
+	for _, transform := range c.transforms {
+		if transform.tableFilter.MatchTable(row.Table.Schema, row.Table.Table) {
+			transforms = append(transforms, transform)
+		}
+	}
+	if len(transforms) == 0 {
+		return row
+	}
+
+	columns, transformed := transformColumns(transforms, row.Columns)
+	preColumns, preTransformed := transformColumns(transforms, row.PreColumns)
+	if !transformed && !preTransformed {
+		return row
+	}
+	newRow := *row
+	newRow.Columns = columns
+	newRow.PreColumns = preColumns
+	return &newRow
+}
+
+// transformColumns returns the columns with the transformed values, the
+// columns are copied if any value is transformed.
+func transformColumns(
+	transforms []*columnTransform, cols []*model.Column,
+) ([]*model.Column, bool) {
+	var newCols []*model.Column
+	for i, col := range cols {
+		if col == nil || col.Value == nil || col.Flag.IsHandleKey() {
+			continue
+		}
+		for _, transform := range transforms {
+			if !transform.config.MatchColumn(col.Name) {
+				continue
+			}
+			if newCols == nil {
+				newCols = append(make([]*model.Column, 0, len(cols)), cols...)
+			}
+			newCol := *col
+			newCol.Value = transformValue(transform.config, col)
+			newCols[i] = &newCol
+			break
+		}
+	}
+	if newCols == nil {
+		return cols, false
+	}
+	return newCols, true
+}
+
+// transformValue returns the transformed value of the column. The values of
+// fixed are converted to the type of the column, the other transforms return
+// NULL if the column isn't a string or binary column.
+func transformValue(transform *config.ColumnTransform, col *model.Column) interface{} {
+	switch col.Type {
+	case mysql.TypeString, mysql.TypeVarString, mysql.TypeVarchar,
+		mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob, mysql.TypeBlob:
+	default:
+		if transform.Type != config.ColumnTransformFixed {
+			return nil
+		}
+		// The value has been checked by CheckTable.
+		value, err := fixedValue(col.Type, col.Flag.IsUnsigned(), transform.Value)
+		if err != nil {
+			return nil
+		}
+		return value
+	}
+	var value []byte
+	switch v := col.Value.(type) {
+	case []byte:
+		value = v
+	case string:
+		value = []byte(v)
+	default:
+		return nil
+	}
+
+	var result []byte
+	switch transform.Type {
+	case config.ColumnTransformHash:
+		sum := sha256.Sum256(append([]byte(transform.Salt), value...))
+		result = []byte(hex.EncodeToString(sum[:]))
+	case config.ColumnTransformRedact:
+		if col.Flag.IsBinary() {
+			result = make([]byte, len(value))
+			for i := range value {
+				result[i] = '*'
+				if i >= len(value)-transform.Length {
+					result[i] = value[i]
+				}
+			}
+			break
+		}
+		chars := []rune(string(value))
+		for i := 0; i < len(chars)-transform.Length; i++ {
+			chars[i] = '*'
+		}
+		result = []byte(string(chars))
+	case config.ColumnTransformTruncate:
+		if col.Flag.IsBinary() {
+			if len(value) > transform.Length {
+				value = value[:transform.Length]
+			}
+			result = append([]byte(nil), value...)
+			break
+		}
+		chars := []rune(string(value))
+		if len(chars) > transform.Length {
+			chars = chars[:transform.Length]
+		}
+		result = []byte(string(chars))
+	case config.ColumnTransformFixed:
+		result = []byte(transform.Value)
+	default:
+		return nil
+	}
+
+	if _, ok := col.Value.(string); ok {
+		return string(result)
+	}
+	return result
+}
+
+// fixedValue converts the value of fixed to the value of a column of the type
+// which is not a string or binary column, in the form of the values decoded
+// by the mounter.
+func fixedValue(tp byte, unsigned bool, value string) (interface{}, error) {
+	switch tp {
+	case mysql.TypeTiny, mysql.TypeShort, mysql.TypeInt24, mysql.TypeLong,
+		mysql.TypeLonglong, mysql.TypeYear:
+		if unsigned {
+			return strconv.ParseUint(value, 10, 64)
+		}
+		return strconv.ParseInt(value, 10, 64)
+	case mysql.TypeBit, mysql.TypeEnum, mysql.TypeSet:
+		return strconv.ParseUint(value, 10, 64)
+	case mysql.TypeFloat:
+		v, err := strconv.ParseFloat(value, 32)
+		return float32(v), err
+	case mysql.TypeDouble:
+		return strconv.ParseFloat(value, 64)
+	default:
+		// The decimals, the temporal and the JSON values are strings.
+		return value, nil
+	}
+}
@@ -0,0 +1,132 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventsink
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	timodel "github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestColumnTransformer(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.GetDefaultReplicaConfig()
+	transformer, err := NewColumnTransformer(cfg)
+	require.Nil(t, err)
+	require.Nil(t, transformer)
+
+	cfg.Sink.ColumnTransforms = []*config.ColumnTransform{
+		{Matcher: []string{"test.users"}, Columns: []string{"email"}, Type: config.ColumnTransformHash, Salt: "s"},
+		{Matcher: []string{"test.users"}, Columns: []string{"phone", "email"}, Type: config.ColumnTransformRedact, Length: 2},
+		{Matcher: []string{"test.users"}, Columns: []string{"bio", "token"}, Type: config.ColumnTransformTruncate, Length: 2},
+		{Matcher: []string{"test.users"}, Columns: []string{"birthday", "ssn"}, Type: config.ColumnTransformFixed, Value: "-"},
+		{Matcher: []string{"test.users"}, Columns: []string{"age"}, Type: config.ColumnTransformFixed, Value: "0"},
+	}
+	transformer, err = NewColumnTransformer(cfg)
+	require.Nil(t, err)
+
+	cols := []*model.Column{
+		{Name: "id", Type: mysql.TypeVarchar, Value: []byte("1"), Flag: model.HandleKeyFlag},
+		{Name: "email", Type: mysql.TypeVarchar, Value: []byte("a@b.c")},
+		{Name: "phone", Type: mysql.TypeVarchar, Value: "电话12345"},
+		{Name: "bio", Type: mysql.TypeBlob, Value: []byte("你好世界")},
+		{Name: "token", Type: mysql.TypeBlob, Value: []byte("abc"), Flag: model.BinaryFlag},
+		{Name: "birthday", Type: mysql.TypeDate, Value: "2000-01-01"},
+		{Name: "ssn", Type: mysql.TypeString, Value: nil},
+		{Name: "name", Type: mysql.TypeVarchar, Value: []byte("n")},
+		{Name: "age", Type: mysql.TypeLong, Value: int64(30)},
+	}
+	row := &model.RowChangedEvent{
+		Table:      &model.TableName{Schema: "test", Table: "users"},
+		Columns:    cols,
+		PreColumns: cols,
+	}
+	txn := &model.SingleTableTxn{Rows: []*model.RowChangedEvent{row}}
+	newTxn := transformer.TransformTxn(txn)
+	require.NotSame(t, txn, newTxn)
+	newRow := newTxn.Rows[0]
+
+	sum := sha256.Sum256([]byte("sa@b.c"))
+	expected := []interface{}{
+		[]byte("1"),
+		[]byte(hex.EncodeToString(sum[:])),
+		"*****45",
+		[]byte("你好"),
+		[]byte("ab"),
+		"-",
+		nil,
+		[]byte("n"),
+		int64(0),
+	}
+	for i, col := range newRow.Columns {
+		require.Equal(t, expected[i], col.Value, col.Name)
+		require.Equal(t, expected[i], newRow.PreColumns[i].Value, col.Name)
+	}
+	// The rows of the caller are not changed.
+	require.Same(t, row, txn.Rows[0])
+	require.Equal(t, []byte("a@b.c"), cols[1].Value)
+	require.Equal(t, "2000-01-01", cols[5].Value)
+
+	// The rows of the other tables are kept as they are.
+	other := &model.RowChangedEvent{
+		Table:   &model.TableName{Schema: "test", Table: "t"},
+		Columns: cols,
+	}
+	require.Same(t, other, transformer.Transform(other))
+}
+
+func TestColumnTransformerCheckTable(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.GetDefaultReplicaConfig()
+	cfg.Sink.ColumnTransforms = []*config.ColumnTransform{
+		{Matcher: []string{"test.users"}, Columns: []string{"age"}, Type: config.ColumnTransformFixed, Value: "0"},
+	}
+	transformer, err := NewColumnTransformer(cfg)
+	require.Nil(t, err)
+
+	newColumn := func(id int64, name string, tp byte) *timodel.ColumnInfo {
+		return &timodel.ColumnInfo{ID: id, Name: timodel.NewCIStr(name), FieldType: *types.NewFieldType(tp)}
+	}
+	table := &model.TableInfo{
+		TableInfo: &timodel.TableInfo{Columns: []*timodel.ColumnInfo{
+			newColumn(1, "id", mysql.TypeLong),
+			newColumn(2, "age", mysql.TypeLong),
+		}},
+		TableName:   model.TableName{Schema: "test", Table: "users"},
+		ColumnsFlag: map[int64]model.ColumnFlagType{1: model.HandleKeyFlag},
+	}
+	require.Nil(t, transformer.CheckTable(table))
+
+	// The values of fixed must be valid for the columns.
+	cfg.Sink.ColumnTransforms[0].Value = "-"
+	require.Regexp(t, "is invalid for the column age", transformer.CheckTable(table))
+
+	// The handle key columns can't be transformed.
+	cfg.Sink.ColumnTransforms[0].Columns = []string{"*"}
+	cfg.Sink.ColumnTransforms[0].Type = config.ColumnTransformHash
+	require.Regexp(t, "can't transform the handle key column id", transformer.CheckTable(table))
+
+	// The other tables are not checked.
+	table.TableName.Table = "t"
+	require.Nil(t, transformer.CheckTable(table))
+}
@@ -0,0 +1,70 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elasticsearch
+
+import (
+	"bytes"
+	"context"
+	"net/url"
+
+	"github.com/pingcap/tiflow/cdc/contextutil"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sinkv2/eventsink/batch"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/pingcap/tiflow/pkg/sink/elasticsearch"
+)
+
+// writer sends the batches in bulk requests, the rows of all the tables are
+// batched together.
+type writer struct {
+	cfg    *elasticsearch.Config
+	client *elasticsearch.Client
+}
+
+func (w *writer) BatchKey(_ *model.RowChangedEvent) model.TableName {
+	return model.TableName{}
+}
+
+func (w *writer) Encode(buf *bytes.Buffer, row *model.RowChangedEvent) error {
+	index := elasticsearch.IndexName(w.cfg.IndexPrefix, *row.Table)
+	return elasticsearch.EncodeRow(buf, index, row, w.cfg.DeleteAsTombstone)
+}
+
+func (w *writer) Write(ctx context.Context, _ model.TableName, body []byte) error {
+	return w.client.Bulk(ctx, body)
+}
+
+func (w *writer) Close() {
+	w.client.Close()
+}
+
+// NewElasticsearchSink creates an Elasticsearch sink. It writes the row
+// changed events to the index of their tables, the events are batched in a
+// bulk request which is sent when it's full or the flush interval elapses.
+func NewElasticsearchSink(
+	ctx context.Context, sinkURI *url.URL, replicaConfig *config.ReplicaConfig, errCh chan error,
+) (*batch.DMLSink, error) {
+	cfg := elasticsearch.NewConfig()
+	if err := cfg.Apply(sinkURI); err != nil {
+		return nil, err
+	}
+	cfg.ApplyClientIdentity(replicaConfig.Sink.ClientIdentity,
+		contextutil.ChangefeedIDFromCtx(ctx), contextutil.CaptureAddrFromCtx(ctx))
+	client, err := elasticsearch.NewClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return batch.NewDMLSink(ctx, "Elasticsearch", &writer{cfg: cfg, client: client},
+		cfg.BatchSize, cfg.FlushInterval, errCh), nil
+}
@@ -0,0 +1,99 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elasticsearch
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sinkv2/eventsink"
+	"github.com/pingcap/tiflow/cdc/sinkv2/tablesink/state"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteEvents(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu     sync.Mutex
+		bodies []string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "ticdc-test", r.UserAgent())
+		body, err := io.ReadAll(r.Body)
+		require.Nil(t, err)
+		mu.Lock()
+		defer mu.Unlock()
+		bodies = append(bodies, r.URL.Path+" "+string(body))
+		_, _ = w.Write([]byte(`{"errors":false,"items":[]}`))
+	}))
+	defer server.Close()
+
+	sinkURI, err := url.Parse("elasticsearch://" + server.Listener.Addr().String() +
+		"/?flush-size=2&flush-interval=1m&index-prefix=tidb-")
+	require.Nil(t, err)
+	replicaConfig := config.GetDefaultReplicaConfig()
+	replicaConfig.Sink.ClientIdentity = &config.ClientIdentityConfig{HTTPUserAgent: "ticdc-test"}
+	errCh := make(chan error, 1)
+	s, err := NewElasticsearchSink(context.Background(), sinkURI, replicaConfig, errCh)
+	require.Nil(t, err)
+	defer s.Close()
+
+	var acked int64
+	newEvent := func(table string, id int64, sinkState state.TableSinkState) *eventsink.RowChangeCallbackableEvent {
+		return &eventsink.RowChangeCallbackableEvent{
+			Event: &model.RowChangedEvent{
+				CommitTs: uint64(id),
+				Table:    &model.TableName{Schema: "test", Table: table},
+				Columns: []*model.Column{{
+					Name: "id", Type: mysql.TypeLonglong, Value: id,
+					Flag: model.HandleKeyFlag | model.PrimaryKeyFlag,
+				}},
+			},
+			Callback:  func() { atomic.AddInt64(&acked, 1) },
+			SinkState: &sinkState,
+		}
+	}
+	// the rows of all the tables are sent in a bulk request once it's full,
+	// and the row of the stopping table is dropped.
+	require.Nil(t, s.WriteEvents(
+		newEvent("t1", 1, state.TableSinkSinking),
+		newEvent("t2", 2, state.TableSinkStopping),
+		newEvent("t3", 3, state.TableSinkSinking),
+		newEvent("t1", 4, state.TableSinkSinking),
+	))
+	require.Eventually(t, func() bool {
+		return atomic.LoadInt64(&acked) == 3
+	}, 5*time.Second, 10*time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Equal(t, []string{"/_bulk " +
+		`{"index":{"_index":"tidb-test.t1","_id":"1","version":1,"version_type":"external_gte"}}` + "\n" +
+		`{"id":1,"tidb_commit_ts":1,"tidb_is_deleted":false}` + "\n" +
+		`{"index":{"_index":"tidb-test.t3","_id":"3","version":3,"version_type":"external_gte"}}` + "\n" +
+		`{"id":3,"tidb_commit_ts":3,"tidb_is_deleted":false}` + "\n",
+	}, bodies)
+	require.Len(t, errCh, 0)
+}
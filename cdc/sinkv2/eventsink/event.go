@@ -33,6 +33,9 @@ type CallbackableEvent[E TableEvent] struct {
 	Event     E
 	Callback  CallbackFunc
 	SinkState *state.TableSinkState
+	// TxnRowCount is the approximate number of the rows changed by the
+	// upstream transaction of the event in its table, 0 means unknown.
+	TxnRowCount int
 }
 
 // GetTableSinkState returns the table sink state.
@@ -13,6 +13,11 @@
 
 package eventsink
 
+import (
+	"context"
+	"net/url"
+)
+
 // EventSink is the interface for event sink.
 type EventSink[E TableEvent] interface {
 	// WriteEvents writes events to the sink.
@@ -21,3 +26,11 @@ type EventSink[E TableEvent] interface {
 	// Close closes the sink.
 	Close() error
 }
+
+// CredentialsUpdater is implemented by the EventSink which is able to update
+// the credentials of the downstream without being recreated.
+type CredentialsUpdater interface {
+	// UpdateCredentials re-establishes the connections to the downstream with
+	// the credentials in the sink URI, the other parts of which must not change.
+	UpdateCredentials(ctx context.Context, sinkURI *url.URL) error
+}
@@ -15,22 +15,30 @@ package factory
 
 import (
 	"context"
+	"net/url"
 	"strings"
+	"sync"
 
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/cdc/contextutil"
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/cdc/processor/tablepb"
 	"github.com/pingcap/tiflow/cdc/sinkv2/eventsink"
 	"github.com/pingcap/tiflow/cdc/sinkv2/eventsink/blackhole"
+	"github.com/pingcap/tiflow/cdc/sinkv2/eventsink/clickhouse"
 	"github.com/pingcap/tiflow/cdc/sinkv2/eventsink/cloudstorage"
+	"github.com/pingcap/tiflow/cdc/sinkv2/eventsink/elasticsearch"
 	"github.com/pingcap/tiflow/cdc/sinkv2/eventsink/mq"
 	"github.com/pingcap/tiflow/cdc/sinkv2/eventsink/mq/dmlproducer"
 	"github.com/pingcap/tiflow/cdc/sinkv2/eventsink/txn"
+	"github.com/pingcap/tiflow/cdc/sinkv2/eventsink/webhook"
 	"github.com/pingcap/tiflow/cdc/sinkv2/tablesink"
 	"github.com/pingcap/tiflow/pkg/config"
 	cerror "github.com/pingcap/tiflow/pkg/errors"
 	"github.com/pingcap/tiflow/pkg/sink"
 	"github.com/pingcap/tiflow/pkg/sink/kafka"
 	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
 )
 
 // SinkFactory is the factory of sink.
@@ -42,6 +50,20 @@ type SinkFactory struct {
 	sinkType sink.Type
 	rowSink  eventsink.EventSink[*model.RowChangedEvent]
 	txnSink  eventsink.EventSink[*model.SingleTableTxn]
+	// columnSelectors drop the columns of the rows appended to the table
+	// sinks, it's nil if no column selector is configured.
+	columnSelectors *eventsink.ColumnSelectors
+	// sampleRate is the fraction of the rows appended to the table sinks,
+	// which is set for the sampled extra sinks, 0 means all the rows.
+	sampleRate float64
+	// extraSinks are the extra sinks of the changefeed, which are written
+	// by multiTableSinks along with the primary sink.
+	extraSinks []*extraSink
+
+	mu sync.Mutex
+	// tableSinks are the live multiTableSinks, which are tracked to
+	// calculate the checkpoint of each sink.
+	tableSinks map[*multiTableSink]struct{}
 }
 
 // New creates a new SinkFactory by schema.
@@ -54,8 +76,16 @@ func New(ctx context.Context,
 	if err != nil {
 		return nil, err
 	}
+	// The metrics config controls the labels of the metrics of the sinks.
+	ctx = contextutil.PutMetricsConfigInCtx(ctx, cfg.Metrics)
 
 	s := &SinkFactory{}
+	if len(cfg.Sink.ColumnSelectors) > 0 {
+		s.columnSelectors, err = eventsink.NewColumnSelectors(cfg)
+		if err != nil {
+			return nil, err
+		}
+	}
 	schema := strings.ToLower(sinkURI.Scheme)
 	switch schema {
 	case sink.MySQLScheme, sink.MySQLSSLScheme, sink.TiDBScheme, sink.TiDBSSLScheme:
@@ -65,7 +95,7 @@ func New(ctx context.Context,
 		}
 		s.txnSink = txnSink
 		s.sinkType = sink.TxnSink
-	case sink.KafkaScheme, sink.KafkaSSLScheme:
+	case sink.KafkaScheme, sink.KafkaSSLScheme, sink.KafkaSRVScheme:
 		mqs, err := mq.NewKafkaDMLSink(ctx, sinkURI, cfg, errCh,
 			kafka.NewSaramaAdminClient, kafka.NewSaramaClient, dmlproducer.NewKafkaDMLProducer)
 		if err != nil {
@@ -80,6 +110,27 @@ func New(ctx context.Context,
 		}
 		s.txnSink = storageSink
 		s.sinkType = sink.TxnSink
+	case sink.ClickHouseScheme, sink.ClickHouseSSLScheme:
+		chs, err := clickhouse.NewClickHouseSink(ctx, sinkURI, cfg, errCh)
+		if err != nil {
+			return nil, err
+		}
+		s.rowSink = chs
+		s.sinkType = sink.RowSink
+	case sink.ElasticsearchScheme, sink.ElasticsearchSSLScheme:
+		es, err := elasticsearch.NewElasticsearchSink(ctx, sinkURI, cfg, errCh)
+		if err != nil {
+			return nil, err
+		}
+		s.rowSink = es
+		s.sinkType = sink.RowSink
+	case sink.WebhookScheme, sink.WebhookSSLScheme:
+		ws, err := webhook.NewWebhookSink(ctx, sinkURI, cfg, errCh)
+		if err != nil {
+			return nil, err
+		}
+		s.rowSink = ws
+		s.sinkType = sink.RowSink
 	case sink.BlackHoleScheme:
 		bs := blackhole.New()
 		s.rowSink = bs
@@ -89,28 +140,148 @@ func New(ctx context.Context,
 			cerror.ErrSinkURIInvalid.GenWithStack("the sink scheme (%s) is not supported", schema)
 	}
 
+	for _, extra := range cfg.Sink.ExtraSinks {
+		es := &extraSink{
+			name:         extra.Name,
+			changefeedID: contextutil.ChangefeedIDFromCtx(ctx),
+			shadow:       extra.SampleRate > 0 && extra.SampleRate < 1,
+		}
+		extraErrCh := errCh
+		if es.shadow {
+			// The errors of a shadow sink detach it instead of failing
+			// the changefeed.
+			extraErrCh = make(chan error, 1)
+			go es.watchErrors(ctx, extraErrCh)
+		}
+		es.factory, err = New(ctx, extra.SinkURI, cfg.ExtraSinkReplicaConfig(extra), extraErrCh)
+		if err != nil {
+			if es.shadow {
+				es.detach(err)
+				continue
+			}
+			_ = s.Close()
+			return nil, err
+		}
+		es.factory.sampleRate = extra.SampleRate
+		s.extraSinks = append(s.extraSinks, es)
+	}
+	if len(s.extraSinks) > 0 {
+		s.tableSinks = make(map[*multiTableSink]struct{})
+	}
+
 	return s, nil
 }
 
-// CreateTableSink creates a TableSink by schema.
+// CreateTableSink creates a TableSink by schema. The TableSink writes to
+// the extra sinks too if there are any.
 func (s *SinkFactory) CreateTableSink(
 	changefeedID model.ChangeFeedID, span tablepb.Span, totalRowsCounter prometheus.Counter,
+) tablesink.TableSink {
+	primary := s.createTableSink(changefeedID, span, totalRowsCounter)
+	if len(s.extraSinks) == 0 {
+		return primary
+	}
+	t := &multiTableSink{factory: s, primary: primary}
+	for _, extra := range s.extraSinks {
+		t.extras = append(t.extras,
+			extra.factory.createTableSink(changefeedID, span, extraSinkTotalRows))
+	}
+	s.mu.Lock()
+	s.tableSinks[t] = struct{}{}
+	s.mu.Unlock()
+	return t
+}
+
+func (s *SinkFactory) untrackTableSink(t *multiTableSink) {
+	s.mu.Lock()
+	delete(s.tableSinks, t)
+	s.mu.Unlock()
+}
+
+func (s *SinkFactory) createTableSink(
+	changefeedID model.ChangeFeedID, span tablepb.Span, totalRowsCounter prometheus.Counter,
 ) tablesink.TableSink {
 	switch s.sinkType {
 	case sink.RowSink:
 		// We have to indicate the type here, otherwise it can not be compiled.
+		var appender eventsink.Appender[*model.RowChangedEvent] = &eventsink.RowChangeEventAppender{}
+		if s.columnSelectors != nil {
+			appender = eventsink.NewColumnSelectAppender(appender, s.columnSelectors)
+		}
+		if s.sampleRate > 0 && s.sampleRate < 1 {
+			appender = eventsink.NewSampleAppender(appender, s.sampleRate)
+		}
 		return tablesink.New[*model.RowChangedEvent](changefeedID, span,
-			s.rowSink, &eventsink.RowChangeEventAppender{}, totalRowsCounter)
+			s.rowSink, appender, totalRowsCounter)
 	case sink.TxnSink:
+		var appender eventsink.Appender[*model.SingleTableTxn] = &eventsink.TxnEventAppender{}
+		if s.columnSelectors != nil {
+			appender = eventsink.NewColumnSelectAppender(appender, s.columnSelectors)
+		}
+		if s.sampleRate > 0 && s.sampleRate < 1 {
+			appender = eventsink.NewSampleAppender(appender, s.sampleRate)
+		}
 		return tablesink.New[*model.SingleTableTxn](changefeedID, span,
-			s.txnSink, &eventsink.TxnEventAppender{}, totalRowsCounter)
+			s.txnSink, appender, totalRowsCounter)
 	default:
 		panic("unknown sink type")
 	}
 }
 
+// UpdateCredentials updates the credentials of the sink without recreating
+// it, only the sink URI which differs in the credentials is accepted.
+func (s *SinkFactory) UpdateCredentials(ctx context.Context, sinkURIStr string) error {
+	sinkURI, err := url.Parse(sinkURIStr)
+	if err != nil {
+		return cerror.WrapError(cerror.ErrSinkURIInvalid, err)
+	}
+	var updater eventsink.CredentialsUpdater
+	switch s.sinkType {
+	case sink.RowSink:
+		updater, _ = s.rowSink.(eventsink.CredentialsUpdater)
+	case sink.TxnSink:
+		updater, _ = s.txnSink.(eventsink.CredentialsUpdater)
+	}
+	if updater == nil {
+		return cerror.ErrSinkCredentialsNotUpdatable.GenWithStackByArgs(
+			"the sink does not support it")
+	}
+	return updater.UpdateCredentials(ctx, sinkURI)
+}
+
+// SinkCheckpoints returns the checkpoint of the primary sink and each extra
+// sink, which is the min checkpoint of the tables written to the sink. The
+// sinks without tables are omitted. false is returned if there are no extra
+// sinks.
+func (s *SinkFactory) SinkCheckpoints() (map[string]model.Ts, bool) {
+	if len(s.extraSinks) == 0 {
+		return nil, false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	checkpoints := make(map[string]model.Ts, len(s.extraSinks)+1)
+	observe := func(name string, ts model.Ts) {
+		if current, ok := checkpoints[name]; !ok || ts < current {
+			checkpoints[name] = ts
+		}
+	}
+	for t := range s.tableSinks {
+		observe(config.PrimarySinkName, t.primary.GetCheckpointTs().Ts)
+		for i, extra := range t.extras {
+			observe(s.extraSinks[i].name, extra.GetCheckpointTs().Ts)
+		}
+	}
+	return checkpoints, true
+}
+
 // Close closes the sink.
 func (s *SinkFactory) Close() error {
+	for _, extra := range s.extraSinks {
+		if err := extra.factory.Close(); err != nil {
+			log.Warn("Failed to close the extra sink",
+				zap.String("name", extra.name), zap.Error(err))
+		}
+	}
 	switch s.sinkType {
 	case sink.RowSink:
 		return s.rowSink.Close()
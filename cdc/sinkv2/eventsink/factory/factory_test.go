@@ -21,6 +21,7 @@ import (
 	"testing"
 
 	"github.com/Shopify/sarama"
+	"github.com/pingcap/errors"
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/cdc/sinkv2/eventsink/mq"
 	"github.com/pingcap/tiflow/cdc/sinkv2/eventsink/mq/dmlproducer"
@@ -113,3 +114,57 @@ func TestSinkFactory(t *testing.T) {
 	err = sinkFactory.Close()
 	require.Nil(t, err, "sink factory can be closed")
 }
+
+func TestSinkFactoryWithExtraSinks(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	replicaConfig := config.GetDefaultReplicaConfig()
+	replicaConfig.Sink.ExtraSinks = []*config.ExtraSinkConfig{
+		{Name: "blackhole", SinkURI: "blackhole://", SampleRate: 0.01},
+	}
+	sinkFactory, err := New(ctx, "blackhole://", replicaConfig, make(chan error, 1))
+	require.Nil(t, err)
+	require.Len(t, sinkFactory.extraSinks, 1)
+	// Only the extra sink is sampled.
+	require.Equal(t, float64(0), sinkFactory.sampleRate)
+	require.Equal(t, 0.01, sinkFactory.extraSinks[0].factory.sampleRate)
+
+	tableSink := sinkFactory.CreateTableSink(model.DefaultChangeFeedID("1"),
+		spanz.TableIDToComparableSpan(1), prometheus.NewCounter(prometheus.CounterOpts{}))
+	multi, ok := tableSink.(*multiTableSink)
+	require.True(t, ok)
+	require.Len(t, multi.extras, 1)
+
+	tableSink.AppendRowChangedEvents(&model.RowChangedEvent{
+		CommitTs: 10,
+		Table:    &model.TableName{Schema: "test", Table: "t", TableID: 1},
+	})
+	require.Nil(t, tableSink.UpdateResolvedTs(model.NewResolvedTs(20)))
+	require.Equal(t, model.Ts(20), tableSink.GetCheckpointTs().Ts)
+	checkpoints, ok := sinkFactory.SinkCheckpoints()
+	require.True(t, ok)
+	require.Equal(t, map[string]model.Ts{config.PrimarySinkName: 20, "blackhole": 20}, checkpoints)
+
+	// The sampled extra sink is a shadow, which is detached on errors
+	// instead of holding back the changefeed.
+	require.True(t, sinkFactory.extraSinks[0].shadow)
+	sinkFactory.extraSinks[0].detach(errors.New("injected"))
+	tableSink.AppendRowChangedEvents(&model.RowChangedEvent{
+		CommitTs: 30,
+		Table:    &model.TableName{Schema: "test", Table: "t", TableID: 1},
+	})
+	require.Nil(t, tableSink.UpdateResolvedTs(model.NewResolvedTs(40)))
+	require.Equal(t, model.Ts(40), tableSink.GetCheckpointTs().Ts)
+	checkpoints, ok = sinkFactory.SinkCheckpoints()
+	require.True(t, ok)
+	require.Equal(t, map[string]model.Ts{config.PrimarySinkName: 40, "blackhole": 20}, checkpoints)
+
+	tableSink.Close(ctx)
+	checkpoints, ok = sinkFactory.SinkCheckpoints()
+	require.True(t, ok)
+	require.Empty(t, checkpoints)
+	require.Nil(t, sinkFactory.Close())
+}
@@ -0,0 +1,158 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package factory
+
+import (
+	"context"
+
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sinkv2/tablesink"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/tikv/client-go/v2/oracle"
+	"go.uber.org/atomic"
+	"go.uber.org/zap"
+)
+
+// extraSinkTotalRows counts the rows appended to the table sinks of the
+// extra sinks, which are already counted by the ones of the primary sink.
+// It's not registered, so it's only a placeholder.
+var extraSinkTotalRows = prometheus.NewCounter(prometheus.CounterOpts{
+	Name: "extra_sink_total_rows",
+})
+
+// shadowMaxLag is the max lag of the checkpoint of a shadow sink behind the
+// one of the primary sink, the shadow sink is detached if it lags more.
+const shadowMaxLag = 10 * time.Minute
+
+// extraSink is an extra sink of a changefeed.
+type extraSink struct {
+	name         string
+	changefeedID model.ChangeFeedID
+	factory      *SinkFactory
+	// shadow is true if the sink is sampled. It's a shadow of the primary
+	// sink for testing, which never holds back or fails the changefeed.
+	shadow bool
+	// detached is set once the shadow sink fails, the events are not
+	// written to it anymore.
+	detached atomic.Bool
+}
+
+// watchErrors detaches the shadow sink on the first error of it.
+func (e *extraSink) watchErrors(ctx context.Context, errCh <-chan error) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case err := <-errCh:
+			e.detach(err)
+		}
+	}
+}
+
+// detach stops writing to the shadow sink.
+func (e *extraSink) detach(err error) {
+	if e.detached.Swap(true) {
+		return
+	}
+	log.Warn("The shadow sink is detached from the changefeed",
+		zap.String("namespace", e.changefeedID.Namespace),
+		zap.String("changefeed", e.changefeedID.ID),
+		zap.String("name", e.name), zap.Error(err))
+}
+
+// Assert TableSink implementation
+var _ tablesink.TableSink = (*multiTableSink)(nil)
+
+// multiTableSink writes the events of a table to the primary sink and the
+// extra sinks with a table sink for each of them. The checkpoint of the table
+// is the min one of them except the shadow sinks, so the other sinks are not
+// decoupled, a slow one holds back all the others.
+//
+// Note: the table sinks share the row changed events, which must not be
+// changed by the sinks.
+type multiTableSink struct {
+	factory *SinkFactory
+	primary tablesink.TableSink
+	// extras are the table sinks of the extra sinks in the order of
+	// SinkFactory.extraSinks.
+	extras []tablesink.TableSink
+}
+
+// AppendRowChangedEvents appends the events to all the table sinks.
+func (t *multiTableSink) AppendRowChangedEvents(rows ...*model.RowChangedEvent) {
+	t.primary.AppendRowChangedEvents(rows...)
+	for i, extra := range t.extras {
+		if t.factory.extraSinks[i].detached.Load() {
+			continue
+		}
+		extra.AppendRowChangedEvents(rows...)
+	}
+}
+
+// UpdateResolvedTs updates the resolved ts of all the table sinks.
+func (t *multiTableSink) UpdateResolvedTs(resolvedTs model.ResolvedTs) error {
+	if err := t.primary.UpdateResolvedTs(resolvedTs); err != nil {
+		return err
+	}
+	for i, extra := range t.extras {
+		es := t.factory.extraSinks[i]
+		if es.detached.Load() {
+			continue
+		}
+		if err := extra.UpdateResolvedTs(resolvedTs); err != nil {
+			if !es.shadow {
+				return err
+			}
+			es.detach(err)
+		}
+	}
+	return nil
+}
+
+// GetCheckpointTs returns the min checkpoint ts of the table sinks except
+// the shadow ones, which are detached if they lag too much.
+func (t *multiTableSink) GetCheckpointTs() model.ResolvedTs {
+	primaryTs := t.primary.GetCheckpointTs()
+	checkpointTs := primaryTs
+	for i, extra := range t.extras {
+		if es := t.factory.extraSinks[i]; es.shadow {
+			if es.detached.Load() {
+				continue
+			}
+			lag := oracle.GetTimeFromTS(primaryTs.Ts).Sub(
+				oracle.GetTimeFromTS(extra.GetCheckpointTs().Ts))
+			if lag > shadowMaxLag {
+				es.detach(errors.Errorf("the checkpoint lags %s behind the primary sink", lag))
+			}
+			continue
+		}
+		if ts := extra.GetCheckpointTs(); ts.Less(checkpointTs) {
+			checkpointTs = ts
+		}
+	}
+	return checkpointTs
+}
+
+// Close closes all the table sinks.
+func (t *multiTableSink) Close(ctx context.Context) {
+	t.factory.untrackTableSink(t)
+	t.primary.Close(ctx)
+	for _, extra := range t.extras {
+		extra.Close(ctx)
+	}
+}
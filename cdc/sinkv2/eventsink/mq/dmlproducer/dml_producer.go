@@ -38,4 +38,5 @@ type DMLProducer interface {
 // So we let the GC close errCh.
 // It's usually a buffered channel.
 type Factory func(ctx context.Context, client kafka.Client,
-	adminClient kafka.ClusterAdminClient, errCh chan error) (DMLProducer, error)
+	adminClient kafka.ClusterAdminClient, options *kafka.Options,
+	errCh chan error) (DMLProducer, error)
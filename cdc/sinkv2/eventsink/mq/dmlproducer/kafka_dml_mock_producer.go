@@ -32,7 +32,7 @@ type MockDMLProducer struct {
 
 // NewDMLMockProducer creates a mock producer.
 func NewDMLMockProducer(_ context.Context, _ kafka.Client,
-	_ kafka.ClusterAdminClient, _ chan error,
+	_ kafka.ClusterAdminClient, _ *kafka.Options, _ chan error,
 ) (DMLProducer, error) {
 	return &MockDMLProducer{
 		events: make(map[mqv1.TopicPartitionKey][]*common.Message),
@@ -15,6 +15,7 @@ package dmlproducer
 
 import (
 	"context"
+	"fmt"
 	"sync"
 	"time"
 
@@ -27,14 +28,21 @@ import (
 	"github.com/pingcap/tiflow/cdc/sink/codec/common"
 	"github.com/pingcap/tiflow/cdc/sinkv2/eventsink"
 	collector "github.com/pingcap/tiflow/cdc/sinkv2/metrics/mq/kafka"
+	"github.com/pingcap/tiflow/pkg/compression"
+	"github.com/pingcap/tiflow/pkg/config"
 	cerror "github.com/pingcap/tiflow/pkg/errors"
 	pkafka "github.com/pingcap/tiflow/pkg/sink/kafka"
 	"github.com/pingcap/tiflow/pkg/util"
+	"github.com/pingcap/tiflow/pkg/warning"
 	"go.uber.org/zap"
 )
 
 var _ DMLProducer = (*kafkaDMLProducer)(nil)
 
+// unavailableCheckInterval is the interval to check whether the brokers are
+// unavailable longer than the threshold when the sink pauses on them.
+const unavailableCheckInterval = time.Second
+
 // messageMetaData is used to store the callback function for the message.
 type messageMetaData struct {
 	callback eventsink.CallbackFunc
@@ -62,6 +70,22 @@ type kafkaDMLProducer struct {
 	// failpointCh is used to inject failpoints to the run loop.
 	// Only used in test.
 	failpointCh chan error
+	// payloadCodec compresses the message values if the codec is not
+	// supported by the Kafka protocol, see pkafka.PayloadCodec.
+	payloadCodec compression.Codec
+	// retryBudget escalates the errors of the messages whose retries are
+	// exhausted, see pkafka.EscalateSendError.
+	retryBudget *config.RetryBudgetConfig
+
+	// inflight bounds the messages buffered by the producer when the sink
+	// pauses on unavailable brokers, it is nil if the sink doesn't pause.
+	inflight             chan struct{}
+	unavailableThreshold time.Duration
+	// lastProgress is the last time when a message is acknowledged or no
+	// message is in flight, pausedAt is the time when the sink is paused.
+	// They are only accessed by the run loop.
+	lastProgress time.Time
+	pausedAt     time.Time
 }
 
 // NewKafkaDMLProducer creates a new kafka producer.
@@ -69,6 +93,7 @@ func NewKafkaDMLProducer(
 	ctx context.Context,
 	client pkafka.Client,
 	adminClient pkafka.ClusterAdminClient,
+	options *pkafka.Options,
 	errCh chan error,
 ) (DMLProducer, error) {
 	changefeedID := contextutil.ChangefeedIDFromCtx(ctx)
@@ -98,8 +123,12 @@ func NewKafkaDMLProducer(
 		return nil, cerror.WrapError(cerror.ErrKafkaNewSaramaProducer, err)
 	}
 
+	var batchCodec compression.Codec
+	if options != nil {
+		batchCodec = pkafka.RecordBatchCodec(options.Compression)
+	}
 	collector := collector.New(changefeedID, util.RoleProcessor,
-		adminClient, client.MetricRegistry())
+		adminClient, client.MetricRegistry(), batchCodec)
 
 	k := &kafkaDMLProducer{
 		id:            changefeedID,
@@ -109,6 +138,15 @@ func NewKafkaDMLProducer(
 		closed:        false,
 		closedChan:    make(chan struct{}),
 		failpointCh:   make(chan error, 1),
+		lastProgress:  time.Now(),
+	}
+	if options != nil {
+		k.payloadCodec = pkafka.PayloadCodec(options.Compression)
+		k.retryBudget = options.RetryBudget
+	}
+	if options != nil && options.PauseOnUnavailable {
+		k.inflight = make(chan struct{}, options.UnavailableBufferSize)
+		k.unavailableThreshold = options.UnavailableThreshold
 	}
 
 	// Start collecting metrics.
@@ -140,6 +178,19 @@ func (k *kafkaDMLProducer) AsyncSendMessage(
 	ctx context.Context, topic string,
 	partition int32, message *common.Message,
 ) error {
+	// Block the sink until a buffered message is acknowledged if too many
+	// messages are buffered, so that the sink stops pulling the events
+	// instead of buffering them while the brokers are unavailable.
+	if k.inflight != nil {
+		select {
+		case <-ctx.Done():
+			return errors.Trace(ctx.Err())
+		case <-k.closedChan:
+			return cerror.ErrKafkaProducerClosed.GenWithStackByArgs()
+		case k.inflight <- struct{}{}:
+		}
+	}
+
 	// We have to hold the lock to avoid writing to a closed producer.
 	// Close may be blocked for a long time.
 	k.closedMu.RLock()
@@ -147,6 +198,7 @@ func (k *kafkaDMLProducer) AsyncSendMessage(
 
 	// If the producer is closed, we should skip the message and return an error.
 	if k.closed {
+		k.releaseInflight()
 		return cerror.ErrKafkaProducerClosed.GenWithStackByArgs()
 	}
 	failpoint.Inject("KafkaSinkAsyncSendError", func() {
@@ -155,25 +207,53 @@ func (k *kafkaDMLProducer) AsyncSendMessage(
 		log.Info("KafkaSinkAsyncSendError error injected", zap.String("namespace", k.id.Namespace),
 			zap.String("changefeed", k.id.ID))
 		k.failpointCh <- errors.New("kafka sink injected error")
+		k.releaseInflight()
 		failpoint.Return(nil)
 	})
 
+	value, headers, err := pkafka.CompressPayload(
+		k.payloadCodec, message.Value, recordHeaders(message.Headers))
+	if err != nil {
+		k.releaseInflight()
+		return errors.Trace(err)
+	}
 	msg := &sarama.ProducerMessage{
 		Topic:     topic,
 		Partition: partition,
 		Key:       sarama.StringEncoder(message.Key),
-		Value:     sarama.ByteEncoder(message.Value),
+		Value:     sarama.ByteEncoder(value),
+		Headers:   headers,
 		Metadata:  messageMetaData{callback: message.Callback},
 	}
 
 	select {
 	case <-ctx.Done():
+		k.releaseInflight()
 		return errors.Trace(ctx.Err())
 	case k.asyncProducer.Input() <- msg:
 	}
 	return nil
 }
 
+// releaseInflight releases a slot of the buffered messages.
+func (k *kafkaDMLProducer) releaseInflight() {
+	if k.inflight != nil {
+		<-k.inflight
+	}
+}
+
+// recordHeaders converts the headers of a message to the kafka record headers.
+func recordHeaders(headers []common.MessageHeader) []sarama.RecordHeader {
+	if len(headers) == 0 {
+		return nil
+	}
+	res := make([]sarama.RecordHeader, 0, len(headers))
+	for _, header := range headers {
+		res = append(res, sarama.RecordHeader{Key: []byte(header.Key), Value: header.Value})
+	}
+	return res
+}
+
 func (k *kafkaDMLProducer) Close() {
 	// We have to hold the lock to synchronize closing with writing.
 	k.closedMu.Lock()
@@ -243,6 +323,20 @@ func (k *kafkaDMLProducer) Close() {
 }
 
 func (k *kafkaDMLProducer) run(ctx context.Context) error {
+	// The nil channel never fires if the sink doesn't pause.
+	var checkUnavailable <-chan time.Time
+	if k.inflight != nil {
+		ticker := time.NewTicker(unavailableCheckInterval)
+		defer ticker.Stop()
+		checkUnavailable = ticker.C
+		// The changefeed isn't left in the sink-paused state by a closed
+		// producer.
+		defer func() {
+			if !k.pausedAt.IsZero() {
+				warning.SetSinkPaused(k.id, false)
+			}
+		}()
+	}
 	for {
 		select {
 		case <-ctx.Done():
@@ -256,8 +350,12 @@ func (k *kafkaDMLProducer) run(ctx context.Context) error {
 				zap.String("changefeed", k.id.ID),
 				zap.Error(err))
 			return errors.Trace(err)
+		case now := <-checkUnavailable:
+			k.checkUnavailable(now)
 		case ack := <-k.asyncProducer.Successes():
 			if ack != nil {
+				k.releaseInflight()
+				k.markProgress(time.Now())
 				callback := ack.Metadata.(messageMetaData).callback
 				if callback != nil {
 					callback()
@@ -272,7 +370,52 @@ func (k *kafkaDMLProducer) run(ctx context.Context) error {
 			if err == nil {
 				return nil
 			}
-			return cerror.WrapError(cerror.ErrKafkaAsyncSendMessage, err)
+			return pkafka.EscalateSendError(k.retryBudget,
+				cerror.WrapError(cerror.ErrKafkaAsyncSendMessage, err))
 		}
 	}
 }
+
+// checkUnavailable pauses the sink if no buffered message is acknowledged
+// longer than the threshold. The sink stops pulling the events once the
+// buffer is full, and it's resumed by the next acknowledged message.
+func (k *kafkaDMLProducer) checkUnavailable(now time.Time) {
+	buffered := len(k.inflight)
+	if buffered == 0 {
+		k.markProgress(now)
+		return
+	}
+	unavailable := now.Sub(k.lastProgress)
+	if !k.pausedAt.IsZero() || unavailable < k.unavailableThreshold {
+		return
+	}
+	k.pausedAt = now
+	warning.SetSinkPaused(k.id, true)
+	log.Warn("Kafka DML producer paused because the brokers are unavailable",
+		zap.String("namespace", k.id.Namespace),
+		zap.String("changefeed", k.id.ID),
+		zap.Duration("unavailable", unavailable),
+		zap.Int("bufferedMessages", buffered))
+	warning.Record(k.id, cerror.ErrKafkaSinkPaused, fmt.Sprintf(
+		"sink-paused: no message is acknowledged by the brokers for %s, "+
+			"%d messages are buffered", unavailable.Round(time.Second), buffered))
+}
+
+// markProgress records the progress of the producer, and resumes the sink if
+// it's paused.
+func (k *kafkaDMLProducer) markProgress(now time.Time) {
+	k.lastProgress = now
+	if k.pausedAt.IsZero() {
+		return
+	}
+	paused := now.Sub(k.pausedAt)
+	k.pausedAt = time.Time{}
+	warning.SetSinkPaused(k.id, false)
+	log.Info("Kafka DML producer resumed because the brokers are available",
+		zap.String("namespace", k.id.Namespace),
+		zap.String("changefeed", k.id.ID),
+		zap.Duration("paused", paused))
+	warning.Record(k.id, cerror.ErrKafkaSinkPaused, fmt.Sprintf(
+		"sink-resumed: the brokers are available after paused for %s",
+		paused.Round(time.Second)))
+}
@@ -22,9 +22,11 @@ import (
 	"time"
 
 	"github.com/Shopify/sarama"
+	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/cdc/sink/codec/common"
 	cerror "github.com/pingcap/tiflow/pkg/errors"
 	"github.com/pingcap/tiflow/pkg/sink/kafka"
+	"github.com/pingcap/tiflow/pkg/warning"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/atomic"
 )
@@ -83,7 +85,7 @@ func TestProducerAck(t *testing.T) {
 	require.Nil(t, err)
 	adminClient, err := kafka.NewMockAdminClient(options.BrokerEndpoints, saramaConfig)
 	require.Nil(t, err)
-	producer, err := NewKafkaDMLProducer(ctx, client, adminClient, errCh)
+	producer, err := NewKafkaDMLProducer(ctx, client, adminClient, nil, errCh)
 	require.Nil(t, err)
 	require.NotNil(t, producer)
 
@@ -149,7 +151,7 @@ func TestProducerSendMsgFailed(t *testing.T) {
 	require.Nil(t, err)
 	adminClient, err := kafka.NewMockAdminClient(options.BrokerEndpoints, saramaConfig)
 	require.Nil(t, err)
-	producer, err := NewKafkaDMLProducer(ctx, client, adminClient, errCh)
+	producer, err := NewKafkaDMLProducer(ctx, client, adminClient, nil, errCh)
 	defer func() {
 		producer.Close()
 
@@ -210,10 +212,61 @@ func TestProducerDoubleClose(t *testing.T) {
 	require.Nil(t, err)
 	adminClient, err := kafka.NewMockAdminClient(options.BrokerEndpoints, saramaConfig)
 	require.Nil(t, err)
-	producer, err := NewKafkaDMLProducer(ctx, client, adminClient, errCh)
+	producer, err := NewKafkaDMLProducer(ctx, client, adminClient, nil, errCh)
 	require.Nil(t, err)
 	require.NotNil(t, producer)
 
 	producer.Close()
 	producer.Close()
 }
+
+func TestProducerPauseOnUnavailable(t *testing.T) {
+	t.Parallel()
+
+	id := model.DefaultChangeFeedID("test-pause-on-unavailable")
+	defer warning.Remove(id)
+	start := time.Now()
+	k := &kafkaDMLProducer{
+		id:                   id,
+		closedChan:           make(chan struct{}),
+		inflight:             make(chan struct{}, 1),
+		unavailableThreshold: time.Minute,
+		lastProgress:         start,
+	}
+
+	// The buffer is bounded.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	k.inflight <- struct{}{}
+	err := k.AsyncSendMessage(ctx, "test", 0, &common.Message{})
+	require.Regexp(t, "context deadline exceeded", err)
+
+	k.checkUnavailable(start.Add(30 * time.Second))
+	require.True(t, k.pausedAt.IsZero())
+	require.Empty(t, warning.Warnings(id))
+
+	k.checkUnavailable(start.Add(2 * time.Minute))
+	require.False(t, k.pausedAt.IsZero())
+	require.True(t, warning.SinkPaused(id))
+	warnings := warning.Warnings(id)
+	require.Len(t, warnings, 1)
+	require.Equal(t, "CDC:ErrKafkaSinkPaused", warnings[0].Code)
+	require.Regexp(t, "sink-paused: .* for 2m0s, 1 messages are buffered", warnings[0].Message)
+
+	// The sink is paused only once.
+	k.checkUnavailable(start.Add(3 * time.Minute))
+	require.Equal(t, uint64(1), warning.Warnings(id)[0].Count)
+
+	k.releaseInflight()
+	k.markProgress(start.Add(4 * time.Minute))
+	require.True(t, k.pausedAt.IsZero())
+	require.False(t, warning.SinkPaused(id))
+	warnings = warning.Warnings(id)
+	require.Equal(t, uint64(2), warnings[0].Count)
+	require.Regexp(t, "sink-resumed: .* for 2m0s", warnings[0].Message)
+
+	// An idle producer is never paused.
+	k.checkUnavailable(start.Add(10 * time.Minute))
+	require.True(t, k.pausedAt.IsZero())
+	require.Equal(t, start.Add(10*time.Minute), k.lastProgress)
+}
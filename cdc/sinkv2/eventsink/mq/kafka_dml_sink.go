@@ -16,12 +16,18 @@ package mq
 import (
 	"context"
 	"net/url"
+	"strings"
+	"time"
 
 	"github.com/pingcap/errors"
 	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/cdc/contextutil"
+	"github.com/pingcap/tiflow/cdc/sink/codec/common"
 	"github.com/pingcap/tiflow/cdc/sink/mq/dispatcher"
 	"github.com/pingcap/tiflow/cdc/sink/mq/producer/kafka"
+	"github.com/pingcap/tiflow/cdc/sinkv2/eventsink"
 	"github.com/pingcap/tiflow/cdc/sinkv2/eventsink/mq/dmlproducer"
+	"github.com/pingcap/tiflow/cdc/sinkv2/eventsink/tee"
 	"github.com/pingcap/tiflow/cdc/sinkv2/util"
 	"github.com/pingcap/tiflow/pkg/config"
 	cerror "github.com/pingcap/tiflow/pkg/errors"
@@ -48,10 +54,20 @@ func NewKafkaDMLSink(
 	if err := options.Apply(sinkURI); err != nil {
 		return nil, cerror.WrapError(cerror.ErrKafkaInvalidConfig, err)
 	}
+	options.RetryBudget = replicaConfig.Sink.RetryBudget
+	if options.ClientID == "" && replicaConfig.Sink.ClientIdentity != nil {
+		options.ClientID = replicaConfig.Sink.ClientIdentity.KafkaClientID
+	}
 	saramaConfig, err := pkafka.NewSaramaConfig(ctx, options)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	// The shared clients replace the ones created by the creators, they are
+	// shared with the other changefeeds connecting to the same cluster.
+	if options.ShareClient {
+		adminClientCreator = pkafka.NewSharedAdminClientCreator(options)
+		clientCreator = pkafka.NewSharedClientCreator(options)
+	}
 
 	adminClient, err := adminClientCreator(options.BrokerEndpoints, saramaConfig)
 	if err != nil {
@@ -76,6 +92,12 @@ func NewKafkaDMLSink(
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	// The tombstones remove the deleted rows by the keys of the messages.
+	if options.Tombstone != pkafka.TombstoneNone && !protocol.IsRowKeyed() {
+		return nil, cerror.ErrKafkaInvalidConfig.GenWithStack(
+			"tombstone is not supported by the protocol %s, which doesn't key the messages by the rows",
+			protocol)
+	}
 
 	client, err := clientCreator(options.BrokerEndpoints, saramaConfig)
 	if err != nil {
@@ -83,8 +105,8 @@ func NewKafkaDMLSink(
 	}
 
 	log.Info("Try to create a DML sink producer",
-		zap.Any("options", options))
-	p, err := producerCreator(ctx, client, adminClient, errCh)
+		zap.Object("options", options))
+	p, err := producerCreator(ctx, client, adminClient, options, errCh)
 	if err != nil {
 		return nil, cerror.WrapError(cerror.ErrKafkaNewSaramaProducer, err)
 	}
@@ -96,32 +118,91 @@ func NewKafkaDMLSink(
 		}
 	}()
 
+	changefeedID := contextutil.ChangefeedIDFromCtx(ctx)
+	eventRouter, err := dispatcher.NewEventRouter(replicaConfig, topic)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	topicCfg := options.DeriveTopicConfig()
+	// The topics are created with the overrides of the rules dispatching
+	// the events to them.
+	topicCfg.Overrides = eventRouter
 	topicManager, err := util.GetTopicManagerAndTryCreateTopic(
+		changefeedID,
 		topic,
-		options.DeriveTopicConfig(),
+		topicCfg,
 		client,
 		adminClient,
 	)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	// Preventing the topic manager from being left registered when error occurs.
+	defer func() {
+		if err != nil {
+			topicManager.Close()
+		}
+	}()
 
-	eventRouter, err := dispatcher.NewEventRouter(replicaConfig, topic)
+	encoderConfig, err := util.GetEncoderConfig(sinkURI, protocol, replicaConfig,
+		saramaConfig.Producer.MaxMessageBytes)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	encoderConfig.ChangefeedID = changefeedID
 
-	encoderConfig, err := util.GetEncoderConfig(sinkURI, protocol, replicaConfig,
-		saramaConfig.Producer.MaxMessageBytes)
+	var sequencer *common.Sequencer
+	if options.SequenceNumber {
+		sequencer = common.NewSequencer()
+	}
+	headers := common.NewMetadataHeaders(replicaConfig.Sink.Headers,
+		changefeedID, replicaConfig.Sink.TiDBSourceID)
+	transformer, err := eventsink.NewColumnTransformer(replicaConfig)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-
+	defaultTopic := eventRouter.GetDefaultTopic()
+	if options.WarmUp {
+		start := time.Now()
+		if err = client.WarmUp([]string{defaultTopic}); err != nil {
+			return nil, cerror.WrapError(cerror.ErrKafkaNewSaramaProducer, err)
+		}
+		log.Info("Kafka topic warmed up",
+			zap.String("namespace", changefeedID.Namespace),
+			zap.String("changefeed", changefeedID.ID),
+			zap.String("topic", defaultTopic),
+			zap.Duration("duration", time.Since(start)))
+	}
+	var archive *tee.Archive
+	if replicaConfig.Sink.TeeSinkURI != "" {
+		// Every capture has a DML sink of the changefeed, the archive of a
+		// capture is named by its address, which is kept across restarts.
+		captureAddr := contextutil.CaptureAddrFromCtx(ctx)
+		archive, err = tee.NewArchive(ctx, replicaConfig.Sink.TeeSinkURI,
+			"dml-"+strings.NewReplacer(":", "_", "/", "_").Replace(captureAddr),
+			changefeedID, protocol)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
 	s, err := newSink(ctx, p, topicManager, eventRouter, encoderConfig,
-		replicaConfig.Sink.EncoderConcurrency, errCh)
+		replicaConfig.Sink.EncoderConcurrency, sequencer, headers,
+		newTombstoner(options.Tombstone, options.TombstoneDelay), transformer,
+		archive, errCh)
 	if err != nil {
+		if archive != nil {
+			_ = archive.Close()
+		}
 		return nil, errors.Trace(err)
 	}
+	s.splitUpdates = replicaConfig.Sink.UpdateKeyChange == config.UpdateKeyChangeSplit
+	if options.WarmUp {
+		// The client is closed by the producer, the other topics are warmed
+		// up the first time the events are routed to them.
+		s.warmUpClient = client
+		s.warmedUp.Store(defaultTopic, struct{}{})
+	}
 
 	return s, nil
 }
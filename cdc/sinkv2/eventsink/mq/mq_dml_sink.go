@@ -15,6 +15,8 @@ package mq
 
 import (
 	"context"
+	"sync"
+	"time"
 
 	"github.com/pingcap/errors"
 	"github.com/pingcap/log"
@@ -27,11 +29,14 @@ import (
 	"github.com/pingcap/tiflow/cdc/sink/mq/manager"
 	"github.com/pingcap/tiflow/cdc/sinkv2/eventsink"
 	"github.com/pingcap/tiflow/cdc/sinkv2/eventsink/mq/dmlproducer"
+	"github.com/pingcap/tiflow/cdc/sinkv2/eventsink/tee"
 	"github.com/pingcap/tiflow/cdc/sinkv2/metrics"
 	"github.com/pingcap/tiflow/cdc/sinkv2/tablesink/state"
 	"github.com/pingcap/tiflow/pkg/config"
 	cerror "github.com/pingcap/tiflow/pkg/errors"
 	"github.com/pingcap/tiflow/pkg/sink"
+	pkafka "github.com/pingcap/tiflow/pkg/sink/kafka"
+	"go.uber.org/atomic"
 	"go.uber.org/zap"
 )
 
@@ -52,6 +57,15 @@ type dmlSink struct {
 	// topicManager used to manage topics.
 	// It is also responsible for creating topics.
 	topicManager manager.TopicManager
+	// splitUpdates indicates whether to split the updates whose old and
+	// new values are dispatched to different partitions.
+	splitUpdates bool
+	// warmUpClient warms up the topics the first time the events are
+	// routed to them, it is nil if the warm-up is disabled.
+	warmUpClient pkafka.Client
+	// warmedUp are the topics warmed up, warmUpMu serializes the warm-ups.
+	warmedUp sync.Map
+	warmUpMu sync.Mutex
 }
 
 func newSink(ctx context.Context,
@@ -60,6 +74,11 @@ func newSink(ctx context.Context,
 	eventRouter *dispatcher.EventRouter,
 	encoderConfig *common.Config,
 	encoderConcurrency int,
+	sequencer *common.Sequencer,
+	headers *common.MetadataHeaders,
+	tombstones *tombstoner,
+	transformer *eventsink.ColumnTransformer,
+	archive *tee.Archive,
 	errCh chan error,
 ) (*dmlSink, error) {
 	changefeedID := contextutil.ChangefeedIDFromCtx(ctx)
@@ -72,6 +91,14 @@ func newSink(ctx context.Context,
 	statistics := metrics.NewStatistics(ctx, sink.RowSink)
 	worker := newWorker(changefeedID, encoderConfig.Protocol,
 		encoderBuilder, encoderConcurrency, producer, statistics)
+	worker.sequencer = sequencer
+	if sequencer != nil {
+		worker.sinkStates = make(map[int64]*state.TableSinkState)
+	}
+	worker.headers = headers
+	worker.tombstones = tombstones
+	worker.transformer = transformer
+	worker.archive = archive
 	s := &dmlSink{
 		id:           changefeedID,
 		protocol:     encoderConfig.Protocol,
@@ -102,6 +129,9 @@ func newSink(ctx context.Context,
 // WriteEvents writes events to the sink.
 // This is an asynchronously and thread-safe method.
 func (s *dmlSink) WriteEvents(rows ...*eventsink.RowChangeCallbackableEvent) error {
+	if s.worker.headers.TxnMetadata() {
+		countTxnRows(rows)
+	}
 	for _, row := range rows {
 		if row.GetTableSinkState() != state.TableSinkSinking {
 			// The table where the event comes from is in stopping, so it's safe
@@ -114,21 +144,115 @@ func (s *dmlSink) WriteEvents(rows ...*eventsink.RowChangeCallbackableEvent) err
 		if err != nil {
 			return errors.Trace(err)
 		}
+		if err := s.warmUp(topic); err != nil {
+			return errors.Trace(err)
+		}
 		partition := s.eventRouter.GetPartitionForRowChange(row.Event, partitionNum)
-		// This never be blocked because this is an unbounded channel.
-		s.worker.msgChan.In() <- mqEvent{
-			key: mqv1.TopicPartitionKey{
-				Topic: topic, Partition: partition,
-			},
-			rowEvent: row,
+		callback, commitTs := row.Callback, row.Event.CommitTs
+		row.Callback = func() {
+			s.worker.statistics.ObserveEventAge(config.MetricLabelTopic, topic, commitTs)
+			callback()
 		}
+		if s.splitUpdates && row.Event.IsUpdate() {
+			deleteRow, insertRow := splitUpdate(row.Event)
+			oldPartition := s.eventRouter.GetPartitionForRowChange(deleteRow, partitionNum)
+			if oldPartition != partition {
+				// NOTICE: Please do not change the order, the delete event always
+				// comes before the insert event.
+				callback := joinCallback(row.Callback)
+				s.addEvent(topic, oldPartition, &eventsink.RowChangeCallbackableEvent{
+					Event: deleteRow, Callback: callback, SinkState: row.SinkState,
+					TxnRowCount: row.TxnRowCount,
+				})
+				s.addEvent(topic, partition, &eventsink.RowChangeCallbackableEvent{
+					Event: insertRow, Callback: callback, SinkState: row.SinkState,
+					TxnRowCount: row.TxnRowCount,
+				})
+				continue
+			}
+		}
+		s.addEvent(topic, partition, row)
 	}
 
 	return nil
 }
 
+func (s *dmlSink) addEvent(topic string, partition int32, row *eventsink.RowChangeCallbackableEvent) {
+	// This never be blocked because this is an unbounded channel.
+	s.worker.msgChan.In() <- mqEvent{
+		key: mqv1.TopicPartitionKey{
+			Topic: topic, Partition: partition,
+		},
+		rowEvent: row,
+	}
+}
+
+// splitUpdate splits an update into a delete of the old values and an
+// insert of the new values.
+func splitUpdate(row *model.RowChangedEvent) (*model.RowChangedEvent, *model.RowChangedEvent) {
+	deleteRow, insertRow := *row, *row
+	deleteRow.Columns = nil
+	insertRow.PreColumns = nil
+	return &deleteRow, &insertRow
+}
+
+// joinCallback returns a callback which calls the given one when it has
+// been called by both of the split events.
+func joinCallback(callback eventsink.CallbackFunc) eventsink.CallbackFunc {
+	pending := atomic.NewInt32(2)
+	return func() {
+		if pending.Dec() == 0 {
+			callback()
+		}
+	}
+}
+
+// countTxnRows sets the row counts of the upstream transactions of the
+// events. The events of a flush of a table sink contain all the rows of their
+// transactions in the table, unless a large transaction is split into
+// several flushes, so the counts are approximate.
+func countTxnRows(rows []*eventsink.RowChangeCallbackableEvent) {
+	type txnKey struct{ startTs, commitTs model.Ts }
+	counts := make(map[txnKey]int)
+	for _, row := range rows {
+		counts[txnKey{row.Event.StartTs, row.Event.CommitTs}]++
+	}
+	for _, row := range rows {
+		row.TxnRowCount = counts[txnKey{row.Event.StartTs, row.Event.CommitTs}]
+	}
+}
+
+// warmUp fetches the metadata of the topic and connects to the leaders of
+// its partitions the first time the events are routed to it, so that the
+// producer doesn't have to do it when the messages are sent.
+func (s *dmlSink) warmUp(topic string) error {
+	if s.warmUpClient == nil {
+		return nil
+	}
+	if _, ok := s.warmedUp.Load(topic); ok {
+		return nil
+	}
+	s.warmUpMu.Lock()
+	defer s.warmUpMu.Unlock()
+	if _, ok := s.warmedUp.Load(topic); ok {
+		return nil
+	}
+	start := time.Now()
+	if err := s.warmUpClient.WarmUp([]string{topic}); err != nil {
+		return cerror.WrapError(cerror.ErrKafkaNewSaramaProducer, err)
+	}
+	s.warmedUp.Store(topic, struct{}{})
+	log.Info("Kafka topic warmed up",
+		zap.String("namespace", s.id.Namespace),
+		zap.String("changefeed", s.id.ID),
+		zap.String("topic", topic),
+		zap.Duration("duration", time.Since(start)))
+	return nil
+}
+
 // Close closes the sink.
 func (s *dmlSink) Close() error {
-	s.worker.close()
-	return nil
+	err := s.worker.close()
+	s.topicManager.Close()
+	return errors.Trace(err)
 }
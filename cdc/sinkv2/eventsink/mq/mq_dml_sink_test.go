@@ -21,13 +21,16 @@ import (
 	"time"
 
 	"github.com/Shopify/sarama"
+	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tiflow/cdc/model"
+	mqv1 "github.com/pingcap/tiflow/cdc/sink/mq"
 	"github.com/pingcap/tiflow/cdc/sinkv2/eventsink"
 	"github.com/pingcap/tiflow/cdc/sinkv2/eventsink/mq/dmlproducer"
 	"github.com/pingcap/tiflow/cdc/sinkv2/tablesink/state"
 	"github.com/pingcap/tiflow/pkg/config"
 	"github.com/pingcap/tiflow/pkg/sink/kafka"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/atomic"
 )
 
 func initBroker(t *testing.T, partitionNum int) (*sarama.MockBroker, string) {
@@ -124,3 +127,147 @@ func TestWriteEvents(t *testing.T) {
 	err = s.Close()
 	require.Nil(t, err)
 }
+
+func TestWriteEventsWarmUp(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	leader, topic := initBroker(t, kafka.DefaultMockPartitionNum)
+	defer leader.Close()
+	uriTemplate := "kafka://%s/%s?kafka-version=0.9.0.0&max-batch-size=1" +
+		"&max-message-bytes=1048576&partition-num=1" +
+		"&kafka-client-id=unit-test&auto-create-topic=true&compression=gzip" +
+		"&protocol=open-protocol&warm-up=true"
+	uri := fmt.Sprintf(uriTemplate, leader.Addr(), topic)
+
+	sinkURI, err := url.Parse(uri)
+	require.Nil(t, err)
+	replicaConfig := config.GetDefaultReplicaConfig()
+	require.Nil(t, replicaConfig.ValidateAndAdjust(sinkURI))
+	replicaConfig.Sink.DispatchRules = []*config.DispatchRule{
+		{
+			Matcher:   []string{"a.*"},
+			TopicRule: "{schema}_{table}",
+		},
+	}
+	errCh := make(chan error, 1)
+
+	s, err := NewKafkaDMLSink(ctx, sinkURI, replicaConfig, errCh,
+		kafka.NewMockAdminClient, kafka.NewMockClient, dmlproducer.NewDMLMockProducer)
+	require.Nil(t, err)
+	client, ok := s.warmUpClient.(*kafka.ClientMockImpl)
+	require.True(t, ok)
+	// The default topic is warmed up when the sink is created.
+	require.Equal(t, []string{topic}, client.WarmedUpTopics)
+
+	tableStatus := state.TableSinkSinking
+	events := make([]*eventsink.RowChangeCallbackableEvent, 0, 20)
+	for i := 0; i < 10; i++ {
+		for _, schema := range []string{"a", "b"} {
+			events = append(events, &eventsink.RowChangeCallbackableEvent{
+				Event: &model.RowChangedEvent{
+					CommitTs: 1,
+					Table:    &model.TableName{Schema: schema, Table: "b"},
+					Columns:  []*model.Column{{Name: "col1", Type: 1, Value: "aa"}},
+				},
+				Callback:  func() {},
+				SinkState: &tableStatus,
+			})
+		}
+	}
+
+	err = s.WriteEvents(events...)
+	require.Nil(t, err)
+	// Each topic is warmed up only once.
+	require.Equal(t, []string{topic, "a_b"}, client.WarmedUpTopics)
+	err = s.Close()
+	require.Nil(t, err)
+}
+
+func TestWriteEventsSplitUpdates(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	leader, topic := initBroker(t, kafka.DefaultMockPartitionNum)
+	defer leader.Close()
+	uriTemplate := "kafka://%s/%s?kafka-version=0.9.0.0&max-message-bytes=1048576" +
+		"&partition-num=3&auto-create-topic=false&protocol=canal-json"
+	uri := fmt.Sprintf(uriTemplate, leader.Addr(), topic)
+
+	sinkURI, err := url.Parse(uri)
+	require.Nil(t, err)
+	replicaConfig := config.GetDefaultReplicaConfig()
+	replicaConfig.Sink.DispatchRules = []*config.DispatchRule{
+		{Matcher: []string{"a.b"}, PartitionRule: "index-value"},
+	}
+	replicaConfig.Sink.UpdateKeyChange = config.UpdateKeyChangeSplit
+	require.Nil(t, replicaConfig.ValidateAndAdjust(sinkURI))
+	errCh := make(chan error, 1)
+
+	s, err := NewKafkaDMLSink(ctx, sinkURI, replicaConfig, errCh,
+		kafka.NewMockAdminClient, kafka.NewMockClient, dmlproducer.NewDMLMockProducer)
+	require.Nil(t, err)
+
+	// Find an update whose old and new values are dispatched to different
+	// partitions.
+	newColumns := func(id int64) []*model.Column {
+		return []*model.Column{{
+			Name: "id", Type: mysql.TypeLonglong, Value: id, Flag: model.HandleKeyFlag,
+		}}
+	}
+	row := &model.RowChangedEvent{
+		CommitTs:   1,
+		Table:      &model.TableName{Schema: "a", Table: "b"},
+		PreColumns: newColumns(1),
+	}
+	oldPartition := s.eventRouter.GetPartitionForRowChange(row, 3)
+	for id := int64(2); ; id++ {
+		row.Columns = newColumns(id)
+		if s.eventRouter.GetPartitionForRowChange(row, 3) != oldPartition {
+			break
+		}
+	}
+
+	tableStatus := state.TableSinkSinking
+	var called atomic.Int32
+	err = s.WriteEvents(&eventsink.RowChangeCallbackableEvent{
+		Event:     row,
+		Callback:  func() { called.Inc() },
+		SinkState: &tableStatus,
+	})
+	require.Nil(t, err)
+	require.Eventually(t, func() bool {
+		return called.Load() == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	producer := s.worker.producer.(*dmlproducer.MockDMLProducer)
+	require.Len(t, producer.GetAllEvents(), 2)
+	deleteMessages := producer.GetEvents(mqv1.TopicPartitionKey{
+		Topic: topic, Partition: oldPartition,
+	})
+	require.Len(t, deleteMessages, 1)
+	require.Contains(t, string(deleteMessages[0].Value), `"type":"DELETE"`)
+	require.Nil(t, s.Close())
+}
+
+func TestCountTxnRows(t *testing.T) {
+	t.Parallel()
+
+	newEvent := func(startTs, commitTs model.Ts) *eventsink.RowChangeCallbackableEvent {
+		return &eventsink.RowChangeCallbackableEvent{
+			Event: &model.RowChangedEvent{StartTs: startTs, CommitTs: commitTs},
+		}
+	}
+	rows := []*eventsink.RowChangeCallbackableEvent{
+		newEvent(1, 2), newEvent(1, 2), newEvent(3, 4), newEvent(1, 2), newEvent(2, 4),
+	}
+	countTxnRows(rows)
+	counts := make([]int, 0, len(rows))
+	for _, row := range rows {
+		counts = append(counts, row.TxnRowCount)
+	}
+	require.Equal(t, []int{3, 3, 1, 3, 1}, counts)
+}
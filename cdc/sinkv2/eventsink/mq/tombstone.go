@@ -0,0 +1,153 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mq
+
+import (
+	"time"
+
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sink/codec/common"
+	pkafka "github.com/pingcap/tiflow/pkg/sink/kafka"
+)
+
+// tombstoneCheckInterval is the interval to check the delayed tombstones.
+const tombstoneCheckInterval = 100 * time.Millisecond
+
+// tombstoneKey identifies the key of a message in a partition.
+type tombstoneKey struct {
+	topic     string
+	partition int32
+	key       string
+}
+
+// delayedTombstone is a tombstone waiting to be sent.
+type delayedTombstone struct {
+	key      tombstoneKey
+	message  *common.Message
+	deadline time.Time
+	// canceled is set if another message of the key is sent before the
+	// deadline, so that the tombstone doesn't remove the newer value.
+	canceled bool
+}
+
+// tombstoner emits the tombstones, i.e. the messages with null values, for
+// the delete events, so that the deleted keys are removed by the log
+// compaction of the topics. It works with the protocols encoding each row
+// into a keyed message only. The delete events are acknowledged after their
+// tombstones are sent, so the delayed tombstones kept in memory are emitted
+// again if the changefeed is restarted before they are sent.
+type tombstoner struct {
+	mode  string
+	delay time.Duration
+	// pending are the delayed tombstones in the order of the deadlines,
+	// which are the same as the order they are added in.
+	pending []*delayedTombstone
+	keys    map[tombstoneKey]*delayedTombstone
+}
+
+// newTombstoner creates a tombstoner, it returns nil if no tombstone is
+// emitted.
+func newTombstoner(mode string, delay time.Duration) *tombstoner {
+	if mode == "" || mode == pkafka.TombstoneNone {
+		return nil
+	}
+	return &tombstoner{
+		mode:  mode,
+		delay: delay,
+		keys:  make(map[tombstoneKey]*delayedTombstone),
+	}
+}
+
+// onMessage is called before the message of the rows is sent, it returns
+// the tombstone to be sent right after the message. The message is turned
+// into a tombstone itself in the replace mode.
+func (t *tombstoner) onMessage(
+	topic string, partition int32, message *common.Message, rows []*model.RowChangedEvent,
+) *common.Message {
+	if message.Key == nil {
+		return nil
+	}
+	key := tombstoneKey{topic: topic, partition: partition, key: string(message.Key)}
+	if pending, ok := t.keys[key]; ok {
+		pending.canceled = true
+		delete(t.keys, key)
+		// The events of the canceled tombstone are acknowledged along with
+		// the newer message of the key.
+		message.Callback = chainCallbacks(pending.message.Callback, message.Callback)
+		pending.message.Callback = nil
+	}
+	if len(rows) != 1 || !rows[0].IsDelete() {
+		return nil
+	}
+
+	if t.mode == pkafka.TombstoneReplace {
+		message.Value = nil
+		return nil
+	}
+	tombstone := &common.Message{
+		Key:      message.Key,
+		Ts:       message.Ts,
+		Schema:   message.Schema,
+		Table:    message.Table,
+		Type:     message.Type,
+		Protocol: message.Protocol,
+		// The delete event is acknowledged after the tombstone is sent.
+		Callback: message.Callback,
+	}
+	message.Callback = nil
+	if t.delay == 0 {
+		return tombstone
+	}
+	pending := &delayedTombstone{
+		key:      key,
+		message:  tombstone,
+		deadline: time.Now().Add(t.delay),
+	}
+	t.pending = append(t.pending, pending)
+	t.keys[key] = pending
+	return nil
+}
+
+// due returns the delayed tombstones whose deadlines are reached.
+func (t *tombstoner) due(now time.Time) []*delayedTombstone {
+	var result []*delayedTombstone
+	i := 0
+	for ; i < len(t.pending); i++ {
+		pending := t.pending[i]
+		if pending.deadline.After(now) {
+			break
+		}
+		if pending.canceled {
+			continue
+		}
+		delete(t.keys, pending.key)
+		result = append(result, pending)
+	}
+	t.pending = t.pending[i:]
+	return result
+}
+
+// chainCallbacks returns a callback calling first and then second.
+func chainCallbacks(first, second func()) func() {
+	if first == nil {
+		return second
+	}
+	if second == nil {
+		return first
+	}
+	return func() {
+		first()
+		second()
+	}
+}
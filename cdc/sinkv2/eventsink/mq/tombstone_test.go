@@ -0,0 +1,89 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mq
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sink/codec/common"
+	pkafka "github.com/pingcap/tiflow/pkg/sink/kafka"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTombstoner(t *testing.T) {
+	t.Parallel()
+
+	require.Nil(t, newTombstoner(pkafka.TombstoneNone, 0))
+
+	deleteRow := []*model.RowChangedEvent{{
+		CommitTs:   1,
+		PreColumns: []*model.Column{{Name: "id", Value: 1}},
+	}}
+	insertRow := []*model.RowChangedEvent{{
+		CommitTs: 2,
+		Columns:  []*model.Column{{Name: "id", Value: 1}},
+	}}
+	acked := 0
+	newMessage := func() *common.Message {
+		return &common.Message{
+			Key: []byte("k"), Value: []byte("v"), Ts: 1,
+			Callback: func() { acked++ },
+		}
+	}
+
+	// The tombstone is sent right after the message, and the delete event is
+	// acknowledged after the tombstone is sent.
+	ts := newTombstoner(pkafka.TombstoneAfterDelete, 0)
+	msg := newMessage()
+	tombstone := ts.onMessage("topic", 0, msg, deleteRow)
+	require.Equal(t, []byte("v"), msg.Value)
+	require.Nil(t, msg.Callback)
+	require.Equal(t, []byte("k"), tombstone.Key)
+	require.Nil(t, tombstone.Value)
+	tombstone.Callback()
+	require.Equal(t, 1, acked)
+	require.Nil(t, ts.onMessage("topic", 0, newMessage(), insertRow))
+	require.Nil(t, ts.onMessage("topic", 0, &common.Message{Value: []byte("v")}, deleteRow))
+
+	// The message is turned into a tombstone.
+	ts = newTombstoner(pkafka.TombstoneReplace, 0)
+	msg = newMessage()
+	require.Nil(t, ts.onMessage("topic", 0, msg, deleteRow))
+	require.Equal(t, []byte("k"), msg.Key)
+	require.Nil(t, msg.Value)
+
+	// The delayed tombstone is canceled by the newer message of the key,
+	// which acknowledges the delete event then.
+	acked = 0
+	ts = newTombstoner(pkafka.TombstoneAfterDelete, time.Minute)
+	require.Nil(t, ts.onMessage("topic", 0, newMessage(), deleteRow))
+	require.Nil(t, ts.onMessage("topic", 1, newMessage(), deleteRow))
+	require.Empty(t, ts.due(time.Now()))
+	msg = newMessage()
+	require.Nil(t, ts.onMessage("topic", 0, msg, insertRow))
+	msg.Callback()
+	require.Equal(t, 2, acked)
+	due := ts.due(time.Now().Add(time.Minute))
+	require.Len(t, due, 1)
+	require.Equal(t, int32(1), due[0].key.partition)
+	require.Nil(t, due[0].message.Value)
+	// The delete event isn't acknowledged until the delayed tombstone is sent.
+	require.Equal(t, 2, acked)
+	due[0].message.Callback()
+	require.Equal(t, 3, acked)
+	require.Empty(t, ts.pending)
+	require.Empty(t, ts.keys)
+}
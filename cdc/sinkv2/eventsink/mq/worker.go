@@ -21,9 +21,11 @@ import (
 	"github.com/pingcap/log"
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/cdc/sink/codec"
+	"github.com/pingcap/tiflow/cdc/sink/codec/common"
 	mqv1 "github.com/pingcap/tiflow/cdc/sink/mq"
 	"github.com/pingcap/tiflow/cdc/sinkv2/eventsink"
 	"github.com/pingcap/tiflow/cdc/sinkv2/eventsink/mq/dmlproducer"
+	"github.com/pingcap/tiflow/cdc/sinkv2/eventsink/tee"
 	"github.com/pingcap/tiflow/cdc/sinkv2/metrics"
 	"github.com/pingcap/tiflow/cdc/sinkv2/metrics/mq"
 	"github.com/pingcap/tiflow/cdc/sinkv2/tablesink/state"
@@ -74,6 +76,25 @@ type worker struct {
 	metricMQWorkerBatchDuration prometheus.Observer
 	// statistics is used to record DML metrics.
 	statistics *metrics.Statistics
+	// sequencer stamps the messages with sequence numbers,
+	// it is nil if the sequence numbers are disabled.
+	sequencer *common.Sequencer
+	// sinkStates are the states of the table sinks whose rows are sequenced
+	// by the table IDs. A table sink created again resumes from its
+	// checkpoint, so the sequence positions of its table are reset then.
+	sinkStates map[int64]*state.TableSinkState
+	// headers attaches the CDC metadata to the messages,
+	// it is nil if no metadata is attached.
+	headers *common.MetadataHeaders
+	// tombstones emits the tombstones for the delete events,
+	// it is nil if no tombstone is emitted.
+	tombstones *tombstoner
+	// transformer transforms the values of the rows before they're encoded,
+	// it is nil if no column transform is configured.
+	transformer *eventsink.ColumnTransformer
+	// archive archives the messages acknowledged by the downstream,
+	// it is nil if no tee sink is configured.
+	archive *tee.Archive
 }
 
 // newWorker creates a new flush worker.
@@ -155,7 +176,8 @@ func (w *worker) nonBatchEncodeRun(ctx context.Context) error {
 					zap.Any("event", event))
 				continue
 			}
-			if err := w.encoderGroup.AddEvents(ctx, event.key.Topic, event.key.Partition, event.rowEvent); err != nil {
+			if err := w.encoderGroup.AddEvents(ctx, event.key.Topic, event.key.Partition,
+				w.transform(event.rowEvent)); err != nil {
 				return errors.Trace(err)
 			}
 		}
@@ -256,14 +278,37 @@ func (w *worker) group(
 		if _, ok := partitionedRows[event.key]; !ok {
 			partitionedRows[event.key] = make([]*eventsink.RowChangeCallbackableEvent, 0)
 		}
-		partitionedRows[event.key] = append(partitionedRows[event.key], event.rowEvent)
+		partitionedRows[event.key] = append(partitionedRows[event.key], w.transform(event.rowEvent))
 	}
 	return partitionedRows
 }
 
+// transform returns the event with the transformed row. The event is copied
+// if the row is transformed, because it may be shared by the other sinks.
+func (w *worker) transform(
+	event *eventsink.RowChangeCallbackableEvent,
+) *eventsink.RowChangeCallbackableEvent {
+	if w.transformer == nil {
+		return event
+	}
+	row := w.transformer.Transform(event.Event)
+	if row == event.Event {
+		return event
+	}
+	transformed := *event
+	transformed.Event = row
+	return &transformed
+}
+
 func (w *worker) sendMessages(ctx context.Context) error {
 	inputCh := w.encoderGroup.Output()
 	ticker := time.NewTicker(15 * time.Second)
+	var tombstoneCh <-chan time.Time
+	if w.tombstones != nil && w.tombstones.delay > 0 {
+		tombstoneTicker := time.NewTicker(tombstoneCheckInterval)
+		defer tombstoneTicker.Stop()
+		tombstoneCh = tombstoneTicker.C
+	}
 	metric := codec.EncoderGroupOutputChanSizeGauge.
 		WithLabelValues(w.changeFeedID.Namespace, w.changeFeedID.ID)
 	defer func() {
@@ -277,6 +322,17 @@ func (w *worker) sendMessages(ctx context.Context) error {
 			return errors.Trace(ctx.Err())
 		case <-ticker.C:
 			metric.Set(float64(len(inputCh)))
+		case now := <-tombstoneCh:
+			for _, pending := range w.tombstones.due(now) {
+				if w.archive != nil {
+					w.archive.Track(pending.key.topic, pending.key.partition,
+						pending.message, pending.message.Ts, pending.message.Ts)
+				}
+				if err := w.sendMessage(ctx, pending.key.topic,
+					pending.key.partition, pending.message); err != nil {
+					return err
+				}
+			}
 		case future, ok := <-inputCh:
 			if !ok {
 				log.Warn("MQ sink encode output channel closed",
@@ -287,23 +343,122 @@ func (w *worker) sendMessages(ctx context.Context) error {
 			if err := future.Ready(ctx); err != nil {
 				return errors.Trace(err)
 			}
-			for _, message := range future.Messages {
-				start := time.Now()
-				if err := w.statistics.RecordBatchExecution(func() (int, error) {
-					if err := w.producer.AsyncSendMessage(ctx, future.Topic, future.Partition, message); err != nil {
-						return 0, err
+			var rows []*model.RowChangedEvent
+			txnRowCount := 0
+			var minCommitTs, commitTs uint64
+			for i, event := range future.Events() {
+				if i == 0 || event.Event.CommitTs < minCommitTs {
+					minCommitTs = event.Event.CommitTs
+				}
+				if event.Event.CommitTs > commitTs {
+					commitTs = event.Event.CommitTs
+				}
+			}
+			if w.headers != nil || w.tombstones != nil || w.sequencer != nil {
+				rows = make([]*model.RowChangedEvent, 0, len(future.Events()))
+				for i, event := range future.Events() {
+					rows = append(rows, event.Event)
+					if i == 0 {
+						txnRowCount = event.TxnRowCount
+					}
+					if w.sequencer != nil {
+						w.checkSinkState(event)
 					}
-					return message.GetRowsCount(), nil
-				}); err != nil {
+				}
+			}
+			var rowsOfMessages [][]*model.RowChangedEvent
+			if rows != nil {
+				rowsOfMessages = splitRowsByMessages(future.Messages, rows)
+			}
+			for i, message := range future.Messages {
+				var messageRows []*model.RowChangedEvent
+				if rowsOfMessages != nil {
+					messageRows = rowsOfMessages[i]
+				}
+				var tombstone *common.Message
+				if w.tombstones != nil {
+					tombstone = w.tombstones.onMessage(future.Topic, future.Partition, message, messageRows)
+				}
+				if w.headers != nil {
+					w.headers.StampRows(message, messageRows, txnRowCount)
+				}
+				if w.sequencer != nil {
+					w.sequencer.StampRows(message, future.Topic, future.Partition, messageRows)
+				}
+				if w.archive != nil {
+					w.archive.Track(future.Topic, future.Partition, message, minCommitTs, commitTs)
+				}
+				if err := w.sendMessage(ctx, future.Topic, future.Partition, message); err != nil {
 					return err
 				}
-				w.metricMQWorkerSendMessageDuration.Observe(time.Since(start).Seconds())
+				if tombstone != nil {
+					if w.archive != nil {
+						w.archive.Track(future.Topic, future.Partition,
+							tombstone, tombstone.Ts, tombstone.Ts)
+					}
+					if err := w.sendMessage(ctx, future.Topic, future.Partition, tombstone); err != nil {
+						return err
+					}
+				}
 			}
 		}
 	}
 }
 
-func (w *worker) close() {
+// checkSinkState resets the sequence positions of the table of the event if
+// it's from a new table sink.
+func (w *worker) checkSinkState(event *eventsink.RowChangeCallbackableEvent) {
+	if event.Event.Table == nil || event.SinkState == nil {
+		return
+	}
+	tableID := event.Event.Table.TableID
+	if last, ok := w.sinkStates[tableID]; ok && last != event.SinkState {
+		w.sequencer.Reset(tableID)
+	}
+	w.sinkStates[tableID] = event.SinkState
+}
+
+// splitRowsByMessages returns the rows encoded into each of the messages.
+// The rows are encoded in order, so they are split by the row counts of the
+// messages. nil is returned if the row counts don't add up to the rows, the
+// messages are treated as carrying unknown rows then.
+func splitRowsByMessages(
+	messages []*common.Message, rows []*model.RowChangedEvent,
+) [][]*model.RowChangedEvent {
+	total := 0
+	for _, message := range messages {
+		total += message.GetRowsCount()
+	}
+	if total != len(rows) {
+		return nil
+	}
+	result := make([][]*model.RowChangedEvent, 0, len(messages))
+	offset := 0
+	for _, message := range messages {
+		count := message.GetRowsCount()
+		result = append(result, rows[offset:offset+count])
+		offset += count
+	}
+	return result
+}
+
+func (w *worker) sendMessage(
+	ctx context.Context, topic string, partition int32, message *common.Message,
+) error {
+	start := time.Now()
+	if err := w.statistics.RecordBatchExecution(func() (int, error) {
+		if err := w.producer.AsyncSendMessage(ctx, topic, partition, message); err != nil {
+			return 0, err
+		}
+		return message.GetRowsCount(), nil
+	}); err != nil {
+		return err
+	}
+	w.metricMQWorkerSendMessageDuration.Observe(time.Since(start).Seconds())
+	return nil
+}
+
+func (w *worker) close() error {
 	w.msgChan.Close()
 	// We must finish consuming the data here,
 	// otherwise it will cause the channel to not close properly.
@@ -311,8 +466,13 @@ func (w *worker) close() {
 		// Do nothing. We do not care about the data.
 	}
 	w.producer.Close()
-
 	mq.WorkerSendMessageDuration.DeleteLabelValues(w.changeFeedID.Namespace, w.changeFeedID.ID)
 	mq.WorkerBatchSize.DeleteLabelValues(w.changeFeedID.Namespace, w.changeFeedID.ID)
 	mq.WorkerBatchDuration.DeleteLabelValues(w.changeFeedID.Namespace, w.changeFeedID.ID)
+	// The archive is closed after the producer, so that the messages
+	// acknowledged before the producer is closed are archived.
+	if w.archive != nil {
+		return w.archive.Close()
+	}
+	return nil
 }
@@ -19,6 +19,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/cdc/sink/codec/builder"
 	"github.com/pingcap/tiflow/cdc/sink/codec/common"
@@ -37,7 +38,7 @@ func newBatchEncodeWorker(ctx context.Context, t *testing.T) (*worker, dmlproduc
 	encoderConfig := common.NewConfig(config.ProtocolOpen).WithMaxMessageBytes(200)
 	builder, err := builder.NewEventBatchEncoderBuilder(context.Background(), encoderConfig)
 	require.Nil(t, err)
-	p, err := dmlproducer.NewDMLMockProducer(context.Background(), nil, nil, nil)
+	p, err := dmlproducer.NewDMLMockProducer(context.Background(), nil, nil, nil, nil)
 	require.Nil(t, err)
 	id := model.DefaultChangeFeedID("test")
 	encoderConcurrency := 4
@@ -50,7 +51,7 @@ func newNonBatchEncodeWorker(ctx context.Context, t *testing.T) (*worker, dmlpro
 	encoderConfig := common.NewConfig(config.ProtocolCanalJSON).WithMaxMessageBytes(200)
 	builder, err := builder.NewEventBatchEncoderBuilder(context.Background(), encoderConfig)
 	require.Nil(t, err)
-	p, err := dmlproducer.NewDMLMockProducer(context.Background(), nil, nil, nil)
+	p, err := dmlproducer.NewDMLMockProducer(context.Background(), nil, nil, nil, nil)
 	require.Nil(t, err)
 	id := model.DefaultChangeFeedID("test")
 	encoderConcurrency := 4
@@ -318,6 +319,43 @@ func TestBatchEncode_GroupWhenTableStopping(t *testing.T) {
 	)
 }
 
+func TestBatchEncode_GroupWithTransformer(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	worker, _ := newBatchEncodeWorker(ctx, t)
+	defer worker.close()
+	cfg := config.GetDefaultReplicaConfig()
+	cfg.Sink.ColumnTransforms = []*config.ColumnTransform{{
+		Matcher: []string{"a.b"},
+		Columns: []string{"col1"},
+		Type:    config.ColumnTransformFixed,
+		Value:   "masked",
+	}}
+	transformer, err := eventsink.NewColumnTransformer(cfg)
+	require.Nil(t, err)
+	worker.transformer = transformer
+
+	key := mqv1.TopicPartitionKey{Topic: "test", Partition: 1}
+	replicatingStatus := state.TableSinkSinking
+	event := &eventsink.RowChangeCallbackableEvent{
+		Event: &model.RowChangedEvent{
+			CommitTs: 1,
+			Table:    &model.TableName{Schema: "a", Table: "b"},
+			Columns:  []*model.Column{{Name: "col1", Type: mysql.TypeVarchar, Value: []byte("aa")}},
+		},
+		Callback:  func() {},
+		SinkState: &replicatingStatus,
+	}
+
+	partitionedRows := worker.group([]mqEvent{{rowEvent: event, key: key}})
+	require.Len(t, partitionedRows[key], 1)
+	require.Equal(t, []byte("masked"), partitionedRows[key][0].Event.Columns[0].Value)
+	// The event of the caller is not changed.
+	require.Equal(t, []byte("aa"), event.Event.Columns[0].Value)
+}
+
 func TestBatchEncode_SendMessages(t *testing.T) {
 	t.Parallel()
 
@@ -551,3 +589,23 @@ func TestNonBatchEncodeWorker_Abort(t *testing.T) {
 	cancel()
 	wg.Wait()
 }
+
+func TestSplitRowsByMessages(t *testing.T) {
+	t.Parallel()
+
+	rows := []*model.RowChangedEvent{{CommitTs: 1}, {CommitTs: 2}, {CommitTs: 3}}
+	newMessage := func(rowsCount int) *common.Message {
+		message := &common.Message{}
+		message.SetRowsCount(rowsCount)
+		return message
+	}
+
+	split := splitRowsByMessages([]*common.Message{newMessage(2), newMessage(1)}, rows)
+	require.Equal(t, [][]*model.RowChangedEvent{rows[:2], rows[2:]}, split)
+
+	split = splitRowsByMessages([]*common.Message{newMessage(1), newMessage(1), newMessage(1)}, rows)
+	require.Equal(t, [][]*model.RowChangedEvent{rows[:1], rows[1:2], rows[2:]}, split)
+
+	// The row counts don't add up to the rows.
+	require.Nil(t, splitRowsByMessages([]*common.Message{newMessage(1)}, rows))
+}
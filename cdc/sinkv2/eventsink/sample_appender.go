@@ -0,0 +1,110 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventsink
+
+import (
+	"hash/fnv"
+	"math"
+	"strconv"
+
+	"github.com/pingcap/tiflow/cdc/model"
+)
+
+// Assert Appender[E TableEvent] implementation
+var (
+	_ Appender[*model.RowChangedEvent] = (*SampleAppender[*model.RowChangedEvent])(nil)
+	_ Appender[*model.SingleTableTxn]  = (*SampleAppender[*model.SingleTableTxn])(nil)
+)
+
+// SampleAppender appends a deterministic sample of the rows by the wrapped
+// appender. A row is sampled by the hash of its table and handle key, so all
+// the changes of a sampled row are kept, and every capture samples the same
+// rows. An update is sampled if either its old key or its new key is, so the
+// updates changing the keys of the sampled rows are kept. The rows of the
+// tables without a handle key are never sampled, since their changes can't
+// be related to each other.
+type SampleAppender[E TableEvent] struct {
+	inner Appender[E]
+	// threshold is the upper bound of the hashes of the sampled rows.
+	threshold uint64
+}
+
+// NewSampleAppender creates a SampleAppender wrapping the appender, rate is
+// the fraction of the sampled rows in (0, 1].
+func NewSampleAppender[E TableEvent](inner Appender[E], rate float64) *SampleAppender[E] {
+	return &SampleAppender[E]{
+		inner:     inner,
+		threshold: uint64(rate * (math.MaxUint32 + 1)),
+	}
+}
+
+// Append appends the sampled rows to the buffer.
+func (a *SampleAppender[E]) Append(buffer []E, rows ...*model.RowChangedEvent) []E {
+	var sampled []*model.RowChangedEvent
+	for i, row := range rows {
+		if a.isSampled(row) {
+			if sampled != nil {
+				sampled = append(sampled, row)
+			}
+			continue
+		}
+		// Copy the rows lazily, the slice of the caller must not be changed.
+		if sampled == nil {
+			sampled = append(make([]*model.RowChangedEvent, 0, len(rows)), rows[:i]...)
+		}
+	}
+	if sampled != nil {
+		rows = sampled
+	}
+	return a.inner.Append(buffer, rows...)
+}
+
+func (a *SampleAppender[E]) isSampled(row *model.RowChangedEvent) bool {
+	keys := row.HandleKeyColumns()
+	if len(keys) == 0 {
+		return false
+	}
+	if uint64(sampleHash(row.Table, keys)) < a.threshold {
+		return true
+	}
+	if !row.IsUpdate() {
+		return false
+	}
+	oldKeys := make([]*model.Column, 0, len(keys))
+	for _, col := range row.PreColumns {
+		if col != nil && col.Flag.IsHandleKey() {
+			oldKeys = append(oldKeys, col)
+		}
+	}
+	return uint64(sampleHash(row.Table, oldKeys)) < a.threshold
+}
+
+// sampleHash returns the hash of the table and the key columns of a row, the
+// values are prefixed with their lengths to avoid the ambiguity.
+func sampleHash(table *model.TableName, keys []*model.Column) uint32 {
+	h := fnv.New32a()
+	write := func(s string) {
+		_, _ = h.Write([]byte(strconv.Itoa(len(s))))
+		_, _ = h.Write([]byte{':'})
+		_, _ = h.Write([]byte(s))
+	}
+	if table != nil {
+		write(table.Schema)
+		write(table.Table)
+	}
+	for _, col := range keys {
+		write(model.ColumnValueString(col.Value))
+	}
+	return h.Sum32()
+}
@@ -0,0 +1,84 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventsink
+
+import (
+	"testing"
+
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/stretchr/testify/require"
+)
+
+func newSampleTestRow(commitTs uint64, id int64, pre bool) *model.RowChangedEvent {
+	cols := []*model.Column{
+		{Name: "id", Value: id, Flag: model.HandleKeyFlag | model.PrimaryKeyFlag},
+		{Name: "v", Value: "a"},
+	}
+	row := &model.RowChangedEvent{
+		Table:    &model.TableName{Schema: "test", Table: "t1", TableID: 1},
+		StartTs:  commitTs - 1,
+		CommitTs: commitTs,
+	}
+	if pre {
+		row.PreColumns = cols
+	} else {
+		row.Columns = cols
+	}
+	return row
+}
+
+func TestSampleAppender(t *testing.T) {
+	t.Parallel()
+
+	appender := NewSampleAppender[*model.RowChangedEvent](&RowChangeEventAppender{}, 0.1)
+	inserts := make([]*model.RowChangedEvent, 0, 1000)
+	deletes := make([]*model.RowChangedEvent, 0, 1000)
+	for id := int64(0); id < 1000; id++ {
+		inserts = append(inserts, newSampleTestRow(10, id, false))
+		deletes = append(deletes, newSampleTestRow(20, id, true))
+	}
+	sampled := appender.Append(nil, inserts...)
+	require.Len(t, inserts, 1000)
+	require.Greater(t, len(sampled), 50)
+	require.Less(t, len(sampled), 150)
+
+	// The changes of the same keys are sampled, by any appender.
+	sampledDeletes := NewSampleAppender[*model.RowChangedEvent](&RowChangeEventAppender{}, 0.1).
+		Append(nil, deletes...)
+	require.Len(t, sampledDeletes, len(sampled))
+	for i := range sampled {
+		require.Equal(t, sampled[i].Columns[0].Value, sampledDeletes[i].PreColumns[0].Value)
+	}
+
+	// The updates changing the keys of the sampled rows are sampled.
+	sampledID := sampled[0].Columns[0].Value.(int64)
+	unsampledID := int64(0)
+	for appender.isSampled(newSampleTestRow(10, unsampledID, false)) {
+		unsampledID++
+	}
+	update := newSampleTestRow(30, unsampledID, false)
+	update.PreColumns = newSampleTestRow(30, sampledID, true).PreColumns
+	require.Len(t, appender.Append(nil, update), 1)
+	update.PreColumns = newSampleTestRow(30, unsampledID, true).PreColumns
+	require.Len(t, appender.Append(nil, update), 0)
+
+	// The rows of the tables without a handle key are never sampled.
+	keyless := newSampleTestRow(40, sampledID, false)
+	keyless.Columns[0].Flag = 0
+	require.Len(t, appender.Append(nil, keyless), 0)
+
+	// All the rows are sampled if the rate is 1.
+	appender = NewSampleAppender[*model.RowChangedEvent](&RowChangeEventAppender{}, 1)
+	require.Len(t, appender.Append(nil, inserts...), 1000)
+}
@@ -0,0 +1,477 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tee
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/tidb/br/pkg/storage"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sink/codec/common"
+	"github.com/pingcap/tiflow/pkg/config"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/util"
+	"go.uber.org/zap"
+)
+
+const (
+	// manifestFile describes the archive, it's in the root of the archive.
+	manifestFile = "manifest.json"
+	// checkpointFile is the progress of the files written by an archive,
+	// it's in the directory of the archive.
+	checkpointFile = "checkpoint.json"
+
+	// flushInterval is the interval to write the buffered messages, the
+	// checkpoint of the sink lags behind by about it.
+	flushInterval = time.Second
+	// fileSize is the size of the buffered messages to write them at once.
+	fileSize = 16 * 1024 * 1024
+	// defaultMaxBufferSize is the max size of the buffered messages, the
+	// messages are dropped and recorded in the checkpoint if the storage
+	// can't keep up with the sink.
+	defaultMaxBufferSize = 256 * 1024 * 1024
+	// closeTimeout is the timeout to write the remaining messages when the
+	// archive is closed.
+	closeTimeout = 10 * time.Second
+)
+
+// Manifest describes an archive. The archived messages are encoded by the
+// protocol of the sink, the archive can't be shared by the sinks of
+// different protocols.
+type Manifest struct {
+	Namespace  string `json:"namespace"`
+	Changefeed string `json:"changefeed"`
+	Protocol   string `json:"protocol"`
+}
+
+// Record is an archived message.
+type Record struct {
+	Topic     string `json:"topic"`
+	Partition int32  `json:"partition"`
+	// Broadcast means the message is sent to all the partitions of the topic.
+	Broadcast bool                   `json:"broadcast,omitempty"`
+	Type      model.MessageType      `json:"type"`
+	Key       []byte                 `json:"key,omitempty"`
+	Value     []byte                 `json:"value,omitempty"`
+	Headers   []common.MessageHeader `json:"headers,omitempty"`
+	// MinTs and MaxTs are the range of the commit ts of the events in the
+	// message, they're the same if the message carries a single event.
+	MinTs uint64 `json:"min-ts"`
+	MaxTs uint64 `json:"max-ts"`
+}
+
+func (r *Record) size() int {
+	size := len(r.Topic) + len(r.Key) + len(r.Value)
+	for _, header := range r.Headers {
+		size += len(header.Key) + len(header.Value)
+	}
+	return size
+}
+
+// TsRange is a range of commit ts.
+type TsRange struct {
+	MinTs    uint64 `json:"min-ts"`
+	MaxTs    uint64 `json:"max-ts"`
+	Messages int    `json:"messages"`
+}
+
+func (r *TsRange) add(record *Record) {
+	if r.Messages == 0 || record.MinTs < r.MinTs {
+		r.MinTs = record.MinTs
+	}
+	if record.MaxTs > r.MaxTs {
+		r.MaxTs = record.MaxTs
+	}
+	r.Messages++
+}
+
+func (r *TsRange) merge(other TsRange) {
+	if other.Messages == 0 {
+		return
+	}
+	if r.Messages == 0 || other.MinTs < r.MinTs {
+		r.MinTs = other.MinTs
+	}
+	if other.MaxTs > r.MaxTs {
+		r.MaxTs = other.MaxTs
+	}
+	r.Messages += other.Messages
+}
+
+// Checkpoint is the progress of the files written by an archive.
+type Checkpoint struct {
+	// Files is the number of the files written.
+	Files int `json:"files"`
+	// Archived is the range of the archived messages.
+	Archived TsRange `json:"archived"`
+	// Dropped are the messages which are not archived because the storage
+	// couldn't keep up with the sink.
+	Dropped []TsRange `json:"dropped,omitempty"`
+}
+
+// Archive stores the messages emitted by a sink to an external storage, so
+// that the exact stream can be replayed later. The messages are buffered
+// and written by the archive itself, and a message is only reported as
+// acknowledged to the sink once it's written, or once it's recorded in the
+// checkpoint of the archive as dropped because too many are buffered. So the
+// checkpoint of the changefeed never passes a message which is neither
+// archived nor recorded as a gap, and the messages buffered when a capture
+// crashes are emitted and archived again after it restarts.
+//
+// Each archive writes the files in its own directory, which are named by
+// {sequence}_{min-ts}_{max-ts}.json, each line of a file is a Record. The
+// directory is named by the sink, an archive resumes the sequence and the
+// checkpoint written to it before.
+type Archive struct {
+	changefeedID model.ChangeFeedID
+	storage      storage.ExternalStorage
+	dir          string
+	// maxBufferSize is the max size of the buffered messages.
+	maxBufferSize int
+
+	mu struct {
+		sync.Mutex
+		records []*Record
+		size    int
+		dropped TsRange
+		// callbacks report the buffered and the dropped messages as
+		// acknowledged to the sink.
+		callbacks []func()
+	}
+	flushCh chan struct{}
+	// checkpoint, dirty and acked are only accessed by the flushing
+	// goroutine, dirty means the checkpoint is changed but not written,
+	// acked are the callbacks of the messages which are written, they're
+	// called once the checkpoint is written.
+	checkpoint Checkpoint
+	dirty      bool
+	acked      []func()
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewArchive creates an archive in the external storage of the uri, name is
+// the directory of the archive, it must be unique among the sinks writing
+// to the storage at the same time and stable across their restarts.
+func NewArchive(
+	ctx context.Context, uri string, name string,
+	changefeedID model.ChangeFeedID, protocol config.Protocol,
+) (*Archive, error) {
+	s, err := util.GetExternalStorageFromURI(ctx, uri)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	manifest := &Manifest{
+		Namespace:  changefeedID.Namespace,
+		Changefeed: changefeedID.ID,
+		Protocol:   protocol.String(),
+	}
+	if err := writeManifest(ctx, s, manifest); err != nil {
+		return nil, err
+	}
+
+	a := &Archive{
+		changefeedID:  changefeedID,
+		storage:       s,
+		dir:           name,
+		maxBufferSize: defaultMaxBufferSize,
+		flushCh:       make(chan struct{}, 1),
+	}
+	if err := a.resume(ctx); err != nil {
+		return nil, err
+	}
+	ctx, a.cancel = context.WithCancel(ctx)
+	a.wg.Add(1)
+	go func() {
+		defer a.wg.Done()
+		a.run(ctx)
+	}()
+	log.Info("Tee archive created",
+		zap.String("namespace", changefeedID.Namespace),
+		zap.String("changefeed", changefeedID.ID),
+		zap.String("dir", a.dir),
+		zap.Int("files", a.checkpoint.Files))
+	return a, nil
+}
+
+// resume reads the checkpoint written to the directory of the archive
+// before. The sequence continues after the last file even if the checkpoint
+// wasn't written after it.
+func (a *Archive) resume(ctx context.Context) error {
+	name := a.dir + "/" + checkpointFile
+	exists, err := a.storage.FileExists(ctx, name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if exists {
+		data, err := a.storage.ReadFile(ctx, name)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if err := json.Unmarshal(data, &a.checkpoint); err != nil {
+			return errors.Annotatef(err, "decode the checkpoint %s", name)
+		}
+	}
+	dirs, err := listFiles(ctx, a.storage)
+	if err != nil {
+		return err
+	}
+	for _, file := range dirs[a.dir] {
+		if file.seq <= a.checkpoint.Files {
+			continue
+		}
+		records, err := readRecords(ctx, a.storage, file.name)
+		if err != nil {
+			return err
+		}
+		for _, record := range records {
+			a.checkpoint.Archived.add(record)
+		}
+		a.checkpoint.Files = file.seq
+		a.dirty = true
+	}
+	return nil
+}
+
+// writeManifest writes the manifest of the archive if it doesn't exist, or
+// checks that the existing one is written by the same protocol.
+func writeManifest(ctx context.Context, s storage.ExternalStorage, manifest *Manifest) error {
+	exists, err := s.FileExists(ctx, manifestFile)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if exists {
+		existing, err := ReadManifest(ctx, s)
+		if err != nil {
+			return err
+		}
+		if existing.Protocol != manifest.Protocol {
+			return cerror.ErrSinkInvalidConfig.GenWithStack(
+				"the archive is written by the protocol %s, but the sink uses %s",
+				existing.Protocol, manifest.Protocol)
+		}
+		return nil
+	}
+	data, err := json.Marshal(manifest)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(s.WriteFile(ctx, manifestFile, data))
+}
+
+// ReadManifest reads the manifest of an archive.
+func ReadManifest(ctx context.Context, s storage.ExternalStorage) (*Manifest, error) {
+	data, err := s.ReadFile(ctx, manifestFile)
+	if err != nil {
+		return nil, errors.Annotate(err, "read the manifest of the archive")
+	}
+	manifest := &Manifest{}
+	if err := json.Unmarshal(data, manifest); err != nil {
+		return nil, errors.Annotate(err, "decode the manifest of the archive")
+	}
+	return manifest, nil
+}
+
+// Track archives the message once it's acknowledged by the downstream, the
+// events in the message are committed in [minTs, maxTs]. The callback of
+// the message is called once it's archived. It must be called before the
+// message is sent.
+func (a *Archive) Track(
+	topic string, partition int32, message *common.Message, minTs, maxTs uint64,
+) {
+	record := newRecord(topic, partition, message, minTs, maxTs)
+	callback := message.Callback
+	message.Callback = func() {
+		a.append(record, callback)
+	}
+}
+
+// Append archives a message which has been acknowledged by the downstream,
+// the partition is ignored if the message is broadcast. It returns once the
+// message is archived.
+func (a *Archive) Append(
+	ctx context.Context, topic string, partition int32, broadcast bool,
+	message *common.Message,
+) error {
+	record := newRecord(topic, partition, message, message.Ts, message.Ts)
+	record.Broadcast = broadcast
+	done := make(chan struct{})
+	a.append(record, func() { close(done) })
+	a.notifyFlush()
+	select {
+	case <-ctx.Done():
+		return errors.Trace(ctx.Err())
+	case <-done:
+		return nil
+	}
+}
+
+func newRecord(
+	topic string, partition int32, message *common.Message, minTs, maxTs uint64,
+) *Record {
+	return &Record{
+		Topic:     topic,
+		Partition: partition,
+		Type:      message.Type,
+		Key:       message.Key,
+		Value:     message.Value,
+		Headers:   message.Headers,
+		MinTs:     minTs,
+		MaxTs:     maxTs,
+	}
+}
+
+func (a *Archive) append(record *Record, callback func()) {
+	size := record.size()
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if callback != nil {
+		a.mu.callbacks = append(a.mu.callbacks, callback)
+	}
+	if a.mu.size+size > a.maxBufferSize {
+		a.mu.dropped.add(record)
+		return
+	}
+	a.mu.records = append(a.mu.records, record)
+	a.mu.size += size
+	if a.mu.size >= fileSize {
+		a.notifyFlush()
+	}
+}
+
+func (a *Archive) notifyFlush() {
+	select {
+	case a.flushCh <- struct{}{}:
+	default:
+	}
+}
+
+func (a *Archive) run(ctx context.Context) {
+	ticker := time.NewTicker(flushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-a.flushCh:
+		}
+		if err := a.flush(ctx); err != nil {
+			log.Warn("Failed to write the tee archive, retry later",
+				zap.String("namespace", a.changefeedID.Namespace),
+				zap.String("changefeed", a.changefeedID.ID),
+				zap.String("dir", a.dir),
+				zap.Error(err))
+		}
+	}
+}
+
+// flush writes the buffered messages to a file and updates the checkpoint,
+// then the messages are reported as acknowledged. The messages are put back
+// to the buffer if they can't be written.
+func (a *Archive) flush(ctx context.Context) error {
+	a.mu.Lock()
+	records, size, dropped := a.mu.records, a.mu.size, a.mu.dropped
+	callbacks := a.mu.callbacks
+	a.mu.records, a.mu.size, a.mu.dropped = nil, 0, TsRange{}
+	a.mu.callbacks = nil
+	a.mu.Unlock()
+	if len(records) == 0 && dropped.Messages == 0 && len(callbacks) == 0 && !a.dirty {
+		return nil
+	}
+
+	if len(records) > 0 {
+		var buf bytes.Buffer
+		var archived TsRange
+		encoder := json.NewEncoder(&buf)
+		for _, record := range records {
+			if err := encoder.Encode(record); err != nil {
+				a.requeue(records, size, dropped, callbacks)
+				return errors.Trace(err)
+			}
+			archived.add(record)
+		}
+		name := fmt.Sprintf("%s/%06d_%d_%d.json",
+			a.dir, a.checkpoint.Files+1, archived.MinTs, archived.MaxTs)
+		if err := a.storage.WriteFile(ctx, name, buf.Bytes()); err != nil {
+			a.requeue(records, size, dropped, callbacks)
+			return errors.Trace(err)
+		}
+		a.checkpoint.Files++
+		a.checkpoint.Archived.merge(archived)
+	}
+	if dropped.Messages > 0 {
+		log.Warn("The messages are dropped by the tee archive, "+
+			"since the storage can't keep up with the sink",
+			zap.String("namespace", a.changefeedID.Namespace),
+			zap.String("changefeed", a.changefeedID.ID),
+			zap.Uint64("minTs", dropped.MinTs),
+			zap.Uint64("maxTs", dropped.MaxTs),
+			zap.Int("messages", dropped.Messages))
+		a.checkpoint.Dropped = append(a.checkpoint.Dropped, dropped)
+	}
+
+	// The checkpoint is written again by the next flush if it fails, and the
+	// messages are reported as acknowledged after that, since the dropped
+	// ones are only recorded in the checkpoint.
+	a.dirty = true
+	a.acked = append(a.acked, callbacks...)
+	data, err := json.Marshal(&a.checkpoint)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := a.storage.WriteFile(ctx, a.dir+"/"+checkpointFile, data); err != nil {
+		return errors.Trace(err)
+	}
+	a.dirty = false
+	for _, callback := range a.acked {
+		callback()
+	}
+	a.acked = nil
+	return nil
+}
+
+// requeue puts the messages failed to be written back to the buffer.
+func (a *Archive) requeue(
+	records []*Record, size int, dropped TsRange, callbacks []func(),
+) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.mu.records = append(records, a.mu.records...)
+	a.mu.size += size
+	a.mu.dropped.merge(dropped)
+	a.mu.callbacks = append(callbacks, a.mu.callbacks...)
+}
+
+// Close writes the remaining messages and stops the archive. The messages
+// which can't be written are not reported as acknowledged, so they're
+// emitted and archived again after the sink restarts.
+func (a *Archive) Close() error {
+	a.cancel()
+	a.wg.Wait()
+	ctx, cancel := context.WithTimeout(context.Background(), closeTimeout)
+	defer cancel()
+	if err := a.flush(ctx); err != nil {
+		return errors.Annotatef(err, "write the remaining messages to the tee archive %s", a.dir)
+	}
+	return nil
+}
@@ -0,0 +1,200 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tee
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/br/pkg/storage"
+)
+
+// archiveFile is a file written by an archive.
+type archiveFile struct {
+	name  string
+	seq   int
+	minTs uint64
+	maxTs uint64
+}
+
+// cursor iterates the records of the files written by an archive, the files
+// are read one by one.
+type cursor struct {
+	files   []archiveFile
+	records []*Record
+}
+
+func (c *cursor) peek(ctx context.Context, s storage.ExternalStorage) (*Record, error) {
+	for len(c.records) == 0 {
+		if len(c.files) == 0 {
+			return nil, nil
+		}
+		records, err := readRecords(ctx, s, c.files[0].name)
+		if err != nil {
+			return nil, err
+		}
+		c.files, c.records = c.files[1:], records
+	}
+	return c.records[0], nil
+}
+
+func readRecords(ctx context.Context, s storage.ExternalStorage, name string) ([]*Record, error) {
+	data, err := s.ReadFile(ctx, name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var records []*Record
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(nil, len(data)+1)
+	for scanner.Scan() {
+		record := &Record{}
+		if err := json.Unmarshal(scanner.Bytes(), record); err != nil {
+			return nil, errors.Annotatef(err, "decode the archived message in %s", name)
+		}
+		records = append(records, record)
+	}
+	return records, errors.Trace(scanner.Err())
+}
+
+// listFiles lists the files written by the archives, grouped by the
+// directories of the archives and sorted by the sequence.
+func listFiles(ctx context.Context, s storage.ExternalStorage) (map[string][]archiveFile, error) {
+	dirs := make(map[string][]archiveFile)
+	err := s.WalkDir(ctx, &storage.WalkOption{}, func(name string, _ int64) error {
+		dir, base := path.Split(name)
+		dir = strings.TrimSuffix(dir, "/")
+		if dir == "" {
+			// The manifest of the archive.
+			return nil
+		}
+		if base == checkpointFile {
+			if _, ok := dirs[dir]; !ok {
+				dirs[dir] = nil
+			}
+			return nil
+		}
+		file := archiveFile{name: name}
+		if _, err := fmt.Sscanf(strings.TrimSuffix(base, ".json"), "%d_%d_%d",
+			&file.seq, &file.minTs, &file.maxTs); err != nil {
+			return errors.Annotatef(err, "unexpected file %s in the archive", name)
+		}
+		dirs[dir] = append(dirs[dir], file)
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, files := range dirs {
+		sort.Slice(files, func(i, j int) bool { return files[i].seq < files[j].seq })
+	}
+	return dirs, nil
+}
+
+// Replay emits the archived messages which carry the events committed in
+// [fromTs, toTs]. The messages written by an archive are emitted in the
+// order they were acknowledged, and the messages of different archives are
+// merged by their commit ts. Only one file of each archive is kept in
+// memory at a time.
+func Replay(
+	ctx context.Context, s storage.ExternalStorage,
+	fromTs, toTs uint64, emit func(*Record) error,
+) error {
+	dirs, err := listFiles(ctx, s)
+	if err != nil {
+		return err
+	}
+	names := make([]string, 0, len(dirs))
+	for dir := range dirs {
+		names = append(names, dir)
+	}
+	sort.Strings(names)
+	cursors := make([]*cursor, 0, len(names))
+	for _, dir := range names {
+		c := &cursor{}
+		for _, file := range dirs[dir] {
+			if file.maxTs >= fromTs && file.minTs <= toTs {
+				c.files = append(c.files, file)
+			}
+		}
+		cursors = append(cursors, c)
+	}
+
+	for {
+		var next *cursor
+		var record *Record
+		for _, c := range cursors {
+			head, err := c.peek(ctx, s)
+			if err != nil {
+				return err
+			}
+			if head != nil && (record == nil || head.MinTs < record.MinTs) {
+				next, record = c, head
+			}
+		}
+		if next == nil {
+			return nil
+		}
+		next.records = next.records[1:]
+		if record.MaxTs < fromTs || record.MinTs > toTs {
+			continue
+		}
+		if err := emit(record); err != nil {
+			return errors.Trace(err)
+		}
+	}
+}
+
+// Dropped returns the ranges of the messages which are not archived and
+// overlap [fromTs, toTs].
+func Dropped(
+	ctx context.Context, s storage.ExternalStorage, fromTs, toTs uint64,
+) ([]TsRange, error) {
+	dirs, err := listFiles(ctx, s)
+	if err != nil {
+		return nil, err
+	}
+	var dropped []TsRange
+	for dir := range dirs {
+		name := dir + "/" + checkpointFile
+		exists, err := s.FileExists(ctx, name)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if !exists {
+			continue
+		}
+		data, err := s.ReadFile(ctx, name)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		checkpoint := &Checkpoint{}
+		if err := json.Unmarshal(data, checkpoint); err != nil {
+			return nil, errors.Annotatef(err, "decode the checkpoint %s", name)
+		}
+		for _, r := range checkpoint.Dropped {
+			if r.MaxTs >= fromTs && r.MinTs <= toTs {
+				dropped = append(dropped, r)
+			}
+		}
+	}
+	sort.Slice(dropped, func(i, j int) bool { return dropped[i].MinTs < dropped[j].MinTs })
+	return dropped, nil
+}
@@ -0,0 +1,208 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tee
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sink/codec/common"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/pingcap/tiflow/pkg/util"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/atomic"
+)
+
+func replayAll(t *testing.T, uri string, fromTs, toTs uint64) []*Record {
+	ctx := context.Background()
+	s, err := util.GetExternalStorageFromURI(ctx, uri)
+	require.Nil(t, err)
+	var records []*Record
+	err = Replay(ctx, s, fromTs, toTs, func(record *Record) error {
+		records = append(records, record)
+		return nil
+	})
+	require.Nil(t, err)
+	return records
+}
+
+func TestArchiveTrack(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	uri := "file://" + t.TempDir()
+	changefeedID := model.DefaultChangeFeedID("test")
+	dml, err := NewArchive(ctx, uri, "dml", changefeedID, config.ProtocolAvro)
+	require.Nil(t, err)
+	ddl, err := NewArchive(ctx, uri, "ddl", changefeedID, config.ProtocolAvro)
+	require.Nil(t, err)
+
+	var acked atomic.Int32
+	messages := make([]*common.Message, 0, 3)
+	for i := 0; i < 3; i++ {
+		message := &common.Message{
+			Key:      []byte{byte(i)},
+			Value:    []byte("value"),
+			Type:     model.MessageTypeRow,
+			Headers:  []common.MessageHeader{{Key: "h", Value: []byte("v")}},
+			Callback: func() { acked.Inc() },
+		}
+		dml.Track("topic", int32(i), message, uint64(i*10+1), uint64(i*10+5))
+		messages = append(messages, message)
+	}
+	// Only the acknowledged messages are archived, and they're reported as
+	// acknowledged once they're archived.
+	messages[0].Callback()
+	messages[2].Callback()
+	require.Eventually(t, func() bool {
+		return acked.Load() == 2
+	}, 5*time.Second, 10*time.Millisecond)
+	err = ddl.Append(ctx, "topic", 0, true, &common.Message{
+		Value: []byte("ddl"),
+		Ts:    12,
+		Type:  model.MessageTypeDDL,
+	})
+	require.Nil(t, err)
+	require.Nil(t, dml.Close())
+	require.Nil(t, ddl.Close())
+
+	records := replayAll(t, uri, 0, 100)
+	require.Len(t, records, 3)
+	require.Equal(t, []byte{0}, records[0].Key)
+	require.Equal(t, int32(0), records[0].Partition)
+	require.Equal(t, []common.MessageHeader{{Key: "h", Value: []byte("v")}},
+		records[0].Headers)
+	require.Equal(t, model.MessageTypeDDL, records[1].Type)
+	require.True(t, records[1].Broadcast)
+	require.Equal(t, []byte{2}, records[2].Key)
+	require.Equal(t, uint64(21), records[2].MinTs)
+	require.Equal(t, uint64(25), records[2].MaxTs)
+
+	// The messages overlapping the range are replayed.
+	records = replayAll(t, uri, 13, 22)
+	require.Len(t, records, 1)
+	require.Equal(t, []byte{2}, records[0].Key)
+}
+
+func TestArchiveProtocolMismatch(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	uri := "file://" + t.TempDir()
+	changefeedID := model.DefaultChangeFeedID("test")
+	a, err := NewArchive(ctx, uri, "dml", changefeedID, config.ProtocolAvro)
+	require.Nil(t, err)
+	require.Nil(t, a.Close())
+
+	_, err = NewArchive(ctx, uri, "dml", changefeedID, config.ProtocolCanalJSON)
+	require.ErrorContains(t, err, "the archive is written by the protocol avro")
+
+	s, err := util.GetExternalStorageFromURI(ctx, uri)
+	require.Nil(t, err)
+	manifest, err := ReadManifest(ctx, s)
+	require.Nil(t, err)
+	require.Equal(t, &Manifest{
+		Namespace:  model.DefaultNamespace,
+		Changefeed: "test",
+		Protocol:   "avro",
+	}, manifest)
+}
+
+func TestArchiveDropped(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	uri := "file://" + t.TempDir()
+	a, err := NewArchive(ctx, uri, "dml",
+		model.DefaultChangeFeedID("test"), config.ProtocolOpen)
+	require.Nil(t, err)
+	a.maxBufferSize = 12
+
+	var acked atomic.Int32
+	for i := 0; i < 3; i++ {
+		message := &common.Message{
+			Value:    []byte("value"),
+			Ts:       uint64(i + 1),
+			Type:     model.MessageTypeRow,
+			Callback: func() { acked.Inc() },
+		}
+		a.Track("t", 0, message, message.Ts, message.Ts)
+		message.Callback()
+	}
+	require.Nil(t, a.Close())
+	// The dropped message is acknowledged once it's recorded.
+	require.Equal(t, int32(3), acked.Load())
+
+	records := replayAll(t, uri, 0, 100)
+	require.Len(t, records, 2)
+
+	s, err := util.GetExternalStorageFromURI(ctx, uri)
+	require.Nil(t, err)
+	dropped, err := Dropped(ctx, s, 0, 100)
+	require.Nil(t, err)
+	require.Equal(t, []TsRange{{MinTs: 3, MaxTs: 3, Messages: 1}}, dropped)
+	dropped, err = Dropped(ctx, s, 4, 100)
+	require.Nil(t, err)
+	require.Empty(t, dropped)
+}
+
+func TestArchiveResume(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	uri := "file://" + t.TempDir()
+	changefeedID := model.DefaultChangeFeedID("test")
+	s, err := util.GetExternalStorageFromURI(ctx, uri)
+	require.Nil(t, err)
+
+	appendDDL := func(ts uint64) {
+		a, err := NewArchive(ctx, uri, "ddl", changefeedID, config.ProtocolOpen)
+		require.Nil(t, err)
+		err = a.Append(ctx, "t", 0, true, &common.Message{
+			Value: []byte("ddl"),
+			Ts:    ts,
+			Type:  model.MessageTypeDDL,
+		})
+		require.Nil(t, err)
+		require.Nil(t, a.Close())
+	}
+	appendDDL(1)
+	appendDDL(2)
+	// The sequence continues after the last file even if the checkpoint
+	// isn't written after it.
+	require.Nil(t, s.DeleteFile(ctx, "ddl/"+checkpointFile))
+	appendDDL(3)
+
+	dirs, err := listFiles(ctx, s)
+	require.Nil(t, err)
+	require.Len(t, dirs, 1)
+	require.Len(t, dirs["ddl"], 3)
+	for i, file := range dirs["ddl"] {
+		require.Equal(t, i+1, file.seq)
+	}
+	records := replayAll(t, uri, 0, 100)
+	require.Len(t, records, 3)
+
+	data, err := s.ReadFile(ctx, "ddl/"+checkpointFile)
+	require.Nil(t, err)
+	checkpoint := &Checkpoint{}
+	require.Nil(t, json.Unmarshal(data, checkpoint))
+	require.Equal(t, Checkpoint{
+		Files:    3,
+		Archived: TsRange{MinTs: 1, MaxTs: 3, Messages: 3},
+	}, *checkpoint)
+}
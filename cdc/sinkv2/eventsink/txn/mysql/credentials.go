@@ -0,0 +1,117 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"net/url"
+	"sync/atomic"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/config"
+	pmysql "github.com/pingcap/tiflow/pkg/sink/mysql"
+	"go.uber.org/zap"
+)
+
+// sharedDB is the connection pool shared by the backends, it's closed after
+// all the backends release it.
+type sharedDB struct {
+	db   *sql.DB
+	refs int32
+}
+
+func newSharedDB(db *sql.DB, refs int) *sharedDB {
+	return &sharedDB{db: db, refs: int32(refs)}
+}
+
+func (d *sharedDB) release() error {
+	if atomic.AddInt32(&d.refs, -1) == 0 {
+		return errors.Trace(d.db.Close())
+	}
+	return nil
+}
+
+// UpdateCredentials re-establishes the connections of the backends with the
+// credentials in the sink URI. Each backend switches to the new connections
+// before its next flush, i.e. at the boundary of the transactions, and the
+// old connections are closed after all the backends switch.
+func UpdateCredentials(
+	ctx context.Context,
+	changefeedID model.ChangeFeedID,
+	sinkURI *url.URL,
+	replicaConfig *config.ReplicaConfig,
+	dbConnFactory pmysql.Factory,
+	backends []*mysqlBackend,
+) error {
+	if len(backends) == 0 {
+		return nil
+	}
+
+	cfg := pmysql.NewConfig()
+	err := cfg.Apply(ctx, changefeedID, sinkURI, replicaConfig)
+	if err != nil {
+		return err
+	}
+	dsnStr, err := pmysql.GenerateDSN(ctx, sinkURI, cfg, dbConnFactory)
+	if err != nil {
+		return err
+	}
+	db, err := dbConnFactory(ctx, dsnStr)
+	if err != nil {
+		return err
+	}
+	db.SetMaxIdleConns(len(backends))
+	db.SetMaxOpenConns(len(backends))
+
+	shared := newSharedDB(db, len(backends))
+	for _, backend := range backends {
+		// The connections of the previous update are not used by the
+		// backend yet, they are released directly.
+		if prev := backend.pendingDB.Swap(shared); prev != nil {
+			if err := prev.release(); err != nil {
+				log.Warn("failed to close the unused connections",
+					zap.String("changefeed", backend.changefeed), zap.Error(err))
+			}
+		}
+	}
+	log.Info("MySQL backends will switch to the connections with the updated credentials",
+		zap.String("namespace", changefeedID.Namespace),
+		zap.String("changefeed", changefeedID.ID),
+		zap.Int("workerCount", len(backends)))
+	return nil
+}
+
+// switchDB switches to the connections with the updated credentials if
+// there are. It's called before a flush, so no transaction is in flight.
+func (s *mysqlBackend) switchDB() {
+	next := s.pendingDB.Swap(nil)
+	if next == nil {
+		return
+	}
+	if s.sharedDB != nil {
+		if err := s.sharedDB.release(); err != nil {
+			log.Warn("failed to close the connections with the old credentials",
+				zap.Int("workerID", s.workerID),
+				zap.String("changefeed", s.changefeed), zap.Error(err))
+		}
+	}
+	s.sharedDB = next
+	s.db = next.db
+	log.Info("MySQL backend switched to the connections with the updated credentials",
+		zap.Int("workerID", s.workerID),
+		zap.String("changefeed", s.changefeed))
+}
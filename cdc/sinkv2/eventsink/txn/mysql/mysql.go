@@ -19,6 +19,7 @@ import (
 	"database/sql/driver"
 	"fmt"
 	"net/url"
+	"sync/atomic"
 	"time"
 
 	dmysql "github.com/go-sql-driver/mysql"
@@ -38,6 +39,7 @@ import (
 	"github.com/pingcap/tiflow/pkg/retry"
 	pmysql "github.com/pingcap/tiflow/pkg/sink/mysql"
 	"github.com/pingcap/tiflow/pkg/sqlmodel"
+	"github.com/pingcap/tiflow/pkg/warning"
 	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/zap"
 )
@@ -53,11 +55,17 @@ const (
 )
 
 type mysqlBackend struct {
-	workerID    int
-	changefeed  string
-	db          *sql.DB
-	cfg         *pmysql.Config
-	dmlMaxRetry uint64
+	workerID     int
+	changefeed   string
+	changefeedID model.ChangeFeedID
+	db           *sql.DB
+	cfg          *pmysql.Config
+	dmlMaxRetry  uint64
+	// sharedDB holds the db, which is shared by the backends.
+	sharedDB *sharedDB
+	// pendingDB is the connections with the updated credentials,
+	// it is switched to before the next flush.
+	pendingDB atomic.Pointer[sharedDB]
 
 	events []*eventsink.TxnCallbackableEvent
 	rows   int
@@ -102,15 +110,18 @@ func NewMySQLBackends(
 	db.SetMaxIdleConns(cfg.WorkerCount)
 	db.SetMaxOpenConns(cfg.WorkerCount)
 
+	shared := newSharedDB(db, cfg.WorkerCount)
 	backends := make([]*mysqlBackend, 0, cfg.WorkerCount)
 	for i := 0; i < cfg.WorkerCount; i++ {
 		backends = append(backends, &mysqlBackend{
-			workerID:    i,
-			changefeed:  changefeed,
-			db:          db,
-			cfg:         cfg,
-			dmlMaxRetry: defaultDMLMaxRetry,
-			statistics:  statistics,
+			workerID:     i,
+			changefeed:   changefeed,
+			changefeedID: changefeedID,
+			db:           db,
+			cfg:          cfg,
+			dmlMaxRetry:  defaultDMLMaxRetry,
+			sharedDB:     shared,
+			statistics:   statistics,
 
 			metricTxnSinkDMLBatchCommit:   txn.SinkDMLBatchCommit.WithLabelValues(changefeedID.Namespace, changefeedID.ID),
 			metricTxnSinkDMLBatchCallback: txn.SinkDMLBatchCallback.WithLabelValues(changefeedID.Namespace, changefeedID.ID),
@@ -135,6 +146,7 @@ func (s *mysqlBackend) OnTxnEvent(event *eventsink.TxnCallbackableEvent) (needFl
 
 // Flush implements interface backend.
 func (s *mysqlBackend) Flush(ctx context.Context) (err error) {
+	s.switchDB()
 	if s.rows == 0 {
 		return
 	}
@@ -165,6 +177,13 @@ func (s *mysqlBackend) Flush(ctx context.Context) (err error) {
 	for _, callback := range dmls.callbacks {
 		callback()
 	}
+	for _, event := range s.events {
+		if event.Event.Table == nil {
+			continue
+		}
+		s.statistics.ObserveEventAge(config.MetricLabelTable,
+			event.Event.Table.String(), event.Event.CommitTs)
+	}
 	s.metricTxnSinkDMLBatchCommit.Observe(startCallback.Sub(start).Seconds())
 	s.metricTxnSinkDMLBatchCallback.Observe(time.Since(startCallback).Seconds())
 
@@ -182,8 +201,12 @@ func (s *mysqlBackend) Flush(ctx context.Context) (err error) {
 
 // Close implements interface backend.
 func (s *mysqlBackend) Close() (err error) {
-	if s.db != nil {
-		err = s.db.Close()
+	if pending := s.pendingDB.Swap(nil); pending != nil {
+		err = pending.release()
+	}
+	if s.sharedDB != nil {
+		err = s.sharedDB.release()
+		s.sharedDB = nil
 		s.db = nil
 	}
 	return
@@ -454,6 +477,11 @@ func (s *mysqlBackend) prepareDMLs() *preparedDMLs {
 		// A row can be translated in to INSERT, when it was committed after
 		// the table it belongs to been replicating by TiCDC, which means it must not be
 		// replicated before, and there is no such row in downstream MySQL.
+		if translateToInsert && firstRow.CommitTs <= firstRow.ReplicatingTs {
+			warning.Record(s.changefeedID, cerror.ErrMySQLSafeMode,
+				fmt.Sprintf("table %s, commitTs %d, replicatingTs %d",
+					firstRow.Table, firstRow.CommitTs, firstRow.ReplicatingTs))
+		}
 		translateToInsert = translateToInsert && firstRow.CommitTs > firstRow.ReplicatingTs
 		log.Debug("translate to insert",
 			zap.Bool("translateToInsert", translateToInsert),
@@ -564,7 +592,7 @@ func (s *mysqlBackend) execDMLWithMaxRetries(pctx context.Context, dmls *prepare
 	}
 
 	start := time.Now()
-	return retry.Do(pctx, func() error {
+	op := func() error {
 		writeTimeout, _ := time.ParseDuration(s.cfg.WriteTimeout)
 		writeTimeout += networkDriftDuration
 
@@ -637,10 +665,14 @@ func (s *mysqlBackend) execDMLWithMaxRetries(pctx context.Context, dmls *prepare
 			zap.String("changefeed", s.changefeed),
 			zap.Int("numOfRows", dmls.rowCount))
 		return nil
-	}, retry.WithBackoffBaseDelay(pmysql.BackoffBaseDelay.Milliseconds()),
+	}
+	opts := append([]retry.Option{
+		retry.WithBackoffBaseDelay(pmysql.BackoffBaseDelay.Milliseconds()),
 		retry.WithBackoffMaxDelay(pmysql.BackoffMaxDelay.Milliseconds()),
 		retry.WithMaxTries(s.dmlMaxRetry),
-		retry.WithIsRetryableErr(isRetryableDMLError))
+		retry.WithIsRetryableErr(isRetryableDMLError),
+	}, s.cfg.RetryBudget.RetryOptions()...)
+	return s.cfg.RetryBudget.Escalate(retry.Do(pctx, op, opts...))
 }
 
 func logDMLTxnErr(
@@ -19,6 +19,7 @@ import (
 	"sync/atomic"
 
 	"github.com/pingcap/errors"
+	"github.com/pingcap/tiflow/cdc/contextutil"
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/cdc/sinkv2/eventsink"
 	"github.com/pingcap/tiflow/cdc/sinkv2/eventsink/txn/mysql"
@@ -26,6 +27,7 @@ import (
 	"github.com/pingcap/tiflow/cdc/sinkv2/tablesink/state"
 	"github.com/pingcap/tiflow/pkg/causality"
 	"github.com/pingcap/tiflow/pkg/config"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
 	psink "github.com/pingcap/tiflow/pkg/sink"
 	pmysql "github.com/pingcap/tiflow/pkg/sink/mysql"
 )
@@ -38,6 +40,9 @@ const (
 // Assert EventSink[E event.TableEvent] implementation
 var _ eventsink.EventSink[*model.SingleTableTxn] = (*sink)(nil)
 
+// Assert CredentialsUpdater implementation
+var _ eventsink.CredentialsUpdater = (*sink)(nil)
+
 // sink is the sink for SingleTableTxn.
 type sink struct {
 	conflictDetector *causality.ConflictDetector[*worker, *txnEvent]
@@ -48,12 +53,18 @@ type sink struct {
 	closed int32
 
 	statistics *metrics.Statistics
+	// updateCredentials updates the credentials of the backends,
+	// it is nil if the backends do not support it.
+	updateCredentials func(ctx context.Context, sinkURI *url.URL) error
 }
 
-func newSink(ctx context.Context, backends []backend, errCh chan<- error, conflictDetectorSlots uint64) *sink {
+func newSink(ctx context.Context, backends []backend, errCh chan<- error,
+	conflictDetectorSlots uint64, transformer *eventsink.ColumnTransformer,
+) *sink {
 	workers := make([]*worker, 0, len(backends))
 	for i, backend := range backends {
 		w := newWorker(ctx, i, backend, errCh, len(backends))
+		w.transformer = transformer
 		w.runBackgroundLoop()
 		workers = append(workers, w)
 	}
@@ -71,6 +82,11 @@ func NewMySQLSink(
 ) (*sink, error) {
 	var getConn pmysql.Factory = pmysql.CreateMySQLDBConn
 
+	transformer, err := eventsink.NewColumnTransformer(replicaConfig)
+	if err != nil {
+		return nil, err
+	}
+
 	ctx1, cancel := context.WithCancel(ctx)
 	statistics := metrics.NewStatistics(ctx1, psink.TxnSink)
 	backendImpls, err := mysql.NewMySQLBackends(ctx, sinkURI, replicaConfig, getConn, statistics)
@@ -83,9 +99,14 @@ func NewMySQLSink(
 	for _, impl := range backendImpls {
 		backends = append(backends, impl)
 	}
-	sink := newSink(ctx, backends, errCh, conflictDetectorSlots)
+	sink := newSink(ctx, backends, errCh, conflictDetectorSlots, transformer)
 	sink.statistics = statistics
 	sink.cancel = cancel
+	changefeedID := contextutil.ChangefeedIDFromCtx(ctx)
+	sink.updateCredentials = func(ctx context.Context, sinkURI *url.URL) error {
+		return mysql.UpdateCredentials(ctx, changefeedID, sinkURI,
+			replicaConfig, getConn, backendImpls)
+	}
 
 	return sink, nil
 }
@@ -109,6 +130,18 @@ func (s *sink) WriteEvents(txnEvents ...*eventsink.TxnCallbackableEvent) error {
 	return nil
 }
 
+// UpdateCredentials implements eventsink.CredentialsUpdater.
+func (s *sink) UpdateCredentials(ctx context.Context, sinkURI *url.URL) error {
+	if atomic.LoadInt32(&s.closed) != 0 {
+		return errors.Trace(errors.New("closed sink"))
+	}
+	if s.updateCredentials == nil {
+		return cerror.ErrSinkCredentialsNotUpdatable.GenWithStackByArgs(
+			"the backends do not support it")
+	}
+	return s.updateCredentials(ctx, sinkURI)
+}
+
 // Close closes the sink. It won't wait for all pending items backend handled.
 func (s *sink) Close() error {
 	atomic.StoreInt32(&s.closed, 1)
@@ -22,6 +22,7 @@ import (
 
 	"github.com/pingcap/log"
 	"github.com/pingcap/tiflow/cdc/contextutil"
+	"github.com/pingcap/tiflow/cdc/sinkv2/eventsink"
 	"github.com/pingcap/tiflow/cdc/sinkv2/metrics/txn"
 	"github.com/pingcap/tiflow/cdc/sinkv2/tablesink/state"
 	"github.com/pingcap/tiflow/pkg/chann"
@@ -46,6 +47,9 @@ type worker struct {
 	wg      sync.WaitGroup
 	backend backend
 	errCh   chan<- error
+	// transformer transforms the values of the rows before they're sent to
+	// the backend, it is nil if no column transform is configured.
+	transformer *eventsink.ColumnTransformer
 
 	// Metrics.
 	metricConflictDetectDuration prometheus.Observer
@@ -192,7 +196,16 @@ func (w *worker) onEvent(txn txnWithNotifier) bool {
 	w.metricConflictDetectDuration.Observe(time.Since(txn.start).Seconds())
 	w.metricTxnWorkerHandledRows.Add(float64(len(txn.Event.Rows)))
 	w.wantMoreCallbacks = append(w.wantMoreCallbacks, txn.wantMore)
-	return w.backend.OnTxnEvent(txn.txnEvent.TxnCallbackableEvent)
+	event := txn.txnEvent.TxnCallbackableEvent
+	if w.transformer != nil {
+		// The event is copied because it may be shared by the other sinks.
+		if transformed := w.transformer.TransformTxn(event.Event); transformed != event.Event {
+			newEvent := *event
+			newEvent.Event = transformed
+			event = &newEvent
+		}
+	}
+	return w.backend.OnTxnEvent(event)
 }
 
 // doFlush flushes the backend.
@@ -0,0 +1,269 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"hash/fnv"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/cdc/contextutil"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sink/codec"
+	"github.com/pingcap/tiflow/cdc/sink/codec/builder"
+	"github.com/pingcap/tiflow/cdc/sinkv2/eventsink"
+	"github.com/pingcap/tiflow/cdc/sinkv2/metrics"
+	"github.com/pingcap/tiflow/cdc/sinkv2/tablesink/state"
+	"github.com/pingcap/tiflow/cdc/sinkv2/util"
+	"github.com/pingcap/tiflow/pkg/chann"
+	"github.com/pingcap/tiflow/pkg/config"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/sink"
+	"github.com/pingcap/tiflow/pkg/sink/webhook"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+)
+
+// Assert EventSink[E event.TableEvent] implementation
+var _ eventsink.EventSink[*model.RowChangedEvent] = (*dmlSink)(nil)
+
+// dmlSink is the webhook sink. It encodes the row changed events by the
+// protocol of the changefeed and posts the batches to the endpoint. The
+// events of a table are sent by the same worker, so they are posted in
+// order, and the workers post the batches concurrently.
+type dmlSink struct {
+	changefeedID model.ChangeFeedID
+	protocol     config.Protocol
+	cfg          *webhook.Config
+	client       *webhook.Client
+	statistics   *metrics.Statistics
+	// eventCh is an unbounded channel to hold the events.
+	eventCh *chann.Chann[*eventsink.RowChangeCallbackableEvent]
+	workers []*worker
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewWebhookSink creates a webhook sink.
+func NewWebhookSink(
+	ctx context.Context,
+	sinkURI *url.URL,
+	replicaConfig *config.ReplicaConfig,
+	errCh chan error,
+) (*dmlSink, error) {
+	cfg := webhook.NewConfig()
+	if err := cfg.Apply(sinkURI); err != nil {
+		return nil, err
+	}
+	protocol, err := util.GetProtocol(replicaConfig.Sink.Protocol)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	changefeedID := contextutil.ChangefeedIDFromCtx(ctx)
+	if identity := replicaConfig.Sink.ClientIdentity; identity != nil {
+		cfg.UserAgent = config.ExpandClientIdentity(identity.HTTPUserAgent,
+			changefeedID.Namespace, changefeedID.ID, contextutil.CaptureAddrFromCtx(ctx))
+	}
+	encoderConfig, err := util.GetEncoderConfig(sinkURI, protocol, replicaConfig,
+		config.DefaultMaxMessageBytes)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	encoderConfig.ChangefeedID = changefeedID
+	encoderBuilder, err := builder.NewEventBatchEncoderBuilder(ctx, encoderConfig)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrWebhookInvalidConfig, err)
+	}
+	client, err := webhook.NewClient(changefeedID, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	s := &dmlSink{
+		changefeedID: changefeedID,
+		protocol:     protocol,
+		cfg:          cfg,
+		client:       client,
+		statistics:   metrics.NewStatistics(ctx, sink.RowSink),
+		eventCh:      chann.New[*eventsink.RowChangeCallbackableEvent](),
+		cancel:       cancel,
+	}
+	for i := 0; i < cfg.WorkerCount; i++ {
+		s.workers = append(s.workers, &worker{
+			sink:    s,
+			encoder: encoderBuilder.Build(),
+			// the channel is bounded, so the events are not dispatched to a
+			// worker which is blocked by the retries of its requests.
+			inputCh: make(chan *eventsink.RowChangeCallbackableEvent, cfg.FlushSize),
+		})
+	}
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		if err := s.run(ctx); err != nil && errors.Cause(err) != context.Canceled {
+			select {
+			case <-ctx.Done():
+			case errCh <- err:
+			default:
+				log.Error("Error channel is full in webhook sink",
+					zap.String("namespace", s.changefeedID.Namespace),
+					zap.String("changefeed", s.changefeedID.ID),
+					zap.Error(err))
+			}
+		}
+	}()
+	return s, nil
+}
+
+// WriteEvents writes events to the sink.
+// This is an asynchronously and thread-safe method.
+func (s *dmlSink) WriteEvents(rows ...*eventsink.RowChangeCallbackableEvent) error {
+	for _, row := range rows {
+		// This never be blocked because this is an unbounded channel.
+		s.eventCh.In() <- row
+	}
+	return nil
+}
+
+func (s *dmlSink) run(ctx context.Context) error {
+	g, ctx := errgroup.WithContext(ctx)
+	for _, w := range s.workers {
+		w := w
+		g.Go(func() error {
+			return w.run(ctx)
+		})
+	}
+	g.Go(func() error {
+		return s.dispatch(ctx)
+	})
+	return g.Wait()
+}
+
+// dispatch dispatches the events to the workers by their tables.
+func (s *dmlSink) dispatch(ctx context.Context) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return errors.Trace(ctx.Err())
+		case row, ok := <-s.eventCh.Out():
+			if !ok {
+				return nil
+			}
+			if row.GetTableSinkState() != state.TableSinkSinking {
+				// The table where the event comes from is in stopping, so it's safe
+				// to drop the event directly.
+				row.Callback()
+				continue
+			}
+			h := fnv.New32a()
+			_, _ = h.Write([]byte(row.Event.Table.QuoteString()))
+			w := s.workers[h.Sum32()%uint32(len(s.workers))]
+			select {
+			case <-ctx.Done():
+				return errors.Trace(ctx.Err())
+			case w.inputCh <- row:
+			}
+		}
+	}
+}
+
+// Close closes the sink.
+func (s *dmlSink) Close() error {
+	s.cancel()
+	s.wg.Wait()
+	s.eventCh.Close()
+	// We must finish consuming the data here,
+	// otherwise it will cause the channel to not close properly.
+	for range s.eventCh.Out() {
+		// Do nothing. We do not care about the data.
+	}
+	s.client.Close()
+	s.statistics.Close()
+	return nil
+}
+
+// worker encodes the events in batches and posts them to the endpoint.
+type worker struct {
+	sink    *dmlSink
+	encoder codec.EventBatchEncoder
+	inputCh chan *eventsink.RowChangeCallbackableEvent
+}
+
+func (w *worker) run(ctx context.Context) error {
+	ticker := time.NewTicker(w.sink.cfg.FlushInterval)
+	defer ticker.Stop()
+
+	var events []*eventsink.RowChangeCallbackableEvent
+	flush := func() error {
+		if len(events) == 0 {
+			return nil
+		}
+		if err := w.flush(ctx, events); err != nil {
+			return err
+		}
+		events = events[:0]
+		return nil
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return errors.Trace(ctx.Err())
+		case <-ticker.C:
+			if err := flush(); err != nil {
+				return err
+			}
+		case row := <-w.inputCh:
+			if err := w.encoder.AppendRowChangedEvent(ctx, "", row.Event, nil); err != nil {
+				return err
+			}
+			events = append(events, row)
+			if len(events) >= w.sink.cfg.FlushSize {
+				if err := flush(); err != nil {
+					return err
+				}
+			}
+		}
+	}
+}
+
+// flush posts the encoded batch and acknowledges its events. It's blocked
+// until the requests succeed, which holds the events in the memory quota of
+// the sink and so applies the backpressure to the changefeed.
+func (w *worker) flush(ctx context.Context, events []*eventsink.RowChangeCallbackableEvent) error {
+	reqs := webhook.BuildRequests(w.sink.protocol, w.encoder.Build())
+	err := w.sink.statistics.RecordBatchExecution(func() (int, error) {
+		for _, req := range reqs {
+			if err := w.sink.client.Send(ctx, req); err != nil {
+				return 0, err
+			}
+		}
+		return len(events), nil
+	})
+	if err != nil {
+		return err
+	}
+	for _, event := range events {
+		w.sink.statistics.ObserveRows(event.Event)
+		w.sink.statistics.ObserveEventAge(config.MetricLabelTable, event.Event.Table.String(),
+			event.Event.CommitTs)
+		event.Callback()
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sinkv2/eventsink"
+	"github.com/pingcap/tiflow/cdc/sinkv2/tablesink/state"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/stretchr/testify/require"
+)
+
+func newEvent(
+	table string, id int64, sinkState state.TableSinkState, acked *int64,
+) *eventsink.RowChangeCallbackableEvent {
+	return &eventsink.RowChangeCallbackableEvent{
+		Event: &model.RowChangedEvent{
+			CommitTs: uint64(id),
+			Table:    &model.TableName{Schema: "test", Table: table},
+			Columns: []*model.Column{{
+				Name: "id", Type: mysql.TypeLonglong, Value: id,
+				Flag: model.HandleKeyFlag | model.PrimaryKeyFlag,
+			}},
+		},
+		Callback:  func() { atomic.AddInt64(acked, 1) },
+		SinkState: &sinkState,
+	}
+}
+
+func newReplicaConfig() *config.ReplicaConfig {
+	cfg := config.GetDefaultReplicaConfig()
+	cfg.Sink.Protocol = config.ProtocolCanalJSON.String()
+	return cfg
+}
+
+func TestWriteEvents(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu     sync.Mutex
+		bodies []string
+		failed bool
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/hooks", r.URL.Path)
+		require.Equal(t, "abc", r.URL.Query().Get("token"))
+		body, err := io.ReadAll(r.Body)
+		require.Nil(t, err)
+		mu.Lock()
+		defer mu.Unlock()
+		// the first request is rejected to slow down the sink.
+		if !failed {
+			failed = true
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		bodies = append(bodies, string(body))
+	}))
+	defer server.Close()
+
+	sinkURI, err := url.Parse(server.URL + "/hooks?token=abc&protocol=canal-json" +
+		"&worker-count=1&flush-size=2&flush-interval=1m&retry-backoff-base=10ms")
+	require.Nil(t, err)
+	errCh := make(chan error, 1)
+	s, err := NewWebhookSink(context.Background(), sinkURI, newReplicaConfig(), errCh)
+	require.Nil(t, err)
+	defer s.Close()
+
+	// the rows are posted in a batch once it's full, and the row of the
+	// stopping table is dropped.
+	var acked int64
+	require.Nil(t, s.WriteEvents(
+		newEvent("t1", 1, state.TableSinkSinking, &acked),
+		newEvent("t2", 2, state.TableSinkStopping, &acked),
+		newEvent("t1", 3, state.TableSinkSinking, &acked),
+	))
+	require.Eventually(t, func() bool {
+		return atomic.LoadInt64(&acked) == 3
+	}, 5*time.Second, 10*time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, bodies, 1)
+	rows := strings.Split(strings.TrimSuffix(bodies[0], config.CRLF), config.CRLF)
+	require.Len(t, rows, 2)
+	require.Contains(t, rows[0], `"table":"t1"`)
+	require.Contains(t, rows[0], `"id":"1"`)
+	require.Contains(t, rows[1], `"id":"3"`)
+	require.Len(t, errCh, 0)
+}
+
+func TestWriteEventsFailed(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	sinkURI, err := url.Parse(server.URL + "?protocol=canal-json&flush-size=1")
+	require.Nil(t, err)
+	errCh := make(chan error, 1)
+	s, err := NewWebhookSink(context.Background(), sinkURI, newReplicaConfig(), errCh)
+	require.Nil(t, err)
+	defer s.Close()
+
+	var acked int64
+	require.Nil(t, s.WriteEvents(newEvent("t1", 1, state.TableSinkSinking, &acked)))
+	select {
+	case err := <-errCh:
+		require.Regexp(t, "webhook request failed.*400", err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the error is not reported")
+	}
+	require.Equal(t, int64(0), atomic.LoadInt64(&acked))
+}
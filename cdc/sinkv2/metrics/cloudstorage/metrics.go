@@ -39,7 +39,7 @@ var (
 )
 
 // InitMetrics registers all metrics in this file.
-func InitMetrics(registry *prometheus.Registry) {
+func InitMetrics(registry prometheus.Registerer) {
 	registry.MustRegister(CloudStorageWriteBytesGauge)
 	registry.MustRegister(CloudStorageFileCountGauge)
 }
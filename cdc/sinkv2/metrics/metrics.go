@@ -55,6 +55,18 @@ var (
 			Buckets:   prometheus.ExponentialBuckets(0.01, 2, 18),
 		}, []string{"namespace", "changefeed", "type"}) // type is for `sinkType`
 
+	// EventAgeAtDeliveryHistogram records the age of the events when they are
+	// delivered to the downstream, which is the delivery time minus the physical
+	// time of the commit ts. It's the latency that users actually experience.
+	EventAgeAtDeliveryHistogram = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "ticdc",
+			Subsystem: "sinkv2",
+			Name:      "event_age_at_delivery",
+			Help:      "Bucketed histogram of the age (s) of events when they are delivered to the downstream.",
+			Buckets:   prometheus.ExponentialBuckets(0.01, 2, 20), // 10ms~1.5h
+		}, []string{"namespace", "changefeed", "type", "target"}) // target is a table or an MQ topic
+
 	// ExecutionErrorCounter is the counter of execution errors.
 	ExecutionErrorCounter = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
@@ -66,11 +78,12 @@ var (
 )
 
 // InitMetrics registers all metrics in this file.
-func InitMetrics(registry *prometheus.Registry) {
+func InitMetrics(registry prometheus.Registerer) {
 	registry.MustRegister(ExecBatchHistogram)
 	registry.MustRegister(ExecDDLHistogram)
 	registry.MustRegister(LargeRowSizeHistogram)
 	registry.MustRegister(ExecutionErrorCounter)
+	registry.MustRegister(EventAgeAtDeliveryHistogram)
 
 	txn.InitMetrics(registry)
 	mq.InitMetrics(registry)
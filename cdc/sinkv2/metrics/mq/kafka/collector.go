@@ -20,6 +20,7 @@ import (
 
 	"github.com/pingcap/log"
 	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/compression"
 	"github.com/pingcap/tiflow/pkg/sink/kafka"
 	"github.com/pingcap/tiflow/pkg/util"
 	"github.com/rcrowley/go-metrics"
@@ -50,6 +51,12 @@ type Collector struct {
 	brokers     map[int32]struct{}
 	// TiCDC metrics registry.
 	registry metrics.Registry
+	// batchCodec is the codec compressing the record batches in sarama, it's
+	// nil if the record batches are not compressed.
+	batchCodec compression.Codec
+	// compressedBatches is the number of the record batches whose
+	// compression ratios are observed.
+	compressedBatches int64
 }
 
 // New creates a new metric collector.
@@ -58,6 +65,7 @@ func New(
 	role util.Role,
 	adminClient kafka.ClusterAdminClient,
 	registry metrics.Registry,
+	batchCodec compression.Codec,
 ) *Collector {
 	return &Collector{
 		changefeedID: changefeedID,
@@ -65,6 +73,7 @@ func New(
 		adminClient:  adminClient,
 		brokers:      make(map[int32]struct{}),
 		registry:     registry,
+		batchCodec:   batchCodec,
 	}
 }
 
@@ -114,9 +123,18 @@ func (m *Collector) collectProducerMetrics() {
 
 	compressionRatioMetric := m.registry.Get(compressionRatioMetricName)
 	if histogram, ok := compressionRatioMetric.(metrics.Histogram); ok {
+		snapshot := histogram.Snapshot()
 		compressionRatioGauge.
 			WithLabelValues(namespace, changefeedID).
-			Set(histogram.Snapshot().Mean())
+			Set(snapshot.Mean())
+		// The record batches are compressed in sarama, which records the
+		// ratio times 100 of each batch, so the mean ratio of the batches
+		// compressed since the last collection is observed.
+		if m.batchCodec != nil && snapshot.Count() > m.compressedBatches {
+			compression.ObserveRatio(compression.ComponentKafka,
+				m.batchCodec.Name(), snapshot.Mean()/100)
+		}
+		m.compressedBatches = snapshot.Count()
 	}
 }
 
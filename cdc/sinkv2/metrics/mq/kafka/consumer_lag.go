@@ -0,0 +1,202 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/pingcap/tiflow/pkg/sink/kafka"
+	"go.uber.org/zap"
+)
+
+// ConsumerLagCollector polls the lag of a downstream consumer group,
+// which is the number of messages produced but not consumed by the group.
+type ConsumerLagCollector struct {
+	changefeedID model.ChangeFeedID
+	group        string
+	client       kafka.Client
+	adminClient  kafka.ClusterAdminClient
+	// metricsConfig decides whether the lag is reported per topic and
+	// per partition.
+	metricsConfig *config.MetricsConfig
+
+	mu struct {
+		sync.Mutex
+		// topics is the partition number of the monitored topics.
+		topics map[string]int32
+		lag    *model.ConsumerGroupLag
+	}
+}
+
+// NewConsumerLagCollector creates a new consumer lag collector.
+// The clients are not closed by the collector.
+func NewConsumerLagCollector(
+	changefeedID model.ChangeFeedID,
+	group string,
+	client kafka.Client,
+	adminClient kafka.ClusterAdminClient,
+	metricsConfig *config.MetricsConfig,
+) *ConsumerLagCollector {
+	c := &ConsumerLagCollector{
+		changefeedID:  changefeedID,
+		group:         group,
+		client:        client,
+		adminClient:   adminClient,
+		metricsConfig: metricsConfig,
+	}
+	c.mu.topics = make(map[string]int32)
+	return c
+}
+
+// AddTopic adds a topic to be monitored.
+func (c *ConsumerLagCollector) AddTopic(topic string, partitionNum int32) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.mu.topics[topic] = partitionNum
+}
+
+// Lag returns the last collected lag, nil is returned if
+// the lag is not collected yet.
+func (c *ConsumerLagCollector) Lag() *model.ConsumerGroupLag {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.mu.lag
+}
+
+// Run collects the consumer group lag periodically.
+func (c *ConsumerLagCollector) Run(ctx context.Context) {
+	ticker := time.NewTicker(flushMetricsInterval)
+	defer func() {
+		ticker.Stop()
+		c.cleanupMetrics()
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := c.collect(); err != nil {
+				log.Warn("Collect kafka consumer group lag failed",
+					zap.String("namespace", c.changefeedID.Namespace),
+					zap.String("changefeed", c.changefeedID.ID),
+					zap.String("group", c.group),
+					zap.Error(err))
+			}
+		}
+	}
+}
+
+func (c *ConsumerLagCollector) collect() error {
+	c.mu.Lock()
+	topicPartitions := make(map[string][]int32, len(c.mu.topics))
+	for topic, partitionNum := range c.mu.topics {
+		partitions := make([]int32, 0, partitionNum)
+		for i := int32(0); i < partitionNum; i++ {
+			partitions = append(partitions, i)
+		}
+		topicPartitions[topic] = partitions
+	}
+	c.mu.Unlock()
+	if len(topicPartitions) == 0 {
+		return nil
+	}
+
+	resp, err := c.adminClient.ListConsumerGroupOffsets(c.group, topicPartitions)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if resp.Err != sarama.ErrNoError {
+		return errors.Trace(resp.Err)
+	}
+
+	var total int64
+	// the lags are summed up by the labels, if the topic or the partition
+	// label is disabled.
+	labelLags := make(map[lagLabels]int64, len(topicPartitions))
+	for topic, partitions := range topicPartitions {
+		for _, partition := range partitions {
+			newest, err := c.client.GetOffset(topic, partition, sarama.OffsetNewest)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			committed := int64(0)
+			if block := resp.GetBlock(topic, partition); block != nil {
+				if block.Err != sarama.ErrNoError {
+					return errors.Trace(block.Err)
+				}
+				// -1 means that the group has no committed offset,
+				// all messages in the partition are not consumed.
+				if block.Offset > 0 {
+					committed = block.Offset
+				}
+			}
+			var lag int64
+			if newest > committed {
+				lag = newest - committed
+			}
+			labelLags[c.labels(topic, partition)] += lag
+			total += lag
+		}
+	}
+	for labels, lag := range labelLags {
+		consumerGroupLagGauge.
+			WithLabelValues(c.changefeedID.Namespace, c.changefeedID.ID,
+				c.group, labels.topic, labels.partition).
+			Set(float64(lag))
+	}
+
+	c.mu.Lock()
+	c.mu.lag = &model.ConsumerGroupLag{
+		Group:      c.group,
+		Lag:        total,
+		UpdateTime: time.Now(),
+	}
+	c.mu.Unlock()
+	return nil
+}
+
+// lagLabels is the topic and partition labels of the lag metric.
+type lagLabels struct {
+	topic     string
+	partition string
+}
+
+func (c *ConsumerLagCollector) labels(topic string, partition int32) lagLabels {
+	return lagLabels{
+		topic: c.metricsConfig.LabelValue(config.MetricLabelTopic, topic),
+		partition: c.metricsConfig.LabelValue(config.MetricLabelPartition,
+			strconv.Itoa(int(partition))),
+	}
+}
+
+func (c *ConsumerLagCollector) cleanupMetrics() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for topic, partitionNum := range c.mu.topics {
+		for i := int32(0); i < partitionNum; i++ {
+			labels := c.labels(topic, i)
+			consumerGroupLagGauge.DeleteLabelValues(c.changefeedID.Namespace, c.changefeedID.ID,
+				c.group, labels.topic, labels.partition)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"testing"
+
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/pingcap/tiflow/pkg/sink/kafka"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/require"
+)
+
+type mockOffsetClient struct {
+	kafka.Client
+	offsets map[int32]int64
+}
+
+func (c *mockOffsetClient) GetOffset(_ string, partitionID int32, _ int64) (int64, error) {
+	return c.offsets[partitionID], nil
+}
+
+func TestConsumerLagCollector(t *testing.T) {
+	t.Parallel()
+
+	client := &mockOffsetClient{
+		Client:  kafka.NewClientMockImpl(),
+		offsets: map[int32]int64{0: 10, 1: 20, 2: 30},
+	}
+	adminClient := kafka.NewClusterAdminClientMockImpl()
+	c := NewConsumerLagCollector(model.DefaultChangeFeedID("test"),
+		"group", client, adminClient, nil)
+
+	// No topic is monitored yet.
+	require.Nil(t, c.collect())
+	require.Nil(t, c.Lag())
+
+	c.AddTopic(kafka.DefaultMockTopicName, 3)
+	adminClient.SetConsumerGroupOffset("group", kafka.DefaultMockTopicName, 0, 10)
+	adminClient.SetConsumerGroupOffset("group", kafka.DefaultMockTopicName, 1, 15)
+	require.Nil(t, c.collect())
+	lag := c.Lag()
+	require.Equal(t, "group", lag.Group)
+	// The partition 2 has no committed offset.
+	require.Equal(t, int64(35), lag.Lag)
+}
+
+func TestConsumerLagCollectorAggregateTopics(t *testing.T) {
+	t.Parallel()
+
+	client := &mockOffsetClient{
+		Client:  kafka.NewClientMockImpl(),
+		offsets: map[int32]int64{0: 10, 1: 20},
+	}
+	adminClient := kafka.NewClusterAdminClientMockImpl()
+	changefeedID := model.DefaultChangeFeedID("test-aggregate")
+	c := NewConsumerLagCollector(changefeedID, "group", client, adminClient,
+		&config.MetricsConfig{DisabledLabels: []string{config.MetricLabelTopic}})
+
+	c.AddTopic(kafka.DefaultMockTopicName, 2)
+	require.Nil(t, c.collect())
+	require.Equal(t, int64(30), c.Lag().Lag)
+	// The lag is reported with the aggregated topic label only.
+	m := &dto.Metric{}
+	require.Nil(t, consumerGroupLagGauge.WithLabelValues(changefeedID.Namespace,
+		changefeedID.ID, "group", config.AggregatedMetricLabelValue, "1").Write(m))
+	require.Equal(t, float64(20), m.GetGauge().GetValue())
+	require.False(t, consumerGroupLagGauge.DeleteLabelValues(changefeedID.Namespace,
+		changefeedID.ID, "group", kafka.DefaultMockTopicName, "1"))
+
+	c.cleanupMetrics()
+	require.False(t, consumerGroupLagGauge.DeleteLabelValues(changefeedID.Namespace,
+		changefeedID.ID, "group", config.AggregatedMetricLabelValue, "1"))
+}
+
+func TestConsumerLagCollectorAggregatePartitions(t *testing.T) {
+	t.Parallel()
+
+	client := &mockOffsetClient{
+		Client:  kafka.NewClientMockImpl(),
+		offsets: map[int32]int64{0: 10, 1: 20},
+	}
+	adminClient := kafka.NewClusterAdminClientMockImpl()
+	changefeedID := model.DefaultChangeFeedID("test-aggregate-partitions")
+	c := NewConsumerLagCollector(changefeedID, "group", client, adminClient,
+		&config.MetricsConfig{DisabledLabels: []string{config.MetricLabelPartition}})
+
+	c.AddTopic(kafka.DefaultMockTopicName, 2)
+	require.Nil(t, c.collect())
+	// The lag of the partitions are summed up.
+	m := &dto.Metric{}
+	require.Nil(t, consumerGroupLagGauge.WithLabelValues(changefeedID.Namespace,
+		changefeedID.ID, "group", kafka.DefaultMockTopicName,
+		config.AggregatedMetricLabelValue).Write(m))
+	require.Equal(t, float64(30), m.GetGauge().GetValue())
+
+	c.cleanupMetrics()
+	require.False(t, consumerGroupLagGauge.DeleteLabelValues(changefeedID.Namespace,
+		changefeedID.ID, "group", kafka.DefaultMockTopicName, config.AggregatedMetricLabelValue))
+}
@@ -66,14 +66,24 @@ var (
 			Name:      "kafka_producer_response_rate",
 			Help:      "Responses/second received from all brokers.",
 		}, []string{"namespace", "changefeed", "broker"})
+	// The lag of the downstream consumer group, which is the sum of
+	// the lag of a partition of a topic.
+	consumerGroupLagGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "ticdc",
+			Subsystem: "sinkv2",
+			Name:      "kafka_consumer_group_lag",
+			Help:      "The number of messages not consumed by the downstream consumer group.",
+		}, []string{"namespace", "changefeed", "group", "topic", "partition"})
 )
 
 // InitMetrics registers all metrics in this file.
-func InitMetrics(registry *prometheus.Registry) {
+func InitMetrics(registry prometheus.Registerer) {
 	registry.MustRegister(compressionRatioGauge)
 	registry.MustRegister(outgoingByteRateGauge)
 	registry.MustRegister(requestRateGauge)
 	registry.MustRegister(requestLatencyInMsGauge)
 	registry.MustRegister(requestsInFlightGauge)
 	registry.MustRegister(responseRateGauge)
+	registry.MustRegister(consumerGroupLagGauge)
 }
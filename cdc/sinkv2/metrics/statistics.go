@@ -15,20 +15,24 @@ package metrics
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	"github.com/pingcap/tiflow/cdc/contextutil"
 	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/config"
 	"github.com/pingcap/tiflow/pkg/sink"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/tikv/client-go/v2/oracle"
 )
 
 // NewStatistics creates a statistics
 func NewStatistics(ctx context.Context, sinkType sink.Type) *Statistics {
 	statistics := &Statistics{
-		sinkType:     sinkType,
-		captureAddr:  contextutil.CaptureAddrFromCtx(ctx),
-		changefeedID: contextutil.ChangefeedIDFromCtx(ctx),
+		sinkType:      sinkType,
+		captureAddr:   contextutil.CaptureAddrFromCtx(ctx),
+		changefeedID:  contextutil.ChangefeedIDFromCtx(ctx),
+		metricsConfig: contextutil.MetricsConfigFromCtx(ctx),
 	}
 
 	namespcae := statistics.changefeedID.Namespace
@@ -44,9 +48,10 @@ func NewStatistics(ctx context.Context, sinkType sink.Type) *Statistics {
 // Statistics maintains some status and metrics of the Sink
 // Note: All methods of Statistics should be thread-safe.
 type Statistics struct {
-	sinkType     sink.Type
-	captureAddr  string
-	changefeedID model.ChangeFeedID
+	sinkType      sink.Type
+	captureAddr   string
+	changefeedID  model.ChangeFeedID
+	metricsConfig *config.MetricsConfig
 
 	// Histogram for DDL Executing duration.
 	metricExecDDLHis prometheus.Observer
@@ -56,6 +61,8 @@ type Statistics struct {
 	metricRowSizeHis prometheus.Observer
 	// Counter for sink error.
 	metricExecErrCnt prometheus.Counter
+	// Histograms for the event age at delivery, keyed by the target.
+	metricEventAgeHis sync.Map
 }
 
 // ObserveRows stats all received `RowChangedEvent`s.
@@ -69,6 +76,24 @@ func (b *Statistics) ObserveRows(rows ...*model.RowChangedEvent) {
 	}
 }
 
+// ObserveEventAge observes the age of an event delivered to the target, which
+// is a table or an MQ topic indicated by label. The age is the delivery time
+// minus the physical time of the commit ts of the event.
+func (b *Statistics) ObserveEventAge(label, target string, commitTs uint64) {
+	target = b.metricsConfig.LabelValue(label, target)
+	his, ok := b.metricEventAgeHis.Load(target)
+	if !ok {
+		his, _ = b.metricEventAgeHis.LoadOrStore(target, EventAgeAtDeliveryHistogram.WithLabelValues(
+			b.changefeedID.Namespace, b.changefeedID.ID, b.sinkType.String(), target))
+	}
+	age := time.Since(oracle.GetTimeFromTS(commitTs)).Seconds()
+	if age < 0 {
+		// The clocks of TiCDC and PD may drift.
+		age = 0
+	}
+	his.(prometheus.Observer).Observe(age)
+}
+
 // RecordBatchExecution stats batch executors which return (batchRowCount, error).
 func (b *Statistics) RecordBatchExecution(executor func() (int, error)) error {
 	batchSize, err := executor()
@@ -97,4 +122,9 @@ func (b *Statistics) Close() {
 	ExecBatchHistogram.DeleteLabelValues(b.changefeedID.Namespace, b.changefeedID.ID)
 	LargeRowSizeHistogram.DeleteLabelValues(b.changefeedID.Namespace, b.changefeedID.ID)
 	ExecutionErrorCounter.DeleteLabelValues(b.changefeedID.Namespace, b.changefeedID.ID)
+	b.metricEventAgeHis.Range(func(target, _ interface{}) bool {
+		EventAgeAtDeliveryHistogram.DeleteLabelValues(b.changefeedID.Namespace, b.changefeedID.ID,
+			b.sinkType.String(), target.(string))
+		return true
+	})
 }
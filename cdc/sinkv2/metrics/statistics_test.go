@@ -0,0 +1,61 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pingcap/tiflow/cdc/contextutil"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/pingcap/tiflow/pkg/sink"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/require"
+	"github.com/tikv/client-go/v2/oracle"
+)
+
+func TestStatisticsObserveEventAge(t *testing.T) {
+	t.Parallel()
+
+	changefeedID := model.DefaultChangeFeedID("test-event-age")
+	ctx := contextutil.PutChangefeedIDInCtx(context.Background(), changefeedID)
+	ctx = contextutil.PutMetricsConfigInCtx(ctx,
+		&config.MetricsConfig{DisabledLabels: []string{config.MetricLabelTopic}})
+	statistics := NewStatistics(ctx, sink.RowSink)
+
+	commitTs := oracle.GoTimeToTS(time.Now().Add(-time.Minute))
+	statistics.ObserveEventAge(config.MetricLabelTable, "test.t", commitTs)
+	statistics.ObserveEventAge(config.MetricLabelTable, "test.t", commitTs)
+	statistics.ObserveEventAge(config.MetricLabelTopic, "topic1", commitTs)
+	statistics.ObserveEventAge(config.MetricLabelTopic, "topic2", commitTs)
+
+	m := &dto.Metric{}
+	require.Nil(t, EventAgeAtDeliveryHistogram.WithLabelValues(changefeedID.Namespace,
+		changefeedID.ID, sink.RowSink.String(), "test.t").(prometheus.Histogram).Write(m))
+	require.Equal(t, uint64(2), m.GetHistogram().GetSampleCount())
+	require.GreaterOrEqual(t, m.GetHistogram().GetSampleSum(), float64(2*60))
+	// The disabled topic label is aggregated.
+	require.Nil(t, EventAgeAtDeliveryHistogram.WithLabelValues(changefeedID.Namespace,
+		changefeedID.ID, sink.RowSink.String(), config.AggregatedMetricLabelValue).(prometheus.Histogram).Write(m))
+	require.Equal(t, uint64(2), m.GetHistogram().GetSampleCount())
+
+	statistics.Close()
+	require.False(t, EventAgeAtDeliveryHistogram.DeleteLabelValues(changefeedID.Namespace,
+		changefeedID.ID, sink.RowSink.String(), "test.t"))
+	require.False(t, EventAgeAtDeliveryHistogram.DeleteLabelValues(changefeedID.Namespace,
+		changefeedID.ID, sink.RowSink.String(), config.AggregatedMetricLabelValue))
+}
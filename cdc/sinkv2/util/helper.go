@@ -17,6 +17,7 @@ import (
 	"net/url"
 	"strings"
 
+	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/cdc/sink/codec/common"
 	"github.com/pingcap/tiflow/cdc/sink/mq/manager"
 	"github.com/pingcap/tiflow/pkg/config"
@@ -49,7 +50,7 @@ func GetProtocol(protocolStr string) (config.Protocol, error) {
 func GetFileExtension(protocol config.Protocol) string {
 	switch protocol {
 	case config.ProtocolAvro, config.ProtocolCanalJSON, config.ProtocolMaxwell,
-		config.ProtocolOpen:
+		config.ProtocolOpen, config.ProtocolDebezium, config.ProtocolCloudEvents:
 		return ".json"
 	case config.ProtocolCraft:
 		return ".craft"
@@ -57,6 +58,8 @@ func GetFileExtension(protocol config.Protocol) string {
 		return ".canal"
 	case config.ProtocolCsv:
 		return ".csv"
+	case config.ProtocolParquet:
+		return ".parquet"
 	default:
 		return ".unknown"
 	}
@@ -87,12 +90,14 @@ func GetEncoderConfig(
 
 // GetTopicManagerAndTryCreateTopic returns the topic manager and try to create the topic.
 func GetTopicManagerAndTryCreateTopic(
+	changefeedID model.ChangeFeedID,
 	topic string,
 	topicCfg *kafka.AutoCreateTopicConfig,
 	client kafka.Client,
 	adminClient kafka.ClusterAdminClient,
 ) (manager.TopicManager, error) {
 	topicManager, err := manager.NewKafkaTopicManager(
+		changefeedID,
 		client,
 		adminClient,
 		topicCfg,
@@ -102,6 +107,7 @@ func GetTopicManagerAndTryCreateTopic(
 	}
 
 	if _, err := topicManager.CreateTopicAndWaitUntilVisible(topic); err != nil {
+		topicManager.Close()
 		return nil, cerror.WrapError(cerror.ErrKafkaCreateTopic, err)
 	}
 
@@ -25,6 +25,7 @@ import (
 	"github.com/pingcap/tiflow/cdc/contextutil"
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/cdc/sorter"
+	"github.com/pingcap/tiflow/pkg/config"
 	"github.com/pingcap/tiflow/pkg/notify"
 	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
@@ -54,12 +55,14 @@ func NewEntrySorter() *EntrySorter {
 func (es *EntrySorter) Run(ctx context.Context) error {
 	changefeedID := contextutil.ChangefeedIDFromCtx(ctx)
 	_, tableName := contextutil.TableIDFromCtx(ctx)
-	metricEntrySorterResolvedChanSizeGauge := entrySorterResolvedChanSizeGauge.
-		WithLabelValues(changefeedID.Namespace, changefeedID.ID, tableName)
-	metricEntrySorterOutputChanSizeGauge := entrySorterOutputChanSizeGauge.
-		WithLabelValues(changefeedID.Namespace, changefeedID.ID, tableName)
-	metricEntryUnsortedSizeGauge := entrySorterUnsortedSizeGauge.
-		WithLabelValues(changefeedID.Namespace, changefeedID.ID, tableName)
+	tableName = contextutil.MetricsConfigFromCtx(ctx).
+		LabelValue(config.MetricLabelTable, tableName)
+	metricEntrySorterResolvedChanSizeGauge := newSizeGauge(entrySorterResolvedChanSizeGauge.
+		WithLabelValues(changefeedID.Namespace, changefeedID.ID, tableName))
+	metricEntrySorterOutputChanSizeGauge := newSizeGauge(entrySorterOutputChanSizeGauge.
+		WithLabelValues(changefeedID.Namespace, changefeedID.ID, tableName))
+	metricEntryUnsortedSizeGauge := newSizeGauge(entrySorterUnsortedSizeGauge.
+		WithLabelValues(changefeedID.Namespace, changefeedID.ID, tableName))
 	metricEntrySorterSortDuration := entrySorterSortDuration.
 		WithLabelValues(changefeedID.Namespace, changefeedID.ID, tableName)
 	metricEntrySorterMergeDuration := entrySorterMergeDuration.
@@ -86,6 +89,11 @@ func (es *EntrySorter) Run(ctx context.Context) error {
 			case <-ctx.Done():
 				atomic.StoreInt32(&es.closed, 1)
 				close(es.outputCh)
+				// withdraw the sizes reported, which may be summed up
+				// with the ones of the other sorters.
+				metricEntrySorterOutputChanSizeGauge.Set(0)
+				metricEntrySorterResolvedChanSizeGauge.Set(0)
+				metricEntryUnsortedSizeGauge.Set(0)
 				return errors.Trace(ctx.Err())
 			case <-time.After(defaultMetricInterval):
 				metricEntrySorterOutputChanSizeGauge.Set(float64(len(es.outputCh)))
@@ -22,6 +22,8 @@ import (
 
 	"github.com/pingcap/errors"
 	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
 	"github.com/stretchr/testify/require"
 )
 
@@ -405,3 +407,22 @@ func BenchmarkSorter(b *testing.B) {
 	cancel()
 	wg.Wait()
 }
+
+func TestSizeGaugeAggregated(t *testing.T) {
+	t.Parallel()
+
+	gauge := prometheus.NewGauge(prometheus.GaugeOpts{Name: "test"})
+	value := func() float64 {
+		m := &dto.Metric{}
+		require.Nil(t, gauge.Write(m))
+		return m.GetGauge().GetValue()
+	}
+	g1, g2 := newSizeGauge(gauge), newSizeGauge(gauge)
+	g1.Set(10)
+	g2.Set(5)
+	require.Equal(t, float64(15), value())
+	g1.Set(3)
+	require.Equal(t, float64(8), value())
+	g2.Set(0)
+	require.Equal(t, float64(3), value())
+}
@@ -63,8 +63,26 @@ var (
 		}, []string{"namespace", "changefeed", "table"})
 )
 
+// sizeGauge reports a size by adding the delta to the last reported one,
+// so that the sizes of the sorters sharing an aggregated table label are
+// summed up instead of overwriting each other. It's not thread-safe.
+type sizeGauge struct {
+	gauge prometheus.Gauge
+	last  float64
+}
+
+func newSizeGauge(gauge prometheus.Gauge) *sizeGauge {
+	return &sizeGauge{gauge: gauge}
+}
+
+// Set reports the size.
+func (g *sizeGauge) Set(size float64) {
+	g.gauge.Add(size - g.last)
+	g.last = size
+}
+
 // InitMetrics registers all metrics in this file
-func InitMetrics(registry *prometheus.Registry) {
+func InitMetrics(registry prometheus.Registerer) {
 	registry.MustRegister(entrySorterResolvedChanSizeGauge)
 	registry.MustRegister(entrySorterOutputChanSizeGauge)
 	registry.MustRegister(entrySorterUnsortedSizeGauge)
@@ -68,7 +68,7 @@ var (
 )
 
 // InitMetrics registers all metrics in this file
-func InitMetrics(registry *prometheus.Registry) {
+func InitMetrics(registry prometheus.Registerer) {
 	registry.MustRegister(InputEventCount)
 	registry.MustRegister(OutputEventCount)
 	registry.MustRegister(ResolvedTsGauge)
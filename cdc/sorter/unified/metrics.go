@@ -50,7 +50,7 @@ var (
 )
 
 // InitMetrics registers all metrics in this file
-func InitMetrics(registry *prometheus.Registry) {
+func InitMetrics(registry prometheus.Registerer) {
 	registry.MustRegister(sorterConsumeCount)
 	registry.MustRegister(sorterMergerStartTsGauge)
 	registry.MustRegister(sorterFlushCountHistogram)
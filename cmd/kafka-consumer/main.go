@@ -39,6 +39,7 @@ import (
 	"github.com/pingcap/tiflow/cdc/sink/codec"
 	"github.com/pingcap/tiflow/cdc/sink/codec/canal"
 	"github.com/pingcap/tiflow/cdc/sink/codec/open"
+	"github.com/pingcap/tiflow/cdc/sink/codec/protobuf"
 	"github.com/pingcap/tiflow/cdc/sink/mq/dispatcher"
 	cmdUtil "github.com/pingcap/tiflow/pkg/cmd/util"
 	"github.com/pingcap/tiflow/pkg/config"
@@ -46,6 +47,7 @@ import (
 	"github.com/pingcap/tiflow/pkg/logutil"
 	"github.com/pingcap/tiflow/pkg/quotes"
 	"github.com/pingcap/tiflow/pkg/security"
+	pkafka "github.com/pingcap/tiflow/pkg/sink/kafka"
 	"github.com/pingcap/tiflow/pkg/util"
 	"go.uber.org/zap"
 )
@@ -64,6 +66,9 @@ var (
 
 	protocol            config.Protocol
 	enableTiDBExtension bool
+	strictDecode        bool
+	checkOrder          bool
+	schemaRegistryURL   string
 
 	// eventRouterReplicaConfig only used to initialize the consumer's eventRouter
 	// which then can be used to check RowChangedEvent dispatched correctness
@@ -90,6 +95,11 @@ func init() {
 	flag.StringVar(&ca, "ca", "", "CA certificate path for Kafka SSL connection")
 	flag.StringVar(&cert, "cert", "", "Certificate path for Kafka SSL connection")
 	flag.StringVar(&key, "key", "", "Private key path for Kafka SSL connection")
+	flag.BoolVar(&strictDecode, "strict-decode", false,
+		"Reject messages with unknown fields or trailing data")
+	flag.BoolVar(&checkOrder, "check-order", false,
+		"Verify the commit ts order of the rows of each key and the resolved ts of each partition, "+
+			"the violations are reported with the offsets")
 	flag.Parse()
 
 	err := logutil.InitLogger(&logutil.Config{
@@ -180,13 +190,18 @@ func init() {
 		if err != nil {
 			log.Panic("invalid enable-tidb-extension of upstream-uri")
 		}
-		if protocol != config.ProtocolCanalJSON && b {
-			log.Panic("enable-tidb-extension only work with canal-json")
+		if protocol != config.ProtocolCanalJSON && protocol != config.ProtocolProtobuf && b {
+			log.Panic("enable-tidb-extension only work with canal-json and protobuf")
 		}
 
 		enableTiDBExtension = b
 	}
 
+	schemaRegistryURL = upstreamURI.Query().Get("schema-registry")
+	if protocol == config.ProtocolProtobuf && schemaRegistryURL == "" {
+		log.Panic("schema-registry of upstream-uri is required by protobuf")
+	}
+
 	if configFile != "" {
 		eventRouterReplicaConfig = config.GetDefaultReplicaConfig()
 		eventRouterReplicaConfig.Sink.Protocol = protocol.String()
@@ -346,6 +361,9 @@ func main() {
 	if err = client.Close(); err != nil {
 		log.Panic("Error closing client", zap.Error(err))
 	}
+	if consumer.orderChecker != nil && consumer.orderChecker.summary() > 0 {
+		os.Exit(1)
+	}
 }
 
 type partitionSink struct {
@@ -375,8 +393,14 @@ type Consumer struct {
 
 	protocol            config.Protocol
 	enableTiDBExtension bool
+	strictDecode        bool
+
+	// protobufRegistry looks up the schemas of the protobuf messages.
+	protobufRegistry *protobuf.SchemaRegistry
 
 	eventRouter *dispatcher.EventRouter
+	// orderChecker is not nil if the order of the events is verified.
+	orderChecker *orderChecker
 }
 
 // NewConsumer creates a new cdc kafka consumer
@@ -394,6 +418,16 @@ func NewConsumer(ctx context.Context) (*Consumer, error) {
 	}
 	c.protocol = protocol
 	c.enableTiDBExtension = enableTiDBExtension
+	c.strictDecode = strictDecode
+	if checkOrder {
+		c.orderChecker = newOrderChecker(kafkaPartitionNum)
+	}
+	if protocol == config.ProtocolProtobuf {
+		c.protobufRegistry, err = protobuf.NewSchemaRegistry(schemaRegistryURL)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
 
 	// this means user has input config file to enable dispatcher check
 	// some protocol does not provide enough information to check the
@@ -504,11 +538,21 @@ func (c *Consumer) ConsumeClaim(session sarama.ConsumerGroupSession, claim saram
 			decoder codec.EventBatchDecoder
 			err     error
 		)
+		// The values compressed by the codecs out of the Kafka protocol are
+		// decompressed by the codec named in the headers.
+		message.Value, err = pkafka.DecompressPayload(message.Value, message.Headers)
+		if err != nil {
+			log.Panic("decompress message value failed", zap.Error(err))
+		}
 		switch c.protocol {
 		case config.ProtocolOpen, config.ProtocolDefault:
-			decoder, err = open.NewBatchDecoder(message.Key, message.Value)
+			decoder, err = open.NewBatchDecoder(message.Key, message.Value,
+				codec.WithStrictDecode(c.strictDecode))
 		case config.ProtocolCanalJSON:
-			decoder = canal.NewBatchDecoder(message.Value, c.enableTiDBExtension, "")
+			decoder = canal.NewBatchDecoder(message.Value, c.enableTiDBExtension, "",
+				codec.WithStrictDecode(c.strictDecode))
+		case config.ProtocolProtobuf:
+			decoder = protobuf.NewBatchDecoder(ctx, c.protobufRegistry, message.Key, message.Value)
 		default:
 			log.Panic("Protocol not supported", zap.Any("Protocol", c.protocol))
 		}
@@ -566,6 +610,10 @@ func (c *Consumer) ConsumeClaim(session sarama.ConsumerGroupSession, claim saram
 					}
 				}
 
+				if c.orderChecker != nil {
+					c.orderChecker.checkRow(partition, message.Offset, row)
+				}
+
 				globalResolvedTs := atomic.LoadUint64(&c.globalResolvedTs)
 				if row.CommitTs <= globalResolvedTs || row.CommitTs <= sink.resolvedTs {
 					log.Warn("RowChangedEvent fallback row, ignore it",
@@ -597,9 +645,13 @@ func (c *Consumer) ConsumeClaim(session sarama.ConsumerGroupSession, claim saram
 				if err != nil {
 					log.Panic("decode message value failed", zap.ByteString("value", message.Value))
 				}
+				if c.orderChecker != nil {
+					c.orderChecker.checkResolvedTs(partition, message.Offset, ts)
+				}
 				resolvedTs := atomic.LoadUint64(&sink.resolvedTs)
 				// `resolvedTs` should be monotonically increasing, it's allowed to receive redundant one.
-				if ts < resolvedTs {
+				// The fallback is reported by the order checker if it's enabled.
+				if ts < resolvedTs && c.orderChecker == nil {
 					log.Panic("partition resolved ts fallback",
 						zap.Uint64("ts", ts),
 						zap.Uint64("resolvedTs", resolvedTs),
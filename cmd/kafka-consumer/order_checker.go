@@ -0,0 +1,211 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/cdc/model"
+	"go.uber.org/zap"
+)
+
+const (
+	// violationCommitTsRegression means a row has a smaller commit ts than
+	// the previous row of the same key.
+	violationCommitTsRegression = "commit-ts-regression"
+	// violationKeyAcrossPartitions means the rows of the same key are
+	// dispatched to different partitions, so their order is not guaranteed.
+	violationKeyAcrossPartitions = "key-across-partitions"
+	// violationRowBehindWatermark means a row is received after a resolved
+	// ts which is not less than its commit ts in the same partition.
+	violationRowBehindWatermark = "row-behind-watermark"
+	// violationWatermarkRegression means the resolved ts of a partition
+	// falls back.
+	violationWatermarkRegression = "watermark-regression"
+)
+
+// rowPosition is the position of the last row of a key.
+type rowPosition struct {
+	commitTs  uint64
+	partition int32
+	offset    int64
+}
+
+// orderChecker verifies the order of the events consumed from the topic,
+// the commit ts of the rows of a key must be monotonic, and a row must not
+// be received after the resolved ts which covers it. The violations are
+// logged with the partitions and offsets, instead of panicking, so that all
+// of them can be found in one pass.
+//
+// Note that the messages resent after a changefeed restarts are reported
+// too, since they can not be told apart from the disordered ones.
+type orderChecker struct {
+	mu           sync.Mutex
+	partitionNum int32
+	// lastRows is the position of the last row of each key. A key is
+	// removed once all partitions have a watermark not less than its
+	// commit ts, since any later row of it with a smaller commit ts is
+	// reported as behind the watermark anyway, so the map only holds the
+	// keys received after the min watermark.
+	lastRows map[string]rowPosition
+	// minWatermark is the min watermark of all partitions, the keys are
+	// pruned when it advances.
+	minWatermark uint64
+	// watermarks is the max resolved ts received of each partition.
+	watermarks map[int32]uint64
+	// violations is the count of the violations of each kind.
+	violations map[string]int
+}
+
+func newOrderChecker(partitionNum int32) *orderChecker {
+	return &orderChecker{
+		partitionNum: partitionNum,
+		lastRows:     make(map[string]rowPosition),
+		watermarks:   make(map[int32]uint64),
+		violations:   make(map[string]int),
+	}
+}
+
+// rowKey returns the key of a row, which consists of the table and the
+// values of the handle key columns. An empty key is returned if the row
+// has no handle key columns.
+func rowKey(row *model.RowChangedEvent) string {
+	cols := row.Columns
+	if row.IsDelete() {
+		cols = row.PreColumns
+	}
+	var b strings.Builder
+	for _, col := range cols {
+		if col == nil || !(col.Flag.IsHandleKey() || col.Flag.IsPrimaryKey()) {
+			continue
+		}
+		fmt.Fprintf(&b, "/%s=%v", col.Name, col.Value)
+	}
+	if b.Len() == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%s.%s%s", row.Table.Schema, row.Table.Table, b.String())
+}
+
+func (c *orderChecker) report(kind string, partition int32, offset int64, fields ...zap.Field) {
+	c.violations[kind]++
+	fields = append([]zap.Field{
+		zap.String("violation", kind),
+		zap.Int32("partition", partition),
+		zap.Int64("offset", offset),
+	}, fields...)
+	log.Warn("event order violation found", fields...)
+}
+
+// checkRow checks the row received at the offset of the partition.
+func (c *orderChecker) checkRow(partition int32, offset int64, row *model.RowChangedEvent) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if watermark := c.watermarks[partition]; row.CommitTs <= watermark {
+		c.report(violationRowBehindWatermark, partition, offset,
+			zap.Uint64("commitTs", row.CommitTs),
+			zap.Uint64("watermark", watermark),
+			zap.String("table", row.Table.String()))
+	}
+
+	key := rowKey(row)
+	if key == "" {
+		return
+	}
+	last, ok := c.lastRows[key]
+	if ok && last.partition != partition {
+		c.report(violationKeyAcrossPartitions, partition, offset,
+			zap.String("key", key),
+			zap.Int32("lastPartition", last.partition),
+			zap.Int64("lastOffset", last.offset))
+	}
+	// the rows of a key in the same transaction share the commit ts.
+	if ok && row.CommitTs < last.commitTs {
+		c.report(violationCommitTsRegression, partition, offset,
+			zap.String("key", key),
+			zap.Uint64("commitTs", row.CommitTs),
+			zap.Uint64("lastCommitTs", last.commitTs),
+			zap.Int32("lastPartition", last.partition),
+			zap.Int64("lastOffset", last.offset))
+	}
+	if !ok || row.CommitTs >= last.commitTs {
+		c.lastRows[key] = rowPosition{
+			commitTs:  row.CommitTs,
+			partition: partition,
+			offset:    offset,
+		}
+	}
+}
+
+// checkResolvedTs checks the resolved ts received at the offset of the partition.
+func (c *orderChecker) checkResolvedTs(partition int32, offset int64, ts uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	watermark := c.watermarks[partition]
+	if ts < watermark {
+		c.report(violationWatermarkRegression, partition, offset,
+			zap.Uint64("resolvedTs", ts),
+			zap.Uint64("watermark", watermark))
+		return
+	}
+	c.watermarks[partition] = ts
+	c.pruneKeys()
+}
+
+// pruneKeys removes the keys covered by the min watermark of all partitions.
+func (c *orderChecker) pruneKeys() {
+	if int32(len(c.watermarks)) < c.partitionNum {
+		return
+	}
+	minWatermark := uint64(math.MaxUint64)
+	for _, watermark := range c.watermarks {
+		if watermark < minWatermark {
+			minWatermark = watermark
+		}
+	}
+	if minWatermark <= c.minWatermark {
+		return
+	}
+	c.minWatermark = minWatermark
+	for key, last := range c.lastRows {
+		if last.commitTs <= minWatermark {
+			delete(c.lastRows, key)
+		}
+	}
+}
+
+// summary logs the count of the violations found, and returns the total.
+func (c *orderChecker) summary() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	total := 0
+	for _, count := range c.violations {
+		total += count
+	}
+	log.Info("event order check finished",
+		zap.Int("totalViolations", total),
+		zap.Int(violationCommitTsRegression, c.violations[violationCommitTsRegression]),
+		zap.Int(violationKeyAcrossPartitions, c.violations[violationKeyAcrossPartitions]),
+		zap.Int(violationRowBehindWatermark, c.violations[violationRowBehindWatermark]),
+		zap.Int(violationWatermarkRegression, c.violations[violationWatermarkRegression]),
+		zap.Int("checkedKeys", len(c.lastRows)))
+	return total
+}
@@ -0,0 +1,91 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"testing"
+
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/stretchr/testify/require"
+)
+
+func newOrderCheckerRow(id int, commitTs uint64) *model.RowChangedEvent {
+	return &model.RowChangedEvent{
+		CommitTs: commitTs,
+		Table:    &model.TableName{Schema: "test", Table: "t"},
+		Columns: []*model.Column{
+			{Name: "id", Value: id, Flag: model.HandleKeyFlag | model.PrimaryKeyFlag},
+			{Name: "v", Value: "v"},
+		},
+	}
+}
+
+func TestOrderCheckerRows(t *testing.T) {
+	t.Parallel()
+
+	c := newOrderChecker(2)
+	c.checkRow(0, 0, newOrderCheckerRow(1, 10))
+	c.checkRow(0, 1, newOrderCheckerRow(1, 10))
+	c.checkRow(0, 2, newOrderCheckerRow(1, 12))
+	require.Equal(t, 0, c.summary())
+
+	c.checkRow(0, 3, newOrderCheckerRow(1, 11))
+	require.Equal(t, 1, c.violations[violationCommitTsRegression])
+
+	c.checkRow(1, 0, newOrderCheckerRow(1, 13))
+	require.Equal(t, 1, c.violations[violationKeyAcrossPartitions])
+	require.Equal(t, rowPosition{commitTs: 13, partition: 1, offset: 0}, c.lastRows["test.t/id=1"])
+	require.Equal(t, 2, c.summary())
+}
+
+func TestOrderCheckerWatermarks(t *testing.T) {
+	t.Parallel()
+
+	c := newOrderChecker(2)
+	c.checkResolvedTs(0, 0, 10)
+	c.checkRow(0, 1, newOrderCheckerRow(1, 10))
+	require.Equal(t, 1, c.violations[violationRowBehindWatermark])
+	// the watermark of the other partition is not affected.
+	c.checkRow(1, 0, newOrderCheckerRow(2, 10))
+	require.Equal(t, 1, c.violations[violationRowBehindWatermark])
+
+	c.checkResolvedTs(0, 2, 9)
+	require.Equal(t, 1, c.violations[violationWatermarkRegression])
+	require.Equal(t, uint64(10), c.watermarks[0])
+	require.Equal(t, 2, c.summary())
+}
+
+func TestOrderCheckerPruneKeys(t *testing.T) {
+	t.Parallel()
+
+	c := newOrderChecker(2)
+	c.checkRow(0, 0, newOrderCheckerRow(1, 10))
+	c.checkRow(1, 0, newOrderCheckerRow(2, 20))
+	// keys are kept until all partitions have a watermark.
+	c.checkResolvedTs(0, 1, 15)
+	require.Len(t, c.lastRows, 2)
+
+	c.checkResolvedTs(1, 1, 15)
+	require.Len(t, c.lastRows, 1)
+	require.Contains(t, c.lastRows, "test.t/id=2")
+
+	// a regression of a pruned key is still reported.
+	c.checkRow(0, 2, newOrderCheckerRow(1, 9))
+	require.Equal(t, 1, c.violations[violationRowBehindWatermark])
+
+	c.checkResolvedTs(0, 3, 20)
+	c.checkResolvedTs(1, 2, 20)
+	require.Empty(t, c.lastRows)
+	require.Equal(t, 1, c.summary())
+}
@@ -40,6 +40,7 @@ import (
 	"github.com/pingcap/tiflow/cdc/sink/codec/csv"
 	sinkutil "github.com/pingcap/tiflow/cdc/sinkv2/util"
 	"github.com/pingcap/tiflow/pkg/cmd/util"
+	"github.com/pingcap/tiflow/pkg/compression"
 	"github.com/pingcap/tiflow/pkg/config"
 	"github.com/pingcap/tiflow/pkg/logutil"
 	"github.com/pingcap/tiflow/pkg/quotes"
@@ -223,6 +224,9 @@ type consumer struct {
 	codecCfg        *common.Config
 	externalStorage storage.ExternalStorage
 	fileExtension   string
+	// compressionCodec decompresses the data files, it's the compression
+	// of the upstream sink uri.
+	compressionCodec compression.Codec
 	// tableIdxMap maintains a map of <dmlPathKey, max file index>
 	tableIdxMap map[dmlPathKey]uint64
 	// tableTsMap maintains a map of <TableID, max commit ts>
@@ -263,7 +267,11 @@ func newConsumer(ctx context.Context) (*consumer, error) {
 		return nil, err
 	}
 
-	extension := sinkutil.GetFileExtension(protocol)
+	compressionCodec, err := compression.Get(upstreamURI.Query().Get("compression"))
+	if err != nil {
+		return nil, err
+	}
+	extension := sinkutil.GetFileExtension(protocol) + compressionCodec.Extension()
 
 	storage, err := putil.GetExternalStorageFromURI(ctx, upstreamURIStr)
 	if err != nil {
@@ -280,13 +288,14 @@ func newConsumer(ctx context.Context) (*consumer, error) {
 	}
 
 	return &consumer{
-		sink:            s,
-		replicationCfg:  replicaConfig,
-		codecCfg:        codecConfig,
-		externalStorage: storage,
-		fileExtension:   extension,
-		tableIdxMap:     make(map[dmlPathKey]uint64),
-		tableTsMap:      make(map[model.TableID]uint64),
+		sink:             s,
+		replicationCfg:   replicaConfig,
+		codecCfg:         codecConfig,
+		externalStorage:  storage,
+		fileExtension:    extension,
+		compressionCodec: compressionCodec,
+		tableIdxMap:      make(map[dmlPathKey]uint64),
+		tableTsMap:       make(map[model.TableID]uint64),
 		tableIDGenerator: &fakeTableIDGenerator{
 			tableIDs: make(map[string]int64),
 		},
@@ -328,7 +337,7 @@ func (c *consumer) getNewFiles(ctx context.Context) (map[dmlPathKey]fileIndexRan
 		var dmlkey dmlPathKey
 		var schemaKey schemaPathKey
 
-		if strings.HasSuffix(path, "metadata") {
+		if strings.HasSuffix(path, "metadata") || cloudstorage.IsFileStatsPath(path) {
 			return nil
 		}
 
@@ -499,6 +508,10 @@ func (c *consumer) run(ctx context.Context) error {
 				if err != nil {
 					return errors.Trace(err)
 				}
+				content, err = compression.Decompress(compression.ComponentStorageSink, c.compressionCodec, content)
+				if err != nil {
+					return errors.Trace(err)
+				}
 				tableID := c.tableIDGenerator.generateFakeTableID(
 					k.schema, k.table, k.partitionNum)
 				err = c.emitDMLEvents(ctx, tableID, k, content)
@@ -222,23 +222,32 @@ func TestVersionChecking(t *testing.T) {
 			AddRow("version", "5.7.26-log"))
 	}, cfgs)
 
-	// MariaDB should have a warning
+	// MariaDB in the compatibility matrix should pass
+
+	checkHappyPath(t, func() {
+		mock := initMockDB(t)
+		mock.ExpectQuery("SHOW GLOBAL VARIABLES LIKE 'version'").WillReturnRows(sqlmock.NewRows([]string{"Variable_name", "Value"}).
+			AddRow("version", "10.1.29-MariaDB"))
+	}, cfgs)
+
+	// too low MariaDB version should have a warning
 
 	mock := initMockDB(t)
 	mock.ExpectQuery("SHOW GLOBAL VARIABLES LIKE 'version'").WillReturnRows(sqlmock.NewRows([]string{"Variable_name", "Value"}).
-		AddRow("version", "10.1.29-MariaDB"))
+		AddRow("version", "10.0.38-MariaDB"))
 	msg, err := CheckSyncConfig(context.Background(), cfgs, common.DefaultErrorCnt, common.DefaultWarnCnt)
 	require.NoError(t, err)
-	require.Contains(t, msg, "Migrating from MariaDB is still experimental")
+	require.Contains(t, msg, "MariaDB version suggested at least 10.1.2 but got 10.0.38")
 
 	mock = initMockDB(t)
 	mock.ExpectQuery("SHOW GLOBAL VARIABLES LIKE 'version'").WillReturnRows(sqlmock.NewRows([]string{"Variable_name", "Value"}).
-		AddRow("version", "10.1.29-MariaDB"))
+		AddRow("version", "10.0.38-MariaDB"))
 	result, err := RunCheckOnConfigs(context.Background(), cfgs, false)
 	require.NoError(t, err)
 	require.True(t, result.Summary.Passed)
 	require.Equal(t, int64(1), result.Summary.Warning)
-	require.Contains(t, result.Results[0].Errors[0].ShortErr, "Migrating from MariaDB is still experimental.")
+	require.Contains(t, result.Results[0].Errors[0].ShortErr, "MariaDB version suggested at least")
+	require.Contains(t, result.Results[0].Instruction, "enable-gtid")
 
 	// too low MySQL version
 
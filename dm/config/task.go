@@ -104,6 +104,7 @@ var (
 	defaultQueueSize               = 1024 // do not give too large default value to avoid OOM
 	defaultCheckpointFlushInterval = 30   // in seconds
 	defaultSafeModeDuration        = strconv.Itoa(2*defaultCheckpointFlushInterval) + "s"
+	defaultLagHeartbeatInterval    = 1 // in seconds
 
 	// TargetDBConfig.
 	defaultSessionCfg = []struct {
@@ -388,6 +389,8 @@ type SyncerConfig struct {
 	// TODO: add this two new config items for openapi.
 	Compact      bool `yaml:"compact" toml:"compact" json:"compact"`
 	MultipleRows bool `yaml:"multiple-rows" toml:"multiple-rows" json:"multiple-rows"`
+	// the max time to wait for a batch to be filled up with `batch` jobs before executing it, 0 means never wait.
+	BatchLatency Duration `yaml:"batch-latency" toml:"batch-latency" json:"batch-latency"`
 
 	// deprecated
 	MaxRetry int `yaml:"max-retry" toml:"max-retry" json:"max-retry"`
@@ -401,6 +404,17 @@ type SyncerConfig struct {
 	SafeModeDuration string `yaml:"safe-mode-duration" toml:"safe-mode-duration" json:"safe-mode-duration"`
 	// deprecated, use `ansi-quotes` in top level config instead
 	EnableANSIQuotes bool `yaml:"enable-ansi-quotes" toml:"enable-ansi-quotes" json:"enable-ansi-quotes"`
+
+	// EnableLagHeartbeat writes a heartbeat row into the upstream periodically,
+	// and measures the replication lag when the row is observed in the downstream.
+	EnableLagHeartbeat bool `yaml:"enable-lag-heartbeat" toml:"enable-lag-heartbeat" json:"enable-lag-heartbeat"`
+	// heartbeat update interval in seconds.
+	LagHeartbeatInterval int `yaml:"lag-heartbeat-interval" toml:"lag-heartbeat-interval" json:"lag-heartbeat-interval"`
+
+	// ConflictQuarantine moves the row changes which meet duplicate-key conflicts
+	// into a quarantine table in the meta schema instead of failing the task,
+	// so merging the sharded tables can go on and the rows can be replayed later.
+	ConflictQuarantine bool `yaml:"conflict-quarantine" toml:"conflict-quarantine" json:"conflict-quarantine"`
 }
 
 // DefaultSyncerConfig return default syncer config for task.
@@ -411,6 +425,7 @@ func DefaultSyncerConfig() SyncerConfig {
 		QueueSize:               defaultQueueSize,
 		CheckpointFlushInterval: defaultCheckpointFlushInterval,
 		SafeModeDuration:        defaultSafeModeDuration,
+		LagHeartbeatInterval:    defaultLagHeartbeatInterval,
 	}
 }
 
@@ -872,6 +887,9 @@ func (c *TaskConfig) adjust() error {
 		if inst.Syncer.SafeModeDuration == "" {
 			inst.Syncer.SafeModeDuration = strconv.Itoa(2*inst.Syncer.CheckpointFlushInterval) + "s"
 		}
+		if inst.Syncer.LagHeartbeatInterval <= 0 {
+			inst.Syncer.LagHeartbeatInterval = defaultLagHeartbeatInterval
+		}
 		if duration, err := time.ParseDuration(inst.Syncer.SafeModeDuration); err != nil {
 			return terror.ErrConfigInvalidSafeModeDuration.Generate(inst.Syncer.SafeModeDuration, err)
 		} else if inst.Syncer.SafeMode && duration == 0 {
@@ -1156,9 +1174,13 @@ type SyncerConfigForDowngrade struct {
 	SafeMode                bool   `yaml:"safe-mode"`
 	EnableANSIQuotes        bool   `yaml:"enable-ansi-quotes"`
 
-	SafeModeDuration string `yaml:"safe-mode-duration,omitempty"`
-	Compact          bool   `yaml:"compact,omitempty"`
-	MultipleRows     bool   `yaml:"multipleRows,omitempty"`
+	SafeModeDuration     string   `yaml:"safe-mode-duration,omitempty"`
+	Compact              bool     `yaml:"compact,omitempty"`
+	MultipleRows         bool     `yaml:"multipleRows,omitempty"`
+	BatchLatency         Duration `yaml:"batch-latency,omitempty"`
+	EnableLagHeartbeat   bool     `yaml:"enable-lag-heartbeat,omitempty"`
+	LagHeartbeatInterval int      `yaml:"lag-heartbeat-interval,omitempty"`
+	ConflictQuarantine   bool     `yaml:"conflict-quarantine,omitempty"`
 }
 
 // NewSyncerConfigsForDowngrade converts SyncerConfig to SyncerConfigForDowngrade.
@@ -1179,6 +1201,10 @@ func NewSyncerConfigsForDowngrade(syncerConfigs map[string]*SyncerConfig) map[st
 			EnableANSIQuotes:        syncerConfig.EnableANSIQuotes,
 			Compact:                 syncerConfig.Compact,
 			MultipleRows:            syncerConfig.MultipleRows,
+			EnableLagHeartbeat:      syncerConfig.EnableLagHeartbeat,
+			LagHeartbeatInterval:    syncerConfig.LagHeartbeatInterval,
+			BatchLatency:            syncerConfig.BatchLatency,
+			ConflictQuarantine:      syncerConfig.ConflictQuarantine,
 		}
 		syncerConfigsForDowngrade[configName] = newSyncerConfig
 	}
@@ -1192,6 +1218,9 @@ func (c *SyncerConfigForDowngrade) omitDefaultVals() {
 	if c.SafeModeDuration == strconv.Itoa(2*c.CheckpointFlushInterval)+"s" {
 		c.SafeModeDuration = ""
 	}
+	if c.LagHeartbeatInterval == defaultLagHeartbeatInterval {
+		c.LagHeartbeatInterval = 0
+	}
 }
 
 // TaskConfigForDowngrade is the base configuration for task in v2.0.
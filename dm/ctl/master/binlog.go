@@ -54,6 +54,7 @@ func newBinlogSkipCmd() *cobra.Command {
 			return sendHandleErrorRequest(cmd, request)
 		},
 	}
+	addScopeFlags(cmd)
 	return cmd
 }
 
@@ -98,6 +99,7 @@ func newBinlogReplaceCmd() *cobra.Command {
 			return sendHandleErrorRequest(cmd, request)
 		},
 	}
+	addScopeFlags(cmd)
 	return cmd
 }
 
@@ -33,9 +33,17 @@ func NewHandleErrorCmd() *cobra.Command {
 		RunE:   handleErrorFunc,
 	}
 	cmd.Flags().StringP("binlog-pos", "b", "", "position used to match binlog event if matched the handler-error operation will be applied. The format like \"mysql-bin|000001.000003:3270\"")
+	addScopeFlags(cmd)
 	return cmd
 }
 
+// addScopeFlags adds the flags which limit `skip`/`replace` operation to some
+// tables and event types in the transaction.
+func addScopeFlags(cmd *cobra.Command) {
+	cmd.Flags().StringSlice("table", nil, "table filter rules like \"db.tbl*\", if set, the operation is only applied to the events of these tables in the transaction at the position")
+	cmd.Flags().StringSlice("event-type", nil, "event types in insert, update, delete, all dml and all ddl, if set, the operation is only applied to the events of these types in the transaction at the position")
+}
+
 func convertOp(t string) pb.ErrorOp {
 	switch t {
 	case "skip":
@@ -113,6 +121,14 @@ func sendHandleErrorRequest(cmd *cobra.Command, request *pb.HandleErrorRequest)
 
 	request.BinlogPos = binlogPos
 	request.Sources = sources
+	if cmd.Flags().Lookup("table") != nil {
+		if request.Tables, err = cmd.Flags().GetStringSlice("table"); err != nil {
+			return err
+		}
+		if request.EventTypes, err = cmd.Flags().GetStringSlice("event-type"); err != nil {
+			return err
+		}
+	}
 
 	resp := &pb.HandleErrorResponse{}
 	err = common.SendRequest(
@@ -23,11 +23,13 @@ import (
 	"github.com/pingcap/errors"
 	"github.com/pingcap/failpoint"
 	"github.com/pingcap/tidb/dumpling/export"
+	"github.com/pingcap/tidb/util/dbutil"
 	tidbpromutil "github.com/pingcap/tidb/util/promutil"
 	filter "github.com/pingcap/tidb/util/table-filter"
 	"github.com/pingcap/tiflow/dm/config"
 	"github.com/pingcap/tiflow/dm/pb"
 	"github.com/pingcap/tiflow/dm/pkg/binlog"
+	"github.com/pingcap/tiflow/dm/pkg/checker"
 	"github.com/pingcap/tiflow/dm/pkg/conn"
 	tcontext "github.com/pingcap/tiflow/dm/pkg/context"
 	dutils "github.com/pingcap/tiflow/dm/pkg/dumpling"
@@ -53,6 +55,13 @@ type Dumpling struct {
 	closed     atomic.Bool
 	core       *export.Dumper
 	mu         sync.RWMutex
+
+	// gtidSnapshot means the dump is with "gtid-snapshot" consistency and
+	// should be verified after it finishes.
+	gtidSnapshot bool
+	// strictSnapshot means "gtid-snapshot" is specified by the user rather
+	// than selected for "auto", then an inconsistent dump fails the unit.
+	strictSnapshot bool
 }
 
 // NewDumpling creates a new Dumpling.
@@ -208,6 +217,14 @@ func (m *Dumpling) Process(ctx context.Context, pr chan pb.ProcessResult) {
 	default:
 	}
 
+	if len(errs) == 0 && m.gtidSnapshot && !isCanceled {
+		if err = m.verifySnapshot(ctx); err != nil {
+			processError := unit.NewProcessError(err)
+			m.handleExitErrMetric(processError)
+			errs = append(errs, processError)
+		}
+	}
+
 	if len(errs) == 0 {
 		m.logger.Info("dump data finished", zap.Duration("cost time", time.Since(begin)))
 	} else {
@@ -401,6 +418,7 @@ func (m *Dumpling) constructArgs(ctx context.Context) (*export.Config, error) {
 		}
 	}
 
+	m.detectConsistency(ctx, dumpConfig)
 	// record exit position when consistency is none, to support scenarios like Aurora upstream
 	if dumpConfig.Consistency == "none" {
 		dumpConfig.PosAfterConnect = true
@@ -421,6 +439,90 @@ func (m *Dumpling) constructArgs(ctx context.Context) (*export.Config, error) {
 	return dumpConfig, nil
 }
 
+// detectConsistency selects the consistency to dump with. "auto" turns to
+// "gtid-snapshot" when `FLUSH TABLES WITH READ LOCK` is unavailable, like on
+// RDS or Aurora, and "gtid-snapshot" is dumped as "none" then verified.
+func (m *Dumpling) detectConsistency(ctx context.Context, dumpCfg *export.Config) {
+	m.strictSnapshot = dumpCfg.Consistency == dutils.ConsistencyGTIDSnapshot
+	if dumpCfg.Consistency == dutils.ConsistencyAuto {
+		canFlush, err := m.canFlushTablesWithReadLock(ctx)
+		if err != nil {
+			m.logger.Warn("check whether can flush tables with read lock failed, keep auto consistency", zap.Error(err))
+			return
+		}
+		dumpCfg.Consistency = dutils.SelectConsistency(dumpCfg.Consistency, canFlush)
+	}
+	if dumpCfg.Consistency == dutils.ConsistencyGTIDSnapshot {
+		m.logger.Info("dump with gtid-snapshot consistency, FLUSH TABLES WITH READ LOCK will not be used")
+		dumpCfg.Consistency = dutils.ConsistencyNone
+		m.gtidSnapshot = true
+	}
+}
+
+// canFlushTablesWithReadLock returns whether the upstream account can execute
+// `FLUSH TABLES WITH READ LOCK`. It's probed by executing the statement, since
+// Aurora forbids it even if the account has the RELOAD privilege. TiDB doesn't
+// need it because dumpling dumps TiDB with "snapshot" for "auto".
+func (m *Dumpling) canFlushTablesWithReadLock(ctx context.Context) (bool, error) {
+	baseDB, err := conn.GetUpstreamDB(&m.cfg.From)
+	if err != nil {
+		return false, err
+	}
+	defer baseDB.Close()
+
+	version, err := dbutil.ShowVersion(ctx, baseDB.DB)
+	if err != nil {
+		return false, err
+	}
+	if checker.IsTiDBFromVersion(version) {
+		return true, nil
+	}
+
+	// the lock is held by the session, so the statements run on one connection.
+	c, err := baseDB.DB.Conn(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer c.Close()
+	if _, err = c.ExecContext(ctx, "FLUSH TABLES WITH READ LOCK"); err != nil {
+		if conn.IsErrAccessDenied(err) {
+			m.logger.Info("FLUSH TABLES WITH READ LOCK is unavailable", zap.Error(err))
+			return false, nil
+		}
+		return false, err
+	}
+	// closing the connection releases the lock even if UNLOCK TABLES fails.
+	if _, err = c.ExecContext(ctx, "UNLOCK TABLES"); err != nil {
+		m.logger.Warn("fail to unlock tables after probing FLUSH TABLES WITH READ LOCK", zap.Error(err))
+	}
+	return true, nil
+}
+
+// verifySnapshot verifies the dump with "gtid-snapshot" consistency. If any
+// transaction is committed while the connections are established, the
+// connections may see different snapshots. The dump fails then if
+// "gtid-snapshot" is specified by the user, otherwise the syncer replicates in
+// safe mode until the location after the connections are established.
+func (m *Dumpling) verifySnapshot(ctx context.Context) error {
+	loc, locAfterConnect, err := dutils.ParseMetaData(ctx, m.cfg.Dir, "metadata", m.cfg.Flavor, m.cfg.ExtStorage)
+	if err != nil {
+		return terror.ErrDumpUnitRuntime.Delegate(err, "fail to parse metadata to verify the snapshot")
+	}
+	if locAfterConnect == nil {
+		return terror.ErrDumpUnitRuntime.Generate("the exit location is not recorded in metadata, can't verify the snapshot")
+	}
+	if dutils.IsSnapshotConsistent(loc, locAfterConnect) {
+		m.logger.Info("dump data is verified as snapshot consistent", zap.Stringer("location", loc))
+		return nil
+	}
+	if m.strictSnapshot {
+		return terror.ErrDumpUnitRuntime.Generatef("dump data is not snapshot consistent, location %s, exit location %s, please resume the task to dump again", loc, locAfterConnect)
+	}
+	m.logger.Warn("dump data is not snapshot consistent, syncer will enable safe mode until the exit location",
+		zap.Stringer("location", loc), zap.Stringer("exit location", locAfterConnect))
+	return nil
+}
+
 // detectSQLMode tries to detect SQL mode from upstream. If success, write it to LoaderConfig.
 // Because loader will use this SQL mode, we need to treat disable `EscapeBackslash` when NO_BACKSLASH_ESCAPES.
 func (m *Dumpling) detectSQLMode(ctx context.Context, dumpCfg *export.Config) {
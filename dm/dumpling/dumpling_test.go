@@ -15,14 +15,18 @@ package dumpling
 
 import (
 	"context"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/docker/go-units"
+	"github.com/go-sql-driver/mysql"
 	. "github.com/pingcap/check"
 	"github.com/pingcap/failpoint"
 	"github.com/pingcap/tidb/dumpling/export"
+	tmysql "github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tidb/util/filter"
 	tidbpromutil "github.com/pingcap/tidb/util/promutil"
 	tfilter "github.com/pingcap/tidb/util/table-filter"
@@ -215,6 +219,79 @@ func (t *testDumplingSuite) TestConstructArgs(c *C) {
 	c.Assert(exportCfg.SessionParams["time_zone"], Equals, "+01:00")
 }
 
+func (t *testDumplingSuite) TestCanFlushTablesWithReadLock(c *C) {
+	ctx := context.Background()
+	d := NewDumpling(&config.SubTaskConfig{})
+
+	mock := conn.InitMockDB(c)
+	mock.ExpectQuery("SELECT version()").
+		WillReturnRows(sqlmock.NewRows([]string{"version()"}).AddRow("8.0.28"))
+	mock.ExpectExec("FLUSH TABLES WITH READ LOCK").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("UNLOCK TABLES").WillReturnResult(sqlmock.NewResult(0, 0))
+	canFlush, err := d.canFlushTablesWithReadLock(ctx)
+	c.Assert(err, IsNil)
+	c.Assert(canFlush, IsTrue)
+	c.Assert(mock.ExpectationsWereMet(), IsNil)
+
+	// Aurora forbids FTWRL even if the account has the RELOAD privilege.
+	mock = conn.InitMockDB(c)
+	mock.ExpectQuery("SELECT version()").
+		WillReturnRows(sqlmock.NewRows([]string{"version()"}).AddRow("8.0.mysql_aurora.3.02.0"))
+	mock.ExpectExec("FLUSH TABLES WITH READ LOCK").
+		WillReturnError(&mysql.MySQLError{Number: tmysql.ErrAccessDenied, Message: "Access denied for user 'admin'@'%'"})
+	canFlush, err = d.canFlushTablesWithReadLock(ctx)
+	c.Assert(err, IsNil)
+	c.Assert(canFlush, IsFalse)
+	c.Assert(mock.ExpectationsWereMet(), IsNil)
+
+	// TiDB doesn't need FTWRL.
+	mock = conn.InitMockDB(c)
+	mock.ExpectQuery("SELECT version()").
+		WillReturnRows(sqlmock.NewRows([]string{"version()"}).AddRow("5.7.25-TiDB-v6.5.0"))
+	canFlush, err = d.canFlushTablesWithReadLock(ctx)
+	c.Assert(err, IsNil)
+	c.Assert(canFlush, IsTrue)
+	c.Assert(mock.ExpectationsWereMet(), IsNil)
+}
+
+func TestVerifySnapshot(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	cfg := &config.SubTaskConfig{Flavor: "mysql"}
+	cfg.Dir = t.TempDir()
+	d := NewDumpling(cfg)
+	writeMetadata := func(afterConnectGTID string) {
+		metadata := `SHOW MASTER STATUS:
+	Log: mysql-bin.000003
+	Pos: 1274
+	GTID:97b5142f-e19c-11e8-808c-0242ac110005:1-13
+
+SHOW MASTER STATUS: /* AFTER CONNECTION POOL ESTABLISHED */
+	Log: mysql-bin.000003
+	Pos: 1274
+	GTID:` + afterConnectGTID + "\n"
+		require.Nil(t, os.WriteFile(filepath.Join(cfg.Dir, "metadata"), []byte(metadata), 0o644))
+	}
+
+	writeMetadata("97b5142f-e19c-11e8-808c-0242ac110005:1-13")
+	require.Nil(t, d.verifySnapshot(ctx))
+
+	// a transaction is committed while the connections are established.
+	writeMetadata("97b5142f-e19c-11e8-808c-0242ac110005:1-14")
+	require.Nil(t, d.verifySnapshot(ctx))
+	d.strictSnapshot = true
+	require.Regexp(t, "not snapshot consistent", d.verifySnapshot(ctx))
+
+	// the exit location is required to verify the snapshot.
+	require.Nil(t, os.WriteFile(filepath.Join(cfg.Dir, "metadata"), []byte(`SHOW MASTER STATUS:
+	Log: mysql-bin.000003
+	Pos: 1274
+	GTID:97b5142f-e19c-11e8-808c-0242ac110005:1-13
+`), 0o644))
+	require.Regexp(t, "exit location is not recorded", d.verifySnapshot(ctx))
+}
+
 func genDumpCfg(t *testing.T) *config.SubTaskConfig {
 	t.Helper()
 
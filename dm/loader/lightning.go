@@ -36,6 +36,7 @@ import (
 	"github.com/pingcap/tiflow/dm/pkg/binlog"
 	"github.com/pingcap/tiflow/dm/pkg/conn"
 	"github.com/pingcap/tiflow/dm/pkg/log"
+	dmrouter "github.com/pingcap/tiflow/dm/pkg/router"
 	"github.com/pingcap/tiflow/dm/pkg/storage"
 	"github.com/pingcap/tiflow/dm/pkg/terror"
 	"github.com/pingcap/tiflow/dm/pkg/utils"
@@ -79,6 +80,7 @@ type LightningLoader struct {
 	lastErr        error
 
 	speedRecorder *export.SpeedRecorder
+	tableProgress *tableProgress
 }
 
 // NewLightning creates a new Loader importing data with lightning.
@@ -192,13 +194,7 @@ func (l *LightningLoader) ignoreCheckpointError(ctx context.Context, cfg *lcfg.C
 	if status != lightningStatusRunning {
 		return nil
 	}
-	var cpdb checkpoints.DB
-	if l.cfg.ExtStorage != nil {
-		cpdb, err = checkpoints.NewFileCheckpointsDBWithExstorageFileName(
-			ctx, l.cfg.ExtStorage.URI(), l.cfg.ExtStorage, lightningCheckpointFileName)
-	} else {
-		cpdb, err = checkpoints.OpenCheckpointsDB(ctx, cfg)
-	}
+	cpdb, err := l.openCheckpointsDB(ctx, cfg)
 	if err != nil {
 		return err
 	}
@@ -208,6 +204,14 @@ func (l *LightningLoader) ignoreCheckpointError(ctx context.Context, cfg *lcfg.C
 	return errors.Trace(cpdb.IgnoreErrorCheckpoint(ctx, "all"))
 }
 
+func (l *LightningLoader) openCheckpointsDB(ctx context.Context, cfg *lcfg.Config) (checkpoints.DB, error) {
+	if l.cfg.ExtStorage != nil {
+		return checkpoints.NewFileCheckpointsDBWithExstorageFileName(
+			ctx, l.cfg.ExtStorage.URI(), l.cfg.ExtStorage, lightningCheckpointFileName)
+	}
+	return checkpoints.OpenCheckpointsDB(ctx, cfg)
+}
+
 func (l *LightningLoader) runLightning(ctx context.Context, cfg *lcfg.Config) (err error) {
 	taskCtx, cancel := context.WithCancel(ctx)
 	l.Lock()
@@ -370,11 +374,23 @@ func GetLightningConfig(globalCfg *lcfg.GlobalConfig, subtaskCfg *config.SubTask
 	return cfg, nil
 }
 
-func (l *LightningLoader) getLightningConfig() (*lcfg.Config, error) {
+func (l *LightningLoader) getLightningConfig(ctx context.Context) (*lcfg.Config, error) {
 	cfg, err := GetLightningConfig(l.lightningGlobalConfig, l.cfg)
 	if err != nil {
 		return nil, err
 	}
+	// lightning doesn't support capture groups in route targets, so expand
+	// them to the rules of the dumped tables.
+	if dmrouter.HasCaptureGroupReference(l.cfg.RouteRules) {
+		tables, err2 := collectDumpedTables(ctx, l.cfg.LoaderConfig.Dir)
+		if err2 != nil {
+			return nil, err2
+		}
+		cfg.Routes, err = dmrouter.ExpandRules(l.cfg.CaseSensitive, l.cfg.RouteRules, tables)
+		if err != nil {
+			return nil, terror.ErrLoadUnitGenTableRouter.Delegate(err)
+		}
+	}
 	cfg.TiDB.StrSQLMode = l.sqlMode
 	cfg.TiDB.Vars["time_zone"] = l.timeZone
 	return cfg, nil
@@ -412,10 +428,20 @@ func (l *LightningLoader) restore(ctx context.Context) error {
 			return err
 		}
 		var cfg *lcfg.Config
-		cfg, err = l.getLightningConfig()
+		cfg, err = l.getLightningConfig(ctx)
 		if err != nil {
 			return err
 		}
+		progress, err2 := newTableProgress(ctx, l.cfg, cfg.Routes, func(ctx context.Context) (checkpoints.DB, error) {
+			return l.openCheckpointsDB(ctx, cfg)
+		}, l.logger)
+		if err2 != nil {
+			l.logger.Warn("fail to estimate the progress of the tables", log.ShortError(err2))
+		} else {
+			l.Lock()
+			l.tableProgress = progress
+			l.Unlock()
+		}
 		if err2 := readyAndWait(ctx, l.cli, l.cfg); err2 != nil {
 			return err2
 		}
@@ -574,6 +600,9 @@ func (l *LightningLoader) status() *pb.LoadStatus {
 	finished, total := l.core.Status()
 	progress := percent(finished, total, l.finish.Load())
 	currentSpeed := int64(l.speedRecorder.GetSpeed(float64(finished)))
+	l.RLock()
+	tableProgress := l.tableProgress
+	l.RUnlock()
 
 	l.logger.Info("progress status of lightning",
 		zap.Int64("finished_bytes", finished),
@@ -588,6 +617,10 @@ func (l *LightningLoader) status() *pb.LoadStatus {
 		MetaBinlog:     l.metaBinlog.Load(),
 		MetaBinlogGTID: l.metaBinlogGTID.Load(),
 		Bps:            currentSpeed,
+		Eta:            estimateETA(total-finished, currentSpeed),
+	}
+	if tableProgress != nil {
+		s.Tables = tableProgress.status(context.Background(), l.finish.Load())
 	}
 	return s
 }
@@ -596,3 +629,24 @@ func (l *LightningLoader) status() *pb.LoadStatus {
 func (l *LightningLoader) Status(_ *binlog.SourceStatus) interface{} {
 	return l.status()
 }
+
+// collectDumpedTables returns the dumped tables in dir, as a map of schema -> tables.
+func collectDumpedTables(ctx context.Context, dir string) (map[string][]string, error) {
+	files, err := storage.CollectDirFiles(ctx, dir, nil)
+	if err != nil {
+		return nil, err
+	}
+	tables := make(map[string][]string)
+	for file := range files {
+		if db, ok := utils.GetDBFromDumpFilename(file); ok {
+			if _, ok2 := tables[db]; !ok2 {
+				tables[db] = nil
+			}
+			continue
+		}
+		if db, table, ok := utils.GetTableFromDumpFilename(file); ok {
+			tables[db] = append(tables[db], table)
+		}
+	}
+	return tables, nil
+}
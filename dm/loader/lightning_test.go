@@ -14,10 +14,14 @@
 package loader
 
 import (
+	"context"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/pingcap/errors"
 	"github.com/pingcap/tidb/br/pkg/lightning/common"
+	router "github.com/pingcap/tidb/util/table-router"
 	"github.com/pingcap/tiflow/dm/config"
 	"github.com/pingcap/tiflow/dm/pkg/terror"
 	"github.com/stretchr/testify/require"
@@ -32,11 +36,41 @@ func TestSetLightningConfig(t *testing.T) {
 		},
 	}
 	l := NewLightning(stCfg, nil, "")
-	cfg, err := l.getLightningConfig()
+	cfg, err := l.getLightningConfig(context.Background())
 	require.NoError(t, err)
 	require.Equal(t, stCfg.LoaderConfig.PoolSize, cfg.App.RegionConcurrency)
 }
 
+func TestLightningConfigWithCaptureGroups(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	for _, file := range []string{
+		"shard_01-schema-create.sql",
+		"shard_01.t_1-schema.sql",
+		"shard_01.t_1.000000000.sql",
+		"shard_02-schema-create.sql",
+	} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, file), nil, 0o644))
+	}
+
+	stCfg := &config.SubTaskConfig{
+		LoaderConfig: config.LoaderConfig{
+			PoolSize: 10,
+			Dir:      dir,
+		},
+		RouteRules: []*router.TableRule{
+			{SchemaPattern: "~^shard_(\\d+)$", TablePattern: "~^t_(\\d+)$", TargetSchema: "merged", TargetTable: "t_$1_$2"},
+		},
+	}
+	l := NewLightning(stCfg, nil, "")
+	cfg, err := l.getLightningConfig(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []*router.TableRule{
+		{SchemaPattern: "shard_01", TablePattern: "t_1", TargetSchema: "merged", TargetTable: "t_01_1"},
+	}, cfg.Routes)
+}
+
 func TestConvertLightningError(t *testing.T) {
 	t.Parallel()
 
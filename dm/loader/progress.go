@@ -0,0 +1,181 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loader
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pingcap/tidb/br/pkg/lightning/checkpoints"
+	"github.com/pingcap/tidb/br/pkg/lightning/common"
+	"github.com/pingcap/tidb/dumpling/export"
+	router "github.com/pingcap/tidb/util/table-router"
+	"github.com/pingcap/tiflow/dm/config"
+	"github.com/pingcap/tiflow/dm/pb"
+	"github.com/pingcap/tiflow/dm/pkg/log"
+	dmrouter "github.com/pingcap/tiflow/dm/pkg/router"
+	"github.com/pingcap/tiflow/dm/pkg/storage"
+	"github.com/pingcap/tiflow/dm/pkg/utils"
+)
+
+// tableProgressInterval is the min interval to estimate the progress of the
+// tables again, because it reads the whole checkpoints of lightning.
+const tableProgressInterval = 10 * time.Second
+
+// tableProgress estimates the load progress of each target table. The total
+// bytes of a table are the sizes of its dumped data files, and the finished
+// bytes are estimated by the chunk checkpoints of lightning.
+type tableProgress struct {
+	mu sync.Mutex
+
+	logger log.Logger
+	// tables are the sorted target tables, named like lightning does.
+	tables          []string
+	totalBytes      map[string]int64
+	speedRecorders  map[string]*export.SpeedRecorder
+	openCheckpoints func(ctx context.Context) (checkpoints.DB, error)
+
+	lastUpdate time.Time
+	statuses   []*pb.TableLoadStatus
+}
+
+// newTableProgress creates a tableProgress from the dumped data files, which
+// are routed to the target tables by the route rules of lightning.
+func newTableProgress(
+	ctx context.Context,
+	cfg *config.SubTaskConfig,
+	routes []*router.TableRule,
+	openCheckpoints func(ctx context.Context) (checkpoints.DB, error),
+	logger log.Logger,
+) (*tableProgress, error) {
+	sizes, err := storage.CollectDirFileSizes(ctx, cfg.LoaderConfig.Dir, cfg.ExtStorage)
+	if err != nil {
+		return nil, err
+	}
+	r, err := dmrouter.NewRouteTable(cfg.CaseSensitive, routes)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &tableProgress{
+		logger:          logger,
+		totalBytes:      make(map[string]int64),
+		speedRecorders:  make(map[string]*export.SpeedRecorder),
+		openCheckpoints: openCheckpoints,
+	}
+	for file, size := range sizes {
+		schema, table, ok := utils.GetTableFromDataFilename(file)
+		if !ok {
+			continue
+		}
+		targetSchema, targetTable, err := r.Route(schema, table)
+		if err != nil {
+			return nil, err
+		}
+		if targetSchema == "" {
+			targetSchema = schema
+		}
+		if targetTable == "" {
+			targetTable = table
+		}
+		name := common.UniqueTable(targetSchema, targetTable)
+		if _, ok := p.totalBytes[name]; !ok {
+			p.tables = append(p.tables, name)
+			p.speedRecorders[name] = export.NewSpeedRecorder()
+		}
+		p.totalBytes[name] += size
+	}
+	sort.Strings(p.tables)
+	return p, nil
+}
+
+// status returns the load status of the tables, finished means lightning has
+// loaded all the tables.
+func (p *tableProgress) status(ctx context.Context, finished bool) []*pb.TableLoadStatus {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if !finished && time.Since(p.lastUpdate) < tableProgressInterval {
+		return p.statuses
+	}
+
+	var cpdb checkpoints.DB
+	if !finished {
+		var err error
+		cpdb, err = p.openCheckpoints(ctx)
+		if err != nil {
+			p.logger.Warn("fail to open lightning checkpoints to estimate the progress of the tables", log.ShortError(err))
+			return p.statuses
+		}
+		// don't close the file checkpoints DB, closing it saves the checkpoints
+		// read here, which may overwrite the newer ones written by lightning.
+		// It holds no other resource.
+	}
+
+	statuses := make([]*pb.TableLoadStatus, 0, len(p.tables))
+	for _, table := range p.tables {
+		total := p.totalBytes[table]
+		finishedBytes := total
+		if !finished {
+			finishedBytes = 0
+			cp, err := cpdb.Get(ctx, table)
+			if err == nil {
+				finishedBytes = estimateFinishedBytes(cp, total)
+			}
+		}
+		bps := int64(p.speedRecorders[table].GetSpeed(float64(finishedBytes)))
+		statuses = append(statuses, &pb.TableLoadStatus{
+			Table:         table,
+			FinishedBytes: finishedBytes,
+			TotalBytes:    total,
+			Progress:      percent(finishedBytes, total, finished),
+			Bps:           bps,
+			Eta:           estimateETA(total-finishedBytes, bps),
+		})
+	}
+	p.statuses = statuses
+	p.lastUpdate = time.Now()
+	return statuses
+}
+
+// estimateFinishedBytes estimates the finished bytes of a table by the ratio
+// of the restored offsets of its chunks, because the offsets in compressed
+// files can't be compared with the file sizes.
+func estimateFinishedBytes(cp *checkpoints.TableCheckpoint, total int64) int64 {
+	if cp.Status >= checkpoints.CheckpointStatusAllWritten {
+		return total
+	}
+	var finished, chunkTotal int64
+	for _, engine := range cp.Engines {
+		for _, chunk := range engine.Chunks {
+			finished += chunk.Chunk.Offset - chunk.Key.Offset
+			chunkTotal += chunk.Chunk.EndOffset - chunk.Key.Offset
+		}
+	}
+	if chunkTotal <= 0 {
+		return 0
+	}
+	return int64(float64(total) * float64(finished) / float64(chunkTotal))
+}
+
+// estimateETA returns the estimated seconds to load the remaining bytes in
+// the speed, 0 if it's finished or unknown.
+func estimateETA(remaining, bps int64) int64 {
+	if remaining <= 0 || bps <= 0 {
+		return 0
+	}
+	return (remaining + bps - 1) / bps
+}
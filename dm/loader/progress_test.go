@@ -0,0 +1,95 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loader
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pingcap/tidb/br/pkg/lightning/checkpoints"
+	"github.com/pingcap/tidb/br/pkg/lightning/mydump"
+	router "github.com/pingcap/tidb/util/table-router"
+	"github.com/pingcap/tiflow/dm/config"
+	"github.com/pingcap/tiflow/dm/pkg/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTableProgress(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	for file, size := range map[string]int{
+		"shard_01-schema-create.sql": 10,
+		"shard_01.t-schema.sql":      10,
+		"shard_01.t.000000000.sql":   100,
+		"shard_01.t.000000001.sql":   50,
+		"shard_02.t.000000000.sql":   30,
+		"db.other.sql":               20,
+		"metadata":                   10,
+	} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, file), make([]byte, size), 0o644))
+	}
+	cfg := &config.SubTaskConfig{LoaderConfig: config.LoaderConfig{Dir: dir}}
+	routes := []*router.TableRule{
+		{SchemaPattern: "shard_*", TablePattern: "t", TargetSchema: "merged", TargetTable: "t"},
+	}
+
+	p, err := newTableProgress(context.Background(), cfg, routes, nil, log.L())
+	require.NoError(t, err)
+	require.Equal(t, []string{"`db`.`other`", "`merged`.`t`"}, p.tables)
+	require.Equal(t, map[string]int64{"`db`.`other`": 20, "`merged`.`t`": 180}, p.totalBytes)
+
+	statuses := p.status(context.Background(), true)
+	require.Len(t, statuses, 2)
+	require.Equal(t, "`merged`.`t`", statuses[1].Table)
+	require.Equal(t, int64(180), statuses[1].FinishedBytes)
+	require.Equal(t, int64(180), statuses[1].TotalBytes)
+	require.Equal(t, "100.00 %", statuses[1].Progress)
+	require.Equal(t, int64(0), statuses[1].Eta)
+}
+
+func TestEstimateFinishedBytes(t *testing.T) {
+	t.Parallel()
+
+	newChunk := func(start, offset, end int64) *checkpoints.ChunkCheckpoint {
+		return &checkpoints.ChunkCheckpoint{
+			Key:   checkpoints.ChunkCheckpointKey{Offset: start},
+			Chunk: mydump.Chunk{Offset: offset, EndOffset: end},
+		}
+	}
+	cp := &checkpoints.TableCheckpoint{
+		Status: checkpoints.CheckpointStatusLoaded,
+		Engines: map[int32]*checkpoints.EngineCheckpoint{
+			0: {Chunks: []*checkpoints.ChunkCheckpoint{newChunk(0, 100, 100), newChunk(100, 100, 200)}},
+			1: {Chunks: []*checkpoints.ChunkCheckpoint{newChunk(0, 50, 200)}},
+		},
+	}
+	require.Equal(t, int64(300), estimateFinishedBytes(cp, 1000))
+
+	cp.Status = checkpoints.CheckpointStatusAllWritten
+	require.Equal(t, int64(1000), estimateFinishedBytes(cp, 1000))
+
+	require.Equal(t, int64(0), estimateFinishedBytes(&checkpoints.TableCheckpoint{}, 1000))
+}
+
+func TestEstimateETA(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, int64(0), estimateETA(0, 10))
+	require.Equal(t, int64(0), estimateETA(100, 0))
+	require.Equal(t, int64(10), estimateETA(100, 10))
+	require.Equal(t, int64(11), estimateETA(101, 10))
+}
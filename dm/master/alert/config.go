@@ -0,0 +1,97 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alert
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"time"
+
+	"github.com/pingcap/tiflow/dm/config"
+	"github.com/pingcap/tiflow/dm/pkg/terror"
+)
+
+const defaultInterval = 30 * time.Second
+
+// Config is the config of the alert rules evaluated by the leader of
+// dm-master and the receivers of the alerts.
+type Config struct {
+	// Interval is the interval to evaluate the rules.
+	Interval config.Duration `toml:"interval" json:"interval"`
+	// TaskPaused alerts the subtasks paused for longer than it, 0 disables the rule.
+	TaskPaused config.Duration `toml:"task-paused" json:"task-paused"`
+	// ReplicationLag alerts the running subtasks whose seconds behind master
+	// exceed it, 0 disables the rule.
+	ReplicationLag config.Duration `toml:"replication-lag" json:"replication-lag"`
+	// ValidatorMismatch alerts the subtasks whose validator found mismatched rows.
+	ValidatorMismatch bool `toml:"validator-mismatch" json:"validator-mismatch"`
+
+	Webhook *WebhookConfig `toml:"webhook" json:"webhook"`
+	Email   *EmailConfig   `toml:"email" json:"email"`
+}
+
+// WebhookConfig is the config of the webhook receiver, the alerts are posted
+// to the URL in JSON.
+type WebhookConfig struct {
+	URL string `toml:"url" json:"url"`
+}
+
+// EmailConfig is the config of the email receiver.
+type EmailConfig struct {
+	// SMTPAddr is the address of the SMTP server in the format of host:port.
+	SMTPAddr string   `toml:"smtp-addr" json:"smtp-addr"`
+	Username string   `toml:"username" json:"username"`
+	Password string   `toml:"password" json:"-"`
+	From     string   `toml:"from" json:"from"`
+	To       []string `toml:"to" json:"to"`
+}
+
+// hasRules returns whether any rule is enabled.
+func (c *Config) hasRules() bool {
+	return c.TaskPaused.Duration > 0 || c.ReplicationLag.Duration > 0 || c.ValidatorMismatch
+}
+
+// Enabled returns whether the alerts are evaluated.
+func (c *Config) Enabled() bool {
+	return c.hasRules() && (c.Webhook != nil || c.Email != nil)
+}
+
+// Adjust validates the config and fills the default values.
+func (c *Config) Adjust() error {
+	if c.Interval.Duration < 0 || c.TaskPaused.Duration < 0 || c.ReplicationLag.Duration < 0 {
+		return terror.ErrMasterAlertConfigInvalid.Generate("durations must not be negative")
+	}
+	if c.Interval.Duration == 0 {
+		c.Interval.Duration = defaultInterval
+	}
+	if c.hasRules() && c.Webhook == nil && c.Email == nil {
+		return terror.ErrMasterAlertConfigInvalid.Generate("no webhook or email is configured to receive the alerts")
+	}
+	if c.Webhook != nil {
+		u, err := url.Parse(c.Webhook.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return terror.ErrMasterAlertConfigInvalid.Generate(fmt.Sprintf("invalid webhook url %q", c.Webhook.URL))
+		}
+	}
+	if c.Email != nil {
+		if _, _, err := net.SplitHostPort(c.Email.SMTPAddr); err != nil {
+			return terror.ErrMasterAlertConfigInvalid.Generate(fmt.Sprintf("invalid email smtp-addr %q", c.Email.SMTPAddr))
+		}
+		if c.Email.From == "" || len(c.Email.To) == 0 {
+			return terror.ErrMasterAlertConfigInvalid.Generate("email from and to must be set")
+		}
+	}
+	return nil
+}
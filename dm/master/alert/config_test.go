@@ -0,0 +1,59 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alert
+
+import (
+	"testing"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigAdjust(t *testing.T) {
+	t.Parallel()
+
+	var cfg Config
+	require.NoError(t, cfg.Adjust())
+	require.False(t, cfg.Enabled())
+	require.Equal(t, defaultInterval, cfg.Interval.Duration)
+
+	_, err := toml.Decode(`
+task-paused = "10m"
+validator-mismatch = true
+[webhook]
+url = "http://127.0.0.1:9000/alerts"
+[email]
+smtp-addr = "127.0.0.1:25"
+from = "dm@example.com"
+to = ["ops@example.com"]
+`, &cfg)
+	require.NoError(t, err)
+	require.NoError(t, cfg.Adjust())
+	require.True(t, cfg.Enabled())
+	require.Equal(t, 10*time.Minute, cfg.TaskPaused.Duration)
+
+	testCases := []struct {
+		cfg Config
+		err string
+	}{
+		{Config{ValidatorMismatch: true}, "no webhook or email"},
+		{Config{ValidatorMismatch: true, Webhook: &WebhookConfig{URL: "127.0.0.1:9000"}}, "invalid webhook url"},
+		{Config{Email: &EmailConfig{SMTPAddr: "127.0.0.1"}}, "invalid email smtp-addr"},
+		{Config{Email: &EmailConfig{SMTPAddr: "127.0.0.1:25"}}, "email from and to must be set"},
+	}
+	for _, tc := range testCases {
+		require.ErrorContains(t, tc.cfg.Adjust(), tc.err)
+	}
+}
@@ -0,0 +1,189 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alert
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/pingcap/tiflow/dm/pb"
+)
+
+// the rules of the alerts.
+const (
+	RuleTaskPaused        = "task-paused"
+	RuleReplicationLag    = "replication-lag"
+	RuleValidatorMismatch = "validator-mismatch"
+)
+
+// Alert is an alert of a subtask.
+type Alert struct {
+	Rule    string `json:"rule"`
+	Task    string `json:"task"`
+	Source  string `json:"source"`
+	Message string `json:"message"`
+	// Resolved means the condition of the alert is gone.
+	Resolved bool      `json:"resolved"`
+	StartsAt time.Time `json:"starts-at"`
+}
+
+type subtaskKey struct {
+	task   string
+	source string
+}
+
+type alertKey struct {
+	subtaskKey
+	rule string
+}
+
+// Evaluator evaluates the rules with the status of the subtasks. It keeps the
+// firing alerts, so an alert is only reported when it fires and resolves.
+type Evaluator struct {
+	cfg *Config
+
+	pausedSince map[subtaskKey]time.Time
+	firing      map[alertKey]*Alert
+}
+
+// NewEvaluator creates a new Evaluator.
+func NewEvaluator(cfg *Config) *Evaluator {
+	e := &Evaluator{cfg: cfg}
+	e.Reset()
+	return e
+}
+
+// Reset forgets the firing alerts and the paused subtasks.
+func (e *Evaluator) Reset() {
+	e.pausedSince = make(map[subtaskKey]time.Time)
+	e.firing = make(map[alertKey]*Alert)
+}
+
+// Evaluate evaluates the rules with the status responses of the sources, and
+// returns the alerts which fire or resolve. The alerts of the sources failed
+// to respond are left as they are.
+func (e *Evaluator) Evaluate(now time.Time, resps []*pb.QueryStatusResponse) []*Alert {
+	var (
+		active         = make(map[alertKey]string)
+		seenSubtasks   = make(map[subtaskKey]struct{})
+		failedSources  = make(map[string]struct{})
+		pausedSubtasks = make(map[subtaskKey]time.Time)
+	)
+	for _, resp := range resps {
+		source := resp.GetSourceStatus().GetSource()
+		if !resp.Result {
+			failedSources[source] = struct{}{}
+			continue
+		}
+		for _, st := range resp.SubTaskStatus {
+			key := subtaskKey{task: st.Name, source: source}
+			seenSubtasks[key] = struct{}{}
+			if st.Stage == pb.Stage_Paused {
+				since, ok := e.pausedSince[key]
+				if !ok {
+					since = now
+				}
+				pausedSubtasks[key] = since
+			}
+			for rule, msg := range e.evaluateSubtask(now, st, pausedSubtasks[key]) {
+				active[alertKey{subtaskKey: key, rule: rule}] = msg
+			}
+		}
+	}
+	for key, since := range e.pausedSince {
+		if _, ok := failedSources[key.source]; ok {
+			pausedSubtasks[key] = since
+		}
+	}
+	e.pausedSince = pausedSubtasks
+
+	var changed []*Alert
+	for key, msg := range active {
+		if _, ok := e.firing[key]; ok {
+			continue
+		}
+		alert := &Alert{
+			Rule:     key.rule,
+			Task:     key.task,
+			Source:   key.source,
+			Message:  msg,
+			StartsAt: now,
+		}
+		e.firing[key] = alert
+		changed = append(changed, alert)
+	}
+	for key, alert := range e.firing {
+		if _, ok := active[key]; ok {
+			continue
+		}
+		if _, ok := failedSources[key.source]; ok {
+			continue
+		}
+		delete(e.firing, key)
+		resolved := *alert
+		resolved.Resolved = true
+		changed = append(changed, &resolved)
+	}
+	sort.Slice(changed, func(i, j int) bool {
+		a, b := changed[i], changed[j]
+		if a.Task != b.Task {
+			return a.Task < b.Task
+		}
+		if a.Source != b.Source {
+			return a.Source < b.Source
+		}
+		return a.Rule < b.Rule
+	})
+	return changed
+}
+
+// evaluateSubtask returns the messages of the rules matched by the subtask.
+func (e *Evaluator) evaluateSubtask(now time.Time, st *pb.SubTaskStatus, pausedSince time.Time) map[string]string {
+	matched := make(map[string]string)
+	if e.cfg.TaskPaused.Duration > 0 && st.Stage == pb.Stage_Paused {
+		if paused := now.Sub(pausedSince); paused >= e.cfg.TaskPaused.Duration {
+			msg := fmt.Sprintf("subtask has been paused for %s", paused.Round(time.Second))
+			if errs := st.GetResult().GetErrors(); len(errs) > 0 {
+				msg += ": " + errs[0].Message
+			}
+			matched[RuleTaskPaused] = msg
+		}
+	}
+	if e.cfg.ReplicationLag.Duration > 0 && st.Stage == pb.Stage_Running {
+		lag := time.Duration(st.GetSync().GetSecondsBehindMaster()) * time.Second
+		if lag >= e.cfg.ReplicationLag.Duration {
+			matched[RuleReplicationLag] = fmt.Sprintf("replication lag is %s", lag)
+		}
+	}
+	if e.cfg.ValidatorMismatch {
+		if n := newErrorRows(st.GetValidation()); n > 0 {
+			matched[RuleValidatorMismatch] = fmt.Sprintf("validator found %d mismatched rows", n)
+		}
+	}
+	return matched
+}
+
+// newErrorRows returns the count of the new error rows of the validator,
+// which is a part of the formatted error rows status.
+func newErrorRows(status *pb.ValidationStatus) int64 {
+	if status == nil {
+		return 0
+	}
+	var newRows int64
+	if _, err := fmt.Sscanf(status.ErrorRowsStatus, "new/ignored/resolved: %d/", &newRows); err != nil {
+		return 0
+	}
+	return newRows
+}
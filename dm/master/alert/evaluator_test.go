@@ -0,0 +1,94 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alert
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pingcap/tiflow/dm/config"
+	"github.com/pingcap/tiflow/dm/pb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvaluate(t *testing.T) {
+	t.Parallel()
+
+	e := NewEvaluator(&Config{
+		TaskPaused:        config.Duration{Duration: time.Minute},
+		ReplicationLag:    config.Duration{Duration: 30 * time.Second},
+		ValidatorMismatch: true,
+	})
+	newResp := func(source string, subtasks ...*pb.SubTaskStatus) *pb.QueryStatusResponse {
+		return &pb.QueryStatusResponse{
+			Result:        true,
+			SourceStatus:  &pb.SourceStatus{Source: source},
+			SubTaskStatus: subtasks,
+		}
+	}
+	paused := &pb.SubTaskStatus{
+		Name:   "task1",
+		Stage:  pb.Stage_Paused,
+		Result: &pb.ProcessResult{Errors: []*pb.ProcessError{{Message: "connection refused"}}},
+	}
+	lagging := &pb.SubTaskStatus{
+		Name:   "task2",
+		Stage:  pb.Stage_Running,
+		Status: &pb.SubTaskStatus_Sync{Sync: &pb.SyncStatus{SecondsBehindMaster: 60}},
+		Validation: &pb.ValidationStatus{
+			ErrorRowsStatus: "new/ignored/resolved: 3/0/1",
+		},
+	}
+	now := time.Now()
+
+	// the subtask isn't paused long enough.
+	alerts := e.Evaluate(now, []*pb.QueryStatusResponse{newResp("s1", paused), newResp("s2", lagging)})
+	require.Len(t, alerts, 2)
+	require.Equal(t, RuleReplicationLag, alerts[0].Rule)
+	require.Equal(t, "replication lag is 1m0s", alerts[0].Message)
+	require.Equal(t, RuleValidatorMismatch, alerts[1].Rule)
+	require.Equal(t, "validator found 3 mismatched rows", alerts[1].Message)
+
+	// the firing alerts are not reported again.
+	now = now.Add(time.Minute)
+	alerts = e.Evaluate(now, []*pb.QueryStatusResponse{newResp("s1", paused), newResp("s2", lagging)})
+	require.Len(t, alerts, 1)
+	require.Equal(t, &Alert{
+		Rule:     RuleTaskPaused,
+		Task:     "task1",
+		Source:   "s1",
+		Message:  "subtask has been paused for 1m0s: connection refused",
+		StartsAt: now,
+	}, alerts[0])
+
+	// the alerts of the failed source are kept, the ones of the recovered
+	// subtask are resolved.
+	now = now.Add(time.Minute)
+	failed := &pb.QueryStatusResponse{SourceStatus: &pb.SourceStatus{Source: "s1"}}
+	alerts = e.Evaluate(now, []*pb.QueryStatusResponse{failed, newResp("s2", &pb.SubTaskStatus{
+		Name: "task2", Stage: pb.Stage_Running,
+	})})
+	require.Len(t, alerts, 2)
+	for _, alert := range alerts {
+		require.True(t, alert.Resolved)
+		require.Equal(t, "task2", alert.Task)
+	}
+
+	// the paused subtask is removed.
+	alerts = e.Evaluate(now, []*pb.QueryStatusResponse{newResp("s1")})
+	require.Len(t, alerts, 1)
+	require.True(t, alerts[0].Resolved)
+	require.Equal(t, RuleTaskPaused, alerts[0].Rule)
+	require.Empty(t, e.pausedSince)
+}
@@ -0,0 +1,83 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alert
+
+import (
+	"context"
+	"time"
+
+	"github.com/pingcap/tiflow/dm/pb"
+	"github.com/pingcap/tiflow/dm/pkg/log"
+	"go.uber.org/zap"
+)
+
+// StatusFetcher returns the status of the sources, false is returned if the
+// status is unavailable, e.g. the member of dm-master is not the leader.
+type StatusFetcher func(ctx context.Context) ([]*pb.QueryStatusResponse, bool)
+
+// Manager evaluates the alert rules periodically and notifies the receivers.
+type Manager struct {
+	cfg       *Config
+	evaluator *Evaluator
+	notifiers []Notifier
+	logger    log.Logger
+}
+
+// NewManager creates a new Manager.
+func NewManager(cfg *Config, logger log.Logger) *Manager {
+	return &Manager{
+		cfg:       cfg,
+		evaluator: NewEvaluator(cfg),
+		notifiers: NewNotifiers(cfg),
+		logger:    logger.WithFields(zap.String("component", "alert")),
+	}
+}
+
+// Run evaluates the rules with the status returned by fetch until the
+// context is done.
+func (m *Manager) Run(ctx context.Context, fetch StatusFetcher) {
+	ticker := time.NewTicker(m.cfg.Interval.Duration)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			resps, ok := fetch(ctx)
+			if !ok {
+				// the firing alerts are reported again by the new leader.
+				m.evaluator.Reset()
+				continue
+			}
+			m.notify(ctx, m.evaluator.Evaluate(time.Now(), resps))
+		}
+	}
+}
+
+func (m *Manager) notify(ctx context.Context, alerts []*Alert) {
+	if len(alerts) == 0 {
+		return
+	}
+	for _, alert := range alerts {
+		m.logger.Warn("alert changed", zap.String("rule", alert.Rule), zap.String("task", alert.Task),
+			zap.String("source", alert.Source), zap.Bool("resolved", alert.Resolved),
+			zap.String("message", alert.Message))
+	}
+	for _, n := range m.notifiers {
+		if err := n.Notify(ctx, alerts); err != nil {
+			m.logger.Error("fail to notify the alerts", zap.Int("count", len(alerts)), log.ShortError(err))
+		}
+	}
+}
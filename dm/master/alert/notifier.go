@@ -0,0 +1,122 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alert
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/smtp"
+	"strings"
+	"time"
+
+	"github.com/pingcap/errors"
+)
+
+const notifyTimeout = 10 * time.Second
+
+// Notifier delivers the alerts to the receivers.
+type Notifier interface {
+	Notify(ctx context.Context, alerts []*Alert) error
+}
+
+// NewNotifiers creates the notifiers of the receivers in the config.
+func NewNotifiers(cfg *Config) []Notifier {
+	var notifiers []Notifier
+	if cfg.Webhook != nil {
+		notifiers = append(notifiers, &webhookNotifier{
+			url:    cfg.Webhook.URL,
+			client: &http.Client{Timeout: notifyTimeout},
+		})
+	}
+	if cfg.Email != nil {
+		notifiers = append(notifiers, &emailNotifier{cfg: cfg.Email, sendMail: smtp.SendMail})
+	}
+	return notifiers
+}
+
+// webhookNotifier posts the alerts in JSON like {"alerts": [...]}.
+type webhookNotifier struct {
+	url    string
+	client *http.Client
+}
+
+func (n *webhookNotifier) Notify(ctx context.Context, alerts []*Alert) error {
+	body, err := json.Marshal(map[string]interface{}{"alerts": alerts})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer resp.Body.Close()
+	// drain the body to reuse the connection.
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode/100 != 2 {
+		return errors.Errorf("webhook responds with status %s", resp.Status)
+	}
+	return nil
+}
+
+// emailNotifier sends the alerts in a plain text email.
+type emailNotifier struct {
+	cfg      *EmailConfig
+	sendMail func(addr string, a smtp.Auth, from string, to []string, msg []byte) error
+}
+
+func (n *emailNotifier) Notify(_ context.Context, alerts []*Alert) error {
+	var auth smtp.Auth
+	if n.cfg.Username != "" {
+		host, _, err := net.SplitHostPort(n.cfg.SMTPAddr)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		auth = smtp.PlainAuth("", n.cfg.Username, n.cfg.Password, host)
+	}
+	return errors.Trace(n.sendMail(n.cfg.SMTPAddr, auth, n.cfg.From, n.cfg.To, formatEmail(n.cfg, alerts)))
+}
+
+func formatEmail(cfg *EmailConfig, alerts []*Alert) []byte {
+	var firing int
+	for _, alert := range alerts {
+		if !alert.Resolved {
+			firing++
+		}
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", cfg.From)
+	fmt.Fprintf(&b, "To: %s\r\n", strings.Join(cfg.To, ", "))
+	fmt.Fprintf(&b, "Subject: [DM] %d alerts firing, %d resolved\r\n", firing, len(alerts)-firing)
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+	for _, alert := range alerts {
+		state := "FIRING"
+		if alert.Resolved {
+			state = "RESOLVED"
+		}
+		fmt.Fprintf(&b, "[%s] %s task=%s source=%s since=%s: %s\r\n", state, alert.Rule,
+			alert.Task, alert.Source, alert.StartsAt.Format(time.RFC3339), alert.Message)
+	}
+	return []byte(b.String())
+}
@@ -0,0 +1,81 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alert
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/smtp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookNotifier(t *testing.T) {
+	t.Parallel()
+
+	received := make(chan []*Alert, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Alerts []*Alert `json:"alerts"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		received <- body.Alerts
+	}))
+	defer server.Close()
+
+	notifiers := NewNotifiers(&Config{Webhook: &WebhookConfig{URL: server.URL}})
+	require.Len(t, notifiers, 1)
+	alerts := []*Alert{{Rule: RuleTaskPaused, Task: "task", Source: "s1", Message: "paused", StartsAt: time.Unix(100, 0).UTC()}}
+	require.NoError(t, notifiers[0].Notify(context.Background(), alerts))
+	require.Equal(t, alerts, <-received)
+}
+
+func TestEmailNotifier(t *testing.T) {
+	t.Parallel()
+
+	cfg := &EmailConfig{
+		SMTPAddr: "127.0.0.1:25",
+		Username: "user",
+		Password: "pass",
+		From:     "dm@example.com",
+		To:       []string{"a@example.com", "b@example.com"},
+	}
+	var msg string
+	n := &emailNotifier{cfg: cfg, sendMail: func(addr string, a smtp.Auth, from string, to []string, m []byte) error {
+		require.Equal(t, cfg.SMTPAddr, addr)
+		require.NotNil(t, a)
+		require.Equal(t, cfg.From, from)
+		require.Equal(t, cfg.To, to)
+		msg = string(m)
+		return nil
+	}}
+	startsAt := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	require.NoError(t, n.Notify(context.Background(), []*Alert{
+		{Rule: RuleTaskPaused, Task: "task", Source: "s1", Message: "paused", StartsAt: startsAt},
+		{Rule: RuleReplicationLag, Task: "task", Source: "s2", Message: "lag", StartsAt: startsAt, Resolved: true},
+	}))
+	require.Equal(t, "From: dm@example.com\r\n"+
+		"To: a@example.com, b@example.com\r\n"+
+		"Subject: [DM] 1 alerts firing, 1 resolved\r\n"+
+		"Content-Type: text/plain; charset=UTF-8\r\n\r\n"+
+		"[FIRING] task-paused task=task source=s1 since=2023-01-01T00:00:00Z: paused\r\n"+
+		"[RESOLVED] replication-lag task=task source=s2 since=2023-01-01T00:00:00Z: lag\r\n", msg)
+}
@@ -27,6 +27,7 @@ import (
 
 	"github.com/BurntSushi/toml"
 	"github.com/pingcap/tiflow/dm/config/security"
+	"github.com/pingcap/tiflow/dm/master/alert"
 	"github.com/pingcap/tiflow/dm/pkg/log"
 	"github.com/pingcap/tiflow/dm/pkg/terror"
 	"github.com/pingcap/tiflow/dm/pkg/utils"
@@ -143,6 +144,9 @@ type Config struct {
 	printSampleConfig bool
 
 	ExperimentalFeatures ExperimentalFeatures `toml:"experimental"`
+
+	// Alert is the config of the built-in alert rules evaluated by the leader.
+	Alert alert.Config `toml:"alert" json:"alert"`
 }
 
 func (c *Config) String() string {
@@ -338,7 +342,8 @@ func (c *Config) adjust() error {
 		c.ExperimentalFeatures.OpenAPI = false
 		log.L().Warn("openapi is a GA feature and removed from experimental features, so this configuration may have no affect in feature release, please set openapi=true in dm-master config file")
 	}
-	return err
+
+	return c.Alert.Adjust()
 }
 
 // Reload load config from local file.
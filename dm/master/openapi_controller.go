@@ -22,6 +22,9 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/pingcap/log"
@@ -33,7 +36,10 @@ import (
 	"github.com/pingcap/tiflow/dm/master/workerrpc"
 	"github.com/pingcap/tiflow/dm/openapi"
 	"github.com/pingcap/tiflow/dm/pb"
+	"github.com/pingcap/tiflow/dm/pkg/conn"
+	tcontext "github.com/pingcap/tiflow/dm/pkg/context"
 	"github.com/pingcap/tiflow/dm/pkg/ha"
+	"github.com/pingcap/tiflow/dm/pkg/quarantine"
 	"github.com/pingcap/tiflow/dm/pkg/terror"
 	clientv3 "go.etcd.io/etcd/client/v3"
 	"go.uber.org/zap"
@@ -564,6 +570,24 @@ func (s *Server) getTaskStatus(ctx context.Context, taskName string, req openapi
 				Progress:       loadS.Progress,
 				TotalBytes:     loadS.TotalBytes,
 			}
+			if loadS.Eta > 0 {
+				eta := loadS.Eta
+				openapiSubTaskStatus.LoadStatus.Eta = &eta
+			}
+			if len(loadS.Tables) > 0 {
+				tables := make([]openapi.TableLoadStatus, 0, len(loadS.Tables))
+				for _, table := range loadS.Tables {
+					tables = append(tables, openapi.TableLoadStatus{
+						Bps:           table.Bps,
+						Eta:           table.Eta,
+						FinishedBytes: table.FinishedBytes,
+						Progress:      table.Progress,
+						Table:         table.Table,
+						TotalBytes:    table.TotalBytes,
+					})
+				}
+				openapiSubTaskStatus.LoadStatus.Tables = &tables
+			}
 		}
 		// add sync status
 		if syncerS := subTaskStatus.GetSync(); syncerS != nil {
@@ -616,6 +640,157 @@ func (s *Server) getTaskStatus(ctx context.Context, taskName string, req openapi
 	return subTaskStatusList, nil
 }
 
+// the types of the nodes in the task topology.
+const (
+	topologyNodeSource     = "source"
+	topologyNodeWorker     = "worker"
+	topologyNodeDownstream = "downstream"
+)
+
+// nolint:unparam
+func (s *Server) getTaskTopology(ctx context.Context, taskName string) (*openapi.TaskTopology, error) {
+	subTaskConfigM := s.scheduler.GetSubTaskCfgsByTask(taskName)
+	if len(subTaskConfigM) == 0 {
+		return nil, terror.ErrSchedulerTaskNotExist.Generate(taskName)
+	}
+	sourceNameList := make([]string, 0, len(subTaskConfigM))
+	for sourceName := range subTaskConfigM {
+		sourceNameList = append(sourceNameList, sourceName)
+	}
+	sort.Strings(sourceNameList)
+
+	topology := &openapi.TaskTopology{
+		TaskName: taskName,
+		Nodes:    make([]openapi.TaskTopologyNode, 0, 2*len(sourceNameList)+1),
+		Edges:    make([]openapi.TaskTopologyEdge, 0, 2*len(sourceNameList)),
+	}
+	// all subtasks of a task share the same downstream.
+	downstream := subTaskConfigM[sourceNameList[0]].To
+	downstreamNode := openapi.TaskTopologyNode{
+		Id:      topologyNodeDownstream,
+		Type:    topologyNodeDownstream,
+		Name:    topologyNodeDownstream,
+		Address: net.JoinHostPort(downstream.Host, strconv.Itoa(downstream.Port)),
+	}
+	for _, sourceName := range sourceNameList {
+		from := subTaskConfigM[sourceName].From
+		sourceStage := s.scheduler.GetExpectSubTaskStage(taskName, sourceName).Expect.String()
+		sourceNode := openapi.TaskTopologyNode{
+			Id:      topologyNodeSource + "/" + sourceName,
+			Type:    topologyNodeSource,
+			Name:    sourceName,
+			Address: net.JoinHostPort(from.Host, strconv.Itoa(from.Port)),
+			Stage:   &sourceStage,
+		}
+		topology.Nodes = append(topology.Nodes, sourceNode)
+		// no data flows from a source which is not bound to any worker.
+		worker := s.scheduler.GetWorkerBySource(sourceName)
+		if worker == nil {
+			continue
+		}
+		workerInfo := worker.BaseInfo()
+		workerStage := string(worker.Stage())
+		workerNode := openapi.TaskTopologyNode{
+			Id:      topologyNodeWorker + "/" + workerInfo.Name,
+			Type:    topologyNodeWorker,
+			Name:    workerInfo.Name,
+			Address: workerInfo.Addr,
+			Stage:   &workerStage,
+		}
+		topology.Nodes = append(topology.Nodes, workerNode)
+		topology.Edges = append(topology.Edges,
+			openapi.TaskTopologyEdge{From: sourceNode.Id, To: workerNode.Id},
+			openapi.TaskTopologyEdge{From: workerNode.Id, To: downstreamNode.Id},
+		)
+	}
+	topology.Nodes = append(topology.Nodes, downstreamNode)
+	return topology, nil
+}
+
+// percentage returns finished/total in percent, nil if total is unknown. It's
+// no more than 100 because the total may be estimated.
+func percentage(finished, total float64) *float64 {
+	if total <= 0 {
+		return nil
+	}
+	p := finished / total * 100
+	if p > 100 {
+		p = 100
+	}
+	return &p
+}
+
+func (s *Server) getTaskProgress(ctx context.Context, taskName string, req openapi.DMAPIGetTaskProgressParams) ([]openapi.SubTaskProgress, error) {
+	if s.scheduler.GetSubTaskCfgsByTask(taskName) == nil {
+		return nil, terror.ErrSchedulerTaskNotExist.Generate(taskName)
+	}
+	if req.SourceNameList == nil || len(*req.SourceNameList) == 0 {
+		sourceNameList := openapi.SourceNameList(s.getTaskSourceNameList(taskName))
+		req.SourceNameList = &sourceNameList
+	}
+	workerStatusList := s.getStatusFromWorkers(ctx, *req.SourceNameList, taskName, true)
+	progressList := make([]openapi.SubTaskProgress, 0, len(workerStatusList))
+	for _, workerStatus := range workerStatusList {
+		if workerStatus == nil || workerStatus.SourceStatus == nil {
+			// this should not happen unless the rpc in the worker server has been modified
+			return nil, terror.ErrOpenAPICommonError.New("worker's query-status response is nil")
+		}
+		progress := openapi.SubTaskProgress{
+			SourceName: workerStatus.SourceStatus.GetSource(),
+			WorkerName: workerStatus.SourceStatus.GetWorker(),
+			Errors:     []openapi.TaskError{},
+		}
+		if !workerStatus.Result {
+			progress.Errors = append(progress.Errors, openapi.TaskError{Message: workerStatus.Msg})
+			progressList = append(progressList, progress)
+			continue
+		}
+		if len(workerStatus.SubTaskStatus) == 0 || workerStatus.SubTaskStatus[0] == nil {
+			// this should not happen unless the rpc in the worker server has been modified
+			return nil, terror.ErrOpenAPICommonError.New("worker's query-status response is nil")
+		}
+		subTaskStatus := workerStatus.SubTaskStatus[0]
+		progress.Stage = openapi.TaskStage(subTaskStatus.GetStage().String())
+		progress.Unit = subTaskStatus.GetUnit().String()
+		if dumpS := subTaskStatus.GetDump(); dumpS != nil {
+			// prefer the rows, the tables are too coarse when there are only a few tables.
+			if dumpS.EstimateTotalRows > 0 {
+				progress.DumpProgress = percentage(dumpS.FinishedRows, dumpS.EstimateTotalRows)
+			} else {
+				progress.DumpProgress = percentage(dumpS.CompletedTables, float64(dumpS.TotalTables))
+			}
+		}
+		if loadS := subTaskStatus.GetLoad(); loadS != nil {
+			progress.LoadProgress = percentage(float64(loadS.FinishedBytes), float64(loadS.TotalBytes))
+		}
+		if syncerS := subTaskStatus.GetSync(); syncerS != nil {
+			progress.SyncProgress = &openapi.SyncProgress{
+				MasterBinlog:        syncerS.GetMasterBinlog(),
+				MasterBinlogGtid:    syncerS.GetMasterBinlogGtid(),
+				SecondsBehindMaster: syncerS.SecondsBehindMaster,
+				Synced:              syncerS.Synced,
+				SyncerBinlog:        syncerS.SyncerBinlog,
+				SyncerBinlogGtid:    syncerS.SyncerBinlogGtid,
+			}
+		}
+		if subTaskStatus.Result != nil {
+			for _, err := range subTaskStatus.Result.Errors {
+				progress.Errors = append(progress.Errors, openapi.TaskError{
+					ErrorCode:  int(err.ErrCode),
+					ErrorClass: err.ErrClass,
+					ErrorScope: err.ErrScope,
+					ErrorLevel: err.ErrLevel,
+					Message:    err.Message,
+					RawCause:   err.RawCause,
+					Workaround: err.Workaround,
+				})
+			}
+		}
+		progressList = append(progressList, progress)
+	}
+	return progressList, nil
+}
+
 func (s *Server) listTask(ctx context.Context, req openapi.DMAPIGetTaskListParams) ([]openapi.Task, error) {
 	subTaskConfigMap := s.scheduler.GetALlSubTaskCfgs()
 	taskList := config.SubTaskConfigsToOpenAPITaskList(subTaskConfigMap)
@@ -769,6 +944,86 @@ func handleCliArgs(cli *clientv3.Client, taskName string, sources []string, cliA
 	return nil
 }
 
+// getTaskConflictDB returns the downstream DB and the meta schema of the task,
+// where the conflicting rows are quarantined.
+func (s *Server) getTaskConflictDB(taskName string) (*conn.BaseDB, string, error) {
+	subTaskConfigM := s.scheduler.GetSubTaskCfgsByTask(taskName)
+	if len(subTaskConfigM) == 0 {
+		return nil, "", terror.ErrSchedulerTaskNotExist.Generate(taskName)
+	}
+	// all subtasks of a task share the same downstream and meta schema.
+	var subTaskCfg *config.SubTaskConfig
+	for _, cfg := range subTaskConfigM {
+		subTaskCfg = cfg
+		break
+	}
+	toDBCfg := subTaskCfg.To
+	toDBCfg.Adjust()
+	baseDB, err := conn.GetDownstreamDB(&toDBCfg)
+	if err != nil {
+		return nil, "", terror.WithScope(err, terror.ScopeDownstream)
+	}
+	return baseDB, subTaskCfg.MetaSchema, nil
+}
+
+func (s *Server) getTaskConflictList(ctx context.Context, taskName string, req openapi.DMAPIGetTaskConflictListParams) ([]openapi.TaskConflict, error) {
+	var status string
+	if req.Status != nil {
+		status = *req.Status
+		if status != quarantine.StatusPending && status != quarantine.StatusReplayed {
+			return nil, terror.ErrOpenAPICommonError.Generatef("unknown status %s of quarantined rows", status)
+		}
+	}
+	baseDB, metaSchema, err := s.getTaskConflictDB(taskName)
+	if err != nil {
+		return nil, err
+	}
+	defer baseDB.Close()
+
+	rows, err := quarantine.List(tcontext.Background().WithContext(ctx), baseDB, metaSchema, taskName, status)
+	if err != nil {
+		return nil, err
+	}
+	conflicts := make([]openapi.TaskConflict, 0, len(rows))
+	for _, row := range rows {
+		conflicts = append(conflicts, openapi.TaskConflict{
+			Id:           row.ID,
+			SourceName:   row.SourceID,
+			SourceSchema: row.SourceSchema,
+			SourceTable:  row.SourceTable,
+			TargetSchema: row.TargetSchema,
+			TargetTable:  row.TargetTable,
+			Location:     row.Location,
+			Sqls:         row.SQLs(),
+			ErrorMsg:     row.ErrMsg,
+			Status:       row.Status,
+			CreateTime:   row.CreateTime,
+			UpdateTime:   row.UpdateTime,
+		})
+	}
+	return conflicts, nil
+}
+
+func (s *Server) replayTaskConflicts(ctx context.Context, taskName string, req openapi.ReplayTaskConflictsRequest) ([]int64, error) {
+	baseDB, metaSchema, err := s.getTaskConflictDB(taskName)
+	if err != nil {
+		return nil, err
+	}
+	defer baseDB.Close()
+
+	var ids []int64
+	if req.IdList != nil {
+		ids = *req.IdList
+	}
+	replayed, err := quarantine.Replay(tcontext.Background().WithContext(ctx), baseDB, metaSchema, taskName, ids)
+	if err != nil {
+		log.L().Warn("failed to replay quarantined rows", zap.String("task", taskName),
+			zap.Int64s("replayed", replayed), zap.Error(err))
+		return nil, err
+	}
+	return replayed, nil
+}
+
 // nolint:unparam
 func (s *Server) convertTaskConfig(ctx context.Context, req openapi.ConverterTaskRequest) (*openapi.Task, *config.TaskConfig, error) {
 	if req.TaskConfigFile != nil {
@@ -520,6 +520,27 @@ func (s *Server) DMAPIGetTaskStatus(c *gin.Context, taskName string, params open
 	c.IndentedJSON(http.StatusOK, resp)
 }
 
+// DMAPIGetTaskProgress url is: (GET /api/v1/tasks/{task-name}/progress).
+func (s *Server) DMAPIGetTaskProgress(c *gin.Context, taskName string, params openapi.DMAPIGetTaskProgressParams) {
+	progressList, err := s.getTaskProgress(c.Request.Context(), taskName, params)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	resp := openapi.GetTaskProgressResponse{Total: len(progressList), Data: progressList}
+	c.IndentedJSON(http.StatusOK, resp)
+}
+
+// DMAPIGetTaskTopology url is: (GET /api/v1/tasks/{task-name}/topology).
+func (s *Server) DMAPIGetTaskTopology(c *gin.Context, taskName string) {
+	topology, err := s.getTaskTopology(c.Request.Context(), taskName)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.IndentedJSON(http.StatusOK, topology)
+}
+
 // DMAPIGetTaskList url is:(GET /api/v1/tasks).
 func (s *Server) DMAPIGetTaskList(c *gin.Context, params openapi.DMAPIGetTaskListParams) {
 	ctx := c.Request.Context()
@@ -560,6 +581,33 @@ func (s *Server) DMAPIStopTask(c *gin.Context, taskName string) {
 	c.Status(http.StatusOK)
 }
 
+// DMAPIGetTaskConflictList url is: (GET /api/v1/tasks/{task-name}/conflicts).
+func (s *Server) DMAPIGetTaskConflictList(c *gin.Context, taskName string, params openapi.DMAPIGetTaskConflictListParams) {
+	conflicts, err := s.getTaskConflictList(c.Request.Context(), taskName, params)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	resp := openapi.GetTaskConflictListResponse{Total: len(conflicts), Data: conflicts}
+	c.IndentedJSON(http.StatusOK, resp)
+}
+
+// DMAPIReplayTaskConflicts url is: (POST /api/v1/tasks/{task-name}/conflicts/replay).
+func (s *Server) DMAPIReplayTaskConflicts(c *gin.Context, taskName string) {
+	var req openapi.ReplayTaskConflictsRequest
+	if err := c.Bind(&req); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	replayed, err := s.replayTaskConflicts(c.Request.Context(), taskName, req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	resp := openapi.ReplayTaskConflictsResponse{Total: len(replayed), IdList: replayed}
+	c.IndentedJSON(http.StatusOK, resp)
+}
+
 // DMAPIGetSchemaListByTaskAndSource get task source schema list url is: (GET /api/v1/tasks/{task-name}/sources/{source-name}/schemas).
 func (s *Server) DMAPIGetSchemaListByTaskAndSource(c *gin.Context, taskName string, sourceName string) {
 	worker := s.scheduler.GetWorkerBySource(sourceName)
@@ -39,6 +39,7 @@ import (
 	"github.com/pingcap/tiflow/dm/pkg/conn"
 	"github.com/pingcap/tiflow/dm/pkg/ha"
 	"github.com/pingcap/tiflow/dm/pkg/log"
+	"github.com/pingcap/tiflow/dm/pkg/quarantine"
 	"github.com/pingcap/tiflow/dm/pkg/terror"
 	"github.com/pingcap/tiflow/dm/pkg/utils"
 	"github.com/stretchr/testify/require"
@@ -1065,6 +1066,40 @@ func (s *OpenAPIViewSuite) TestTaskAPI() {
 	s.NoError(result.UnmarshalBodyToObject(&resultTaskStatusWithStatus))
 	s.EqualValues(resultTaskStatus, resultTaskStatusWithStatus)
 
+	// get task progress
+	taskProgressURL := fmt.Sprintf("%s/%s/progress", taskURL, task.Name)
+	result = testutil.NewRequest().Get(taskProgressURL).GoWithHTTPHandler(s.T(), s1.openapiHandles)
+	s.Equal(http.StatusOK, result.Code())
+	var resultTaskProgress openapi.GetTaskProgressResponse
+	s.NoError(result.UnmarshalBodyToObject(&resultTaskProgress))
+	s.Equal(1, resultTaskProgress.Total)
+	s.Equal(source1Name, resultTaskProgress.Data[0].SourceName)
+	s.Equal(workerName1, resultTaskProgress.Data[0].WorkerName)
+	s.Equal(openapi.TaskStageRunning, resultTaskProgress.Data[0].Stage)
+	s.NotNil(resultTaskProgress.Data[0].DumpProgress)
+	s.Equal(float64(50), *resultTaskProgress.Data[0].DumpProgress)
+	s.Nil(resultTaskProgress.Data[0].LoadProgress)
+	s.Nil(resultTaskProgress.Data[0].SyncProgress)
+	s.Len(resultTaskProgress.Data[0].Errors, 0)
+
+	// get task topology
+	taskTopologyURL := fmt.Sprintf("%s/%s/topology", taskURL, task.Name)
+	result = testutil.NewRequest().Get(taskTopologyURL).GoWithHTTPHandler(s.T(), s1.openapiHandles)
+	s.Equal(http.StatusOK, result.Code())
+	var resultTaskTopology openapi.TaskTopology
+	s.NoError(result.UnmarshalBodyToObject(&resultTaskTopology))
+	s.Equal(task.Name, resultTaskTopology.TaskName)
+	s.Len(resultTaskTopology.Nodes, 3)
+	s.Equal("source/"+source1Name, resultTaskTopology.Nodes[0].Id)
+	s.Equal(pb.Stage_Running.String(), *resultTaskTopology.Nodes[0].Stage)
+	s.Equal("worker/"+workerName1, resultTaskTopology.Nodes[1].Id)
+	s.Equal("172.16.10.72:8262", resultTaskTopology.Nodes[1].Address)
+	s.Equal("downstream", resultTaskTopology.Nodes[2].Type)
+	s.Equal([]openapi.TaskTopologyEdge{
+		{From: "source/" + source1Name, To: "worker/" + workerName1},
+		{From: "worker/" + workerName1, To: "downstream"},
+	}, resultTaskTopology.Edges)
+
 	// list task with status
 	result = testutil.NewRequest().Get(taskURL+"?with_status=true").GoWithHTTPHandler(s.T(), s1.openapiHandles)
 	s.Equal(http.StatusOK, result.Code())
@@ -1157,6 +1192,32 @@ func (s *OpenAPIViewSuite) TestTaskAPI() {
 
 	s.testSourceOperationWithTask(&source1, &task, s1)
 
+	// list quarantined conflicting rows
+	conflictURL := fmt.Sprintf("%s/%s/conflicts", taskURL, task.Name)
+	_, mockDB, err := conn.InitMockDBFull()
+	s.NoError(err)
+	mockDB.ExpectQuery("SELECT id, source_id, .* FROM .*_syncer_conflict_quarantine.* WHERE status = \\? ORDER BY id").
+		WithArgs(quarantine.StatusPending).
+		WillReturnRows(sqlmock.NewRows([]string{
+			"id", "source_id", "source_schema", "source_table", "target_schema", "target_table",
+			"location", "statements", "error_msg", "status", "create_time", "update_time",
+		}).AddRow(1, source1Name, "db", "tb", "db", "tb", "position: (mysql-bin.000001, 4)",
+			`[{"sql":"INSERT INTO `+"`db`.`tb`"+` (`+"`id`"+`) VALUES (?)","args":["MQ=="]}]`,
+			"Duplicate entry '1' for key 'PRIMARY'", quarantine.StatusPending, "2023-01-01 00:00:00", "2023-01-01 00:00:00"))
+	result = testutil.NewRequest().Get(conflictURL+"?status=pending").GoWithHTTPHandler(s.T(), s1.openapiHandles)
+	s.Equal(http.StatusOK, result.Code())
+	var conflictList openapi.GetTaskConflictListResponse
+	s.NoError(result.UnmarshalBodyToObject(&conflictList))
+	s.Equal(1, conflictList.Total)
+	s.Equal(int64(1), conflictList.Data[0].Id)
+	s.Equal(source1Name, conflictList.Data[0].SourceName)
+	s.Equal([]string{"INSERT INTO `db`.`tb` (`id`) VALUES (?); [\"1\"]"}, conflictList.Data[0].Sqls)
+	s.NoError(mockDB.ExpectationsWereMet())
+
+	// unknown status
+	result = testutil.NewRequest().Get(conflictURL+"?status=unknown").GoWithHTTPHandler(s.T(), s1.openapiHandles)
+	s.Equal(http.StatusBadRequest, result.Code())
+
 	// stop task
 	stopTaskURL := fmt.Sprintf("%s/%s/stop", taskURL, task.Name)
 	stopTaskReq := openapi.StopTaskRequest{}
@@ -1176,6 +1237,16 @@ func (s *OpenAPIViewSuite) TestTaskAPI() {
 	resultListTask = openapi.GetTaskListResponse{} // reset
 	s.NoError(result.UnmarshalBodyToObject(&resultTaskList))
 	s.Equal(0, resultTaskList.Total)
+
+	// get the progress and topology of a not exist task
+	result = testutil.NewRequest().Get(taskProgressURL).GoWithHTTPHandler(s.T(), s1.openapiHandles)
+	s.Equal(http.StatusBadRequest, result.Code())
+	result = testutil.NewRequest().Get(taskTopologyURL).GoWithHTTPHandler(s.T(), s1.openapiHandles)
+	s.Equal(http.StatusBadRequest, result.Code())
+
+	// replay the conflicts of a not exist task
+	result = testutil.NewRequest().Post(conflictURL+"/replay").WithJsonBody(openapi.ReplayTaskConflictsRequest{}).GoWithHTTPHandler(s.T(), s1.openapiHandles)
+	s.Equal(http.StatusBadRequest, result.Code())
 }
 
 func TestOpenAPIViewSuite(t *testing.T) {
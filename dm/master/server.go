@@ -39,6 +39,7 @@ import (
 	"github.com/pingcap/tiflow/dm/config/security"
 	ctlcommon "github.com/pingcap/tiflow/dm/ctl/common"
 	"github.com/pingcap/tiflow/dm/loader"
+	"github.com/pingcap/tiflow/dm/master/alert"
 	"github.com/pingcap/tiflow/dm/master/metrics"
 	"github.com/pingcap/tiflow/dm/master/scheduler"
 	"github.com/pingcap/tiflow/dm/master/shardddl"
@@ -264,6 +265,14 @@ func (s *Server) Start(ctx context.Context) (err error) {
 		s.electionNotify(ctx)
 	}()
 
+	if s.cfg.Alert.Enabled() {
+		s.bgFunWg.Add(1)
+		go func() {
+			defer s.bgFunWg.Done()
+			alert.NewManager(&s.cfg.Alert, log.L()).Run(ctx, s.alertStatus)
+		}()
+	}
+
 	runBackgroundOnce.Do(func() {
 		s.bgFunWg.Add(1)
 		go func() {
@@ -855,6 +864,15 @@ func (s *Server) QueryStatus(ctx context.Context, req *pb.QueryStatusListRequest
 	return &pb.QueryStatusListResponse{Result: true, Sources: workerResps}, nil
 }
 
+// alertStatus returns the status of all the sources to evaluate the alert
+// rules, only the leader evaluates them.
+func (s *Server) alertStatus(ctx context.Context) ([]*pb.QueryStatusResponse, bool) {
+	if s.leader.Load() != oneselfLeader {
+		return nil, false
+	}
+	return s.getStatusFromWorkers(ctx, s.scheduler.GetSourceCfgIDs(), "", false), true
+}
+
 // adjust unsynced field in sync status by looking at DDL locks.
 // because if a DM-worker doesn't receive any shard DDL, it doesn't even know it's unsynced for itself.
 func (s *Server) fillUnsyncedStatus(resps []*pb.QueryStatusResponse) {
@@ -1765,6 +1783,8 @@ func (s *Server) removeMetaData(ctx context.Context, taskName, metaSchema string
 		dbutil.TableName(metaSchema, cputil.SyncerShardMeta(taskName))))
 	sqls = append(sqls, fmt.Sprintf("DROP TABLE IF EXISTS %s",
 		dbutil.TableName(metaSchema, cputil.SyncerOnlineDDL(taskName))))
+	sqls = append(sqls, fmt.Sprintf("DROP TABLE IF EXISTS %s",
+		dbutil.TableName(metaSchema, cputil.SyncerConflictQuarantine(taskName))))
 	sqls = append(sqls, fmt.Sprintf("DROP TABLE IF EXISTS %s",
 		dbutil.TableName(metaSchema, cputil.ValidatorCheckpoint(taskName))))
 	sqls = append(sqls, fmt.Sprintf("DROP TABLE IF EXISTS %s",
@@ -2548,10 +2568,12 @@ func (s *Server) HandleError(ctx context.Context, req *pb.HandleErrorRequest) (*
 	workerReq := workerrpc.Request{
 		Type: workerrpc.CmdHandleError,
 		HandleError: &pb.HandleWorkerErrorRequest{
-			Op:        req.Op,
-			Task:      req.Task,
-			BinlogPos: req.BinlogPos,
-			Sqls:      req.Sqls,
+			Op:         req.Op,
+			Task:       req.Task,
+			BinlogPos:  req.BinlogPos,
+			Sqls:       req.Sqls,
+			Tables:     req.Tables,
+			EventTypes: req.EventTypes,
 		},
 	}
 
@@ -122,6 +122,15 @@ type ServerInterface interface {
 	// update a task
 	// (PUT /api/v1/tasks/{task-name})
 	DMAPIUpdateTask(c *gin.Context, taskName string)
+	// get the quarantined conflicting rows of a task
+	// (GET /api/v1/tasks/{task-name}/conflicts)
+	DMAPIGetTaskConflictList(c *gin.Context, taskName string, params DMAPIGetTaskConflictListParams)
+	// replay the pending quarantined conflicting rows of a task
+	// (POST /api/v1/tasks/{task-name}/conflicts/replay)
+	DMAPIReplayTaskConflicts(c *gin.Context, taskName string)
+	// get the progress of each unit of a task
+	// (GET /api/v1/tasks/{task-name}/progress)
+	DMAPIGetTaskProgress(c *gin.Context, taskName string, params DMAPIGetTaskProgressParams)
 	// get task source table and target table route relation
 	// (GET /api/v1/tasks/{task-name}/sources/{source-name}/migrate_targets)
 	DMAPIGetTaskMigrateTargets(c *gin.Context, taskName string, sourceName string, params DMAPIGetTaskMigrateTargetsParams)
@@ -149,6 +158,9 @@ type ServerInterface interface {
 	// stop a task
 	// (POST /api/v1/tasks/{task-name}/stop)
 	DMAPIStopTask(c *gin.Context, taskName string)
+	// get the topology of a task, from the sources through the workers to the downstream
+	// (GET /api/v1/tasks/{task-name}/topology)
+	DMAPIGetTaskTopology(c *gin.Context, taskName string)
 }
 
 // ServerInterfaceWrapper converts contexts to parameters.
@@ -806,6 +818,92 @@ func (siw *ServerInterfaceWrapper) DMAPIUpdateTask(c *gin.Context) {
 	siw.Handler.DMAPIUpdateTask(c, taskName)
 }
 
+// DMAPIGetTaskConflictList operation middleware
+func (siw *ServerInterfaceWrapper) DMAPIGetTaskConflictList(c *gin.Context) {
+	var err error
+
+	// ------------- Path parameter "task-name" -------------
+	var taskName string
+
+	err = runtime.BindStyledParameter("simple", false, "task-name", c.Param("task-name"), &taskName)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter task-name: %s", err)})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DMAPIGetTaskConflictListParams
+
+	// ------------- Optional query parameter "status" -------------
+	if paramValue := c.Query("status"); paramValue != "" {
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "status", c.Request.URL.Query(), &params.Status)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter status: %s", err)})
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
+
+	siw.Handler.DMAPIGetTaskConflictList(c, taskName, params)
+}
+
+// DMAPIReplayTaskConflicts operation middleware
+func (siw *ServerInterfaceWrapper) DMAPIReplayTaskConflicts(c *gin.Context) {
+	var err error
+
+	// ------------- Path parameter "task-name" -------------
+	var taskName string
+
+	err = runtime.BindStyledParameter("simple", false, "task-name", c.Param("task-name"), &taskName)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter task-name: %s", err)})
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
+
+	siw.Handler.DMAPIReplayTaskConflicts(c, taskName)
+}
+
+// DMAPIGetTaskProgress operation middleware
+func (siw *ServerInterfaceWrapper) DMAPIGetTaskProgress(c *gin.Context) {
+	var err error
+
+	// ------------- Path parameter "task-name" -------------
+	var taskName string
+
+	err = runtime.BindStyledParameter("simple", false, "task-name", c.Param("task-name"), &taskName)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter task-name: %s", err)})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DMAPIGetTaskProgressParams
+
+	// ------------- Optional query parameter "source_name_list" -------------
+	if paramValue := c.Query("source_name_list"); paramValue != "" {
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "source_name_list", c.Request.URL.Query(), &params.SourceNameList)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter source_name_list: %s", err)})
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
+
+	siw.Handler.DMAPIGetTaskProgress(c, taskName, params)
+}
+
 // DMAPIGetTaskMigrateTargets operation middleware
 func (siw *ServerInterfaceWrapper) DMAPIGetTaskMigrateTargets(c *gin.Context) {
 	var err error
@@ -1139,6 +1237,26 @@ func (siw *ServerInterfaceWrapper) DMAPIStopTask(c *gin.Context) {
 	siw.Handler.DMAPIStopTask(c, taskName)
 }
 
+// DMAPIGetTaskTopology operation middleware
+func (siw *ServerInterfaceWrapper) DMAPIGetTaskTopology(c *gin.Context) {
+	var err error
+
+	// ------------- Path parameter "task-name" -------------
+	var taskName string
+
+	err = runtime.BindStyledParameter("simple", false, "task-name", c.Param("task-name"), &taskName)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter task-name: %s", err)})
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
+
+	siw.Handler.DMAPIGetTaskTopology(c, taskName)
+}
+
 // GinServerOptions provides options for the Gin server.
 type GinServerOptions struct {
 	BaseURL     string
@@ -1225,6 +1343,12 @@ func RegisterHandlersWithOptions(router *gin.Engine, si ServerInterface, options
 
 	router.PUT(options.BaseURL+"/api/v1/tasks/:task-name", wrapper.DMAPIUpdateTask)
 
+	router.GET(options.BaseURL+"/api/v1/tasks/:task-name/conflicts", wrapper.DMAPIGetTaskConflictList)
+
+	router.POST(options.BaseURL+"/api/v1/tasks/:task-name/conflicts/replay", wrapper.DMAPIReplayTaskConflicts)
+
+	router.GET(options.BaseURL+"/api/v1/tasks/:task-name/progress", wrapper.DMAPIGetTaskProgress)
+
 	router.GET(options.BaseURL+"/api/v1/tasks/:task-name/sources/:source-name/migrate_targets", wrapper.DMAPIGetTaskMigrateTargets)
 
 	router.GET(options.BaseURL+"/api/v1/tasks/:task-name/sources/:source-name/schemas", wrapper.DMAPIGetSchemaListByTaskAndSource)
@@ -1243,114 +1367,137 @@ func RegisterHandlersWithOptions(router *gin.Engine, si ServerInterface, options
 
 	router.POST(options.BaseURL+"/api/v1/tasks/:task-name/stop", wrapper.DMAPIStopTask)
 
+	router.GET(options.BaseURL+"/api/v1/tasks/:task-name/topology", wrapper.DMAPIGetTaskTopology)
+
 	return router
 }
 
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
-	"H4sIAAAAAAAC/+x9bXPbOJLwX8Gj5z7sTEmWZDtO4qv9kMSerO+cl4o9tbe1lWMgEpSwJgEGAO3Rpvzf",
-	"r/BCEiQBkrItx5pkP+w4Igh0N/odjea3UUjTjBJEBB8dfxvxcIVSqP58lSAm3kECl4hd0owmdLmWv2eM",
-	"ZogJjNSoFeVC/hf9AdMsQaPj0Xz/+d5sb7Y3H41HYp3Jn7hgmCxHt+NRRll9+MvZy4NyHCYCLREb3d6O",
-	"Rwx9zTFD0ej4n3oR8/LncjRd/AuFQs76Jsm5QOwdlP/fhhFGkfo1QjxkOBOYktGx+hVxDmgMxAqBMGcM",
-	"EQFSNQkgNEKjsQut4xf7R07cYIKvUXsdShJMEOACityshrlZxl5BsByVsy4oTRAkctoEwQg54Mfcnknh",
-	"YIYOmJTAFNW3TU/jQKyxF+rNAtkSurEmcsfm+FkISkYLUs1pgbDG/QdD8eh49P+nFZNODYdOnex5Ox4t",
-	"GYwhgYPneavH21NoUpQzBAnWPI4FSnnffJoJ7ekMRSBjUP07YzRFYoVyPhjIj+Ur9sQ3lF3dGc6/q5f9",
-	"cN76t1K/+t3kbEFzEgWc5ixEQcHI9TXVEKCHADmklDtNs/ay6Zp/TSazrgUFXPqXkg97F1FjXSu0xVFP",
-	"MVwcJenrkLoI5ZRPSq4RkzwL+dUn9DVHmovqeysgv+pjKTmBYiTIr4KQkhgvgxgnDqLph0A+BJiANUwT",
-	"EFOWQgFWQmT8eDqNaMj3MkyWIcz2QppO/72aChwtplzARYKmcpGJnidnUM47kdNN4jxJ9pxk68OcZ5Rw",
-	"9KdE3eYYhY4DUidvMAQFulAc5GUNzWB9FNKTWGrLx/OTfqY3K/ohfiBWdlHOtegJ5nJjPqEErq1lG3ow",
-	"lH8AQQEXNAMQMDkcMDN+3IDSolKp2Pv1+XuYonM52snwJ3maXSg/pA1e5Z9EeZqBnOA2THLZBAkUBYoR",
-	"1W+ad0fHo4jmiwRVe0fydCF9ufEIcYFTKFAgqIBJwOjN0DdjTDBfoShYrAXa+KUNFtKQObDCRBwdjno9",
-	"1Nr74zahWqg0wXRTycVsp2QzXoNM9DKbehosMEnoMlgKHDn5gwlMluDt5dlJYczzjAuGYAr0qzVjh17C",
-	"eRzu709QOHsxmc/Ry8liH4aT2f7hPgzn89lsdnA8nzx/cfhyNB6RPEkkXg2XtTKRNRDdVr8EUeqzyup3",
-	"g6kN/wKTvZn83/5wWCJsvJ0Y5onklb2pfqCXqMMmwYgwQ6GgbA1uVoghBZrel4QuAeZSMUh+GgDBNrTD",
-	"KWOU/R2L1TvEudPXkSyj7A1AcmyLjdSvQSjdnta76hkItUvUlKaxeTXlS9+bqQGqzzZUE41teFyS9BYJ",
-	"49GekZj6HYBQDwpcYmGeASy3rdQauU9tSE0zzOVvhk1NPC2gunHTAYncdj+GERRwcORQj7YdAY5SYMrS",
-	"DlCaUlLk6t1IaP59eCRMKLNlJLTv84DQV87U9sHWDsODAm58kC2DL324B6R56eJvGeR3eMmUC8uWSPAH",
-	"BL428WNg8rCcky+qOR8D+ktpgC8Ey0ORM+THQgMYhCrwCPjXpB7UvPl0+uryFFy+en1+Cr6I+Rfwly84",
-	"+gIwEX+Zz38B7z9cgve/n5+DV79ffgjO3r/5dPru9P3l+OOns3evPv0D/PfpP/Qbv4Dpr5f/759G76Mo",
-	"wCRCf3wGb85/v7g8/XR6An6d/gJO3789e3/61zNC6MlrcHL626vfzy/Bm7+9+nRxevnXXMQv0sUhePPh",
-	"/PzV5Wnxb+lWudISBrV2pBYtnIkS5ew6hqvf5wMi0/L1Yi6Lqs6taiTvHjw9fTCbze6dnj6nMOoPuxIK",
-	"I3fY1REF+f2MFAlo3GVLKCpUreelx9+mB6NLhjh3PtRxynCYGlRrBUT2fNbSdVQcgLtI3sjC3pcvfOny",
-	"QTz0Yv9o3ksNw/V9rPRBeeCoO2EVrlB4FTDEVVjS5LiMoYkaAcwIOxqqHmIOMsg5ivaAW9Tvk0QZ12Hs",
-	"wbSpiXuDXh2nIKB0iDfojZOcr2oRnA626rP+nWGBuIrVNF5yAZXIlhhkFBMBuPwFCnDyDoSQaEnGAsBY",
-	"RgYMlXGpfK1Iv7WOZPjXJAgpEYg4cONfE7CmObiBRFgY1vbOYWnAl3BemZrCGkhzMwZfwn3/owP3o3vY",
-	"l/90Gpg1CdvI/p5FsKA5zQROMRc4BHwFWSTJKDWAtN7gBouVzribraEkWYOco0hG2ARAE6gCGoY54wAT",
-	"75wnJ+cgrQWn5dY0k4/WPrkY13FWs41T0/ubpY85cwX5VUYilPjnGchogsM1qGWc27H/Hxlmxq8r5GnW",
-	"FCY1SGcQBNb5mXI5O34uTIgnD2KZOfknu9aOX7nuwdGstfTlCoFisJSgDDFMIxzCJFkDo/LidkpGoxWN",
-	"gZkcXMMkR8dALSEZiqOQkojfDXqGUohJwDMYohoG82dN+N9hgtM8BTFDCESYXwH1loLh7eu7LH/r44kH",
-	"zWM/Yt6uL09XWzNDIY7XBnieL6zsXEwZaIG9B85iQKgA+k0seUKduUtVJQAlCNzgJAELpBTQHrhQkJqz",
-	"nWOwD9Hzo8ODw0n8/GU8mc/Ri8kiQvtFOlQ6mi80KvP+BGBD0ts0dsm72tY3Sojb9FAWTR9NFULZFnGV",
-	"eQ70w8ojtGzYzzzyTuWRb31c0h+t2Gq7ziWmeqIKPepTNGhYHIRqMdGGpSLqXxpUnY/B/OXzl7+4hL22",
-	"rof5XDx3D2brZi43CJpwRRWEBOjhAQihCFdBngVpWRFVB+JmJT0UJpW4GgvyTDtT5e5Y4ZdPzJ16dTP+",
-	"rPDem/J8oaZ0uYnu0ouCiJora9N9ygmRL/dpzjqzOpnIRte1wz6iF2C7VPGFclfL45i2nGl3Vukedbwz",
-	"rpJk/VmYRmLsAoU5w2LdXkY50aZKhvOk7uFp8xZjlESlZVvhKEJEO9dLJMqgxp6oNgmIGU3VEOV7xdLP",
-	"aaulRviKmAhgktAbFAUhaYP9hqYpJeC90cwXF+dAvoNjHEKdPCiJ1UsczpMghP7Ay5pYq6pipM1tTp6V",
-	"E0tMvFP/Zk0n8fh4+s54C9P/eTZ7WVSFNFDrX/UKrf2LvqnWk7uSMXwtUbtC67IkxVq8Z71mZFSnpYMG",
-	"bQCd0mGCsreM5pkjbRwl7VK33o2OMeMiSGiorYzrFRmNomizaYXOpruG5mTzCVvJEjX7uMK5hUgJtrWg",
-	"k6hllU5D1ejf3b5ezS+JYcJb6ZHSkqgoXGsAGTap12sq3rzetibGrazM5aD1qHSztRMpo7lcKiillbnW",
-	"OS7z7gUhTuA1dVgz/XtZ11fSquH2uSSxCPFd1AamJtJd+OjMAEDObyiLvDOWA+pTHhw+OxriiRYZBvfc",
-	"8qE178HB7MgVzWZFQqGzlFUNqlyVMh7peskOXaSgWhat88yoGCff6aoXtSpFB1eFaq9js6Lb3uNPyK+G",
-	"F3VcQn5VlXSMRzl3+XoGN/mwhR+jVAystgscGWqzZF2Ei391aKEOx8cq2fU7PnrUZJj3Y5Pct17pQbrq",
-	"WfqLUrRDxFXeT7pEN4y6fM+C53kJTC/PV6xyD/5lKEtwCD183CjHbGfNTDWz8baTNdAlzyYN7tCJG9Zx",
-	"FpxlA+LkHRmWd1Z2MpTSaxSkSJ8rD7Yk+j2VV1au7AJy5QlF9IaYeKj42Z26hzEKUhqhQOAUBVGRI21H",
-	"RzhFoHgszYp8s8g7W3p7xp0apyLXIP3QEDats5hQQDpgg/zKlAuqATZA+7PZ0WQ2n8z2wfzZ8ezwePZs",
-	"WIn1haBZ55bdHycJLM3FYKrfQKzjFo0vzeqkf8YHYlarR2g7qXmaDRR0qyp3g0K4wTonoTAaCIl1UG0d",
-	"ejrYpDij7+DQPiXlD/L7TN6FGmj89YGYXaxJWGGmTtndmMlHQMFmc4U6pxq7XHyGOE2uURQoD52GV4Hn",
-	"KL1TzRYXRpykcZ8U+3VnQUqDp1OVVuToyPFJrN0VCSb/oed1ILuQlMBkKaniWsI+dbtZ4XBVJsQwB8XL",
-	"G8XxrazjwPygw0SHiIhAZEMLLcwBULBAK0wiK+U25N0yQHQYFfmsE6PaCD9Guq4CXRd3PAfAZcrZB9PA",
-	"koOlDNq79lwPaGw7ZAjkZFLMYm99p1jXMgW90bRNCBvJ2q6PhyUF69vj3IymHLjoZIXvtlD52MolzKo8",
-	"4r65RF+JVlvSLk3lR1t5+tREjBNJP5brhAKMIizfgsnH2ug+vf8ak3O6/E1N9knO5TLLiKwgCVGgb90G",
-	"RXHeCpIl6q31sFxCHcMAnmcy0lFHgqp0QF/mjaIEZEm+xGTIZVu8JJShQB0yS2Yoyd+40KuGgYwhcxyt",
-	"hjl36xoxrpM//YoRCWjIUD9litKJcpSbRHA4vQp9Ligrqi+8BzbVpN4aKr87YXMjv3KHd5QEUa7CGeGY",
-	"bUVv5OatIIl0bjVOcChQpDBRkWme6gPTLNGp6OIigya+JV+WmpVKRrn37uOOG7hWBymUSl0EBZJmzVos",
-	"Q5ybepPReFQVn7gX02Z9WFpEeUPqBSs3cpe0RG9trQrvU11AXApycyelwJgxQI0ZDy9OVkrMVCg3hLuR",
-	"a92ANrrU+QQK+FqGbkWCxb2VBeRFNGZ2L86TRCJCQoZSRHTtMExUPWrFsDBJhjpuFQg92qrB7E38nbvS",
-	"ZCC3vXDoUtfphEBK4OXEHEBRnNgm6BolLV1vlJyyro7QRf5c+NUe/VcbUyMtiNJkiK4zMJga7HYFXQaF",
-	"QEzVrmib5AfGN7yC639PmIod+zP6zh34LU8Sw+9SeH0Xha1cgeTEUr4kF3HHBU3CMReIhI7TPqWjiGA0",
-	"AYXawsT4YeoAT5c7USYVZqwua5WzAch5ziSv1vcmF9RFAjmdp+5GUCaj1wizttrfmxbrB0Zht2bWAwKx",
-	"YghG9Wqzw6YlUwTTL0j6hZQYd9Ppw+LUO/P8yDm1fqN3ah8HnJGQbcYBlhLyMIA0bMECirBe2Dpv18PZ",
-	"c0kXdMUowf8ul1JzAPQHCnP1k5SHrzkkAqul3MVsWTKQfE1E7kzD+p0Wt3dRiYy6UdOimdGYlY/Ue8Ju",
-	"3hDFEZnluPguRijNvcES5o2hS7gTq2a9BsBNcBqL+UyGP8IofbjO+IJfDQ4vKp+mnVhrRLvVCrODOJzt",
-	"Hx1M9l+EzyfzOXo+gUfPDiZH4Wzx4jB69jI+mB3PJ89nh/PD/YPx7Nnh88PoILSGvzh4tj/Znx1Ei/3D",
-	"oyg6iI7nk/nzmbMlSL1+zGrxoR5UhXy+NzNaJ9ChMz2wnZx/Rxbet/k1L9MDyoShBErb0V0oLFVn6bSE",
-	"Zo/7PLmmtbzVHtnG8zR1bt3j9hK5idFgt9bi5L7shA2HdxuKHGnhnV4ImmUqe1BVPP1mLtY44wunr+0v",
-	"0tNOvaD2UYjt4vOBMX/DeqqHaoKCfx0qQz4edsbHO2sbBvKlHSN78idjcIOTKIQsKhID9eB3Mfn1nlnx",
-	"1hmnL1suqvKMdhA2AFbhhLXzfM4yFz47ITx2uOKeh9yMiCKuS7JNlqbAmDe2ZX5HCg5cwGeRG+QZ3sTG",
-	"Ebt2kLRK03TT9ElVpGynAuUuhSFbqppw1kmUNPHuOkozKR/e81J6jdgNwwJtdMBdvqW9bWFWKf/ov/VU",
-	"rdsPuu9eYgxxolri8Kt2fqqj8sJ5+bBUp/3drgoFVk3q1F1No5KHIeLcA+5mdXztucZtariA0lfhHrQB",
-	"13A1pBd/5F5ajU41XUelHeGGvwSlvdHVit47T+ZyEweF9RLUlMXwrsZdfQe9dyiZ6SuSabR1fPiLz97G",
-	"hFu9+XyrUj9CKuPkhIaOhN3JO/AhQ+TVxzNw8uGNVLksGR2P+nrqTaTxnGiXFlNiWuzp+CKmisWxUIi3",
-	"FigOYY5HR5KA6nQiQwRmeHQ8OlA/SY0vVgraKczw9Ho+Nf0bpsX0xl8qWyudRWqtVx/P6u2J1CGl1qxq",
-	"vv3ZzGT8ikJvmOlUsUTjX1wXwlR+VGcPVHcjJEX1hlnUikxtIs/TFLL16FjiAMpGSCSmgOfhCkAOat2R",
-	"BFxyq3PR6LMqGfVhr5VPkwBKDF/TaP1guLf7LLWQNsuChVz39gnvQ65oVtuKPSfhb8ctftQHzHwoS1Zd",
-	"pR6HMR1drLrIMh4dPiAYrc5ojqW1Oe8QDKvhbWG4NtmY6Tf9h4oIb7X+S5D2Ax079SGOE0yQJtt7fdqU",
-	"QQZTpHf5n63jLwu8IiZX7SKgWI0KQzCyYBjZalwffbvym/6+0p9bjHPo8MOf2I5STddG++JBG1k4DAMl",
-	"rGp59jgS5mixtmMSZrVd3kjCzMZMvxkvbCMJM97jAAmzwfNLmAXDjy1h9SbanRsZpXsFcE7JeovECQ3/",
-	"6+LDe48o1cGSc5X3/NrsFtEQqOUqqCIaNiAyPmoHOH+7fHc+CBw5sAecldAH5D5wdJDXr3qqRoV9zCzl",
-	"q7jvpW4Ol1coFE9/zRFbW0yNxSooRziY2F06dTt2fExhDRgSOdOtXHSZ1sR0cSiuIrhAqDUv2ASGz9vV",
-	"vo7ekA5JsS/YJkUH1wYfNIdU/FDE+CpG4779t5t9b8vZdvQT39zhnj8YPGVO5MnbOd0ID0ASFaWJEBB0",
-	"Y++6a8PbOmD6zTpZ6LdyJ+phyRSdOmGZ0IVqp5MT/DWv3wr3G7z6Qccgg+e9lddWGDHV97toVkACE25a",
-	"1xR9CVRCx5RTuFSHmuOeOmMHDK/mAwD7eGo8xIbsIq88jk3bpj3p0Gdlx+BDJy8aylMBYvWRkrZ96WKI",
-	"vjTOzvDE5+3YPVca/7aeCJXg3n4f1nhieshkseB9bds00p/lUElwv9tjPt6xWyzaFzM8OduiifwAm1o1",
-	"pujYU/2NjJ9bus0tLd3Q++6oCsk2E9ZPRX+6H9OcuL43dGvsya5qhqpBWJwT3WKyuHT1MAy2geL4wdnL",
-	"8YWhXeUuo6S2zlxl65sO3qp6q/64rNXuLzvcDX7anKY4oNYWc3Nesr6+OyDE1k0EhyRrt8A6/hY82w1w",
-	"640Td+SAqugypItXfcnZoewx/ab/qDJ4A5hF1Xw/PV4ZdxT4epavcB+4vLP+d6tcWr+Rv1tMquuf786j",
-	"ZVeRIRqsbLv1dKxh58WZRzkLanw2aUfYx/62s/2t8/t7WIJBwmPzwWm/e3Vphv3oucZ2OeufxcUqGKFU",
-	"VRRA/T0EXSvQw136iKdPMxVfjetlIMnzkF895um3uTe1WBfNy3SbJ9eaxbOhBqtsq9W1qkM+mss227mN",
-	"N0pPWzZzy6q29XFABxMqIiemzdzTUbQlVBW762r6Icf7l7pPz/YO9+3rAt/zaN/1pawdOucvvxNV3+Gm",
-	"OpuGxTfseY95NB+73+b+1z+n72UBHGsexhxgkuVC91Y2ulT3mS+w0l1Gqy/V6x7llIFrHCJwjRiHW2Wi",
-	"Bkq7w0aXqkBKUZmYRq2mnTyNAWz26G8RdW8A5xV3x4aZ1OJ22CPUs+64ai8v591Lx19WN/u2IevmTtf3",
-	"U+8+AJ6oPq/t7CbCNdVNZnqU+5ka9Ej73ryjujkb7G8Jnt3Rz6Z10N3Z4ptqkbdJDV+DOzaKju0ufY6w",
-	"uIRlYFDsa++303Vz/pvVTQU+2FjuzjbNfjjF3rbXXVvuLZCr7lj/3PSdKU0buu8t/X03rf1UOaKr2FrB",
-	"gK4RAThWXeoBzxdF2MfKXkU/y619kf4AM7EzfPEIudLvoZ0aQeShrzNeR1G1f/f7SqqfMgNstYr6fgnG",
-	"2Y+eYCyrqwcmGC2T5TmfK3rwFf01h6SDan07+c4oskcvjnCesegu0aY78chX9PDr8Bl1I+nuCdWYXx//",
-	"TLzNLTt3Mq7O6uzqCkgi043W/MBoLsxdNFy7WHx3qRxcS1ZWkb1eS1q/ItHdTtB/EKH8Wd3Wxd/uErd7",
-	"c/GGJW9lsdtPlv5ZhLezsuSsxHtgUZLvLRK0YUpikaALwfJQ5OynTD01mRr7O9r6SF5wwGCau78Vtfvp",
-	"+5rkcYvFN03O/JSQnxIy/z7BUp35dj9Y6hRDf5asTM/8FMWNF/9RBPHhU5RWUrAph3+uWmwtcRuazW6v",
-	"VcDeOpfyE+A/WOa79enzXb2Pq78vfrfk87CbRdaHDHdQ2ZctzXe9tn5HLzGZaxWaezbjTpr1Ki/9Lfwf",
-	"TndptHdfddHMr7nUx0fYdbGj9ebza5rvRTSFmKjW8yNJajOBWxeM+rrdRzQc3OLe9LSffs1xeDVRGnii",
-	"y1InVVewmo4ZuTwzhfZ2obrBYjWJUgsetWwbmqILbDmu+OH28+3/BQAA//8+kDDk07YAAA==",
+	"H4sIAAAAAAACA+09a3PbOJJ/Bee7qtuZkizJrzyu9kMSe7K5c5xU7Km5ramchiIhiWOK5PBhRzvl/37d",
+	"eJAgCZCQLDnW2LdVF4+IR6O70W8Af+650SKOQhpm6d7rP/dSd04XDvvzTUCT7KMTOjOaXEVxFESzJf4e",
+	"J1EMX3zKWs2jNMN/6TdnEQd07/Xe6ODF/hD+N9rr7WXLGH9Ks8QPZ3t3vb04SqrNXw1fHRbt/DCjMNve",
+	"HbRM6B+5n1Bv7/WvfBLR+WvROpr8Tt0MR30X5GlGk48O/v8mjI7nsV89mrqJH2d+FEJ3/JWmKYmmJJtT",
+	"4uZJAlggCzYICSOPwpSaZb1+eXCiXZsT+De0OU8UBn5ISZo5WS5m81MxjTpDluS0GHUSRQF1QhwW/vWo",
+	"Bn4YRBmJrUE0tRg0dBa0SjY+jGZhNVqwnnKxBXQ9juQW4phZyEFGGy84p40zpd1/JHQKY/37oGTSgeDQ",
+	"gZY9YbpZ4kzhV+tx3vP26hAcFcUI48DnPO5ndJF2jceZUB1OYMRJEof9N6x+QYFceWoN5OeiizrwbZRc",
+	"rw3nL6yzGc47Myl51++2zyZRHnrjNMoTl44lI1fnZE0Ib0KwSbHvOM6a0y6W6R9Bf9g2YQasZpwKP3ZO",
+	"wtrqZmhuRz6E/XZE1Fch1SFKuz+j8AZoCLzgpNdfYGjKuahK2ww+drEUDsAYCf4du1E49WfjqR9okMY/",
+	"EvxI/JAsnUVAplGycDIyz7I4fT0YeJGb7sewZNeJ92Gywb/mg8z3JgNY3SSgA5ykz8fJEwfH7eNw/Wke",
+	"BPtatHWtPIX1pPQvuXSVY9hyNJBqeSOhTkYvGQcZWYMzWBeG+CCK2DLxfL+b6cWMZog3xMo6zOkmPfVT",
+	"JMwXGjhLZdqaHHTxD5JFICyimDgkweYkEe17NSgVLBWCvVueX0Dzc2ytZfjTfBFfMjukCV5pn3jQiuSh",
+	"34QJpw1oRr0xY0T2G+ddGMCLcvitpF2YLyZoy4EATDMf2lDQVJkTjJPo1rbn1A/9dA7zTZYZXbnTChNx",
+	"yDSrApP05Giv00Kt9O81EdVYSh1MPZZ0zHYWrsZrTpJ1Mhv7Op74IdgC4xnIGi1/QPNwRt5ffTiVyjyP",
+	"YYdSZ0F414qyo6+c0dQ9OOhTd/iyPxrRV/3JgeP2hwdH8M9oNBwOD1+P+i9eHr2CfiHILlxXzWQtVWQF",
+	"RL3WL0BEeVZq/XYwueKHD/tD/L8De1g8X1g7UycPkFf2B/wDn6IKG4IBHYCGUbIkt3OaUAYapwv0IGA3",
+	"gGBAfrKAYBvS4SxJouQXP5t/BHNNa+sgyzB9Qyi2bbAR+xWUiqfpy74Rl5tE9d3UE10X6czUcyGA6tIN",
+	"5UA9FR7dTnpPM2HRfginkdkAcHmjsW5biG/ER7IVUiM3iQ2UNHYmf91tqq9TAap9bdwhQbKbV+g5mWPt",
+	"OVS9bY2DwwQY07QWQhN3Cs7evgjOv5tfhHBltrwIbvtsEPrSmNo+2Nxg2CjgwgbZMvhow4GJPw18N9sg",
+	"7tVhH2IJGwb9IUD+6M8SZoUnM5qlGwS+MvBDrORzEs0werEp9s8n6qgPsYLNbl8O/8Pt3yu0gi7BCHKz",
+	"PKHmVXAAxy7z/sZg0VU9y3dfzt5cnZGrN2/Pz8hv2eg38rfffO83cMCzv41GP5CLT1fk4ufzc/Lm56tP",
+	"4w8X0P7j2cVV7/OXDx/ffPkn+Z+zf/IeP5DBj1f/9qtQvmC8+6FHv30l785/vrw6+3J2Sn4c/EDOLt5/",
+	"uDj7+4cwjE7fktOzn978fH5F3v3jzZfLs6u/59n05WJyRN59Oj8HqOR/o22riw2JpTXdZW+ijVYxj0PT",
+	"nP0+sggPFN3lWApWtaSqRVA3niM4BLv83jmC88jxun3fAFrpfV/Kt0nNNBU+m0dS6kahl6LLxR07HpCH",
+	"4XpkSPwp8bP/TIn0+QgYtHl4HUa3oWo2mq3GFkfY3GkBMAuPSdmSJaKV74XT16SGlFW6j6XDXMULQ6Oa",
+	"8QBnh8lsUvjDljIfWiuUM0mcFdBSY5uGW66Op6y+ik0N7nQ8V8sF3HdjmJI2VpsIo+md2BDbvmsvfWJ+",
+	"IG0PmwIJ3esxII85x3UOiRPaZy2IaKH65OVHcIpjJ02pt0/0su4+obxeFcaOldZVUWfohXvLlLO8MfQy",
+	"BVUyr8QRuMtfHfWXBPZLyjYSXxdOwNIpuII4AoKSFH9xMnL6kbhOyEWZnxFniv4prFFGR/hu5EHgRmIQ",
+	"hDxGhTNAoUZO/hGQZZSTWwemK1dYoZ1G1ZLf3FGpa6U6RH3bg08H5k+H+k/3ULD/pdWwy9BtLvbnGIwT",
+	"gfMIflyAHe67JJ07iYdoRAmA5gu59bM5z/sI0kRhsCQ5MC3GeULiiHAJiVw3T1KM+pvGPD09J4tKiKQg",
+	"TT0ErtBJx7iajOE2cvf318uf80QXairjYi6uP48JrMJ3l6SS92hq6W8xTJxW9tOwvplYIx7HAhKwKGEx",
+	"nU4dG6JxiqbFP5MbbvkW8x6eDBtTX80x68Mb4w4C0P3I810nAJYRIm/aDAzyZYEpIQYn0D2nrwmbAhlK",
+	"mB/rQZ8A0/rhOI0dl1ZWMDquw/8R1OUiX5BpQjGemV4T1ovB8P7tOtPfmXhio9mUB4wed0WLK3PG1PWn",
+	"SwF8mk+UGDFgkjTA3icfpiSMQNiznj7yBDM0UVRlIHkoiKMgIBPKBNA+uWSQigzja3Lg0BcnR4dH/emL",
+	"V1MMyr/sTzx6IIPyaGm/5EsZdYehazu9iWPdfmdkfcc2cRMfTKPxBKnclM0tzvIfY/6xNEoVHfaczdip",
+	"bMadiUu63TVVbFe5RNTwlN5PdYgaDmU6nm8TrlhKpP6thtVRj4xevXj1g26zV+Y1MJ+O5+7BbO3MpQeB",
+	"I07W4iBAmwfAdTJ3Ps7j8aKoy6sCAXwDKEhQiLO2gAxuTBXUUdwv0zbXytXV+LNc9/4AZDAbUmcm6guA",
+	"JBI5V1aG+5KHIXbukpxVZtUykbpcHYVNSJdg60VxDF3UeHZqLJrwvSLPWCtD9AoXH1PWSMyEjdsjdBFn",
+	"S7CTnTAlYOCAsRMyK1ckvIsIgEUoo7s2TbsYk29qtRo2IBjx9wZ39bCohE9HtUvmZBSp3KZ05E4I0xhs",
+	"EAX07uBhDfBLCuztZ8vmNMz1ERV2aRpU7XJulIB5EniFPTL3PQ+8IeYSzWhWuKLqQJVBwMiMFqwJs5in",
+	"aJ02lUkt6IClpMBr0S31xm7YBPtdtFjA0BdCn15enhPsA6aU62TVwFQncmDZsN/M7rIyMFcwsqUqI7SS",
+	"BgfGlRiH/kkZDtfx+eyjsPEG/3s8fCUrympL6571mi7Nk74r50OqxIl/g0uDPkU5mzJ5x3x1f7aKSw0O",
+	"mgBqd4dwpd8nUR5rsh1e0CyT7ST01E/SDAwhl9sGui4YQ6DeasPykKi2aR6uPmAjxMVG75VrbiykAFuZ",
+	"UIvUosKvJmr473oLvWJNTp0gbQS1Cv3PYidcAqCzy7pXFLPo3rQBhDNQGjlW80XoHHHTH33wHAUU06Up",
+	"lzk6o8wIwjRwbiKNDcJ/L2qCC1zVjHXdTpSBGR22iain1hdNa+M2TpreRolnHLFoUB3y8Oj4xMZ/kHEh",
+	"/dj4URn38HB4otOYsQwDtZbBs0algVl4kW2dVIcTN6qi0VpTnbId9mmrNVeqzK0ryrmtuFrBfmfpBBb0",
+	"WheEoaFUloPB/k91FrpYG35srC+JosyyUnesySuIKatbWP5XixRqMXyUcn+z4cNb9e2sHxXlpvkKu19X",
+	"C9dd0MYNopRFa9Ekuk0inccgeT4tgOnk+ZJV7sG/aAWD/WDg41opdzPWKU5CCB8pWBJ+XEIkLzQyccUa",
+	"cMlZKiBa3sFgSmtVeEIX0Q0dL4o8r6Um4f1YNoCZshMnZZaQF92GwouVP+sTLs4UZo08OsZY9NiTke2m",
+	"T4uhavkZ1Qr2lNkCRW4PU63EKdFlJR9qm43LLLDGEAoNbA5GglmpMWugAnQwHJ4A+/SHB2R0/Hp49Hp4",
+	"bHc84zKL4laS3X9NCGyUZ9ZYv3V87rfw9UZxFfXHqeXKamVATTM1X8RjNfNeS55SWEdYHjmirGYfXFXk",
+	"tB7PP9FvsEaWbFLr+Qtgjw72j3Wl+iiaUl28CCfkeazCQ07zicgjWhdwsRpjnajFbOVKK8YOphWrVRxd",
+	"K15LNrYpkSJM1IWLS9ZQ+A6VtbcyMTRW68fYMvUbEj8RBp8KOw6wsiyXJ9rqiNiAuJYYE0spePCreeOU",
+	"Glmzbew0pHIUZoXqc2tlzdjZDpJqeYse/YycsiarRbR3cfCmmNVuZchp5cpWZtTUwKh5CLwfBTfUGzPX",
+	"NnKvx4bipRV42uqYpTUXa5lX3bmaghj+hWl2aMmQwuNoIkcRR6nPtBD31Ali3EmwyoAXIFSSCR3ZEMu8",
+	"RZNfeJ55PKHA/54S2bcIipYRDY0VhN9aAay0MAG4Tly9OrV2ItO6izWZyN2dwSpI3aCZAICPq0HIBBkf",
+	"/sZNoJtCrSm5nfvuvEj3+CmRnVeKd26Mi7g1Mc5i23j6I2c7WTVIb+Q9GjY5An5k0BoHitibYXCzjea8",
+	"QY3sICuA0/pyFFuzrRpR7Yw6qohQF1mhem+DW7O6D3R4UsKc6qYysZVuM9cLUbsqXp1KvWtzb1tTfa2y",
+	"Yzbp96s77i4ZbtlDa1fySkzbV/ROGG9QwwkERvL7ptlMRfdN4XolSlmb5pFJM0z9ALdMknN8Op7HzAMn",
+	"+Fxp3WXZvfXD82j2ExvsC46l81hpOHdgC435ZTZjedwCfpzRzuJVJVrCw3vgOcYYBGQ1TqwWkt+RAzuY",
+	"xEE+80ObO2z8WRgldMyq5nD/F+ivpXRZMxKD+OH1dayZllo3NEl5XqRbF2L9N0dDtWzGW/RZDKmOBE08",
+	"iC0fqxBkOamxAqUc1FgUbnYYVG5Mr/VOaxSOvZxF+jLNaPPoFokH1PZ42pHl14X3zYK2+YJXgMUBz9LK",
+	"88Ec+cr+UjQr6hUW+dLXb9w6S1YZEkWofjDRCJaMMlkMm1gU0MKvZTWtfjJuuNtlDJi/wzooaYN1Ivad",
+	"p6VY5HvBD7UVG7lOSdwwog1hbVaItzAhJk7N1TZ3LQ25Am748btToP1bjGrK3IOelBJyGagU1MO7SnAh",
+	"oZvQBUZ2AnapFDthVDKsEwS2rlkJQoe0qjF7ff1aqtQZSK8vNLJUl7iHb6wKBr6nxMmkexeA0RQ0ZL0Q",
+	"csyg0hgC+LP0nA3yr9KmglriLQIbWSdgEKfqmkcCYieDNYXcCAm4Y2QAxtS8hOv/ThMWVu1Odmsp8A5l",
+	"PE2MBaYiUI1mmsubdlSUTxz3OppOwTj8plkOzVIRjQTWSnn0uyzwlurNybMIpbpLQAlFoGWW4lSGiKei",
+	"Ze7RjKJM3a9g43ihD+dLqJIoCPDvFtCaIDne73la1N8UMW2/LDFtAmwHlibzrzMIcK3JArYmuih120BD",
+	"Ios8vJEf5EntZgU5+SN3EieExfMiL8IFhPSaZBVZGV4pVXjzZhx+RFJmR1bMftQCkI2v3OksBh1Zme5q",
+	"uUrtyjIhcEQDpXyPFDKyK8poGQvnnUo7qbMATfQovATFeDF0+CMwBFiYXkmbqysI6sNWSGb5QviqKxR+",
+	"FU6gcmaNFzfqT6kxBaPHQksHLRK0EVF2VGld7qvpU+55VwKdVTLWiFRfXw18hREFsXqVO1qK9Lm6hapL",
+	"MmlbnkxqbuzKeSsMkPohOOQsyNzIXGkvsAmctF41sAzdvgjTGTavvPamLHU5GZ68Mt91w/V9ZZa5P5ub",
+	"J0jdKK7Rl4n10NFq8UV5i08zAOfcjl0nT6kxau4k7K7CzhCrsvZeBX1VqKuLLoFTQalMbKL5T2A3CnsW",
+	"Rbvpfj0lTY6WZmE/I9VTzb1mYQqGPQ1dTaEr80HCDDQtkW6JHwolwWpX+fkscGeB06bsjqNiNAKYAFDC",
+	"WpEDqlcdyXA4w0Eh8BIx/wQfm27d/kDOPxYOWZODWINxNodN5lWPxx3VtTNDGO+A+IPliAiiVs34C+PI",
+	"oxPt0LxH59Amjf4BXIbVOEBxMgwMgI7reIKl89UFNA/wqWOhUJgnUej/q5iKjQHIo27OUzVg74KZAUYG",
+	"m0p/+g7mtkNffSFr47B6j4reSi63DLvFpYGzB9DtrYpztKrmtE5Sr6XnTALLHEEsYjSt8cP02jp8WMYs",
+	"mqnxWgKjnGF4OHWHByeH/YOX7gs86vOi75wcH/ZP3OHk5ZF3/Gp6OMSjPsOj0dHBYW94fPTiyDt0leYv",
+	"D48P+gfDQ29ycHTieYceNB+9GGrdluqBN+VmXPahPHlo6hlHVQQdaTM+2yl3a6loMBG/EkUygNLHwr6M",
+	"5XPb/FAUnUVQwhU07orU1LXlHY+4rDxOXeZWI2pGJNdXZB22Uji5K+GkwmEkg6xykNEnLC2LWUKoPKL1",
+	"k8gbaOOH2lia+VQhD9qB5aBEfdUQXmoZ069pT/aRDSD5VyMy8LNdeWvaWtZvyZdqDNyQH+nhGSDPdRJP",
+	"Bv6rwe1J/8d71rU0vFFTvUtWxieaQVYLWDMtrK21Toq6MOmJzKCHS+7ZJDG8iKb8DLnIwsgVpzWyjNbE",
+	"oOUEJo1cQ4/93c+a2HQLSss0TDtOH9VhjO0cvljnTMSWDgxojwgUODFSncIcKJZNpcIYw7zFO3RWqu0u",
+	"enFrOxOzFH90X9NSztsNuumw6tTxA3aTdHrdzD+1HDrQ3pZUiNPuS+KlACsH1cquulLJXRd2hAHc1Y6w",
+	"NcfqNbFhRKzx3hvqzURyxTqNJsY682baFBq+9LDeiBcYOWk7zdP0B/ojqzv+Jfk4bD2x6i5ssRXqD/tO",
+	"AzzfzQ7I4T0jOC5uEgckPe4Xca0R3ykS9w0rFnrrwseD1mM5ke6dgIF17TGblA3TtfoLbQrT4WstLrJT",
+	"FtgTgSA/JfIQcw/+EqWlIv3TkjeQ+kN/CxMT2DqM6K50yEMfhB/xPQkoA1pSpSTISqhfMdyvvyGBfotZ",
+	"akt9JaWIwfKMVIG7ShOBRt6C/4fd/Qryh4aVAr+q6OkV85YUq1Crvm676LlItipvsyCNdczHLxnb6AMb",
+	"9vYSn/yB38qo3UTfVpXdEhcx7/2mKC1nNN4mJa6NSok0s0Gu8SnStoc5tnBOousgW+3Zps1fKWl8eGir",
+	"d0resRg1T2acRq4mo3f6kXyKafjm8wdy+ukd2oZJgCmTjjdz+qi6+tz3hoHEEzo8EDJleiXzM7bwxgSy",
+	"Guz13gkikJVJQQMn9uGnQ/YTmqbZnEE7gN8HN6OBuBp4IIcXjl3xdMIHj80F01SfH2AFstwEZOMdDIci",
+	"NSEvY3BiXrOCy/g95Ynd0uFrfeNM/9ABw3rNfucWFyNimi8WTgI8hmsgxUMHMALIbtB5Tkoqrx+A5E6V",
+	"lwn2vrJj3abVc+FTRwDbhm8jb7mxtTffUWgsWkxLJjjv3SOmA0+KVkixr0U89KrzIy9uTm1Zsnw14mEY",
+	"U/NKRRtaentHGwSj8fKJZmrud7RsDOVBO6m4ViHM4E/+Bwtd3XH5h08IGSj1aTrF2jaOtguefo2dBPpy",
+	"Kv/aqMNTwJPBQ3buCASYtFVkKxk+K8U4r+LRJWLM70Z+bTDOkSZg8MgoGnG81p4ntCKkNBgsd1j5pMnD",
+	"7DDNEyo7tsOUZxVX2mGCMIM/hRW20g4T1qPFDlPBM+8wBYanvcOqj2S2EtJb7EvgtDsLmByMxv++/HRh",
+	"2EpVsHCs4i6uJruBKUnYdCVU8FMNImGjtoDzj6uP51bgYMMOcOYZr9Q1gcOdvG7RUz5E1MXMuL/knUys",
+	"Xq6o02I8DQZTslSYGlqMixYaJtaf4bjraR5LxttwszzhcQteE9oX9+NKF1wHQuVa2FVg+Lpd6at5+0mz",
+	"U9RL8AL5QluND+pNSn6QPj7z0VIT/dXHPLdlbGveC13d4B5tDJ4iJvLo9RyvfiRO6Mk6aIeE9Faluo7g",
+	"TRkw+FNJgXZruVP2sWCKVpkwC6IJu6hchBpVjjQrvGpG1krhGW/OagqMacTvYIpiCYkTpOJScHnjKwvo",
+	"iLovnehgY9xTZuyA4uV8QJwunurZ6JBd5JWH0Wnb1Cct8qx4EfBIy4sC8xGeucT62qZ+aWOIrjDOzvDE",
+	"1+3oPV0Y/64aCEVw774PazwyOSSiWM59ddvA489usyC42ewRj3PvFot2+QyPTrdwJG+AqOURshaa8jew",
+	"n0m6TZIWZuh9KcpcstU26xf58sfTVCcqFhR1crfLkqF8emGah/zxHnk8djMMtoLgeOLspSBh17lLCKmt",
+	"M1dxPXULb5WvVj1d1mq+3GVvBj9uTmMcUHlwaHVeEkBYhmn5Qx82wdotsI65zGu7Dm71cZMdSVDJm8B5",
+	"lb0pOGvLHvAr+6OM4FkwC7+g7NHxSq/lJIJh+nLtltNrDypslUurV4PtFpPygxrr82hx9YGNBCuuxn88",
+	"2rD1hN+D5II4VnYtCc+eZqw8dVbcPX5PCytLnDCd8tMkLebVlWj21GONzXLWv4qJJRmhEFURcfhLs0Xt",
+	"dxt38RRPl2TCamUbPckv70yvHzL7LQ54TpbFJUgzU7pbfrNVWMUN3m2zavZHfdr6kwu9lcLTis7csqiV",
+	"ZG4TswzJgXgK4vEI2gKqkt15Nb1Nev+KX2uzveS+elzge6b2P/G35zk4u6NKZZ6fiPuHahSuizOYO7yh",
+	"iazcbSM/b7hN+ktQOlgAHzBHHvbxLY44z/j7Z0KW8rcg5ar4S0B4WE28+szeEYwScuODHMICfGerTFRb",
+	"0u6w0RUrkGJYDsVjSuLJR3b5dO0dzQZS9y04Tx5ytVOp8hjrA9Sz7rhoL04R30vGX5VHkLex18WZru8n",
+	"3k0APFJ5XqHsKptrwG/D6hDuH1ijB6J7/TD96mxwsCV4dkc+izvO1meLP9lJ71Vq+GrcsZJ3rF4XrnGL",
+	"C1gsnWLjKfVdrpszXwFRF+DWynJ3yDR8coK9qa/bSG4skCvPWD8TfWdK02zp3pDf60ntx8oRbcXWDAa8",
+	"Wh7fmMEH8Yr7HMDXS4p7GZ7LrU2evoWa2Bm+eIBY6feQTjUn8sh0hWdLUbWZ+l0l1Y+ZAbZaRX2/AOPw",
+	"qQcYi+pqywCjorIGxZsHViEf+bSCVTblsYiqauqy9gJE2iPiGn8Ml7HXjJbU6xEwALIlWYAkSokj3q3R",
+	"pmSMUs38PMBDJEJUSu1i3lklk+RR9oQOXkgmXttbn9kHnNAdIZgvrJGKzfSJiWcNBrrLR7c38+6wMecv",
+	"xslSvGyFo9V3Fzul9+fyHcQdkdxlcrq4GGzXM9SSCLsolNUHq6njzosHVtbiXX1pkLynXL5BYMPYlbcN",
+	"dpK9H6YuU7t5+Et54oW2PVO95Y/2I/LH9NoHZG1+/D6mUZVbdm4fsjIhtbATLxBQnx4GjZJn4hi8X7nT",
+	"ZP1daV3GXhSwv2XK+03orVe890Q25XNhfRt/66vr783FK1bbF3X2zyz9XP+/s3tJewhgw1sJ++HdTatl",
+	"Q/BYN5DazfLkeU89tj3VM7/6YUK55ABrnEOHv2TlQGXnpQqLr5oXet4hzztk9H2cpSrz7b6z1LoNzQm6",
+	"IjP0vBVXnvypbMTNh9+VfGR9H/61joHxHbei2my3WjOns8T2Ets8waR7se5dvwqEEXnN4LPdoWZxim6N",
+	"I83PSZMNWiE7en5anOjk3LMad0Zxp/CK4icpu/iyd190RfF6kitTHgLqLjtXXmV7ihXIbW/APNYsqyRw",
+	"mVjt8Sf42BNqPBoHfydRPpsrb6alRJwKrLxmVucs9vRnciO5oPqi0jLK971o4fghe09pD8kjBtBrmb2u",
+	"J5zw2nrbd5vEQ00DYCf3us90e5+fteqXV91WtNeezuZnG2q7UGFFa99bKPCwaZvQyKcNinbyh7uvd/8P",
+	"zLAnuYjZAAA=",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -184,6 +184,12 @@ type GetSourceStatusResponse struct {
 	Total int            `json:"total"`
 }
 
+// GetTaskConflictListResponse defines model for GetTaskConflictListResponse.
+type GetTaskConflictListResponse struct {
+	Data  []TaskConflict `json:"data"`
+	Total int            `json:"total"`
+}
+
 // GetTaskListResponse defines model for GetTaskListResponse.
 type GetTaskListResponse struct {
 	Data  []Task `json:"data"`
@@ -196,6 +202,12 @@ type GetTaskMigrateTargetsResponse struct {
 	Total int                 `json:"total"`
 }
 
+// GetTaskProgressResponse defines model for GetTaskProgressResponse.
+type GetTaskProgressResponse struct {
+	Data  []SubTaskProgress `json:"data"`
+	Total int               `json:"total"`
+}
+
 // GetTaskStatusResponse defines model for GetTaskStatusResponse.
 type GetTaskStatusResponse struct {
 	Data  []SubTaskStatus `json:"data"`
@@ -217,11 +229,16 @@ type GrafanaTopology struct {
 
 // status of load unit
 type LoadStatus struct {
+	// estimated seconds to finish the load, 0 if it's finished or unknown
+	Eta            *int64 `json:"eta,omitempty"`
 	FinishedBytes  int64  `json:"finished_bytes"`
 	MetaBinlog     string `json:"meta_binlog"`
 	MetaBinlogGtid string `json:"meta_binlog_gtid"`
 	Progress       string `json:"progress"`
-	TotalBytes     int64  `json:"total_bytes"`
+
+	// load status of the target tables
+	Tables     *[]TableLoadStatus `json:"tables,omitempty"`
+	TotalBytes int64              `json:"total_bytes"`
 }
 
 // MasterTopology defines model for MasterTopology.
@@ -314,6 +331,19 @@ type RelayStatus struct {
 	Stage string `json:"stage"`
 }
 
+// ReplayTaskConflictsRequest defines model for ReplayTaskConflictsRequest.
+type ReplayTaskConflictsRequest struct {
+	// ids of the rows to replay, empty means all pending rows
+	IdList *[]int64 `json:"id_list,omitempty"`
+}
+
+// ReplayTaskConflictsResponse defines model for ReplayTaskConflictsResponse.
+type ReplayTaskConflictsResponse struct {
+	// ids of the replayed rows
+	IdList []int64 `json:"id_list"`
+	Total  int     `json:"total"`
+}
+
 // schema name list
 type SchemaNameList []string
 
@@ -423,6 +453,31 @@ type StopTaskRequest struct {
 	TimeoutDuration *string `json:"timeout_duration,omitempty"`
 }
 
+// SubTaskProgress defines model for SubTaskProgress.
+type SubTaskProgress struct {
+	// percentage of the dumped data, only exists in dump unit
+	DumpProgress *float64 `json:"dump_progress,omitempty"`
+
+	// recent errors of the subtask
+	Errors []TaskError `json:"errors"`
+
+	// percentage of the loaded data, only exists in load unit
+	LoadProgress *float64 `json:"load_progress,omitempty"`
+
+	// source name
+	SourceName string    `json:"source_name"`
+	Stage      TaskStage `json:"stage"`
+
+	// progress of sync unit, the binlog position synced compared with the upstream
+	SyncProgress *SyncProgress `json:"sync_progress,omitempty"`
+
+	// task unit type
+	Unit string `json:"unit"`
+
+	// worker name
+	WorkerName string `json:"worker_name"`
+}
+
 // SubTaskStatus defines model for SubTaskStatus.
 type SubTaskStatus struct {
 	// status of dump unit
@@ -452,6 +507,16 @@ type SubTaskStatus struct {
 	WorkerName string `json:"worker_name"`
 }
 
+// progress of sync unit, the binlog position synced compared with the upstream
+type SyncProgress struct {
+	MasterBinlog        string `json:"master_binlog"`
+	MasterBinlogGtid    string `json:"master_binlog_gtid"`
+	SecondsBehindMaster int64  `json:"seconds_behind_master"`
+	Synced              bool   `json:"synced"`
+	SyncerBinlog        string `json:"syncer_binlog"`
+	SyncerBinlogGtid    string `json:"syncer_binlog_gtid"`
+}
+
 // status of sync unit
 type SyncStatus struct {
 	BinlogType string `json:"binlog_type"`
@@ -472,6 +537,18 @@ type SyncStatus struct {
 	UnresolvedGroups []ShardingGroup `json:"unresolved_groups"`
 }
 
+// load status of a target table
+type TableLoadStatus struct {
+	Bps int64 `json:"bps"`
+
+	// estimated seconds to finish the table, 0 if it's finished or unknown
+	Eta           int64  `json:"eta"`
+	FinishedBytes int64  `json:"finished_bytes"`
+	Progress      string `json:"progress"`
+	Table         string `json:"table"`
+	TotalBytes    int64  `json:"total_bytes"`
+}
+
 // schema name list
 type TableNameList []string
 
@@ -534,6 +611,37 @@ type TaskBinLogFilterRule struct {
 	IgnoreSql *[]string `json:"ignore_sql,omitempty"`
 }
 
+// a quarantined row change which conflicts with the downstream
+type TaskConflict struct {
+	CreateTime string `json:"create_time"`
+	ErrorMsg   string `json:"error_msg"`
+	Id         int64  `json:"id"`
+
+	// binlog location of the row change
+	Location     string `json:"location"`
+	SourceName   string `json:"source_name"`
+	SourceSchema string `json:"source_schema"`
+	SourceTable  string `json:"source_table"`
+
+	// statements of the row change with their arguments
+	Sqls         []string `json:"sqls"`
+	Status       string   `json:"status"`
+	TargetSchema string   `json:"target_schema"`
+	TargetTable  string   `json:"target_table"`
+	UpdateTime   string   `json:"update_time"`
+}
+
+// an error occurred in a unit of the subtask
+type TaskError struct {
+	ErrorClass string `json:"error_class"`
+	ErrorCode  int    `json:"error_code"`
+	ErrorLevel string `json:"error_level"`
+	ErrorScope string `json:"error_scope"`
+	Message    string `json:"message"`
+	RawCause   string `json:"raw_cause"`
+	Workaround string `json:"workaround"`
+}
+
 // configuration of full migrate tasks
 type TaskFullMigrateConf struct {
 	// to control the way in which data is exported for consistency assurance
@@ -654,6 +762,34 @@ type TaskTemplateResponse struct {
 	SuccessTaskList []string `json:"success_task_list"`
 }
 
+// TaskTopology defines model for TaskTopology.
+type TaskTopology struct {
+	Edges    []TaskTopologyEdge `json:"edges"`
+	Nodes    []TaskTopologyNode `json:"nodes"`
+	TaskName string             `json:"task_name"`
+}
+
+// data flows from one node to another in the task topology
+type TaskTopologyEdge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// a node of the task topology, which is a source, a worker or the downstream
+type TaskTopologyNode struct {
+	Address string `json:"address"`
+
+	// unique id of the node in the topology
+	Id   string `json:"id"`
+	Name string `json:"name"`
+
+	// expected stage of the subtask for a source, stage of the worker for a worker
+	Stage *string `json:"stage,omitempty"`
+
+	// type of the node, source, worker or downstream
+	Type string `json:"type"`
+}
+
 // UpdateSourceRequest defines model for UpdateSourceRequest.
 type UpdateSourceRequest struct {
 	// source
@@ -763,6 +899,21 @@ type DMAPIGetTaskParams struct {
 // DMAPIUpdateTaskJSONBody defines parameters for DMAPIUpdateTask.
 type DMAPIUpdateTaskJSONBody UpdateTaskRequest
 
+// DMAPIGetTaskConflictListParams defines parameters for DMAPIGetTaskConflictList.
+type DMAPIGetTaskConflictListParams struct {
+	// status of the quarantined rows, pending or replayed, empty means all
+	Status *string `json:"status,omitempty"`
+}
+
+// DMAPIReplayTaskConflictsJSONBody defines parameters for DMAPIReplayTaskConflicts.
+type DMAPIReplayTaskConflictsJSONBody ReplayTaskConflictsRequest
+
+// DMAPIGetTaskProgressParams defines parameters for DMAPIGetTaskProgress.
+type DMAPIGetTaskProgressParams struct {
+	// source name list
+	SourceNameList *SourceNameList `json:"source_name_list,omitempty"`
+}
+
 // DMAPIGetTaskMigrateTargetsParams defines parameters for DMAPIGetTaskMigrateTargets.
 type DMAPIGetTaskMigrateTargetsParams struct {
 	SchemaPattern *string `json:"schema_pattern,omitempty"`
@@ -820,6 +971,9 @@ type DMAPIImportTaskTemplateJSONRequestBody DMAPIImportTaskTemplateJSONBody
 // DMAPIUpdateTaskJSONRequestBody defines body for DMAPIUpdateTask for application/json ContentType.
 type DMAPIUpdateTaskJSONRequestBody DMAPIUpdateTaskJSONBody
 
+// DMAPIReplayTaskConflictsJSONRequestBody defines body for DMAPIReplayTaskConflicts for application/json ContentType.
+type DMAPIReplayTaskConflictsJSONRequestBody DMAPIReplayTaskConflictsJSONBody
+
 // DMAPIOperateTableStructureJSONRequestBody defines body for DMAPIOperateTableStructure for application/json ContentType.
 type DMAPIOperateTableStructureJSONRequestBody DMAPIOperateTableStructureJSONBody
 
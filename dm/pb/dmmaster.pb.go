@@ -2877,11 +2877,13 @@ func (m *GetMasterCfgResponse) GetCfg() string {
 }
 
 type HandleErrorRequest struct {
-	Op        ErrorOp  `protobuf:"varint,1,opt,name=op,proto3,enum=pb.ErrorOp" json:"op,omitempty"`
-	Task      string   `protobuf:"bytes,2,opt,name=task,proto3" json:"task,omitempty"`
-	Sources   []string `protobuf:"bytes,3,rep,name=sources,proto3" json:"sources,omitempty"`
-	BinlogPos string   `protobuf:"bytes,4,opt,name=binlogPos,proto3" json:"binlogPos,omitempty"`
-	Sqls      []string `protobuf:"bytes,5,rep,name=sqls,proto3" json:"sqls,omitempty"`
+	Op         ErrorOp  `protobuf:"varint,1,opt,name=op,proto3,enum=pb.ErrorOp" json:"op,omitempty"`
+	Task       string   `protobuf:"bytes,2,opt,name=task,proto3" json:"task,omitempty"`
+	Sources    []string `protobuf:"bytes,3,rep,name=sources,proto3" json:"sources,omitempty"`
+	BinlogPos  string   `protobuf:"bytes,4,opt,name=binlogPos,proto3" json:"binlogPos,omitempty"`
+	Sqls       []string `protobuf:"bytes,5,rep,name=sqls,proto3" json:"sqls,omitempty"`
+	Tables     []string `protobuf:"bytes,6,rep,name=tables,proto3" json:"tables,omitempty"`
+	EventTypes []string `protobuf:"bytes,7,rep,name=eventTypes,proto3" json:"eventTypes,omitempty"`
 }
 
 func (m *HandleErrorRequest) Reset()         { *m = HandleErrorRequest{} }
@@ -2952,6 +2954,20 @@ func (m *HandleErrorRequest) GetSqls() []string {
 	return nil
 }
 
+func (m *HandleErrorRequest) GetTables() []string {
+	if m != nil {
+		return m.Tables
+	}
+	return nil
+}
+
+func (m *HandleErrorRequest) GetEventTypes() []string {
+	if m != nil {
+		return m.EventTypes
+	}
+	return nil
+}
+
 type HandleErrorResponse struct {
 	Result  bool                    `protobuf:"varint,1,opt,name=result,proto3" json:"result,omitempty"`
 	Msg     string                  `protobuf:"bytes,2,opt,name=msg,proto3" json:"msg,omitempty"`
@@ -3591,155 +3607,156 @@ func init() {
 func init() { proto.RegisterFile("dmmaster.proto", fileDescriptor_f9bef11f2a341f03) }
 
 var fileDescriptor_f9bef11f2a341f03 = []byte{
-	// 2366 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x3a, 0x4b, 0x6f, 0x1b, 0xc9,
-	0xd1, 0x1c, 0x92, 0xa2, 0xc8, 0xa2, 0x44, 0x53, 0x2d, 0x89, 0x1a, 0x8f, 0x65, 0x5a, 0xee, 0x7d,
-	0x40, 0x10, 0x3e, 0x58, 0xb0, 0xbe, 0x9c, 0x16, 0xd8, 0x20, 0x6b, 0xd1, 0x6b, 0x0b, 0x91, 0xd7,
-	0x9b, 0x91, 0xed, 0x64, 0x11, 0x20, 0x9b, 0x21, 0xd9, 0xa4, 0x08, 0x0d, 0x67, 0xc6, 0x33, 0x43,
-	0x69, 0x0d, 0x63, 0x73, 0xc8, 0x29, 0xa7, 0x3c, 0xb0, 0x41, 0xf6, 0x98, 0x43, 0xfe, 0x40, 0x7e,
-	0x46, 0x8e, 0x0b, 0xe4, 0x92, 0x4b, 0x80, 0xc0, 0xce, 0x3d, 0x7f, 0x21, 0xe8, 0xea, 0x9e, 0x99,
-	0x9e, 0x07, 0xb9, 0xe1, 0x02, 0x11, 0x72, 0x9b, 0xaa, 0x6a, 0xd6, 0xbb, 0xab, 0xab, 0x0a, 0x84,
-	0xd6, 0x70, 0x3a, 0xb5, 0x82, 0x90, 0xf9, 0xf7, 0x3c, 0xdf, 0x0d, 0x5d, 0x52, 0xf6, 0xfa, 0x46,
-	0x6b, 0x38, 0xbd, 0x72, 0xfd, 0x8b, 0x08, 0x67, 0xec, 0x8e, 0x5d, 0x77, 0x6c, 0xb3, 0x43, 0xcb,
-	0x9b, 0x1c, 0x5a, 0x8e, 0xe3, 0x86, 0x56, 0x38, 0x71, 0x9d, 0x40, 0x50, 0xe9, 0x2f, 0xa0, 0x7d,
-	0x16, 0x5a, 0x7e, 0xf8, 0xcc, 0x0a, 0x2e, 0x4c, 0xf6, 0x72, 0xc6, 0x82, 0x90, 0x10, 0xa8, 0x86,
-	0x56, 0x70, 0xa1, 0x6b, 0x7b, 0xda, 0x7e, 0xc3, 0xc4, 0x6f, 0xa2, 0xc3, 0x6a, 0xe0, 0xce, 0xfc,
-	0x01, 0x0b, 0xf4, 0xf2, 0x5e, 0x65, 0xbf, 0x61, 0x46, 0x20, 0xe9, 0x02, 0xf8, 0x6c, 0xea, 0x5e,
-	0xb2, 0x27, 0x2c, 0xb4, 0xf4, 0xca, 0x9e, 0xb6, 0x5f, 0x37, 0x15, 0x0c, 0xd9, 0x85, 0x46, 0x80,
-	0x12, 0x26, 0x53, 0xa6, 0x57, 0x91, 0x65, 0x82, 0xa0, 0x5f, 0x69, 0xb0, 0xa1, 0x28, 0x10, 0x78,
-	0xae, 0x13, 0x30, 0xd2, 0x81, 0x9a, 0xcf, 0x82, 0x99, 0x1d, 0xa2, 0x0e, 0x75, 0x53, 0x42, 0xa4,
-	0x0d, 0x95, 0x69, 0x30, 0xd6, 0xcb, 0xc8, 0x85, 0x7f, 0x92, 0xa3, 0x44, 0xaf, 0xca, 0x5e, 0x65,
-	0xbf, 0x79, 0xa4, 0xdf, 0xf3, 0xfa, 0xf7, 0x8e, 0xdd, 0xe9, 0xd4, 0x75, 0x7e, 0x8c, 0x6e, 0x88,
-	0x98, 0x26, 0x1a, 0xef, 0x41, 0x73, 0x70, 0xce, 0x06, 0x5c, 0x1c, 0x17, 0x21, 0x74, 0x52, 0x51,
-	0xf4, 0x67, 0x40, 0x9e, 0x7a, 0xcc, 0xb7, 0x42, 0xa6, 0xfa, 0xc5, 0x80, 0xb2, 0xeb, 0xa1, 0x46,
-	0xad, 0x23, 0xe0, 0x62, 0x38, 0xf1, 0xa9, 0x67, 0x96, 0x5d, 0x8f, 0xfb, 0xcc, 0xb1, 0xa6, 0x4c,
-	0xaa, 0x86, 0xdf, 0xaa, 0xcf, 0x2a, 0x29, 0x9f, 0xd1, 0xdf, 0x68, 0xb0, 0x99, 0x12, 0x20, 0xed,
-	0x5e, 0x24, 0x21, 0xf1, 0x49, 0xb9, 0xc8, 0x27, 0x95, 0x42, 0x9f, 0x54, 0xff, 0x43, 0x9f, 0xd0,
-	0x8f, 0x60, 0xe3, 0xb9, 0x37, 0xcc, 0x18, 0xbc, 0x54, 0x22, 0xd0, 0xdf, 0x6b, 0x40, 0x54, 0x1e,
-	0xff, 0x23, 0xb1, 0xfc, 0x18, 0x3a, 0x3f, 0x9a, 0x31, 0xff, 0xd5, 0x59, 0x68, 0x85, 0xb3, 0xe0,
-	0x74, 0x12, 0x84, 0x8a, 0x79, 0x18, 0x33, 0xad, 0x38, 0x66, 0x19, 0xf3, 0x2e, 0x61, 0x27, 0xc7,
-	0x67, 0x69, 0x13, 0xef, 0x67, 0x4d, 0xdc, 0xe1, 0x26, 0x2a, 0x7c, 0xf3, 0x91, 0x39, 0x86, 0xcd,
-	0xb3, 0x73, 0xf7, 0xaa, 0xd7, 0x3b, 0x3d, 0x75, 0x07, 0x17, 0xc1, 0x77, 0x8b, 0xcd, 0x1f, 0x35,
-	0x58, 0x95, 0x1c, 0x48, 0x0b, 0xca, 0x27, 0x3d, 0xf9, 0xbb, 0xf2, 0x49, 0x2f, 0xe6, 0x54, 0x56,
-	0x38, 0x11, 0xa8, 0x4e, 0xdd, 0x21, 0x93, 0x59, 0x85, 0xdf, 0x64, 0x0b, 0x56, 0xdc, 0x2b, 0x87,
-	0xf9, 0xd2, 0xc9, 0x02, 0xe0, 0x27, 0x7b, 0xbd, 0xd3, 0x40, 0x5f, 0x41, 0x81, 0xf8, 0xcd, 0xfd,
-	0x11, 0xbc, 0x72, 0x06, 0x6c, 0xa8, 0xd7, 0x10, 0x2b, 0x21, 0x62, 0x40, 0x7d, 0xe6, 0x48, 0xca,
-	0x2a, 0x52, 0x62, 0x98, 0x0e, 0x60, 0x2b, 0x6d, 0xe6, 0xd2, 0xbe, 0xbd, 0x0b, 0x2b, 0x36, 0xff,
-	0xa9, 0xf4, 0x6c, 0x93, 0x7b, 0x56, 0xb2, 0x33, 0x05, 0x85, 0xfe, 0x5d, 0x83, 0xad, 0xe7, 0x0e,
-	0xff, 0x8e, 0x08, 0xd2, 0x9b, 0x59, 0x9f, 0x50, 0x58, 0xf3, 0x99, 0x67, 0x5b, 0x03, 0xf6, 0x14,
-	0x4d, 0x16, 0x62, 0x52, 0x38, 0x9e, 0x7a, 0x23, 0xd7, 0x1f, 0x30, 0x13, 0x6b, 0x9d, 0xac, 0x7c,
-	0x2a, 0x8a, 0xbc, 0x83, 0xd7, 0xb9, 0x8a, 0xd7, 0x79, 0x93, 0xab, 0x93, 0x92, 0x2d, 0xef, 0xb5,
-	0x12, 0xb4, 0x95, 0x74, 0x65, 0x35, 0xa0, 0x3e, 0xb4, 0x42, 0xab, 0x6f, 0x05, 0x4c, 0xaf, 0xa1,
-	0x02, 0x31, 0xcc, 0x83, 0x11, 0x5a, 0x7d, 0x9b, 0xe9, 0xab, 0x22, 0x18, 0x08, 0xd0, 0x8f, 0x60,
-	0x3b, 0x63, 0xde, 0xb2, 0x5e, 0xa4, 0x26, 0xdc, 0x94, 0x95, 0x29, 0xba, 0x72, 0xb6, 0xf5, 0x2a,
-	0x72, 0xd3, 0x2d, 0xa5, 0x3e, 0xa1, 0x7f, 0x91, 0x9a, 0x37, 0x24, 0x93, 0x7d, 0x5f, 0x6b, 0x60,
-	0x14, 0x31, 0x95, 0xca, 0x2d, 0xe4, 0xfa, 0xdf, 0x2d, 0x7b, 0x5f, 0x6b, 0xb0, 0xf3, 0xe9, 0xcc,
-	0x1f, 0x17, 0x19, 0xab, 0xd8, 0xa3, 0xe5, 0x02, 0x33, 0x71, 0xac, 0x41, 0x38, 0xb9, 0x64, 0x52,
-	0xab, 0x18, 0xc6, 0xdb, 0xc4, 0x5f, 0x3a, 0xae, 0x58, 0xc5, 0xc4, 0x6f, 0x7e, 0x7e, 0x34, 0xb1,
-	0x19, 0x16, 0x1b, 0x71, 0x79, 0x62, 0x18, 0xef, 0xca, 0xac, 0xdf, 0x9b, 0xf8, 0xfa, 0x0a, 0x52,
-	0x24, 0x44, 0xbf, 0x00, 0x3d, 0xaf, 0xd8, 0x75, 0x94, 0x54, 0x7a, 0x09, 0xed, 0x63, 0x5e, 0x3f,
-	0xbf, 0xed, 0x25, 0xe8, 0x40, 0x8d, 0xf9, 0xfe, 0xb1, 0x23, 0x22, 0x53, 0x31, 0x25, 0xc4, 0xfd,
-	0x76, 0x65, 0xf9, 0x0e, 0x27, 0x08, 0x27, 0x44, 0xe0, 0xb7, 0xb4, 0x02, 0x1f, 0xc2, 0x86, 0x22,
-	0x77, 0xe9, 0xc4, 0xfd, 0x95, 0x06, 0x5b, 0x32, 0xc9, 0xce, 0xd0, 0x92, 0x48, 0xf7, 0x5d, 0x25,
-	0xbd, 0xd6, 0xb8, 0xf9, 0x82, 0x9c, 0xe4, 0xd7, 0xc0, 0x75, 0x46, 0x93, 0xb1, 0x4c, 0x5a, 0x09,
-	0xf1, 0x98, 0x09, 0x87, 0x9c, 0xf4, 0xe4, 0xeb, 0x1d, 0xc3, 0xbc, 0xe5, 0x11, 0x2d, 0xd6, 0x27,
-	0x49, 0x44, 0x15, 0x0c, 0x9d, 0xc1, 0x76, 0x46, 0x93, 0x6b, 0x09, 0xdc, 0x43, 0xd8, 0x36, 0xd9,
-	0x78, 0xc2, 0xfb, 0xc1, 0xe8, 0xc8, 0xc2, 0x87, 0xce, 0x1a, 0x0e, 0x7d, 0x16, 0x04, 0x52, 0x6c,
-	0x04, 0xd2, 0x07, 0xd0, 0xc9, 0xb2, 0x59, 0x3a, 0x18, 0xdf, 0x87, 0xad, 0xa7, 0xa3, 0x91, 0x3d,
-	0x71, 0xd8, 0x13, 0x36, 0xed, 0xa7, 0x34, 0x09, 0x5f, 0x79, 0xb1, 0x26, 0xfc, 0xbb, 0xa8, 0x75,
-	0xe2, 0x85, 0x2c, 0xf3, 0xfb, 0xa5, 0x55, 0xf8, 0x5e, 0x9c, 0x0e, 0xa7, 0xcc, 0x1a, 0x26, 0x2a,
-	0xe4, 0xd2, 0x41, 0x90, 0x45, 0x3a, 0xa0, 0xe0, 0xf4, 0xaf, 0x96, 0x16, 0xfc, 0x6b, 0x0d, 0xe0,
-	0x09, 0x76, 0xe5, 0x27, 0xce, 0xc8, 0x2d, 0x74, 0xbe, 0x01, 0xf5, 0x29, 0xda, 0x75, 0xd2, 0xc3,
-	0x5f, 0x56, 0xcd, 0x18, 0xe6, 0x95, 0xdd, 0xb2, 0x27, 0xf1, 0x83, 0x22, 0x00, 0xfe, 0x0b, 0x8f,
-	0x31, 0xff, 0xb9, 0x79, 0x2a, 0xaa, 0x5b, 0xc3, 0x8c, 0x61, 0x9e, 0x8e, 0x03, 0x7b, 0xc2, 0x9c,
-	0x10, 0xa9, 0xe2, 0x11, 0x51, 0x30, 0xb4, 0x0f, 0x20, 0x02, 0x39, 0x57, 0x1f, 0x02, 0x55, 0x1e,
-	0xfd, 0x28, 0x04, 0xfc, 0x9b, 0xeb, 0x11, 0x84, 0xd6, 0x38, 0xea, 0x01, 0x04, 0x80, 0xe5, 0x0a,
-	0xd3, 0x4d, 0xa6, 0xbd, 0x84, 0xe8, 0x29, 0xb4, 0x79, 0x4b, 0x24, 0x9c, 0x26, 0x62, 0x16, 0xb9,
-	0x46, 0x4b, 0xb2, 0xba, 0xa8, 0x4b, 0x8e, 0x64, 0x57, 0x12, 0xd9, 0xf4, 0x13, 0xc1, 0x4d, 0x78,
-	0x71, 0x2e, 0xb7, 0x7d, 0x58, 0x15, 0xd3, 0x8f, 0x78, 0x70, 0x9a, 0x47, 0x2d, 0x1e, 0xce, 0xc4,
-	0xf5, 0x66, 0x44, 0x8e, 0xf8, 0x09, 0x2f, 0x2c, 0xe2, 0x27, 0x2e, 0x71, 0x8a, 0x5f, 0xe2, 0x3a,
-	0x33, 0x22, 0xd3, 0x3f, 0x69, 0xb0, 0x2a, 0xd8, 0x04, 0xe4, 0x1e, 0xd4, 0x6c, 0xb4, 0x1a, 0x59,
-	0x35, 0x8f, 0xb6, 0x30, 0xa7, 0x32, 0xbe, 0x78, 0x5c, 0x32, 0xe5, 0x29, 0x7e, 0x5e, 0xa8, 0x85,
-	0x5e, 0x50, 0xce, 0xab, 0xd6, 0xf2, 0xf3, 0xe2, 0x14, 0x3f, 0x2f, 0xc4, 0xa2, 0x87, 0x94, 0xf3,
-	0xaa, 0x35, 0xfc, 0xbc, 0x38, 0xf5, 0xa0, 0x0e, 0x35, 0x91, 0x4b, 0xf4, 0x25, 0x6c, 0x20, 0xdf,
-	0xd4, 0x0d, 0xec, 0xa4, 0xd4, 0xad, 0xc7, 0x6a, 0x75, 0x52, 0x6a, 0xd5, 0x63, 0xf1, 0x9d, 0x94,
-	0xf8, 0x7a, 0x24, 0x86, 0xa7, 0x07, 0x0f, 0x5f, 0x94, 0x8d, 0x02, 0xa0, 0x0c, 0x88, 0x2a, 0x72,
-	0xe9, 0xb2, 0xf7, 0x1e, 0xac, 0x0a, 0xe5, 0x53, 0x5d, 0x9c, 0x74, 0xb5, 0x19, 0xd1, 0xe8, 0x1f,
-	0xca, 0x49, 0xad, 0x1f, 0x9c, 0xb3, 0xa9, 0x35, 0xbf, 0xd6, 0x23, 0x39, 0x19, 0xd2, 0x72, 0x9d,
-	0xee, 0xdc, 0x21, 0x2d, 0xd5, 0x7e, 0x55, 0xe7, 0xb5, 0x5f, 0x2b, 0x4a, 0xfb, 0x85, 0x97, 0x03,
-	0xe5, 0xc9, 0x76, 0x4d, 0x42, 0xfc, 0xf4, 0xc8, 0x9e, 0x05, 0xe7, 0xd8, 0xac, 0xd5, 0x4d, 0x01,
-	0x70, 0x6d, 0x78, 0xef, 0xab, 0xd7, 0x11, 0x89, 0xdf, 0xfc, 0x2a, 0x8f, 0x7c, 0x77, 0x2a, 0x9e,
-	0x0d, 0xbd, 0x21, 0x86, 0xe9, 0x04, 0x13, 0xd1, 0x9f, 0x59, 0xfe, 0x98, 0x85, 0x3a, 0x24, 0x74,
-	0x81, 0x51, 0x5f, 0x1e, 0xe9, 0x97, 0x6b, 0x79, 0x79, 0x0e, 0x60, 0xeb, 0x11, 0x0b, 0xcf, 0x66,
-	0x7d, 0xfe, 0x76, 0x1f, 0x8f, 0xc6, 0x0b, 0x1e, 0x1e, 0xfa, 0x1c, 0xb6, 0x33, 0x67, 0x97, 0x56,
-	0x91, 0x40, 0x75, 0x30, 0x1a, 0x47, 0x01, 0xc3, 0x6f, 0xda, 0x83, 0xf5, 0x47, 0x2c, 0x54, 0x64,
-	0xdf, 0x51, 0x9e, 0x1a, 0xd9, 0x57, 0x1e, 0x8f, 0xc6, 0xcf, 0x5e, 0x79, 0x6c, 0xc1, 0xbb, 0x73,
-	0x0a, 0xad, 0x88, 0xcb, 0xd2, 0x5a, 0xb5, 0xa1, 0x32, 0x18, 0xc5, 0x1d, 0xe9, 0x60, 0x34, 0xa6,
-	0xdb, 0xb0, 0xf9, 0x88, 0xc9, 0x7b, 0x9d, 0x68, 0x46, 0xf7, 0xd1, 0x5b, 0x0a, 0x5a, 0x8a, 0x92,
-	0x0c, 0xb4, 0x84, 0xc1, 0xef, 0x34, 0x20, 0x8f, 0x2d, 0x67, 0x68, 0xb3, 0x87, 0xbe, 0xef, 0xfa,
-	0x73, 0xdb, 0x70, 0xa4, 0x7e, 0xa7, 0x24, 0xdf, 0x85, 0x46, 0x7f, 0xe2, 0xd8, 0xee, 0xf8, 0x53,
-	0x37, 0x88, 0x5a, 0xb2, 0x18, 0x81, 0x29, 0xfa, 0xd2, 0x8e, 0x87, 0x3b, 0xfe, 0x4d, 0x03, 0xd8,
-	0x4c, 0xa9, 0x74, 0x2d, 0x09, 0xf6, 0x08, 0xb6, 0x9f, 0xf9, 0x96, 0x13, 0x8c, 0x98, 0x9f, 0x6e,
-	0xee, 0x92, 0xf7, 0x48, 0x53, 0xdf, 0x23, 0xa5, 0x6c, 0x09, 0xc9, 0x12, 0xe2, 0xcd, 0x4d, 0x96,
-	0xd1, 0xd2, 0x0f, 0xfc, 0x30, 0x5e, 0xde, 0xa4, 0xe6, 0x85, 0xdb, 0x4a, 0x54, 0xd6, 0x95, 0x31,
-	0xe6, 0xc5, 0x51, 0xd4, 0x68, 0x4a, 0x4d, 0xcb, 0x73, 0x34, 0x15, 0xa1, 0x89, 0x34, 0x0d, 0xe3,
-	0x12, 0x77, 0x9d, 0xcd, 0xff, 0x9f, 0x35, 0xe8, 0xe0, 0x3e, 0xee, 0x85, 0x65, 0x4f, 0x86, 0xb8,
-	0x2a, 0x4c, 0x2e, 0x14, 0x4c, 0xdd, 0x21, 0xfb, 0xfc, 0xd2, 0xb2, 0x67, 0xd2, 0xdd, 0x8f, 0x4b,
-	0x66, 0x83, 0xe3, 0x5e, 0x70, 0x14, 0x39, 0x80, 0x36, 0x76, 0xf3, 0x9f, 0xf3, 0xa1, 0x47, 0x1e,
-	0x43, 0x75, 0x1e, 0x6b, 0x66, 0x2b, 0xee, 0xf3, 0xc5, 0xd9, 0x85, 0x65, 0x97, 0xe7, 0xac, 0xd2,
-	0x5a, 0xc7, 0xf0, 0x83, 0x9a, 0x58, 0x4b, 0x3c, 0x68, 0x2a, 0x83, 0x04, 0xbd, 0x82, 0x9d, 0x9c,
-	0xc6, 0xd7, 0xe2, 0xab, 0x27, 0xb0, 0x7d, 0x16, 0xba, 0x5e, 0xde, 0x53, 0x0b, 0x27, 0xc7, 0xd8,
-	0xb8, 0x72, 0xda, 0x38, 0x7a, 0xc9, 0x3d, 0x9f, 0x66, 0x77, 0x1d, 0x66, 0x1c, 0xfc, 0x00, 0x6e,
-	0x64, 0xf6, 0x12, 0x64, 0x03, 0xd6, 0x4f, 0x9c, 0x4b, 0xae, 0x88, 0x40, 0xb4, 0x4b, 0x64, 0x0d,
-	0xea, 0x67, 0x17, 0x13, 0x8f, 0xc3, 0x6d, 0x8d, 0x43, 0x0f, 0xbf, 0x60, 0x03, 0x84, 0xca, 0x07,
-	0x7d, 0xa8, 0x47, 0x33, 0x15, 0xd9, 0x84, 0x1b, 0xf2, 0xa7, 0x11, 0xaa, 0x5d, 0x22, 0x37, 0xa0,
-	0x89, 0x21, 0x12, 0xa8, 0xb6, 0x46, 0xda, 0xb0, 0x26, 0x56, 0x85, 0x12, 0x53, 0x26, 0x2d, 0x00,
-	0x6e, 0xbd, 0x84, 0x2b, 0x08, 0x9f, 0xbb, 0x57, 0x12, 0xae, 0x1e, 0xfc, 0x10, 0xea, 0x51, 0xa3,
-	0xae, 0xc8, 0x88, 0x50, 0xed, 0x12, 0xd7, 0xf9, 0xe1, 0xe5, 0x64, 0x10, 0xc6, 0x28, 0x8d, 0xec,
-	0xc0, 0xe6, 0xb1, 0xe5, 0x0c, 0x98, 0x9d, 0x26, 0x94, 0x0f, 0x1c, 0x58, 0x95, 0x6f, 0x01, 0x57,
-	0x4d, 0xf2, 0xe2, 0xa0, 0x30, 0x94, 0xbf, 0x4c, 0x08, 0x69, 0x5c, 0x0d, 0x51, 0xa8, 0x11, 0x46,
-	0x35, 0x85, 0x1f, 0x11, 0x16, 0x6a, 0xa2, 0x8a, 0x08, 0x57, 0xc9, 0x16, 0xb4, 0xf1, 0xd7, 0x6c,
-	0xea, 0xd9, 0x56, 0x28, 0xb0, 0x2b, 0x07, 0x3d, 0x68, 0xc4, 0xc5, 0x80, 0x1f, 0x91, 0x12, 0x63,
-	0x5c, 0xbb, 0xc4, 0x3d, 0x82, 0x2e, 0x42, 0xdc, 0x8b, 0xa3, 0xb6, 0x26, 0x9c, 0xe6, 0x7a, 0x11,
-	0xa2, 0x7c, 0xf4, 0xaf, 0x0d, 0xa8, 0x09, 0x65, 0xc8, 0x67, 0xd0, 0x88, 0xb7, 0xe6, 0x04, 0x3b,
-	0xc2, 0xec, 0x16, 0xdf, 0xd8, 0xce, 0x60, 0x45, 0xd8, 0xe9, 0x9d, 0x5f, 0xfe, 0xf5, 0x9f, 0x5f,
-	0x95, 0x6f, 0xd2, 0xad, 0x43, 0xcb, 0x9b, 0x04, 0x87, 0x97, 0xf7, 0x2d, 0xdb, 0x3b, 0xb7, 0xee,
-	0x1f, 0xf2, 0x34, 0x0c, 0x3e, 0xd0, 0x0e, 0xc8, 0x08, 0x9a, 0xca, 0x6a, 0x9a, 0x74, 0x38, 0x9b,
-	0xfc, 0x32, 0xdc, 0xd8, 0xc9, 0xe1, 0xa5, 0x80, 0xf7, 0x51, 0xc0, 0x9e, 0x71, 0xab, 0x48, 0xc0,
-	0xe1, 0x6b, 0xfe, 0xcc, 0x7e, 0xc9, 0xe5, 0x7c, 0x08, 0x90, 0x6c, 0x8b, 0x09, 0x6a, 0x9b, 0xdb,
-	0x40, 0x1b, 0x9d, 0x2c, 0x5a, 0x0a, 0x29, 0x11, 0x1b, 0x9a, 0xca, 0xda, 0x94, 0x18, 0x99, 0x3d,
-	0xaa, 0xb2, 0xe7, 0x35, 0x6e, 0x15, 0xd2, 0x24, 0xa7, 0x77, 0x51, 0xdd, 0x2e, 0xd9, 0xcd, 0xa8,
-	0x1b, 0xe0, 0x51, 0xa9, 0x2f, 0x39, 0x86, 0x35, 0x75, 0x3b, 0x49, 0xd0, 0xfa, 0x82, 0xb5, 0xac,
-	0xa1, 0xe7, 0x09, 0xb1, 0xca, 0x1f, 0xc3, 0x7a, 0xea, 0xa2, 0x11, 0x3d, 0xb7, 0x13, 0x8c, 0xd8,
-	0xdc, 0x2c, 0xa0, 0xc4, 0x7c, 0x3e, 0x83, 0x4e, 0x7e, 0x9b, 0x86, 0x5e, 0xbc, 0xad, 0x04, 0x25,
-	0xbf, 0xd1, 0x32, 0xba, 0xf3, 0xc8, 0x31, 0xeb, 0xa7, 0xd0, 0xce, 0x6e, 0x9d, 0x08, 0xba, 0x6f,
-	0xce, 0x92, 0xcc, 0xd8, 0x2d, 0x26, 0xc6, 0x0c, 0x3f, 0x80, 0x46, 0xbc, 0xd4, 0x11, 0x89, 0x9a,
-	0xdd, 0x2d, 0x89, 0x44, 0xcd, 0x6d, 0x7e, 0x68, 0x89, 0x8c, 0x61, 0x3d, 0xb5, 0x46, 0x11, 0xfe,
-	0x2a, 0xda, 0xf1, 0x08, 0x7f, 0x15, 0xee, 0x5c, 0xe8, 0x5d, 0x0c, 0xf0, 0x2d, 0xa3, 0x93, 0x0d,
-	0xb0, 0x28, 0x7f, 0x3c, 0x15, 0x4f, 0xa0, 0x95, 0xde, 0x78, 0x90, 0x9b, 0xe2, 0xfd, 0x2e, 0x58,
-	0xa6, 0x18, 0x46, 0x11, 0x29, 0xd6, 0xd9, 0x87, 0xf5, 0xd4, 0xe2, 0x42, 0xea, 0x5c, 0xb0, 0x0b,
-	0x91, 0x3a, 0x17, 0x6d, 0x39, 0xe8, 0xff, 0xa1, 0xce, 0xef, 0x1f, 0xbc, 0x9b, 0xd1, 0x59, 0xce,
-	0x3f, 0x87, 0xaf, 0x79, 0x03, 0xfb, 0x65, 0x94, 0x9c, 0x17, 0xb1, 0x9f, 0x44, 0x89, 0x4b, 0xf9,
-	0x29, 0xb5, 0xfc, 0x48, 0xf9, 0x29, 0xbd, 0xe0, 0xa0, 0xef, 0xa1, 0xcc, 0x3b, 0x86, 0x91, 0x91,
-	0x29, 0xe6, 0xc3, 0xc3, 0xd7, 0xae, 0x87, 0xd7, 0xf6, 0xa7, 0x00, 0xc9, 0x84, 0x27, 0xae, 0x6d,
-	0x6e, 0xc8, 0x14, 0xd7, 0x36, 0x3f, 0x08, 0xd2, 0x2e, 0xca, 0xd0, 0x49, 0xa7, 0xd8, 0x2e, 0x32,
-	0x4a, 0x22, 0x2e, 0x26, 0xa7, 0x54, 0xc4, 0xd5, 0x49, 0x2f, 0x1d, 0xf1, 0xd4, 0xac, 0x43, 0xf7,
-	0x50, 0x8a, 0x61, 0x6c, 0x67, 0x23, 0x8e, 0xc7, 0xb8, 0x11, 0x36, 0x0e, 0x0b, 0xc9, 0x0c, 0x22,
-	0xe4, 0x14, 0x8d, 0x30, 0x42, 0x4e, 0xe1, 0xc0, 0x12, 0x55, 0x3a, 0xd2, 0xcd, 0xca, 0x99, 0xf5,
-	0xd5, 0x62, 0x47, 0x9e, 0x41, 0x4d, 0x0c, 0x15, 0x64, 0x43, 0x32, 0x53, 0xf8, 0x13, 0x15, 0x25,
-	0x19, 0xbf, 0x83, 0x8c, 0x6f, 0x93, 0x45, 0x25, 0x94, 0xfc, 0x1c, 0x9a, 0x4a, 0x1f, 0x2e, 0xea,
-	0x74, 0x7e, 0x56, 0x10, 0x75, 0xba, 0xa0, 0x61, 0x9f, 0xeb, 0x25, 0xc6, 0x4f, 0xe1, 0xb5, 0x38,
-	0x86, 0x35, 0x75, 0x4e, 0x11, 0x45, 0xaf, 0x60, 0xa0, 0x31, 0xf4, 0x3c, 0x21, 0xbe, 0x10, 0x27,
-	0xd0, 0x4a, 0x37, 0xdc, 0xe2, 0x6e, 0x15, 0x76, 0xf3, 0xe2, 0x6e, 0x15, 0xf7, 0xe7, 0xb4, 0xc4,
-	0xf5, 0x51, 0x3b, 0x62, 0xa2, 0x3e, 0x41, 0xa9, 0xa2, 0xa4, 0xe7, 0x09, 0x31, 0x93, 0x53, 0xb8,
-	0x91, 0xe9, 0x16, 0xc5, 0xdb, 0x51, 0xdc, 0xf4, 0x8a, 0xb7, 0x63, 0x4e, 0x7b, 0x29, 0xac, 0x4b,
-	0xf7, 0x6c, 0xc2, 0xba, 0xc2, 0xb6, 0xd0, 0x30, 0x8a, 0x48, 0x31, 0xab, 0x9f, 0xe0, 0xb0, 0x98,
-	0x90, 0xe4, 0xc3, 0xd6, 0x95, 0xbe, 0xcd, 0x12, 0x22, 0xa6, 0x77, 0xe6, 0xd2, 0x63, 0xce, 0xcf,
-	0x81, 0xa4, 0x0e, 0x88, 0x84, 0xb9, 0x9d, 0xfb, 0x61, 0x2a, 0x6f, 0xba, 0xf3, 0xc8, 0x31, 0x5b,
-	0x2b, 0x7e, 0x86, 0xb2, 0xac, 0xef, 0x2a, 0xfe, 0x9f, 0xc3, 0x9e, 0x2e, 0x3a, 0x12, 0x89, 0x78,
-	0xa0, 0xff, 0xe5, 0x4d, 0x57, 0xfb, 0xe6, 0x4d, 0x57, 0xfb, 0xc7, 0x9b, 0xae, 0xf6, 0xdb, 0xb7,
-	0xdd, 0xd2, 0x37, 0x6f, 0xbb, 0xa5, 0xbf, 0xbd, 0xed, 0x96, 0xfa, 0x35, 0xfc, 0xfb, 0xc2, 0xff,
-	0xff, 0x3b, 0x00, 0x00, 0xff, 0xff, 0x85, 0xf5, 0x22, 0xfb, 0x02, 0x21, 0x00, 0x00,
+	// 2372 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x1a, 0x4d, 0x6f, 0xdb, 0xc8,
+	0xd5, 0x94, 0x64, 0x5b, 0x7e, 0xfe, 0x88, 0x3c, 0x96, 0x64, 0x9a, 0x71, 0x14, 0x87, 0xfb, 0x01,
+	0xc3, 0x28, 0x62, 0xc4, 0xed, 0x69, 0x81, 0x2d, 0xba, 0x91, 0xb2, 0x89, 0x51, 0x67, 0xb3, 0xa5,
+	0x93, 0xb4, 0x8b, 0x02, 0xdd, 0x52, 0xd2, 0x48, 0x16, 0x4c, 0x91, 0x0c, 0x49, 0xc9, 0x1b, 0x04,
+	0xdb, 0x43, 0x4f, 0x3d, 0xb5, 0x05, 0xb6, 0xe8, 0x1e, 0x7b, 0xe8, 0x1f, 0xe8, 0xcf, 0x68, 0x6f,
+	0x0b, 0xf4, 0xd2, 0x4b, 0x81, 0x22, 0xe9, 0xbd, 0x7f, 0xa1, 0x98, 0x37, 0xc3, 0xe1, 0xf0, 0x43,
+	0x4a, 0xb5, 0x40, 0x8d, 0xbd, 0xf1, 0xbd, 0x37, 0x7a, 0xdf, 0xf3, 0xe6, 0xbd, 0x67, 0xc3, 0x56,
+	0x7f, 0x3c, 0xb6, 0xc3, 0x88, 0x06, 0x77, 0xfd, 0xc0, 0x8b, 0x3c, 0x52, 0xf2, 0xbb, 0xc6, 0x56,
+	0x7f, 0x7c, 0xe5, 0x05, 0x97, 0x31, 0xce, 0xd8, 0x1f, 0x7a, 0xde, 0xd0, 0xa1, 0xc7, 0xb6, 0x3f,
+	0x3a, 0xb6, 0x5d, 0xd7, 0x8b, 0xec, 0x68, 0xe4, 0xb9, 0x21, 0xa7, 0x9a, 0xbf, 0x82, 0xda, 0x79,
+	0x64, 0x07, 0xd1, 0x53, 0x3b, 0xbc, 0xb4, 0xe8, 0x8b, 0x09, 0x0d, 0x23, 0x42, 0xa0, 0x12, 0xd9,
+	0xe1, 0xa5, 0xae, 0x1d, 0x68, 0x87, 0x6b, 0x16, 0x7e, 0x13, 0x1d, 0x56, 0x43, 0x6f, 0x12, 0xf4,
+	0x68, 0xa8, 0x97, 0x0e, 0xca, 0x87, 0x6b, 0x56, 0x0c, 0x92, 0x16, 0x40, 0x40, 0xc7, 0xde, 0x94,
+	0x3e, 0xa6, 0x91, 0xad, 0x97, 0x0f, 0xb4, 0xc3, 0xaa, 0xa5, 0x60, 0xc8, 0x3e, 0xac, 0x85, 0x28,
+	0x61, 0x34, 0xa6, 0x7a, 0x05, 0x59, 0x26, 0x08, 0xf3, 0x2b, 0x0d, 0xb6, 0x15, 0x05, 0x42, 0xdf,
+	0x73, 0x43, 0x4a, 0x9a, 0xb0, 0x12, 0xd0, 0x70, 0xe2, 0x44, 0xa8, 0x43, 0xd5, 0x12, 0x10, 0xa9,
+	0x41, 0x79, 0x1c, 0x0e, 0xf5, 0x12, 0x72, 0x61, 0x9f, 0xe4, 0x24, 0xd1, 0xab, 0x7c, 0x50, 0x3e,
+	0x5c, 0x3f, 0xd1, 0xef, 0xfa, 0xdd, 0xbb, 0x6d, 0x6f, 0x3c, 0xf6, 0xdc, 0x9f, 0xa2, 0x1b, 0x62,
+	0xa6, 0x89, 0xc6, 0x07, 0xb0, 0xde, 0xbb, 0xa0, 0xbd, 0x4b, 0x8b, 0x8b, 0xe0, 0x3a, 0xa9, 0x28,
+	0xf3, 0x17, 0x40, 0x9e, 0xf8, 0x34, 0xb0, 0x23, 0xaa, 0xfa, 0xc5, 0x80, 0x92, 0xe7, 0xa3, 0x46,
+	0x5b, 0x27, 0xc0, 0xc4, 0x30, 0xe2, 0x13, 0xdf, 0x2a, 0x79, 0x3e, 0xf3, 0x99, 0x6b, 0x8f, 0xa9,
+	0x50, 0x0d, 0xbf, 0x89, 0x9e, 0xd6, 0x2d, 0xf1, 0x99, 0xf9, 0x3b, 0x0d, 0x76, 0x52, 0x02, 0x84,
+	0xdd, 0xf3, 0x24, 0x24, 0x3e, 0x29, 0x15, 0xf9, 0xa4, 0x5c, 0xe8, 0x93, 0xca, 0xff, 0xe8, 0x13,
+	0xf3, 0x23, 0xd8, 0x7e, 0xe6, 0xf7, 0x33, 0x06, 0x2f, 0x94, 0x08, 0xe6, 0x1f, 0x34, 0x20, 0x2a,
+	0x8f, 0xef, 0x48, 0x2c, 0x3f, 0x86, 0xe6, 0x4f, 0x26, 0x34, 0x78, 0x79, 0x1e, 0xd9, 0xd1, 0x24,
+	0x3c, 0x1b, 0x85, 0x91, 0x62, 0x1e, 0xc6, 0x4c, 0x2b, 0x8e, 0x59, 0xc6, 0xbc, 0x29, 0xec, 0xe6,
+	0xf8, 0x2c, 0x6c, 0xe2, 0xbd, 0xac, 0x89, 0xbb, 0xcc, 0x44, 0x85, 0x6f, 0x3e, 0x32, 0x6d, 0xd8,
+	0x39, 0xbf, 0xf0, 0xae, 0x3a, 0x9d, 0xb3, 0x33, 0xaf, 0x77, 0x19, 0x7e, 0xbb, 0xd8, 0xfc, 0x49,
+	0x83, 0x55, 0xc1, 0x81, 0x6c, 0x41, 0xe9, 0xb4, 0x23, 0x7e, 0x57, 0x3a, 0xed, 0x48, 0x4e, 0x25,
+	0x85, 0x13, 0x81, 0xca, 0xd8, 0xeb, 0x53, 0x91, 0x55, 0xf8, 0x4d, 0xea, 0xb0, 0xec, 0x5d, 0xb9,
+	0x34, 0x10, 0x4e, 0xe6, 0x00, 0x3b, 0xd9, 0xe9, 0x9c, 0x85, 0xfa, 0x32, 0x0a, 0xc4, 0x6f, 0xe6,
+	0x8f, 0xf0, 0xa5, 0xdb, 0xa3, 0x7d, 0x7d, 0x05, 0xb1, 0x02, 0x22, 0x06, 0x54, 0x27, 0xae, 0xa0,
+	0xac, 0x22, 0x45, 0xc2, 0x66, 0x0f, 0xea, 0x69, 0x33, 0x17, 0xf6, 0xed, 0x1d, 0x58, 0x76, 0xd8,
+	0x4f, 0x85, 0x67, 0xd7, 0x99, 0x67, 0x05, 0x3b, 0x8b, 0x53, 0xcc, 0x7f, 0x6a, 0x50, 0x7f, 0xe6,
+	0xb2, 0xef, 0x98, 0x20, 0xbc, 0x99, 0xf5, 0x89, 0x09, 0x1b, 0x01, 0xf5, 0x1d, 0xbb, 0x47, 0x9f,
+	0xa0, 0xc9, 0x5c, 0x4c, 0x0a, 0xc7, 0x52, 0x6f, 0xe0, 0x05, 0x3d, 0x6a, 0x61, 0xad, 0x13, 0x95,
+	0x4f, 0x45, 0x91, 0x77, 0xf0, 0x3a, 0x57, 0xf0, 0x3a, 0xef, 0x30, 0x75, 0x52, 0xb2, 0xc5, 0xbd,
+	0x56, 0x82, 0xb6, 0x9c, 0xae, 0xac, 0x06, 0x54, 0xfb, 0x76, 0x64, 0x77, 0xed, 0x90, 0xea, 0x2b,
+	0xa8, 0x80, 0x84, 0x59, 0x30, 0x22, 0xbb, 0xeb, 0x50, 0x7d, 0x95, 0x07, 0x03, 0x01, 0xf3, 0x23,
+	0x68, 0x64, 0xcc, 0x5b, 0xd4, 0x8b, 0xa6, 0x05, 0x7b, 0xa2, 0x32, 0xc5, 0x57, 0xce, 0xb1, 0x5f,
+	0xc6, 0x6e, 0xba, 0xa9, 0xd4, 0x27, 0xf4, 0x2f, 0x52, 0xf3, 0x86, 0x64, 0xb2, 0xef, 0x6b, 0x0d,
+	0x8c, 0x22, 0xa6, 0x42, 0xb9, 0xb9, 0x5c, 0xff, 0xbf, 0x65, 0xef, 0x6b, 0x0d, 0x76, 0x3f, 0x9d,
+	0x04, 0xc3, 0x22, 0x63, 0x15, 0x7b, 0xb4, 0x5c, 0x60, 0x46, 0xae, 0xdd, 0x8b, 0x46, 0x53, 0x2a,
+	0xb4, 0x92, 0x30, 0xde, 0x26, 0xf6, 0xd2, 0x31, 0xc5, 0xca, 0x16, 0x7e, 0xb3, 0xf3, 0x83, 0x91,
+	0x43, 0xb1, 0xd8, 0xf0, 0xcb, 0x23, 0x61, 0xbc, 0x2b, 0x93, 0x6e, 0x67, 0x14, 0xe8, 0xcb, 0x48,
+	0x11, 0x90, 0xf9, 0x05, 0xe8, 0x79, 0xc5, 0xae, 0xa3, 0xa4, 0x9a, 0x53, 0xa8, 0xb5, 0x59, 0xfd,
+	0x7c, 0xdb, 0x4b, 0xd0, 0x84, 0x15, 0x1a, 0x04, 0x6d, 0x97, 0x47, 0xa6, 0x6c, 0x09, 0x88, 0xf9,
+	0xed, 0xca, 0x0e, 0x5c, 0x46, 0xe0, 0x4e, 0x88, 0xc1, 0xb7, 0xb4, 0x02, 0x1f, 0xc2, 0xb6, 0x22,
+	0x77, 0xe1, 0xc4, 0xfd, 0x8d, 0x06, 0x75, 0x91, 0x64, 0xe7, 0x68, 0x49, 0xac, 0xfb, 0xbe, 0x92,
+	0x5e, 0x1b, 0xcc, 0x7c, 0x4e, 0x4e, 0xf2, 0xab, 0xe7, 0xb9, 0x83, 0xd1, 0x50, 0x24, 0xad, 0x80,
+	0x58, 0xcc, 0xb8, 0x43, 0x4e, 0x3b, 0xe2, 0xf5, 0x96, 0x30, 0x6b, 0x79, 0x78, 0x8b, 0xf5, 0x49,
+	0x12, 0x51, 0x05, 0x63, 0x4e, 0xa0, 0x91, 0xd1, 0xe4, 0x5a, 0x02, 0xf7, 0x00, 0x1a, 0x16, 0x1d,
+	0x8e, 0xc2, 0x88, 0x06, 0xf1, 0x91, 0xb9, 0x0f, 0x9d, 0xdd, 0xef, 0x07, 0x34, 0x0c, 0x85, 0xd8,
+	0x18, 0x34, 0xef, 0x43, 0x33, 0xcb, 0x66, 0xe1, 0x60, 0xfc, 0x10, 0xea, 0x4f, 0x06, 0x03, 0x67,
+	0xe4, 0xd2, 0xc7, 0x74, 0xdc, 0x4d, 0x69, 0x12, 0xbd, 0xf4, 0xa5, 0x26, 0xec, 0xbb, 0xa8, 0x75,
+	0x62, 0x85, 0x2c, 0xf3, 0xfb, 0x85, 0x55, 0xf8, 0x81, 0x4c, 0x87, 0x33, 0x6a, 0xf7, 0x69, 0x30,
+	0x33, 0x1d, 0x38, 0x99, 0xa7, 0x03, 0x0a, 0x4e, 0xff, 0x6a, 0x61, 0xc1, 0xbf, 0xd5, 0x00, 0x1e,
+	0x63, 0x57, 0x7e, 0xea, 0x0e, 0xbc, 0x42, 0xe7, 0x1b, 0x50, 0x1d, 0xa3, 0x5d, 0xa7, 0x1d, 0xfc,
+	0x65, 0xc5, 0x92, 0x30, 0xab, 0xec, 0xb6, 0x33, 0x92, 0x0f, 0x0a, 0x07, 0xd8, 0x2f, 0x7c, 0x4a,
+	0x83, 0x67, 0xd6, 0x19, 0xaf, 0x6e, 0x6b, 0x96, 0x84, 0x59, 0x3a, 0xf6, 0x9c, 0x11, 0x75, 0x23,
+	0xa4, 0xf2, 0x47, 0x44, 0xc1, 0x98, 0x5d, 0x00, 0x1e, 0xc8, 0x99, 0xfa, 0x10, 0xa8, 0xb0, 0xe8,
+	0xc7, 0x21, 0x60, 0xdf, 0x4c, 0x8f, 0x30, 0xb2, 0x87, 0x71, 0x0f, 0xc0, 0x01, 0x2c, 0x57, 0x98,
+	0x6e, 0x22, 0xed, 0x05, 0x64, 0x9e, 0x41, 0x8d, 0xb5, 0x44, 0xdc, 0x69, 0x3c, 0x66, 0xb1, 0x6b,
+	0xb4, 0x24, 0xab, 0x8b, 0xba, 0xe4, 0x58, 0x76, 0x39, 0x91, 0x6d, 0x7e, 0xc2, 0xb9, 0x71, 0x2f,
+	0xce, 0xe4, 0x76, 0x08, 0xab, 0x7c, 0xfa, 0xe1, 0x0f, 0xce, 0xfa, 0xc9, 0x16, 0x0b, 0x67, 0xe2,
+	0x7a, 0x2b, 0x26, 0xc7, 0xfc, 0xb8, 0x17, 0xe6, 0xf1, 0xe3, 0x97, 0x38, 0xc5, 0x2f, 0x71, 0x9d,
+	0x15, 0x93, 0xcd, 0x3f, 0x6b, 0xb0, 0xca, 0xd9, 0x84, 0xe4, 0x2e, 0xac, 0x38, 0x68, 0x35, 0xb2,
+	0x5a, 0x3f, 0xa9, 0x63, 0x4e, 0x65, 0x7c, 0xf1, 0x68, 0xc9, 0x12, 0xa7, 0xd8, 0x79, 0xae, 0x96,
+	0x5e, 0x4a, 0x9f, 0x57, 0xad, 0x65, 0xe7, 0xf9, 0x29, 0x76, 0x9e, 0x8b, 0xd5, 0xcb, 0xe9, 0xf3,
+	0xaa, 0x35, 0xec, 0x3c, 0x3f, 0x75, 0xbf, 0x0a, 0x2b, 0x3c, 0x97, 0xcc, 0x17, 0xb0, 0x8d, 0x7c,
+	0x53, 0x37, 0xb0, 0x99, 0x52, 0xb7, 0x2a, 0xd5, 0x6a, 0xa6, 0xd4, 0xaa, 0x4a, 0xf1, 0xcd, 0x94,
+	0xf8, 0x6a, 0x2c, 0x86, 0xa5, 0x07, 0x0b, 0x5f, 0x9c, 0x8d, 0x1c, 0x30, 0x29, 0x10, 0x55, 0xe4,
+	0xc2, 0x65, 0xef, 0x3d, 0x58, 0xe5, 0xca, 0xa7, 0xba, 0x38, 0xe1, 0x6a, 0x2b, 0xa6, 0x99, 0x7f,
+	0x2c, 0x25, 0xb5, 0xbe, 0x77, 0x41, 0xc7, 0xf6, 0xec, 0x5a, 0x8f, 0xe4, 0x64, 0x48, 0xcb, 0x75,
+	0xba, 0x33, 0x87, 0xb4, 0x54, 0xfb, 0x55, 0x99, 0xd5, 0x7e, 0x2d, 0x2b, 0xed, 0x17, 0x5e, 0x0e,
+	0x94, 0x27, 0xda, 0x35, 0x01, 0xb1, 0xd3, 0x03, 0x67, 0x12, 0x5e, 0x60, 0xb3, 0x56, 0xb5, 0x38,
+	0xc0, 0xb4, 0x61, 0xbd, 0xaf, 0x5e, 0x45, 0x24, 0x7e, 0xb3, 0xab, 0x3c, 0x08, 0xbc, 0x31, 0x7f,
+	0x36, 0xf4, 0x35, 0xa4, 0x28, 0x98, 0x98, 0xfe, 0xd4, 0x0e, 0x86, 0x34, 0xd2, 0x21, 0xa1, 0x73,
+	0x8c, 0xfa, 0xf2, 0x08, 0xbf, 0x5c, 0xcb, 0xcb, 0x73, 0x04, 0xf5, 0x87, 0x34, 0x3a, 0x9f, 0x74,
+	0xd9, 0xdb, 0xdd, 0x1e, 0x0c, 0xe7, 0x3c, 0x3c, 0xe6, 0x33, 0x68, 0x64, 0xce, 0x2e, 0xac, 0x22,
+	0x81, 0x4a, 0x6f, 0x30, 0x8c, 0x03, 0x86, 0xdf, 0x66, 0x07, 0x36, 0x1f, 0xd2, 0x48, 0x91, 0x7d,
+	0x5b, 0x79, 0x6a, 0x44, 0x5f, 0xd9, 0x1e, 0x0c, 0x9f, 0xbe, 0xf4, 0xe9, 0x9c, 0x77, 0xe7, 0x0c,
+	0xb6, 0x62, 0x2e, 0x0b, 0x6b, 0x55, 0x83, 0x72, 0x6f, 0x20, 0x3b, 0xd2, 0xde, 0x60, 0x68, 0x36,
+	0x60, 0xe7, 0x21, 0x15, 0xf7, 0x3a, 0xd1, 0xcc, 0x3c, 0x84, 0x7a, 0x1a, 0x2d, 0x44, 0x09, 0x06,
+	0x5a, 0xc2, 0xe0, 0x6f, 0x1a, 0x90, 0x47, 0xb6, 0xdb, 0x77, 0xe8, 0x83, 0x20, 0xf0, 0x82, 0x99,
+	0x6d, 0x38, 0x52, 0xbf, 0x55, 0x92, 0xef, 0xc3, 0x5a, 0x77, 0xe4, 0x3a, 0xde, 0xf0, 0x53, 0x2f,
+	0x8c, 0x5b, 0x32, 0x89, 0xc0, 0x14, 0x7d, 0xe1, 0xc8, 0xe1, 0x8e, 0x7d, 0x33, 0x87, 0x60, 0xb6,
+	0x87, 0xf1, 0x70, 0xc7, 0x21, 0x96, 0x9a, 0x74, 0x4a, 0xdd, 0x88, 0x79, 0x38, 0x14, 0xe3, 0x9d,
+	0x82, 0x31, 0x43, 0xd8, 0x49, 0x99, 0x72, 0x2d, 0x89, 0xf9, 0x10, 0x1a, 0x4f, 0x03, 0xdb, 0x0d,
+	0x07, 0x34, 0x48, 0x37, 0x85, 0xc9, 0x3b, 0xa6, 0xa9, 0xef, 0x98, 0x52, 0xee, 0xb8, 0x64, 0x01,
+	0xb1, 0xa6, 0x28, 0xcb, 0x68, 0xe1, 0xc6, 0xa0, 0x2f, 0x97, 0x3e, 0xa9, 0x39, 0xe3, 0x96, 0x12,
+	0xcd, 0x4d, 0x65, 0xfc, 0x79, 0x7e, 0x12, 0x37, 0xa8, 0x42, 0xd3, 0xd2, 0x0c, 0x4d, 0x79, 0x48,
+	0x63, 0x4d, 0x23, 0x59, 0x1a, 0xaf, 0x73, 0x68, 0xf8, 0x8b, 0x06, 0x4d, 0xdc, 0xe3, 0x3d, 0xb7,
+	0x9d, 0x51, 0x1f, 0x57, 0x8c, 0xc9, 0x45, 0x04, 0xb6, 0x3f, 0xf8, 0x7c, 0x6a, 0x3b, 0x13, 0xe1,
+	0xee, 0x47, 0x4b, 0xd6, 0x1a, 0xc3, 0x3d, 0x67, 0x28, 0x72, 0x04, 0x35, 0x9c, 0x02, 0x3e, 0x67,
+	0xc3, 0x92, 0x38, 0x86, 0xea, 0x3c, 0xd2, 0xac, 0x2d, 0x39, 0x1f, 0xf0, 0xb3, 0x73, 0xcb, 0x35,
+	0xcb, 0x75, 0xa5, 0x25, 0x97, 0xf0, 0xfd, 0x15, 0xbe, 0xce, 0xb8, 0xbf, 0xae, 0x0c, 0x20, 0xe6,
+	0x15, 0xec, 0xe6, 0x34, 0xbe, 0x16, 0x5f, 0x3d, 0x86, 0xc6, 0x79, 0xe4, 0xf9, 0x79, 0x4f, 0xcd,
+	0x9d, 0x38, 0xa5, 0x71, 0xa5, 0xb4, 0x71, 0xe6, 0x14, 0x9a, 0x59, 0x76, 0xd7, 0x61, 0xc6, 0xd1,
+	0x8f, 0xe0, 0x46, 0x66, 0x9f, 0x41, 0xb6, 0x61, 0xf3, 0xd4, 0x9d, 0x32, 0x45, 0x38, 0xa2, 0xb6,
+	0x44, 0x36, 0xa0, 0x7a, 0x7e, 0x39, 0xf2, 0x19, 0x5c, 0xd3, 0x18, 0xf4, 0xe0, 0x0b, 0xda, 0x43,
+	0xa8, 0x74, 0xd4, 0x85, 0x6a, 0x3c, 0x8b, 0x91, 0x1d, 0xb8, 0x21, 0x7e, 0x1a, 0xa3, 0x6a, 0x4b,
+	0xe4, 0x06, 0xac, 0x63, 0x88, 0x38, 0xaa, 0xa6, 0x91, 0x1a, 0x6c, 0xf0, 0x15, 0xa3, 0xc0, 0x94,
+	0xc8, 0x16, 0x00, 0xb3, 0x5e, 0xc0, 0x65, 0x84, 0x2f, 0xbc, 0x2b, 0x01, 0x57, 0x8e, 0x7e, 0x0c,
+	0xd5, 0xb8, 0xc1, 0x57, 0x64, 0xc4, 0xa8, 0xda, 0x12, 0xd3, 0xf9, 0xc1, 0x74, 0xd4, 0x8b, 0x24,
+	0x4a, 0x23, 0xbb, 0xb0, 0xd3, 0xb6, 0xdd, 0x1e, 0x75, 0xd2, 0x84, 0xd2, 0x91, 0x0b, 0xab, 0xe2,
+	0x0d, 0x61, 0xaa, 0x09, 0x5e, 0x0c, 0xe4, 0x86, 0xb2, 0x17, 0x0d, 0x21, 0x8d, 0xa9, 0xc1, 0x0b,
+	0x3c, 0xc2, 0xa8, 0x26, 0xf7, 0x23, 0xc2, 0x5c, 0x4d, 0x54, 0x11, 0xe1, 0x0a, 0xa9, 0x43, 0x0d,
+	0x7f, 0x4d, 0xc7, 0xbe, 0x63, 0x47, 0x1c, 0xbb, 0x7c, 0xd4, 0x81, 0x35, 0x59, 0x0c, 0xd8, 0x11,
+	0x21, 0x51, 0xe2, 0x6a, 0x4b, 0xcc, 0x23, 0xe8, 0x22, 0xc4, 0x3d, 0x3f, 0xa9, 0x69, 0xdc, 0x69,
+	0x9e, 0x1f, 0x23, 0x4a, 0x27, 0xff, 0xd9, 0x86, 0x15, 0xae, 0x0c, 0xf9, 0x0c, 0xd6, 0xe4, 0xb6,
+	0x9d, 0x60, 0x27, 0x99, 0xdd, 0xfe, 0x1b, 0x8d, 0x0c, 0x96, 0x87, 0xdd, 0xbc, 0xfd, 0xeb, 0xbf,
+	0xff, 0xfb, 0xab, 0xd2, 0x9e, 0x59, 0x67, 0x7f, 0x48, 0x08, 0x8f, 0xa7, 0xf7, 0x6c, 0xc7, 0xbf,
+	0xb0, 0xef, 0x1d, 0xb3, 0x34, 0x0c, 0x3f, 0xd0, 0x8e, 0xc8, 0x00, 0xd6, 0x95, 0x95, 0x36, 0x69,
+	0x32, 0x36, 0xf9, 0x25, 0xba, 0xb1, 0x9b, 0xc3, 0x0b, 0x01, 0xef, 0xa3, 0x80, 0x03, 0xe3, 0x66,
+	0x91, 0x80, 0xe3, 0x57, 0xec, 0x79, 0xfe, 0x92, 0xc9, 0xf9, 0x10, 0x20, 0xd9, 0x32, 0x13, 0xd4,
+	0x36, 0xb7, 0xb9, 0x36, 0x9a, 0x59, 0xb4, 0x10, 0xb2, 0x44, 0x1c, 0x58, 0x57, 0xd6, 0xad, 0xc4,
+	0xc8, 0xec, 0x5f, 0x95, 0xfd, 0xb0, 0x71, 0xb3, 0x90, 0x26, 0x38, 0xbd, 0x8b, 0xea, 0xb6, 0xc8,
+	0x7e, 0x46, 0xdd, 0x10, 0x8f, 0x0a, 0x7d, 0x49, 0x1b, 0x36, 0xd4, 0xad, 0x26, 0x41, 0xeb, 0x0b,
+	0xd6, 0xb9, 0x86, 0x9e, 0x27, 0x48, 0x95, 0x3f, 0x86, 0xcd, 0xd4, 0x45, 0x23, 0x7a, 0x6e, 0x97,
+	0x18, 0xb3, 0xd9, 0x2b, 0xa0, 0x48, 0x3e, 0x9f, 0x41, 0x33, 0xbf, 0x85, 0x43, 0x2f, 0xde, 0x52,
+	0x82, 0x92, 0xdf, 0x84, 0x19, 0xad, 0x59, 0x64, 0xc9, 0xfa, 0x09, 0xd4, 0xb2, 0xdb, 0x2a, 0x82,
+	0xee, 0x9b, 0xb1, 0x5c, 0x33, 0xf6, 0x8b, 0x89, 0x92, 0xe1, 0x07, 0xb0, 0x26, 0x97, 0x41, 0x3c,
+	0x51, 0xb3, 0x3b, 0x29, 0xa3, 0x91, 0xc1, 0xca, 0xdf, 0x0e, 0x61, 0x33, 0xb5, 0x7e, 0xe1, 0xfe,
+	0x2a, 0xda, 0x0d, 0x19, 0x7b, 0x05, 0x14, 0xc1, 0xe7, 0x0e, 0x06, 0xf8, 0xa6, 0xd1, 0xcc, 0x06,
+	0x18, 0x8f, 0x61, 0xca, 0x9f, 0xc2, 0x56, 0x7a, 0x53, 0x42, 0xf6, 0xf8, 0xfb, 0x5d, 0xb0, 0x84,
+	0x31, 0x8c, 0x22, 0x92, 0xd4, 0x39, 0x80, 0xcd, 0xd4, 0xc2, 0x43, 0xe8, 0x5c, 0xb0, 0x43, 0x31,
+	0xf6, 0x0a, 0x28, 0x82, 0xcf, 0xf7, 0x50, 0xe7, 0xf7, 0x8f, 0xde, 0xcd, 0xe8, 0x2c, 0xe6, 0xa6,
+	0xe3, 0x57, 0xac, 0xf1, 0xfd, 0x32, 0x4e, 0xce, 0x4b, 0xe9, 0x27, 0x5e, 0xe2, 0x52, 0x7e, 0x4a,
+	0x2d, 0x4d, 0x8c, 0xbd, 0x02, 0x8a, 0x90, 0xf9, 0x1e, 0xca, 0xbc, 0x6d, 0x18, 0x19, 0x99, 0x7c,
+	0xae, 0x3c, 0x7e, 0xe5, 0xf9, 0x78, 0x6d, 0x7f, 0x0e, 0x90, 0x4c, 0x86, 0xfc, 0xda, 0xe6, 0x86,
+	0x53, 0xa3, 0x99, 0x45, 0x0b, 0x19, 0x2d, 0x94, 0xa1, 0x93, 0x66, 0xb1, 0x5d, 0x64, 0x00, 0x9b,
+	0xa9, 0xb1, 0x27, 0x1d, 0x71, 0x75, 0x42, 0x34, 0xf6, 0x0a, 0x28, 0x42, 0xca, 0x01, 0x4a, 0x31,
+	0x8c, 0x46, 0x36, 0xe2, 0x78, 0x8c, 0x19, 0xe1, 0xc0, 0x66, 0x6a, 0x76, 0xe1, 0x72, 0x8a, 0x46,
+	0x1f, 0x63, 0xaf, 0x80, 0x92, 0xae, 0x74, 0xa4, 0x95, 0x95, 0x33, 0xe9, 0xaa, 0xc5, 0x8e, 0x3c,
+	0x85, 0x15, 0x3e, 0x8c, 0x90, 0x6d, 0xc1, 0x4c, 0xe1, 0x4f, 0x54, 0x94, 0x60, 0xfc, 0x0e, 0x32,
+	0xbe, 0x45, 0xe6, 0x95, 0x50, 0xf2, 0x4b, 0x58, 0x57, 0xfa, 0x70, 0x5e, 0xa7, 0xf3, 0x33, 0x86,
+	0xb1, 0x9b, 0xc3, 0xbf, 0xc5, 0x4b, 0x94, 0x9d, 0xc2, 0x6b, 0xd1, 0x86, 0x0d, 0x75, 0xbe, 0xe1,
+	0x45, 0xaf, 0x60, 0x10, 0x32, 0xf4, 0x3c, 0x41, 0x5e, 0x88, 0x53, 0xd8, 0x4a, 0x37, 0xdc, 0xfc,
+	0x6e, 0x15, 0x76, 0xf3, 0x86, 0x51, 0x44, 0x92, 0xac, 0xda, 0xb0, 0xa1, 0x76, 0xc4, 0x44, 0x7d,
+	0x82, 0x52, 0x45, 0x49, 0xcf, 0x13, 0x24, 0x93, 0x33, 0xb8, 0x91, 0xe9, 0x16, 0xf9, 0xdb, 0x51,
+	0xdc, 0xf4, 0x1a, 0x37, 0x0b, 0x69, 0xaa, 0x75, 0xe9, 0x9e, 0x8d, 0x5b, 0x57, 0xd8, 0x16, 0x1a,
+	0x46, 0x11, 0x49, 0xb2, 0xfa, 0x19, 0x0e, 0x99, 0x09, 0x49, 0x3c, 0x6c, 0x2d, 0xe1, 0xdb, 0x2c,
+	0x21, 0x66, 0x7a, 0x7b, 0x26, 0x5d, 0x72, 0x7e, 0x06, 0x24, 0x75, 0x80, 0x27, 0xcc, 0xad, 0xdc,
+	0x0f, 0x53, 0x79, 0xd3, 0x9a, 0x45, 0x96, 0x6c, 0x6d, 0xf9, 0x0c, 0x65, 0x59, 0xdf, 0x51, 0xfc,
+	0x3f, 0x83, 0xbd, 0x39, 0xef, 0x48, 0x2c, 0xe2, 0xbe, 0xfe, 0xd7, 0xd7, 0x2d, 0xed, 0x9b, 0xd7,
+	0x2d, 0xed, 0x5f, 0xaf, 0x5b, 0xda, 0xef, 0xdf, 0xb4, 0x96, 0xbe, 0x79, 0xd3, 0x5a, 0xfa, 0xc7,
+	0x9b, 0xd6, 0x52, 0x77, 0x05, 0xff, 0xed, 0xe1, 0xfb, 0xff, 0x1d, 0x00, 0x60, 0x78, 0xfe, 0x0c,
+	0x3a, 0x21, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -6924,6 +6941,24 @@ func (m *HandleErrorRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.EventTypes) > 0 {
+		for iNdEx := len(m.EventTypes) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.EventTypes[iNdEx])
+			copy(dAtA[i:], m.EventTypes[iNdEx])
+			i = encodeVarintDmmaster(dAtA, i, uint64(len(m.EventTypes[iNdEx])))
+			i--
+			dAtA[i] = 0x3a
+		}
+	}
+	if len(m.Tables) > 0 {
+		for iNdEx := len(m.Tables) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Tables[iNdEx])
+			copy(dAtA[i:], m.Tables[iNdEx])
+			i = encodeVarintDmmaster(dAtA, i, uint64(len(m.Tables[iNdEx])))
+			i--
+			dAtA[i] = 0x32
+		}
+	}
 	if len(m.Sqls) > 0 {
 		for iNdEx := len(m.Sqls) - 1; iNdEx >= 0; iNdEx-- {
 			i -= len(m.Sqls[iNdEx])
@@ -8434,6 +8469,18 @@ func (m *HandleErrorRequest) Size() (n int) {
 			n += 1 + l + sovDmmaster(uint64(l))
 		}
 	}
+	if len(m.Tables) > 0 {
+		for _, s := range m.Tables {
+			l = len(s)
+			n += 1 + l + sovDmmaster(uint64(l))
+		}
+	}
+	if len(m.EventTypes) > 0 {
+		for _, s := range m.EventTypes {
+			l = len(s)
+			n += 1 + l + sovDmmaster(uint64(l))
+		}
+	}
 	return n
 }
 
@@ -14792,6 +14839,70 @@ func (m *HandleErrorRequest) Unmarshal(dAtA []byte) error {
 			}
 			m.Sqls = append(m.Sqls, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Tables", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDmmaster
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDmmaster
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDmmaster
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Tables = append(m.Tables, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EventTypes", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDmmaster
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDmmaster
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDmmaster
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.EventTypes = append(m.EventTypes, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipDmmaster(dAtA[iNdEx:])
@@ -776,12 +776,14 @@ func (m *DumpStatus) GetProgress() string {
 
 // LoadStatus represents status for load unit
 type LoadStatus struct {
-	FinishedBytes  int64  `protobuf:"varint,1,opt,name=finishedBytes,proto3" json:"finishedBytes,omitempty"`
-	TotalBytes     int64  `protobuf:"varint,2,opt,name=totalBytes,proto3" json:"totalBytes,omitempty"`
-	Progress       string `protobuf:"bytes,3,opt,name=progress,proto3" json:"progress,omitempty"`
-	MetaBinlog     string `protobuf:"bytes,4,opt,name=metaBinlog,proto3" json:"metaBinlog,omitempty"`
-	MetaBinlogGTID string `protobuf:"bytes,5,opt,name=metaBinlogGTID,proto3" json:"metaBinlogGTID,omitempty"`
-	Bps            int64  `protobuf:"varint,6,opt,name=bps,proto3" json:"bps,omitempty"`
+	FinishedBytes  int64              `protobuf:"varint,1,opt,name=finishedBytes,proto3" json:"finishedBytes,omitempty"`
+	TotalBytes     int64              `protobuf:"varint,2,opt,name=totalBytes,proto3" json:"totalBytes,omitempty"`
+	Progress       string             `protobuf:"bytes,3,opt,name=progress,proto3" json:"progress,omitempty"`
+	MetaBinlog     string             `protobuf:"bytes,4,opt,name=metaBinlog,proto3" json:"metaBinlog,omitempty"`
+	MetaBinlogGTID string             `protobuf:"bytes,5,opt,name=metaBinlogGTID,proto3" json:"metaBinlogGTID,omitempty"`
+	Bps            int64              `protobuf:"varint,6,opt,name=bps,proto3" json:"bps,omitempty"`
+	Tables         []*TableLoadStatus `protobuf:"bytes,7,rep,name=tables,proto3" json:"tables,omitempty"`
+	Eta            int64              `protobuf:"varint,8,opt,name=eta,proto3" json:"eta,omitempty"`
 }
 
 func (m *LoadStatus) Reset()         { *m = LoadStatus{} }
@@ -859,6 +861,105 @@ func (m *LoadStatus) GetBps() int64 {
 	return 0
 }
 
+func (m *LoadStatus) GetTables() []*TableLoadStatus {
+	if m != nil {
+		return m.Tables
+	}
+	return nil
+}
+
+func (m *LoadStatus) GetEta() int64 {
+	if m != nil {
+		return m.Eta
+	}
+	return 0
+}
+
+// TableLoadStatus represents load status of a target table
+type TableLoadStatus struct {
+	Table         string `protobuf:"bytes,1,opt,name=table,proto3" json:"table,omitempty"`
+	FinishedBytes int64  `protobuf:"varint,2,opt,name=finishedBytes,proto3" json:"finishedBytes,omitempty"`
+	TotalBytes    int64  `protobuf:"varint,3,opt,name=totalBytes,proto3" json:"totalBytes,omitempty"`
+	Progress      string `protobuf:"bytes,4,opt,name=progress,proto3" json:"progress,omitempty"`
+	Bps           int64  `protobuf:"varint,5,opt,name=bps,proto3" json:"bps,omitempty"`
+	Eta           int64  `protobuf:"varint,6,opt,name=eta,proto3" json:"eta,omitempty"`
+}
+
+func (m *TableLoadStatus) Reset()         { *m = TableLoadStatus{} }
+func (m *TableLoadStatus) String() string { return proto.CompactTextString(m) }
+func (*TableLoadStatus) ProtoMessage()    {}
+func (*TableLoadStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_51a1b9e17fd67b10, []int{6}
+}
+func (m *TableLoadStatus) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TableLoadStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TableLoadStatus.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *TableLoadStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TableLoadStatus.Merge(m, src)
+}
+func (m *TableLoadStatus) XXX_Size() int {
+	return m.Size()
+}
+func (m *TableLoadStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_TableLoadStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TableLoadStatus proto.InternalMessageInfo
+
+func (m *TableLoadStatus) GetTable() string {
+	if m != nil {
+		return m.Table
+	}
+	return ""
+}
+
+func (m *TableLoadStatus) GetFinishedBytes() int64 {
+	if m != nil {
+		return m.FinishedBytes
+	}
+	return 0
+}
+
+func (m *TableLoadStatus) GetTotalBytes() int64 {
+	if m != nil {
+		return m.TotalBytes
+	}
+	return 0
+}
+
+func (m *TableLoadStatus) GetProgress() string {
+	if m != nil {
+		return m.Progress
+	}
+	return ""
+}
+
+func (m *TableLoadStatus) GetBps() int64 {
+	if m != nil {
+		return m.Bps
+	}
+	return 0
+}
+
+func (m *TableLoadStatus) GetEta() int64 {
+	if m != nil {
+		return m.Eta
+	}
+	return 0
+}
+
 // ShardingGroup represents a DDL sharding group, this is used by SyncStatus, and is differ from ShardingGroup in syncer pkg
 // target: target table name
 // DDL: in syncing DDL
@@ -877,7 +978,7 @@ func (m *ShardingGroup) Reset()         { *m = ShardingGroup{} }
 func (m *ShardingGroup) String() string { return proto.CompactTextString(m) }
 func (*ShardingGroup) ProtoMessage()    {}
 func (*ShardingGroup) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{6}
+	return fileDescriptor_51a1b9e17fd67b10, []int{7}
 }
 func (m *ShardingGroup) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -961,13 +1062,14 @@ type SyncStatus struct {
 	TotalRows           int64            `protobuf:"varint,15,opt,name=totalRows,proto3" json:"totalRows,omitempty"`
 	TotalRps            int64            `protobuf:"varint,16,opt,name=totalRps,proto3" json:"totalRps,omitempty"`
 	RecentRps           int64            `protobuf:"varint,17,opt,name=recentRps,proto3" json:"recentRps,omitempty"`
+	HeartbeatLag        int64            `protobuf:"varint,18,opt,name=heartbeatLag,proto3" json:"heartbeatLag,omitempty"`
 }
 
 func (m *SyncStatus) Reset()         { *m = SyncStatus{} }
 func (m *SyncStatus) String() string { return proto.CompactTextString(m) }
 func (*SyncStatus) ProtoMessage()    {}
 func (*SyncStatus) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{7}
+	return fileDescriptor_51a1b9e17fd67b10, []int{8}
 }
 func (m *SyncStatus) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1115,6 +1217,13 @@ func (m *SyncStatus) GetRecentRps() int64 {
 	return 0
 }
 
+func (m *SyncStatus) GetHeartbeatLag() int64 {
+	if m != nil {
+		return m.HeartbeatLag
+	}
+	return 0
+}
+
 // SourceStatus represents status for source runing on dm-worker
 type SourceStatus struct {
 	Source      string         `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`
@@ -1127,7 +1236,7 @@ func (m *SourceStatus) Reset()         { *m = SourceStatus{} }
 func (m *SourceStatus) String() string { return proto.CompactTextString(m) }
 func (*SourceStatus) ProtoMessage()    {}
 func (*SourceStatus) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{8}
+	return fileDescriptor_51a1b9e17fd67b10, []int{9}
 }
 func (m *SourceStatus) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1200,7 +1309,7 @@ func (m *RelayStatus) Reset()         { *m = RelayStatus{} }
 func (m *RelayStatus) String() string { return proto.CompactTextString(m) }
 func (*RelayStatus) ProtoMessage()    {}
 func (*RelayStatus) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{9}
+	return fileDescriptor_51a1b9e17fd67b10, []int{10}
 }
 func (m *RelayStatus) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1319,7 +1428,7 @@ func (m *SubTaskStatus) Reset()         { *m = SubTaskStatus{} }
 func (m *SubTaskStatus) String() string { return proto.CompactTextString(m) }
 func (*SubTaskStatus) ProtoMessage()    {}
 func (*SubTaskStatus) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{10}
+	return fileDescriptor_51a1b9e17fd67b10, []int{11}
 }
 func (m *SubTaskStatus) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1480,7 +1589,7 @@ func (m *SubTaskStatusList) Reset()         { *m = SubTaskStatusList{} }
 func (m *SubTaskStatusList) String() string { return proto.CompactTextString(m) }
 func (*SubTaskStatusList) ProtoMessage()    {}
 func (*SubTaskStatusList) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{11}
+	return fileDescriptor_51a1b9e17fd67b10, []int{12}
 }
 func (m *SubTaskStatusList) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1526,7 +1635,7 @@ func (m *CheckError) Reset()         { *m = CheckError{} }
 func (m *CheckError) String() string { return proto.CompactTextString(m) }
 func (*CheckError) ProtoMessage()    {}
 func (*CheckError) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{12}
+	return fileDescriptor_51a1b9e17fd67b10, []int{13}
 }
 func (m *CheckError) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1572,7 +1681,7 @@ func (m *DumpError) Reset()         { *m = DumpError{} }
 func (m *DumpError) String() string { return proto.CompactTextString(m) }
 func (*DumpError) ProtoMessage()    {}
 func (*DumpError) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{13}
+	return fileDescriptor_51a1b9e17fd67b10, []int{14}
 }
 func (m *DumpError) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1617,7 +1726,7 @@ func (m *LoadError) Reset()         { *m = LoadError{} }
 func (m *LoadError) String() string { return proto.CompactTextString(m) }
 func (*LoadError) ProtoMessage()    {}
 func (*LoadError) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{14}
+	return fileDescriptor_51a1b9e17fd67b10, []int{15}
 }
 func (m *LoadError) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1664,7 +1773,7 @@ func (m *SyncSQLError) Reset()         { *m = SyncSQLError{} }
 func (m *SyncSQLError) String() string { return proto.CompactTextString(m) }
 func (*SyncSQLError) ProtoMessage()    {}
 func (*SyncSQLError) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{15}
+	return fileDescriptor_51a1b9e17fd67b10, []int{16}
 }
 func (m *SyncSQLError) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1723,7 +1832,7 @@ func (m *SyncError) Reset()         { *m = SyncError{} }
 func (m *SyncError) String() string { return proto.CompactTextString(m) }
 func (*SyncError) ProtoMessage()    {}
 func (*SyncError) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{16}
+	return fileDescriptor_51a1b9e17fd67b10, []int{17}
 }
 func (m *SyncError) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1771,7 +1880,7 @@ func (m *SourceError) Reset()         { *m = SourceError{} }
 func (m *SourceError) String() string { return proto.CompactTextString(m) }
 func (*SourceError) ProtoMessage()    {}
 func (*SourceError) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{17}
+	return fileDescriptor_51a1b9e17fd67b10, []int{18}
 }
 func (m *SourceError) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1837,7 +1946,7 @@ func (m *RelayError) Reset()         { *m = RelayError{} }
 func (m *RelayError) String() string { return proto.CompactTextString(m) }
 func (*RelayError) ProtoMessage()    {}
 func (*RelayError) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{18}
+	return fileDescriptor_51a1b9e17fd67b10, []int{19}
 }
 func (m *RelayError) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1898,7 +2007,7 @@ func (m *SubTaskError) Reset()         { *m = SubTaskError{} }
 func (m *SubTaskError) String() string { return proto.CompactTextString(m) }
 func (*SubTaskError) ProtoMessage()    {}
 func (*SubTaskError) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{19}
+	return fileDescriptor_51a1b9e17fd67b10, []int{20}
 }
 func (m *SubTaskError) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2038,7 +2147,7 @@ func (m *SubTaskErrorList) Reset()         { *m = SubTaskErrorList{} }
 func (m *SubTaskErrorList) String() string { return proto.CompactTextString(m) }
 func (*SubTaskErrorList) ProtoMessage()    {}
 func (*SubTaskErrorList) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{20}
+	return fileDescriptor_51a1b9e17fd67b10, []int{21}
 }
 func (m *SubTaskErrorList) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2090,7 +2199,7 @@ func (m *ProcessResult) Reset()         { *m = ProcessResult{} }
 func (m *ProcessResult) String() string { return proto.CompactTextString(m) }
 func (*ProcessResult) ProtoMessage()    {}
 func (*ProcessResult) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{21}
+	return fileDescriptor_51a1b9e17fd67b10, []int{22}
 }
 func (m *ProcessResult) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2157,7 +2266,7 @@ func (m *ProcessError) Reset()         { *m = ProcessError{} }
 func (m *ProcessError) String() string { return proto.CompactTextString(m) }
 func (*ProcessError) ProtoMessage()    {}
 func (*ProcessError) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{22}
+	return fileDescriptor_51a1b9e17fd67b10, []int{23}
 }
 func (m *ProcessError) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2251,7 +2360,7 @@ func (m *PurgeRelayRequest) Reset()         { *m = PurgeRelayRequest{} }
 func (m *PurgeRelayRequest) String() string { return proto.CompactTextString(m) }
 func (*PurgeRelayRequest) ProtoMessage()    {}
 func (*PurgeRelayRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{23}
+	return fileDescriptor_51a1b9e17fd67b10, []int{24}
 }
 func (m *PurgeRelayRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2325,7 +2434,7 @@ func (m *OperateWorkerSchemaRequest) Reset()         { *m = OperateWorkerSchemaR
 func (m *OperateWorkerSchemaRequest) String() string { return proto.CompactTextString(m) }
 func (*OperateWorkerSchemaRequest) ProtoMessage()    {}
 func (*OperateWorkerSchemaRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{24}
+	return fileDescriptor_51a1b9e17fd67b10, []int{25}
 }
 func (m *OperateWorkerSchemaRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2436,7 +2545,7 @@ func (m *V1SubTaskMeta) Reset()         { *m = V1SubTaskMeta{} }
 func (m *V1SubTaskMeta) String() string { return proto.CompactTextString(m) }
 func (*V1SubTaskMeta) ProtoMessage()    {}
 func (*V1SubTaskMeta) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{25}
+	return fileDescriptor_51a1b9e17fd67b10, []int{26}
 }
 func (m *V1SubTaskMeta) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2501,7 +2610,7 @@ func (m *OperateV1MetaRequest) Reset()         { *m = OperateV1MetaRequest{} }
 func (m *OperateV1MetaRequest) String() string { return proto.CompactTextString(m) }
 func (*OperateV1MetaRequest) ProtoMessage()    {}
 func (*OperateV1MetaRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{26}
+	return fileDescriptor_51a1b9e17fd67b10, []int{27}
 }
 func (m *OperateV1MetaRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2547,7 +2656,7 @@ func (m *OperateV1MetaResponse) Reset()         { *m = OperateV1MetaResponse{} }
 func (m *OperateV1MetaResponse) String() string { return proto.CompactTextString(m) }
 func (*OperateV1MetaResponse) ProtoMessage()    {}
 func (*OperateV1MetaResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{27}
+	return fileDescriptor_51a1b9e17fd67b10, []int{28}
 }
 func (m *OperateV1MetaResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2598,17 +2707,19 @@ func (m *OperateV1MetaResponse) GetMeta() map[string]*V1SubTaskMeta {
 }
 
 type HandleWorkerErrorRequest struct {
-	Op        ErrorOp  `protobuf:"varint,1,opt,name=op,proto3,enum=pb.ErrorOp" json:"op,omitempty"`
-	Task      string   `protobuf:"bytes,2,opt,name=task,proto3" json:"task,omitempty"`
-	BinlogPos string   `protobuf:"bytes,3,opt,name=binlogPos,proto3" json:"binlogPos,omitempty"`
-	Sqls      []string `protobuf:"bytes,4,rep,name=sqls,proto3" json:"sqls,omitempty"`
+	Op         ErrorOp  `protobuf:"varint,1,opt,name=op,proto3,enum=pb.ErrorOp" json:"op,omitempty"`
+	Task       string   `protobuf:"bytes,2,opt,name=task,proto3" json:"task,omitempty"`
+	BinlogPos  string   `protobuf:"bytes,3,opt,name=binlogPos,proto3" json:"binlogPos,omitempty"`
+	Sqls       []string `protobuf:"bytes,4,rep,name=sqls,proto3" json:"sqls,omitempty"`
+	Tables     []string `protobuf:"bytes,5,rep,name=tables,proto3" json:"tables,omitempty"`
+	EventTypes []string `protobuf:"bytes,6,rep,name=eventTypes,proto3" json:"eventTypes,omitempty"`
 }
 
 func (m *HandleWorkerErrorRequest) Reset()         { *m = HandleWorkerErrorRequest{} }
 func (m *HandleWorkerErrorRequest) String() string { return proto.CompactTextString(m) }
 func (*HandleWorkerErrorRequest) ProtoMessage()    {}
 func (*HandleWorkerErrorRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{28}
+	return fileDescriptor_51a1b9e17fd67b10, []int{29}
 }
 func (m *HandleWorkerErrorRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2665,6 +2776,20 @@ func (m *HandleWorkerErrorRequest) GetSqls() []string {
 	return nil
 }
 
+func (m *HandleWorkerErrorRequest) GetTables() []string {
+	if m != nil {
+		return m.Tables
+	}
+	return nil
+}
+
+func (m *HandleWorkerErrorRequest) GetEventTypes() []string {
+	if m != nil {
+		return m.EventTypes
+	}
+	return nil
+}
+
 type GetWorkerCfgRequest struct {
 }
 
@@ -2672,7 +2797,7 @@ func (m *GetWorkerCfgRequest) Reset()         { *m = GetWorkerCfgRequest{} }
 func (m *GetWorkerCfgRequest) String() string { return proto.CompactTextString(m) }
 func (*GetWorkerCfgRequest) ProtoMessage()    {}
 func (*GetWorkerCfgRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{29}
+	return fileDescriptor_51a1b9e17fd67b10, []int{30}
 }
 func (m *GetWorkerCfgRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2709,7 +2834,7 @@ func (m *GetWorkerCfgResponse) Reset()         { *m = GetWorkerCfgResponse{} }
 func (m *GetWorkerCfgResponse) String() string { return proto.CompactTextString(m) }
 func (*GetWorkerCfgResponse) ProtoMessage()    {}
 func (*GetWorkerCfgResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{30}
+	return fileDescriptor_51a1b9e17fd67b10, []int{31}
 }
 func (m *GetWorkerCfgResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2753,7 +2878,7 @@ func (m *CheckSubtasksCanUpdateRequest) Reset()         { *m = CheckSubtasksCanU
 func (m *CheckSubtasksCanUpdateRequest) String() string { return proto.CompactTextString(m) }
 func (*CheckSubtasksCanUpdateRequest) ProtoMessage()    {}
 func (*CheckSubtasksCanUpdateRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{31}
+	return fileDescriptor_51a1b9e17fd67b10, []int{32}
 }
 func (m *CheckSubtasksCanUpdateRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2798,7 +2923,7 @@ func (m *CheckSubtasksCanUpdateResponse) Reset()         { *m = CheckSubtasksCan
 func (m *CheckSubtasksCanUpdateResponse) String() string { return proto.CompactTextString(m) }
 func (*CheckSubtasksCanUpdateResponse) ProtoMessage()    {}
 func (*CheckSubtasksCanUpdateResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{32}
+	return fileDescriptor_51a1b9e17fd67b10, []int{33}
 }
 func (m *CheckSubtasksCanUpdateResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2850,7 +2975,7 @@ func (m *GetValidationStatusRequest) Reset()         { *m = GetValidationStatusR
 func (m *GetValidationStatusRequest) String() string { return proto.CompactTextString(m) }
 func (*GetValidationStatusRequest) ProtoMessage()    {}
 func (*GetValidationStatusRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{33}
+	return fileDescriptor_51a1b9e17fd67b10, []int{34}
 }
 func (m *GetValidationStatusRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2910,7 +3035,7 @@ func (m *ValidationStatus) Reset()         { *m = ValidationStatus{} }
 func (m *ValidationStatus) String() string { return proto.CompactTextString(m) }
 func (*ValidationStatus) ProtoMessage()    {}
 func (*ValidationStatus) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{34}
+	return fileDescriptor_51a1b9e17fd67b10, []int{35}
 }
 func (m *ValidationStatus) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3021,7 +3146,7 @@ func (m *ValidationTableStatus) Reset()         { *m = ValidationTableStatus{} }
 func (m *ValidationTableStatus) String() string { return proto.CompactTextString(m) }
 func (*ValidationTableStatus) ProtoMessage()    {}
 func (*ValidationTableStatus) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{35}
+	return fileDescriptor_51a1b9e17fd67b10, []int{36}
 }
 func (m *ValidationTableStatus) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3096,7 +3221,7 @@ func (m *GetValidationStatusResponse) Reset()         { *m = GetValidationStatus
 func (m *GetValidationStatusResponse) String() string { return proto.CompactTextString(m) }
 func (*GetValidationStatusResponse) ProtoMessage()    {}
 func (*GetValidationStatusResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{36}
+	return fileDescriptor_51a1b9e17fd67b10, []int{37}
 }
 func (m *GetValidationStatusResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3162,7 +3287,7 @@ func (m *GetValidationErrorRequest) Reset()         { *m = GetValidationErrorReq
 func (m *GetValidationErrorRequest) String() string { return proto.CompactTextString(m) }
 func (*GetValidationErrorRequest) ProtoMessage()    {}
 func (*GetValidationErrorRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{37}
+	return fileDescriptor_51a1b9e17fd67b10, []int{38}
 }
 func (m *GetValidationErrorRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3222,7 +3347,7 @@ func (m *ValidationError) Reset()         { *m = ValidationError{} }
 func (m *ValidationError) String() string { return proto.CompactTextString(m) }
 func (*ValidationError) ProtoMessage()    {}
 func (*ValidationError) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{38}
+	return fileDescriptor_51a1b9e17fd67b10, []int{39}
 }
 func (m *ValidationError) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3331,7 +3456,7 @@ func (m *GetValidationErrorResponse) Reset()         { *m = GetValidationErrorRe
 func (m *GetValidationErrorResponse) String() string { return proto.CompactTextString(m) }
 func (*GetValidationErrorResponse) ProtoMessage()    {}
 func (*GetValidationErrorResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{39}
+	return fileDescriptor_51a1b9e17fd67b10, []int{40}
 }
 func (m *GetValidationErrorResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3392,7 +3517,7 @@ func (m *OperateValidationErrorRequest) Reset()         { *m = OperateValidation
 func (m *OperateValidationErrorRequest) String() string { return proto.CompactTextString(m) }
 func (*OperateValidationErrorRequest) ProtoMessage()    {}
 func (*OperateValidationErrorRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{40}
+	return fileDescriptor_51a1b9e17fd67b10, []int{41}
 }
 func (m *OperateValidationErrorRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3458,7 +3583,7 @@ func (m *OperateValidationErrorResponse) Reset()         { *m = OperateValidatio
 func (m *OperateValidationErrorResponse) String() string { return proto.CompactTextString(m) }
 func (*OperateValidationErrorResponse) ProtoMessage()    {}
 func (*OperateValidationErrorResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{41}
+	return fileDescriptor_51a1b9e17fd67b10, []int{42}
 }
 func (m *OperateValidationErrorResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3518,6 +3643,7 @@ func init() {
 	proto.RegisterType((*CheckStatus)(nil), "pb.CheckStatus")
 	proto.RegisterType((*DumpStatus)(nil), "pb.DumpStatus")
 	proto.RegisterType((*LoadStatus)(nil), "pb.LoadStatus")
+	proto.RegisterType((*TableLoadStatus)(nil), "pb.TableLoadStatus")
 	proto.RegisterType((*ShardingGroup)(nil), "pb.ShardingGroup")
 	proto.RegisterType((*SyncStatus)(nil), "pb.SyncStatus")
 	proto.RegisterType((*SourceStatus)(nil), "pb.SourceStatus")
@@ -3560,187 +3686,193 @@ func init() {
 func init() { proto.RegisterFile("dmworker.proto", fileDescriptor_51a1b9e17fd67b10) }
 
 var fileDescriptor_51a1b9e17fd67b10 = []byte{
-	// 2880 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x3a, 0xcd, 0x6f, 0x24, 0x57,
-	0xf1, 0xd3, 0xdd, 0xf3, 0x59, 0x33, 0xb6, 0xdb, 0x6f, 0xbd, 0xfb, 0x9b, 0x38, 0xbb, 0x13, 0xa7,
-	0x37, 0xca, 0xcf, 0xb1, 0x82, 0x95, 0x98, 0xa0, 0xa0, 0x48, 0x90, 0x64, 0xed, 0x8d, 0x77, 0x83,
-	0x37, 0xde, 0x6d, 0x3b, 0xcb, 0x09, 0x89, 0x76, 0xcf, 0xf3, 0xb8, 0x71, 0x4f, 0x77, 0x6f, 0x77,
-	0x8f, 0x2d, 0x1f, 0x10, 0x17, 0xc4, 0x15, 0x2e, 0x20, 0x81, 0xb8, 0x80, 0x84, 0xc4, 0x89, 0x03,
-	0x7f, 0x00, 0x47, 0xc8, 0x31, 0xe2, 0xc4, 0x11, 0x65, 0xff, 0x06, 0xae, 0x08, 0x55, 0xbd, 0xf7,
-	0xba, 0x5f, 0xcf, 0x87, 0x37, 0x8b, 0xc4, 0xad, 0xeb, 0xe3, 0x55, 0xd5, 0xab, 0xaf, 0x57, 0x35,
-	0x36, 0x2c, 0x0f, 0xc7, 0x97, 0x71, 0x7a, 0xce, 0xd3, 0xed, 0x24, 0x8d, 0xf3, 0x98, 0x99, 0xc9,
-	0x89, 0xb3, 0x09, 0xec, 0xc9, 0x84, 0xa7, 0x57, 0x47, 0xb9, 0x97, 0x4f, 0x32, 0x97, 0x3f, 0x9b,
-	0xf0, 0x2c, 0x67, 0x0c, 0xea, 0x91, 0x37, 0xe6, 0x7d, 0x63, 0xc3, 0xd8, 0xec, 0xb8, 0xf4, 0xed,
-	0x24, 0xb0, 0xb6, 0x1b, 0x8f, 0xc7, 0x71, 0xf4, 0x7d, 0x92, 0xe1, 0xf2, 0x2c, 0x89, 0xa3, 0x8c,
-	0xb3, 0x5b, 0xd0, 0x4c, 0x79, 0x36, 0x09, 0x73, 0xe2, 0x6e, 0xbb, 0x12, 0x62, 0x36, 0x58, 0xe3,
-	0x6c, 0xd4, 0x37, 0x49, 0x04, 0x7e, 0x22, 0x67, 0x16, 0x4f, 0x52, 0x9f, 0xf7, 0x2d, 0x42, 0x4a,
-	0x08, 0xf1, 0xc2, 0xae, 0x7e, 0x5d, 0xe0, 0x05, 0xe4, 0xfc, 0xc9, 0x80, 0x1b, 0x15, 0xe3, 0x5e,
-	0x5a, 0xe3, 0x7b, 0xd0, 0x13, 0x3a, 0x84, 0x04, 0xd2, 0xdb, 0xdd, 0xb1, 0xb7, 0x93, 0x93, 0xed,
-	0x23, 0x0d, 0xef, 0x56, 0xb8, 0xd8, 0xfb, 0xb0, 0x94, 0x4d, 0x4e, 0x8e, 0xbd, 0xec, 0x5c, 0x1e,
-	0xab, 0x6f, 0x58, 0x9b, 0xdd, 0x9d, 0x55, 0x3a, 0xa6, 0x13, 0xdc, 0x2a, 0x9f, 0xf3, 0x07, 0x03,
-	0xba, 0xbb, 0x67, 0xdc, 0x97, 0x30, 0x1a, 0x9a, 0x78, 0x59, 0xc6, 0x87, 0xca, 0x50, 0x01, 0xb1,
-	0x35, 0x68, 0xe4, 0x71, 0xee, 0x85, 0x64, 0x6a, 0xc3, 0x15, 0x00, 0x1b, 0x00, 0x64, 0x13, 0xdf,
-	0xe7, 0x59, 0x76, 0x3a, 0x09, 0xc9, 0xd4, 0x86, 0xab, 0x61, 0x50, 0xda, 0xa9, 0x17, 0x84, 0x7c,
-	0x48, 0x6e, 0x6a, 0xb8, 0x12, 0x62, 0x7d, 0x68, 0x5d, 0x7a, 0x69, 0x14, 0x44, 0xa3, 0x7e, 0x83,
-	0x08, 0x0a, 0xc4, 0x13, 0x43, 0x9e, 0x7b, 0x41, 0xd8, 0x6f, 0x6e, 0x18, 0x9b, 0x3d, 0x57, 0x42,
-	0xce, 0xbf, 0x0d, 0x80, 0xbd, 0xc9, 0x38, 0x91, 0x66, 0x6e, 0x40, 0x97, 0x2c, 0x38, 0xf6, 0x4e,
-	0x42, 0x9e, 0x91, 0xad, 0x96, 0xab, 0xa3, 0xd8, 0x26, 0xac, 0xf8, 0xf1, 0x38, 0x09, 0x79, 0xce,
-	0x87, 0x92, 0x0b, 0x4d, 0x37, 0xdc, 0x69, 0x34, 0x7b, 0x03, 0x96, 0x4e, 0x83, 0x28, 0xc8, 0xce,
-	0xf8, 0xf0, 0xde, 0x55, 0xce, 0x85, 0xcb, 0x0d, 0xb7, 0x8a, 0x64, 0x0e, 0xf4, 0x14, 0xc2, 0x8d,
-	0x2f, 0x33, 0xba, 0x90, 0xe1, 0x56, 0x70, 0xec, 0x6d, 0x58, 0xe5, 0x59, 0x1e, 0x8c, 0xbd, 0x9c,
-	0x1f, 0xa3, 0x29, 0xc4, 0xd8, 0x20, 0xc6, 0x59, 0x02, 0xc6, 0xfe, 0x24, 0xc9, 0xe8, 0x9e, 0x96,
-	0x8b, 0x9f, 0x6c, 0x1d, 0xda, 0x49, 0x1a, 0x8f, 0x52, 0x9e, 0x65, 0xfd, 0x16, 0xa5, 0x44, 0x01,
-	0x3b, 0x5f, 0x18, 0x00, 0x07, 0xb1, 0x37, 0x94, 0x0e, 0x98, 0x31, 0x5a, 0xb8, 0x60, 0xca, 0xe8,
-	0x01, 0x00, 0xf9, 0x44, 0xb0, 0x98, 0xc4, 0xa2, 0x61, 0x2a, 0x0a, 0xad, 0xaa, 0x42, 0x3c, 0x3b,
-	0xe6, 0xb9, 0x77, 0x2f, 0x88, 0xc2, 0x78, 0x24, 0xd3, 0x5c, 0xc3, 0xb0, 0x37, 0x61, 0xb9, 0x84,
-	0xf6, 0x8f, 0x1f, 0xee, 0xd1, 0x4d, 0x3b, 0xee, 0x14, 0x76, 0xf6, 0x9a, 0xce, 0x2f, 0x0d, 0x58,
-	0x3a, 0x3a, 0xf3, 0xd2, 0x61, 0x10, 0x8d, 0xf6, 0xd3, 0x78, 0x92, 0x60, 0xd4, 0x73, 0x2f, 0x1d,
-	0xf1, 0x5c, 0x96, 0xaf, 0x84, 0xb0, 0xa8, 0xf7, 0xf6, 0x0e, 0xd0, 0x72, 0x0b, 0x8b, 0x1a, 0xbf,
-	0xc5, 0xcd, 0xd3, 0x2c, 0x3f, 0x88, 0x7d, 0x2f, 0x0f, 0xe2, 0x48, 0x1a, 0x5e, 0x45, 0x52, 0xe1,
-	0x5e, 0x45, 0x3e, 0x65, 0x9e, 0x45, 0x85, 0x4b, 0x10, 0xde, 0x78, 0x12, 0x49, 0x4a, 0x83, 0x28,
-	0x05, 0xec, 0xfc, 0xab, 0x0e, 0x70, 0x74, 0x15, 0xf9, 0x53, 0x39, 0x76, 0xff, 0x82, 0x47, 0x79,
-	0x35, 0xc7, 0x04, 0x0a, 0x85, 0x89, 0x94, 0x4b, 0x94, 0x73, 0x0b, 0x98, 0xdd, 0x86, 0x4e, 0xca,
-	0x7d, 0x1e, 0xe5, 0x48, 0xb4, 0x88, 0x58, 0x22, 0x30, 0x9b, 0xc6, 0x5e, 0x96, 0xf3, 0xb4, 0xe2,
-	0xde, 0x0a, 0x8e, 0x6d, 0x81, 0xad, 0xc3, 0xfb, 0x79, 0x30, 0x94, 0x2e, 0x9e, 0xc1, 0xa3, 0x3c,
-	0xba, 0x84, 0x92, 0xd7, 0x14, 0xf2, 0x74, 0x1c, 0xca, 0xd3, 0x61, 0x92, 0x27, 0xb2, 0x6c, 0x06,
-	0x8f, 0xf2, 0x4e, 0xc2, 0xd8, 0x3f, 0x0f, 0xa2, 0x11, 0x05, 0xa0, 0x4d, 0xae, 0xaa, 0xe0, 0xd8,
-	0x77, 0xc0, 0x9e, 0x44, 0x29, 0xcf, 0xe2, 0xf0, 0x82, 0x0f, 0x29, 0x8e, 0x59, 0xbf, 0xa3, 0xb5,
-	0x1d, 0x3d, 0xc2, 0xee, 0x0c, 0xab, 0x16, 0x21, 0x10, 0x9d, 0x46, 0x46, 0x68, 0x00, 0x70, 0x42,
-	0x86, 0x1c, 0x5f, 0x25, 0xbc, 0xdf, 0x15, 0x79, 0x57, 0x62, 0xd8, 0x3b, 0x70, 0x23, 0xe3, 0x7e,
-	0x1c, 0x0d, 0xb3, 0x7b, 0xfc, 0x2c, 0x88, 0x86, 0x8f, 0xc8, 0x17, 0xfd, 0x1e, 0xb9, 0x78, 0x1e,
-	0x09, 0x33, 0x86, 0x0c, 0xdf, 0xdb, 0x3b, 0x38, 0xbc, 0x8c, 0x78, 0xda, 0x5f, 0x12, 0x19, 0x53,
-	0x41, 0x62, 0xb8, 0xfd, 0x38, 0x3a, 0x0d, 0x03, 0x3f, 0x7f, 0x94, 0x8d, 0xfa, 0xcb, 0xc4, 0xa3,
-	0xa3, 0x30, 0xa4, 0x79, 0x51, 0xd6, 0x2b, 0x22, 0xa4, 0x05, 0xa2, 0x48, 0x06, 0x37, 0xc9, 0xfa,
-	0xb6, 0x96, 0x0c, 0xae, 0x9e, 0x0c, 0x48, 0x5c, 0xd5, 0x93, 0xc1, 0x4d, 0x32, 0xe7, 0xb7, 0x06,
-	0xf4, 0xf4, 0xde, 0xae, 0xbd, 0x3a, 0xc6, 0x82, 0x57, 0xc7, 0xd4, 0x5f, 0x1d, 0xf6, 0x56, 0xf1,
-	0xba, 0x88, 0xd7, 0x82, 0xfc, 0xff, 0x38, 0x8d, 0xb1, 0x0d, 0xbb, 0x44, 0x28, 0x1e, 0x9c, 0x77,
-	0xa1, 0x9b, 0xf2, 0xd0, 0xbb, 0x2a, 0x9e, 0x09, 0xe4, 0x5f, 0x41, 0x7e, 0xb7, 0x44, 0xbb, 0x3a,
-	0x8f, 0xf3, 0x37, 0x13, 0xba, 0x1a, 0x71, 0x26, 0x77, 0x8d, 0xaf, 0x99, 0xbb, 0xe6, 0x82, 0xdc,
-	0xdd, 0x50, 0x26, 0x4d, 0x4e, 0xf6, 0x82, 0x54, 0x96, 0xb3, 0x8e, 0x2a, 0x38, 0x2a, 0xc5, 0xa2,
-	0xa3, 0xb0, 0xdb, 0x6b, 0xa0, 0x56, 0x2a, 0xd3, 0x68, 0xb6, 0x0d, 0x8c, 0x50, 0xbb, 0x5e, 0xee,
-	0x9f, 0x7d, 0x9e, 0xc8, 0xec, 0x69, 0x52, 0x0a, 0xce, 0xa1, 0xb0, 0xd7, 0xa0, 0x91, 0xe5, 0xde,
-	0x88, 0x53, 0xa9, 0x2c, 0xef, 0x74, 0x28, 0xb5, 0x11, 0xe1, 0x0a, 0xbc, 0xe6, 0xfc, 0xf6, 0x0b,
-	0x9c, 0xef, 0xfc, 0xd9, 0x82, 0xa5, 0xca, 0x6b, 0x3c, 0x6f, 0x6a, 0x29, 0x35, 0x9a, 0x0b, 0x34,
-	0x6e, 0x40, 0x7d, 0x12, 0x05, 0x22, 0xd8, 0xcb, 0x3b, 0x3d, 0xa4, 0x7f, 0x1e, 0x05, 0x39, 0x56,
-	0x87, 0x4b, 0x14, 0xcd, 0xa6, 0xfa, 0x8b, 0x12, 0xe2, 0x1d, 0xb8, 0x51, 0x96, 0xe6, 0xde, 0xde,
-	0xc1, 0x41, 0xec, 0x9f, 0x17, 0xbd, 0x7c, 0x1e, 0x89, 0x31, 0x31, 0xb3, 0x50, 0x8b, 0x79, 0x50,
-	0x13, 0x53, 0xcb, 0xff, 0x43, 0xc3, 0xc7, 0x29, 0x82, 0xbc, 0x24, 0x13, 0x4a, 0x1b, 0x2b, 0x1e,
-	0xd4, 0x5c, 0x41, 0x67, 0x6f, 0x40, 0x7d, 0x38, 0x19, 0x27, 0xd2, 0x57, 0xcb, 0xc8, 0x57, 0x3e,
-	0xeb, 0x0f, 0x6a, 0x2e, 0x51, 0x91, 0x2b, 0x8c, 0xbd, 0x61, 0xbf, 0x53, 0x72, 0x95, 0x6f, 0x1f,
-	0x72, 0x21, 0x15, 0xb9, 0xb0, 0x67, 0x50, 0xff, 0x90, 0x5c, 0x65, 0xfb, 0x46, 0x2e, 0xa4, 0xb2,
-	0xf7, 0x00, 0x2e, 0xbc, 0x30, 0x18, 0x8a, 0xc7, 0xa2, 0x4b, 0xbc, 0x6b, 0xc8, 0xfb, 0xb4, 0xc0,
-	0xca, 0xac, 0xd7, 0xf8, 0xee, 0xb5, 0xa1, 0x99, 0x89, 0xf4, 0xff, 0x2e, 0xac, 0x56, 0x62, 0x76,
-	0x10, 0x64, 0xe4, 0x60, 0x41, 0xee, 0x1b, 0x8b, 0x06, 0x2d, 0x75, 0x7e, 0x00, 0x40, 0x9e, 0xb8,
-	0x9f, 0xa6, 0x71, 0xaa, 0x06, 0x3e, 0xa3, 0x18, 0xf8, 0x9c, 0x3b, 0xd0, 0x41, 0x0f, 0x5c, 0x43,
-	0xc6, 0xab, 0x2f, 0x22, 0x27, 0xd0, 0xa3, 0x3b, 0x3f, 0x39, 0x58, 0xc0, 0xc1, 0x76, 0x60, 0x4d,
-	0x4c, 0x5d, 0xa2, 0x08, 0x1e, 0xc7, 0x59, 0x40, 0x9e, 0x10, 0xe5, 0x38, 0x97, 0x86, 0xbd, 0x8c,
-	0xa3, 0xb8, 0xa3, 0x27, 0x07, 0x6a, 0x2e, 0x50, 0xb0, 0xf3, 0x2d, 0xe8, 0xa0, 0x46, 0xa1, 0x6e,
-	0x13, 0x9a, 0x44, 0x50, 0x7e, 0xb0, 0x8b, 0x20, 0x48, 0x83, 0x5c, 0x49, 0x77, 0x7e, 0x6e, 0x40,
-	0x57, 0x34, 0x39, 0x71, 0xf2, 0x65, 0x7b, 0xdc, 0x46, 0xe5, 0xb8, 0xea, 0x12, 0xba, 0xc4, 0x6d,
-	0x00, 0x6a, 0x53, 0x82, 0xa1, 0x5e, 0x26, 0x45, 0x89, 0x75, 0x35, 0x0e, 0x0c, 0x4c, 0x09, 0xcd,
-	0x71, 0xed, 0xaf, 0x4d, 0xe8, 0xc9, 0x90, 0x0a, 0x96, 0xff, 0x51, 0xb1, 0xca, 0x7a, 0xaa, 0xeb,
-	0xf5, 0xf4, 0xa6, 0xaa, 0xa7, 0x46, 0x79, 0x8d, 0x32, 0x8b, 0xca, 0x72, 0xba, 0x2b, 0xcb, 0xa9,
-	0x49, 0x6c, 0x4b, 0xaa, 0x9c, 0x14, 0x97, 0xa8, 0xa6, 0xbb, 0xb2, 0x9a, 0x5a, 0x25, 0x53, 0x91,
-	0x52, 0x45, 0x31, 0xdd, 0x95, 0xc5, 0xd4, 0x2e, 0x99, 0x8a, 0x30, 0xab, 0x5a, 0xba, 0xd7, 0x82,
-	0x06, 0x85, 0xd3, 0xf9, 0x00, 0x6c, 0xdd, 0x35, 0x54, 0x13, 0x6f, 0x4a, 0x62, 0x25, 0x15, 0x34,
-	0x26, 0x57, 0x9e, 0x7d, 0x06, 0x4b, 0x95, 0x56, 0x84, 0x2f, 0x7e, 0x90, 0xed, 0x7a, 0x91, 0xcf,
-	0xc3, 0x62, 0xef, 0xd0, 0x30, 0x5a, 0x92, 0x99, 0xa5, 0x64, 0x29, 0xa2, 0x92, 0x64, 0xda, 0xf6,
-	0x60, 0x55, 0xb6, 0x87, 0xbf, 0x1b, 0xd0, 0xd3, 0x0f, 0xe0, 0x02, 0x72, 0x3f, 0x4d, 0x77, 0xe3,
-	0xa1, 0x88, 0x66, 0xc3, 0x55, 0x20, 0xa6, 0x3e, 0x7e, 0x86, 0x5e, 0x96, 0xc9, 0x0c, 0x2c, 0x60,
-	0x49, 0x3b, 0xf2, 0xe3, 0x44, 0xed, 0x83, 0x05, 0x2c, 0x69, 0x07, 0xfc, 0x82, 0x87, 0xf2, 0x81,
-	0x2a, 0x60, 0xd4, 0xf6, 0x88, 0x67, 0x19, 0xa6, 0x89, 0xe8, 0xab, 0x0a, 0xc4, 0x53, 0xae, 0x77,
-	0xb9, 0xeb, 0x4d, 0x32, 0x2e, 0x67, 0xb6, 0x02, 0x46, 0xb7, 0xe0, 0xde, 0xea, 0xa5, 0xf1, 0x24,
-	0x52, 0x93, 0x9a, 0x86, 0x71, 0x2e, 0x61, 0xf5, 0xf1, 0x24, 0x1d, 0x71, 0x4a, 0x62, 0xb5, 0x06,
-	0xaf, 0x43, 0x3b, 0x88, 0x3c, 0x3f, 0x0f, 0x2e, 0xb8, 0xf4, 0x64, 0x01, 0x63, 0xfe, 0xe6, 0xc1,
-	0x98, 0xcb, 0x51, 0x95, 0xbe, 0x91, 0xff, 0x34, 0x08, 0x39, 0xe5, 0xb5, 0xbc, 0x92, 0x82, 0xa9,
-	0x44, 0xc5, 0x9b, 0x2c, 0x97, 0x5c, 0x01, 0x39, 0xbf, 0x31, 0x61, 0xfd, 0x30, 0xe1, 0xa9, 0x97,
-	0x73, 0xb1, 0x58, 0x1f, 0xf9, 0x67, 0x7c, 0xec, 0x29, 0x13, 0x6e, 0x83, 0x19, 0x27, 0xa4, 0x5c,
-	0xe6, 0xbb, 0x20, 0x1f, 0x26, 0xae, 0x19, 0x27, 0x64, 0x84, 0x97, 0x9d, 0x4b, 0xdf, 0xd2, 0xf7,
-	0xc2, 0x2d, 0x7b, 0x1d, 0xda, 0x43, 0x2f, 0xf7, 0x4e, 0xbc, 0x8c, 0x2b, 0x9f, 0x2a, 0x98, 0x16,
-	0x52, 0xdc, 0xdf, 0xa4, 0x47, 0x05, 0x40, 0x92, 0x48, 0x9b, 0xf4, 0xa6, 0x84, 0x90, 0xfb, 0x34,
-	0x9c, 0x64, 0x67, 0xe4, 0xc6, 0xb6, 0x2b, 0x00, 0xb4, 0xa5, 0xc8, 0xf9, 0xb6, 0x7c, 0x2e, 0x06,
-	0x00, 0xa7, 0x69, 0x3c, 0x16, 0x8d, 0x85, 0x1e, 0xa0, 0xb6, 0xab, 0x61, 0x14, 0xfd, 0x58, 0xac,
-	0x2b, 0x50, 0xd2, 0x05, 0xc6, 0xc9, 0x61, 0xe9, 0xe9, 0xbb, 0x32, 0xed, 0x1f, 0xf1, 0xdc, 0x63,
-	0xeb, 0x9a, 0x3b, 0x00, 0xdd, 0x81, 0x14, 0xe9, 0x8c, 0x17, 0x76, 0x0f, 0xd5, 0x72, 0x2c, 0xad,
-	0xe5, 0x28, 0x0f, 0xd6, 0x29, 0xc5, 0xe9, 0xdb, 0x79, 0x0f, 0xd6, 0x64, 0x44, 0x9e, 0xbe, 0x8b,
-	0x5a, 0x17, 0xc6, 0x42, 0x90, 0x85, 0x7a, 0xe7, 0xaf, 0x06, 0xdc, 0x9c, 0x3a, 0xf6, 0xd2, 0xbf,
-	0x57, 0xbc, 0x0f, 0x75, 0x5c, 0xf8, 0xfa, 0x16, 0x95, 0xe6, 0x5d, 0xd4, 0x31, 0x57, 0xe4, 0x36,
-	0x02, 0xf7, 0xa3, 0x3c, 0xbd, 0x72, 0xe9, 0xc0, 0xfa, 0xa7, 0xd0, 0x29, 0x50, 0x28, 0xf7, 0x9c,
-	0x5f, 0xa9, 0xee, 0x7b, 0xce, 0xaf, 0x70, 0xa2, 0xb8, 0xf0, 0xc2, 0x89, 0x70, 0x8d, 0x7c, 0x60,
-	0x2b, 0x8e, 0x75, 0x05, 0xfd, 0x03, 0xf3, 0xdb, 0x86, 0xf3, 0x63, 0xe8, 0x3f, 0xf0, 0xa2, 0x61,
-	0x28, 0xf3, 0x51, 0x34, 0x05, 0xe9, 0x82, 0x57, 0x35, 0x17, 0x74, 0x51, 0x0a, 0x51, 0xaf, 0xc9,
-	0xc6, 0xdb, 0xd0, 0x39, 0x51, 0xcf, 0xa1, 0x74, 0x7c, 0x89, 0xa0, 0x9c, 0x79, 0x16, 0x66, 0x72,
-	0xad, 0xa4, 0x6f, 0xe7, 0x26, 0xdc, 0xd8, 0xe7, 0xb9, 0xd0, 0xbd, 0x7b, 0x3a, 0x92, 0x9a, 0x9d,
-	0x4d, 0x58, 0xab, 0xa2, 0xa5, 0x73, 0x6d, 0xb0, 0xfc, 0xd3, 0xe2, 0xa9, 0xf1, 0x4f, 0x47, 0xce,
-	0x11, 0xdc, 0x11, 0xd3, 0xd2, 0xe4, 0x04, 0x4d, 0xc0, 0xd6, 0xf7, 0x79, 0x32, 0xf4, 0x72, 0xae,
-	0x2e, 0xb1, 0x03, 0x6b, 0x99, 0xa0, 0xed, 0x9e, 0x8e, 0x8e, 0xe3, 0x71, 0x78, 0x94, 0xa7, 0x41,
-	0xa4, 0x64, 0xcc, 0xa5, 0x39, 0x07, 0x30, 0x58, 0x24, 0x54, 0x1a, 0xd2, 0x87, 0x96, 0xfc, 0xb1,
-	0x46, 0x86, 0x59, 0x81, 0xb3, 0x71, 0x76, 0x46, 0xb0, 0xbe, 0xcf, 0xf3, 0x99, 0x99, 0xa9, 0x6c,
-	0x3b, 0xa8, 0xe3, 0xb3, 0xf2, 0x79, 0x2c, 0x60, 0xf6, 0x0d, 0xe8, 0x9d, 0x06, 0x61, 0xce, 0x53,
-	0xb9, 0x73, 0xcc, 0xe4, 0x7a, 0x85, 0xec, 0xfc, 0xd4, 0x02, 0x7b, 0x5a, 0x4d, 0x11, 0x27, 0x63,
-	0x6e, 0xd7, 0x30, 0x2b, 0x5d, 0x83, 0x41, 0x7d, 0x8c, 0x8d, 0x5d, 0xd6, 0x0c, 0x7e, 0x97, 0x85,
-	0x56, 0x5f, 0x50, 0x68, 0x9b, 0xb0, 0x22, 0xa7, 0xbf, 0x58, 0xed, 0x35, 0x72, 0x81, 0x98, 0x42,
-	0xe3, 0xc0, 0x3c, 0x85, 0xa2, 0x75, 0x43, 0xf4, 0x9b, 0x79, 0x24, 0x6d, 0x1a, 0x6f, 0x7d, 0x8d,
-	0x69, 0x3c, 0x11, 0x04, 0xf1, 0x93, 0x92, 0x74, 0x59, 0x5b, 0x08, 0x9f, 0x43, 0x62, 0x6f, 0xc3,
-	0x6a, 0xc2, 0x23, 0x5c, 0xb4, 0x35, 0xfe, 0x0e, 0xf1, 0xcf, 0x12, 0xf0, 0x9a, 0xf4, 0x54, 0x6a,
-	0xbc, 0x20, 0xae, 0x39, 0x85, 0x76, 0x7e, 0x6f, 0xc0, 0xcd, 0x32, 0x0c, 0xf4, 0x53, 0xd9, 0x0b,
-	0xb6, 0xd3, 0x75, 0x68, 0x67, 0xa9, 0x4f, 0x9c, 0xea, 0xe5, 0x54, 0x30, 0x75, 0xf2, 0x2c, 0x17,
-	0x34, 0xf9, 0xcc, 0x28, 0xf8, 0xc5, 0xb1, 0xe9, 0x43, 0x6b, 0x5c, 0x7d, 0x3e, 0x25, 0xe8, 0xfc,
-	0xc5, 0x80, 0x57, 0xe7, 0x66, 0xe5, 0x7f, 0xf1, 0xb3, 0x2b, 0x14, 0xa1, 0xcb, 0x64, 0x33, 0xbb,
-	0x7e, 0x4b, 0xc0, 0x79, 0xe3, 0x43, 0x58, 0xca, 0x4b, 0xcf, 0x70, 0xf5, 0xb3, 0xeb, 0x2b, 0xd5,
-	0x83, 0x9a, 0xf3, 0xdc, 0x2a, 0xbf, 0x73, 0x0e, 0xaf, 0x54, 0xec, 0xaf, 0x74, 0xae, 0x1d, 0x9a,
-	0xc2, 0x91, 0x97, 0xcb, 0xfe, 0x75, 0x4b, 0x13, 0x2c, 0xa6, 0x5e, 0xa2, 0xba, 0x05, 0x5f, 0xa5,
-	0x10, 0xcd, 0x6a, 0x21, 0x3a, 0xbf, 0x33, 0x61, 0x65, 0x4a, 0x15, 0x5b, 0x06, 0x33, 0x18, 0xca,
-	0x40, 0x9a, 0xc1, 0x70, 0x61, 0x51, 0xe9, 0xc1, 0xb5, 0xa6, 0x82, 0x8b, 0x6d, 0x24, 0xf5, 0xf7,
-	0xbc, 0xdc, 0x93, 0xaf, 0xb4, 0x02, 0x2b, 0x61, 0x6f, 0x4c, 0x85, 0xbd, 0x0f, 0xad, 0x61, 0x96,
-	0xd3, 0x29, 0x51, 0x3b, 0x0a, 0xc4, 0x06, 0x4c, 0xd9, 0x48, 0x3f, 0x00, 0x89, 0xb9, 0xa7, 0x44,
-	0xb0, 0xed, 0x62, 0xf5, 0x6a, 0x5f, 0xeb, 0x13, 0xc9, 0x55, 0x4c, 0x3d, 0x1d, 0xd9, 0x3a, 0x70,
-	0xea, 0xd1, 0x32, 0x0a, 0xaa, 0x19, 0xf5, 0x6c, 0xaa, 0xcd, 0xc9, 0x80, 0xbc, 0x74, 0x3e, 0xbd,
-	0xa5, 0x86, 0x61, 0x91, 0x4a, 0x37, 0xaa, 0x19, 0x51, 0x99, 0x87, 0x7f, 0x65, 0xc0, 0x1d, 0xf5,
-	0x64, 0xce, 0x4f, 0x84, 0xbb, 0xda, 0x13, 0x36, 0x2b, 0x49, 0x3e, 0x65, 0x34, 0x45, 0x7f, 0x1c,
-	0x86, 0x62, 0xfd, 0x31, 0xd5, 0x14, 0xad, 0x30, 0x95, 0xcc, 0xb0, 0xa6, 0x5a, 0xf4, 0x1a, 0x59,
-	0xfb, 0x50, 0xfc, 0x4c, 0x5f, 0x77, 0x05, 0xe0, 0x7c, 0x0a, 0x83, 0x45, 0x76, 0xbd, 0xac, 0x3f,
-	0xb6, 0xce, 0xa1, 0x29, 0xe6, 0x1e, 0xb6, 0x04, 0x9d, 0x87, 0x11, 0xd5, 0xd0, 0x61, 0x62, 0xd7,
-	0x58, 0x1b, 0xea, 0x47, 0x79, 0x9c, 0xd8, 0x06, 0xeb, 0x40, 0xe3, 0x31, 0x0e, 0xbe, 0xb6, 0xc9,
-	0x00, 0x9a, 0xd8, 0x18, 0xc7, 0xdc, 0xb6, 0x10, 0x7d, 0x94, 0x7b, 0x69, 0x6e, 0xd7, 0x11, 0x2d,
-	0x5e, 0x30, 0xbb, 0xc1, 0x96, 0x01, 0x3e, 0x9e, 0xe4, 0xb1, 0x64, 0x6b, 0x22, 0x6d, 0x8f, 0x87,
-	0x3c, 0xe7, 0x76, 0x6b, 0xeb, 0x27, 0x74, 0x64, 0x84, 0x2f, 0x6d, 0x4f, 0xea, 0x22, 0xd8, 0xae,
-	0xb1, 0x16, 0x58, 0x9f, 0xf1, 0x4b, 0xdb, 0x60, 0x5d, 0x68, 0xb9, 0x93, 0x28, 0x0a, 0xa2, 0x91,
-	0xd0, 0x47, 0xaa, 0x87, 0xb6, 0x85, 0x04, 0x34, 0x28, 0xe1, 0x43, 0xbb, 0xce, 0x7a, 0xd0, 0xfe,
-	0x44, 0xfe, 0xa2, 0x6e, 0x37, 0x90, 0x84, 0x6c, 0x78, 0xa6, 0x89, 0x24, 0x52, 0x8e, 0x50, 0x0b,
-	0x21, 0x3a, 0x85, 0x50, 0x7b, 0xeb, 0x10, 0xda, 0x6a, 0xc9, 0x63, 0x2b, 0xd0, 0x95, 0x36, 0x20,
-	0xca, 0xae, 0xe1, 0x85, 0xe8, 0x5d, 0xb6, 0x0d, 0xbc, 0x3c, 0xae, 0x6b, 0xb6, 0x89, 0x5f, 0xb8,
-	0x93, 0xd9, 0x16, 0x39, 0xe4, 0x2a, 0xf2, 0xed, 0x3a, 0x32, 0xd2, 0x6c, 0x6f, 0x0f, 0xb7, 0x1e,
-	0x41, 0x8b, 0x3e, 0x0f, 0x71, 0x64, 0x59, 0x96, 0xf2, 0x24, 0xc6, 0xae, 0xa1, 0x4f, 0x51, 0xbb,
-	0xe0, 0x36, 0xd0, 0x37, 0x74, 0x1d, 0x01, 0x9b, 0x68, 0x82, 0xf0, 0x93, 0x40, 0x58, 0x5b, 0x3f,
-	0x33, 0xa0, 0xad, 0xa6, 0x72, 0x76, 0x03, 0x56, 0x94, 0x93, 0x24, 0x4a, 0x48, 0xdc, 0xe7, 0xb9,
-	0x40, 0xd8, 0x06, 0x29, 0x28, 0x40, 0x13, 0xfd, 0xea, 0xf2, 0x71, 0x7c, 0xc1, 0x25, 0xc6, 0x42,
-	0x95, 0xb8, 0x04, 0x4a, 0xb8, 0x8e, 0x07, 0x10, 0xa6, 0x52, 0xb7, 0x1b, 0xec, 0x16, 0x30, 0x04,
-	0x1f, 0x05, 0x23, 0x4c, 0x27, 0x31, 0x2a, 0x67, 0x76, 0x73, 0xeb, 0x23, 0x68, 0xab, 0x89, 0x54,
-	0xb3, 0x43, 0xa1, 0x0a, 0x3b, 0x04, 0xc2, 0x36, 0x4a, 0xc5, 0x12, 0x63, 0x6e, 0x3d, 0xa5, 0x4d,
-	0x0e, 0x07, 0x3a, 0xcd, 0x33, 0x12, 0x23, 0xd3, 0xeb, 0x3c, 0x48, 0x64, 0xc0, 0x79, 0x12, 0x7a,
-	0x7e, 0x91, 0x60, 0x17, 0x3c, 0xcd, 0x6d, 0x0b, 0xbf, 0x1f, 0x46, 0x3f, 0xe2, 0x3e, 0x66, 0x18,
-	0x86, 0x21, 0xc8, 0x72, 0xbb, 0xb1, 0x75, 0x00, 0xdd, 0xa7, 0xaa, 0xd1, 0x1f, 0x26, 0x78, 0x01,
-	0x65, 0x5c, 0x89, 0xb5, 0x6b, 0xa8, 0x93, 0xb2, 0xb3, 0xc0, 0xda, 0x06, 0x5b, 0x85, 0x25, 0x8c,
-	0x46, 0x89, 0x32, 0xb7, 0x9e, 0x00, 0x9b, 0x6d, 0x51, 0xe8, 0xb4, 0xd2, 0x60, 0xbb, 0x86, 0x96,
-	0x7c, 0xc6, 0x2f, 0xf1, 0x9b, 0x62, 0xf8, 0x70, 0x14, 0xc5, 0x29, 0x27, 0x9a, 0x8a, 0x21, 0xfd,
-	0x14, 0x87, 0x08, 0x6b, 0xeb, 0xe9, 0x54, 0x33, 0x3f, 0x4c, 0xb4, 0x74, 0x27, 0xd8, 0xae, 0x51,
-	0xf2, 0x91, 0x14, 0x81, 0x90, 0x0e, 0x24, 0x31, 0x02, 0x63, 0xa2, 0xa2, 0xdd, 0x90, 0x7b, 0xa9,
-	0x80, 0xad, 0x9d, 0x3f, 0x36, 0xa1, 0x29, 0x66, 0x56, 0xf6, 0x11, 0x74, 0xb5, 0x3f, 0x66, 0x32,
-	0xea, 0xb4, 0xb3, 0x7f, 0x7a, 0x5d, 0xff, 0xbf, 0x19, 0xbc, 0x68, 0x0f, 0x4e, 0x8d, 0x7d, 0x08,
-	0x50, 0xee, 0xa8, 0xec, 0x26, 0x0d, 0x3e, 0xd3, 0x3b, 0xeb, 0x7a, 0x9f, 0x7e, 0xdd, 0x98, 0xf3,
-	0x87, 0x5a, 0xa7, 0xc6, 0xbe, 0x07, 0x4b, 0xb2, 0x07, 0x89, 0xd4, 0x62, 0x03, 0x6d, 0xc3, 0x98,
-	0xb3, 0x7d, 0x5e, 0x2b, 0xec, 0x93, 0x42, 0x98, 0x48, 0x1f, 0xd6, 0x9f, 0xb3, 0xae, 0x08, 0x31,
-	0xaf, 0x2c, 0x5c, 0x64, 0x9c, 0x1a, 0xdb, 0x87, 0xae, 0x58, 0x37, 0x44, 0x67, 0xbd, 0x8d, 0xbc,
-	0x8b, 0xf6, 0x8f, 0x6b, 0x0d, 0xda, 0x85, 0x9e, 0xbe, 0x21, 0x30, 0xf2, 0xe4, 0x9c, 0x55, 0x42,
-	0x08, 0x99, 0xb7, 0x4c, 0x38, 0x35, 0xe6, 0xc1, 0xad, 0xf9, 0x73, 0x3e, 0x7b, 0xbd, 0xfc, 0x19,
-	0x76, 0xc1, 0x62, 0xb1, 0xee, 0x5c, 0xc7, 0x52, 0xa8, 0xf8, 0x01, 0xf4, 0x0b, 0xe5, 0x45, 0x5a,
-	0xcb, 0xac, 0x18, 0x48, 0xd3, 0x16, 0xac, 0x06, 0xeb, 0xaf, 0x2d, 0xa4, 0x17, 0xe2, 0x8f, 0x61,
-	0xb5, 0x64, 0x88, 0x85, 0xfb, 0xd8, 0x9d, 0x99, 0x73, 0x15, 0xb7, 0x0e, 0x16, 0x91, 0x0b, 0xa9,
-	0x3f, 0x2c, 0x97, 0xdb, 0xaa, 0xe4, 0xd7, 0xf5, 0xd8, 0xce, 0x97, 0xee, 0x5c, 0xc7, 0xa2, 0x34,
-	0xdc, 0xeb, 0x7f, 0xf1, 0xd5, 0xc0, 0xf8, 0xf2, 0xab, 0x81, 0xf1, 0xcf, 0xaf, 0x06, 0xc6, 0x2f,
-	0x9e, 0x0f, 0x6a, 0x5f, 0x3e, 0x1f, 0xd4, 0xfe, 0xf1, 0x7c, 0x50, 0x3b, 0x69, 0xd2, 0xbf, 0x2b,
-	0x7c, 0xf3, 0x3f, 0x01, 0x00, 0x00, 0xff, 0xff, 0x83, 0xfc, 0xb2, 0x14, 0xc0, 0x20, 0x00, 0x00,
+	// 2966 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x1a, 0x4d, 0x8f, 0x1c, 0x47,
+	0x75, 0xba, 0xe7, 0xfb, 0xcd, 0x7e, 0xb4, 0xcb, 0x6b, 0xd3, 0xd9, 0xd8, 0x13, 0xa7, 0x1d, 0x85,
+	0xcd, 0x02, 0x56, 0xb2, 0x04, 0x05, 0x45, 0x82, 0x24, 0xde, 0x75, 0x6c, 0x87, 0x71, 0xd6, 0xee,
+	0xdd, 0x98, 0x13, 0x12, 0xbd, 0x33, 0xb5, 0xb3, 0xcd, 0xf6, 0x74, 0xb7, 0xbb, 0x6b, 0x76, 0xb5,
+	0x27, 0x2e, 0x08, 0x8e, 0x70, 0x01, 0x29, 0x88, 0x0b, 0x48, 0x48, 0x5c, 0xe0, 0x80, 0xc4, 0x95,
+	0x23, 0x70, 0x8c, 0x38, 0x71, 0x44, 0xc9, 0xff, 0x40, 0xe8, 0xbd, 0xaa, 0xea, 0xae, 0x9e, 0x8f,
+	0x75, 0x8c, 0xc4, 0xad, 0xdf, 0x47, 0xbd, 0x7a, 0xf5, 0xbe, 0xea, 0xbd, 0x9a, 0x81, 0xb5, 0xd1,
+	0xe4, 0x3c, 0xc9, 0x4e, 0x79, 0x76, 0x27, 0xcd, 0x12, 0x91, 0x30, 0x3b, 0x3d, 0xf2, 0xb6, 0x80,
+	0x3d, 0x99, 0xf2, 0xec, 0xe2, 0x40, 0x04, 0x62, 0x9a, 0xfb, 0xfc, 0xd9, 0x94, 0xe7, 0x82, 0x31,
+	0x68, 0xc4, 0xc1, 0x84, 0xbb, 0xd6, 0x2d, 0x6b, 0xab, 0xeb, 0xd3, 0xb7, 0x97, 0xc2, 0xc6, 0x6e,
+	0x32, 0x99, 0x24, 0xf1, 0xf7, 0x49, 0x86, 0xcf, 0xf3, 0x34, 0x89, 0x73, 0xce, 0xae, 0x43, 0x2b,
+	0xe3, 0xf9, 0x34, 0x12, 0xc4, 0xdd, 0xf1, 0x15, 0xc4, 0x1c, 0xa8, 0x4f, 0xf2, 0xb1, 0x6b, 0x93,
+	0x08, 0xfc, 0x44, 0xce, 0x3c, 0x99, 0x66, 0x43, 0xee, 0xd6, 0x09, 0xa9, 0x20, 0xc4, 0x4b, 0xbd,
+	0xdc, 0x86, 0xc4, 0x4b, 0xc8, 0xfb, 0x93, 0x05, 0x57, 0x2b, 0xca, 0xbd, 0xf0, 0x8e, 0x6f, 0xc3,
+	0x8a, 0xdc, 0x43, 0x4a, 0xa0, 0x7d, 0x7b, 0x3b, 0xce, 0x9d, 0xf4, 0xe8, 0xce, 0x81, 0x81, 0xf7,
+	0x2b, 0x5c, 0xec, 0x1d, 0x58, 0xcd, 0xa7, 0x47, 0x87, 0x41, 0x7e, 0xaa, 0x96, 0x35, 0x6e, 0xd5,
+	0xb7, 0x7a, 0x3b, 0x57, 0x68, 0x99, 0x49, 0xf0, 0xab, 0x7c, 0xde, 0xef, 0x2d, 0xe8, 0xed, 0x9e,
+	0xf0, 0xa1, 0x82, 0x51, 0xd1, 0x34, 0xc8, 0x73, 0x3e, 0xd2, 0x8a, 0x4a, 0x88, 0x6d, 0x40, 0x53,
+	0x24, 0x22, 0x88, 0x48, 0xd5, 0xa6, 0x2f, 0x01, 0xd6, 0x07, 0xc8, 0xa7, 0xc3, 0x21, 0xcf, 0xf3,
+	0xe3, 0x69, 0x44, 0xaa, 0x36, 0x7d, 0x03, 0x83, 0xd2, 0x8e, 0x83, 0x30, 0xe2, 0x23, 0x32, 0x53,
+	0xd3, 0x57, 0x10, 0x73, 0xa1, 0x7d, 0x1e, 0x64, 0x71, 0x18, 0x8f, 0xdd, 0x26, 0x11, 0x34, 0x88,
+	0x2b, 0x46, 0x5c, 0x04, 0x61, 0xe4, 0xb6, 0x6e, 0x59, 0x5b, 0x2b, 0xbe, 0x82, 0xbc, 0xff, 0x58,
+	0x00, 0x7b, 0xd3, 0x49, 0xaa, 0xd4, 0xbc, 0x05, 0x3d, 0xd2, 0xe0, 0x30, 0x38, 0x8a, 0x78, 0x4e,
+	0xba, 0xd6, 0x7d, 0x13, 0xc5, 0xb6, 0x60, 0x7d, 0x98, 0x4c, 0xd2, 0x88, 0x0b, 0x3e, 0x52, 0x5c,
+	0xa8, 0xba, 0xe5, 0xcf, 0xa2, 0xd9, 0x6b, 0xb0, 0x7a, 0x1c, 0xc6, 0x61, 0x7e, 0xc2, 0x47, 0x77,
+	0x2f, 0x04, 0x97, 0x26, 0xb7, 0xfc, 0x2a, 0x92, 0x79, 0xb0, 0xa2, 0x11, 0x7e, 0x72, 0x9e, 0xd3,
+	0x81, 0x2c, 0xbf, 0x82, 0x63, 0x5f, 0x87, 0x2b, 0x3c, 0x17, 0xe1, 0x24, 0x10, 0xfc, 0x10, 0x55,
+	0x21, 0xc6, 0x26, 0x31, 0xce, 0x13, 0xd0, 0xf7, 0x47, 0x69, 0x4e, 0xe7, 0xac, 0xfb, 0xf8, 0xc9,
+	0x36, 0xa1, 0x93, 0x66, 0xc9, 0x38, 0xe3, 0x79, 0xee, 0xb6, 0x29, 0x24, 0x0a, 0xd8, 0xfb, 0x99,
+	0x0d, 0x30, 0x48, 0x82, 0x91, 0x32, 0xc0, 0x9c, 0xd2, 0xd2, 0x04, 0x33, 0x4a, 0xf7, 0x01, 0xc8,
+	0x26, 0x92, 0xc5, 0x26, 0x16, 0x03, 0x53, 0xd9, 0xb0, 0x5e, 0xdd, 0x10, 0xd7, 0x4e, 0xb8, 0x08,
+	0xee, 0x86, 0x71, 0x94, 0x8c, 0x55, 0x98, 0x1b, 0x18, 0xf6, 0x3a, 0xac, 0x95, 0xd0, 0xfd, 0xc3,
+	0x87, 0x7b, 0x74, 0xd2, 0xae, 0x3f, 0x83, 0x5d, 0x70, 0xcc, 0xaf, 0x41, 0x4b, 0x48, 0x8f, 0xb4,
+	0x29, 0x4a, 0xaf, 0x62, 0x94, 0x92, 0x33, 0xca, 0x03, 0xfa, 0x8a, 0x05, 0x97, 0x73, 0x11, 0xb8,
+	0x1d, 0xb9, 0x9c, 0x8b, 0xc0, 0xfb, 0xa3, 0x05, 0xeb, 0x33, 0xdc, 0x14, 0x9e, 0x88, 0x52, 0xe9,
+	0x2f, 0x81, 0x79, 0x23, 0xd9, 0xcf, 0x37, 0x52, 0xfd, 0x52, 0x23, 0x35, 0x66, 0x8c, 0xa4, 0x0e,
+	0xd7, 0x2c, 0x0f, 0xa7, 0xf4, 0x6d, 0x95, 0xfa, 0xfe, 0xd2, 0x82, 0xd5, 0x83, 0x93, 0x20, 0x1b,
+	0x85, 0xf1, 0xf8, 0x7e, 0x96, 0x4c, 0x53, 0x0c, 0x72, 0x11, 0x64, 0x63, 0x2e, 0x94, 0xba, 0x0a,
+	0xc2, 0x1a, 0xb6, 0xb7, 0x37, 0x40, 0x35, 0xeb, 0x58, 0xc3, 0xf0, 0x5b, 0x9e, 0x21, 0xcb, 0xc5,
+	0x20, 0x19, 0x06, 0x22, 0x4c, 0x62, 0xe5, 0xa7, 0x2a, 0x12, 0x25, 0xe6, 0x17, 0xf1, 0x90, 0x12,
+	0x0d, 0xd7, 0x2a, 0x08, 0x75, 0x9f, 0xc6, 0x8a, 0xd2, 0x24, 0x4a, 0x01, 0x7b, 0x9f, 0x36, 0x01,
+	0x0e, 0x2e, 0xe2, 0xe1, 0x4c, 0x4a, 0xdd, 0x3b, 0xe3, 0xb1, 0xa8, 0xa6, 0x94, 0x44, 0xa1, 0x30,
+	0x99, 0x61, 0xa9, 0xb6, 0x64, 0x01, 0xb3, 0x1b, 0xd0, 0xcd, 0xf8, 0x90, 0xc7, 0x02, 0x89, 0xd2,
+	0x86, 0x25, 0x02, 0x93, 0x67, 0x12, 0xe4, 0x82, 0x67, 0x95, 0x68, 0xaa, 0xe0, 0xd8, 0x36, 0x38,
+	0x26, 0x7c, 0x5f, 0x84, 0x23, 0x15, 0x51, 0x73, 0x78, 0x94, 0x47, 0x87, 0xd0, 0xf2, 0x5a, 0x52,
+	0x9e, 0x89, 0x43, 0x79, 0x26, 0x4c, 0xf2, 0x64, 0x52, 0xcd, 0xe1, 0x51, 0xde, 0x51, 0x94, 0x0c,
+	0x4f, 0xc3, 0x78, 0x4c, 0x0e, 0xe8, 0x90, 0xa9, 0x2a, 0x38, 0xf6, 0x1d, 0x70, 0xa6, 0x71, 0xc6,
+	0xf3, 0x24, 0x3a, 0xe3, 0x23, 0xf2, 0x63, 0xee, 0x76, 0x8d, 0x2a, 0x6b, 0x7a, 0xd8, 0x9f, 0x63,
+	0x35, 0x3c, 0x04, 0xb2, 0xb0, 0x4a, 0x08, 0xa3, 0xef, 0x88, 0x14, 0x39, 0xbc, 0x48, 0xb9, 0xdb,
+	0x93, 0x69, 0x56, 0x62, 0xd8, 0x9b, 0x70, 0x35, 0xe7, 0xc3, 0x24, 0x1e, 0xe5, 0x77, 0xf9, 0x49,
+	0x18, 0x8f, 0x1e, 0x91, 0x2d, 0xdc, 0x15, 0x32, 0xf1, 0x22, 0x12, 0x46, 0x0c, 0x29, 0xbe, 0xb7,
+	0x37, 0xd8, 0x3f, 0x8f, 0x79, 0xe6, 0xae, 0xca, 0x88, 0xa9, 0x20, 0xd1, 0xdd, 0xc3, 0x24, 0x3e,
+	0x8e, 0xc2, 0xa1, 0x78, 0x94, 0x8f, 0xdd, 0x35, 0xe2, 0x31, 0x51, 0xe8, 0x52, 0x51, 0x54, 0xb1,
+	0x75, 0xe9, 0xd2, 0x02, 0x51, 0x04, 0x83, 0x9f, 0xe6, 0xae, 0x63, 0x04, 0x83, 0x6f, 0x06, 0x03,
+	0x12, 0xaf, 0x98, 0xc1, 0xe0, 0xcb, 0x60, 0x38, 0xe1, 0x41, 0x26, 0x8e, 0x78, 0x20, 0x06, 0xc1,
+	0xd8, 0x65, 0xc4, 0x50, 0xc1, 0x79, 0xbf, 0xb1, 0x60, 0xc5, 0xbc, 0xee, 0x8c, 0x8b, 0xd8, 0x5a,
+	0x72, 0x11, 0xdb, 0xe6, 0x45, 0xcc, 0xde, 0x28, 0x2e, 0x5c, 0x79, 0x81, 0x92, 0x8f, 0x1e, 0x67,
+	0x09, 0xde, 0x4c, 0x3e, 0x11, 0x8a, 0x3b, 0xf8, 0x2d, 0xe8, 0x65, 0x3c, 0x0a, 0x2e, 0x8a, 0x9b,
+	0x13, 0xf9, 0xd7, 0x91, 0xdf, 0x2f, 0xd1, 0xbe, 0xc9, 0xe3, 0xfd, 0xdd, 0x86, 0x9e, 0x41, 0x9c,
+	0x8b, 0x6f, 0xeb, 0x4b, 0xc6, 0xb7, 0xbd, 0x24, 0xbe, 0x6f, 0x69, 0x95, 0xa6, 0x47, 0x7b, 0x61,
+	0xa6, 0x52, 0xde, 0x44, 0x15, 0x1c, 0x95, 0x84, 0x32, 0x51, 0x78, 0x01, 0x1a, 0xa0, 0x91, 0x4e,
+	0xb3, 0x68, 0x76, 0x07, 0x18, 0xa1, 0x76, 0x03, 0x31, 0x3c, 0xf9, 0x24, 0x55, 0x11, 0xd6, 0xa2,
+	0x30, 0x5d, 0x40, 0x61, 0xaf, 0x40, 0x33, 0x17, 0xc1, 0x98, 0x53, 0x3a, 0xad, 0xed, 0x74, 0x29,
+	0xfc, 0x11, 0xe1, 0x4b, 0xbc, 0x61, 0xfc, 0xce, 0x73, 0x8c, 0xef, 0xfd, 0xb9, 0x0e, 0xab, 0x95,
+	0x06, 0x65, 0x51, 0x23, 0x57, 0xee, 0x68, 0x2f, 0xd9, 0xf1, 0x16, 0x34, 0xa6, 0x71, 0x28, 0x9d,
+	0xbd, 0xb6, 0xb3, 0x82, 0xf4, 0x4f, 0xe2, 0x50, 0x60, 0x06, 0xf9, 0x44, 0x31, 0x74, 0x6a, 0x3c,
+	0x2f, 0x20, 0xde, 0x84, 0xab, 0x65, 0xfa, 0xee, 0xed, 0x0d, 0x06, 0xc9, 0xf0, 0xb4, 0xb8, 0xde,
+	0x16, 0x91, 0x18, 0x93, 0x6d, 0x1c, 0x95, 0xa1, 0x07, 0x35, 0xd9, 0xc8, 0x7d, 0x15, 0x9a, 0x43,
+	0x6c, 0xac, 0xdc, 0x76, 0x19, 0x50, 0x46, 0xa7, 0xf5, 0xa0, 0xe6, 0x4b, 0x3a, 0x7b, 0x0d, 0x1a,
+	0xa3, 0xe9, 0x24, 0x55, 0xb6, 0x5a, 0x43, 0xbe, 0xb2, 0xd3, 0x79, 0x50, 0xf3, 0x89, 0x8a, 0x5c,
+	0x51, 0x12, 0x8c, 0xdc, 0x6e, 0xc9, 0x55, 0xde, 0x7f, 0xc8, 0x85, 0x54, 0xe4, 0xc2, 0xba, 0xe2,
+	0x42, 0xc9, 0x55, 0x96, 0x78, 0xe4, 0x42, 0x2a, 0x7b, 0x1b, 0xe0, 0x2c, 0x88, 0xc2, 0x91, 0xbc,
+	0x50, 0x7a, 0xc4, 0xbb, 0x81, 0xbc, 0x4f, 0x0b, 0xac, 0x8a, 0x7a, 0x83, 0xef, 0x6e, 0x07, 0x5a,
+	0xb9, 0x0c, 0xff, 0xef, 0xc2, 0x95, 0x8a, 0xcf, 0x06, 0x61, 0x4e, 0x06, 0x96, 0x64, 0xd7, 0x5a,
+	0xd6, 0x7b, 0xea, 0xf5, 0x7d, 0x00, 0xb2, 0xc4, 0xbd, 0x2c, 0x4b, 0x32, 0xdd, 0x03, 0x5b, 0x45,
+	0x0f, 0xec, 0xdd, 0x84, 0x2e, 0x5a, 0xe0, 0x12, 0x32, 0x1e, 0x7d, 0x19, 0x39, 0x85, 0x15, 0x3a,
+	0xf3, 0x93, 0xc1, 0x12, 0x0e, 0xb6, 0x03, 0x1b, 0xb2, 0x11, 0x95, 0x49, 0xf0, 0x38, 0xc9, 0x43,
+	0xb2, 0x84, 0x4c, 0xc7, 0x85, 0x34, 0xac, 0x77, 0x1c, 0xc5, 0x1d, 0x3c, 0x19, 0xe8, 0x56, 0x49,
+	0xc3, 0xde, 0xb7, 0xa0, 0x8b, 0x3b, 0xca, 0xed, 0xb6, 0xa0, 0x45, 0x04, 0x6d, 0x07, 0xa7, 0x70,
+	0x82, 0x52, 0xc8, 0x57, 0x74, 0xef, 0xe7, 0x16, 0xf4, 0x64, 0x91, 0x93, 0x2b, 0x5f, 0xb4, 0xc6,
+	0xdd, 0xaa, 0x2c, 0xd7, 0x55, 0xc2, 0x94, 0x78, 0x07, 0x80, 0xca, 0x94, 0x64, 0x68, 0x94, 0x41,
+	0x51, 0x62, 0x7d, 0x83, 0x03, 0x1d, 0x53, 0x42, 0x0b, 0x4c, 0xfb, 0xa9, 0x0d, 0x2b, 0xca, 0xa5,
+	0x92, 0xe5, 0xff, 0x94, 0xac, 0x2a, 0x9f, 0x1a, 0x66, 0x3e, 0xbd, 0xae, 0xf3, 0xa9, 0x59, 0x1e,
+	0xa3, 0x8c, 0xa2, 0x32, 0x9d, 0x6e, 0xab, 0x74, 0x6a, 0x11, 0xdb, 0xaa, 0x4e, 0x27, 0xcd, 0x45,
+	0x44, 0x64, 0xa2, 0x6c, 0x6a, 0x97, 0x4c, 0x45, 0x48, 0x15, 0xc9, 0x74, 0x5b, 0x25, 0x53, 0xa7,
+	0x64, 0x2a, 0xdc, 0xac, 0x73, 0xe9, 0x6e, 0x1b, 0x9a, 0xe4, 0x4e, 0xef, 0x5d, 0x70, 0x4c, 0xd3,
+	0x50, 0x4e, 0xbc, 0xae, 0x88, 0x95, 0x50, 0x30, 0x98, 0x7c, 0xb5, 0xf6, 0x19, 0xac, 0x56, 0x4a,
+	0x11, 0x76, 0x05, 0x61, 0xbe, 0x1b, 0xc4, 0x43, 0x1e, 0x15, 0xa3, 0x98, 0x81, 0x31, 0x82, 0xcc,
+	0x2e, 0x25, 0x2b, 0x11, 0x95, 0x20, 0x33, 0x06, 0xaa, 0x7a, 0x65, 0xa0, 0xfa, 0xa7, 0x05, 0x2b,
+	0xe6, 0x02, 0x9c, 0xc9, 0xee, 0x65, 0xd9, 0x6e, 0x32, 0x92, 0xde, 0x6c, 0xfa, 0x1a, 0xc4, 0xd0,
+	0xc7, 0xcf, 0x28, 0xc8, 0x73, 0x15, 0x81, 0x05, 0xac, 0x68, 0x07, 0xc3, 0x24, 0xd5, 0x23, 0x72,
+	0x01, 0x2b, 0xda, 0x80, 0x9f, 0xf1, 0x48, 0x37, 0xce, 0x1a, 0xc6, 0xdd, 0x1e, 0xf1, 0x3c, 0xc7,
+	0x30, 0x91, 0x75, 0x55, 0x83, 0xb8, 0xca, 0x0f, 0xce, 0x77, 0x83, 0x69, 0xce, 0x55, 0x5f, 0x57,
+	0xc0, 0x68, 0x16, 0x1c, 0xe5, 0x83, 0x2c, 0x99, 0xc6, 0xba, 0x9b, 0x33, 0x30, 0xde, 0x39, 0x5c,
+	0x79, 0x3c, 0xcd, 0xc6, 0x9c, 0x82, 0x58, 0xbf, 0x0c, 0x6c, 0x42, 0x27, 0x8c, 0x83, 0xa1, 0x08,
+	0xcf, 0xb8, 0xb2, 0x64, 0x01, 0x63, 0xfc, 0x8a, 0x70, 0xc2, 0x55, 0x3b, 0x4b, 0xdf, 0xc8, 0x7f,
+	0x1c, 0x46, 0x9c, 0xe2, 0x5a, 0x1d, 0x49, 0xc3, 0x94, 0xa2, 0xf2, 0x4e, 0x56, 0x73, 0xbf, 0x84,
+	0xbc, 0x5f, 0xdb, 0xb0, 0xb9, 0x9f, 0xf2, 0x2c, 0x10, 0x5c, 0xbe, 0x35, 0x1c, 0x0c, 0x4f, 0xf8,
+	0x24, 0xd0, 0x2a, 0xdc, 0x00, 0x3b, 0x49, 0x5d, 0xab, 0x8c, 0x77, 0x49, 0xde, 0x4f, 0x7d, 0x3b,
+	0x49, 0x49, 0x89, 0x20, 0x3f, 0x55, 0xb6, 0xa5, 0xef, 0xa5, 0x0f, 0x0f, 0x9b, 0xd0, 0x19, 0x05,
+	0x22, 0x38, 0x0a, 0x72, 0xae, 0x6d, 0xaa, 0xe1, 0x72, 0x08, 0x6a, 0x9a, 0x43, 0x10, 0x4a, 0xa2,
+	0xdd, 0x94, 0x35, 0x15, 0x84, 0xdc, 0xc7, 0xd1, 0x34, 0x3f, 0x21, 0x33, 0x76, 0x7c, 0x09, 0xa0,
+	0x2e, 0x45, 0xcc, 0x77, 0xd4, 0x75, 0xd1, 0x07, 0x38, 0xce, 0x92, 0x89, 0x2c, 0x2c, 0x74, 0x01,
+	0x75, 0x7c, 0x03, 0xa3, 0xe9, 0x87, 0x72, 0xa4, 0x81, 0x92, 0x2e, 0x31, 0x9e, 0x80, 0xd5, 0xa7,
+	0x6f, 0xa9, 0xb0, 0x7f, 0xc4, 0x45, 0xc0, 0x36, 0x0d, 0x73, 0x80, 0x1c, 0xfe, 0xf2, 0x53, 0x65,
+	0x8c, 0xe7, 0x56, 0x0f, 0x5d, 0x72, 0xea, 0x46, 0xc9, 0xd1, 0x16, 0x6c, 0x50, 0x88, 0xd3, 0xb7,
+	0xf7, 0x36, 0x6c, 0x28, 0x8f, 0x3c, 0x7d, 0x0b, 0x77, 0x5d, 0xea, 0x0b, 0x49, 0x96, 0xdb, 0x7b,
+	0x7f, 0xb3, 0xe0, 0xda, 0xcc, 0xb2, 0x17, 0x7e, 0xc2, 0x79, 0x07, 0x1a, 0x38, 0x03, 0xbb, 0x75,
+	0x4a, 0xcd, 0xdb, 0xb8, 0xc7, 0x42, 0x91, 0x77, 0x10, 0xb8, 0x17, 0x8b, 0xec, 0xc2, 0xa7, 0x05,
+	0x9b, 0x1f, 0x41, 0xb7, 0x40, 0xa1, 0xdc, 0x53, 0x7e, 0xa1, 0xab, 0xef, 0x29, 0xbf, 0xc0, 0x8e,
+	0xe2, 0x2c, 0x88, 0xa6, 0xd2, 0x34, 0xea, 0x82, 0xad, 0x18, 0xd6, 0x97, 0xf4, 0x77, 0xed, 0x6f,
+	0x5b, 0xde, 0x5f, 0x2c, 0x70, 0x1f, 0x04, 0xf1, 0x28, 0x52, 0x01, 0x29, 0xab, 0x82, 0xb2, 0xc1,
+	0xcb, 0x86, 0x0d, 0x7a, 0x28, 0x86, 0xa8, 0x97, 0x84, 0xe3, 0x0d, 0xe8, 0x1e, 0xe9, 0xfb, 0x50,
+	0x59, 0xbe, 0x44, 0xe0, 0x8a, 0xfc, 0x59, 0x94, 0xab, 0xd9, 0x93, 0xbe, 0xe5, 0x8c, 0x4b, 0x43,
+	0xbe, 0x9c, 0x3b, 0x15, 0x84, 0xc1, 0xc2, 0x71, 0x9c, 0xc4, 0x6a, 0x8f, 0xaf, 0x02, 0x48, 0x33,
+	0x30, 0xde, 0x35, 0xb8, 0x7a, 0x9f, 0x0b, 0xa9, 0xf3, 0xee, 0xf1, 0x58, 0x69, 0xec, 0x6d, 0xc1,
+	0x46, 0x15, 0xad, 0xbc, 0xe2, 0x40, 0x7d, 0x78, 0x5c, 0xdc, 0x51, 0xc3, 0xe3, 0xb1, 0x77, 0x00,
+	0x37, 0x65, 0x9b, 0x35, 0x3d, 0x42, 0xd5, 0xb1, 0x66, 0x7e, 0x92, 0x8e, 0x02, 0xc1, 0xf5, 0xe1,
+	0x77, 0x60, 0x23, 0x97, 0xb4, 0xdd, 0xe3, 0xf1, 0x61, 0x32, 0x89, 0x0e, 0x44, 0x16, 0xc6, 0x5a,
+	0xc6, 0x42, 0x9a, 0x37, 0x80, 0xfe, 0x32, 0xa1, 0x4a, 0x11, 0x17, 0xda, 0xea, 0xe1, 0x4b, 0xc5,
+	0x87, 0x06, 0xe7, 0x03, 0xc4, 0x1b, 0xc3, 0xe6, 0x7d, 0x2e, 0xe6, 0x9a, 0xad, 0xb2, 0x5e, 0xe1,
+	0x1e, 0x1f, 0x97, 0xf7, 0x6a, 0x01, 0xb3, 0x6f, 0xe0, 0x2b, 0x54, 0x24, 0x78, 0x26, 0x97, 0xcc,
+	0x27, 0x49, 0x85, 0xec, 0xfd, 0xa4, 0x0e, 0xce, 0xec, 0x36, 0x85, 0x7f, 0xad, 0x85, 0xe5, 0xc6,
+	0xae, 0x94, 0x1b, 0x06, 0x8d, 0x09, 0xde, 0x08, 0x2a, 0xd9, 0xf0, 0xbb, 0xcc, 0xd0, 0xc6, 0x92,
+	0x0c, 0xdd, 0x82, 0x75, 0xd5, 0x36, 0x26, 0x7a, 0x20, 0x52, 0x93, 0xc7, 0x0c, 0x1a, 0x3b, 0xed,
+	0x19, 0x14, 0xcd, 0x29, 0xb2, 0x50, 0x2d, 0x22, 0x19, 0x6d, 0x7c, 0xfb, 0x4b, 0xb4, 0xf1, 0xa9,
+	0x24, 0xc8, 0xe7, 0x39, 0x65, 0xb2, 0x8e, 0x14, 0xbe, 0x80, 0x84, 0xef, 0x77, 0x29, 0x8f, 0x71,
+	0x8a, 0x37, 0xf8, 0xbb, 0xc4, 0x3f, 0x4f, 0xc0, 0x63, 0xd2, 0x1d, 0x6b, 0xf0, 0x82, 0x3c, 0xe6,
+	0x0c, 0xda, 0xfb, 0x9d, 0x05, 0xd7, 0x4a, 0x37, 0xd0, 0xdb, 0xd5, 0x73, 0xc6, 0xda, 0x4d, 0xe8,
+	0xe4, 0xd9, 0x90, 0x38, 0xf5, 0x95, 0xab, 0x61, 0xa4, 0x8d, 0x72, 0x21, 0x69, 0xea, 0x7e, 0xd2,
+	0xf0, 0xf3, 0x7d, 0xe3, 0x42, 0x7b, 0x52, 0xbd, 0x77, 0x15, 0xe8, 0xfd, 0xd5, 0x82, 0x97, 0x17,
+	0x46, 0xe5, 0xff, 0xf0, 0x84, 0x0d, 0x85, 0xeb, 0x72, 0x55, 0x05, 0x2f, 0x1f, 0x2f, 0xb0, 0x51,
+	0x79, 0x0f, 0x56, 0x45, 0x69, 0x19, 0xae, 0x9f, 0xb0, 0x5f, 0xaa, 0x2e, 0x34, 0x8c, 0xe7, 0x57,
+	0xf9, 0xbd, 0x53, 0x78, 0xa9, 0xa2, 0x7f, 0xa5, 0xe2, 0xed, 0x50, 0xfb, 0x8e, 0xbc, 0x5c, 0xd5,
+	0xbd, 0xeb, 0x86, 0x60, 0xd9, 0x2e, 0x13, 0xd5, 0x2f, 0xf8, 0x2a, 0x89, 0x68, 0x57, 0x13, 0xd1,
+	0xfb, 0xad, 0x0d, 0xeb, 0x33, 0x5b, 0xb1, 0x35, 0xb0, 0xc3, 0x91, 0x72, 0xa4, 0x1d, 0x8e, 0x96,
+	0x26, 0x95, 0xe9, 0xdc, 0xfa, 0x8c, 0x73, 0xb1, 0x8c, 0x64, 0xc3, 0xbd, 0x40, 0x04, 0xea, 0x7a,
+	0xd7, 0x60, 0xc5, 0xed, 0xcd, 0x19, 0xb7, 0xbb, 0xd0, 0x1e, 0xe5, 0x82, 0x56, 0xc9, 0xdc, 0xd1,
+	0x20, 0x16, 0x6e, 0x8a, 0x46, 0x7a, 0x5d, 0x92, 0x0d, 0x53, 0x89, 0x60, 0x77, 0x8a, 0x99, 0xad,
+	0x73, 0xa9, 0x4d, 0x14, 0x57, 0xd1, 0x2e, 0x75, 0x55, 0xe9, 0x08, 0x27, 0x95, 0x88, 0x82, 0x6a,
+	0x44, 0x3d, 0x9b, 0x29, 0x73, 0xca, 0x21, 0x2f, 0x1c, 0x4f, 0x6f, 0xe8, 0x2e, 0xba, 0x5e, 0x3e,
+	0x17, 0xcf, 0x4a, 0x55, 0x8d, 0xf4, 0xaf, 0x2c, 0xb8, 0xa9, 0xef, 0xda, 0xc5, 0x81, 0x70, 0xdb,
+	0xb8, 0xfa, 0xe6, 0x25, 0xa9, 0x2b, 0x90, 0xda, 0xef, 0x0f, 0xa2, 0x88, 0x56, 0xba, 0xb6, 0x6e,
+	0xbf, 0x35, 0xa6, 0x12, 0x19, 0xf5, 0x99, 0x12, 0xbd, 0x41, 0xda, 0x3e, 0x94, 0x3f, 0x79, 0x34,
+	0x7c, 0x09, 0x78, 0x1f, 0x41, 0x7f, 0x99, 0x5e, 0x2f, 0x6a, 0x8f, 0xed, 0x53, 0x68, 0xc9, 0x86,
+	0x89, 0xad, 0x42, 0xf7, 0x61, 0x4c, 0x39, 0xb4, 0x9f, 0x3a, 0x35, 0xd6, 0x81, 0xc6, 0x81, 0x48,
+	0x52, 0xc7, 0x62, 0x5d, 0x68, 0x3e, 0xc6, 0x8e, 0xd9, 0xb1, 0x19, 0x40, 0x0b, 0x0b, 0xe3, 0x84,
+	0x3b, 0x75, 0x44, 0x1f, 0x88, 0x20, 0x13, 0x4e, 0x03, 0xd1, 0xf2, 0x06, 0x73, 0x9a, 0x6c, 0x0d,
+	0xe0, 0x83, 0xa9, 0x48, 0x14, 0x5b, 0x0b, 0x69, 0x7b, 0x3c, 0xe2, 0x82, 0x3b, 0xed, 0xed, 0x1f,
+	0xd3, 0x92, 0x31, 0xde, 0xb4, 0x2b, 0x6a, 0x2f, 0x82, 0x9d, 0x1a, 0x6b, 0x43, 0xfd, 0x63, 0x7e,
+	0xee, 0x58, 0xac, 0x07, 0x6d, 0x7f, 0x1a, 0xe3, 0xef, 0x37, 0x72, 0x3f, 0xda, 0x7a, 0xe4, 0xd4,
+	0x91, 0x80, 0x0a, 0xa5, 0x7c, 0xe4, 0x34, 0xd8, 0x0a, 0x74, 0x3e, 0x54, 0x0f, 0xef, 0x4e, 0x13,
+	0x49, 0xc8, 0x86, 0x6b, 0x5a, 0x48, 0xa2, 0xcd, 0x11, 0x6a, 0x23, 0x44, 0xab, 0x10, 0xea, 0x6c,
+	0xef, 0x43, 0x47, 0x4f, 0x87, 0x6c, 0x1d, 0x7a, 0x4a, 0x07, 0x44, 0x39, 0x35, 0x3c, 0x10, 0xdd,
+	0xcb, 0x8e, 0x85, 0x87, 0xc7, 0x39, 0xcf, 0xb1, 0xf1, 0x0b, 0x87, 0x39, 0xa7, 0x4e, 0x06, 0xb9,
+	0x88, 0x87, 0x4e, 0x03, 0x19, 0x69, 0x28, 0x70, 0x46, 0xdb, 0x8f, 0xa0, 0x4d, 0x9f, 0xfb, 0xd8,
+	0xea, 0xac, 0x29, 0x79, 0x0a, 0xe3, 0xd4, 0xd0, 0xa6, 0xb8, 0xbb, 0xe4, 0xb6, 0xd0, 0x36, 0x74,
+	0x1c, 0x09, 0xdb, 0xa8, 0x82, 0xb4, 0x93, 0x44, 0xd4, 0xb7, 0x7f, 0x6a, 0x41, 0x47, 0xb7, 0xf3,
+	0xec, 0x2a, 0xac, 0x6b, 0x23, 0x29, 0x94, 0x94, 0x78, 0x9f, 0x0b, 0x89, 0x70, 0x2c, 0xda, 0xa0,
+	0x00, 0x6d, 0xb4, 0xab, 0xcf, 0x27, 0xc9, 0x19, 0x57, 0x98, 0x3a, 0x6e, 0x89, 0xd3, 0xa3, 0x82,
+	0x1b, 0xb8, 0x60, 0x10, 0xaa, 0x54, 0x77, 0x9a, 0xec, 0x3a, 0x30, 0x04, 0x1f, 0x85, 0x63, 0x0c,
+	0x27, 0xd9, 0x63, 0xe7, 0x4e, 0x6b, 0xfb, 0x7d, 0xe8, 0xe8, 0x56, 0xd6, 0xd0, 0x43, 0xa3, 0x0a,
+	0x3d, 0x24, 0xc2, 0xb1, 0xca, 0x8d, 0x15, 0xc6, 0xde, 0x7e, 0x0a, 0x6d, 0xd5, 0x08, 0x1a, 0x96,
+	0x51, 0x18, 0x15, 0x5e, 0xa7, 0x61, 0xaa, 0x1c, 0xce, 0xd3, 0x28, 0x18, 0x16, 0x01, 0x76, 0xc6,
+	0x33, 0xe1, 0xd4, 0xf1, 0xfb, 0x61, 0xfc, 0x23, 0x3e, 0xc4, 0x08, 0x43, 0x37, 0x84, 0xb9, 0x70,
+	0x9a, 0xdb, 0x03, 0xe8, 0x3d, 0xd5, 0x85, 0x7e, 0x1f, 0x7f, 0xfe, 0x60, 0x5a, 0xb9, 0x12, 0xeb,
+	0xd4, 0x70, 0x4f, 0x8a, 0xce, 0x02, 0xeb, 0x58, 0xec, 0x0a, 0xac, 0xa2, 0x37, 0x4a, 0x94, 0xbd,
+	0xfd, 0x04, 0xd8, 0x7c, 0x89, 0x42, 0xa3, 0x95, 0x0a, 0x3b, 0x35, 0xd4, 0xe4, 0x63, 0x7e, 0x8e,
+	0xdf, 0xe4, 0xc3, 0x87, 0xe3, 0x38, 0xc9, 0x38, 0xd1, 0xb4, 0x0f, 0xe9, 0x0d, 0x0f, 0x11, 0xf5,
+	0xed, 0xa7, 0x33, 0xc5, 0x7c, 0x3f, 0x35, 0xc2, 0x9d, 0x60, 0xa7, 0x46, 0xc1, 0x47, 0x52, 0x24,
+	0x42, 0x19, 0x90, 0xc4, 0x48, 0x8c, 0x8d, 0x1b, 0xed, 0x46, 0x3c, 0xc8, 0x24, 0x5c, 0xdf, 0xf9,
+	0x43, 0x0b, 0x5a, 0xb2, 0x67, 0x65, 0xef, 0x43, 0xcf, 0xf8, 0x61, 0x98, 0x51, 0xa5, 0x9d, 0xff,
+	0x19, 0x7b, 0xf3, 0x2b, 0x73, 0x78, 0x59, 0x1e, 0xbc, 0x1a, 0x7b, 0x0f, 0xa0, 0x1c, 0x6e, 0xd9,
+	0x35, 0x6a, 0x7c, 0x66, 0x87, 0xdd, 0x4d, 0x17, 0xd1, 0x8b, 0x7e, 0xf4, 0xf6, 0x6a, 0xec, 0x7b,
+	0xb0, 0xaa, 0x6a, 0x90, 0x0c, 0x2d, 0xd6, 0x37, 0x46, 0x93, 0x05, 0x63, 0xeb, 0xa5, 0xc2, 0x3e,
+	0x2c, 0x84, 0xc9, 0xf0, 0x61, 0xee, 0x82, 0x39, 0x47, 0x8a, 0x79, 0x69, 0xe9, 0x04, 0xe4, 0xd5,
+	0xd8, 0x7d, 0xe8, 0xc9, 0x31, 0x45, 0x56, 0xd6, 0x1b, 0xc8, 0xbb, 0x6c, 0x6e, 0xb9, 0x54, 0xa1,
+	0x5d, 0x58, 0x31, 0x27, 0x04, 0x46, 0x96, 0x5c, 0x30, 0x4a, 0x6c, 0xba, 0xf3, 0x84, 0x42, 0x48,
+	0x00, 0xd7, 0x17, 0xf7, 0xf9, 0xec, 0xd5, 0xf2, 0xfd, 0x76, 0xc9, 0x60, 0xb1, 0xe9, 0x5d, 0xc6,
+	0x52, 0x6c, 0xf1, 0x03, 0x70, 0x8b, 0xcd, 0x8b, 0xb0, 0x56, 0x51, 0xd1, 0x57, 0xaa, 0x2d, 0x19,
+	0x0d, 0x36, 0x5f, 0x59, 0x4a, 0x2f, 0xc4, 0x1f, 0xc2, 0x95, 0x92, 0x21, 0x91, 0xe6, 0x63, 0x37,
+	0xe7, 0xd6, 0x55, 0xcc, 0xda, 0x5f, 0x46, 0x2e, 0xa4, 0xfe, 0xb0, 0x9c, 0x8a, 0xab, 0x92, 0x5f,
+	0x35, 0x7d, 0xbb, 0x58, 0xba, 0x77, 0x19, 0x8b, 0xde, 0xe1, 0xae, 0xfb, 0x8f, 0xcf, 0xfb, 0xd6,
+	0x67, 0x9f, 0xf7, 0xad, 0x7f, 0x7f, 0xde, 0xb7, 0x7e, 0xf1, 0x45, 0xbf, 0xf6, 0xd9, 0x17, 0xfd,
+	0xda, 0xbf, 0xbe, 0xe8, 0xd7, 0x8e, 0x5a, 0xf4, 0xd7, 0x8f, 0x6f, 0xfe, 0x77, 0x00, 0x83, 0xe8,
+	0x0a, 0x0e, 0x0c, 0x22, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -4451,6 +4583,25 @@ func (m *LoadStatus) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.Eta != 0 {
+		i = encodeVarintDmworker(dAtA, i, uint64(m.Eta))
+		i--
+		dAtA[i] = 0x40
+	}
+	if len(m.Tables) > 0 {
+		for iNdEx := len(m.Tables) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Tables[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintDmworker(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x3a
+		}
+	}
 	if m.Bps != 0 {
 		i = encodeVarintDmworker(dAtA, i, uint64(m.Bps))
 		i--
@@ -4490,6 +4641,63 @@ func (m *LoadStatus) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *TableLoadStatus) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TableLoadStatus) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *TableLoadStatus) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Eta != 0 {
+		i = encodeVarintDmworker(dAtA, i, uint64(m.Eta))
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.Bps != 0 {
+		i = encodeVarintDmworker(dAtA, i, uint64(m.Bps))
+		i--
+		dAtA[i] = 0x28
+	}
+	if len(m.Progress) > 0 {
+		i -= len(m.Progress)
+		copy(dAtA[i:], m.Progress)
+		i = encodeVarintDmworker(dAtA, i, uint64(len(m.Progress)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.TotalBytes != 0 {
+		i = encodeVarintDmworker(dAtA, i, uint64(m.TotalBytes))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.FinishedBytes != 0 {
+		i = encodeVarintDmworker(dAtA, i, uint64(m.FinishedBytes))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Table) > 0 {
+		i -= len(m.Table)
+		copy(dAtA[i:], m.Table)
+		i = encodeVarintDmworker(dAtA, i, uint64(len(m.Table)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *ShardingGroup) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -4574,6 +4782,13 @@ func (m *SyncStatus) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.HeartbeatLag != 0 {
+		i = encodeVarintDmworker(dAtA, i, uint64(m.HeartbeatLag))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x90
+	}
 	if m.RecentRps != 0 {
 		i = encodeVarintDmworker(dAtA, i, uint64(m.RecentRps))
 		i--
@@ -5940,6 +6155,24 @@ func (m *HandleWorkerErrorRequest) MarshalToSizedBuffer(dAtA []byte) (int, error
 	_ = i
 	var l int
 	_ = l
+	if len(m.EventTypes) > 0 {
+		for iNdEx := len(m.EventTypes) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.EventTypes[iNdEx])
+			copy(dAtA[i:], m.EventTypes[iNdEx])
+			i = encodeVarintDmworker(dAtA, i, uint64(len(m.EventTypes[iNdEx])))
+			i--
+			dAtA[i] = 0x32
+		}
+	}
+	if len(m.Tables) > 0 {
+		for iNdEx := len(m.Tables) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Tables[iNdEx])
+			copy(dAtA[i:], m.Tables[iNdEx])
+			i = encodeVarintDmworker(dAtA, i, uint64(len(m.Tables[iNdEx])))
+			i--
+			dAtA[i] = 0x2a
+		}
+	}
 	if len(m.Sqls) > 0 {
 		for iNdEx := len(m.Sqls) - 1; iNdEx >= 0; iNdEx-- {
 			i -= len(m.Sqls[iNdEx])
@@ -6779,35 +7012,73 @@ func (m *LoadStatus) Size() (n int) {
 	if m.Bps != 0 {
 		n += 1 + sovDmworker(uint64(m.Bps))
 	}
+	if len(m.Tables) > 0 {
+		for _, e := range m.Tables {
+			l = e.Size()
+			n += 1 + l + sovDmworker(uint64(l))
+		}
+	}
+	if m.Eta != 0 {
+		n += 1 + sovDmworker(uint64(m.Eta))
+	}
 	return n
 }
 
-func (m *ShardingGroup) Size() (n int) {
+func (m *TableLoadStatus) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	l = len(m.Target)
+	l = len(m.Table)
 	if l > 0 {
 		n += 1 + l + sovDmworker(uint64(l))
 	}
-	if len(m.DDLs) > 0 {
-		for _, s := range m.DDLs {
-			l = len(s)
-			n += 1 + l + sovDmworker(uint64(l))
-		}
+	if m.FinishedBytes != 0 {
+		n += 1 + sovDmworker(uint64(m.FinishedBytes))
 	}
-	l = len(m.FirstLocation)
+	if m.TotalBytes != 0 {
+		n += 1 + sovDmworker(uint64(m.TotalBytes))
+	}
+	l = len(m.Progress)
 	if l > 0 {
 		n += 1 + l + sovDmworker(uint64(l))
 	}
-	if len(m.Synced) > 0 {
-		for _, s := range m.Synced {
-			l = len(s)
-			n += 1 + l + sovDmworker(uint64(l))
-		}
-	}
+	if m.Bps != 0 {
+		n += 1 + sovDmworker(uint64(m.Bps))
+	}
+	if m.Eta != 0 {
+		n += 1 + sovDmworker(uint64(m.Eta))
+	}
+	return n
+}
+
+func (m *ShardingGroup) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Target)
+	if l > 0 {
+		n += 1 + l + sovDmworker(uint64(l))
+	}
+	if len(m.DDLs) > 0 {
+		for _, s := range m.DDLs {
+			l = len(s)
+			n += 1 + l + sovDmworker(uint64(l))
+		}
+	}
+	l = len(m.FirstLocation)
+	if l > 0 {
+		n += 1 + l + sovDmworker(uint64(l))
+	}
+	if len(m.Synced) > 0 {
+		for _, s := range m.Synced {
+			l = len(s)
+			n += 1 + l + sovDmworker(uint64(l))
+		}
+	}
 	if len(m.Unsynced) > 0 {
 		for _, s := range m.Unsynced {
 			l = len(s)
@@ -6887,6 +7158,9 @@ func (m *SyncStatus) Size() (n int) {
 	if m.RecentRps != 0 {
 		n += 2 + sovDmworker(uint64(m.RecentRps))
 	}
+	if m.HeartbeatLag != 0 {
+		n += 2 + sovDmworker(uint64(m.HeartbeatLag))
+	}
 	return n
 }
 
@@ -7481,6 +7755,18 @@ func (m *HandleWorkerErrorRequest) Size() (n int) {
 			n += 1 + l + sovDmworker(uint64(l))
 		}
 	}
+	if len(m.Tables) > 0 {
+		for _, s := range m.Tables {
+			l = len(s)
+			n += 1 + l + sovDmworker(uint64(l))
+		}
+	}
+	if len(m.EventTypes) > 0 {
+		for _, s := range m.EventTypes {
+			l = len(s)
+			n += 1 + l + sovDmworker(uint64(l))
+		}
+	}
 	return n
 }
 
@@ -8731,6 +9017,249 @@ func (m *LoadStatus) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Tables", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDmworker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Tables = append(m.Tables, &TableLoadStatus{})
+			if err := m.Tables[len(m.Tables)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Eta", wireType)
+			}
+			m.Eta = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDmworker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Eta |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDmworker(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TableLoadStatus) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDmworker
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TableLoadStatus: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TableLoadStatus: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Table", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDmworker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Table = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FinishedBytes", wireType)
+			}
+			m.FinishedBytes = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDmworker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.FinishedBytes |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TotalBytes", wireType)
+			}
+			m.TotalBytes = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDmworker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TotalBytes |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Progress", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDmworker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Progress = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Bps", wireType)
+			}
+			m.Bps = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDmworker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Bps |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Eta", wireType)
+			}
+			m.Eta = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDmworker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Eta |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipDmworker(dAtA[iNdEx:])
@@ -9434,6 +9963,25 @@ func (m *SyncStatus) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 18:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HeartbeatLag", wireType)
+			}
+			m.HeartbeatLag = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDmworker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.HeartbeatLag |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipDmworker(dAtA[iNdEx:])
@@ -12981,6 +13529,70 @@ func (m *HandleWorkerErrorRequest) Unmarshal(dAtA []byte) error {
 			}
 			m.Sqls = append(m.Sqls, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Tables", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDmworker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Tables = append(m.Tables, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EventTypes", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDmworker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.EventTypes = append(m.EventTypes, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipDmworker(dAtA[iNdEx:])
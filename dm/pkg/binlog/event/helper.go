@@ -34,6 +34,8 @@ func GTIDsFromPreviousGTIDsEvent(e *replication.BinlogEvent) (gmysql.GTIDSet, er
 }
 
 // GTIDsFromMariaDBGTIDListEvent get GTID set from a MariaDBGTIDListEvent.
+// The event lists the last GTID of every server in every replication domain,
+// only the latest one, which has the highest sequence number, is kept for each domain.
 func GTIDsFromMariaDBGTIDListEvent(e *replication.BinlogEvent) (gmysql.GTIDSet, error) {
 	var gtidListEv *replication.MariadbGTIDListEvent
 	switch ev := e.Event.(type) {
@@ -49,6 +51,9 @@ func GTIDsFromMariaDBGTIDListEvent(e *replication.BinlogEvent) (gmysql.GTIDSet,
 	}
 	mGSet := ggSet.(*gmysql.MariadbGTIDSet)
 	for _, mGTID := range gtidListEv.GTIDs {
+		if prev, ok := mGSet.Sets[mGTID.DomainID]; ok && prev.SequenceNumber >= mGTID.SequenceNumber {
+			continue
+		}
 		mgClone := mGTID // use another variable so we can get different pointer (&mgClone below) when iterating
 		err = mGSet.AddSet(&mgClone)
 		if err != nil {
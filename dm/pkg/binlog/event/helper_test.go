@@ -78,4 +78,23 @@ func TestGTIDsFromMariaDBGTIDListEvent(t *testing.T) {
 	gSet, err = GTIDsFromMariaDBGTIDListEvent(mariaGTIDListEv)
 	require.Nil(t, err)
 	require.Equal(t, gSetExpect, gSet)
+
+	// several servers in the replication domains
+	mariaGTIDListEv = &replication.BinlogEvent{
+		Header: header,
+		Event: &replication.MariadbGTIDListEvent{
+			GTIDs: []gmysql.MariadbGTID{
+				{DomainID: 0, ServerID: 2, SequenceNumber: 20},
+				{DomainID: 0, ServerID: 1, SequenceNumber: 10},
+				{DomainID: 1, ServerID: 1, SequenceNumber: 5},
+				{DomainID: 1, ServerID: 3, SequenceNumber: 8},
+			},
+		},
+	}
+	gSet, err = GTIDsFromMariaDBGTIDListEvent(mariaGTIDListEv)
+	require.Nil(t, err)
+	require.Equal(t, []uint32{0, 1}, gtid.MariaDBDomainIDs(gSet))
+	gSetExpect, err = gtid.ParserGTID(gmysql.MariaDBFlavor, "0-2-20,1-3-8")
+	require.Nil(t, err)
+	require.True(t, gSet.Equal(gSetExpect))
 }
@@ -564,6 +564,20 @@ func statusVarsToKV(statusVars []byte) (map[byte][]byte, error) {
 	return vars, nil
 }
 
+// IsMariaDBEvent returns true if the event type is only written by MariaDB,
+// it is used to detect a MariaDB source which is configured as MySQL.
+func IsMariaDBEvent(tp replication.EventType) bool {
+	switch tp {
+	case replication.MARIADB_ANNOTATE_ROWS_EVENT,
+		replication.MARIADB_BINLOG_CHECKPOINT_EVENT,
+		replication.MARIADB_GTID_EVENT,
+		replication.MARIADB_GTID_LIST_EVENT:
+		return true
+	default:
+		return false
+	}
+}
+
 // GetGTIDStr gets GTID string representation from a GTID event or MariaDB GTID evnets.
 // learn from: https://github.com/go-mysql-org/go-mysql/blob/c6ab05a85eb86dc51a27ceed6d2f366a32874a24/replication/binlogsyncer.go#L732-L749
 func GetGTIDStr(e *replication.BinlogEvent) (string, error) {
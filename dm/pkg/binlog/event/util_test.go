@@ -20,6 +20,7 @@ import (
 	"io"
 	"testing"
 
+	"github.com/go-mysql-org/go-mysql/replication"
 	"github.com/pingcap/tiflow/dm/pkg/terror"
 	"github.com/stretchr/testify/require"
 )
@@ -172,3 +173,15 @@ func TestGetTimezoneByStatusVars(t *testing.T) {
 		require.Equal(t, test.output, vars)
 	}
 }
+
+func TestIsMariaDBEvent(t *testing.T) {
+	t.Parallel()
+
+	require.True(t, IsMariaDBEvent(replication.MARIADB_GTID_EVENT))
+	require.True(t, IsMariaDBEvent(replication.MARIADB_GTID_LIST_EVENT))
+	require.True(t, IsMariaDBEvent(replication.MARIADB_ANNOTATE_ROWS_EVENT))
+	require.True(t, IsMariaDBEvent(replication.MARIADB_BINLOG_CHECKPOINT_EVENT))
+	require.False(t, IsMariaDBEvent(replication.GTID_EVENT))
+	require.False(t, IsMariaDBEvent(replication.PREVIOUS_GTIDS_EVENT))
+	require.False(t, IsMariaDBEvent(replication.QUERY_EVENT))
+}
@@ -0,0 +1,100 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	gmysql "github.com/go-mysql-org/go-mysql/mysql"
+	"github.com/pingcap/tidb/util/dbutil"
+	"github.com/pingcap/tiflow/dm/pkg/conn"
+	"github.com/pingcap/tiflow/dm/pkg/gtid"
+)
+
+// MariaDBGTIDChecker checks whether a MariaDB source is compatible with
+// the GTID based replication of DM.
+type MariaDBGTIDChecker struct {
+	db         *sql.DB
+	dbinfo     *dbutil.DBConfig
+	enableGTID bool
+}
+
+// NewMariaDBGTIDChecker returns a RealChecker.
+func NewMariaDBGTIDChecker(db *sql.DB, dbinfo *dbutil.DBConfig, enableGTID bool) RealChecker {
+	return &MariaDBGTIDChecker{db: db, dbinfo: dbinfo, enableGTID: enableGTID}
+}
+
+// Check implements the RealChecker interface.
+// The checker passes for MySQL sources, and for MariaDB sources which are
+// replicated by binlog positions.
+func (pc *MariaDBGTIDChecker) Check(ctx context.Context) *Result {
+	result := &Result{
+		Name:  pc.Name(),
+		Desc:  "check whether MariaDB GTID is compatible",
+		State: StateWarning,
+		Extra: fmt.Sprintf("address of db instance - %s:%d", pc.dbinfo.Host, pc.dbinfo.Port),
+	}
+
+	version, err := dbutil.ShowVersion(ctx, pc.db)
+	if err != nil {
+		markCheckError(result, err)
+		return result
+	}
+	if !conn.IsMariaDB(version) || !pc.enableGTID {
+		result.State = StateSuccess
+		return result
+	}
+
+	// Without the strict mode, MariaDB accepts out-of-order GTIDs in
+	// a domain, which can be skipped after the task is resumed.
+	strictMode, err := dbutil.ShowMySQLVariable(ctx, pc.db, "gtid_strict_mode")
+	if err != nil {
+		markCheckError(result, err)
+		return result
+	}
+	if strings.ToUpper(strictMode) != "ON" {
+		result.Errors = append(result.Errors, NewWarn("gtid_strict_mode is %s, and should be ON", strictMode))
+		result.Instruction = "MariaDB as source: please execute 'set global gtid_strict_mode = ON;' " +
+			"to prevent out-of-order GTIDs in a replication domain."
+		return result
+	}
+
+	binlogPos, err := dbutil.ShowMySQLVariable(ctx, pc.db, "gtid_binlog_pos")
+	if err != nil {
+		markCheckError(result, err)
+		return result
+	}
+	gSet, err := gtid.ParserGTID(gmysql.MariaDBFlavor, binlogPos)
+	if err != nil {
+		result.Errors = append(result.Errors, NewError("gtid_binlog_pos %s is not a valid MariaDB GTID set", binlogPos))
+		result.Instruction = "MariaDB as source: please check whether GTID is enabled in the binlog, " +
+			"or migrate with enable-gtid set to false."
+		result.State = StateFailure
+		return result
+	}
+	if domainIDs := gtid.MariaDBDomainIDs(gSet); len(domainIDs) > 1 {
+		result.Extra = fmt.Sprintf("%s, replication domains - %v", result.Extra, domainIDs)
+	}
+
+	result.State = StateSuccess
+	return result
+}
+
+// Name implements the RealChecker interface.
+func (pc *MariaDBGTIDChecker) Name() string {
+	return "mariadb_gtid"
+}
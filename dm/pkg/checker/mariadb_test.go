@@ -0,0 +1,72 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/pingcap/tidb/util/dbutil"
+	"github.com/stretchr/testify/require"
+)
+
+func expectVariable(mock sqlmock.Sqlmock, name, value string) {
+	mock.ExpectQuery("SHOW GLOBAL VARIABLES LIKE '" + name + "'").
+		WillReturnRows(sqlmock.NewRows([]string{"Variable_name", "Value"}).AddRow(name, value))
+}
+
+func TestMariaDBGTIDChecker(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	// MySQL source
+	checker := NewMariaDBGTIDChecker(db, &dbutil.DBConfig{}, true)
+	expectVariable(mock, "version", "5.7.26-log")
+	result := checker.Check(ctx)
+	require.Equal(t, StateSuccess, result.State)
+
+	// MariaDB source replicated by binlog positions
+	checker = NewMariaDBGTIDChecker(db, &dbutil.DBConfig{}, false)
+	expectVariable(mock, "version", "10.5.8-MariaDB")
+	result = checker.Check(ctx)
+	require.Equal(t, StateSuccess, result.State)
+
+	// gtid_strict_mode is off
+	checker = NewMariaDBGTIDChecker(db, &dbutil.DBConfig{}, true)
+	expectVariable(mock, "version", "10.5.8-MariaDB")
+	expectVariable(mock, "gtid_strict_mode", "OFF")
+	result = checker.Check(ctx)
+	require.Equal(t, StateWarning, result.State)
+	require.Contains(t, result.Errors[0].ShortErr, "gtid_strict_mode is OFF")
+	require.Contains(t, result.Instruction, "set global gtid_strict_mode = ON")
+
+	// invalid gtid_binlog_pos
+	expectVariable(mock, "version", "10.5.8-MariaDB")
+	expectVariable(mock, "gtid_strict_mode", "ON")
+	expectVariable(mock, "gtid_binlog_pos", "invalid")
+	result = checker.Check(ctx)
+	require.Equal(t, StateFailure, result.State)
+	require.Contains(t, result.Errors[0].ShortErr, "is not a valid MariaDB GTID set")
+
+	// multiple replication domains
+	expectVariable(mock, "version", "10.5.8-MariaDB")
+	expectVariable(mock, "gtid_strict_mode", "ON")
+	expectVariable(mock, "gtid_binlog_pos", "0-1-100,1-2-20")
+	result = checker.Check(ctx)
+	require.Equal(t, StateSuccess, result.State)
+	require.Contains(t, result.Extra, "replication domains - [0 1]")
+	require.NoError(t, mock.ExpectationsWereMet())
+}
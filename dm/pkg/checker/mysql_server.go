@@ -17,6 +17,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strings"
 
 	toolsutils "github.com/pingcap/tidb-tools/pkg/utils"
 	"github.com/pingcap/tidb/util/dbutil"
@@ -36,6 +37,7 @@ func NewMySQLVersionChecker(db *sql.DB, dbinfo *dbutil.DBConfig) RealChecker {
 
 // SupportedVersion defines the MySQL/MariaDB version that DM/syncer supports
 // * 5.6.0 <= MySQL Version < 8.0.0.
+// * 10.1.2 <= MariaDB Version < 10.7.0.
 var SupportedVersion = map[string]struct {
 	Min MySQLVersion
 	Max MySQLVersion
@@ -44,6 +46,10 @@ var SupportedVersion = map[string]struct {
 		MySQLVersion{5, 6, 0},
 		MySQLVersion{8, 0, 0},
 	},
+	"mariadb": {
+		MySQLVersion{10, 1, 2},
+		MySQLVersion{10, 7, 0},
+	},
 }
 
 // Check implements the RealChecker interface.
@@ -74,9 +80,7 @@ func (pc *MySQLVersionChecker) Check(ctx context.Context) *Result {
 func (pc *MySQLVersionChecker) checkVersion(value string, result *Result) *Error {
 	needVersion := SupportedVersion["mysql"]
 	if conn.IsMariaDB(value) {
-		err := NewWarn("Migrating from MariaDB is still experimental.")
-		err.Instruction = "It is recommended that you upgrade MariaDB to 10.1.2 or a later version."
-		return err
+		return pc.checkMariaDBVersion(value, result)
 	}
 	if IsTiDBFromVersion(value) {
 		err := NewWarn("migration from TiDB not supported")
@@ -106,6 +110,38 @@ func (pc *MySQLVersionChecker) checkVersion(value string, result *Result) *Error
 	return nil
 }
 
+// checkMariaDBVersion checks the version against the MariaDB compatibility matrix.
+// MariaDB earlier than 10.1.2 lacks a complete GTID implementation, and the
+// versions later than the max one are not verified.
+func (pc *MySQLVersionChecker) checkMariaDBVersion(value string, result *Result) *Error {
+	needVersion := SupportedVersion["mariadb"]
+	// MariaDB may report its version with the "5.5.5-" prefix for the
+	// compatibility with the MySQL replication protocol.
+	version, err := toMySQLVersion(strings.TrimPrefix(value, "5.5.5-"))
+	if err != nil {
+		markCheckError(result, err)
+		return nil
+	}
+
+	if !version.Ge(needVersion.Min) {
+		err := NewWarn("MariaDB version suggested at least %v but got %v", needVersion.Min, version)
+		err.Instruction = fmt.Sprintf("MariaDB earlier than %v does not support GTID based replication completely. "+
+			"It is recommended that you upgrade MariaDB to %v or a later version, or migrate with enable-gtid set to false.",
+			needVersion.Min, needVersion.Min)
+		return err
+	}
+
+	if !version.Lt(needVersion.Max) {
+		err := NewWarn("MariaDB version suggested earlier than %v but got %v", needVersion.Max, version)
+		err.Instruction = fmt.Sprintf("MariaDB %v and later versions are not verified, "+
+			"it is recommended that you run a full test migration before migrating the production data.", needVersion.Max)
+		return err
+	}
+
+	result.State = StateSuccess
+	return nil
+}
+
 // Name implements the RealChecker interface.
 func (pc *MySQLVersionChecker) Name() string {
 	return "mysql_version"
@@ -37,7 +37,10 @@ func TestMysqlVersion(t *testing.T) {
 		{"8.0.20", false},
 		{"5.5.50-MariaDB-1~wheezy", false},
 		{"10.1.1-MariaDB-1~wheezy", false},
-		{"10.1.2-MariaDB-1~wheezy", false},
+		{"10.1.2-MariaDB-1~wheezy", true},
+		{"5.5.5-10.5.8-MariaDB-log", true},
+		{"10.6.12-MariaDB", true},
+		{"10.7.0-MariaDB", false},
 		{"10.13.1-MariaDB-1~wheezy", false},
 	}
 
@@ -75,6 +75,8 @@ func NewSourceDumpPrivilegeChecker(
 
 // Check implements the RealChecker interface.
 // We check RELOAD, SELECT, LOCK TABLES privileges according to consistency.
+// For "auto", lack of RELOAD privilege is only a warning because DM turns to
+// "gtid-snapshot" consistency then.
 func (pc *SourceDumpPrivilegeChecker) Check(ctx context.Context) *Result {
 	result := &Result{
 		Name:  pc.Name(),
@@ -101,7 +103,7 @@ func (pc *SourceDumpPrivilegeChecker) Check(ctx context.Context) *Result {
 	}
 
 	switch pc.consistency {
-	case "auto", "flush":
+	case "flush":
 		dumpRequiredPrivs[mysql.ReloadPriv] = priv{needGlobal: true}
 	case "lock":
 		dumpRequiredPrivs[mysql.LockTablesPriv] = priv{needGlobal: true}
@@ -111,12 +113,42 @@ func (pc *SourceDumpPrivilegeChecker) Check(ctx context.Context) *Result {
 	if err2 != nil {
 		result.Errors = append(result.Errors, err2)
 		result.Instruction = "Please grant the required privileges to the account."
-	} else {
-		result.State = StateSuccess
+		if pc.consistency == "flush" {
+			result.Instruction += " If the upstream is a cloud-managed MySQL like RDS or Aurora where `FLUSH TABLES WITH READ LOCK` is unavailable, please use `--consistency gtid-snapshot` instead."
+		}
+		return result
+	}
+	result.State = StateSuccess
+
+	// dumpling uses `FLUSH TABLES WITH READ LOCK` for "auto", DM dumps with
+	// "gtid-snapshot" instead when the account can't execute it.
+	if pc.consistency == "auto" {
+		canFlush, err := HasReloadPrivilege(grants)
+		if err != nil {
+			markCheckError(result, err)
+			return result
+		}
+		if !canFlush {
+			result.State = StateWarning
+			result.Errors = append(result.Errors, NewWarn("lack of RELOAD global (*.*) privilege, `FLUSH TABLES WITH READ LOCK` is unavailable"))
+			result.Instruction = "DM will dump with `--consistency gtid-snapshot`, which verifies that no transaction is committed while the dump connections are established, and replicates in safe mode until the dump exit location otherwise. Please grant RELOAD privilege to the account if you need a locked consistent dump."
+		}
 	}
 	return result
 }
 
+// HasReloadPrivilege returns whether the grants have the global RELOAD
+// privilege, which is required by `FLUSH TABLES WITH READ LOCK`.
+func HasReloadPrivilege(grants []string) (bool, error) {
+	lackedPriv, err := VerifyPrivileges(grants, map[mysql.PrivilegeType]priv{
+		mysql.ReloadPriv: {needGlobal: true},
+	})
+	if err != nil {
+		return false, err
+	}
+	return len(lackedPriv) == 0, nil
+}
+
 // Name implements the RealChecker interface.
 func (pc *SourceDumpPrivilegeChecker) Name() string {
 	return "source db dump privilege checker"
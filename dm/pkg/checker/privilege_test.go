@@ -223,6 +223,39 @@ func TestVerifyDumpPrivileges(t *testing.T) {
 	}
 }
 
+func TestHasReloadPrivilege(t *testing.T) {
+	cases := []struct {
+		grants   []string
+		canFlush bool
+	}{
+		{
+			grants:   []string{"GRANT RELOAD, SELECT ON *.* TO 'user'@'%'"},
+			canFlush: true,
+		},
+		{
+			grants:   []string{"GRANT ALL PRIVILEGES ON *.* TO 'user'@'%'"},
+			canFlush: true,
+		},
+		{
+			grants:   []string{"GRANT SELECT, LOCK TABLES ON *.* TO 'user'@'%'"},
+			canFlush: false,
+		},
+		{
+			grants:   []string{"GRANT ALL PRIVILEGES ON `db1`.* TO 'user'@'%'"},
+			canFlush: false,
+		},
+	}
+
+	for _, cs := range cases {
+		canFlush, err := HasReloadPrivilege(cs.grants)
+		require.NoError(t, err, "grants: %v", cs.grants)
+		require.Equal(t, cs.canFlush, canFlush, "grants: %v", cs.grants)
+	}
+
+	_, err := HasReloadPrivilege(nil)
+	require.Error(t, err)
+}
+
 func TestVerifyReplicationPrivileges(t *testing.T) {
 	cases := []struct {
 		grants           []string
@@ -33,7 +33,6 @@ import (
 	tmysql "github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tidb/util/dbutil"
 	"github.com/pingcap/tidb/util/filter"
-	"github.com/pingcap/tidb/util/regexpr-router"
 	tcontext "github.com/pingcap/tiflow/dm/pkg/context"
 	"github.com/pingcap/tiflow/dm/pkg/log"
 	"github.com/pingcap/tiflow/dm/pkg/terror"
@@ -280,6 +279,12 @@ func IsErrBinlogPurged(err error) bool {
 	return IsMySQLError(err, tmysql.ErrMasterFatalErrorReadingBinlog)
 }
 
+// IsErrAccessDenied checks whether err is caused by the lack of privilege,
+// like executing `FLUSH TABLES WITH READ LOCK` on RDS or Aurora.
+func IsErrAccessDenied(err error) bool {
+	return IsMySQLError(err, tmysql.ErrAccessDenied) || IsMySQLError(err, tmysql.ErrSpecificAccessDenied)
+}
+
 // IsNoSuchThreadError checks whether err is NoSuchThreadError.
 func IsNoSuchThreadError(err error) bool {
 	return IsMySQLError(err, tmysql.ErrNoSuchThread)
@@ -493,13 +498,19 @@ func FetchAllDoTables(ctx context.Context, db *BaseDB, bw *filter.Filter) (map[s
 	return schemaToTables, nil
 }
 
+// TableRouter routes the source tables to the target tables.
+type TableRouter interface {
+	Route(schema, table string) (string, string, error)
+	FetchExtendColumn(schema, table, source string) ([]string, []string)
+}
+
 // FetchTargetDoTables returns all need to do tables after filtered and routed (fetches from upstream MySQL).
 func FetchTargetDoTables(
 	ctx context.Context,
 	source string,
 	db *BaseDB,
 	bw *filter.Filter,
-	router *regexprrouter.RouteTable,
+	router TableRouter,
 ) (map[filter.Table][]filter.Table, map[filter.Table][]string, error) {
 	// fetch tables from source and filter them
 	sourceTables, err := FetchAllDoTables(ctx, db, bw)
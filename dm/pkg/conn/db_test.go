@@ -218,6 +218,10 @@ func TestMySQLError(t *testing.T) {
 
 	err = newMysqlErr(tmysql.ErrDupEntry, "Duplicate entry '123456' for key 'index'")
 	require.Equal(t, true, IsErrDuplicateEntry(err))
+
+	err = newMysqlErr(tmysql.ErrSpecificAccessDenied, "Access denied; you need (at least one of) the RELOAD privilege(s) for this operation")
+	require.Equal(t, true, IsErrAccessDenied(err))
+	require.Equal(t, false, IsErrAccessDenied(errors.New("connection refused")))
 }
 
 func TestGetAllServerID(t *testing.T) {
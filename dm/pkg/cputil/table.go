@@ -38,6 +38,11 @@ func SyncerOnlineDDL(task string) string {
 	return task + "_onlineddl"
 }
 
+// SyncerConflictQuarantine returns syncer's conflict quarantine table name.
+func SyncerConflictQuarantine(task string) string {
+	return task + "_syncer_conflict_quarantine"
+}
+
 func ValidatorCheckpoint(task string) string {
 	return task + "_validator_checkpoint"
 }
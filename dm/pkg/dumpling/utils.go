@@ -37,6 +37,41 @@ import (
 // DefaultTableFilter is the default table filter for dumpling.
 var DefaultTableFilter = []string{"*.*", export.DefaultTableFilter}
 
+const (
+	// ConsistencyAuto is the default consistency of dumpling, which is "flush" for MySQL.
+	ConsistencyAuto = "auto"
+	// ConsistencyNone means dumpling doesn't lock the tables.
+	ConsistencyNone = "none"
+	// ConsistencyGTIDSnapshot is the consistency for the upstreams where
+	// `FLUSH TABLES WITH READ LOCK` is unavailable, like RDS and Aurora. DM
+	// dumps with "none" and every connection starts a consistent snapshot
+	// transaction, then DM verifies that no transaction is committed between
+	// the locations recorded before and after the connections are established,
+	// so all the connections see the same snapshot.
+	ConsistencyGTIDSnapshot = "gtid-snapshot"
+)
+
+// SelectConsistency returns the consistency DM uses to dump. "auto" is
+// turned to "gtid-snapshot" if the upstream account can't execute
+// `FLUSH TABLES WITH READ LOCK`, the others are returned as they are.
+func SelectConsistency(consistency string, canFlush bool) string {
+	if consistency == ConsistencyAuto && !canFlush {
+		return ConsistencyGTIDSnapshot
+	}
+	return consistency
+}
+
+// IsSnapshotConsistent returns whether the dump is consistent when dumped
+// with "gtid-snapshot", that is, the location before the connection pool is
+// established equals to the one after it.
+func IsSnapshotConsistent(loc, locAfterConnect *binlog.Location) bool {
+	if loc == nil || locAfterConnect == nil {
+		return false
+	}
+	cmpGTID := loc.GTIDSetStr() != "" && locAfterConnect.GTIDSetStr() != ""
+	return binlog.CompareLocation(*loc, *locAfterConnect, cmpGTID) == 0
+}
+
 // ParseMetaData parses mydumper's output meta file and returns binlog location.
 // since v2.0.0, dumpling maybe configured to output master status after connection pool is established,
 // we return this location as well.
@@ -246,7 +281,7 @@ func ParseExtraArgs(logger *log.Logger, dumpCfg *export.Config, args []string) e
 	dumplingFlagSet.IntVarP(&dumpCfg.Threads, "threads", "t", dumpCfg.Threads, "Number of goroutines to use, default 4")
 	dumplingFlagSet.StringVarP(&fileSizeStr, "filesize", "F", "", "The approximate size of output file")
 	dumplingFlagSet.Uint64VarP(&dumpCfg.StatementSize, "statement-size", "s", dumpCfg.StatementSize, "Attempted size of INSERT statement in bytes")
-	dumplingFlagSet.StringVar(&dumpCfg.Consistency, "consistency", dumpCfg.Consistency, "Consistency level during dumping: {auto|none|flush|lock|snapshot}")
+	dumplingFlagSet.StringVar(&dumpCfg.Consistency, "consistency", dumpCfg.Consistency, "Consistency level during dumping: {auto|none|flush|lock|snapshot|gtid-snapshot}")
 	dumplingFlagSet.StringVar(&dumpCfg.Snapshot, "snapshot", dumpCfg.Snapshot, "Snapshot position. Valid only when consistency=snapshot")
 	dumplingFlagSet.BoolVarP(&dumpCfg.NoViews, "no-views", "W", dumpCfg.NoViews, "Do not dump views")
 	dumplingFlagSet.Uint64VarP(&dumpCfg.Rows, "rows", "r", dumpCfg.Rows, "Split table into chunks of this many rows, default unlimited")
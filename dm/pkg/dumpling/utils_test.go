@@ -22,6 +22,7 @@ import (
 
 	"github.com/go-mysql-org/go-mysql/mysql"
 	"github.com/pingcap/tidb/dumpling/export"
+	"github.com/pingcap/tiflow/dm/pkg/binlog"
 	"github.com/pingcap/tiflow/dm/pkg/gtid"
 	"github.com/pingcap/tiflow/dm/pkg/log"
 	"github.com/pingcap/tiflow/dm/pkg/terror"
@@ -319,3 +320,32 @@ func TestParseArgs(t *testing.T) {
 	err = ParseExtraArgs(&logger, exportCfg, strings.Fields(extraArgs))
 	require.Equal(t, "cannot both specify `--no-locks` and `--consistency` other than `none`", err.Error())
 }
+
+func TestSelectConsistency(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, ConsistencyAuto, SelectConsistency(ConsistencyAuto, true))
+	require.Equal(t, ConsistencyGTIDSnapshot, SelectConsistency(ConsistencyAuto, false))
+	require.Equal(t, "flush", SelectConsistency("flush", false))
+	require.Equal(t, ConsistencyNone, SelectConsistency(ConsistencyNone, false))
+	require.Equal(t, ConsistencyGTIDSnapshot, SelectConsistency(ConsistencyGTIDSnapshot, true))
+}
+
+func TestIsSnapshotConsistent(t *testing.T) {
+	t.Parallel()
+
+	newLocation := func(pos uint32, gtidStr string) *binlog.Location {
+		gset, err := gtid.ParserGTID(mysql.MySQLFlavor, gtidStr)
+		require.NoError(t, err)
+		loc := binlog.NewLocation(mysql.Position{Name: "mysql-bin.000001", Pos: pos}, gset)
+		return &loc
+	}
+
+	loc := newLocation(100, "97b5142f-e19c-11e8-808c-0242ac110005:1-13")
+	require.False(t, IsSnapshotConsistent(loc, nil))
+	require.True(t, IsSnapshotConsistent(loc, newLocation(100, "97b5142f-e19c-11e8-808c-0242ac110005:1-13")))
+	require.False(t, IsSnapshotConsistent(loc, newLocation(200, "97b5142f-e19c-11e8-808c-0242ac110005:1-14")))
+	// compare by position when GTID is not enabled
+	require.True(t, IsSnapshotConsistent(newLocation(100, ""), newLocation(100, "")))
+	require.False(t, IsSnapshotConsistent(newLocation(100, ""), newLocation(200, "")))
+}
@@ -14,6 +14,7 @@
 package gtid
 
 import (
+	"sort"
 	"strings"
 
 	"github.com/go-mysql-org/go-mysql/mysql"
@@ -90,3 +91,41 @@ func IsZeroMySQLGTIDSet(gStr string) bool {
 	interval := strings.TrimSpace(sep[1])
 	return interval == "0"
 }
+
+// MariaDBDomainIDs returns the sorted replication domain IDs in a MariaDB GTID
+// set, a MariaDB source with multi-source replication or parallel replication
+// may write GTIDs of several domains. nil is returned for a MySQL GTID set.
+func MariaDBDomainIDs(gset mysql.GTIDSet) []uint32 {
+	mGSet, ok := gset.(*mysql.MariadbGTIDSet)
+	if !ok || mGSet == nil {
+		return nil
+	}
+	domainIDs := make([]uint32, 0, len(mGSet.Sets))
+	for domainID := range mGSet.Sets {
+		domainIDs = append(domainIDs, domainID)
+	}
+	sort.Slice(domainIDs, func(i, j int) bool { return domainIDs[i] < domainIDs[j] })
+	return domainIDs
+}
+
+// CoverPreviousGTIDs returns whether the replication starting from gset can
+// begin with a binlog file whose Previous_gtids or Gtid_list is prevGSet.
+// For MariaDB, every replication domain is checked separately, and the domains
+// absent from gset are replicated from the beginning of the file, which is
+// what a MariaDB master does for a slave connecting without these domains.
+func CoverPreviousGTIDs(gset, prevGSet mysql.GTIDSet) bool {
+	mGSet, ok := gset.(*mysql.MariadbGTIDSet)
+	if !ok {
+		return gset.Contain(prevGSet)
+	}
+	mPrevGSet, ok := prevGSet.(*mysql.MariadbGTIDSet)
+	if !ok {
+		return false
+	}
+	for domainID, prev := range mPrevGSet.Sets {
+		if g, ok := mGSet.Sets[domainID]; ok && g.SequenceNumber < prev.SequenceNumber {
+			return false
+		}
+	}
+	return true
+}
@@ -94,3 +94,49 @@ func TestParseZeroAsEmptyGTIDSet(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, "", gset.String())
 }
+
+func TestMariaDBDomainIDs(t *testing.T) {
+	t.Parallel()
+
+	gSet, err := ParserGTID(mysql.MariaDBFlavor, "0-0-1,10-10-10,4-20-1")
+	require.NoError(t, err)
+	require.Equal(t, []uint32{0, 4, 10}, MariaDBDomainIDs(gSet))
+
+	gSet, err = ParserGTID(mysql.MariaDBFlavor, "")
+	require.NoError(t, err)
+	require.Len(t, MariaDBDomainIDs(gSet), 0)
+
+	gSet, err = ParserGTID(mysql.MySQLFlavor, "3ccc475b-2343-11e7-be21-6c0b84d59f30:1-14")
+	require.NoError(t, err)
+	require.Nil(t, MariaDBDomainIDs(gSet))
+}
+
+func TestCoverPreviousGTIDs(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		flavor  string
+		gset    string
+		prev    string
+		covered bool
+	}{
+		{mysql.MySQLFlavor, "3ccc475b-2343-11e7-be21-6c0b84d59f30:1-14", "3ccc475b-2343-11e7-be21-6c0b84d59f30:1-10", true},
+		{mysql.MySQLFlavor, "3ccc475b-2343-11e7-be21-6c0b84d59f30:1-14", "3ccc475b-2343-11e7-be21-6c0b84d59f30:1-15", false},
+		{mysql.MySQLFlavor, "3ccc475b-2343-11e7-be21-6c0b84d59f30:1-14", "53ea0ed1-9bf8-11e6-8bea-64006a897c73:1-2", false},
+		{mysql.MariaDBFlavor, "0-1-100", "0-1-90", true},
+		{mysql.MariaDBFlavor, "0-1-100", "0-1-101", false},
+		{mysql.MariaDBFlavor, "0-1-100,1-2-20", "0-1-90,1-2-20", true},
+		{mysql.MariaDBFlavor, "0-1-100,1-2-20", "0-1-90,1-2-21", false},
+		// the domains absent from the GTID set are replicated from the beginning of the file
+		{mysql.MariaDBFlavor, "0-1-100", "0-1-90,1-2-20", true},
+		{mysql.MariaDBFlavor, "0-1-100", "0-1-101,1-2-20", false},
+		{mysql.MariaDBFlavor, "0-1-100,1-2-20", "", true},
+	}
+	for _, cs := range cases {
+		gSet, err := ParserGTID(cs.flavor, cs.gset)
+		require.NoError(t, err)
+		prevGSet, err := ParserGTID(cs.flavor, cs.prev)
+		require.NoError(t, err)
+		require.Equal(t, cs.covered, CoverPreviousGTIDs(gSet, prevGSet), "%s covers %s", cs.gset, cs.prev)
+	}
+}
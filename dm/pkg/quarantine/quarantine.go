@@ -0,0 +1,241 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package quarantine
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/util/dbutil"
+	"github.com/pingcap/tiflow/dm/pkg/conn"
+	tcontext "github.com/pingcap/tiflow/dm/pkg/context"
+	"github.com/pingcap/tiflow/dm/pkg/cputil"
+	"go.uber.org/zap"
+)
+
+// the status of a quarantined row.
+const (
+	StatusPending  = "pending"
+	StatusReplayed = "replayed"
+)
+
+// Statement is a SQL statement of a quarantined row. The arguments are kept as
+// raw bytes, so they can be replayed without losing the precision or the
+// binary content, a nil argument is NULL.
+type Statement struct {
+	SQL  string   `json:"sql"`
+	Args [][]byte `json:"args"`
+}
+
+// NewStatements converts the queries and arguments generated by the syncer
+// into statements.
+func NewStatements(queries []string, args [][]interface{}) []Statement {
+	stmts := make([]Statement, 0, len(queries))
+	for i, query := range queries {
+		stmt := Statement{SQL: query, Args: make([][]byte, 0, len(args[i]))}
+		for _, arg := range args[i] {
+			switch v := arg.(type) {
+			case nil:
+				stmt.Args = append(stmt.Args, nil)
+			case []byte:
+				stmt.Args = append(stmt.Args, v)
+			case string:
+				stmt.Args = append(stmt.Args, []byte(v))
+			default:
+				stmt.Args = append(stmt.Args, []byte(fmt.Sprintf("%v", v)))
+			}
+		}
+		stmts = append(stmts, stmt)
+	}
+	return stmts
+}
+
+func (s Statement) args() []interface{} {
+	args := make([]interface{}, 0, len(s.Args))
+	for _, arg := range s.Args {
+		if arg == nil {
+			args = append(args, nil)
+		} else {
+			args = append(args, arg)
+		}
+	}
+	return args
+}
+
+// Row is a row change which conflicts with a row in the downstream when
+// merging the sharded tables, it's quarantined instead of failing the task.
+type Row struct {
+	ID           int64
+	SourceID     string
+	SourceSchema string
+	SourceTable  string
+	TargetSchema string
+	TargetTable  string
+	// the binlog location of the row change.
+	Location   string
+	Statements []Statement
+	ErrMsg     string
+	Status     string
+	CreateTime string
+	UpdateTime string
+}
+
+// TableName returns the quoted name of the quarantine table of the task.
+func TableName(metaSchema, task string) string {
+	return dbutil.TableName(metaSchema, cputil.SyncerConflictQuarantine(task))
+}
+
+// CreateTableSQLs returns the SQLs to create the quarantine table.
+func CreateTableSQLs(metaSchema, task string) []string {
+	return []string{
+		fmt.Sprintf("CREATE SCHEMA IF NOT EXISTS %s", dbutil.ColumnName(metaSchema)),
+		`CREATE TABLE IF NOT EXISTS ` + TableName(metaSchema, task) + ` (
+			id BIGINT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+			source_id VARCHAR(32) NOT NULL,
+			source_schema VARCHAR(128) NOT NULL,
+			source_table VARCHAR(128) NOT NULL,
+			target_schema VARCHAR(128) NOT NULL,
+			target_table VARCHAR(128) NOT NULL,
+			location TEXT NOT NULL,
+			statements LONGTEXT NOT NULL,
+			error_msg TEXT NOT NULL,
+			status VARCHAR(16) NOT NULL,
+			create_time timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			update_time timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+			INDEX idx_status(status)
+		)`,
+	}
+}
+
+// InsertSQL returns the SQL and arguments to quarantine the row.
+func InsertSQL(metaSchema, task string, row *Row) (string, []interface{}, error) {
+	stmts, err := json.Marshal(row.Statements)
+	if err != nil {
+		return "", nil, errors.Trace(err)
+	}
+	query := "INSERT INTO " + TableName(metaSchema, task) +
+		" (source_id, source_schema, source_table, target_schema, target_table, location, statements, error_msg, status)" +
+		" VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)"
+	args := []interface{}{
+		row.SourceID, row.SourceSchema, row.SourceTable, row.TargetSchema, row.TargetTable,
+		row.Location, string(stmts), row.ErrMsg, StatusPending,
+	}
+	return query, args, nil
+}
+
+// List returns the quarantined rows of the task ordered by id, an empty
+// status returns the rows of all statuses.
+func List(tctx *tcontext.Context, db *conn.BaseDB, metaSchema, task, status string) ([]*Row, error) {
+	query := "SELECT id, source_id, source_schema, source_table, target_schema, target_table, location, statements, error_msg, status, create_time, update_time " +
+		"FROM " + TableName(metaSchema, task)
+	var args []interface{}
+	if status != "" {
+		query += " WHERE status = ?"
+		args = append(args, status)
+	}
+	query += " ORDER BY id"
+	rows, err := db.QueryContext(tctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make([]*Row, 0)
+	for rows.Next() {
+		var (
+			row   Row
+			stmts string
+		)
+		err = rows.Scan(&row.ID, &row.SourceID, &row.SourceSchema, &row.SourceTable, &row.TargetSchema, &row.TargetTable,
+			&row.Location, &stmts, &row.ErrMsg, &row.Status, &row.CreateTime, &row.UpdateTime)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if err = json.Unmarshal([]byte(stmts), &row.Statements); err != nil {
+			return nil, errors.Annotatef(err, "unmarshal statements of quarantined row %d", row.ID)
+		}
+		res = append(res, &row)
+	}
+	return res, errors.Trace(rows.Err())
+}
+
+// Replay executes the statements of the pending quarantined rows in the
+// downstream, and marks them as replayed. The statements of a row and the
+// status update are executed in one transaction, so a row is either replayed
+// or still pending when an error occurs. Empty ids replays all pending rows.
+// It returns the ids of the replayed rows.
+func Replay(tctx *tcontext.Context, db *conn.BaseDB, metaSchema, task string, ids []int64) ([]int64, error) {
+	rows, err := List(tctx, db, metaSchema, task, StatusPending)
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) > 0 {
+		pending := make(map[int64]*Row, len(rows))
+		for _, row := range rows {
+			pending[row.ID] = row
+		}
+		rows = rows[:0]
+		for _, id := range ids {
+			row, ok := pending[id]
+			if !ok {
+				return nil, errors.Errorf("quarantined row %d is not found or not pending", id)
+			}
+			rows = append(rows, row)
+		}
+	}
+
+	dbConn, err := db.GetBaseConn(tctx.Ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer db.CloseConnWithoutErr(dbConn)
+
+	replayed := make([]int64, 0, len(rows))
+	for _, row := range rows {
+		queries := make([]string, 0, len(row.Statements)+1)
+		args := make([][]interface{}, 0, len(row.Statements)+1)
+		for _, stmt := range row.Statements {
+			queries = append(queries, stmt.SQL)
+			args = append(args, stmt.args())
+		}
+		queries = append(queries, "UPDATE "+TableName(metaSchema, task)+" SET status = ? WHERE id = ? AND status = ?")
+		args = append(args, []interface{}{StatusReplayed, row.ID, StatusPending})
+		if _, err = dbConn.ExecuteSQL(tctx, nil, task, queries, args...); err != nil {
+			return replayed, errors.Annotatef(err, "replay quarantined row %d", row.ID)
+		}
+		tctx.L().Info("replay quarantined row", zap.Int64("id", row.ID),
+			zap.String("target", dbutil.TableName(row.TargetSchema, row.TargetTable)))
+		replayed = append(replayed, row.ID)
+	}
+	return replayed, nil
+}
+
+// SQLs returns the readable SQLs of the row, the arguments are shown as strings.
+func (r *Row) SQLs() []string {
+	sqls := make([]string, 0, len(r.Statements))
+	for _, stmt := range r.Statements {
+		args := make([]string, 0, len(stmt.Args))
+		for _, arg := range stmt.Args {
+			if arg == nil {
+				args = append(args, "NULL")
+			} else {
+				args = append(args, fmt.Sprintf("%q", arg))
+			}
+		}
+		sqls = append(sqls, fmt.Sprintf("%s; [%s]", stmt.SQL, strings.Join(args, ", ")))
+	}
+	return sqls
+}
@@ -0,0 +1,108 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package quarantine
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/pingcap/tiflow/dm/pkg/conn"
+	tcontext "github.com/pingcap/tiflow/dm/pkg/context"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewStatements(t *testing.T) {
+	t.Parallel()
+
+	stmts := NewStatements(
+		[]string{"INSERT INTO `db`.`t` (`id`,`name`,`data`,`f`) VALUES (?,?,?,?)"},
+		[][]interface{}{{int64(1), "a", []byte{0xff, 0x00}, nil}},
+	)
+	require.Equal(t, []Statement{{
+		SQL:  "INSERT INTO `db`.`t` (`id`,`name`,`data`,`f`) VALUES (?,?,?,?)",
+		Args: [][]byte{[]byte("1"), []byte("a"), {0xff, 0x00}, nil},
+	}}, stmts)
+
+	// binary content and NULL survive the JSON round trip.
+	data, err := json.Marshal(stmts)
+	require.NoError(t, err)
+	var decoded []Statement
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	require.Equal(t, stmts, decoded)
+	require.Equal(t, []interface{}{[]byte("1"), []byte("a"), []byte{0xff, 0x00}, nil}, decoded[0].args())
+
+	row := &Row{Statements: stmts}
+	require.Equal(t, []string{"INSERT INTO `db`.`t` (`id`,`name`,`data`,`f`) VALUES (?,?,?,?); [\"1\", \"a\", \"\\xff\\x00\", NULL]"}, row.SQLs())
+}
+
+func TestListAndReplay(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	baseDB := conn.NewBaseDBForTest(db)
+	tctx := tcontext.Background()
+
+	query, args, err := InsertSQL("dm_meta", "task", &Row{
+		SourceID:     "mysql-01",
+		SourceSchema: "shard_01",
+		SourceTable:  "t",
+		TargetSchema: "merged",
+		TargetTable:  "t",
+		Location:     "position: (mysql-bin.000001, 1234)",
+		Statements:   []Statement{{SQL: "INSERT INTO `merged`.`t` (`id`) VALUES (?)", Args: [][]byte{[]byte("1")}}},
+		ErrMsg:       "Duplicate entry '1' for key 'PRIMARY'",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "INSERT INTO `dm_meta`.`task_syncer_conflict_quarantine` (source_id, source_schema, source_table, target_schema, target_table, location, statements, error_msg, status) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)", query)
+	stmts := args[6].(string)
+	require.Equal(t, `[{"sql":"INSERT INTO `+"`merged`.`t`"+` (`+"`id`"+`) VALUES (?)","args":["MQ=="]}]`, stmts)
+	require.Equal(t, StatusPending, args[8])
+
+	columns := []string{"id", "source_id", "source_schema", "source_table", "target_schema", "target_table", "location", "statements", "error_msg", "status", "create_time", "update_time"}
+	listSQL := regexp.QuoteMeta("SELECT id, source_id, source_schema, source_table, target_schema, target_table, location, statements, error_msg, status, create_time, update_time FROM `dm_meta`.`task_syncer_conflict_quarantine` WHERE status = ? ORDER BY id")
+	pendingRows := func() *sqlmock.Rows {
+		return sqlmock.NewRows(columns).
+			AddRow(1, "mysql-01", "shard_01", "t", "merged", "t", "pos1", stmts, "dup", StatusPending, "2023-01-01 00:00:00", "2023-01-01 00:00:00").
+			AddRow(2, "mysql-02", "shard_02", "t", "merged", "t", "pos2", stmts, "dup", StatusPending, "2023-01-01 00:00:00", "2023-01-01 00:00:00")
+	}
+
+	mock.ExpectQuery(listSQL).WithArgs(StatusPending).WillReturnRows(pendingRows())
+	rows, err := List(tctx, baseDB, "dm_meta", "task", StatusPending)
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	require.Equal(t, "shard_02", rows[1].SourceSchema)
+	require.Equal(t, []Statement{{SQL: "INSERT INTO `merged`.`t` (`id`) VALUES (?)", Args: [][]byte{[]byte("1")}}}, rows[1].Statements)
+
+	// replay a row which is not pending
+	mock.ExpectQuery(listSQL).WithArgs(StatusPending).WillReturnRows(pendingRows())
+	_, err = Replay(tctx, baseDB, "dm_meta", "task", []int64{3})
+	require.ErrorContains(t, err, "quarantined row 3 is not found or not pending")
+
+	// replay the second row
+	mock.ExpectQuery(listSQL).WithArgs(StatusPending).WillReturnRows(pendingRows())
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `merged`.`t` (`id`) VALUES (?)")).
+		WithArgs(driver.Value([]byte("1"))).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `dm_meta`.`task_syncer_conflict_quarantine` SET status = ? WHERE id = ? AND status = ?")).
+		WithArgs(StatusReplayed, 2, StatusPending).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	replayed, err := Replay(tctx, baseDB, "dm_meta", "task", []int64{2})
+	require.NoError(t, err)
+	require.Equal(t, []int64{2}, replayed)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
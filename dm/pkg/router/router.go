@@ -0,0 +1,321 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pingcap/errors"
+	regexprrouter "github.com/pingcap/tidb/util/regexpr-router"
+	router "github.com/pingcap/tidb/util/table-router"
+)
+
+// referencePattern matches the capture group references in the targets,
+// `$$` is an escaped `$`.
+var referencePattern = regexp.MustCompile(`\$(\$|\d+|\{\w+\})`)
+
+// captureRule is a route rule whose target references the capture groups
+// of its regular expression patterns, e.g.
+//
+//	schema-pattern: "~^shard_(\d+)$"
+//	table-pattern: "~^t_(\d+)$"
+//	target-schema: "merged"
+//	target-table: "t_$1_$2"
+//
+// the capture groups of the schema pattern are numbered before the ones of the
+// table pattern, named groups can be referenced by `${name}`.
+type captureRule struct {
+	rule    *router.TableRule
+	schema  *regexp.Regexp
+	table   *regexp.Regexp
+	pattern *regexp.Regexp // combined pattern, only used to expand the targets
+
+	// the targets normalized for regexp.Expand.
+	targetSchema string
+	targetTable  string
+}
+
+// RouteTable is a regexprrouter.RouteTable which supports rewriting
+// the targets by the capture groups of the source patterns.
+type RouteTable struct {
+	*regexprrouter.RouteTable
+
+	// rules is ordered by table rules first, because they take precedence.
+	rules []*captureRule
+}
+
+// NewRouteTable creates a RouteTable.
+func NewRouteTable(caseSensitive bool, rules []*router.TableRule) (*RouteTable, error) {
+	r := &RouteTable{}
+	var tableRules, schemaRules []*captureRule
+	for _, rule := range rules {
+		cr, err := newCaptureRule(caseSensitive, rule)
+		if err != nil {
+			return nil, err
+		}
+		if cr == nil {
+			continue
+		}
+		if cr.table != nil {
+			tableRules = append(tableRules, cr)
+		} else {
+			schemaRules = append(schemaRules, cr)
+		}
+	}
+	r.rules = append(tableRules, schemaRules...)
+
+	var err error
+	r.RouteTable, err = regexprrouter.NewRegExprRouter(caseSensitive, rules)
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// HasCaptureGroupReference returns whether any target of the rules references
+// the capture groups.
+func HasCaptureGroupReference(rules []*router.TableRule) bool {
+	for _, rule := range rules {
+		if hasReference(rule) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasReference(rule *router.TableRule) bool {
+	for _, target := range []string{rule.TargetSchema, rule.TargetTable} {
+		for _, ref := range referencePattern.FindAllString(target, -1) {
+			if ref != "$$" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func newCaptureRule(caseSensitive bool, rule *router.TableRule) (*captureRule, error) {
+	if !hasReference(rule) {
+		return nil, nil
+	}
+	if !strings.HasPrefix(rule.SchemaPattern, "~") &&
+		(rule.TablePattern == "" || !strings.HasPrefix(rule.TablePattern, "~")) {
+		return nil, errors.Errorf("route rule %+v references capture groups, but neither schema pattern nor table pattern is a regular expression", rule)
+	}
+
+	cr := &captureRule{rule: rule}
+	compile := func(pattern string) (*regexp.Regexp, error) {
+		if !strings.HasPrefix(pattern, "~") {
+			// a wildcard or plain pattern has no capture group.
+			pattern = "~(?:)"
+		}
+		if !caseSensitive {
+			return regexp.Compile("(?i)" + pattern[1:])
+		}
+		return regexp.Compile(pattern[1:])
+	}
+	var err error
+	if cr.schema, err = compile(rule.SchemaPattern); err != nil {
+		return nil, errors.Annotatef(err, "route rule %+v", rule)
+	}
+	combined := "(?:" + cr.schema.String() + ")"
+	if rule.TablePattern != "" {
+		if cr.table, err = compile(rule.TablePattern); err != nil {
+			return nil, errors.Annotatef(err, "route rule %+v", rule)
+		}
+		combined += "(?:" + cr.table.String() + ")"
+	}
+	if cr.pattern, err = regexp.Compile(combined); err != nil {
+		return nil, errors.Annotatef(err, "route rule %+v", rule)
+	}
+
+	cr.targetSchema = normalizeTemplate(rule.TargetSchema)
+	cr.targetTable = normalizeTemplate(rule.TargetTable)
+
+	// check the references, regexp.Expand replaces an unknown group with an empty string silently.
+	names := make(map[string]struct{})
+	for _, name := range cr.pattern.SubexpNames() {
+		if name != "" {
+			names[name] = struct{}{}
+		}
+	}
+	for _, target := range []string{rule.TargetSchema, rule.TargetTable} {
+		for _, ref := range referencePattern.FindAllStringSubmatch(target, -1) {
+			group := strings.Trim(ref[1], "{}")
+			if group == "$" {
+				continue
+			}
+			if n, err2 := strconv.Atoi(group); err2 == nil {
+				if n > cr.pattern.NumSubexp() {
+					return nil, errors.Errorf("route rule %+v references capture group %d, but patterns only have %d groups", rule, n, cr.pattern.NumSubexp())
+				}
+				continue
+			}
+			if _, ok := names[group]; !ok {
+				return nil, errors.Errorf("route rule %+v references unknown capture group %s", rule, group)
+			}
+		}
+	}
+	return cr, nil
+}
+
+// normalizeTemplate converts the template for regexp.Expand, which takes the
+// longest name after `$`, so `$1_a` would be a reference to the group `1_a`.
+// A `$` which is not a reference is escaped.
+func normalizeTemplate(template string) string {
+	var b strings.Builder
+	last := 0
+	for _, loc := range referencePattern.FindAllStringSubmatchIndex(template, -1) {
+		b.WriteString(strings.ReplaceAll(template[last:loc[0]], "$", "$$"))
+		group := template[loc[2]:loc[3]]
+		switch {
+		case group == "$":
+			b.WriteString("$$")
+		case strings.HasPrefix(group, "{"):
+			b.WriteString("$" + group)
+		default:
+			b.WriteString("${" + group + "}")
+		}
+		last = loc[1]
+	}
+	b.WriteString(strings.ReplaceAll(template[last:], "$", "$$"))
+	return b.String()
+}
+
+// expand returns the targets of the rule for the source table, and returns
+// false if the source table doesn't match the rule.
+func (cr *captureRule) expand(schema, table string) (string, string, bool) {
+	match := cr.schema.FindStringSubmatchIndex(schema)
+	if match == nil {
+		return "", "", false
+	}
+	// expand the templates with the source schema and table concatenated,
+	// so the group indices of the table pattern are offset by len(schema).
+	src := schema
+	if cr.table != nil {
+		tableMatch := cr.table.FindStringSubmatchIndex(table)
+		if tableMatch == nil {
+			return "", "", false
+		}
+		// skip the whole match of the table pattern.
+		for _, idx := range tableMatch[2:] {
+			if idx >= 0 {
+				idx += len(schema)
+			}
+			match = append(match, idx)
+		}
+		src += table
+	}
+	targetSchema := string(cr.pattern.ExpandString(nil, cr.targetSchema, src, match))
+	targetTable := string(cr.pattern.ExpandString(nil, cr.targetTable, src, match))
+	return targetSchema, targetTable, true
+}
+
+// Route routes the source table to the target table, the capture groups
+// referenced by the targets of the matched rule are expanded.
+func (r *RouteTable) Route(schema, table string) (string, string, error) {
+	targetSchema, targetTable, _, err := r.route(schema, table)
+	return targetSchema, targetTable, err
+}
+
+func (r *RouteTable) route(schema, table string) (string, string, *captureRule, error) {
+	targetSchema, targetTable, err := r.RouteTable.Route(schema, table)
+	if err != nil || len(r.rules) == 0 {
+		return targetSchema, targetTable, nil, err
+	}
+	for _, cr := range r.rules {
+		// the embedded router has chosen the rule whose raw targets are returned.
+		if cr.rule.TargetSchema != targetSchema {
+			continue
+		}
+		if cr.rule.TargetTable != "" && cr.rule.TargetTable != targetTable {
+			continue
+		}
+		if cr.table != nil && table == "" {
+			continue
+		}
+		if s, t, ok := cr.expand(schema, table); ok {
+			if s == "" {
+				s = schema
+			}
+			if t == "" {
+				t = table
+			}
+			return s, t, cr, nil
+		}
+	}
+	return targetSchema, targetTable, nil, nil
+}
+
+// ExpandRules replaces the rules which reference capture groups with the rules
+// of the exact source tables, for the components which don't support capture
+// groups like lightning. tables is a map of schema -> tables.
+func ExpandRules(caseSensitive bool, rules []*router.TableRule, tables map[string][]string) ([]*router.TableRule, error) {
+	if !HasCaptureGroupReference(rules) {
+		return rules, nil
+	}
+	r, err := NewRouteTable(caseSensitive, cloneRules(rules))
+	if err != nil {
+		return nil, err
+	}
+
+	expanded := make([]*router.TableRule, 0, len(rules))
+	for _, rule := range rules {
+		if !hasReference(rule) {
+			expanded = append(expanded, rule)
+		}
+	}
+	for schema, tbs := range tables {
+		targetSchema, _, cr, err := r.route(schema, "")
+		if err != nil {
+			return nil, err
+		}
+		if cr != nil {
+			expanded = append(expanded, &router.TableRule{
+				SchemaPattern: schema,
+				TargetSchema:  targetSchema,
+			})
+		}
+		for _, table := range tbs {
+			targetSchema, targetTable, cr, err := r.route(schema, table)
+			if err != nil {
+				return nil, err
+			}
+			if cr == nil {
+				continue
+			}
+			expanded = append(expanded, &router.TableRule{
+				SchemaPattern:   schema,
+				TablePattern:    table,
+				TargetSchema:    targetSchema,
+				TargetTable:     targetTable,
+				TableExtractor:  cr.rule.TableExtractor,
+				SchemaExtractor: cr.rule.SchemaExtractor,
+				SourceExtractor: cr.rule.SourceExtractor,
+			})
+		}
+	}
+	return expanded, nil
+}
+
+func cloneRules(rules []*router.TableRule) []*router.TableRule {
+	cloned := make([]*router.TableRule, 0, len(rules))
+	for _, rule := range rules {
+		clone := *rule
+		cloned = append(cloned, &clone)
+	}
+	return cloned
+}
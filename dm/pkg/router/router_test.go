@@ -0,0 +1,129 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"sort"
+	"testing"
+
+	router "github.com/pingcap/tidb/util/table-router"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRouteWithCaptureGroups(t *testing.T) {
+	t.Parallel()
+
+	rules := []*router.TableRule{
+		// all shards to one table
+		{SchemaPattern: "~^shard_(\\d+)$", TablePattern: "t", TargetSchema: "merged", TargetTable: "t"},
+		// shards to a table per shard
+		{SchemaPattern: "~^shard_(\\d+)$", TablePattern: "~^log_(\\d+)$", TargetSchema: "merged", TargetTable: "log_$1_$2"},
+		// schema rule
+		{SchemaPattern: "~^archive_(\\w+)$", TargetSchema: "archive_$1_bak"},
+		// literal `$`
+		{SchemaPattern: "~^price_(\\d+)$", TablePattern: "*", TargetSchema: "price$$_${1}"},
+		{SchemaPattern: "plain", TargetSchema: "plain$x"},
+	}
+	r, err := NewRouteTable(false, rules)
+	require.NoError(t, err)
+
+	cases := []struct {
+		schema, table             string
+		targetSchema, targetTable string
+	}{
+		{"shard_01", "t", "merged", "t"},
+		{"shard_01", "log_202301", "merged", "log_01_202301"},
+		{"Shard_02", "LOG_202302", "merged", "log_02_202302"},
+		{"archive_2022", "t", "archive_2022_bak", "t"},
+		{"archive_2022", "", "archive_2022_bak", ""},
+		{"price_3", "t", "price$_3", "t"},
+		{"plain", "t", "plain$x", "t"},
+		{"other", "t", "other", "t"},
+	}
+	for _, c := range cases {
+		targetSchema, targetTable, err := r.Route(c.schema, c.table)
+		require.NoError(t, err)
+		require.Equal(t, c.targetSchema, targetSchema, "%s.%s", c.schema, c.table)
+		require.Equal(t, c.targetTable, targetTable, "%s.%s", c.schema, c.table)
+	}
+
+	// named groups, the patterns are lowercased if not case sensitive, so
+	// `(?P<name>)` is only available when case sensitive.
+	r, err = NewRouteTable(true, []*router.TableRule{
+		{SchemaPattern: "~^shard_(?P<shard>\\d+)$", TablePattern: "~^log_(?P<month>\\d+)$", TargetSchema: "merged", TargetTable: "log_${shard}_${month}"},
+	})
+	require.NoError(t, err)
+	targetSchema, targetTable, err := r.Route("shard_01", "log_202301")
+	require.NoError(t, err)
+	require.Equal(t, "merged", targetSchema)
+	require.Equal(t, "log_01_202301", targetTable)
+}
+
+func TestInvalidCaptureGroups(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewRouteTable(false, []*router.TableRule{
+		{SchemaPattern: "shard_*", TargetSchema: "merged_$1"},
+	})
+	require.ErrorContains(t, err, "neither schema pattern nor table pattern is a regular expression")
+
+	_, err = NewRouteTable(false, []*router.TableRule{
+		{SchemaPattern: "~shard_(\\d+)", TablePattern: "t", TargetSchema: "merged", TargetTable: "t_$2"},
+	})
+	require.ErrorContains(t, err, "references capture group 2, but patterns only have 1 groups")
+
+	_, err = NewRouteTable(false, []*router.TableRule{
+		{SchemaPattern: "~shard_(\\d+)", TargetSchema: "merged_${id}"},
+	})
+	require.ErrorContains(t, err, "references unknown capture group id")
+}
+
+func TestExpandRules(t *testing.T) {
+	t.Parallel()
+
+	plain := &router.TableRule{SchemaPattern: "db", TablePattern: "t", TargetSchema: "db2", TargetTable: "t2"}
+	rules := []*router.TableRule{
+		plain,
+		{SchemaPattern: "~^shard_(\\d+)$", TablePattern: "~^t_(\\d+)$", TargetSchema: "merged", TargetTable: "t_$1_$2"},
+		{SchemaPattern: "~^shard_(\\d+)$", TargetSchema: "shard_$1_bak"},
+	}
+
+	expanded, err := ExpandRules(false, []*router.TableRule{plain}, nil)
+	require.NoError(t, err)
+	require.Equal(t, []*router.TableRule{plain}, expanded)
+
+	expanded, err = ExpandRules(false, rules, map[string][]string{
+		"db":       {"t"},
+		"shard_01": {"t_1", "other"},
+	})
+	require.NoError(t, err)
+	sort.Slice(expanded[1:], func(i, j int) bool {
+		a, b := expanded[1+i], expanded[1+j]
+		return a.SchemaPattern+"."+a.TablePattern < b.SchemaPattern+"."+b.TablePattern
+	})
+	require.Equal(t, []*router.TableRule{
+		plain,
+		{SchemaPattern: "shard_01", TargetSchema: "shard_01_bak"},
+		{SchemaPattern: "shard_01", TablePattern: "other", TargetSchema: "shard_01_bak", TargetTable: "other"},
+		{SchemaPattern: "shard_01", TablePattern: "t_1", TargetSchema: "merged", TargetTable: "t_01_1"},
+	}, expanded)
+
+	// the expanded rules route the tables as same as the capture groups.
+	r, err := NewRouteTable(false, expanded)
+	require.NoError(t, err)
+	targetSchema, targetTable, err := r.Route("shard_01", "t_1")
+	require.NoError(t, err)
+	require.Equal(t, "merged", targetSchema)
+	require.Equal(t, "t_01_1", targetTable)
+}
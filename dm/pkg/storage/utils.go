@@ -129,6 +129,25 @@ func CollectDirFiles(ctx context.Context, dir string, storage bstorage.ExternalS
 	return files, err
 }
 
+// CollectDirFileSizes gets files in dir and their sizes.
+func CollectDirFileSizes(ctx context.Context, dir string, storage bstorage.ExternalStorage) (map[string]int64, error) {
+	var err error
+	if storage == nil {
+		storage, err = CreateStorage(ctx, dir)
+		if err != nil {
+			return nil, err
+		}
+	}
+	files := make(map[string]int64)
+
+	err = storage.WalkDir(ctx, &bstorage.WalkOption{}, func(filePath string, size int64) error {
+		files[path.Base(filePath)] = size
+		return nil
+	})
+
+	return files, err
+}
+
 // RemoveAll remove files in dir.
 func RemoveAll(ctx context.Context, dir string, storage bstorage.ExternalStorage) error {
 	var err error
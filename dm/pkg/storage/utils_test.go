@@ -278,6 +278,21 @@ func TestCollectDirFilesAndRemove(t *testing.T) {
 	}
 }
 
+func TestCollectDirFileSizes(t *testing.T) {
+	localDir := t.TempDir()
+	err := os.WriteFile(path.Join(localDir, "db.tbl.000000000.sql"), []byte("insert"), 0o644)
+	require.NoError(t, err)
+	err = os.WriteFile(path.Join(localDir, "db.tbl-schema.sql"), nil, 0o644)
+	require.NoError(t, err)
+
+	sizes, err := CollectDirFileSizes(context.Background(), localDir, nil)
+	require.NoError(t, err)
+	require.Equal(t, map[string]int64{
+		"db.tbl.000000000.sql": 6,
+		"db.tbl-schema.sql":    0,
+	}, sizes)
+}
+
 func TestRemoveAll(t *testing.T) {
 	fileNames := []string{"schema.sql", "table.sql"}
 
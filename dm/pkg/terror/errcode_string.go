@@ -427,6 +427,7 @@ func _() {
 	_ = x[codeMasterOptimisticDownstreamMetaNotFound-38056]
 	_ = x[codeMasterInvalidClusterID-38057]
 	_ = x[codeMasterStartTask-38058]
+	_ = x[codeMasterAlertConfigInvalid-38059]
 	_ = x[codeWorkerParseFlagSet-40001]
 	_ = x[codeWorkerInvalidFlag-40002]
 	_ = x[codeWorkerDecodeConfigFromFile-40003]
@@ -596,7 +597,7 @@ func _() {
 	_ = x[codeNotSet-50000]
 }
 
-const _ErrCode_name = "DBDriverErrorDBBadConnDBInvalidConnDBUnExpectDBQueryFailedDBExecuteFailedParseMydumperMetaGetFileSizeDropMultipleTablesRenameMultipleTablesAlterMultipleTablesParseSQLUnknownTypeDDLRestoreASTNodeParseGTIDNotSupportedFlavorNotMySQLGTIDNotMariaDBGTIDNotUUIDStringMariaDBDomainIDInvalidServerIDGetSQLModeFromStrVerifySQLOperateArgsStatFileSizeReaderAlreadyRunningReaderAlreadyStartedReaderStateCannotCloseReaderShouldStartSyncEmptyRelayDirReadDirBaseFileNotFoundBinFileCmpCondNotSupportBinlogFileNotValidBinlogFilesNotFoundGetRelayLogStatAddWatchForRelayLogDirWatcherStartWatcherChanClosedWatcherChanRecvErrorRelayLogFileSizeSmallerBinlogFileNotSpecifiedNoRelayLogMatchPosFirstRelayLogNotMatchPosParserParseRelayLogNoSubdirToSwitchNeedSyncAgainSyncClosedSchemaTableNameNotValidGenTableRouterEncryptSecretKeyNotValidEncryptGenCipherEncryptGenIVCiphertextLenNotValidCiphertextContextNotValidInvalidBinlogPosStrEncCipherTextBase64DecodeBinlogWriteBinaryDataBinlogWriteDataToBufferBinlogHeaderLengthNotValidBinlogEventDecodeBinlogEmptyNextBinNameBinlogParseSIDBinlogEmptyGTIDBinlogGTIDSetNotValidBinlogGTIDMySQLNotValidBinlogGTIDMariaDBNotValidBinlogMariaDBServerIDMismatchBinlogOnlyOneGTIDSupportBinlogOnlyOneIntervalInUUIDBinlogIntervalValueNotValidBinlogEmptyQueryBinlogTableMapEvNotValidBinlogExpectFormatDescEvBinlogExpectTableMapEvBinlogExpectRowsEvBinlogUnexpectedEvBinlogParseSingleEvBinlogEventTypeNotValidBinlogEventNoRowsBinlogEventNoColumnsBinlogEventRowLengthNotEqBinlogColumnTypeNotSupportBinlogGoMySQLTypeNotSupportBinlogColumnTypeMisMatchBinlogDummyEvSizeTooSmallBinlogFlavorNotSupportBinlogDMLEmptyDataBinlogLatestGTIDNotInPrevBinlogReadFileByGTIDBinlogWriterNotStateNewBinlogWriterStateCannotCloseBinlogWriterNeedStartBinlogWriterOpenFileBinlogWriterGetFileStatBinlogWriterWriteDataLenBinlogWriterFileNotOpenedBinlogWriterFileSyncBinlogPrevGTIDEvNotValidBinlogDecodeMySQLGTIDSetBinlogNeedMariaDBGTIDSetBinlogParseMariaDBGTIDSetBinlogMariaDBAddGTIDSetTracingEventDataNotValidTracingUploadDataTracingEventTypeNotValidTracingGetTraceCodeTracingDataChecksumTracingGetTSOBackoffArgsNotValidInitLoggerFailGTIDTruncateInvalidRelayLogGivenPosTooBigElectionCampaignFailElectionGetLeaderIDFailBinlogInvalidFilenameWithUUIDSuffixDecodeEtcdKeyFailShardDDLOptimismTrySyncFailConnInvalidTLSConfigConnRegistryTLSConfigUpgradeVersionEtcdFailInvalidV1WorkerMetaPathFailUpdateV1DBSchemaBinlogStatusVarsParseVerifyHandleErrorArgsRewriteSQLNoUUIDDirMatchGTIDNoRelayPosMatchGTIDReaderReachEndOfFileMetadataNoBinlogLocPreviousGTIDNotExistNoMasterStatusBinlogNotLogColumnShardDDLOptimismNeedSkipAndRedirectShardDDLOptimismAddNotFullyDroppedColumnSyncerCancelledDDLIncorrectReturnColumnsNumConfigCheckItemNotSupportConfigTomlTransformConfigYamlTransformConfigTaskNameEmptyConfigEmptySourceIDConfigTooLongSourceIDConfigOnlineSchemeNotSupportConfigInvalidTimezoneConfigParseFlagSetConfigDecryptDBPasswordConfigMetaInvalidConfigMySQLInstNotFoundConfigMySQLInstsAtLeastOneConfigMySQLInstSameSourceIDConfigMydumperCfgConflictConfigLoaderCfgConflictConfigSyncerCfgConflictConfigReadCfgFromFileConfigNeedUniqueTaskNameConfigInvalidTaskModeConfigNeedTargetDBConfigMetadataNotSetConfigRouteRuleNotFoundConfigFilterRuleNotFoundConfigColumnMappingNotFoundConfigBAListNotFoundConfigMydumperCfgNotFoundConfigMydumperPathNotValidConfigLoaderCfgNotFoundConfigSyncerCfgNotFoundConfigSourceIDNotFoundConfigDuplicateCfgItemConfigShardModeNotSupportConfigMoreThanOneConfigEtcdParseConfigMissingForBoundConfigBinlogEventFilterConfigGlobalConfigsUnusedConfigExprFilterManyExprConfigExprFilterNotFoundConfigExprFilterWrongGrammarConfigExprFilterEmptyNameConfigCheckerMaxTooSmallConfigGenBAListConfigGenTableRouterConfigGenColumnMappingConfigInvalidChunkFileSizeConfigOnlineDDLInvalidRegexConfigOnlineDDLMistakeRegexConfigOpenAPITaskConfigExistConfigOpenAPITaskConfigNotExistCollationCompatibleNotSupportConfigInvalidLoadModeConfigInvalidLoadDuplicateResolutionConfigValidationModeContinuousValidatorCfgNotFoundConfigStartTimeTooLateConfigLoaderDirInvalidConfigLoaderS3NotSupportConfigInvalidSafeModeDurationConfigConfictSafeModeDurationAndSafeModeConfigInvalidLoadPhysicalDuplicateResolutionConfigInvalidLoadPhysicalChecksumConfigColumnMappingDeprecatedBinlogExtractPositionBinlogInvalidFilenameBinlogParsePosFromStrCheckpointInvalidTaskModeCheckpointSaveInvalidPosCheckpointInvalidTableFileCheckpointDBNotExistInFileCheckpointTableNotExistInFileCheckpointRestoreCountGreaterTaskCheckSameTableNameTaskCheckFailedOpenDBTaskCheckGenTableRouterTaskCheckGenColumnMappingTaskCheckSyncConfigErrorTaskCheckGenBAListSourceCheckGTIDRelayParseUUIDIndexRelayParseUUIDSuffixRelayUUIDWithSuffixNotFoundRelayGenFakeRotateEventRelayNoValidRelaySubDirRelayUUIDSuffixNotValidRelayUUIDSuffixLessThanPrevRelayLoadMetaDataRelayBinlogNameNotValidRelayNoCurrentUUIDRelayFlushLocalMetaRelayUpdateIndexFileRelayLogDirpathEmptyRelayReaderNotStateNewRelayReaderStateCannotCloseRelayReaderNeedStartRelayTCPReaderStartSyncRelayTCPReaderNilGTIDRelayTCPReaderStartSyncGTIDRelayTCPReaderGetEventRelayWriterNotStateNewRelayWriterStateCannotCloseRelayWriterNeedStartRelayWriterNotOpenedRelayWriterExpectRotateEvRelayWriterRotateEvWithNoWriterRelayWriterStatusNotValidRelayWriterGetFileStatRelayWriterLatestPosGTFileSizeRelayWriterFileOperateRelayCheckBinlogFileHeaderExistRelayCheckFormatDescEventExistRelayCheckFormatDescEventParseEvRelayCheckIsDuplicateEventRelayUpdateGTIDRelayNeedPrevGTIDEvBeforeGTIDEvRelayNeedMaGTIDListEvBeforeGTIDEvRelayMkdirRelaySwitchMasterNeedGTIDRelayThisStrategyIsPurgingRelayOtherStrategyIsPurgingRelayPurgeIsForbiddenRelayNoActiveRelayLogRelayPurgeRequestNotValidRelayTrimUUIDNotFoundRelayRemoveFileFailRelayPurgeArgsNotValidPreviousGTIDsNotValidRotateEventWithDifferentServerIDDumpUnitRuntimeDumpUnitGenTableRouterDumpUnitGenBAListDumpUnitGlobalLockLoadUnitCreateSchemaFileLoadUnitInvalidFileEndingLoadUnitParseQuoteValuesLoadUnitDoColumnMappingLoadUnitReadSchemaFileLoadUnitParseStatementLoadUnitNotCreateTableLoadUnitDispatchSQLFromFileLoadUnitInvalidInsertSQLLoadUnitGenTableRouterLoadUnitGenColumnMappingLoadUnitNoDBFileLoadUnitNoTableFileLoadUnitDumpDirNotFoundLoadUnitDuplicateTableFileLoadUnitGenBAListLoadTaskWorkerNotMatchLoadCheckPointNotMatchLoadLightningRuntimeLoadLightningHasDupLoadLightningChecksumSyncerUnitPanicSyncUnitInvalidTableNameSyncUnitTableNameQuerySyncUnitNotSupportedDMLSyncUnitAddTableInShardingSyncUnitDropSchemaTableInShardingSyncUnitInvalidShardMetaSyncUnitDDLWrongSequenceSyncUnitDDLActiveIndexLargerSyncUnitDupTableGroupSyncUnitShardingGroupNotFoundSyncUnitSafeModeSetCountSyncUnitCausalityConflictSyncUnitDMLStatementFoundSyncerUnitBinlogEventFilterSyncerUnitInvalidReplicaEventSyncerUnitParseStmtSyncerUnitUUIDNotLatestSyncerUnitDDLExecChanCloseOrBusySyncerUnitDDLChanDoneSyncerUnitDDLChanCanceledSyncerUnitDDLOnMultipleTableSyncerUnitInjectDDLOnlySyncerUnitInjectDDLWithoutSchemaSyncerUnitNotSupportedOperateSyncerUnitNilOperatorReqSyncerUnitDMLColumnNotMatchSyncerUnitDMLOldNewValueMismatchSyncerUnitDMLPruneColumnMismatchSyncerUnitGenBinlogEventFilterSyncerUnitGenTableRouterSyncerUnitGenColumnMappingSyncerUnitDoColumnMappingSyncerUnitCacheKeyNotFoundSyncerUnitHeartbeatCheckConfigSyncerUnitHeartbeatRecordExistsSyncerUnitHeartbeatRecordNotFoundSyncerUnitHeartbeatRecordNotValidSyncerUnitOnlineDDLInvalidMetaSyncerUnitOnlineDDLSchemeNotSupportSyncerUnitOnlineDDLOnMultipleTableSyncerUnitGhostApplyEmptyTableSyncerUnitGhostRenameTableNotValidSyncerUnitGhostRenameToGhostTableSyncerUnitGhostRenameGhostTblToOtherSyncerUnitGhostOnlineDDLOnGhostTblSyncerUnitPTApplyEmptyTableSyncerUnitPTRenameTableNotValidSyncerUnitPTRenameToPTTableSyncerUnitPTRenamePTTblToOtherSyncerUnitPTOnlineDDLOnPTTblSyncerUnitRemoteSteamerWithGTIDSyncerUnitRemoteSteamerStartSyncSyncerUnitGetTableFromDBSyncerUnitFirstEndPosNotFoundSyncerUnitResolveCasualityFailSyncerUnitReopenStreamNotSupportSyncerUnitUpdateConfigInShardingSyncerUnitExecWithNoBlockingDDLSyncerUnitGenBAListSyncerUnitHandleDDLFailedSyncerShardDDLConflictSyncerFailpointSyncerEventSyncerOperatorNotExistSyncerEventNotExistSyncerParseDDLSyncerUnsupportedStmtSyncerGetEventSyncerDownstreamTableNotFoundSyncerReprocessWithSafeModeFailMasterSQLOpNilRequestMasterSQLOpNotSupportMasterSQLOpWithoutShardingMasterGRPCCreateConnMasterGRPCSendOnCloseConnMasterGRPCClientCloseMasterGRPCInvalidReqTypeMasterGRPCRequestErrorMasterDeployMapperVerifyMasterConfigParseFlagSetMasterConfigUnknownItemMasterConfigInvalidFlagMasterConfigTomlTransformMasterConfigTimeoutParseMasterConfigUpdateCfgFileMasterShardingDDLDiffMasterStartServiceMasterNoEmitTokenMasterLockNotFoundMasterLockIsResolvingMasterWorkerCliNotFoundMasterWorkerNotWaitLockMasterHandleSQLReqFailMasterOwnerExecDDLMasterPartWorkerExecDDLFailMasterWorkerExistDDLLockMasterGetWorkerCfgExtractorMasterTaskConfigExtractorMasterWorkerArgsExtractorMasterQueryWorkerConfigMasterOperNotFoundMasterOperRespNotSuccessMasterOperRequestTimeoutMasterHandleHTTPApisMasterHostPortNotValidMasterGetHostnameFailMasterGenEmbedEtcdConfigFailMasterStartEmbedEtcdFailMasterParseURLFailMasterJoinEmbedEtcdFailMasterInvalidOperateOpMasterAdvertiseAddrNotValidMasterRequestIsNotForwardToLeaderMasterIsNotAsyncRequestMasterFailToGetExpectResultMasterPessimistNotStartedMasterOptimistNotStartedMasterMasterNameNotExistMasterInvalidOfflineTypeMasterAdvertisePeerURLsNotValidMasterTLSConfigNotValidMasterBoundChangingMasterFailToImportFromV10xMasterInconsistentOptimistDDLsAndInfoMasterOptimisticTableInfobeforeNotExistMasterOptimisticDownstreamMetaNotFoundMasterInvalidClusterIDMasterStartTaskWorkerParseFlagSetWorkerInvalidFlagWorkerDecodeConfigFromFileWorkerUndecodedItemFromFileWorkerNeedSourceIDWorkerTooLongSourceIDWorkerRelayBinlogNameWorkerWriteConfigFileWorkerLogInvalidHandlerWorkerLogPointerInvalidWorkerLogFetchPointerWorkerLogUnmarshalPointerWorkerLogClearPointerWorkerLogTaskKeyNotValidWorkerLogUnmarshalTaskKeyWorkerLogFetchLogIterWorkerLogGetTaskLogWorkerLogUnmarshalBinaryWorkerLogForwardPointerWorkerLogMarshalTaskWorkerLogSaveTaskWorkerLogDeleteKVWorkerLogDeleteKVIterWorkerLogUnmarshalTaskMetaWorkerLogFetchTaskFromMetaWorkerLogVerifyTaskMetaWorkerLogSaveTaskMetaWorkerLogGetTaskMetaWorkerLogDeleteTaskMetaWorkerMetaTomlTransformWorkerMetaOldFileStatWorkerMetaOldReadFileWorkerMetaEncodeTaskWorkerMetaRemoveOldDirWorkerMetaTaskLogNotFoundWorkerMetaHandleTaskOrderWorkerMetaOpenTxnWorkerMetaCommitTxnWorkerRelayStageNotValidWorkerRelayOperNotSupportWorkerOpenKVDBFileWorkerUpgradeCheckKVDirWorkerMarshalVerBinaryWorkerUnmarshalVerBinaryWorkerGetVersionFromKVWorkerSaveVersionToKVWorkerVerAutoDowngradeWorkerStartServiceWorkerAlreadyClosedWorkerNotRunningStageWorkerNotPausedStageWorkerUpdateTaskStageWorkerMigrateStopRelayWorkerSubTaskNotFoundWorkerSubTaskExistsWorkerOperSyncUnitOnlyWorkerRelayUnitStageWorkerNoSyncerRunningWorkerCannotUpdateSourceIDWorkerNoAvailUnitsWorkerDDLLockInfoNotFoundWorkerDDLLockInfoExistsWorkerCacheDDLInfoExistsWorkerExecSkipDDLConflictWorkerExecDDLSyncerOnlyWorkerExecDDLTimeoutWorkerWaitRelayCatchupTimeoutWorkerRelayIsPurgingWorkerHostPortNotValidWorkerNoStartWorkerAlreadyStartedWorkerSourceNotMatchWorkerFailToGetSubtaskConfigFromEtcdWorkerFailToGetSourceConfigFromEtcdWorkerDDLLockOpNotFoundWorkerTLSConfigNotValidWorkerFailConnectMasterWorkerWaitRelayCatchupGTIDWorkerRelayConfigChangingWorkerRouteTableDupMatchWorkerUpdateSubTaskConfigWorkerValidatorNotPausedWorkerServerClosedTracerParseFlagSetTracerConfigTomlTransformTracerConfigInvalidFlagTracerTraceEventNotFoundTracerTraceIDNotProvidedTracerParamNotValidTracerPostMethodOnlyTracerEventAssertionFailTracerEventTypeNotValidTracerStartServiceHAFailTxnOperationHAInvalidItemHAFailWatchEtcdHAFailLeaseOperationHAFailKeepaliveValidatorLoadPersistedDataValidatorPersistDataValidatorGetEventValidatorProcessRowEventValidatorValidateChangeValidatorNotFoundValidatorPanicValidatorTooMuchPendingSchemaTrackerInvalidJSONSchemaTrackerCannotCreateSchemaSchemaTrackerCannotCreateTableSchemaTrackerCannotSerializeSchemaTrackerCannotGetTableSchemaTrackerCannotExecDDLSchemaTrackerCannotFetchDownstreamTableSchemaTrackerCannotParseDownstreamTableSchemaTrackerInvalidCreateTableStmtSchemaTrackerRestoreStmtFailSchemaTrackerCannotDropTableSchemaTrackerInitSchemaTrackerMarshalJSONSchemaTrackerUnMarshalJSONSchemaTrackerUnSchemaNotExistSchemaTrackerCannotSetDownstreamSQLModeSchemaTrackerCannotInitDownstreamParserSchemaTrackerCannotMockDownstreamTableSchemaTrackerCannotFetchDownstreamCreateTableStmtSchemaTrackerIsClosedSchedulerNotStartedSchedulerStartedSchedulerWorkerExistSchedulerWorkerNotExistSchedulerWorkerOnlineSchedulerWorkerInvalidTransSchedulerSourceCfgExistSchedulerSourceCfgNotExistSchedulerSourcesUnboundSchedulerSourceOpTaskExistSchedulerRelayStageInvalidUpdateSchedulerRelayStageSourceNotExistSchedulerMultiTaskSchedulerSubTaskExistSchedulerSubTaskStageInvalidUpdateSchedulerSubTaskOpTaskNotExistSchedulerSubTaskOpSourceNotExistSchedulerTaskNotExistSchedulerRequireRunningTaskInSyncUnitSchedulerRelayWorkersBusySchedulerRelayWorkersBoundSchedulerRelayWorkersWrongRelaySchedulerSourceOpRelayExistSchedulerLatchInUseSchedulerSourceCfgUpdateSchedulerWrongWorkerInputSchedulerCantTransferToRelayWorkerSchedulerStartRelayOnSpecifiedSchedulerStopRelayOnSpecifiedSchedulerStartRelayOnBoundSchedulerStopRelayOnBoundSchedulerPauseTaskForTransferSourceSchedulerWorkerNotFreeSchedulerSubTaskNotExistSchedulerSubTaskCfgUpdateCtlGRPCCreateConnCtlInvalidTLSCfgCtlLoadTLSCfgOpenAPICommonOpenAPITaskSourceNotFoundNotSet"
+const _ErrCode_name = "DBDriverErrorDBBadConnDBInvalidConnDBUnExpectDBQueryFailedDBExecuteFailedParseMydumperMetaGetFileSizeDropMultipleTablesRenameMultipleTablesAlterMultipleTablesParseSQLUnknownTypeDDLRestoreASTNodeParseGTIDNotSupportedFlavorNotMySQLGTIDNotMariaDBGTIDNotUUIDStringMariaDBDomainIDInvalidServerIDGetSQLModeFromStrVerifySQLOperateArgsStatFileSizeReaderAlreadyRunningReaderAlreadyStartedReaderStateCannotCloseReaderShouldStartSyncEmptyRelayDirReadDirBaseFileNotFoundBinFileCmpCondNotSupportBinlogFileNotValidBinlogFilesNotFoundGetRelayLogStatAddWatchForRelayLogDirWatcherStartWatcherChanClosedWatcherChanRecvErrorRelayLogFileSizeSmallerBinlogFileNotSpecifiedNoRelayLogMatchPosFirstRelayLogNotMatchPosParserParseRelayLogNoSubdirToSwitchNeedSyncAgainSyncClosedSchemaTableNameNotValidGenTableRouterEncryptSecretKeyNotValidEncryptGenCipherEncryptGenIVCiphertextLenNotValidCiphertextContextNotValidInvalidBinlogPosStrEncCipherTextBase64DecodeBinlogWriteBinaryDataBinlogWriteDataToBufferBinlogHeaderLengthNotValidBinlogEventDecodeBinlogEmptyNextBinNameBinlogParseSIDBinlogEmptyGTIDBinlogGTIDSetNotValidBinlogGTIDMySQLNotValidBinlogGTIDMariaDBNotValidBinlogMariaDBServerIDMismatchBinlogOnlyOneGTIDSupportBinlogOnlyOneIntervalInUUIDBinlogIntervalValueNotValidBinlogEmptyQueryBinlogTableMapEvNotValidBinlogExpectFormatDescEvBinlogExpectTableMapEvBinlogExpectRowsEvBinlogUnexpectedEvBinlogParseSingleEvBinlogEventTypeNotValidBinlogEventNoRowsBinlogEventNoColumnsBinlogEventRowLengthNotEqBinlogColumnTypeNotSupportBinlogGoMySQLTypeNotSupportBinlogColumnTypeMisMatchBinlogDummyEvSizeTooSmallBinlogFlavorNotSupportBinlogDMLEmptyDataBinlogLatestGTIDNotInPrevBinlogReadFileByGTIDBinlogWriterNotStateNewBinlogWriterStateCannotCloseBinlogWriterNeedStartBinlogWriterOpenFileBinlogWriterGetFileStatBinlogWriterWriteDataLenBinlogWriterFileNotOpenedBinlogWriterFileSyncBinlogPrevGTIDEvNotValidBinlogDecodeMySQLGTIDSetBinlogNeedMariaDBGTIDSetBinlogParseMariaDBGTIDSetBinlogMariaDBAddGTIDSetTracingEventDataNotValidTracingUploadDataTracingEventTypeNotValidTracingGetTraceCodeTracingDataChecksumTracingGetTSOBackoffArgsNotValidInitLoggerFailGTIDTruncateInvalidRelayLogGivenPosTooBigElectionCampaignFailElectionGetLeaderIDFailBinlogInvalidFilenameWithUUIDSuffixDecodeEtcdKeyFailShardDDLOptimismTrySyncFailConnInvalidTLSConfigConnRegistryTLSConfigUpgradeVersionEtcdFailInvalidV1WorkerMetaPathFailUpdateV1DBSchemaBinlogStatusVarsParseVerifyHandleErrorArgsRewriteSQLNoUUIDDirMatchGTIDNoRelayPosMatchGTIDReaderReachEndOfFileMetadataNoBinlogLocPreviousGTIDNotExistNoMasterStatusBinlogNotLogColumnShardDDLOptimismNeedSkipAndRedirectShardDDLOptimismAddNotFullyDroppedColumnSyncerCancelledDDLIncorrectReturnColumnsNumConfigCheckItemNotSupportConfigTomlTransformConfigYamlTransformConfigTaskNameEmptyConfigEmptySourceIDConfigTooLongSourceIDConfigOnlineSchemeNotSupportConfigInvalidTimezoneConfigParseFlagSetConfigDecryptDBPasswordConfigMetaInvalidConfigMySQLInstNotFoundConfigMySQLInstsAtLeastOneConfigMySQLInstSameSourceIDConfigMydumperCfgConflictConfigLoaderCfgConflictConfigSyncerCfgConflictConfigReadCfgFromFileConfigNeedUniqueTaskNameConfigInvalidTaskModeConfigNeedTargetDBConfigMetadataNotSetConfigRouteRuleNotFoundConfigFilterRuleNotFoundConfigColumnMappingNotFoundConfigBAListNotFoundConfigMydumperCfgNotFoundConfigMydumperPathNotValidConfigLoaderCfgNotFoundConfigSyncerCfgNotFoundConfigSourceIDNotFoundConfigDuplicateCfgItemConfigShardModeNotSupportConfigMoreThanOneConfigEtcdParseConfigMissingForBoundConfigBinlogEventFilterConfigGlobalConfigsUnusedConfigExprFilterManyExprConfigExprFilterNotFoundConfigExprFilterWrongGrammarConfigExprFilterEmptyNameConfigCheckerMaxTooSmallConfigGenBAListConfigGenTableRouterConfigGenColumnMappingConfigInvalidChunkFileSizeConfigOnlineDDLInvalidRegexConfigOnlineDDLMistakeRegexConfigOpenAPITaskConfigExistConfigOpenAPITaskConfigNotExistCollationCompatibleNotSupportConfigInvalidLoadModeConfigInvalidLoadDuplicateResolutionConfigValidationModeContinuousValidatorCfgNotFoundConfigStartTimeTooLateConfigLoaderDirInvalidConfigLoaderS3NotSupportConfigInvalidSafeModeDurationConfigConfictSafeModeDurationAndSafeModeConfigInvalidLoadPhysicalDuplicateResolutionConfigInvalidLoadPhysicalChecksumConfigColumnMappingDeprecatedBinlogExtractPositionBinlogInvalidFilenameBinlogParsePosFromStrCheckpointInvalidTaskModeCheckpointSaveInvalidPosCheckpointInvalidTableFileCheckpointDBNotExistInFileCheckpointTableNotExistInFileCheckpointRestoreCountGreaterTaskCheckSameTableNameTaskCheckFailedOpenDBTaskCheckGenTableRouterTaskCheckGenColumnMappingTaskCheckSyncConfigErrorTaskCheckGenBAListSourceCheckGTIDRelayParseUUIDIndexRelayParseUUIDSuffixRelayUUIDWithSuffixNotFoundRelayGenFakeRotateEventRelayNoValidRelaySubDirRelayUUIDSuffixNotValidRelayUUIDSuffixLessThanPrevRelayLoadMetaDataRelayBinlogNameNotValidRelayNoCurrentUUIDRelayFlushLocalMetaRelayUpdateIndexFileRelayLogDirpathEmptyRelayReaderNotStateNewRelayReaderStateCannotCloseRelayReaderNeedStartRelayTCPReaderStartSyncRelayTCPReaderNilGTIDRelayTCPReaderStartSyncGTIDRelayTCPReaderGetEventRelayWriterNotStateNewRelayWriterStateCannotCloseRelayWriterNeedStartRelayWriterNotOpenedRelayWriterExpectRotateEvRelayWriterRotateEvWithNoWriterRelayWriterStatusNotValidRelayWriterGetFileStatRelayWriterLatestPosGTFileSizeRelayWriterFileOperateRelayCheckBinlogFileHeaderExistRelayCheckFormatDescEventExistRelayCheckFormatDescEventParseEvRelayCheckIsDuplicateEventRelayUpdateGTIDRelayNeedPrevGTIDEvBeforeGTIDEvRelayNeedMaGTIDListEvBeforeGTIDEvRelayMkdirRelaySwitchMasterNeedGTIDRelayThisStrategyIsPurgingRelayOtherStrategyIsPurgingRelayPurgeIsForbiddenRelayNoActiveRelayLogRelayPurgeRequestNotValidRelayTrimUUIDNotFoundRelayRemoveFileFailRelayPurgeArgsNotValidPreviousGTIDsNotValidRotateEventWithDifferentServerIDDumpUnitRuntimeDumpUnitGenTableRouterDumpUnitGenBAListDumpUnitGlobalLockLoadUnitCreateSchemaFileLoadUnitInvalidFileEndingLoadUnitParseQuoteValuesLoadUnitDoColumnMappingLoadUnitReadSchemaFileLoadUnitParseStatementLoadUnitNotCreateTableLoadUnitDispatchSQLFromFileLoadUnitInvalidInsertSQLLoadUnitGenTableRouterLoadUnitGenColumnMappingLoadUnitNoDBFileLoadUnitNoTableFileLoadUnitDumpDirNotFoundLoadUnitDuplicateTableFileLoadUnitGenBAListLoadTaskWorkerNotMatchLoadCheckPointNotMatchLoadLightningRuntimeLoadLightningHasDupLoadLightningChecksumSyncerUnitPanicSyncUnitInvalidTableNameSyncUnitTableNameQuerySyncUnitNotSupportedDMLSyncUnitAddTableInShardingSyncUnitDropSchemaTableInShardingSyncUnitInvalidShardMetaSyncUnitDDLWrongSequenceSyncUnitDDLActiveIndexLargerSyncUnitDupTableGroupSyncUnitShardingGroupNotFoundSyncUnitSafeModeSetCountSyncUnitCausalityConflictSyncUnitDMLStatementFoundSyncerUnitBinlogEventFilterSyncerUnitInvalidReplicaEventSyncerUnitParseStmtSyncerUnitUUIDNotLatestSyncerUnitDDLExecChanCloseOrBusySyncerUnitDDLChanDoneSyncerUnitDDLChanCanceledSyncerUnitDDLOnMultipleTableSyncerUnitInjectDDLOnlySyncerUnitInjectDDLWithoutSchemaSyncerUnitNotSupportedOperateSyncerUnitNilOperatorReqSyncerUnitDMLColumnNotMatchSyncerUnitDMLOldNewValueMismatchSyncerUnitDMLPruneColumnMismatchSyncerUnitGenBinlogEventFilterSyncerUnitGenTableRouterSyncerUnitGenColumnMappingSyncerUnitDoColumnMappingSyncerUnitCacheKeyNotFoundSyncerUnitHeartbeatCheckConfigSyncerUnitHeartbeatRecordExistsSyncerUnitHeartbeatRecordNotFoundSyncerUnitHeartbeatRecordNotValidSyncerUnitOnlineDDLInvalidMetaSyncerUnitOnlineDDLSchemeNotSupportSyncerUnitOnlineDDLOnMultipleTableSyncerUnitGhostApplyEmptyTableSyncerUnitGhostRenameTableNotValidSyncerUnitGhostRenameToGhostTableSyncerUnitGhostRenameGhostTblToOtherSyncerUnitGhostOnlineDDLOnGhostTblSyncerUnitPTApplyEmptyTableSyncerUnitPTRenameTableNotValidSyncerUnitPTRenameToPTTableSyncerUnitPTRenamePTTblToOtherSyncerUnitPTOnlineDDLOnPTTblSyncerUnitRemoteSteamerWithGTIDSyncerUnitRemoteSteamerStartSyncSyncerUnitGetTableFromDBSyncerUnitFirstEndPosNotFoundSyncerUnitResolveCasualityFailSyncerUnitReopenStreamNotSupportSyncerUnitUpdateConfigInShardingSyncerUnitExecWithNoBlockingDDLSyncerUnitGenBAListSyncerUnitHandleDDLFailedSyncerShardDDLConflictSyncerFailpointSyncerEventSyncerOperatorNotExistSyncerEventNotExistSyncerParseDDLSyncerUnsupportedStmtSyncerGetEventSyncerDownstreamTableNotFoundSyncerReprocessWithSafeModeFailMasterSQLOpNilRequestMasterSQLOpNotSupportMasterSQLOpWithoutShardingMasterGRPCCreateConnMasterGRPCSendOnCloseConnMasterGRPCClientCloseMasterGRPCInvalidReqTypeMasterGRPCRequestErrorMasterDeployMapperVerifyMasterConfigParseFlagSetMasterConfigUnknownItemMasterConfigInvalidFlagMasterConfigTomlTransformMasterConfigTimeoutParseMasterConfigUpdateCfgFileMasterShardingDDLDiffMasterStartServiceMasterNoEmitTokenMasterLockNotFoundMasterLockIsResolvingMasterWorkerCliNotFoundMasterWorkerNotWaitLockMasterHandleSQLReqFailMasterOwnerExecDDLMasterPartWorkerExecDDLFailMasterWorkerExistDDLLockMasterGetWorkerCfgExtractorMasterTaskConfigExtractorMasterWorkerArgsExtractorMasterQueryWorkerConfigMasterOperNotFoundMasterOperRespNotSuccessMasterOperRequestTimeoutMasterHandleHTTPApisMasterHostPortNotValidMasterGetHostnameFailMasterGenEmbedEtcdConfigFailMasterStartEmbedEtcdFailMasterParseURLFailMasterJoinEmbedEtcdFailMasterInvalidOperateOpMasterAdvertiseAddrNotValidMasterRequestIsNotForwardToLeaderMasterIsNotAsyncRequestMasterFailToGetExpectResultMasterPessimistNotStartedMasterOptimistNotStartedMasterMasterNameNotExistMasterInvalidOfflineTypeMasterAdvertisePeerURLsNotValidMasterTLSConfigNotValidMasterBoundChangingMasterFailToImportFromV10xMasterInconsistentOptimistDDLsAndInfoMasterOptimisticTableInfobeforeNotExistMasterOptimisticDownstreamMetaNotFoundMasterInvalidClusterIDMasterStartTaskMasterAlertConfigInvalidWorkerParseFlagSetWorkerInvalidFlagWorkerDecodeConfigFromFileWorkerUndecodedItemFromFileWorkerNeedSourceIDWorkerTooLongSourceIDWorkerRelayBinlogNameWorkerWriteConfigFileWorkerLogInvalidHandlerWorkerLogPointerInvalidWorkerLogFetchPointerWorkerLogUnmarshalPointerWorkerLogClearPointerWorkerLogTaskKeyNotValidWorkerLogUnmarshalTaskKeyWorkerLogFetchLogIterWorkerLogGetTaskLogWorkerLogUnmarshalBinaryWorkerLogForwardPointerWorkerLogMarshalTaskWorkerLogSaveTaskWorkerLogDeleteKVWorkerLogDeleteKVIterWorkerLogUnmarshalTaskMetaWorkerLogFetchTaskFromMetaWorkerLogVerifyTaskMetaWorkerLogSaveTaskMetaWorkerLogGetTaskMetaWorkerLogDeleteTaskMetaWorkerMetaTomlTransformWorkerMetaOldFileStatWorkerMetaOldReadFileWorkerMetaEncodeTaskWorkerMetaRemoveOldDirWorkerMetaTaskLogNotFoundWorkerMetaHandleTaskOrderWorkerMetaOpenTxnWorkerMetaCommitTxnWorkerRelayStageNotValidWorkerRelayOperNotSupportWorkerOpenKVDBFileWorkerUpgradeCheckKVDirWorkerMarshalVerBinaryWorkerUnmarshalVerBinaryWorkerGetVersionFromKVWorkerSaveVersionToKVWorkerVerAutoDowngradeWorkerStartServiceWorkerAlreadyClosedWorkerNotRunningStageWorkerNotPausedStageWorkerUpdateTaskStageWorkerMigrateStopRelayWorkerSubTaskNotFoundWorkerSubTaskExistsWorkerOperSyncUnitOnlyWorkerRelayUnitStageWorkerNoSyncerRunningWorkerCannotUpdateSourceIDWorkerNoAvailUnitsWorkerDDLLockInfoNotFoundWorkerDDLLockInfoExistsWorkerCacheDDLInfoExistsWorkerExecSkipDDLConflictWorkerExecDDLSyncerOnlyWorkerExecDDLTimeoutWorkerWaitRelayCatchupTimeoutWorkerRelayIsPurgingWorkerHostPortNotValidWorkerNoStartWorkerAlreadyStartedWorkerSourceNotMatchWorkerFailToGetSubtaskConfigFromEtcdWorkerFailToGetSourceConfigFromEtcdWorkerDDLLockOpNotFoundWorkerTLSConfigNotValidWorkerFailConnectMasterWorkerWaitRelayCatchupGTIDWorkerRelayConfigChangingWorkerRouteTableDupMatchWorkerUpdateSubTaskConfigWorkerValidatorNotPausedWorkerServerClosedTracerParseFlagSetTracerConfigTomlTransformTracerConfigInvalidFlagTracerTraceEventNotFoundTracerTraceIDNotProvidedTracerParamNotValidTracerPostMethodOnlyTracerEventAssertionFailTracerEventTypeNotValidTracerStartServiceHAFailTxnOperationHAInvalidItemHAFailWatchEtcdHAFailLeaseOperationHAFailKeepaliveValidatorLoadPersistedDataValidatorPersistDataValidatorGetEventValidatorProcessRowEventValidatorValidateChangeValidatorNotFoundValidatorPanicValidatorTooMuchPendingSchemaTrackerInvalidJSONSchemaTrackerCannotCreateSchemaSchemaTrackerCannotCreateTableSchemaTrackerCannotSerializeSchemaTrackerCannotGetTableSchemaTrackerCannotExecDDLSchemaTrackerCannotFetchDownstreamTableSchemaTrackerCannotParseDownstreamTableSchemaTrackerInvalidCreateTableStmtSchemaTrackerRestoreStmtFailSchemaTrackerCannotDropTableSchemaTrackerInitSchemaTrackerMarshalJSONSchemaTrackerUnMarshalJSONSchemaTrackerUnSchemaNotExistSchemaTrackerCannotSetDownstreamSQLModeSchemaTrackerCannotInitDownstreamParserSchemaTrackerCannotMockDownstreamTableSchemaTrackerCannotFetchDownstreamCreateTableStmtSchemaTrackerIsClosedSchedulerNotStartedSchedulerStartedSchedulerWorkerExistSchedulerWorkerNotExistSchedulerWorkerOnlineSchedulerWorkerInvalidTransSchedulerSourceCfgExistSchedulerSourceCfgNotExistSchedulerSourcesUnboundSchedulerSourceOpTaskExistSchedulerRelayStageInvalidUpdateSchedulerRelayStageSourceNotExistSchedulerMultiTaskSchedulerSubTaskExistSchedulerSubTaskStageInvalidUpdateSchedulerSubTaskOpTaskNotExistSchedulerSubTaskOpSourceNotExistSchedulerTaskNotExistSchedulerRequireRunningTaskInSyncUnitSchedulerRelayWorkersBusySchedulerRelayWorkersBoundSchedulerRelayWorkersWrongRelaySchedulerSourceOpRelayExistSchedulerLatchInUseSchedulerSourceCfgUpdateSchedulerWrongWorkerInputSchedulerCantTransferToRelayWorkerSchedulerStartRelayOnSpecifiedSchedulerStopRelayOnSpecifiedSchedulerStartRelayOnBoundSchedulerStopRelayOnBoundSchedulerPauseTaskForTransferSourceSchedulerWorkerNotFreeSchedulerSubTaskNotExistSchedulerSubTaskCfgUpdateCtlGRPCCreateConnCtlInvalidTLSCfgCtlLoadTLSCfgOpenAPICommonOpenAPITaskSourceNotFoundNotSet"
 
 var _ErrCode_map = map[ErrCode]string{
 	10001: _ErrCode_name[0:13],
@@ -1018,173 +1019,174 @@ var _ErrCode_map = map[ErrCode]string{
 	38056: _ErrCode_name[9478:9516],
 	38057: _ErrCode_name[9516:9538],
 	38058: _ErrCode_name[9538:9553],
-	40001: _ErrCode_name[9553:9571],
-	40002: _ErrCode_name[9571:9588],
-	40003: _ErrCode_name[9588:9614],
-	40004: _ErrCode_name[9614:9641],
-	40005: _ErrCode_name[9641:9659],
-	40006: _ErrCode_name[9659:9680],
-	40007: _ErrCode_name[9680:9701],
-	40008: _ErrCode_name[9701:9722],
-	40009: _ErrCode_name[9722:9745],
-	40010: _ErrCode_name[9745:9768],
-	40011: _ErrCode_name[9768:9789],
-	40012: _ErrCode_name[9789:9814],
-	40013: _ErrCode_name[9814:9835],
-	40014: _ErrCode_name[9835:9859],
-	40015: _ErrCode_name[9859:9884],
-	40016: _ErrCode_name[9884:9905],
-	40017: _ErrCode_name[9905:9924],
-	40018: _ErrCode_name[9924:9948],
-	40019: _ErrCode_name[9948:9971],
-	40020: _ErrCode_name[9971:9991],
-	40021: _ErrCode_name[9991:10008],
-	40022: _ErrCode_name[10008:10025],
-	40023: _ErrCode_name[10025:10046],
-	40024: _ErrCode_name[10046:10072],
-	40025: _ErrCode_name[10072:10098],
-	40026: _ErrCode_name[10098:10121],
-	40027: _ErrCode_name[10121:10142],
-	40028: _ErrCode_name[10142:10162],
-	40029: _ErrCode_name[10162:10185],
-	40030: _ErrCode_name[10185:10208],
-	40031: _ErrCode_name[10208:10229],
-	40032: _ErrCode_name[10229:10250],
-	40033: _ErrCode_name[10250:10270],
-	40034: _ErrCode_name[10270:10292],
-	40035: _ErrCode_name[10292:10317],
-	40036: _ErrCode_name[10317:10342],
-	40037: _ErrCode_name[10342:10359],
-	40038: _ErrCode_name[10359:10378],
-	40039: _ErrCode_name[10378:10402],
-	40040: _ErrCode_name[10402:10427],
-	40041: _ErrCode_name[10427:10445],
-	40042: _ErrCode_name[10445:10468],
-	40043: _ErrCode_name[10468:10490],
-	40044: _ErrCode_name[10490:10514],
-	40045: _ErrCode_name[10514:10536],
-	40046: _ErrCode_name[10536:10557],
-	40047: _ErrCode_name[10557:10579],
-	40048: _ErrCode_name[10579:10597],
-	40049: _ErrCode_name[10597:10616],
-	40050: _ErrCode_name[10616:10637],
-	40051: _ErrCode_name[10637:10657],
-	40052: _ErrCode_name[10657:10678],
-	40053: _ErrCode_name[10678:10700],
-	40054: _ErrCode_name[10700:10721],
-	40055: _ErrCode_name[10721:10740],
-	40056: _ErrCode_name[10740:10762],
-	40057: _ErrCode_name[10762:10782],
-	40058: _ErrCode_name[10782:10803],
-	40059: _ErrCode_name[10803:10829],
-	40060: _ErrCode_name[10829:10847],
-	40061: _ErrCode_name[10847:10872],
-	40062: _ErrCode_name[10872:10895],
-	40063: _ErrCode_name[10895:10919],
-	40064: _ErrCode_name[10919:10944],
-	40065: _ErrCode_name[10944:10967],
-	40066: _ErrCode_name[10967:10987],
-	40067: _ErrCode_name[10987:11016],
-	40068: _ErrCode_name[11016:11036],
-	40069: _ErrCode_name[11036:11058],
-	40070: _ErrCode_name[11058:11071],
-	40071: _ErrCode_name[11071:11091],
-	40072: _ErrCode_name[11091:11111],
-	40073: _ErrCode_name[11111:11147],
-	40074: _ErrCode_name[11147:11182],
-	40075: _ErrCode_name[11182:11205],
-	40076: _ErrCode_name[11205:11228],
-	40077: _ErrCode_name[11228:11251],
-	40078: _ErrCode_name[11251:11277],
-	40079: _ErrCode_name[11277:11302],
-	40080: _ErrCode_name[11302:11326],
-	40081: _ErrCode_name[11326:11351],
-	40082: _ErrCode_name[11351:11375],
-	40083: _ErrCode_name[11375:11393],
-	42001: _ErrCode_name[11393:11411],
-	42002: _ErrCode_name[11411:11436],
-	42003: _ErrCode_name[11436:11459],
-	42004: _ErrCode_name[11459:11483],
-	42005: _ErrCode_name[11483:11507],
-	42006: _ErrCode_name[11507:11526],
-	42007: _ErrCode_name[11526:11546],
-	42008: _ErrCode_name[11546:11570],
-	42009: _ErrCode_name[11570:11593],
-	42010: _ErrCode_name[11593:11611],
-	42501: _ErrCode_name[11611:11629],
-	42502: _ErrCode_name[11629:11642],
-	42503: _ErrCode_name[11642:11657],
-	42504: _ErrCode_name[11657:11677],
-	42505: _ErrCode_name[11677:11692],
-	43001: _ErrCode_name[11692:11718],
-	43002: _ErrCode_name[11718:11738],
-	43003: _ErrCode_name[11738:11755],
-	43004: _ErrCode_name[11755:11779],
-	43005: _ErrCode_name[11779:11802],
-	43006: _ErrCode_name[11802:11819],
-	43007: _ErrCode_name[11819:11833],
-	43008: _ErrCode_name[11833:11856],
-	44001: _ErrCode_name[11856:11880],
-	44002: _ErrCode_name[11880:11911],
-	44003: _ErrCode_name[11911:11941],
-	44004: _ErrCode_name[11941:11969],
-	44005: _ErrCode_name[11969:11996],
-	44006: _ErrCode_name[11996:12022],
-	44007: _ErrCode_name[12022:12061],
-	44008: _ErrCode_name[12061:12100],
-	44009: _ErrCode_name[12100:12135],
-	44010: _ErrCode_name[12135:12163],
-	44011: _ErrCode_name[12163:12191],
-	44012: _ErrCode_name[12191:12208],
-	44013: _ErrCode_name[12208:12232],
-	44014: _ErrCode_name[12232:12258],
-	44015: _ErrCode_name[12258:12287],
-	44016: _ErrCode_name[12287:12326],
-	44017: _ErrCode_name[12326:12365],
-	44018: _ErrCode_name[12365:12403],
-	44019: _ErrCode_name[12403:12452],
-	44020: _ErrCode_name[12452:12473],
-	46001: _ErrCode_name[12473:12492],
-	46002: _ErrCode_name[12492:12508],
-	46003: _ErrCode_name[12508:12528],
-	46004: _ErrCode_name[12528:12551],
-	46005: _ErrCode_name[12551:12572],
-	46006: _ErrCode_name[12572:12599],
-	46007: _ErrCode_name[12599:12622],
-	46008: _ErrCode_name[12622:12648],
-	46009: _ErrCode_name[12648:12671],
-	46010: _ErrCode_name[12671:12697],
-	46011: _ErrCode_name[12697:12729],
-	46012: _ErrCode_name[12729:12762],
-	46013: _ErrCode_name[12762:12780],
-	46014: _ErrCode_name[12780:12801],
-	46015: _ErrCode_name[12801:12835],
-	46016: _ErrCode_name[12835:12865],
-	46017: _ErrCode_name[12865:12897],
-	46018: _ErrCode_name[12897:12918],
-	46019: _ErrCode_name[12918:12955],
-	46020: _ErrCode_name[12955:12980],
-	46021: _ErrCode_name[12980:13006],
-	46022: _ErrCode_name[13006:13037],
-	46023: _ErrCode_name[13037:13064],
-	46024: _ErrCode_name[13064:13083],
-	46025: _ErrCode_name[13083:13107],
-	46026: _ErrCode_name[13107:13132],
-	46027: _ErrCode_name[13132:13166],
-	46028: _ErrCode_name[13166:13196],
-	46029: _ErrCode_name[13196:13225],
-	46030: _ErrCode_name[13225:13251],
-	46031: _ErrCode_name[13251:13276],
-	46032: _ErrCode_name[13276:13311],
-	46033: _ErrCode_name[13311:13333],
-	46034: _ErrCode_name[13333:13357],
-	46035: _ErrCode_name[13357:13382],
-	48001: _ErrCode_name[13382:13399],
-	48002: _ErrCode_name[13399:13415],
-	48003: _ErrCode_name[13415:13428],
-	49001: _ErrCode_name[13428:13441],
-	49002: _ErrCode_name[13441:13466],
-	50000: _ErrCode_name[13466:13472],
+	38059: _ErrCode_name[9553:9577],
+	40001: _ErrCode_name[9577:9595],
+	40002: _ErrCode_name[9595:9612],
+	40003: _ErrCode_name[9612:9638],
+	40004: _ErrCode_name[9638:9665],
+	40005: _ErrCode_name[9665:9683],
+	40006: _ErrCode_name[9683:9704],
+	40007: _ErrCode_name[9704:9725],
+	40008: _ErrCode_name[9725:9746],
+	40009: _ErrCode_name[9746:9769],
+	40010: _ErrCode_name[9769:9792],
+	40011: _ErrCode_name[9792:9813],
+	40012: _ErrCode_name[9813:9838],
+	40013: _ErrCode_name[9838:9859],
+	40014: _ErrCode_name[9859:9883],
+	40015: _ErrCode_name[9883:9908],
+	40016: _ErrCode_name[9908:9929],
+	40017: _ErrCode_name[9929:9948],
+	40018: _ErrCode_name[9948:9972],
+	40019: _ErrCode_name[9972:9995],
+	40020: _ErrCode_name[9995:10015],
+	40021: _ErrCode_name[10015:10032],
+	40022: _ErrCode_name[10032:10049],
+	40023: _ErrCode_name[10049:10070],
+	40024: _ErrCode_name[10070:10096],
+	40025: _ErrCode_name[10096:10122],
+	40026: _ErrCode_name[10122:10145],
+	40027: _ErrCode_name[10145:10166],
+	40028: _ErrCode_name[10166:10186],
+	40029: _ErrCode_name[10186:10209],
+	40030: _ErrCode_name[10209:10232],
+	40031: _ErrCode_name[10232:10253],
+	40032: _ErrCode_name[10253:10274],
+	40033: _ErrCode_name[10274:10294],
+	40034: _ErrCode_name[10294:10316],
+	40035: _ErrCode_name[10316:10341],
+	40036: _ErrCode_name[10341:10366],
+	40037: _ErrCode_name[10366:10383],
+	40038: _ErrCode_name[10383:10402],
+	40039: _ErrCode_name[10402:10426],
+	40040: _ErrCode_name[10426:10451],
+	40041: _ErrCode_name[10451:10469],
+	40042: _ErrCode_name[10469:10492],
+	40043: _ErrCode_name[10492:10514],
+	40044: _ErrCode_name[10514:10538],
+	40045: _ErrCode_name[10538:10560],
+	40046: _ErrCode_name[10560:10581],
+	40047: _ErrCode_name[10581:10603],
+	40048: _ErrCode_name[10603:10621],
+	40049: _ErrCode_name[10621:10640],
+	40050: _ErrCode_name[10640:10661],
+	40051: _ErrCode_name[10661:10681],
+	40052: _ErrCode_name[10681:10702],
+	40053: _ErrCode_name[10702:10724],
+	40054: _ErrCode_name[10724:10745],
+	40055: _ErrCode_name[10745:10764],
+	40056: _ErrCode_name[10764:10786],
+	40057: _ErrCode_name[10786:10806],
+	40058: _ErrCode_name[10806:10827],
+	40059: _ErrCode_name[10827:10853],
+	40060: _ErrCode_name[10853:10871],
+	40061: _ErrCode_name[10871:10896],
+	40062: _ErrCode_name[10896:10919],
+	40063: _ErrCode_name[10919:10943],
+	40064: _ErrCode_name[10943:10968],
+	40065: _ErrCode_name[10968:10991],
+	40066: _ErrCode_name[10991:11011],
+	40067: _ErrCode_name[11011:11040],
+	40068: _ErrCode_name[11040:11060],
+	40069: _ErrCode_name[11060:11082],
+	40070: _ErrCode_name[11082:11095],
+	40071: _ErrCode_name[11095:11115],
+	40072: _ErrCode_name[11115:11135],
+	40073: _ErrCode_name[11135:11171],
+	40074: _ErrCode_name[11171:11206],
+	40075: _ErrCode_name[11206:11229],
+	40076: _ErrCode_name[11229:11252],
+	40077: _ErrCode_name[11252:11275],
+	40078: _ErrCode_name[11275:11301],
+	40079: _ErrCode_name[11301:11326],
+	40080: _ErrCode_name[11326:11350],
+	40081: _ErrCode_name[11350:11375],
+	40082: _ErrCode_name[11375:11399],
+	40083: _ErrCode_name[11399:11417],
+	42001: _ErrCode_name[11417:11435],
+	42002: _ErrCode_name[11435:11460],
+	42003: _ErrCode_name[11460:11483],
+	42004: _ErrCode_name[11483:11507],
+	42005: _ErrCode_name[11507:11531],
+	42006: _ErrCode_name[11531:11550],
+	42007: _ErrCode_name[11550:11570],
+	42008: _ErrCode_name[11570:11594],
+	42009: _ErrCode_name[11594:11617],
+	42010: _ErrCode_name[11617:11635],
+	42501: _ErrCode_name[11635:11653],
+	42502: _ErrCode_name[11653:11666],
+	42503: _ErrCode_name[11666:11681],
+	42504: _ErrCode_name[11681:11701],
+	42505: _ErrCode_name[11701:11716],
+	43001: _ErrCode_name[11716:11742],
+	43002: _ErrCode_name[11742:11762],
+	43003: _ErrCode_name[11762:11779],
+	43004: _ErrCode_name[11779:11803],
+	43005: _ErrCode_name[11803:11826],
+	43006: _ErrCode_name[11826:11843],
+	43007: _ErrCode_name[11843:11857],
+	43008: _ErrCode_name[11857:11880],
+	44001: _ErrCode_name[11880:11904],
+	44002: _ErrCode_name[11904:11935],
+	44003: _ErrCode_name[11935:11965],
+	44004: _ErrCode_name[11965:11993],
+	44005: _ErrCode_name[11993:12020],
+	44006: _ErrCode_name[12020:12046],
+	44007: _ErrCode_name[12046:12085],
+	44008: _ErrCode_name[12085:12124],
+	44009: _ErrCode_name[12124:12159],
+	44010: _ErrCode_name[12159:12187],
+	44011: _ErrCode_name[12187:12215],
+	44012: _ErrCode_name[12215:12232],
+	44013: _ErrCode_name[12232:12256],
+	44014: _ErrCode_name[12256:12282],
+	44015: _ErrCode_name[12282:12311],
+	44016: _ErrCode_name[12311:12350],
+	44017: _ErrCode_name[12350:12389],
+	44018: _ErrCode_name[12389:12427],
+	44019: _ErrCode_name[12427:12476],
+	44020: _ErrCode_name[12476:12497],
+	46001: _ErrCode_name[12497:12516],
+	46002: _ErrCode_name[12516:12532],
+	46003: _ErrCode_name[12532:12552],
+	46004: _ErrCode_name[12552:12575],
+	46005: _ErrCode_name[12575:12596],
+	46006: _ErrCode_name[12596:12623],
+	46007: _ErrCode_name[12623:12646],
+	46008: _ErrCode_name[12646:12672],
+	46009: _ErrCode_name[12672:12695],
+	46010: _ErrCode_name[12695:12721],
+	46011: _ErrCode_name[12721:12753],
+	46012: _ErrCode_name[12753:12786],
+	46013: _ErrCode_name[12786:12804],
+	46014: _ErrCode_name[12804:12825],
+	46015: _ErrCode_name[12825:12859],
+	46016: _ErrCode_name[12859:12889],
+	46017: _ErrCode_name[12889:12921],
+	46018: _ErrCode_name[12921:12942],
+	46019: _ErrCode_name[12942:12979],
+	46020: _ErrCode_name[12979:13004],
+	46021: _ErrCode_name[13004:13030],
+	46022: _ErrCode_name[13030:13061],
+	46023: _ErrCode_name[13061:13088],
+	46024: _ErrCode_name[13088:13107],
+	46025: _ErrCode_name[13107:13131],
+	46026: _ErrCode_name[13131:13156],
+	46027: _ErrCode_name[13156:13190],
+	46028: _ErrCode_name[13190:13220],
+	46029: _ErrCode_name[13220:13249],
+	46030: _ErrCode_name[13249:13275],
+	46031: _ErrCode_name[13275:13300],
+	46032: _ErrCode_name[13300:13335],
+	46033: _ErrCode_name[13335:13357],
+	46034: _ErrCode_name[13357:13381],
+	46035: _ErrCode_name[13381:13406],
+	48001: _ErrCode_name[13406:13423],
+	48002: _ErrCode_name[13423:13439],
+	48003: _ErrCode_name[13439:13452],
+	49001: _ErrCode_name[13452:13465],
+	49002: _ErrCode_name[13465:13490],
+	50000: _ErrCode_name[13490:13496],
 }
 
 func (i ErrCode) String() string {
@@ -524,6 +524,7 @@ const (
 	codeMasterOptimisticDownstreamMetaNotFound
 	codeMasterInvalidClusterID
 	codeMasterStartTask
+	codeMasterAlertConfigInvalid
 )
 
 // DM-worker error code.
@@ -1223,6 +1224,7 @@ var (
 	ErrMasterOptimisticDownstreamMetaNotFound  = New(codeMasterOptimisticDownstreamMetaNotFound, ClassDMMaster, ScopeInternal, LevelHigh, "downstream database config and meta for task %s not found", "")
 	ErrMasterInvalidClusterID                  = New(codeMasterInvalidClusterID, ClassDMMaster, ScopeInternal, LevelHigh, "invalid cluster id: %v", "")
 	ErrMasterStartTask                         = New(codeMasterStartTask, ClassDMMaster, ScopeInternal, LevelHigh, "can not start task: %s reason: %s", "")
+	ErrMasterAlertConfigInvalid                = New(codeMasterAlertConfigInvalid, ClassDMMaster, ScopeInternal, LevelMedium, "invalid alert config: %s", "Please check the `alert` config in dm-master configuration file.")
 
 	// DM-worker error.
 	ErrWorkerParseFlagSet            = New(codeWorkerParseFlagSet, ClassDMWorker, ScopeInternal, LevelMedium, "parse dm-worker config flag set", "")
@@ -160,3 +160,41 @@ func GetTableFromDumpFilename(filename string) (db, table string, ok bool) {
 	}
 	return fields[0], fields[1], true
 }
+
+// GetTableFromDataFilename extracts db and table name from the filename of a
+// dumped data file, like `db.table.000000000.sql.gz` or `db.table.csv`.
+func GetTableFromDataFilename(filename string) (db, table string, ok bool) {
+	if strings.Contains(filename, "-schema") {
+		return "", "", false
+	}
+	name := filename
+	for _, ext := range []string{".gz", ".gzip", ".zst", ".zstd", ".snappy"} {
+		if strings.HasSuffix(name, ext) {
+			name = strings.TrimSuffix(name, ext)
+			break
+		}
+	}
+	trimmed := false
+	for _, ext := range []string{".sql", ".csv", ".parquet"} {
+		if strings.HasSuffix(name, ext) {
+			name = strings.TrimSuffix(name, ext)
+			trimmed = true
+			break
+		}
+	}
+	if !trimmed {
+		return "", "", false
+	}
+
+	fields := strings.Split(name, ".")
+	switch len(fields) {
+	case 2:
+		return fields[0], fields[1], true
+	case 3:
+		if _, err := strconv.ParseUint(fields[2], 10, 64); err != nil {
+			return "", "", false
+		}
+		return fields[0], fields[1], true
+	}
+	return "", "", false
+}
@@ -54,3 +54,30 @@ func TestFile(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, int64(len("some content")), size)
 }
+
+func TestGetTableFromDataFilename(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		filename string
+		db       string
+		table    string
+		ok       bool
+	}{
+		{"db.tbl.000000000.sql", "db", "tbl", true},
+		{"db.tbl.000000001.csv.gz", "db", "tbl", true},
+		{"db.tbl.sql", "db", "tbl", true},
+		{"db.tbl.0000.parquet.zst", "db", "tbl", true},
+		{"db.tbl-schema.sql", "", "", false},
+		{"db-schema-create.sql", "", "", false},
+		{"db.tbl.abc.sql", "", "", false},
+		{"metadata", "", "", false},
+		{"db.tbl.000000000.txt", "", "", false},
+	}
+	for _, cs := range cases {
+		db, table, ok := GetTableFromDataFilename(cs.filename)
+		require.Equal(t, cs.ok, ok, cs.filename)
+		require.Equal(t, cs.db, db, cs.filename)
+		require.Equal(t, cs.table, table, cs.filename)
+	}
+}
@@ -34,6 +34,7 @@ import (
 	"github.com/pingcap/tiflow/dm/pb"
 	"github.com/pingcap/tiflow/dm/pkg/binlog"
 	"github.com/pingcap/tiflow/dm/pkg/binlog/common"
+	"github.com/pingcap/tiflow/dm/pkg/binlog/event"
 	binlogReader "github.com/pingcap/tiflow/dm/pkg/binlog/reader"
 	"github.com/pingcap/tiflow/dm/pkg/conn"
 	tcontext "github.com/pingcap/tiflow/dm/pkg/context"
@@ -566,6 +567,9 @@ func (r *Relay) handleEvents(
 		_, lastGTID = r.meta.GTID()
 		err         error
 		eventIndex  int // only for test
+		// mariaDBEventDetected is true if a MariaDB specific event is received
+		// while the flavor is not MariaDB, it is only warned once.
+		mariaDBEventDetected bool
 	)
 	if lastGTID == nil {
 		if lastGTID, err = gtid.ParserGTID(r.cfg.Flavor, ""); err != nil {
@@ -628,6 +632,13 @@ func (r *Relay) handleEvents(
 
 		e := rResult.Event
 		r.logger.Debug("receive binlog event with header", zap.Reflect("header", e.Header))
+		if !mariaDBEventDetected && r.cfg.Flavor != mysql.MariaDBFlavor && event.IsMariaDBEvent(e.Header.EventType) {
+			mariaDBEventDetected = true
+			r.logger.Warn("receive MariaDB specific binlog event, but the flavor of the source is not MariaDB, "+
+				"GTIDs can not be handled correctly, please set `flavor: mariadb` in the source config",
+				zap.String("flavor", r.cfg.Flavor),
+				zap.Stringer("event type", e.Header.EventType))
+		}
 
 		// 2. transform events
 		transformTimer := time.Now()
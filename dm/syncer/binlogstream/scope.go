@@ -0,0 +1,186 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package binlogstream
+
+import (
+	"strings"
+
+	"github.com/go-mysql-org/go-mysql/replication"
+	bf "github.com/pingcap/tidb-tools/pkg/binlog-filter"
+	"github.com/pingcap/tidb/parser"
+	"github.com/pingcap/tidb/util/filter"
+	tfilter "github.com/pingcap/tidb/util/table-filter"
+	"github.com/pingcap/tiflow/dm/pkg/conn"
+	parserpkg "github.com/pingcap/tiflow/dm/pkg/parser"
+	"github.com/pingcap/tiflow/dm/pkg/terror"
+)
+
+// operatorScope limits an operator to the events of some tables and event
+// types. A scoped operator works on the events from its position to the end
+// of the transaction at that position, the events out of the scope in the
+// transaction are kept as they are.
+type operatorScope struct {
+	tables     tfilter.Filter // nil means all the tables
+	eventTypes map[bf.EventType]struct{}
+	// ddlOnly is true if only the DDL query events can be matched, the
+	// replace operator can't inject its DDLs into a DML transaction.
+	ddlOnly bool
+}
+
+// newOperatorScope creates an operatorScope from the table filter rules and
+// the event types of a handle-error request, it returns nil if both of them
+// are empty.
+func newOperatorScope(tables, eventTypes []string) (*operatorScope, error) {
+	if len(tables) == 0 && len(eventTypes) == 0 {
+		return nil, nil
+	}
+
+	scope := &operatorScope{eventTypes: make(map[bf.EventType]struct{}, len(eventTypes))}
+	if len(tables) > 0 {
+		f, err := tfilter.Parse(tables)
+		if err != nil {
+			return nil, terror.ErrVerifyHandleErrorArgs.Generatef(
+				"invalid --table %v in handle-error operation: %s", tables, err.Error())
+		}
+		scope.tables = tfilter.CaseInsensitive(f)
+	}
+	for _, et := range eventTypes {
+		eventType := bf.EventType(strings.ToLower(strings.TrimSpace(et)))
+		switch eventType {
+		case bf.InsertEvent, bf.UpdateEvent, bf.DeleteEvent, bf.AllDML, bf.AllDDL:
+			scope.eventTypes[eventType] = struct{}{}
+		default:
+			return nil, terror.ErrVerifyHandleErrorArgs.Generatef(
+				"invalid --event-type %s in handle-error operation, should be one of %s, %s, %s, %s, %s",
+				et, bf.InsertEvent, bf.UpdateEvent, bf.DeleteEvent, bf.AllDML, bf.AllDDL)
+		}
+	}
+	return scope, nil
+}
+
+// match returns whether the event is in the scope. Only rows events and
+// DDL query events can be matched.
+func (s *operatorScope) match(e *replication.BinlogEvent) bool {
+	var (
+		eventType bf.EventType
+		tables    []*filter.Table
+	)
+	switch ev := e.Event.(type) {
+	case *replication.RowsEvent:
+		if s.ddlOnly {
+			return false
+		}
+		switch e.Header.EventType {
+		case replication.WRITE_ROWS_EVENTv0, replication.WRITE_ROWS_EVENTv1, replication.WRITE_ROWS_EVENTv2:
+			eventType = bf.InsertEvent
+		case replication.UPDATE_ROWS_EVENTv0, replication.UPDATE_ROWS_EVENTv1, replication.UPDATE_ROWS_EVENTv2:
+			eventType = bf.UpdateEvent
+		case replication.DELETE_ROWS_EVENTv0, replication.DELETE_ROWS_EVENTv1, replication.DELETE_ROWS_EVENTv2:
+			eventType = bf.DeleteEvent
+		default:
+			return false
+		}
+		if ev.Table != nil {
+			tables = []*filter.Table{{Schema: string(ev.Table.Schema), Name: string(ev.Table.Table)}}
+		}
+	case *replication.QueryEvent:
+		if isTxnControlQuery(ev) {
+			return false
+		}
+		eventType = bf.AllDDL
+		tables = fetchQueryTables(ev)
+	default:
+		return false
+	}
+
+	if !s.matchEventType(eventType) {
+		return false
+	}
+	if s.tables == nil {
+		return true
+	}
+	for _, table := range tables {
+		if table.Name == "" {
+			if s.tables.MatchSchema(table.Schema) {
+				return true
+			}
+			continue
+		}
+		if s.tables.MatchTable(table.Schema, table.Name) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasDMLEventTypes returns whether any DML event type is in the scope.
+func (s *operatorScope) hasDMLEventTypes() bool {
+	for eventType := range s.eventTypes {
+		if eventType != bf.AllDDL {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *operatorScope) matchEventType(eventType bf.EventType) bool {
+	if len(s.eventTypes) == 0 {
+		return true
+	}
+	if _, ok := s.eventTypes[eventType]; ok {
+		return true
+	}
+	if eventType == bf.AllDDL {
+		return false
+	}
+	_, ok := s.eventTypes[bf.AllDML]
+	return ok
+}
+
+// fetchQueryTables returns the tables of the DDLs in a query event, the query
+// is parsed with the default SQL mode, which is enough to get the tables.
+func fetchQueryTables(ev *replication.QueryEvent) []*filter.Table {
+	stmts, err := parserpkg.Parse(parser.New(), string(ev.Query), "", "")
+	if err != nil {
+		return nil
+	}
+	var tables []*filter.Table
+	for _, stmt := range stmts {
+		names, err := parserpkg.FetchDDLTables(string(ev.Schema), stmt, conn.LCTableNamesSensitive)
+		if err != nil {
+			continue
+		}
+		tables = append(tables, names...)
+	}
+	return tables
+}
+
+// isTxnControlQuery returns whether the query event is a BEGIN or a COMMIT
+// of a transaction.
+func isTxnControlQuery(ev *replication.QueryEvent) bool {
+	query := strings.TrimSpace(string(ev.Query))
+	return strings.EqualFold(query, "BEGIN") || strings.EqualFold(query, "COMMIT")
+}
+
+// isTxnEnd returns whether the event is the last event of a transaction, a
+// DDL is a transaction by itself.
+func isTxnEnd(e *replication.BinlogEvent) bool {
+	switch ev := e.Event.(type) {
+	case *replication.XIDEvent:
+		return true
+	case *replication.QueryEvent:
+		return !strings.EqualFold(strings.TrimSpace(string(ev.Query)), "BEGIN")
+	}
+	return false
+}
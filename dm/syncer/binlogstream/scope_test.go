@@ -0,0 +1,101 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package binlogstream
+
+import (
+	"testing"
+
+	"github.com/go-mysql-org/go-mysql/replication"
+	"github.com/stretchr/testify/require"
+)
+
+func rowsEvent(eventType replication.EventType, schema, table string) *replication.BinlogEvent {
+	return &replication.BinlogEvent{
+		Header: &replication.EventHeader{EventType: eventType},
+		Event: &replication.RowsEvent{
+			Table: &replication.TableMapEvent{Schema: []byte(schema), Table: []byte(table)},
+		},
+	}
+}
+
+func queryEvent(schema, query string) *replication.BinlogEvent {
+	return &replication.BinlogEvent{
+		Header: &replication.EventHeader{EventType: replication.QUERY_EVENT},
+		Event:  &replication.QueryEvent{Schema: []byte(schema), Query: []byte(query)},
+	}
+}
+
+func TestOperatorScope(t *testing.T) {
+	t.Parallel()
+
+	scope, err := newOperatorScope(nil, nil)
+	require.NoError(t, err)
+	require.Nil(t, scope)
+
+	_, err = newOperatorScope([]string{"db.["}, nil)
+	require.ErrorContains(t, err, "invalid --table")
+	_, err = newOperatorScope(nil, []string{"truncate"})
+	require.ErrorContains(t, err, "invalid --event-type truncate")
+
+	insertT1 := rowsEvent(replication.WRITE_ROWS_EVENTv2, "db", "t1")
+	updateT1 := rowsEvent(replication.UPDATE_ROWS_EVENTv2, "db", "t1")
+	deleteT2 := rowsEvent(replication.DELETE_ROWS_EVENTv2, "db", "t2")
+	alterT1 := queryEvent("db", "ALTER TABLE t1 ADD COLUMN c INT")
+	createDB := queryEvent("", "CREATE DATABASE db")
+	begin := queryEvent("db", "BEGIN")
+	xid := &replication.BinlogEvent{
+		Header: &replication.EventHeader{EventType: replication.XID_EVENT},
+		Event:  &replication.XIDEvent{},
+	}
+
+	// only tables
+	scope, err = newOperatorScope([]string{"db.t1"}, nil)
+	require.NoError(t, err)
+	require.True(t, scope.match(insertT1))
+	require.True(t, scope.match(updateT1))
+	require.False(t, scope.match(deleteT2))
+	require.True(t, scope.match(alterT1))
+	require.False(t, scope.match(createDB))
+	require.False(t, scope.match(begin))
+	require.False(t, scope.match(xid))
+
+	// only event types
+	scope, err = newOperatorScope(nil, []string{"Insert", "all ddl"})
+	require.NoError(t, err)
+	require.True(t, scope.match(insertT1))
+	require.False(t, scope.match(updateT1))
+	require.False(t, scope.match(deleteT2))
+	require.True(t, scope.match(alterT1))
+	require.True(t, scope.match(createDB))
+
+	// both of them
+	scope, err = newOperatorScope([]string{"db.*"}, []string{"all dml"})
+	require.NoError(t, err)
+	require.True(t, scope.match(insertT1))
+	require.True(t, scope.match(deleteT2))
+	require.False(t, scope.match(alterT1))
+
+	// only the DDLs, e.g. for the replace operator
+	scope, err = newOperatorScope([]string{"db.t1"}, nil)
+	require.NoError(t, err)
+	require.False(t, scope.hasDMLEventTypes())
+	scope.ddlOnly = true
+	require.False(t, scope.match(insertT1))
+	require.True(t, scope.match(alterT1))
+
+	require.False(t, isTxnEnd(begin))
+	require.False(t, isTxnEnd(insertT1))
+	require.True(t, isTxnEnd(alterT1))
+	require.True(t, isTxnEnd(xid))
+}
@@ -36,6 +36,7 @@ type operator struct {
 	op        pb.ErrorOp
 	pos       mysql.Position
 	events    []*replication.BinlogEvent // ddls -> events
+	scope     *operatorScope             // nil means the operator works on the event at pos
 	originReq *pb.HandleWorkerErrorRequest
 }
 
@@ -44,6 +45,7 @@ func newOperator(
 	op pb.ErrorOp,
 	pos mysql.Position,
 	events []*replication.BinlogEvent,
+	scope *operatorScope,
 	originReq *pb.HandleWorkerErrorRequest,
 ) *operator {
 	reqClone := *originReq
@@ -52,6 +54,7 @@ func newOperator(
 		op:        op,
 		pos:       pos,
 		events:    events,
+		scope:     scope,
 		originReq: &reqClone,
 	}
 }
@@ -74,6 +77,9 @@ type streamModifier struct {
 	// next event in current operator. This field can be
 	// modified by StreamerController.
 	nextEventInOp int
+	// whether the transaction of current scoped operator has been met. This
+	// field can be modified by StreamerController.
+	inScopedTxn bool
 
 	logger log.Logger
 }
@@ -88,6 +94,12 @@ func newStreamModifier(logger log.Logger) *streamModifier {
 // Set handles HandleWorkerErrorRequest with ErrorOp_Skip, ErrorOp_Replace, ErrorOp_Inject.
 // - ErrorOp_Skip: events will be ignored.
 // - ErrorOp_Replace, ErrorOp_Inject: events should be query events generated by caller.
+//
+// If the request has tables or event types, ErrorOp_Skip and ErrorOp_Replace
+// only work on the matched events in the transaction at the position, the
+// first matched event is replaced and the other matched events are ignored
+// for ErrorOp_Replace. ErrorOp_Replace only works on the DDLs, since its
+// events can't be injected into a DML transaction.
 func (m *streamModifier) Set(req *pb.HandleWorkerErrorRequest, events []*replication.BinlogEvent) error {
 	// precheck
 	switch req.Op {
@@ -104,12 +116,26 @@ func (m *streamModifier) Set(req *pb.HandleWorkerErrorRequest, events []*replica
 		return terror.ErrSyncerEvent.Generatef("invalid error op: %s", req.Op.String())
 	}
 
+	scope, err := newOperatorScope(req.Tables, req.EventTypes)
+	if err != nil {
+		return err
+	}
+	if scope != nil && req.Op == pb.ErrorOp_Inject {
+		return terror.ErrVerifyHandleErrorArgs.Generatef("inject op can not be limited to tables or event types")
+	}
+	if scope != nil && req.Op == pb.ErrorOp_Replace {
+		if scope.hasDMLEventTypes() {
+			return terror.ErrVerifyHandleErrorArgs.Generatef("replace op can not be limited to dml event types")
+		}
+		scope.ddlOnly = true
+	}
+
 	pos, err := binlog.PositionFromPosStr(req.BinlogPos)
 	if err != nil {
 		return err
 	}
 
-	toInject := newOperator(req.Op, pos, events, req)
+	toInject := newOperator(req.Op, pos, events, scope, req)
 	toInsertIndex := m.minIdxLargerOrEqual(pos)
 
 	if toInsertIndex == len(m.ops) {
@@ -219,6 +245,7 @@ func (m *streamModifier) front() *operator {
 func (m *streamModifier) next() {
 	m.nextOp++
 	m.nextEventInOp = 0
+	m.inScopedTxn = false
 }
 
 type getEventFromFrontOpStatus int
@@ -256,6 +283,7 @@ func (m *streamModifier) minIdxLargerOrEqual(pos mysql.Position) int {
 // reset will also reset nextEventInOp to a correct value.
 func (m *streamModifier) reset(loc binlog.Location) {
 	m.nextEventInOp = 0
+	m.inScopedTxn = false
 	m.nextOp = m.minIdxLargerOrEqual(loc.Position)
 
 	if m.nextOp == len(m.ops) {
@@ -157,6 +157,30 @@ func TestInvalidSet(t *testing.T) {
 	}
 	err = m.Set(wrongReq, []*replication.BinlogEvent{{}, {}, {}})
 	require.ErrorContains(t, err, "should be like (mysql-bin.000001, 2345)")
+
+	wrongReq = &pb.HandleWorkerErrorRequest{
+		Op:        pb.ErrorOp_Inject,
+		BinlogPos: "(bin.000001, 1010)",
+		Tables:    []string{"db.tbl"},
+	}
+	err = m.Set(wrongReq, []*replication.BinlogEvent{{}})
+	require.ErrorContains(t, err, "inject op can not be limited")
+
+	wrongReq = &pb.HandleWorkerErrorRequest{
+		Op:         pb.ErrorOp_Skip,
+		BinlogPos:  "(bin.000001, 1010)",
+		EventTypes: []string{"truncate"},
+	}
+	err = m.Set(wrongReq, nil)
+	require.ErrorContains(t, err, "invalid --event-type truncate")
+
+	wrongReq = &pb.HandleWorkerErrorRequest{
+		Op:         pb.ErrorOp_Replace,
+		BinlogPos:  "(bin.000001, 1010)",
+		EventTypes: []string{"all ddl", "insert"},
+	}
+	err = m.Set(wrongReq, []*replication.BinlogEvent{{}})
+	require.ErrorContains(t, err, "replace op can not be limited to dml event types")
 }
 
 func TestSet(t *testing.T) {
@@ -430,6 +430,25 @@ LOOP:
 			Pos:  c.lastEventFromUpstream.Header.LogPos - c.lastEventFromUpstream.Header.EventSize,
 		}
 		cmp := binlog.ComparePosition(startPos, frontOp.pos)
+		// a scoped op works on the events until the end of the transaction.
+		if frontOp.scope != nil && cmp >= 0 {
+			if cmp > 0 && !c.streamModifier.inScopedTxn {
+				c.logger.Warn("mismatched handle op",
+					zap.Stringer("op", frontOp.op),
+					zap.Stringer("startPos", startPos),
+					zap.Stringer("frontOp", frontOp.pos),
+				)
+				c.streamModifier.next()
+				continue
+			}
+			c.streamModifier.inScopedTxn = true
+			var txnEnd bool
+			event, suffix, op, txnEnd = c.getEventFromScopedOp(frontOp, startPos)
+			if txnEnd {
+				c.streamModifier.next()
+			}
+			break LOOP
+		}
 		switch cmp {
 		// when upstream event is earlier than any injected op.
 		case -1:
@@ -534,6 +553,53 @@ LOOP:
 	return
 }
 
+// getEventFromScopedOp gets event for a scoped op from the transaction of the
+// op. It returns whether the event is the end of the transaction, in which
+// case the op is consumed.
+func (c *StreamerController) getEventFromScopedOp(
+	frontOp *operator,
+	startPos mysql.Position,
+) (
+	event *replication.BinlogEvent,
+	suffix int,
+	op pb.ErrorOp,
+	txnEnd bool,
+) {
+	upstreamEvent := c.lastEventFromUpstream
+	// the query events of the DML transaction, such as BEGIN, are neither
+	// matched nor the end of the transaction.
+	_, isQueryEvent := upstreamEvent.Event.(*replication.QueryEvent)
+	inDMLQuery := isQueryEvent && c.upstream.inDMLQuery
+	txnEnd = !inDMLQuery && isTxnEnd(upstreamEvent)
+	if inDMLQuery || !frontOp.scope.match(upstreamEvent) {
+		c.lastEventFromUpstream = nil
+		return upstreamEvent, 0, pb.ErrorOp_InvalidErrorOp, txnEnd
+	}
+
+	if frontOp.op == pb.ErrorOp_Replace {
+		var status getEventFromFrontOpStatus
+		event, status = c.streamModifier.getEventFromFrontOp()
+		switch status {
+		case lastEvent:
+			// same as Replace of the op without scope, the last event imitates
+			// the real event.
+			event.Header.LogPos = upstreamEvent.Header.LogPos
+			event.Header.EventSize = upstreamEvent.Header.EventSize
+			c.lastEventFromUpstream = nil
+			return event, 0, pb.ErrorOp_Replace, txnEnd
+		case normal:
+			event.Header.LogPos = startPos.Pos
+			return event, c.streamModifier.nextEventInOp, pb.ErrorOp_Replace, false
+		}
+		// the matched events after the replaced one are skipped.
+	}
+
+	// skipped event and op should be sent to caller, to let schema tracker
+	// and checkpoint work
+	c.lastEventFromUpstream = nil
+	return upstreamEvent, 0, pb.ErrorOp_Skip, txnEnd
+}
+
 // Close closes streamer.
 func (c *StreamerController) Close() {
 	c.Lock()
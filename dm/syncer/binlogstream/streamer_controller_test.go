@@ -265,6 +265,120 @@ func TestGetEventWithSkip(t *testing.T) {
 	checkGetEvent(t, controller, expecteds)
 }
 
+func scopedTxnEvent(logPos uint32, e *replication.BinlogEvent) *replication.BinlogEvent {
+	e.Header.LogPos = logPos
+	e.Header.EventSize = 10
+	return e
+}
+
+func TestGetEventWithScopedSkip(t *testing.T) {
+	upstream := &mockStream{
+		events: []*replication.BinlogEvent{
+			scopedTxnEvent(1010, queryEvent("db", "BEGIN")),
+			scopedTxnEvent(1020, rowsEvent(replication.WRITE_ROWS_EVENTv2, "db", "t1")),
+			scopedTxnEvent(1030, rowsEvent(replication.WRITE_ROWS_EVENTv2, "db", "t2")),
+			scopedTxnEvent(1040, rowsEvent(replication.UPDATE_ROWS_EVENTv2, "db", "t1")),
+			{
+				Header: &replication.EventHeader{LogPos: 1050, EventSize: 10},
+				Event:  &replication.XIDEvent{},
+			},
+			// the next transaction is not affected
+			scopedTxnEvent(1060, rowsEvent(replication.WRITE_ROWS_EVENTv2, "db", "t1")),
+		},
+	}
+	producer := &mockStreamProducer{upstream}
+
+	controller := NewStreamerController4Test(producer, upstream)
+
+	skipReq := &pb.HandleWorkerErrorRequest{
+		Op:         pb.ErrorOp_Skip,
+		BinlogPos:  "(bin.000001, 1000)",
+		Tables:     []string{"db.t1"},
+		EventTypes: []string{"insert"},
+	}
+	err := controller.Set(skipReq, nil)
+	require.NoError(t, err)
+	loc := binlog.Location{Position: mysql.Position{
+		Name: "bin.000001",
+		Pos:  1000,
+	}}
+	controller.streamModifier.reset(loc)
+	controller.upstream.locationRecorder.reset(loc)
+
+	expecteds := []expectedInfo{
+		{1010, 0, nil, pb.ErrorOp_InvalidErrorOp},
+		{1020, 0, nil, pb.ErrorOp_Skip},
+		{1030, 0, nil, pb.ErrorOp_InvalidErrorOp},
+		{1040, 0, nil, pb.ErrorOp_InvalidErrorOp},
+		{1050, 0, nil, pb.ErrorOp_InvalidErrorOp},
+		{1060, 0, nil, pb.ErrorOp_InvalidErrorOp},
+	}
+
+	checkGetEvent(t, controller, expecteds)
+}
+
+func TestGetEventWithScopedReplace(t *testing.T) {
+	upstream := &mockStream{
+		events: []*replication.BinlogEvent{
+			scopedTxnEvent(1010, queryEvent("db", "ALTER TABLE t1 ADD COLUMN c INT")),
+			scopedTxnEvent(1020, queryEvent("db", "ALTER TABLE t2 ADD COLUMN c INT")),
+			scopedTxnEvent(1030, queryEvent("db", "BEGIN")),
+			scopedTxnEvent(1040, rowsEvent(replication.WRITE_ROWS_EVENTv2, "db", "t1")),
+			{
+				Header: &replication.EventHeader{LogPos: 1050, EventSize: 10},
+				Event:  &replication.XIDEvent{},
+			},
+		},
+	}
+	producer := &mockStreamProducer{upstream}
+
+	controller := NewStreamerController4Test(producer, upstream)
+
+	replaceEvents := func() []*replication.BinlogEvent {
+		return []*replication.BinlogEvent{
+			{
+				Header:  &replication.EventHeader{},
+				Event:   &replication.QueryEvent{Query: []byte("a DDL")},
+				RawData: []byte("replace 1"),
+			},
+			{
+				Header:  &replication.EventHeader{},
+				Event:   &replication.QueryEvent{Query: []byte("a DDL")},
+				RawData: []byte("replace 2"),
+			},
+		}
+	}
+	// the replace ops at the DDL of t1, the DDL of t2 and the DML transaction.
+	for _, pos := range []string{"(bin.000001, 1000)", "(bin.000001, 1010)", "(bin.000001, 1020)"} {
+		replaceReq := &pb.HandleWorkerErrorRequest{
+			Op:        pb.ErrorOp_Replace,
+			BinlogPos: pos,
+			Tables:    []string{"db.t1"},
+		}
+		err := controller.Set(replaceReq, replaceEvents())
+		require.NoError(t, err)
+	}
+	loc := binlog.Location{Position: mysql.Position{
+		Name: "bin.000001",
+		Pos:  1000,
+	}}
+	controller.streamModifier.reset(loc)
+	controller.upstream.locationRecorder.reset(loc)
+
+	// the DDL of t1 is replaced, the DDL of t2 is out of the scope, and the
+	// DML events are never replaced.
+	expecteds := []expectedInfo{
+		{1000, 1, []byte("replace 1"), pb.ErrorOp_Replace},
+		{1010, 0, []byte("replace 2"), pb.ErrorOp_Replace},
+		{1020, 0, nil, pb.ErrorOp_InvalidErrorOp},
+		{1030, 0, nil, pb.ErrorOp_InvalidErrorOp},
+		{1040, 0, nil, pb.ErrorOp_InvalidErrorOp},
+		{1050, 0, nil, pb.ErrorOp_InvalidErrorOp},
+	}
+
+	checkGetEvent(t, controller, expecteds)
+}
+
 func checkGetEvent(t *testing.T, controller *StreamerController, expecteds []expectedInfo) {
 	t.Helper()
 
@@ -25,7 +25,6 @@ import (
 	"github.com/pingcap/tidb/parser/ast"
 	"github.com/pingcap/tidb/parser/model"
 	tablefilter "github.com/pingcap/tidb/util/filter"
-	regexprrouter "github.com/pingcap/tidb/util/regexpr-router"
 	filter "github.com/pingcap/tidb/util/table-filter"
 	"github.com/pingcap/tiflow/dm/config"
 	"github.com/pingcap/tiflow/dm/pkg/binlog"
@@ -34,6 +33,7 @@ import (
 	tcontext "github.com/pingcap/tiflow/dm/pkg/context"
 	"github.com/pingcap/tiflow/dm/pkg/log"
 	parserpkg "github.com/pingcap/tiflow/dm/pkg/parser"
+	dmrouter "github.com/pingcap/tiflow/dm/pkg/router"
 	"github.com/pingcap/tiflow/dm/pkg/schema"
 	"github.com/pingcap/tiflow/dm/pkg/shardddl/optimism"
 	"github.com/pingcap/tiflow/dm/pkg/terror"
@@ -68,7 +68,7 @@ type DDLWorker struct {
 	upstreamTZStr              string
 	onlineDDL                  onlineddl.OnlinePlugin
 	checkpoint                 CheckPoint
-	tableRouter                *regexprrouter.RouteTable
+	tableRouter                *dmrouter.RouteTable
 	sourceTableNamesFlavor     conn.LowerCaseTableNamesFlavor
 	collationCompatible        string
 	charsetAndDefaultCollation map[string]string
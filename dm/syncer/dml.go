@@ -418,23 +418,19 @@ func genDMLsWithSameTable(op sqlmodel.DMLType, jobs []*job) ([]string, [][]inter
 		return queries, args
 	}
 
-	// group dmls with same table
-	for i, j := range jobs {
-		if i == 0 {
-			lastTable = j.dml.TargetTableID()
+	// group dmls with same table, the dmls of different tables are not conflicted,
+	// so the interleaved dmls of a table can be merged while keeping their order.
+	tables := make([]string, 0, 1)
+	tableDMLs := make(map[string][]*sqlmodel.RowChange)
+	for _, j := range jobs {
+		table := j.dml.TargetTableID()
+		if _, ok := tableDMLs[table]; !ok {
+			tables = append(tables, table)
 		}
-		if lastTable != j.dml.TargetTableID() {
-			query, arg := genDMLsWithSameCols(op, groupDMLs)
-			queries = append(queries, query...)
-			args = append(args, arg...)
-
-			groupDMLs = groupDMLs[0:0]
-			lastTable = j.dml.TargetTableID()
-		}
-		groupDMLs = append(groupDMLs, j.dml)
+		tableDMLs[table] = append(tableDMLs[table], j.dml)
 	}
-	if len(groupDMLs) > 0 {
-		query, arg := genDMLsWithSameCols(op, groupDMLs)
+	for _, table := range tables {
+		query, arg := genDMLsWithSameCols(op, tableDMLs[table])
 		queries = append(queries, query...)
 		args = append(args, arg...)
 	}
@@ -344,3 +344,34 @@ func (s *testSyncerSuite) TestGBKExtractValueFromData(c *C) {
 	got := extractValueFromData(row, ti.Columns, ti)
 	c.Assert(got, DeepEquals, expect)
 }
+
+func TestGenDMLWithInterleavedTables(t *testing.T) {
+	table1 := &cdcmodel.TableName{Schema: "db", Table: "tb1"}
+	table2 := &cdcmodel.TableName{Schema: "db", Table: "tb2"}
+	tableInfo1 := mockTableInfo(t, "create table db.tb1(id int primary key, name varchar(24))")
+	tableInfo2 := mockTableInfo(t, "create table db.tb2(id int primary key, name varchar(24))")
+
+	dmls := []*job{
+		newDMLJob(sqlmodel.NewRowChange(table1, table1, nil, []interface{}{1, "a"}, tableInfo1, nil, nil), ec),
+		newDMLJob(sqlmodel.NewRowChange(table2, table2, nil, []interface{}{1, "a"}, tableInfo2, nil, nil), ec),
+		newDMLJob(sqlmodel.NewRowChange(table1, table1, nil, []interface{}{2, "b"}, tableInfo1, nil, nil), ec),
+		newDMLJob(sqlmodel.NewRowChange(table2, table2, nil, []interface{}{2, "b"}, tableInfo2, nil, nil), ec),
+		// a delete breaks the insert batch
+		newDMLJob(sqlmodel.NewRowChange(table1, table1, []interface{}{1, "a"}, nil, tableInfo1, nil, nil), ec),
+		newDMLJob(sqlmodel.NewRowChange(table1, table1, nil, []interface{}{1, "c"}, tableInfo1, nil, nil), ec),
+	}
+
+	queries, args := genDMLsWithSameOp(dmls)
+	require.Equal(t, []string{
+		"INSERT INTO `db`.`tb1` (`id`,`name`) VALUES (?,?),(?,?)",
+		"INSERT INTO `db`.`tb2` (`id`,`name`) VALUES (?,?),(?,?)",
+		"DELETE FROM `db`.`tb1` WHERE (`id`) IN ((?))",
+		"INSERT INTO `db`.`tb1` (`id`,`name`) VALUES (?,?)",
+	}, queries)
+	require.Equal(t, [][]interface{}{
+		{1, "a", 2, "b"},
+		{1, "a", 2, "b"},
+		{1},
+		{1, "c"},
+	}, args)
+}
@@ -19,6 +19,7 @@ import (
 
 	"github.com/pingcap/errors"
 	"github.com/pingcap/failpoint"
+	"github.com/pingcap/tiflow/dm/pkg/conn"
 	tcontext "github.com/pingcap/tiflow/dm/pkg/context"
 	"github.com/pingcap/tiflow/dm/pkg/log"
 	"github.com/pingcap/tiflow/dm/pkg/terror"
@@ -31,15 +32,19 @@ import (
 
 // DMLWorker is used to sync dml.
 type DMLWorker struct {
-	compact       bool
-	batch         int
-	workerCount   int
-	chanSize      int
-	multipleRows  bool
-	toDBConns     []*dbconn.DBConn
-	syncCtx       *tcontext.Context
-	logger        log.Logger
-	metricProxies *metrics.Proxies
+	compact      bool
+	batch        int
+	batchLatency time.Duration
+	workerCount  int
+	chanSize     int
+	multipleRows bool
+	// quarantine the row changes which meet duplicate-key conflicts into the meta schema.
+	conflictQuarantine bool
+	metaSchema         string
+	toDBConns          []*dbconn.DBConn
+	syncCtx            *tcontext.Context
+	logger             log.Logger
+	metricProxies      *metrics.Proxies
 
 	// for MetricsProxies
 	task   string
@@ -67,9 +72,12 @@ func dmlWorkerWrap(inCh chan *job, syncer *Syncer) chan *job {
 	dmlWorker := &DMLWorker{
 		compact:              syncer.cfg.Compact,
 		batch:                syncer.cfg.Batch,
+		batchLatency:         syncer.cfg.BatchLatency.Duration,
 		workerCount:          syncer.cfg.WorkerCount,
 		chanSize:             chanSize,
 		multipleRows:         syncer.cfg.MultipleRows,
+		conflictQuarantine:   syncer.cfg.ConflictQuarantine,
+		metaSchema:           syncer.cfg.MetaSchema,
 		task:                 syncer.cfg.Name,
 		source:               syncer.cfg.SourceID,
 		worker:               syncer.cfg.WorkerName,
@@ -156,23 +164,52 @@ func (w *DMLWorker) sendJobToAllDmlQueue(j *job, jobChs []chan *job, queueBucket
 
 // executeJobs execute jobs in same queueBucket
 // All the jobs received should be executed consecutively.
+// If batchLatency is set, a batch which is not full waits for at most batchLatency
+// for the following jobs, so more DMLs can be merged into multi-row statements.
 func (w *DMLWorker) executeJobs(queueID int, jobCh chan *job) {
 	jobs := make([]*job, 0, w.batch)
 	workerJobIdx := dmlWorkerJobIdx(queueID)
 	queueBucket := queueBucketName(queueID)
-	for j := range jobCh {
+	// batchTimer is nil when there is no batch waiting for jobs.
+	var batchTimer <-chan time.Time
+	for {
+		var (
+			j  *job
+			ok bool
+		)
+		select {
+		case j, ok = <-jobCh:
+		case <-batchTimer:
+			batchTimer = nil
+			w.executeBatchJobs(queueID, jobs)
+			jobs = jobs[0:0]
+			if len(jobCh) == 0 {
+				w.lagFunc(nil, workerJobIdx)
+			}
+			continue
+		}
+		if !ok {
+			if len(jobs) > 0 {
+				w.executeBatchJobs(queueID, jobs)
+			}
+			return
+		}
 		w.metricProxies.QueueSizeGauge.WithLabelValues(w.task, queueBucket, w.source).Set(float64(len(jobCh)))
 
 		if j.tp != flush && j.tp != asyncFlush && j.tp != conflict {
 			if len(jobs) == 0 {
 				// set job TS when received first job of this batch.
 				w.lagFunc(j, workerJobIdx)
+				if w.batchLatency > 0 {
+					batchTimer = time.After(w.batchLatency)
+				}
 			}
 			jobs = append(jobs, j)
-			if len(jobs) < w.batch && len(jobCh) > 0 {
+			if len(jobs) < w.batch && (len(jobCh) > 0 || batchTimer != nil) {
 				continue
 			}
 		}
+		batchTimer = nil
 
 		failpoint.Inject("syncDMLBatchNotFull", func() {
 			if len(jobCh) == 0 && len(jobs) < w.batch {
@@ -257,6 +294,10 @@ func (w *DMLWorker) executeBatchJobs(queueID int, jobs []*job) {
 	ctx, cancel := w.syncCtx.WithTimeout(maxDMLConnectionDuration)
 	defer cancel()
 	affect, err = db.ExecuteSQL(ctx, w.metricProxies, queries, args...)
+	if err != nil && w.conflictQuarantine && conn.IsErrDuplicateEntry(err) {
+		// the batch is rolled back, so the jobs can be executed again one by one.
+		affect, err = w.quarantineConflicts(ctx, queueID, jobs)
+	}
 	failpoint.Inject("SafeModeExit", func(val failpoint.Value) {
 		if intVal, ok := val.(int); ok && intVal == 4 && len(jobs) > 0 {
 			w.logger.Warn("fail to exec DML", zap.String("failpoint", "SafeModeExit"))
@@ -14,14 +14,27 @@
 package syncer
 
 import (
+	"context"
+	"regexp"
 	"testing"
+	"time"
 
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
 	tiddl "github.com/pingcap/tidb/ddl"
+	"github.com/pingcap/tidb/errno"
 	"github.com/pingcap/tidb/parser"
 	"github.com/pingcap/tidb/parser/ast"
 	timodel "github.com/pingcap/tidb/parser/model"
 	timock "github.com/pingcap/tidb/util/mock"
 	cdcmodel "github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/dm/config"
+	"github.com/pingcap/tiflow/dm/pkg/conn"
+	tcontext "github.com/pingcap/tiflow/dm/pkg/context"
+	"github.com/pingcap/tiflow/dm/pkg/log"
+	"github.com/pingcap/tiflow/dm/pkg/retry"
+	"github.com/pingcap/tiflow/dm/syncer/dbconn"
+	"github.com/pingcap/tiflow/dm/syncer/metrics"
 	"github.com/pingcap/tiflow/pkg/sqlmodel"
 	"github.com/stretchr/testify/require"
 )
@@ -128,3 +141,116 @@ func TestJudgeKeyNotFound(t *testing.T) {
 	require.False(t, dmlWorker.judgeKeyNotFound(2, jobs))
 	require.False(t, dmlWorker.judgeKeyNotFound(4, jobs))
 }
+
+func TestExecuteJobsWithBatchLatency(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	dbConn, err := db.Conn(context.Background())
+	require.NoError(t, err)
+
+	cfg := &config.SubTaskConfig{Name: "task", WorkerCount: 1}
+	executed := make(chan int, 1)
+	worker := &DMLWorker{
+		batch:         10,
+		batchLatency:  500 * time.Millisecond,
+		multipleRows:  true,
+		toDBConns:     []*dbconn.DBConn{dbconn.NewDBConn(cfg, conn.NewBaseConnForTest(dbConn, &retry.FiniteRetryStrategy{}))},
+		syncCtx:       tcontext.Background(),
+		logger:        log.L(),
+		metricProxies: metrics.DefaultMetricsProxies.CacheForOneTask("task", "worker", "source"),
+		successFunc: func(_ int, _ int, jobs []*job) {
+			executed <- len(jobs)
+		},
+		fatalFunc: func(_ *job, err error) {
+			require.NoError(t, err)
+		},
+		lagFunc: func(*job, int) {},
+	}
+
+	source := &cdcmodel.TableName{Schema: "db", Table: "tb"}
+	tableInfo := mockTableInfo(t, "create table db.tb(id int primary key, name varchar(24))")
+	newInsertJob := func(id int) *job {
+		return newDMLJob(sqlmodel.NewRowChange(source, source, nil, []interface{}{id, "a"}, tableInfo, nil, nil), ec)
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `db`.`tb` (`id`,`name`) VALUES (?,?),(?,?)")).
+		WithArgs(1, "a", 2, "a").
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	jobCh := make(chan *job, 10)
+	go worker.executeJobs(0, jobCh)
+	// the second job arrives within the batch latency, so it is merged into the batch.
+	jobCh <- newInsertJob(1)
+	time.Sleep(10 * time.Millisecond)
+	jobCh <- newInsertJob(2)
+	select {
+	case n := <-executed:
+		require.Equal(t, 2, n)
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "batch is not executed after batch latency")
+	}
+	close(jobCh)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestExecuteBatchJobsWithConflictQuarantine(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	dbConn, err := db.Conn(context.Background())
+	require.NoError(t, err)
+
+	cfg := &config.SubTaskConfig{Name: "task", WorkerCount: 1}
+	var executed []*job
+	worker := &DMLWorker{
+		batch:              10,
+		conflictQuarantine: true,
+		metaSchema:         "dm_meta",
+		task:               "task",
+		source:             "mysql-01",
+		toDBConns:          []*dbconn.DBConn{dbconn.NewDBConn(cfg, conn.NewBaseConnForTest(dbConn, &retry.FiniteRetryStrategy{}))},
+		syncCtx:            tcontext.Background(),
+		logger:             log.L(),
+		metricProxies:      metrics.DefaultMetricsProxies.CacheForOneTask("task", "worker", "source"),
+		successFunc: func(_ int, _ int, jobs []*job) {
+			executed = jobs
+		},
+		fatalFunc: func(_ *job, err error) {
+			require.NoError(t, err)
+		},
+	}
+
+	source := &cdcmodel.TableName{Schema: "db", Table: "tb"}
+	tableInfo := mockTableInfo(t, "create table db.tb(id int primary key, name varchar(24))")
+	jobs := []*job{
+		newDMLJob(sqlmodel.NewRowChange(source, source, nil, []interface{}{1, "a"}, tableInfo, nil, nil), ec),
+		newDMLJob(sqlmodel.NewRowChange(source, source, nil, []interface{}{2, "a"}, tableInfo, nil, nil), ec),
+	}
+	insertSQL := regexp.QuoteMeta("INSERT INTO `db`.`tb` (`id`,`name`) VALUES (?,?)")
+	dupErr := &mysql.MySQLError{Number: errno.ErrDupEntry, Message: "Duplicate entry '2' for key 'PRIMARY'"}
+
+	// the batch meets a conflict and is rolled back
+	mock.ExpectBegin()
+	mock.ExpectExec(insertSQL).WithArgs(1, "a").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(insertSQL).WithArgs(2, "a").WillReturnError(dupErr)
+	mock.ExpectRollback()
+	// the jobs are executed one by one, and the conflicting one is quarantined
+	mock.ExpectBegin()
+	mock.ExpectExec(insertSQL).WithArgs(1, "a").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	mock.ExpectBegin()
+	mock.ExpectExec(insertSQL).WithArgs(2, "a").WillReturnError(dupErr)
+	mock.ExpectRollback()
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `dm_meta`.`task_syncer_conflict_quarantine`")).
+		WithArgs("mysql-01", "db", "tb", "db", "tb", sqlmock.AnyArg(),
+			"[{\"sql\":\"INSERT INTO `db`.`tb` (`id`,`name`) VALUES (?,?)\",\"args\":[\"Mg==\",\"YQ==\"]}]",
+			sqlmock.AnyArg(), "pending").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	worker.executeBatchJobs(0, jobs)
+	require.Equal(t, jobs, executed)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
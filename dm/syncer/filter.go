@@ -123,6 +123,10 @@ func skipByFilter(binlogFilter *bf.BinlogEvent, table *filter.Table, et bf.Event
 }
 
 func (s *Syncer) skipByTable(table *filter.Table) bool {
+	// the lag heartbeat table is always replicated to measure the lag.
+	if s.isLagHeartbeatTable(table) {
+		return false
+	}
 	return skipByTable(s.baList, table)
 }
 
@@ -37,8 +37,15 @@ func (s *Syncer) HandleError(ctx context.Context, req *pb.HandleWorkerErrorReque
 			return "", fmt.Errorf("source '%s' has no error", s.cfg.SourceID)
 		}
 
-		if !isQueryEvent && req.Op != pb.ErrorOp_Inject {
-			return "", fmt.Errorf("only support to handle ddl error currently, see https://docs.pingcap.com/tidb-data-migration/stable/error-handling for other errors")
+		// the skip operator limited to some tables or event types can handle
+		// the errors of the DMLs in the transaction. The DMLs can't be replaced
+		// by the DDLs of the replace operator.
+		scoped := len(req.Tables) > 0 || len(req.EventTypes) > 0
+		if !isQueryEvent && req.Op == pb.ErrorOp_Replace {
+			return "", fmt.Errorf("replace op can not handle dml error, specify --table or --event-type to skip the dml events, see https://docs.pingcap.com/tidb-data-migration/stable/error-handling for other errors")
+		}
+		if !isQueryEvent && req.Op != pb.ErrorOp_Inject && !scoped {
+			return "", fmt.Errorf("only support to handle ddl error currently, specify --table or --event-type to handle dml error, see https://docs.pingcap.com/tidb-data-migration/stable/error-handling for other errors")
 		}
 		pos = startLocation.Position.String()
 	} else {
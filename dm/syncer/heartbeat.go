@@ -0,0 +1,154 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pingcap/tidb/util/dbutil"
+	"github.com/pingcap/tidb/util/filter"
+	"github.com/pingcap/tiflow/dm/pkg/conn"
+	tcontext "github.com/pingcap/tiflow/dm/pkg/context"
+	"go.uber.org/zap"
+)
+
+// the lag heartbeat table is written in the upstream and replicated by the
+// task like a normal table, so the replication lag is measured end to end.
+const (
+	lagHeartbeatSchema = "dm_heartbeat"
+	lagHeartbeatTable  = "lag_heartbeat"
+)
+
+func lagHeartbeatCreateSQLs(table *filter.Table) []string {
+	return []string{
+		fmt.Sprintf("CREATE DATABASE IF NOT EXISTS %s", dbutil.ColumnName(table.Schema)),
+		fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+			task VARCHAR(128) NOT NULL,
+			source_id VARCHAR(32) NOT NULL,
+			ts BIGINT NOT NULL,
+			PRIMARY KEY (task, source_id)
+		)`, dbutil.TableName(table.Schema, table.Name)),
+	}
+}
+
+// isLagHeartbeatTable returns whether the table is the lag heartbeat table,
+// a DDL which creates the heartbeat schema has an empty table name.
+func (s *Syncer) isLagHeartbeatTable(table *filter.Table) bool {
+	if !s.cfg.EnableLagHeartbeat || !strings.EqualFold(table.Schema, lagHeartbeatSchema) {
+		return false
+	}
+	return table.Name == "" || strings.EqualFold(table.Name, lagHeartbeatTable)
+}
+
+// calcLagHeartbeatLag returns the replication lag in seconds of a heartbeat
+// written at ts (unix nanoseconds) and observed at now.
+func calcLagHeartbeatLag(now time.Time, ts int64) int64 {
+	lag := int64(now.Sub(time.Unix(0, ts)).Seconds())
+	if lag < 0 {
+		return 0
+	}
+	return lag
+}
+
+// prepareLagHeartbeat creates the lag heartbeat table in both the upstream and
+// the downstream, so the schema tracker can always fetch the table structure
+// from the downstream even if the upstream table is created before the task.
+func (s *Syncer) prepareLagHeartbeat(tctx *tcontext.Context) error {
+	source := &filter.Table{Schema: lagHeartbeatSchema, Name: lagHeartbeatTable}
+	for _, sql := range lagHeartbeatCreateSQLs(source) {
+		if _, err := s.fromDB.BaseDB.ExecContext(tctx, sql); err != nil {
+			return err
+		}
+	}
+	for _, sql := range lagHeartbeatCreateSQLs(s.route(source)) {
+		if _, err := s.toDB.ExecContext(tctx, sql); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeLagHeartbeat writes the current time of DM-worker into the upstream.
+func (s *Syncer) writeLagHeartbeat(tctx *tcontext.Context, now time.Time) error {
+	sql := fmt.Sprintf("REPLACE INTO %s (task, source_id, ts) VALUES (?, ?, ?)",
+		dbutil.TableName(lagHeartbeatSchema, lagHeartbeatTable))
+	_, err := s.fromDB.BaseDB.ExecContext(tctx, sql, s.cfg.Name, s.cfg.SourceID, now.UnixNano())
+	return err
+}
+
+// observeLagHeartbeat reads the replicated heartbeat from the downstream, and
+// returns false if the heartbeat is not replicated yet.
+func (s *Syncer) observeLagHeartbeat(tctx *tcontext.Context) (int64, bool, error) {
+	target := s.route(&filter.Table{Schema: lagHeartbeatSchema, Name: lagHeartbeatTable})
+	sql := fmt.Sprintf("SELECT ts FROM %s WHERE task = ? AND source_id = ?",
+		dbutil.TableName(target.Schema, target.Name))
+	return queryLagHeartbeat(tctx, s.toDB, sql, s.cfg.Name, s.cfg.SourceID)
+}
+
+func queryLagHeartbeat(tctx *tcontext.Context, db *conn.BaseDB, sql string, args ...interface{}) (int64, bool, error) {
+	rows, err := db.QueryContext(tctx, sql, args...)
+	if err != nil {
+		return 0, false, err
+	}
+	defer rows.Close()
+	if !rows.Next() {
+		return 0, false, rows.Err()
+	}
+	var ts int64
+	if err = rows.Scan(&ts); err != nil {
+		return 0, false, err
+	}
+	return ts, true, rows.Err()
+}
+
+// lagHeartbeatCronJob writes a heartbeat into the upstream every interval,
+// and updates the replication lag by the heartbeat observed in the downstream.
+// The writer and the observer are both DM-worker, so the lag is not affected
+// by the clock skew between the upstream and the downstream.
+func (s *Syncer) lagHeartbeatCronJob(ctx context.Context) {
+	defer s.runWg.Done()
+
+	tctx := s.tctx.WithContext(ctx)
+	if err := s.prepareLagHeartbeat(tctx); err != nil {
+		s.tctx.L().Warn("fail to prepare lag heartbeat table, lag heartbeat is disabled", zap.Error(err))
+		return
+	}
+
+	ticker := time.NewTicker(time.Duration(s.cfg.LagHeartbeatInterval) * time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := s.writeLagHeartbeat(tctx, time.Now()); err != nil {
+				s.tctx.L().Warn("fail to write lag heartbeat", zap.Error(err))
+			}
+			ts, ok, err := s.observeLagHeartbeat(tctx)
+			if err != nil {
+				s.tctx.L().Warn("fail to observe lag heartbeat", zap.Error(err))
+				continue
+			}
+			if !ok {
+				continue
+			}
+			lag := calcLagHeartbeatLag(time.Now(), ts)
+			s.metricsProxies.Metrics.HeartbeatLagGauge.Set(float64(lag))
+			s.heartbeatLag.Store(lag)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,111 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/pingcap/tidb/util/filter"
+	router "github.com/pingcap/tidb/util/table-router"
+	"github.com/pingcap/tiflow/dm/config"
+	"github.com/pingcap/tiflow/dm/pkg/conn"
+	tcontext "github.com/pingcap/tiflow/dm/pkg/context"
+	dmrouter "github.com/pingcap/tiflow/dm/pkg/router"
+	"github.com/pingcap/tiflow/dm/syncer/dbconn"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalcLagHeartbeatLag(t *testing.T) {
+	now := time.Now()
+	require.Equal(t, int64(0), calcLagHeartbeatLag(now, now.UnixNano()))
+	require.Equal(t, int64(3), calcLagHeartbeatLag(now, now.Add(-3500*time.Millisecond).UnixNano()))
+	// the heartbeat is written after now is taken.
+	require.Equal(t, int64(0), calcLagHeartbeatLag(now, now.Add(time.Second).UnixNano()))
+}
+
+func TestIsLagHeartbeatTable(t *testing.T) {
+	cfg := &config.SubTaskConfig{}
+	cfg.BAList = &filter.Rules{DoDBs: []string{"db"}}
+	s := &Syncer{cfg: cfg}
+	var err error
+	s.baList, err = filter.New(false, cfg.BAList)
+	require.NoError(t, err)
+
+	heartbeat := &filter.Table{Schema: lagHeartbeatSchema, Name: lagHeartbeatTable}
+	require.False(t, s.isLagHeartbeatTable(heartbeat))
+	require.True(t, s.skipByTable(heartbeat))
+
+	cfg.EnableLagHeartbeat = true
+	require.True(t, s.isLagHeartbeatTable(heartbeat))
+	require.True(t, s.isLagHeartbeatTable(&filter.Table{Schema: lagHeartbeatSchema}))
+	require.False(t, s.isLagHeartbeatTable(&filter.Table{Schema: lagHeartbeatSchema, Name: "t"}))
+	require.False(t, s.skipByTable(heartbeat))
+	require.True(t, s.skipByTable(&filter.Table{Schema: lagHeartbeatSchema, Name: "t"}))
+}
+
+func TestWriteAndObserveLagHeartbeat(t *testing.T) {
+	fromDB, fromMock, err := sqlmock.New()
+	require.NoError(t, err)
+	toDB, toMock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	cfg := &config.SubTaskConfig{Name: "task", SourceID: "source"}
+	s := &Syncer{
+		cfg:    cfg,
+		fromDB: &dbconn.UpStreamConn{BaseDB: conn.NewBaseDBForTest(fromDB)},
+		toDB:   conn.NewBaseDBForTest(toDB),
+	}
+	s.tableRouter, err = dmrouter.NewRouteTable(false, []*router.TableRule{
+		{SchemaPattern: lagHeartbeatSchema, TargetSchema: "dm_heartbeat_target"},
+	})
+	require.NoError(t, err)
+	tctx := tcontext.Background()
+
+	fromMock.ExpectExec(regexp.QuoteMeta("CREATE DATABASE IF NOT EXISTS `dm_heartbeat`")).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	fromMock.ExpectExec(regexp.QuoteMeta("CREATE TABLE IF NOT EXISTS `dm_heartbeat`.`lag_heartbeat`")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	toMock.ExpectExec(regexp.QuoteMeta("CREATE DATABASE IF NOT EXISTS `dm_heartbeat_target`")).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	toMock.ExpectExec(regexp.QuoteMeta("CREATE TABLE IF NOT EXISTS `dm_heartbeat_target`.`lag_heartbeat`")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	require.NoError(t, s.prepareLagHeartbeat(tctx))
+
+	now := time.Now()
+	fromMock.ExpectExec(regexp.QuoteMeta("REPLACE INTO `dm_heartbeat`.`lag_heartbeat` (task, source_id, ts) VALUES (?, ?, ?)")).
+		WithArgs("task", "source", now.UnixNano()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	require.NoError(t, s.writeLagHeartbeat(tctx, now))
+
+	query := regexp.QuoteMeta("SELECT ts FROM `dm_heartbeat_target`.`lag_heartbeat` WHERE task = ? AND source_id = ?")
+	// not replicated yet
+	toMock.ExpectQuery(query).WithArgs("task", "source").
+		WillReturnRows(sqlmock.NewRows([]string{"ts"}))
+	_, ok, err := s.observeLagHeartbeat(tctx)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	toMock.ExpectQuery(query).WithArgs("task", "source").
+		WillReturnRows(sqlmock.NewRows([]string{"ts"}).AddRow(now.UnixNano()))
+	ts, ok, err := s.observeLagHeartbeat(tctx)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, now.UnixNano(), ts)
+
+	require.NoError(t, fromMock.ExpectationsWereMet())
+	require.NoError(t, toMock.ExpectationsWereMet())
+}
@@ -46,6 +46,7 @@ type Metrics struct {
 	ExitWithNonResumableErrorCounter prometheus.Counter
 	ReplicationLagGauge              prometheus.Gauge
 	ReplicationLagHistogram          prometheus.Observer
+	HeartbeatLagGauge                prometheus.Gauge
 	RemainingTimeGauge               prometheus.Gauge
 	ShardLockResolving               prometheus.Gauge
 	FinishedTransactionTotal         prometheus.Counter
@@ -78,6 +79,7 @@ type Proxies struct {
 	syncerExitWithErrorCounter      *prometheus.CounterVec
 	replicationLagGauge             *prometheus.GaugeVec
 	replicationLagHistogram         *prometheus.HistogramVec
+	heartbeatLagGauge               *prometheus.GaugeVec
 	remainingTimeGauge              *prometheus.GaugeVec
 	UnsyncedTableGauge              *prometheus.GaugeVec
 	shardLockResolving              *prometheus.GaugeVec
@@ -247,6 +249,13 @@ func (m *Proxies) Init(f promutil.Factory) {
 			Help:      "replication lag histogram in second between mysql and syncer",
 			Buckets:   prometheus.ExponentialBuckets(0.5, 2, 12), // exponential from 0.5s to 1024s
 		}, []string{"task", "source_id", "worker"})
+	m.heartbeatLagGauge = f.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "dm",
+			Subsystem: "syncer",
+			Name:      "heartbeat_lag_gauge",
+			Help:      "replication lag gauge in second measured by the heartbeat written in upstream and observed in downstream",
+		}, []string{"task", "source_id", "worker"})
 	m.remainingTimeGauge = f.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Namespace: "dm",
@@ -313,6 +322,7 @@ func (m *Proxies) CacheForOneTask(taskName, workerName, sourceID string) *Proxie
 	ret.Metrics.ExitWithNonResumableErrorCounter = m.syncerExitWithErrorCounter.WithLabelValues(taskName, sourceID, "false")
 	ret.Metrics.ReplicationLagGauge = m.replicationLagGauge.WithLabelValues(taskName, sourceID, workerName)
 	ret.Metrics.ReplicationLagHistogram = m.replicationLagHistogram.WithLabelValues(taskName, sourceID, workerName)
+	ret.Metrics.HeartbeatLagGauge = m.heartbeatLagGauge.WithLabelValues(taskName, sourceID, workerName)
 	ret.Metrics.RemainingTimeGauge = m.remainingTimeGauge.WithLabelValues(taskName, sourceID, workerName)
 	ret.Metrics.ShardLockResolving = m.shardLockResolving.WithLabelValues(taskName, sourceID)
 	ret.Metrics.FinishedTransactionTotal = m.finishedTransactionTotal.WithLabelValues(taskName, workerName, sourceID)
@@ -341,6 +351,7 @@ func (m *Proxies) RegisterMetrics(registry *prometheus.Registry) {
 	registry.MustRegister(m.syncerExitWithErrorCounter)
 	registry.MustRegister(m.replicationLagGauge)
 	registry.MustRegister(m.replicationLagHistogram)
+	registry.MustRegister(m.heartbeatLagGauge)
 	registry.MustRegister(m.remainingTimeGauge)
 	registry.MustRegister(m.UnsyncedTableGauge)
 	registry.MustRegister(m.shardLockResolving)
@@ -371,6 +382,7 @@ func (m *Proxies) RemoveLabelValuesWithTaskInMetrics(task string) {
 	m.syncerExitWithErrorCounter.DeletePartialMatch(prometheus.Labels{"task": task})
 	m.replicationLagGauge.DeletePartialMatch(prometheus.Labels{"task": task})
 	m.replicationLagHistogram.DeletePartialMatch(prometheus.Labels{"task": task})
+	m.heartbeatLagGauge.DeletePartialMatch(prometheus.Labels{"task": task})
 	m.remainingTimeGauge.DeletePartialMatch(prometheus.Labels{"task": task})
 	m.UnsyncedTableGauge.DeletePartialMatch(prometheus.Labels{"task": task})
 	m.shardLockResolving.DeletePartialMatch(prometheus.Labels{"task": task})
@@ -0,0 +1,83 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+import (
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/util/dbutil"
+	"github.com/pingcap/tiflow/dm/pkg/conn"
+	tcontext "github.com/pingcap/tiflow/dm/pkg/context"
+	"github.com/pingcap/tiflow/dm/pkg/log"
+	"github.com/pingcap/tiflow/dm/pkg/quarantine"
+	"go.uber.org/zap"
+)
+
+// prepareConflictQuarantine creates the conflict quarantine table in the downstream.
+func (s *Syncer) prepareConflictQuarantine(tctx *tcontext.Context) error {
+	sqls := quarantine.CreateTableSQLs(s.cfg.MetaSchema, s.cfg.Name)
+	tctx.L().Info("create conflict quarantine table", zap.Strings("statements", sqls))
+	for _, sql := range sqls {
+		if _, err := s.toDB.ExecContext(tctx, sql); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// quarantineConflicts executes the jobs of a batch which meets a duplicate-key
+// conflict one by one, and moves the row changes which still conflict with the
+// downstream into the quarantine table. It returns as db.ExecuteSQL does, the
+// index of the failed job when an error occurs.
+func (w *DMLWorker) quarantineConflicts(tctx *tcontext.Context, queueID int, jobs []*job) (int, error) {
+	var (
+		db     = w.toDBConns[queueID]
+		affect int
+	)
+	for i, j := range jobs {
+		queries, args := w.genSQLs([]*job{j})
+		n, err := db.ExecuteSQL(tctx, w.metricProxies, queries, args...)
+		if err == nil {
+			affect += n
+			continue
+		}
+		if !conn.IsErrDuplicateEntry(err) {
+			return i, err
+		}
+
+		sourceTable, targetTable := j.dml.GetSourceTable(), j.dml.GetTargetTable()
+		query, arg, err2 := quarantine.InsertSQL(w.metaSchema, w.task, &quarantine.Row{
+			SourceID:     w.source,
+			SourceSchema: sourceTable.Schema,
+			SourceTable:  sourceTable.Table,
+			TargetSchema: targetTable.Schema,
+			TargetTable:  targetTable.Table,
+			Location:     j.currentLocation.String(),
+			Statements:   quarantine.NewStatements(queries, args),
+			ErrMsg:       errors.Cause(err).Error(),
+		})
+		if err2 != nil {
+			return i, err2
+		}
+		if n, err2 = db.ExecuteSQL(tctx, w.metricProxies, []string{query}, arg); err2 != nil {
+			return i, err2
+		}
+		affect += n
+		w.logger.Warn("quarantine the conflicting row change",
+			zap.String("source table", dbutil.TableName(sourceTable.Schema, sourceTable.Table)),
+			zap.String("target table", dbutil.TableName(targetTable.Schema, targetTable.Table)),
+			zap.Stringer("location", j.currentLocation),
+			log.ShortError(err))
+	}
+	return affect, nil
+}
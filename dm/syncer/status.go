@@ -38,6 +38,7 @@ func (s *Syncer) Status(sourceStatus *binlog.SourceStatus) interface{} {
 		RecentRps:           s.rps.Load(),
 		SyncerBinlog:        syncerLocation.Position.String(),
 		SecondsBehindMaster: s.secondsBehindMaster.Load(),
+		HeartbeatLag:        s.heartbeatLag.Load(),
 	}
 
 	if syncerLocation.GetGTID() != nil {
@@ -38,8 +38,6 @@ import (
 	"github.com/pingcap/tidb/sessionctx"
 	"github.com/pingcap/tidb/util/dbutil"
 	"github.com/pingcap/tidb/util/filter"
-	regexprrouter "github.com/pingcap/tidb/util/regexpr-router"
-	router "github.com/pingcap/tidb/util/table-router"
 	"github.com/pingcap/tiflow/dm/config"
 	"github.com/pingcap/tiflow/dm/config/dbconfig"
 	"github.com/pingcap/tiflow/dm/pb"
@@ -52,6 +50,7 @@ import (
 	"github.com/pingcap/tiflow/dm/pkg/ha"
 	"github.com/pingcap/tiflow/dm/pkg/log"
 	parserpkg "github.com/pingcap/tiflow/dm/pkg/parser"
+	dmrouter "github.com/pingcap/tiflow/dm/pkg/router"
 	"github.com/pingcap/tiflow/dm/pkg/schema"
 	"github.com/pingcap/tiflow/dm/pkg/shardddl/optimism"
 	"github.com/pingcap/tiflow/dm/pkg/shardddl/pessimism"
@@ -158,7 +157,7 @@ type Syncer struct {
 	isTransactionEnd    bool
 	waitTransactionLock sync.Mutex
 
-	tableRouter     *regexprrouter.RouteTable
+	tableRouter     *dmrouter.RouteTable
 	binlogFilter    *bf.BinlogEvent
 	baList          *filter.Filter
 	exprFilterGroup *ExprFilterGroup
@@ -224,6 +223,7 @@ type Syncer struct {
 
 	tsOffset                  atomic.Int64    // time offset between upstream and syncer, DM's timestamp - MySQL's timestamp
 	secondsBehindMaster       atomic.Int64    // current task delay second behind upstream
+	heartbeatLag              atomic.Int64    // replication lag in seconds measured by the lag heartbeat
 	workerJobTSArray          []*atomic.Int64 // worker's sync job TS array, note that idx=0 is skip idx and idx=1 is ddl idx,sql worker job idx=(queue id + 2)
 	lastCheckpointFlushedTime time.Time
 
@@ -457,6 +457,12 @@ func (s *Syncer) Init(ctx context.Context) (err error) {
 
 	rollbackHolder.Add(fr.FuncRollback{Name: "close-checkpoint", Fn: s.checkpoint.Close})
 
+	if s.cfg.ConflictQuarantine {
+		if err = s.prepareConflictQuarantine(tctx); err != nil {
+			return err
+		}
+	}
+
 	err = s.checkpoint.Load(tctx)
 	if err != nil {
 		return err
@@ -1736,6 +1742,10 @@ func (s *Syncer) Run(ctx context.Context) (err error) {
 	go s.updateLagCronJob(s.runCtx.Ctx)
 	s.runWg.Add(1)
 	go s.updateTSOffsetCronJob(s.runCtx.Ctx)
+	if s.cfg.EnableLagHeartbeat {
+		s.runWg.Add(1)
+		go s.lagHeartbeatCronJob(s.runCtx.Ctx)
+	}
 
 	// some prepare work before the binlog event loop:
 	// 1. first we flush checkpoint as needed, so in next resume we won't go to Load unit.
@@ -1808,6 +1818,9 @@ func (s *Syncer) Run(ctx context.Context) (err error) {
 		lastTxnEndLocation = s.checkpoint.GlobalPoint()
 
 		currentGTID string
+		// mariaDBEventDetected is true if a MariaDB specific event is received
+		// while the flavor is not MariaDB, it is only warned once.
+		mariaDBEventDetected bool
 	)
 	s.tctx.L().Info("replicate binlog from checkpoint", zap.Stringer("checkpoint", lastTxnEndLocation))
 
@@ -2175,6 +2188,13 @@ func (s *Syncer) Run(ctx context.Context) (err error) {
 			// try to handle pessimistic sharding?
 			queryEvent, ok := e.Event.(*replication.QueryEvent)
 			if !ok {
+				// rows events are skipped by the handle-error operator limited
+				// to some tables or event types, the checkpoint is saved when
+				// the transaction ends.
+				if _, ok = e.Event.(*replication.RowsEvent); ok {
+					s.tctx.L().Info("skip rows event by handle-error", zap.Reflect("header", e.Header))
+					continue
+				}
 				s.tctx.L().Warn("can't skip an event which is not DDL", zap.Reflect("header", e.Header))
 				break
 			}
@@ -2291,6 +2311,14 @@ func (s *Syncer) Run(ctx context.Context) (err error) {
 			return false, err
 		}
 
+		if !mariaDBEventDetected && s.cfg.Flavor != mysql.MariaDBFlavor && event.IsMariaDBEvent(e.Header.EventType) {
+			mariaDBEventDetected = true
+			s.tctx.L().Warn("receive MariaDB specific binlog event, but the flavor of the source is not MariaDB, "+
+				"GTIDs can not be handled correctly, please set `flavor: mariadb` in the source config",
+				zap.String("flavor", s.cfg.Flavor),
+				zap.Stringer("event type", e.Header.EventType))
+		}
+
 		switch ev := e.Event.(type) {
 		case *replication.RotateEvent:
 			err2 = s.handleRotateEvent(ev, ec)
@@ -2654,7 +2682,7 @@ func (qec *queryEventContext) String() string {
 }
 
 // generateExtendColumn generate extended columns by extractor.
-func generateExtendColumn(data [][]interface{}, r *regexprrouter.RouteTable, table *filter.Table, sourceID string) [][]interface{} {
+func generateExtendColumn(data [][]interface{}, r *dmrouter.RouteTable, table *filter.Table, sourceID string) [][]interface{} {
 	extendCol, extendVal := r.FetchExtendColumn(table.Schema, table.Name, sourceID)
 	if len(extendCol) == 0 {
 		return nil
@@ -2858,12 +2886,10 @@ func (s *Syncer) trackOriginDDL(ev *replication.QueryEvent, ec eventContext) (ma
 }
 
 func (s *Syncer) genRouter() error {
-	s.tableRouter, _ = regexprrouter.NewRegExprRouter(s.cfg.CaseSensitive, []*router.TableRule{})
-	for _, rule := range s.cfg.RouteRules {
-		err := s.tableRouter.AddRule(rule)
-		if err != nil {
-			return terror.ErrSyncerUnitGenTableRouter.Delegate(err)
-		}
+	var err error
+	s.tableRouter, err = dmrouter.NewRouteTable(s.cfg.CaseSensitive, s.cfg.RouteRules)
+	if err != nil {
+		return terror.ErrSyncerUnitGenTableRouter.Delegate(err)
 	}
 	return nil
 }
@@ -3066,7 +3092,7 @@ func (s *Syncer) route(table *filter.Table) *filter.Table {
 	return route(s.tableRouter, table)
 }
 
-func route(tableRouter *regexprrouter.RouteTable, table *filter.Table) *filter.Table {
+func route(tableRouter *dmrouter.RouteTable, table *filter.Table) *filter.Table {
 	if table.Schema == "" {
 		return table
 	}
@@ -3246,7 +3272,7 @@ func (s *Syncer) Update(ctx context.Context, cfg *config.SubTaskConfig) error {
 	var (
 		err             error
 		oldBaList       *filter.Filter
-		oldTableRouter  *regexprrouter.RouteTable
+		oldTableRouter  *dmrouter.RouteTable
 		oldBinlogFilter *bf.BinlogEvent
 	)
 
@@ -3274,7 +3300,7 @@ func (s *Syncer) Update(ctx context.Context, cfg *config.SubTaskConfig) error {
 
 	// update route
 	oldTableRouter = s.tableRouter
-	s.tableRouter, err = regexprrouter.NewRegExprRouter(cfg.CaseSensitive, cfg.RouteRules)
+	s.tableRouter, err = dmrouter.NewRouteTable(cfg.CaseSensitive, cfg.RouteRules)
 	if err != nil {
 		return terror.ErrSyncerUnitGenTableRouter.Delegate(err)
 	}
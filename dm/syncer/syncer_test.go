@@ -52,6 +52,7 @@ import (
 	"github.com/pingcap/tiflow/dm/pkg/log"
 	parserpkg "github.com/pingcap/tiflow/dm/pkg/parser"
 	"github.com/pingcap/tiflow/dm/pkg/retry"
+	dmrouter "github.com/pingcap/tiflow/dm/pkg/router"
 	"github.com/pingcap/tiflow/dm/pkg/schema"
 	"github.com/pingcap/tiflow/dm/pkg/terror"
 	"github.com/pingcap/tiflow/dm/pkg/utils"
@@ -1022,7 +1023,7 @@ func (s *testSyncerSuite) TestRun(c *C) {
 	sourceSchemaFromCheckPoint, err := syncer.OperateSchema(ctx, &pb.OperateWorkerSchemaRequest{Op: pb.SchemaOp_GetSchema, Database: "test_1", Table: "t_1"})
 	c.Assert(err, IsNil)
 
-	syncer.tableRouter = &regexprrouter.RouteTable{}
+	syncer.tableRouter = &dmrouter.RouteTable{RouteTable: &regexprrouter.RouteTable{}}
 	c.Assert(syncer.tableRouter.AddRule(&router.TableRule{
 		SchemaPattern: "test_1",
 		TablePattern:  "t_1",
@@ -39,6 +39,8 @@ function fail_acquire_global_lock() {
 
 	cp $cur/conf/dm-task.yaml $WORK_DIR/dm-task.yaml
 	sed -i '/heartbeat-report-interval/i\ignore-checking-items: ["dump_privilege"]' $WORK_DIR/dm-task.yaml
+	# "auto" turns to "gtid-snapshot" without RELOAD privilege, so specify "flush" to acquire the global lock
+	sed -i 's/extra-args: ""/extra-args: "--consistency flush"/g' $WORK_DIR/dm-task.yaml
 	run_dm_ctl $WORK_DIR "127.0.0.1:$MASTER_PORT" \
 		"start-task $WORK_DIR/dm-task.yaml --remove-meta"
 
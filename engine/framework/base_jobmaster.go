@@ -28,6 +28,7 @@ import (
 	metaModel "github.com/pingcap/tiflow/engine/pkg/meta/model"
 	"github.com/pingcap/tiflow/engine/pkg/p2p"
 	"github.com/pingcap/tiflow/engine/pkg/promutil"
+	"github.com/pingcap/tiflow/engine/pkg/statestore"
 	"github.com/pingcap/tiflow/pkg/errors"
 	"github.com/pingcap/tiflow/pkg/logutil"
 	"go.uber.org/zap"
@@ -86,6 +87,13 @@ type BaseJobMaster interface {
 
 	// IsS3StorageEnabled returns whether the s3 storage is enabled
 	IsS3StorageEnabled() bool
+
+	// OpenStateStore opens the store of the versioned state snapshots of the
+	// job. The snapshots are kept in the s3 storage, so they survive the
+	// failover of the job master, and they are removed with the job. The
+	// store is fenced by the epoch of the job master, the job master before
+	// a failover fails to save the snapshots once the new one opens it.
+	OpenStateStore(ctx context.Context) (statestore.Store, error)
 }
 
 // BaseJobMasterExt extends BaseJobMaster with some extra methods.
@@ -341,6 +349,36 @@ func (d *DefaultBaseJobMaster) IsS3StorageEnabled() bool {
 	return d.worker.IsS3StorageEnabled()
 }
 
+// stateStoreResourceID is the s3 resource of the state store of a job.
+const stateStoreResourceID = "/s3/job-state"
+
+// OpenStateStore implements BaseJobMaster.OpenStateStore
+func (d *DefaultBaseJobMaster) OpenStateStore(ctx context.Context) (statestore.Store, error) {
+	if !d.worker.IsS3StorageEnabled() {
+		return nil, errors.ErrStateStoreNotEnabled.GenWithStackByArgs()
+	}
+
+	ctx, cancel := d.errCenter.WithCancelOnFirstError(ctx)
+	defer cancel()
+
+	// the worker ID of a job master is the job ID, the resource belongs to
+	// the job rather than the job manager, so it's removed with the job.
+	jobID := d.worker.id
+	h, err := d.worker.resourceBroker.OpenStorage(
+		ctx, d.worker.projectInfo, d.worker.id, jobID, stateStoreResourceID)
+	if err != nil {
+		return nil, err
+	}
+	// persist the resource, otherwise it's removed when the executor that
+	// creates it is offline.
+	if err := h.Persist(ctx); err != nil {
+		return nil, err
+	}
+	// the snapshots are fenced by the epoch of the job master, so that the
+	// job master before a failover can't overwrite them.
+	return statestore.NewExternalStore(h.BrExternalStorage(), d.CurrentEpoch()), nil
+}
+
 // SendMessage delegates the SendMessage or inner worker
 func (d *DefaultBaseJobMaster) SendMessage(ctx context.Context, topic p2p.Topic, message interface{}, nonblocking bool) error {
 	ctx, cancel := d.errCenter.WithCancelOnFirstError(ctx)
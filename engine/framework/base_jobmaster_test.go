@@ -27,6 +27,7 @@ import (
 	"github.com/pingcap/tiflow/engine/pkg/client"
 	dcontext "github.com/pingcap/tiflow/engine/pkg/context"
 	"github.com/pingcap/tiflow/engine/pkg/deps"
+	"github.com/pingcap/tiflow/engine/pkg/externalresource/broker"
 	metaMock "github.com/pingcap/tiflow/engine/pkg/meta/mock"
 	pkgOrm "github.com/pingcap/tiflow/engine/pkg/orm"
 	ormModel "github.com/pingcap/tiflow/engine/pkg/orm/model"
@@ -266,6 +267,28 @@ func TestBaseJobMasterBasics(t *testing.T) {
 	jobMaster.mu.Unlock()
 }
 
+func TestOpenStateStore(t *testing.T) {
+	t.Parallel()
+
+	jobMaster := &testJobMasterImpl{}
+	base := newBaseJobMasterForTests(t, jobMaster)
+	resourceBroker := broker.NewBrokerForTesting("executor-1")
+	defer resourceBroker.Close()
+	base.worker.resourceBroker = resourceBroker
+
+	ctx := context.Background()
+	store, err := base.OpenStateStore(ctx)
+	require.NoError(t, err)
+	resourceBroker.AssertPersisted(t, stateStoreResourceID)
+
+	version, err := store.Save(ctx, []byte("state"))
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), version)
+	snapshot, err := store.Load(ctx, 0)
+	require.NoError(t, err)
+	require.Equal(t, []byte("state"), snapshot.Data)
+}
+
 func TestOnOpenAPIInitialized(t *testing.T) {
 	t.Parallel()
 
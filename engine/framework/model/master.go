@@ -57,6 +57,9 @@ func (code MasterState) IsTerminatedState() bool {
 // to be indexed.
 type MasterMetaExt struct {
 	Selectors []*label.Selector `json:"selectors"`
+	// TenantID is the tenant who submits the job, it's used to check the
+	// quota of the tenant.
+	TenantID tenant.Tenant `json:"tenant-id,omitempty"`
 }
 
 // Value implements driver.Valuer.
@@ -37,6 +37,7 @@ import (
 	dcontext "github.com/pingcap/tiflow/engine/pkg/context"
 	dmpkg "github.com/pingcap/tiflow/engine/pkg/dm"
 	"github.com/pingcap/tiflow/engine/pkg/p2p"
+	"github.com/pingcap/tiflow/engine/pkg/statestore"
 	"github.com/pingcap/tiflow/pkg/errors"
 	"go.uber.org/zap"
 )
@@ -109,10 +110,18 @@ func (j dmJobMasterFactory) IsRetryableError(err error) bool {
 // it need to be called firstly in InitImpl and OnMasterRecovered
 // we should create all components if there is any error
 // CloseImpl/StopImpl will be called later to close components
-func (jm *JobMaster) initComponents() error {
+func (jm *JobMaster) initComponents(ctx context.Context) error {
 	jm.Logger().Info("initializing the dm jobmaster components")
 	taskStatus, workerStatus, err := jm.getInitStatus()
 	jm.metadata = metadata.NewMetaData(jm.MetaKVClient(), jm.Logger())
+	// the unit state is kept in the state store if s3 storage is enabled,
+	// it's fenced by the epoch of the job master.
+	if err == nil && jm.IsS3StorageEnabled() {
+		var store statestore.Store
+		if store, err = jm.OpenStateStore(ctx); err == nil {
+			jm.metadata.UseStateStore(store)
+		}
+	}
 	jm.messageAgent = dmpkg.NewMessageAgent(jm.ID(), jm, jm.messageHandlerManager, jm.Logger())
 	jm.checkpointAgent = checkpoint.NewCheckpointAgent(jm.ID(), jm.Logger())
 	jm.taskManager = NewTaskManager(jm.ID(), taskStatus, jm.metadata.JobStore(), jm.messageAgent, jm.Logger(), jm.MetricFactory())
@@ -125,7 +134,7 @@ func (jm *JobMaster) initComponents() error {
 // InitImpl implements JobMasterImpl.InitImpl
 func (jm *JobMaster) InitImpl(ctx context.Context) error {
 	jm.Logger().Info("initializing the dm jobmaster")
-	if err := jm.initComponents(); err != nil {
+	if err := jm.initComponents(ctx); err != nil {
 		return errors.Trace(err)
 	}
 	if err := jm.preCheck(ctx, jm.initJobCfg); err != nil {
@@ -151,7 +160,7 @@ func (jm *JobMaster) InitImpl(ctx context.Context) error {
 // When it is called, the jobCfg may not be in the metadata, and we should not report an error
 func (jm *JobMaster) OnMasterRecovered(ctx context.Context) error {
 	jm.Logger().Info("recovering the dm jobmaster")
-	if err := jm.initComponents(); err != nil {
+	if err := jm.initComponents(ctx); err != nil {
 		return errors.Trace(err)
 	}
 	if err := jm.bootstrap(ctx); err != nil {
@@ -431,7 +431,7 @@ func TestDuplicateFinishedState(t *testing.T) {
 
 	mockBaseJobmaster.On("MetaKVClient").Return(metaKVClient)
 	mockBaseJobmaster.On("GetWorkers").Return(map[string]framework.WorkerHandle{}).Once()
-	err := jm.initComponents()
+	err := jm.initComponents(ctx)
 	require.NoError(t, err)
 	loadTime, _ := time.Parse(time.RFC3339Nano, "2022-11-04T19:47:57.43382274+08:00")
 	dumpDuration := time.Hour
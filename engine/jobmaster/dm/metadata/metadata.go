@@ -18,6 +18,7 @@ import (
 
 	"github.com/coreos/go-semver/semver"
 	metaModel "github.com/pingcap/tiflow/engine/pkg/meta/model"
+	"github.com/pingcap/tiflow/engine/pkg/statestore"
 	"go.uber.org/zap"
 )
 
@@ -52,6 +53,13 @@ func (m *MetaData) UnitStateStore() *UnitStateStore {
 	return m.unitStateStore
 }
 
+// UseStateStore persists the UnitState in the state store of the job rather
+// than in metadata. The UnitState in metadata is read until a snapshot is
+// saved, and is deleted then.
+func (m *MetaData) UseStateStore(store statestore.Store) {
+	m.unitStateStore = newSnapshotUnitStateStore(store, m.unitStateStore.Store)
+}
+
 // Upgrade upgrades metadata.
 func (m *MetaData) Upgrade(ctx context.Context, fromVer semver.Version) error {
 	// call infoStore.Upgrade/ddlStore.Upgrade if needed.
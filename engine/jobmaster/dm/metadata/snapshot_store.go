@@ -0,0 +1,160 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metadata
+
+import (
+	"bytes"
+	"context"
+	"sync"
+
+	"github.com/pingcap/tiflow/engine/pkg/statestore"
+	"github.com/pingcap/tiflow/pkg/errors"
+)
+
+const (
+	// snapshotsToKeep is the number of the snapshots kept in the state store.
+	snapshotsToKeep = 8
+	// snapshotPurgeInterval is the number of the snapshots saved between
+	// two purges.
+	snapshotPurgeInterval = 16
+)
+
+// deletedSnapshot is the snapshot saved when the state is deleted.
+var deletedSnapshot = []byte("null")
+
+// snapshotMetaStore implements Store interface. It persists a state instance
+// as versioned snapshots in the state store of the job, and will cache the
+// latest state. The state in framework metadata, which is written before the
+// job uses the state store, is read if there is no snapshot, and is deleted
+// once a snapshot is saved. It's thread-safe.
+type snapshotMetaStore struct {
+	stateFactory
+
+	mu         sync.Mutex
+	stateCache state
+	store      statestore.Store
+	legacy     Store
+	encodeFn   func(state) ([]byte, error)
+	decodeFn   func([]byte, state) error
+}
+
+func newJSONSnapshotMetaStore(store statestore.Store, legacy Store) *snapshotMetaStore {
+	return &snapshotMetaStore{
+		store:    store,
+		legacy:   legacy,
+		encodeFn: jsonEncodeFn,
+		decodeFn: jsonDecodeFn,
+	}
+}
+
+func (s *snapshotMetaStore) Put(ctx context.Context, state state) error {
+	if !checkAllFieldsIsPublic(state) {
+		return errors.New("fields of state should all be public")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	value, err := s.encodeFn(state)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := s.save(ctx, value); err != nil {
+		return err
+	}
+	s.stateCache = state
+	return nil
+}
+
+func (s *snapshotMetaStore) Delete(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.save(ctx, deletedSnapshot); err != nil {
+		return err
+	}
+	s.stateCache = nil
+	return nil
+}
+
+func (s *snapshotMetaStore) save(ctx context.Context, value []byte) error {
+	version, err := s.store.Save(ctx, value)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if version%snapshotPurgeInterval == 0 {
+		if err := s.store.Purge(ctx, snapshotsToKeep); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if s.legacy == nil {
+		return nil
+	}
+	// the legacy store only deletes the state it has read.
+	if _, err := s.legacy.Get(ctx); err != nil && errors.Cause(err) != ErrStateNotFound {
+		return errors.Trace(err)
+	}
+	if err := s.legacy.Delete(ctx); err != nil {
+		return errors.Trace(err)
+	}
+	s.legacy = nil
+	return nil
+}
+
+func (s *snapshotMetaStore) Get(ctx context.Context) (state, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.stateCache != nil {
+		return s.cloneState()
+	}
+
+	snapshot, err := s.store.Load(ctx, 0)
+	if err != nil {
+		if !errors.Is(err, errors.ErrStateSnapshotNotFound) {
+			return nil, errors.Trace(err)
+		}
+		if s.legacy == nil {
+			return nil, ErrStateNotFound
+		}
+		legacyState, err := s.legacy.Get(ctx)
+		if err != nil {
+			return nil, err
+		}
+		s.stateCache = legacyState
+		return s.cloneState()
+	}
+	if bytes.Equal(snapshot.Data, deletedSnapshot) {
+		return nil, ErrStateNotFound
+	}
+
+	s.stateCache = s.createState()
+	if err := s.decodeFn(snapshot.Data, s.stateCache); err != nil {
+		s.stateCache = nil
+		return nil, errors.Trace(err)
+	}
+	return s.cloneState()
+}
+
+func (s *snapshotMetaStore) cloneState() (state, error) {
+	value, err := s.encodeFn(s.stateCache)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	clone := s.createState()
+	if err := s.decodeFn(value, clone); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return clone, nil
+}
@@ -23,6 +23,7 @@ import (
 	frameModel "github.com/pingcap/tiflow/engine/framework/model"
 	"github.com/pingcap/tiflow/engine/pkg/adapter"
 	metaModel "github.com/pingcap/tiflow/engine/pkg/meta/model"
+	"github.com/pingcap/tiflow/engine/pkg/statestore"
 	"github.com/pingcap/tiflow/pkg/errors"
 )
 
@@ -37,7 +38,7 @@ type UnitState struct {
 type UnitStateStore struct {
 	// rmwLock is used to prevent concurrent read-modify-write to the state.
 	rmwLock sync.Mutex
-	*frameworkMetaStore
+	Store
 }
 
 func (f *UnitStateStore) createState() state {
@@ -76,10 +77,21 @@ func (f *UnitStateStore) ReadModifyWrite(
 
 // NewUnitStateStore creates a new UnitStateStore.
 func NewUnitStateStore(kvClient metaModel.KVClient) *UnitStateStore {
-	ret := &UnitStateStore{
-		frameworkMetaStore: newJSONFrameworkMetaStore(kvClient),
-	}
-	ret.frameworkMetaStore.stateFactory = ret
+	ret := &UnitStateStore{}
+	store := newJSONFrameworkMetaStore(kvClient)
+	store.stateFactory = ret
+	ret.Store = store
+	return ret
+}
+
+// newSnapshotUnitStateStore creates a new UnitStateStore which persists the
+// UnitState in the state store of the job, the UnitState in legacy is read
+// if there is no snapshot.
+func newSnapshotUnitStateStore(store statestore.Store, legacy Store) *UnitStateStore {
+	ret := &UnitStateStore{}
+	snapshotStore := newJSONSnapshotMetaStore(store, legacy)
+	snapshotStore.stateFactory = ret
+	ret.Store = snapshotStore
 	return ret
 }
 
@@ -18,7 +18,10 @@ import (
 	"encoding/json"
 	"testing"
 
+	brStorage "github.com/pingcap/tidb/br/pkg/storage"
 	"github.com/pingcap/tiflow/engine/pkg/meta/mock"
+	"github.com/pingcap/tiflow/engine/pkg/statestore"
+	"github.com/pingcap/tiflow/pkg/errors"
 	"github.com/stretchr/testify/require"
 )
 
@@ -55,3 +58,47 @@ func TestUnitStateStore(t *testing.T) {
 	require.EqualError(t, err, "state not found")
 	require.Nil(t, state)
 }
+
+func TestSnapshotUnitStateStore(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	legacy := NewUnitStateStore(mock.NewMetaMock())
+	legacyState := &UnitState{
+		FinishedUnitStatus: map[string][]*FinishedTaskStatus{},
+		CurrentUnitStatus:  map[string]*UnitStatus{"task": {Task: "task"}},
+	}
+	require.NoError(t, legacy.Put(ctx, legacyState))
+
+	storage, err := brStorage.NewLocalStorage(t.TempDir())
+	require.NoError(t, err)
+	s := newSnapshotUnitStateStore(statestore.NewExternalStore(storage, 1), legacy.Store)
+
+	// the state in metadata is read until a snapshot is saved.
+	state, err := s.Get(ctx)
+	require.NoError(t, err)
+	require.Equal(t, legacyState, state)
+	require.NoError(t, s.ReadModifyWrite(ctx, func(state *UnitState) error {
+		state.CurrentUnitStatus["task2"] = &UnitStatus{Task: "task2"}
+		return nil
+	}))
+	_, err = legacy.Get(ctx)
+	require.EqualError(t, err, "state not found")
+
+	// the job master after failover reads the latest snapshot, and the
+	// one before failover is fenced.
+	s2 := newSnapshotUnitStateStore(statestore.NewExternalStore(storage, 2), legacy.Store)
+	state, err = s2.Get(ctx)
+	require.NoError(t, err)
+	require.Len(t, state.(*UnitState).CurrentUnitStatus, 2)
+	err = s.Put(ctx, legacyState)
+	require.True(t, errors.Is(err, errors.ErrStateStoreFenced))
+
+	require.NoError(t, s2.Delete(ctx))
+	state, err = s2.Get(ctx)
+	require.EqualError(t, err, "state not found")
+	require.Nil(t, state)
+	state, err = newSnapshotUnitStateStore(statestore.NewExternalStore(storage, 3), nil).Get(ctx)
+	require.EqualError(t, err, "state not found")
+	require.Nil(t, state)
+}
@@ -0,0 +1,307 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statestore
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	brStorage "github.com/pingcap/tidb/br/pkg/storage"
+	"github.com/pingcap/tiflow/pkg/errors"
+)
+
+const (
+	snapshotFilePrefix = "snapshot-"
+	fenceFilePrefix    = "fence-"
+)
+
+var _ Store = (*ExternalStore)(nil)
+
+// ExternalStore implements Store, it keeps each snapshot as a file in an
+// external storage, like S3. A snapshot file is written at once, so a
+// snapshot is either saved completely or not saved at all.
+//
+// The writers of a store are fenced by their epochs, like the epochs of the
+// job masters. A store claims its epoch by a fence file before it reads or
+// writes the snapshots, and a writer with an older epoch than the latest
+// fence fails to save with ErrStateStoreFenced. The file of a snapshot is
+// named by its version and the epoch of its writer, so writers never
+// overwrite the snapshots of each other. A snapshot saved by a stale writer
+// concurrently with the claim is checked against the fence after it's
+// written and removed, and if both writers save the same version the one
+// of the newer epoch is used.
+type ExternalStore struct {
+	mu      sync.Mutex
+	storage brStorage.ExternalStorage
+	epoch   int64
+	claimed bool
+}
+
+// NewExternalStore creates a new ExternalStore written with the epoch.
+func NewExternalStore(storage brStorage.ExternalStorage, epoch int64) *ExternalStore {
+	return &ExternalStore{storage: storage, epoch: epoch}
+}
+
+// Save implements Store.Save.
+func (s *ExternalStore) Save(ctx context.Context, data []byte) (uint64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.claim(ctx); err != nil {
+		return 0, err
+	}
+	files, err := s.list(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if err := s.checkFence(files.fence); err != nil {
+		return 0, err
+	}
+	var version uint64 = 1
+	if len(files.snapshots) > 0 {
+		version = files.snapshots[len(files.snapshots)-1].version + 1
+	}
+
+	name := snapshotFileName(version, s.epoch)
+	if err := s.storage.WriteFile(ctx, name, data); err != nil {
+		return 0, errors.Trace(err)
+	}
+	// the store may be fenced while the snapshot is written, the snapshot
+	// is removed so that it is never loaded.
+	files, err = s.list(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if err := s.checkFence(files.fence); err != nil {
+		if err := s.storage.DeleteFile(ctx, name); err != nil {
+			return 0, errors.Trace(err)
+		}
+		return 0, err
+	}
+	return version, nil
+}
+
+// Load implements Store.Load.
+func (s *ExternalStore) Load(ctx context.Context, version uint64) (*Snapshot, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.claim(ctx); err != nil {
+		return nil, err
+	}
+	files, err := s.list(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var found *storeFile
+	if version == 0 && len(files.snapshots) > 0 {
+		found = &files.snapshots[len(files.snapshots)-1]
+	}
+	for i := range files.snapshots {
+		if files.snapshots[i].version == version {
+			found = &files.snapshots[i]
+		}
+	}
+	if found == nil {
+		return nil, errors.ErrStateSnapshotNotFound.GenWithStackByArgs(version)
+	}
+	data, err := s.storage.ReadFile(ctx, found.name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &Snapshot{Version: found.version, Data: data}, nil
+}
+
+// Versions implements Store.Versions.
+func (s *ExternalStore) Versions(ctx context.Context) ([]uint64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	files, err := s.list(ctx)
+	if err != nil {
+		return nil, err
+	}
+	versions := make([]uint64, 0, len(files.snapshots))
+	for _, file := range files.snapshots {
+		versions = append(versions, file.version)
+	}
+	return versions, nil
+}
+
+// Purge implements Store.Purge. The fence files of the older epochs are
+// removed as well.
+func (s *ExternalStore) Purge(ctx context.Context, keep int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if keep < 1 {
+		keep = 1
+	}
+	files, err := s.list(ctx)
+	if err != nil {
+		return err
+	}
+	if err := s.checkFence(files.fence); err != nil {
+		return err
+	}
+	var stale []string
+	if len(files.snapshots) > keep {
+		for _, file := range files.snapshots[:len(files.snapshots)-keep] {
+			stale = append(stale, file.name)
+		}
+	}
+	stale = append(stale, files.stale...)
+	for _, name := range stale {
+		if err := s.storage.DeleteFile(ctx, name); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// claim writes the fence file of the epoch of the store once, so that the
+// writers with older epochs are fenced.
+func (s *ExternalStore) claim(ctx context.Context) error {
+	if s.claimed {
+		return nil
+	}
+	files, err := s.list(ctx)
+	if err != nil {
+		return err
+	}
+	if err := s.checkFence(files.fence); err != nil {
+		return err
+	}
+	if files.fence < s.epoch {
+		if err := s.storage.WriteFile(ctx, fenceFileName(s.epoch), nil); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	s.claimed = true
+	return nil
+}
+
+func (s *ExternalStore) checkFence(fence int64) error {
+	if fence > s.epoch {
+		return errors.ErrStateStoreFenced.GenWithStackByArgs(s.epoch, fence)
+	}
+	return nil
+}
+
+// storeFile is a snapshot file or a fence file, the version of a fence
+// file is 0.
+type storeFile struct {
+	name    string
+	version uint64
+	epoch   int64
+}
+
+type storeFiles struct {
+	// snapshots are in ascending order of the versions, only the one of
+	// the newest epoch is kept for each version.
+	snapshots []storeFile
+	// fence is the newest epoch in the fence files.
+	fence int64
+	// stale are the files that are superseded by the files of the newer
+	// epochs.
+	stale []string
+}
+
+func (s *ExternalStore) list(ctx context.Context) (*storeFiles, error) {
+	files := &storeFiles{}
+	var snapshots, fences []storeFile
+	err := s.storage.WalkDir(ctx, &brStorage.WalkOption{}, func(filePath string, _ int64) error {
+		name := path.Base(filePath)
+		if version, epoch, ok := parseSnapshotFileName(name); ok {
+			snapshots = append(snapshots, storeFile{name: name, version: version, epoch: epoch})
+		} else if epoch, ok := parseFenceFileName(name); ok {
+			fences = append(fences, storeFile{name: name, epoch: epoch})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	sort.Slice(snapshots, func(i, j int) bool {
+		if snapshots[i].version != snapshots[j].version {
+			return snapshots[i].version < snapshots[j].version
+		}
+		return snapshots[i].epoch > snapshots[j].epoch
+	})
+	for _, file := range snapshots {
+		n := len(files.snapshots)
+		if n > 0 && files.snapshots[n-1].version == file.version {
+			files.stale = append(files.stale, file.name)
+			continue
+		}
+		files.snapshots = append(files.snapshots, file)
+	}
+	for _, file := range fences {
+		if file.epoch > files.fence {
+			files.fence = file.epoch
+		}
+	}
+	for _, file := range fences {
+		if file.epoch < files.fence {
+			files.stale = append(files.stale, file.name)
+		}
+	}
+	return files, nil
+}
+
+// snapshotFileName returns the file name of a snapshot, the version is
+// padded so that the files are listed in the order of the versions.
+func snapshotFileName(version uint64, epoch int64) string {
+	return fmt.Sprintf("%s%020d-%020d", snapshotFilePrefix, version, epoch)
+}
+
+func parseSnapshotFileName(name string) (uint64, int64, bool) {
+	if !strings.HasPrefix(name, snapshotFilePrefix) {
+		return 0, 0, false
+	}
+	parts := strings.Split(strings.TrimPrefix(name, snapshotFilePrefix), "-")
+	if len(parts) != 2 {
+		return 0, 0, false
+	}
+	version, err := strconv.ParseUint(parts[0], 10, 64)
+	if err != nil || version == 0 {
+		return 0, 0, false
+	}
+	epoch, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	return version, epoch, true
+}
+
+func fenceFileName(epoch int64) string {
+	return fmt.Sprintf("%s%020d", fenceFilePrefix, epoch)
+}
+
+func parseFenceFileName(name string) (int64, bool) {
+	if !strings.HasPrefix(name, fenceFilePrefix) {
+		return 0, false
+	}
+	epoch, err := strconv.ParseInt(strings.TrimPrefix(name, fenceFilePrefix), 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return epoch, true
+}
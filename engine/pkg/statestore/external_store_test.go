@@ -0,0 +1,140 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statestore
+
+import (
+	"context"
+	"testing"
+
+	brStorage "github.com/pingcap/tidb/br/pkg/storage"
+	"github.com/pingcap/tiflow/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExternalStore(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	storage, err := brStorage.NewLocalStorage(t.TempDir())
+	require.NoError(t, err)
+	store := NewExternalStore(storage, 1)
+
+	_, err = store.Load(ctx, 0)
+	require.True(t, errors.Is(err, errors.ErrStateSnapshotNotFound))
+
+	for i := 1; i <= 3; i++ {
+		version, err := store.Save(ctx, []byte{byte(i)})
+		require.NoError(t, err)
+		require.Equal(t, uint64(i), version)
+	}
+	versions, err := store.Versions(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{1, 2, 3}, versions)
+
+	snapshot, err := store.Load(ctx, 0)
+	require.NoError(t, err)
+	require.Equal(t, &Snapshot{Version: 3, Data: []byte{3}}, snapshot)
+	snapshot, err = store.Load(ctx, 2)
+	require.NoError(t, err)
+	require.Equal(t, &Snapshot{Version: 2, Data: []byte{2}}, snapshot)
+	_, err = store.Load(ctx, 4)
+	require.True(t, errors.Is(err, errors.ErrStateSnapshotNotFound))
+
+	// a new store, like the one of a new job master after failover, continues
+	// with the versions in the storage.
+	store = NewExternalStore(storage, 2)
+	version, err := store.Save(ctx, []byte{4})
+	require.NoError(t, err)
+	require.Equal(t, uint64(4), version)
+
+	require.NoError(t, store.Purge(ctx, 2))
+	versions, err = store.Versions(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{3, 4}, versions)
+	require.NoError(t, store.Purge(ctx, 0))
+	versions, err = store.Versions(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{4}, versions)
+}
+
+func TestExternalStoreFenced(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	storage, err := brStorage.NewLocalStorage(t.TempDir())
+	require.NoError(t, err)
+	oldStore := NewExternalStore(storage, 1)
+	version, err := oldStore.Save(ctx, []byte{1})
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), version)
+
+	// the new job master after failover takes over the store once it loads
+	// the latest snapshot, and the old one can't save any more.
+	newStore := NewExternalStore(storage, 2)
+	snapshot, err := newStore.Load(ctx, 0)
+	require.NoError(t, err)
+	require.Equal(t, &Snapshot{Version: 1, Data: []byte{1}}, snapshot)
+	_, err = oldStore.Save(ctx, []byte{2})
+	require.True(t, errors.Is(err, errors.ErrStateStoreFenced))
+	require.True(t, errors.Is(oldStore.Purge(ctx, 1), errors.ErrStateStoreFenced))
+	_, err = NewExternalStore(storage, 1).Load(ctx, 0)
+	require.True(t, errors.Is(err, errors.ErrStateStoreFenced))
+
+	// a snapshot written by the old job master before it's fenced is kept,
+	// but one of the same version as a snapshot of the new job master is
+	// superseded by it.
+	require.NoError(t, storage.WriteFile(ctx, snapshotFileName(2, 1), []byte{2}))
+	version, err = newStore.Save(ctx, []byte{3})
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), version)
+	require.NoError(t, storage.WriteFile(ctx, snapshotFileName(3, 1), []byte{4}))
+	snapshot, err = newStore.Load(ctx, 3)
+	require.NoError(t, err)
+	require.Equal(t, []byte{3}, snapshot.Data)
+
+	require.NoError(t, newStore.Purge(ctx, 1))
+	versions, err := newStore.Versions(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{3}, versions)
+	exists, err := storage.FileExists(ctx, fenceFileName(1))
+	require.NoError(t, err)
+	require.False(t, exists)
+	exists, err = storage.FileExists(ctx, snapshotFileName(3, 1))
+	require.NoError(t, err)
+	require.False(t, exists)
+}
+
+func TestSnapshotFileName(t *testing.T) {
+	t.Parallel()
+
+	name := snapshotFileName(12, 3)
+	require.Equal(t, "snapshot-00000000000000000012-00000000000000000003", name)
+	version, epoch, ok := parseSnapshotFileName(name)
+	require.True(t, ok)
+	require.Equal(t, uint64(12), version)
+	require.Equal(t, int64(3), epoch)
+
+	for _, name := range []string{
+		"snapshot-", "snapshot-0-1", "snapshot-1", "snapshot-abc-1", "snapshot-1-abc", "other-1-1",
+	} {
+		_, _, ok = parseSnapshotFileName(name)
+		require.False(t, ok, name)
+	}
+
+	epoch, ok = parseFenceFileName(fenceFileName(7))
+	require.True(t, ok)
+	require.Equal(t, int64(7), epoch)
+	_, ok = parseFenceFileName("fence-abc")
+	require.False(t, ok)
+}
@@ -0,0 +1,43 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statestore
+
+import (
+	"context"
+)
+
+// Snapshot is a versioned snapshot of the state of a job.
+type Snapshot struct {
+	Version uint64
+	Data    []byte
+}
+
+// Store persists the versioned snapshots of the state of a job, so that the
+// job can recover from the latest snapshot after a failover, or roll back to
+// an older one. The versions of the snapshots are increasing and start from 1.
+type Store interface {
+	// Save saves the state as a new snapshot and returns its version. It
+	// returns ErrStateStoreFenced if the store is taken over by a writer
+	// with a newer epoch.
+	Save(ctx context.Context, data []byte) (uint64, error)
+	// Load loads the snapshot of the version, or the latest snapshot if the
+	// version is 0. It returns ErrStateSnapshotNotFound if there is no such
+	// snapshot.
+	Load(ctx context.Context, version uint64) (*Snapshot, error)
+	// Versions returns the versions of the snapshots in ascending order.
+	Versions(ctx context.Context) ([]uint64, error)
+	// Purge removes the snapshots except the latest keep ones, the latest
+	// snapshot is always kept.
+	Purge(ctx context.Context, keep int) error
+}
@@ -0,0 +1,76 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tenant
+
+import (
+	"sort"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pingcap/tiflow/pkg/label"
+)
+
+// Quota is the resource quota of a tenant on the shared executors.
+type Quota struct {
+	// MaxJobs is the max number of the jobs of the tenant that are not
+	// terminated, 0 means no limit.
+	MaxJobs int `toml:"max-jobs" json:"max-jobs"`
+	// ExecutorLabels isolates the CPU, memory and workers of the tenant from
+	// the other tenants, the jobs of the tenant and their workers are only
+	// scheduled to the executors with all the labels.
+	ExecutorLabels map[string]string `toml:"executor-labels" json:"executor-labels"`
+}
+
+// Validate implements validation.Validatable.
+func (q Quota) Validate() error {
+	return validation.ValidateStruct(&q,
+		validation.Field(&q.MaxJobs, validation.Min(0)),
+		validation.Field(&q.ExecutorLabels, validation.By(func(interface{}) error {
+			for k, v := range q.ExecutorLabels {
+				if _, err := label.NewKey(k); err != nil {
+					return err
+				}
+				if _, err := label.NewValue(v); err != nil {
+					return err
+				}
+			}
+			return nil
+		})),
+	)
+}
+
+// Exceeded returns whether a tenant with the given number of jobs can't
+// submit a new job.
+func (q *Quota) Exceeded(jobs int) bool {
+	return q != nil && q.MaxJobs > 0 && jobs >= q.MaxJobs
+}
+
+// Selectors returns the selectors of the executors which the jobs of the
+// tenant are scheduled to.
+func (q *Quota) Selectors() []*label.Selector {
+	if q == nil || len(q.ExecutorLabels) == 0 {
+		return nil
+	}
+	selectors := make([]*label.Selector, 0, len(q.ExecutorLabels))
+	for k, v := range q.ExecutorLabels {
+		selectors = append(selectors, &label.Selector{
+			Key:    label.Key(k),
+			Target: v,
+			Op:     label.OpEq,
+		})
+	}
+	sort.Slice(selectors, func(i, j int) bool {
+		return selectors[i].Key < selectors[j].Key
+	})
+	return selectors
+}
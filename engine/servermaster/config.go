@@ -25,6 +25,7 @@ import (
 	"github.com/pingcap/log"
 	resModel "github.com/pingcap/tiflow/engine/pkg/externalresource/model"
 	metaModel "github.com/pingcap/tiflow/engine/pkg/meta/model"
+	"github.com/pingcap/tiflow/engine/pkg/tenant"
 	"github.com/pingcap/tiflow/engine/servermaster/jobop"
 	"github.com/pingcap/tiflow/pkg/errors"
 	"github.com/pingcap/tiflow/pkg/logutil"
@@ -79,6 +80,13 @@ type Config struct {
 	Security *security.Credential `toml:"security" json:"security"`
 
 	JobBackoff *jobop.BackoffConfig `toml:"job-backoff" json:"job-backoff"`
+
+	// TenantQuotas are the quotas of the tenants on the shared executors.
+	TenantQuotas map[tenant.Tenant]*tenant.Quota `toml:"tenant-quotas" json:"tenant-quotas"`
+	// DefaultTenantQuota is the quota shared by all the tenants without their
+	// own quota, including the jobs without a tenant. Their jobs are not
+	// limited if it's nil.
+	DefaultTenantQuota *tenant.Quota `toml:"default-tenant-quota" json:"default-tenant-quota"`
 }
 
 func (c *Config) String() string {
@@ -139,6 +147,8 @@ func (c *Config) AdjustAndValidate() (err error) {
 	return validation.ValidateStruct(c,
 		validation.Field(&c.FrameworkMeta),
 		validation.Field(&c.BusinessMeta),
+		validation.Field(&c.TenantQuotas),
+		validation.Field(&c.DefaultTenantQuota),
 	)
 }
 
@@ -18,6 +18,7 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/pingcap/tiflow/engine/pkg/tenant"
 	"github.com/pingcap/tiflow/pkg/cmd/util"
 	"github.com/pingcap/tiflow/pkg/security"
 	"github.com/stretchr/testify/require"
@@ -110,3 +111,36 @@ func TestLoadConfigFromFile(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, cfg, cfg2)
 }
+
+func TestTenantQuotasConfig(t *testing.T) {
+	t.Parallel()
+
+	testToml := `
+[tenant-quotas.tenant-a]
+max-jobs = 10
+
+[tenant-quotas.tenant-a.executor-labels]
+tenant = "a"
+
+[tenant-quotas.tenant-b]
+max-jobs = 0
+
+[default-tenant-quota]
+max-jobs = 5
+`
+	config := GetDefaultMasterConfig()
+	err := util.StrictDecodeFile(mustWriteToTempFile(t, testToml), "tiflow master", config)
+	require.NoError(t, err)
+	require.NoError(t, config.AdjustAndValidate())
+	require.Equal(t, map[string]*tenant.Quota{
+		"tenant-a": {MaxJobs: 10, ExecutorLabels: map[string]string{"tenant": "a"}},
+		"tenant-b": {MaxJobs: 0},
+	}, config.TenantQuotas)
+	require.Equal(t, &tenant.Quota{MaxJobs: 5}, config.DefaultTenantQuota)
+
+	config.TenantQuotas["tenant-a"].ExecutorLabels["tenant"] = "a/b"
+	require.Regexp(t, "executor-labels", config.AdjustAndValidate())
+	config.TenantQuotas["tenant-a"].ExecutorLabels["tenant"] = "a"
+	config.TenantQuotas["tenant-a"].MaxJobs = -1
+	require.Regexp(t, "max-jobs", config.AdjustAndValidate())
+}
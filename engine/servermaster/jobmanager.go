@@ -104,6 +104,11 @@ type JobManagerImpl struct {
 
 	// http client for the job detail
 	jobHTTPClient engineHTTPUtil.JobHTTPClient
+
+	// tenantQuotas are the quotas of the tenants, the tenants without a
+	// quota share defaultTenantQuota, they are not limited if it's nil.
+	tenantQuotas       map[tenant.Tenant]*tenant.Quota
+	defaultTenantQuota *tenant.Quota
 }
 
 // CancelJob implements JobManagerServer.CancelJob.
@@ -241,6 +246,8 @@ func (jm *JobManagerImpl) CreateJob(ctx context.Context, req *pb.CreateJobReques
 	if err != nil {
 		return nil, err
 	}
+	// isolate the job on the executors of its tenant.
+	selectors = append(selectors, jm.tenantQuota(req.TenantId).Selectors()...)
 
 	// TODO call jm.notifier.Notify when we want to support "add job" event.
 	log.Info("create job", zap.Any("job", req.Job),
@@ -261,6 +268,7 @@ func (jm *JobManagerImpl) CreateJob(ctx context.Context, req *pb.CreateJobReques
 		State:  frameModel.MasterStateUninit,
 		Ext: frameModel.MasterMetaExt{
 			Selectors: selectors,
+			TenantID:  req.TenantId,
 		},
 	}
 	switch job.Type {
@@ -279,10 +287,7 @@ func (jm *JobManagerImpl) CreateJob(ctx context.Context, req *pb.CreateJobReques
 	}
 
 	// create job master metadata before creating it.
-	if err := jm.frameMetaClient.InsertJob(ctx, meta); err != nil {
-		if pkgOrm.IsDuplicateEntryError(err) {
-			return nil, errors.ErrJobAlreadyExists.GenWithStackByArgs(job.Id)
-		}
+	if err := jm.insertJobMeta(ctx, meta); err != nil {
 		return nil, err
 	}
 
@@ -320,6 +325,74 @@ func (jm *JobManagerImpl) CreateJob(ctx context.Context, req *pb.CreateJobReques
 	return buildPBJob(meta, false /* includeConfig */)
 }
 
+// insertJobMeta inserts the metadata of a new job, it rejects the job if the
+// tenant of the job exceeds its quota.
+func (jm *JobManagerImpl) insertJobMeta(ctx context.Context, meta *frameModel.MasterMeta) error {
+	tenantID := meta.Ext.TenantID
+	if quota := jm.tenantQuota(tenantID); quota != nil {
+		// hold the lock until the job is inserted, so that the concurrent
+		// requests of the tenant can't exceed the quota together.
+		if ok := jm.jobStatusChangeMu.Lock(ctx); !ok {
+			return errors.Trace(ctx.Err())
+		}
+		defer jm.jobStatusChangeMu.Unlock()
+
+		jobs, err := jm.countTenantJobs(ctx, tenantID)
+		if err != nil {
+			return err
+		}
+		if quota.Exceeded(jobs) {
+			log.Warn("reject the job because the tenant exceeds its quota",
+				zap.String("job-id", meta.ID), zap.String("tenant-id", tenantID),
+				zap.Int("jobs", jobs), zap.Int("max-jobs", quota.MaxJobs))
+			return errors.ErrTenantQuotaExceeded.GenWithStackByArgs(tenantID, quota.MaxJobs)
+		}
+	}
+
+	if err := jm.frameMetaClient.InsertJob(ctx, meta); err != nil {
+		if pkgOrm.IsDuplicateEntryError(err) {
+			return errors.ErrJobAlreadyExists.GenWithStackByArgs(meta.ID)
+		}
+		return err
+	}
+	return nil
+}
+
+// tenantQuota returns the quota of the tenant, it's the default tenant quota
+// if the tenant has no quota of its own.
+func (jm *JobManagerImpl) tenantQuota(tenantID tenant.Tenant) *tenant.Quota {
+	if quota, ok := jm.tenantQuotas[tenantID]; ok {
+		return quota
+	}
+	return jm.defaultTenantQuota
+}
+
+// countTenantJobs returns the number of the jobs counted in the quota of the
+// tenant that are not terminated. The jobs of all the tenants without their
+// own quota are counted in the default tenant quota together, so that a
+// client can't bypass it by choosing a new tenant ID.
+func (jm *JobManagerImpl) countTenantJobs(ctx context.Context, tenantID tenant.Tenant) (int, error) {
+	metas, err := jm.frameMetaClient.QueryJobs(ctx)
+	if err != nil {
+		return 0, err
+	}
+	_, ownQuota := jm.tenantQuotas[tenantID]
+	count := 0
+	for _, meta := range metas {
+		if meta.Type == frameModel.JobManager {
+			continue
+		}
+		if _, ok := jm.tenantQuotas[meta.Ext.TenantID]; ok != ownQuota ||
+			ownQuota && meta.Ext.TenantID != tenantID {
+			continue
+		}
+		if !isJobTerminated(meta.State) {
+			count++
+		}
+	}
+	return count, nil
+}
+
 func validateCreateJobRequest(req *pb.CreateJobRequest) error {
 	if req.Job == nil {
 		return status.Error(codes.InvalidArgument, "job must not be nil")
@@ -529,6 +602,8 @@ func NewJobManagerImpl(
 	dctx *dcontext.Context,
 	id frameModel.MasterID,
 	backoffConfig *jobop.BackoffConfig,
+	tenantQuotas map[tenant.Tenant]*tenant.Quota,
+	defaultTenantQuota *tenant.Quota,
 ) (*JobManagerImpl, error) {
 	metaCli, err := dctx.Deps().Construct(func(cli pkgOrm.Client) (pkgOrm.Client, error) {
 		return cli, nil
@@ -558,6 +633,8 @@ func NewJobManagerImpl(
 		jobOperatorNotifier: new(notify.Notifier),
 		jobHTTPClient:       engineHTTPUtil.NewJobHTTPClient(httpCli),
 		JobBackoffMgr:       jobop.NewBackoffManagerImpl(clocker, backoffConfig),
+		tenantQuotas:        tenantQuotas,
+		defaultTenantQuota:  defaultTenantQuota,
 	}
 	impl.BaseMaster = framework.NewBaseMaster(
 		dctx,
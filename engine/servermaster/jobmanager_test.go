@@ -32,6 +32,7 @@ import (
 	"github.com/pingcap/tiflow/engine/pkg/notifier"
 	"github.com/pingcap/tiflow/engine/pkg/openapi"
 	pkgOrm "github.com/pingcap/tiflow/engine/pkg/orm"
+	"github.com/pingcap/tiflow/engine/pkg/tenant"
 	"github.com/pingcap/tiflow/engine/servermaster/jobop"
 	jobopMock "github.com/pingcap/tiflow/engine/servermaster/jobop/mock"
 	"github.com/pingcap/tiflow/pkg/errors"
@@ -120,6 +121,65 @@ func TestJobManagerCreateJob(t *testing.T) {
 	require.True(t, errors.Is(err, errors.ErrJobAlreadyExists))
 }
 
+func TestJobManagerCreateJobExceedTenantQuota(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	mockMaster, mgr := prepareMockJobManager(ctx, t, "create-job-quota-test")
+	mockMaster.On("InitImpl", mock.Anything).Return(nil)
+	mockMaster.MasterClient().EXPECT().ScheduleTask(
+		gomock.Any(),
+		gomock.Any()).Return(&pb.ScheduleTaskResponse{}, errors.ErrClusterResourceNotEnough.FastGenByArgs()).AnyTimes()
+	wg, ctx := errgroup.WithContext(ctx)
+	mgr.wg = wg
+	mgr.tenantQuotas = map[tenant.Tenant]*tenant.Quota{
+		"tenant-a": {MaxJobs: 1, ExecutorLabels: map[string]string{"tenant": "a"}},
+	}
+	mgr.defaultTenantQuota = &tenant.Quota{MaxJobs: 2}
+	mockMaster.Impl = mgr
+	err := mockMaster.Init(ctx)
+	require.Nil(t, err)
+
+	newReq := func(tenantID string) *pb.CreateJobRequest {
+		return &pb.CreateJobRequest{
+			Job: &pb.Job{
+				Type:   pb.Job_CVSDemo,
+				Config: []byte("{\"srcHost\":\"0.0.0.0:1234\", \"dstHost\":\"0.0.0.0:1234\", \"srcDir\":\"data\", \"dstDir\":\"data1\"}"),
+			},
+			TenantId: tenantID,
+		}
+	}
+	job, err := mgr.CreateJob(ctx, newReq("tenant-a"))
+	require.NoError(t, err)
+	_, err = mgr.CreateJob(ctx, newReq("tenant-a"))
+	require.True(t, errors.Is(err, errors.ErrTenantQuotaExceeded))
+	// the job is isolated on the executors of its tenant.
+	meta, err := mockMaster.GetFrameMetaClient().GetJobByID(ctx, job.Id)
+	require.NoError(t, err)
+	require.Equal(t, []*label.Selector{{Key: "tenant", Target: "a", Op: label.OpEq}}, meta.Ext.Selectors)
+
+	// the tenants without a quota share the default quota, so a new tenant
+	// ID can't bypass it.
+	_, err = mgr.CreateJob(ctx, newReq("tenant-b"))
+	require.NoError(t, err)
+	_, err = mgr.CreateJob(ctx, newReq(""))
+	require.NoError(t, err)
+	_, err = mgr.CreateJob(ctx, newReq("tenant-c"))
+	require.True(t, errors.Is(err, errors.ErrTenantQuotaExceeded))
+
+	// the terminated jobs don't count in the quota
+	err = mockMaster.GetFrameMetaClient().UpdateJob(ctx, job.Id,
+		map[string]interface{}{
+			"state": frameModel.MasterStateFinished,
+		},
+	)
+	require.NoError(t, err)
+	_, err = mgr.CreateJob(ctx, newReq("tenant-a"))
+	require.NoError(t, err)
+}
+
 type mockBaseMasterCreateWorkerFailed struct {
 	*framework.MockMasterImpl
 }
@@ -794,7 +794,8 @@ func (s *Server) runLeaderService(ctx context.Context) (err error) {
 	s.leaderDegrader.updateExecutorManager(true)
 
 	dctx = dctx.WithDeps(dp)
-	s.jobManager, err = NewJobManagerImpl(dctx, metadata.JobManagerUUID, s.cfg.JobBackoff)
+	s.jobManager, err = NewJobManagerImpl(dctx, metadata.JobManagerUUID, s.cfg.JobBackoff,
+		s.cfg.TenantQuotas, s.cfg.DefaultTenantQuota)
 	if err != nil {
 		return
 	}
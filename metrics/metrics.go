@@ -0,0 +1,30 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package metrics embeds the Grafana dashboards and the Prometheus alert
+// rules of TiCDC, so that they are released with the binary.
+package metrics
+
+import (
+	_ "embed" // embed the dashboard and the alert rules
+)
+
+// TiCDCGrafanaDashboard is the Grafana dashboard of TiCDC.
+//
+//go:embed grafana/ticdc.json
+var TiCDCGrafanaDashboard []byte
+
+// TiCDCAlertRules are the Prometheus alert rules of TiCDC.
+//
+//go:embed alertmanager/ticdc.rules.yml
+var TiCDCAlertRules []byte
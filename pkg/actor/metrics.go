@@ -80,7 +80,7 @@ var (
 )
 
 // InitMetrics registers all metrics in this file
-func InitMetrics(registry *prometheus.Registry) {
+func InitMetrics(registry prometheus.Registerer) {
 	registry.MustRegister(totalWorkers)
 	registry.MustRegister(workingWorkers)
 	registry.MustRegister(workingDuration)
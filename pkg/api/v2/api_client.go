@@ -27,6 +27,7 @@ type APIV2Interface interface {
 	TsoGetter
 	UnsafeGetter
 	StatusGetter
+	MonitoringGetter
 }
 
 // APIV2Client implements APIV1Interface and it is used to interact with cdc owner http api.
@@ -75,6 +76,14 @@ func (c *APIV2Client) Status() StatusInterface {
 	return newStatus(c)
 }
 
+// Monitoring returns a MonitoringInterface to communicate with cdc api
+func (c *APIV2Client) Monitoring() MonitoringInterface {
+	if c == nil {
+		return nil
+	}
+	return newMonitoring(c)
+}
+
 // NewAPIClient creates a new APIV1Client.
 func NewAPIClient(serverAddr string, credential *security.Credential) (*APIV2Client, error) {
 	c := &rest.Config{}
@@ -33,6 +33,9 @@ type ChangefeedInterface interface {
 	Create(ctx context.Context, cfg *v2.ChangefeedConfig) (*v2.ChangeFeedInfo, error)
 	// GetInfo gets a changefeed's info
 	GetInfo(ctx context.Context, name string) (*v2.ChangeFeedInfo, error)
+	// GetInfoWithSecrets gets a changefeed's info without masking the
+	// secrets in the sink uri
+	GetInfoWithSecrets(ctx context.Context, name string) (*v2.ChangeFeedInfo, error)
 	// VerifyTable verifies table for a changefeed
 	VerifyTable(ctx context.Context, cfg *v2.VerifyTableConfig) (*v2.Tables, error)
 	// Update updates a changefeed
@@ -93,6 +96,19 @@ func (c *changefeeds) GetInfo(ctx context.Context,
 	return result, err
 }
 
+func (c *changefeeds) GetInfoWithSecrets(ctx context.Context,
+	name string,
+) (*v2.ChangeFeedInfo, error) {
+	result := &v2.ChangeFeedInfo{}
+	u := fmt.Sprintf("changefeeds/%s/meta_info", name)
+	err := c.client.Get().
+		WithURI(u).
+		WithParam("show_secrets", "true").
+		Do(ctx).
+		Into(result)
+	return result, err
+}
+
 func (c *changefeeds) Update(ctx context.Context,
 	cfg *v2.ChangefeedConfig, name string,
 ) (*v2.ChangeFeedInfo, error) {
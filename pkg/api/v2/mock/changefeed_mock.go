@@ -117,6 +117,21 @@ func (mr *MockChangefeedInterfaceMockRecorder) GetInfo(ctx, name interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInfo", reflect.TypeOf((*MockChangefeedInterface)(nil).GetInfo), ctx, name)
 }
 
+// GetInfoWithSecrets mocks base method.
+func (m *MockChangefeedInterface) GetInfoWithSecrets(ctx context.Context, name string) (*v2.ChangeFeedInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInfoWithSecrets", ctx, name)
+	ret0, _ := ret[0].(*v2.ChangeFeedInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInfoWithSecrets indicates an expected call of GetInfoWithSecrets.
+func (mr *MockChangefeedInterfaceMockRecorder) GetInfoWithSecrets(ctx, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInfoWithSecrets", reflect.TypeOf((*MockChangefeedInterface)(nil).GetInfoWithSecrets), ctx, name)
+}
+
 // Pause mocks base method.
 func (m *MockChangefeedInterface) Pause(ctx context.Context, name string) error {
 	m.ctrl.T.Helper()
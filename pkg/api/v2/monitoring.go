@@ -0,0 +1,59 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"context"
+
+	"github.com/pingcap/tiflow/pkg/api/internal/rest"
+)
+
+// MonitoringGetter has a method to return a MonitoringInterface.
+type MonitoringGetter interface {
+	Monitoring() MonitoringInterface
+}
+
+// MonitoringInterface has methods to work with monitoring api
+type MonitoringInterface interface {
+	GrafanaDashboard(ctx context.Context) ([]byte, error)
+	AlertRules(ctx context.Context) ([]byte, error)
+}
+
+// monitoring implements MonitoringInterface
+type monitoring struct {
+	client rest.CDCRESTInterface
+}
+
+// newMonitoring returns monitoring
+func newMonitoring(c *APIV2Client) *monitoring {
+	return &monitoring{
+		client: c.RESTClient(),
+	}
+}
+
+// GrafanaDashboard returns the Grafana dashboard json of the server
+func (c *monitoring) GrafanaDashboard(ctx context.Context) ([]byte, error) {
+	return c.client.Get().
+		WithURI("monitoring/grafana_dashboard").
+		Do(ctx).
+		Raw()
+}
+
+// AlertRules returns the Prometheus alert rules yaml of the server
+func (c *monitoring) AlertRules(ctx context.Context) ([]byte, error) {
+	return c.client.Get().
+		WithURI("monitoring/alert_rules").
+		Do(ctx).
+		Raw()
+}
@@ -213,6 +213,41 @@ func (ra *RedoApplier) ReadMeta(ctx context.Context) (checkpointTs uint64, resol
 	return rd.ReadMeta(ctx)
 }
 
+// ReadPendingDDLs reads the meta and the DDL events not applied yet from
+// the redo logs, whose commit ts are in (checkpointTs, resolvedTs].
+func (ra *RedoApplier) ReadPendingDDLs(ctx context.Context) (
+	checkpointTs uint64, resolvedTs uint64, ddls []*model.DDLEvent, err error,
+) {
+	rd, err := createRedoReader(ctx, ra.cfg)
+	if err != nil {
+		return 0, 0, nil, err
+	}
+	defer rd.Close() //nolint:errcheck
+
+	checkpointTs, resolvedTs, err = rd.ReadMeta(ctx)
+	if err != nil {
+		return 0, 0, nil, err
+	}
+	if checkpointTs == resolvedTs {
+		return checkpointTs, resolvedTs, nil, nil
+	}
+	if err := rd.ResetReader(ctx, checkpointTs, resolvedTs); err != nil {
+		return 0, 0, nil, err
+	}
+	for {
+		redoDDLs, err := rd.ReadNextDDL(ctx, readBatch)
+		if err != nil {
+			return 0, 0, nil, err
+		}
+		if len(redoDDLs) == 0 {
+			return checkpointTs, resolvedTs, ddls, nil
+		}
+		for _, redoDDL := range redoDDLs {
+			ddls = append(ddls, redoDDL.DDL)
+		}
+	}
+}
+
 // Apply applies redo log to given target
 func (ra *RedoApplier) Apply(ctx context.Context) error {
 	rd, err := createRedoReader(ctx, ra.cfg)
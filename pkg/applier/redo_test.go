@@ -260,3 +260,29 @@ func TestApplyMeetSinkError(t *testing.T) {
 	err = ap.Apply(ctx)
 	require.Regexp(t, "CDC:ErrMySQLConnectionError", err)
 }
+
+func TestReadPendingDDLs(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ddlEventCh := make(chan *model.RedoDDLEvent, 2)
+	ddlEventCh <- &model.RedoDDLEvent{DDL: &model.DDLEvent{CommitTs: 1100, Query: "create table t1(a int)"}}
+	ddlEventCh <- &model.RedoDDLEvent{DDL: &model.DDLEvent{CommitTs: 1200, Query: "create table t2(a int)"}}
+	close(ddlEventCh)
+	createRedoReaderBak := createRedoReader
+	createRedoReader = func(ctx context.Context, cfg *RedoApplierConfig) (reader.RedoLogReader, error) {
+		return NewMockReader(1000, 2000, nil, ddlEventCh), nil
+	}
+	defer func() {
+		createRedoReader = createRedoReaderBak
+	}()
+
+	ap := NewRedoApplier(&RedoApplierConfig{Storage: "blackhole://"})
+	checkpointTs, resolvedTs, ddls, err := ap.ReadPendingDDLs(ctx)
+	require.Nil(t, err)
+	require.Equal(t, uint64(1000), checkpointTs)
+	require.Equal(t, uint64(2000), resolvedTs)
+	require.Len(t, ddls, 2)
+	require.Equal(t, uint64(1100), ddls[0].CommitTs)
+	require.Equal(t, "create table t2(a int)", ddls[1].Query)
+}
@@ -0,0 +1,123 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bench
+
+import (
+	"time"
+
+	"github.com/pingcap/errors"
+	cmdcontext "github.com/pingcap/tiflow/pkg/cmd/context"
+	"github.com/pingcap/tiflow/pkg/cmd/util"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/pingcap/tiflow/pkg/logutil"
+	"github.com/spf13/cobra"
+)
+
+// options defines flags for the `bench` command.
+type options struct {
+	sinkURI      string
+	configFile   string
+	schema       string
+	tables       int
+	columns      int
+	rowWidth     int
+	rowsPerSec   int
+	batchSize    int
+	ddlInterval  time.Duration
+	duration     time.Duration
+	maxInflight  int
+	reportPeriod time.Duration
+	logLevel     string
+}
+
+// newOptions creates new options for the `bench` command.
+func newOptions() *options {
+	return &options{}
+}
+
+// addFlags receives a *cobra.Command reference and binds
+// flags related to template printing to it.
+func (o *options) addFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&o.sinkURI, "sink-uri", "", "sink uri of the target, eg, \"kafka://127.0.0.1:9092/bench?protocol=open-protocol\"")
+	cmd.Flags().StringVar(&o.configFile, "config", "", "path of the changefeed configuration file")
+	cmd.Flags().StringVar(&o.schema, "schema", "cdc_bench", "schema of the generated tables")
+	cmd.Flags().IntVar(&o.tables, "tables", 16, "number of generated tables")
+	cmd.Flags().IntVar(&o.columns, "columns", 4, "number of non-primary-key columns of each table")
+	cmd.Flags().IntVar(&o.rowWidth, "row-width", 256, "total bytes of non-primary-key columns in a row")
+	cmd.Flags().IntVar(&o.rowsPerSec, "rows-per-second", 0, "rate of generated rows, 0 means as fast as the sink can accept")
+	cmd.Flags().IntVar(&o.batchSize, "batch-size", 256, "number of rows in a transaction")
+	cmd.Flags().DurationVar(&o.ddlInterval, "ddl-interval", 0, "interval of generated DDLs, 0 means no DDL is generated")
+	cmd.Flags().DurationVar(&o.duration, "duration", time.Minute, "duration of the benchmark")
+	cmd.Flags().IntVar(&o.maxInflight, "max-inflight-rows", 1024*1024, "max rows that are generated but not flushed by the sink")
+	cmd.Flags().DurationVar(&o.reportPeriod, "report-interval", 10*time.Second, "interval of progress reports")
+	cmd.Flags().StringVar(&o.logLevel, "log-level", "warn", "log level (etc: debug|info|warn|error)")
+	// the possible error returned from MarkFlagRequired is `no such flag`
+	cmd.MarkFlagRequired("sink-uri") //nolint:errcheck
+}
+
+func (o *options) validate() error {
+	if o.tables <= 0 || o.columns <= 0 || o.rowWidth <= 0 || o.batchSize <= 0 {
+		return errors.New("tables, columns, row-width and batch-size must be positive")
+	}
+	if o.rowsPerSec < 0 || o.maxInflight <= 0 {
+		return errors.New(
+			"rows-per-second must not be negative and max-inflight-rows must be positive")
+	}
+	if o.duration <= 0 || o.reportPeriod <= 0 {
+		return errors.New("duration and report-interval must be positive")
+	}
+	return nil
+}
+
+// run runs the `bench` command.
+func (o *options) run(cmd *cobra.Command) error {
+	ctx := cmdcontext.GetDefaultContext()
+
+	replicaConfig := config.GetDefaultReplicaConfig()
+	if len(o.configFile) > 0 {
+		err := util.StrictDecodeFile(o.configFile, "TiCDC bench", replicaConfig)
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+	r := newRunner(o, replicaConfig, cmd)
+	report, err := r.run(ctx)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	cmd.Println(report.String())
+	return nil
+}
+
+// NewCmdBench creates the `bench` command.
+func NewCmdBench() *cobra.Command {
+	o := newOptions()
+
+	command := &cobra.Command{
+		Use:   "bench",
+		Short: "Drive the sink with a synthetic workload and report its max sustainable throughput",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.validate(); err != nil {
+				return err
+			}
+			cancel := util.InitCmd(cmd, &logutil.Config{Level: o.logLevel})
+			defer cancel()
+			return o.run(cmd)
+		},
+	}
+	o.addFlags(command)
+
+	return command
+}
@@ -0,0 +1,298 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bench
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sinkv2/ddlsink"
+	ddlfactory "github.com/pingcap/tiflow/cdc/sinkv2/ddlsink/factory"
+	dmlfactory "github.com/pingcap/tiflow/cdc/sinkv2/eventsink/factory"
+	"github.com/pingcap/tiflow/cdc/sinkv2/tablesink"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/pingcap/tiflow/pkg/spanz"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/spf13/cobra"
+	"github.com/tikv/client-go/v2/oracle"
+	"golang.org/x/time/rate"
+)
+
+const (
+	benchChangefeed = "cdc-bench"
+	// checkInterval is the interval to poll checkpoints of table sinks.
+	checkInterval = 50 * time.Millisecond
+)
+
+// pendingBatch is a batch of rows which are written to a table sink
+// but not flushed yet.
+type pendingBatch struct {
+	commitTs model.Ts
+	rows     int
+	bytes    int64
+}
+
+// report is the result of a benchmark.
+type report struct {
+	elapsed       time.Duration
+	generatedRows int64
+	flushedRows   int64
+	flushedBytes  int64
+	ddls          int
+	maxDDLLatency time.Duration
+	// maxCheckpointLag is the max lag between generating a row
+	// and the row being flushed by the sink.
+	maxCheckpointLag time.Duration
+}
+
+func (r *report) rowsPerSecond() float64 {
+	if r.elapsed <= 0 {
+		return 0
+	}
+	return float64(r.flushedRows) / r.elapsed.Seconds()
+}
+
+func (r *report) bytesPerSecond() float64 {
+	if r.elapsed <= 0 {
+		return 0
+	}
+	return float64(r.flushedBytes) / r.elapsed.Seconds()
+}
+
+// String implements fmt.Stringer.
+func (r *report) String() string {
+	return fmt.Sprintf("elapsed: %s, generated rows: %d, flushed rows: %d, "+
+		"throughput: %.2f rows/s, %.2f MiB/s, max checkpoint lag: %s, "+
+		"ddls: %d, max ddl latency: %s",
+		r.elapsed.Round(time.Millisecond), r.generatedRows, r.flushedRows,
+		r.rowsPerSecond(), r.bytesPerSecond()/1024/1024,
+		r.maxCheckpointLag.Round(time.Millisecond), r.ddls,
+		r.maxDDLLatency.Round(time.Millisecond))
+}
+
+// runner drives the sink with a synthetic workload.
+type runner struct {
+	opts *options
+	cfg  *config.ReplicaConfig
+	cmd  *cobra.Command
+
+	workload   *workload
+	ddlSink    ddlsink.DDLEventSink
+	tableSinks []tablesink.TableSink
+	pending    [][]pendingBatch
+	// lastTs is used to make sure commit ts is strictly increasing.
+	lastTs model.Ts
+
+	report report
+}
+
+func newRunner(opts *options, cfg *config.ReplicaConfig, cmd *cobra.Command) *runner {
+	return &runner{
+		opts:     opts,
+		cfg:      cfg,
+		cmd:      cmd,
+		workload: newWorkload(opts.schema, opts.tables, opts.columns, opts.rowWidth),
+		pending:  make([][]pendingBatch, opts.tables),
+	}
+}
+
+// nextTs returns a commit ts which is close to the current time.
+func (r *runner) nextTs() model.Ts {
+	ts := oracle.GoTimeToTS(time.Now())
+	if ts <= r.lastTs {
+		ts = r.lastTs + 1
+	}
+	r.lastTs = ts
+	return ts
+}
+
+func (r *runner) run(ctx context.Context) (*report, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	errCh := make(chan error, 16)
+	var err error
+	r.ddlSink, err = ddlfactory.New(ctx, r.opts.sinkURI, r.cfg)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer r.ddlSink.Close() //nolint:errcheck
+	if err := r.createTables(ctx); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	sinkFactory, err := dmlfactory.New(ctx, r.opts.sinkURI, r.cfg, errCh)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer sinkFactory.Close() //nolint:errcheck
+	changefeedID := model.DefaultChangeFeedID(benchChangefeed)
+	counter := prometheus.NewCounter(prometheus.CounterOpts{})
+	for _, table := range r.workload.tables {
+		span := spanz.TableIDToComparableSpan(table.ID)
+		r.tableSinks = append(r.tableSinks,
+			sinkFactory.CreateTableSink(changefeedID, span, counter))
+	}
+	defer func() {
+		for _, s := range r.tableSinks {
+			s.Close(ctx)
+		}
+	}()
+
+	var limiter *rate.Limiter
+	if r.opts.rowsPerSec > 0 {
+		limiter = rate.NewLimiter(rate.Limit(r.opts.rowsPerSec), r.opts.batchSize)
+	}
+	start := time.Now()
+	deadline := start.Add(r.opts.duration)
+	lastReport, lastDDL := start, start
+	lastCheck := time.Time{}
+	for tableIndex := 0; time.Now().Before(deadline); tableIndex++ {
+		select {
+		case <-ctx.Done():
+			return nil, errors.Trace(ctx.Err())
+		case err := <-errCh:
+			return nil, errors.Trace(err)
+		default:
+		}
+
+		if r.opts.ddlInterval > 0 && time.Since(lastDDL) >= r.opts.ddlInterval {
+			if err := r.executeDDL(ctx, errCh); err != nil {
+				return nil, errors.Trace(err)
+			}
+			lastDDL = time.Now()
+		}
+
+		// Back pressure, wait until the sink catches up.
+		for r.inflightRows() >= int64(r.opts.maxInflight) {
+			if err := r.waitForFlush(ctx, errCh); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if limiter != nil {
+			if err := limiter.WaitN(ctx, r.opts.batchSize); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if err := r.writeBatch(tableIndex % len(r.tableSinks)); err != nil {
+			return nil, errors.Trace(err)
+		}
+
+		if time.Since(lastCheck) >= checkInterval {
+			r.collectFlushed()
+			lastCheck = time.Now()
+		}
+		if time.Since(lastReport) >= r.opts.reportPeriod {
+			r.report.elapsed = time.Since(start)
+			r.cmd.Println(r.report.String())
+			lastReport = time.Now()
+		}
+	}
+	r.collectFlushed()
+	r.report.elapsed = time.Since(start)
+	return &r.report, nil
+}
+
+// createTables creates the bench schema and tables in the downstream.
+func (r *runner) createTables(ctx context.Context) error {
+	ddls := []*model.DDLEvent{r.workload.createSchemaDDL(r.nextTs())}
+	ddls = append(ddls, r.workload.createTableDDLs(r.nextTs())...)
+	for _, ddl := range ddls {
+		if err := r.ddlSink.WriteDDLEvent(ctx, ddl); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// executeDDL waits for all rows before the DDL to be flushed and
+// then executes the DDL, just like the owner does with barriers.
+func (r *runner) executeDDL(ctx context.Context, errCh <-chan error) error {
+	ddl := r.workload.nextDDL(r.nextTs())
+	start := time.Now()
+	for r.inflightRows() > 0 {
+		if err := r.waitForFlush(ctx, errCh); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if err := r.ddlSink.WriteDDLEvent(ctx, ddl); err != nil {
+		return errors.Trace(err)
+	}
+	if latency := time.Since(start); latency > r.report.maxDDLLatency {
+		r.report.maxDDLLatency = latency
+	}
+	r.report.ddls++
+	return nil
+}
+
+func (r *runner) writeBatch(tableIndex int) error {
+	commitTs := r.nextTs()
+	rows := make([]*model.RowChangedEvent, 0, r.opts.batchSize)
+	var bytes int64
+	for i := 0; i < r.opts.batchSize; i++ {
+		row := r.workload.nextRow(tableIndex, commitTs)
+		bytes += row.ApproximateDataSize
+		rows = append(rows, row)
+	}
+	tableSink := r.tableSinks[tableIndex]
+	tableSink.AppendRowChangedEvents(rows...)
+	if err := tableSink.UpdateResolvedTs(model.NewResolvedTs(commitTs)); err != nil {
+		return errors.Trace(err)
+	}
+	r.pending[tableIndex] = append(r.pending[tableIndex], pendingBatch{
+		commitTs: commitTs, rows: len(rows), bytes: bytes,
+	})
+	r.report.generatedRows += int64(len(rows))
+	return nil
+}
+
+func (r *runner) inflightRows() int64 {
+	return r.report.generatedRows - r.report.flushedRows
+}
+
+func (r *runner) waitForFlush(ctx context.Context, errCh <-chan error) error {
+	select {
+	case <-ctx.Done():
+		return errors.Trace(ctx.Err())
+	case err := <-errCh:
+		return errors.Trace(err)
+	case <-time.After(checkInterval):
+	}
+	r.collectFlushed()
+	return nil
+}
+
+// collectFlushed counts rows which have been flushed by table sinks.
+func (r *runner) collectFlushed() {
+	now := time.Now()
+	for i, tableSink := range r.tableSinks {
+		checkpointTs := tableSink.GetCheckpointTs().Ts
+		batches := r.pending[i]
+		n := 0
+		for ; n < len(batches) && batches[n].commitTs <= checkpointTs; n++ {
+			r.report.flushedRows += int64(batches[n].rows)
+			r.report.flushedBytes += batches[n].bytes
+		}
+		r.pending[i] = batches[n:]
+		if len(r.pending[i]) > 0 {
+			lag := now.Sub(oracle.GetTimeFromTS(r.pending[i][0].commitTs))
+			if lag > r.report.maxCheckpointLag {
+				r.report.maxCheckpointLag = lag
+			}
+		}
+	}
+}
@@ -0,0 +1,177 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bench
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+
+	timodel "github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/quotes"
+)
+
+const benchTableIDBase = 1000
+
+// workload generates synthetic row changed events and DDL events for
+// a set of tables with the schema:
+//
+//	CREATE TABLE t_N (id BIGINT PRIMARY KEY, c_0 VARCHAR(W), ..., c_M VARCHAR(W))
+type workload struct {
+	schema   string
+	tables   []*model.TableInfo
+	columns  int
+	rowWidth int
+
+	// nextIDs records the next primary key of each table.
+	nextIDs []int64
+	// ddlCount is used to generate distinct DDL statements.
+	ddlCount int
+	rnd      *rand.Rand
+}
+
+// newWorkload creates a workload. rowWidth is the total bytes of
+// non-primary-key columns in a row.
+func newWorkload(schema string, tableCount, columns, rowWidth int) *workload {
+	w := &workload{
+		schema:   schema,
+		columns:  columns,
+		rowWidth: rowWidth,
+		nextIDs:  make([]int64, tableCount),
+		rnd:      rand.New(rand.NewSource(1)),
+	}
+	for i := 0; i < tableCount; i++ {
+		info := model.BuildTiDBTableInfo(w.genColumns(0), [][]int{{0}})
+		info.ID = benchTableIDBase + int64(i) + 1
+		info.Name = timodel.NewCIStr(fmt.Sprintf("t_%d", i))
+		info.PKIsHandle = true
+		info.IsCommonHandle = false
+		for j, col := range info.Columns {
+			col.ID = int64(j) + 1
+		}
+		w.tables = append(w.tables,
+			model.WrapTableInfo(benchTableIDBase, schema, 0, info))
+	}
+	return w
+}
+
+func (w *workload) columnWidth() int {
+	width := w.rowWidth / w.columns
+	if width == 0 {
+		width = 1
+	}
+	return width
+}
+
+func (w *workload) genColumns(id int64) []*model.Column {
+	cols := make([]*model.Column, 0, w.columns+1)
+	pkFlag := model.HandleKeyFlag | model.PrimaryKeyFlag
+	cols = append(cols, &model.Column{
+		Name:  "id",
+		Type:  mysql.TypeLonglong,
+		Flag:  pkFlag,
+		Value: id,
+	})
+	width := w.columnWidth()
+	for i := 0; i < w.columns; i++ {
+		cols = append(cols, &model.Column{
+			Name:             fmt.Sprintf("c_%d", i),
+			Type:             mysql.TypeVarchar,
+			Flag:             model.NullableFlag,
+			Value:            w.randString(width),
+			ApproximateBytes: width,
+		})
+	}
+	return cols
+}
+
+func (w *workload) randString(n int) string {
+	const letters = "abcdefghijklmnopqrstuvwxyz0123456789"
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = letters[w.rnd.Intn(len(letters))]
+	}
+	return string(b)
+}
+
+// createSchemaDDL returns the DDL event that creates the bench schema.
+func (w *workload) createSchemaDDL(commitTs model.Ts) *model.DDLEvent {
+	return &model.DDLEvent{
+		StartTs:  commitTs - 1,
+		CommitTs: commitTs,
+		Query: fmt.Sprintf("CREATE DATABASE IF NOT EXISTS %s",
+			quotes.QuoteName(w.schema)),
+		TableInfo: &model.TableInfo{
+			TableName: model.TableName{Schema: w.schema},
+		},
+		Type: timodel.ActionCreateSchema,
+	}
+}
+
+// createTableDDLs returns DDL events that create all bench tables.
+func (w *workload) createTableDDLs(commitTs model.Ts) []*model.DDLEvent {
+	ddls := make([]*model.DDLEvent, 0, len(w.tables))
+	for _, table := range w.tables {
+		var b strings.Builder
+		fmt.Fprintf(&b, "CREATE TABLE IF NOT EXISTS %s (`id` BIGINT PRIMARY KEY",
+			table.TableName.QuoteString())
+		for i := 0; i < w.columns; i++ {
+			fmt.Fprintf(&b, ", `c_%d` VARCHAR(%d)", i, w.columnWidth())
+		}
+		b.WriteString(")")
+		ddls = append(ddls, &model.DDLEvent{
+			StartTs:   commitTs - 1,
+			CommitTs:  commitTs,
+			Query:     b.String(),
+			TableInfo: table,
+			Type:      timodel.ActionCreateTable,
+		})
+	}
+	return ddls
+}
+
+// nextDDL returns a DDL event which does not change the table structure,
+// so that rows generated later are still valid.
+func (w *workload) nextDDL(commitTs model.Ts) *model.DDLEvent {
+	table := w.tables[w.ddlCount%len(w.tables)]
+	w.ddlCount++
+	return &model.DDLEvent{
+		StartTs:  commitTs - 1,
+		CommitTs: commitTs,
+		Query: fmt.Sprintf("ALTER TABLE %s COMMENT = 'bench %d'",
+			table.TableName.QuoteString(), w.ddlCount),
+		TableInfo:    table,
+		PreTableInfo: table,
+		Type:         timodel.ActionModifyTableComment,
+	}
+}
+
+// nextRow returns an insert event of the given table.
+func (w *workload) nextRow(tableIndex int, commitTs model.Ts) *model.RowChangedEvent {
+	table := w.tables[tableIndex]
+	id := w.nextIDs[tableIndex]
+	w.nextIDs[tableIndex]++
+	return &model.RowChangedEvent{
+		StartTs:      commitTs - 1,
+		CommitTs:     commitTs,
+		Table:        &table.TableName,
+		TableInfo:    table,
+		Columns:      w.genColumns(id),
+		IndexColumns: [][]int{{0}},
+		// 8 bytes for the primary key.
+		ApproximateDataSize: int64(8 + w.columnWidth()*w.columns),
+	}
+}
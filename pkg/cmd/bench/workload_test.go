@@ -0,0 +1,83 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bench
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	timodel "github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkload(t *testing.T) {
+	t.Parallel()
+
+	w := newWorkload("test", 2, 4, 64)
+	require.Len(t, w.tables, 2)
+	require.Equal(t, "t_1", w.tables[1].TableName.Table)
+	require.Equal(t, "test", w.tables[1].TableName.Schema)
+
+	ddls := w.createTableDDLs(10)
+	require.Len(t, ddls, 2)
+	require.Equal(t, "CREATE TABLE IF NOT EXISTS `test`.`t_0` (`id` BIGINT PRIMARY KEY, "+
+		"`c_0` VARCHAR(16), `c_1` VARCHAR(16), `c_2` VARCHAR(16), `c_3` VARCHAR(16))",
+		ddls[0].Query)
+	require.Equal(t, timodel.ActionCreateTable, ddls[0].Type)
+
+	row := w.nextRow(1, 20)
+	require.Len(t, row.Columns, 5)
+	require.Equal(t, int64(0), row.Columns[0].Value)
+	require.Len(t, row.Columns[1].Value, 16)
+	require.Equal(t, "t_1", row.Table.Table)
+	require.True(t, row.IsInsert())
+	row = w.nextRow(1, 21)
+	require.Equal(t, int64(1), row.Columns[0].Value)
+
+	ddl := w.nextDDL(30)
+	require.Equal(t, "ALTER TABLE `test`.`t_0` COMMENT = 'bench 1'", ddl.Query)
+	ddl = w.nextDDL(31)
+	require.Equal(t, "ALTER TABLE `test`.`t_1` COMMENT = 'bench 2'", ddl.Query)
+}
+
+func TestRunnerBlackHole(t *testing.T) {
+	t.Parallel()
+
+	opts := &options{
+		sinkURI:      "blackhole://",
+		schema:       "test",
+		tables:       2,
+		columns:      2,
+		rowWidth:     16,
+		batchSize:    8,
+		ddlInterval:  100 * time.Millisecond,
+		duration:     500 * time.Millisecond,
+		maxInflight:  64,
+		reportPeriod: time.Hour,
+	}
+	require.NoError(t, opts.validate())
+	r := newRunner(opts, config.GetDefaultReplicaConfig(), &cobra.Command{})
+	report, err := r.run(context.Background())
+	require.NoError(t, err)
+	require.Greater(t, report.generatedRows, int64(0))
+	require.LessOrEqual(t, report.generatedRows-report.flushedRows, int64(opts.maxInflight))
+	require.Greater(t, report.ddls, 0)
+	require.Contains(t, report.String(), "rows/s")
+
+	opts.tables = 0
+	require.Error(t, opts.validate())
+}
@@ -53,8 +53,12 @@ func NewCmdCli() *cobra.Command {
 	// Add subcommands.
 	cmds.AddCommand(newCmdCapture(f))
 	cmds.AddCommand(newCmdChangefeed(f))
+	cmds.AddCommand(newCmdConfig(f))
+	cmds.AddCommand(newCmdDebug(f))
+	cmds.AddCommand(newCmdMonitoring(f))
 	cmds.AddCommand(newCmdProcessor(f))
 	cmds.AddCommand(newCmdTso(f))
+	cmds.AddCommand(newCmdTool())
 	cmds.AddCommand(newCmdUnsafe(f))
 
 	return cmds
@@ -53,6 +53,7 @@ type mockAPIV2Client struct {
 	tso         apiv2client.TsoInterface
 	changefeeds apiv2client.ChangefeedInterface
 	unsafes     apiv2client.UnsafeInterface
+	monitoring  apiv2client.MonitoringInterface
 }
 
 func (f *mockAPIV2Client) Changefeeds() apiv2client.ChangefeedInterface {
@@ -67,6 +68,10 @@ func (f *mockAPIV2Client) Unsafe() apiv2client.UnsafeInterface {
 	return f.unsafes
 }
 
+func (f *mockAPIV2Client) Monitoring() apiv2client.MonitoringInterface {
+	return f.monitoring
+}
+
 type mockFactory struct {
 	factory.Factory
 	captures    *mock.MockCaptureInterface
@@ -77,6 +82,7 @@ type mockFactory struct {
 	changefeedsv2 *v2mock.MockChangefeedInterface
 	tso           *v2mock.MockTsoInterface
 	unsafes       *v2mock.MockUnsafeInterface
+	monitoring    *v2mock.MockMonitoringInterface
 }
 
 func newMockFactory(ctrl *gomock.Controller) *mockFactory {
@@ -87,6 +93,7 @@ func newMockFactory(ctrl *gomock.Controller) *mockFactory {
 	unsafes := v2mock.NewMockUnsafeInterface(ctrl)
 	tso := v2mock.NewMockTsoInterface(ctrl)
 	cfv2 := v2mock.NewMockChangefeedInterface(ctrl)
+	monitoring := v2mock.NewMockMonitoringInterface(ctrl)
 	return &mockFactory{
 		captures:      cps,
 		changefeeds:   cf,
@@ -95,6 +102,7 @@ func newMockFactory(ctrl *gomock.Controller) *mockFactory {
 		changefeedsv2: cfv2,
 		tso:           tso,
 		unsafes:       unsafes,
+		monitoring:    monitoring,
 	}
 }
 
@@ -112,6 +120,7 @@ func (f *mockFactory) APIV2Client() (apiv2client.APIV2Interface, error) {
 		changefeeds: f.changefeedsv2,
 		tso:         f.tso,
 		unsafes:     f.unsafes,
+		monitoring:  f.monitoring,
 	}, nil
 }
 
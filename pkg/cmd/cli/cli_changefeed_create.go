@@ -31,6 +31,7 @@ import (
 	"github.com/pingcap/tiflow/pkg/config"
 	cerror "github.com/pingcap/tiflow/pkg/errors"
 	"github.com/pingcap/tiflow/pkg/filter"
+	"github.com/pingcap/tiflow/pkg/pdutil"
 	"github.com/spf13/cobra"
 	"github.com/tikv/client-go/v2/oracle"
 	"go.uber.org/zap"
@@ -106,6 +107,8 @@ type createChangefeedOptions struct {
 	changefeedID            string
 	disableGCSafePointCheck bool
 	startTs                 uint64
+	startTime               string
+	startTimeZone           string
 	timezone                string
 
 	cfg *config.ReplicaConfig
@@ -125,6 +128,9 @@ func (o *createChangefeedOptions) addFlags(cmd *cobra.Command) {
 	cmd.PersistentFlags().StringVarP(&o.changefeedID, "changefeed-id", "c", "", "Replication task (changefeed) ID")
 	cmd.PersistentFlags().BoolVarP(&o.disableGCSafePointCheck, "disable-gc-check", "", false, "Disable GC safe point check")
 	cmd.PersistentFlags().Uint64Var(&o.startTs, "start-ts", 0, "Start ts of changefeed")
+	cmd.PersistentFlags().StringVar(&o.startTime, "start-time", "",
+		"Start time of changefeed in the layout of \"2006-01-02 15:04:05\", it can't be used with --start-ts")
+	cmd.PersistentFlags().StringVar(&o.startTimeZone, "start-time-zone", "SYSTEM", "Timezone of --start-time")
 	cmd.PersistentFlags().StringVar(&o.timezone, "tz", "SYSTEM", "timezone used when checking sink uri (changefeed timezone is determined by cdc server)")
 	// we don't support specify these flags below when cdc version >= 6.2.0
 	_ = cmd.PersistentFlags().MarkHidden("tz")
@@ -157,19 +163,11 @@ func (o *createChangefeedOptions) completeReplicaCfg(
 			return cerror.WrapError(cerror.ErrSinkURIInvalid, err)
 		}
 
-		protocol := sinkURIParsed.Query().Get(config.ProtocolKey)
-		if protocol != "" {
-			cfg.Sink.Protocol = protocol
-		}
-		for _, fp := range config.ForceEnableOldValueProtocols {
-			if cfg.Sink.Protocol == fp {
-				log.Warn("Attempting to replicate without old value enabled. CDC will enable old value and continue.", zap.String("protocol", cfg.Sink.Protocol))
-				cfg.EnableOldValue = true
-				break
-			}
+		if err := cfg.NegotiateOldValue(sinkURIParsed); err != nil {
+			return err
 		}
 
-		if cfg.ForceReplicate {
+		if !cfg.EnableOldValue && cfg.ForceReplicate {
 			log.Error("if use force replicate, old value feature must be enabled")
 			return cerror.ErrOldValueNotEnabled.GenWithStackByArgs()
 		}
@@ -281,8 +279,15 @@ func (o *createChangefeedOptions) run(ctx context.Context, cmd *cobra.Command) e
 		return err
 	}
 
-	if o.startTs == 0 {
-		o.startTs = oracle.ComposeTS(tso.Timestamp, tso.LogicTime)
+	// The TSO is queried only once, the time passed since then is negligible
+	// when the clock skew is checked.
+	timeService := pdutil.TimeServiceFunc(func(context.Context) (uint64, error) {
+		return oracle.ComposeTS(tso.Timestamp, tso.LogicTime), nil
+	})
+	o.startTs, err = pdutil.ResolveStartTs(ctx, timeService,
+		o.startTs, o.startTime, o.startTimeZone)
+	if err != nil {
+		return err
 	}
 
 	if !o.commonChangefeedOptions.noConfirm {
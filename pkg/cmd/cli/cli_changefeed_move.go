@@ -0,0 +1,250 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"context"
+	"time"
+
+	"github.com/pingcap/errors"
+	v2 "github.com/pingcap/tiflow/cdc/api/v2"
+	"github.com/pingcap/tiflow/cdc/model"
+	apiv2client "github.com/pingcap/tiflow/pkg/api/v2"
+	cmdcontext "github.com/pingcap/tiflow/pkg/cmd/context"
+	"github.com/pingcap/tiflow/pkg/cmd/factory"
+	"github.com/pingcap/tiflow/pkg/cmd/util"
+	"github.com/spf13/cobra"
+	"github.com/tikv/client-go/v2/oracle"
+)
+
+const defaultMovePollInterval = time.Second
+
+// targetClientGetter overrides the addresses of a ClientGetter, so that
+// the target cluster is accessed with the same security settings.
+type targetClientGetter struct {
+	factory.ClientGetter
+	pdAddr     string
+	serverAddr string
+}
+
+// GetPdAddr returns the pd address of the target cluster.
+func (g *targetClientGetter) GetPdAddr() string {
+	return g.pdAddr
+}
+
+// GetServerAddr returns the server address of the target cluster.
+func (g *targetClientGetter) GetServerAddr() string {
+	return g.serverAddr
+}
+
+// moveChangefeedOptions defines flags for the `cli changefeed move` command.
+type moveChangefeedOptions struct {
+	apiClient       apiv2client.APIV2Interface
+	targetAPIClient apiv2client.APIV2Interface
+
+	changefeedID       string
+	targetChangefeedID string
+	targetPdAddr       string
+	targetServerAddr   string
+	handoffTs          uint64
+	handoffLag         time.Duration
+	timeout            time.Duration
+	keepSource         bool
+
+	pollInterval    time.Duration
+	newTargetClient func(getter factory.ClientGetter) (apiv2client.APIV2Interface, error)
+}
+
+// newMoveChangefeedOptions creates new options for the `cli changefeed move` command.
+func newMoveChangefeedOptions() *moveChangefeedOptions {
+	return &moveChangefeedOptions{
+		pollInterval: defaultMovePollInterval,
+		newTargetClient: func(getter factory.ClientGetter) (apiv2client.APIV2Interface, error) {
+			return factory.NewFactory(getter).APIV2Client()
+		},
+	}
+}
+
+// addFlags receives a *cobra.Command reference and binds
+// flags related to template printing to it.
+func (o *moveChangefeedOptions) addFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringVarP(&o.changefeedID, "changefeed-id", "c", "", "Replication task (changefeed) ID")
+	cmd.PersistentFlags().StringVar(&o.targetChangefeedID, "target-changefeed-id", "",
+		"Changefeed ID in the target cluster, the source changefeed ID is used if it is empty")
+	cmd.PersistentFlags().StringVar(&o.targetPdAddr, "target-pd", "",
+		"PD address of the target TiCDC cluster, use ',' to separate multiple PDs")
+	cmd.PersistentFlags().StringVar(&o.targetServerAddr, "target-server", "",
+		"Server address of the target TiCDC cluster")
+	cmd.PersistentFlags().Uint64Var(&o.handoffTs, "handoff-ts", 0,
+		"The ts at which the target cluster takes over the changefeed, "+
+			"current tso plus --handoff-lag is used if it is 0")
+	cmd.PersistentFlags().DurationVar(&o.handoffLag, "handoff-lag", 30*time.Second,
+		"The lag between the current tso and the handoff ts")
+	cmd.PersistentFlags().DurationVar(&o.timeout, "timeout", 10*time.Minute,
+		"The max time to wait for the source changefeed to reach the handoff ts")
+	cmd.PersistentFlags().BoolVar(&o.keepSource, "keep-source", false,
+		"Keep the finished changefeed in the source cluster")
+	_ = cmd.MarkPersistentFlagRequired("changefeed-id")
+}
+
+// complete adapts from the command line args to the data and client required.
+func (o *moveChangefeedOptions) complete(f factory.Factory) error {
+	if (o.targetPdAddr == "") == (o.targetServerAddr == "") {
+		return errors.New("exactly one of --target-pd and --target-server must be specified")
+	}
+	if o.targetChangefeedID == "" {
+		o.targetChangefeedID = o.changefeedID
+	}
+
+	apiClient, err := f.APIV2Client()
+	if err != nil {
+		return err
+	}
+	o.apiClient = apiClient
+
+	targetAPIClient, err := o.newTargetClient(&targetClientGetter{
+		ClientGetter: f,
+		pdAddr:       o.targetPdAddr,
+		serverAddr:   o.targetServerAddr,
+	})
+	if err != nil {
+		return err
+	}
+	o.targetAPIClient = targetAPIClient
+	return nil
+}
+
+// run the `cli changefeed move` command.
+//
+// The handoff is done at a ts boundary: the source changefeed is set to
+// stop at the handoff ts, and the target changefeed starts from it once the
+// source one is finished, so that there is neither gap nor duplicate window.
+func (o *moveChangefeedOptions) run(cmd *cobra.Command) error {
+	ctx := cmdcontext.GetDefaultContext()
+
+	// The sink uri with secrets is required to create the target changefeed.
+	info, err := o.apiClient.Changefeeds().GetInfoWithSecrets(ctx, o.changefeedID)
+	if err != nil {
+		return err
+	}
+	if info.State != model.StateNormal && info.State != model.StateStopped {
+		return errors.Errorf("can not move changefeed %s in state %s",
+			o.changefeedID, info.State)
+	}
+
+	handoffTs := o.handoffTs
+	if handoffTs == 0 {
+		tso, err := o.apiClient.Tso().Query(ctx,
+			&v2.UpstreamConfig{ID: info.UpstreamID})
+		if err != nil {
+			return err
+		}
+		handoffTs = oracle.ComposeTS(tso.Timestamp+o.handoffLag.Milliseconds(), 0)
+	}
+	if info.TargetTs != 0 && handoffTs >= info.TargetTs {
+		return errors.Errorf("handoff ts %d is not less than the target ts %d of changefeed %s",
+			handoffTs, info.TargetTs, o.changefeedID)
+	}
+
+	// Stop the source changefeed at the handoff ts.
+	if info.State == model.StateNormal {
+		if err := o.apiClient.Changefeeds().Pause(ctx, o.changefeedID); err != nil {
+			return err
+		}
+	}
+	if _, err := o.apiClient.Changefeeds().Update(ctx,
+		&v2.ChangefeedConfig{TargetTs: handoffTs}, o.changefeedID); err != nil {
+		return err
+	}
+	if err := o.apiClient.Changefeeds().Resume(ctx,
+		&v2.ResumeChangefeedConfig{}, o.changefeedID); err != nil {
+		return err
+	}
+	cmd.Printf("Waiting for changefeed %s to reach the handoff ts %d\n",
+		o.changefeedID, handoffTs)
+	if err := o.waitFinished(ctx); err != nil {
+		return err
+	}
+
+	// Start the target changefeed from the handoff ts.
+	_, err = o.targetAPIClient.Changefeeds().Create(ctx, &v2.ChangefeedConfig{
+		ID:            o.targetChangefeedID,
+		StartTs:       handoffTs,
+		TargetTs:      info.TargetTs,
+		SinkURI:       info.SinkURI,
+		Engine:        info.Engine,
+		ReplicaConfig: info.Config,
+	})
+	if err != nil {
+		return errors.Annotatef(err,
+			"source changefeed %s is finished at %d, but failed to create the target changefeed",
+			o.changefeedID, handoffTs)
+	}
+
+	if !o.keepSource {
+		if err := o.apiClient.Changefeeds().Delete(ctx, o.changefeedID); err != nil {
+			return err
+		}
+	}
+	cmd.Printf("Move changefeed successfully!\nID: %s\nTargetID: %s\nHandoffTs: %d\n",
+		o.changefeedID, o.targetChangefeedID, handoffTs)
+	return nil
+}
+
+// waitFinished waits until the source changefeed reaches its target ts.
+func (o *moveChangefeedOptions) waitFinished(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, o.timeout)
+	defer cancel()
+	ticker := time.NewTicker(o.pollInterval)
+	defer ticker.Stop()
+	for {
+		info, err := o.apiClient.Changefeeds().GetInfo(ctx, o.changefeedID)
+		if err != nil {
+			return err
+		}
+		switch info.State {
+		case model.StateFinished:
+			return nil
+		case model.StateNormal:
+		default:
+			return errors.Errorf("changefeed %s is %s before reaching the handoff ts",
+				o.changefeedID, info.State)
+		}
+		select {
+		case <-ctx.Done():
+			return errors.Annotatef(ctx.Err(),
+				"changefeed %s does not reach the handoff ts", o.changefeedID)
+		case <-ticker.C:
+		}
+	}
+}
+
+// newCmdMoveChangefeed creates the `cli changefeed move` command.
+func newCmdMoveChangefeed(f factory.Factory) *cobra.Command {
+	o := newMoveChangefeedOptions()
+
+	command := &cobra.Command{
+		Use:   "move",
+		Short: "Move a replication task (changefeed) to another TiCDC cluster",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			util.CheckErr(o.complete(f))
+			util.CheckErr(o.run(cmd))
+		},
+	}
+
+	o.addFlags(command)
+
+	return command
+}
@@ -0,0 +1,133 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	v2 "github.com/pingcap/tiflow/cdc/api/v2"
+	"github.com/pingcap/tiflow/cdc/model"
+	apiv2client "github.com/pingcap/tiflow/pkg/api/v2"
+	v2mock "github.com/pingcap/tiflow/pkg/api/v2/mock"
+	cmdcontext "github.com/pingcap/tiflow/pkg/cmd/context"
+	"github.com/pingcap/tiflow/pkg/cmd/factory"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+	"github.com/tikv/client-go/v2/oracle"
+)
+
+func newTestMoveChangefeedOptions(
+	f *mockFactory, target *v2mock.MockChangefeedInterface,
+) *moveChangefeedOptions {
+	o := newMoveChangefeedOptions()
+	o.changefeedID = "abc"
+	o.targetServerAddr = "127.0.0.1:8301"
+	o.handoffLag = time.Second
+	o.timeout = time.Second
+	o.pollInterval = 10 * time.Millisecond
+	o.newTargetClient = func(getter factory.ClientGetter) (apiv2client.APIV2Interface, error) {
+		if getter.GetServerAddr() != "127.0.0.1:8301" {
+			panic("unexpected target server address")
+		}
+		return &mockAPIV2Client{changefeeds: target}, nil
+	}
+	return o
+}
+
+func TestChangefeedMoveCli(t *testing.T) {
+	cmdcontext.SetDefaultContext(context.Background())
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	f := newMockFactory(ctrl)
+	target := v2mock.NewMockChangefeedInterface(ctrl)
+	o := newTestMoveChangefeedOptions(f, target)
+	require.Nil(t, o.complete(f))
+	require.Equal(t, "abc", o.targetChangefeedID)
+
+	info := &v2.ChangeFeedInfo{
+		ID:         "abc",
+		UpstreamID: 1,
+		SinkURI:    "blackhole://",
+		State:      model.StateNormal,
+		Config:     &v2.ReplicaConfig{CaseSensitive: true},
+	}
+	now := time.Now().UnixMilli()
+	handoffTs := oracle.ComposeTS(now+time.Second.Milliseconds(), 0)
+	gomock.InOrder(
+		f.changefeedsv2.EXPECT().GetInfoWithSecrets(gomock.Any(), "abc").Return(info, nil),
+		f.tso.EXPECT().Query(gomock.Any(), &v2.UpstreamConfig{ID: 1}).
+			Return(&v2.Tso{Timestamp: now}, nil),
+		f.changefeedsv2.EXPECT().Pause(gomock.Any(), "abc").Return(nil),
+		f.changefeedsv2.EXPECT().Update(gomock.Any(),
+			&v2.ChangefeedConfig{TargetTs: handoffTs}, "abc").Return(info, nil),
+		f.changefeedsv2.EXPECT().Resume(gomock.Any(), gomock.Any(), "abc").Return(nil),
+		f.changefeedsv2.EXPECT().GetInfo(gomock.Any(), "abc").
+			Return(&v2.ChangeFeedInfo{State: model.StateNormal}, nil),
+		f.changefeedsv2.EXPECT().GetInfo(gomock.Any(), "abc").
+			Return(&v2.ChangeFeedInfo{State: model.StateFinished}, nil),
+		target.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, cfg *v2.ChangefeedConfig) (*v2.ChangeFeedInfo, error) {
+				require.Equal(t, "abc", cfg.ID)
+				require.Equal(t, handoffTs, cfg.StartTs)
+				require.Equal(t, info.SinkURI, cfg.SinkURI)
+				require.Equal(t, info.Config, cfg.ReplicaConfig)
+				return info, nil
+			}),
+		f.changefeedsv2.EXPECT().Delete(gomock.Any(), "abc").Return(nil),
+	)
+	require.Nil(t, o.run(&cobra.Command{}))
+}
+
+func TestChangefeedMoveCliFailed(t *testing.T) {
+	cmdcontext.SetDefaultContext(context.Background())
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	f := newMockFactory(ctrl)
+	target := v2mock.NewMockChangefeedInterface(ctrl)
+
+	// Exactly one of the target pd and server must be specified.
+	o := newTestMoveChangefeedOptions(f, target)
+	o.targetPdAddr = "http://127.0.0.1:2379"
+	require.NotNil(t, o.complete(f))
+
+	// The handoff ts must be less than the target ts.
+	o = newTestMoveChangefeedOptions(f, target)
+	o.handoffTs = 100
+	require.Nil(t, o.complete(f))
+	f.changefeedsv2.EXPECT().GetInfoWithSecrets(gomock.Any(), "abc").
+		Return(&v2.ChangeFeedInfo{State: model.StateNormal, TargetTs: 100}, nil)
+	require.NotNil(t, o.run(&cobra.Command{}))
+
+	// A failed changefeed can not be moved.
+	f.changefeedsv2.EXPECT().GetInfoWithSecrets(gomock.Any(), "abc").
+		Return(&v2.ChangeFeedInfo{State: model.StateFailed}, nil)
+	require.NotNil(t, o.run(&cobra.Command{}))
+
+	// The target changefeed is not created if the source one fails
+	// before reaching the handoff ts.
+	o.handoffTs = 50
+	gomock.InOrder(
+		f.changefeedsv2.EXPECT().GetInfoWithSecrets(gomock.Any(), "abc").
+			Return(&v2.ChangeFeedInfo{State: model.StateStopped}, nil),
+		f.changefeedsv2.EXPECT().Update(gomock.Any(),
+			&v2.ChangefeedConfig{TargetTs: 50}, "abc").Return(nil, nil),
+		f.changefeedsv2.EXPECT().Resume(gomock.Any(), gomock.Any(), "abc").Return(nil),
+		f.changefeedsv2.EXPECT().GetInfo(gomock.Any(), "abc").
+			Return(&v2.ChangeFeedInfo{State: model.StateError}, nil),
+	)
+	require.NotNil(t, o.run(&cobra.Command{}))
+}
@@ -54,6 +54,7 @@ type queryChangefeedOptions struct {
 	apiClientV2  apiv2client.APIV2Interface
 	changefeedID string
 	simplified   bool
+	showSecrets  bool
 }
 
 // newQueryChangefeedOptions creates new options for the `cli changefeed query` command.
@@ -65,6 +66,9 @@ func newQueryChangefeedOptions() *queryChangefeedOptions {
 // flags related to template printing to it.
 func (o *queryChangefeedOptions) addFlags(cmd *cobra.Command) {
 	cmd.PersistentFlags().BoolVarP(&o.simplified, "simple", "s", false, "Output simplified replication status")
+	cmd.PersistentFlags().BoolVar(&o.showSecrets, "show-secrets", false,
+		"Output the sink uri without masking the secrets, "+
+			"it is only allowed on the owner host or with a client certificate to the owner")
 	cmd.PersistentFlags().StringVarP(&o.changefeedID, "changefeed-id", "c", "", "Replication task (changefeed) ID")
 	_ = cmd.MarkPersistentFlagRequired("changefeed-id")
 }
@@ -105,15 +109,24 @@ func (o *queryChangefeedOptions) run(cmd *cobra.Command) error {
 		return err
 	}
 
-	info, err := o.apiClientV2.Changefeeds().GetInfo(ctx, o.changefeedID)
+	var info *v2.ChangeFeedInfo
+	if o.showSecrets {
+		info, err = o.apiClientV2.Changefeeds().GetInfoWithSecrets(ctx, o.changefeedID)
+	} else {
+		info, err = o.apiClientV2.Changefeeds().GetInfo(ctx, o.changefeedID)
+	}
 	if err != nil && cerror.ErrChangeFeedNotExists.NotEqual(err) {
 		return err
 	}
+	sinkURI := detail.SinkURI
+	if o.showSecrets {
+		sinkURI = info.SinkURI
+	}
 	meta := &cfMeta{
 		UpstreamID:     detail.UpstreamID,
 		Namespace:      detail.Namespace,
 		ID:             detail.ID,
-		SinkURI:        detail.SinkURI,
+		SinkURI:        sinkURI,
 		Config:         info.Config,
 		CreateTime:     detail.CreateTime,
 		StartTs:        detail.StartTs,
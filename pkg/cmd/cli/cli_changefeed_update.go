@@ -123,6 +123,11 @@ func (o *updateChangefeedOptions) run(cmd *cobra.Command) error {
 	}
 
 	changefeedConfig := o.getChangefeedConfig(cmd, newInfo)
+	// The sink uri returned by the server is masked, it must not be
+	// sent back unless it is changed.
+	if newInfo.SinkURI == old.SinkURI {
+		changefeedConfig.SinkURI = ""
+	}
 	info, err := o.apiV2Client.Changefeeds().Update(ctx, changefeedConfig, o.changefeedID)
 	if err != nil {
 		return err
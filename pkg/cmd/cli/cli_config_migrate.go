@@ -0,0 +1,147 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"sort"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/cmd/context"
+	"github.com/pingcap/tiflow/pkg/cmd/factory"
+	"github.com/pingcap/tiflow/pkg/cmd/util"
+	"github.com/pingcap/tiflow/pkg/config"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/etcd"
+	"github.com/spf13/cobra"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+// migrateConfigOptions defines flags for the `cli config migrate` command.
+type migrateConfigOptions struct {
+	clusterID  string
+	dryRun     bool
+	etcdClient *etcd.CDCEtcdClientImpl
+}
+
+// newMigrateConfigOptions creates new migrateConfigOptions
+// for the `cli config migrate` command.
+func newMigrateConfigOptions() *migrateConfigOptions {
+	return &migrateConfigOptions{}
+}
+
+// complete adapts from the command line args to the data and client required.
+func (o *migrateConfigOptions) complete(f factory.Factory) error {
+	etcdClient, err := f.EtcdClient()
+	if err != nil {
+		return err
+	}
+	etcdClient.ClusterID = o.clusterID
+	o.etcdClient = etcdClient
+	return nil
+}
+
+func (o *migrateConfigOptions) addFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&o.clusterID, "cluster-id", "default", "cdc cluster id")
+	cmd.Flags().BoolVar(&o.dryRun, "dry-run", false,
+		"Only show the migrations and the migrated configs without persisting them")
+}
+
+// run runs the `cli config migrate` command.
+func (o *migrateConfigOptions) run(cmd *cobra.Command) error {
+	ctx := context.GetDefaultContext()
+	defer o.etcdClient.Close()
+
+	_, kvs, err := o.etcdClient.GetChangeFeeds(ctx)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	ids := make([]model.ChangeFeedID, 0, len(kvs))
+	for id := range kvs {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return ids[i].ID < ids[j].ID
+	})
+
+	migrated := 0
+	for _, id := range ids {
+		kv := kvs[id]
+		info, applied, err := model.MigrateChangeFeedInfo(kv.Value)
+		if err != nil {
+			return errors.Annotatef(err, "changefeed %s", id.ID)
+		}
+		if len(applied) == 0 {
+			continue
+		}
+		migrated++
+		cmd.Printf("Changefeed %s:\n", id.ID)
+		for _, m := range applied {
+			cmd.Printf("  %s\n", m)
+		}
+		if o.dryRun {
+			if err := util.JSONPrint(cmd, info.Config); err != nil {
+				return errors.Trace(err)
+			}
+			continue
+		}
+
+		value, err := info.Marshal()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		// The changefeed info is only persisted if it is not modified since
+		// it was read, otherwise the modification would be overwritten.
+		key := string(kv.Key)
+		resp, err := o.etcdClient.GetEtcdClient().Txn(ctx,
+			[]clientv3.Cmp{clientv3.Compare(clientv3.ModRevision(key), "=", kv.ModRevision)},
+			[]clientv3.Op{clientv3.OpPut(key, value)}, nil)
+		if err != nil {
+			return cerror.WrapError(cerror.ErrPDEtcdAPIError, err)
+		}
+		if !resp.Succeeded {
+			return errors.Errorf("changefeed %s is modified during the migration, "+
+				"please retry", id.ID)
+		}
+	}
+
+	if o.dryRun {
+		cmd.Printf("%d changefeed configs need to be migrated to version %d\n",
+			migrated, config.CurrentReplicaConfigVersion)
+	} else {
+		cmd.Printf("%d changefeed configs are migrated to version %d\n",
+			migrated, config.CurrentReplicaConfigVersion)
+	}
+	return nil
+}
+
+// newCmdMigrateConfig creates the `cli config migrate` command.
+func newCmdMigrateConfig(f factory.Factory) *cobra.Command {
+	o := newMigrateConfigOptions()
+
+	command := &cobra.Command{
+		Use: "migrate",
+		Short: "Migrate the persisted changefeed configs to the current version. " +
+			"The owner migrates them automatically when it starts",
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			util.CheckErr(o.complete(f))
+			util.CheckErr(o.run(cmd))
+		},
+	}
+
+	o.addFlags(command)
+
+	return command
+}
@@ -0,0 +1,333 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/applier"
+	cmdcontext "github.com/pingcap/tiflow/pkg/cmd/context"
+	"github.com/pingcap/tiflow/pkg/cmd/factory"
+	cmdutil "github.com/pingcap/tiflow/pkg/cmd/util"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/etcd"
+	"github.com/pingcap/tiflow/pkg/orchestrator"
+	orchestratorutil "github.com/pingcap/tiflow/pkg/orchestrator/util"
+	"github.com/pingcap/tiflow/pkg/util"
+	"github.com/spf13/cobra"
+	"github.com/tikv/client-go/v2/oracle"
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	"go.etcd.io/etcd/client/v3/concurrency"
+)
+
+// dumpStateReport is the persisted state of a changefeed dumped by
+// the `cli debug dump-state` command.
+type dumpStateReport struct {
+	ClusterID  string                 `json:"cluster-id"`
+	Namespace  string                 `json:"namespace"`
+	ID         string                 `json:"id"`
+	Owner      string                 `json:"owner,omitempty"`
+	Info       *dumpStateInfo         `json:"info"`
+	Position   *dumpStatePosition     `json:"position,omitempty"`
+	Barriers   *dumpStateBarriers     `json:"barriers,omitempty"`
+	Processors []*dumpStateProcessor  `json:"processors"`
+	Captures   []*capture             `json:"captures"`
+	Redo       *dumpStateRedo         `json:"redo,omitempty"`
+	SortDirs   []*dumpStateSortDirent `json:"sort-dirs,omitempty"`
+}
+
+// dumpStateInfo is the info of a changefeed, the sink URI is masked.
+type dumpStateInfo struct {
+	State    model.FeedState     `json:"state"`
+	SinkURI  string              `json:"sink-uri"`
+	StartTs  uint64              `json:"start-ts"`
+	TargetTs uint64              `json:"target-ts"`
+	Error    *model.RunningError `json:"error,omitempty"`
+}
+
+// dumpStatePosition is the global position of a changefeed.
+type dumpStatePosition struct {
+	CheckpointTs   uint64         `json:"checkpoint-ts"`
+	CheckpointTime model.JSONTime `json:"checkpoint-time"`
+	ResolvedTs     uint64         `json:"resolved-ts"`
+	ResolvedTime   model.JSONTime `json:"resolved-time"`
+}
+
+// dumpStateBarriers are the persisted positions which stop a changefeed
+// from advancing its checkpoint beyond them.
+type dumpStateBarriers struct {
+	TargetTs     uint64             `json:"target-ts,omitempty"`
+	AdminJobType string             `json:"admin-job-type"`
+	Savepoints   []*model.Savepoint `json:"savepoints,omitempty"`
+}
+
+// dumpStateProcessor is the position of a processor of a changefeed, alive
+// is false if its capture is gone.
+type dumpStateProcessor struct {
+	CaptureID       string                  `json:"capture-id"`
+	Alive           bool                    `json:"alive"`
+	CheckpointTs    uint64                  `json:"checkpoint-ts"`
+	ResolvedTs      uint64                  `json:"resolved-ts"`
+	SinkCheckpoints map[string]uint64       `json:"sink-checkpoints,omitempty"`
+	Warnings        []*model.RunningWarning `json:"warnings,omitempty"`
+	Error           *model.RunningError     `json:"error,omitempty"`
+}
+
+// dumpStateRedo is the meta of the redo logs of a changefeed and the DDLs
+// in them which are not applied by the downstream yet.
+type dumpStateRedo struct {
+	CheckpointTs uint64                 `json:"checkpoint-ts"`
+	ResolvedTs   uint64                 `json:"resolved-ts"`
+	PendingDDLs  []*dumpStatePendingDDL `json:"pending-ddls"`
+}
+
+// dumpStatePendingDDL is a DDL read from the redo logs.
+type dumpStatePendingDDL struct {
+	CommitTs uint64 `json:"commit-ts"`
+	Query    string `json:"query"`
+}
+
+// dumpStateSortDirent is a directory under the sort dir, the sorter files
+// are shared by all the changefeeds of a capture.
+type dumpStateSortDirent struct {
+	Path  string `json:"path"`
+	Files int    `json:"files"`
+	Bytes int64  `json:"bytes"`
+}
+
+// dumpStateOptions defines flags for the `cli debug dump-state` command.
+type dumpStateOptions struct {
+	etcdClient *etcd.CDCEtcdClientImpl
+
+	clusterID    string
+	namespace    string
+	changefeedID string
+	sortDir      string
+	redoStorage  string
+	redoTmpDir   string
+}
+
+// newDumpStateOptions creates new options for the `cli debug dump-state` command.
+func newDumpStateOptions() *dumpStateOptions {
+	return &dumpStateOptions{}
+}
+
+// addFlags receives a *cobra.Command reference and binds
+// flags related to template printing to it.
+func (o *dumpStateOptions) addFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringVar(&o.clusterID, "cluster-id", "default", "cdc cluster id")
+	cmd.PersistentFlags().StringVarP(&o.namespace, "namespace", "n", "default", "Replication task (changefeed) Namespace")
+	cmd.PersistentFlags().StringVarP(&o.changefeedID, "changefeed-id", "c", "", "Replication task (changefeed) ID")
+	cmd.PersistentFlags().StringVar(&o.sortDir, "sort-dir", "", "The sort dir of a capture to inspect, it's skipped if empty")
+	cmd.PersistentFlags().StringVar(&o.redoStorage, "redo-storage", "", "The storage URI of the redo logs of the changefeed, they're skipped if empty")
+	cmd.PersistentFlags().StringVar(&o.redoTmpDir, "redo-tmp-dir", "", "The temporary dir to download the redo logs")
+	_ = cmd.MarkPersistentFlagRequired("changefeed-id")
+}
+
+// complete adapts from the command line args to the data and client required.
+func (o *dumpStateOptions) complete(f factory.Factory) error {
+	etcdClient, err := f.EtcdClient()
+	if err != nil {
+		return err
+	}
+	etcdClient.ClusterID = o.clusterID
+	o.etcdClient = etcdClient
+	return nil
+}
+
+// run runs the `cli debug dump-state` command.
+func (o *dumpStateOptions) run(cmd *cobra.Command) error {
+	ctx := cmdcontext.GetDefaultContext()
+	defer o.etcdClient.Close()
+
+	kvs, err := o.etcdClient.GetAllCDCInfo(ctx)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	ownerID, err := o.etcdClient.GetOwnerID(ctx)
+	if err != nil && errors.Cause(err) != concurrency.ErrElectionNoLeader {
+		return errors.Trace(err)
+	}
+
+	report, err := buildDumpStateReport(o.clusterID,
+		model.ChangeFeedID{Namespace: o.namespace, ID: o.changefeedID}, ownerID, kvs)
+	if err != nil {
+		return err
+	}
+
+	if o.redoStorage != "" {
+		ap := applier.NewRedoApplier(&applier.RedoApplierConfig{
+			Storage: o.redoStorage,
+			Dir:     o.redoTmpDir,
+		})
+		checkpointTs, resolvedTs, ddls, err := ap.ReadPendingDDLs(ctx)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		report.Redo = &dumpStateRedo{
+			CheckpointTs: checkpointTs,
+			ResolvedTs:   resolvedTs,
+			PendingDDLs:  make([]*dumpStatePendingDDL, 0, len(ddls)),
+		}
+		for _, ddl := range ddls {
+			report.Redo.PendingDDLs = append(report.Redo.PendingDDLs,
+				&dumpStatePendingDDL{CommitTs: ddl.CommitTs, Query: ddl.Query})
+		}
+	}
+
+	if o.sortDir != "" {
+		report.SortDirs, err = collectSortDirents(o.sortDir)
+		if err != nil {
+			return err
+		}
+	}
+
+	return cmdutil.JSONPrint(cmd, report)
+}
+
+// buildDumpStateReport builds the report of a changefeed from the metadata
+// of the cluster read from etcd.
+func buildDumpStateReport(
+	clusterID string, changefeedID model.ChangeFeedID,
+	ownerID string, kvs []*mvccpb.KeyValue,
+) (*dumpStateReport, error) {
+	state := orchestrator.NewGlobalState(clusterID)
+	for _, kv := range kvs {
+		err := state.Update(orchestratorutil.NewEtcdKeyFromBytes(kv.Key), kv.Value, true)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	cfState, ok := state.Changefeeds[changefeedID]
+	if !ok || cfState.Info == nil {
+		return nil, cerror.ErrChangeFeedNotExists.GenWithStackByArgs(changefeedID.ID)
+	}
+
+	report := &dumpStateReport{
+		ClusterID: clusterID,
+		Namespace: changefeedID.Namespace,
+		ID:        changefeedID.ID,
+		Owner:     ownerID,
+		Info: &dumpStateInfo{
+			State:    cfState.Info.State,
+			SinkURI:  util.MaskSinkURIForLog(cfState.Info.SinkURI),
+			StartTs:  cfState.Info.StartTs,
+			TargetTs: cfState.Info.TargetTs,
+			Error:    cfState.Info.Error,
+		},
+		Processors: make([]*dumpStateProcessor, 0, len(cfState.TaskPositions)),
+		Captures:   make([]*capture, 0, len(state.Captures)),
+	}
+	if status := cfState.Status; status != nil {
+		report.Position = &dumpStatePosition{
+			CheckpointTs:   status.CheckpointTs,
+			CheckpointTime: model.JSONTime(oracle.GetTimeFromTS(status.CheckpointTs)),
+			ResolvedTs:     status.ResolvedTs,
+			ResolvedTime:   model.JSONTime(oracle.GetTimeFromTS(status.ResolvedTs)),
+		}
+		report.Barriers = &dumpStateBarriers{
+			TargetTs:     cfState.Info.TargetTs,
+			AdminJobType: status.AdminJobType.String(),
+		}
+		for _, sp := range status.Savepoints {
+			if !sp.Done {
+				report.Barriers.Savepoints = append(report.Barriers.Savepoints, sp)
+			}
+		}
+	}
+	for captureID, position := range cfState.TaskPositions {
+		_, alive := state.Captures[captureID]
+		report.Processors = append(report.Processors, &dumpStateProcessor{
+			CaptureID:       captureID,
+			Alive:           alive,
+			CheckpointTs:    position.CheckPointTs,
+			ResolvedTs:      position.ResolvedTs,
+			SinkCheckpoints: position.SinkCheckpoints,
+			Warnings:        position.Warnings,
+			Error:           position.Error,
+		})
+	}
+	sort.Slice(report.Processors, func(i, j int) bool {
+		return report.Processors[i].CaptureID < report.Processors[j].CaptureID
+	})
+	for captureID, info := range state.Captures {
+		report.Captures = append(report.Captures, &capture{
+			ID:            captureID,
+			IsOwner:       captureID == ownerID,
+			AdvertiseAddr: info.AdvertiseAddr,
+			ClusterID:     clusterID,
+		})
+	}
+	sort.Slice(report.Captures, func(i, j int) bool {
+		return report.Captures[i].ID < report.Captures[j].ID
+	})
+	return report, nil
+}
+
+// collectSortDirents returns the number of files and their total size of
+// each directory in the sort dir, the files in the sort dir itself are
+// counted in the entry of the sort dir.
+func collectSortDirents(sortDir string) ([]*dumpStateSortDirent, error) {
+	dirents := make(map[string]*dumpStateSortDirent)
+	err := filepath.Walk(sortDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		dir := filepath.Dir(path)
+		dirent, ok := dirents[dir]
+		if !ok {
+			dirent = &dumpStateSortDirent{Path: dir}
+			dirents[dir] = dirent
+		}
+		dirent.Files++
+		dirent.Bytes += info.Size()
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]*dumpStateSortDirent, 0, len(dirents))
+	for _, dirent := range dirents {
+		result = append(result, dirent)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Path < result[j].Path
+	})
+	return result, nil
+}
+
+// newCmdDumpState creates the `cli debug dump-state` command.
+func newCmdDumpState(f factory.Factory) *cobra.Command {
+	o := newDumpStateOptions()
+
+	command := &cobra.Command{
+		Use:   "dump-state",
+		Short: "Dump the persisted state of a changefeed without a running owner",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.complete(f))
+			cmdutil.CheckErr(o.run(cmd))
+		},
+	}
+	o.addFlags(command)
+
+	return command
+}
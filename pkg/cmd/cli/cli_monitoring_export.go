@@ -0,0 +1,115 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"os"
+
+	"github.com/pingcap/errors"
+	apiv2client "github.com/pingcap/tiflow/pkg/api/v2"
+	cmdcontext "github.com/pingcap/tiflow/pkg/cmd/context"
+	"github.com/pingcap/tiflow/pkg/cmd/factory"
+	"github.com/pingcap/tiflow/pkg/cmd/util"
+	"github.com/spf13/cobra"
+)
+
+const (
+	monitoringGrafanaDashboard = "grafana-dashboard"
+	monitoringAlertRules       = "alert-rules"
+)
+
+// exportMonitoringOptions defines flags for the `cli monitoring` sub commands.
+type exportMonitoringOptions struct {
+	apiClient apiv2client.APIV2Interface
+
+	kind   string
+	output string
+}
+
+// newExportMonitoringOptions creates new options for the `cli monitoring`
+// sub commands.
+func newExportMonitoringOptions(kind string) *exportMonitoringOptions {
+	return &exportMonitoringOptions{kind: kind}
+}
+
+// addFlags receives a *cobra.Command reference and binds
+// flags related to template printing to it.
+func (o *exportMonitoringOptions) addFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringVarP(&o.output, "output", "o", "",
+		"The file to write into, it's printed to stdout if not specified")
+}
+
+// complete adapts from the command line args to the data and client required.
+func (o *exportMonitoringOptions) complete(f factory.Factory) error {
+	apiClient, err := f.APIV2Client()
+	if err != nil {
+		return err
+	}
+	o.apiClient = apiClient
+	return nil
+}
+
+// run the `cli monitoring` sub commands.
+func (o *exportMonitoringOptions) run(cmd *cobra.Command) error {
+	ctx := cmdcontext.GetDefaultContext()
+
+	var (
+		data []byte
+		err  error
+	)
+	switch o.kind {
+	case monitoringGrafanaDashboard:
+		data, err = o.apiClient.Monitoring().GrafanaDashboard(ctx)
+	case monitoringAlertRules:
+		data, err = o.apiClient.Monitoring().AlertRules(ctx)
+	default:
+		return errors.Errorf("unknown monitoring configuration %s", o.kind)
+	}
+	if err != nil {
+		return err
+	}
+
+	if o.output == "" {
+		cmd.Println(string(data))
+		return nil
+	}
+	if err := os.WriteFile(o.output, data, 0o644); err != nil {
+		return errors.Trace(err)
+	}
+	cmd.Printf("%s is written into %s\n", o.kind, o.output)
+	return nil
+}
+
+// newCmdExportMonitoring creates the `cli monitoring grafana-dashboard` and
+// `cli monitoring alert-rules` commands.
+func newCmdExportMonitoring(f factory.Factory, kind string) *cobra.Command {
+	o := newExportMonitoringOptions(kind)
+
+	short := "Export the Grafana dashboard json of the server"
+	if kind == monitoringAlertRules {
+		short = "Export the Prometheus alert rules yaml of the server"
+	}
+	command := &cobra.Command{
+		Use:   kind,
+		Short: short,
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			util.CheckErr(o.complete(f))
+			util.CheckErr(o.run(cmd))
+		},
+	}
+	o.addFlags(command)
+
+	return command
+}
@@ -0,0 +1,49 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pingcap/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportMonitoringCli(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	f := newMockFactory(ctrl)
+
+	cmd := newCmdExportMonitoring(f, monitoringGrafanaDashboard)
+	os.Args = []string{monitoringGrafanaDashboard}
+	f.monitoring.EXPECT().GrafanaDashboard(gomock.Any()).Return([]byte(`{"panels":[]}`), nil)
+	require.Nil(t, cmd.Execute())
+
+	output := filepath.Join(t.TempDir(), "ticdc.rules.yml")
+	cmd = newCmdExportMonitoring(f, monitoringAlertRules)
+	os.Args = []string{monitoringAlertRules, "--output=" + output}
+	f.monitoring.EXPECT().AlertRules(gomock.Any()).Return([]byte("groups:\n"), nil)
+	require.Nil(t, cmd.Execute())
+	data, err := os.ReadFile(output)
+	require.Nil(t, err)
+	require.Equal(t, "groups:\n", string(data))
+
+	o := newExportMonitoringOptions(monitoringAlertRules)
+	require.Nil(t, o.complete(f))
+	f.monitoring.EXPECT().AlertRules(gomock.Any()).Return(nil, errors.New("test"))
+	require.NotNil(t, o.run(cmd))
+}
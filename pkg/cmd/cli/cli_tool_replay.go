@@ -0,0 +1,222 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/Shopify/sarama"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/br/pkg/storage"
+	"github.com/pingcap/tiflow/cdc/sinkv2/eventsink/tee"
+	cmdcontext "github.com/pingcap/tiflow/pkg/cmd/context"
+	"github.com/pingcap/tiflow/pkg/cmd/util"
+	"github.com/pingcap/tiflow/pkg/compression"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/sink"
+	"github.com/pingcap/tiflow/pkg/sink/kafka"
+	putil "github.com/pingcap/tiflow/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+// replayOptions defines flags for the `cli tool replay` command.
+type replayOptions struct {
+	archiveURI string
+	sinkURI    string
+	topic      string
+	fromTs     uint64
+	toTs       uint64
+}
+
+// newReplayOptions creates new replayOptions for the `cli tool replay` command.
+func newReplayOptions() *replayOptions {
+	return &replayOptions{}
+}
+
+// addFlags receives a *cobra.Command reference and binds
+// the flags of the replay command to it.
+func (o *replayOptions) addFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringVar(&o.archiveURI, "archive-uri", "",
+		"The URI of the archive, which is the tee-sink-uri of the changefeed")
+	cmd.PersistentFlags().StringVar(&o.sinkURI, "sink-uri", "",
+		"The URI of the Kafka cluster which the archived messages are re-produced to")
+	cmd.PersistentFlags().StringVar(&o.topic, "topic", "",
+		"The topic which the archived messages are re-produced to, "+
+			"the topics of the archived messages are used if it's not specified")
+	cmd.PersistentFlags().Uint64Var(&o.fromTs, "from-ts", 0,
+		"Replay the messages carrying the events whose commit ts is not less than it")
+	cmd.PersistentFlags().Uint64Var(&o.toTs, "to-ts", 0,
+		"Replay the messages carrying the events whose commit ts is not greater than it")
+	// the possible error returned from MarkFlagRequired is `no such flag`
+	cmd.MarkPersistentFlagRequired("archive-uri") //nolint:errcheck
+	cmd.MarkPersistentFlagRequired("sink-uri")    //nolint:errcheck
+	cmd.MarkPersistentFlagRequired("to-ts")       //nolint:errcheck
+}
+
+// validate checks that the provided replay options are specified.
+func (o *replayOptions) validate() error {
+	if o.fromTs > o.toTs {
+		return errors.Errorf("from-ts (%d) must not be greater than to-ts (%d)",
+			o.fromTs, o.toTs)
+	}
+
+	archiveURI, err := url.Parse(o.archiveURI)
+	if err != nil {
+		return cerror.WrapError(cerror.ErrSinkURIInvalid, err)
+	}
+	if !sink.IsStorageScheme(archiveURI.Scheme) {
+		return errors.Errorf("the scheme of archive-uri must be a storage scheme, "+
+			"but got %s", archiveURI.Scheme)
+	}
+
+	sinkURI, err := url.Parse(o.sinkURI)
+	if err != nil {
+		return cerror.WrapError(cerror.ErrSinkURIInvalid, err)
+	}
+	if !sink.IsMQScheme(sinkURI.Scheme) {
+		return errors.Errorf("the scheme of sink-uri must be kafka, "+
+			"but got %s", sinkURI.Scheme)
+	}
+	return nil
+}
+
+// run runs the `cli tool replay` command.
+func (o *replayOptions) run(cmd *cobra.Command) error {
+	ctx := cmdcontext.GetDefaultContext()
+
+	archive, err := putil.GetExternalStorageFromURI(ctx, o.archiveURI)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	manifest, err := tee.ReadManifest(ctx, archive)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	cmd.Printf("Replay the archive of changefeed %s/%s, "+
+		"whose messages are encoded by the protocol %s\n",
+		manifest.Namespace, manifest.Changefeed, manifest.Protocol)
+	dropped, err := tee.Dropped(ctx, archive, o.fromTs, o.toTs)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, r := range dropped {
+		cmd.Printf("Warning: %d messages in [%d, %d] were dropped by the archive "+
+			"and can't be replayed\n", r.Messages, r.MinTs, r.MaxTs)
+	}
+
+	sinkURI, err := url.Parse(o.sinkURI)
+	if err != nil {
+		return cerror.WrapError(cerror.ErrSinkURIInvalid, err)
+	}
+	options := kafka.NewOptions()
+	if err := options.Apply(sinkURI); err != nil {
+		return cerror.WrapError(cerror.ErrKafkaInvalidConfig, err)
+	}
+	saramaConfig, err := kafka.NewSaramaConfig(ctx, options)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	client, err := kafka.NewSaramaClient(options.BrokerEndpoints, saramaConfig)
+	if err != nil {
+		return cerror.WrapError(cerror.ErrKafkaNewSaramaProducer, err)
+	}
+	defer client.Close()
+	producer, err := client.SyncProducer()
+	if err != nil {
+		return cerror.WrapError(cerror.ErrKafkaNewSaramaProducer, err)
+	}
+	defer producer.Close()
+
+	partitions := make(map[string]int32)
+	partitionNum := func(topic string) (int32, error) {
+		if num, ok := partitions[topic]; ok {
+			return num, nil
+		}
+		ids, err := client.Partitions(topic)
+		if err != nil {
+			return 0, cerror.WrapError(cerror.ErrKafkaNewSaramaProducer, err)
+		}
+		partitions[topic] = int32(len(ids))
+		return partitions[topic], nil
+	}
+	count, err := replayArchive(ctx, archive, producer,
+		kafka.PayloadCodec(options.Compression), o.topic, o.fromTs, o.toTs, partitionNum)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	cmd.Printf("Replayed %d messages\n", count)
+	return nil
+}
+
+// replayArchive re-produces the archived messages which carry the events
+// committed in [fromTs, toTs] as they were emitted, and returns the number
+// of the messages. The messages are re-produced to the topic if it's not
+// empty, and to the partitions they were emitted to modulo the number of
+// the partitions of the topic. The values are compressed by the codec if
+// it's not nil, see kafka.PayloadCodec.
+func replayArchive(
+	ctx context.Context, archive storage.ExternalStorage,
+	producer kafka.SyncProducer, codec compression.Codec,
+	topic string, fromTs, toTs uint64,
+	partitionNum func(topic string) (int32, error),
+) (int, error) {
+	count := 0
+	err := tee.Replay(ctx, archive, fromTs, toTs, func(record *tee.Record) error {
+		target := topic
+		if target == "" {
+			target = record.Topic
+		}
+		num, err := partitionNum(target)
+		if err != nil {
+			return err
+		}
+		headers := make([]sarama.RecordHeader, 0, len(record.Headers))
+		for _, header := range record.Headers {
+			headers = append(headers, sarama.RecordHeader{
+				Key:   []byte(header.Key),
+				Value: header.Value,
+			})
+		}
+		value, headers, err := kafka.CompressPayload(codec, record.Value, headers)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		count++
+		if record.Broadcast {
+			return producer.SendMessages(target, num, record.Key, value, headers)
+		}
+		return producer.SendMessage(target, record.Partition%num, record.Key, value, headers)
+	})
+	return count, err
+}
+
+// newCmdReplay creates the `cli tool replay` command.
+func newCmdReplay() *cobra.Command {
+	o := newReplayOptions()
+
+	command := &cobra.Command{
+		Use:   "replay",
+		Short: "Re-produce the messages archived by a tee sink to Kafka as they were emitted",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			util.CheckErr(o.validate())
+			util.CheckErr(o.run(cmd))
+		},
+	}
+
+	o.addFlags(command)
+
+	return command
+}
@@ -0,0 +1,126 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sink/codec/common"
+	"github.com/pingcap/tiflow/cdc/sinkv2/eventsink/tee"
+	"github.com/pingcap/tiflow/pkg/config"
+	putil "github.com/pingcap/tiflow/pkg/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplayOptionsValidate(t *testing.T) {
+	t.Parallel()
+
+	o := newReplayOptions()
+	o.archiveURI = "s3://bucket/archive"
+	o.sinkURI = "kafka://127.0.0.1:9092/topic"
+	o.fromTs = 100
+	o.toTs = 200
+	require.NoError(t, o.validate())
+
+	o.fromTs = 300
+	require.Regexp(t, ".*must not be greater than to-ts.*", o.validate())
+	o.fromTs = 100
+
+	o.archiveURI = "kafka://127.0.0.1:9092/topic"
+	require.Regexp(t, ".*must be a storage scheme.*", o.validate())
+	o.archiveURI = "s3://bucket/archive"
+
+	o.sinkURI = "mysql://127.0.0.1:3306/"
+	require.Regexp(t, ".*must be kafka.*", o.validate())
+}
+
+// recordingProducer records the messages produced.
+type recordingProducer struct {
+	messages []string
+}
+
+func (p *recordingProducer) SendMessage(topic string,
+	partitionNum int32, key []byte, value []byte, headers []sarama.RecordHeader,
+) error {
+	p.messages = append(p.messages, fmt.Sprintf("%s/%d:%s:%s:%d",
+		topic, partitionNum, key, value, len(headers)))
+	return nil
+}
+
+func (p *recordingProducer) SendMessages(topic string,
+	partitionNum int32, key []byte, value []byte, headers []sarama.RecordHeader,
+) error {
+	p.messages = append(p.messages, fmt.Sprintf("%s/*%d:%s:%s:%d",
+		topic, partitionNum, key, value, len(headers)))
+	return nil
+}
+
+func (p *recordingProducer) Close() error {
+	return nil
+}
+
+func TestReplayArchive(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	uri := "file://" + t.TempDir()
+	changefeedID := model.DefaultChangeFeedID("test")
+	dml, err := tee.NewArchive(ctx, uri, "dml", changefeedID, config.ProtocolAvro)
+	require.NoError(t, err)
+	ddl, err := tee.NewArchive(ctx, uri, "ddl", changefeedID, config.ProtocolAvro)
+	require.NoError(t, err)
+	for i, ts := range []uint64{100, 120, 140} {
+		message := &common.Message{
+			Key:     []byte(fmt.Sprintf("k%d", ts)),
+			Value:   []byte(fmt.Sprintf("v%d", ts)),
+			Type:    model.MessageTypeRow,
+			Headers: []common.MessageHeader{{Key: "h", Value: []byte("v")}},
+		}
+		dml.Track("t1", int32(i+2), message, ts, ts+5)
+		message.Callback()
+	}
+	err = ddl.Append(ctx, "t1", 0, true, &common.Message{
+		Value: []byte("ddl"),
+		Ts:    130,
+		Type:  model.MessageTypeDDL,
+	})
+	require.NoError(t, err)
+	require.NoError(t, dml.Close())
+	require.NoError(t, ddl.Close())
+
+	archive, err := putil.GetExternalStorageFromURI(ctx, uri)
+	require.NoError(t, err)
+	partitionNum := func(topic string) (int32, error) {
+		return 3, nil
+	}
+	producer := &recordingProducer{}
+	count, err := replayArchive(ctx, archive, producer, nil, "", 110, 130, partitionNum)
+	require.NoError(t, err)
+	require.Equal(t, 2, count)
+	// The record of [100, 105] is skipped, and the partitions of the rest
+	// are kept modulo the number of the partitions.
+	require.Equal(t, []string{"t1/0:k120:v120:1", "t1/*3::ddl:0"}, producer.messages)
+
+	producer = &recordingProducer{}
+	count, err = replayArchive(ctx, archive, producer, nil, "t2", 0, 200, partitionNum)
+	require.NoError(t, err)
+	require.Equal(t, 4, count)
+	require.Equal(t, []string{
+		"t2/2:k100:v100:1", "t2/0:k120:v120:1", "t2/*3::ddl:0", "t2/1:k140:v140:1",
+	}, producer.messages)
+}
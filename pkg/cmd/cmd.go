@@ -16,6 +16,7 @@ package cmd
 import (
 	"os"
 
+	"github.com/pingcap/tiflow/pkg/cmd/bench"
 	"github.com/pingcap/tiflow/pkg/cmd/cli"
 	"github.com/pingcap/tiflow/pkg/cmd/redo"
 	"github.com/pingcap/tiflow/pkg/cmd/server"
@@ -46,6 +47,7 @@ func Run() {
 	cmd.AddCommand(cli.NewCmdCli())
 	cmd.AddCommand(version.NewCmdVersion())
 	cmd.AddCommand(redo.NewCmdRedo())
+	cmd.AddCommand(bench.NewCmdBench())
 
 	if err := cmd.Execute(); err != nil {
 		cmd.PrintErrln(err)
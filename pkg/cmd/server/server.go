@@ -144,7 +144,7 @@ func (o *options) run(cmd *cobra.Command) error {
 
 	util.LogHTTPProxies()
 	server.RecordGoRuntimeSettings()
-	server, err := server.New(strings.Split(o.serverPdAddr, ","))
+	server, err := server.New(strings.Split(o.serverPdAddr, ","), o.serverConfigFilePath)
 	if err != nil {
 		log.Error("create cdc server failed", zap.Error(err))
 		return errors.Trace(err)
@@ -217,6 +217,8 @@ func TestParseCfg(t *testing.T) {
 			},
 			EnableNewSink: true,
 		},
+		Election:  config.NewDefaultElectionConfig(),
+		GCTuner:   config.NewDefaultGCTunerConfig(),
 		ClusterID: "default",
 	}, o.serverConfig)
 }
@@ -378,6 +380,8 @@ check-balance-interval = "10s"
 			},
 			EnableNewSink: true,
 		},
+		Election:  config.NewDefaultElectionConfig(),
+		GCTuner:   config.NewDefaultGCTunerConfig(),
 		ClusterID: "default",
 	}, o.serverConfig)
 }
@@ -529,6 +533,8 @@ cert-allowed-cn = ["dd","ee"]
 			},
 			EnableNewSink: true,
 		},
+		Election:  config.NewDefaultElectionConfig(),
+		GCTuner:   config.NewDefaultGCTunerConfig(),
 		ClusterID: "default",
 	}, o.serverConfig)
 }
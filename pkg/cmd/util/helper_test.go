@@ -222,6 +222,10 @@ func TestAndWriteStorageSinkTOML(t *testing.T) {
 			NullString:      "\\N",
 			IncludeCommitTs: false,
 		},
+		ParquetConfig: &config.ParquetConfig{
+			RowGroupSize: 128 * 1024 * 1024,
+			Compression:  "snappy",
+		},
 	}, cfg.Sink)
 }
 
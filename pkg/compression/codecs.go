@@ -0,0 +1,140 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compression
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/klauspost/compress/gzip"
+	"github.com/klauspost/compress/snappy"
+	"github.com/klauspost/compress/zstd"
+	"github.com/pierrec/lz4/v4"
+	"github.com/pingcap/errors"
+)
+
+func init() {
+	Register(noneCodec{})
+	Register(gzipCodec{})
+	Register(snappyCodec{})
+	Register(lz4Codec{})
+	Register(newZSTDCodec())
+}
+
+type noneCodec struct{}
+
+func (noneCodec) Name() string       { return None }
+func (noneCodec) Extension() string  { return "" }
+func (noneCodec) KafkaCodecID() int8 { return 0 }
+
+func (noneCodec) Compress(data []byte) ([]byte, error) {
+	return data, nil
+}
+
+func (noneCodec) Decompress(data []byte) ([]byte, error) {
+	return data, nil
+}
+
+type gzipCodec struct{}
+
+func (gzipCodec) Name() string       { return GZIP }
+func (gzipCodec) Extension() string  { return ".gz" }
+func (gzipCodec) KafkaCodecID() int8 { return 1 }
+
+func (gzipCodec) Compress(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := writeAndClose(gzip.NewWriter(&buf), data); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (gzipCodec) Decompress(data []byte) ([]byte, error) {
+	r, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer r.Close()
+	decompressed, err := io.ReadAll(r)
+	return decompressed, errors.Trace(err)
+}
+
+// snappyCodec uses the snappy block format, which is the same as the Kafka
+// producer uses for the messages.
+type snappyCodec struct{}
+
+func (snappyCodec) Name() string       { return Snappy }
+func (snappyCodec) Extension() string  { return ".snappy" }
+func (snappyCodec) KafkaCodecID() int8 { return 2 }
+
+func (snappyCodec) Compress(data []byte) ([]byte, error) {
+	return snappy.Encode(nil, data), nil
+}
+
+func (snappyCodec) Decompress(data []byte) ([]byte, error) {
+	decompressed, err := snappy.Decode(nil, data)
+	return decompressed, errors.Trace(err)
+}
+
+type lz4Codec struct{}
+
+func (lz4Codec) Name() string       { return LZ4 }
+func (lz4Codec) Extension() string  { return ".lz4" }
+func (lz4Codec) KafkaCodecID() int8 { return 3 }
+
+func (lz4Codec) Compress(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := writeAndClose(lz4.NewWriter(&buf), data); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (lz4Codec) Decompress(data []byte) ([]byte, error) {
+	decompressed, err := io.ReadAll(lz4.NewReader(bytes.NewReader(data)))
+	return decompressed, errors.Trace(err)
+}
+
+// zstdCodec shares the encoder and the decoder since they're expensive to
+// create, EncodeAll and DecodeAll can be called concurrently.
+type zstdCodec struct {
+	encoder *zstd.Encoder
+	decoder *zstd.Decoder
+}
+
+func newZSTDCodec() *zstdCodec {
+	encoder, _ := zstd.NewWriter(nil)
+	decoder, _ := zstd.NewReader(nil)
+	return &zstdCodec{encoder: encoder, decoder: decoder}
+}
+
+func (*zstdCodec) Name() string       { return ZSTD }
+func (*zstdCodec) Extension() string  { return ".zst" }
+func (*zstdCodec) KafkaCodecID() int8 { return 4 }
+
+func (c *zstdCodec) Compress(data []byte) ([]byte, error) {
+	return c.encoder.EncodeAll(data, nil), nil
+}
+
+func (c *zstdCodec) Decompress(data []byte) ([]byte, error) {
+	decompressed, err := c.decoder.DecodeAll(data, nil)
+	return decompressed, errors.Trace(err)
+}
+
+func writeAndClose(w io.WriteCloser, data []byte) error {
+	if _, err := w.Write(data); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(w.Close())
+}
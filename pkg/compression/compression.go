@@ -0,0 +1,181 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compression
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+)
+
+const (
+	// None means the data is not compressed.
+	None = "none"
+	// GZIP means the data is compressed by gzip.
+	GZIP = "gzip"
+	// Snappy means the data is compressed by snappy.
+	Snappy = "snappy"
+	// LZ4 means the data is compressed by lz4.
+	LZ4 = "lz4"
+	// ZSTD means the data is compressed by zstd.
+	ZSTD = "zstd"
+)
+
+const (
+	// ComponentKafka is the component label of the Kafka producer payloads.
+	ComponentKafka = "kafka"
+	// ComponentStorageSink is the component label of the storage sink files.
+	ComponentStorageSink = "storage_sink"
+	// ComponentRedo is the component label of the redo log files.
+	ComponentRedo = "redo"
+)
+
+// Codec is a compression algorithm, it must be safe for concurrent use.
+type Codec interface {
+	// Name is the name of the codec used in the configurations.
+	Name() string
+	// Extension is the file name extension of the compressed files, it's
+	// empty if the codec doesn't compress the data.
+	Extension() string
+	// Compress compresses the data.
+	Compress(data []byte) ([]byte, error)
+	// Decompress decompresses the data compressed by Compress.
+	Decompress(data []byte) ([]byte, error)
+}
+
+// KafkaCodec is a Codec supported by the Kafka protocol, the record batches
+// of the Kafka producer are compressed by the Kafka client with it, so that
+// any Kafka consumer can decompress them.
+type KafkaCodec interface {
+	Codec
+	// KafkaCodecID is the id of the codec in the attributes of the Kafka
+	// record batches.
+	KafkaCodecID() int8
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[string]Codec)
+)
+
+// Register registers a codec by its name, it panics if a codec of the same
+// name is registered.
+func Register(codec Codec) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	name := strings.ToLower(codec.Name())
+	if _, ok := registry[name]; ok {
+		panic("compression codec " + name + " is registered twice")
+	}
+	registry[name] = codec
+}
+
+// Get returns the codec of the name, the name is case-insensitive and an
+// empty name means None.
+func Get(name string) (Codec, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		name = None
+	}
+
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	codec, ok := registry[name]
+	if !ok {
+		return nil, cerror.ErrCompressionUnsupported.GenWithStackByArgs(name, supported())
+	}
+	return codec, nil
+}
+
+// Supported returns the sorted names of the registered codecs.
+func Supported() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	return supported()
+}
+
+func supported() []string {
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// FromFileName returns the codec of a file by its extension and the file
+// name without the extension, the codec is nil if the file isn't compressed
+// by any registered codec.
+func FromFileName(name string) (Codec, string) {
+	ext := filepath.Ext(name)
+	if ext == "" {
+		return nil, name
+	}
+
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	for _, codec := range registry {
+		if codec.Extension() == ext {
+			return codec, strings.TrimSuffix(name, ext)
+		}
+	}
+	return nil, name
+}
+
+// Compress compresses the data of the component with the codec, the
+// compression ratio and the time cost are observed by the metrics.
+func Compress(component string, codec Codec, data []byte) ([]byte, error) {
+	if codec.Name() == None {
+		return data, nil
+	}
+	start := time.Now()
+	compressed, err := codec.Compress(data)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrCompressionFailed, err, codec.Name())
+	}
+	compressDuration.WithLabelValues(component, codec.Name(), "compress").
+		Observe(time.Since(start).Seconds())
+	if len(data) > 0 {
+		ObserveRatio(component, codec.Name(), float64(len(compressed))/float64(len(data)))
+	}
+	return compressed, nil
+}
+
+// ObserveRatio observes the compression ratio of the data compressed out of
+// this package, such as the Kafka record batches compressed by the Kafka
+// client.
+func ObserveRatio(component string, codec string, ratio float64) {
+	compressRatio.WithLabelValues(component, codec).Observe(ratio)
+}
+
+// Decompress decompresses the data of the component with the codec, the
+// time cost is observed by the metrics.
+func Decompress(component string, codec Codec, data []byte) ([]byte, error) {
+	if codec.Name() == None {
+		return data, nil
+	}
+	start := time.Now()
+	decompressed, err := codec.Decompress(data)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrCompressionFailed, err, codec.Name())
+	}
+	compressDuration.WithLabelValues(component, codec.Name(), "decompress").
+		Observe(time.Since(start).Seconds())
+	return decompressed, nil
+}
@@ -0,0 +1,106 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compression
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGet(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, []string{GZIP, LZ4, None, Snappy, ZSTD}, Supported())
+	for _, name := range []string{"", " NONE "} {
+		codec, err := Get(name)
+		require.NoError(t, err)
+		require.Equal(t, None, codec.Name())
+	}
+	codec, err := Get("Zstd")
+	require.NoError(t, err)
+	require.Equal(t, ZSTD, codec.Name())
+	_, err = Get("brotli")
+	require.Regexp(t, "compression codec brotli is not supported", err)
+}
+
+func TestCodecs(t *testing.T) {
+	t.Parallel()
+
+	data := bytes.Repeat([]byte("compressible data "), 1024)
+	for _, name := range Supported() {
+		codec, err := Get(name)
+		require.NoError(t, err)
+		compressed, err := Compress(ComponentStorageSink, codec, data)
+		require.NoError(t, err)
+		if name == None {
+			require.Equal(t, data, compressed)
+		} else {
+			require.Less(t, len(compressed), len(data)/10, name)
+		}
+		decompressed, err := Decompress(ComponentStorageSink, codec, compressed)
+		require.NoError(t, err)
+		require.Equal(t, data, decompressed, name)
+	}
+
+	codec, err := Get(GZIP)
+	require.NoError(t, err)
+	_, err = Decompress(ComponentRedo, codec, []byte("not gzip"))
+	require.Regexp(t, "ErrCompressionFailed", err)
+}
+
+func TestFromFileName(t *testing.T) {
+	t.Parallel()
+
+	for name, expected := range map[string]struct {
+		codec   string
+		trimmed string
+	}{
+		"CDC000001.json.gz":     {GZIP, "CDC000001.json"},
+		"CDC000001.csv.zst":     {ZSTD, "CDC000001.csv"},
+		"CDC000001.csv.lz4":     {LZ4, "CDC000001.csv"},
+		"CDC000001.json.snappy": {Snappy, "CDC000001.json"},
+	} {
+		codec, trimmed := FromFileName(name)
+		require.NotNil(t, codec, name)
+		require.Equal(t, expected.codec, codec.Name())
+		require.Equal(t, expected.trimmed, trimmed)
+	}
+	for _, name := range []string{"CDC000001.json", "CDC000001", "meta"} {
+		codec, trimmed := FromFileName(name)
+		require.Nil(t, codec)
+		require.Equal(t, name, trimmed)
+	}
+}
+
+type testCodec struct {
+	noneCodec
+}
+
+func (testCodec) Name() string { return "test" }
+
+func TestRegister(t *testing.T) {
+	Register(testCodec{})
+	defer func() {
+		registryMu.Lock()
+		delete(registry, "test")
+		registryMu.Unlock()
+	}()
+
+	codec, err := Get("TEST")
+	require.NoError(t, err)
+	require.Equal(t, "test", codec.Name())
+	require.Panics(t, func() { Register(testCodec{}) })
+}
@@ -0,0 +1,42 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compression
+
+import "github.com/prometheus/client_golang/prometheus"
+
+var (
+	compressRatio = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "ticdc",
+			Subsystem: "compression",
+			Name:      "ratio",
+			Help:      "Bucketed histogram of the compressed size divided by the original size.",
+			Buckets:   prometheus.LinearBuckets(0.05, 0.05, 20),
+		}, []string{"component", "codec"})
+
+	compressDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "ticdc",
+			Subsystem: "compression",
+			Name:      "duration_seconds",
+			Help:      "Bucketed histogram of the CPU time (s) spent on compressing and decompressing.",
+			Buckets:   prometheus.ExponentialBuckets(0.0001 /* 0.1 ms */, 2, 18),
+		}, []string{"component", "codec", "type"})
+)
+
+// InitMetrics registers all metrics in this file
+func InitMetrics(registry prometheus.Registerer) {
+	registry.MustRegister(compressRatio)
+	registry.MustRegister(compressDuration)
+}
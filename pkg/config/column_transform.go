@@ -0,0 +1,101 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"strings"
+
+	filter "github.com/pingcap/tidb/util/table-filter"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+)
+
+const (
+	// ColumnTransformHash replaces the values with the hex encoded SHA-256
+	// of the salt followed by the values.
+	ColumnTransformHash = "hash"
+	// ColumnTransformRedact replaces the characters of the values with `*`,
+	// except the last Length ones.
+	ColumnTransformRedact = "redact"
+	// ColumnTransformTruncate keeps the first Length characters of the values.
+	ColumnTransformTruncate = "truncate"
+	// ColumnTransformFixed replaces the values with Value.
+	ColumnTransformFixed = "fixed"
+)
+
+// ColumnTransform transforms the values of the matched columns of the matched
+// tables, the columns are matched by the column rules like the ones of the
+// column selectors. Only the values of the string and binary columns are
+// transformed by hash, redact and truncate, the values of the other matched
+// columns are replaced with NULL. The values of all the types are replaced by
+// fixed. The NULL values are never transformed, and a table is rejected if
+// its handle key columns are matched.
+type ColumnTransform struct {
+	Matcher []string `toml:"matcher" json:"matcher"`
+	Columns []string `toml:"columns" json:"columns"`
+	// Type is one of hash, redact, truncate and fixed.
+	Type string `toml:"type" json:"type"`
+	// Length is the number of the characters kept by redact and truncate,
+	// they're bytes for the binary columns.
+	Length int `toml:"length" json:"length,omitempty"`
+	// Value is the value of fixed, it must be a valid value of the matched
+	// columns, e.g. an integer for the integer columns.
+	Value string `toml:"value" json:"value,omitempty"`
+	// Salt is hashed along with the values by hash, so the values can't be
+	// guessed by the hashes of the known values.
+	Salt string `toml:"salt" json:"salt,omitempty"`
+}
+
+// MatchColumn returns whether the column is transformed, the column names
+// are case-insensitive. The transform must have been validated.
+func (c *ColumnTransform) MatchColumn(name string) bool {
+	return matchColumnRules(c.Columns, strings.ToLower(name))
+}
+
+func (c *ColumnTransform) validateAndAdjust() error {
+	if len(c.Matcher) == 0 || len(c.Columns) == 0 {
+		return cerror.ErrSinkInvalidConfig.GenWithStack(
+			"matcher and columns of column-transforms must be specified")
+	}
+	if _, err := filter.Parse(c.Matcher); err != nil {
+		return cerror.ErrSinkInvalidConfig.GenWithStack(
+			"invalid column-transforms matcher %v: %s", c.Matcher, err)
+	}
+	for _, rule := range c.Columns {
+		if !isValidColumnRule(rule) {
+			return cerror.ErrSinkInvalidConfig.GenWithStack(
+				"invalid column-transforms column rule %s of the matcher %v", rule, c.Matcher)
+		}
+	}
+
+	c.Type = strings.ToLower(c.Type)
+	switch c.Type {
+	case ColumnTransformHash, ColumnTransformFixed:
+	case ColumnTransformRedact:
+		if c.Length < 0 {
+			return cerror.ErrSinkInvalidConfig.GenWithStack(
+				"length of the column-transforms %v must not be negative", c.Matcher)
+		}
+	case ColumnTransformTruncate:
+		if c.Length <= 0 {
+			return cerror.ErrSinkInvalidConfig.GenWithStack(
+				"length of the truncate column-transforms %v must be greater than 0", c.Matcher)
+		}
+	default:
+		return cerror.ErrSinkInvalidConfig.GenWithStack(
+			"type of the column-transforms %v should be one of %s, %s, %s and %s, but got %s",
+			c.Matcher, ColumnTransformHash, ColumnTransformRedact,
+			ColumnTransformTruncate, ColumnTransformFixed, c.Type)
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestColumnTransformValidateAndAdjust(t *testing.T) {
+	t.Parallel()
+
+	c := &ColumnTransform{
+		Matcher: []string{"test.users"},
+		Columns: []string{"phone", "card_*"},
+		Type:    "REDACT",
+		Length:  4,
+	}
+	require.Nil(t, c.validateAndAdjust())
+	require.Equal(t, ColumnTransformRedact, c.Type)
+	require.True(t, c.MatchColumn("Phone"))
+	require.True(t, c.MatchColumn("card_no"))
+	require.False(t, c.MatchColumn("name"))
+
+	c.Type = ColumnTransformTruncate
+	c.Length = 0
+	require.Regexp(t, ".*must be greater than 0.*", c.validateAndAdjust())
+	c.Type = "encrypt"
+	require.Regexp(t, ".*should be one of hash, redact, truncate and fixed.*", c.validateAndAdjust())
+	c.Type = ColumnTransformHash
+	c.Columns = []string{"[phone"}
+	require.Regexp(t, ".*invalid column-transforms column rule.*", c.validateAndAdjust())
+	c.Columns = nil
+	require.Regexp(t, ".*matcher and columns of column-transforms must be specified.*",
+		c.validateAndAdjust())
+	c.Matcher = []string{"[test"}
+	c.Columns = []string{"phone"}
+	require.Regexp(t, ".*invalid column-transforms matcher.*", c.validateAndAdjust())
+}
+
+func TestValidateAndAdjustColumnTransforms(t *testing.T) {
+	t.Parallel()
+
+	s := &SinkConfig{ColumnTransforms: []*ColumnTransform{{
+		Matcher: []string{"test.*"},
+		Columns: []string{"phone"},
+		Type:    ColumnTransformHash,
+	}}}
+	sinkURI, err := url.Parse("mysql://127.0.0.1:3306/")
+	require.Nil(t, err)
+	require.Nil(t, s.validateAndAdjust(sinkURI, true))
+
+	sinkURI, err = url.Parse("s3://bucket/prefix?protocol=csv")
+	require.Nil(t, err)
+	require.Regexp(t, ".*column-transforms is only supported by the MQ and MySQL sinks.*",
+		s.validateAndAdjust(sinkURI, true))
+	s.ExtraSinks = []*ExtraSinkConfig{{Name: "archive", SinkURI: "s3://bucket/archive"}}
+	require.Regexp(t, ".*column-transforms is only supported by the MQ and MySQL sinks.*",
+		s.validateAndAdjust(nil, true))
+	s.ExtraSinks = nil
+	// The archive stores the transformed messages emitted by the MQ sink.
+	s.TeeSinkURI = "s3://bucket/archive"
+	sinkURI, err = url.Parse("kafka://127.0.0.1:9092/topic")
+	require.Nil(t, err)
+	require.Nil(t, s.validateAndAdjust(sinkURI, true))
+}
@@ -81,6 +81,9 @@ const (
   "capture-session-ttl": 10,
   "owner-flush-interval": 50000000,
   "processor-flush-interval": 50000000,
+  "checkpoint-persist-interval": 0,
+  "checkpoint-persist-jitter": 0,
+  "changefeed-init-concurrency": 16,
   "sorter": {
     "num-concurrent-worker": 4,
     "chunk-size-limit": 999,
@@ -143,6 +146,17 @@ const (
     },
     "enable-new-sink": true
   },
+  "election": {
+    "mode": "etcd",
+    "lease-namespace": "",
+    "lease-name": "ticdc-owner",
+    "lease-duration": 15000000000,
+    "retry-period": 2000000000
+  },
+  "gc-tuner": {
+    "memory-threshold-percentage": 0,
+    "min-gc-percent": 20
+  },
   "cluster-id": "default"
 }`
 
@@ -150,6 +164,7 @@ const (
   "memory-quota": 268435456,
   "case-sensitive": false,
   "enable-old-value": true,
+  "old-value-mode": "auto",
   "force-replicate": true,
   "check-gc-safe-point": true,
   "enable-sync-point": false,
@@ -161,7 +176,8 @@ const (
       "1.1"
     ],
     "ignore-txn-start-ts": null,
-    "event-filters": null
+    "event-filters": null,
+    "table-start-ts": null
   },
   "mounter": {
     "worker-num": 3
@@ -205,6 +221,7 @@ const (
   "memory-quota": 268435456,
   "case-sensitive": false,
   "enable-old-value": true,
+  "old-value-mode": "auto",
   "force-replicate": true,
   "check-gc-safe-point": true,
   "enable-sync-point": false,
@@ -0,0 +1,56 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"time"
+
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+)
+
+const (
+	// DefaultConsistencyGroupMaxSkew is the default max skew between the
+	// checkpoints of the changefeeds in a consistency group.
+	DefaultConsistencyGroupMaxSkew = 30 * time.Second
+	// minConsistencyGroupMaxSkew is the minimum of the max skew. The
+	// changefeeds in a group can not advance if the max skew is zero.
+	minConsistencyGroupMaxSkew = time.Second
+)
+
+// ConsistencyGroupConfig links the changefeeds of the same group name in a
+// namespace, so that their checkpoints advance in lockstep within max skew.
+type ConsistencyGroupConfig struct {
+	Name string `toml:"name" json:"name"`
+	// MaxSkew is the max lead of the checkpoint of a changefeed over
+	// the min checkpoint of the changefeeds in the group.
+	MaxSkew time.Duration `toml:"max-skew" json:"max-skew"`
+}
+
+// ValidateAndAdjust validates the consistency group config and adjusts it if necessary.
+func (c *ConsistencyGroupConfig) ValidateAndAdjust() error {
+	if c.Name == "" {
+		return cerror.ErrInvalidReplicaConfig.FastGenByArgs(
+			"the consistency-group.name must be specified")
+	}
+	if c.MaxSkew == 0 {
+		c.MaxSkew = DefaultConsistencyGroupMaxSkew
+	}
+	if c.MaxSkew < minConsistencyGroupMaxSkew {
+		return cerror.ErrInvalidReplicaConfig.FastGenByArgs(
+			fmt.Sprintf("The consistency-group.max-skew:%s must be equal or greater than %s",
+				c.MaxSkew, minConsistencyGroupMaxSkew))
+	}
+	return nil
+}
@@ -17,6 +17,7 @@ import (
 	"fmt"
 
 	"github.com/pingcap/tidb/br/pkg/storage"
+	"github.com/pingcap/tiflow/pkg/compression"
 	cerror "github.com/pingcap/tiflow/pkg/errors"
 	"github.com/pingcap/tiflow/pkg/redo"
 )
@@ -33,6 +34,9 @@ type ConsistentConfig struct {
 	MaxLogSize        int64  `toml:"max-log-size" json:"max-log-size"`
 	FlushIntervalInMs int64  `toml:"flush-interval" json:"flush-interval"`
 	Storage           string `toml:"storage" json:"storage"`
+	// Compression is the compression codec of the redo log files uploaded
+	// to an external storage, empty means none.
+	Compression string `toml:"compression" json:"compression,omitempty"`
 }
 
 // ValidateAndAdjust validates the consistency config and adjusts it if necessary.
@@ -47,6 +51,10 @@ func (c *ConsistentConfig) ValidateAndAdjust() error {
 				c.FlushIntervalInMs, MinFlushIntervalInMs))
 	}
 
+	if _, err := compression.Get(c.Compression); err != nil {
+		return cerror.ErrInvalidReplicaConfig.GenWithStackByArgs(err.Error())
+	}
+
 	uri, err := storage.ParseRawURL(c.Storage)
 	if err != nil {
 		return cerror.ErrInvalidReplicaConfig.GenWithStackByArgs(
@@ -0,0 +1,64 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+)
+
+// ConsumerContract is the contract of the downstream consumers of a topic of
+// the MQ sinks. The DDLs which drop or change the type of a required column
+// of the tables sent to the topic are rejected before they reach the topic,
+// and the changefeed is paused with the violations as the error, it can be
+// resumed after the contract or the DDL is fixed.
+type ConsumerContract struct {
+	// Topic is the topic consumed by the consumers.
+	Topic string `toml:"topic" json:"topic"`
+	// Columns are the columns required by the consumers.
+	Columns []*ContractColumn `toml:"columns" json:"columns"`
+}
+
+// ContractColumn is a column required by the consumers.
+type ContractColumn struct {
+	// Name is the name of the column, it's case-insensitive.
+	Name string `toml:"name" json:"name"`
+	// Type is the type required by the consumers, like int or varchar(255).
+	// The length is only compared if it's given, and any type is accepted
+	// if it's empty.
+	Type string `toml:"type" json:"type,omitempty"`
+}
+
+// validateConsumerContracts validates the consumer contracts, a topic can
+// have one contract at most.
+func validateConsumerContracts(contracts []*ConsumerContract) error {
+	topics := make(map[string]struct{}, len(contracts))
+	for _, contract := range contracts {
+		if contract.Topic == "" || len(contract.Columns) == 0 {
+			return cerror.ErrSinkInvalidConfig.GenWithStack(
+				"topic and columns of consumer-contracts must be specified")
+		}
+		if _, ok := topics[contract.Topic]; ok {
+			return cerror.ErrSinkInvalidConfig.GenWithStack(
+				"duplicated topic %s of consumer-contracts", contract.Topic)
+		}
+		topics[contract.Topic] = struct{}{}
+		for _, column := range contract.Columns {
+			if column.Name == "" {
+				return cerror.ErrSinkInvalidConfig.GenWithStack(
+					"name of the columns of consumer-contracts must be specified")
+			}
+		}
+	}
+	return nil
+}
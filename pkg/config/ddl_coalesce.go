@@ -0,0 +1,70 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"time"
+
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+)
+
+const (
+	// DefaultDDLCoalesceMaxBatchSize is the default max number of the DDL
+	// jobs executed in a batch.
+	DefaultDDLCoalesceMaxBatchSize = 64
+	// DefaultDDLCoalesceMaxBatchInterval is the default max interval between
+	// the commit ts of the first and the last DDL job in a batch.
+	DefaultDDLCoalesceMaxBatchInterval = 10 * time.Second
+)
+
+// DDLCoalesceConfig enables coalescing the DDL jobs, a batch is the front job
+// and the following ones which are early executable, e.g. adding partitions,
+// indexes or columns. The batch is written to the sink in one write at the
+// barrier of the front job, so the changefeed waits for the checkpoint once
+// per batch instead of once per job.
+//
+// Note: the later jobs of a batch are written before the DML events committed
+// ahead of them, which are still valid on the new schema. The jobs which are
+// not early executable, e.g. dropping or renaming tables, end a batch and
+// still wait for the checkpoint to reach their own commit ts.
+type DDLCoalesceConfig struct {
+	// MaxBatchSize is the max number of the DDL jobs in a batch.
+	MaxBatchSize int `toml:"max-batch-size" json:"max-batch-size"`
+	// MaxBatchInterval is the max interval between the commit ts of the
+	// first and the last DDL job in a batch, it bounds the delay of the
+	// other barriers of the changefeed.
+	MaxBatchInterval time.Duration `toml:"max-batch-interval" json:"max-batch-interval"`
+}
+
+// ValidateAndAdjust validates the DDL coalesce config and adjusts it if necessary.
+func (c *DDLCoalesceConfig) ValidateAndAdjust() error {
+	if c.MaxBatchSize == 0 {
+		c.MaxBatchSize = DefaultDDLCoalesceMaxBatchSize
+	}
+	if c.MaxBatchSize < 1 {
+		return cerror.ErrInvalidReplicaConfig.FastGenByArgs(
+			fmt.Sprintf("The ddl-coalesce.max-batch-size:%d must be greater than 0",
+				c.MaxBatchSize))
+	}
+	if c.MaxBatchInterval == 0 {
+		c.MaxBatchInterval = DefaultDDLCoalesceMaxBatchInterval
+	}
+	if c.MaxBatchInterval < 0 {
+		return cerror.ErrInvalidReplicaConfig.FastGenByArgs(
+			fmt.Sprintf("The ddl-coalesce.max-batch-interval:%s must be greater than 0",
+				c.MaxBatchInterval))
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/pingcap/errors"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"go.uber.org/zap/zapcore"
+)
+
+// DynamicConfig is the part of the server config which can be changed without
+// restarting the server. The keys are the same as the ones of ServerConfig.
+// The sorter config is not dynamic, since the memory of the sorter is
+// allocated once when the server starts.
+type DynamicConfig struct {
+	LogLevel            string         `toml:"log-level" json:"log-level"`
+	PerTableMemoryQuota uint64         `toml:"per-table-memory-quota" json:"per-table-memory-quota"`
+	GCTuner             *GCTunerConfig `toml:"gc-tuner" json:"gc-tuner"`
+}
+
+// GetDynamicConfig returns the dynamic part of the server config.
+func (c *ServerConfig) GetDynamicConfig() *DynamicConfig {
+	d := &DynamicConfig{
+		LogLevel:            c.LogLevel,
+		PerTableMemoryQuota: c.PerTableMemoryQuota,
+		GCTuner:             NewDefaultGCTunerConfig(),
+	}
+	if c.GCTuner != nil {
+		*d.GCTuner = *c.GCTuner
+	}
+	return d
+}
+
+// WithDynamicConfig returns a copy of the server config with the dynamic part
+// replaced by d, the server config itself is not changed since it may be
+// read concurrently.
+func (c *ServerConfig) WithDynamicConfig(d *DynamicConfig) (*ServerConfig, error) {
+	if err := d.ValidateAndAdjust(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	newCfg := c.Clone()
+	newCfg.LogLevel = d.LogLevel
+	newCfg.PerTableMemoryQuota = d.PerTableMemoryQuota
+	gcTuner := *d.GCTuner
+	newCfg.GCTuner = &gcTuner
+	return newCfg, nil
+}
+
+// Clone clones the dynamic config.
+func (d *DynamicConfig) Clone() *DynamicConfig {
+	clone := *d
+	if d.GCTuner != nil {
+		gcTuner := *d.GCTuner
+		clone.GCTuner = &gcTuner
+	}
+	return &clone
+}
+
+// Merge overrides the dynamic config by the keys in a JSON document, the keys
+// absent in the document are kept.
+func (d *DynamicConfig) Merge(data []byte) error {
+	if err := json.Unmarshal(data, d); err != nil {
+		return cerror.WrapError(cerror.ErrInvalidServerOption, err)
+	}
+	return nil
+}
+
+// ValidateAndAdjust validates and adjusts the dynamic config.
+func (d *DynamicConfig) ValidateAndAdjust() error {
+	if strings.EqualFold(d.LogLevel, "warning") {
+		d.LogLevel = "warn"
+	}
+	var level zapcore.Level
+	if err := level.UnmarshalText([]byte(d.LogLevel)); err != nil {
+		return cerror.ErrInvalidServerOption.GenWithStack("invalid log-level %s", d.LogLevel)
+	}
+	if d.PerTableMemoryQuota == 0 {
+		d.PerTableMemoryQuota = DefaultTableMemoryQuota
+	}
+	if d.GCTuner == nil {
+		d.GCTuner = NewDefaultGCTunerConfig()
+	}
+	return d.GCTuner.ValidateAndAdjust()
+}
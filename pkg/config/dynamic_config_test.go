@@ -0,0 +1,75 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestServerConfigWithDynamicConfig(t *testing.T) {
+	t.Parallel()
+
+	cfg := GetDefaultServerConfig()
+	cfg.Sorter.SortDir = "/data/tmp/sorter"
+	d := cfg.GetDynamicConfig()
+	require.Equal(t, &DynamicConfig{
+		LogLevel:            "info",
+		PerTableMemoryQuota: DefaultTableMemoryQuota,
+		GCTuner:             &GCTunerConfig{MinGCPercent: 20},
+	}, d)
+
+	d.LogLevel = "warning"
+	d.PerTableMemoryQuota = 0
+	d.GCTuner.MemoryThresholdPercentage = 70
+	newCfg, err := cfg.WithDynamicConfig(d)
+	require.Nil(t, err)
+	require.Equal(t, "warn", newCfg.LogLevel)
+	require.Equal(t, uint64(DefaultTableMemoryQuota), newCfg.PerTableMemoryQuota)
+	require.Equal(t, "/data/tmp/sorter", newCfg.Sorter.SortDir)
+	require.Equal(t, 70, newCfg.GCTuner.MemoryThresholdPercentage)
+	// the original config is not changed.
+	require.Equal(t, "info", cfg.LogLevel)
+	require.Equal(t, 0, cfg.GCTuner.MemoryThresholdPercentage)
+
+	d = cfg.GetDynamicConfig()
+	d.LogLevel = "verbose"
+	_, err = cfg.WithDynamicConfig(d)
+	require.Regexp(t, "invalid log-level", err)
+
+	d = cfg.GetDynamicConfig()
+	d.GCTuner.MemoryThresholdPercentage = 100
+	_, err = cfg.WithDynamicConfig(d)
+	require.Regexp(t, "memory-threshold-percentage", err)
+}
+
+func TestDynamicConfigMerge(t *testing.T) {
+	t.Parallel()
+
+	d := GetDefaultServerConfig().GetDynamicConfig()
+	clone := d.Clone()
+	err := clone.Merge([]byte(`{"log-level":"debug","gc-tuner":{"memory-threshold-percentage":60}}`))
+	require.Nil(t, err)
+	require.Equal(t, "debug", clone.LogLevel)
+	require.Equal(t, 60, clone.GCTuner.MemoryThresholdPercentage)
+	require.Equal(t, 20, clone.GCTuner.MinGCPercent)
+	require.Equal(t, uint64(DefaultTableMemoryQuota), clone.PerTableMemoryQuota)
+	// the merge doesn't change the original config.
+	require.Equal(t, "info", d.LogLevel)
+	require.Equal(t, 0, d.GCTuner.MemoryThresholdPercentage)
+
+	err = clone.Merge([]byte(`{"log-level":`))
+	require.Regexp(t, "ErrInvalidServerOption", err)
+}
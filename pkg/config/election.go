@@ -0,0 +1,86 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"time"
+
+	"github.com/pingcap/tiflow/pkg/errors"
+)
+
+const (
+	// ElectionModeEtcd elects the owner by etcd only.
+	ElectionModeEtcd = "etcd"
+	// ElectionModeKubernetes elects the owner by a Kubernetes Lease object
+	// without campaigning in etcd, the holder is fenced by the resourceVersion
+	// and holder identity of the lease. The captures still keep their etcd
+	// sessions, because the holder writes the owner key in etcd for the
+	// other captures to discover it, and the owner's writes to etcd are
+	// fenced by that key.
+	ElectionModeKubernetes = "kubernetes"
+)
+
+// ElectionConfig represents config for the owner election
+type ElectionConfig struct {
+	// the election mode, etcd or kubernetes
+	Mode string `toml:"mode" json:"mode"`
+	// the namespace of the Kubernetes Lease object, the namespace of
+	// the pod is used if it is empty
+	LeaseNamespace string `toml:"lease-namespace" json:"lease-namespace"`
+	// the name of the Kubernetes Lease object
+	LeaseName string `toml:"lease-name" json:"lease-name"`
+	// the duration that candidates wait before taking over the lease
+	// which is not renewed by its holder
+	LeaseDuration TomlDuration `toml:"lease-duration" json:"lease-duration"`
+	// the interval of renewing or acquiring the lease
+	RetryPeriod TomlDuration `toml:"retry-period" json:"retry-period"`
+}
+
+// NewDefaultElectionConfig returns the default election config.
+func NewDefaultElectionConfig() *ElectionConfig {
+	return &ElectionConfig{
+		Mode:          ElectionModeEtcd,
+		LeaseName:     "ticdc-owner",
+		LeaseDuration: TomlDuration(15 * time.Second),
+		RetryPeriod:   TomlDuration(2 * time.Second),
+	}
+}
+
+// ValidateAndAdjust validates and adjusts the election configuration
+func (c *ElectionConfig) ValidateAndAdjust() error {
+	switch c.Mode {
+	case "":
+		c.Mode = ElectionModeEtcd
+	case ElectionModeEtcd, ElectionModeKubernetes:
+	default:
+		return errors.ErrInvalidServerOption.GenWithStack(
+			"unknown election mode " + c.Mode)
+	}
+	if c.Mode != ElectionModeKubernetes {
+		return nil
+	}
+	if c.LeaseName == "" {
+		return errors.ErrInvalidServerOption.GenWithStack(
+			"lease-name must be specified in kubernetes election mode")
+	}
+	if c.LeaseDuration < TomlDuration(time.Second) || c.RetryPeriod <= 0 {
+		return errors.ErrInvalidServerOption.GenWithStack(
+			"lease-duration must be at least 1s and retry-period must be positive")
+	}
+	if c.RetryPeriod >= c.LeaseDuration {
+		return errors.ErrInvalidServerOption.GenWithStack(
+			"retry-period must be less than lease-duration")
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+)
+
+const (
+	// SortEnginePebble sorts the events of the changefeed in the pebble
+	// databases shared by all changefeeds of the capture.
+	SortEnginePebble = "pebble"
+	// SortEngineMemory sorts the events of the changefeed in memory, it is
+	// only suitable for changefeeds with small traffic. The events buffered
+	// by the memory engines of a capture are bounded by the memory quota of
+	// the sorter.
+	SortEngineMemory = "memory"
+
+	// SinkEngineV1 is the legacy sink architecture.
+	SinkEngineV1 = "v1"
+	// SinkEngineV2 is the new sink architecture.
+	SinkEngineV2 = "v2"
+)
+
+// IsNewSinkEnabled returns whether the changefeed uses the new sink. The
+// sink engine of the changefeed takes precedence over the server flag.
+func (c *ReplicaConfig) IsNewSinkEnabled(debug *DebugConfig) bool {
+	switch c.SinkEngine {
+	case SinkEngineV1:
+		return false
+	case SinkEngineV2:
+		return true
+	default:
+		return debug.EnableNewSink
+	}
+}
+
+// validateEngines checks the engines of the changefeed are supported by the
+// capture, an empty engine follows the configuration of the server.
+func (c *ReplicaConfig) validateEngines(debug *DebugConfig) error {
+	switch c.SortEngine {
+	case "":
+	case SortEnginePebble, SortEngineMemory:
+		if !debug.IsPullBasedSinkEnabled() {
+			return cerror.ErrInvalidReplicaConfig.FastGenByArgs(
+				"sort-engine is only supported with the pull-based sink, " +
+					"you can set `debug.enable-pull-based-sink` to be true")
+		}
+	default:
+		return cerror.ErrInvalidReplicaConfig.FastGenByArgs(
+			"sort-engine must be one of pebble and memory")
+	}
+	if c.SortSpill != nil {
+		if !debug.IsPullBasedSinkEnabled() || c.SortEngine == SortEngineMemory {
+			return cerror.ErrInvalidReplicaConfig.FastGenByArgs(
+				"sort-spill is only supported by the sort-engine pebble " +
+					"with the pull-based sink")
+		}
+	}
+
+	switch c.SinkEngine {
+	case "", SinkEngineV2:
+	case SinkEngineV1:
+		if debug.IsPullBasedSinkEnabled() {
+			return cerror.ErrInvalidReplicaConfig.FastGenByArgs(
+				"sink-engine v1 is not supported with the pull-based sink, " +
+					"you can set `debug.enable-pull-based-sink` to be false")
+		}
+		if c.Sink != nil && c.Sink.TeeSinkURI != "" {
+			return cerror.ErrInvalidReplicaConfig.FastGenByArgs(
+				"tee-sink-uri is only supported by the sink-engine v2")
+		}
+		if c.Sink != nil && len(c.Sink.ExtraSinks) > 0 {
+			return cerror.ErrInvalidReplicaConfig.FastGenByArgs(
+				"extra-sinks is only supported by the sink-engine v2")
+		}
+	default:
+		return cerror.ErrInvalidReplicaConfig.FastGenByArgs(
+			"sink-engine must be one of v1 and v2")
+	}
+	return nil
+}
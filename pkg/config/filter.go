@@ -14,8 +14,12 @@
 package config
 
 import (
+	"fmt"
+	"strings"
+
 	bf "github.com/pingcap/tidb-tools/pkg/binlog-filter"
 	filter "github.com/pingcap/tidb/util/table-filter"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
 )
 
 // FilterConfig represents filter config for a changefeed
@@ -24,6 +28,65 @@ type FilterConfig struct {
 	*filter.MySQLReplicationRules
 	IgnoreTxnStartTs []uint64           `toml:"ignore-txn-start-ts" json:"ignore-txn-start-ts"`
 	EventFilters     []*EventFilterRule `toml:"event-filters" json:"event-filters"`
+	// TableStartTs specifies start ts of tables, tables not matched by
+	// any rule start at the start ts of the changefeed.
+	TableStartTs []*TableStartTsRule `toml:"table-start-ts" json:"table-start-ts"`
+	// TableAttributes selects the tables by their attributes in TiDB besides
+	// their names, it's disabled if it's nil.
+	TableAttributes *TableAttributesConfig `toml:"table-attributes" json:"table-attributes,omitempty"`
+}
+
+// MinTableStartTs returns the min start ts of the table start ts rules,
+// 0 is returned if there is no rule.
+func (c *FilterConfig) MinTableStartTs() uint64 {
+	var minTs uint64
+	for _, rule := range c.TableStartTs {
+		if minTs == 0 || rule.StartTs < minTs {
+			minTs = rule.StartTs
+		}
+	}
+	return minTs
+}
+
+// TableStartTsRule specifies the start ts of the tables matched by Matcher.
+type TableStartTsRule struct {
+	Matcher []string `toml:"matcher" json:"matcher"`
+	StartTs uint64   `toml:"start-ts" json:"start-ts"`
+}
+
+// CommentTagSeparators are the separators of the tags in the table comments.
+const CommentTagSeparators = " \t\r\n,;"
+
+// TableAttributesConfig selects the tables by their attributes in TiDB, so
+// the tables can be opted into a changefeed by tagging them. A table matched
+// by the filter rules is replicated only if it has any of the attributes.
+// Tagging a table after the changefeed starts replicates it as a CREATE TABLE,
+// the rows written before must be snapshotted to the downstream manually, and
+// untagging a table replicates it as a DROP TABLE.
+// The tables can't be selected by a label set in the sessions, e.g. by a
+// session variable, because the labels are not recorded in the DDL jobs.
+type TableAttributesConfig struct {
+	// PlacementPolicies are the names of the placement policies of the tables.
+	PlacementPolicies []string `toml:"placement-policies" json:"placement-policies,omitempty"`
+	// CommentTags are the tags in the comments of the tables, the tags are
+	// the words separated by spaces, commas and semicolons in the comments,
+	// e.g. `cdc` or the labels like `replication=analytics`.
+	CommentTags []string `toml:"comment-tags" json:"comment-tags,omitempty"`
+}
+
+// ValidateAndAdjust validates the table attributes config.
+func (c *TableAttributesConfig) ValidateAndAdjust() error {
+	if len(c.PlacementPolicies) == 0 && len(c.CommentTags) == 0 {
+		return cerror.ErrInvalidReplicaConfig.FastGenByArgs(
+			"placement-policies or comment-tags of table-attributes must be specified")
+	}
+	for _, tag := range c.CommentTags {
+		if tag == "" || strings.ContainsAny(tag, CommentTagSeparators) {
+			return cerror.ErrInvalidReplicaConfig.FastGenByArgs(
+				fmt.Sprintf("invalid comment tag %q of table-attributes", tag))
+		}
+	}
+	return nil
 }
 
 // EventFilterRule is used by sql event filter and expression filter
@@ -32,9 +95,17 @@ type EventFilterRule struct {
 	IgnoreEvent []bf.EventType `toml:"ignore-event" json:"ignore-event"`
 	// regular expression
 	IgnoreSQL []string `toml:"ignore-sql" json:"ignore-sql"`
+	// AllowDDL are the types of the DDLs which are never ignored by the rule,
+	// even if they match IgnoreEvent or IgnoreSQL, e.g. ignore "alter table"
+	// but allow "add column".
+	AllowDDL []bf.EventType `toml:"allow-ddl" json:"allow-ddl,omitempty"`
 	// sql expression
 	IgnoreInsertValueExpr    string `toml:"ignore-insert-value-expr" json:"ignore-insert-value-expr"`
 	IgnoreUpdateNewValueExpr string `toml:"ignore-update-new-value-expr" json:"ignore-update-new-value-expr"`
 	IgnoreUpdateOldValueExpr string `toml:"ignore-update-old-value-expr" json:"ignore-update-old-value-expr"`
 	IgnoreDeleteValueExpr    string `toml:"ignore-delete-value-expr" json:"ignore-delete-value-expr"`
+	// KeepValueExpr keeps only the rows matching it, the others are ignored.
+	// An update moving a row out of the kept ones is replicated as a delete,
+	// and an update moving a row in is replicated as an insert.
+	KeepValueExpr string `toml:"keep-value-expr" json:"keep-value-expr,omitempty"`
 }
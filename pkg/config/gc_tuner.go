@@ -0,0 +1,46 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import "github.com/pingcap/tiflow/pkg/errors"
+
+// GCTunerConfig represents config for the GC tuner of a server, which adjusts
+// GOGC by the memory in use to reduce the GC frequency.
+type GCTunerConfig struct {
+	// the percentage of the total memory used as the threshold of the tuner,
+	// 0 disables the tuner
+	MemoryThresholdPercentage int `toml:"memory-threshold-percentage" json:"memory-threshold-percentage"`
+	// the minimal GOGC value set by the tuner
+	MinGCPercent uint32 `toml:"min-gc-percent" json:"min-gc-percent"`
+}
+
+// NewDefaultGCTunerConfig returns the default GC tuner config.
+func NewDefaultGCTunerConfig() *GCTunerConfig {
+	return &GCTunerConfig{
+		MemoryThresholdPercentage: 0,
+		MinGCPercent:              20,
+	}
+}
+
+// ValidateAndAdjust validates and adjusts the GC tuner configuration
+func (c *GCTunerConfig) ValidateAndAdjust() error {
+	if c.MemoryThresholdPercentage < 0 || c.MemoryThresholdPercentage >= 100 {
+		return errors.ErrInvalidServerOption.GenWithStack(
+			"gc-tuner.memory-threshold-percentage should be within [0, 100)")
+	}
+	if c.MinGCPercent == 0 {
+		c.MinGCPercent = NewDefaultGCTunerConfig().MinGCPercent
+	}
+	return nil
+}
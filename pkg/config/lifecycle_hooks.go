@@ -0,0 +1,67 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"time"
+
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+)
+
+const (
+	// DefaultPauseFlushTimeout is the default max time to wait for the flush
+	// of the received data before pausing a changefeed.
+	DefaultPauseFlushTimeout = 5 * time.Minute
+	// DefaultRemoveCleanupTimeout is the default max time to clean up the
+	// downstream before removing a changefeed.
+	DefaultRemoveCleanupTimeout = 5 * time.Minute
+)
+
+// LifecycleHooksConfig configures the hooks run by the owner before the
+// state transitions of a changefeed.
+type LifecycleHooksConfig struct {
+	// FlushBeforePause makes a pause wait until the checkpoint reaches the
+	// resolved ts at the time of the pause, or PauseFlushTimeout passes.
+	FlushBeforePause  bool          `toml:"flush-before-pause" json:"flush-before-pause"`
+	PauseFlushTimeout time.Duration `toml:"pause-flush-timeout" json:"pause-flush-timeout"`
+	// CleanupOnRemove deletes the CleanupTargets before the changefeed is
+	// removed. The changefeed is stopped first, and it's removed even if the
+	// cleanup fails or doesn't finish in RemoveCleanupTimeout.
+	CleanupOnRemove      bool          `toml:"cleanup-on-remove" json:"cleanup-on-remove"`
+	RemoveCleanupTimeout time.Duration `toml:"remove-cleanup-timeout" json:"remove-cleanup-timeout"`
+	// CleanupTargets are the data deleted by CleanupOnRemove, which are the
+	// topics of a Kafka sink, or the files and the directories relative to
+	// the storage path of a cloud storage sink. They are listed explicitly
+	// since the downstream may be shared with other writers.
+	CleanupTargets []string `toml:"cleanup-targets" json:"cleanup-targets,omitempty"`
+}
+
+// ValidateAndAdjust validates the lifecycle hooks config and adjusts it if necessary.
+func (c *LifecycleHooksConfig) ValidateAndAdjust() error {
+	if c.PauseFlushTimeout < 0 || c.RemoveCleanupTimeout < 0 {
+		return cerror.ErrInvalidReplicaConfig.FastGenByArgs(
+			"The timeouts of lifecycle-hooks must not be negative")
+	}
+	if c.PauseFlushTimeout == 0 {
+		c.PauseFlushTimeout = DefaultPauseFlushTimeout
+	}
+	if c.CleanupOnRemove && len(c.CleanupTargets) == 0 {
+		return cerror.ErrInvalidReplicaConfig.FastGenByArgs(
+			"The cleanup-targets of lifecycle-hooks must be set if cleanup-on-remove is enabled")
+	}
+	if c.RemoveCleanupTimeout == 0 {
+		c.RemoveCleanupTimeout = DefaultRemoveCleanupTimeout
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+)
+
+const (
+	// MetricLabelTable is the label of the metrics which are
+	// reported per table.
+	MetricLabelTable = "table"
+	// MetricLabelTopic is the label of the metrics which are
+	// reported per topic of the downstream message queue.
+	MetricLabelTopic = "topic"
+	// MetricLabelPartition is the label of the metrics which are
+	// reported per partition of the downstream message queue.
+	MetricLabelPartition = "partition"
+
+	// AggregatedMetricLabelValue is the value of a disabled label, all
+	// the series of the metric are aggregated into it.
+	AggregatedMetricLabelValue = "all"
+)
+
+var highCardinalityMetricLabels = []string{
+	MetricLabelTable,
+	MetricLabelTopic,
+	MetricLabelPartition,
+}
+
+// MetricsConfig controls the high cardinality labels attached to the metrics
+// of a changefeed. The metrics keep the disabled labels, but their values
+// are aggregated into AggregatedMetricLabelValue, so that the dashboards
+// work in both ways.
+type MetricsConfig struct {
+	// DisabledLabels are the high cardinality labels which are aggregated.
+	DisabledLabels []string `toml:"disabled-labels" json:"disabled-labels"`
+	// AggregateOnly aggregates all the high cardinality labels, it's
+	// designed for the changefeeds replicating tens of thousands tables.
+	AggregateOnly bool `toml:"aggregate-only" json:"aggregate-only"`
+}
+
+// ValidateAndAdjust validates the metrics config.
+func (c *MetricsConfig) ValidateAndAdjust() error {
+	for _, label := range c.DisabledLabels {
+		if !isHighCardinalityMetricLabel(label) {
+			return cerror.ErrInvalidReplicaConfig.FastGenByArgs(
+				fmt.Sprintf("The metrics.disabled-labels:%s is not supported, "+
+					"it must be one of %v", label, highCardinalityMetricLabels))
+		}
+	}
+	return nil
+}
+
+// LabelValue returns the value of the label attached to the metrics, which
+// is AggregatedMetricLabelValue if the label is disabled. It's safe to call
+// on a nil config, all the labels are enabled by default.
+func (c *MetricsConfig) LabelValue(label, value string) string {
+	if c == nil {
+		return value
+	}
+	if c.AggregateOnly {
+		return AggregatedMetricLabelValue
+	}
+	for _, disabled := range c.DisabledLabels {
+		if disabled == label {
+			return AggregatedMetricLabelValue
+		}
+	}
+	return value
+}
+
+func isHighCardinalityMetricLabel(label string) bool {
+	for _, l := range highCardinalityMetricLabels {
+		if l == label {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,149 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+)
+
+// CurrentReplicaConfigVersion is the version of the replica config persisted
+// by this release. It must be the version of the last migration.
+const CurrentReplicaConfigVersion = 2
+
+// replicaConfigMigration upgrades the persisted replica config from the
+// previous version to the version. A migration works on the JSON object of
+// the config, so that the renamed and moved fields, which are unknown to the
+// current ReplicaConfig, are not lost. It must be idempotent, since the
+// configs persisted by the earlier releases are not versioned.
+type replicaConfigMigration struct {
+	version     int
+	description string
+	// migrate returns true if the config is changed.
+	migrate func(cfg map[string]interface{}) bool
+}
+
+var replicaConfigMigrations = []replicaConfigMigration{
+	{
+		version:     1,
+		description: "convert sink.dispatch-rules to sink.dispatchers",
+		migrate:     migrateDispatchRules,
+	},
+	{
+		version:     2,
+		description: "rename sink.dispatchers.dispatcher to sink.dispatchers.partition",
+		migrate:     migrateDispatcherRule,
+	},
+}
+
+// MigrateReplicaConfig upgrades the JSON encoded replica config persisted by
+// an earlier release, the migrations newer than fromVersion are applied. It
+// returns the migrated config and the descriptions of the applied migrations,
+// the config is returned as it is if no migration is applied.
+func MigrateReplicaConfig(data []byte, fromVersion int) ([]byte, []string, error) {
+	var cfg map[string]interface{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	// Keep the numbers as they are, otherwise the large integers, such as
+	// the timestamps, lose precision when they are decoded as float64.
+	decoder.UseNumber()
+	if err := decoder.Decode(&cfg); err != nil {
+		return nil, nil, cerror.WrapError(cerror.ErrDecodeFailed, err)
+	}
+	if cfg == nil {
+		return data, nil, nil
+	}
+
+	var applied []string
+	for _, m := range replicaConfigMigrations {
+		if m.version <= fromVersion {
+			continue
+		}
+		if m.migrate(cfg) {
+			applied = append(applied, fmt.Sprintf("v%d: %s", m.version, m.description))
+		}
+	}
+	if len(applied) == 0 {
+		return data, nil, nil
+	}
+	migrated, err := json.Marshal(cfg)
+	if err != nil {
+		return nil, nil, cerror.WrapError(cerror.ErrEncodeFailed, err)
+	}
+	return migrated, applied, nil
+}
+
+// migrateDispatchRules converts the dispatch rules of the v1 config, which
+// are in the form of {"db-name", "tbl-name", "rule"}, to the dispatchers.
+func migrateDispatchRules(cfg map[string]interface{}) bool {
+	sink, ok := cfg["sink"].(map[string]interface{})
+	if !ok {
+		return false
+	}
+	rules, ok := sink["dispatch-rules"]
+	if !ok {
+		return false
+	}
+	delete(sink, "dispatch-rules")
+
+	dispatchers, _ := sink["dispatchers"].([]interface{})
+	oldRules, _ := rules.([]interface{})
+	for _, r := range oldRules {
+		rule, ok := r.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		dispatchers = append(dispatchers, map[string]interface{}{
+			"matcher": []interface{}{
+				fmt.Sprintf("%v.%v", rule["db-name"], rule["tbl-name"]),
+			},
+			"dispatcher": rule["rule"],
+		})
+	}
+	if dispatchers != nil {
+		sink["dispatchers"] = dispatchers
+	}
+	return true
+}
+
+// migrateDispatcherRule moves the deprecated dispatcher rule of the
+// dispatchers to the partition rule. The rule which has both of them is
+// left as it is, so that it's rejected by the validation.
+func migrateDispatcherRule(cfg map[string]interface{}) bool {
+	sink, ok := cfg["sink"].(map[string]interface{})
+	if !ok {
+		return false
+	}
+	dispatchers, _ := sink["dispatchers"].([]interface{})
+	changed := false
+	for _, d := range dispatchers {
+		dispatcher, ok := d.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		rule, _ := dispatcher["dispatcher"].(string)
+		if rule == "" {
+			continue
+		}
+		if partition, _ := dispatcher["partition"].(string); partition != "" {
+			continue
+		}
+		dispatcher["partition"] = rule
+		dispatcher["dispatcher"] = ""
+		changed = true
+	}
+	return changed
+}
@@ -0,0 +1,78 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplicaConfigMigrationVersion(t *testing.T) {
+	t.Parallel()
+
+	last := replicaConfigMigrations[len(replicaConfigMigrations)-1]
+	require.Equal(t, CurrentReplicaConfigVersion, last.version)
+	for i, m := range replicaConfigMigrations {
+		require.Equal(t, i+1, m.version)
+	}
+}
+
+func TestMigrateReplicaConfig(t *testing.T) {
+	t.Parallel()
+
+	data := []byte(`{"memory-quota":1073741824,"filter":{"ignore-txn-start-ts":[418881574869139457]},` +
+		`"sink":{"dispatchers":[{"matcher":["a.a"],"dispatcher":"ts"},` +
+		`{"matcher":["a.e"],"dispatcher":"ts","partition":"rowid"}],` +
+		`"dispatch-rules":[{"db-name":"a","tbl-name":"b","rule":"r1"}]}}`)
+	migrated, applied, err := MigrateReplicaConfig(data, 0)
+	require.Nil(t, err)
+	require.Equal(t, []string{
+		"v1: convert sink.dispatch-rules to sink.dispatchers",
+		"v2: rename sink.dispatchers.dispatcher to sink.dispatchers.partition",
+	}, applied)
+
+	cfg := &ReplicaConfig{}
+	require.Nil(t, cfg.UnmarshalJSON(migrated))
+	require.Equal(t, uint64(1073741824), cfg.MemoryQuota)
+	// the large integers keep their precision.
+	require.Equal(t, []uint64{418881574869139457}, cfg.Filter.IgnoreTxnStartTs)
+	require.Equal(t, []*DispatchRule{
+		{Matcher: []string{"a.a"}, PartitionRule: "ts"},
+		// both of the rules are kept, so that it's rejected by the validation.
+		{Matcher: []string{"a.e"}, DispatcherRule: "ts", PartitionRule: "rowid"},
+		{Matcher: []string{"a.b"}, PartitionRule: "r1"},
+	}, cfg.Sink.DispatchRules)
+
+	// The migrations are idempotent.
+	again, applied, err := MigrateReplicaConfig(migrated, 0)
+	require.Nil(t, err)
+	require.Empty(t, applied)
+	require.Equal(t, migrated, again)
+
+	// The migrations not newer than the version are skipped.
+	_, applied, err = MigrateReplicaConfig(data, 1)
+	require.Nil(t, err)
+	require.Equal(t, []string{
+		"v2: rename sink.dispatchers.dispatcher to sink.dispatchers.partition",
+	}, applied)
+
+	_, _, err = MigrateReplicaConfig([]byte("{"), 0)
+	require.Regexp(t, ".*ErrDecodeFailed.*", err)
+
+	out, applied, err := MigrateReplicaConfig([]byte("null"), 0)
+	require.Nil(t, err)
+	require.Empty(t, applied)
+	require.Equal(t, []byte("null"), out)
+}
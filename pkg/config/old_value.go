@@ -0,0 +1,85 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"net/url"
+
+	"github.com/pingcap/log"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"go.uber.org/zap"
+)
+
+const (
+	// OldValueModeAuto enables the old value if the sink requires the
+	// before images of the update and delete events.
+	OldValueModeAuto = "auto"
+	// OldValueModeManual uses `enable-old-value` as it is, the changefeed
+	// is rejected if the protocol can not work without the old value.
+	OldValueModeManual = "manual"
+)
+
+// OldValueRequirement returns true if the before images of the update and
+// delete events are required by the given protocol, and the reason of it.
+func OldValueRequirement(protocol string) (bool, string) {
+	p, err := ParseSinkProtocolFromString(protocol)
+	if err != nil {
+		return false, ""
+	}
+	switch p {
+	case ProtocolCanal, ProtocolCanalJSON:
+		return true, "the old fields of update events are required"
+	case ProtocolMaxwell:
+		return true, "the old data of update events is required"
+	case ProtocolDebezium:
+		return true, "the before images of update events are required"
+	case ProtocolAvro, ProtocolProtobuf:
+		return true, "the keys of delete events are encoded from the before images"
+	default:
+		// The MySQL sink works without the old value, updates are
+		// written as replace statements then, like in the safe mode.
+		return false, ""
+	}
+}
+
+// NegotiateOldValue enables the old value in the auto mode if the
+// protocol of the sink requires it. The protocol in the sink uri takes
+// precedence over the one in the sink config.
+func (c *ReplicaConfig) NegotiateOldValue(sinkURI *url.URL) error {
+	switch c.OldValueMode {
+	case "", OldValueModeAuto:
+	case OldValueModeManual:
+		return nil
+	default:
+		return cerror.ErrInvalidReplicaConfig.FastGenByArgs(
+			"old-value-mode must be one of auto and manual")
+	}
+	if c.EnableOldValue || c.Sink == nil {
+		return nil
+	}
+
+	protocol := c.Sink.Protocol
+	if sinkURI != nil {
+		if p := sinkURI.Query().Get(ProtocolKey); p != "" {
+			protocol = p
+		}
+	}
+	if required, reason := OldValueRequirement(protocol); required {
+		log.Info("old value is enabled automatically",
+			zap.String("protocol", protocol),
+			zap.String("reason", reason))
+		c.EnableOldValue = true
+	}
+	return nil
+}
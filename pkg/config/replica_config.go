@@ -21,8 +21,10 @@ import (
 
 	"github.com/pingcap/errors"
 	"github.com/pingcap/log"
-	"github.com/pingcap/tiflow/pkg/config/outdated"
+	filter "github.com/pingcap/tidb/util/table-filter"
 	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/redo"
+	"github.com/pingcap/tiflow/pkg/util"
 	"go.uber.org/zap"
 )
 
@@ -37,6 +39,7 @@ var defaultReplicaConfig = &ReplicaConfig{
 	MemoryQuota:        DefaultChangefeedMemoryQuota,
 	CaseSensitive:      true,
 	EnableOldValue:     true,
+	OldValueMode:       OldValueModeAuto,
 	CheckGCSafePoint:   true,
 	EnableSyncPoint:    false,
 	SyncPointInterval:  time.Minute * 10,
@@ -88,9 +91,12 @@ func (d *Duration) UnmarshalText(text []byte) error {
 type ReplicaConfig replicaConfig
 
 type replicaConfig struct {
-	MemoryQuota      uint64 `toml:"memory-quota" json:"memory-quota"`
-	CaseSensitive    bool   `toml:"case-sensitive" json:"case-sensitive"`
-	EnableOldValue   bool   `toml:"enable-old-value" json:"enable-old-value"`
+	MemoryQuota    uint64 `toml:"memory-quota" json:"memory-quota"`
+	CaseSensitive  bool   `toml:"case-sensitive" json:"case-sensitive"`
+	EnableOldValue bool   `toml:"enable-old-value" json:"enable-old-value"`
+	// OldValueMode decides whether `EnableOldValue` is adjusted by the
+	// requirement of the sink, it is either "auto" or "manual".
+	OldValueMode     string `toml:"old-value-mode" json:"old-value-mode"`
 	ForceReplicate   bool   `toml:"force-replicate" json:"force-replicate"`
 	CheckGCSafePoint bool   `toml:"check-gc-safe-point" json:"check-gc-safe-point"`
 	EnableSyncPoint  bool   `toml:"enable-sync-point" json:"enable-sync-point"`
@@ -104,6 +110,24 @@ type replicaConfig struct {
 	Mounter            *MounterConfig    `toml:"mounter" json:"mounter"`
 	Sink               *SinkConfig       `toml:"sink" json:"sink"`
 	Consistent         *ConsistentConfig `toml:"consistent" json:"consistent"`
+	// ConsistencyGroup is nil if the changefeed is not in a consistency group.
+	ConsistencyGroup *ConsistencyGroupConfig `toml:"consistency-group" json:"consistency-group,omitempty"`
+	// Metrics is nil if all the labels of the metrics are enabled.
+	Metrics *MetricsConfig `toml:"metrics" json:"metrics,omitempty"`
+	// DDLCoalesce is nil if the DDL jobs are not coalesced, only a sink which
+	// executes DDLs concurrently gets them in batches then.
+	DDLCoalesce *DDLCoalesceConfig `toml:"ddl-coalesce" json:"ddl-coalesce,omitempty"`
+	// RestartPolicy is nil if the changefeed is restarted by the default policy.
+	RestartPolicy *RestartPolicyConfig `toml:"restart-policy" json:"restart-policy,omitempty"`
+	// LifecycleHooks is nil if no hook is run before the state transitions.
+	LifecycleHooks *LifecycleHooksConfig `toml:"lifecycle-hooks" json:"lifecycle-hooks,omitempty"`
+	// SortEngine and SinkEngine choose the engines of the changefeed,
+	// the configuration of the server is used if they are empty.
+	SortEngine string `toml:"sort-engine" json:"sort-engine,omitempty"`
+	SinkEngine string `toml:"sink-engine" json:"sink-engine,omitempty"`
+	// SortSpill is nil if the sorted events are never spilled to an
+	// external storage.
+	SortSpill *SortSpillConfig `toml:"sort-spill" json:"sort-spill,omitempty"`
 }
 
 // Marshal returns the json marshal format of a ReplicationConfig
@@ -115,7 +139,9 @@ func (c *ReplicaConfig) Marshal() (string, error) {
 	return string(cfg), nil
 }
 
-// UnmarshalJSON unmarshals into *ReplicationConfig from json marshal byte slice
+// UnmarshalJSON unmarshals into *ReplicationConfig from json marshal byte slice.
+// The config persisted by an earlier release is not migrated here, see
+// MigrateReplicaConfig.
 func (c *ReplicaConfig) UnmarshalJSON(data []byte) error {
 	// The purpose of casting ReplicaConfig to replicaConfig is to avoid recursive calls UnmarshalJSON,
 	// resulting in stack overflow
@@ -124,12 +150,6 @@ func (c *ReplicaConfig) UnmarshalJSON(data []byte) error {
 	if err != nil {
 		return cerror.WrapError(cerror.ErrDecodeFailed, err)
 	}
-	v1 := outdated.ReplicaConfigV1{}
-	err = v1.Unmarshal(data)
-	if err != nil {
-		return cerror.WrapError(cerror.ErrDecodeFailed, err)
-	}
-	r.fillFromV1(&v1)
 	return nil
 }
 
@@ -149,20 +169,14 @@ func (c *ReplicaConfig) Clone() *ReplicaConfig {
 	return clone
 }
 
-func (c *replicaConfig) fillFromV1(v1 *outdated.ReplicaConfigV1) {
-	if v1 == nil || v1.Sink == nil {
-		return
-	}
-	for _, dispatch := range v1.Sink.DispatchRules {
-		c.Sink.DispatchRules = append(c.Sink.DispatchRules, &DispatchRule{
-			Matcher:        []string{fmt.Sprintf("%s.%s", dispatch.Schema, dispatch.Name)},
-			DispatcherRule: dispatch.Rule,
-		})
-	}
-}
-
 // ValidateAndAdjust verifies and adjusts the replica configuration.
 func (c *ReplicaConfig) ValidateAndAdjust(sinkURI *url.URL) error {
+	if err := c.NegotiateOldValue(sinkURI); err != nil {
+		return err
+	}
+	if err := c.validateEngines(GetGlobalServerConfig().Debug); err != nil {
+		return err
+	}
 	// check sink uri
 	if c.Sink != nil {
 		err := c.Sink.validateAndAdjust(sinkURI, c.EnableOldValue)
@@ -175,6 +189,49 @@ func (c *ReplicaConfig) ValidateAndAdjust(sinkURI *url.URL) error {
 		if err != nil {
 			return err
 		}
+		// The redo logs are written before the values are transformed by the
+		// sink workers, so the values would be stored in cleartext.
+		if redo.IsConsistentEnabled(c.Consistent.Level) &&
+			c.Sink != nil && len(c.Sink.ColumnTransforms) > 0 {
+			return cerror.ErrInvalidReplicaConfig.FastGenByArgs(
+				"column-transforms can't be used along with the consistent replication")
+		}
+	}
+	if c.ConsistencyGroup != nil {
+		err := c.ConsistencyGroup.ValidateAndAdjust()
+		if err != nil {
+			return err
+		}
+	}
+	if c.Metrics != nil {
+		err := c.Metrics.ValidateAndAdjust()
+		if err != nil {
+			return err
+		}
+	}
+	if c.DDLCoalesce != nil {
+		err := c.DDLCoalesce.ValidateAndAdjust()
+		if err != nil {
+			return err
+		}
+	}
+	if c.RestartPolicy != nil {
+		err := c.RestartPolicy.ValidateAndAdjust()
+		if err != nil {
+			return err
+		}
+	}
+	if c.LifecycleHooks != nil {
+		err := c.LifecycleHooks.ValidateAndAdjust()
+		if err != nil {
+			return err
+		}
+	}
+	if c.SortSpill != nil {
+		err := c.SortSpill.ValidateAndAdjust()
+		if err != nil {
+			return err
+		}
 	}
 
 	// check sync point config
@@ -194,6 +251,23 @@ func (c *ReplicaConfig) ValidateAndAdjust(sinkURI *url.URL) error {
 						minSyncPointRetention.String()))
 		}
 	}
+	if c.Filter != nil {
+		for _, rule := range c.Filter.TableStartTs {
+			if len(rule.Matcher) == 0 || rule.StartTs == 0 {
+				return cerror.ErrInvalidReplicaConfig.FastGenByArgs(
+					"matcher and start-ts of table-start-ts must be specified")
+			}
+			if _, err := filter.Parse(rule.Matcher); err != nil {
+				return cerror.ErrInvalidReplicaConfig.FastGenByArgs(
+					fmt.Sprintf("invalid table-start-ts matcher %v: %s", rule.Matcher, err))
+			}
+		}
+		if c.Filter.TableAttributes != nil {
+			if err := c.Filter.TableAttributes.ValidateAndAdjust(); err != nil {
+				return err
+			}
+		}
+	}
 	if c.MemoryQuota == uint64(0) {
 		c.FixMemoryQuota()
 	}
@@ -206,6 +280,57 @@ func (c *ReplicaConfig) FixMemoryQuota() {
 	c.MemoryQuota = DefaultChangefeedMemoryQuota
 }
 
+// ExtraSinkReplicaConfig returns the replica config of an extra sink, which
+// is the one of the changefeed with the protocol of the extra sink.
+func (c *ReplicaConfig) ExtraSinkReplicaConfig(extra *ExtraSinkConfig) *ReplicaConfig {
+	cfg := c.Clone()
+	cfg.Sink.TeeSinkURI = ""
+	cfg.Sink.ExtraSinks = nil
+	cfg.Sink.Protocol = extra.Protocol
+	return cfg
+}
+
+// MaskSensitiveData masks the secrets in the URIs of the replica config, which
+// are the tee sink URI, the URIs of the extra sinks and the storage URI of the
+// sort spill. The sink URI of the changefeed is not a part of it.
+func (c *ReplicaConfig) MaskSensitiveData() {
+	if c.Sink != nil {
+		if c.Sink.TeeSinkURI != "" {
+			c.Sink.TeeSinkURI = util.MaskSinkURIForLog(c.Sink.TeeSinkURI)
+		}
+		for _, extra := range c.Sink.ExtraSinks {
+			extra.SinkURI = util.MaskSinkURIForLog(extra.SinkURI)
+		}
+	}
+	if c.SortSpill != nil && c.SortSpill.Storage != "" {
+		c.SortSpill.Storage = util.MaskSinkURIForLog(c.SortSpill.Storage)
+	}
+}
+
+// RestoreMaskedData replaces the URIs masked by MaskSensitiveData with the
+// ones of the old config, so that a config read from the API can be sent
+// back to update the changefeed without losing the secrets.
+func (c *ReplicaConfig) RestoreMaskedData(old *ReplicaConfig) {
+	restore := func(uri *string, oldURI string) {
+		if *uri != "" && oldURI != "" && *uri == util.MaskSinkURIForLog(oldURI) {
+			*uri = oldURI
+		}
+	}
+	if c.Sink != nil && old.Sink != nil {
+		restore(&c.Sink.TeeSinkURI, old.Sink.TeeSinkURI)
+		for _, extra := range c.Sink.ExtraSinks {
+			for _, oldExtra := range old.Sink.ExtraSinks {
+				if extra.Name == oldExtra.Name {
+					restore(&extra.SinkURI, oldExtra.SinkURI)
+				}
+			}
+		}
+	}
+	if c.SortSpill != nil && old.SortSpill != nil {
+		restore(&c.SortSpill.Storage, old.SortSpill.Storage)
+	}
+}
+
 // GetSinkURIAndAdjustConfigWithSinkURI parses sinkURI as a URI and adjust config with sinkURI.
 func GetSinkURIAndAdjustConfigWithSinkURI(
 	sinkURIStr string,
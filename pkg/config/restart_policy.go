@@ -0,0 +1,99 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"time"
+
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+)
+
+const (
+	// DefaultRestartBackoffBaseDelay is the default initial interval before
+	// restarting a changefeed in the error state.
+	DefaultRestartBackoffBaseDelay = 10 * time.Second
+	// DefaultRestartBackoffMaxDelay is the default max interval before
+	// restarting a changefeed in the error state.
+	DefaultRestartBackoffMaxDelay = 30 * time.Minute
+	// DefaultRestartBackoffJitter is the default randomization factor of the
+	// restart intervals, which avoids restarting the changefeeds together.
+	DefaultRestartBackoffJitter = 0.1
+	// DefaultRestartWindow is the default window in which the restarts of a
+	// changefeed are counted.
+	DefaultRestartWindow = time.Hour
+)
+
+// RestartPolicyConfig controls how the owner restarts a changefeed in the error
+// state. The changefeed is restarted after an exponential backoff, and it turns
+// into the failed state if it has been restarted MaxRestarts times in Window,
+// so that a crash-looping changefeed doesn't hammer the downstream forever.
+type RestartPolicyConfig struct {
+	// MaxRestarts is the max number of restarts in Window, 0 means the
+	// changefeed is always restarted.
+	MaxRestarts int `toml:"max-restarts" json:"max-restarts"`
+	// Window is the sliding window in which the restarts are counted.
+	Window time.Duration `toml:"window" json:"window"`
+	// BackoffBaseDelay is the initial interval before a restart, it doubles
+	// after each restart.
+	BackoffBaseDelay time.Duration `toml:"backoff-base-delay" json:"backoff-base-delay"`
+	// BackoffMaxDelay is the max interval before a restart.
+	BackoffMaxDelay time.Duration `toml:"backoff-max-delay" json:"backoff-max-delay"`
+	// BackoffJitter is the randomization factor of the intervals in [0, 1).
+	BackoffJitter float64 `toml:"backoff-jitter" json:"backoff-jitter"`
+}
+
+// NewDefaultRestartPolicyConfig returns the default restart policy, which
+// always restarts the changefeed.
+func NewDefaultRestartPolicyConfig() *RestartPolicyConfig {
+	return &RestartPolicyConfig{
+		Window:           DefaultRestartWindow,
+		BackoffBaseDelay: DefaultRestartBackoffBaseDelay,
+		BackoffMaxDelay:  DefaultRestartBackoffMaxDelay,
+		BackoffJitter:    DefaultRestartBackoffJitter,
+	}
+}
+
+// ValidateAndAdjust validates the restart policy config and adjusts it if necessary.
+func (c *RestartPolicyConfig) ValidateAndAdjust() error {
+	if c.MaxRestarts < 0 {
+		return cerror.ErrInvalidReplicaConfig.FastGenByArgs(
+			fmt.Sprintf("The restart-policy.max-restarts:%d must not be negative",
+				c.MaxRestarts))
+	}
+	if c.Window < 0 || c.BackoffBaseDelay < 0 || c.BackoffMaxDelay < 0 {
+		return cerror.ErrInvalidReplicaConfig.FastGenByArgs(
+			"The durations of restart-policy must not be negative")
+	}
+	if c.Window == 0 {
+		c.Window = DefaultRestartWindow
+	}
+	if c.BackoffBaseDelay == 0 {
+		c.BackoffBaseDelay = DefaultRestartBackoffBaseDelay
+	}
+	if c.BackoffMaxDelay == 0 {
+		c.BackoffMaxDelay = DefaultRestartBackoffMaxDelay
+	}
+	if c.BackoffBaseDelay > c.BackoffMaxDelay {
+		return cerror.ErrInvalidReplicaConfig.FastGenByArgs(
+			fmt.Sprintf("The restart-policy.backoff-base-delay:%s must not be greater "+
+				"than restart-policy.backoff-max-delay:%s", c.BackoffBaseDelay, c.BackoffMaxDelay))
+	}
+	if c.BackoffJitter < 0 || c.BackoffJitter >= 1 {
+		return cerror.ErrInvalidReplicaConfig.FastGenByArgs(
+			fmt.Sprintf("The restart-policy.backoff-jitter:%v must be in [0, 1)",
+				c.BackoffJitter))
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"time"
+
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/retry"
+)
+
+const (
+	// RetryExhaustedWarning keeps the changefeed retryable when the retry
+	// budget is exhausted, the changefeed turns into the error state and
+	// it's restarted by the owner later.
+	RetryExhaustedWarning = "warning"
+	// RetryExhaustedFailed fails the changefeed when the retry budget is
+	// exhausted, it must be resumed manually.
+	RetryExhaustedFailed = "failed"
+)
+
+// RetryBudgetConfig is the retry budget of the writes to the downstream of
+// a sink. The zero fields are left to the defaults of the sink.
+type RetryBudgetConfig struct {
+	// MaxAttempts is the max number of attempts of a write, including the
+	// first one.
+	MaxAttempts uint64 `toml:"max-attempts" json:"max-attempts"`
+	// MaxDuration is the max duration of retrying a write.
+	MaxDuration time.Duration `toml:"max-duration" json:"max-duration"`
+	// BackoffBaseDelay is the initial delay between the attempts.
+	BackoffBaseDelay time.Duration `toml:"backoff-base-delay" json:"backoff-base-delay"`
+	// BackoffMaxDelay is the max delay between the attempts.
+	BackoffMaxDelay time.Duration `toml:"backoff-max-delay" json:"backoff-max-delay"`
+	// OnExhausted is the state the changefeed turns into when the budget
+	// is exhausted, it's either "warning" or "failed".
+	OnExhausted string `toml:"on-exhausted" json:"on-exhausted"`
+}
+
+// ValidateAndAdjust validates the retry budget config and adjusts it if necessary.
+func (c *RetryBudgetConfig) ValidateAndAdjust() error {
+	switch c.OnExhausted {
+	case "":
+		c.OnExhausted = RetryExhaustedWarning
+	case RetryExhaustedWarning, RetryExhaustedFailed:
+	default:
+		return cerror.ErrSinkInvalidConfig.GenWithStack(
+			"retry-budget.on-exhausted must be %s or %s, but got %s",
+			RetryExhaustedWarning, RetryExhaustedFailed, c.OnExhausted)
+	}
+	if c.MaxDuration < 0 || c.BackoffBaseDelay < 0 || c.BackoffMaxDelay < 0 {
+		return cerror.ErrSinkInvalidConfig.GenWithStack(
+			"the durations of retry-budget can not be negative")
+	}
+	if c.BackoffMaxDelay > 0 && c.BackoffBaseDelay > c.BackoffMaxDelay {
+		return cerror.ErrSinkInvalidConfig.GenWithStack(
+			"retry-budget.backoff-base-delay:%s must not be greater than "+
+				"retry-budget.backoff-max-delay:%s", c.BackoffBaseDelay, c.BackoffMaxDelay)
+	}
+	return nil
+}
+
+// RetryOptions returns the retry options of the budget. They are supposed
+// to be applied after the defaults of the sink, so the configured fields
+// override them. It's safe to call on a nil config.
+func (c *RetryBudgetConfig) RetryOptions() []retry.Option {
+	if c == nil {
+		return nil
+	}
+	var opts []retry.Option
+	if c.MaxAttempts > 0 {
+		opts = append(opts, retry.WithMaxTries(c.MaxAttempts))
+	}
+	if c.MaxDuration > 0 {
+		opts = append(opts, retry.WithTotalRetryDuratoin(c.MaxDuration))
+	}
+	if c.BackoffBaseDelay > 0 {
+		opts = append(opts, retry.WithBackoffBaseDelay(c.BackoffBaseDelay.Milliseconds()))
+	}
+	if c.BackoffMaxDelay > 0 {
+		opts = append(opts, retry.WithBackoffMaxDelay(c.BackoffMaxDelay.Milliseconds()))
+	}
+	return opts
+}
+
+// Escalate escalates the error of a write by the policy if the budget is
+// exhausted. With the failed policy, the error is wrapped as unretryable so
+// the owner fails the changefeed, otherwise it's returned as it is.
+func (c *RetryBudgetConfig) Escalate(err error) error {
+	if err == nil || c == nil || c.OnExhausted != RetryExhaustedFailed {
+		return err
+	}
+	if !cerror.Is(err, cerror.ErrReachMaxTry) {
+		return err
+	}
+	return cerror.WrapChangefeedUnretryableErr(err)
+}
@@ -106,6 +106,8 @@ var defaultServerConfig = &ServerConfig{
 	CaptureSessionTTL:      10,
 	OwnerFlushInterval:     TomlDuration(50 * time.Millisecond),
 	ProcessorFlushInterval: TomlDuration(50 * time.Millisecond),
+	// Avoid overwhelming the downstream when a capture with many changefeeds restarts.
+	ChangefeedInitConcurrency: 16,
 	Sorter: &SorterConfig{
 		NumConcurrentWorker:    4,
 		ChunkSizeLimit:         128 * 1024 * 1024,       // 128MB
@@ -153,6 +155,8 @@ var defaultServerConfig = &ServerConfig{
 		EnableNewSink:       true,
 		EnablePullBasedSink: true,
 	},
+	Election:  NewDefaultElectionConfig(),
+	GCTuner:   NewDefaultGCTunerConfig(),
 	ClusterID: "default",
 }
 
@@ -175,11 +179,27 @@ type ServerConfig struct {
 	OwnerFlushInterval     TomlDuration `toml:"owner-flush-interval" json:"owner-flush-interval"`
 	ProcessorFlushInterval TomlDuration `toml:"processor-flush-interval" json:"processor-flush-interval"`
 
+	// CheckpointPersistInterval is the minimal interval for the owner to
+	// persist the status of changefeeds into etcd. Status updates in the
+	// interval are coalesced, except the ones that reach a barrier, and
+	// the pending updates of all changefeeds are written in batched etcd
+	// transactions. 0 means the status is persisted on every owner flush.
+	CheckpointPersistInterval TomlDuration `toml:"checkpoint-persist-interval" json:"checkpoint-persist-interval"`
+	// CheckpointPersistJitter is the max random jitter added to each
+	// CheckpointPersistInterval.
+	CheckpointPersistJitter TomlDuration `toml:"checkpoint-persist-jitter" json:"checkpoint-persist-jitter"`
+	// ChangefeedInitConcurrency is the max number of the changefeeds whose
+	// sinks are initialized concurrently on a capture, the others wait in a
+	// queue. 0 means unlimited.
+	ChangefeedInitConcurrency int `toml:"changefeed-init-concurrency" json:"changefeed-init-concurrency"`
+
 	Sorter              *SorterConfig   `toml:"sorter" json:"sorter"`
 	Security            *SecurityConfig `toml:"security" json:"security"`
 	PerTableMemoryQuota uint64          `toml:"per-table-memory-quota" json:"per-table-memory-quota"`
 	KVClient            *KVClientConfig `toml:"kv-client" json:"kv-client"`
 	Debug               *DebugConfig    `toml:"debug" json:"debug"`
+	Election            *ElectionConfig `toml:"election" json:"election"`
+	GCTuner             *GCTunerConfig  `toml:"gc-tuner" json:"gc-tuner"`
 	ClusterID           string          `toml:"cluster-id" json:"cluster-id"`
 }
 
@@ -237,6 +257,14 @@ func (c *ServerConfig) ValidateAndAdjust() error {
 	if c.AdvertiseAddr == "" {
 		c.AdvertiseAddr = c.Addr
 	}
+	if c.CheckpointPersistInterval < 0 || c.CheckpointPersistJitter < 0 {
+		return cerror.ErrInvalidServerOption.GenWithStack(
+			"checkpoint-persist-interval and checkpoint-persist-jitter must not be negative")
+	}
+	if c.ChangefeedInitConcurrency < 0 {
+		return cerror.ErrInvalidServerOption.GenWithStack(
+			"changefeed-init-concurrency must not be negative")
+	}
 	// Advertise address must be specified.
 	if idx := strings.LastIndex(c.AdvertiseAddr, ":"); idx >= 0 {
 		ip := net.ParseIP(c.AdvertiseAddr[:idx])
@@ -296,6 +324,20 @@ func (c *ServerConfig) ValidateAndAdjust() error {
 		return errors.Trace(err)
 	}
 
+	if c.Election == nil {
+		c.Election = defaultCfg.Election
+	}
+	if err = c.Election.ValidateAndAdjust(); err != nil {
+		return errors.Trace(err)
+	}
+
+	if c.GCTuner == nil {
+		c.GCTuner = defaultCfg.GCTuner
+	}
+	if err = c.GCTuner.ValidateAndAdjust(); err != nil {
+		return errors.Trace(err)
+	}
+
 	return nil
 }
 
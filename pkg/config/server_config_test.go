@@ -172,3 +172,21 @@ func TestIsValidClusterID(t *testing.T) {
 		require.Equal(t, c.valid, isValidClusterID(c.id))
 	}
 }
+
+func TestElectionConfigValidateAndAdjust(t *testing.T) {
+	t.Parallel()
+	conf := &ElectionConfig{}
+	require.Nil(t, conf.ValidateAndAdjust())
+	require.Equal(t, ElectionModeEtcd, conf.Mode)
+
+	conf = NewDefaultElectionConfig()
+	conf.Mode = ElectionModeKubernetes
+	require.Nil(t, conf.ValidateAndAdjust())
+	conf.RetryPeriod = conf.LeaseDuration
+	require.Regexp(t, ".*retry-period must be less than lease-duration.*", conf.ValidateAndAdjust())
+	conf.LeaseName = ""
+	require.Regexp(t, ".*lease-name must be specified.*", conf.ValidateAndAdjust())
+
+	conf.Mode = "unknown"
+	require.Regexp(t, ".*unknown election mode.*", conf.ValidateAndAdjust())
+}
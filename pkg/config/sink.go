@@ -16,10 +16,13 @@ package config
 import (
 	"fmt"
 	"net/url"
+	"path"
+	"regexp"
 	"strings"
 
 	"github.com/pingcap/errors"
 	"github.com/pingcap/log"
+	filter "github.com/pingcap/tidb/util/table-filter"
 	cerror "github.com/pingcap/tiflow/pkg/errors"
 	"github.com/pingcap/tiflow/pkg/sink"
 	"go.uber.org/zap"
@@ -77,8 +80,10 @@ func (l AtomicityLevel) validate(scheme string) error {
 	case noneTxnAtomicity:
 		// Do nothing here to avoid modifying the persistence parameters.
 	case tableTxnAtomicity:
-		// MqSink only support `noneTxnAtomicity`.
-		if sink.IsMQScheme(scheme) {
+		// MqSink, ClickHouseSink, ElasticsearchSink and WebhookSink only support
+		// `noneTxnAtomicity`.
+		if sink.IsMQScheme(scheme) || sink.IsClickHouseScheme(scheme) ||
+			sink.IsElasticsearchScheme(scheme) || sink.IsWebhookScheme(scheme) {
 			errMsg := fmt.Sprintf("%s level atomicity is not supported by %s scheme", l, scheme)
 			return cerror.ErrSinkURIInvalid.GenWithStackByArgs(errMsg)
 		}
@@ -89,13 +94,6 @@ func (l AtomicityLevel) validate(scheme string) error {
 	return nil
 }
 
-// ForceEnableOldValueProtocols specifies which protocols need to be forced to enable old value.
-var ForceEnableOldValueProtocols = []string{
-	ProtocolCanal.String(),
-	ProtocolCanalJSON.String(),
-	ProtocolMaxwell.String(),
-}
-
 // SinkConfig represents sink config for a changefeed
 type SinkConfig struct {
 	TxnAtomicity AtomicityLevel `toml:"transaction-atomicity" json:"transaction-atomicity"`
@@ -109,12 +107,105 @@ type SinkConfig struct {
 	Terminator               string            `toml:"terminator" json:"terminator"`
 	DateSeparator            string            `toml:"date-separator" json:"date-separator"`
 	EnablePartitionSeparator bool              `toml:"enable-partition-separator" json:"enable-partition-separator"`
+	// RetryBudget is the retry budget of the writes to the downstream,
+	// the defaults of the sink are used if it's nil.
+	RetryBudget *RetryBudgetConfig `toml:"retry-budget" json:"retry-budget,omitempty"`
+	// AvroKeyRules customize the columns which form the avro key of the
+	// matched tables, the handle key is used for the other tables.
+	AvroKeyRules []*AvroKeyRule `toml:"avro-key-rules" json:"avro-key-rules,omitempty"`
+	// ColumnTransforms transform the values of the matched columns, e.g. to
+	// mask the sensitive ones, before they are encoded.
+	ColumnTransforms []*ColumnTransform `toml:"column-transforms" json:"column-transforms,omitempty"`
+	// TeeSinkURI is the URI of an external storage which all the messages
+	// emitted by the MQ sink are archived to, so that they can be replayed
+	// later, it's disabled if empty. The archive has its own checkpoint, and
+	// the checkpoint of the changefeed only passes the messages which are
+	// archived or recorded as dropped in it, so it lags by about a second
+	// and stops while the storage is unavailable.
+	TeeSinkURI string `toml:"tee-sink-uri" json:"tee-sink-uri,omitempty"`
+	// ExtraSinks are the sinks which the changefeed replicates to besides
+	// the one of its sink URI. They share the scan and the sort of the
+	// upstream, and the checkpoint of the changefeed, which is the min one
+	// of all the sinks. So a slow extra sink holds back the changefeed, and
+	// an error of any sink restarts the whole changefeed. The sampled extra
+	// sinks are the exceptions, see ExtraSinkConfig.SampleRate.
+	ExtraSinks []*ExtraSinkConfig `toml:"extra-sinks" json:"extra-sinks,omitempty"`
+	// ParquetConfig is the config of the parquet protocol, the defaults are
+	// used if it's nil.
+	ParquetConfig *ParquetConfig `toml:"parquet" json:"parquet,omitempty"`
+	// Headers are the CDC metadata attached to the messages of the MQ
+	// sinks, no metadata is attached if it's nil.
+	Headers *HeadersConfig `toml:"headers" json:"headers,omitempty"`
+	// ConsumerContracts are the contracts of the downstream consumers of
+	// the topics, which the DDLs are checked against.
+	ConsumerContracts []*ConsumerContract `toml:"consumer-contracts" json:"consumer-contracts,omitempty"`
+	// UpdateKeyChange is the policy of the MQ sinks for the updates whose
+	// old and new values are dispatched to different partitions, which is
+	// one of the UpdateKeyChange* policies. The updates are kept if it's
+	// empty.
+	UpdateKeyChange string `toml:"update-key-change" json:"update-key-change,omitempty"`
+	// ClientIdentity customizes how the changefeed identifies itself in the
+	// connections to the downstream.
+	ClientIdentity *ClientIdentityConfig `toml:"client-identity" json:"client-identity,omitempty"`
 	// TiDBSourceID is the source ID of the upstream TiDB,
 	// which is used to set the `tidb_cdc_write_source` session variable.
 	// Note: This field is only used internally and only used in the MySQL sink.
 	TiDBSourceID uint64 `toml:"-" json:"-"`
 }
 
+const (
+	// UpdateKeyChangeKeep keeps an update changing the dispatch key as an
+	// update, which is dispatched by its new values. The consumers of the
+	// partition of the old values never see the change, so they should
+	// remove the old key by the before image if they need to.
+	UpdateKeyChangeKeep = "keep"
+	// UpdateKeyChangeSplit splits an update changing the dispatch key into
+	// a delete dispatched by the old values and an insert dispatched by the
+	// new values. The delete and the insert are sent to different
+	// partitions, so the consumers may receive the insert first.
+	UpdateKeyChangeSplit = "split"
+)
+
+// ClientIdentityConfig customizes how a changefeed identifies itself to the
+// downstream, so that the operators can attribute the traffic and apply the
+// quotas by changefeed. The templates can refer to the placeholders
+// {namespace}, {changefeed} and {capture}, which are replaced by the
+// namespace and the ID of the changefeed and the address of the capture.
+type ClientIdentityConfig struct {
+	// KafkaClientID is the template of the client.id of the Kafka clients,
+	// the kafka-client-id parameter of the sink URI takes precedence.
+	KafkaClientID string `toml:"kafka-client-id" json:"kafka-client-id,omitempty"`
+	// HTTPUserAgent is the template of the User-Agent of the requests of the
+	// webhook, ClickHouse and Elasticsearch sinks.
+	HTTPUserAgent string `toml:"http-user-agent" json:"http-user-agent,omitempty"`
+}
+
+var clientIdentityPlaceholderRE = regexp.MustCompile(`\{[^{}]*\}`)
+
+// ExpandClientIdentity replaces the placeholders of the client identity
+// template.
+func ExpandClientIdentity(template, namespace, changefeed, capture string) string {
+	return strings.NewReplacer(
+		"{namespace}", namespace,
+		"{changefeed}", changefeed,
+		"{capture}", capture,
+	).Replace(template)
+}
+
+func (c *ClientIdentityConfig) validate() error {
+	for name, template := range map[string]string{
+		"kafka-client-id": c.KafkaClientID,
+		"http-user-agent": c.HTTPUserAgent,
+	} {
+		expanded := ExpandClientIdentity(template, "", "", "")
+		if placeholder := clientIdentityPlaceholderRE.FindString(expanded); placeholder != "" {
+			return cerror.ErrSinkInvalidConfig.GenWithStack(
+				"unknown placeholder %s in client-identity %s %s", placeholder, name, template)
+		}
+	}
+	return nil
+}
+
 // CSVConfig defines a series of configuration items for csv codec.
 type CSVConfig struct {
 	// delimiter between fields
@@ -127,6 +218,55 @@ type CSVConfig struct {
 	IncludeCommitTs bool `toml:"include-commit-ts" json:"include-commit-ts"`
 }
 
+const (
+	// DefaultParquetRowGroupSize is the default max size of a row group of
+	// the parquet files.
+	DefaultParquetRowGroupSize = 128 * 1024 * 1024
+	// DefaultParquetCompression is the default compression codec of the
+	// parquet files.
+	DefaultParquetCompression = "snappy"
+)
+
+// ParquetConfig defines a series of configuration items for parquet codec.
+type ParquetConfig struct {
+	// RowGroupSize is the max size in bytes of a row group, a parquet file
+	// is split into row groups of this size at most.
+	RowGroupSize int64 `toml:"row-group-size" json:"row-group-size"`
+	// Compression is the compression codec of the column chunks, which is
+	// one of none, snappy, gzip and zstd.
+	Compression string `toml:"compression" json:"compression"`
+}
+
+// NewDefaultParquetConfig returns the default parquet config.
+func NewDefaultParquetConfig() *ParquetConfig {
+	return &ParquetConfig{
+		RowGroupSize: DefaultParquetRowGroupSize,
+		Compression:  DefaultParquetCompression,
+	}
+}
+
+// HeadersConfig defines the CDC metadata attached to the messages of the MQ
+// sinks as headers, so that the consumers can route and filter the messages
+// without decoding the payloads.
+type HeadersConfig struct {
+	// CommitTs attaches the commit ts of the events.
+	CommitTs bool `toml:"commit-ts" json:"commit-ts"`
+	// SchemaVersion attaches the version of the table schema of the events.
+	SchemaVersion bool `toml:"schema-version" json:"schema-version"`
+	// ChangefeedID attaches the namespace and the ID of the changefeed.
+	ChangefeedID bool `toml:"changefeed-id" json:"changefeed-id"`
+	// EventType attaches the type of the events, which is one of insert,
+	// update, delete, ddl and resolved.
+	EventType bool `toml:"event-type" json:"event-type"`
+	// SourceClusterID attaches the source ID of the upstream cluster.
+	SourceClusterID bool `toml:"source-cluster-id" json:"source-cluster-id"`
+	// TxnMetadata attaches the start ts, the commit ts and the approximate
+	// row count of the upstream transaction of the events, and the source ID
+	// of the upstream cluster, because the TiDB instance which executed the
+	// transaction isn't recorded in the change logs.
+	TxnMetadata bool `toml:"txn-metadata" json:"txn-metadata"`
+}
+
 // DateSeparator specifies the date separator in storage destination path
 type DateSeparator int
 
@@ -180,27 +320,161 @@ type DispatchRule struct {
 	// In the future release, the DispatcherRule is expected to be removed .
 	PartitionRule string `toml:"partition" json:"partition"`
 	TopicRule     string `toml:"topic" json:"topic"`
+	// TopicPreset is one of the topic presets, it's an alternative to
+	// TopicRule and is replaced by the topic expression of the preset.
+	TopicPreset string `toml:"topic-preset" json:"topic-preset,omitempty"`
+	// PartitionNum and ReplicationFactor override the ones in the sink URI
+	// when the topics of the rule are auto-created, zero means no override.
+	PartitionNum      int32 `toml:"partition-num" json:"partition-num,omitempty"`
+	ReplicationFactor int16 `toml:"replication-factor" json:"replication-factor,omitempty"`
+	// PartitionColumns are the columns hashed by the `columns` partition
+	// dispatcher.
+	PartitionColumns []string `toml:"partition-columns" json:"partition-columns,omitempty"`
+	// PartitionExpression is the expression evaluated over the row values by
+	// the `expression` partition dispatcher.
+	PartitionExpression string `toml:"partition-expression" json:"partition-expression,omitempty"`
+	// PartitionIncludeTable controls whether the `columns` and `expression`
+	// partition dispatchers hash the schema and table names together with the
+	// row values. If it's false, the rows of the same key are dispatched to
+	// the same partition even after the table is renamed.
+	PartitionIncludeTable bool `toml:"partition-include-table" json:"partition-include-table,omitempty"`
 }
 
-// ColumnSelector represents a column selector for a table.
+const (
+	// TopicPresetSingle dispatches the events to the default topic.
+	TopicPresetSingle = "single"
+	// TopicPresetDB dispatches the events to a topic per database.
+	TopicPresetDB = "db"
+	// TopicPresetDBTable dispatches the events to a topic per table.
+	TopicPresetDBTable = "db_table"
+)
+
+// topicPresets are the topic expressions of the topic presets.
+var topicPresets = map[string]string{
+	TopicPresetSingle:  "",
+	TopicPresetDB:      "{schema}",
+	TopicPresetDBTable: "{schema}_{table}",
+}
+
+// ColumnSelector represents a column selector for a table. The columns are
+// rules like the table filter rules but for the column names, a rule prefixed
+// with `!` excludes the matched columns, and the last matched rule decides
+// whether a column is selected. All the columns are selected if the columns
+// are empty. The columns matched by ExcludeColumns are never selected.
 type ColumnSelector struct {
+	Matcher        []string `toml:"matcher" json:"matcher"`
+	Columns        []string `toml:"columns" json:"columns"`
+	ExcludeColumns []string `toml:"exclude-columns" json:"exclude-columns,omitempty"`
+}
+
+// SelectColumn returns whether the column is selected by the selector, the
+// column names are case-insensitive. The selector must have been validated.
+func (c *ColumnSelector) SelectColumn(name string) bool {
+	name = strings.ToLower(name)
+	for _, pattern := range c.ExcludeColumns {
+		if matchColumnPattern(pattern, name) {
+			return false
+		}
+	}
+	return len(c.Columns) == 0 || matchColumnRules(c.Columns, name)
+}
+
+func (c *ColumnSelector) validate() error {
+	if len(c.Matcher) == 0 || (len(c.Columns) == 0 && len(c.ExcludeColumns) == 0) {
+		return cerror.ErrSinkInvalidConfig.GenWithStack(
+			"matcher and columns or exclude-columns of column-selectors must be specified")
+	}
+	if _, err := filter.Parse(c.Matcher); err != nil {
+		return cerror.ErrSinkInvalidConfig.GenWithStack(
+			"invalid column-selectors matcher %v: %s", c.Matcher, err)
+	}
+	for _, rule := range append(append([]string(nil), c.Columns...), c.ExcludeColumns...) {
+		if !isValidColumnRule(rule) {
+			return cerror.ErrSinkInvalidConfig.GenWithStack(
+				"invalid column-selectors column rule %s of the matcher %v", rule, c.Matcher)
+		}
+	}
+	return nil
+}
+
+// matchColumnRules returns whether the column is matched by the column rules,
+// the last matched rule decides it, and a rule prefixed with `!` unmatches
+// the column. The name must be in lower case.
+func matchColumnRules(rules []string, name string) bool {
+	for i := len(rules) - 1; i >= 0; i-- {
+		pattern, exclude := parseColumnRule(rules[i])
+		if matchColumnPattern(pattern, name) {
+			return !exclude
+		}
+	}
+	return false
+}
+
+func isValidColumnRule(rule string) bool {
+	pattern, _ := parseColumnRule(rule)
+	_, err := path.Match(toGlobPattern(pattern), "")
+	return err == nil && pattern != ""
+}
+
+// parseColumnRule splits the `!` prefix of a column rule.
+func parseColumnRule(rule string) (pattern string, exclude bool) {
+	rule = strings.TrimSpace(rule)
+	if strings.HasPrefix(rule, "!") {
+		return strings.TrimSpace(rule[1:]), true
+	}
+	return rule, false
+}
+
+// toGlobPattern converts the negated character classes `[!a-z]` of the column
+// rules to the ones of path.Match.
+func toGlobPattern(pattern string) string {
+	return strings.ReplaceAll(pattern, "[!", "[^")
+}
+
+func matchColumnPattern(pattern, name string) bool {
+	matched, err := path.Match(toGlobPattern(strings.ToLower(pattern)), name)
+	return err == nil && matched
+}
+
+// AvroKeyRule represents the columns of the avro key for a table.
+type AvroKeyRule struct {
 	Matcher []string `toml:"matcher" json:"matcher"`
 	Columns []string `toml:"columns" json:"columns"`
 }
 
+// PrimarySinkName is the name of the sink of the sink URI of a changefeed,
+// which can't be used by the extra sinks.
+const PrimarySinkName = "primary"
+
+// ExtraSinkConfig represents an extra sink of a changefeed.
+type ExtraSinkConfig struct {
+	// Name identifies the sink in the status of the changefeed.
+	Name    string `toml:"name" json:"name"`
+	SinkURI string `toml:"sink-uri" json:"sink-uri"`
+	// Protocol is the protocol of the MQ and storage sinks, the one in the
+	// sink URI takes precedence.
+	Protocol string `toml:"protocol" json:"protocol,omitempty"`
+	// SampleRate is the fraction of the rows replicated to the sink, which
+	// are sampled deterministically by the hash of their keys, so that the
+	// changes of a sampled row are always replicated. 0 means all the rows
+	// are replicated. A sampled sink is a shadow of the primary one for
+	// testing, so it doesn't hold back the checkpoint of the changefeed,
+	// and it's detached rather than failing the changefeed on errors.
+	SampleRate float64 `toml:"sample-rate" json:"sample-rate,omitempty"`
+}
+
 func (s *SinkConfig) validateAndAdjust(sinkURI *url.URL, enableOldValue bool) error {
 	if err := s.validateAndAdjustSinkURI(sinkURI); err != nil {
 		return err
 	}
 
 	if !enableOldValue {
-		for _, protocolStr := range ForceEnableOldValueProtocols {
-			if protocolStr == s.Protocol {
-				log.Error(fmt.Sprintf("Old value is not enabled when using `%s` protocol. "+
-					"Please update changefeed config", s.Protocol))
-				return cerror.WrapError(cerror.ErrKafkaInvalidConfig,
-					errors.New(fmt.Sprintf("%s protocol requires old value to be enabled", s.Protocol)))
-			}
+		if required, reason := OldValueRequirement(s.Protocol); required {
+			log.Error(fmt.Sprintf("Old value is not enabled when using `%s` protocol. "+
+				"Please update changefeed config", s.Protocol))
+			return cerror.WrapError(cerror.ErrKafkaInvalidConfig,
+				errors.New(fmt.Sprintf("%s protocol requires old value to be enabled, "+
+					"since %s", s.Protocol, reason)))
 		}
 	}
 	for _, rule := range s.DispatchRules {
@@ -217,6 +491,44 @@ func (s *SinkConfig) validateAndAdjust(sinkURI *url.URL, enableOldValue bool) er
 			rule.PartitionRule = rule.DispatcherRule
 			rule.DispatcherRule = ""
 		}
+		// After `validate()` is called, we only use TopicRule to represent a
+		// topic dispatching rule, the topic preset is replaced by its topic
+		// expression and cleared.
+		if rule.TopicPreset != "" {
+			if rule.TopicRule != "" {
+				return cerror.ErrSinkInvalidConfig.GenWithStack(
+					"topic and topic-preset cannot be configured both "+
+						"for the dispatch rule %v", rule.Matcher)
+			}
+			expr, ok := topicPresets[strings.ToLower(rule.TopicPreset)]
+			if !ok {
+				return cerror.ErrSinkInvalidConfig.GenWithStack(
+					"unknown topic-preset %s of the dispatch rule %v, "+
+						"it should be one of single, db and db_table",
+					rule.TopicPreset, rule.Matcher)
+			}
+			rule.TopicRule = expr
+			rule.TopicPreset = ""
+		}
+		switch strings.ToLower(rule.PartitionRule) {
+		case "columns":
+			if len(rule.PartitionColumns) == 0 {
+				return cerror.ErrSinkInvalidConfig.GenWithStack(
+					"partition-columns of the dispatch rule %v must not be empty "+
+						"for the columns dispatcher", rule.Matcher)
+			}
+		case "expression":
+			if strings.TrimSpace(rule.PartitionExpression) == "" {
+				return cerror.ErrSinkInvalidConfig.GenWithStack(
+					"partition-expression of the dispatch rule %v must not be empty "+
+						"for the expression dispatcher", rule.Matcher)
+			}
+		}
+		if rule.PartitionNum < 0 || rule.ReplicationFactor < 0 {
+			return cerror.ErrSinkInvalidConfig.GenWithStack(
+				"partition-num and replication-factor of the dispatch rule %v "+
+					"must not be negative", rule.Matcher)
+		}
 	}
 
 	if s.EncoderConcurrency < 0 {
@@ -237,6 +549,132 @@ func (s *SinkConfig) validateAndAdjust(sinkURI *url.URL, enableOldValue bool) er
 		}
 	}
 
+	if s.RetryBudget != nil {
+		if err := s.RetryBudget.ValidateAndAdjust(); err != nil {
+			return err
+		}
+	}
+
+	if s.TeeSinkURI != "" {
+		teeURI, err := url.Parse(s.TeeSinkURI)
+		if err != nil {
+			return cerror.WrapError(cerror.ErrSinkURIInvalid, err)
+		}
+		if !sink.IsStorageScheme(teeURI.Scheme) {
+			return cerror.ErrSinkInvalidConfig.GenWithStack(
+				"tee-sink-uri must be a storage sink URI, but the scheme is %s",
+				teeURI.Scheme)
+		}
+		// The messages emitted by the MQ sinks are archived as they are.
+		if sinkURI != nil && !sink.IsMQScheme(sinkURI.Scheme) {
+			return cerror.ErrSinkInvalidConfig.GenWithStack(
+				"tee-sink-uri is only supported by the MQ sinks, but the scheme is %s",
+				sinkURI.Scheme)
+		}
+	}
+
+	names := make(map[string]struct{}, len(s.ExtraSinks))
+	for _, extra := range s.ExtraSinks {
+		if extra.Name == "" || extra.SinkURI == "" {
+			return cerror.ErrSinkInvalidConfig.GenWithStack(
+				"name and sink-uri of extra-sinks must be specified")
+		}
+		if extra.Name == PrimarySinkName {
+			return cerror.ErrSinkInvalidConfig.GenWithStack(
+				"the name %s of extra-sinks is reserved", PrimarySinkName)
+		}
+		if _, ok := names[extra.Name]; ok {
+			return cerror.ErrSinkInvalidConfig.GenWithStack(
+				"duplicated name %s of extra-sinks", extra.Name)
+		}
+		names[extra.Name] = struct{}{}
+		if _, err := url.Parse(extra.SinkURI); err != nil {
+			return cerror.WrapError(cerror.ErrSinkURIInvalid, err)
+		}
+		if extra.SampleRate < 0 || extra.SampleRate > 1 {
+			return cerror.ErrSinkInvalidConfig.GenWithStack(
+				"sample-rate of the extra sink %s must be in [0, 1], but got %v",
+				extra.Name, extra.SampleRate)
+		}
+	}
+
+	for _, rule := range s.AvroKeyRules {
+		if len(rule.Matcher) == 0 || len(rule.Columns) == 0 {
+			return cerror.ErrSinkInvalidConfig.GenWithStack(
+				"matcher and columns of avro-key-rules must be specified")
+		}
+		if _, err := filter.Parse(rule.Matcher); err != nil {
+			return cerror.ErrSinkInvalidConfig.GenWithStack(
+				"invalid avro-key-rules matcher %v: %s", rule.Matcher, err)
+		}
+	}
+
+	for _, selector := range s.ColumnSelectors {
+		if err := selector.validate(); err != nil {
+			return err
+		}
+	}
+	if len(s.ColumnSelectors) > 0 {
+		// The schemas of the tables are written by the storage sinks, which
+		// always have all the columns.
+		if scheme, ok := s.checkSchemes(sinkURI, sink.IsMQScheme); !ok {
+			return cerror.ErrSinkInvalidConfig.GenWithStack(
+				"column-selectors is only supported by the MQ sinks, but the scheme is %s",
+				scheme)
+		}
+	}
+
+	for _, transform := range s.ColumnTransforms {
+		if err := transform.validateAndAdjust(); err != nil {
+			return err
+		}
+	}
+	if len(s.ColumnTransforms) > 0 {
+		// The values are transformed by the workers of the MQ and MySQL
+		// sinks, the other sinks would write them in cleartext.
+		if scheme, ok := s.checkSchemes(sinkURI, func(scheme string) bool {
+			return sink.IsMQScheme(scheme) || sink.IsMySQLCompatibleScheme(scheme)
+		}); !ok {
+			return cerror.ErrSinkInvalidConfig.GenWithStack(
+				"column-transforms is only supported by the MQ and MySQL sinks, "+
+					"but the scheme is %s", scheme)
+		}
+	}
+
+	if err := validateConsumerContracts(s.ConsumerContracts); err != nil {
+		return err
+	}
+
+	switch strings.ToLower(s.UpdateKeyChange) {
+	case "":
+	case UpdateKeyChangeKeep:
+		// The updates changing the handle keys are split before they reach
+		// the sinks if the old value is disabled.
+		if !enableOldValue {
+			return cerror.ErrSinkInvalidConfig.GenWithStack(
+				"update-key-change %s requires old value to be enabled", UpdateKeyChangeKeep)
+		}
+		s.UpdateKeyChange = UpdateKeyChangeKeep
+	case UpdateKeyChangeSplit:
+		s.UpdateKeyChange = UpdateKeyChangeSplit
+	default:
+		return cerror.ErrSinkInvalidConfig.GenWithStack(
+			"update-key-change should be one of %s and %s, but got %s",
+			UpdateKeyChangeKeep, UpdateKeyChangeSplit, s.UpdateKeyChange)
+	}
+
+	if s.ClientIdentity != nil {
+		if err := s.ClientIdentity.validate(); err != nil {
+			return err
+		}
+	}
+
+	if s.ParquetConfig != nil {
+		if err := s.validateAndAdjustParquetConfig(); err != nil {
+			return err
+		}
+	}
+
 	if s.CSVConfig != nil {
 		return s.validateAndAdjustCSVConfig()
 	}
@@ -244,6 +682,28 @@ func (s *SinkConfig) validateAndAdjust(sinkURI *url.URL, enableOldValue bool) er
 	return nil
 }
 
+func (s *SinkConfig) validateAndAdjustParquetConfig() error {
+	if s.ParquetConfig.RowGroupSize < 0 {
+		return cerror.ErrSinkInvalidConfig.GenWithStack(
+			"parquet config row-group-size %d cannot be negative", s.ParquetConfig.RowGroupSize)
+	}
+	if s.ParquetConfig.RowGroupSize == 0 {
+		s.ParquetConfig.RowGroupSize = DefaultParquetRowGroupSize
+	}
+
+	s.ParquetConfig.Compression = strings.ToLower(s.ParquetConfig.Compression)
+	switch s.ParquetConfig.Compression {
+	case "":
+		s.ParquetConfig.Compression = DefaultParquetCompression
+	case "none", "snappy", "gzip", "zstd":
+	default:
+		return cerror.ErrSinkInvalidConfig.GenWithStack(
+			"invalid parquet config compression %s, it must be one of none, snappy, gzip and zstd",
+			s.ParquetConfig.Compression)
+	}
+	return nil
+}
+
 func (s *SinkConfig) validateAndAdjustCSVConfig() error {
 	// validate quote
 	if len(s.CSVConfig.Quote) > 1 {
@@ -300,12 +760,22 @@ func (s *SinkConfig) validateAndAdjustSinkURI(sinkURI *url.URL) error {
 
 	// Validate that protocol is compatible with the scheme. For testing purposes,
 	// any protocol should be legal for blackhole.
-	if sink.IsMQScheme(sinkURI.Scheme) || sink.IsStorageScheme(sinkURI.Scheme) {
-		_, err := ParseSinkProtocolFromString(s.Protocol)
+	if sink.IsMQScheme(sinkURI.Scheme) || sink.IsStorageScheme(sinkURI.Scheme) ||
+		sink.IsWebhookScheme(sinkURI.Scheme) {
+		protocol, err := ParseSinkProtocolFromString(s.Protocol)
 		if err != nil {
 			return err
 		}
-	} else if sink.IsMySQLCompatibleScheme(sinkURI.Scheme) && s.Protocol != "" {
+		// The tombstones remove the deleted rows by the keys of the messages.
+		tombstone := strings.ToLower(sinkURI.Query().Get("tombstone"))
+		if tombstone != "" && tombstone != "none" && !protocol.IsRowKeyed() {
+			return cerror.ErrSinkURIInvalid.GenWithStackByArgs(fmt.Sprintf(
+				"tombstone is not supported by protocol %s, which doesn't key "+
+					"the messages by the rows", s.Protocol))
+		}
+	} else if (sink.IsMySQLCompatibleScheme(sinkURI.Scheme) ||
+		sink.IsClickHouseScheme(sinkURI.Scheme) ||
+		sink.IsElasticsearchScheme(sinkURI.Scheme)) && s.Protocol != "" {
 		return cerror.ErrSinkURIInvalid.GenWithStackByArgs(fmt.Sprintf("protocol %s "+
 			"is incompatible with %s scheme", s.Protocol, sinkURI.Scheme))
 	}
@@ -316,6 +786,24 @@ func (s *SinkConfig) validateAndAdjustSinkURI(sinkURI *url.URL) error {
 	return nil
 }
 
+// checkSchemes checks the schemes of the sink URI and the extra sinks by
+// supported, the first unsupported scheme is returned if there is one.
+func (s *SinkConfig) checkSchemes(
+	sinkURI *url.URL, supported func(scheme string) bool,
+) (string, bool) {
+	if sinkURI != nil && !supported(sinkURI.Scheme) {
+		return sinkURI.Scheme, false
+	}
+	for _, extra := range s.ExtraSinks {
+		// The sink URIs of the extra sinks have been validated.
+		extraURI, _ := url.Parse(extra.SinkURI)
+		if !supported(extraURI.Scheme) {
+			return extraURI.Scheme, false
+		}
+	}
+	return "", true
+}
+
 // applyParameterBySinkURI parse sinkURI and set `Protocol` and `TxnAtomicity` to `SinkConfig`.
 // Return:
 // - ErrIncompatibleSinkConfig to terminate `updated` changefeed operation.
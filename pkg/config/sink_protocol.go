@@ -38,6 +38,10 @@ const (
 	ProtocolCraft
 	ProtocolOpen
 	ProtocolCsv
+	ProtocolParquet
+	ProtocolDebezium
+	ProtocolCloudEvents
+	ProtocolProtobuf
 )
 
 // IsBatchEncode returns whether the protocol is a batch encoder.
@@ -45,6 +49,13 @@ func (p Protocol) IsBatchEncode() bool {
 	return p == ProtocolOpen || p == ProtocolCanal || p == ProtocolMaxwell || p == ProtocolCraft
 }
 
+// IsRowKeyed returns whether the protocol encodes each row into a message
+// keyed by the row, which is required to remove the deleted rows by the
+// tombstones.
+func (p Protocol) IsRowKeyed() bool {
+	return p == ProtocolAvro || p == ProtocolDebezium || p == ProtocolProtobuf
+}
+
 // ParseSinkProtocolFromString converts the protocol from string to Protocol enum type.
 func ParseSinkProtocolFromString(protocol string) (Protocol, error) {
 	switch strings.ToLower(protocol) {
@@ -66,6 +77,14 @@ func ParseSinkProtocolFromString(protocol string) (Protocol, error) {
 		return ProtocolOpen, nil
 	case "csv":
 		return ProtocolCsv, nil
+	case "parquet":
+		return ProtocolParquet, nil
+	case "debezium":
+		return ProtocolDebezium, nil
+	case "cloudevents":
+		return ProtocolCloudEvents, nil
+	case "protobuf":
+		return ProtocolProtobuf, nil
 	default:
 		return ProtocolUnknown, cerror.ErrSinkUnknownProtocol.GenWithStackByArgs(protocol)
 	}
@@ -90,6 +109,14 @@ func (p Protocol) String() string {
 		return "open-protocol"
 	case ProtocolCsv:
 		return "csv"
+	case ProtocolParquet:
+		return "parquet"
+	case ProtocolDebezium:
+		return "debezium"
+	case ProtocolCloudEvents:
+		return "cloudevents"
+	case ProtocolProtobuf:
+		return "protobuf"
 	default:
 		panic("unreachable")
 	}
@@ -62,6 +62,22 @@ func TestParseSinkProtocolFromString(t *testing.T) {
 			protocol:             "open-protocol",
 			expectedProtocolEnum: ProtocolOpen,
 		},
+		{
+			protocol:             "parquet",
+			expectedProtocolEnum: ProtocolParquet,
+		},
+		{
+			protocol:             "debezium",
+			expectedProtocolEnum: ProtocolDebezium,
+		},
+		{
+			protocol:             "cloudevents",
+			expectedProtocolEnum: ProtocolCloudEvents,
+		},
+		{
+			protocol:             "protobuf",
+			expectedProtocolEnum: ProtocolProtobuf,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -109,6 +125,22 @@ func TestString(t *testing.T) {
 			protocolEnum:     ProtocolOpen,
 			expectedProtocol: "open-protocol",
 		},
+		{
+			protocolEnum:     ProtocolParquet,
+			expectedProtocol: "parquet",
+		},
+		{
+			protocolEnum:     ProtocolDebezium,
+			expectedProtocol: "debezium",
+		},
+		{
+			protocolEnum:     ProtocolCloudEvents,
+			expectedProtocol: "cloudevents",
+		},
+		{
+			protocolEnum:     ProtocolProtobuf,
+			expectedProtocol: "protobuf",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -157,3 +189,17 @@ func TestIsBatchEncoder(t *testing.T) {
 		require.Equal(t, tc.expect, tc.protocolEnum.IsBatchEncode())
 	}
 }
+
+func TestIsRowKeyed(t *testing.T) {
+	t.Parallel()
+
+	for _, p := range []Protocol{ProtocolAvro, ProtocolDebezium, ProtocolProtobuf} {
+		require.True(t, p.IsRowKeyed(), p.String())
+	}
+	for _, p := range []Protocol{
+		ProtocolOpen, ProtocolCanal, ProtocolCanalJSON, ProtocolMaxwell,
+		ProtocolCraft, ProtocolCloudEvents,
+	} {
+		require.False(t, p.IsRowKeyed(), p.String())
+	}
+}
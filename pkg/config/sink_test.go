@@ -14,9 +14,14 @@
 package config
 
 import (
+	"context"
+	"errors"
 	"net/url"
 	"testing"
+	"time"
 
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/retry"
 	"github.com/stretchr/testify/require"
 )
 
@@ -67,6 +72,11 @@ func TestValidateOldValue(t *testing.T) {
 			enableOldValue: true,
 			expectedErr:    "",
 		},
+		{
+			protocol:       "avro",
+			enableOldValue: false,
+			expectedErr:    ".*avro protocol requires old value to be enabled, since the keys.*",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -149,6 +159,20 @@ func TestValidateTxnAtomicity(t *testing.T) {
 				"&protocol=open-protocol",
 			expectedErr: "invalid level atomicity is not supported by kafka scheme",
 		},
+		{
+			sinkURI:        "https://127.0.0.1:8443/hooks?protocol=canal-json",
+			expectedErr:    "",
+			shouldSplitTxn: true,
+		},
+		{
+			sinkURI:     "http://127.0.0.1:8080/hooks",
+			expectedErr: ".*unknown .* message protocol for sink.*",
+		},
+		{
+			sinkURI: "http://127.0.0.1:8080/hooks?transaction-atomicity=table" +
+				"&protocol=canal-json",
+			expectedErr: "table level atomicity is not supported by http scheme",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -406,3 +430,319 @@ func TestValidateAndAdjustCSVConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateConsumerContracts(t *testing.T) {
+	t.Parallel()
+
+	s := &SinkConfig{ConsumerContracts: []*ConsumerContract{{
+		Topic:   "topic",
+		Columns: []*ContractColumn{{Name: "id", Type: "int"}, {Name: "name"}},
+	}}}
+	require.Nil(t, validateConsumerContracts(s.ConsumerContracts))
+
+	s.ConsumerContracts = append(s.ConsumerContracts, &ConsumerContract{
+		Topic: "topic", Columns: []*ContractColumn{{Name: "id"}},
+	})
+	require.Regexp(t, "duplicated topic topic", validateConsumerContracts(s.ConsumerContracts))
+	require.Regexp(t, "topic and columns of consumer-contracts must be specified",
+		validateConsumerContracts([]*ConsumerContract{{Topic: "topic"}}))
+	require.Regexp(t, "name of the columns of consumer-contracts must be specified",
+		validateConsumerContracts([]*ConsumerContract{{
+			Topic: "topic", Columns: []*ContractColumn{{Type: "int"}},
+		}}))
+}
+
+func TestValidateAndAdjustUpdateKeyChange(t *testing.T) {
+	t.Parallel()
+
+	s := &SinkConfig{UpdateKeyChange: "Split"}
+	require.Nil(t, s.validateAndAdjust(nil, false))
+	require.Equal(t, UpdateKeyChangeSplit, s.UpdateKeyChange)
+
+	s.UpdateKeyChange = "keep"
+	require.Nil(t, s.validateAndAdjust(nil, true))
+	require.Regexp(t, "update-key-change keep requires old value to be enabled",
+		s.validateAndAdjust(nil, false))
+
+	s.UpdateKeyChange = "merge"
+	require.Regexp(t, "update-key-change should be one of keep and split",
+		s.validateAndAdjust(nil, true))
+}
+
+func TestValidateAndAdjustClientIdentity(t *testing.T) {
+	t.Parallel()
+
+	s := &SinkConfig{ClientIdentity: &ClientIdentityConfig{
+		KafkaClientID: "cdc_{namespace}_{changefeed}",
+		HTTPUserAgent: "ticdc/{changefeed} ({capture})",
+	}}
+	require.Nil(t, s.validateAndAdjust(nil, true))
+	require.Equal(t, "ticdc/cf (127.0.0.1:8300)", ExpandClientIdentity(
+		s.ClientIdentity.HTTPUserAgent, "default", "cf", "127.0.0.1:8300"))
+
+	s.ClientIdentity.HTTPUserAgent = "ticdc/{id}"
+	require.Regexp(t, "unknown placeholder {id} in client-identity http-user-agent",
+		s.validateAndAdjust(nil, true))
+}
+
+func TestValidateAndAdjustRetryBudget(t *testing.T) {
+	t.Parallel()
+
+	s := &SinkConfig{RetryBudget: &RetryBudgetConfig{}}
+	require.Nil(t, s.validateAndAdjust(nil, true))
+	require.Equal(t, RetryExhaustedWarning, s.RetryBudget.OnExhausted)
+
+	s.RetryBudget = &RetryBudgetConfig{OnExhausted: "paused"}
+	require.Regexp(t, ".*retry-budget.on-exhausted must be.*",
+		s.validateAndAdjust(nil, true))
+
+	s.RetryBudget = &RetryBudgetConfig{MaxDuration: -time.Second}
+	require.Regexp(t, ".*can not be negative.*", s.validateAndAdjust(nil, true))
+
+	s.RetryBudget = &RetryBudgetConfig{
+		BackoffBaseDelay: time.Minute,
+		BackoffMaxDelay:  time.Second,
+	}
+	require.Regexp(t, ".*must not be greater than.*", s.validateAndAdjust(nil, true))
+}
+
+func TestValidateAndAdjustAvroKeyRules(t *testing.T) {
+	t.Parallel()
+
+	s := &SinkConfig{AvroKeyRules: []*AvroKeyRule{{
+		Matcher: []string{"test.*"},
+		Columns: []string{"id"},
+	}}}
+	require.Nil(t, s.validateAndAdjust(nil, true))
+
+	s.AvroKeyRules[0].Columns = nil
+	require.Regexp(t, ".*matcher and columns of avro-key-rules must be specified.*",
+		s.validateAndAdjust(nil, true))
+
+	s.AvroKeyRules[0] = &AvroKeyRule{Matcher: []string{"[test"}, Columns: []string{"id"}}
+	require.Regexp(t, ".*invalid avro-key-rules matcher.*", s.validateAndAdjust(nil, true))
+}
+
+func TestValidateAndAdjustColumnSelectors(t *testing.T) {
+	t.Parallel()
+
+	s := &SinkConfig{ColumnSelectors: []*ColumnSelector{{
+		Matcher: []string{"test.*"},
+		Columns: []string{"*", "!secret_*"},
+	}}}
+	require.Nil(t, s.validateAndAdjust(nil, true))
+	sinkURI, err := url.Parse("kafka://127.0.0.1:9092/topic?protocol=canal-json")
+	require.Nil(t, err)
+	require.Nil(t, s.validateAndAdjust(sinkURI, true))
+
+	sinkURI, err = url.Parse("s3://bucket/prefix?protocol=csv")
+	require.Nil(t, err)
+	require.Regexp(t, ".*column-selectors is only supported by the MQ sinks.*",
+		s.validateAndAdjust(sinkURI, true))
+	s.ExtraSinks = []*ExtraSinkConfig{{Name: "archive", SinkURI: "s3://bucket/archive"}}
+	require.Regexp(t, ".*column-selectors is only supported by the MQ sinks.*",
+		s.validateAndAdjust(nil, true))
+	s.ExtraSinks = nil
+
+	s.ColumnSelectors[0].Columns = nil
+	require.Regexp(t, ".*matcher and columns or exclude-columns of column-selectors must be specified.*",
+		s.validateAndAdjust(nil, true))
+	s.ColumnSelectors[0].ExcludeColumns = []string{"[blob"}
+	require.Regexp(t, ".*invalid column-selectors column rule.*", s.validateAndAdjust(nil, true))
+	s.ColumnSelectors[0] = &ColumnSelector{Matcher: []string{"[test"}, Columns: []string{"id"}}
+	require.Regexp(t, ".*invalid column-selectors matcher.*", s.validateAndAdjust(nil, true))
+}
+
+func TestColumnSelectorSelectColumn(t *testing.T) {
+	t.Parallel()
+
+	selector := &ColumnSelector{Matcher: []string{"test.*"}}
+	selector.Columns = []string{"id", "name"}
+	require.True(t, selector.SelectColumn("ID"))
+	require.False(t, selector.SelectColumn("age"))
+
+	selector.Columns = []string{"*", "!name"}
+	require.True(t, selector.SelectColumn("id"))
+	require.False(t, selector.SelectColumn("Name"))
+
+	selector.Columns = []string{"src*", "!src1"}
+	require.True(t, selector.SelectColumn("src2"))
+	require.False(t, selector.SelectColumn("src1"))
+	require.False(t, selector.SelectColumn("dst"))
+
+	selector.Columns = []string{"sdb?c", "c[!0-9]"}
+	require.True(t, selector.SelectColumn("sdb1c"))
+	require.True(t, selector.SelectColumn("ca"))
+	require.False(t, selector.SelectColumn("c1"))
+
+	selector.Columns = nil
+	selector.ExcludeColumns = []string{"*_blob", "phone"}
+	require.True(t, selector.SelectColumn("id"))
+	require.False(t, selector.SelectColumn("avatar_blob"))
+	require.False(t, selector.SelectColumn("Phone"))
+}
+
+func TestValidateAndAdjustTeeSinkURI(t *testing.T) {
+	t.Parallel()
+
+	s := &SinkConfig{TeeSinkURI: "s3://bucket/archive"}
+	require.Nil(t, s.validateAndAdjust(nil, true))
+
+	s.TeeSinkURI = "kafka://127.0.0.1:9092/topic"
+	require.Regexp(t, ".*tee-sink-uri must be a storage sink URI.*",
+		s.validateAndAdjust(nil, true))
+
+	s.TeeSinkURI = "s3://bucket/archive"
+	sinkURI, err := url.Parse("kafka://127.0.0.1:9092/topic")
+	require.Nil(t, err)
+	require.Nil(t, s.validateAndAdjust(sinkURI, true))
+	sinkURI, err = url.Parse("mysql://127.0.0.1:3306/")
+	require.Nil(t, err)
+	require.Regexp(t, ".*tee-sink-uri is only supported by the MQ sinks.*",
+		s.validateAndAdjust(sinkURI, true))
+}
+
+func TestValidateAndAdjustTombstone(t *testing.T) {
+	t.Parallel()
+
+	for _, uri := range []string{
+		"kafka://127.0.0.1:9092/topic?protocol=debezium&tombstone=after-delete",
+		"kafka://127.0.0.1:9092/topic?protocol=canal-json&tombstone=none",
+		"kafka://127.0.0.1:9092/topic?protocol=canal-json",
+	} {
+		sinkURI, err := url.Parse(uri)
+		require.Nil(t, err)
+		require.Nil(t, (&SinkConfig{}).validateAndAdjust(sinkURI, true), uri)
+	}
+	for _, uri := range []string{
+		"kafka://127.0.0.1:9092/topic?protocol=canal-json&tombstone=replace",
+		"kafka://127.0.0.1:9092/topic?protocol=cloudevents&tombstone=after-delete",
+		"kafka://127.0.0.1:9092/topic?protocol=open-protocol&tombstone=after-delete",
+	} {
+		sinkURI, err := url.Parse(uri)
+		require.Nil(t, err)
+		require.Regexp(t, ".*tombstone is not supported by protocol.*",
+			(&SinkConfig{}).validateAndAdjust(sinkURI, true), uri)
+	}
+}
+
+func TestValidateAndAdjustExtraSinks(t *testing.T) {
+	t.Parallel()
+
+	s := &SinkConfig{ExtraSinks: []*ExtraSinkConfig{
+		{Name: "kafka", SinkURI: "kafka://127.0.0.1:9092/topic", Protocol: "canal-json"},
+		{Name: "archive", SinkURI: "s3://bucket/archive"},
+	}}
+	require.Nil(t, s.validateAndAdjust(nil, true))
+
+	s.ExtraSinks[1].Name = "kafka"
+	require.Regexp(t, ".*duplicated name kafka of extra-sinks.*", s.validateAndAdjust(nil, true))
+	s.ExtraSinks[1].Name = PrimarySinkName
+	require.Regexp(t, ".*the name primary of extra-sinks is reserved.*", s.validateAndAdjust(nil, true))
+	s.ExtraSinks[1] = &ExtraSinkConfig{Name: "archive"}
+	require.Regexp(t, ".*name and sink-uri of extra-sinks must be specified.*",
+		s.validateAndAdjust(nil, true))
+
+	s.ExtraSinks[1] = &ExtraSinkConfig{Name: "shadow", SinkURI: "mysql://127.0.0.1:3306/", SampleRate: 0.01}
+	require.Nil(t, s.validateAndAdjust(nil, true))
+	s.ExtraSinks[1].SampleRate = 1.5
+	require.Regexp(t, ".*sample-rate of the extra sink shadow must be in.*",
+		s.validateAndAdjust(nil, true))
+}
+
+func TestValidateAndAdjustTopicPresets(t *testing.T) {
+	t.Parallel()
+
+	s := &SinkConfig{DispatchRules: []*DispatchRule{
+		{Matcher: []string{"test1.*"}, TopicPreset: "db", PartitionNum: 6},
+		{Matcher: []string{"test2.*"}, TopicPreset: "DB_TABLE", ReplicationFactor: 3},
+		{Matcher: []string{"test3.*"}, TopicPreset: "single"},
+		{Matcher: []string{"test4.*"}, TopicRule: "hello_{schema}"},
+		// The literal topics aren't taken as the presets.
+		{Matcher: []string{"test5.*"}, TopicRule: "db"},
+	}}
+	require.Nil(t, s.validateAndAdjust(nil, true))
+	require.Equal(t, "{schema}", s.DispatchRules[0].TopicRule)
+	require.Equal(t, "{schema}_{table}", s.DispatchRules[1].TopicRule)
+	require.Equal(t, "", s.DispatchRules[2].TopicRule)
+	require.Equal(t, "hello_{schema}", s.DispatchRules[3].TopicRule)
+	require.Equal(t, "db", s.DispatchRules[4].TopicRule)
+	for _, rule := range s.DispatchRules {
+		require.Equal(t, "", rule.TopicPreset)
+	}
+	// The adjusted config is still valid.
+	require.Nil(t, s.validateAndAdjust(nil, true))
+
+	s.DispatchRules[0].PartitionNum = -1
+	require.Regexp(t, ".*must not be negative.*", s.validateAndAdjust(nil, true))
+	s.DispatchRules[0].PartitionNum = 6
+
+	s.DispatchRules[4].TopicPreset = "db_table"
+	require.Regexp(t, ".*topic and topic-preset cannot be configured both.*",
+		s.validateAndAdjust(nil, true))
+	s.DispatchRules[4].TopicRule = ""
+	s.DispatchRules[4].TopicPreset = "table"
+	require.Regexp(t, ".*unknown topic-preset table.*", s.validateAndAdjust(nil, true))
+}
+
+func TestValidateAndAdjustPartitionColumnsAndExpression(t *testing.T) {
+	t.Parallel()
+
+	s := &SinkConfig{DispatchRules: []*DispatchRule{
+		{Matcher: []string{"test1.*"}, PartitionRule: "columns"},
+	}}
+	require.Regexp(t, ".*partition-columns.*must not be empty.*", s.validateAndAdjust(nil, true))
+	s.DispatchRules[0].PartitionColumns = []string{"id"}
+	require.Nil(t, s.validateAndAdjust(nil, true))
+
+	s.DispatchRules[0].PartitionRule = "expression"
+	require.Regexp(t, ".*partition-expression.*must not be empty.*", s.validateAndAdjust(nil, true))
+	s.DispatchRules[0].PartitionExpression = "lower(name)"
+	require.Nil(t, s.validateAndAdjust(nil, true))
+}
+
+func TestRetryBudgetEscalate(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	op := func() error { return errors.New("test") }
+	budget := &RetryBudgetConfig{
+		MaxAttempts:      2,
+		BackoffBaseDelay: time.Millisecond,
+		OnExhausted:      RetryExhaustedWarning,
+	}
+	err := budget.Escalate(retry.Do(ctx, op, budget.RetryOptions()...))
+	require.True(t, cerror.Is(err, cerror.ErrReachMaxTry))
+	require.False(t, cerror.IsChangefeedUnRetryableError(err))
+
+	budget.OnExhausted = RetryExhaustedFailed
+	err = budget.Escalate(retry.Do(ctx, op, budget.RetryOptions()...))
+	require.True(t, cerror.IsChangefeedUnRetryableError(err))
+
+	// an unretryable error is not escalated since the budget is not used up.
+	err = budget.Escalate(retry.Do(ctx, op, append(budget.RetryOptions(),
+		retry.WithIsRetryableErr(func(error) bool { return false }))...))
+	require.False(t, cerror.IsChangefeedUnRetryableError(err))
+
+	// a nil budget keeps the error as it is.
+	var nilBudget *RetryBudgetConfig
+	require.Nil(t, nilBudget.RetryOptions())
+	require.Equal(t, err, nilBudget.Escalate(err))
+}
+
+func TestValidateAndAdjustParquetConfig(t *testing.T) {
+	t.Parallel()
+
+	s := &SinkConfig{ParquetConfig: &ParquetConfig{}}
+	require.Nil(t, s.validateAndAdjust(nil, true))
+	require.Equal(t, NewDefaultParquetConfig(), s.ParquetConfig)
+
+	s.ParquetConfig = &ParquetConfig{RowGroupSize: 1024, Compression: "ZSTD"}
+	require.Nil(t, s.validateAndAdjust(nil, true))
+	require.Equal(t, &ParquetConfig{RowGroupSize: 1024, Compression: "zstd"}, s.ParquetConfig)
+
+	s.ParquetConfig = &ParquetConfig{RowGroupSize: -1}
+	require.Regexp(t, ".*row-group-size -1 cannot be negative.*", s.validateAndAdjust(nil, true))
+	s.ParquetConfig = &ParquetConfig{Compression: "brotli"}
+	require.Regexp(t, ".*invalid parquet config compression brotli.*", s.validateAndAdjust(nil, true))
+}
@@ -0,0 +1,78 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pingcap/tidb/br/pkg/storage"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+)
+
+const (
+	// DefaultSortSpillColdAfter is the default age of the sorted but unsent
+	// events, older events are spilled to the external storage.
+	DefaultSortSpillColdAfter = 30 * time.Minute
+	// DefaultSortSpillSegmentSize is the default size of a spilled file.
+	DefaultSortSpillSegmentSize = 64 * 1024 * 1024
+	// minSortSpillColdAfter avoids spilling the events which are being sent.
+	minSortSpillColdAfter = time.Minute
+)
+
+// SortSpillConfig spills the cold data of the pebble sort engine to an
+// external storage, so that the local disk doesn't bound how long the sink
+// of the changefeed can be unavailable. The spilled events are streamed back
+// when the sink recovers, and removed once they are sent.
+//
+// The files spilled by a crashed capture are removed by the sorters spilling
+// to the same storage, once they are not refreshed for 10 minutes.
+type SortSpillConfig struct {
+	// Storage is the URI of the external storage, like s3://bucket/prefix.
+	Storage string `toml:"storage" json:"storage"`
+	// ColdAfter is the age of the sorted but unsent events, which is
+	// calculated by their commit ts, older events are spilled.
+	ColdAfter time.Duration `toml:"cold-after" json:"cold-after"`
+	// SegmentSize is the max size of a spilled file, the events of a
+	// transaction are never split into different files.
+	SegmentSize uint64 `toml:"segment-size" json:"segment-size"`
+}
+
+// ValidateAndAdjust validates the sort spill config and adjusts it if necessary.
+func (c *SortSpillConfig) ValidateAndAdjust() error {
+	uri, err := storage.ParseRawURL(c.Storage)
+	if err != nil {
+		return cerror.ErrInvalidReplicaConfig.GenWithStackByArgs(
+			fmt.Sprintf("invalid sort-spill.storage uri: %s", c.Storage))
+	}
+	switch uri.Scheme {
+	case "s3", "gcs", "gs", "azblob", "azure", "file":
+	default:
+		return cerror.ErrInvalidReplicaConfig.FastGenByArgs(
+			fmt.Sprintf("The sort-spill.storage scheme:%s must be one of "+
+				"s3, gcs, gs, azblob, azure and file", uri.Scheme))
+	}
+	if c.ColdAfter == 0 {
+		c.ColdAfter = DefaultSortSpillColdAfter
+	}
+	if c.ColdAfter < minSortSpillColdAfter {
+		return cerror.ErrInvalidReplicaConfig.FastGenByArgs(
+			fmt.Sprintf("The sort-spill.cold-after:%s must be equal or greater than %s",
+				c.ColdAfter, minSortSpillColdAfter))
+	}
+	if c.SegmentSize == 0 {
+		c.SegmentSize = DefaultSortSpillSegmentSize
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSortSpillConfigValidateAndAdjust(t *testing.T) {
+	t.Parallel()
+
+	c := &SortSpillConfig{Storage: "s3://bucket/spill"}
+	require.Nil(t, c.ValidateAndAdjust())
+	require.Equal(t, DefaultSortSpillColdAfter, c.ColdAfter)
+	require.Equal(t, uint64(DefaultSortSpillSegmentSize), c.SegmentSize)
+
+	c = &SortSpillConfig{Storage: "local:///tmp/spill"}
+	require.Regexp(t, ".*must be one of.*", c.ValidateAndAdjust())
+
+	c = &SortSpillConfig{Storage: "noop://"}
+	require.Regexp(t, ".*must be one of.*", c.ValidateAndAdjust())
+
+	c = &SortSpillConfig{Storage: "file:///tmp/spill", ColdAfter: time.Second}
+	require.Regexp(t, ".*must be equal or greater than.*", c.ValidateAndAdjust())
+}
@@ -24,6 +24,7 @@ import (
 	ssystem "github.com/pingcap/tiflow/cdc/sorter/db/system"
 	"github.com/pingcap/tiflow/pkg/config"
 	"github.com/pingcap/tiflow/pkg/etcd"
+	"github.com/pingcap/tiflow/pkg/initqueue"
 	"github.com/pingcap/tiflow/pkg/p2p"
 	"github.com/tikv/client-go/v2/oracle"
 	"go.uber.org/zap"
@@ -50,6 +51,10 @@ type GlobalVars struct {
 	// MessageServer and MessageRouter are for peer-messaging
 	MessageServer *p2p.MessageServer
 	MessageRouter p2p.MessageRouter
+
+	// InitQueue limits the concurrent initializations of the changefeeds on
+	// the capture, it's nil if they are not limited.
+	InitQueue *initqueue.Queue
 }
 
 // ChangefeedVars contains some vars which can be used anywhere in a pipeline
@@ -76,7 +76,7 @@ var (
 )
 
 // InitMetrics registers all metrics in this file
-func InitMetrics(registry *prometheus.Registry) {
+func InitMetrics(registry prometheus.Registerer) {
 	registry.MustRegister(dbSnapshotGauge)
 	registry.MustRegister(dbIteratorGauge)
 	registry.MustRegister(dbLevelCount)
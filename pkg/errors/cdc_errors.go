@@ -260,10 +260,26 @@ var (
 		"kafka create topic failed",
 		errors.RFCCodeText("CDC:ErrKafkaCreateTopic"),
 	)
+	ErrKafkaDeleteTopic = errors.Normalize(
+		"kafka delete topic failed",
+		errors.RFCCodeText("CDC:ErrKafkaDeleteTopic"),
+	)
+	ErrKafkaRefreshTopicMetadata = errors.Normalize(
+		"kafka refresh topic metadata failed",
+		errors.RFCCodeText("CDC:ErrKafkaRefreshTopicMetadata"),
+	)
 	ErrKafkaInvalidTopicExpression = errors.Normalize(
 		"invalid topic expression",
 		errors.RFCCodeText("CDC:ErrKafkaTopicExprInvalid"),
 	)
+	ErrPartitionExpressionInvalid = errors.Normalize(
+		"invalid partition expression '%s': %s",
+		errors.RFCCodeText("CDC:ErrPartitionExpressionInvalid"),
+	)
+	ErrPartitionColumnNotFound = errors.Normalize(
+		"cannot find partition column '%s' from table '%s'",
+		errors.RFCCodeText("CDC:ErrPartitionColumnNotFound"),
+	)
 	ErrKafkaBrokerConfigNotFound = errors.Normalize(
 		"kafka broker config item not found",
 		errors.RFCCodeText("CDC:ErrKafkaBrokerConfigNotFound"),
@@ -271,6 +287,18 @@ var (
 	ErrKafkaTopicNotExists = errors.Normalize("kafka topic not exists after creation",
 		errors.RFCCodeText("CDC:ErrKafkaTopicNotExists"),
 	)
+	ErrKafkaFetchOAuthToken = errors.Normalize(
+		"kafka fetch oauth token failed",
+		errors.RFCCodeText("CDC:ErrKafkaFetchOAuthToken"),
+	)
+	ErrKafkaSinkPaused = errors.Normalize(
+		"kafka sink paused because the brokers are unavailable",
+		errors.RFCCodeText("CDC:ErrKafkaSinkPaused"),
+	)
+	ErrConsumerContractViolated = errors.Normalize(
+		"DDL `%s` violates the consumer contract of topic %s: %s",
+		errors.RFCCodeText("CDC:ErrConsumerContractViolated"),
+	)
 	ErrRedoConfigInvalid = errors.Normalize(
 		"redo log config invalid",
 		errors.RFCCodeText("CDC:ErrRedoConfigInvalid"),
@@ -319,6 +347,14 @@ var (
 		"sink uri invalid '%s'",
 		errors.RFCCodeText("CDC:ErrSinkURIInvalid"),
 	)
+	ErrSinkSelfCheckFailed = errors.Normalize(
+		"sink self check failed, %s",
+		errors.RFCCodeText("CDC:ErrSinkSelfCheckFailed"),
+	)
+	ErrSinkCredentialsNotUpdatable = errors.Normalize(
+		"the credentials of the sink can not be updated online, %s",
+		errors.RFCCodeText("CDC:ErrSinkCredentialsNotUpdatable"),
+	)
 	ErrIncompatibleSinkConfig = errors.Normalize(
 		"incompatible configuration in sink uri(%s) and config file(%s), "+
 			"please try to update the configuration only through sink uri",
@@ -332,6 +368,10 @@ var (
 		"MySQL txn error",
 		errors.RFCCodeText("CDC:ErrMySQLTxnError"),
 	)
+	ErrMySQLSafeMode = errors.Normalize(
+		"MySQL sink writes the rows replicated before in safe mode",
+		errors.RFCCodeText("CDC:ErrMySQLSafeMode"),
+	)
 	ErrMySQLQueryError = errors.Normalize(
 		"MySQL query error",
 		errors.RFCCodeText("CDC:ErrMySQLQueryError"),
@@ -364,6 +404,10 @@ var (
 		"encode to avro native data",
 		errors.RFCCodeText("CDC:ErrAvroEncodeFailed"),
 	)
+	ErrAvroIllegalName = errors.Normalize(
+		"illegal avro name %s, it must match [A-Za-z_][A-Za-z0-9_]*",
+		errors.RFCCodeText("CDC:ErrAvroIllegalName"),
+	)
 	ErrAvroEncodeToBinary = errors.Normalize(
 		"encode to binray from native",
 		errors.RFCCodeText("CDC:ErrAvroEncodeToBinary"),
@@ -372,6 +416,30 @@ var (
 		"schema manager API error",
 		errors.RFCCodeText("CDC:ErrAvroSchemaAPIError"),
 	)
+	ErrCloudEventsEncodeFailed = errors.Normalize(
+		"cloudevents encode failed",
+		errors.RFCCodeText("CDC:ErrCloudEventsEncodeFailed"),
+	)
+	ErrDebeziumEncodeFailed = errors.Normalize(
+		"debezium encode failed",
+		errors.RFCCodeText("CDC:ErrDebeziumEncodeFailed"),
+	)
+	ErrProtobufEncodeFailed = errors.Normalize(
+		"protobuf encode failed",
+		errors.RFCCodeText("CDC:ErrProtobufEncodeFailed"),
+	)
+	ErrProtobufSchemaAPIError = errors.Normalize(
+		"protobuf schema registry API error",
+		errors.RFCCodeText("CDC:ErrProtobufSchemaAPIError"),
+	)
+	ErrJSONSchemaValidateFailed = errors.Normalize(
+		"json schema validate failed",
+		errors.RFCCodeText("CDC:ErrJSONSchemaValidateFailed"),
+	)
+	ErrJSONSchemaAPIError = errors.Normalize(
+		"json schema registry API error",
+		errors.RFCCodeText("CDC:ErrJSONSchemaAPIError"),
+	)
 	ErrMaxwellEncodeFailed = errors.Normalize(
 		"maxwell encode failed",
 		errors.RFCCodeText("CDC:ErrMaxwellEncodeFailed"),
@@ -392,6 +460,10 @@ var (
 		"open-protocol codec single row too large",
 		errors.RFCCodeText("CDC:ErrOpenProtocolCodecRowTooLarge"),
 	)
+	ErrOpenProtocolCodecBatchTooLarge = errors.Normalize(
+		"open-protocol codec batch too large after compressed, split into smaller ones",
+		errors.RFCCodeText("CDC:ErrOpenProtocolCodecBatchTooLarge"),
+	)
 	ErrCanalDecodeFailed = errors.Normalize(
 		"canal decode failed",
 		errors.RFCCodeText("CDC:ErrCanalDecodeFailed"),
@@ -424,6 +496,18 @@ var (
 		"csv decode failed",
 		errors.RFCCodeText("CDC:ErrCSVDecodeFailed"),
 	)
+	ErrParquetEncodeFailed = errors.Normalize(
+		"parquet encode failed",
+		errors.RFCCodeText("CDC:ErrParquetEncodeFailed"),
+	)
+	ErrCompressionUnsupported = errors.Normalize(
+		"compression codec %s is not supported, it must be one of %v",
+		errors.RFCCodeText("CDC:ErrCompressionUnsupported"),
+	)
+	ErrCompressionFailed = errors.Normalize(
+		"compression codec %s failed",
+		errors.RFCCodeText("CDC:ErrCompressionFailed"),
+	)
 	ErrCloudStorageInvalidConfig = errors.Normalize(
 		"cloud storage config invalid",
 		errors.RFCCodeText("CDC:ErrCloudStorageInvalidConfig"),
@@ -432,6 +516,54 @@ var (
 		"cloud storage defragment encoded messages failed",
 		errors.RFCCodeText("CDC:ErrCloudStorageDefragmentFailed"),
 	)
+	ErrIcebergCatalogError = errors.Normalize(
+		"iceberg catalog request failed",
+		errors.RFCCodeText("CDC:ErrIcebergCatalogError"),
+	)
+	ErrIcebergCommitConflict = errors.Normalize(
+		"iceberg table %s has been changed by a concurrent commit",
+		errors.RFCCodeText("CDC:ErrIcebergCommitConflict"),
+	)
+	ErrIcebergEncodeFailed = errors.Normalize(
+		"iceberg encode failed",
+		errors.RFCCodeText("CDC:ErrIcebergEncodeFailed"),
+	)
+	ErrIcebergInvalidTable = errors.Normalize(
+		"iceberg table %s is invalid, %s",
+		errors.RFCCodeText("CDC:ErrIcebergInvalidTable"),
+	)
+	ErrClickHouseInvalidConfig = errors.Normalize(
+		"clickhouse config invalid",
+		errors.RFCCodeText("CDC:ErrClickHouseInvalidConfig"),
+	)
+	ErrClickHouseExecFailed = errors.Normalize(
+		"clickhouse execution failed",
+		errors.RFCCodeText("CDC:ErrClickHouseExecFailed"),
+	)
+	ErrClickHouseUnsupportedTable = errors.Normalize(
+		"table %s is not supported by clickhouse sink, %s",
+		errors.RFCCodeText("CDC:ErrClickHouseUnsupportedTable"),
+	)
+	ErrElasticsearchInvalidConfig = errors.Normalize(
+		"elasticsearch config invalid",
+		errors.RFCCodeText("CDC:ErrElasticsearchInvalidConfig"),
+	)
+	ErrElasticsearchRequestFailed = errors.Normalize(
+		"elasticsearch request failed",
+		errors.RFCCodeText("CDC:ErrElasticsearchRequestFailed"),
+	)
+	ErrElasticsearchUnsupportedTable = errors.Normalize(
+		"table %s is not supported by elasticsearch sink, %s",
+		errors.RFCCodeText("CDC:ErrElasticsearchUnsupportedTable"),
+	)
+	ErrWebhookInvalidConfig = errors.Normalize(
+		"webhook config invalid",
+		errors.RFCCodeText("CDC:ErrWebhookInvalidConfig"),
+	)
+	ErrWebhookRequestFailed = errors.Normalize(
+		"webhook request failed",
+		errors.RFCCodeText("CDC:ErrWebhookRequestFailed"),
+	)
 
 	// utilities related errors
 	ErrToTLSConfigFailed = errors.Normalize(
@@ -491,6 +623,14 @@ var (
 		"codec decode error",
 		errors.RFCCodeText("CDC:ErrCodecDecode"),
 	)
+	ErrCodecTruncatedPayload = errors.Normalize(
+		"codec truncated payload, %s",
+		errors.RFCCodeText("CDC:ErrCodecTruncatedPayload"),
+	)
+	ErrCodecStrictDecodeFailed = errors.Normalize(
+		"codec strict decode failed, %s",
+		errors.RFCCodeText("CDC:ErrCodecStrictDecodeFailed"),
+	)
 	ErrUnknownMetaType = errors.Normalize(
 		"unknown meta type %v",
 		errors.RFCCodeText("CDC:ErrUnknownMetaType"),
@@ -713,6 +853,14 @@ var (
 		"changefeed in abnormal state: %s, replication status: %+v",
 		errors.RFCCodeText("CDC:ErrChangefeedAbnormalState"),
 	)
+	ErrSavepointAlreadyExists = errors.Normalize(
+		"savepoint %s already exists",
+		errors.RFCCodeText("CDC:ErrSavepointAlreadyExists"),
+	)
+	ErrSavepointNotAllowed = errors.Normalize(
+		"can not create savepoint: %s",
+		errors.RFCCodeText("CDC:ErrSavepointNotAllowed"),
+	)
 	ErrInvalidAdminJobType = errors.Normalize(
 		"invalid admin job type: %d",
 		errors.RFCCodeText("CDC:ErrInvalidAdminJobType"),
@@ -743,6 +891,15 @@ var (
 		"fail to create changefeed because target-ts %d is earlier than start-ts %d",
 		errors.RFCCodeText("CDC:ErrTargetTsBeforeStartTs"),
 	)
+	ErrInvalidStartTime = errors.Normalize(
+		"invalid start time %s of changefeed, %s",
+		errors.RFCCodeText("CDC:ErrInvalidStartTime"),
+	)
+	ErrClockSkewTooLarge = errors.Normalize(
+		"the skew %s between the local clock and the cluster clock "+
+			"exceeds the limit %s",
+		errors.RFCCodeText("CDC:ErrClockSkewTooLarge"),
+	)
 	ErrSnapshotLostByGC = errors.Normalize(
 		"fail to create or maintain changefeed due to snapshot loss"+
 			" caused by GC. checkpoint-ts %d is earlier than or equal to GC safepoint at %d",
@@ -818,6 +975,11 @@ var (
 			", and you should manually handle it",
 		errors.RFCCodeText("CDC:ErrChangefeedUnretryable"),
 	)
+	ErrChangefeedRestartBudgetExhausted = errors.Normalize(
+		"changefeed has been restarted %d times in %s, which exhausts the restart budget, "+
+			"the last error: %s",
+		errors.RFCCodeText("CDC:ErrChangefeedRestartBudgetExhausted"),
+	)
 
 	// pipeline errors
 	ErrSendToClosedPipeline = errors.Normalize(
@@ -932,6 +1094,11 @@ var (
 		"sorter is closed",
 		errors.RFCCodeText("CDC:ErrSorterClosed"),
 	)
+	ErrMemorySortEngineQuotaExceeded = errors.Normalize(
+		"the memory sort engines exceed the memory quota %d bytes, "+
+			"use the sort-engine pebble for the changefeeds with large traffic",
+		errors.RFCCodeText("CDC:ErrMemorySortEngineQuotaExceeded"),
+	)
 
 	// Pull based sink config error.
 	ErrInvalidPullBasedSinkConfig = errors.Normalize(
@@ -1138,6 +1305,10 @@ var (
 		"invalid ignore event type: '%s'",
 		errors.RFCCodeText("CDC:ErrInvalidIgnoreEventType"),
 	)
+	ErrInvalidAllowDDLType = errors.Normalize(
+		"invalid allow ddl type: '%s'",
+		errors.RFCCodeText("CDC:ErrInvalidAllowDDLType"),
+	)
 	ErrConvertDDLToEventTypeFailed = errors.Normalize(
 		"failed to convert ddl '%s' to filter event type",
 		errors.RFCCodeText("CDC:ErrConvertDDLToEventTypeFailed"),
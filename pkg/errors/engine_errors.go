@@ -349,6 +349,20 @@ var (
 		errors.RFCCodeText("DFLOW:ErrResourceMetastoreError"),
 	)
 
+	// state store related errors
+	ErrStateStoreNotEnabled = errors.Normalize(
+		"state store is not enabled, s3 storage is required",
+		errors.RFCCodeText("DFLOW:ErrStateStoreNotEnabled"),
+	)
+	ErrStateSnapshotNotFound = errors.Normalize(
+		"state snapshot %d is not found",
+		errors.RFCCodeText("DFLOW:ErrStateSnapshotNotFound"),
+	)
+	ErrStateStoreFenced = errors.Normalize(
+		"state store of epoch %d is fenced by epoch %d",
+		errors.RFCCodeText("DFLOW:ErrStateStoreFenced"),
+	)
+
 	// Job related error
 	ErrJobNotFound = errors.Normalize(
 		"job %s is not found",
@@ -370,6 +384,10 @@ var (
 		"job %s is not running",
 		errors.RFCCodeText("DFLOW:ErrJobNotRunning"),
 	)
+	ErrTenantQuotaExceeded = errors.Normalize(
+		"tenant %s exceeds the quota of %d jobs",
+		errors.RFCCodeText("DFLOW:ErrTenantQuotaExceeded"),
+	)
 
 	// metastore related errors
 	ErrMetaStoreNotExists = errors.Normalize(
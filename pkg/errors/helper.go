@@ -70,6 +70,37 @@ func IsChangefeedFastFailErrorCode(errCode errors.RFCErrorCode) bool {
 	return false
 }
 
+// changefeedPauseErrors is read only.
+// If this type of error occurs in a changefeed, the changefeed is paused with
+// the error as the report, so that it can be resumed by the users after the
+// cause is fixed.
+var changefeedPauseErrors = []*errors.Error{
+	ErrConsumerContractViolated,
+}
+
+// IsChangefeedPauseError checks if an error pauses the changefeed.
+func IsChangefeedPauseError(err error) bool {
+	if err == nil {
+		return false
+	}
+	rfcCode, ok := RFCCode(err)
+	if !ok {
+		return false
+	}
+	return IsChangefeedPauseErrorCode(rfcCode)
+}
+
+// IsChangefeedPauseErrorCode checks the error code, returns true if it pauses
+// the changefeed.
+func IsChangefeedPauseErrorCode(errCode errors.RFCErrorCode) bool {
+	for _, e := range changefeedPauseErrors {
+		if errCode == e.RFCCode() {
+			return true
+		}
+	}
+	return false
+}
+
 var changefeedUnRetryableErrors = []*errors.Error{
 	ErrExpressionColumnNotFound,
 	ErrExpressionParseFailed,
@@ -169,6 +200,7 @@ var httpStatusCodeMapping = map[errors.RFCErrorCode]int{
 	ErrJobAlreadyCanceled.RFCCode():    http.StatusBadRequest,
 	ErrJobNotTerminated.RFCCode():      http.StatusBadRequest,
 	ErrJobNotRunning.RFCCode():         http.StatusBadRequest,
+	ErrTenantQuotaExceeded.RFCCode():   http.StatusTooManyRequests,
 	ErrMetaStoreNotExists.RFCCode():    http.StatusNotFound,
 	ErrResourceAlreadyExists.RFCCode(): http.StatusConflict,
 	ErrIllegalResourcePath.RFCCode():   http.StatusBadRequest,
@@ -211,6 +243,7 @@ var gRPCStatusCodeMapping = map[errors.RFCErrorCode]codes.Code{
 	ErrJobAlreadyCanceled.RFCCode():    codes.FailedPrecondition,
 	ErrJobNotTerminated.RFCCode():      codes.FailedPrecondition,
 	ErrJobNotRunning.RFCCode():         codes.FailedPrecondition,
+	ErrTenantQuotaExceeded.RFCCode():   codes.ResourceExhausted,
 	ErrMetaStoreNotExists.RFCCode():    codes.NotFound,
 	ErrResourceAlreadyExists.RFCCode(): codes.AlreadyExists,
 	ErrIllegalResourcePath.RFCCode():   codes.InvalidArgument,
@@ -133,6 +133,21 @@ func TestChangefeedFastFailError(t *testing.T) {
 	require.Equal(t, false, IsChangefeedFastFailErrorCode(rfcCode))
 }
 
+func TestChangefeedPauseError(t *testing.T) {
+	t.Parallel()
+	err := ErrConsumerContractViolated.GenWithStackByArgs("drop table t", "topic", "aa")
+	rfcCode, _ := RFCCode(err)
+	require.True(t, IsChangefeedPauseError(err))
+	require.True(t, IsChangefeedPauseErrorCode(rfcCode))
+	require.True(t, IsChangefeedPauseError(errors.Trace(err)))
+
+	err = ErrGCTTLExceeded.FastGenByArgs()
+	rfcCode, _ = RFCCode(err)
+	require.False(t, IsChangefeedPauseError(err))
+	require.False(t, IsChangefeedPauseErrorCode(rfcCode))
+	require.False(t, IsChangefeedPauseError(nil))
+}
+
 func TestIsChangefeedUnRetryableError(t *testing.T) {
 	t.Parallel()
 	cases := []struct {
@@ -49,6 +49,12 @@ func CaptureInfoKeyPrefix(clusterID string) string {
 	return BaseKey(clusterID) + metaPrefix + captureKey
 }
 
+// DynamicConfigKey is the key of the dynamic server config of a cluster, the
+// value is a JSON document overriding the dynamic config of all the captures.
+func DynamicConfigKey(clusterID string) string {
+	return BaseKey(clusterID) + metaPrefix + dynamicConfigKey
+}
+
 // TaskPositionKeyPrefix is the prefix of task position keys
 func TaskPositionKeyPrefix(clusterID, namespace string) string {
 	return NamespacedPrefix(clusterID, namespace) + taskPositionKey
@@ -59,6 +65,11 @@ func ChangefeedStatusKeyPrefix(clusterID, namespace string) string {
 	return NamespacedPrefix(clusterID, namespace) + ChangefeedStatusKey
 }
 
+// UpstreamInfoKeyPrefix is the prefix of upstream info keys
+func UpstreamInfoKeyPrefix(clusterID, namespace string) string {
+	return NamespacedPrefix(clusterID, namespace) + upstreamKey
+}
+
 // GetEtcdKeyChangeFeedList returns the prefix key of all changefeed config
 func GetEtcdKeyChangeFeedList(clusterID, namespace string) string {
 	return fmt.Sprintf("%s/changefeed/info", NamespacedPrefix(clusterID, namespace))
@@ -128,6 +139,20 @@ type CDCEtcdClient interface {
 		namespace string,
 	) (*model.UpstreamInfo, error)
 
+	GetAllUpstreamInfo(ctx context.Context,
+		namespace string,
+	) (map[model.UpstreamID]*model.UpstreamInfo, error)
+
+	SaveUpstreamInfo(ctx context.Context,
+		upstreamInfo *model.UpstreamInfo,
+		namespace string,
+	) error
+
+	DeleteUpstreamInfo(ctx context.Context,
+		upstreamID model.UpstreamID,
+		namespace string,
+	) error
+
 	GetGCServiceID() string
 
 	GetEnsureGCServiceID(tag string) string
@@ -636,6 +661,60 @@ func (c *CDCEtcdClientImpl) GetUpstreamInfo(ctx context.Context,
 	return info, errors.Trace(err)
 }
 
+// GetAllUpstreamInfo gets all upstreamInfos of a namespace from etcd server
+func (c *CDCEtcdClientImpl) GetAllUpstreamInfo(ctx context.Context,
+	namespace string,
+) (map[model.UpstreamID]*model.UpstreamInfo, error) {
+	resp, err := c.Client.Get(ctx, UpstreamInfoKeyPrefix(c.ClusterID, namespace),
+		clientv3.WithPrefix())
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrPDEtcdAPIError, err)
+	}
+	infos := make(map[model.UpstreamID]*model.UpstreamInfo, resp.Count)
+	for _, kv := range resp.Kvs {
+		info := &model.UpstreamInfo{}
+		if err := info.Unmarshal(kv.Value); err != nil {
+			return nil, errors.Trace(err)
+		}
+		infos[info.ID] = info
+	}
+	return infos, nil
+}
+
+// SaveUpstreamInfo stores an upstreamInfo into etcd server
+func (c *CDCEtcdClientImpl) SaveUpstreamInfo(ctx context.Context,
+	upstreamInfo *model.UpstreamInfo,
+	namespace string,
+) error {
+	key := CDCKey{
+		Tp:         CDCKeyTypeUpStream,
+		ClusterID:  c.ClusterID,
+		UpstreamID: upstreamInfo.ID,
+		Namespace:  namespace,
+	}
+	value, err := upstreamInfo.Marshal()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	_, err = c.Client.Put(ctx, key.String(), string(value))
+	return cerror.WrapError(cerror.ErrPDEtcdAPIError, err)
+}
+
+// DeleteUpstreamInfo deletes an upstreamInfo from etcd server
+func (c *CDCEtcdClientImpl) DeleteUpstreamInfo(ctx context.Context,
+	upstreamID model.UpstreamID,
+	namespace string,
+) error {
+	key := CDCKey{
+		Tp:         CDCKeyTypeUpStream,
+		ClusterID:  c.ClusterID,
+		UpstreamID: upstreamID,
+		Namespace:  namespace,
+	}
+	_, err := c.Client.Delete(ctx, key.String())
+	return cerror.WrapError(cerror.ErrPDEtcdAPIError, err)
+}
+
 // GcServiceIDForTest returns the gc service ID for tests
 func GcServiceIDForTest() string {
 	return fmt.Sprintf("ticdc-%s-%d", "default", 0)
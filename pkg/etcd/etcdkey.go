@@ -37,6 +37,8 @@ const (
 	// metaVersionKey is the key path for metadata version
 	metaVersionKey = "/meta/meta-version"
 	upstreamKey    = "/upstream"
+	// dynamicConfigKey is the key path for the dynamic server config
+	dynamicConfigKey = "/dynamic-config"
 
 	// DeletionCounterKey is the key path for the counter of deleted keys
 	DeletionCounterKey = metaPrefix + "/meta/ticdc-delete-etcd-key-count"
@@ -63,6 +65,7 @@ const (
 	CDCKeyTypeTaskPosition
 	CDCKeyTypeMetaVersion
 	CDCKeyTypeUpStream
+	CDCKeyTypeDynamicConfig
 )
 
 // CDCKey represents an etcd key which is defined by TiCDC
@@ -135,6 +138,8 @@ func (k *CDCKey) Parse(clusterID, key string) error {
 			k.OwnerLeaseID = ""
 		case strings.HasPrefix(key, metaVersionKey):
 			k.Tp = CDCKeyTypeMetaVersion
+		case strings.HasPrefix(key, dynamicConfigKey):
+			k.Tp = CDCKeyTypeDynamicConfig
 		default:
 			return cerror.ErrInvalidEtcdKey.GenWithStackByArgs(key)
 		}
@@ -206,6 +211,8 @@ func (k *CDCKey) String() string {
 			"/" + k.CaptureID + "/" + k.ChangefeedID.ID
 	case CDCKeyTypeMetaVersion:
 		return BaseKey(k.ClusterID) + metaPrefix + metaVersionKey
+	case CDCKeyTypeDynamicConfig:
+		return BaseKey(k.ClusterID) + metaPrefix + dynamicConfigKey
 	case CDCKeyTypeUpStream:
 		return fmt.Sprintf("%s%s/%d",
 			NamespacedPrefix(k.ClusterID, k.Namespace),
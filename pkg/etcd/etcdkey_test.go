@@ -111,6 +111,12 @@ func TestEtcdKey(t *testing.T) {
 			Tp:        CDCKeyTypeMetaVersion,
 			ClusterID: DefaultCDCClusterID,
 		},
+	}, {
+		key: fmt.Sprintf("%s%s", DefaultClusterAndMetaPrefix, dynamicConfigKey),
+		expected: &CDCKey{
+			Tp:        CDCKeyTypeDynamicConfig,
+			ClusterID: DefaultCDCClusterID,
+		},
 	}}
 	for _, tc := range testcases {
 		k := new(CDCKey)
@@ -45,6 +45,7 @@ type dmlExprFilterRule struct {
 	updateOldExprs map[string]expression.Expression // tableName -> expr
 	updateNewExprs map[string]expression.Expression // tableName -> expr
 	deleteExprs    map[string]expression.Expression // tableName -> expr
+	keepExprs      map[string]expression.Expression // tableName -> expr
 
 	tableMatcher tfilter.Filter
 	// All tables in this rule share the same config.
@@ -68,6 +69,7 @@ func newExprFilterRule(
 		updateOldExprs: make(map[string]expression.Expression),
 		updateNewExprs: make(map[string]expression.Expression),
 		deleteExprs:    make(map[string]expression.Expression),
+		keepExprs:      make(map[string]expression.Expression),
 		config:         cfg,
 		tableMatcher:   tf,
 		sessCtx:        sessCtx,
@@ -104,6 +106,12 @@ func (r *dmlExprFilterRule) verify(tableInfos []*model.TableInfo) error {
 		return cerror.ErrExpressionParseFailed.
 			FastGenByArgs(r.config.IgnoreDeleteValueExpr)
 	}
+	_, _, err = p.ParseSQL(completeExpression(r.config.KeepValueExpr))
+	if err != nil {
+		log.Error("failed to parse expression", zap.Error(err))
+		return cerror.ErrExpressionParseFailed.
+			FastGenByArgs(r.config.KeepValueExpr)
+	}
 	// verify expression filter rule.
 	for _, ti := range tableInfos {
 		tableName := ti.TableName.String()
@@ -111,33 +119,40 @@ func (r *dmlExprFilterRule) verify(tableInfos []*model.TableInfo) error {
 			continue
 		}
 		if r.config.IgnoreInsertValueExpr != "" {
-			e, err := r.getSimpleExprOfTable(r.config.IgnoreInsertValueExpr, ti)
+			e, err := getSimpleExprOfTable(r.sessCtx, r.config.IgnoreInsertValueExpr, ti)
 			if err != nil {
 				return err
 			}
 			r.insertExprs[tableName] = e
 		}
 		if r.config.IgnoreUpdateOldValueExpr != "" {
-			e, err := r.getSimpleExprOfTable(r.config.IgnoreUpdateOldValueExpr, ti)
+			e, err := getSimpleExprOfTable(r.sessCtx, r.config.IgnoreUpdateOldValueExpr, ti)
 			if err != nil {
 				return err
 			}
 			r.updateOldExprs[tableName] = e
 		}
 		if r.config.IgnoreUpdateNewValueExpr != "" {
-			e, err := r.getSimpleExprOfTable(r.config.IgnoreUpdateNewValueExpr, ti)
+			e, err := getSimpleExprOfTable(r.sessCtx, r.config.IgnoreUpdateNewValueExpr, ti)
 			if err != nil {
 				return err
 			}
 			r.updateNewExprs[tableName] = e
 		}
 		if r.config.IgnoreDeleteValueExpr != "" {
-			e, err := r.getSimpleExprOfTable(r.config.IgnoreDeleteValueExpr, ti)
+			e, err := getSimpleExprOfTable(r.sessCtx, r.config.IgnoreDeleteValueExpr, ti)
 			if err != nil {
 				return err
 			}
 			r.deleteExprs[tableName] = e
 		}
+		if r.config.KeepValueExpr != "" {
+			e, err := getSimpleExprOfTable(r.sessCtx, r.config.KeepValueExpr, ti)
+			if err != nil {
+				return err
+			}
+			r.keepExprs[tableName] = e
+		}
 	}
 	return nil
 }
@@ -148,6 +163,7 @@ func (r *dmlExprFilterRule) resetExpr(tableName string) {
 	delete(r.updateOldExprs, tableName)
 	delete(r.updateNewExprs, tableName)
 	delete(r.deleteExprs, tableName)
+	delete(r.keepExprs, tableName)
 }
 
 // getInsertExprs returns the expression filter to filter INSERT events.
@@ -160,7 +176,7 @@ func (r *dmlExprFilterRule) getInsertExpr(ti *model.TableInfo) (
 		return r.insertExprs[tableName], nil
 	}
 	if r.config.IgnoreInsertValueExpr != "" {
-		expr, err := r.getSimpleExprOfTable(r.config.IgnoreInsertValueExpr, ti)
+		expr, err := getSimpleExprOfTable(r.sessCtx, r.config.IgnoreInsertValueExpr, ti)
 		if err != nil {
 			return nil, err
 		}
@@ -178,7 +194,7 @@ func (r *dmlExprFilterRule) getUpdateOldExpr(ti *model.TableInfo) (
 	}
 
 	if r.config.IgnoreUpdateOldValueExpr != "" {
-		expr, err := r.getSimpleExprOfTable(r.config.IgnoreUpdateOldValueExpr, ti)
+		expr, err := getSimpleExprOfTable(r.sessCtx, r.config.IgnoreUpdateOldValueExpr, ti)
 		if err != nil {
 			return nil, err
 		}
@@ -196,7 +212,7 @@ func (r *dmlExprFilterRule) getUpdateNewExpr(ti *model.TableInfo) (
 	}
 
 	if r.config.IgnoreUpdateNewValueExpr != "" {
-		expr, err := r.getSimpleExprOfTable(r.config.IgnoreUpdateNewValueExpr, ti)
+		expr, err := getSimpleExprOfTable(r.sessCtx, r.config.IgnoreUpdateNewValueExpr, ti)
 		if err != nil {
 			return nil, err
 		}
@@ -214,7 +230,7 @@ func (r *dmlExprFilterRule) getDeleteExpr(ti *model.TableInfo) (
 	}
 
 	if r.config.IgnoreDeleteValueExpr != "" {
-		expr, err := r.getSimpleExprOfTable(r.config.IgnoreDeleteValueExpr, ti)
+		expr, err := getSimpleExprOfTable(r.sessCtx, r.config.IgnoreDeleteValueExpr, ti)
 		if err != nil {
 			return nil, err
 		}
@@ -223,11 +239,30 @@ func (r *dmlExprFilterRule) getDeleteExpr(ti *model.TableInfo) (
 	return r.deleteExprs[tableName], nil
 }
 
-func (r *dmlExprFilterRule) getSimpleExprOfTable(
+func (r *dmlExprFilterRule) getKeepExpr(ti *model.TableInfo) (
+	expression.Expression, error,
+) {
+	tableName := ti.TableName.String()
+	if r.keepExprs[tableName] != nil {
+		return r.keepExprs[tableName], nil
+	}
+
+	if r.config.KeepValueExpr != "" {
+		expr, err := getSimpleExprOfTable(r.sessCtx, r.config.KeepValueExpr, ti)
+		if err != nil {
+			return nil, err
+		}
+		r.keepExprs[tableName] = expr
+	}
+	return r.keepExprs[tableName], nil
+}
+
+func getSimpleExprOfTable(
+	sessCtx sessionctx.Context,
 	expr string,
 	ti *model.TableInfo,
 ) (expression.Expression, error) {
-	e, err := expression.ParseSimpleExprWithTableInfo(r.sessCtx, expr, ti.TableInfo)
+	e, err := expression.ParseSimpleExprWithTableInfo(sessCtx, expr, ti.TableInfo)
 	if err != nil {
 		// If an expression contains an unknown column,
 		// we return an error and stop the changefeed.
@@ -265,6 +300,10 @@ func (r *dmlExprFilterRule) shouldSkipDML(
 		r.tables[tableName] = ti.Clone()
 	}
 
+	if skip, err := r.skipDMLByKeepExpr(row, rawRow, ti); err != nil || skip {
+		return skip, err
+	}
+
 	switch {
 	case row.IsInsert():
 		exprs, err := r.getInsertExpr(ti)
@@ -314,6 +353,49 @@ func (r *dmlExprFilterRule) shouldSkipDML(
 	}
 }
 
+// skipDMLByKeepExpr returns true if the row doesn't match the keep
+// expression. The downstream never receives the rows which aren't kept, so
+// an update moving a row out of the kept ones is converted to a delete of
+// the old row, and an update moving a row in is converted to an insert of
+// the new row.
+func (r *dmlExprFilterRule) skipDMLByKeepExpr(
+	row *model.RowChangedEvent,
+	rawRow model.RowChangedDatums,
+	ti *model.TableInfo,
+) (bool, error) {
+	expr, err := r.getKeepExpr(ti)
+	if err != nil || expr == nil {
+		return false, err
+	}
+
+	switch {
+	case row.IsInsert():
+		kept, err := r.skipDMLByExpression(rawRow.RowDatums, expr)
+		return !kept, err
+	case row.IsUpdate():
+		oldKept, err := r.skipDMLByExpression(rawRow.PreRowDatums, expr)
+		if err != nil {
+			return false, err
+		}
+		newKept, err := r.skipDMLByExpression(rawRow.RowDatums, expr)
+		if err != nil {
+			return false, err
+		}
+		switch {
+		case oldKept && !newKept:
+			row.Columns = nil
+		case !oldKept && newKept:
+			row.PreColumns = nil
+		}
+		return !oldKept && !newKept, nil
+	case row.IsDelete():
+		kept, err := r.skipDMLByExpression(rawRow.PreRowDatums, expr)
+		return !kept, err
+	default:
+		return false, nil
+	}
+}
+
 func (r *dmlExprFilterRule) skipDMLByExpression(
 	rowData []types.Datum,
 	expr expression.Expression,
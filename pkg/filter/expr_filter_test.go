@@ -208,6 +208,82 @@ func TestShouldSkipDMLBasic(t *testing.T) {
 				},
 			},
 		},
+		{ // test case for keep value expression
+			ddl: "create table test.orders(id int primary key, status char(20), amount int)",
+			cfg: &config.FilterConfig{
+				EventFilters: []*config.EventFilterRule{
+					{
+						Matcher:               []string{"test.orders"},
+						IgnoreInsertValueExpr: "amount > 1000",
+						KeepValueExpr:         "status != 'internal'",
+					},
+				},
+			},
+			cases: []innerCase{
+				{ // insert
+					schema: "test",
+					table:  "orders",
+					columns: []*model.Column{
+						{Name: "none"},
+					},
+					row:    []interface{}{1, "paid", 100},
+					ignore: false,
+				},
+				{ // insert, not kept
+					schema: "test",
+					table:  "orders",
+					columns: []*model.Column{
+						{Name: "none"},
+					},
+					row:    []interface{}{2, "internal", 100},
+					ignore: true,
+				},
+				{ // insert, kept but ignored
+					schema: "test",
+					table:  "orders",
+					columns: []*model.Column{
+						{Name: "none"},
+					},
+					row:    []interface{}{3, "paid", 2000},
+					ignore: true,
+				},
+				{ // update moving the row out, converted to a delete
+					schema: "test",
+					table:  "orders",
+					preColumns: []*model.Column{
+						{Name: "none"},
+					},
+					preRow: []interface{}{4, "paid", 100},
+					columns: []*model.Column{
+						{Name: "none"},
+					},
+					row:    []interface{}{4, "internal", 100},
+					ignore: false,
+				},
+				{ // update, not kept
+					schema: "test",
+					table:  "orders",
+					preColumns: []*model.Column{
+						{Name: "none"},
+					},
+					preRow: []interface{}{5, "internal", 100},
+					columns: []*model.Column{
+						{Name: "none"},
+					},
+					row:    []interface{}{5, "internal", 200},
+					ignore: true,
+				},
+				{ // delete, not kept
+					schema: "test",
+					table:  "orders",
+					preColumns: []*model.Column{
+						{Name: "none"},
+					},
+					preRow: []interface{}{6, "internal", 100},
+					ignore: true,
+				},
+			},
+		},
 		{ // test case for gbk charset
 			ddl: "create table test.poet(id int primary key, name varchar(50) CHARACTER SET GBK COLLATE gbk_bin, works char(100))",
 			cfg: &config.FilterConfig{
@@ -350,6 +426,60 @@ func TestShouldSkipDMLBasic(t *testing.T) {
 	}
 }
 
+func TestKeepExprConvertsUpdates(t *testing.T) {
+	helper := newTestHelper(t)
+	defer helper.close()
+	helper.getTk().MustExec("use test;")
+
+	tableInfo := helper.execDDL(
+		"create table test.payments(id int primary key, status char(20), amount int)")
+	f, err := newExprFilter("", &config.FilterConfig{
+		EventFilters: []*config.EventFilterRule{
+			{
+				Matcher:       []string{"test.payments"},
+				KeepValueExpr: "status != 'internal'",
+			},
+		},
+	})
+	require.Nil(t, err)
+	sessCtx := utils.NewSessionCtx(map[string]string{
+		"time_zone": "System",
+	})
+	update := func(preRow, row []interface{}) (*model.RowChangedEvent, bool) {
+		rowDatums, err := utils.AdjustBinaryProtocolForDatum(sessCtx, row, tableInfo.Columns)
+		require.Nil(t, err)
+		preRowDatums, err := utils.AdjustBinaryProtocolForDatum(sessCtx, preRow, tableInfo.Columns)
+		require.Nil(t, err)
+		event := &model.RowChangedEvent{
+			Table:      &model.TableName{Schema: "test", Table: "payments"},
+			PreColumns: []*model.Column{{Name: "none"}},
+			Columns:    []*model.Column{{Name: "none"}},
+		}
+		ignore, err := f.shouldSkipDML(event, model.RowChangedDatums{
+			RowDatums:    rowDatums,
+			PreRowDatums: preRowDatums,
+		}, tableInfo)
+		require.Nil(t, err)
+		return event, ignore
+	}
+
+	// An update moving the row out of the kept ones is a delete.
+	event, ignore := update([]interface{}{1, "paid", 100}, []interface{}{1, "internal", 100})
+	require.False(t, ignore)
+	require.True(t, event.IsDelete())
+	// An update moving the row in is an insert.
+	event, ignore = update([]interface{}{2, "internal", 100}, []interface{}{2, "paid", 100})
+	require.False(t, ignore)
+	require.True(t, event.IsInsert())
+	// An update within the kept ones is still an update.
+	event, ignore = update([]interface{}{3, "paid", 100}, []interface{}{3, "refunded", 100})
+	require.False(t, ignore)
+	require.True(t, event.IsUpdate())
+	// An update outside the kept ones is ignored.
+	_, ignore = update([]interface{}{4, "internal", 100}, []interface{}{4, "internal", 200})
+	require.True(t, ignore)
+}
+
 // This test case is for testing when there are syntax error
 // or unknown error in the expression the return error type and message
 // are as expected.
@@ -71,6 +71,9 @@ type Filter interface {
 	ShouldDiscardDDL(ddlType timodel.ActionType, schema, table string) bool
 	// ShouldIgnoreTable returns true if the table should be ignored.
 	ShouldIgnoreTable(schema, table string) bool
+	// ShouldIgnoreTableInfo returns true if the table should be ignored by
+	// its name or its attributes.
+	ShouldIgnoreTableInfo(tableInfo *model.TableInfo) bool
 	// Verify should only be called by create changefeed OpenAPI.
 	// Its purpose is to verify the expression filter config.
 	Verify(tableInfos []*model.TableInfo) error
@@ -86,6 +89,9 @@ type filter struct {
 	sqlEventFilter *sqlEventFilter
 	// ignoreTxnStartTs is used to filter out dml/ddl event by its starsTs.
 	ignoreTxnStartTs []uint64
+	// tableAttributeFilter is used to filter in dml/ddl event by the
+	// attributes of the table, it's nil if it's disabled.
+	tableAttributeFilter *tableAttributeFilter
 }
 
 // NewFilter creates a filter.
@@ -108,16 +114,17 @@ func NewFilter(cfg *config.ReplicaConfig, tz string) (Filter, error) {
 		return nil, err
 	}
 	return &filter{
-		tableFilter:      f,
-		dmlExprFilter:    dmlExprFilter,
-		sqlEventFilter:   sqlEventFilter,
-		ignoreTxnStartTs: cfg.Filter.IgnoreTxnStartTs,
+		tableFilter:          f,
+		dmlExprFilter:        dmlExprFilter,
+		sqlEventFilter:       sqlEventFilter,
+		ignoreTxnStartTs:     cfg.Filter.IgnoreTxnStartTs,
+		tableAttributeFilter: newTableAttributeFilter(cfg.Filter),
 	}, nil
 }
 
 // ShouldIgnoreDMLEvent checks if a DML event should be ignore by conditions below:
 // 0. By startTs.
-// 1. By table name.
+// 1. By table name and attributes.
 // 2. By type.
 // 3. By columns value.
 func (f *filter) ShouldIgnoreDMLEvent(
@@ -129,7 +136,7 @@ func (f *filter) ShouldIgnoreDMLEvent(
 		return true, nil
 	}
 
-	if f.ShouldIgnoreTable(dml.Table.Schema, dml.Table.Table) {
+	if f.ShouldIgnoreTable(dml.Table.Schema, dml.Table.Table) || !f.matchTableAttributes(ti) {
 		return true, nil
 	}
 
@@ -146,7 +153,7 @@ func (f *filter) ShouldIgnoreDMLEvent(
 // ShouldIgnoreDDLEvent checks if a DDL Event should be ignore by conditions below:
 // 0. By startTs.
 // 1. By schema name.
-// 2. By table name.
+// 2. By table name and attributes.
 // 3. By type.
 // 4. By query.
 func (f *filter) ShouldIgnoreDDLEvent(ddl *model.DDLEvent) (bool, error) {
@@ -161,8 +168,13 @@ func (f *filter) ShouldIgnoreDDLEvent(ddl *model.DDLEvent) (bool, error) {
 		shouldIgnoreTableOrSchema = !f.tableFilter.MatchSchema(ddl.TableInfo.TableName.Schema)
 	case timodel.ActionRenameTable:
 		shouldIgnoreTableOrSchema = f.ShouldIgnoreTable(ddl.PreTableInfo.TableName.Schema, ddl.PreTableInfo.TableName.Table)
+	case timodel.ActionAlterTablePlacement:
+		// It's only applied to the schema storage to select the tables by
+		// their placement policies, the downstream doesn't have them.
+		shouldIgnoreTableOrSchema = true
 	default:
-		shouldIgnoreTableOrSchema = f.ShouldIgnoreTable(ddl.TableInfo.TableName.Schema, ddl.TableInfo.TableName.Table)
+		shouldIgnoreTableOrSchema = f.ShouldIgnoreTable(ddl.TableInfo.TableName.Schema, ddl.TableInfo.TableName.Table) ||
+			!f.matchTableAttributes(ddl.TableInfo)
 	}
 	if shouldIgnoreTableOrSchema {
 		return true, nil
@@ -176,6 +188,13 @@ func (f *filter) ShouldIgnoreDDLEvent(ddl *model.DDLEvent) (bool, error) {
 func (f *filter) ShouldDiscardDDL(ddlType timodel.ActionType, schema, table string) (discard bool) {
 	discard = true
 
+	if ddlType == timodel.ActionAlterTablePlacement {
+		if f.tableAttributeFilter != nil && len(f.tableAttributeFilter.placementPolicies) > 0 {
+			discard = f.ShouldIgnoreTable(schema, table)
+		}
+		return
+	}
+
 	for _, actionType := range allowDDLList {
 		if ddlType == actionType {
 			discard = false
@@ -206,6 +225,24 @@ func (f *filter) ShouldIgnoreTable(db, tbl string) bool {
 	return !f.tableFilter.MatchTable(db, tbl)
 }
 
+// ShouldIgnoreTableInfo returns true if the table should be ignored by this
+// change feed, by its name or its attributes.
+func (f *filter) ShouldIgnoreTableInfo(tableInfo *model.TableInfo) bool {
+	if f.ShouldIgnoreTable(tableInfo.TableName.Schema, tableInfo.TableName.Table) {
+		return true
+	}
+	return !f.matchTableAttributes(tableInfo)
+}
+
+// matchTableAttributes returns whether the table is selected by its
+// attributes, all the tables are selected if the filter is disabled.
+func (f *filter) matchTableAttributes(tableInfo *model.TableInfo) bool {
+	if f.tableAttributeFilter == nil || tableInfo == nil || tableInfo.TableInfo == nil {
+		return true
+	}
+	return f.tableAttributeFilter.match(tableInfo.TableInfo)
+}
+
 func (f *filter) Verify(tableInfos []*model.TableInfo) error {
 	return f.dmlExprFilter.verify(tableInfos)
 }
@@ -327,3 +327,75 @@ func TestShouldIgnoreDDL(t *testing.T) {
 		}
 	}
 }
+
+func TestShouldIgnoreTableInfoByAttributes(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.GetDefaultReplicaConfig()
+	cfg.Filter.Rules = []string{"test.*"}
+	cfg.Filter.TableAttributes = &config.TableAttributesConfig{
+		PlacementPolicies: []string{"P1"},
+		CommentTags:       []string{"cdc", "Replication=Analytics"},
+	}
+	f, err := NewFilter(cfg, "")
+	require.Nil(t, err)
+
+	newTableInfo := func(schema, comment, policy string) *model.TableInfo {
+		tableInfo := &model.TableInfo{
+			TableName: model.TableName{Schema: schema, Table: "t"},
+			TableInfo: &timodel.TableInfo{Comment: comment},
+		}
+		if policy != "" {
+			tableInfo.PlacementPolicyRef = &timodel.PolicyRefInfo{Name: timodel.NewCIStr(policy)}
+		}
+		return tableInfo
+	}
+	require.False(t, f.ShouldIgnoreTableInfo(newTableInfo("test", "orders; CDC", "")))
+	require.False(t, f.ShouldIgnoreTableInfo(newTableInfo("test", "users, replication=analytics", "")))
+	require.False(t, f.ShouldIgnoreTableInfo(newTableInfo("test", "", "p1")))
+	require.True(t, f.ShouldIgnoreTableInfo(newTableInfo("test", "cdc_not_a_tag", "p2")))
+	require.True(t, f.ShouldIgnoreTableInfo(newTableInfo("other", "cdc", "")))
+
+	ignore, err := f.ShouldIgnoreDDLEvent(&model.DDLEvent{
+		Type:      timodel.ActionCreateTable,
+		TableInfo: newTableInfo("test", "no tag", ""),
+	})
+	require.Nil(t, err)
+	require.True(t, ignore)
+
+	// All the tables are selected if the attributes are not configured.
+	f, err = NewFilter(config.GetDefaultReplicaConfig(), "")
+	require.Nil(t, err)
+	require.False(t, f.ShouldIgnoreTableInfo(newTableInfo("test", "", "")))
+}
+
+func TestAlterTablePlacementByAttributes(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.GetDefaultReplicaConfig()
+	cfg.Filter.Rules = []string{"test.*"}
+	f, err := NewFilter(cfg, "")
+	require.Nil(t, err)
+	require.True(t, f.ShouldDiscardDDL(timodel.ActionAlterTablePlacement, "test", "t"))
+
+	// The placement of the tables is tracked if they are selected by it, but
+	// it's never replicated.
+	cfg.Filter.TableAttributes = &config.TableAttributesConfig{PlacementPolicies: []string{"p1"}}
+	f, err = NewFilter(cfg, "")
+	require.Nil(t, err)
+	require.False(t, f.ShouldDiscardDDL(timodel.ActionAlterTablePlacement, "test", "t"))
+	require.True(t, f.ShouldDiscardDDL(timodel.ActionAlterTablePlacement, "other", "t"))
+	tableInfo := &model.TableInfo{
+		TableName: model.TableName{Schema: "test", Table: "t"},
+		TableInfo: &timodel.TableInfo{
+			PlacementPolicyRef: &timodel.PolicyRefInfo{Name: timodel.NewCIStr("p1")},
+		},
+	}
+	ignore, err := f.ShouldIgnoreDDLEvent(&model.DDLEvent{
+		Type:         timodel.ActionAlterTablePlacement,
+		TableInfo:    tableInfo,
+		PreTableInfo: tableInfo,
+	})
+	require.Nil(t, err)
+	require.True(t, ignore)
+}
@@ -0,0 +1,144 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filter
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/tidb/expression"
+	"github.com/pingcap/tidb/parser"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/dm/pkg/utils"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// RowExpr is a SQL expression evaluated over the row changed events by the
+// same engine as the expression filters. The expression is built for each
+// version of a table, so the columns are looked up by their names after the
+// schema changes.
+type RowExpr struct {
+	expr    string
+	sessCtx sessionctx.Context
+
+	mu sync.Mutex
+	// tableName -> the expression built for the table
+	exprs map[string]*tableExpr
+}
+
+type tableExpr struct {
+	version uint64
+	expr    expression.Expression
+}
+
+// NewRowExpr creates a RowExpr, it returns an error if the syntax of the
+// expression is invalid.
+func NewRowExpr(expr string) (*RowExpr, error) {
+	if _, _, err := parser.New().ParseSQL(completeExpression(expr)); err != nil {
+		log.Error("failed to parse expression", zap.Error(err))
+		return nil, cerror.ErrExpressionParseFailed.FastGenByArgs(expr)
+	}
+	return &RowExpr{
+		expr: expr,
+		// The values of the row changed events are already in the time zone
+		// of the changefeed, they are not converted again in UTC.
+		sessCtx: utils.NewSessionCtx(map[string]string{
+			"time_zone": "UTC",
+		}),
+		exprs: make(map[string]*tableExpr),
+	}, nil
+}
+
+// Verify builds the expression for the table, it returns an error if the
+// expression refers to a column which doesn't exist in the table.
+func (e *RowExpr) Verify(ti *model.TableInfo) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	_, err := e.getExpr(ti)
+	return err
+}
+
+// Eval evaluates the expression over the new row of an insert or an update,
+// and over the old row of a delete.
+func (e *RowExpr) Eval(row *model.RowChangedEvent) (types.Datum, error) {
+	if row.TableInfo == nil {
+		return types.Datum{}, errors.Errorf("the table info of %s is unknown", row.Table)
+	}
+	cols := row.Columns
+	if len(cols) == 0 {
+		cols = row.PreColumns
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	expr, err := e.getExpr(row.TableInfo)
+	if err != nil {
+		return types.Datum{}, err
+	}
+	datums, err := e.datums(row.TableInfo, cols)
+	if err != nil {
+		return types.Datum{}, err
+	}
+	d, err := expr.Eval(chunk.MutRowFromDatums(datums).ToRow())
+	if err != nil {
+		return types.Datum{}, errors.Trace(err)
+	}
+	return d, nil
+}
+
+// getExpr returns the expression of the table, it's rebuilt if the table is
+// changed. The caller must hold e.mu.
+func (e *RowExpr) getExpr(ti *model.TableInfo) (expression.Expression, error) {
+	tableName := ti.TableName.String()
+	if te, ok := e.exprs[tableName]; ok && te.version == ti.Version {
+		return te.expr, nil
+	}
+	expr, err := getSimpleExprOfTable(e.sessCtx, e.expr, ti)
+	if err != nil {
+		return nil, err
+	}
+	e.exprs[tableName] = &tableExpr{version: ti.Version, expr: expr}
+	return expr, nil
+}
+
+// datums converts the columns of a row to the datums at the offsets of the
+// columns in the table, which the expressions of the table are built over.
+func (e *RowExpr) datums(ti *model.TableInfo, cols []*model.Column) ([]types.Datum, error) {
+	values := make(map[string]*model.Column, len(cols))
+	for _, col := range cols {
+		if col != nil {
+			values[strings.ToLower(col.Name)] = col
+		}
+	}
+	sc := e.sessCtx.GetSessionVars().StmtCtx
+	datums := make([]types.Datum, len(ti.Columns))
+	for _, colInfo := range ti.Columns {
+		col, ok := values[colInfo.Name.L]
+		if !ok || col.Value == nil || colInfo.Offset >= len(datums) {
+			continue
+		}
+		d, err := types.NewDatum(col.Value).ConvertTo(sc, &colInfo.FieldType)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		datums[colInfo.Offset] = d
+	}
+	return datums, nil
+}
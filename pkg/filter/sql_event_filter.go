@@ -18,6 +18,8 @@ import (
 	"github.com/pingcap/log"
 	bf "github.com/pingcap/tidb-tools/pkg/binlog-filter"
 	"github.com/pingcap/tidb/parser"
+	"github.com/pingcap/tidb/parser/ast"
+	timodel "github.com/pingcap/tidb/parser/model"
 	tfilter "github.com/pingcap/tidb/util/table-filter"
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/pkg/config"
@@ -47,6 +49,11 @@ type sqlEventRule struct {
 	// which means not match `test.t1`.
 	tf tfilter.Filter
 	bf *bf.BinlogEvent
+	// ignoredAlterTypes are the fine-grained types of the DDLs altering a
+	// table to ignore, the binlog filter doesn't know them.
+	ignoredAlterTypes map[bf.EventType]struct{}
+	// allowedDDLTypes are the types of the DDLs never ignored by the rule.
+	allowedDDLTypes map[bf.EventType]struct{}
 }
 
 func newSQLEventFilterRule(cfg *config.EventFilterRule) (*sqlEventRule, error) {
@@ -56,17 +63,33 @@ func newSQLEventFilterRule(cfg *config.EventFilterRule) (*sqlEventRule, error) {
 	}
 
 	res := &sqlEventRule{
-		tf: tf,
+		tf:                tf,
+		ignoredAlterTypes: make(map[bf.EventType]struct{}),
+		allowedDDLTypes:   make(map[bf.EventType]struct{}),
 	}
 
 	if err := verifyIgnoreEvents(cfg.IgnoreEvent); err != nil {
 		return nil, err
 	}
+	if err := verifyAllowDDLs(cfg.AllowDDL); err != nil {
+		return nil, err
+	}
+	ignoredEvents := make([]bf.EventType, 0, len(cfg.IgnoreEvent))
+	for _, et := range cfg.IgnoreEvent {
+		if isAlterTableType(et) {
+			res.ignoredAlterTypes[et] = struct{}{}
+		} else {
+			ignoredEvents = append(ignoredEvents, et)
+		}
+	}
+	for _, et := range cfg.AllowDDL {
+		res.allowedDDLTypes[et] = struct{}{}
+	}
 
 	bfRule := &bf.BinlogEventRule{
 		SchemaPattern: binlogFilterSchemaPlaceholder,
 		TablePattern:  binlogFilterTablePlaceholder,
-		Events:        cfg.IgnoreEvent,
+		Events:        ignoredEvents,
 		SQLPattern:    cfg.IgnoreSQL,
 		Action:        bf.Ignore,
 	}
@@ -92,6 +115,48 @@ func verifyIgnoreEvents(types []bf.EventType) error {
 	return nil
 }
 
+func verifyAllowDDLs(types []bf.EventType) error {
+	for _, et := range types {
+		switch et {
+		case bf.AllDML, bf.InsertEvent, bf.UpdateEvent, bf.DeleteEvent:
+			return cerror.ErrInvalidAllowDDLType.GenWithStackByArgs(string(et))
+		}
+		if verifyIgnoreEvents([]bf.EventType{et}) != nil {
+			return cerror.ErrInvalidAllowDDLType.GenWithStackByArgs(string(et))
+		}
+	}
+	return nil
+}
+
+// skipDDL returns whether the DDL of the types is ignored by the rule. A DDL
+// altering a table in several ways, i.e. a multi-schema change, is ignored
+// if any of its fine-grained types is ignored, unless all of them are allowed.
+func (r *sqlEventRule) skipDDL(eventType bf.EventType, alterTypes []bf.EventType, query string) (bool, error) {
+	for _, et := range []bf.EventType{bf.AllDDL, eventType} {
+		if _, ok := r.allowedDDLTypes[et]; ok {
+			return false, nil
+		}
+	}
+	allowed := len(alterTypes) > 0
+	for _, alterType := range alterTypes {
+		if _, ok := r.allowedDDLTypes[alterType]; ok {
+			continue
+		}
+		allowed = false
+		if _, ok := r.ignoredAlterTypes[alterType]; ok {
+			return true, nil
+		}
+	}
+	if allowed {
+		return false, nil
+	}
+	action, err := r.bf.Filter(binlogFilterSchemaPlaceholder, binlogFilterTablePlaceholder, eventType, query)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	return action == bf.Ignore, nil
+}
+
 // sqlEventFilter is a filter that filters DDL/DML event by its type or query.
 type sqlEventFilter struct {
 	p     *parser.Parser
@@ -148,13 +213,17 @@ func (f *sqlEventFilter) shouldSkipDDL(ddl *model.DDLEvent) (bool, error) {
 		return false, nil
 	}
 
+	alterTypes, err := f.alterTypesOf(ddl)
+	if err != nil {
+		return false, err
+	}
 	rules := f.getRules(ddl.TableInfo.TableName.Schema, ddl.TableInfo.TableName.Table)
 	for _, rule := range rules {
-		action, err := rule.bf.Filter(binlogFilterSchemaPlaceholder, binlogFilterTablePlaceholder, evenType, ddl.Query)
+		skip, err := rule.skipDDL(evenType, alterTypes, ddl.Query)
 		if err != nil {
-			return false, errors.Trace(err)
+			return false, err
 		}
-		if action == bf.Ignore {
+		if skip {
 			return true, nil
 		}
 	}
@@ -213,4 +282,122 @@ var supportedEventTypes = []bf.EventType{
 	bf.AddTablePartition,
 	bf.DropTablePartition,
 	bf.TruncateTablePartition,
+
+	// fine-grained alter table events
+	addColumnEvent,
+	dropColumnEvent,
+	modifyColumnEvent,
+	addIndexEvent,
+	dropIndexEvent,
+	renameIndexEvent,
+	addPrimaryKeyEvent,
+	dropPrimaryKeyEvent,
+	modifyTableCommentEvent,
+	modifyTableCharsetEvent,
+}
+
+// The fine-grained types of the DDLs altering a table, which are all
+// "alter table" for the binlog filter, they are matched by the type of the
+// DDL job instead.
+const (
+	addColumnEvent          bf.EventType = "add column"
+	dropColumnEvent         bf.EventType = "drop column"
+	modifyColumnEvent       bf.EventType = "modify column"
+	addIndexEvent           bf.EventType = "add index"
+	dropIndexEvent          bf.EventType = "drop index"
+	renameIndexEvent        bf.EventType = "rename index"
+	addPrimaryKeyEvent      bf.EventType = "add primary key"
+	dropPrimaryKeyEvent     bf.EventType = "drop primary key"
+	modifyTableCommentEvent bf.EventType = "modify table comment"
+	modifyTableCharsetEvent bf.EventType = "modify table charset"
+)
+
+var alterTableTypes = map[timodel.ActionType]bf.EventType{
+	timodel.ActionAddColumn:                    addColumnEvent,
+	timodel.ActionDropColumn:                   dropColumnEvent,
+	timodel.ActionModifyColumn:                 modifyColumnEvent,
+	timodel.ActionSetDefaultValue:              modifyColumnEvent,
+	timodel.ActionAddIndex:                     addIndexEvent,
+	timodel.ActionDropIndex:                    dropIndexEvent,
+	timodel.ActionRenameIndex:                  renameIndexEvent,
+	timodel.ActionAddPrimaryKey:                addPrimaryKeyEvent,
+	timodel.ActionDropPrimaryKey:               dropPrimaryKeyEvent,
+	timodel.ActionModifyTableComment:           modifyTableCommentEvent,
+	timodel.ActionModifyTableCharsetAndCollate: modifyTableCharsetEvent,
+}
+
+// alterTypesOf returns the fine-grained types of the DDL altering a table.
+// A multi-schema change has the types of all its sub-jobs, which are told by
+// the specs of its query, because the DDL event doesn't carry them.
+func (f *sqlEventFilter) alterTypesOf(ddl *model.DDLEvent) ([]bf.EventType, error) {
+	if ddl.Type != timodel.ActionMultiSchemaChange {
+		if alterType, ok := alterTableTypes[ddl.Type]; ok {
+			return []bf.EventType{alterType}, nil
+		}
+		return nil, nil
+	}
+	stmt, err := f.p.ParseOneStmt(ddl.Query, "", "")
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrConvertDDLToEventTypeFailed, err, ddl.Query)
+	}
+	alterStmt, ok := stmt.(*ast.AlterTableStmt)
+	if !ok {
+		return nil, nil
+	}
+	res := make([]bf.EventType, 0, len(alterStmt.Specs))
+	for _, spec := range alterStmt.Specs {
+		res = append(res, alterSpecTypes(spec)...)
+	}
+	return res, nil
+}
+
+// alterSpecTypes returns the fine-grained types of a spec of the DDL altering
+// a table, the specs without any, e.g. renaming a column, return nil.
+func alterSpecTypes(spec *ast.AlterTableSpec) []bf.EventType {
+	switch spec.Tp {
+	case ast.AlterTableAddColumns:
+		return []bf.EventType{addColumnEvent}
+	case ast.AlterTableDropColumn:
+		return []bf.EventType{dropColumnEvent}
+	case ast.AlterTableModifyColumn, ast.AlterTableChangeColumn, ast.AlterTableAlterColumn:
+		return []bf.EventType{modifyColumnEvent}
+	case ast.AlterTableAddConstraint:
+		if spec.Constraint == nil {
+			return nil
+		}
+		switch spec.Constraint.Tp {
+		case ast.ConstraintPrimaryKey:
+			return []bf.EventType{addPrimaryKeyEvent}
+		case ast.ConstraintKey, ast.ConstraintIndex, ast.ConstraintUniq,
+			ast.ConstraintUniqKey, ast.ConstraintUniqIndex, ast.ConstraintFulltext:
+			return []bf.EventType{addIndexEvent}
+		}
+	case ast.AlterTableDropIndex:
+		return []bf.EventType{dropIndexEvent}
+	case ast.AlterTableDropPrimaryKey:
+		return []bf.EventType{dropPrimaryKeyEvent}
+	case ast.AlterTableRenameIndex:
+		return []bf.EventType{renameIndexEvent}
+	case ast.AlterTableOption:
+		var res []bf.EventType
+		for _, option := range spec.Options {
+			switch option.Tp {
+			case ast.TableOptionComment:
+				res = append(res, modifyTableCommentEvent)
+			case ast.TableOptionCharset, ast.TableOptionCollate:
+				res = append(res, modifyTableCharsetEvent)
+			}
+		}
+		return res
+	}
+	return nil
+}
+
+func isAlterTableType(et bf.EventType) bool {
+	for _, alterType := range alterTableTypes {
+		if et == alterType {
+			return true
+		}
+	}
+	return false
 }
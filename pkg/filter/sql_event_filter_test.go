@@ -18,6 +18,7 @@ import (
 
 	"github.com/pingcap/errors"
 	bf "github.com/pingcap/tidb-tools/pkg/binlog-filter"
+	timodel "github.com/pingcap/tidb/parser/model"
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/pkg/config"
 	cerror "github.com/pingcap/tiflow/pkg/errors"
@@ -190,6 +191,67 @@ func TestShouldSkipDDL(t *testing.T) {
 	}
 }
 
+func TestShouldSkipDDLByAlterType(t *testing.T) {
+	t.Parallel()
+
+	f, err := newSQLEventFilter(&config.FilterConfig{
+		EventFilters: []*config.EventFilterRule{
+			{
+				Matcher:     []string{"test.t1"},
+				IgnoreEvent: []bf.EventType{bf.AlterTable, bf.DropTable, bf.TruncateTable},
+				AllowDDL:    []bf.EventType{addColumnEvent},
+			},
+			{
+				Matcher:     []string{"test.t2"},
+				IgnoreEvent: []bf.EventType{dropColumnEvent},
+				IgnoreSQL:   []string{"^alter table .* drop index"},
+			},
+		},
+	})
+	require.Nil(t, err)
+
+	cases := []struct {
+		table   string
+		query   string
+		jobType timodel.ActionType
+		skip    bool
+	}{
+		{"t1", "alter table t1 add column c int", timodel.ActionAddColumn, false},
+		{"t1", "alter table t1 drop column c", timodel.ActionDropColumn, true},
+		{"t1", "drop table t1", timodel.ActionDropTable, true},
+		{"t1", "truncate table t1", timodel.ActionTruncateTable, true},
+		{"t2", "alter table t2 drop column c", timodel.ActionDropColumn, true},
+		{"t2", "alter table t2 drop index i", timodel.ActionDropIndex, true},
+		{"t2", "alter table t2 add column c int", timodel.ActionAddColumn, false},
+		{"t2", "alter table t2 modify column c bigint", timodel.ActionModifyColumn, false},
+		{"t2", "drop table t2", timodel.ActionDropTable, false},
+		// A multi-schema change is ignored if any of its sub-jobs is ignored.
+		{"t1", "alter table t1 add column a int, add column b int", timodel.ActionMultiSchemaChange, false},
+		{"t1", "alter table t1 add column a int, drop column c", timodel.ActionMultiSchemaChange, true},
+		{"t2", "alter table t2 add column a int, drop column c", timodel.ActionMultiSchemaChange, true},
+		{"t2", "alter table t2 add column a int, modify column c bigint", timodel.ActionMultiSchemaChange, false},
+	}
+	for _, c := range cases {
+		ddl := &model.DDLEvent{
+			TableInfo: &model.TableInfo{
+				TableName: model.TableName{Schema: "test", Table: c.table},
+			},
+			Query: c.query,
+			Type:  c.jobType,
+		}
+		skip, err := f.shouldSkipDDL(ddl)
+		require.Nil(t, err)
+		require.Equal(t, c.skip, skip, "case: %+v", c)
+	}
+
+	_, err = newSQLEventFilter(&config.FilterConfig{
+		EventFilters: []*config.EventFilterRule{
+			{Matcher: []string{"test.t1"}, AllowDDL: []bf.EventType{bf.InsertEvent}},
+		},
+	})
+	require.True(t, errors.ErrorEqual(cerror.ErrInvalidAllowDDLType, err))
+}
+
 func TestShouldSkipDML(t *testing.T) {
 	t.Parallel()
 	type innerCase struct {
@@ -0,0 +1,69 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filter
+
+import (
+	"strings"
+
+	timodel "github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tiflow/pkg/config"
+)
+
+// tableAttributeFilter selects the tables by their placement policies and
+// the tags in their comments. All the names are case-insensitive.
+type tableAttributeFilter struct {
+	placementPolicies map[string]struct{}
+	commentTags       map[string]struct{}
+}
+
+// newTableAttributeFilter creates a tableAttributeFilter, it returns nil if
+// the tables are not selected by their attributes.
+func newTableAttributeFilter(cfg *config.FilterConfig) *tableAttributeFilter {
+	if cfg.TableAttributes == nil {
+		return nil
+	}
+	f := &tableAttributeFilter{
+		placementPolicies: make(map[string]struct{}, len(cfg.TableAttributes.PlacementPolicies)),
+		commentTags:       make(map[string]struct{}, len(cfg.TableAttributes.CommentTags)),
+	}
+	for _, policy := range cfg.TableAttributes.PlacementPolicies {
+		f.placementPolicies[strings.ToLower(policy)] = struct{}{}
+	}
+	for _, tag := range cfg.TableAttributes.CommentTags {
+		f.commentTags[strings.ToLower(tag)] = struct{}{}
+	}
+	return f
+}
+
+// match returns whether the table has any of the attributes.
+func (f *tableAttributeFilter) match(tableInfo *timodel.TableInfo) bool {
+	if policy := tableInfo.PlacementPolicyRef; policy != nil {
+		if _, ok := f.placementPolicies[policy.Name.L]; ok {
+			return true
+		}
+	}
+	for _, tag := range commentTags(tableInfo.Comment) {
+		if _, ok := f.commentTags[tag]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// commentTags returns the tags in the comment in lower case.
+func commentTags(comment string) []string {
+	return strings.FieldsFunc(strings.ToLower(comment), func(r rune) bool {
+		return strings.ContainsRune(config.CommentTagSeparators, r)
+	})
+}
@@ -27,7 +27,8 @@ import (
 
 // Client wraps an HTTP client and support TLS requests.
 type Client struct {
-	client http.Client
+	client    http.Client
+	userAgent string
 }
 
 // NewClient creates an HTTP client with the given Credential.
@@ -61,6 +62,20 @@ func (c *Client) SetTimeout(timeout time.Duration) {
 	c.client.Timeout = timeout
 }
 
+// SetProxy specifies the proxy of the requests made by this Client, the http,
+// https and socks5 proxies are supported. See http.Transport.Proxy.
+func (c *Client) SetProxy(proxyURL *url.URL) {
+	transport := c.client.Transport.(*http.Transport).Clone()
+	transport.Proxy = http.ProxyURL(proxyURL)
+	c.client.Transport = transport
+}
+
+// SetUserAgent specifies the User-Agent of the requests made by this Client,
+// the default one of Go is used if it's empty.
+func (c *Client) SetUserAgent(userAgent string) {
+	c.userAgent = userAgent
+}
+
 // Get issues a GET to the specified URL with context.
 // See http.Client.Get.
 func (c *Client) Get(ctx context.Context, url string) (resp *http.Response, err error) {
@@ -68,7 +83,7 @@ func (c *Client) Get(ctx context.Context, url string) (resp *http.Response, err
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	return c.client.Do(req)
+	return c.Do(req)
 }
 
 // PostForm issues a POST to the specified URL,
@@ -83,12 +98,15 @@ func (c *Client) PostForm(
 		return nil, errors.Trace(err)
 	}
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-	return c.client.Do(req)
+	return c.Do(req)
 }
 
 // Do sends an HTTP request and returns an HTTP response.
 // See http.Client.Do.
 func (c *Client) Do(req *http.Request) (*http.Response, error) {
+	if c.userAgent != "" && req.Header.Get("User-Agent") == "" {
+		req.Header.Set("User-Agent", c.userAgent)
+	}
 	return c.client.Do(req)
 }
 
@@ -109,6 +109,53 @@ func TestTimeout(t *testing.T) {
 	require.GreaterOrEqual(t, time.Since(start), timeout)
 }
 
+func TestProxy(t *testing.T) {
+	t.Parallel()
+
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		// the proxy receives the absolute url of the request.
+		fmt.Fprintf(w, "%s %s", req.URL.String(), req.Header.Get("Proxy-Authorization"))
+	}))
+	defer proxy.Close()
+
+	cli, err := NewClient(nil)
+	require.NoError(t, err)
+	proxyURL, err := url.Parse(proxy.URL)
+	require.NoError(t, err)
+	proxyURL.User = url.UserPassword("user", "password")
+	cli.SetProxy(proxyURL)
+	defer cli.CloseIdleConnections()
+
+	respBody, err := cli.DoRequest(context.Background(), "http://downstream.example.com/path",
+		http.MethodGet, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, "http://downstream.example.com/path Basic dXNlcjpwYXNzd29yZA==", string(respBody))
+}
+
+func TestUserAgent(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		fmt.Fprint(w, req.UserAgent())
+	}))
+	defer server.Close()
+
+	cli, err := NewClient(nil)
+	require.NoError(t, err)
+	cli.SetUserAgent("ticdc/default/test")
+	defer cli.CloseIdleConnections()
+
+	respBody, err := cli.DoRequest(context.Background(), server.URL, http.MethodGet, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, "ticdc/default/test", string(respBody))
+
+	// The User-Agent of the request takes precedence.
+	respBody, err = cli.DoRequest(context.Background(), server.URL, http.MethodGet,
+		http.Header{"User-Agent": []string{"custom"}}, nil)
+	require.NoError(t, err)
+	require.Equal(t, "custom", string(respBody))
+}
+
 func handler(w http.ResponseWriter, req *http.Request) {
 	w.Header().Set("Content-Type", "text/plain")
 	//nolint:errcheck
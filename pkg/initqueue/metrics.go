@@ -0,0 +1,50 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package initqueue
+
+import "github.com/prometheus/client_golang/prometheus"
+
+var (
+	pendingGauge = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "ticdc",
+			Subsystem: "capture",
+			Name:      "changefeed_init_pending",
+			Help:      "The number of the changefeed initializations waiting in the queue.",
+		})
+
+	runningGauge = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "ticdc",
+			Subsystem: "capture",
+			Name:      "changefeed_init_running",
+			Help:      "The number of the running changefeed initializations.",
+		})
+
+	waitDurationHistogram = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Namespace: "ticdc",
+			Subsystem: "capture",
+			Name:      "changefeed_init_wait_duration_seconds",
+			Help:      "Bucketed histogram of the duration the changefeed initializations wait in the queue.",
+			Buckets:   prometheus.ExponentialBuckets(0.01, 2, 16), // 10ms~327s
+		})
+)
+
+// InitMetrics registers all metrics in this file.
+func InitMetrics(registry prometheus.Registerer) {
+	registry.MustRegister(pendingGauge)
+	registry.MustRegister(runningGauge)
+	registry.MustRegister(waitDurationHistogram)
+}
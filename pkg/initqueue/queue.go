@@ -0,0 +1,206 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package initqueue
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/util"
+	"go.uber.org/zap"
+)
+
+// Key identifies an initialization in the queue. The owner and the processor
+// of a changefeed are initialized separately on a capture.
+type Key struct {
+	ChangefeedID model.ChangeFeedID
+	Role         util.Role
+}
+
+// priority returns the priority of the initialization, the smaller one is
+// admitted first. The DDL sink of the owner is initialized before the sinks
+// of the processors, because all the changefeeds are blocked until their
+// owners are initialized.
+func (k Key) priority() int {
+	if k.Role == util.RoleOwner {
+		return 0
+	}
+	return 1
+}
+
+// Progress is the progress of the initializations in the queue.
+type Progress struct {
+	// Pending is the number of the initializations waiting in the queue.
+	Pending int
+	// Running is the number of the admitted initializations.
+	Running int
+	// Finished is the number of the initializations released after they
+	// are admitted.
+	Finished uint64
+}
+
+type entry struct {
+	key      Key
+	seq      uint64
+	enqueued time.Time
+	admitted bool
+	// ready is closed when the initialization is admitted.
+	ready chan struct{}
+}
+
+// Queue limits the concurrent initializations of the changefeeds on a capture,
+// such as creating the sinks, which may overwhelm the downstream and PD if
+// hundreds of changefeeds are initialized at the same time after the capture
+// restarts. The initializations are admitted by their priorities, and the
+// ones of the same priority are admitted in the order they are queued.
+type Queue struct {
+	// concurrency is the max number of the running initializations, 0 means
+	// unlimited.
+	concurrency int
+
+	mu       sync.Mutex
+	seq      uint64
+	entries  map[Key]*entry
+	pending  []*entry
+	running  int
+	finished uint64
+}
+
+// New creates a Queue with the max number of the concurrent initializations,
+// 0 means unlimited.
+func New(concurrency int) *Queue {
+	return &Queue{
+		concurrency: concurrency,
+		entries:     make(map[Key]*entry),
+	}
+}
+
+// TryAcquire queues the initialization if it's not queued, and returns
+// whether it's admitted. It never blocks, so it can be called in every tick
+// until the initialization is admitted. Release must be called after the
+// initialization finishes or is abandoned.
+func (q *Queue) TryAcquire(key Key) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.enqueue(key).admitted
+}
+
+// Acquire queues the initialization and waits until it's admitted. Release
+// must be called after the initialization finishes if it returns nil.
+func (q *Queue) Acquire(ctx context.Context, key Key) error {
+	q.mu.Lock()
+	e := q.enqueue(key)
+	q.mu.Unlock()
+
+	select {
+	case <-ctx.Done():
+		q.Release(key)
+		return errors.Trace(ctx.Err())
+	case <-e.ready:
+		return nil
+	}
+}
+
+// Release removes the initialization from the queue, and admits the pending
+// ones if it's running.
+func (q *Queue) Release(key Key) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	e, ok := q.entries[key]
+	if !ok {
+		return
+	}
+	delete(q.entries, key)
+	if !e.admitted {
+		for i, pending := range q.pending {
+			if pending == e {
+				q.pending = append(q.pending[:i], q.pending[i+1:]...)
+				break
+			}
+		}
+		pendingGauge.Set(float64(len(q.pending)))
+		return
+	}
+
+	q.running--
+	q.finished++
+	runningGauge.Set(float64(q.running))
+	q.admit()
+	log.Info("changefeed initialization finished",
+		zap.String("namespace", key.ChangefeedID.Namespace),
+		zap.String("changefeed", key.ChangefeedID.ID),
+		zap.String("role", key.Role.String()),
+		zap.Int("pending", len(q.pending)),
+		zap.Int("running", q.running),
+		zap.Uint64("finished", q.finished))
+}
+
+// Progress returns the progress of the initializations.
+func (q *Queue) Progress() Progress {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return Progress{Pending: len(q.pending), Running: q.running, Finished: q.finished}
+}
+
+// enqueue returns the entry of the initialization, which is queued if it
+// doesn't exist.
+func (q *Queue) enqueue(key Key) *entry {
+	if e, ok := q.entries[key]; ok {
+		return e
+	}
+	q.seq++
+	e := &entry{key: key, seq: q.seq, enqueued: time.Now(), ready: make(chan struct{})}
+	q.entries[key] = e
+	q.pending = append(q.pending, e)
+	q.admit()
+	if !e.admitted {
+		log.Info("changefeed initialization is queued",
+			zap.String("namespace", key.ChangefeedID.Namespace),
+			zap.String("changefeed", key.ChangefeedID.ID),
+			zap.String("role", key.Role.String()),
+			zap.Int("pending", len(q.pending)),
+			zap.Int("running", q.running),
+			zap.Int("concurrency", q.concurrency))
+	}
+	pendingGauge.Set(float64(len(q.pending)))
+	return e
+}
+
+// admit admits the pending initializations with the highest priorities until
+// the concurrency is reached.
+func (q *Queue) admit() {
+	for len(q.pending) > 0 && (q.concurrency <= 0 || q.running < q.concurrency) {
+		next := 0
+		for i, e := range q.pending {
+			best := q.pending[next]
+			if e.key.priority() < best.key.priority() ||
+				(e.key.priority() == best.key.priority() && e.seq < best.seq) {
+				next = i
+			}
+		}
+		e := q.pending[next]
+		q.pending = append(q.pending[:next], q.pending[next+1:]...)
+		e.admitted = true
+		close(e.ready)
+		q.running++
+		waitDurationHistogram.Observe(time.Since(e.enqueued).Seconds())
+	}
+	pendingGauge.Set(float64(len(q.pending)))
+	runningGauge.Set(float64(q.running))
+}
@@ -0,0 +1,109 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package initqueue
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/util"
+	"github.com/stretchr/testify/require"
+)
+
+func processorKey(id string) Key {
+	return Key{ChangefeedID: model.DefaultChangeFeedID(id), Role: util.RoleProcessor}
+}
+
+func ownerKey(id string) Key {
+	return Key{ChangefeedID: model.DefaultChangeFeedID(id), Role: util.RoleOwner}
+}
+
+func TestQueueTryAcquire(t *testing.T) {
+	t.Parallel()
+
+	q := New(2)
+	require.True(t, q.TryAcquire(processorKey("a")))
+	require.True(t, q.TryAcquire(processorKey("b")))
+	require.False(t, q.TryAcquire(processorKey("c")))
+	require.False(t, q.TryAcquire(processorKey("d")))
+	// it's idempotent.
+	require.True(t, q.TryAcquire(processorKey("a")))
+	require.False(t, q.TryAcquire(processorKey("c")))
+	require.Equal(t, Progress{Pending: 2, Running: 2}, q.Progress())
+
+	// the owner is admitted before the processors queued earlier.
+	require.False(t, q.TryAcquire(ownerKey("e")))
+	q.Release(processorKey("a"))
+	require.True(t, q.TryAcquire(ownerKey("e")))
+	require.False(t, q.TryAcquire(processorKey("c")))
+	require.Equal(t, Progress{Pending: 2, Running: 2, Finished: 1}, q.Progress())
+
+	// the processors are admitted in the order they are queued.
+	q.Release(processorKey("b"))
+	require.True(t, q.TryAcquire(processorKey("c")))
+	require.False(t, q.TryAcquire(processorKey("d")))
+
+	// the abandoned pending initialization is removed.
+	q.Release(processorKey("d"))
+	require.Equal(t, Progress{Pending: 0, Running: 2, Finished: 2}, q.Progress())
+	q.Release(ownerKey("e"))
+	q.Release(processorKey("c"))
+	q.Release(processorKey("c"))
+	require.Equal(t, Progress{Pending: 0, Running: 0, Finished: 4}, q.Progress())
+}
+
+func TestQueueUnlimited(t *testing.T) {
+	t.Parallel()
+
+	q := New(0)
+	for _, id := range []string{"a", "b", "c"} {
+		require.True(t, q.TryAcquire(processorKey(id)))
+	}
+	require.Equal(t, Progress{Running: 3}, q.Progress())
+}
+
+func TestQueueAcquire(t *testing.T) {
+	t.Parallel()
+
+	q := New(1)
+	ctx := context.Background()
+	require.Nil(t, q.Acquire(ctx, ownerKey("a")))
+
+	done := make(chan error, 1)
+	go func() {
+		done <- q.Acquire(ctx, ownerKey("b"))
+	}()
+	select {
+	case <-done:
+		t.Fatal("the initialization is admitted beyond the concurrency")
+	case <-time.After(100 * time.Millisecond):
+	}
+	q.Release(ownerKey("a"))
+	require.Nil(t, <-done)
+
+	// the canceled initialization is removed from the queue.
+	cctx, cancel := context.WithCancel(ctx)
+	go func() {
+		done <- q.Acquire(cctx, ownerKey("c"))
+	}()
+	require.Eventually(t, func() bool {
+		return q.Progress().Pending == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	cancel()
+	require.Equal(t, context.Canceled, errors.Cause(<-done))
+	require.Equal(t, Progress{Running: 1, Finished: 1}, q.Progress())
+}
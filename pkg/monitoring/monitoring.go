@@ -0,0 +1,363 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package monitoring
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tiflow/metrics"
+	"github.com/pingcap/tiflow/pkg/version"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	// metricPrefix is the prefix of the metrics of TiCDC, other metrics like
+	// the ones of the Go runtime are never added to the dashboard.
+	metricPrefix = "ticdc_"
+	// metricSelector selects the metrics by the variables of the dashboard.
+	metricSelector = `{k8s_cluster="$k8s_cluster", tidb_cluster="$tidb_cluster", instance=~"$ticdc_instance"}`
+
+	uncoveredRowTitle = "Uncovered Metrics"
+	panelWidth        = 12
+	panelHeight       = 7
+)
+
+var (
+	metricNameRegexp = regexp.MustCompile(`ticdc_[a-zA-Z0-9_:]+`)
+	// descRegexp parses the name and the help of a prometheus.Desc from its
+	// String, which is the only way to access them.
+	descRegexp = regexp.MustCompile(`^Desc\{fqName: ("(?:[^"\\]|\\.)*"), help: ("(?:[^"\\]|\\.)*")`)
+	// seriesSuffixes are the suffixes of the series of the histograms and
+	// the summaries.
+	seriesSuffixes = []string{"", "_bucket", "_sum", "_count"}
+)
+
+// metricInfo describes a registered metric of TiCDC.
+type metricInfo struct {
+	name string
+	help string
+	tp   dto.MetricType
+}
+
+// GrafanaDashboard returns the Grafana dashboard of the running TiCDC. The
+// metrics which are registered by the running TiCDC but not covered by the
+// released dashboard are added to a collapsed row, one panel for each, so
+// that the dashboard always matches the running version. The metrics are
+// described by their collectors, so the ones without any series yet are
+// included too.
+func GrafanaDashboard(registry *Registry) ([]byte, error) {
+	var dashboard map[string]interface{}
+	if err := json.Unmarshal(metrics.TiCDCGrafanaDashboard, &dashboard); err != nil {
+		return nil, errors.Trace(err)
+	}
+	covered := make(map[string]struct{})
+	for _, name := range metricNameRegexp.FindAllString(string(metrics.TiCDCGrafanaDashboard), -1) {
+		covered[name] = struct{}{}
+	}
+
+	panels, _ := dashboard["panels"].([]interface{})
+	nextID, nextY := nextPanelPosition(panels)
+	uncovered := make([]interface{}, 0)
+	for _, metric := range uncoveredMetrics(describeMetrics(registry.Collectors()), covered) {
+		nextID++
+		uncovered = append(uncovered, map[string]interface{}{
+			"type":        "graph",
+			"id":          nextID,
+			"title":       metric.name,
+			"description": metric.help,
+			"datasource":  "${DS_TEST-CLUSTER}",
+			"gridPos": map[string]interface{}{
+				"h": panelHeight,
+				"w": panelWidth,
+				"x": (len(uncovered) % 2) * panelWidth,
+				"y": nextY + 1 + (len(uncovered)/2)*panelHeight,
+			},
+			"lines":     true,
+			"linewidth": 1,
+			"legend":    map[string]interface{}{"show": true},
+			"targets": []interface{}{
+				map[string]interface{}{
+					"expr":         panelExpr(metric),
+					"format":       "time_series",
+					"legendFormat": "{{instance}}",
+					"refId":        "A",
+				},
+			},
+		})
+	}
+	if len(uncovered) > 0 {
+		nextID++
+		panels = append(panels, map[string]interface{}{
+			"type":      "row",
+			"id":        nextID,
+			"title":     uncoveredRowTitle,
+			"collapsed": true,
+			"gridPos":   map[string]interface{}{"h": 1, "w": 24, "x": 0, "y": nextY},
+			"panels":    uncovered,
+		})
+		dashboard["panels"] = panels
+	}
+
+	dashboard["description"] = fmt.Sprintf("TiCDC %s", version.ReleaseVersion)
+	tags, _ := dashboard["tags"].([]interface{})
+	dashboard["tags"] = append(tags, "ticdc-"+version.ReleaseVersion)
+	data, err := json.MarshalIndent(dashboard, "", "  ")
+	return data, errors.Trace(err)
+}
+
+// alertRules is the Prometheus alert rules file, the rules are kept as they
+// are.
+type alertRules struct {
+	Groups []struct {
+		Name  string          `yaml:"name"`
+		Rules []yaml.MapSlice `yaml:"rules"`
+	} `yaml:"groups"`
+}
+
+// AlertRules returns the Prometheus alert rules of the running TiCDC. The
+// released rules which query the metrics not registered by the running TiCDC
+// are dropped, and listed in the comments of the header.
+func AlertRules(registry *Registry) ([]byte, error) {
+	var rules alertRules
+	if err := yaml.Unmarshal(metrics.TiCDCAlertRules, &rules); err != nil {
+		return nil, errors.Trace(err)
+	}
+	registered := make(map[string]struct{})
+	for _, metric := range describeMetrics(registry.Collectors()) {
+		for _, suffix := range seriesSuffixes {
+			registered[metric.name+suffix] = struct{}{}
+		}
+	}
+
+	var header strings.Builder
+	fmt.Fprintf(&header, "# Prometheus alert rules of TiCDC %s\n", version.ReleaseVersion)
+	for i := range rules.Groups {
+		kept := make([]yaml.MapSlice, 0, len(rules.Groups[i].Rules))
+		for _, rule := range rules.Groups[i].Rules {
+			var alert, expr string
+			for _, item := range rule {
+				switch item.Key {
+				case "alert":
+					alert, _ = item.Value.(string)
+				case "expr":
+					expr, _ = item.Value.(string)
+				}
+			}
+			var missing []string
+			for _, name := range metricNameRegexp.FindAllString(expr, -1) {
+				if _, ok := registered[name]; !ok {
+					missing = append(missing, name)
+				}
+			}
+			if len(missing) > 0 {
+				fmt.Fprintf(&header, "# %s is dropped, the metrics are not registered: %s\n",
+					alert, strings.Join(missing, ", "))
+				continue
+			}
+			kept = append(kept, rule)
+		}
+		rules.Groups[i].Rules = kept
+	}
+	data, err := yaml.Marshal(&rules)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return append([]byte(header.String()), data...), nil
+}
+
+// describeMetrics returns the metrics of TiCDC described by the collectors.
+func describeMetrics(collectors []prometheus.Collector) []metricInfo {
+	res := make([]metricInfo, 0)
+	seen := make(map[string]struct{})
+	for _, c := range collectors {
+		descs := describe(c)
+		ticdc := false
+		for _, desc := range descs {
+			if name, _ := parseDesc(desc); strings.HasPrefix(name, metricPrefix) {
+				ticdc = true
+			}
+		}
+		if !ticdc {
+			continue
+		}
+		types := metricTypes(c, descs)
+		for _, desc := range descs {
+			name, help := parseDesc(desc)
+			if !strings.HasPrefix(name, metricPrefix) {
+				continue
+			}
+			if _, ok := seen[name]; ok {
+				continue
+			}
+			seen[name] = struct{}{}
+			res = append(res, metricInfo{name: name, help: help, tp: types[desc.String()]})
+		}
+	}
+	return res
+}
+
+func describe(c prometheus.Collector) []*prometheus.Desc {
+	ch := make(chan *prometheus.Desc)
+	go func() {
+		c.Describe(ch)
+		close(ch)
+	}()
+	var descs []*prometheus.Desc
+	for desc := range ch {
+		descs = append(descs, desc)
+	}
+	return descs
+}
+
+// parseDesc returns the name and the help of a metric.
+func parseDesc(desc *prometheus.Desc) (name, help string) {
+	matches := descRegexp.FindStringSubmatch(desc.String())
+	if matches == nil {
+		return "", ""
+	}
+	name, _ = strconv.Unquote(matches[1])
+	help, _ = strconv.Unquote(matches[2])
+	return name, help
+}
+
+// metricTypes returns the types of the metrics of a collector keyed by the
+// strings of their descriptions. The vectors may have no series, so their
+// types are told by their Go types, and the other collectors always have
+// their series once registered.
+func metricTypes(c prometheus.Collector, descs []*prometheus.Desc) map[string]dto.MetricType {
+	types := make(map[string]dto.MetricType, len(descs))
+	var vecType *dto.MetricType
+	switch c.(type) {
+	case *prometheus.CounterVec:
+		vecType = dto.MetricType_COUNTER.Enum()
+	case *prometheus.GaugeVec:
+		vecType = dto.MetricType_GAUGE.Enum()
+	case *prometheus.HistogramVec:
+		vecType = dto.MetricType_HISTOGRAM.Enum()
+	case *prometheus.SummaryVec:
+		vecType = dto.MetricType_SUMMARY.Enum()
+	}
+	if vecType != nil {
+		for _, desc := range descs {
+			types[desc.String()] = *vecType
+		}
+		return types
+	}
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		c.Collect(ch)
+		close(ch)
+	}()
+	for m := range ch {
+		var metric dto.Metric
+		if err := m.Write(&metric); err != nil {
+			continue
+		}
+		switch {
+		case metric.Counter != nil:
+			types[m.Desc().String()] = dto.MetricType_COUNTER
+		case metric.Gauge != nil:
+			types[m.Desc().String()] = dto.MetricType_GAUGE
+		case metric.Histogram != nil:
+			types[m.Desc().String()] = dto.MetricType_HISTOGRAM
+		case metric.Summary != nil:
+			types[m.Desc().String()] = dto.MetricType_SUMMARY
+		default:
+			types[m.Desc().String()] = dto.MetricType_UNTYPED
+		}
+	}
+	return types
+}
+
+// uncoveredMetrics returns the metrics which are not used by the dashboard,
+// sorted by their names.
+func uncoveredMetrics(infos []metricInfo, covered map[string]struct{}) []metricInfo {
+	res := make([]metricInfo, 0)
+	for _, metric := range infos {
+		isCovered := false
+		for _, suffix := range seriesSuffixes {
+			if _, ok := covered[metric.name+suffix]; ok {
+				isCovered = true
+				break
+			}
+		}
+		if !isCovered {
+			res = append(res, metric)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].name < res[j].name })
+	return res
+}
+
+// panelExpr returns the query of a metric by its type.
+func panelExpr(metric metricInfo) string {
+	name := metric.name
+	switch metric.tp {
+	case dto.MetricType_COUNTER:
+		return fmt.Sprintf("sum(rate(%s%s[1m])) by (instance)", name, metricSelector)
+	case dto.MetricType_HISTOGRAM:
+		return fmt.Sprintf("histogram_quantile(0.99, sum(rate(%s_bucket%s[1m])) by (le, instance))",
+			name, metricSelector)
+	case dto.MetricType_SUMMARY:
+		return fmt.Sprintf("sum(rate(%s_sum%s[1m])) by (instance) / sum(rate(%s_count%s[1m])) by (instance)",
+			name, metricSelector, name, metricSelector)
+	default:
+		return fmt.Sprintf("sum(%s%s) by (instance)", name, metricSelector)
+	}
+}
+
+// nextPanelPosition returns the max id of the panels and the y below them.
+func nextPanelPosition(panels []interface{}) (maxID int, nextY int) {
+	var walk func(panels []interface{})
+	walk = func(panels []interface{}) {
+		for _, p := range panels {
+			panel, ok := p.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if id, ok := panel["id"].(float64); ok && int(id) > maxID {
+				maxID = int(id)
+			}
+			if children, ok := panel["panels"].([]interface{}); ok {
+				walk(children)
+			}
+		}
+	}
+	walk(panels)
+
+	// Only the top level panels are counted, the panels in a collapsed row
+	// don't take any space.
+	for _, p := range panels {
+		panel, ok := p.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		pos, _ := panel["gridPos"].(map[string]interface{})
+		y, _ := pos["y"].(float64)
+		h, _ := pos["h"].(float64)
+		if int(y+h) > nextY {
+			nextY = int(y + h)
+		}
+	}
+	return
+}
@@ -0,0 +1,102 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package monitoring
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGrafanaDashboard(t *testing.T) {
+	t.Parallel()
+
+	registry := NewRegistry()
+	covered := prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "ticdc", Subsystem: "server", Name: "go_gc",
+	})
+	uncovered := prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: "ticdc", Subsystem: "test", Name: "uncovered_duration",
+		Help: "uncovered histogram",
+	})
+	// A vector without any series is not gathered, but it is still described.
+	noSeries := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "ticdc", Subsystem: "test", Name: "no_series_total",
+		Help: "counter without series",
+	}, []string{"changefeed"})
+	other := prometheus.NewCounter(prometheus.CounterOpts{Name: "other_total"})
+	registry.MustRegister(covered, uncovered, noSeries, other)
+	uncovered.Observe(1)
+
+	data, err := GrafanaDashboard(registry)
+	require.Nil(t, err)
+	var dashboard struct {
+		Panels []struct {
+			Type   string `json:"type"`
+			Title  string `json:"title"`
+			Panels []struct {
+				Title       string `json:"title"`
+				Description string `json:"description"`
+				Targets     []struct {
+					Expr string `json:"expr"`
+				} `json:"targets"`
+			} `json:"panels"`
+		} `json:"panels"`
+	}
+	require.Nil(t, json.Unmarshal(data, &dashboard))
+
+	row := dashboard.Panels[len(dashboard.Panels)-1]
+	require.Equal(t, "row", row.Type)
+	require.Equal(t, uncoveredRowTitle, row.Title)
+	require.Len(t, row.Panels, 2)
+	require.Equal(t, "ticdc_test_no_series_total", row.Panels[0].Title)
+	require.Equal(t, "counter without series", row.Panels[0].Description)
+	require.True(t, strings.HasPrefix(row.Panels[0].Targets[0].Expr,
+		"sum(rate(ticdc_test_no_series_total{"))
+	require.Equal(t, "ticdc_test_uncovered_duration", row.Panels[1].Title)
+	require.Equal(t, "uncovered histogram", row.Panels[1].Description)
+	require.True(t, strings.HasPrefix(row.Panels[1].Targets[0].Expr,
+		"histogram_quantile(0.99, sum(rate(ticdc_test_uncovered_duration_bucket{"))
+
+	// No row is added if all the metrics are covered.
+	registry = NewRegistry()
+	registry.MustRegister(covered)
+	data, err = GrafanaDashboard(registry)
+	require.Nil(t, err)
+	require.NotContains(t, string(data), uncoveredRowTitle)
+}
+
+func TestAlertRules(t *testing.T) {
+	t.Parallel()
+
+	registry := NewRegistry()
+	registry.MustRegister(prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "ticdc", Subsystem: "owner", Name: "ownership_counter",
+	}))
+	data, err := AlertRules(registry)
+	require.Nil(t, err)
+	rules := string(data)
+	require.True(t, strings.HasPrefix(rules, "# Prometheus alert rules of TiCDC"))
+	require.Contains(t, rules, "alert: cdc_multiple_owners")
+	require.Regexp(t, "# .* is dropped, the metrics are not registered: ticdc_", rules)
+
+	// The rules of the unregistered metrics are dropped.
+	data, err = AlertRules(NewRegistry())
+	require.Nil(t, err)
+	require.NotContains(t, string(data), "alert: cdc_multiple_owners")
+	require.Contains(t, string(data), "cdc_multiple_owners is dropped")
+}
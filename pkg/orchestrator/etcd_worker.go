@@ -83,6 +83,7 @@ type etcdWorkerMetrics struct {
 	// kv events related metrics
 	metricEtcdTxnSize            prometheus.Observer
 	metricEtcdTxnDuration        prometheus.Observer
+	metricEtcdTxnPatchGroups     prometheus.Observer
 	metricEtcdWorkerTickDuration prometheus.Observer
 }
 
@@ -123,6 +124,7 @@ func (worker *EtcdWorker) initMetrics() {
 	metrics := &etcdWorkerMetrics{}
 	metrics.metricEtcdTxnSize = etcdTxnSize
 	metrics.metricEtcdTxnDuration = etcdTxnExecDuration
+	metrics.metricEtcdTxnPatchGroups = etcdTxnPatchGroups
 	metrics.metricEtcdWorkerTickDuration = etcdWorkerTickDuration
 	worker.metrics = metrics
 }
@@ -421,6 +423,9 @@ func (worker *EtcdWorker) applyPatchGroups(ctx context.Context, patchGroups [][]
 		if err != nil {
 			return patchGroups, committedChanges, err
 		}
+		if len(changeSate) > 0 {
+			worker.metrics.metricEtcdTxnPatchGroups.Observe(float64(n))
+		}
 		patchGroups = patchGroups[n:]
 	}
 	return patchGroups, committedChanges, nil
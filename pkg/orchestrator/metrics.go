@@ -34,6 +34,15 @@ var (
 			Buckets:   prometheus.ExponentialBuckets(0.002 /* 2 ms */, 2, 18),
 		})
 
+	etcdTxnPatchGroups = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Namespace: "ticdc",
+			Subsystem: "etcd_worker",
+			Name:      "etcd_txn_patch_groups",
+			Help:      "Bucketed histogram of the number of patch groups batched in a etcd txn.",
+			Buckets:   prometheus.ExponentialBuckets(1, 2, 12),
+		})
+
 	etcdWorkerTickDuration = prometheus.NewHistogram(
 		prometheus.HistogramOpts{
 			Namespace: "ticdc",
@@ -45,8 +54,9 @@ var (
 )
 
 // InitMetrics registers all metrics in this file
-func InitMetrics(registry *prometheus.Registry) {
+func InitMetrics(registry prometheus.Registerer) {
 	registry.MustRegister(etcdTxnSize)
 	registry.MustRegister(etcdTxnExecDuration)
+	registry.MustRegister(etcdTxnPatchGroups)
 	registry.MustRegister(etcdWorkerTickDuration)
 }
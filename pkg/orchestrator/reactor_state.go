@@ -123,7 +123,7 @@ func (s *GlobalReactorState) Update(key util.EtcdKey, value []byte, _ bool) erro
 			zap.Uint64("upstream", k.UpstreamID),
 			zap.Any("info", newUpstreamInfo))
 		s.Upstreams[k.UpstreamID] = &newUpstreamInfo
-	case etcd.CDCKeyTypeMetaVersion:
+	case etcd.CDCKeyTypeMetaVersion, etcd.CDCKeyTypeDynamicConfig:
 	default:
 		log.Warn("receive an unexpected etcd event", zap.String("key", key.String()), zap.ByteString("value", value))
 	}
@@ -103,7 +103,7 @@ var (
 )
 
 // InitMetrics initializes metrics used by pkg/p2p
-func InitMetrics(registry *prometheus.Registry) {
+func InitMetrics(registry prometheus.Registerer) {
 	registry.MustRegister(serverStreamCount)
 	registry.MustRegister(serverMessageCount)
 	registry.MustRegister(serverMessageBatchHistogram)
@@ -0,0 +1,143 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pdutil
+
+import (
+	"context"
+	"time"
+
+	"github.com/pingcap/errors"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/util"
+	"github.com/tikv/client-go/v2/oracle"
+	pd "github.com/tikv/pd/client"
+)
+
+const (
+	// StartTimeLayout is the layout of the wall-clock start time of a
+	// changefeed, which is interpreted in the given timezone.
+	StartTimeLayout = "2006-01-02 15:04:05"
+	// DefaultMaxClockSkew is the max skew between the local clock and the
+	// cluster clock, which is tolerated when a wall-clock time is resolved.
+	DefaultMaxClockSkew = 5 * time.Second
+)
+
+// TimeService is the source of the timestamps of a cluster. It is backed by
+// PD TSO or the local clock, so that the timestamps are resolved in the same
+// way by the CLI, the open API and the owner.
+type TimeService interface {
+	// CurrentTs returns the current timestamp of the cluster.
+	CurrentTs(ctx context.Context) (uint64, error)
+}
+
+// TimeServiceFunc is an adapter to use a function as a TimeService.
+type TimeServiceFunc func(ctx context.Context) (uint64, error)
+
+// CurrentTs implements the TimeService interface.
+func (f TimeServiceFunc) CurrentTs(ctx context.Context) (uint64, error) {
+	return f(ctx)
+}
+
+// NewPDTimeService returns a TimeService backed by PD TSO.
+func NewPDTimeService(pdClient pd.Client) TimeService {
+	return TimeServiceFunc(func(ctx context.Context) (uint64, error) {
+		physical, logical, err := pdClient.GetTS(ctx)
+		if err != nil {
+			return 0, cerror.ErrPDEtcdAPIError.GenWithStackByArgs(
+				"fail to get ts from pd client")
+		}
+		return oracle.ComposeTS(physical, logical), nil
+	})
+}
+
+// NewLocalTimeService returns a TimeService backed by the local clock.
+func NewLocalTimeService() TimeService {
+	return TimeServiceFunc(func(ctx context.Context) (uint64, error) {
+		return oracle.GoTimeToTS(time.Now()), nil
+	})
+}
+
+// CheckClockSkew returns the skew between the local clock and the clock of
+// the time service, ErrClockSkewTooLarge is returned if it exceeds maxSkew.
+func CheckClockSkew(
+	ctx context.Context, service TimeService, maxSkew time.Duration,
+) (time.Duration, error) {
+	before := time.Now()
+	ts, err := service.CurrentTs(ctx)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	// The time service is called between before and after, so the middle of
+	// them is the best estimation of the local time when the ts is allocated.
+	local := before.Add(time.Since(before) / 2)
+	skew := oracle.GetTimeFromTS(ts).Sub(local)
+	if skew > maxSkew || skew < -maxSkew {
+		return skew, cerror.ErrClockSkewTooLarge.GenWithStackByArgs(skew, maxSkew)
+	}
+	return skew, nil
+}
+
+// ResolveStartTs resolves the start ts of a changefeed. The startTs is used
+// if it's specified, otherwise the startTime, which is a wall-clock time in
+// StartTimeLayout, is interpreted in the timezone. The current ts of the
+// time service is used if neither of them is specified.
+//
+// A start time is validated against the clock skew between the local clock
+// and the time service, since it is specified by the local wall clock, and
+// it must not be in the future of the cluster.
+func ResolveStartTs(
+	ctx context.Context, service TimeService,
+	startTs uint64, startTime string, timezone string,
+) (uint64, error) {
+	if startTs != 0 && startTime != "" {
+		return 0, cerror.ErrInvalidStartTime.GenWithStackByArgs(
+			startTime, "start-ts and start-time can not be specified together")
+	}
+	if startTs != 0 {
+		return startTs, nil
+	}
+	if startTime == "" {
+		ts, err := service.CurrentTs(ctx)
+		return ts, errors.Trace(err)
+	}
+
+	tz, err := util.GetTimezone(timezone)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	t, err := time.ParseInLocation(StartTimeLayout, startTime, tz)
+	if err != nil {
+		return 0, cerror.ErrInvalidStartTime.GenWithStackByArgs(startTime,
+			"it must be in the layout of "+StartTimeLayout)
+	}
+	if _, err := CheckClockSkew(ctx, service, DefaultMaxClockSkew); err != nil {
+		return 0, errors.Trace(err)
+	}
+	currentTs, err := service.CurrentTs(ctx)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	ts := oracle.GoTimeToTS(t)
+	if ts > currentTs {
+		return 0, cerror.ErrInvalidStartTime.GenWithStackByArgs(startTime,
+			"it is in the future of the cluster")
+	}
+	return ts, nil
+}
+
+// AddDurationToTs returns the ts which is the duration after the given ts,
+// the logical part of the ts is dropped.
+func AddDurationToTs(ts uint64, d time.Duration) uint64 {
+	return oracle.GoTimeToTS(oracle.GetTimeFromTS(ts).Add(d))
+}
@@ -0,0 +1,81 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pdutil
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"github.com/tikv/client-go/v2/oracle"
+)
+
+func TestCheckClockSkew(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	_, err := CheckClockSkew(ctx, NewPDTimeService(&MockPDClient{}), time.Second)
+	require.NoError(t, err)
+
+	skewed := TimeServiceFunc(func(ctx context.Context) (uint64, error) {
+		return oracle.GoTimeToTS(time.Now().Add(time.Minute)), nil
+	})
+	skew, err := CheckClockSkew(ctx, skewed, time.Second)
+	require.True(t, cerror.ErrClockSkewTooLarge.Equal(err))
+	require.Greater(t, skew, 59*time.Second)
+}
+
+func TestResolveStartTs(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	service := NewLocalTimeService()
+
+	ts, err := ResolveStartTs(ctx, service, 100, "", "")
+	require.NoError(t, err)
+	require.Equal(t, uint64(100), ts)
+
+	_, err = ResolveStartTs(ctx, service, 100, "2023-01-01 00:00:00", "UTC")
+	require.True(t, cerror.ErrInvalidStartTime.Equal(err))
+
+	before := oracle.GoTimeToTS(time.Now())
+	ts, err = ResolveStartTs(ctx, service, 0, "", "")
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, ts, before)
+
+	ts, err = ResolveStartTs(ctx, service, 0, "2023-01-01 08:00:00", "Asia/Shanghai")
+	require.NoError(t, err)
+	require.Equal(t,
+		time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC).UnixMilli(),
+		oracle.ExtractPhysical(ts))
+
+	_, err = ResolveStartTs(ctx, service, 0, "2023/01/01", "UTC")
+	require.True(t, cerror.ErrInvalidStartTime.Equal(err))
+
+	future := time.Now().Add(time.Hour).UTC().Format(StartTimeLayout)
+	_, err = ResolveStartTs(ctx, service, 0, future, "UTC")
+	require.True(t, cerror.ErrInvalidStartTime.Equal(err))
+
+	_, err = ResolveStartTs(ctx, service, 0, "2023-01-01 00:00:00", "Invalid/Zone")
+	require.True(t, cerror.ErrLoadTimezone.Equal(err))
+}
+
+func TestAddDurationToTs(t *testing.T) {
+	t.Parallel()
+
+	ts := oracle.ComposeTS(1000, 10)
+	require.Equal(t, oracle.ComposeTS(1000+60*1000, 0), AddDurationToTs(ts, time.Minute))
+}
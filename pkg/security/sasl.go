@@ -14,6 +14,7 @@
 package security
 
 import (
+	"net/url"
 	"strings"
 
 	"github.com/Shopify/sarama"
@@ -35,6 +36,11 @@ const (
 	SCRAM512Mechanism SASLMechanism = sarama.SASLTypeSCRAMSHA512
 	// GSSAPIMechanism means the SASL mechanism is GSSAPI.
 	GSSAPIMechanism SASLMechanism = sarama.SASLTypeGSSAPI
+	// OAuthMechanism means the SASL mechanism is OAUTHBEARER.
+	OAuthMechanism SASLMechanism = sarama.SASLTypeOAuth
+	// AWSMSKIAMMechanism means the IAM access control of AWS MSK, which is
+	// authenticated by OAUTHBEARER with the tokens signed by AWS credentials.
+	AWSMSKIAMMechanism SASLMechanism = "AWS_MSK_IAM"
 )
 
 // SASLMechanismFromString converts the string to SASL mechanism.
@@ -48,6 +54,10 @@ func SASLMechanismFromString(s string) (SASLMechanism, error) {
 		return SCRAM512Mechanism, nil
 	case "gssapi":
 		return GSSAPIMechanism, nil
+	case "oauthbearer":
+		return OAuthMechanism, nil
+	case "aws_msk_iam":
+		return AWSMSKIAMMechanism, nil
 	default:
 		return UnknownMechanism, errors.Errorf("unknown %s SASL mechanism", s)
 	}
@@ -59,6 +69,8 @@ type SASL struct {
 	SASLPassword  string        `toml:"sasl-password" json:"sasl-password"`
 	SASLMechanism SASLMechanism `toml:"sasl-mechanism" json:"sasl-mechanism"`
 	GSSAPI        GSSAPI        `toml:"sasl-gssapi" json:"sasl-gssapi"`
+	OAuth2        OAuth2        `toml:"sasl-oauth" json:"sasl-oauth"`
+	AWSMSKIAM     AWSMSKIAM     `toml:"sasl-aws-msk-iam" json:"sasl-aws-msk-iam"`
 }
 
 // GSSAPIAuthType defines the type of GSSAPI authentication.
@@ -96,3 +108,42 @@ type GSSAPI struct {
 	Realm              string         `toml:"sasl-gssapi-realm" json:"sasl-gssapi-realm"`
 	DisablePAFXFAST    bool           `toml:"sasl-gssapi-disable-pafxfast" json:"sasl-gssapi-disable-pafxfast"`
 }
+
+// OAuth2 holds necessary parameters to support sasl-oauthbearer, the tokens
+// are fetched from the token endpoint by the client credentials flow.
+type OAuth2 struct {
+	ClientID     string   `toml:"sasl-oauth-client-id" json:"sasl-oauth-client-id"`
+	ClientSecret string   `toml:"sasl-oauth-client-secret" json:"sasl-oauth-client-secret"`
+	TokenURL     string   `toml:"sasl-oauth-token-url" json:"sasl-oauth-token-url"`
+	Scopes       []string `toml:"sasl-oauth-scopes" json:"sasl-oauth-scopes"`
+	// Extensions are sent to the brokers along with the tokens, e.g. the
+	// logicalCluster and identityPoolId of Confluent Cloud.
+	Extensions map[string]string `toml:"sasl-oauth-extensions" json:"sasl-oauth-extensions"`
+}
+
+// Validate checks whether the OAuth2 config is complete.
+func (o *OAuth2) Validate() error {
+	if o.ClientID == "" || o.ClientSecret == "" {
+		return errors.New("sasl-oauth-client-id and sasl-oauth-client-secret should be supplied")
+	}
+	if o.TokenURL == "" {
+		return errors.New("sasl-oauth-token-url should be supplied")
+	}
+	u, err := url.Parse(o.TokenURL)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return errors.Errorf("invalid sasl-oauth-token-url %s", o.TokenURL)
+	}
+	return nil
+}
+
+// AWSMSKIAM holds necessary parameters to support the IAM access control of
+// AWS MSK, the credentials are got from the default credential chain of the
+// AWS SDK.
+type AWSMSKIAM struct {
+	// Region is the region of the MSK cluster, the region of the AWS SDK
+	// config like AWS_REGION is used if it's empty.
+	Region string `toml:"sasl-aws-region" json:"sasl-aws-region"`
+}
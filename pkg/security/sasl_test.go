@@ -74,6 +74,21 @@ func TestSASLMechanismFromString(t *testing.T) {
 			s:                 "GSSAPI",
 			expectedMechanism: "GSSAPI",
 		},
+		{
+			name:              "lower case oauthbearer mechanism",
+			s:                 "oauthbearer",
+			expectedMechanism: "OAUTHBEARER",
+		},
+		{
+			name:              "lower case aws_msk_iam mechanism",
+			s:                 "aws_msk_iam",
+			expectedMechanism: "AWS_MSK_IAM",
+		},
+		{
+			name:              "upper case AWS_MSK_IAM mechanism",
+			s:                 "AWS_MSK_IAM",
+			expectedMechanism: "AWS_MSK_IAM",
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
@@ -142,3 +157,16 @@ func TestAuthTypeFromString(t *testing.T) {
 		})
 	}
 }
+
+func TestOAuth2Validate(t *testing.T) {
+	t.Parallel()
+
+	o := &OAuth2{}
+	require.Regexp(t, "sasl-oauth-client-id and sasl-oauth-client-secret should be supplied", o.Validate())
+	o.ClientID, o.ClientSecret = "client", "secret"
+	require.Regexp(t, "sasl-oauth-token-url should be supplied", o.Validate())
+	o.TokenURL = "ftp://127.0.0.1/token"
+	require.Regexp(t, "invalid sasl-oauth-token-url", o.Validate())
+	o.TokenURL = "https://127.0.0.1/oauth2/token"
+	require.Nil(t, o.Validate())
+}
@@ -0,0 +1,75 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clickhouse
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/httputil"
+	"github.com/pingcap/tiflow/pkg/quotes"
+)
+
+// Client executes the statements through the HTTP interface of ClickHouse.
+type Client struct {
+	cfg  *Config
+	http *httputil.Client
+}
+
+// NewClient creates a new Client.
+func NewClient(cfg *Config) (*Client, error) {
+	cli, err := cfg.NewClient()
+	if err != nil {
+		return nil, errors.WrapError(errors.ErrClickHouseInvalidConfig, err)
+	}
+	return &Client{cfg: cfg, http: cli}, nil
+}
+
+// Exec executes a statement.
+func (c *Client) Exec(ctx context.Context, query string) error {
+	return c.do(ctx, nil, []byte(query))
+}
+
+// Insert inserts the rows encoded in the JSONEachRow format into the table.
+func (c *Client) Insert(ctx context.Context, schema, table string, rows []byte) error {
+	query := "INSERT INTO " + quotes.QuoteSchema(schema, table) + " FORMAT JSONEachRow"
+	return c.do(ctx, url.Values{"query": []string{query}}, rows)
+}
+
+func (c *Client) do(ctx context.Context, params url.Values, body []byte) error {
+	if params == nil {
+		params = url.Values{}
+	}
+	// accept the date and time values in the formats of TiDB.
+	params.Set("date_time_input_format", "best_effort")
+	headers := http.Header{}
+	if c.cfg.User != "" {
+		headers.Set("X-ClickHouse-User", c.cfg.User)
+		headers.Set("X-ClickHouse-Key", c.cfg.Password)
+	}
+	_, err := c.http.DoRequest(ctx, c.cfg.Endpoint+"/?"+params.Encode(),
+		http.MethodPost, headers, bytes.NewReader(body))
+	if err != nil {
+		return errors.WrapError(errors.ErrClickHouseExecFailed, err)
+	}
+	return nil
+}
+
+// Close closes the idle connections of the client.
+func (c *Client) Close() {
+	c.http.CloseIdleConnections()
+}
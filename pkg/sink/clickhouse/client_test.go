@@ -0,0 +1,66 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clickhouse
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu              sync.Mutex
+		queries, bodies []string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, "root", r.Header.Get("X-ClickHouse-User"))
+		require.Equal(t, "pass", r.Header.Get("X-ClickHouse-Key"))
+		body, err := io.ReadAll(r.Body)
+		require.Nil(t, err)
+		mu.Lock()
+		defer mu.Unlock()
+		queries = append(queries, r.URL.Query().Get("query"))
+		bodies = append(bodies, string(body))
+		if string(body) == "bad" {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	cfg := NewConfig()
+	cfg.Endpoint = server.URL
+	cfg.User = "root"
+	cfg.Password = "pass"
+	client, err := NewClient(cfg)
+	require.Nil(t, err)
+	defer client.Close()
+
+	ctx := context.Background()
+	require.Nil(t, client.Exec(ctx, "DROP TABLE `test`.`t`"))
+	require.Nil(t, client.Insert(ctx, "test", "t", []byte(`{"id":1}`)))
+	require.Regexp(t, "clickhouse execution failed", client.Exec(ctx, "bad"))
+	mu.Lock()
+	defer mu.Unlock()
+	require.Equal(t, []string{"", "INSERT INTO `test`.`t` FORMAT JSONEachRow", ""}, queries)
+	require.Equal(t, []string{"DROP TABLE `test`.`t`", `{"id":1}`, "bad"}, bodies)
+}
@@ -0,0 +1,58 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clickhouse
+
+import (
+	"net/url"
+
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	psink "github.com/pingcap/tiflow/pkg/sink"
+	"github.com/pingcap/tiflow/pkg/sink/httpsink"
+)
+
+const (
+	// defaultBatchSize is the default value of batch-size.
+	defaultBatchSize = 10000
+	// the upper limit of batch-size.
+	maxBatchSize = 1000000
+)
+
+// Config is the configuration for ClickHouse sink, the Endpoint is the URL of
+// the HTTP interface of ClickHouse and BatchSize is the max number of the rows
+// inserted into a table at once.
+type Config struct {
+	httpsink.Config
+}
+
+// NewConfig returns the default ClickHouse sink config.
+func NewConfig() *Config {
+	return &Config{Config: httpsink.Config{
+		BatchSize:     defaultBatchSize,
+		FlushInterval: httpsink.DefaultFlushInterval,
+	}}
+}
+
+// Apply applies the sink URI parameters to the config. The clickhouse scheme
+// connects to the HTTP interface of ClickHouse, and the clickhouse+ssl scheme
+// connects to the HTTPS interface.
+func (c *Config) Apply(sinkURI *url.URL) error {
+	return c.Config.Apply(sinkURI, httpsink.Options{
+		Name:             "clickhouse",
+		Scheme:           psink.ClickHouseScheme,
+		SSLScheme:        psink.ClickHouseSSLScheme,
+		BatchSizeParam:   "batch-size",
+		MaxBatchSize:     maxBatchSize,
+		ErrInvalidConfig: cerror.ErrClickHouseInvalidConfig,
+	})
+}
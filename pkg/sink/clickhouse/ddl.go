@@ -0,0 +1,199 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clickhouse
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pingcap/log"
+	timodel "github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tiflow/cdc/model"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/quotes"
+	"go.uber.org/zap"
+)
+
+const (
+	// CommitTsColumn is the version column of the ReplacingMergeTree tables,
+	// the row with the max commit ts of a key is kept by the merges.
+	CommitTsColumn = "_tidb_commit_ts"
+	// IsDeletedColumn marks the deleted rows, which are removed by the merges
+	// once they are the latest version of the keys.
+	IsDeletedColumn = "_tidb_is_deleted"
+)
+
+// BuildDDLs translates a TiDB DDL event to the ClickHouse statements. Nil is
+// returned if the DDL has nothing to do with ClickHouse or can't be
+// translated, such as the DDLs of the indexes and the partitions.
+func BuildDDLs(ddl *model.DDLEvent) ([]string, error) {
+	tableName := ddl.TableInfo.TableName
+	switch ddl.Type {
+	case timodel.ActionCreateSchema:
+		return []string{"CREATE DATABASE IF NOT EXISTS " + quotes.QuoteName(tableName.Schema)}, nil
+	case timodel.ActionDropSchema:
+		return []string{"DROP DATABASE IF EXISTS " + quotes.QuoteName(tableName.Schema)}, nil
+	case timodel.ActionCreateTable, timodel.ActionRecoverTable:
+		query, err := buildCreateTable(ddl.TableInfo)
+		if err != nil {
+			return nil, err
+		}
+		return []string{query}, nil
+	case timodel.ActionDropTable:
+		return []string{"DROP TABLE IF EXISTS " + tableName.QuoteString()}, nil
+	case timodel.ActionTruncateTable:
+		return []string{"TRUNCATE TABLE IF EXISTS " + tableName.QuoteString()}, nil
+	case timodel.ActionRenameTable:
+		return []string{fmt.Sprintf("RENAME TABLE %s TO %s",
+			ddl.PreTableInfo.TableName.QuoteString(), tableName.QuoteString())}, nil
+	case timodel.ActionAddColumn, timodel.ActionAddColumns, timodel.ActionDropColumn,
+		timodel.ActionDropColumns, timodel.ActionModifyColumn, timodel.ActionRenameColumn:
+		if ddl.PreTableInfo == nil {
+			return nil, nil
+		}
+		return buildAlterColumns(ddl.PreTableInfo, ddl.TableInfo), nil
+	default:
+		log.Info("skip the DDL unsupported by clickhouse",
+			zap.String("query", ddl.Query), zap.Stringer("type", ddl.Type))
+		return nil, nil
+	}
+}
+
+// buildCreateTable creates a ReplacingMergeTree table ordered by the handle
+// key of the TiDB table, so the versions of a row are merged by the key.
+func buildCreateTable(tableInfo *model.TableInfo) (string, error) {
+	var (
+		columns []string
+		keys    []string
+	)
+	for _, col := range tableInfo.Columns {
+		if !model.IsColCDCVisible(col) {
+			continue
+		}
+		columns = append(columns, quotes.QuoteName(col.Name.O)+" "+ColumnType(&col.FieldType))
+		if tableInfo.ColumnsFlag[col.ID].IsHandleKey() {
+			keys = append(keys, quotes.QuoteName(col.Name.O))
+		}
+	}
+	if len(keys) == 0 {
+		return "", cerror.ErrClickHouseUnsupportedTable.GenWithStackByArgs(
+			tableInfo.TableName.String(), "it has no primary key or not null unique key")
+	}
+	columns = append(columns,
+		quotes.QuoteName(CommitTsColumn)+" UInt64",
+		quotes.QuoteName(IsDeletedColumn)+" UInt8")
+
+	return fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (%s) ENGINE = ReplacingMergeTree(%s, %s) ORDER BY (%s)",
+		tableInfo.TableName.QuoteString(), strings.Join(columns, ", "),
+		quotes.QuoteName(CommitTsColumn), quotes.QuoteName(IsDeletedColumn),
+		strings.Join(keys, ", ")), nil
+}
+
+// buildAlterColumns compares the columns before and after a DDL by their IDs
+// to alter the columns of the ClickHouse table.
+func buildAlterColumns(preTableInfo, tableInfo *model.TableInfo) []string {
+	preColumns := make(map[int64]*timodel.ColumnInfo, len(preTableInfo.Columns))
+	for _, col := range preTableInfo.Columns {
+		if model.IsColCDCVisible(col) {
+			preColumns[col.ID] = col
+		}
+	}
+
+	var queries []string
+	alter := func(format string, args ...interface{}) {
+		queries = append(queries, fmt.Sprintf("ALTER TABLE %s ", tableInfo.TableName.QuoteString())+
+			fmt.Sprintf(format, args...))
+	}
+	for _, col := range tableInfo.Columns {
+		if !model.IsColCDCVisible(col) {
+			continue
+		}
+		name, tp := quotes.QuoteName(col.Name.O), ColumnType(&col.FieldType)
+		preCol, ok := preColumns[col.ID]
+		if !ok {
+			alter("ADD COLUMN IF NOT EXISTS %s %s", name, tp)
+			continue
+		}
+		delete(preColumns, col.ID)
+		if preCol.Name.O != col.Name.O {
+			alter("RENAME COLUMN IF EXISTS %s TO %s", quotes.QuoteName(preCol.Name.O), name)
+		}
+		if ColumnType(&preCol.FieldType) != tp {
+			alter("MODIFY COLUMN IF EXISTS %s %s", name, tp)
+		}
+	}
+	for _, col := range preTableInfo.Columns {
+		if _, ok := preColumns[col.ID]; ok {
+			alter("DROP COLUMN IF EXISTS %s", quotes.QuoteName(col.Name.O))
+		}
+	}
+	return queries
+}
+
+// ColumnType returns the ClickHouse type of a TiDB column. The types without
+// an equivalent, such as TIME, JSON, ENUM and SET, are mapped to String.
+func ColumnType(ft *types.FieldType) string {
+	unsigned := mysql.HasUnsignedFlag(ft.GetFlag())
+	intType := func(bits int) string {
+		if unsigned {
+			return fmt.Sprintf("UInt%d", bits)
+		}
+		return fmt.Sprintf("Int%d", bits)
+	}
+
+	var tp string
+	switch ft.GetType() {
+	case mysql.TypeTiny:
+		tp = intType(8)
+	case mysql.TypeShort:
+		tp = intType(16)
+	case mysql.TypeInt24, mysql.TypeLong:
+		tp = intType(32)
+	case mysql.TypeLonglong:
+		tp = intType(64)
+	case mysql.TypeYear:
+		tp = "UInt16"
+	case mysql.TypeFloat:
+		tp = "Float32"
+	case mysql.TypeDouble:
+		tp = "Float64"
+	case mysql.TypeNewDecimal:
+		flen, decimal := ft.GetFlen(), ft.GetDecimal()
+		if flen <= 0 {
+			flen = mysql.MaxDecimalWidth
+		}
+		if decimal < 0 {
+			decimal = 0
+		}
+		tp = fmt.Sprintf("Decimal(%d, %d)", flen, decimal)
+	case mysql.TypeDate:
+		tp = "Date32"
+	case mysql.TypeDatetime, mysql.TypeTimestamp:
+		fsp := ft.GetDecimal()
+		if fsp < 0 {
+			fsp = 0
+		}
+		tp = fmt.Sprintf("DateTime64(%d)", fsp)
+	case mysql.TypeBit:
+		tp = "UInt64"
+	default:
+		tp = "String"
+	}
+	if !mysql.HasNotNullFlag(ft.GetFlag()) {
+		return "Nullable(" + tp + ")"
+	}
+	return tp
+}
@@ -0,0 +1,129 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clickhouse
+
+import (
+	"testing"
+
+	timodel "github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/stretchr/testify/require"
+)
+
+func newFieldType(tp byte, flag uint, flen, decimal int) types.FieldType {
+	ft := types.NewFieldType(tp)
+	ft.SetFlag(flag)
+	ft.SetFlen(flen)
+	ft.SetDecimal(decimal)
+	return *ft
+}
+
+func newTableInfo(name string, columns ...*timodel.ColumnInfo) *model.TableInfo {
+	for i, col := range columns {
+		col.Offset = i
+		col.State = timodel.StatePublic
+	}
+	return model.WrapTableInfo(1, "test", 1, &timodel.TableInfo{
+		ID:         100,
+		Name:       timodel.NewCIStr(name),
+		Columns:    columns,
+		PKIsHandle: true,
+	})
+}
+
+func TestColumnType(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		ft       types.FieldType
+		expected string
+	}{
+		{newFieldType(mysql.TypeTiny, mysql.NotNullFlag, 4, 0), "Int8"},
+		{newFieldType(mysql.TypeShort, mysql.NotNullFlag|mysql.UnsignedFlag, 6, 0), "UInt16"},
+		{newFieldType(mysql.TypeInt24, 0, 9, 0), "Nullable(Int32)"},
+		{newFieldType(mysql.TypeLonglong, mysql.UnsignedFlag, 20, 0), "Nullable(UInt64)"},
+		{newFieldType(mysql.TypeDouble, mysql.NotNullFlag, 22, -1), "Float64"},
+		{newFieldType(mysql.TypeNewDecimal, mysql.NotNullFlag, 10, 2), "Decimal(10, 2)"},
+		{newFieldType(mysql.TypeDate, mysql.NotNullFlag, 10, 0), "Date32"},
+		{newFieldType(mysql.TypeDatetime, mysql.NotNullFlag, 26, 6), "DateTime64(6)"},
+		{newFieldType(mysql.TypeTimestamp, 0, 19, 0), "Nullable(DateTime64(0))"},
+		{newFieldType(mysql.TypeVarchar, mysql.NotNullFlag, 255, 0), "String"},
+		{newFieldType(mysql.TypeJSON, 0, 0, 0), "Nullable(String)"},
+		{newFieldType(mysql.TypeDuration, mysql.NotNullFlag, 10, 0), "String"},
+	}
+	for _, tc := range testCases {
+		require.Equal(t, tc.expected, ColumnType(&tc.ft))
+	}
+}
+
+func TestBuildDDLs(t *testing.T) {
+	t.Parallel()
+
+	id := &timodel.ColumnInfo{
+		ID: 1, Name: timodel.NewCIStr("id"),
+		FieldType: newFieldType(mysql.TypeLong, mysql.NotNullFlag|mysql.PriKeyFlag, 11, 0),
+	}
+	name := &timodel.ColumnInfo{
+		ID: 2, Name: timodel.NewCIStr("name"),
+		FieldType: newFieldType(mysql.TypeVarchar, 0, 255, 0),
+	}
+	tableInfo := newTableInfo("t", id, name)
+
+	queries, err := BuildDDLs(&model.DDLEvent{
+		Type: timodel.ActionCreateSchema, TableInfo: &model.TableInfo{TableName: model.TableName{Schema: "test"}},
+	})
+	require.Nil(t, err)
+	require.Equal(t, []string{"CREATE DATABASE IF NOT EXISTS `test`"}, queries)
+
+	queries, err = BuildDDLs(&model.DDLEvent{Type: timodel.ActionCreateTable, TableInfo: tableInfo})
+	require.Nil(t, err)
+	require.Equal(t, []string{"CREATE TABLE IF NOT EXISTS `test`.`t` (`id` Int32, `name` Nullable(String), " +
+		"`_tidb_commit_ts` UInt64, `_tidb_is_deleted` UInt8) " +
+		"ENGINE = ReplacingMergeTree(`_tidb_commit_ts`, `_tidb_is_deleted`) ORDER BY (`id`)"}, queries)
+
+	queries, err = BuildDDLs(&model.DDLEvent{
+		Type: timodel.ActionRenameTable, PreTableInfo: tableInfo, TableInfo: newTableInfo("t1", id, name),
+	})
+	require.Nil(t, err)
+	require.Equal(t, []string{"RENAME TABLE `test`.`t` TO `test`.`t1`"}, queries)
+
+	// rename name to title and change its type, drop id and add a new column.
+	title := &timodel.ColumnInfo{
+		ID: 2, Name: timodel.NewCIStr("title"),
+		FieldType: newFieldType(mysql.TypeLonglong, mysql.NotNullFlag, 20, 0),
+	}
+	score := &timodel.ColumnInfo{
+		ID: 3, Name: timodel.NewCIStr("score"),
+		FieldType: newFieldType(mysql.TypeFloat, 0, 12, -1),
+	}
+	queries, err = BuildDDLs(&model.DDLEvent{
+		Type: timodel.ActionModifyColumn, PreTableInfo: tableInfo, TableInfo: newTableInfo("t", title, score),
+	})
+	require.Nil(t, err)
+	require.Equal(t, []string{
+		"ALTER TABLE `test`.`t` RENAME COLUMN IF EXISTS `name` TO `title`",
+		"ALTER TABLE `test`.`t` MODIFY COLUMN IF EXISTS `title` Int64",
+		"ALTER TABLE `test`.`t` ADD COLUMN IF NOT EXISTS `score` Nullable(Float32)",
+		"ALTER TABLE `test`.`t` DROP COLUMN IF EXISTS `id`",
+	}, queries)
+
+	queries, err = BuildDDLs(&model.DDLEvent{Type: timodel.ActionAddIndex, TableInfo: tableInfo})
+	require.Nil(t, err)
+	require.Empty(t, queries)
+
+	_, err = BuildDDLs(&model.DDLEvent{Type: timodel.ActionCreateTable, TableInfo: newTableInfo("t2", name)})
+	require.Regexp(t, "no primary key", err)
+}
@@ -0,0 +1,75 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clickhouse
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+
+	"github.com/pingcap/tidb/util/rowcodec"
+	"github.com/pingcap/tiflow/cdc/model"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/sink/httpsink"
+)
+
+// EncodeRow appends the row changed event to the buffer in the JSONEachRow
+// format. A deleted row is written with its IsDeletedColumn set, and so is
+// the old row of an update which changes the handle key.
+func EncodeRow(buf *bytes.Buffer, row *model.RowChangedEvent) error {
+	if row.IsDelete() {
+		return encodeColumns(buf, row.PreColumns, row.ColInfos, row.CommitTs, true)
+	}
+	if row.IsUpdate() && handleKeyChanged(row.PreColumns, row.Columns) {
+		if err := encodeColumns(buf, row.PreColumns, row.ColInfos, row.CommitTs, true); err != nil {
+			return err
+		}
+	}
+	return encodeColumns(buf, row.Columns, row.ColInfos, row.CommitTs, false)
+}
+
+func handleKeyChanged(preColumns, columns []*model.Column) bool {
+	for i, col := range columns {
+		if col == nil || !col.Flag.IsHandleKey() || i >= len(preColumns) {
+			continue
+		}
+		if preColumns[i] == nil || !reflect.DeepEqual(preColumns[i].Value, col.Value) {
+			return true
+		}
+	}
+	return false
+}
+
+func encodeColumns(
+	buf *bytes.Buffer, columns []*model.Column, colInfos []rowcodec.ColInfo,
+	commitTs uint64, deleted bool,
+) error {
+	values, err := httpsink.RowValues(columns, colInfos)
+	if err != nil {
+		return cerror.WrapError(cerror.ErrClickHouseExecFailed, err)
+	}
+	values[CommitTsColumn] = commitTs
+	values[IsDeletedColumn] = 0
+	if deleted {
+		values[IsDeletedColumn] = 1
+	}
+
+	data, err := json.Marshal(values)
+	if err != nil {
+		return cerror.WrapError(cerror.ErrClickHouseExecFailed, err)
+	}
+	buf.Write(data)
+	buf.WriteByte('\n')
+	return nil
+}
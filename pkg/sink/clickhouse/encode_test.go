@@ -0,0 +1,81 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clickhouse
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/util/rowcodec"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeRow(t *testing.T) {
+	t.Parallel()
+
+	enumType := newFieldType(mysql.TypeEnum, 0, 0, 0)
+	enumType.SetElems([]string{"a", "b"})
+	colInfos := []rowcodec.ColInfo{{ID: 1}, {ID: 2}, {ID: 3, Ft: &enumType}, {ID: 4}}
+	newColumns := func(id int64, data []byte) []*model.Column {
+		var value interface{}
+		if data != nil {
+			value = data
+		}
+		return []*model.Column{
+			{
+				Name: "id", Type: mysql.TypeLonglong, Value: id,
+				Flag: model.HandleKeyFlag | model.PrimaryKeyFlag,
+			},
+			{Name: "data", Type: mysql.TypeBlob, Value: value, Flag: model.BinaryFlag},
+			{Name: "kind", Type: mysql.TypeEnum, Value: uint64(2)},
+			{Name: "price", Type: mysql.TypeNewDecimal, Value: "12.30"},
+		}
+	}
+	table := &model.TableName{Schema: "test", Table: "t"}
+
+	var buf bytes.Buffer
+	require.Nil(t, EncodeRow(&buf, &model.RowChangedEvent{
+		CommitTs: 10, Table: table, ColInfos: colInfos, Columns: newColumns(1, []byte("abc")),
+	}))
+	require.Equal(t, `{"_tidb_commit_ts":10,"_tidb_is_deleted":0,"data":"YWJj","id":1,"kind":"b","price":12.30}`+"\n",
+		buf.String())
+
+	// update without changing the handle key.
+	buf.Reset()
+	require.Nil(t, EncodeRow(&buf, &model.RowChangedEvent{
+		CommitTs: 11, Table: table, ColInfos: colInfos,
+		PreColumns: newColumns(1, []byte("abc")), Columns: newColumns(1, nil),
+	}))
+	require.Equal(t, `{"_tidb_commit_ts":11,"_tidb_is_deleted":0,"data":null,"id":1,"kind":"b","price":12.30}`+"\n",
+		buf.String())
+
+	// update changing the handle key deletes the old row.
+	buf.Reset()
+	require.Nil(t, EncodeRow(&buf, &model.RowChangedEvent{
+		CommitTs: 12, Table: table, ColInfos: colInfos,
+		PreColumns: newColumns(1, nil), Columns: newColumns(2, nil),
+	}))
+	require.Equal(t, `{"_tidb_commit_ts":12,"_tidb_is_deleted":1,"data":null,"id":1,"kind":"b","price":12.30}`+"\n"+
+		`{"_tidb_commit_ts":12,"_tidb_is_deleted":0,"data":null,"id":2,"kind":"b","price":12.30}`+"\n",
+		buf.String())
+
+	buf.Reset()
+	require.Nil(t, EncodeRow(&buf, &model.RowChangedEvent{
+		CommitTs: 13, Table: table, ColInfos: colInfos, PreColumns: newColumns(2, nil),
+	}))
+	require.Equal(t, `{"_tidb_commit_ts":13,"_tidb_is_deleted":1,"data":null,"id":2,"kind":"b","price":12.30}`+"\n",
+		buf.String())
+}
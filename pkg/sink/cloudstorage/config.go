@@ -22,6 +22,7 @@ import (
 	"time"
 
 	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/pkg/compression"
 	"github.com/pingcap/tiflow/pkg/config"
 	cerror "github.com/pingcap/tiflow/pkg/errors"
 	psink "github.com/pingcap/tiflow/pkg/sink"
@@ -47,6 +48,15 @@ const (
 	maxFileSize = 512 * 1024 * 1024
 )
 
+const (
+	// TableFormatRaw writes the encoded messages of the protocol to the
+	// data files as they are.
+	TableFormatRaw = "raw"
+	// TableFormatIceberg commits the row changes to Apache Iceberg tables,
+	// see pkg/sink/iceberg for details.
+	TableFormatIceberg = "iceberg"
+)
+
 // Config is the configuration for cloud storage sink.
 type Config struct {
 	WorkerCount              int
@@ -54,6 +64,21 @@ type Config struct {
 	FileSize                 int
 	DateSeparator            string
 	EnablePartitionSeparator bool
+	// EnableFileStats indicates whether to write the statistics of each data
+	// file next to it, see FileStats for details.
+	EnableFileStats bool
+	// TableFormat is the format of the tables written to the storage, which
+	// is TableFormatRaw or TableFormatIceberg.
+	TableFormat string
+	// StorageURI is the sink URI without the query parameters, which is the
+	// root of the absolute paths in the Iceberg metadata.
+	StorageURI string
+	// IcebergCatalogURI is the URI of the Iceberg REST catalog the Iceberg
+	// tables are committed to, which is required by TableFormatIceberg.
+	IcebergCatalogURI string
+	// Compression is the compression codec of the data files, the extension
+	// of the codec is appended to the file names.
+	Compression string
 }
 
 // NewConfig returns the default cloud storage sink config.
@@ -62,6 +87,8 @@ func NewConfig() *Config {
 		WorkerCount:   defaultWorkerCount,
 		FlushInterval: defaultFlushInterval,
 		FileSize:      defaultFileSize,
+		TableFormat:   TableFormatRaw,
+		Compression:   compression.None,
 	}
 }
 
@@ -91,6 +118,34 @@ func (c *Config) Apply(
 	if err != nil {
 		return err
 	}
+	err = getEnableFileStats(query, &c.EnableFileStats)
+	if err != nil {
+		return err
+	}
+	err = getTableFormat(query, &c.TableFormat)
+	if err != nil {
+		return err
+	}
+	if c.TableFormat == TableFormatIceberg && c.EnableFileStats {
+		return cerror.ErrCloudStorageInvalidConfig.GenWithStack(
+			"enable-file-stats is not supported by the table format %s", c.TableFormat)
+	}
+	err = getIcebergCatalogURI(query, c.TableFormat, &c.IcebergCatalogURI)
+	if err != nil {
+		return err
+	}
+	err = getCompression(query, &c.Compression)
+	if err != nil {
+		return err
+	}
+	if c.TableFormat == TableFormatIceberg && c.Compression != compression.None {
+		return cerror.ErrCloudStorageInvalidConfig.GenWithStack(
+			"compression is not supported by the table format %s", c.TableFormat)
+	}
+	storageURI := *sinkURI
+	storageURI.RawQuery = ""
+	storageURI.Fragment = ""
+	c.StorageURI = storageURI.String()
 
 	c.DateSeparator = replicaConfig.Sink.DateSeparator
 	c.EnablePartitionSeparator = replicaConfig.Sink.EnablePartitionSeparator
@@ -171,3 +226,72 @@ func getFileSize(values url.Values, fileSize *int) error {
 	*fileSize = sz
 	return nil
 }
+
+func getEnableFileStats(values url.Values, enableFileStats *bool) error {
+	s := values.Get("enable-file-stats")
+	if len(s) == 0 {
+		return nil
+	}
+
+	enabled, err := strconv.ParseBool(s)
+	if err != nil {
+		return cerror.WrapError(cerror.ErrCloudStorageInvalidConfig, err)
+	}
+	*enableFileStats = enabled
+	return nil
+}
+
+func getTableFormat(values url.Values, tableFormat *string) error {
+	s := values.Get("table-format")
+	if len(s) == 0 {
+		return nil
+	}
+
+	format := strings.ToLower(s)
+	if format != TableFormatRaw && format != TableFormatIceberg {
+		return cerror.ErrCloudStorageInvalidConfig.GenWithStack(
+			"invalid table-format %s, it must be %s or %s", s, TableFormatRaw, TableFormatIceberg)
+	}
+	*tableFormat = format
+	return nil
+}
+
+func getIcebergCatalogURI(values url.Values, tableFormat string, catalogURI *string) error {
+	s := values.Get("iceberg-catalog-uri")
+	if tableFormat != TableFormatIceberg {
+		if len(s) != 0 {
+			return cerror.ErrCloudStorageInvalidConfig.GenWithStack(
+				"iceberg-catalog-uri is only supported by the table format %s", TableFormatIceberg)
+		}
+		return nil
+	}
+	// the catalog commits the tables atomically, the storage can't.
+	if len(s) == 0 {
+		return cerror.ErrCloudStorageInvalidConfig.GenWithStack(
+			"iceberg-catalog-uri is required by the table format %s", TableFormatIceberg)
+	}
+	u, err := url.Parse(s)
+	if err != nil {
+		return cerror.WrapError(cerror.ErrCloudStorageInvalidConfig, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return cerror.ErrCloudStorageInvalidConfig.GenWithStack(
+			"invalid iceberg-catalog-uri %s, it must be an http or https URI", s)
+	}
+	*catalogURI = s
+	return nil
+}
+
+func getCompression(values url.Values, codec *string) error {
+	s := values.Get("compression")
+	if len(s) == 0 {
+		return nil
+	}
+
+	c, err := compression.Get(s)
+	if err != nil {
+		return cerror.WrapError(cerror.ErrCloudStorageInvalidConfig, err)
+	}
+	*codec = c.Name()
+	return nil
+}
@@ -29,7 +29,11 @@ func TestConfigApply(t *testing.T) {
 	expected.FlushInterval = 10 * time.Second
 	expected.FileSize = 16 * 1024 * 1024
 	expected.DateSeparator = config.DateSeparatorNone.String()
-	uri := "s3://bucket/prefix?worker-count=32&flush-interval=10s&file-size=16777216"
+	expected.EnableFileStats = true
+	expected.StorageURI = "s3://bucket/prefix"
+	expected.Compression = "gzip"
+	uri := "s3://bucket/prefix?worker-count=32&flush-interval=10s&file-size=16777216" +
+		"&enable-file-stats=true&compression=GZIP"
 	sinkURI, err := url.Parse(uri)
 	require.Nil(t, err)
 	cfg := NewConfig()
@@ -104,6 +108,51 @@ func TestVerifySinkURIParams(t *testing.T) {
 			uri:         "s3://bucket/prefix?file-size=1073741824",
 			expectedErr: "",
 		},
+		{
+			name:        "invalid sink uri with non-boolean enable-file-stats",
+			uri:         "s3://bucket/prefix?enable-file-stats=yes",
+			expectedErr: "invalid syntax",
+		},
+		{
+			name:        "valid sink uri with iceberg table-format",
+			uri:         "s3://bucket/prefix?table-format=iceberg&iceberg-catalog-uri=http%3A%2F%2F127.0.0.1%3A8181",
+			expectedErr: "",
+		},
+		{
+			name:        "invalid sink uri with iceberg table-format and no catalog",
+			uri:         "s3://bucket/prefix?table-format=iceberg",
+			expectedErr: "iceberg-catalog-uri is required",
+		},
+		{
+			name:        "invalid sink uri with iceberg table-format and a file catalog",
+			uri:         "s3://bucket/prefix?table-format=iceberg&iceberg-catalog-uri=file%3A%2F%2F%2Ftmp",
+			expectedErr: "it must be an http or https URI",
+		},
+		{
+			name:        "invalid sink uri with raw table-format and a catalog",
+			uri:         "s3://bucket/prefix?iceberg-catalog-uri=http%3A%2F%2F127.0.0.1%3A8181",
+			expectedErr: "iceberg-catalog-uri is only supported",
+		},
+		{
+			name:        "invalid sink uri with unknown table-format",
+			uri:         "s3://bucket/prefix?table-format=delta",
+			expectedErr: "invalid table-format delta",
+		},
+		{
+			name:        "invalid sink uri with iceberg table-format and enable-file-stats",
+			uri:         "s3://bucket/prefix?table-format=iceberg&enable-file-stats=true",
+			expectedErr: "enable-file-stats is not supported",
+		},
+		{
+			name:        "invalid sink uri with unknown compression",
+			uri:         "s3://bucket/prefix?compression=brotli",
+			expectedErr: "compression codec brotli is not supported",
+		},
+		{
+			name:        "invalid sink uri with iceberg table-format and compression",
+			uri:         "s3://bucket/prefix?table-format=iceberg&iceberg-catalog-uri=http%3A%2F%2F127.0.0.1%3A8181&compression=zstd",
+			expectedErr: "compression is not supported",
+		},
 	}
 
 	for _, tc := range testCases {
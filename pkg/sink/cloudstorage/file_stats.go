@@ -0,0 +1,164 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudstorage
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pingcap/tiflow/cdc/model"
+)
+
+// FileStatsSuffix is the suffix of the statistics file of a data file, which
+// is written next to the data file, e.g. CDC000001.json.meta.
+const FileStatsSuffix = ".meta"
+
+// FileStats is the statistics of a data file. It's used by the merge-on-read
+// query engines to prune the data files without scanning them.
+type FileStats struct {
+	RowCount    int    `json:"row-count"`
+	MinCommitTs uint64 `json:"min-commit-ts"`
+	MaxCommitTs uint64 `json:"max-commit-ts"`
+	// KeyColumns are the handle key columns of the table, MinKey and MaxKey
+	// are the range of the handle key of the rows, which are compared column
+	// by column. They are empty if the table doesn't have a handle key.
+	KeyColumns []string      `json:"key-columns,omitempty"`
+	MinKey     []interface{} `json:"min-key,omitempty"`
+	MaxKey     []interface{} `json:"max-key,omitempty"`
+
+	// noKeyRange is set if the handle key of the rows are not consistent.
+	noKeyRange bool
+}
+
+// GenerateFileStatsPath returns the path of the statistics file of the data file.
+func GenerateFileStatsPath(dataFilePath string) string {
+	return dataFilePath + FileStatsSuffix
+}
+
+// IsFileStatsPath returns true if the path is a statistics file.
+func IsFileStatsPath(path string) bool {
+	return strings.HasSuffix(path, FileStatsSuffix)
+}
+
+// ObserveRow updates the statistics with the row.
+func (s *FileStats) ObserveRow(row *model.RowChangedEvent) {
+	if s.RowCount == 0 || row.CommitTs < s.MinCommitTs {
+		s.MinCommitTs = row.CommitTs
+	}
+	if row.CommitTs > s.MaxCommitTs {
+		s.MaxCommitTs = row.CommitTs
+	}
+	s.RowCount++
+
+	if s.noKeyRange {
+		return
+	}
+	cols := row.HandleKeyColumns()
+	if len(cols) == 0 {
+		s.resetKeyRange()
+		return
+	}
+	names := make([]string, 0, len(cols))
+	key := make([]interface{}, 0, len(cols))
+	for _, col := range cols {
+		names = append(names, col.Name)
+		key = append(key, normalizeKeyValue(col.Value))
+	}
+	if s.KeyColumns == nil {
+		s.KeyColumns = names
+		s.MinKey = key
+		s.MaxKey = key
+		return
+	}
+	if strings.Join(names, ",") != strings.Join(s.KeyColumns, ",") {
+		s.resetKeyRange()
+		return
+	}
+	if compareKeys(key, s.MinKey) < 0 {
+		s.MinKey = key
+	}
+	if compareKeys(key, s.MaxKey) > 0 {
+		s.MaxKey = key
+	}
+}
+
+func (s *FileStats) resetKeyRange() {
+	s.noKeyRange = true
+	s.KeyColumns = nil
+	s.MinKey = nil
+	s.MaxKey = nil
+}
+
+// normalizeKeyValue converts the bytes to a string, so that it's encoded
+// as it is in the statistics file instead of base64.
+func normalizeKeyValue(v interface{}) interface{} {
+	if b, ok := v.([]byte); ok {
+		return string(b)
+	}
+	return v
+}
+
+func compareKeys(a, b []interface{}) int {
+	for i := range a {
+		if c := compareKeyValue(a[i], b[i]); c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+// compareKeyValue compares two values of a key column, nil is the smallest.
+// The values of different types are compared by their string representation.
+func compareKeyValue(a, b interface{}) int {
+	if a == nil || b == nil {
+		switch {
+		case a == nil && b == nil:
+			return 0
+		case a == nil:
+			return -1
+		default:
+			return 1
+		}
+	}
+	switch x := a.(type) {
+	case int64:
+		if y, ok := b.(int64); ok {
+			return compareOrdered(x, y)
+		}
+	case uint64:
+		if y, ok := b.(uint64); ok {
+			return compareOrdered(x, y)
+		}
+	case float64:
+		if y, ok := b.(float64); ok {
+			return compareOrdered(x, y)
+		}
+	case string:
+		if y, ok := b.(string); ok {
+			return strings.Compare(x, y)
+		}
+	}
+	return strings.Compare(fmt.Sprint(a), fmt.Sprint(b))
+}
+
+func compareOrdered[T int64 | uint64 | float64](x, y T) int {
+	switch {
+	case x < y:
+		return -1
+	case x > y:
+		return 1
+	default:
+		return 0
+	}
+}
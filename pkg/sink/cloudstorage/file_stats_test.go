@@ -0,0 +1,87 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudstorage
+
+import (
+	"testing"
+
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/stretchr/testify/require"
+)
+
+func newStatsRow(commitTs uint64, id int64, name string) *model.RowChangedEvent {
+	return &model.RowChangedEvent{
+		CommitTs: commitTs,
+		Columns: []*model.Column{
+			{Name: "id", Value: id, Flag: model.HandleKeyFlag},
+			{Name: "name", Value: []byte(name), Flag: model.HandleKeyFlag},
+			{Name: "v", Value: "x"},
+		},
+	}
+}
+
+func TestFileStatsObserveRow(t *testing.T) {
+	t.Parallel()
+
+	var stats FileStats
+	stats.ObserveRow(newStatsRow(110, 5, "b"))
+	stats.ObserveRow(newStatsRow(100, 5, "a"))
+	stats.ObserveRow(newStatsRow(120, 10, "a"))
+	deleted := &model.RowChangedEvent{
+		CommitTs:   115,
+		PreColumns: newStatsRow(0, 1, "z").Columns,
+	}
+	stats.ObserveRow(deleted)
+
+	require.Equal(t, 4, stats.RowCount)
+	require.Equal(t, uint64(100), stats.MinCommitTs)
+	require.Equal(t, uint64(120), stats.MaxCommitTs)
+	require.Equal(t, []string{"id", "name"}, stats.KeyColumns)
+	require.Equal(t, []interface{}{int64(1), "z"}, stats.MinKey)
+	require.Equal(t, []interface{}{int64(10), "a"}, stats.MaxKey)
+
+	// the key range is dropped if a row doesn't have the handle key.
+	stats.ObserveRow(&model.RowChangedEvent{
+		CommitTs: 130,
+		Columns:  []*model.Column{{Name: "v", Value: "x"}},
+	})
+	stats.ObserveRow(newStatsRow(140, 20, "a"))
+	require.Equal(t, 6, stats.RowCount)
+	require.Equal(t, uint64(140), stats.MaxCommitTs)
+	require.Nil(t, stats.KeyColumns)
+	require.Nil(t, stats.MinKey)
+	require.Nil(t, stats.MaxKey)
+}
+
+func TestCompareKeyValue(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, -1, compareKeyValue(nil, int64(1)))
+	require.Equal(t, 0, compareKeyValue(nil, nil))
+	require.Equal(t, 1, compareKeyValue(int64(10), int64(9)))
+	require.Equal(t, -1, compareKeyValue(uint64(1), uint64(2)))
+	require.Equal(t, 1, compareKeyValue(2.5, 1.5))
+	require.Equal(t, -1, compareKeyValue("a", "b"))
+	require.Equal(t, -1, compareKeyValue(int64(1), "a"))
+}
+
+func TestFileStatsPath(t *testing.T) {
+	t.Parallel()
+
+	path := GenerateFileStatsPath("test/t1/100/CDC000001.json")
+	require.Equal(t, "test/t1/100/CDC000001.json.meta", path)
+	require.True(t, IsFileStatsPath(path))
+	require.False(t, IsFileStatsPath("test/t1/100/CDC000001.json"))
+	require.False(t, IsFileStatsPath("metadata"))
+}
@@ -0,0 +1,68 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudstorage
+
+import (
+	"context"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/br/pkg/storage"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/pingcap/tiflow/pkg/retry"
+)
+
+const (
+	// The defaults of the retry budget of the storage sink, the writes are
+	// attempted only once if the budget is not set, since the clients of the
+	// external storages retry the requests themselves.
+	defaultWriteMaxTries           = 3
+	defaultWriteBackoffBaseDelayMs = 500
+	defaultWriteBackoffMaxDelayMs  = 10 * 1000
+)
+
+// retryStorage retries the writes to the external storage by the retry
+// budget of the sink.
+type retryStorage struct {
+	storage.ExternalStorage
+	budget *config.RetryBudgetConfig
+}
+
+// NewRetryStorage wraps the storage so that the files are written within the
+// retry budget, and the errors are escalated by its policy once the budget is
+// exhausted. The storage is returned as it is if the budget is nil.
+func NewRetryStorage(s storage.ExternalStorage, budget *config.RetryBudgetConfig) storage.ExternalStorage {
+	if budget == nil {
+		return s
+	}
+	return &retryStorage{ExternalStorage: s, budget: budget}
+}
+
+// WriteFile implements storage.ExternalStorage.
+func (s *retryStorage) WriteFile(ctx context.Context, name string, data []byte) error {
+	opts := append([]retry.Option{
+		retry.WithMaxTries(defaultWriteMaxTries),
+		retry.WithBackoffBaseDelay(defaultWriteBackoffBaseDelayMs),
+		retry.WithBackoffMaxDelay(defaultWriteBackoffMaxDelayMs),
+		retry.WithIsRetryableErr(isRetryableWriteErr),
+	}, s.budget.RetryOptions()...)
+	err := retry.Do(ctx, func() error {
+		return s.ExternalStorage.WriteFile(ctx, name, data)
+	}, opts...)
+	return s.budget.Escalate(err)
+}
+
+func isRetryableWriteErr(err error) bool {
+	cause := errors.Cause(err)
+	return cause != context.Canceled && cause != context.DeadlineExceeded
+}
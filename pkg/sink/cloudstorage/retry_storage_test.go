@@ -0,0 +1,80 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudstorage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/br/pkg/storage"
+	"github.com/pingcap/tiflow/pkg/config"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+// flakyStorage fails the first failures writes.
+type flakyStorage struct {
+	storage.ExternalStorage
+	failures int
+	writes   int
+}
+
+func (s *flakyStorage) WriteFile(_ context.Context, _ string, _ []byte) error {
+	s.writes++
+	if s.writes <= s.failures {
+		return errors.New("injected write error")
+	}
+	return nil
+}
+
+func TestRetryStorage(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	s := &flakyStorage{failures: 1}
+	// the writes are not retried without a budget.
+	require.Equal(t, s, NewRetryStorage(s, nil))
+	require.Error(t, NewRetryStorage(s, nil).WriteFile(ctx, "f", nil))
+
+	budget := &config.RetryBudgetConfig{
+		MaxAttempts:      3,
+		BackoffBaseDelay: time.Millisecond,
+		BackoffMaxDelay:  time.Millisecond,
+		OnExhausted:      config.RetryExhaustedWarning,
+	}
+	s = &flakyStorage{failures: 2}
+	require.Nil(t, NewRetryStorage(s, budget).WriteFile(ctx, "f", nil))
+	require.Equal(t, 3, s.writes)
+
+	s = &flakyStorage{failures: 3}
+	err := NewRetryStorage(s, budget).WriteFile(ctx, "f", nil)
+	require.True(t, cerror.Is(err, cerror.ErrReachMaxTry))
+	require.False(t, cerror.IsChangefeedUnRetryableError(err))
+	require.Equal(t, 3, s.writes)
+
+	budget.OnExhausted = config.RetryExhaustedFailed
+	s = &flakyStorage{failures: 3}
+	err = NewRetryStorage(s, budget).WriteFile(ctx, "f", nil)
+	require.True(t, cerror.IsChangefeedUnRetryableError(err))
+
+	// the canceled writes are not retried.
+	cctx, cancel := context.WithCancel(ctx)
+	cancel()
+	s = &flakyStorage{failures: 3}
+	err = NewRetryStorage(s, budget).WriteFile(cctx, "f", nil)
+	require.Equal(t, context.Canceled, errors.Cause(err))
+	require.Equal(t, 0, s.writes)
+}
@@ -0,0 +1,135 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elasticsearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/httputil"
+)
+
+// Request is a request to the REST API of Elasticsearch.
+type Request struct {
+	Method string
+	// Path is the path of the API with the query parameters.
+	Path string
+	Body []byte
+	// IgnoreNotFound ignores the 404 response, such as the one of deleting
+	// an index which doesn't exist.
+	IgnoreNotFound bool
+}
+
+// bulkResponse is the response of a bulk request, the items are the results
+// of the actions keyed by the action types.
+type bulkResponse struct {
+	Errors bool                      `json:"errors"`
+	Items  []map[string]bulkItemResp `json:"items"`
+}
+
+type bulkItemResp struct {
+	Index  string          `json:"_index"`
+	ID     string          `json:"_id"`
+	Status int             `json:"status"`
+	Error  json.RawMessage `json:"error"`
+}
+
+// Client sends the requests to the REST API of Elasticsearch.
+type Client struct {
+	cfg  *Config
+	http *httputil.Client
+}
+
+// NewClient creates a new Client.
+func NewClient(cfg *Config) (*Client, error) {
+	cli, err := cfg.NewClient()
+	if err != nil {
+		return nil, errors.WrapError(errors.ErrElasticsearchInvalidConfig, err)
+	}
+	return &Client{cfg: cfg, http: cli}, nil
+}
+
+// Do sends a request.
+func (c *Client) Do(ctx context.Context, req *Request) error {
+	_, err := c.do(ctx, req, "application/json")
+	return err
+}
+
+// Bulk sends the actions encoded by EncodeRow in a bulk request. The actions
+// rejected because a newer version of the document exists, and the ones
+// deleting a document which doesn't exist are regarded as succeeded.
+func (c *Client) Bulk(ctx context.Context, body []byte) error {
+	content, err := c.do(ctx, &Request{Method: http.MethodPost, Path: "/_bulk", Body: body},
+		"application/x-ndjson")
+	if err != nil {
+		return err
+	}
+	var resp bulkResponse
+	if err := json.Unmarshal(content, &resp); err != nil {
+		return errors.WrapError(errors.ErrElasticsearchRequestFailed, err)
+	}
+	if !resp.Errors {
+		return nil
+	}
+	for _, item := range resp.Items {
+		for action, result := range item {
+			if result.Status/100 == 2 || result.Status == http.StatusConflict ||
+				(action == "delete" && result.Status == http.StatusNotFound) {
+				continue
+			}
+			return errors.ErrElasticsearchRequestFailed.GenWithStack(
+				"failed to %s document %s of index %s, status: %d, error: %s",
+				action, result.ID, result.Index, result.Status, result.Error)
+		}
+	}
+	return nil
+}
+
+func (c *Client) do(ctx context.Context, req *Request, contentType string) ([]byte, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, req.Method, c.cfg.Endpoint+req.Path,
+		bytes.NewReader(req.Body))
+	if err != nil {
+		return nil, errors.WrapError(errors.ErrElasticsearchRequestFailed, err)
+	}
+	httpReq.Header.Set("Content-Type", contentType)
+	if c.cfg.User != "" {
+		httpReq.SetBasicAuth(c.cfg.User, c.cfg.Password)
+	}
+	resp, err := c.http.Do(httpReq)
+	if err != nil {
+		return nil, errors.WrapError(errors.ErrElasticsearchRequestFailed, err)
+	}
+	defer resp.Body.Close()
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.WrapError(errors.ErrElasticsearchRequestFailed, err)
+	}
+	if resp.StatusCode == http.StatusNotFound && req.IgnoreNotFound {
+		return content, nil
+	}
+	if resp.StatusCode/100 != 2 {
+		return nil, errors.ErrElasticsearchRequestFailed.GenWithStack(
+			"%s %s: [%d] %s", req.Method, req.Path, resp.StatusCode, content)
+	}
+	return content, nil
+}
+
+// Close closes the idle connections of the client.
+func (c *Client) Close() {
+	c.http.CloseIdleConnections()
+}
@@ -0,0 +1,88 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elasticsearch
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu       sync.Mutex
+		requests []string
+		bulkResp string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, pass, ok := r.BasicAuth()
+		require.True(t, ok)
+		require.Equal(t, "elastic", user)
+		require.Equal(t, "pass", pass)
+		body, err := io.ReadAll(r.Body)
+		require.Nil(t, err)
+		mu.Lock()
+		defer mu.Unlock()
+		requests = append(requests, r.Method+" "+r.URL.Path+" "+string(body))
+		switch r.URL.Path {
+		case "/_bulk":
+			require.Equal(t, "application/x-ndjson", r.Header.Get("Content-Type"))
+			_, _ = w.Write([]byte(bulkResp))
+		case "/absent":
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	cfg := NewConfig()
+	cfg.Endpoint = server.URL
+	cfg.User = "elastic"
+	cfg.Password = "pass"
+	client, err := NewClient(cfg)
+	require.Nil(t, err)
+	defer client.Close()
+
+	ctx := context.Background()
+	require.Nil(t, client.Do(ctx, &Request{Method: http.MethodPut, Path: "/_index_template/t", Body: []byte("{}")}))
+	require.Nil(t, client.Do(ctx, &Request{Method: http.MethodDelete, Path: "/absent", IgnoreNotFound: true}))
+	require.Regexp(t, "elasticsearch request failed.*404",
+		client.Do(ctx, &Request{Method: http.MethodDelete, Path: "/absent"}))
+
+	// the stale versions and the absent documents to delete are ignored.
+	bulkResp = `{"errors":true,"items":[{"index":{"_index":"t","_id":"1","status":201}},` +
+		`{"index":{"_index":"t","_id":"2","status":409,"error":{"type":"version_conflict_engine_exception"}}},` +
+		`{"delete":{"_index":"t","_id":"3","status":404}}]}`
+	require.Nil(t, client.Bulk(ctx, []byte("bulk\n")))
+	bulkResp = `{"errors":true,"items":[{"index":{"_index":"t","_id":"1","status":400,` +
+		`"error":{"type":"mapper_parsing_exception"}}}]}`
+	require.Regexp(t, "failed to index document 1 of index t, status: 400.*mapper_parsing_exception",
+		client.Bulk(ctx, []byte("bulk\n")))
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Equal(t, []string{
+		"PUT /_index_template/t {}",
+		"DELETE /absent ",
+		"DELETE /absent ",
+		"POST /_bulk bulk\n",
+		"POST /_bulk bulk\n",
+	}, requests)
+}
@@ -0,0 +1,100 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elasticsearch
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	psink "github.com/pingcap/tiflow/pkg/sink"
+	"github.com/pingcap/tiflow/pkg/sink/httpsink"
+)
+
+const (
+	// defaultFlushSize is the default value of flush-size.
+	defaultFlushSize = 1000
+	// the upper limit of flush-size.
+	maxFlushSize = 100000
+)
+
+// Config is the configuration for Elasticsearch sink, the Endpoint is the URL
+// of the REST API of Elasticsearch and BatchSize is the max number of the rows
+// sent in a bulk request, which is set by the flush-size parameter.
+type Config struct {
+	httpsink.Config
+	// IndexPrefix is prepended to the index names of the tables.
+	IndexPrefix string
+	// DeleteAsTombstone indexes the deleted rows as the documents marked by
+	// IsDeletedField instead of deleting the documents.
+	DeleteAsTombstone bool
+}
+
+// NewConfig returns the default Elasticsearch sink config.
+func NewConfig() *Config {
+	return &Config{Config: httpsink.Config{
+		BatchSize:     defaultFlushSize,
+		FlushInterval: httpsink.DefaultFlushInterval,
+	}}
+}
+
+// Apply applies the sink URI parameters to the config. The elasticsearch
+// scheme connects to Elasticsearch by HTTP, and the elasticsearch+ssl scheme
+// connects to it by HTTPS.
+func (c *Config) Apply(sinkURI *url.URL) error {
+	err := c.Config.Apply(sinkURI, httpsink.Options{
+		Name:             "elasticsearch",
+		Scheme:           psink.ElasticsearchScheme,
+		SSLScheme:        psink.ElasticsearchSSLScheme,
+		BatchSizeParam:   "flush-size",
+		MaxBatchSize:     maxFlushSize,
+		ErrInvalidConfig: cerror.ErrElasticsearchInvalidConfig,
+	})
+	if err != nil {
+		return err
+	}
+
+	query := sinkURI.Query()
+	if err := getIndexPrefix(query, &c.IndexPrefix); err != nil {
+		return err
+	}
+	if s := query.Get("delete-as-tombstone"); len(s) > 0 {
+		tombstone, err := strconv.ParseBool(s)
+		if err != nil {
+			return cerror.WrapError(cerror.ErrElasticsearchInvalidConfig, err)
+		}
+		c.DeleteAsTombstone = tombstone
+	}
+	return nil
+}
+
+func getIndexPrefix(values url.Values, indexPrefix *string) error {
+	s := values.Get("index-prefix")
+	if len(s) == 0 {
+		return nil
+	}
+
+	if s != strings.ToLower(s) || strings.ContainsAny(s, invalidIndexChars) ||
+		strings.ContainsAny(s[:1], invalidIndexLeadingChars) {
+		return cerror.WrapError(cerror.ErrElasticsearchInvalidConfig,
+			fmt.Errorf("invalid index-prefix %s, it must be lowercase, must not "+
+				"start with %q, and must not contain %q",
+				s, invalidIndexLeadingChars, invalidIndexChars))
+	}
+
+	*indexPrefix = s
+	return nil
+}
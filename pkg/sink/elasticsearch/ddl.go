@@ -0,0 +1,173 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elasticsearch
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+
+	"github.com/pingcap/log"
+	timodel "github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tiflow/cdc/model"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// BuildDDLRequests translates a TiDB DDL event to the Elasticsearch requests.
+// Nil is returned if the DDL has nothing to do with Elasticsearch, such as the
+// DDLs of the databases, the indexes and the partitions.
+//
+// The index template of a table is put when the table is created or its
+// columns are changed, and a renamed table is reindexed to its new index.
+func BuildDDLRequests(cfg *Config, ddl *model.DDLEvent) ([]*Request, error) {
+	index := IndexName(cfg.IndexPrefix, ddl.TableInfo.TableName)
+	switch ddl.Type {
+	case timodel.ActionCreateTable, timodel.ActionCreateTables, timodel.ActionRecoverTable:
+		req, err := putIndexTemplate(index, ddl.TableInfo)
+		if err != nil {
+			return nil, err
+		}
+		return []*Request{req}, nil
+	case timodel.ActionDropTable:
+		return dropIndex(index), nil
+	case timodel.ActionTruncateTable:
+		body, err := marshal(map[string]interface{}{
+			"query": map[string]interface{}{"match_all": struct{}{}},
+		})
+		if err != nil {
+			return nil, err
+		}
+		return []*Request{{
+			Method:         http.MethodPost,
+			Path:           "/" + url.PathEscape(index) + "/_delete_by_query?conflicts=proceed",
+			Body:           body,
+			IgnoreNotFound: true,
+		}}, nil
+	case timodel.ActionRenameTable, timodel.ActionRenameTables:
+		if ddl.PreTableInfo == nil {
+			return nil, nil
+		}
+		req, err := putIndexTemplate(index, ddl.TableInfo)
+		if err != nil {
+			return nil, err
+		}
+		preIndex := IndexName(cfg.IndexPrefix, ddl.PreTableInfo.TableName)
+		if preIndex == index {
+			return []*Request{req}, nil
+		}
+		body, err := marshal(map[string]interface{}{
+			"conflicts": "proceed",
+			"source":    map[string]interface{}{"index": preIndex},
+			"dest":      map[string]interface{}{"index": index, "version_type": "external"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		return append([]*Request{req, {
+			Method:         http.MethodPost,
+			Path:           "/_reindex?wait_for_completion=true",
+			Body:           body,
+			IgnoreNotFound: true,
+		}}, dropIndex(preIndex)...), nil
+	case timodel.ActionAddColumn, timodel.ActionAddColumns, timodel.ActionDropColumn,
+		timodel.ActionDropColumns, timodel.ActionModifyColumn, timodel.ActionRenameColumn:
+		req, err := putIndexTemplate(index, ddl.TableInfo)
+		if err != nil {
+			return nil, err
+		}
+		reqs := []*Request{req}
+		if ddl.PreTableInfo != nil {
+			req, err := putNewFieldMappings(index, ddl.PreTableInfo, ddl.TableInfo)
+			if err != nil {
+				return nil, err
+			}
+			if req != nil {
+				reqs = append(reqs, req)
+			}
+		}
+		return reqs, nil
+	default:
+		log.Info("skip the DDL unsupported by elasticsearch",
+			zap.String("query", ddl.Query), zap.Stringer("type", ddl.Type))
+		return nil, nil
+	}
+}
+
+func putIndexTemplate(index string, tableInfo *model.TableInfo) (*Request, error) {
+	body, err := BuildIndexTemplate(index, tableInfo)
+	if err != nil {
+		return nil, err
+	}
+	return &Request{
+		Method: http.MethodPut,
+		Path:   "/_index_template/" + url.PathEscape(index),
+		Body:   body,
+	}, nil
+}
+
+// putNewFieldMappings adds the mappings of the new columns to the existing
+// index, because the index template only applies to the new indices. The
+// mappings of the existing fields can't be changed by Elasticsearch.
+func putNewFieldMappings(index string, preTableInfo, tableInfo *model.TableInfo) (*Request, error) {
+	preColumns := make(map[string]struct{}, len(preTableInfo.Columns))
+	for _, col := range preTableInfo.Columns {
+		preColumns[col.Name.O] = struct{}{}
+	}
+	properties := make(map[string]interface{})
+	for _, col := range tableInfo.Columns {
+		if !model.IsColCDCVisible(col) {
+			continue
+		}
+		if _, ok := preColumns[col.Name.O]; !ok {
+			properties[col.Name.O] = FieldMapping(&col.FieldType)
+		}
+	}
+	if len(properties) == 0 {
+		return nil, nil
+	}
+	body, err := marshal(map[string]interface{}{"properties": properties})
+	if err != nil {
+		return nil, err
+	}
+	return &Request{
+		Method:         http.MethodPut,
+		Path:           "/" + url.PathEscape(index) + "/_mapping",
+		Body:           body,
+		IgnoreNotFound: true,
+	}, nil
+}
+
+func dropIndex(index string) []*Request {
+	return []*Request{
+		{
+			Method:         http.MethodDelete,
+			Path:           "/_index_template/" + url.PathEscape(index),
+			IgnoreNotFound: true,
+		},
+		{
+			Method:         http.MethodDelete,
+			Path:           "/" + url.PathEscape(index),
+			IgnoreNotFound: true,
+		},
+	}
+}
+
+func marshal(v interface{}) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrElasticsearchRequestFailed, err)
+	}
+	return data, nil
+}
@@ -0,0 +1,115 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elasticsearch
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb/parser/charset"
+	timodel "github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildDDLRequests(t *testing.T) {
+	t.Parallel()
+
+	cfg := NewConfig()
+	cfg.IndexPrefix = "tidb-"
+	id := &timodel.ColumnInfo{
+		ID: 1, Name: timodel.NewCIStr("id"),
+		FieldType: newFieldType(mysql.TypeLong, mysql.NotNullFlag|mysql.PriKeyFlag, ""),
+	}
+	name := &timodel.ColumnInfo{
+		ID: 2, Name: timodel.NewCIStr("name"),
+		FieldType: newFieldType(mysql.TypeVarchar, 0, charset.CharsetUTF8MB4),
+	}
+	tableInfo := newTableInfo("t", id, name)
+	paths := func(reqs []*Request) []string {
+		var paths []string
+		for _, req := range reqs {
+			paths = append(paths, req.Method+" "+req.Path)
+		}
+		return paths
+	}
+
+	reqs, err := BuildDDLRequests(cfg, &model.DDLEvent{
+		Type: timodel.ActionCreateSchema, TableInfo: &model.TableInfo{TableName: model.TableName{Schema: "test"}},
+	})
+	require.Nil(t, err)
+	require.Empty(t, reqs)
+
+	reqs, err = BuildDDLRequests(cfg, &model.DDLEvent{Type: timodel.ActionCreateTable, TableInfo: tableInfo})
+	require.Nil(t, err)
+	require.Equal(t, []string{"PUT /_index_template/tidb-test.t"}, paths(reqs))
+	template, err := BuildIndexTemplate("tidb-test.t", tableInfo)
+	require.Nil(t, err)
+	require.Equal(t, template, reqs[0].Body)
+
+	reqs, err = BuildDDLRequests(cfg, &model.DDLEvent{Type: timodel.ActionTruncateTable, TableInfo: tableInfo})
+	require.Nil(t, err)
+	require.Equal(t, []string{"POST /tidb-test.t/_delete_by_query?conflicts=proceed"}, paths(reqs))
+	require.JSONEq(t, `{"query":{"match_all":{}}}`, string(reqs[0].Body))
+
+	reqs, err = BuildDDLRequests(cfg, &model.DDLEvent{Type: timodel.ActionDropTable, TableInfo: tableInfo})
+	require.Nil(t, err)
+	require.Equal(t, []string{"DELETE /_index_template/tidb-test.t", "DELETE /tidb-test.t"}, paths(reqs))
+	require.True(t, reqs[0].IgnoreNotFound)
+	require.True(t, reqs[1].IgnoreNotFound)
+
+	reqs, err = BuildDDLRequests(cfg, &model.DDLEvent{
+		Type: timodel.ActionRenameTable, PreTableInfo: tableInfo, TableInfo: newTableInfo("t1", id, name),
+	})
+	require.Nil(t, err)
+	require.Equal(t, []string{
+		"PUT /_index_template/tidb-test.t1",
+		"POST /_reindex?wait_for_completion=true",
+		"DELETE /_index_template/tidb-test.t",
+		"DELETE /tidb-test.t",
+	}, paths(reqs))
+	require.JSONEq(t, `{"conflicts":"proceed","source":{"index":"tidb-test.t"},`+
+		`"dest":{"index":"tidb-test.t1","version_type":"external"}}`, string(reqs[1].Body))
+
+	// rename name to title and add a new column, the new fields are added
+	// to the existing index.
+	title := &timodel.ColumnInfo{
+		ID: 2, Name: timodel.NewCIStr("title"),
+		FieldType: newFieldType(mysql.TypeVarchar, 0, charset.CharsetUTF8MB4),
+	}
+	score := &timodel.ColumnInfo{
+		ID: 3, Name: timodel.NewCIStr("score"),
+		FieldType: newFieldType(mysql.TypeFloat, 0, ""),
+	}
+	reqs, err = BuildDDLRequests(cfg, &model.DDLEvent{
+		Type: timodel.ActionAddColumn, PreTableInfo: tableInfo, TableInfo: newTableInfo("t", id, title, score),
+	})
+	require.Nil(t, err)
+	require.Equal(t, []string{"PUT /_index_template/tidb-test.t", "PUT /tidb-test.t/_mapping"}, paths(reqs))
+	require.JSONEq(t, `{"properties":{"title":{"type":"keyword"},"score":{"type":"float"}}}`,
+		string(reqs[1].Body))
+
+	reqs, err = BuildDDLRequests(cfg, &model.DDLEvent{
+		Type: timodel.ActionDropColumn, PreTableInfo: tableInfo, TableInfo: newTableInfo("t", id),
+	})
+	require.Nil(t, err)
+	require.Equal(t, []string{"PUT /_index_template/tidb-test.t"}, paths(reqs))
+
+	reqs, err = BuildDDLRequests(cfg, &model.DDLEvent{Type: timodel.ActionAddIndex, TableInfo: tableInfo})
+	require.Nil(t, err)
+	require.Empty(t, reqs)
+
+	_, err = BuildDDLRequests(cfg, &model.DDLEvent{Type: timodel.ActionCreateTable, TableInfo: newTableInfo("t2", name)})
+	require.Regexp(t, "no primary key", err)
+}
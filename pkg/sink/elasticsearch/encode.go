@@ -0,0 +1,135 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elasticsearch
+
+import (
+	"bytes"
+
+	"github.com/pingcap/tidb/util/rowcodec"
+	"github.com/pingcap/tiflow/cdc/model"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/sink/httpsink"
+)
+
+// bulkAction is the action line of a bulk request. The commit ts is the
+// external version of the document, so the stale versions of a row written
+// again after a restart are rejected by Elasticsearch.
+type bulkAction struct {
+	Index       string `json:"_index"`
+	ID          string `json:"_id"`
+	Version     uint64 `json:"version"`
+	VersionType string `json:"version_type"`
+}
+
+// EncodeRow appends the bulk actions of the row changed event to the buffer.
+// The document of a row is identified by its handle key. A deleted row
+// deletes its document, or indexes the document marked by IsDeletedField if
+// tombstone is true, and so does the old row of an update which changes the
+// handle key.
+func EncodeRow(buf *bytes.Buffer, index string, row *model.RowChangedEvent, tombstone bool) error {
+	if row.IsDelete() {
+		return encodeDelete(buf, index, row, tombstone)
+	}
+	id, err := DocumentID(row.Table, row.Columns)
+	if err != nil {
+		return err
+	}
+	if row.IsUpdate() {
+		preID, err := DocumentID(row.Table, row.PreColumns)
+		if err != nil {
+			return err
+		}
+		if preID != id {
+			if err := encodeDelete(buf, index, row, tombstone); err != nil {
+				return err
+			}
+		}
+	}
+	return encodeIndex(buf, index, id, row.Columns, row.ColInfos, row.CommitTs, false)
+}
+
+func encodeDelete(buf *bytes.Buffer, index string, row *model.RowChangedEvent, tombstone bool) error {
+	id, err := DocumentID(row.Table, row.PreColumns)
+	if err != nil {
+		return err
+	}
+	if tombstone {
+		return encodeIndex(buf, index, id, row.PreColumns, row.ColInfos, row.CommitTs, true)
+	}
+	return writeLine(buf, map[string]bulkAction{"delete": newBulkAction(index, id, row.CommitTs)})
+}
+
+func encodeIndex(
+	buf *bytes.Buffer, index, id string, columns []*model.Column,
+	colInfos []rowcodec.ColInfo, commitTs uint64, deleted bool,
+) error {
+	doc, err := httpsink.RowValues(columns, colInfos)
+	if err != nil {
+		return cerror.WrapError(cerror.ErrElasticsearchRequestFailed, err)
+	}
+	doc[CommitTsField] = commitTs
+	doc[IsDeletedField] = deleted
+
+	if err := writeLine(buf, map[string]bulkAction{"index": newBulkAction(index, id, commitTs)}); err != nil {
+		return err
+	}
+	return writeLine(buf, doc)
+}
+
+func newBulkAction(index, id string, commitTs uint64) bulkAction {
+	return bulkAction{Index: index, ID: id, Version: commitTs, VersionType: "external_gte"}
+}
+
+func writeLine(buf *bytes.Buffer, v interface{}) error {
+	data, err := marshal(v)
+	if err != nil {
+		return err
+	}
+	buf.Write(data)
+	buf.WriteByte('\n')
+	return nil
+}
+
+// DocumentID returns the document ID of a row, which is the value of its
+// handle key, or the JSON array of the values if the handle key has
+// several columns.
+func DocumentID(table *model.TableName, columns []*model.Column) (string, error) {
+	var keys []interface{}
+	for _, col := range columns {
+		if col == nil || !col.Flag.IsHandleKey() {
+			continue
+		}
+		value, err := httpsink.ColumnValue(col, nil)
+		if err != nil {
+			return "", cerror.WrapError(cerror.ErrElasticsearchRequestFailed, err)
+		}
+		keys = append(keys, value)
+	}
+	if len(keys) == 0 {
+		return "", cerror.ErrElasticsearchUnsupportedTable.GenWithStackByArgs(
+			table.String(), "it has no primary key or not null unique key")
+	}
+	var v interface{} = keys
+	if len(keys) == 1 {
+		if s, ok := keys[0].(string); ok {
+			return s, nil
+		}
+		v = keys[0]
+	}
+	data, err := marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
@@ -0,0 +1,86 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elasticsearch
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeRow(t *testing.T) {
+	t.Parallel()
+
+	table := &model.TableName{Schema: "test", Table: "t"}
+	newColumns := func(id int64, name string) []*model.Column {
+		return []*model.Column{
+			{Name: "id", Type: mysql.TypeLonglong, Value: id, Flag: model.HandleKeyFlag | model.PrimaryKeyFlag},
+			{Name: "name", Type: mysql.TypeVarchar, Value: []byte(name)},
+		}
+	}
+
+	var buf bytes.Buffer
+	require.Nil(t, EncodeRow(&buf, "test.t", &model.RowChangedEvent{
+		CommitTs: 10, Table: table, Columns: newColumns(1, "a"),
+	}, false))
+	require.Equal(t,
+		`{"index":{"_index":"test.t","_id":"1","version":10,"version_type":"external_gte"}}`+"\n"+
+			`{"id":1,"name":"a","tidb_commit_ts":10,"tidb_is_deleted":false}`+"\n", buf.String())
+
+	// an update which changes the handle key deletes the old document.
+	buf.Reset()
+	require.Nil(t, EncodeRow(&buf, "test.t", &model.RowChangedEvent{
+		CommitTs: 11, Table: table, PreColumns: newColumns(1, "a"), Columns: newColumns(2, "a"),
+	}, false))
+	require.Equal(t,
+		`{"delete":{"_index":"test.t","_id":"1","version":11,"version_type":"external_gte"}}`+"\n"+
+			`{"index":{"_index":"test.t","_id":"2","version":11,"version_type":"external_gte"}}`+"\n"+
+			`{"id":2,"name":"a","tidb_commit_ts":11,"tidb_is_deleted":false}`+"\n", buf.String())
+
+	// a deleted row is indexed as a tombstone.
+	buf.Reset()
+	require.Nil(t, EncodeRow(&buf, "test.t", &model.RowChangedEvent{
+		CommitTs: 12, Table: table, PreColumns: newColumns(2, "a"),
+	}, true))
+	require.Equal(t,
+		`{"index":{"_index":"test.t","_id":"2","version":12,"version_type":"external_gte"}}`+"\n"+
+			`{"id":2,"name":"a","tidb_commit_ts":12,"tidb_is_deleted":true}`+"\n", buf.String())
+
+	err := EncodeRow(&buf, "test.t", &model.RowChangedEvent{
+		CommitTs: 13, Table: table, Columns: []*model.Column{{Name: "id", Type: mysql.TypeLonglong, Value: 1}},
+	}, false)
+	require.Regexp(t, "no primary key", err)
+}
+
+func TestDocumentID(t *testing.T) {
+	t.Parallel()
+
+	table := &model.TableName{Schema: "test", Table: "t"}
+	id, err := DocumentID(table, []*model.Column{
+		{Name: "k", Type: mysql.TypeVarchar, Value: []byte("a"), Flag: model.HandleKeyFlag},
+	})
+	require.Nil(t, err)
+	require.Equal(t, "a", id)
+
+	id, err = DocumentID(table, []*model.Column{
+		{Name: "a", Type: mysql.TypeLonglong, Value: int64(1), Flag: model.HandleKeyFlag},
+		{Name: "v", Type: mysql.TypeLonglong, Value: int64(2)},
+		{Name: "b", Type: mysql.TypeVarchar, Value: []byte("x"), Flag: model.HandleKeyFlag | model.BinaryFlag},
+	})
+	require.Nil(t, err)
+	require.Equal(t, `[1,"eA=="]`, id)
+}
@@ -0,0 +1,151 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elasticsearch
+
+import (
+	"strings"
+
+	"github.com/pingcap/tidb/parser/charset"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tiflow/cdc/model"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+)
+
+const (
+	// CommitTsField is the commit ts of the row, it's also the external
+	// version of the document.
+	CommitTsField = "tidb_commit_ts"
+	// IsDeletedField marks the deleted rows if delete-as-tombstone is enabled.
+	IsDeletedField = "tidb_is_deleted"
+
+	// the characters which can't be used in the index names.
+	invalidIndexChars = `\/*?"<>| ,#:`
+	// the characters which can't be the first one of the index names.
+	invalidIndexLeadingChars = "-_+."
+	// indexTemplatePriority is the priority of the index templates, which
+	// is higher than the ones of the catch-all templates.
+	indexTemplatePriority = 200
+	// dateTimeFormat accepts the DATETIME and TIMESTAMP values of any
+	// fractional seconds precision, so the mapping doesn't change with it.
+	dateTimeFormat = "yyyy-MM-dd HH:mm:ss||yyyy-MM-dd HH:mm:ss.S||yyyy-MM-dd HH:mm:ss.SS||" +
+		"yyyy-MM-dd HH:mm:ss.SSS||yyyy-MM-dd HH:mm:ss.SSSS||yyyy-MM-dd HH:mm:ss.SSSSS||" +
+		"yyyy-MM-dd HH:mm:ss.SSSSSS"
+)
+
+// IndexName returns the index of a table, which is the lowercase
+// `<prefix><schema>.<table>` with the characters invalid in the index
+// names replaced by underscores.
+func IndexName(prefix string, table model.TableName) string {
+	name := strings.Map(func(r rune) rune {
+		if strings.ContainsRune(invalidIndexChars, r) {
+			return '_'
+		}
+		return r
+	}, strings.ToLower(table.Schema+"."+table.Table))
+	if prefix == "" && strings.ContainsAny(name[:1], invalidIndexLeadingChars) {
+		name = "tidb" + name
+	}
+	return prefix + name
+}
+
+// BuildIndexTemplate builds the index template of a table, whose mappings are
+// derived from the columns of the table. The template applies to the index of
+// the table only, the index is created by the first bulk request with it.
+func BuildIndexTemplate(index string, tableInfo *model.TableInfo) ([]byte, error) {
+	properties, err := buildProperties(tableInfo)
+	if err != nil {
+		return nil, err
+	}
+	properties[CommitTsField] = map[string]interface{}{"type": "unsigned_long"}
+	properties[IsDeletedField] = map[string]interface{}{"type": "boolean"}
+
+	return marshal(map[string]interface{}{
+		"index_patterns": []string{index},
+		"priority":       indexTemplatePriority,
+		"template": map[string]interface{}{
+			"mappings": map[string]interface{}{"properties": properties},
+		},
+		"_meta": map[string]interface{}{
+			"source": tableInfo.TableName.String(),
+		},
+	})
+}
+
+// buildProperties builds the field mappings of the columns of a table.
+func buildProperties(tableInfo *model.TableInfo) (map[string]interface{}, error) {
+	properties := make(map[string]interface{}, len(tableInfo.Columns)+2)
+	hasHandleKey := false
+	for _, col := range tableInfo.Columns {
+		if !model.IsColCDCVisible(col) {
+			continue
+		}
+		properties[col.Name.O] = FieldMapping(&col.FieldType)
+		if tableInfo.ColumnsFlag[col.ID].IsHandleKey() {
+			hasHandleKey = true
+		}
+	}
+	if !hasHandleKey {
+		return nil, cerror.ErrElasticsearchUnsupportedTable.GenWithStackByArgs(
+			tableInfo.TableName.String(), "it has no primary key or not null unique key")
+	}
+	return properties, nil
+}
+
+// FieldMapping returns the Elasticsearch field mapping of a TiDB column. The
+// DECIMAL columns are mapped to double, which may lose the precision, and the
+// types without an equivalent, such as TIME, ENUM and SET, are mapped to
+// keyword. The malformed dates, such as the zero dates, are ignored.
+func FieldMapping(ft *types.FieldType) map[string]interface{} {
+	unsigned := mysql.HasUnsignedFlag(ft.GetFlag())
+	binary := ft.GetCharset() == charset.CharsetBin
+
+	var tp string
+	switch ft.GetType() {
+	case mysql.TypeTiny, mysql.TypeShort, mysql.TypeInt24, mysql.TypeLong:
+		tp = "long"
+	case mysql.TypeLonglong:
+		tp = "long"
+		if unsigned {
+			tp = "unsigned_long"
+		}
+	case mysql.TypeYear:
+		tp = "short"
+	case mysql.TypeBit:
+		tp = "unsigned_long"
+	case mysql.TypeFloat:
+		tp = "float"
+	case mysql.TypeDouble, mysql.TypeNewDecimal:
+		tp = "double"
+	case mysql.TypeDate:
+		return map[string]interface{}{"type": "date", "format": "yyyy-MM-dd", "ignore_malformed": true}
+	case mysql.TypeDatetime, mysql.TypeTimestamp:
+		return map[string]interface{}{"type": "date", "format": dateTimeFormat, "ignore_malformed": true}
+	case mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob, mysql.TypeBlob:
+		tp = "text"
+		if binary {
+			tp = "binary"
+		}
+	case mysql.TypeVarchar, mysql.TypeString, mysql.TypeVarString:
+		tp = "keyword"
+		if binary {
+			tp = "binary"
+		}
+	case mysql.TypeJSON:
+		tp = "text"
+	default:
+		tp = "keyword"
+	}
+	return map[string]interface{}{"type": tp}
+}
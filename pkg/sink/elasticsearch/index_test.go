@@ -0,0 +1,115 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elasticsearch
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb/parser/charset"
+	timodel "github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/stretchr/testify/require"
+)
+
+func newFieldType(tp byte, flag uint, charsetName string) types.FieldType {
+	ft := types.NewFieldType(tp)
+	ft.SetFlag(flag)
+	ft.SetCharset(charsetName)
+	return *ft
+}
+
+func newTableInfo(name string, columns ...*timodel.ColumnInfo) *model.TableInfo {
+	for i, col := range columns {
+		col.Offset = i
+		col.State = timodel.StatePublic
+	}
+	return model.WrapTableInfo(1, "test", 1, &timodel.TableInfo{
+		ID:         100,
+		Name:       timodel.NewCIStr(name),
+		Columns:    columns,
+		PKIsHandle: true,
+	})
+}
+
+func TestIndexName(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, "test.t", IndexName("", model.TableName{Schema: "test", Table: "t"}))
+	require.Equal(t, "tidb-test.orders", IndexName("tidb-", model.TableName{Schema: "Test", Table: "Orders"}))
+	require.Equal(t, "test.a_b_c", IndexName("", model.TableName{Schema: "test", Table: "a b*c"}))
+	require.Equal(t, "tidb_test.t", IndexName("", model.TableName{Schema: "_test", Table: "t"}))
+}
+
+func TestFieldMapping(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		ft       types.FieldType
+		expected map[string]interface{}
+	}{
+		{newFieldType(mysql.TypeTiny, 0, ""), map[string]interface{}{"type": "long"}},
+		{newFieldType(mysql.TypeLonglong, mysql.UnsignedFlag, ""), map[string]interface{}{"type": "unsigned_long"}},
+		{newFieldType(mysql.TypeYear, 0, ""), map[string]interface{}{"type": "short"}},
+		{newFieldType(mysql.TypeNewDecimal, 0, ""), map[string]interface{}{"type": "double"}},
+		{newFieldType(mysql.TypeFloat, 0, ""), map[string]interface{}{"type": "float"}},
+		{
+			newFieldType(mysql.TypeDate, 0, ""),
+			map[string]interface{}{"type": "date", "format": "yyyy-MM-dd", "ignore_malformed": true},
+		},
+		{
+			newFieldType(mysql.TypeTimestamp, 0, ""),
+			map[string]interface{}{"type": "date", "format": dateTimeFormat, "ignore_malformed": true},
+		},
+		{newFieldType(mysql.TypeVarchar, 0, charset.CharsetUTF8MB4), map[string]interface{}{"type": "keyword"}},
+		{newFieldType(mysql.TypeVarchar, mysql.BinaryFlag, charset.CharsetBin), map[string]interface{}{"type": "binary"}},
+		{newFieldType(mysql.TypeBlob, 0, charset.CharsetUTF8MB4), map[string]interface{}{"type": "text"}},
+		{newFieldType(mysql.TypeJSON, 0, ""), map[string]interface{}{"type": "text"}},
+		{newFieldType(mysql.TypeDuration, 0, ""), map[string]interface{}{"type": "keyword"}},
+		{newFieldType(mysql.TypeEnum, 0, ""), map[string]interface{}{"type": "keyword"}},
+	}
+	for _, tc := range testCases {
+		require.Equal(t, tc.expected, FieldMapping(&tc.ft))
+	}
+}
+
+func TestBuildIndexTemplate(t *testing.T) {
+	t.Parallel()
+
+	id := &timodel.ColumnInfo{
+		ID: 1, Name: timodel.NewCIStr("id"),
+		FieldType: newFieldType(mysql.TypeLong, mysql.NotNullFlag|mysql.PriKeyFlag, ""),
+	}
+	name := &timodel.ColumnInfo{
+		ID: 2, Name: timodel.NewCIStr("name"),
+		FieldType: newFieldType(mysql.TypeVarchar, 0, charset.CharsetUTF8MB4),
+	}
+	template, err := BuildIndexTemplate("test.t", newTableInfo("t", id, name))
+	require.Nil(t, err)
+	require.JSONEq(t, `{
+		"index_patterns": ["test.t"],
+		"priority": 200,
+		"template": {"mappings": {"properties": {
+			"id": {"type": "long"},
+			"name": {"type": "keyword"},
+			"tidb_commit_ts": {"type": "unsigned_long"},
+			"tidb_is_deleted": {"type": "boolean"}
+		}}},
+		"_meta": {"source": "test.t"}
+	}`, string(template))
+
+	_, err = BuildIndexTemplate("test.t2", newTableInfo("t2", name))
+	require.Regexp(t, "no primary key", err)
+}
@@ -0,0 +1,202 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package httpsink
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/config"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/httputil"
+	"github.com/pingcap/tiflow/pkg/proxy"
+	"github.com/pingcap/tiflow/pkg/security"
+	"go.uber.org/zap"
+)
+
+const (
+	// DefaultFlushInterval is the default value of flush-interval.
+	DefaultFlushInterval = time.Second
+	// the lower limit of flush-interval.
+	minFlushInterval = 100 * time.Millisecond
+	// the upper limit of flush-interval.
+	maxFlushInterval = time.Minute
+	// defaultRequestTimeout is the timeout of a request to the sink.
+	defaultRequestTimeout = 2 * time.Minute
+)
+
+// Config is the common configuration of the sinks which write the batches
+// of rows to a database through its HTTP API.
+type Config struct {
+	// Endpoint is the URL of the HTTP API.
+	Endpoint string
+	User     string
+	Password string
+	// BatchSize is the max number of the rows written in a request.
+	BatchSize int
+	// FlushInterval is the max interval to write the buffered rows.
+	FlushInterval time.Duration
+	// Credential is the TLS credential to connect to the sink, it's nil if
+	// the default one of the system is used.
+	Credential *security.Credential
+	// Proxy is the proxy to connect to the sink, it's nil if no proxy is
+	// used.
+	Proxy *url.URL
+	// UserAgent is the User-Agent of the requests, the default one of Go is
+	// used if it's empty.
+	UserAgent string
+}
+
+// Options describes the sink URI of a sink.
+type Options struct {
+	// Name is the name of the sink in the error messages.
+	Name string
+	// Scheme connects to the sink by HTTP.
+	Scheme string
+	// SSLScheme connects to the sink by HTTPS.
+	SSLScheme string
+	// BatchSizeParam is the parameter of BatchSize.
+	BatchSizeParam string
+	// MaxBatchSize is the upper limit of BatchSize.
+	MaxBatchSize int
+	// ErrInvalidConfig is the error of an invalid sink URI.
+	ErrInvalidConfig *errors.Error
+}
+
+// Apply applies the common sink URI parameters to the config.
+func (c *Config) Apply(sinkURI *url.URL, opts Options) error {
+	if sinkURI == nil {
+		return opts.ErrInvalidConfig.GenWithStack(
+			"failed to open %s sink, empty SinkURI", opts.Name)
+	}
+
+	scheme := strings.ToLower(sinkURI.Scheme)
+	if scheme != opts.Scheme && scheme != opts.SSLScheme {
+		return opts.ErrInvalidConfig.GenWithStack(
+			"can't create %s sink with unsupported scheme: %s", opts.Name, scheme)
+	}
+	if sinkURI.Host == "" {
+		return opts.ErrInvalidConfig.GenWithStack(
+			"the host of the %s sink is empty", opts.Name)
+	}
+	httpScheme := "http"
+	if scheme == opts.SSLScheme {
+		httpScheme = "https"
+	}
+	c.Endpoint = (&url.URL{Scheme: httpScheme, Host: sinkURI.Host}).String()
+	c.User = sinkURI.User.Username()
+	c.Password, _ = sinkURI.User.Password()
+
+	query := sinkURI.Query()
+	if err := getBatchSize(query, opts, &c.BatchSize); err != nil {
+		return err
+	}
+	if err := getFlushInterval(query, opts, &c.FlushInterval); err != nil {
+		return err
+	}
+	proxyURL, err := proxy.FromSinkURI(sinkURI)
+	if err != nil {
+		return cerror.WrapError(opts.ErrInvalidConfig, err)
+	}
+	c.Proxy = proxyURL
+	if query.Get("ssl-ca") != "" {
+		c.Credential = &security.Credential{
+			CAPath:   query.Get("ssl-ca"),
+			CertPath: query.Get("ssl-cert"),
+			KeyPath:  query.Get("ssl-key"),
+		}
+	}
+	return nil
+}
+
+// ApplyClientIdentity sets the User-Agent by the client identity of the
+// changefeed, it's kept unchanged if the identity is nil.
+func (c *Config) ApplyClientIdentity(
+	identity *config.ClientIdentityConfig, changefeedID model.ChangeFeedID, capture string,
+) {
+	if identity == nil {
+		return
+	}
+	c.UserAgent = config.ExpandClientIdentity(identity.HTTPUserAgent,
+		changefeedID.Namespace, changefeedID.ID, capture)
+}
+
+// NewClient creates the HTTP client connecting to the sink.
+func (c *Config) NewClient() (*httputil.Client, error) {
+	cli, err := httputil.NewClient(c.Credential)
+	if err != nil {
+		return nil, err
+	}
+	cli.SetTimeout(defaultRequestTimeout)
+	if c.Proxy != nil {
+		cli.SetProxy(c.Proxy)
+	}
+	cli.SetUserAgent(c.UserAgent)
+	return cli, nil
+}
+
+func getBatchSize(values url.Values, opts Options, batchSize *int) error {
+	s := values.Get(opts.BatchSizeParam)
+	if len(s) == 0 {
+		return nil
+	}
+
+	c, err := strconv.Atoi(s)
+	if err != nil {
+		return cerror.WrapError(opts.ErrInvalidConfig, err)
+	}
+	if c <= 0 {
+		return cerror.WrapError(opts.ErrInvalidConfig,
+			fmt.Errorf("invalid %s %d, it must be greater than 0", opts.BatchSizeParam, c))
+	}
+	if c > opts.MaxBatchSize {
+		log.Warn(opts.BatchSizeParam+" is too large",
+			zap.Int("original", c), zap.Int("override", opts.MaxBatchSize))
+		c = opts.MaxBatchSize
+	}
+
+	*batchSize = c
+	return nil
+}
+
+func getFlushInterval(values url.Values, opts Options, flushInterval *time.Duration) error {
+	s := values.Get("flush-interval")
+	if len(s) == 0 {
+		return nil
+	}
+
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return cerror.WrapError(opts.ErrInvalidConfig, err)
+	}
+	if d > maxFlushInterval {
+		log.Warn("flush-interval is too large", zap.Duration("original", d),
+			zap.Duration("override", maxFlushInterval))
+		d = maxFlushInterval
+	}
+	if d < minFlushInterval {
+		log.Warn("flush-interval is too small", zap.Duration("original", d),
+			zap.Duration("override", minFlushInterval))
+		d = minFlushInterval
+	}
+
+	*flushInterval = d
+	return nil
+}
@@ -0,0 +1,96 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package httpsink
+
+import (
+	"encoding/base64"
+	"encoding/json"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/rowcodec"
+	"github.com/pingcap/tiflow/cdc/model"
+)
+
+// RowValues returns the JSON values of the columns keyed by the column names.
+// The map has room for the meta columns added by the sinks.
+func RowValues(columns []*model.Column, colInfos []rowcodec.ColInfo) (map[string]interface{}, error) {
+	values := make(map[string]interface{}, len(columns)+2)
+	for i, col := range columns {
+		// column could be nil in a condition described in
+		// https://github.com/pingcap/tiflow/issues/6198#issuecomment-1191132951
+		if col == nil {
+			continue
+		}
+		var ft *types.FieldType
+		if i < len(colInfos) {
+			ft = colInfos[i].Ft
+		}
+		value, err := ColumnValue(col, ft)
+		if err != nil {
+			return nil, err
+		}
+		values[col.Name] = value
+	}
+	return values, nil
+}
+
+// ColumnValue converts the value of a column to its JSON value. The binary
+// strings are encoded in base64 because JSON can't carry them, the decimals
+// keep their precision, and the enums and sets are converted to their names
+// if the field type is given.
+func ColumnValue(col *model.Column, ft *types.FieldType) (interface{}, error) {
+	if col.Value == nil {
+		return nil, nil
+	}
+
+	switch col.Type {
+	case mysql.TypeVarchar, mysql.TypeString, mysql.TypeVarString, mysql.TypeTinyBlob,
+		mysql.TypeMediumBlob, mysql.TypeLongBlob, mysql.TypeBlob:
+		v, ok := col.Value.([]byte)
+		if !ok {
+			return col.Value, nil
+		}
+		if col.Flag.IsBinary() {
+			return base64.StdEncoding.EncodeToString(v), nil
+		}
+		return string(v), nil
+	case mysql.TypeNewDecimal:
+		if v, ok := col.Value.(string); ok {
+			return json.Number(v), nil
+		}
+		return col.Value, nil
+	case mysql.TypeEnum:
+		if _, ok := col.Value.(string); ok || ft == nil {
+			return col.Value, nil
+		}
+		enumVar, err := types.ParseEnumValue(ft.GetElems(), col.Value.(uint64))
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return enumVar.Name, nil
+	case mysql.TypeSet:
+		if _, ok := col.Value.(string); ok || ft == nil {
+			return col.Value, nil
+		}
+		setVar, err := types.ParseSetValue(ft.GetElems(), col.Value.(uint64))
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return setVar.Name, nil
+	default:
+		return col.Value, nil
+	}
+}
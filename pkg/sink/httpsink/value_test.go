@@ -0,0 +1,67 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package httpsink
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/rowcodec"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRowValues(t *testing.T) {
+	t.Parallel()
+
+	enumType := types.NewFieldType(mysql.TypeEnum)
+	enumType.SetElems([]string{"a", "b"})
+	setType := types.NewFieldType(mysql.TypeSet)
+	setType.SetElems([]string{"a", "b"})
+	colInfos := []rowcodec.ColInfo{{ID: 1}, {ID: 2}, {ID: 3}, {ID: 4, Ft: enumType}, {ID: 5, Ft: setType}}
+	columns := []*model.Column{
+		{Name: "id", Type: mysql.TypeLonglong, Value: int64(1)},
+		{Name: "name", Type: mysql.TypeVarchar, Value: []byte("abc")},
+		nil,
+		{Name: "kind", Type: mysql.TypeEnum, Value: uint64(2)},
+		{Name: "tags", Type: mysql.TypeSet, Value: uint64(3)},
+		{Name: "data", Type: mysql.TypeBlob, Value: []byte("abc"), Flag: model.BinaryFlag},
+		{Name: "price", Type: mysql.TypeNewDecimal, Value: "12.30"},
+		{Name: "note", Type: mysql.TypeVarchar, Value: nil},
+	}
+	values, err := RowValues(columns, colInfos)
+	require.Nil(t, err)
+	require.Equal(t, map[string]interface{}{
+		"id":    int64(1),
+		"name":  "abc",
+		"kind":  "b",
+		"tags":  "a,b",
+		"data":  "YWJj",
+		"price": json.Number("12.30"),
+		"note":  nil,
+	}, values)
+
+	// the enum value out of the elements is an error.
+	_, err = RowValues([]*model.Column{
+		{Name: "kind", Type: mysql.TypeEnum, Value: uint64(3)},
+	}, colInfos[3:])
+	require.NotNil(t, err)
+
+	// the enum value is kept without the field type.
+	value, err := ColumnValue(&model.Column{Type: mysql.TypeEnum, Value: uint64(2)}, nil)
+	require.Nil(t, err)
+	require.Equal(t, uint64(2), value)
+}
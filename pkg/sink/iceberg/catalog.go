@@ -0,0 +1,186 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iceberg
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/pingcap/errors"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/httputil"
+)
+
+// Catalog is a client of the Iceberg REST catalog. The catalog commits the
+// changes of a table atomically, and rejects a commit if the table has been
+// changed since the metadata the commit is based on, so the writers of a
+// table, such as the captures replicating the partitions of a TiDB table,
+// never overwrite the commits of each other.
+type Catalog struct {
+	uri    string
+	client *httputil.Client
+}
+
+// NewCatalog creates a client of the REST catalog of the URI, the paths
+// like "/v1/namespaces" are appended to it.
+func NewCatalog(uri string) (*Catalog, error) {
+	client, err := httputil.NewClient(nil)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &Catalog{uri: strings.TrimRight(uri, "/"), client: client}, nil
+}
+
+type loadTableResult struct {
+	MetadataLocation string    `json:"metadata-location"`
+	Metadata         *Metadata `json:"metadata"`
+}
+
+// loadTable loads the metadata of the table, it returns nil if the table
+// doesn't exist.
+func (c *Catalog) loadTable(ctx context.Context, namespace, name string) (*Metadata, error) {
+	path := c.tablePath(namespace, name)
+	status, body, err := c.request(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+	if status == http.StatusNotFound {
+		return nil, nil
+	}
+	return metadataOf(http.MethodGet, path, status, body)
+}
+
+// createTable creates the table of the format version 1 in the namespace,
+// the namespace is created if it doesn't exist. If the table is created by
+// another writer at the same time, the table is loaded.
+func (c *Catalog) createTable(
+	ctx context.Context,
+	namespace, name, location string,
+	schema *Schema,
+	properties map[string]string,
+) (*Metadata, error) {
+	status, body, err := c.request(ctx, http.MethodPost, "/v1/namespaces", map[string]interface{}{
+		"namespace":  []string{namespace},
+		"properties": map[string]string{},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if status != http.StatusConflict && status/100 != 2 {
+		return nil, statusError(http.MethodPost, "/v1/namespaces", status, body)
+	}
+
+	props := map[string]string{"format-version": "1"}
+	for k, v := range properties {
+		props[k] = v
+	}
+	path := "/v1/namespaces/" + url.PathEscape(namespace) + "/tables"
+	status, body, err = c.request(ctx, http.MethodPost, path, map[string]interface{}{
+		"name":       name,
+		"location":   location,
+		"schema":     schema,
+		"properties": props,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if status == http.StatusConflict {
+		return c.loadTable(ctx, namespace, name)
+	}
+	return metadataOf(http.MethodPost, path, status, body)
+}
+
+// commitTable commits the updates to the table if the table meets the
+// requirements, it returns ErrIcebergCommitConflict if it doesn't. See the
+// UpdateRequirement and TableUpdate of the REST catalog specification for
+// the requirements and updates.
+func (c *Catalog) commitTable(
+	ctx context.Context,
+	namespace, name string,
+	requirements []map[string]interface{},
+	updates []map[string]interface{},
+) (*Metadata, error) {
+	path := c.tablePath(namespace, name)
+	status, body, err := c.request(ctx, http.MethodPost, path, map[string]interface{}{
+		"requirements": requirements,
+		"updates":      updates,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if status == http.StatusConflict {
+		return nil, cerror.ErrIcebergCommitConflict.GenWithStackByArgs(namespace + "." + name)
+	}
+	return metadataOf(http.MethodPost, path, status, body)
+}
+
+func (c *Catalog) tablePath(namespace, name string) string {
+	return "/v1/namespaces/" + url.PathEscape(namespace) + "/tables/" + url.PathEscape(name)
+}
+
+func metadataOf(method, path string, status int, body []byte) (*Metadata, error) {
+	if status/100 != 2 {
+		return nil, statusError(method, path, status, body)
+	}
+	var result loadTableResult
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, cerror.WrapError(cerror.ErrIcebergCatalogError, err)
+	}
+	if result.Metadata == nil {
+		return nil, cerror.ErrIcebergCatalogError.GenWithStack(
+			"no metadata in the response of the catalog: %s", body)
+	}
+	return result.Metadata, nil
+}
+
+// request sends the request to the catalog, and returns the status code and
+// the body of the response. The requests are not retried, because whether
+// a failed commit has been applied is unknown.
+func (c *Catalog) request(
+	ctx context.Context, method, path string, payload interface{},
+) (int, []byte, error) {
+	var reader io.Reader
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return 0, nil, cerror.WrapError(cerror.ErrIcebergCatalogError, err)
+		}
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.uri+path, reader)
+	if err != nil {
+		return 0, nil, cerror.WrapError(cerror.ErrIcebergCatalogError, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return 0, nil, cerror.WrapError(cerror.ErrIcebergCatalogError, err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, nil, cerror.WrapError(cerror.ErrIcebergCatalogError, err)
+	}
+	return resp.StatusCode, body, nil
+}
+
+func statusError(method, path string, status int, body []byte) error {
+	return cerror.ErrIcebergCatalogError.GenWithStack(
+		"the catalog responded to %s %s with HTTP status %d: %s", method, path, status, body)
+}
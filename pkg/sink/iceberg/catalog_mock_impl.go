@@ -0,0 +1,229 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iceberg
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// MockCatalog is an in-memory REST catalog for the tests, which checks the
+// requirements of the commits like a real catalog.
+type MockCatalog struct {
+	mu         sync.Mutex
+	namespaces map[string]struct{}
+	tables     map[string]*Metadata
+	commits    int
+	conflicts  int
+}
+
+// NewMockCatalog creates a MockCatalog, serve it by httptest.NewServer.
+func NewMockCatalog() *MockCatalog {
+	return &MockCatalog{
+		namespaces: make(map[string]struct{}),
+		tables:     make(map[string]*Metadata),
+	}
+}
+
+type mockRequirement struct {
+	Type            string `json:"type"`
+	UUID            string `json:"uuid"`
+	Ref             string `json:"ref"`
+	SnapshotID      *int64 `json:"snapshot-id"`
+	CurrentSchemaID int    `json:"current-schema-id"`
+}
+
+type mockUpdate struct {
+	Action       string            `json:"action"`
+	Snapshot     *Snapshot         `json:"snapshot"`
+	RefName      string            `json:"ref-name"`
+	SnapshotID   int64             `json:"snapshot-id"`
+	Schema       *Schema           `json:"schema"`
+	LastColumnID int               `json:"last-column-id"`
+	SchemaID     int               `json:"schema-id"`
+	Updates      map[string]string `json:"updates"`
+}
+
+// ServeHTTP implements the http.Handler interface.
+func (c *MockCatalog) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var parts []string
+	for _, p := range strings.Split(strings.Trim(r.URL.EscapedPath(), "/"), "/") {
+		p, _ = url.PathUnescape(p)
+		parts = append(parts, p)
+	}
+	switch {
+	case len(parts) == 2 && r.Method == http.MethodPost:
+		var req struct {
+			Namespace []string `json:"namespace"`
+		}
+		if json.NewDecoder(r.Body).Decode(&req) != nil || len(req.Namespace) != 1 {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if _, ok := c.namespaces[req.Namespace[0]]; ok {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+		c.namespaces[req.Namespace[0]] = struct{}{}
+		_ = json.NewEncoder(w).Encode(req)
+	case len(parts) == 4 && r.Method == http.MethodPost:
+		var req struct {
+			Name       string            `json:"name"`
+			Location   string            `json:"location"`
+			Schema     *Schema           `json:"schema"`
+			Properties map[string]string `json:"properties"`
+		}
+		if json.NewDecoder(r.Body).Decode(&req) != nil || req.Schema == nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if _, ok := c.namespaces[parts[2]]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		key := parts[2] + "." + req.Name
+		if _, ok := c.tables[key]; ok {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+		c.tables[key] = &Metadata{
+			FormatVersion:     formatVersion,
+			TableUUID:         uuid.New().String(),
+			Location:          req.Location,
+			LastColumnID:      req.Schema.lastColumnID(),
+			Schemas:           []*Schema{req.Schema},
+			CurrentSchemaID:   req.Schema.SchemaID,
+			Properties:        req.Properties,
+			CurrentSnapshotID: -1,
+			Snapshots:         []*Snapshot{},
+			Refs:              map[string]*SnapshotRef{},
+		}
+		c.respond(w, c.tables[key])
+	case len(parts) == 5 && r.Method == http.MethodGet:
+		metadata, ok := c.tables[parts[2]+"."+parts[4]]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		c.respond(w, metadata)
+	case len(parts) == 5 && r.Method == http.MethodPost:
+		metadata, ok := c.tables[parts[2]+"."+parts[4]]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		var req struct {
+			Requirements []*mockRequirement `json:"requirements"`
+			Updates      []*mockUpdate      `json:"updates"`
+		}
+		if json.NewDecoder(r.Body).Decode(&req) != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		for _, requirement := range req.Requirements {
+			if !metadata.meet(requirement) {
+				c.conflicts++
+				w.WriteHeader(http.StatusConflict)
+				return
+			}
+		}
+		metadata = metadata.clone()
+		for _, update := range req.Updates {
+			metadata.apply(update)
+		}
+		c.tables[parts[2]+"."+parts[4]] = metadata
+		c.commits++
+		c.respond(w, metadata)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+// Commits returns the number of the successful commits.
+func (c *MockCatalog) Commits() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.commits
+}
+
+// Conflicts returns the number of the commits rejected by the requirements.
+func (c *MockCatalog) Conflicts() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.conflicts
+}
+
+func (c *MockCatalog) respond(w http.ResponseWriter, metadata *Metadata) {
+	_ = json.NewEncoder(w).Encode(&loadTableResult{Metadata: metadata})
+}
+
+func (m *Metadata) meet(r *mockRequirement) bool {
+	switch r.Type {
+	case "assert-table-uuid":
+		return r.UUID == m.TableUUID
+	case "assert-current-schema-id":
+		return r.CurrentSchemaID == m.CurrentSchemaID
+	case "assert-ref-snapshot-id":
+		ref, ok := m.Refs[r.Ref]
+		if r.SnapshotID == nil {
+			return !ok
+		}
+		return ok && ref.SnapshotID == *r.SnapshotID
+	}
+	return false
+}
+
+func (m *Metadata) apply(u *mockUpdate) {
+	switch u.Action {
+	case "add-snapshot":
+		m.Snapshots = append(m.Snapshots, u.Snapshot)
+	case "set-snapshot-ref":
+		m.Refs[u.RefName] = &SnapshotRef{SnapshotID: u.SnapshotID, Type: "branch"}
+		if u.RefName == mainBranch {
+			m.CurrentSnapshotID = u.SnapshotID
+		}
+	case "add-schema":
+		m.Schemas = append(m.Schemas, u.Schema)
+		m.LastColumnID = u.LastColumnID
+	case "set-current-schema":
+		m.CurrentSchemaID = u.SchemaID
+	case "set-properties":
+		for k, v := range u.Updates {
+			m.Properties[k] = v
+		}
+	}
+}
+
+func (m *Metadata) clone() *Metadata {
+	c := *m
+	c.Schemas = append([]*Schema(nil), m.Schemas...)
+	c.Snapshots = append([]*Snapshot(nil), m.Snapshots...)
+	c.Properties = make(map[string]string, len(m.Properties))
+	for k, v := range m.Properties {
+		c.Properties[k] = v
+	}
+	c.Refs = make(map[string]*SnapshotRef, len(m.Refs))
+	for k, v := range m.Refs {
+		c.Refs[k] = v
+	}
+	return &c
+}
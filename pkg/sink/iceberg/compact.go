@@ -0,0 +1,211 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iceberg
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strconv"
+
+	"github.com/linkedin/goavro/v2"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// minCompactFiles is the min number of the small data files of a table which
+// triggers a compaction.
+const minCompactFiles = 10
+
+// compact rewrites the small data files of the current snapshot into a file
+// of about the target size, and commits it as a replace snapshot, which
+// doesn't change the rows of the table. It's skipped if the rows are written
+// with a schema other than the current one, since the rewritten rows would
+// lose the fields not in the schema, and it gives up if the table is changed
+// by a concurrent commit. The replaced files are still referenced by the
+// previous snapshots, they are deleted by the snapshot expiration of
+// Iceberg, and the files of the failed commits are deleted by the orphan
+// file removal of Iceberg.
+func (t *Table) compact(ctx context.Context) error {
+	if !sameFields(t.metadata.currentSchema(), t.schema) {
+		return nil
+	}
+	entries, err := t.readEntries(ctx, t.manifests)
+	if err != nil {
+		return err
+	}
+	var (
+		small, others []*manifestEntry
+		smallSize     int64
+	)
+	for _, e := range entries {
+		if e.fileSize < t.targetFileSize && smallSize < t.targetFileSize {
+			small = append(small, e)
+			smallSize += e.fileSize
+		} else {
+			others = append(others, e)
+		}
+	}
+	if len(small) < minCompactFiles {
+		return nil
+	}
+
+	var (
+		records     []interface{}
+		deletedRows int64
+	)
+	for _, e := range small {
+		data, err := t.storage.ReadFile(ctx, t.relPath(e.filePath))
+		if err != nil {
+			return errors.Trace(err)
+		}
+		rs, err := t.readDataFile(data)
+		if err != nil {
+			return err
+		}
+		records = append(records, rs...)
+		deletedRows += e.recordCount
+	}
+	data, err := encodeOCF(t.codec, records, nil)
+	if err != nil {
+		return err
+	}
+	dataPath := t.newDataFilePath()
+	if err := t.storage.WriteFile(ctx, dataPath, data); err != nil {
+		return errors.Trace(err)
+	}
+
+	snapshotID := newSnapshotID()
+	newEntries := []*manifestEntry{{
+		status:      entryStatusAdded,
+		snapshotID:  snapshotID,
+		filePath:    t.location(dataPath),
+		recordCount: int64(len(records)),
+		fileSize:    int64(len(data)),
+	}}
+	for _, e := range small {
+		e.status, e.snapshotID = entryStatusDeleted, snapshotID
+		newEntries = append(newEntries, e)
+	}
+	newEntries = append(newEntries, others...)
+	manifest, err := t.writeManifest(ctx, snapshotID, newEntries)
+	if err != nil {
+		return err
+	}
+	err = t.commitSnapshot(ctx, snapshotID, []*manifestFile{manifest}, map[string]string{
+		"operation":          "replace",
+		"added-data-files":   "1",
+		"deleted-data-files": strconv.Itoa(len(small)),
+		"added-records":      strconv.Itoa(len(records)),
+		"deleted-records":    strconv.FormatInt(deletedRows, 10),
+		"added-files-size":   strconv.Itoa(len(data)),
+	})
+	if cerror.ErrIcebergCommitConflict.Equal(err) {
+		log.Info("Iceberg table compaction conflicts with a concurrent commit, skip it",
+			zap.String("table", t.dir))
+		if err := t.storage.DeleteFile(ctx, dataPath); err != nil {
+			log.Warn("Failed to delete the compacted data file",
+				zap.String("path", dataPath), zap.Error(err))
+		}
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	log.Info("Iceberg table compacted",
+		zap.String("table", t.dir),
+		zap.Int("files", len(small)),
+		zap.Int("records", len(records)))
+	return nil
+}
+
+// readDataFile reads the records of a data file, and converts them to the
+// records of the schema the rows are written with. The fields are matched
+// by the field IDs in the Avro schema of the file.
+func (t *Table) readDataFile(data []byte) ([]interface{}, error) {
+	r, err := goavro.NewOCFReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrIcebergEncodeFailed, err)
+	}
+	var fileSchema struct {
+		Fields []struct {
+			Name    string `json:"name"`
+			FieldID int    `json:"field-id"`
+		} `json:"fields"`
+	}
+	if err := json.Unmarshal([]byte(r.Codec().Schema()), &fileSchema); err != nil {
+		return nil, cerror.WrapError(cerror.ErrIcebergEncodeFailed, err)
+	}
+	names := make(map[int]string, len(fileSchema.Fields))
+	for _, f := range fileSchema.Fields {
+		names[f.FieldID] = f.Name
+	}
+
+	var records []interface{}
+	for r.Scan() {
+		datum, err := r.Read()
+		if err != nil {
+			return nil, cerror.WrapError(cerror.ErrIcebergEncodeFailed, err)
+		}
+		record, _ := datum.(map[string]interface{})
+		converted := make(map[string]interface{}, len(t.schema.Fields))
+		for _, f := range t.schema.Fields {
+			var value interface{}
+			if name, ok := names[f.ID]; ok {
+				value = record[name]
+			}
+			if converted[f.avroName], err = convertValue(f, value); err != nil {
+				return nil, err
+			}
+		}
+		records = append(records, converted)
+	}
+	if err := r.Err(); err != nil {
+		return nil, cerror.WrapError(cerror.ErrIcebergEncodeFailed, err)
+	}
+	return records, nil
+}
+
+// convertValue converts an Avro value read from a data file to the value of
+// the field, whose type may have been promoted since the file is written.
+func convertValue(f *Field, value interface{}) (interface{}, error) {
+	if f.Required {
+		if value == nil {
+			return nil, cerror.ErrIcebergEncodeFailed.GenWithStack(
+				"the required field %s is missing", f.Name)
+		}
+		return value, nil
+	}
+	// the values of the optional fields are decoded as the unions.
+	union, ok := value.(map[string]interface{})
+	if !ok || len(union) != 1 {
+		return nil, nil
+	}
+	for _, v := range union {
+		value = v
+	}
+	switch v := value.(type) {
+	case int32:
+		if f.Type == "long" {
+			value = int64(v)
+		}
+	case float32:
+		if f.Type == "double" {
+			value = float64(v)
+		}
+	}
+	return goavro.Union(avroUnionName(f.Type), value), nil
+}
@@ -0,0 +1,202 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iceberg
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"time"
+
+	"github.com/linkedin/goavro/v2"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tiflow/cdc/model"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+)
+
+const (
+	dateLayout     = "2006-01-02"
+	dateTimeLayout = "2006-01-02 15:04:05.999999999"
+)
+
+// encodeDataFile encodes the row changed events in an Avro data file.
+func encodeDataFile(codec *goavro.Codec, schema *Schema, rows []*model.RowChangedEvent) ([]byte, error) {
+	records := make([]interface{}, 0, len(rows))
+	for _, row := range rows {
+		record, err := encodeRow(schema, row)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+
+	return encodeOCF(codec, records, nil)
+}
+
+// encodeRow encodes a row changed event to an Avro record. An update is
+// encoded as its new row and a delete is encoded as its old row, OpField
+// tells them apart.
+func encodeRow(schema *Schema, row *model.RowChangedEvent) (map[string]interface{}, error) {
+	op, columns := "I", row.Columns
+	if row.IsDelete() {
+		op, columns = "D", row.PreColumns
+	} else if row.IsUpdate() {
+		op = "U"
+	}
+	values := make(map[string]*model.Column, len(columns))
+	for _, col := range columns {
+		// column could be nil in a condition described in
+		// https://github.com/pingcap/tiflow/issues/6198#issuecomment-1191132951
+		if col != nil {
+			values[col.Name] = col
+		}
+	}
+
+	record := make(map[string]interface{}, len(schema.Fields))
+	for _, f := range schema.Fields {
+		switch f.Name {
+		case OpField:
+			record[f.avroName] = op
+			continue
+		case CommitTsField:
+			record[f.avroName] = int64(row.CommitTs)
+			continue
+		}
+		var value interface{}
+		if col, ok := values[f.Name]; ok && col.Value != nil {
+			var err error
+			value, err = avroValue(f, col.Value)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if value == nil {
+			record[f.avroName] = nil
+		} else {
+			record[f.avroName] = goavro.Union(avroUnionName(f.Type), value)
+		}
+	}
+	return record, nil
+}
+
+// avroValue converts the value of a column to the Avro value of its field.
+// The malformed dates, such as the zero dates, are converted to null.
+func avroValue(f *Field, value interface{}) (interface{}, error) {
+	switch f.Type {
+	case "int":
+		n, err := toInt64(value)
+		return int32(n), err
+	case "long":
+		return toInt64(value)
+	case "float":
+		switch v := value.(type) {
+		case float32:
+			return v, nil
+		case float64:
+			return float32(v), nil
+		}
+	case "double":
+		switch v := value.(type) {
+		case float32:
+			return float64(v), nil
+		case float64:
+			return v, nil
+		}
+	case "date", "timestamp":
+		layout := dateTimeLayout
+		if f.Type == "date" {
+			layout = dateLayout
+		}
+		t, err := time.Parse(layout, fmt.Sprint(value))
+		if err != nil {
+			return nil, nil
+		}
+		return t, nil
+	case "binary":
+		switch v := value.(type) {
+		case []byte:
+			return v, nil
+		case string:
+			return []byte(v), nil
+		}
+	case "string":
+		return stringValue(f.ft, value)
+	default:
+		// decimal
+		var r *big.Rat
+		switch v := value.(type) {
+		case uint64:
+			r = new(big.Rat).SetUint64(v)
+		case int64:
+			r = new(big.Rat).SetInt64(v)
+		default:
+			var ok bool
+			r, ok = new(big.Rat).SetString(fmt.Sprint(v))
+			if !ok {
+				return nil, cerror.ErrIcebergEncodeFailed.GenWithStack(
+					"invalid decimal value %v of column %s", v, f.Name)
+			}
+		}
+		return r, nil
+	}
+	return nil, cerror.ErrIcebergEncodeFailed.GenWithStack(
+		"unexpected value %v(%T) of column %s with type %s", value, value, f.Name, f.Type)
+}
+
+func toInt64(value interface{}) (int64, error) {
+	switch v := value.(type) {
+	case int64:
+		return v, nil
+	case uint64:
+		return int64(v), nil
+	case string:
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return 0, cerror.WrapError(cerror.ErrIcebergEncodeFailed, err)
+		}
+		return n, nil
+	}
+	return 0, cerror.ErrIcebergEncodeFailed.GenWithStack("unexpected integer value %v(%T)", value, value)
+}
+
+// stringValue converts a value to a string, the ENUM and SET values are
+// converted to their names.
+func stringValue(ft *types.FieldType, value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case []byte:
+		return string(v), nil
+	case uint64:
+		if ft == nil {
+			break
+		}
+		switch ft.GetType() {
+		case mysql.TypeEnum:
+			enumVar, err := types.ParseEnumValue(ft.GetElems(), v)
+			if err != nil {
+				return nil, cerror.WrapError(cerror.ErrIcebergEncodeFailed, err)
+			}
+			return enumVar.Name, nil
+		case mysql.TypeSet:
+			setVar, err := types.ParseSetValue(ft.GetElems(), v)
+			if err != nil {
+				return nil, cerror.WrapError(cerror.ErrIcebergEncodeFailed, err)
+			}
+			return setVar.Name, nil
+		}
+	}
+	return fmt.Sprint(value), nil
+}
@@ -0,0 +1,95 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iceberg
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/linkedin/goavro/v2"
+	"github.com/pingcap/tidb/parser/charset"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeDataFile(t *testing.T) {
+	t.Parallel()
+
+	price := newColumnInfo("price", mysql.TypeNewDecimal, 0, "")
+	price.SetFlen(10)
+	price.SetDecimal(2)
+	status := newColumnInfo("status", mysql.TypeEnum, 0, charset.CharsetUTF8MB4)
+	status.SetElems([]string{"open", "closed"})
+	tableInfo := newTableInfo(
+		newColumnInfo("id", mysql.TypeLonglong, mysql.PriKeyFlag|mysql.NotNullFlag, ""),
+		price,
+		newColumnInfo("d", mysql.TypeDate, 0, ""),
+		newColumnInfo("ts", mysql.TypeDatetime, 0, ""),
+		status,
+	)
+	schema := NewSchema(tableInfo)
+	avroSchema, err := schema.avroSchema()
+	require.NoError(t, err)
+	codec, err := goavro.NewCodec(avroSchema)
+	require.NoError(t, err)
+
+	columns := func(id int64, d string) []*model.Column {
+		return []*model.Column{
+			{Name: "id", Value: id},
+			{Name: "price", Value: "12.34"},
+			{Name: "d", Value: d},
+			{Name: "ts", Value: "2023-01-02 03:04:05.123456"},
+			{Name: "status", Value: uint64(2)},
+		}
+	}
+	rows := []*model.RowChangedEvent{
+		{CommitTs: 1, Columns: columns(1, "2023-01-02")},
+		{CommitTs: 2, Columns: columns(1, "0000-00-00"), PreColumns: columns(1, "2023-01-02")},
+		{CommitTs: 3, PreColumns: columns(2, "2023-01-02")},
+	}
+	data, err := encodeDataFile(codec, schema, rows)
+	require.NoError(t, err)
+
+	r, err := goavro.NewOCFReader(bytes.NewReader(data))
+	require.NoError(t, err)
+	var records []map[string]interface{}
+	for r.Scan() {
+		datum, err := r.Read()
+		require.NoError(t, err)
+		records = append(records, datum.(map[string]interface{}))
+	}
+	require.Len(t, records, 3)
+
+	first := records[0]
+	require.Equal(t, "I", first[OpField])
+	require.Equal(t, int64(1), first[CommitTsField])
+	require.Equal(t, map[string]interface{}{"long": int64(1)}, first["id"])
+	require.Equal(t, 0, big.NewRat(1234, 100).Cmp(first["price"].(map[string]interface{})["bytes.decimal"].(*big.Rat)))
+	require.Equal(t, time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+		first["d"].(map[string]interface{})["int.date"])
+	require.Equal(t, time.Date(2023, 1, 2, 3, 4, 5, 123456000, time.UTC),
+		first["ts"].(map[string]interface{})["long.timestamp-micros"])
+	require.Equal(t, map[string]interface{}{"string": "closed"}, first["status"])
+
+	// the zero date is written as null.
+	require.Equal(t, "U", records[1][OpField])
+	require.Nil(t, records[1]["d"])
+
+	// the delete is written as its old row.
+	require.Equal(t, "D", records[2][OpField])
+	require.Equal(t, map[string]interface{}{"long": int64(2)}, records[2]["id"])
+}
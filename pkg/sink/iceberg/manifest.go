@@ -0,0 +1,252 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iceberg
+
+import (
+	"bytes"
+	"strconv"
+
+	"github.com/linkedin/goavro/v2"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+)
+
+const (
+	entryStatusExisting int32 = 0
+	entryStatusAdded    int32 = 1
+	entryStatusDeleted  int32 = 2
+
+	// manifestEntrySchema is the Avro schema of the manifest files of the
+	// format version 1, with the required fields only.
+	manifestEntrySchema = `{
+  "type": "record",
+  "name": "manifest_entry",
+  "fields": [
+    {"name": "status", "type": "int", "field-id": 0},
+    {"name": "snapshot_id", "type": "long", "field-id": 1},
+    {"name": "data_file", "field-id": 2, "type": {
+      "type": "record",
+      "name": "r2",
+      "fields": [
+        {"name": "file_path", "type": "string", "field-id": 100},
+        {"name": "file_format", "type": "string", "field-id": 101},
+        {"name": "partition", "field-id": 102, "type": {"type": "record", "name": "r102", "fields": []}},
+        {"name": "record_count", "type": "long", "field-id": 103},
+        {"name": "file_size_in_bytes", "type": "long", "field-id": 104},
+        {"name": "block_size_in_bytes", "type": "long", "field-id": 105}
+      ]
+    }}
+  ]
+}`
+	// manifestFileSchema is the Avro schema of the manifest lists of the
+	// format version 1.
+	manifestFileSchema = `{
+  "type": "record",
+  "name": "manifest_file",
+  "fields": [
+    {"name": "manifest_path", "type": "string", "field-id": 500},
+    {"name": "manifest_length", "type": "long", "field-id": 501},
+    {"name": "partition_spec_id", "type": "int", "field-id": 502},
+    {"name": "added_snapshot_id", "type": "long", "field-id": 503},
+    {"name": "added_data_files_count", "type": "int", "field-id": 504},
+    {"name": "existing_data_files_count", "type": "int", "field-id": 505},
+    {"name": "deleted_data_files_count", "type": "int", "field-id": 506},
+    {"name": "added_rows_count", "type": "long", "field-id": 512},
+    {"name": "existing_rows_count", "type": "long", "field-id": 513},
+    {"name": "deleted_rows_count", "type": "long", "field-id": 514}
+  ]
+}`
+	// the block size of the data files, which is required by the format
+	// version 1 but not used by the readers.
+	defaultBlockSize = 64 * 1024 * 1024
+)
+
+var (
+	manifestEntryCodec = mustNewCodec(manifestEntrySchema)
+	manifestFileCodec  = mustNewCodec(manifestFileSchema)
+)
+
+func mustNewCodec(schema string) *goavro.Codec {
+	codec, err := goavro.NewCodec(schema)
+	if err != nil {
+		panic(err)
+	}
+	return codec
+}
+
+// manifestEntry is a data file tracked by a manifest.
+type manifestEntry struct {
+	status      int32
+	snapshotID  int64
+	filePath    string
+	recordCount int64
+	fileSize    int64
+}
+
+// manifestFile is a manifest tracked by a manifest list.
+type manifestFile struct {
+	path            string
+	length          int64
+	addedSnapshotID int64
+	addedFiles      int32
+	existingFiles   int32
+	deletedFiles    int32
+	addedRows       int64
+	existingRows    int64
+	deletedRows     int64
+}
+
+// newManifestFile summarizes the entries of a manifest.
+func newManifestFile(path string, length int64, snapshotID int64, entries []*manifestEntry) *manifestFile {
+	m := &manifestFile{path: path, length: length, addedSnapshotID: snapshotID}
+	for _, e := range entries {
+		switch e.status {
+		case entryStatusAdded:
+			m.addedFiles++
+			m.addedRows += e.recordCount
+		case entryStatusDeleted:
+			m.deletedFiles++
+			m.deletedRows += e.recordCount
+		default:
+			m.existingFiles++
+			m.existingRows += e.recordCount
+		}
+	}
+	return m
+}
+
+// encodeManifest encodes the entries in a manifest file of an unpartitioned
+// table with the schema.
+func encodeManifest(schemaJSON string, entries []*manifestEntry) ([]byte, error) {
+	records := make([]interface{}, 0, len(entries))
+	for _, e := range entries {
+		records = append(records, map[string]interface{}{
+			"status":      e.status,
+			"snapshot_id": e.snapshotID,
+			"data_file": map[string]interface{}{
+				"file_path":           e.filePath,
+				"file_format":         "AVRO",
+				"partition":           map[string]interface{}{},
+				"record_count":        e.recordCount,
+				"file_size_in_bytes":  e.fileSize,
+				"block_size_in_bytes": int64(defaultBlockSize),
+			},
+		})
+	}
+	return encodeOCF(manifestEntryCodec, records, map[string][]byte{
+		"schema":            []byte(schemaJSON),
+		"partition-spec":    []byte("[]"),
+		"partition-spec-id": []byte("0"),
+		"format-version":    []byte("1"),
+		"content":           []byte("data"),
+	})
+}
+
+// decodeManifest decodes the entries of a manifest file.
+func decodeManifest(data []byte) ([]*manifestEntry, error) {
+	var entries []*manifestEntry
+	err := decodeOCF(data, func(record map[string]interface{}) {
+		dataFile, _ := record["data_file"].(map[string]interface{})
+		e := &manifestEntry{}
+		e.status, _ = record["status"].(int32)
+		e.snapshotID, _ = record["snapshot_id"].(int64)
+		e.filePath, _ = dataFile["file_path"].(string)
+		e.recordCount, _ = dataFile["record_count"].(int64)
+		e.fileSize, _ = dataFile["file_size_in_bytes"].(int64)
+		entries = append(entries, e)
+	})
+	return entries, err
+}
+
+// encodeManifestList encodes the manifests of a snapshot in a manifest list.
+func encodeManifestList(snapshotID int64, parentSnapshotID *int64, manifests []*manifestFile) ([]byte, error) {
+	records := make([]interface{}, 0, len(manifests))
+	for _, m := range manifests {
+		records = append(records, map[string]interface{}{
+			"manifest_path":             m.path,
+			"manifest_length":           m.length,
+			"partition_spec_id":         int32(0),
+			"added_snapshot_id":         m.addedSnapshotID,
+			"added_data_files_count":    m.addedFiles,
+			"existing_data_files_count": m.existingFiles,
+			"deleted_data_files_count":  m.deletedFiles,
+			"added_rows_count":          m.addedRows,
+			"existing_rows_count":       m.existingRows,
+			"deleted_rows_count":        m.deletedRows,
+		})
+	}
+	meta := map[string][]byte{
+		"snapshot-id":    []byte(strconv.FormatInt(snapshotID, 10)),
+		"format-version": []byte("1"),
+	}
+	if parentSnapshotID != nil {
+		meta["parent-snapshot-id"] = []byte(strconv.FormatInt(*parentSnapshotID, 10))
+	}
+	return encodeOCF(manifestFileCodec, records, meta)
+}
+
+// decodeManifestList decodes the manifests of a manifest list.
+func decodeManifestList(data []byte) ([]*manifestFile, error) {
+	var manifests []*manifestFile
+	err := decodeOCF(data, func(record map[string]interface{}) {
+		m := &manifestFile{}
+		m.path, _ = record["manifest_path"].(string)
+		m.length, _ = record["manifest_length"].(int64)
+		m.addedSnapshotID, _ = record["added_snapshot_id"].(int64)
+		m.addedFiles, _ = record["added_data_files_count"].(int32)
+		m.existingFiles, _ = record["existing_data_files_count"].(int32)
+		m.deletedFiles, _ = record["deleted_data_files_count"].(int32)
+		m.addedRows, _ = record["added_rows_count"].(int64)
+		m.existingRows, _ = record["existing_rows_count"].(int64)
+		m.deletedRows, _ = record["deleted_rows_count"].(int64)
+		manifests = append(manifests, m)
+	})
+	return manifests, err
+}
+
+func encodeOCF(codec *goavro.Codec, records []interface{}, meta map[string][]byte) ([]byte, error) {
+	var buf bytes.Buffer
+	w, err := goavro.NewOCFWriter(goavro.OCFConfig{
+		W:               &buf,
+		Codec:           codec,
+		CompressionName: goavro.CompressionDeflateLabel,
+		MetaData:        meta,
+	})
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrIcebergEncodeFailed, err)
+	}
+	if err := w.Append(records); err != nil {
+		return nil, cerror.WrapError(cerror.ErrIcebergEncodeFailed, err)
+	}
+	return buf.Bytes(), nil
+}
+
+func decodeOCF(data []byte, fn func(record map[string]interface{})) error {
+	r, err := goavro.NewOCFReader(bytes.NewReader(data))
+	if err != nil {
+		return cerror.WrapError(cerror.ErrIcebergEncodeFailed, err)
+	}
+	for r.Scan() {
+		datum, err := r.Read()
+		if err != nil {
+			return cerror.WrapError(cerror.ErrIcebergEncodeFailed, err)
+		}
+		if record, ok := datum.(map[string]interface{}); ok {
+			fn(record)
+		}
+	}
+	if err := r.Err(); err != nil {
+		return cerror.WrapError(cerror.ErrIcebergEncodeFailed, err)
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iceberg
+
+const (
+	formatVersion = 1
+	// mainBranch is the branch of the current snapshot of a table.
+	mainBranch = "main"
+	// tableVersionProperty is the table property of the version of the TiDB
+	// table whose schema is the current schema of the Iceberg table.
+	tableVersionProperty = "tidb.table-version"
+	// nameMappingProperty is the table property of the name mapping of the
+	// current schema.
+	nameMappingProperty = "schema.name-mapping.default"
+)
+
+// Metadata is the table metadata loaded from the catalog, with the fields
+// used by TiCDC only.
+type Metadata struct {
+	FormatVersion     int                     `json:"format-version"`
+	TableUUID         string                  `json:"table-uuid"`
+	Location          string                  `json:"location"`
+	LastUpdatedMs     int64                   `json:"last-updated-ms"`
+	LastColumnID      int                     `json:"last-column-id"`
+	Schema            *Schema                 `json:"schema,omitempty"`
+	Schemas           []*Schema               `json:"schemas"`
+	CurrentSchemaID   int                     `json:"current-schema-id"`
+	Properties        map[string]string       `json:"properties"`
+	CurrentSnapshotID int64                   `json:"current-snapshot-id"`
+	Snapshots         []*Snapshot             `json:"snapshots"`
+	Refs              map[string]*SnapshotRef `json:"refs"`
+}
+
+// Snapshot is a snapshot of a table.
+type Snapshot struct {
+	SnapshotID       int64             `json:"snapshot-id"`
+	ParentSnapshotID *int64            `json:"parent-snapshot-id,omitempty"`
+	TimestampMs      int64             `json:"timestamp-ms"`
+	ManifestList     string            `json:"manifest-list"`
+	Summary          map[string]string `json:"summary"`
+	SchemaID         int               `json:"schema-id"`
+}
+
+// SnapshotRef is a named reference to a snapshot.
+type SnapshotRef struct {
+	SnapshotID int64  `json:"snapshot-id"`
+	Type       string `json:"type"`
+}
+
+// currentSnapshot returns the current snapshot, nil if there is none.
+func (m *Metadata) currentSnapshot() *Snapshot {
+	snapshotID := m.CurrentSnapshotID
+	if ref, ok := m.Refs[mainBranch]; ok {
+		snapshotID = ref.SnapshotID
+	}
+	for _, s := range m.Snapshots {
+		if s.SnapshotID == snapshotID {
+			return s
+		}
+	}
+	return nil
+}
+
+// currentSchema returns the current schema, the metadata of the format
+// version 1 may only have the schema.
+func (m *Metadata) currentSchema() *Schema {
+	for _, s := range m.Schemas {
+		if s.SchemaID == m.CurrentSchemaID {
+			return s
+		}
+	}
+	return m.Schema
+}
+
+// lastSchemaID returns the max ID of the schemas.
+func (m *Metadata) lastSchemaID() int {
+	id := m.CurrentSchemaID
+	for _, s := range m.Schemas {
+		if s.SchemaID > id {
+			id = s.SchemaID
+		}
+	}
+	return id
+}
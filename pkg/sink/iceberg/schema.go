@@ -0,0 +1,321 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iceberg
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/pingcap/tidb/parser/charset"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tiflow/cdc/model"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+)
+
+const (
+	// OpField is the operation of a row change, which is I, U or D like
+	// the csv protocol.
+	OpField = "_tidb_op"
+	// CommitTsField is the commit ts of a row change.
+	CommitTsField = "_tidb_commit_ts"
+
+	// maxDecimalPrecision is the max precision of the Iceberg decimals, the
+	// wider DECIMAL columns are mapped to strings.
+	maxDecimalPrecision = 38
+
+	opFieldID       = 1
+	commitTsFieldID = 2
+	// reservedFieldIDs is the number of the field IDs reserved for the
+	// fields of the TiCDC metadata, the field ID of a column is its column
+	// ID plus it.
+	reservedFieldIDs = 2
+)
+
+// Field is a field of an Iceberg struct.
+type Field struct {
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+	Required bool   `json:"required"`
+	Type     string `json:"type"`
+	// Doc is the TiDB type of the column.
+	Doc string `json:"doc,omitempty"`
+
+	// ft is the TiDB type of the column, it's nil for the fields of the
+	// TiCDC metadata.
+	ft *types.FieldType
+	// avroName is the name of the field in the Avro data files.
+	avroName string
+}
+
+// Schema is the schema of an Iceberg table, which is the struct of the
+// visible columns of a TiDB table followed by OpField and CommitTsField.
+// The field IDs are derived from the column IDs, which are never reused by
+// TiDB, so a column keeps its field across the DDLs, including the renames,
+// and a dropped column is never confused with a new one.
+type Schema struct {
+	Type     string   `json:"type"`
+	SchemaID int      `json:"schema-id"`
+	Fields   []*Field `json:"fields"`
+}
+
+// NewSchema creates the Iceberg schema of a TiDB table.
+func NewSchema(tableInfo *model.TableInfo) *Schema {
+	s := &Schema{Type: "struct"}
+	for _, col := range tableInfo.Columns {
+		if !model.IsColCDCVisible(col) {
+			continue
+		}
+		ft := col.FieldType.Clone()
+		s.Fields = append(s.Fields, &Field{
+			ID:       int(col.ID) + reservedFieldIDs,
+			Name:     col.Name.O,
+			Type:     fieldType(ft),
+			Doc:      ft.String(),
+			ft:       ft,
+			avroName: avroName(col.Name.O),
+		})
+	}
+	s.Fields = append(s.Fields,
+		&Field{ID: opFieldID, Name: OpField, Required: true, Type: "string", avroName: OpField},
+		&Field{ID: commitTsFieldID, Name: CommitTsField, Required: true, Type: "long", avroName: CommitTsField})
+	return s
+}
+
+// lastColumnID returns the max field ID of the schema.
+func (s *Schema) lastColumnID() int {
+	id := 0
+	for _, f := range s.Fields {
+		if f.ID > id {
+			id = f.ID
+		}
+	}
+	return id
+}
+
+// fieldByID returns the field of the ID, nil if there is none.
+func (s *Schema) fieldByID(id int) *Field {
+	for _, f := range s.Fields {
+		if f.ID == id {
+			return f
+		}
+	}
+	return nil
+}
+
+// sameFields returns whether the schemas have the same fields, regardless
+// of the schema IDs and the order of the fields.
+func sameFields(a, b *Schema) bool {
+	if a == nil || b == nil || len(a.Fields) != len(b.Fields) {
+		return false
+	}
+	for _, f := range a.Fields {
+		other := b.fieldByID(f.ID)
+		if other == nil || other.Name != f.Name ||
+			other.Type != f.Type || other.Required != f.Required {
+			return false
+		}
+	}
+	return true
+}
+
+// checkEvolution checks whether the table can evolve from the current
+// schema to the next one. The fields can be added, dropped and renamed, but
+// the type of a field can only be promoted as Iceberg allows, i.e. from int
+// to long, from float to double, or to a decimal of a larger precision and
+// the same scale.
+func checkEvolution(dir string, current, next *Schema) error {
+	for _, f := range next.Fields {
+		old := current.fieldByID(f.ID)
+		if old == nil || old.Type == f.Type || canPromote(old.Type, f.Type) {
+			continue
+		}
+		return cerror.ErrIcebergInvalidTable.GenWithStackByArgs(dir, fmt.Sprintf(
+			"the type of the field %s can not be changed from %s to %s", f.Name, old.Type, f.Type))
+	}
+	return nil
+}
+
+func canPromote(from, to string) bool {
+	switch {
+	case from == "int" && to == "long", from == "float" && to == "double":
+		return true
+	case strings.HasPrefix(from, "decimal") && strings.HasPrefix(to, "decimal"):
+		var fromPrecision, fromScale, toPrecision, toScale int
+		_, _ = fmt.Sscanf(from, "decimal(%d, %d)", &fromPrecision, &fromScale)
+		_, _ = fmt.Sscanf(to, "decimal(%d, %d)", &toPrecision, &toScale)
+		return fromScale == toScale && fromPrecision <= toPrecision
+	}
+	return false
+}
+
+// fieldType returns the Iceberg type of a TiDB column. The unsigned BIGINT
+// columns are mapped to decimal(20, 0) so they never overflow, and the types
+// without an equivalent, such as TIME, JSON, ENUM and SET, are mapped to
+// string. Both DATETIME and TIMESTAMP are mapped to timestamp without zone,
+// whose values are the ones in the time zone of the changefeed.
+func fieldType(ft *types.FieldType) string {
+	unsigned := mysql.HasUnsignedFlag(ft.GetFlag())
+	switch ft.GetType() {
+	case mysql.TypeTiny, mysql.TypeShort, mysql.TypeInt24, mysql.TypeYear:
+		return "int"
+	case mysql.TypeLong:
+		if unsigned {
+			return "long"
+		}
+		return "int"
+	case mysql.TypeLonglong:
+		if unsigned {
+			return "decimal(20, 0)"
+		}
+		return "long"
+	case mysql.TypeBit:
+		return "long"
+	case mysql.TypeFloat:
+		return "float"
+	case mysql.TypeDouble:
+		return "double"
+	case mysql.TypeNewDecimal:
+		flen, decimal := ft.GetFlen(), ft.GetDecimal()
+		if flen <= 0 || flen > maxDecimalPrecision {
+			return "string"
+		}
+		if decimal < 0 {
+			decimal = 0
+		}
+		return fmt.Sprintf("decimal(%d, %d)", flen, decimal)
+	case mysql.TypeDate:
+		return "date"
+	case mysql.TypeDatetime, mysql.TypeTimestamp:
+		return "timestamp"
+	case mysql.TypeVarchar, mysql.TypeString, mysql.TypeVarString, mysql.TypeTinyBlob,
+		mysql.TypeMediumBlob, mysql.TypeLongBlob, mysql.TypeBlob:
+		if ft.GetCharset() == charset.CharsetBin {
+			return "binary"
+		}
+		return "string"
+	default:
+		return "string"
+	}
+}
+
+// avroSchema returns the Avro schema of the data files. The Avro fields carry
+// the Iceberg field IDs, and their names are made compatible with Avro in the
+// same way as Iceberg does.
+func (s *Schema) avroSchema() (string, error) {
+	fields := make([]map[string]interface{}, 0, len(s.Fields))
+	for _, f := range s.Fields {
+		tp := avroType(f.Type)
+		field := map[string]interface{}{
+			"name":     f.avroName,
+			"type":     tp,
+			"field-id": f.ID,
+		}
+		if !f.Required {
+			field["type"] = []interface{}{"null", tp}
+			field["default"] = nil
+		}
+		fields = append(fields, field)
+	}
+	data, err := json.Marshal(map[string]interface{}{
+		"type":   "record",
+		"name":   "table",
+		"fields": fields,
+	})
+	if err != nil {
+		return "", cerror.WrapError(cerror.ErrIcebergEncodeFailed, err)
+	}
+	return string(data), nil
+}
+
+// avroType returns the Avro type of an Iceberg primitive type.
+func avroType(tp string) interface{} {
+	switch {
+	case tp == "timestamp":
+		return map[string]interface{}{
+			"type": "long", "logicalType": "timestamp-micros", "adjust-to-utc": false,
+		}
+	case tp == "date":
+		return map[string]interface{}{"type": "int", "logicalType": "date"}
+	case tp == "binary":
+		return "bytes"
+	case strings.HasPrefix(tp, "decimal"):
+		var precision, scale int
+		_, _ = fmt.Sscanf(tp, "decimal(%d, %d)", &precision, &scale)
+		return map[string]interface{}{
+			"type": "bytes", "logicalType": "decimal", "precision": precision, "scale": scale,
+		}
+	default:
+		return tp
+	}
+}
+
+// avroUnionName returns the name of the non-null branch of the union of an
+// optional field, which is used to encode the values with goavro.
+func avroUnionName(tp string) string {
+	switch {
+	case tp == "timestamp":
+		return "long.timestamp-micros"
+	case tp == "date":
+		return "int.date"
+	case tp == "binary":
+		return "bytes"
+	case strings.HasPrefix(tp, "decimal"):
+		return "bytes.decimal"
+	default:
+		return tp
+	}
+}
+
+// avroName makes a name compatible with Avro like Iceberg does, the invalid
+// characters are replaced by "_x" followed by their hex codes, and a leading
+// digit is prefixed by an underscore.
+func avroName(name string) string {
+	var sb strings.Builder
+	for i, r := range name {
+		valid := r == '_' || ('a' <= r && r <= 'z') || ('A' <= r && r <= 'Z')
+		if i > 0 {
+			valid = valid || ('0' <= r && r <= '9')
+		}
+		switch {
+		case valid:
+			sb.WriteRune(r)
+		case i == 0 && '0' <= r && r <= '9':
+			sb.WriteByte('_')
+			sb.WriteRune(r)
+		default:
+			sb.WriteString(fmt.Sprintf("_x%X", r))
+		}
+	}
+	return sb.String()
+}
+
+// nameMapping returns the name mapping of the schema, which lets the readers
+// map the Avro fields to the Iceberg fields by their names if they don't
+// read the field IDs.
+func (s *Schema) nameMapping() (string, error) {
+	mapping := make([]map[string]interface{}, 0, len(s.Fields))
+	for _, f := range s.Fields {
+		mapping = append(mapping, map[string]interface{}{
+			"field-id": f.ID,
+			"names":    []string{f.Name},
+		})
+	}
+	data, err := json.Marshal(mapping)
+	if err != nil {
+		return "", cerror.WrapError(cerror.ErrIcebergEncodeFailed, err)
+	}
+	return string(data), nil
+}
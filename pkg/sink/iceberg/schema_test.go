@@ -0,0 +1,140 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iceberg
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb/parser/charset"
+	timodel "github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/stretchr/testify/require"
+)
+
+func newColumnInfo(name string, tp byte, flag uint, charsetName string) *timodel.ColumnInfo {
+	ft := types.NewFieldType(tp)
+	ft.SetFlag(flag)
+	ft.SetCharset(charsetName)
+	return &timodel.ColumnInfo{Name: timodel.NewCIStr(name), FieldType: *ft}
+}
+
+func newTableInfo(columns ...*timodel.ColumnInfo) *model.TableInfo {
+	for i, col := range columns {
+		col.ID = int64(i + 1)
+		col.Offset = i
+		col.State = timodel.StatePublic
+	}
+	return model.WrapTableInfo(1, "test", 1, &timodel.TableInfo{
+		ID:      100,
+		Name:    timodel.NewCIStr("t"),
+		Columns: columns,
+	})
+}
+
+func TestNewSchema(t *testing.T) {
+	t.Parallel()
+
+	dec := newColumnInfo("price", mysql.TypeNewDecimal, 0, "")
+	dec.SetFlen(10)
+	dec.SetDecimal(2)
+	wideDec := newColumnInfo("wide", mysql.TypeNewDecimal, 0, "")
+	wideDec.SetFlen(65)
+	tableInfo := newTableInfo(
+		newColumnInfo("id", mysql.TypeLonglong, mysql.PriKeyFlag|mysql.NotNullFlag, ""),
+		newColumnInfo("u", mysql.TypeLonglong, mysql.UnsignedFlag, ""),
+		newColumnInfo("n", mysql.TypeLong, 0, ""),
+		dec,
+		wideDec,
+		newColumnInfo("d", mysql.TypeDate, 0, ""),
+		newColumnInfo("ts", mysql.TypeTimestamp, 0, ""),
+		newColumnInfo("name", mysql.TypeVarchar, 0, charset.CharsetUTF8MB4),
+		newColumnInfo("data", mysql.TypeBlob, mysql.BinaryFlag, charset.CharsetBin),
+		newColumnInfo("j", mysql.TypeJSON, 0, ""),
+	)
+
+	schema := NewSchema(tableInfo)
+	var fieldTypes []string
+	for i, f := range schema.Fields[:10] {
+		require.Equal(t, i+3, f.ID)
+		fieldTypes = append(fieldTypes, f.Type)
+	}
+	for _, f := range schema.Fields[10:] {
+		fieldTypes = append(fieldTypes, f.Type)
+	}
+	require.Equal(t, []string{
+		"long", "decimal(20, 0)", "int", "decimal(10, 2)", "string", "date",
+		"timestamp", "string", "binary", "string", "string", "long",
+	}, fieldTypes)
+	require.Equal(t, OpField, schema.Fields[10].Name)
+	require.Equal(t, opFieldID, schema.Fields[10].ID)
+	require.True(t, schema.Fields[10].Required)
+	require.Equal(t, CommitTsField, schema.Fields[11].Name)
+	require.Equal(t, commitTsFieldID, schema.Fields[11].ID)
+	require.Equal(t, 12, schema.lastColumnID())
+
+	mapping, err := schema.nameMapping()
+	require.NoError(t, err)
+	require.Contains(t, mapping, `{"field-id":3,"names":["id"]}`)
+}
+
+func TestAvroName(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, "name", avroName("name"))
+	require.Equal(t, "_1st", avroName("1st"))
+	require.Equal(t, "a_x2Db", avroName("a-b"))
+	require.Equal(t, "_x4E2D", avroName("中"))
+}
+
+func TestAvroType(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, "long", avroType("long"))
+	require.Equal(t, "bytes", avroType("binary"))
+	require.Equal(t, map[string]interface{}{
+		"type": "bytes", "logicalType": "decimal", "precision": 20, "scale": 0,
+	}, avroType("decimal(20, 0)"))
+	require.Equal(t, "bytes.decimal", avroUnionName("decimal(10, 2)"))
+	require.Equal(t, "long.timestamp-micros", avroUnionName("timestamp"))
+}
+
+func TestCheckEvolution(t *testing.T) {
+	t.Parallel()
+
+	current := &Schema{Fields: []*Field{
+		{ID: 3, Name: "a", Type: "int"},
+		{ID: 4, Name: "b", Type: "decimal(10, 2)"},
+		{ID: 5, Name: "c", Type: "string"},
+	}}
+	next := &Schema{Fields: []*Field{
+		{ID: 3, Name: "a", Type: "long"},
+		{ID: 4, Name: "b", Type: "decimal(12, 2)"},
+		{ID: 6, Name: "d", Type: "int"},
+	}}
+	require.NoError(t, checkEvolution("test/t", current, next))
+	require.False(t, sameFields(current, next))
+	require.True(t, sameFields(next, &Schema{Fields: []*Field{
+		next.Fields[2], next.Fields[0], next.Fields[1],
+	}}))
+
+	next.Fields[1].Type = "decimal(12, 3)"
+	require.ErrorContains(t, checkEvolution("test/t", current, next),
+		"the type of the field b can not be changed from decimal(10, 2) to decimal(12, 3)")
+	next.Fields[1].Type = "decimal(12, 2)"
+	next.Fields = append(next.Fields, &Field{ID: 5, Name: "c", Type: "long"})
+	require.ErrorContains(t, checkEvolution("test/t", current, next),
+		"the type of the field c can not be changed from string to long")
+}
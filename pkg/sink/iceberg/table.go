@@ -0,0 +1,456 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iceberg
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/linkedin/goavro/v2"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/tidb/br/pkg/storage"
+	"github.com/pingcap/tiflow/cdc/model"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/retry"
+	"go.uber.org/zap"
+)
+
+const (
+	// FileExtension is the extension of the data files.
+	FileExtension = ".avro"
+
+	// maxManifests is the max number of manifests of a snapshot, the
+	// manifests are merged into one when there are more of them, so that
+	// the readers don't have to open too many manifests to plan a scan.
+	maxManifests = 100
+
+	commitBackoffBaseDelayInMs = 100
+	commitBackoffMaxDelayInMs  = 5 * 1000
+	commitMaxTries             = 20
+)
+
+// Table is an unpartitioned Iceberg table of the format version 1, whose
+// metadata is committed to a REST catalog and whose files are stored in an
+// external storage:
+//
+//	<schema>/<table>/data/<uuid>.avro
+//	<schema>/<table>/metadata/<uuid>-m0.avro
+//	<schema>/<table>/metadata/snap-<snapshot-id>-1-<uuid>.avro
+//
+// The Iceberg table of a TiDB table is shared by all its versions, its
+// schema evolves with the DDLs. Each Append commits a snapshot with a new
+// data file, and the small data files are compacted from time to time.
+// The commits are optimistic, a commit conflicting with a concurrent one is
+// retried on the latest metadata, so a table can be written concurrently.
+type Table struct {
+	storage storage.ExternalStorage
+	// root is the URI of the storage, which prefixes the paths in the
+	// metadata, because the readers need the absolute paths of the files.
+	root      string
+	catalog   *Catalog
+	namespace string
+	name      string
+	dir       string
+	// targetFileSize is the size of the data files the small ones are
+	// compacted into.
+	targetFileSize int64
+
+	// tableVersion is the version of the TiDB table of the schema the rows
+	// are written with.
+	tableVersion uint64
+	schema       *Schema
+	schemaJSON   string
+	codec        *goavro.Codec
+
+	// metadata is the latest metadata loaded from or committed to the
+	// catalog, and manifests are the ones of its current snapshot.
+	metadata  *Metadata
+	manifests []*manifestFile
+}
+
+// OpenTable opens the Iceberg table of the TiDB table in the catalog, the
+// table is created in the directory "<schema>/<table>" of the storage if it
+// doesn't exist. storageURI is the URI of the storage without the query
+// parameters.
+func OpenTable(
+	ctx context.Context,
+	s storage.ExternalStorage,
+	storageURI string,
+	catalog *Catalog,
+	tableInfo *model.TableInfo,
+	targetFileSize int64,
+) (*Table, error) {
+	t := &Table{
+		storage:        s,
+		root:           strings.TrimSuffix(storageURI, "/"),
+		catalog:        catalog,
+		namespace:      tableInfo.TableName.Schema,
+		name:           tableInfo.TableName.Table,
+		dir:            tableInfo.TableName.Schema + "/" + tableInfo.TableName.Table,
+		targetFileSize: targetFileSize,
+	}
+	if err := t.setSchema(tableInfo); err != nil {
+		return nil, err
+	}
+
+	metadata, err := catalog.loadTable(ctx, t.namespace, t.name)
+	if err != nil {
+		return nil, err
+	}
+	if metadata == nil {
+		mapping, err := t.schema.nameMapping()
+		if err != nil {
+			return nil, err
+		}
+		metadata, err = catalog.createTable(ctx, t.namespace, t.name, t.location(t.dir), t.schema,
+			map[string]string{
+				"write.format.default": "avro",
+				nameMappingProperty:    mapping,
+				tableVersionProperty:   strconv.FormatUint(tableInfo.Version, 10),
+			})
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := t.setMetadata(ctx, metadata); err != nil {
+		return nil, err
+	}
+	if err := t.evolve(ctx); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// UpdateSchema writes the following rows with the schema of the version of
+// the TiDB table. The schema of the Iceberg table evolves to it if the
+// version is newer than the one of the current schema, so the rows of the
+// older versions replayed after a restart don't revert the schema.
+func (t *Table) UpdateSchema(ctx context.Context, tableInfo *model.TableInfo) error {
+	if tableInfo.Version == t.tableVersion {
+		return nil
+	}
+	if err := t.setSchema(tableInfo); err != nil {
+		return err
+	}
+	return t.evolve(ctx)
+}
+
+func (t *Table) setSchema(tableInfo *model.TableInfo) error {
+	schema := NewSchema(tableInfo)
+	avroSchema, err := schema.avroSchema()
+	if err != nil {
+		return err
+	}
+	codec, err := goavro.NewCodec(avroSchema)
+	if err != nil {
+		return cerror.WrapError(cerror.ErrIcebergEncodeFailed, err)
+	}
+	t.tableVersion, t.schema, t.codec = tableInfo.Version, schema, codec
+	return t.setSchemaID(schema.SchemaID)
+}
+
+// setSchemaID sets the ID of the schema the rows are written with.
+func (t *Table) setSchemaID(schemaID int) error {
+	t.schema.SchemaID = schemaID
+	schemaJSON, err := json.Marshal(t.schema)
+	if err != nil {
+		return cerror.WrapError(cerror.ErrIcebergEncodeFailed, err)
+	}
+	t.schemaJSON = string(schemaJSON)
+	return nil
+}
+
+// evolve commits the schema the rows are written with as the current schema
+// of the table, if the version of its TiDB table is newer than the one of
+// the current schema and their fields are different.
+func (t *Table) evolve(ctx context.Context) error {
+	return t.commitWithRetry(ctx, func() error {
+		current := t.metadata.currentSchema()
+		if current == nil {
+			return cerror.ErrIcebergInvalidTable.GenWithStackByArgs(t.dir, "there is no current schema")
+		}
+		version, _ := strconv.ParseUint(t.metadata.Properties[tableVersionProperty], 10, 64)
+		if t.tableVersion <= version || sameFields(current, t.schema) {
+			return t.setSchemaID(current.SchemaID)
+		}
+		if err := checkEvolution(t.dir, current, t.schema); err != nil {
+			return err
+		}
+
+		mapping, err := t.schema.nameMapping()
+		if err != nil {
+			return err
+		}
+		lastColumnID := t.metadata.LastColumnID
+		if id := t.schema.lastColumnID(); id > lastColumnID {
+			lastColumnID = id
+		}
+		if err := t.setSchemaID(t.metadata.lastSchemaID() + 1); err != nil {
+			return err
+		}
+		metadata, err := t.catalog.commitTable(ctx, t.namespace, t.name,
+			[]map[string]interface{}{
+				{"type": "assert-table-uuid", "uuid": t.metadata.TableUUID},
+				{"type": "assert-current-schema-id", "current-schema-id": current.SchemaID},
+			},
+			[]map[string]interface{}{
+				{"action": "add-schema", "schema": t.schema, "last-column-id": lastColumnID},
+				{"action": "set-current-schema", "schema-id": t.schema.SchemaID},
+				{"action": "set-properties", "updates": map[string]string{
+					nameMappingProperty:  mapping,
+					tableVersionProperty: strconv.FormatUint(t.tableVersion, 10),
+				}},
+			})
+		if err != nil {
+			return err
+		}
+		log.Info("Iceberg table schema evolved",
+			zap.String("table", t.dir),
+			zap.Uint64("tableVersion", t.tableVersion),
+			zap.Int("schemaID", t.schema.SchemaID))
+		return t.setMetadata(ctx, metadata)
+	})
+}
+
+// setMetadata sets the metadata and loads the manifests of its current
+// snapshot.
+func (t *Table) setMetadata(ctx context.Context, metadata *Metadata) error {
+	var manifests []*manifestFile
+	if snapshot := metadata.currentSnapshot(); snapshot != nil {
+		data, err := t.storage.ReadFile(ctx, t.relPath(snapshot.ManifestList))
+		if err != nil {
+			return errors.Trace(err)
+		}
+		manifests, err = decodeManifestList(data)
+		if err != nil {
+			return err
+		}
+	}
+	t.metadata, t.manifests = metadata, manifests
+	return nil
+}
+
+// commitWithRetry runs the commit, and runs it again on the latest metadata
+// if it conflicts with a concurrent one.
+func (t *Table) commitWithRetry(ctx context.Context, commit func() error) error {
+	return retry.Do(ctx, func() error {
+		err := commit()
+		if cerror.ErrIcebergCommitConflict.Equal(err) {
+			metadata, loadErr := t.catalog.loadTable(ctx, t.namespace, t.name)
+			if loadErr != nil {
+				return loadErr
+			}
+			if metadata == nil {
+				return cerror.ErrIcebergInvalidTable.GenWithStackByArgs(t.dir, "the table is dropped")
+			}
+			if loadErr := t.setMetadata(ctx, metadata); loadErr != nil {
+				return loadErr
+			}
+		}
+		return err
+	}, retry.WithBackoffBaseDelay(commitBackoffBaseDelayInMs),
+		retry.WithBackoffMaxDelay(commitBackoffMaxDelayInMs),
+		retry.WithMaxTries(commitMaxTries),
+		retry.WithIsRetryableErr(cerror.ErrIcebergCommitConflict.Equal))
+}
+
+// Append commits the rows as a snapshot of the table. The rows are visible
+// to the readers once it returns without an error, and the table is left
+// unchanged if it fails. It returns the number of the written rows.
+func (t *Table) Append(ctx context.Context, rows []*model.RowChangedEvent) (int, error) {
+	if len(rows) == 0 {
+		return 0, nil
+	}
+	data, err := encodeDataFile(t.codec, t.schema, rows)
+	if err != nil {
+		return 0, err
+	}
+	dataPath := t.newDataFilePath()
+	if err := t.storage.WriteFile(ctx, dataPath, data); err != nil {
+		return 0, errors.Trace(err)
+	}
+	added := &manifestEntry{
+		status:      entryStatusAdded,
+		filePath:    t.location(dataPath),
+		recordCount: int64(len(rows)),
+		fileSize:    int64(len(data)),
+	}
+
+	err = t.commitWithRetry(ctx, func() error {
+		snapshotID := newSnapshotID()
+		added.snapshotID = snapshotID
+		entries := []*manifestEntry{added}
+		manifests := t.manifests
+		if len(manifests) >= maxManifests {
+			existing, err := t.readEntries(ctx, manifests)
+			if err != nil {
+				return err
+			}
+			entries = append(entries, existing...)
+			manifests = nil
+		}
+		manifest, err := t.writeManifest(ctx, snapshotID, entries)
+		if err != nil {
+			return err
+		}
+		// the new manifest is listed first like Iceberg does.
+		manifests = append([]*manifestFile{manifest}, manifests...)
+		return t.commitSnapshot(ctx, snapshotID, manifests, map[string]string{
+			"operation":        "append",
+			"added-data-files": "1",
+			"added-records":    strconv.Itoa(len(rows)),
+			"added-files-size": strconv.Itoa(len(data)),
+		})
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	if err := t.compact(ctx); err != nil {
+		log.Warn("Iceberg table compaction failed, it will be retried later",
+			zap.String("table", t.dir), zap.Error(err))
+	}
+	return len(rows), nil
+}
+
+// writeManifest writes the entries in a manifest of the snapshot.
+func (t *Table) writeManifest(
+	ctx context.Context, snapshotID int64, entries []*manifestEntry,
+) (*manifestFile, error) {
+	manifest, err := encodeManifest(t.schemaJSON, entries)
+	if err != nil {
+		return nil, err
+	}
+	manifestPath := t.metadataPath(fmt.Sprintf("%s-m0.avro", uuid.New().String()))
+	if err := t.storage.WriteFile(ctx, manifestPath, manifest); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return newManifestFile(t.location(manifestPath), int64(len(manifest)), snapshotID, entries), nil
+}
+
+// commitSnapshot commits a snapshot of the manifests as the current one, on
+// the condition that the current snapshot is still the one it's based on.
+func (t *Table) commitSnapshot(
+	ctx context.Context,
+	snapshotID int64,
+	manifests []*manifestFile,
+	summary map[string]string,
+) error {
+	var parentSnapshotID *int64
+	if current := t.metadata.currentSnapshot(); current != nil {
+		id := current.SnapshotID
+		parentSnapshotID = &id
+	}
+	manifestList, err := encodeManifestList(snapshotID, parentSnapshotID, manifests)
+	if err != nil {
+		return err
+	}
+	manifestListPath := t.metadataPath(fmt.Sprintf("snap-%d-1-%s.avro", snapshotID, uuid.New().String()))
+	if err := t.storage.WriteFile(ctx, manifestListPath, manifestList); err != nil {
+		return errors.Trace(err)
+	}
+
+	var totalFiles, totalRows int64
+	for _, m := range manifests {
+		totalFiles += int64(m.addedFiles + m.existingFiles)
+		totalRows += m.addedRows + m.existingRows
+	}
+	summary["total-data-files"] = strconv.FormatInt(totalFiles, 10)
+	summary["total-records"] = strconv.FormatInt(totalRows, 10)
+	summary["total-delete-files"] = "0"
+	snapshot := &Snapshot{
+		SnapshotID:       snapshotID,
+		ParentSnapshotID: parentSnapshotID,
+		TimestampMs:      time.Now().UnixMilli(),
+		ManifestList:     t.location(manifestListPath),
+		Summary:          summary,
+		SchemaID:         t.schema.SchemaID,
+	}
+	metadata, err := t.catalog.commitTable(ctx, t.namespace, t.name,
+		[]map[string]interface{}{
+			{"type": "assert-table-uuid", "uuid": t.metadata.TableUUID},
+			// a nil snapshot ID asserts that the branch doesn't exist.
+			{"type": "assert-ref-snapshot-id", "ref": mainBranch, "snapshot-id": parentSnapshotID},
+		},
+		[]map[string]interface{}{
+			{"action": "add-snapshot", "snapshot": snapshot},
+			{"action": "set-snapshot-ref", "ref-name": mainBranch, "type": "branch", "snapshot-id": snapshotID},
+		})
+	if err != nil {
+		return err
+	}
+	t.metadata, t.manifests = metadata, manifests
+	return nil
+}
+
+// readEntries reads the live entries of the manifests as the existing ones.
+func (t *Table) readEntries(ctx context.Context, manifests []*manifestFile) ([]*manifestEntry, error) {
+	var entries []*manifestEntry
+	for _, m := range manifests {
+		data, err := t.storage.ReadFile(ctx, t.relPath(m.path))
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		es, err := decodeManifest(data)
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range es {
+			if e.status == entryStatusDeleted {
+				continue
+			}
+			e.status = entryStatusExisting
+			entries = append(entries, e)
+		}
+	}
+	return entries, nil
+}
+
+// newDataFilePath returns a unique path of a data file, the writers of the
+// table never write the same file.
+func (t *Table) newDataFilePath() string {
+	return fmt.Sprintf("%s/data/%s%s", t.dir, uuid.New().String(), FileExtension)
+}
+
+func (t *Table) metadataPath(name string) string {
+	return t.dir + "/metadata/" + name
+}
+
+// location returns the absolute location of a path in the storage.
+func (t *Table) location(path string) string {
+	return t.root + "/" + path
+}
+
+// relPath returns the path in the storage of an absolute location.
+func (t *Table) relPath(location string) string {
+	return strings.TrimPrefix(location, t.root+"/")
+}
+
+// newSnapshotID returns a random positive snapshot ID.
+func newSnapshotID() int64 {
+	id := uuid.New()
+	n := int64(binary.BigEndian.Uint64(id[:8]) & math.MaxInt64)
+	if n == 0 {
+		return 1
+	}
+	return n
+}
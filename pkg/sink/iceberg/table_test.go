@@ -0,0 +1,218 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iceberg
+
+import (
+	"context"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pingcap/tidb/br/pkg/storage"
+	"github.com/pingcap/tidb/parser/charset"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/util"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestCatalog(t *testing.T) (*Catalog, *MockCatalog) {
+	mock := NewMockCatalog()
+	server := httptest.NewServer(mock)
+	t.Cleanup(server.Close)
+	catalog, err := NewCatalog(server.URL)
+	require.NoError(t, err)
+	return catalog, mock
+}
+
+func newTestStorage(t *testing.T) (storage.ExternalStorage, string) {
+	storageURI := "file://" + t.TempDir()
+	s, err := util.GetExternalStorageFromURI(context.Background(), storageURI)
+	require.NoError(t, err)
+	return s, storageURI
+}
+
+func newRows(commitTs uint64, n int) []*model.RowChangedEvent {
+	var rows []*model.RowChangedEvent
+	for i := 0; i < n; i++ {
+		rows = append(rows, &model.RowChangedEvent{
+			CommitTs: commitTs,
+			Columns: []*model.Column{
+				{Name: "id", Value: int64(i)},
+				{Name: "name", Value: fmt.Sprintf("name%d", i)},
+			},
+		})
+	}
+	return rows
+}
+
+func TestTableAppend(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	s, storageURI := newTestStorage(t)
+	catalog, mock := newTestCatalog(t)
+	tableInfo := newTableInfo(
+		newColumnInfo("id", mysql.TypeLonglong, mysql.PriKeyFlag|mysql.NotNullFlag, ""),
+		newColumnInfo("name", mysql.TypeVarchar, 0, charset.CharsetUTF8MB4),
+	)
+
+	table, err := OpenTable(ctx, s, storageURI, catalog, tableInfo, defaultBlockSize)
+	require.NoError(t, err)
+	require.Nil(t, table.metadata.currentSnapshot())
+	require.Equal(t, storageURI+"/test/t", table.metadata.Location)
+	require.Equal(t, "1", table.metadata.Properties["format-version"])
+
+	n, err := table.Append(ctx, newRows(10, 2))
+	require.NoError(t, err)
+	require.Equal(t, 2, n)
+	n, err = table.Append(ctx, newRows(20, 1))
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+	n, err = table.Append(ctx, nil)
+	require.NoError(t, err)
+	require.Equal(t, 0, n)
+	require.Equal(t, 2, mock.Commits())
+
+	// another writer of the table commits based on the stale metadata, the
+	// commit conflicts and is retried on the latest metadata.
+	other, err := OpenTable(ctx, s, storageURI, catalog, tableInfo, defaultBlockSize)
+	require.NoError(t, err)
+	require.Len(t, other.manifests, 2)
+	_, err = table.Append(ctx, newRows(30, 3))
+	require.NoError(t, err)
+	_, err = other.Append(ctx, newRows(40, 4))
+	require.NoError(t, err)
+	require.Equal(t, 1, mock.Conflicts())
+
+	snapshot := other.metadata.currentSnapshot()
+	require.NotNil(t, snapshot)
+	require.Len(t, other.metadata.Snapshots, 4)
+	require.Equal(t, other.metadata.Snapshots[2].SnapshotID, *snapshot.ParentSnapshotID)
+	require.Equal(t, "10", snapshot.Summary["total-records"])
+	require.Equal(t, "4", snapshot.Summary["total-data-files"])
+
+	data, err := s.ReadFile(ctx, other.relPath(snapshot.ManifestList))
+	require.NoError(t, err)
+	manifests, err := decodeManifestList(data)
+	require.NoError(t, err)
+	require.Len(t, manifests, 4)
+	require.Equal(t, int64(4), manifests[0].addedRows)
+	require.Equal(t, snapshot.SnapshotID, manifests[0].addedSnapshotID)
+
+	entries, err := other.readEntries(ctx, manifests)
+	require.NoError(t, err)
+	require.Len(t, entries, 4)
+	for _, e := range entries {
+		require.Equal(t, entryStatusExisting, e.status)
+	}
+}
+
+func TestTableSchemaEvolution(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	s, storageURI := newTestStorage(t)
+	catalog, _ := newTestCatalog(t)
+	id := newColumnInfo("id", mysql.TypeLong, mysql.PriKeyFlag|mysql.NotNullFlag, "")
+	name := newColumnInfo("name", mysql.TypeVarchar, 0, charset.CharsetUTF8MB4)
+	v1 := newTableInfo(id, name)
+
+	table, err := OpenTable(ctx, s, storageURI, catalog, v1, defaultBlockSize)
+	require.NoError(t, err)
+	_, err = table.Append(ctx, newRows(10, 1))
+	require.NoError(t, err)
+
+	// widen the id and add a column, the table evolves in place.
+	wideID := newColumnInfo("id", mysql.TypeLonglong, mysql.PriKeyFlag|mysql.NotNullFlag, "")
+	age := newColumnInfo("age", mysql.TypeLong, 0, "")
+	v2 := newTableInfo(wideID, name, age)
+	v2.Version = 2
+	require.NoError(t, table.UpdateSchema(ctx, v2))
+	require.Equal(t, 1, table.metadata.CurrentSchemaID)
+	require.Len(t, table.metadata.Schemas, 2)
+	require.Equal(t, "2", table.metadata.Properties[tableVersionProperty])
+	require.Equal(t, "long", table.metadata.currentSchema().fieldByID(3).Type)
+	require.Equal(t, "age", table.metadata.currentSchema().fieldByID(5).Name)
+	_, err = table.Append(ctx, newRows(20, 1))
+	require.NoError(t, err)
+	require.Equal(t, 1, table.metadata.currentSnapshot().SchemaID)
+
+	// the rows of the older version replayed by another writer don't
+	// revert the schema.
+	other, err := OpenTable(ctx, s, storageURI, catalog, v1, defaultBlockSize)
+	require.NoError(t, err)
+	require.Equal(t, 1, other.metadata.CurrentSchemaID)
+	_, err = other.Append(ctx, newRows(15, 1))
+	require.NoError(t, err)
+	require.Len(t, other.metadata.Schemas, 2)
+
+	// the type of a column can't be changed incompatibly.
+	v3 := newTableInfo(newColumnInfo("id", mysql.TypeVarchar, 0, charset.CharsetUTF8MB4), name, age)
+	v3.Version = 3
+	require.ErrorContains(t, table.UpdateSchema(ctx, v3),
+		"the type of the field id can not be changed from long to string")
+}
+
+func TestTableCompaction(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	s, storageURI := newTestStorage(t)
+	catalog, _ := newTestCatalog(t)
+	tableInfo := newTableInfo(
+		newColumnInfo("id", mysql.TypeLong, mysql.PriKeyFlag|mysql.NotNullFlag, ""),
+		newColumnInfo("name", mysql.TypeVarchar, 0, charset.CharsetUTF8MB4),
+	)
+	table, err := OpenTable(ctx, s, storageURI, catalog, tableInfo, defaultBlockSize)
+	require.NoError(t, err)
+	for i := 1; i < minCompactFiles; i++ {
+		_, err = table.Append(ctx, newRows(uint64(i), 2))
+		require.NoError(t, err)
+	}
+	require.Equal(t, "append", table.metadata.currentSnapshot().Summary["operation"])
+
+	// the table is widened before the last append, the compacted rows are
+	// converted to the current schema.
+	widened := newTableInfo(
+		newColumnInfo("id", mysql.TypeLonglong, mysql.PriKeyFlag|mysql.NotNullFlag, ""),
+		newColumnInfo("name", mysql.TypeVarchar, 0, charset.CharsetUTF8MB4),
+	)
+	widened.Version = 2
+	require.NoError(t, table.UpdateSchema(ctx, widened))
+	_, err = table.Append(ctx, newRows(minCompactFiles, 2))
+	require.NoError(t, err)
+
+	snapshot := table.metadata.currentSnapshot()
+	require.Equal(t, "replace", snapshot.Summary["operation"])
+	require.Equal(t, fmt.Sprint(minCompactFiles), snapshot.Summary["deleted-data-files"])
+	require.Equal(t, "1", snapshot.Summary["total-data-files"])
+	require.Equal(t, fmt.Sprint(2*minCompactFiles), snapshot.Summary["total-records"])
+	require.Len(t, table.manifests, 1)
+	require.Equal(t, int32(minCompactFiles), table.manifests[0].deletedFiles)
+
+	entries, err := table.readEntries(ctx, table.manifests)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	data, err := s.ReadFile(ctx, table.relPath(entries[0].filePath))
+	require.NoError(t, err)
+	records, err := table.readDataFile(data)
+	require.NoError(t, err)
+	require.Len(t, records, 2*minCompactFiles)
+	for _, record := range records {
+		id := record.(map[string]interface{})["id"].(map[string]interface{})
+		_, ok := id["long"].(int64)
+		require.True(t, ok)
+	}
+}
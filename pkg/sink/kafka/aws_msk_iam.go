@@ -0,0 +1,118 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	v4 "github.com/aws/aws-sdk-go/aws/signer/v4"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"go.uber.org/zap"
+)
+
+const (
+	// mskIAMService is the signing name of the IAM access control of MSK.
+	mskIAMService = "kafka-cluster"
+	// mskIAMAction is the action to connect to a MSK cluster.
+	mskIAMAction = "kafka-cluster:Connect"
+	// mskIAMTokenTTL is the lifetime of a signed token.
+	mskIAMTokenTTL = 15 * time.Minute
+	// mskIAMUserAgent is sent to the brokers along with the signed tokens.
+	mskIAMUserAgent = "ticdc"
+)
+
+var _ sarama.AccessTokenProvider = (*mskIAMTokenProvider)(nil)
+
+// mskIAMTokenProvider provides the tokens for the IAM access control of AWS
+// MSK, which is authenticated by sasl-oauthbearer. A token is a presigned
+// URL of the kafka-cluster:Connect action, signed by the credentials of the
+// AWS SDK default chain, such as the environment variables, the web identity
+// of IRSA and the instance profile.
+type mskIAMTokenProvider struct {
+	mu sync.Mutex
+
+	region      string
+	credentials *credentials.Credentials
+	token       string
+	expiry      time.Time
+}
+
+// newMSKIAMTokenProvider creates a mskIAMTokenProvider, the region is got
+// from the AWS SDK config like AWS_REGION if it's empty.
+func newMSKIAMTokenProvider(region string) (*mskIAMTokenProvider, error) {
+	opts := session.Options{SharedConfigState: session.SharedConfigEnable}
+	if region != "" {
+		opts.Config.Region = aws.String(region)
+	}
+	sess, err := session.NewSessionWithOptions(opts)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrKafkaInvalidConfig, err)
+	}
+	region = aws.StringValue(sess.Config.Region)
+	if region == "" {
+		return nil, cerror.WrapError(cerror.ErrKafkaInvalidConfig,
+			errors.New("the region of AWS MSK should be supplied by sasl-aws-region or AWS_REGION"))
+	}
+	return &mskIAMTokenProvider{region: region, credentials: sess.Config.Credentials}, nil
+}
+
+// Token implements sarama.AccessTokenProvider, the signed token is cached
+// until it's about to expire.
+func (p *mskIAMTokenProvider) Token() (*sarama.AccessToken, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.token == "" || time.Until(p.expiry) < tokenRefreshMargin {
+		now := time.Now().UTC()
+		token, err := p.sign(now)
+		if err != nil {
+			return nil, cerror.WrapError(cerror.ErrKafkaFetchOAuthToken, err)
+		}
+		p.token = token
+		p.expiry = now.Add(mskIAMTokenTTL)
+		log.Info("kafka aws msk iam token refreshed",
+			zap.String("region", p.region), zap.Time("expiry", p.expiry))
+	}
+	return &sarama.AccessToken{Token: p.token}, nil
+}
+
+// sign presigns the kafka-cluster:Connect request of the region, the token
+// is the URL encoded by base64 without padding.
+func (p *mskIAMTokenProvider) sign(now time.Time) (string, error) {
+	endpoint := fmt.Sprintf("https://kafka.%s.amazonaws.com/?Action=%s",
+		p.region, url.QueryEscape(mskIAMAction))
+	req, err := http.NewRequest(http.MethodGet, endpoint, nil)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	signer := v4.NewSigner(p.credentials)
+	if _, err := signer.Presign(req, nil, mskIAMService, p.region, mskIAMTokenTTL, now); err != nil {
+		return "", errors.Trace(err)
+	}
+	query := req.URL.Query()
+	query.Set("User-Agent", mskIAMUserAgent)
+	req.URL.RawQuery = query.Encode()
+	return base64.RawURLEncoding.EncodeToString([]byte(req.URL.String())), nil
+}
@@ -0,0 +1,73 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"encoding/base64"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMSKIAMTokenProvider(t *testing.T) {
+	t.Parallel()
+
+	provider := &mskIAMTokenProvider{
+		region:      "us-west-2",
+		credentials: credentials.NewStaticCredentials("AKIDEXAMPLE", "secret", ""),
+	}
+	token, err := provider.Token()
+	require.Nil(t, err)
+	require.Empty(t, token.Extensions)
+
+	data, err := base64.RawURLEncoding.DecodeString(token.Token)
+	require.Nil(t, err)
+	u, err := url.Parse(string(data))
+	require.Nil(t, err)
+	require.Equal(t, "https", u.Scheme)
+	require.Equal(t, "kafka.us-west-2.amazonaws.com", u.Host)
+	query := u.Query()
+	require.Equal(t, "kafka-cluster:Connect", query.Get("Action"))
+	require.Equal(t, "AWS4-HMAC-SHA256", query.Get("X-Amz-Algorithm"))
+	require.True(t, strings.HasPrefix(query.Get("X-Amz-Credential"), "AKIDEXAMPLE/"))
+	require.True(t, strings.HasSuffix(query.Get("X-Amz-Credential"), "/us-west-2/kafka-cluster/aws4_request"))
+	require.Equal(t, "900", query.Get("X-Amz-Expires"))
+	require.NotEmpty(t, query.Get("X-Amz-Signature"))
+	require.Equal(t, "ticdc", query.Get("User-Agent"))
+
+	// the token is cached until it's about to expire.
+	cached, err := provider.Token()
+	require.Nil(t, err)
+	require.Equal(t, token.Token, cached.Token)
+
+	provider.expiry = time.Now().Add(time.Second)
+	_, err = provider.Token()
+	require.Nil(t, err)
+	require.True(t, time.Until(provider.expiry) > mskIAMTokenTTL-time.Minute)
+}
+
+func TestMSKIAMTokenProviderError(t *testing.T) {
+	t.Parallel()
+
+	provider := &mskIAMTokenProvider{
+		region:      "us-west-2",
+		credentials: credentials.NewStaticCredentials("", "", ""),
+	}
+	_, err := provider.Token()
+	require.Regexp(t, "kafka fetch oauth token failed", err)
+}
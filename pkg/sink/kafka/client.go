@@ -14,7 +14,10 @@
 package kafka
 
 import (
+	"context"
+
 	"github.com/Shopify/sarama"
+	"github.com/pingcap/errors"
 	"github.com/rcrowley/go-metrics"
 )
 
@@ -26,9 +29,23 @@ type Client interface {
 	// Partitions returns the sorted list of
 	// all partition IDs for the given topic.
 	Partitions(topic string) ([]int32, error)
+	// GetOffset queries the cluster to get the most recent available offset
+	// at the given time (in milliseconds) on the topic/partition combination.
+	// Use sarama.OffsetOldest or sarama.OffsetNewest to get the oldest or
+	// newest offset.
+	GetOffset(topic string, partitionID int32, time int64) (int64, error)
+	// ReadMessages reads at most count messages of the given partition from
+	// the given offset, it returns when count messages are read, or an error
+	// occurs, or the ctx is done.
+	ReadMessages(ctx context.Context, topic string, partitionID int32,
+		offset int64, count int) ([]*sarama.ConsumerMessage, error)
 	SyncProducer() (SyncProducer, error)
 	AsyncProducer() (AsyncProducer, error)
 	MetricRegistry() metrics.Registry
+	// WarmUp fetches the metadata of the topics and connects to the leaders
+	// of all their partitions, so the producers don't have to do it when
+	// the first messages are sent.
+	WarmUp(topics []string) error
 	// Close closes the client
 	Close() error
 }
@@ -39,14 +56,14 @@ type SyncProducer interface {
 	// succeeded or failed to produce. It will return the partition and the offset
 	// of the produced message, or an error if the message failed to produce.
 	SendMessage(topic string, partitionNum int32,
-		key []byte, value []byte) error
+		key []byte, value []byte, headers []sarama.RecordHeader) error
 
 	// SendMessages produces a given set of messages, and returns only when all
 	// messages in the set have either succeeded or failed. Note that messages
 	// can succeed and fail individually; if some succeed and some fail,
 	// SendMessages will return an error.
 	SendMessages(topic string, partitionNum int32,
-		key []byte, value []byte) error
+		key []byte, value []byte, headers []sarama.RecordHeader) error
 
 	// Close shuts down the producer; you must call this function before a producer
 	// object passes out of scope, as it may otherwise leak memory.
@@ -100,6 +117,41 @@ func (c *saramaKafkaClient) Partitions(topic string) ([]int32, error) {
 	return c.client.Partitions(topic)
 }
 
+func (c *saramaKafkaClient) GetOffset(
+	topic string, partitionID int32, time int64,
+) (int64, error) {
+	return c.client.GetOffset(topic, partitionID, time)
+}
+
+func (c *saramaKafkaClient) ReadMessages(
+	ctx context.Context, topic string, partitionID int32, offset int64, count int,
+) ([]*sarama.ConsumerMessage, error) {
+	// The consumer created from the client does not close the client.
+	consumer, err := sarama.NewConsumerFromClient(c.client)
+	if err != nil {
+		return nil, err
+	}
+	defer consumer.Close()
+	partitionConsumer, err := consumer.ConsumePartition(topic, partitionID, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer partitionConsumer.Close()
+
+	messages := make([]*sarama.ConsumerMessage, 0, count)
+	for len(messages) < count {
+		select {
+		case <-ctx.Done():
+			return messages, ctx.Err()
+		case msg := <-partitionConsumer.Messages():
+			messages = append(messages, msg)
+		case err := <-partitionConsumer.Errors():
+			return messages, err
+		}
+	}
+	return messages, nil
+}
+
 func (c *saramaKafkaClient) SyncProducer() (SyncProducer, error) {
 	p, err := sarama.NewSyncProducerFromClient(c.client)
 	if err != nil {
@@ -120,6 +172,49 @@ func (c *saramaKafkaClient) MetricRegistry() metrics.Registry {
 	return c.client.Config().MetricRegistry
 }
 
+// warmUpBatchSize is the number of topics whose metadata is fetched in one
+// request when warming up, so that the requests won't be too large.
+const warmUpBatchSize = 100
+
+func (c *saramaKafkaClient) WarmUp(topics []string) error {
+	for start := 0; start < len(topics); start += warmUpBatchSize {
+		end := start + warmUpBatchSize
+		if end > len(topics) {
+			end = len(topics)
+		}
+		if err := c.client.RefreshMetadata(topics[start:end]...); err != nil {
+			return err
+		}
+	}
+
+	// The connection to a leader is opened when it's got from the client.
+	leaders := make(map[int32]*sarama.Broker)
+	for _, topic := range topics {
+		partitions, err := c.client.Partitions(topic)
+		if err != nil {
+			return err
+		}
+		for _, partition := range partitions {
+			leader, err := c.client.Leader(topic, partition)
+			if err != nil {
+				return err
+			}
+			leaders[leader.ID()] = leader
+		}
+	}
+	for _, leader := range leaders {
+		// Connected waits until the connection is opened.
+		connected, err := leader.Connected()
+		if err != nil {
+			return err
+		}
+		if !connected {
+			return errors.Errorf("failed to connect to broker %s", leader.Addr())
+		}
+	}
+	return nil
+}
+
 func (c *saramaKafkaClient) Close() error {
 	return c.client.Close()
 }
@@ -129,19 +224,20 @@ type saramaSyncProducer struct {
 }
 
 func (p *saramaSyncProducer) SendMessage(topic string,
-	partitionNum int32, key []byte, value []byte,
+	partitionNum int32, key []byte, value []byte, headers []sarama.RecordHeader,
 ) error {
 	_, _, err := p.producer.SendMessage(&sarama.ProducerMessage{
 		Topic:     topic,
 		Key:       sarama.ByteEncoder(key),
 		Value:     sarama.ByteEncoder(value),
+		Headers:   headers,
 		Partition: partitionNum,
 	})
 	return err
 }
 
 func (p *saramaSyncProducer) SendMessages(topic string,
-	partitionNum int32, key []byte, value []byte,
+	partitionNum int32, key []byte, value []byte, headers []sarama.RecordHeader,
 ) error {
 	msgs := make([]*sarama.ProducerMessage, partitionNum)
 	for i := 0; i < int(partitionNum); i++ {
@@ -149,6 +245,7 @@ func (p *saramaSyncProducer) SendMessages(topic string,
 			Topic:     topic,
 			Key:       sarama.ByteEncoder(key),
 			Value:     sarama.ByteEncoder(value),
+			Headers:   headers,
 			Partition: int32(i),
 		}
 	}
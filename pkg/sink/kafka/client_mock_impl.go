@@ -13,11 +13,18 @@
 
 package kafka
 
-import "github.com/rcrowley/go-metrics"
+import (
+	"context"
+
+	"github.com/Shopify/sarama"
+	"github.com/rcrowley/go-metrics"
+)
 
 // ClientMockImpl is a mock implementation of Client interface.
 type ClientMockImpl struct {
 	topics map[string][]int32
+	// WarmedUpTopics are the topics passed to WarmUp.
+	WarmedUpTopics []string
 }
 
 // NewClientMockImpl creates a new ClientMockImpl instance.
@@ -57,6 +64,18 @@ func (c *ClientMockImpl) DeleteTopic(topicName string) {
 	delete(c.topics, topicName)
 }
 
+// GetOffset returns the offset of the given partition.
+func (c *ClientMockImpl) GetOffset(_ string, _ int32, _ int64) (int64, error) {
+	return 0, nil
+}
+
+// ReadMessages reads messages of the given partition.
+func (c *ClientMockImpl) ReadMessages(
+	_ context.Context, _ string, _ int32, _ int64, _ int,
+) ([]*sarama.ConsumerMessage, error) {
+	return nil, nil
+}
+
 // SyncProducer creates a sync producer
 func (c *ClientMockImpl) SyncProducer() (SyncProducer, error) {
 	return &saramaSyncProducer{}, nil
@@ -72,6 +91,12 @@ func (c *ClientMockImpl) MetricRegistry() metrics.Registry {
 	return metrics.DefaultRegistry
 }
 
+// WarmUp records the topics warmed up.
+func (c *ClientMockImpl) WarmUp(topics []string) error {
+	c.WarmedUpTopics = append(c.WarmedUpTopics, topics...)
+	return nil
+}
+
 // Close closes the client
 func (c *ClientMockImpl) Close() error {
 	return nil
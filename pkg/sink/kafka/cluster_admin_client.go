@@ -14,9 +14,22 @@
 package kafka
 
 import (
+	"errors"
+
 	"github.com/Shopify/sarama"
 )
 
+// IsTopicExistsError returns true if the error of creating a topic is caused
+// by the existing topic, the error is either the error code itself or the
+// error of the topic carrying the code.
+func IsTopicExistsError(err error) bool {
+	if errors.Is(err, sarama.ErrTopicAlreadyExists) {
+		return true
+	}
+	var topicErr *sarama.TopicError
+	return errors.As(err, &topicErr) && topicErr.Err == sarama.ErrTopicAlreadyExists
+}
+
 // ClusterAdminClient is the administrative client for Kafka, which supports managing and inspecting topics,
 // brokers, configurations and ACLs.
 type ClusterAdminClient interface {
@@ -30,6 +43,12 @@ type ClusterAdminClient interface {
 	DescribeTopics(topics []string) (metadata []*sarama.TopicMetadata, err error)
 	// CreateTopic creates a new topic.
 	CreateTopic(topic string, detail *sarama.TopicDetail, validateOnly bool) error
+	// DeleteTopic deletes a topic.
+	DeleteTopic(topic string) error
+	// ListConsumerGroupOffsets fetches the committed offsets of a consumer group
+	// for the given topic partitions.
+	ListConsumerGroupOffsets(group string,
+		topicPartitions map[string][]int32) (*sarama.OffsetFetchResponse, error)
 	// Close shuts down the admin and closes underlying client.
 	Close() error
 }
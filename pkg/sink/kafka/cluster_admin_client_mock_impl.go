@@ -62,6 +62,9 @@ type ClusterAdminClientMockImpl struct {
 	// Cluster controller ID.
 	controllerID  int32
 	brokerConfigs []sarama.ConfigEntry
+	// consumerGroupOffsets is the committed offsets of consumer groups,
+	// indexed by group, topic and partition.
+	consumerGroupOffsets map[string]map[string]map[int32]int64
 }
 
 // NewClusterAdminClientMockImpl news a ClusterAdminClientMockImpl struct with default configurations.
@@ -93,6 +96,8 @@ func NewClusterAdminClientMockImpl() *ClusterAdminClientMockImpl {
 		topics:        topics,
 		controllerID:  defaultMockControllerID,
 		brokerConfigs: brokerConfigs,
+
+		consumerGroupOffsets: make(map[string]map[string]map[int32]int64),
 	}
 }
 
@@ -200,6 +205,48 @@ func (c *ClusterAdminClientMockImpl) CreateTopic(topic string, detail *sarama.To
 	return nil
 }
 
+// DeleteTopic deletes the topic from the map, it also simulates the topics
+// deleted out-of-band.
+func (c *ClusterAdminClientMockImpl) DeleteTopic(topic string) error {
+	if _, ok := c.topics[topic]; !ok {
+		return sarama.ErrUnknownTopicOrPartition
+	}
+	delete(c.topics, topic)
+	return nil
+}
+
+// ListConsumerGroupOffsets returns the offsets set by SetConsumerGroupOffset.
+func (c *ClusterAdminClientMockImpl) ListConsumerGroupOffsets(group string,
+	topicPartitions map[string][]int32,
+) (*sarama.OffsetFetchResponse, error) {
+	resp := &sarama.OffsetFetchResponse{}
+	for topic, partitions := range topicPartitions {
+		for _, partition := range partitions {
+			offset, ok := c.consumerGroupOffsets[group][topic][partition]
+			if !ok {
+				offset = -1
+			}
+			resp.AddBlock(topic, partition, &sarama.OffsetFetchResponseBlock{
+				Offset: offset,
+			})
+		}
+	}
+	return resp, nil
+}
+
+// SetConsumerGroupOffset sets the committed offset of a consumer group.
+func (c *ClusterAdminClientMockImpl) SetConsumerGroupOffset(group string,
+	topic string, partition int32, offset int64,
+) {
+	if c.consumerGroupOffsets[group] == nil {
+		c.consumerGroupOffsets[group] = make(map[string]map[int32]int64)
+	}
+	if c.consumerGroupOffsets[group][topic] == nil {
+		c.consumerGroupOffsets[group][topic] = make(map[int32]int64)
+	}
+	c.consumerGroupOffsets[group][topic][partition] = offset
+}
+
 // Close do nothing.
 func (c *ClusterAdminClientMockImpl) Close() error {
 	return nil
@@ -0,0 +1,32 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/pingcap/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsTopicExistsError(t *testing.T) {
+	t.Parallel()
+
+	require.True(t, IsTopicExistsError(sarama.ErrTopicAlreadyExists))
+	require.True(t, IsTopicExistsError(&sarama.TopicError{Err: sarama.ErrTopicAlreadyExists}))
+	require.False(t, IsTopicExistsError(&sarama.TopicError{Err: sarama.ErrInvalidTopic}))
+	require.False(t, IsTopicExistsError(sarama.ErrInvalidReplicationFactor))
+	require.False(t, IsTopicExistsError(errors.New(sarama.ErrTopicAlreadyExists.Error())))
+}
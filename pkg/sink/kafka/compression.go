@@ -0,0 +1,88 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"github.com/Shopify/sarama"
+	"github.com/pingcap/tiflow/pkg/compression"
+)
+
+// CompressionHeaderKey is the key of the record header naming the codec
+// which compresses the value of a message in the producer.
+const CompressionHeaderKey = "ticdc-compression"
+
+// PayloadCodec returns the codec compressing the message values in the
+// producers, it's nil if the messages are not compressed or compressed by
+// sarama, which is the case of the codecs supported by the Kafka protocol.
+func PayloadCodec(name string) compression.Codec {
+	codec, err := compression.Get(name)
+	if err != nil {
+		return nil
+	}
+	if _, ok := codec.(compression.KafkaCodec); ok {
+		return nil
+	}
+	return codec
+}
+
+// CompressPayload compresses the value of a message with the codec and
+// appends the header naming the codec, so that the consumers can decompress
+// it. The value is returned as is if the codec is nil.
+func CompressPayload(
+	codec compression.Codec, value []byte, headers []sarama.RecordHeader,
+) ([]byte, []sarama.RecordHeader, error) {
+	if codec == nil {
+		return value, headers, nil
+	}
+	compressed, err := compression.Compress(compression.ComponentKafka, codec, value)
+	if err != nil {
+		return nil, nil, err
+	}
+	headers = append(headers, sarama.RecordHeader{
+		Key:   []byte(CompressionHeaderKey),
+		Value: []byte(codec.Name()),
+	})
+	return compressed, headers, nil
+}
+
+// DecompressPayload decompresses the value of a consumed message with the
+// codec named by its headers, the value is returned as is if it's not
+// compressed by the producer.
+func DecompressPayload(value []byte, headers []*sarama.RecordHeader) ([]byte, error) {
+	for _, header := range headers {
+		if string(header.Key) != CompressionHeaderKey {
+			continue
+		}
+		codec, err := compression.Get(string(header.Value))
+		if err != nil {
+			return nil, err
+		}
+		return compression.Decompress(compression.ComponentKafka, codec, value)
+	}
+	return value, nil
+}
+
+// RecordBatchCodec returns the codec compressing the record batches in
+// sarama, it's nil if the messages are not compressed or compressed by the
+// producers.
+func RecordBatchCodec(name string) compression.Codec {
+	codec, err := compression.Get(name)
+	if err != nil || codec.Name() == compression.None {
+		return nil
+	}
+	if _, ok := codec.(compression.KafkaCodec); !ok {
+		return nil
+	}
+	return codec
+}
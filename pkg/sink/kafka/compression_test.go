@@ -0,0 +1,92 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/pingcap/tiflow/cdc/contextutil"
+	"github.com/pingcap/tiflow/pkg/compression"
+	"github.com/stretchr/testify/require"
+)
+
+// reverseCodec is a codec not supported by the Kafka protocol.
+type reverseCodec struct{}
+
+func (reverseCodec) Name() string      { return "reverse" }
+func (reverseCodec) Extension() string { return ".rev" }
+
+func (reverseCodec) Compress(data []byte) ([]byte, error) {
+	res := make([]byte, len(data))
+	for i, b := range data {
+		res[len(data)-1-i] = b
+	}
+	return res, nil
+}
+
+func (c reverseCodec) Decompress(data []byte) ([]byte, error) {
+	return c.Compress(data)
+}
+
+func init() {
+	compression.Register(reverseCodec{})
+}
+
+func TestPayloadCompression(t *testing.T) {
+	t.Parallel()
+
+	for _, name := range []string{"", "none", "gzip", "zstd", "unknown"} {
+		require.Nil(t, PayloadCodec(name), name)
+	}
+	codec := PayloadCodec("reverse")
+	require.NotNil(t, codec)
+	require.Nil(t, RecordBatchCodec("reverse"))
+	require.Nil(t, RecordBatchCodec("none"))
+	require.Equal(t, compression.ZSTD, RecordBatchCodec("zstd").Name())
+
+	// The codecs out of the Kafka protocol don't compress the record batches.
+	options := NewOptions()
+	options.ClientID = "test-kafka-client"
+	options.Compression = "reverse"
+	cfg, err := NewSaramaConfig(contextutil.SetOwnerInCtx(context.Background()), options)
+	require.Nil(t, err)
+	require.Equal(t, sarama.CompressionNone, cfg.Producer.Compression)
+
+	headers := []sarama.RecordHeader{{Key: []byte("k"), Value: []byte("v")}}
+	value, headers, err := CompressPayload(codec, []byte("abc"), headers)
+	require.Nil(t, err)
+	require.Equal(t, []byte("cba"), value)
+	require.Len(t, headers, 2)
+	require.Equal(t, CompressionHeaderKey, string(headers[1].Key))
+
+	consumed := make([]*sarama.RecordHeader, 0, len(headers))
+	for i := range headers {
+		consumed = append(consumed, &headers[i])
+	}
+	value, err = DecompressPayload(value, consumed)
+	require.Nil(t, err)
+	require.Equal(t, []byte("abc"), value)
+
+	// The values without the header are not compressed by the producer.
+	value, err = DecompressPayload([]byte("abc"), consumed[:1])
+	require.Nil(t, err)
+	require.Equal(t, []byte("abc"), value)
+
+	value, headers, err = CompressPayload(nil, []byte("abc"), nil)
+	require.Nil(t, err)
+	require.Equal(t, []byte("abc"), value)
+	require.Empty(t, headers)
+}
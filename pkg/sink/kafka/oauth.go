@@ -0,0 +1,95 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/pingcap/log"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/security"
+	"go.uber.org/zap"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+)
+
+const (
+	// tokenRefreshMargin is the time before the expiry of a token when it's
+	// refreshed, so that the brokers never see an expired token.
+	tokenRefreshMargin = time.Minute
+	// tokenRequestTimeout is the timeout of requesting a token from the
+	// token endpoint.
+	tokenRequestTimeout = 10 * time.Second
+)
+
+var _ sarama.AccessTokenProvider = (*tokenProvider)(nil)
+
+// tokenProvider provides the OAuth tokens for sasl-oauthbearer, the tokens
+// are fetched by the client credentials flow and cached until they are
+// about to expire.
+type tokenProvider struct {
+	mu sync.Mutex
+
+	cfg        *clientcredentials.Config
+	extensions map[string]string
+	token      *oauth2.Token
+}
+
+func newTokenProvider(o *security.OAuth2) *tokenProvider {
+	return &tokenProvider{
+		cfg: &clientcredentials.Config{
+			ClientID:     o.ClientID,
+			ClientSecret: o.ClientSecret,
+			TokenURL:     o.TokenURL,
+			Scopes:       o.Scopes,
+		},
+		extensions: o.Extensions,
+	}
+}
+
+// Token implements sarama.AccessTokenProvider, it's called by sarama when
+// it authenticates with a broker.
+func (p *tokenProvider) Token() (*sarama.AccessToken, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.needRefresh() {
+		ctx, cancel := context.WithTimeout(context.Background(), tokenRequestTimeout)
+		defer cancel()
+		token, err := p.cfg.Token(ctx)
+		if err != nil {
+			return nil, cerror.WrapError(cerror.ErrKafkaFetchOAuthToken, err)
+		}
+		p.token = token
+		log.Info("kafka oauth token refreshed",
+			zap.String("tokenURL", p.cfg.TokenURL),
+			zap.Time("expiry", token.Expiry))
+	}
+	return &sarama.AccessToken{Token: p.token.AccessToken, Extensions: p.extensions}, nil
+}
+
+// needRefresh returns whether there is no token or the token is about to
+// expire, a token without expiry never expires.
+func (p *tokenProvider) needRefresh() bool {
+	if p.token == nil {
+		return true
+	}
+	if p.token.Expiry.IsZero() {
+		return false
+	}
+	return time.Until(p.token.Expiry) < tokenRefreshMargin
+}
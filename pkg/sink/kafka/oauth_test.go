@@ -0,0 +1,95 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pingcap/tiflow/pkg/security"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenProvider(t *testing.T) {
+	t.Parallel()
+
+	var (
+		requests  int32
+		expiresIn int32 = 3600
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Nil(t, r.ParseForm())
+		require.Equal(t, "client_credentials", r.PostForm.Get("grant_type"))
+		require.Equal(t, "scope-a scope-b", r.PostForm.Get("scope"))
+		user, password, ok := r.BasicAuth()
+		require.True(t, ok)
+		require.Equal(t, "client", user)
+		require.Equal(t, "secret", password)
+
+		n := atomic.AddInt32(&requests, 1)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"access_token":"token-%d","token_type":"bearer","expires_in":%d}`,
+			n, atomic.LoadInt32(&expiresIn))
+	}))
+	defer server.Close()
+
+	provider := newTokenProvider(&security.OAuth2{
+		ClientID:     "client",
+		ClientSecret: "secret",
+		TokenURL:     server.URL,
+		Scopes:       []string{"scope-a", "scope-b"},
+		Extensions:   map[string]string{"logicalCluster": "lkc-1"},
+	})
+	token, err := provider.Token()
+	require.Nil(t, err)
+	require.Equal(t, "token-1", token.Token)
+	require.Equal(t, map[string]string{"logicalCluster": "lkc-1"}, token.Extensions)
+
+	// the token is cached until it's about to expire.
+	token, err = provider.Token()
+	require.Nil(t, err)
+	require.Equal(t, "token-1", token.Token)
+	require.Equal(t, int32(1), atomic.LoadInt32(&requests))
+
+	// the token expiring within the refresh margin is refreshed.
+	atomic.StoreInt32(&expiresIn, 30)
+	provider.token.Expiry = provider.token.Expiry.Add(-time.Hour)
+	token, err = provider.Token()
+	require.Nil(t, err)
+	require.Equal(t, "token-2", token.Token)
+	token, err = provider.Token()
+	require.Nil(t, err)
+	require.Equal(t, "token-3", token.Token)
+}
+
+func TestTokenProviderError(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error":"invalid_client"}`, http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	provider := newTokenProvider(&security.OAuth2{
+		ClientID:     "client",
+		ClientSecret: "wrong",
+		TokenURL:     server.URL,
+	})
+	_, err := provider.Token()
+	require.Regexp(t, "kafka fetch oauth token failed", err)
+}
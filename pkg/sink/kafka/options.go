@@ -15,6 +15,7 @@ package kafka
 
 import (
 	"fmt"
+	"net"
 	"net/url"
 	"regexp"
 	"strconv"
@@ -26,14 +27,35 @@ import (
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/pkg/config"
 	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/proxy"
 	"github.com/pingcap/tiflow/pkg/security"
+	"github.com/pingcap/tiflow/pkg/sink"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// The modes of emitting the tombstones, i.e. the messages with null values,
+// for the delete events.
+const (
+	// TombstoneNone emits no tombstone.
+	TombstoneNone = "none"
+	// TombstoneAfterDelete emits a tombstone after the message of a delete
+	// event, so that the consumers still receive the deleted rows.
+	TombstoneAfterDelete = "after-delete"
+	// TombstoneReplace emits a tombstone instead of the message of a delete
+	// event.
+	TombstoneReplace = "replace"
 )
 
 // Options stores user specified configurations
 type Options struct {
 	BrokerEndpoints []string
 	PartitionNum    int32
+	// the domain name whose SRV records are resolved to the brokers, it's set
+	// by the kafka+srv scheme and the records are resolved again at most
+	// once per SRVResolveInterval
+	SRVName            string
+	SRVResolveInterval time.Duration
 
 	// User should make sure that `replication-factor` not greater than the number of kafka brokers.
 	ReplicationFactor int16
@@ -45,8 +67,56 @@ type Options struct {
 	EnableTLS       bool
 	Credential      *security.Credential
 	SASL            *security.SASL
+	// the proxy to connect to the brokers, the brokers are connected
+	// directly if it is nil
+	Proxy *url.URL
 	// control whether to create topic
 	AutoCreate bool
+	// control whether to read the produced messages back to verify the sink
+	// after the changefeed is created
+	SelfCheck bool
+	// the consumer group of the downstream consumers, its lag is polled
+	// and exposed in metrics if it is not empty
+	ConsumerGroup string
+	// the compacted topic which the schema history of the changefeed is
+	// maintained in, the schema history is disabled if it is empty
+	SchemaHistoryTopic string
+	// control whether to fetch the metadata and connect to the leaders of
+	// all the routed topics before the first checkpoint is emitted
+	WarmUp bool
+	// control whether to attach the sequence positions of the rows and the
+	// DDLs to the messages, so that the downstream consumers can detect the
+	// gaps and duplicates, see common.Sequencer
+	SequenceNumber bool
+	// control whether to enable the idempotent producer, so that the retries
+	// of the producer don't write duplicated messages to a partition.
+	// Note: it's only a part of the exactly-once delivery, the messages
+	// replayed after a restart are still written again. The transactional
+	// producer committing at the checkpoints is not supported, since it
+	// needs sarama v1.37.0 or later. The consumers can dedup the replays by
+	// the sequence positions, see SequenceNumber.
+	Idempotent bool
+	// control whether to share the kafka clients with the other changefeeds
+	// connecting to the same cluster with the same identity, which reduces
+	// the connections to the brokers. The kafka metrics of the changefeeds
+	// sharing a client are the ones of the whole client.
+	ShareClient bool
+	// control whether to pause sending the messages instead of failing the
+	// changefeed when the brokers are unavailable, the sink is reported as
+	// paused once they are unavailable longer than UnavailableThreshold,
+	// and at most UnavailableBufferSize messages are buffered meanwhile
+	PauseOnUnavailable    bool
+	UnavailableThreshold  time.Duration
+	UnavailableBufferSize int
+	// the tombstones emitted for the delete events, so that the deleted
+	// keys are removed by the log compaction of the topics, see the
+	// Tombstone* modes. The tombstones are emitted after TombstoneDelay
+	// in the after-delete mode.
+	Tombstone      string
+	TombstoneDelay time.Duration
+	// the retry budget of sending the messages, it's set by the sink config
+	// instead of the sink URI, the defaults are used if it is nil
+	RetryBudget *config.RetryBudgetConfig
 
 	// Timeout for network configurations, default to `10s`
 	DialTimeout  time.Duration
@@ -54,6 +124,74 @@ type Options struct {
 	ReadTimeout  time.Duration
 }
 
+// MarshalLogObject implements zapcore.ObjectMarshaler, the SASL passwords are
+// masked so that the options can be logged safely.
+func (o *Options) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddString("brokerEndpoints", strings.Join(o.BrokerEndpoints, ","))
+	enc.AddString("srvName", o.SRVName)
+	enc.AddDuration("srvResolveInterval", o.SRVResolveInterval)
+	enc.AddInt32("partitionNum", o.PartitionNum)
+	enc.AddInt16("replicationFactor", o.ReplicationFactor)
+	enc.AddString("version", o.Version)
+	enc.AddInt("maxMessageBytes", o.MaxMessageBytes)
+	enc.AddString("compression", o.Compression)
+	enc.AddString("clientID", o.ClientID)
+	enc.AddBool("enableTLS", o.EnableTLS)
+	if o.Proxy != nil {
+		enc.AddString("proxy", o.Proxy.Redacted())
+	}
+	if o.Credential != nil {
+		enc.AddString("caPath", o.Credential.CAPath)
+		enc.AddString("certPath", o.Credential.CertPath)
+		enc.AddString("keyPath", o.Credential.KeyPath)
+	}
+	if o.SASL != nil {
+		enc.AddString("saslUser", o.SASL.SASLUser)
+		enc.AddString("saslPassword", maskSecret(o.SASL.SASLPassword))
+		enc.AddString("saslMechanism", string(o.SASL.SASLMechanism))
+		enc.AddString("saslGSSAPIUser", o.SASL.GSSAPI.Username)
+		enc.AddString("saslGSSAPIPassword", maskSecret(o.SASL.GSSAPI.Password))
+		enc.AddString("saslGSSAPIKeyTabPath", o.SASL.GSSAPI.KeyTabPath)
+		enc.AddString("saslGSSAPIRealm", o.SASL.GSSAPI.Realm)
+		enc.AddString("saslOAuthClientID", o.SASL.OAuth2.ClientID)
+		enc.AddString("saslOAuthClientSecret", maskSecret(o.SASL.OAuth2.ClientSecret))
+		enc.AddString("saslOAuthTokenURL", o.SASL.OAuth2.TokenURL)
+		enc.AddString("saslOAuthScopes", strings.Join(o.SASL.OAuth2.Scopes, ","))
+		enc.AddString("saslAWSRegion", o.SASL.AWSMSKIAM.Region)
+	}
+	enc.AddBool("autoCreate", o.AutoCreate)
+	enc.AddBool("selfCheck", o.SelfCheck)
+	enc.AddString("consumerGroup", o.ConsumerGroup)
+	enc.AddString("schemaHistoryTopic", o.SchemaHistoryTopic)
+	enc.AddBool("warmUp", o.WarmUp)
+	enc.AddBool("sequenceNumber", o.SequenceNumber)
+	enc.AddBool("idempotent", o.Idempotent)
+	enc.AddBool("shareClient", o.ShareClient)
+	enc.AddBool("pauseOnUnavailable", o.PauseOnUnavailable)
+	enc.AddDuration("unavailableThreshold", o.UnavailableThreshold)
+	enc.AddInt("unavailableBufferSize", o.UnavailableBufferSize)
+	enc.AddString("tombstone", o.Tombstone)
+	enc.AddDuration("tombstoneDelay", o.TombstoneDelay)
+	if o.RetryBudget != nil {
+		enc.AddUint64("retryMaxAttempts", o.RetryBudget.MaxAttempts)
+		enc.AddDuration("retryMaxDuration", o.RetryBudget.MaxDuration)
+		enc.AddDuration("retryBackoffBaseDelay", o.RetryBudget.BackoffBaseDelay)
+		enc.AddDuration("retryBackoffMaxDelay", o.RetryBudget.BackoffMaxDelay)
+		enc.AddString("retryOnExhausted", o.RetryBudget.OnExhausted)
+	}
+	enc.AddDuration("dialTimeout", o.DialTimeout)
+	enc.AddDuration("writeTimeout", o.WriteTimeout)
+	enc.AddDuration("readTimeout", o.ReadTimeout)
+	return nil
+}
+
+func maskSecret(secret string) string {
+	if secret == "" {
+		return ""
+	}
+	return "xxxxx"
+}
+
 // NewOptions returns a default Kafka configuration
 func NewOptions() *Options {
 	return &Options{
@@ -68,6 +206,14 @@ func NewOptions() *Options {
 		DialTimeout:       10 * time.Second,
 		WriteTimeout:      10 * time.Second,
 		ReadTimeout:       10 * time.Second,
+		// Brokers replaced in containerized deployments can be picked up
+		// quickly, while the DNS servers are not overwhelmed.
+		SRVResolveInterval: 30 * time.Second,
+		// The brokers restarted one by one in a rolling maintenance are
+		// not reported as a paused sink.
+		UnavailableThreshold:  time.Minute,
+		UnavailableBufferSize: 10240,
+		Tombstone:             TombstoneNone,
 	}
 }
 
@@ -102,9 +248,32 @@ func (c *Options) SetPartitionNum(realPartitionCount int32) error {
 
 // Apply the sinkURI to update Options
 func (c *Options) Apply(sinkURI *url.URL) error {
-	c.BrokerEndpoints = strings.Split(sinkURI.Host, ",")
 	params := sinkURI.Query()
-	s := params.Get("partition-num")
+	if sinkURI.Scheme == sink.KafkaSRVScheme {
+		c.SRVName = sinkURI.Hostname()
+		if c.SRVName == "" {
+			return cerror.ErrKafkaInvalidConfig.GenWithStack(
+				"the domain name of the SRV records should be supplied")
+		}
+		c.BrokerEndpoints = []string{srvSeedAddr(c.SRVName)}
+	} else {
+		endpoints, err := parseBrokerEndpoints(sinkURI.Host)
+		if err != nil {
+			return err
+		}
+		c.BrokerEndpoints = endpoints
+	}
+
+	s := params.Get("srv-resolve-interval")
+	if s != "" {
+		a, err := time.ParseDuration(s)
+		if err != nil {
+			return err
+		}
+		c.SRVResolveInterval = a
+	}
+
+	s = params.Get("partition-num")
 	if s != "" {
 		a, err := strconv.ParseInt(s, 10, 32)
 		if err != nil {
@@ -155,6 +324,136 @@ func (c *Options) Apply(sinkURI *url.URL) error {
 		c.AutoCreate = autoCreate
 	}
 
+	s = params.Get("self-check")
+	if s != "" {
+		selfCheck, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		c.SelfCheck = selfCheck
+	}
+
+	c.ConsumerGroup = params.Get("consumer-group")
+	c.SchemaHistoryTopic = params.Get("schema-history-topic")
+
+	s = params.Get("warm-up")
+	if s != "" {
+		warmUp, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		c.WarmUp = warmUp
+	}
+
+	s = params.Get("sequence-number")
+	if s != "" {
+		sequenceNumber, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		c.SequenceNumber = sequenceNumber
+	}
+
+	s = params.Get("enable-idempotence")
+	if s != "" {
+		idempotent, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		c.Idempotent = idempotent
+	}
+
+	// The transactional producer is not available in sarama v1.36.0, so the
+	// exactly-once delivery is not supported and the messages replayed after
+	// a restart may still be duplicated, reject the option instead of
+	// ignoring it.
+	s = params.Get("enable-transaction")
+	if s != "" {
+		transactional, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		if transactional {
+			return cerror.ErrKafkaInvalidConfig.GenWithStack(
+				"the transactional producer and the exactly-once delivery are not supported, " +
+					"use enable-idempotence to avoid the duplicates written by the retries of the producer, " +
+					"and sequence-number to dedup the messages replayed after a restart")
+		}
+	}
+
+	s = params.Get("share-client")
+	if s != "" {
+		shareClient, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		c.ShareClient = shareClient
+	}
+
+	s = params.Get("pause-on-unavailable")
+	if s != "" {
+		pause, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		c.PauseOnUnavailable = pause
+	}
+
+	s = params.Get("unavailable-threshold")
+	if s != "" {
+		a, err := time.ParseDuration(s)
+		if err != nil {
+			return err
+		}
+		if a <= 0 {
+			return cerror.ErrKafkaInvalidConfig.GenWithStack(
+				"unavailable-threshold should be positive, got %s", s)
+		}
+		c.UnavailableThreshold = a
+	}
+
+	s = params.Get("unavailable-buffer-size")
+	if s != "" {
+		a, err := strconv.Atoi(s)
+		if err != nil {
+			return err
+		}
+		if a <= 0 {
+			return cerror.ErrKafkaInvalidConfig.GenWithStack(
+				"unavailable-buffer-size should be positive, got %s", s)
+		}
+		c.UnavailableBufferSize = a
+	}
+
+	s = params.Get("tombstone")
+	if s != "" {
+		switch strings.ToLower(s) {
+		case TombstoneNone, TombstoneAfterDelete, TombstoneReplace:
+			c.Tombstone = strings.ToLower(s)
+		default:
+			return cerror.ErrKafkaInvalidConfig.GenWithStack(
+				"unsupported tombstone %s, it should be one of %s, %s and %s",
+				s, TombstoneNone, TombstoneAfterDelete, TombstoneReplace)
+		}
+	}
+
+	s = params.Get("tombstone-delay")
+	if s != "" {
+		a, err := time.ParseDuration(s)
+		if err != nil {
+			return err
+		}
+		if a < 0 {
+			return cerror.ErrKafkaInvalidConfig.GenWithStack(
+				"tombstone-delay should not be negative, got %s", s)
+		}
+		if c.Tombstone != TombstoneAfterDelete {
+			return cerror.ErrKafkaInvalidConfig.GenWithStack(
+				"tombstone-delay is only supported by the %s tombstone", TombstoneAfterDelete)
+		}
+		c.TombstoneDelay = a
+	}
+
 	s = params.Get("dial-timeout")
 	if s != "" {
 		a, err := time.ParseDuration(s)
@@ -192,6 +491,11 @@ func (c *Options) Apply(sinkURI *url.URL) error {
 		return err
 	}
 
+	c.Proxy, err = proxy.FromSinkURI(sinkURI)
+	if err != nil {
+		return cerror.WrapError(cerror.ErrKafkaInvalidConfig, err)
+	}
+
 	return nil
 }
 
@@ -312,14 +616,130 @@ func (c *Options) applySASL(params url.Values) error {
 		c.SASL.GSSAPI.DisablePAFXFAST = disablePAFXFAST
 	}
 
+	s = params.Get("sasl-aws-region")
+	if s != "" {
+		c.SASL.AWSMSKIAM.Region = s
+	}
+
+	return c.applySASLOAuth(params)
+}
+
+func (c *Options) applySASLOAuth(params url.Values) error {
+	s := params.Get("sasl-oauth-client-id")
+	if s != "" {
+		c.SASL.OAuth2.ClientID = s
+	}
+
+	s = params.Get("sasl-oauth-client-secret")
+	if s != "" {
+		c.SASL.OAuth2.ClientSecret = s
+	}
+
+	s = params.Get("sasl-oauth-token-url")
+	if s != "" {
+		c.SASL.OAuth2.TokenURL = s
+	}
+
+	// the scopes and the extensions are separated by commas, and the
+	// extensions are in the form of key=value.
+	s = params.Get("sasl-oauth-scopes")
+	if s != "" {
+		for _, scope := range strings.Split(s, ",") {
+			if scope = strings.TrimSpace(scope); scope != "" {
+				c.SASL.OAuth2.Scopes = append(c.SASL.OAuth2.Scopes, scope)
+			}
+		}
+	}
+
+	s = params.Get("sasl-oauth-extensions")
+	if s != "" {
+		c.SASL.OAuth2.Extensions = make(map[string]string)
+		for _, extension := range strings.Split(s, ",") {
+			kv := strings.SplitN(extension, "=", 2)
+			if len(kv) != 2 || strings.TrimSpace(kv[0]) == "" {
+				return cerror.WrapError(cerror.ErrKafkaInvalidConfig,
+					errors.Errorf("invalid sasl-oauth-extensions %s", s))
+			}
+			c.SASL.OAuth2.Extensions[strings.TrimSpace(kv[0])] = strings.TrimSpace(kv[1])
+		}
+	}
+
+	if c.SASL.SASLMechanism == security.OAuthMechanism {
+		if err := c.SASL.OAuth2.Validate(); err != nil {
+			return cerror.WrapError(cerror.ErrKafkaInvalidConfig, err)
+		}
+	}
 	return nil
 }
 
+// defaultBrokerPort is the port of a broker endpoint without port.
+const defaultBrokerPort = "9092"
+
+// parseBrokerEndpoints splits the broker endpoints in the host of the sink
+// uri. IPv6 literals should be enclosed in square brackets, and the default
+// port is used if the port of an endpoint is omitted.
+func parseBrokerEndpoints(host string) ([]string, error) {
+	endpoints := make([]string, 0)
+	for _, endpoint := range strings.Split(host, ",") {
+		endpoint = strings.TrimSpace(endpoint)
+		if endpoint == "" {
+			continue
+		}
+		h, port, err := net.SplitHostPort(endpoint)
+		if err != nil {
+			h, port = endpoint, defaultBrokerPort
+			if strings.HasPrefix(h, "[") && strings.HasSuffix(h, "]") {
+				h = h[1 : len(h)-1]
+			} else if strings.Contains(h, ":") {
+				return nil, cerror.ErrKafkaInvalidConfig.GenWithStack(
+					"invalid broker endpoint %s, IPv6 addresses should be "+
+						"enclosed in square brackets like [::1]:9092", endpoint)
+			}
+		}
+		if _, err := strconv.ParseUint(port, 10, 16); err != nil || h == "" {
+			return nil, cerror.ErrKafkaInvalidConfig.GenWithStack(
+				"invalid broker endpoint %s", endpoint)
+		}
+		endpoints = append(endpoints, net.JoinHostPort(h, port))
+	}
+	if len(endpoints) == 0 {
+		return nil, cerror.ErrKafkaInvalidConfig.GenWithStack(
+			"the broker endpoints should be supplied")
+	}
+	return endpoints, nil
+}
+
 // AutoCreateTopicConfig is used to create topic configuration.
 type AutoCreateTopicConfig struct {
 	AutoCreate        bool
 	PartitionNum      int32
 	ReplicationFactor int16
+	// Overrides resolves the overrides of the topics, nil means no override.
+	Overrides TopicOverrides
+}
+
+// TopicOverrides resolves the partition number and the replication factor
+// used to create a topic instead of the ones in the sink URI, zero means
+// no override.
+type TopicOverrides interface {
+	TopicOverride(topic string) (partitionNum int32, replicationFactor int16)
+}
+
+// TopicDetail returns the partition number and the replication factor
+// used to create the topic.
+func (c *AutoCreateTopicConfig) TopicDetail(topic string) (int32, int16) {
+	partitionNum, replicationFactor := c.PartitionNum, c.ReplicationFactor
+	if c.Overrides == nil {
+		return partitionNum, replicationFactor
+	}
+	p, r := c.Overrides.TopicOverride(topic)
+	if p > 0 {
+		partitionNum = p
+	}
+	if r > 0 {
+		replicationFactor = r
+	}
+	return partitionNum, replicationFactor
 }
 
 // DeriveTopicConfig derive a `topicConfig` from the `Options`
@@ -341,7 +761,12 @@ func newKafkaClientID(role, captureAddr string,
 	configuredClientID string,
 ) (clientID string, err error) {
 	if configuredClientID != "" {
-		clientID = configuredClientID
+		// The placeholders are replaced by the sanitized values, the
+		// invalid characters in the template itself are reported.
+		clientID = config.ExpandClientIdentity(configuredClientID,
+			commonInvalidChar.ReplaceAllString(changefeedID.Namespace, "_"),
+			commonInvalidChar.ReplaceAllString(changefeedID.ID, "_"),
+			commonInvalidChar.ReplaceAllString(captureAddr, "_"))
 	} else {
 		clientID = fmt.Sprintf("TiCDC_producer_%s_%s_%s_%s",
 			role, captureAddr, changefeedID.Namespace, changefeedID.ID)
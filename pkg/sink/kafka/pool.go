@@ -0,0 +1,245 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sort"
+	"sync"
+
+	"github.com/Shopify/sarama"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/pkg/security"
+	"github.com/rcrowley/go-metrics"
+	"go.uber.org/zap"
+)
+
+// sharedClientIDPrefix is the prefix of the client ID of the shared clients,
+// which aren't owned by any changefeed.
+const sharedClientIDPrefix = "ticdc_shared_"
+
+// clientPool shares the sarama clients among the changefeeds connecting to
+// the same Kafka cluster with the same identity and producer configurations.
+// The clients are reference counted, and a client is closed when it's not
+// used by any changefeed.
+type clientPool struct {
+	mu        sync.Mutex
+	clients   map[string]*pooledClient
+	newClient func(addrs []string, conf *sarama.Config) (sarama.Client, error)
+}
+
+type pooledClient struct {
+	client sarama.Client
+	refs   int
+}
+
+var sharedClients = newClientPool(sarama.NewClient)
+
+func newClientPool(
+	newClient func(addrs []string, conf *sarama.Config) (sarama.Client, error),
+) *clientPool {
+	return &clientPool{
+		clients:   make(map[string]*pooledClient),
+		newClient: newClient,
+	}
+}
+
+// acquire returns the client of the key, it's created by the config if there
+// is no such client. The client ID of the config is kept if it's a part of
+// the key, otherwise the shared client gets its own client ID.
+func (p *clientPool) acquire(
+	key string, addrs []string, conf *sarama.Config, keepClientID bool,
+) (sarama.Client, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if c, ok := p.clients[key]; ok {
+		c.refs++
+		return c.client, nil
+	}
+
+	// the shared client isn't owned by the changefeed creating it, so its
+	// metrics are of all the changefeeds sharing it.
+	shared := *conf
+	if !keepClientID {
+		shared.ClientID = sharedClientIDPrefix + key[:16]
+	}
+	shared.MetricRegistry = metrics.NewRegistry()
+	client, err := p.newClient(addrs, &shared)
+	if err != nil {
+		return nil, err
+	}
+	p.clients[key] = &pooledClient{client: client, refs: 1}
+	log.Info("shared kafka client created",
+		zap.String("clientID", shared.ClientID), zap.Strings("brokers", addrs))
+	return client, nil
+}
+
+// release releases a reference of the client of the key, the client is
+// closed if it's the last reference.
+func (p *clientPool) release(key string) error {
+	p.mu.Lock()
+	c, ok := p.clients[key]
+	if !ok {
+		p.mu.Unlock()
+		return nil
+	}
+	c.refs--
+	if c.refs > 0 {
+		p.mu.Unlock()
+		return nil
+	}
+	delete(p.clients, key)
+	p.mu.Unlock()
+
+	log.Info("shared kafka client closed",
+		zap.String("clientID", c.client.Config().ClientID))
+	return c.client.Close()
+}
+
+// sharedClientKey identifies the clients which can be shared, they connect to
+// the same brokers with the same identity, and produce the messages by the
+// same producer configurations. The client ID configured by kafka-client-id
+// is a part of the identity, since the brokers enforce the quotas by it, so
+// only the changefeeds with the same configured client ID, or without one,
+// share a client.
+func sharedClientKey(o *Options, addrs []string, conf *sarama.Config) string {
+	brokers := append([]string(nil), addrs...)
+	sort.Strings(brokers)
+	identity := struct {
+		Brokers          []string
+		ClientID         string
+		SRVName          string
+		Version          string
+		EnableTLS        bool
+		Credential       *security.Credential
+		SASL             *security.SASL
+		Proxy            string
+		DialTimeout      string
+		ReadTimeout      string
+		WriteTimeout     string
+		MaxMessageBytes  int
+		Idempotent       bool
+		Compression      sarama.CompressionCodec
+		CompressionLevel int
+		RequiredAcks     sarama.RequiredAcks
+		RetryMax         int
+		RetryBackoff     string
+		MaxOpenRequests  int
+	}{
+		Brokers:          brokers,
+		SRVName:          o.SRVName,
+		Version:          conf.Version.String(),
+		EnableTLS:        o.EnableTLS,
+		Credential:       o.Credential,
+		SASL:             o.SASL,
+		DialTimeout:      conf.Net.DialTimeout.String(),
+		ReadTimeout:      conf.Net.ReadTimeout.String(),
+		WriteTimeout:     conf.Net.WriteTimeout.String(),
+		MaxMessageBytes:  conf.Producer.MaxMessageBytes,
+		Idempotent:       conf.Producer.Idempotent,
+		Compression:      conf.Producer.Compression,
+		CompressionLevel: conf.Producer.CompressionLevel,
+		RequiredAcks:     conf.Producer.RequiredAcks,
+		RetryMax:         conf.Producer.Retry.Max,
+		RetryBackoff:     conf.Producer.Retry.Backoff.String(),
+		MaxOpenRequests:  conf.Net.MaxOpenRequests,
+	}
+	if o.ClientID != "" {
+		identity.ClientID = conf.ClientID
+	}
+	if o.Proxy != nil {
+		identity.Proxy = o.Proxy.String()
+	}
+	// the secrets of the identity are hashed, so the key can be logged.
+	data, err := json.Marshal(identity)
+	if err != nil {
+		log.Panic("fail to marshal the identity of kafka client", zap.Error(err))
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// NewSharedClientCreator returns a ClientCreator which shares the clients
+// among the changefeeds of the same options, so that the number of the
+// connections to the brokers is reduced. The connections and the producer
+// of a shared client serve all the changefeeds sharing it, so are its
+// metrics, which are reported by each of the changefeeds.
+func NewSharedClientCreator(o *Options) ClientCreator {
+	return func(addrs []string, conf *sarama.Config) (Client, error) {
+		key := sharedClientKey(o, addrs, conf)
+		client, err := sharedClients.acquire(key, addrs, conf, o.ClientID != "")
+		if err != nil {
+			return nil, err
+		}
+		return &sharedClient{
+			saramaKafkaClient: saramaKafkaClient{client: client},
+			release:           func() error { return sharedClients.release(key) },
+		}, nil
+	}
+}
+
+// NewSharedAdminClientCreator is like NewSharedClientCreator, but it returns
+// a ClusterAdminClientCreator.
+func NewSharedAdminClientCreator(o *Options) ClusterAdminClientCreator {
+	return func(addrs []string, conf *sarama.Config) (ClusterAdminClient, error) {
+		key := sharedClientKey(o, addrs, conf)
+		client, err := sharedClients.acquire(key, addrs, conf, o.ClientID != "")
+		if err != nil {
+			return nil, err
+		}
+		admin, err := sarama.NewClusterAdminFromClient(client)
+		if err != nil {
+			if releaseErr := sharedClients.release(key); releaseErr != nil {
+				log.Warn("fail to release the shared kafka client", zap.Error(releaseErr))
+			}
+			return nil, errors.Trace(err)
+		}
+		return &sharedAdminClient{
+			ClusterAdmin: admin,
+			release:      func() error { return sharedClients.release(key) },
+		}, nil
+	}
+}
+
+// sharedClient is a reference of a shared client, closing it releases the
+// reference instead of closing the client.
+type sharedClient struct {
+	saramaKafkaClient
+	release func() error
+	once    sync.Once
+}
+
+// Close implements Client.
+func (c *sharedClient) Close() (err error) {
+	c.once.Do(func() { err = c.release() })
+	return
+}
+
+// sharedAdminClient is a ClusterAdminClient of a shared client, closing it
+// releases the reference of the client instead of closing the client.
+type sharedAdminClient struct {
+	sarama.ClusterAdmin
+	release func() error
+	once    sync.Once
+}
+
+// Close implements ClusterAdminClient.
+func (c *sharedAdminClient) Close() (err error) {
+	c.once.Do(func() { err = c.release() })
+	return
+}
@@ -0,0 +1,138 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeSaramaClient struct {
+	sarama.Client
+	conf   *sarama.Config
+	closed bool
+}
+
+func (c *fakeSaramaClient) Config() *sarama.Config {
+	return c.conf
+}
+
+func (c *fakeSaramaClient) Close() error {
+	c.closed = true
+	return nil
+}
+
+func TestClientPool(t *testing.T) {
+	t.Parallel()
+
+	var created []*fakeSaramaClient
+	pool := newClientPool(func(addrs []string, conf *sarama.Config) (sarama.Client, error) {
+		c := &fakeSaramaClient{conf: conf}
+		created = append(created, c)
+		return c, nil
+	})
+
+	conf := sarama.NewConfig()
+	conf.ClientID = "ticdc_changefeed"
+	keyA := "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+	keyB := "bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb"
+	c1, err := pool.acquire(keyA, []string{"127.0.0.1:9092"}, conf, false)
+	require.Nil(t, err)
+	c2, err := pool.acquire(keyA, []string{"127.0.0.1:9092"}, conf, false)
+	require.Nil(t, err)
+	c3, err := pool.acquire(keyB, []string{"127.0.0.1:9092"}, conf, true)
+	require.Nil(t, err)
+	require.Same(t, c1, c2)
+	require.NotSame(t, c1, c3)
+	require.Len(t, created, 2)
+
+	// the shared client has its own metrics, and its own client ID unless
+	// the client ID is a part of the key.
+	require.Equal(t, "ticdc_shared_aaaaaaaaaaaaaaaa", created[0].conf.ClientID)
+	require.NotSame(t, conf.MetricRegistry, created[0].conf.MetricRegistry)
+	require.Equal(t, "ticdc_changefeed", conf.ClientID)
+	require.Equal(t, "ticdc_changefeed", created[1].conf.ClientID)
+
+	// the client is closed when the last reference is released.
+	require.Nil(t, pool.release(keyA))
+	require.False(t, created[0].closed)
+	require.Nil(t, pool.release(keyA))
+	require.True(t, created[0].closed)
+	require.False(t, created[1].closed)
+	require.Nil(t, pool.release(keyA))
+
+	// a new client is created after the previous one is closed.
+	c4, err := pool.acquire(keyA, []string{"127.0.0.1:9092"}, conf, false)
+	require.Nil(t, err)
+	require.NotSame(t, c1, c4)
+	require.Len(t, created, 3)
+}
+
+func TestSharedClientKey(t *testing.T) {
+	t.Parallel()
+
+	newConfig := func(o *Options) *sarama.Config {
+		conf, err := NewSaramaConfig(context.Background(), o)
+		require.Nil(t, err)
+		return conf
+	}
+
+	o1 := NewOptions()
+	o2 := NewOptions()
+	o2.SelfCheck = true
+	key := sharedClientKey(o1, []string{"127.0.0.1:9092", "127.0.0.1:9093"}, newConfig(o1))
+	require.Equal(t, key,
+		sharedClientKey(o2, []string{"127.0.0.1:9093", "127.0.0.1:9092"}, newConfig(o2)))
+
+	// the configured client IDs are a part of the identity.
+	o2.ClientID = "changefeed-2"
+	require.NotEqual(t, key,
+		sharedClientKey(o2, []string{"127.0.0.1:9092", "127.0.0.1:9093"}, newConfig(o2)))
+	o1.ClientID = "changefeed-2"
+	require.Equal(t,
+		sharedClientKey(o1, []string{"127.0.0.1:9092", "127.0.0.1:9093"}, newConfig(o1)),
+		sharedClientKey(o2, []string{"127.0.0.1:9092", "127.0.0.1:9093"}, newConfig(o2)))
+	o1.ClientID = ""
+	o2.ClientID = ""
+
+	// the clients of the other brokers, identities or producer configurations
+	// aren't shared.
+	require.NotEqual(t, key,
+		sharedClientKey(o1, []string{"127.0.0.1:9092"}, newConfig(o1)))
+	o2.SASL.SASLMechanism = "plain"
+	o2.SASL.SASLUser = "user"
+	require.NotEqual(t, key,
+		sharedClientKey(o2, []string{"127.0.0.1:9092", "127.0.0.1:9093"}, newConfig(o2)))
+	o2 = NewOptions()
+	conf := newConfig(o2)
+	conf.Producer.MaxMessageBytes = 1024
+	require.NotEqual(t, key,
+		sharedClientKey(o2, []string{"127.0.0.1:9092", "127.0.0.1:9093"}, conf))
+}
+
+func TestSharedClientClose(t *testing.T) {
+	t.Parallel()
+
+	released := 0
+	c := &sharedClient{release: func() error {
+		released++
+		return nil
+	}}
+	require.Nil(t, c.Close())
+	require.Nil(t, c.Close())
+	require.Equal(t, 1, released)
+}
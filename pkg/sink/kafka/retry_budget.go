@@ -0,0 +1,59 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"github.com/Shopify/sarama"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tiflow/pkg/config"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+)
+
+// EscalateSendError escalates the error of sending the messages by the policy
+// of the retry budget, see config.RetryBudgetConfig.Escalate. The messages are
+// retried by sarama within the budget, so the errors it retries are returned
+// only after the budget is exhausted, while the other errors are returned as
+// they are.
+func EscalateSendError(budget *config.RetryBudgetConfig, err error) error {
+	if err == nil || budget == nil || budget.OnExhausted != config.RetryExhaustedFailed {
+		return err
+	}
+	if !isRetriableSendError(errors.Cause(err)) {
+		return err
+	}
+	return cerror.WrapChangefeedUnretryableErr(err)
+}
+
+// isRetriableSendError returns whether the error is retried by the producer
+// of sarama, the errors other than the ones returned by the brokers are the
+// network errors, which are retried too.
+func isRetriableSendError(err error) bool {
+	switch e := err.(type) {
+	case *sarama.ProducerError:
+		return isRetriableSendError(e.Err)
+	case sarama.ProducerErrors:
+		return len(e) > 0 && isRetriableSendError(e[0].Err)
+	case sarama.KError:
+		switch e {
+		case sarama.ErrUnknownTopicOrPartition, sarama.ErrLeaderNotAvailable,
+			sarama.ErrNotLeaderForPartition, sarama.ErrRequestTimedOut,
+			sarama.ErrNotEnoughReplicas, sarama.ErrNotEnoughReplicasAfterAppend:
+			return true
+		default:
+			return false
+		}
+	default:
+		return true
+	}
+}
@@ -0,0 +1,49 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tiflow/pkg/config"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEscalateSendError(t *testing.T) {
+	t.Parallel()
+
+	budget := &config.RetryBudgetConfig{OnExhausted: config.RetryExhaustedWarning}
+	retried := cerror.WrapError(cerror.ErrKafkaAsyncSendMessage,
+		&sarama.ProducerError{Err: sarama.ErrNotLeaderForPartition})
+	require.Nil(t, EscalateSendError(budget, nil))
+	require.Equal(t, retried, EscalateSendError(budget, retried))
+	require.Equal(t, retried, EscalateSendError(nil, retried))
+
+	budget.OnExhausted = config.RetryExhaustedFailed
+	require.True(t, cerror.IsChangefeedUnRetryableError(EscalateSendError(budget, retried)))
+	// the network errors are retried by sarama too.
+	err := cerror.WrapError(cerror.ErrKafkaSendMessage, errors.New("connection refused"))
+	require.True(t, cerror.IsChangefeedUnRetryableError(EscalateSendError(budget, err)))
+	err = cerror.WrapError(cerror.ErrKafkaSendMessage, sarama.ProducerErrors{
+		{Err: sarama.ErrNotEnoughReplicas},
+	})
+	require.True(t, cerror.IsChangefeedUnRetryableError(EscalateSendError(budget, err)))
+
+	// the errors not retried by sarama are not escalated.
+	err = cerror.WrapError(cerror.ErrKafkaSendMessage, sarama.ErrMessageSizeTooLarge)
+	require.Equal(t, err, EscalateSendError(budget, err))
+}
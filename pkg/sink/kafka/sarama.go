@@ -16,6 +16,8 @@ package kafka
 import (
 	"context"
 	"crypto/tls"
+	"math"
+	"net"
 	"strings"
 	"time"
 
@@ -23,11 +25,84 @@ import (
 	"github.com/pingcap/errors"
 	"github.com/pingcap/log"
 	"github.com/pingcap/tiflow/cdc/contextutil"
+	"github.com/pingcap/tiflow/pkg/compression"
 	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/proxy"
 	"github.com/pingcap/tiflow/pkg/security"
 	"go.uber.org/zap"
 )
 
+// maxUnavailableBackoff is the max backoff of retrying the messages when the
+// sink pauses on unavailable brokers.
+const maxUnavailableBackoff = 10 * time.Second
+
+// applyRetryBudget applies the retry budget of the sink to the producer, the
+// configured fields override the defaults.
+//
+// The messages are retried until the brokers return if the sink pauses on
+// unavailable brokers, the backoff grows so that the brokers are not flooded
+// by the retries once they are back. The retry budget still takes effect in
+// this case if it is set.
+func applyRetryBudget(config *sarama.Config, o *Options) {
+	budget := o.RetryBudget
+	// The attempts of the budget include the first one, while sarama only
+	// counts the retries.
+	if budget != nil && budget.MaxAttempts > 0 {
+		config.Producer.Retry.Max = int(budget.MaxAttempts - 1)
+	} else if o.PauseOnUnavailable {
+		config.Producer.Retry.Max = math.MaxInt32
+	}
+	if budget != nil && budget.BackoffBaseDelay > 0 {
+		config.Producer.Retry.Backoff = budget.BackoffBaseDelay
+	}
+	var maxBackoff time.Duration
+	if o.PauseOnUnavailable {
+		maxBackoff = maxUnavailableBackoff
+	}
+	if budget != nil && budget.BackoffMaxDelay > 0 {
+		maxBackoff = budget.BackoffMaxDelay
+	}
+	if maxBackoff > 0 {
+		base := config.Producer.Retry.Backoff
+		config.Producer.Retry.BackoffFunc = func(retries, _ int) time.Duration {
+			backoff := base
+			for i := 0; i < retries && backoff < maxBackoff; i++ {
+				backoff *= 2
+			}
+			if backoff > maxBackoff {
+				backoff = maxBackoff
+			}
+			return backoff
+		}
+	}
+	// Sarama doesn't limit the total duration of the retries, so they are
+	// bounded by the ones whose backoffs fit into the duration, the time
+	// of the requests is not counted.
+	if budget != nil && budget.MaxDuration > 0 {
+		config.Producer.Retry.Max = retriesWithin(config, budget.MaxDuration)
+	}
+}
+
+// retriesWithin returns the number of the retries of the producer whose
+// backoffs fit into the duration, it's at most the configured max retries.
+func retriesWithin(config *sarama.Config, d time.Duration) int {
+	var total time.Duration
+	for retries := 0; retries < config.Producer.Retry.Max; retries++ {
+		backoff := config.Producer.Retry.Backoff
+		if config.Producer.Retry.BackoffFunc != nil {
+			backoff = config.Producer.Retry.BackoffFunc(retries+1, config.Producer.Retry.Max)
+		}
+		if backoff <= 0 {
+			break
+		}
+		total += backoff
+		if total > d {
+			return retries
+		}
+	}
+	return config.Producer.Retry.Max
+}
+
 // NewSaramaConfig return the default config and set the according version and metrics
 func NewSaramaConfig(ctx context.Context, o *Options) (*sarama.Config, error) {
 	config := sarama.NewConfig()
@@ -74,6 +149,7 @@ func NewSaramaConfig(ctx context.Context, o *Options) (*sarama.Config, error) {
 	// or fail as soon as possible is preferred.
 	config.Producer.Retry.Max = 3
 	config.Producer.Retry.Backoff = 100 * time.Millisecond
+	applyRetryBudget(config, o)
 
 	// make sure sarama producer flush messages as soon as possible.
 	config.Producer.Flush.Bytes = 0
@@ -83,30 +159,49 @@ func NewSaramaConfig(ctx context.Context, o *Options) (*sarama.Config, error) {
 	config.Net.DialTimeout = o.DialTimeout
 	config.Net.WriteTimeout = o.WriteTimeout
 	config.Net.ReadTimeout = o.ReadTimeout
+	// The brokers are connected through the proxy, and the seed broker of the
+	// SRV records is connected by the dialer, which resolves the records
+	// again periodically to pick up the replaced brokers.
+	if o.Proxy != nil || o.SRVName != "" {
+		config.Net.Proxy.Enable = true
+		config.Net.Proxy.Dialer, err = newBrokerDialer(o, config)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	config.Producer.Partitioner = sarama.NewManualPartitioner
 	config.Producer.MaxMessageBytes = o.MaxMessageBytes
 	config.Producer.Return.Successes = true
 	config.Producer.Return.Errors = true
 	config.Producer.RequiredAcks = sarama.WaitForAll
-	compression := strings.ToLower(strings.TrimSpace(o.Compression))
-	switch compression {
-	case "none":
-		config.Producer.Compression = sarama.CompressionNone
-	case "gzip":
-		config.Producer.Compression = sarama.CompressionGZIP
-	case "snappy":
-		config.Producer.Compression = sarama.CompressionSnappy
-	case "lz4":
-		config.Producer.Compression = sarama.CompressionLZ4
-	case "zstd":
-		config.Producer.Compression = sarama.CompressionZSTD
-	default:
+	if o.Idempotent {
+		// The idempotent producer is supported since Kafka 0.11, it requires
+		// at most one in-flight request per broker and at least one retry.
+		if !version.IsAtLeast(sarama.V0_11_0_0) {
+			return nil, cerror.ErrKafkaInvalidConfig.GenWithStack(
+				"the idempotent producer requires kafka-version 0.11.0 or later, got %s", o.Version)
+		}
+		config.Producer.Idempotent = true
+		config.Net.MaxOpenRequests = 1
+		if config.Producer.Retry.Max < 1 {
+			config.Producer.Retry.Max = 1
+		}
+	}
+	// The codecs supported by the Kafka protocol compress the record batches
+	// in sarama, the other registered codecs compress the message values in
+	// the producers, see PayloadCodec.
+	codec, err := compression.Get(o.Compression)
+	if err != nil {
 		log.Warn("Unsupported compression algorithm", zap.String("compression", o.Compression))
-		config.Producer.Compression = sarama.CompressionNone
+		codec, _ = compression.Get(compression.None)
 	}
-	if config.Producer.Compression != sarama.CompressionNone {
-		log.Info("Kafka producer uses " + compression + " compression algorithm")
+	config.Producer.Compression = sarama.CompressionNone
+	if kafkaCodec, ok := codec.(compression.KafkaCodec); ok {
+		config.Producer.Compression = sarama.CompressionCodec(kafkaCodec.KafkaCodecID())
+	}
+	if codec.Name() != compression.None {
+		log.Info("Kafka producer uses " + codec.Name() + " compression algorithm")
 	}
 
 	if o.EnableTLS {
@@ -128,12 +223,37 @@ func NewSaramaConfig(ctx context.Context, o *Options) (*sarama.Config, error) {
 		}
 	}
 
-	completeSaramaSASLConfig(config, o)
+	err = completeSaramaSASLConfig(config, o)
+	if err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
 
-	return config, err
+// newBrokerDialer creates the dialer to connect to the brokers through the
+// proxy, or to the brokers resolved from the SRV records.
+func newBrokerDialer(o *Options, config *sarama.Config) (proxy.Dialer, error) {
+	forward := &net.Dialer{
+		Timeout:   config.Net.DialTimeout,
+		KeepAlive: config.Net.KeepAlive,
+		LocalAddr: config.Net.LocalAddr,
+	}
+	var dialer proxy.Dialer = forward
+	if o.Proxy != nil {
+		var err error
+		dialer, err = proxy.NewDialer(o.Proxy, forward)
+		if err != nil {
+			return nil, cerror.WrapError(cerror.ErrKafkaInvalidConfig, err)
+		}
+	}
+	if o.SRVName != "" {
+		dialer = newSRVDialer(o, dialer)
+	}
+	return dialer, nil
 }
 
-func completeSaramaSASLConfig(config *sarama.Config, o *Options) {
+func completeSaramaSASLConfig(config *sarama.Config, o *Options) error {
 	if o.SASL != nil && o.SASL.SASLMechanism != "" {
 		config.Net.SASL.Enable = true
 		config.Net.SASL.Mechanism = sarama.SASLMechanism(o.SASL.SASLMechanism)
@@ -163,6 +283,17 @@ func completeSaramaSASLConfig(config *sarama.Config, o *Options) {
 			case security.KeyTabAuth:
 				config.Net.SASL.GSSAPI.KeyTabPath = o.SASL.GSSAPI.KeyTabPath
 			}
+		case sarama.SASLTypeOAuth:
+			config.Net.SASL.TokenProvider = newTokenProvider(&o.SASL.OAuth2)
+		case security.AWSMSKIAMMechanism:
+			// MSK accepts the IAM tokens by OAUTHBEARER.
+			provider, err := newMSKIAMTokenProvider(o.SASL.AWSMSKIAM.Region)
+			if err != nil {
+				return err
+			}
+			config.Net.SASL.Mechanism = sarama.SASLTypeOAuth
+			config.Net.SASL.TokenProvider = provider
 		}
 	}
+	return nil
 }
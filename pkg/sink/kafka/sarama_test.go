@@ -15,12 +15,15 @@ package kafka
 
 import (
 	"context"
+	"math"
 	"net/url"
 	"testing"
+	"time"
 
 	"github.com/Shopify/sarama"
 	"github.com/pingcap/errors"
 	"github.com/pingcap/tiflow/cdc/contextutil"
+	"github.com/pingcap/tiflow/pkg/config"
 	cerror "github.com/pingcap/tiflow/pkg/errors"
 	"github.com/pingcap/tiflow/pkg/security"
 	"github.com/stretchr/testify/require"
@@ -128,6 +131,15 @@ func TestApplySASL(t *testing.T) {
 				"&sasl-gssapi-realm=realm&sasl-gssapi-disable-pafxfast=false",
 			exceptErr: "",
 		},
+		{
+			name: "valid OAUTHBEARER SASL",
+			URI: "kafka://127.0.0.1:9092/abc?kafka-version=2.6.0&partition-num=0" +
+				"&sasl-mechanism=oauthbearer&sasl-oauth-client-id=client" +
+				"&sasl-oauth-client-secret=secret" +
+				"&sasl-oauth-token-url=https://127.0.0.1/oauth2/token" +
+				"&sasl-oauth-scopes=a,b&sasl-oauth-extensions=logicalCluster%3Dlkc-1",
+			exceptErr: "",
+		},
 		{
 			name: "invalid mechanism",
 			URI: "kafka://127.0.0.1:9092/abc?kafka-version=2.6.0&partition-num=0" +
@@ -140,6 +152,19 @@ func TestApplySASL(t *testing.T) {
 				"&sasl-mechanism=gssapi&sasl-gssapi-auth-type=keyta1b",
 			exceptErr: "unknown keyta1b auth type",
 		},
+		{
+			name: "OAUTHBEARER without token url",
+			URI: "kafka://127.0.0.1:9092/abc?kafka-version=2.6.0&partition-num=0" +
+				"&sasl-mechanism=oauthbearer&sasl-oauth-client-id=client" +
+				"&sasl-oauth-client-secret=secret",
+			exceptErr: "sasl-oauth-token-url should be supplied",
+		},
+		{
+			name: "invalid OAUTHBEARER extensions",
+			URI: "kafka://127.0.0.1:9092/abc?kafka-version=2.6.0&partition-num=0" +
+				"&sasl-oauth-extensions=logicalCluster",
+			exceptErr: "invalid sasl-oauth-extensions logicalCluster",
+		},
 	}
 
 	for _, test := range tests {
@@ -235,10 +260,10 @@ func TestCompleteSaramaSASLConfig(t *testing.T) {
 		GSSAPI:        security.GSSAPI{},
 	}
 	saramaConfig := sarama.NewConfig()
-	completeSaramaSASLConfig(saramaConfig, options)
+	require.Nil(t, completeSaramaSASLConfig(saramaConfig, options))
 	require.False(t, saramaConfig.Net.SASL.Enable)
 	options.SASL.SASLMechanism = "plain"
-	completeSaramaSASLConfig(saramaConfig, options)
+	require.Nil(t, completeSaramaSASLConfig(saramaConfig, options))
 	require.True(t, saramaConfig.Net.SASL.Enable)
 	// Test that the SCRAMClientGeneratorFunc is set up correctly.
 	options = NewOptions()
@@ -249,11 +274,46 @@ func TestCompleteSaramaSASLConfig(t *testing.T) {
 		GSSAPI:        security.GSSAPI{},
 	}
 	saramaConfig = sarama.NewConfig()
-	completeSaramaSASLConfig(saramaConfig, options)
+	require.Nil(t, completeSaramaSASLConfig(saramaConfig, options))
 	require.Nil(t, saramaConfig.Net.SASL.SCRAMClientGeneratorFunc)
 	options.SASL.SASLMechanism = "SCRAM-SHA-512"
-	completeSaramaSASLConfig(saramaConfig, options)
+	require.Nil(t, completeSaramaSASLConfig(saramaConfig, options))
 	require.NotNil(t, saramaConfig.Net.SASL.SCRAMClientGeneratorFunc)
+
+	// Test that the TokenProvider is set up for OAUTHBEARER.
+	options = NewOptions()
+	sinkURI, err := url.Parse("kafka://127.0.0.1:9092/abc?sasl-mechanism=oauthbearer" +
+		"&sasl-oauth-client-id=client&sasl-oauth-client-secret=secret" +
+		"&sasl-oauth-token-url=https://127.0.0.1/oauth2/token" +
+		"&sasl-oauth-scopes=a,%20b,&sasl-oauth-extensions=logicalCluster%3Dlkc-1,identityPoolId%3Dpool-1")
+	require.NoError(t, err)
+	require.Nil(t, options.applySASL(sinkURI.Query()))
+	require.Equal(t, security.OAuth2{
+		ClientID:     "client",
+		ClientSecret: "secret",
+		TokenURL:     "https://127.0.0.1/oauth2/token",
+		Scopes:       []string{"a", "b"},
+		Extensions:   map[string]string{"logicalCluster": "lkc-1", "identityPoolId": "pool-1"},
+	}, options.SASL.OAuth2)
+	saramaConfig = sarama.NewConfig()
+	require.Nil(t, completeSaramaSASLConfig(saramaConfig, options))
+	require.True(t, saramaConfig.Net.SASL.Enable)
+	require.Equal(t, sarama.SASLMechanism(sarama.SASLTypeOAuth), saramaConfig.Net.SASL.Mechanism)
+	require.NotNil(t, saramaConfig.Net.SASL.TokenProvider)
+
+	// Test that the IAM tokens of AWS MSK are provided by OAUTHBEARER.
+	options = NewOptions()
+	sinkURI, err = url.Parse("kafka://127.0.0.1:9098/abc?sasl-mechanism=aws_msk_iam" +
+		"&sasl-aws-region=us-west-2")
+	require.NoError(t, err)
+	require.Nil(t, options.applySASL(sinkURI.Query()))
+	require.Equal(t, security.AWSMSKIAMMechanism, options.SASL.SASLMechanism)
+	require.Equal(t, "us-west-2", options.SASL.AWSMSKIAM.Region)
+	saramaConfig = sarama.NewConfig()
+	require.Nil(t, completeSaramaSASLConfig(saramaConfig, options))
+	require.True(t, saramaConfig.Net.SASL.Enable)
+	require.Equal(t, sarama.SASLMechanism(sarama.SASLTypeOAuth), saramaConfig.Net.SASL.Mechanism)
+	require.IsType(t, &mskIAMTokenProvider{}, saramaConfig.Net.SASL.TokenProvider)
 }
 
 func TestSaramaTimeout(t *testing.T) {
@@ -264,3 +324,89 @@ func TestSaramaTimeout(t *testing.T) {
 	require.Equal(t, options.WriteTimeout, saramaConfig.Net.WriteTimeout)
 	require.Equal(t, options.ReadTimeout, saramaConfig.Net.ReadTimeout)
 }
+
+func TestSaramaIdempotent(t *testing.T) {
+	options := NewOptions()
+	saramaConfig, err := NewSaramaConfig(context.Background(), options)
+	require.NoError(t, err)
+	require.False(t, saramaConfig.Producer.Idempotent)
+
+	options.Idempotent = true
+	options.RetryBudget = &config.RetryBudgetConfig{MaxAttempts: 1}
+	saramaConfig, err = NewSaramaConfig(context.Background(), options)
+	require.NoError(t, err)
+	require.True(t, saramaConfig.Producer.Idempotent)
+	require.Equal(t, 1, saramaConfig.Net.MaxOpenRequests)
+	require.Equal(t, 1, saramaConfig.Producer.Retry.Max)
+	require.NoError(t, saramaConfig.Validate())
+
+	options.Version = "0.10.2.0"
+	_, err = NewSaramaConfig(context.Background(), options)
+	require.True(t, cerror.ErrKafkaInvalidConfig.Equal(err))
+}
+
+func TestSaramaPauseOnUnavailable(t *testing.T) {
+	options := NewOptions()
+	saramaConfig, err := NewSaramaConfig(context.Background(), options)
+	require.NoError(t, err)
+	require.Equal(t, 3, saramaConfig.Producer.Retry.Max)
+	require.Nil(t, saramaConfig.Producer.Retry.BackoffFunc)
+
+	options.PauseOnUnavailable = true
+	saramaConfig, err = NewSaramaConfig(context.Background(), options)
+	require.NoError(t, err)
+	require.Equal(t, math.MaxInt32, saramaConfig.Producer.Retry.Max)
+	backoff := saramaConfig.Producer.Retry.BackoffFunc
+	require.Equal(t, 100*time.Millisecond, backoff(0, math.MaxInt32))
+	require.Equal(t, 400*time.Millisecond, backoff(2, math.MaxInt32))
+	require.Equal(t, maxUnavailableBackoff, backoff(1000, math.MaxInt32))
+	require.NoError(t, saramaConfig.Validate())
+
+	// The retry budget isn't overridden.
+	options.RetryBudget = &config.RetryBudgetConfig{
+		MaxAttempts: 10, BackoffMaxDelay: time.Second,
+	}
+	saramaConfig, err = NewSaramaConfig(context.Background(), options)
+	require.NoError(t, err)
+	require.Equal(t, 9, saramaConfig.Producer.Retry.Max)
+	backoff = saramaConfig.Producer.Retry.BackoffFunc
+	require.Equal(t, 400*time.Millisecond, backoff(2, 9))
+	require.Equal(t, time.Second, backoff(8, 9))
+	require.NoError(t, saramaConfig.Validate())
+}
+
+func TestSaramaRetryBudget(t *testing.T) {
+	options := NewOptions()
+	options.RetryBudget = &config.RetryBudgetConfig{
+		MaxAttempts:      5,
+		BackoffBaseDelay: 100 * time.Millisecond,
+		BackoffMaxDelay:  400 * time.Millisecond,
+	}
+	saramaConfig, err := NewSaramaConfig(context.Background(), options)
+	require.NoError(t, err)
+	require.Equal(t, 4, saramaConfig.Producer.Retry.Max)
+	backoff := saramaConfig.Producer.Retry.BackoffFunc
+	require.Equal(t, 200*time.Millisecond, backoff(1, 4))
+	require.Equal(t, 400*time.Millisecond, backoff(3, 4))
+	require.NoError(t, saramaConfig.Validate())
+
+	// The retries are bounded by the ones whose backoffs fit into the max duration.
+	options.RetryBudget.MaxDuration = 500 * time.Millisecond
+	saramaConfig, err = NewSaramaConfig(context.Background(), options)
+	require.NoError(t, err)
+	require.Equal(t, 1, saramaConfig.Producer.Retry.Max)
+
+	options.RetryBudget = &config.RetryBudgetConfig{MaxDuration: 150 * time.Millisecond}
+	saramaConfig, err = NewSaramaConfig(context.Background(), options)
+	require.NoError(t, err)
+	require.Equal(t, 1, saramaConfig.Producer.Retry.Max)
+	require.Nil(t, saramaConfig.Producer.Retry.BackoffFunc)
+
+	// The max duration bounds the retries of a paused sink too.
+	options.PauseOnUnavailable = true
+	options.RetryBudget = &config.RetryBudgetConfig{MaxDuration: time.Minute}
+	saramaConfig, err = NewSaramaConfig(context.Background(), options)
+	require.NoError(t, err)
+	require.Equal(t, 10, saramaConfig.Producer.Retry.Max)
+	require.NoError(t, saramaConfig.Validate())
+}
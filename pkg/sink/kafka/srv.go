@@ -0,0 +1,151 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/pkg/proxy"
+	"go.uber.org/zap"
+)
+
+// srvSeedPort is the port of the seed broker standing for the SRV records,
+// connecting to the seed broker connects to one of the targets of the
+// records. The brokers got from the metadata are connected directly.
+const srvSeedPort = "0"
+
+// srvSeedAddr returns the address of the seed broker standing for the SRV
+// records of the domain name.
+func srvSeedAddr(name string) string {
+	return net.JoinHostPort(name, srvSeedPort)
+}
+
+// lookupSRV looks up the SRV records of the kafka service, the full name of
+// the records like _kafka._tcp.example.com is also accepted.
+func lookupSRV(name string) ([]*net.SRV, error) {
+	if strings.HasPrefix(name, "_") {
+		_, records, err := net.LookupSRV("", "", name)
+		return records, err
+	}
+	_, records, err := net.LookupSRV("kafka", "tcp", name)
+	return records, err
+}
+
+// srvResolver resolves the SRV records to the broker endpoints, the results
+// are cached for the interval.
+type srvResolver struct {
+	mu sync.Mutex
+
+	name     string
+	interval time.Duration
+	lookup   func(name string) ([]*net.SRV, error)
+
+	endpoints   []string
+	lastResolve time.Time
+}
+
+func newSRVResolver(name string, interval time.Duration) *srvResolver {
+	return &srvResolver{name: name, interval: interval, lookup: lookupSRV}
+}
+
+// resolve returns the broker endpoints in the order of the records, the
+// cached endpoints are used if the records can't be resolved.
+func (r *srvResolver) resolve() ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(r.endpoints) > 0 && time.Since(r.lastResolve) < r.interval {
+		return r.endpoints, nil
+	}
+	records, err := r.lookup(r.name)
+	if err == nil && len(records) == 0 {
+		err = errors.Errorf("no SRV record of %s is found", r.name)
+	}
+	if err != nil {
+		if len(r.endpoints) > 0 {
+			log.Warn("fail to resolve the SRV records of kafka brokers, "+
+				"the previous endpoints are used",
+				zap.String("name", r.name), zap.Strings("endpoints", r.endpoints),
+				zap.Error(err))
+			return r.endpoints, nil
+		}
+		return nil, errors.Trace(err)
+	}
+
+	endpoints := make([]string, 0, len(records))
+	for _, record := range records {
+		endpoints = append(endpoints, net.JoinHostPort(
+			strings.TrimSuffix(record.Target, "."), strconv.Itoa(int(record.Port))))
+	}
+	if strings.Join(endpoints, ",") != strings.Join(r.endpoints, ",") {
+		log.Info("kafka broker endpoints resolved by SRV records",
+			zap.String("name", r.name), zap.Strings("endpoints", endpoints))
+	}
+	r.endpoints = endpoints
+	r.lastResolve = time.Now()
+	return endpoints, nil
+}
+
+// srvDialer dials the seed broker to one of the endpoints resolved from the
+// SRV records, and dials the other brokers directly. Notice that the TLS
+// certificate of the seed broker is verified against the domain name of the
+// records.
+type srvDialer struct {
+	dialer   proxy.Dialer
+	seedAddr string
+	resolver *srvResolver
+}
+
+// newSRVDialer creates a srvDialer, the brokers are connected by the dialer.
+func newSRVDialer(o *Options, dialer proxy.Dialer) *srvDialer {
+	return &srvDialer{
+		dialer:   dialer,
+		seedAddr: srvSeedAddr(o.SRVName),
+		resolver: newSRVResolver(o.SRVName, o.SRVResolveInterval),
+	}
+}
+
+// Dial implements proxy.Dialer, which is used by sarama to connect to the
+// brokers.
+func (d *srvDialer) Dial(network, addr string) (net.Conn, error) {
+	return d.DialContext(context.Background(), network, addr)
+}
+
+// DialContext implements proxy.Dialer.
+func (d *srvDialer) DialContext(ctx context.Context, network, addr string) (net.Conn, error) {
+	if addr != d.seedAddr {
+		return d.dialer.DialContext(ctx, network, addr)
+	}
+	endpoints, err := d.resolver.resolve()
+	if err != nil {
+		return nil, err
+	}
+	for _, endpoint := range endpoints {
+		var conn net.Conn
+		conn, err = d.dialer.DialContext(ctx, network, endpoint)
+		if err == nil {
+			return conn, nil
+		}
+		log.Warn("fail to connect to the kafka broker resolved by SRV records",
+			zap.String("endpoint", endpoint), zap.Error(err))
+	}
+	return nil, err
+}
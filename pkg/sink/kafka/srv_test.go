@@ -0,0 +1,145 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tiflow/pkg/proxy"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSRVResolver(t *testing.T) {
+	t.Parallel()
+
+	var (
+		lookups int
+		records []*net.SRV
+		err     error
+	)
+	r := newSRVResolver("kafka.example.com", time.Hour)
+	r.lookup = func(name string) ([]*net.SRV, error) {
+		require.Equal(t, "kafka.example.com", name)
+		lookups++
+		return records, err
+	}
+
+	_, resolveErr := r.resolve()
+	require.Regexp(t, "no SRV record of kafka.example.com is found", resolveErr)
+
+	records = []*net.SRV{
+		{Target: "broker-1.example.com.", Port: 9092},
+		{Target: "broker-2.example.com.", Port: 9093},
+	}
+	endpoints, resolveErr := r.resolve()
+	require.Nil(t, resolveErr)
+	require.Equal(t, []string{"broker-1.example.com:9092", "broker-2.example.com:9093"}, endpoints)
+	require.Equal(t, 2, lookups)
+
+	// the endpoints are cached in the interval.
+	records = []*net.SRV{{Target: "broker-3.example.com.", Port: 9092}}
+	endpoints, resolveErr = r.resolve()
+	require.Nil(t, resolveErr)
+	require.Equal(t, []string{"broker-1.example.com:9092", "broker-2.example.com:9093"}, endpoints)
+	require.Equal(t, 2, lookups)
+
+	// the records are resolved again after the interval.
+	r.lastResolve = time.Now().Add(-2 * time.Hour)
+	endpoints, resolveErr = r.resolve()
+	require.Nil(t, resolveErr)
+	require.Equal(t, []string{"broker-3.example.com:9092"}, endpoints)
+
+	// the previous endpoints are used if the records can't be resolved.
+	r.lastResolve = time.Now().Add(-2 * time.Hour)
+	err = errors.New("dns server unavailable")
+	endpoints, resolveErr = r.resolve()
+	require.Nil(t, resolveErr)
+	require.Equal(t, []string{"broker-3.example.com:9092"}, endpoints)
+	require.Equal(t, 4, lookups)
+}
+
+func TestSRVDialer(t *testing.T) {
+	t.Parallel()
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.Nil(t, err)
+	defer l.Close()
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			_ = conn.Close()
+		}
+	}()
+	port := l.Addr().(*net.TCPAddr).Port
+
+	options := NewOptions()
+	options.SRVName = "kafka.example.com"
+	d := newSRVDialer(options, &net.Dialer{})
+	d.resolver.lookup = func(name string) ([]*net.SRV, error) {
+		// the first target is unreachable, so the second one is connected.
+		return []*net.SRV{
+			{Target: "127.0.0.1.", Port: 1},
+			{Target: "127.0.0.1.", Port: uint16(port)},
+		}, nil
+	}
+	conn, err := d.Dial("tcp", "kafka.example.com:0")
+	require.Nil(t, err)
+	require.Equal(t, l.Addr().String(), conn.RemoteAddr().String())
+	_ = conn.Close()
+
+	// the other brokers are connected directly.
+	conn, err = d.Dial("tcp", l.Addr().String())
+	require.Nil(t, err)
+	_ = conn.Close()
+}
+
+func TestSaramaSRVDialer(t *testing.T) {
+	t.Parallel()
+
+	options := NewOptions()
+	saramaConfig, err := NewSaramaConfig(context.Background(), options)
+	require.Nil(t, err)
+	require.False(t, saramaConfig.Net.Proxy.Enable)
+
+	options.SRVName = "kafka.example.com"
+	saramaConfig, err = NewSaramaConfig(context.Background(), options)
+	require.Nil(t, err)
+	require.True(t, saramaConfig.Net.Proxy.Enable)
+	require.IsType(t, &srvDialer{}, saramaConfig.Net.Proxy.Dialer)
+
+	// the brokers are connected through the proxy.
+	options = NewOptions()
+	options.Proxy, err = proxy.Parse("socks5://127.0.0.1:1080")
+	require.Nil(t, err)
+	saramaConfig, err = NewSaramaConfig(context.Background(), options)
+	require.Nil(t, err)
+	require.True(t, saramaConfig.Net.Proxy.Enable)
+	require.NotNil(t, saramaConfig.Net.Proxy.Dialer)
+
+	options.SRVName = "kafka.example.com"
+	saramaConfig, err = NewSaramaConfig(context.Background(), options)
+	require.Nil(t, err)
+	d, ok := saramaConfig.Net.Proxy.Dialer.(*srvDialer)
+	require.True(t, ok)
+	require.NotNil(t, d.dialer)
+	_, ok = d.dialer.(*net.Dialer)
+	require.False(t, ok)
+}
@@ -15,10 +15,14 @@ package mysql
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"net"
 	"net/url"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	dmysql "github.com/go-sql-driver/mysql"
@@ -28,6 +32,7 @@ import (
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/pkg/config"
 	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/proxy"
 	"github.com/pingcap/tiflow/pkg/security"
 	"github.com/pingcap/tiflow/pkg/sink"
 	"go.uber.org/zap"
@@ -45,6 +50,11 @@ const (
 	maxWorkerCount = 1024
 	// The upper limit of max txn rows.
 	maxMaxTxnRow = 2048
+	// defaultDDLConcurrency is the default number of the DDLs on independent
+	// tables executed concurrently, they are executed one by one by default.
+	defaultDDLConcurrency = 1
+	// The upper limit of ddl concurrency.
+	maxDDLConcurrency = 64
 
 	defaultTiDBTxnMode         = txnModeOptimistic
 	defaultBatchReplaceEnabled = true
@@ -84,6 +94,13 @@ type Config struct {
 	IsTiDB         bool // IsTiDB is true if the downstream is TiDB
 	SourceID       uint64
 	BatchDMLEnable bool
+	RetryBudget    *config.RetryBudgetConfig
+	// DDLConcurrency is the max number of the DDLs on independent tables
+	// executed concurrently.
+	DDLConcurrency int
+	// Net is the network registered to connect to the downstream through the
+	// proxy, the downstream is connected by tcp directly if it's empty.
+	Net string
 }
 
 // NewConfig returns the default mysql backend config.
@@ -99,6 +116,7 @@ func NewConfig() *Config {
 		DialTimeout:         defaultDialTimeout,
 		SafeMode:            defaultSafeMode,
 		BatchDMLEnable:      defaultBatchDMLEnable,
+		DDLConcurrency:      defaultDDLConcurrency,
 	}
 }
 
@@ -130,6 +148,9 @@ func (c *Config) Apply(
 	if err = getSSLCA(query, changefeedID, &c.TLS); err != nil {
 		return err
 	}
+	if err = getProxy(sinkURI, &c.Net); err != nil {
+		return err
+	}
 	if err = getBatchReplaceEnable(query, &c.BatchReplaceEnabled, &c.BatchReplaceSize); err != nil {
 		return err
 	}
@@ -151,9 +172,13 @@ func (c *Config) Apply(
 	if err = getBatchDMLEnable(query, &c.BatchDMLEnable); err != nil {
 		return err
 	}
+	if err = getDDLConcurrency(query, &c.DDLConcurrency); err != nil {
+		return err
+	}
 	c.EnableOldValue = replicaConfig.EnableOldValue
 	c.ForceReplicate = replicaConfig.ForceReplicate
 	c.SourceID = replicaConfig.Sink.TiDBSourceID
+	c.RetryBudget = replicaConfig.Sink.RetryBudget
 
 	return nil
 }
@@ -182,6 +207,30 @@ func getWorkerCount(values url.Values, workerCount *int) error {
 	return nil
 }
 
+func getDDLConcurrency(values url.Values, ddlConcurrency *int) error {
+	s := values.Get("ddl-concurrency")
+	if len(s) == 0 {
+		return nil
+	}
+
+	c, err := strconv.Atoi(s)
+	if err != nil {
+		return cerror.WrapError(cerror.ErrMySQLInvalidConfig, err)
+	}
+	if c <= 0 {
+		return cerror.WrapError(cerror.ErrMySQLInvalidConfig,
+			fmt.Errorf("invalid ddl-concurrency %d, which must be greater than 0", c))
+	}
+	if c > maxDDLConcurrency {
+		log.Warn("ddl-concurrency too large",
+			zap.Int("original", c), zap.Int("override", maxDDLConcurrency))
+		c = maxDDLConcurrency
+	}
+
+	*ddlConcurrency = c
+	return nil
+}
+
 func getMaxTxnRow(values url.Values, maxTxnRow *int) error {
 	s := values.Get("max-txn-row")
 	if len(s) == 0 {
@@ -244,6 +293,40 @@ func getSSLCA(values url.Values, changefeedID model.ChangeFeedID, tls *string) e
 	return nil
 }
 
+// proxyNetworks are the names of the networks registered to the driver to
+// connect through the proxies. The driver can't deregister a network, so
+// a network is registered once for each proxy and shared by the changefeeds.
+var proxyNetworks sync.Map
+
+// getProxy registers the network which connects to the downstream through
+// the proxy of the sink uri, TLS works over it as usual.
+func getProxy(sinkURI *url.URL, network *string) error {
+	proxyURL, err := proxy.FromSinkURI(sinkURI)
+	if err != nil {
+		return cerror.WrapError(cerror.ErrMySQLInvalidConfig, err)
+	}
+	if proxyURL == nil {
+		return nil
+	}
+	dialer, err := proxy.NewDialer(proxyURL, &net.Dialer{})
+	if err != nil {
+		return cerror.WrapError(cerror.ErrMySQLInvalidConfig, err)
+	}
+
+	// the network is named by the digest of the proxy url, which may carry
+	// the credentials of the proxy.
+	digest := sha256.Sum256([]byte(proxyURL.String()))
+	name := "cdc_mysql_proxy_" + hex.EncodeToString(digest[:8])
+	if _, loaded := proxyNetworks.LoadOrStore(name, struct{}{}); !loaded {
+		// the timeout of the dsn is applied to the context by the driver.
+		dmysql.RegisterDialContext(name, func(ctx context.Context, addr string) (net.Conn, error) {
+			return dialer.DialContext(ctx, "tcp", addr)
+		})
+	}
+	*network = name
+	return nil
+}
+
 func getBatchReplaceEnable(values url.Values, batchReplaceEnabled *bool, batchReplaceSize *int) error {
 	s := values.Get("batch-replace-enable")
 	if len(s) > 0 {
@@ -0,0 +1,61 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/pingcap/tiflow/pkg/sink"
+)
+
+// credentialParams are the parameters of the sink URI which specify the TLS
+// material of the connections.
+var credentialParams = []string{"ssl-ca", "ssl-cert", "ssl-key"}
+
+// withoutCredentials returns the sink URI without the password and the TLS
+// material.
+func withoutCredentials(sinkURI *url.URL) string {
+	u := *sinkURI
+	if u.User != nil {
+		u.User = url.User(u.User.Username())
+	}
+	query := u.Query()
+	for _, param := range credentialParams {
+		query.Del(param)
+	}
+	u.RawQuery = query.Encode()
+	return u.String()
+}
+
+// IsCredentialsOnlyChange returns true if the sink URIs of a MySQL compatible
+// sink differ in the credentials only, i.e. the password and the TLS material,
+// which can be applied by re-establishing the connections to the downstream.
+func IsCredentialsOnlyChange(oldSinkURI, newSinkURI string) bool {
+	if oldSinkURI == newSinkURI {
+		return false
+	}
+	oldURI, err := url.Parse(oldSinkURI)
+	if err != nil {
+		return false
+	}
+	newURI, err := url.Parse(newSinkURI)
+	if err != nil {
+		return false
+	}
+	if !sink.IsMySQLCompatibleScheme(strings.ToLower(oldURI.Scheme)) {
+		return false
+	}
+	return withoutCredentials(oldURI) == withoutCredentials(newURI)
+}
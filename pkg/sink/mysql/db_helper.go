@@ -267,7 +267,11 @@ func GenBasicDSN(sinkURI *url.URL, cfg *Config) (*dmysql.Config, error) {
 	var dsn *dmysql.Config
 	var err error
 	host := net.JoinHostPort(hostName, port)
-	dsnStr := fmt.Sprintf("%s:%s@tcp(%s)/%s", username, password, host, cfg.TLS)
+	network := "tcp"
+	if cfg.Net != "" {
+		network = cfg.Net
+	}
+	dsnStr := fmt.Sprintf("%s:%s@%s(%s)/%s", username, password, network, host, cfg.TLS)
 	if dsn, err = dmysql.ParseDSN(dsnStr); err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -0,0 +1,228 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schemahistory
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	timodel "github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/sink/cloudstorage"
+	"go.uber.org/zap"
+)
+
+// Writer persists the schema history to the downstream.
+type Writer interface {
+	// WriteRecord writes a version of a table schema.
+	WriteRecord(ctx context.Context, def *cloudstorage.TableDefinition) error
+	// WriteCheckpoint writes the checkpoint ts. All the schemas used by the
+	// data emitted before the checkpoint ts must have been written.
+	WriteCheckpoint(ctx context.Context, ts uint64) error
+}
+
+// Key is the key of a record in the schema history, the checkpoint is
+// written with the Checkpoint flag and an empty table.
+type Key struct {
+	Schema       string `json:"schema,omitempty"`
+	Table        string `json:"table,omitempty"`
+	TableVersion uint64 `json:"table-version,omitempty"`
+	Checkpoint   bool   `json:"checkpoint,omitempty"`
+}
+
+// Checkpoint is the content of the checkpoint in the schema history.
+type Checkpoint struct {
+	CheckpointTs uint64 `json:"checkpoint-ts"`
+}
+
+// EncodeRecord encodes the table definition into a key and a value.
+func EncodeRecord(def *cloudstorage.TableDefinition) ([]byte, []byte, error) {
+	key, err := json.Marshal(Key{Schema: def.Schema, Table: def.Table, TableVersion: def.TableVersion})
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	value, err := json.Marshal(def)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	return key, value, nil
+}
+
+// EncodeCheckpoint encodes the checkpoint ts into a key and a value.
+func EncodeCheckpoint(ts uint64) ([]byte, []byte, error) {
+	key, err := json.Marshal(Key{Checkpoint: true})
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	value, err := json.Marshal(Checkpoint{CheckpointTs: ts})
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	return key, value, nil
+}
+
+// History maintains the schema history of a changefeed in the downstream.
+// Besides the schemas changed by DDLs, it also records the schemas of the
+// tables which are replicated before any DDL on them, so the history is
+// complete at each checkpoint.
+type History struct {
+	changefeedID model.ChangeFeedID
+	writer       Writer
+	// recorded is the latest table version recorded of each table, which
+	// is keyed by the schema and the table name only.
+	recorded map[model.TableName]uint64
+}
+
+// New creates a History with the writer.
+func New(changefeedID model.ChangeFeedID, writer Writer) *History {
+	return &History{
+		changefeedID: changefeedID,
+		writer:       writer,
+		recorded:     make(map[model.TableName]uint64),
+	}
+}
+
+// OnDDL records the table schema changed by the DDL. The tables of a dropped
+// database and the old names of the renamed tables are recorded as dropped.
+func (h *History) OnDDL(ctx context.Context, ddl *model.DDLEvent) error {
+	if ddl.TableInfo == nil {
+		return nil
+	}
+	switch ddl.Type {
+	case timodel.ActionDropSchema:
+		return h.dropSchema(ctx, ddl)
+	case timodel.ActionRenameTable, timodel.ActionRenameTables:
+		pre := ddl.PreTableInfo
+		if pre != nil && pre.TableName.Schema != "" &&
+			(pre.TableName.Schema != ddl.TableInfo.TableName.Schema ||
+				pre.TableName.Table != ddl.TableInfo.TableName.Table) {
+			if err := h.drop(ctx, pre.TableName, ddl); err != nil {
+				return err
+			}
+		}
+	}
+	if ddl.TableInfo.TableInfo == nil {
+		return nil
+	}
+	var def cloudstorage.TableDefinition
+	def.FromDDLEvent(ddl)
+	switch ddl.Type {
+	case timodel.ActionDropTable, timodel.ActionTruncateTable,
+		timodel.ActionRenameTable, timodel.ActionRenameTables:
+		// the table version of a dropped table is the one before it's
+		// dropped, and the one of a truncated or renamed table may be not
+		// greater than the version recorded for the same name, use the commit
+		// ts to make the change a new version.
+		def.TableVersion = ddl.CommitTs
+	}
+	return h.record(ctx, ddl.TableInfo.TableName, &def)
+}
+
+// dropSchema records the recorded tables of the dropped database as dropped.
+func (h *History) dropSchema(ctx context.Context, ddl *model.DDLEvent) error {
+	var tables []model.TableName
+	for table := range h.recorded {
+		if table.Schema == ddl.TableInfo.TableName.Schema {
+			tables = append(tables, table)
+		}
+	}
+	sort.Slice(tables, func(i, j int) bool { return tables[i].Table < tables[j].Table })
+	for _, table := range tables {
+		if err := h.drop(ctx, table, ddl); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// drop records the table as dropped by the DDL.
+func (h *History) drop(ctx context.Context, table model.TableName, ddl *model.DDLEvent) error {
+	var def cloudstorage.TableDefinition
+	def.FromTableInfo(&model.TableInfo{TableName: table, TableInfo: &timodel.TableInfo{}})
+	def.TableVersion = ddl.CommitTs
+	def.Query = ddl.Query
+	def.Type = timodel.ActionDropTable
+	return h.record(ctx, table, &def)
+}
+
+// OnCheckpoint records the schemas of the tables which are not recorded
+// yet, then writes the checkpoint ts.
+func (h *History) OnCheckpoint(ctx context.Context, ts uint64, tables []*model.TableInfo) error {
+	for _, table := range tables {
+		if table == nil || table.TableInfo == nil {
+			continue
+		}
+		if version, ok := h.recorded[nameOf(table.TableName)]; ok && version >= table.Version {
+			continue
+		}
+		var def cloudstorage.TableDefinition
+		def.FromTableInfo(table)
+		if err := h.record(ctx, table.TableName, &def); err != nil {
+			return err
+		}
+	}
+	return errors.Trace(h.writer.WriteCheckpoint(ctx, ts))
+}
+
+func (h *History) record(ctx context.Context, table model.TableName, def *cloudstorage.TableDefinition) error {
+	if err := h.writer.WriteRecord(ctx, def); err != nil {
+		return errors.Trace(err)
+	}
+	name := nameOf(table)
+	if def.Type == timodel.ActionDropTable {
+		delete(h.recorded, name)
+	} else if def.TableVersion > h.recorded[name] {
+		h.recorded[name] = def.TableVersion
+	}
+	log.Debug("record table schema in schema history",
+		zap.String("namespace", h.changefeedID.Namespace),
+		zap.String("changefeed", h.changefeedID.ID),
+		zap.String("schema", def.Schema),
+		zap.String("table", def.Table),
+		zap.Uint64("tableVersion", def.TableVersion))
+	return nil
+}
+
+// nameOf returns the name of the table without its ID, the ID of a table
+// changes when it's truncated.
+func nameOf(table model.TableName) model.TableName {
+	return model.TableName{Schema: table.Schema, Table: table.Table}
+}
+
+// Resolve returns the definition of a table which is in effect at ts from
+// all the recorded versions of the table, that is the one with the largest
+// table version not greater than ts. It returns nil if the table doesn't
+// exist at ts. The result is deterministic as long as ts is not greater
+// than the checkpoint ts of the history.
+func Resolve(defs []*cloudstorage.TableDefinition, ts uint64) *cloudstorage.TableDefinition {
+	sorted := make([]*cloudstorage.TableDefinition, len(defs))
+	copy(sorted, defs)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].TableVersion < sorted[j].TableVersion
+	})
+	idx := sort.Search(len(sorted), func(i int) bool {
+		return sorted[i].TableVersion > ts
+	})
+	if idx == 0 {
+		return nil
+	}
+	def := sorted[idx-1]
+	if def.Type == timodel.ActionDropTable {
+		return nil
+	}
+	return def
+}
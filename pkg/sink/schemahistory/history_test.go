@@ -0,0 +1,188 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schemahistory
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"testing"
+
+	timodel "github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/parser/types"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/sink/cloudstorage"
+	"github.com/pingcap/tiflow/pkg/util"
+	"github.com/stretchr/testify/require"
+)
+
+func newTableInfo(version uint64, columns ...string) *model.TableInfo {
+	info := &model.TableInfo{
+		Version: version,
+		TableName: model.TableName{
+			Schema:  "test",
+			Table:   "table1",
+			TableID: 20,
+		},
+		TableInfo: &timodel.TableInfo{},
+	}
+	for _, col := range columns {
+		info.TableInfo.Columns = append(info.TableInfo.Columns, &timodel.ColumnInfo{
+			Name:      timodel.NewCIStr(col),
+			FieldType: *types.NewFieldType(mysql.TypeLong),
+		})
+	}
+	return info
+}
+
+func TestEncode(t *testing.T) {
+	t.Parallel()
+
+	key, value, err := EncodeCheckpoint(100)
+	require.Nil(t, err)
+	require.JSONEq(t, `{"checkpoint":true}`, string(key))
+	require.JSONEq(t, `{"checkpoint-ts":100}`, string(value))
+
+	ddl := &model.DDLEvent{
+		Type:      timodel.ActionCreateTable,
+		Query:     "create table test.table1 (col1 int)",
+		TableInfo: newTableInfo(100, "col1"),
+	}
+	var def cloudstorage.TableDefinition
+	def.FromDDLEvent(ddl)
+	key, _, err = EncodeRecord(&def)
+	require.Nil(t, err)
+	require.JSONEq(t, `{"schema":"test","table":"table1","table-version":100}`, string(key))
+}
+
+func TestStorageHistory(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	dir := t.TempDir()
+	storage, err := util.GetExternalStorageFromURI(ctx, fmt.Sprintf("file:///%s", dir))
+	require.Nil(t, err)
+	require.Nil(t, os.MkdirAll(path.Join(dir, "history/test/table1"), 0o755))
+	h := New(model.DefaultChangeFeedID("test"), NewStorageWriter(storage, "history"))
+
+	// the table is replicated before any DDL on it, the schema is recorded
+	// at the first checkpoint.
+	tables := []*model.TableInfo{newTableInfo(100, "col1")}
+	require.Nil(t, h.OnCheckpoint(ctx, 150, tables))
+	require.Nil(t, h.OnDDL(ctx, &model.DDLEvent{
+		CommitTs:  200,
+		Type:      timodel.ActionAddColumn,
+		Query:     "alter table test.table1 add column col2 int",
+		TableInfo: newTableInfo(200, "col1", "col2"),
+	}))
+	// the table version is recorded, no duplicated record is written.
+	tables = []*model.TableInfo{newTableInfo(200, "col1", "col2")}
+	require.Nil(t, h.OnCheckpoint(ctx, 250, tables))
+	require.Nil(t, h.OnDDL(ctx, &model.DDLEvent{
+		CommitTs:  300,
+		Type:      timodel.ActionDropTable,
+		Query:     "drop table test.table1",
+		TableInfo: newTableInfo(200, "col1", "col2"),
+	}))
+	require.Nil(t, h.OnCheckpoint(ctx, 350, nil))
+
+	defs, checkpointTs, err := ReadStorage(ctx, storage, "history")
+	require.Nil(t, err)
+	require.Equal(t, uint64(350), checkpointTs)
+	require.Len(t, defs, 3)
+
+	require.Nil(t, Resolve(defs, 50))
+	def := Resolve(defs, 150)
+	require.Equal(t, uint64(100), def.TableVersion)
+	require.Equal(t, 1, def.TotalColumns)
+	def = Resolve(defs, 250)
+	require.Equal(t, uint64(200), def.TableVersion)
+	require.Equal(t, 2, def.TotalColumns)
+	require.Nil(t, Resolve(defs, 300))
+}
+
+type memoryWriter struct {
+	defs []*cloudstorage.TableDefinition
+}
+
+func (w *memoryWriter) WriteRecord(_ context.Context, def *cloudstorage.TableDefinition) error {
+	w.defs = append(w.defs, def)
+	return nil
+}
+
+func (w *memoryWriter) WriteCheckpoint(_ context.Context, _ uint64) error {
+	return nil
+}
+
+func (w *memoryWriter) table(schema, table string) []*cloudstorage.TableDefinition {
+	var defs []*cloudstorage.TableDefinition
+	for _, def := range w.defs {
+		if def.Schema == schema && def.Table == table {
+			defs = append(defs, def)
+		}
+	}
+	return defs
+}
+
+func TestHistoryDroppedTables(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	w := &memoryWriter{}
+	h := New(model.DefaultChangeFeedID("test"), w)
+	table2 := newTableInfo(100, "col1")
+	table2.TableName.Table = "table2"
+	require.Nil(t, h.OnCheckpoint(ctx, 150, []*model.TableInfo{newTableInfo(100, "col1"), table2}))
+
+	// the old name of a renamed table is dropped.
+	renamed := newTableInfo(100, "col1")
+	renamed.TableName.Table = "table3"
+	require.Nil(t, h.OnDDL(ctx, &model.DDLEvent{
+		CommitTs:     200,
+		Type:         timodel.ActionRenameTable,
+		Query:        "rename table test.table1 to test.table3",
+		PreTableInfo: newTableInfo(100, "col1"),
+		TableInfo:    renamed,
+	}))
+	require.Nil(t, Resolve(w.table("test", "table1"), 200))
+	require.Equal(t, uint64(200), Resolve(w.table("test", "table3"), 200).TableVersion)
+
+	// a truncated table is a new version even if its version is not greater.
+	truncated := newTableInfo(100, "col1")
+	truncated.TableName.Table = "table2"
+	truncated.TableName.TableID = 21
+	require.Nil(t, h.OnDDL(ctx, &model.DDLEvent{
+		CommitTs:  300,
+		Type:      timodel.ActionTruncateTable,
+		Query:     "truncate table test.table2",
+		TableInfo: truncated,
+	}))
+	def := Resolve(w.table("test", "table2"), 300)
+	require.Equal(t, uint64(300), def.TableVersion)
+	require.Equal(t, timodel.ActionTruncateTable, def.Type)
+
+	// all the tables of a dropped database are dropped.
+	require.Nil(t, h.OnDDL(ctx, &model.DDLEvent{
+		CommitTs:  400,
+		Type:      timodel.ActionDropSchema,
+		Query:     "drop database test",
+		TableInfo: &model.TableInfo{TableName: model.TableName{Schema: "test"}},
+	}))
+	require.Nil(t, Resolve(w.table("test", "table2"), 400))
+	require.Nil(t, Resolve(w.table("test", "table3"), 400))
+	require.Len(t, w.table("test", "table1"), 2)
+	require.Empty(t, h.recorded)
+}
@@ -0,0 +1,110 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schemahistory
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/br/pkg/storage"
+	"github.com/pingcap/tiflow/pkg/sink/cloudstorage"
+)
+
+const (
+	checkpointFileName = "checkpoint.json"
+	recordFilePrefix   = "schema_"
+	recordFileSuffix   = ".json"
+)
+
+// Assert Writer implementation
+var _ Writer = (*StorageWriter)(nil)
+
+// StorageWriter writes the schema history as files in an external storage.
+// A version of a table schema is written to
+// `{dir}/{schema}/{table}/schema_{tableVersion}.json`, and the checkpoint is
+// written to `{dir}/checkpoint.json`.
+type StorageWriter struct {
+	storage storage.ExternalStorage
+	dir     string
+}
+
+// NewStorageWriter creates a StorageWriter.
+func NewStorageWriter(storage storage.ExternalStorage, dir string) *StorageWriter {
+	return &StorageWriter{storage: storage, dir: dir}
+}
+
+func recordPath(dir string, def *cloudstorage.TableDefinition) string {
+	return path.Join(dir, def.Schema, def.Table,
+		fmt.Sprintf("%s%d%s", recordFilePrefix, def.TableVersion, recordFileSuffix))
+}
+
+// WriteRecord implements Writer.
+func (w *StorageWriter) WriteRecord(ctx context.Context, def *cloudstorage.TableDefinition) error {
+	_, value, err := EncodeRecord(def)
+	if err != nil {
+		return err
+	}
+	return errors.Trace(w.storage.WriteFile(ctx, recordPath(w.dir, def), value))
+}
+
+// WriteCheckpoint implements Writer.
+func (w *StorageWriter) WriteCheckpoint(ctx context.Context, ts uint64) error {
+	_, value, err := EncodeCheckpoint(ts)
+	if err != nil {
+		return err
+	}
+	return errors.Trace(w.storage.WriteFile(ctx, path.Join(w.dir, checkpointFileName), value))
+}
+
+// ReadStorage reads all the recorded table schemas and the checkpoint ts of
+// the schema history in the external storage. The records may be newer than
+// the checkpoint ts if they are written after the last checkpoint.
+func ReadStorage(
+	ctx context.Context, s storage.ExternalStorage, dir string,
+) ([]*cloudstorage.TableDefinition, uint64, error) {
+	var checkpoint Checkpoint
+	data, err := s.ReadFile(ctx, path.Join(dir, checkpointFileName))
+	if err != nil {
+		return nil, 0, errors.Trace(err)
+	}
+	if err = json.Unmarshal(data, &checkpoint); err != nil {
+		return nil, 0, errors.Trace(err)
+	}
+
+	var defs []*cloudstorage.TableDefinition
+	err = s.WalkDir(ctx, &storage.WalkOption{SubDir: dir}, func(filePath string, _ int64) error {
+		name := path.Base(filePath)
+		if !strings.HasPrefix(name, recordFilePrefix) || !strings.HasSuffix(name, recordFileSuffix) {
+			return nil
+		}
+		data, err := s.ReadFile(ctx, filePath)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		def := new(cloudstorage.TableDefinition)
+		if err := json.Unmarshal(data, def); err != nil {
+			return errors.Annotatef(err, "unmarshal schema history record %s", filePath)
+		}
+		defs = append(defs, def)
+		return nil
+	})
+	if err != nil {
+		return nil, 0, errors.Trace(err)
+	}
+	return defs, checkpoint.CheckpointTs, nil
+}
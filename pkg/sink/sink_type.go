@@ -39,6 +39,9 @@ const (
 	KafkaScheme = "kafka"
 	// KafkaSSLScheme indicates the scheme is kafka+ssl.
 	KafkaSSLScheme = "kafka+ssl"
+	// KafkaSRVScheme indicates the scheme is kafka+srv, the brokers are
+	// discovered by the DNS SRV records.
+	KafkaSRVScheme = "kafka+srv"
 	// BlackHoleScheme indicates the scheme is blackhole.
 	BlackHoleScheme = "blackhole"
 	// MySQLScheme indicates the scheme is MySQL.
@@ -63,11 +66,23 @@ const (
 	AzureScheme = "azure"
 	// CloudStorageNoopScheme indicates the scheme is noop.
 	CloudStorageNoopScheme = "noop"
+	// ClickHouseScheme indicates the scheme is ClickHouse.
+	ClickHouseScheme = "clickhouse"
+	// ClickHouseSSLScheme indicates the scheme is ClickHouse+ssl.
+	ClickHouseSSLScheme = "clickhouse+ssl"
+	// ElasticsearchScheme indicates the scheme is Elasticsearch.
+	ElasticsearchScheme = "elasticsearch"
+	// ElasticsearchSSLScheme indicates the scheme is Elasticsearch+ssl.
+	ElasticsearchSSLScheme = "elasticsearch+ssl"
+	// WebhookScheme indicates the scheme is a webhook served by HTTP.
+	WebhookScheme = "http"
+	// WebhookSSLScheme indicates the scheme is a webhook served by HTTPS.
+	WebhookSSLScheme = "https"
 )
 
 // IsMQScheme returns true if the scheme belong to mq scheme.
 func IsMQScheme(scheme string) bool {
-	return scheme == KafkaScheme || scheme == KafkaSSLScheme
+	return scheme == KafkaScheme || scheme == KafkaSSLScheme || scheme == KafkaSRVScheme
 }
 
 // IsMySQLCompatibleScheme returns true if the scheme is compatible with MySQL.
@@ -76,6 +91,21 @@ func IsMySQLCompatibleScheme(scheme string) bool {
 		scheme == TiDBScheme || scheme == TiDBSSLScheme
 }
 
+// IsClickHouseScheme returns true if the scheme belong to ClickHouse scheme.
+func IsClickHouseScheme(scheme string) bool {
+	return scheme == ClickHouseScheme || scheme == ClickHouseSSLScheme
+}
+
+// IsElasticsearchScheme returns true if the scheme belong to Elasticsearch scheme.
+func IsElasticsearchScheme(scheme string) bool {
+	return scheme == ElasticsearchScheme || scheme == ElasticsearchSSLScheme
+}
+
+// IsWebhookScheme returns true if the scheme belong to webhook scheme.
+func IsWebhookScheme(scheme string) bool {
+	return scheme == WebhookScheme || scheme == WebhookSSLScheme
+}
+
 // IsStorageScheme returns true if the scheme belong to storage scheme.
 func IsStorageScheme(scheme string) bool {
 	return scheme == FileScheme || scheme == S3Scheme || scheme == GCSScheme ||
@@ -0,0 +1,227 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/cdc/model"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/httputil"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+const (
+	// TimestampHeader is the header of the unix timestamp in seconds when
+	// the request is signed.
+	TimestampHeader = "X-TiCDC-Timestamp"
+	// SignatureHeader is the header of the HMAC-SHA256 signature of the
+	// request, it's prefixed by "sha256=".
+	SignatureHeader = "X-TiCDC-Signature"
+	// ChangefeedHeader is the header of the changefeed sending the request,
+	// formatted as namespace/id.
+	ChangefeedHeader = "X-TiCDC-Changefeed"
+
+	// maxErrorBodySize is the max size of the response body kept in the
+	// error of a failed request.
+	maxErrorBodySize = 1024
+)
+
+// Request is a request posted to the endpoint.
+type Request struct {
+	ContentType string
+	Body        []byte
+	// Header is the extra headers of the request.
+	Header http.Header
+}
+
+// Client posts the requests to the endpoint of the webhook. The requests are
+// retried with exponential backoff if the endpoint is unavailable or asks the
+// client to slow down by 429 or 5xx, which blocks the sender of the request
+// and so applies the backpressure to it.
+type Client struct {
+	changefeedID model.ChangeFeedID
+	cfg          *Config
+	http         *httputil.Client
+
+	requestDuration prometheus.Observer
+	retryCounter    prometheus.Counter
+}
+
+// NewClient creates a new Client.
+func NewClient(changefeedID model.ChangeFeedID, cfg *Config) (*Client, error) {
+	cli, err := httputil.NewClient(cfg.Credential)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrWebhookInvalidConfig, err)
+	}
+	cli.SetTimeout(cfg.RequestTimeout)
+	if cfg.Proxy != nil {
+		cli.SetProxy(cfg.Proxy)
+	}
+	return &Client{
+		changefeedID: changefeedID,
+		cfg:          cfg,
+		http:         cli,
+		requestDuration: requestDurationHistogram.
+			WithLabelValues(changefeedID.Namespace, changefeedID.ID),
+		retryCounter: retryCounter.
+			WithLabelValues(changefeedID.Namespace, changefeedID.ID),
+	}, nil
+}
+
+// Send posts the request, it's retried until it succeeds, the retries are
+// exhausted or a non-retryable response is returned.
+func (c *Client) Send(ctx context.Context, req *Request) error {
+	for retry := 0; ; retry++ {
+		start := time.Now()
+		retryAfter, retryable, err := c.send(ctx, req)
+		c.requestDuration.Observe(time.Since(start).Seconds())
+		if err == nil {
+			return nil
+		}
+		if !retryable || retry >= c.cfg.MaxRetries {
+			return err
+		}
+
+		backoff := c.backoff(retry)
+		if retryAfter > backoff {
+			backoff = retryAfter
+			if backoff > c.cfg.RetryBackoffMax {
+				backoff = c.cfg.RetryBackoffMax
+			}
+		}
+		c.retryCounter.Inc()
+		log.Warn("webhook request failed, retry it later",
+			zap.String("namespace", c.changefeedID.Namespace),
+			zap.String("changefeed", c.changefeedID.ID),
+			zap.Int("retry", retry+1),
+			zap.Duration("backoff", backoff),
+			zap.Error(err))
+		select {
+		case <-ctx.Done():
+			return errors.Trace(ctx.Err())
+		case <-time.After(backoff):
+		}
+	}
+}
+
+// send posts the request once, it returns whether the request can be retried
+// and the delay asked by the Retry-After header of the response.
+func (c *Client) send(ctx context.Context, req *Request) (time.Duration, bool, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.cfg.Endpoint,
+		bytes.NewReader(req.Body))
+	if err != nil {
+		return 0, false, cerror.WrapError(cerror.ErrWebhookRequestFailed, err)
+	}
+	for key, values := range req.Header {
+		for _, value := range values {
+			httpReq.Header.Add(key, value)
+		}
+	}
+	httpReq.Header.Set("Content-Type", req.ContentType)
+	httpReq.Header.Set(ChangefeedHeader,
+		c.changefeedID.Namespace+"/"+c.changefeedID.ID)
+	if c.cfg.UserAgent != "" {
+		httpReq.Header.Set("User-Agent", c.cfg.UserAgent)
+	}
+	if c.cfg.User != "" {
+		httpReq.SetBasicAuth(c.cfg.User, c.cfg.Password)
+	}
+	if c.cfg.HMACSecret != "" {
+		timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+		httpReq.Header.Set(TimestampHeader, timestamp)
+		httpReq.Header.Set(SignatureHeader, Sign(c.cfg.HMACSecret, timestamp, req.Body))
+	}
+
+	resp, err := c.http.Do(httpReq)
+	if err != nil {
+		// the request is canceled, it's not retried.
+		if ctx.Err() != nil {
+			return 0, false, errors.Trace(ctx.Err())
+		}
+		return 0, true, cerror.WrapError(cerror.ErrWebhookRequestFailed, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 == 2 {
+		// drain the body to reuse the connection.
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return 0, false, nil
+	}
+	content, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+	err = cerror.ErrWebhookRequestFailed.GenWithStack(
+		"POST %s: [%d] %s", c.cfg.Endpoint, resp.StatusCode, content)
+	if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode/100 == 5 {
+		return parseRetryAfter(resp.Header.Get("Retry-After")), true, err
+	}
+	return 0, false, err
+}
+
+// backoff returns the backoff before the retry, which starts from 0.
+func (c *Client) backoff(retry int) time.Duration {
+	backoff := c.cfg.RetryBackoffBase
+	for i := 0; i < retry && backoff < c.cfg.RetryBackoffMax; i++ {
+		backoff *= 2
+	}
+	if backoff > c.cfg.RetryBackoffMax {
+		backoff = c.cfg.RetryBackoffMax
+	}
+	return backoff
+}
+
+// Close closes the idle connections of the client.
+func (c *Client) Close() {
+	c.http.CloseIdleConnections()
+}
+
+// Sign returns the signature of a request, which is the hex encoded
+// HMAC-SHA256 of the timestamp, a dot and the body, prefixed by "sha256=".
+// The endpoint can verify the requests by it.
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// parseRetryAfter parses the Retry-After header, which is either the seconds
+// to delay or an HTTP date. 0 is returned if it's absent or invalid.
+func parseRetryAfter(s string) time.Duration {
+	if s == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(s); err == nil {
+		if seconds <= 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(s); err == nil {
+		if d := time.Until(date); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
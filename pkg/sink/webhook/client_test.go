@@ -0,0 +1,141 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientSend(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu       sync.Mutex
+		requests int
+		statuses []int
+	)
+	reset := func(s ...int) {
+		mu.Lock()
+		defer mu.Unlock()
+		requests = 0
+		statuses = s
+	}
+	requestCount := func() int {
+		mu.Lock()
+		defer mu.Unlock()
+		return requests
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, pass, ok := r.BasicAuth()
+		require.True(t, ok)
+		require.Equal(t, "cdc", user)
+		require.Equal(t, "pass", pass)
+		body, err := io.ReadAll(r.Body)
+		require.Nil(t, err)
+		require.Equal(t, "rows\n", string(body))
+		require.Equal(t, "application/x-ndjson", r.Header.Get("Content-Type"))
+		require.Equal(t, "canal-json", r.Header.Get(ProtocolHeader))
+		require.Equal(t, "default/test", r.Header.Get(ChangefeedHeader))
+		require.Equal(t, "ticdc/test", r.Header.Get("User-Agent"))
+		timestamp := r.Header.Get(TimestampHeader)
+		require.Equal(t, Sign("secret", timestamp, body), r.Header.Get(SignatureHeader))
+
+		mu.Lock()
+		status := statuses[requests]
+		requests++
+		mu.Unlock()
+		if status == http.StatusTooManyRequests {
+			w.Header().Set("Retry-After", "0")
+		}
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	cfg := NewConfig()
+	cfg.Endpoint = server.URL
+	cfg.User = "cdc"
+	cfg.Password = "pass"
+	cfg.HMACSecret = "secret"
+	cfg.UserAgent = "ticdc/test"
+	cfg.RetryBackoffBase = time.Millisecond
+	cfg.RetryBackoffMax = 10 * time.Millisecond
+	client, err := NewClient(model.DefaultChangeFeedID("test"), cfg)
+	require.Nil(t, err)
+	defer client.Close()
+
+	req := &Request{ContentType: "application/x-ndjson", Body: []byte("rows\n"), Header: http.Header{}}
+	req.Header.Set(ProtocolHeader, "canal-json")
+	reset(http.StatusTooManyRequests, http.StatusServiceUnavailable, http.StatusOK)
+	require.Nil(t, client.Send(context.Background(), req))
+	require.Equal(t, 3, requestCount())
+
+	// the retries are exhausted.
+	reset(http.StatusBadGateway, http.StatusBadGateway)
+	cfg.MaxRetries = 1
+	require.Regexp(t, "webhook request failed.*502", client.Send(context.Background(), req))
+	require.Equal(t, 2, requestCount())
+
+	// the client errors are not retried.
+	reset(http.StatusBadRequest)
+	require.Regexp(t, "webhook request failed.*400", client.Send(context.Background(), req))
+	require.Equal(t, 1, requestCount())
+}
+
+func TestClientBackoff(t *testing.T) {
+	t.Parallel()
+
+	cfg := NewConfig()
+	cfg.RetryBackoffBase = 100 * time.Millisecond
+	cfg.RetryBackoffMax = time.Second
+	client := &Client{cfg: cfg}
+	require.Equal(t, 100*time.Millisecond, client.backoff(0))
+	require.Equal(t, 200*time.Millisecond, client.backoff(1))
+	require.Equal(t, 800*time.Millisecond, client.backoff(3))
+	require.Equal(t, time.Second, client.backoff(4))
+	require.Equal(t, time.Second, client.backoff(100))
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, time.Duration(0), parseRetryAfter(""))
+	require.Equal(t, time.Duration(0), parseRetryAfter("a"))
+	require.Equal(t, time.Duration(0), parseRetryAfter("-1"))
+	require.Equal(t, 3*time.Second, parseRetryAfter("3"))
+	d := parseRetryAfter(time.Now().Add(time.Hour).UTC().Format(http.TimeFormat))
+	require.Greater(t, d, 59*time.Minute)
+	require.Equal(t, time.Duration(0),
+		parseRetryAfter(time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat)))
+}
+
+func TestSign(t *testing.T) {
+	t.Parallel()
+
+	// echo -n "1700000000.body" | openssl dgst -sha256 -hmac secret
+	require.Equal(t, "sha256=42ac6f0448c1d9c3e1e82b9726248f58fef84afffcbad5188246e96070e0ea46",
+		Sign("secret", "1700000000", []byte("body")))
+	require.NotEqual(t, Sign("secret", "1700000000", []byte("body")),
+		Sign("secret", "1700000001", []byte("body")))
+	require.NotEqual(t, Sign("secret", "1700000000", []byte("body")),
+		Sign("other", "1700000000", []byte("body")))
+}
@@ -0,0 +1,266 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pingcap/log"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/proxy"
+	"github.com/pingcap/tiflow/pkg/security"
+	psink "github.com/pingcap/tiflow/pkg/sink"
+	"go.uber.org/zap"
+)
+
+const (
+	// defaultWorkerCount is the default value of worker-count.
+	defaultWorkerCount = 4
+	// the upper limit of worker-count.
+	maxWorkerCount = 64
+	// defaultFlushSize is the default value of flush-size.
+	defaultFlushSize = 512
+	// the upper limit of flush-size.
+	maxFlushSize = 100000
+	// defaultFlushInterval is the default value of flush-interval.
+	defaultFlushInterval = time.Second
+	// the lower limit of flush-interval.
+	minFlushInterval = 100 * time.Millisecond
+	// the upper limit of flush-interval.
+	maxFlushInterval = time.Minute
+	// defaultMaxRetries is the default value of max-retries.
+	defaultMaxRetries = 10
+	// defaultRetryBackoffBase is the default value of retry-backoff-base.
+	defaultRetryBackoffBase = 500 * time.Millisecond
+	// defaultRetryBackoffMax is the default value of retry-backoff-max.
+	defaultRetryBackoffMax = 30 * time.Second
+	// defaultRequestTimeout is the default value of request-timeout.
+	defaultRequestTimeout = 30 * time.Second
+)
+
+// sinkParams are the query parameters of the sink URI which configure the
+// sink and the encoder, they are removed from the endpoint to avoid leaking
+// the secrets to the endpoint.
+var sinkParams = []string{
+	"protocol", "worker-count", "flush-size", "flush-interval", "max-retries",
+	"retry-backoff-base", "retry-backoff-max", "request-timeout", "hmac-secret",
+	"ssl-ca", "ssl-cert", "ssl-key", "enable-tidb-extension", "max-batch-size",
+	"max-message-bytes", "schema-registry", "proxy",
+}
+
+// Config is the configuration for the webhook sink.
+type Config struct {
+	// Endpoint is the URL which the batches are posted to, it's the sink URI
+	// without the user info and the parameters of the sink.
+	Endpoint string
+	User     string
+	Password string
+	// WorkerCount is the number of the concurrent requests.
+	WorkerCount int
+	// FlushSize is the max number of the rows encoded in a batch.
+	FlushSize int
+	// FlushInterval is the max interval to send the buffered rows.
+	FlushInterval time.Duration
+	// MaxRetries is the max number of the retries of a failed request, the
+	// requests aren't retried if it's 0.
+	MaxRetries int
+	// RetryBackoffBase is the backoff before the first retry, it's doubled
+	// for each retry until RetryBackoffMax.
+	RetryBackoffBase time.Duration
+	RetryBackoffMax  time.Duration
+	// RequestTimeout is the timeout of a request.
+	RequestTimeout time.Duration
+	// HMACSecret signs the requests by HMAC-SHA256 if it's not empty.
+	HMACSecret string
+	// Credential is the TLS credential to connect to the endpoint, it's nil
+	// if the default one of the system is used.
+	Credential *security.Credential
+	// Proxy is the proxy to connect to the endpoint, it's nil if no proxy is
+	// used.
+	Proxy *url.URL
+	// UserAgent is the User-Agent of the requests, the default one of Go is
+	// used if it's empty.
+	UserAgent string
+}
+
+// NewConfig returns the default webhook sink config.
+func NewConfig() *Config {
+	return &Config{
+		WorkerCount:      defaultWorkerCount,
+		FlushSize:        defaultFlushSize,
+		FlushInterval:    defaultFlushInterval,
+		MaxRetries:       defaultMaxRetries,
+		RetryBackoffBase: defaultRetryBackoffBase,
+		RetryBackoffMax:  defaultRetryBackoffMax,
+		RequestTimeout:   defaultRequestTimeout,
+	}
+}
+
+// Apply applies the sink URI parameters to the config. The parameters which
+// are not recognized by the sink are kept in the endpoint.
+func (c *Config) Apply(sinkURI *url.URL) error {
+	if sinkURI == nil {
+		return cerror.ErrWebhookInvalidConfig.GenWithStack(
+			"failed to open webhook sink, empty SinkURI")
+	}
+
+	scheme := strings.ToLower(sinkURI.Scheme)
+	if !psink.IsWebhookScheme(scheme) {
+		return cerror.ErrWebhookInvalidConfig.GenWithStack(
+			"can't create webhook sink with unsupported scheme: %s", scheme)
+	}
+	if sinkURI.Host == "" {
+		return cerror.ErrWebhookInvalidConfig.GenWithStack(
+			"the host of the webhook sink is empty")
+	}
+	c.User = sinkURI.User.Username()
+	c.Password, _ = sinkURI.User.Password()
+
+	query := sinkURI.Query()
+	if err := getPositiveInt(query, "worker-count", maxWorkerCount, &c.WorkerCount); err != nil {
+		return err
+	}
+	if err := getPositiveInt(query, "flush-size", maxFlushSize, &c.FlushSize); err != nil {
+		return err
+	}
+	if err := getFlushInterval(query, &c.FlushInterval); err != nil {
+		return err
+	}
+	if s := query.Get("max-retries"); len(s) > 0 {
+		retries, err := strconv.Atoi(s)
+		if err != nil {
+			return cerror.WrapError(cerror.ErrWebhookInvalidConfig, err)
+		}
+		if retries < 0 {
+			return cerror.WrapError(cerror.ErrWebhookInvalidConfig,
+				fmt.Errorf("invalid max-retries %d, it must not be negative", retries))
+		}
+		c.MaxRetries = retries
+	}
+	if err := getDuration(query, "retry-backoff-base", &c.RetryBackoffBase); err != nil {
+		return err
+	}
+	if err := getDuration(query, "retry-backoff-max", &c.RetryBackoffMax); err != nil {
+		return err
+	}
+	if c.RetryBackoffMax < c.RetryBackoffBase {
+		return cerror.WrapError(cerror.ErrWebhookInvalidConfig,
+			fmt.Errorf("invalid retry-backoff-max %s, it must not be less than "+
+				"retry-backoff-base %s", c.RetryBackoffMax, c.RetryBackoffBase))
+	}
+	if err := getDuration(query, "request-timeout", &c.RequestTimeout); err != nil {
+		return err
+	}
+	c.HMACSecret = query.Get("hmac-secret")
+	proxyURL, err := proxy.FromSinkURI(sinkURI)
+	if err != nil {
+		return cerror.WrapError(cerror.ErrWebhookInvalidConfig, err)
+	}
+	c.Proxy = proxyURL
+	if query.Get("ssl-ca") != "" {
+		c.Credential = &security.Credential{
+			CAPath:   query.Get("ssl-ca"),
+			CertPath: query.Get("ssl-cert"),
+			KeyPath:  query.Get("ssl-key"),
+		}
+	}
+
+	for _, param := range sinkParams {
+		query.Del(param)
+	}
+	for param := range query {
+		if strings.HasPrefix(param, "avro-") {
+			query.Del(param)
+		}
+	}
+	c.Endpoint = (&url.URL{
+		Scheme:   scheme,
+		Host:     sinkURI.Host,
+		Path:     sinkURI.Path,
+		RawQuery: query.Encode(),
+	}).String()
+	return nil
+}
+
+func getPositiveInt(values url.Values, name string, limit int, value *int) error {
+	s := values.Get(name)
+	if len(s) == 0 {
+		return nil
+	}
+
+	c, err := strconv.Atoi(s)
+	if err != nil {
+		return cerror.WrapError(cerror.ErrWebhookInvalidConfig, err)
+	}
+	if c <= 0 {
+		return cerror.WrapError(cerror.ErrWebhookInvalidConfig,
+			fmt.Errorf("invalid %s %d, it must be greater than 0", name, c))
+	}
+	if c > limit {
+		log.Warn(name+" is too large",
+			zap.Int("original", c), zap.Int("override", limit))
+		c = limit
+	}
+
+	*value = c
+	return nil
+}
+
+func getFlushInterval(values url.Values, flushInterval *time.Duration) error {
+	s := values.Get("flush-interval")
+	if len(s) == 0 {
+		return nil
+	}
+
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return cerror.WrapError(cerror.ErrWebhookInvalidConfig, err)
+	}
+	if d > maxFlushInterval {
+		log.Warn("flush-interval is too large", zap.Duration("original", d),
+			zap.Duration("override", maxFlushInterval))
+		d = maxFlushInterval
+	}
+	if d < minFlushInterval {
+		log.Warn("flush-interval is too small", zap.Duration("original", d),
+			zap.Duration("override", minFlushInterval))
+		d = minFlushInterval
+	}
+
+	*flushInterval = d
+	return nil
+}
+
+func getDuration(values url.Values, name string, value *time.Duration) error {
+	s := values.Get(name)
+	if len(s) == 0 {
+		return nil
+	}
+
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return cerror.WrapError(cerror.ErrWebhookInvalidConfig, err)
+	}
+	if d <= 0 {
+		return cerror.WrapError(cerror.ErrWebhookInvalidConfig,
+			fmt.Errorf("invalid %s %s, it must be greater than 0", name, s))
+	}
+
+	*value = d
+	return nil
+}
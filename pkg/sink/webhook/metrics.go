@@ -0,0 +1,41 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import "github.com/prometheus/client_golang/prometheus"
+
+var (
+	requestDurationHistogram = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "ticdc",
+			Subsystem: "sink",
+			Name:      "webhook_request_duration_seconds",
+			Help:      "Bucketed histogram of the duration of the requests to the webhook.",
+			Buckets:   prometheus.ExponentialBuckets(0.002, 2.0, 18), // 2ms~262s
+		}, []string{"namespace", "changefeed"})
+
+	retryCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "ticdc",
+			Subsystem: "sink",
+			Name:      "webhook_request_retries_total",
+			Help:      "The number of the retried requests to the webhook.",
+		}, []string{"namespace", "changefeed"})
+)
+
+// InitMetrics registers all metrics in this file.
+func InitMetrics(registry prometheus.Registerer) {
+	registry.MustRegister(requestDurationHistogram)
+	registry.MustRegister(retryCounter)
+}
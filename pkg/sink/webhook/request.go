@@ -0,0 +1,77 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"encoding/base64"
+	"net/http"
+
+	"github.com/pingcap/tiflow/cdc/sink/codec/common"
+	"github.com/pingcap/tiflow/pkg/config"
+)
+
+const (
+	// ProtocolHeader is the header of the protocol encoding the body.
+	ProtocolHeader = "X-TiCDC-Protocol"
+	// MessageKeyHeader is the header of the base64 encoded key of the
+	// message, it's only set for the protocols sending a message in a request.
+	MessageKeyHeader = "X-TiCDC-Message-Key"
+)
+
+// BuildRequests builds the requests posting the encoded messages. The
+// messages of canal-json and csv are terminated by the terminator, so they
+// are concatenated in a request. The messages of the other protocols are
+// sent in a request each, along with their keys.
+func BuildRequests(protocol config.Protocol, msgs []*common.Message) []*Request {
+	if len(msgs) == 0 {
+		return nil
+	}
+	header := http.Header{}
+	header.Set(ProtocolHeader, protocol.String())
+
+	switch protocol {
+	case config.ProtocolCanalJSON, config.ProtocolCsv:
+		size := 0
+		for _, msg := range msgs {
+			size += len(msg.Value)
+		}
+		body := make([]byte, 0, size)
+		for _, msg := range msgs {
+			body = append(body, msg.Value...)
+		}
+		return []*Request{{ContentType: contentType(protocol), Body: body, Header: header}}
+	default:
+		reqs := make([]*Request, 0, len(msgs))
+		for _, msg := range msgs {
+			req := &Request{ContentType: contentType(protocol), Body: msg.Value, Header: header}
+			if len(msg.Key) > 0 {
+				req.Header = header.Clone()
+				req.Header.Set(MessageKeyHeader, base64.StdEncoding.EncodeToString(msg.Key))
+			}
+			reqs = append(reqs, req)
+		}
+		return reqs
+	}
+}
+
+func contentType(protocol config.Protocol) string {
+	switch protocol {
+	case config.ProtocolCanalJSON:
+		return "application/x-ndjson"
+	case config.ProtocolCsv:
+		return "text/csv"
+	default:
+		return "application/octet-stream"
+	}
+}
@@ -0,0 +1,50 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/pingcap/tiflow/cdc/sink/codec/common"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildRequests(t *testing.T) {
+	t.Parallel()
+
+	require.Nil(t, BuildRequests(config.ProtocolCanalJSON, nil))
+
+	msgs := []*common.Message{
+		{Key: []byte("k1"), Value: []byte("{\"id\":1}\n")},
+		{Key: []byte("k2"), Value: []byte("{\"id\":2}\n")},
+	}
+	reqs := BuildRequests(config.ProtocolCanalJSON, msgs)
+	require.Len(t, reqs, 1)
+	require.Equal(t, "application/x-ndjson", reqs[0].ContentType)
+	require.Equal(t, "{\"id\":1}\n{\"id\":2}\n", string(reqs[0].Body))
+	require.Equal(t, "canal-json", reqs[0].Header.Get(ProtocolHeader))
+	require.Empty(t, reqs[0].Header.Get(MessageKeyHeader))
+
+	reqs = BuildRequests(config.ProtocolOpen, msgs)
+	require.Len(t, reqs, 2)
+	for i, req := range reqs {
+		require.Equal(t, "application/octet-stream", req.ContentType)
+		require.Equal(t, msgs[i].Value, req.Body)
+		require.Equal(t, "open-protocol", req.Header.Get(ProtocolHeader))
+		require.Equal(t, base64.StdEncoding.EncodeToString(msgs[i].Key),
+			req.Header.Get(MessageKeyHeader))
+	}
+}
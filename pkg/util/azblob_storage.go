@@ -0,0 +1,302 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+	"github.com/pingcap/tidb/br/pkg/storage"
+	"github.com/pingcap/tiflow/pkg/errors"
+)
+
+const (
+	// azblobSASTokenParam is the query parameter of the SAS token of an Azure
+	// Blob storage, the token must be URL encoded since it's a query string
+	// itself.
+	azblobSASTokenParam = "sas-token"
+	// azblobManagedIdentityParam enables the authentication by the managed
+	// identity of the Azure VM or AKS pod.
+	azblobManagedIdentityParam = "use-managed-identity"
+	// azblobManagedIdentityClientIDParam is the client id of a user-assigned
+	// managed identity, the system-assigned one is used if it's empty.
+	azblobManagedIdentityClientIDParam = "managed-identity-client-id"
+	azblobAccountNameParam             = "account-name"
+	azblobEndpointParam                = "endpoint"
+)
+
+// azblobAuthOptions are the authentications of an Azure Blob storage which
+// are not supported by BR, a storage with them is created by TiCDC itself.
+type azblobAuthOptions struct {
+	container   string
+	prefix      string
+	accountName string
+	endpoint    string
+
+	sasToken                string
+	useManagedIdentity      bool
+	managedIdentityClientID string
+}
+
+// parseAzblobAuthOptions parses the options of an Azure Blob storage uri, it
+// returns nil if the uri doesn't use the authentications added by TiCDC.
+func parseAzblobAuthOptions(uri string) (*azblobAuthOptions, error) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return nil, errors.WrapError(errors.ErrCloudStorageInvalidConfig, err)
+	}
+	if u.Scheme != "azblob" && u.Scheme != "azure" {
+		return nil, nil
+	}
+	query := u.Query()
+	opts := &azblobAuthOptions{
+		container:               u.Host,
+		prefix:                  strings.Trim(u.Path, "/"),
+		accountName:             query.Get(azblobAccountNameParam),
+		endpoint:                query.Get(azblobEndpointParam),
+		sasToken:                strings.TrimPrefix(query.Get(azblobSASTokenParam), "?"),
+		managedIdentityClientID: query.Get(azblobManagedIdentityClientIDParam),
+	}
+	if s := query.Get(azblobManagedIdentityParam); s != "" {
+		opts.useManagedIdentity, err = strconv.ParseBool(s)
+		if err != nil {
+			return nil, errors.WrapError(errors.ErrCloudStorageInvalidConfig,
+				fmt.Errorf("invalid %s %s: %w", azblobManagedIdentityParam, s, err))
+		}
+	}
+	if opts.managedIdentityClientID != "" {
+		opts.useManagedIdentity = true
+	}
+	if opts.sasToken == "" && !opts.useManagedIdentity {
+		return nil, nil
+	}
+	if opts.sasToken != "" && opts.useManagedIdentity {
+		return nil, errors.ErrCloudStorageInvalidConfig.GenWithStack(
+			"%s and %s can't be used together", azblobSASTokenParam, azblobManagedIdentityParam)
+	}
+	if opts.container == "" {
+		return nil, errors.ErrCloudStorageInvalidConfig.GenWithStack(
+			"the container of azure blob storage is not specified")
+	}
+	if opts.accountName == "" {
+		opts.accountName = os.Getenv("AZURE_STORAGE_ACCOUNT")
+	}
+	if opts.endpoint == "" {
+		if opts.accountName == "" {
+			return nil, errors.ErrCloudStorageInvalidConfig.GenWithStack(
+				"neither %s nor %s of azure blob storage is specified",
+				azblobAccountNameParam, azblobEndpointParam)
+		}
+		opts.endpoint = fmt.Sprintf("https://%s.blob.core.windows.net", opts.accountName)
+	}
+	opts.endpoint = strings.TrimSuffix(opts.endpoint, "/")
+	return opts, nil
+}
+
+// serviceURL returns the URL of the blob service, the SAS token is appended
+// to it since it's the credential.
+func (o *azblobAuthOptions) serviceURL() string {
+	if o.sasToken == "" {
+		return o.endpoint + "/"
+	}
+	return o.endpoint + "/?" + o.sasToken
+}
+
+// azblobStorage is a storage.ExternalStorage on Azure Blob storage, the
+// files are the block blobs in a container.
+type azblobStorage struct {
+	opts   *azblobAuthOptions
+	client azblob.ContainerClient
+}
+
+// newAzblobStorage creates an azblobStorage authenticated by a SAS token or a
+// managed identity.
+func newAzblobStorage(opts *azblobAuthOptions) (*azblobStorage, error) {
+	var (
+		serviceClient azblob.ServiceClient
+		err           error
+	)
+	if opts.useManagedIdentity {
+		credOpts := &azidentity.ManagedIdentityCredentialOptions{}
+		if opts.managedIdentityClientID != "" {
+			credOpts.ID = azidentity.ClientID(opts.managedIdentityClientID)
+		}
+		cred, err := azidentity.NewManagedIdentityCredential(credOpts)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		serviceClient, err = azblob.NewServiceClient(opts.serviceURL(), cred, nil)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+	} else {
+		serviceClient, err = azblob.NewServiceClientWithNoCredential(opts.serviceURL(), nil)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return &azblobStorage{
+		opts:   opts,
+		client: serviceClient.NewContainerClient(opts.container),
+	}, nil
+}
+
+func (s *azblobStorage) blobName(name string) string {
+	return path.Join(s.opts.prefix, name)
+}
+
+// WriteFile implements storage.ExternalStorage.
+func (s *azblobStorage) WriteFile(ctx context.Context, name string, data []byte) error {
+	client := s.client.NewBlockBlobClient(s.blobName(name))
+	_, err := client.UploadBufferToBlockBlob(ctx, data, azblob.HighLevelUploadToBlockBlobOption{})
+	return errors.Trace(err)
+}
+
+// ReadFile implements storage.ExternalStorage.
+func (s *azblobStorage) ReadFile(ctx context.Context, name string) ([]byte, error) {
+	client := s.client.NewBlockBlobClient(s.blobName(name))
+	resp, err := client.Download(ctx, nil)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	body := resp.Body(azblob.RetryReaderOptions{MaxRetryRequests: 3})
+	defer body.Close()
+	data, err := io.ReadAll(body)
+	return data, errors.Trace(err)
+}
+
+// FileExists implements storage.ExternalStorage.
+func (s *azblobStorage) FileExists(ctx context.Context, name string) (bool, error) {
+	client := s.client.NewBlockBlobClient(s.blobName(name))
+	_, err := client.GetProperties(ctx, nil)
+	if err != nil {
+		var storageErr *azblob.StorageError
+		if errors.As(err, &storageErr) &&
+			storageErr.ErrorCode == azblob.StorageErrorCodeBlobNotFound {
+			return false, nil
+		}
+		return false, errors.Trace(err)
+	}
+	return true, nil
+}
+
+// DeleteFile implements storage.ExternalStorage.
+func (s *azblobStorage) DeleteFile(ctx context.Context, name string) error {
+	client := s.client.NewBlockBlobClient(s.blobName(name))
+	_, err := client.Delete(ctx, nil)
+	return errors.Trace(err)
+}
+
+// Open implements storage.ExternalStorage. The whole blob is read at once,
+// the files written by TiCDC are small enough.
+func (s *azblobStorage) Open(ctx context.Context, name string) (storage.ExternalFileReader, error) {
+	data, err := s.ReadFile(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return &azblobFileReader{Reader: bytes.NewReader(data)}, nil
+}
+
+// WalkDir implements storage.ExternalStorage.
+func (s *azblobStorage) WalkDir(
+	ctx context.Context, opt *storage.WalkOption, fn func(path string, size int64) error,
+) error {
+	if opt == nil {
+		opt = &storage.WalkOption{}
+	}
+	prefix := path.Join(s.opts.prefix, opt.SubDir)
+	if prefix != "" && !strings.HasSuffix(prefix, "/") {
+		prefix += "/"
+	}
+	basePrefix := s.opts.prefix
+	if basePrefix != "" {
+		basePrefix += "/"
+	}
+
+	pager := s.client.ListBlobsFlat(&azblob.ContainerListBlobFlatSegmentOptions{Prefix: &prefix})
+	for pager.NextPage(ctx) {
+		resp := pager.PageResponse()
+		for _, blob := range resp.Segment.BlobItems {
+			var size int64
+			if blob.Properties != nil && blob.Properties.ContentLength != nil {
+				size = *blob.Properties.ContentLength
+			}
+			if err := fn(strings.TrimPrefix(*blob.Name, basePrefix), size); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+	return errors.Trace(pager.Err())
+}
+
+// URI implements storage.ExternalStorage.
+func (s *azblobStorage) URI() string {
+	return "azure://" + path.Join(s.opts.container, s.opts.prefix)
+}
+
+// Create implements storage.ExternalStorage. The data is buffered and
+// uploaded when the writer is closed.
+func (s *azblobStorage) Create(ctx context.Context, name string) (storage.ExternalFileWriter, error) {
+	return &azblobFileWriter{storage: s, name: name}, nil
+}
+
+// Rename implements storage.ExternalStorage. Azure Blob storage can't rename
+// a blob, so it's copied and deleted.
+func (s *azblobStorage) Rename(ctx context.Context, oldFileName, newFileName string) error {
+	data, err := s.ReadFile(ctx, oldFileName)
+	if err != nil {
+		return err
+	}
+	if err := s.WriteFile(ctx, newFileName, data); err != nil {
+		return err
+	}
+	return s.DeleteFile(ctx, oldFileName)
+}
+
+// azblobFileReader is a storage.ExternalFileReader of a blob read in memory.
+type azblobFileReader struct {
+	*bytes.Reader
+}
+
+// Close implements io.Closer.
+func (r *azblobFileReader) Close() error {
+	return nil
+}
+
+// azblobFileWriter is a storage.ExternalFileWriter which uploads a blob
+// when it's closed.
+type azblobFileWriter struct {
+	storage *azblobStorage
+	name    string
+	buf     bytes.Buffer
+}
+
+// Write implements storage.ExternalFileWriter.
+func (w *azblobFileWriter) Write(_ context.Context, p []byte) (int, error) {
+	return w.buf.Write(p)
+}
+
+// Close implements storage.ExternalFileWriter.
+func (w *azblobFileWriter) Close(ctx context.Context) error {
+	return w.storage.WriteFile(ctx, w.name, w.buf.Bytes())
+}
@@ -0,0 +1,62 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAzblobAuthOptions(t *testing.T) {
+	opts, err := parseAzblobAuthOptions("s3://bucket/prefix?sas-token=abc")
+	require.NoError(t, err)
+	require.Nil(t, opts)
+	opts, err = parseAzblobAuthOptions("azblob://container/prefix?account-name=test&account-key=key")
+	require.NoError(t, err)
+	require.Nil(t, opts)
+
+	opts, err = parseAzblobAuthOptions(
+		"azblob://container/prefix/?account-name=test&sas-token=%3Fsv%3D2021-06-08%26sig%3Dabc")
+	require.NoError(t, err)
+	require.Equal(t, &azblobAuthOptions{
+		container:   "container",
+		prefix:      "prefix",
+		accountName: "test",
+		endpoint:    "https://test.blob.core.windows.net",
+		sasToken:    "sv=2021-06-08&sig=abc",
+	}, opts)
+	require.Equal(t, "https://test.blob.core.windows.net/?sv=2021-06-08&sig=abc", opts.serviceURL())
+
+	opts, err = parseAzblobAuthOptions(
+		"azure://container?endpoint=http://127.0.0.1:10000/devstoreaccount1/&managed-identity-client-id=id")
+	require.NoError(t, err)
+	require.Equal(t, &azblobAuthOptions{
+		container:               "container",
+		endpoint:                "http://127.0.0.1:10000/devstoreaccount1",
+		useManagedIdentity:      true,
+		managedIdentityClientID: "id",
+	}, opts)
+	require.Equal(t, "http://127.0.0.1:10000/devstoreaccount1/", opts.serviceURL())
+
+	_, err = parseAzblobAuthOptions("azblob://container?account-name=test&use-managed-identity=yes")
+	require.Regexp(t, "invalid use-managed-identity", err)
+	_, err = parseAzblobAuthOptions("azblob://container?account-name=test&use-managed-identity=true&sas-token=abc")
+	require.Regexp(t, "can't be used together", err)
+	_, err = parseAzblobAuthOptions("azblob://?account-name=test&sas-token=abc")
+	require.Regexp(t, "container of azure blob storage is not specified", err)
+	t.Setenv("AZURE_STORAGE_ACCOUNT", "")
+	_, err = parseAzblobAuthOptions("azblob://container?sas-token=abc")
+	require.Regexp(t, "neither account-name nor endpoint", err)
+}
@@ -34,9 +34,24 @@ func GetExternalStorageFromURI(
 }
 
 // GetExternalStorage creates a new storage.ExternalStorage based on the uri and options.
+// An Azure Blob storage authenticated by a SAS token or a managed identity
+// is created by TiCDC, since BR doesn't support them.
 func GetExternalStorage(
 	ctx context.Context, uri string, opts *storage.BackendOptions,
 ) (storage.ExternalStorage, error) {
+	azblobOpts, err := parseAzblobAuthOptions(uri)
+	if err != nil {
+		return nil, err
+	}
+	if azblobOpts != nil {
+		ret, err := newAzblobStorage(azblobOpts)
+		if err != nil {
+			retErr := errors.ErrFailToCreateExternalStorage.Wrap(errors.Trace(err))
+			return nil, retErr.GenWithStackByArgs("creating ExternalStorage for azure blob storage")
+		}
+		return ret, nil
+	}
+
 	backEnd, err := storage.ParseBackend(uri, opts)
 	if err != nil {
 		return nil, errors.Trace(err)
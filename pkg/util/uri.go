@@ -16,6 +16,7 @@ package util
 import (
 	"net"
 	"net/url"
+	"sort"
 	"strings"
 
 	"github.com/pingcap/log"
@@ -66,17 +67,90 @@ func validOptionalPort(port string) bool {
 	return true
 }
 
+// sensitiveQueryParams are the query parameters of a sink uri which
+// contain secrets.
+var sensitiveQueryParams = map[string]struct{}{
+	"account-key":              {},
+	"password":                 {},
+	"sasl-password":            {},
+	"sasl-gssapi-password":     {},
+	"sasl-oauth-client-secret": {},
+	"secret-access-key":        {},
+	"session-token":            {},
+	"sas-token":                {},
+	"hmac-secret":              {},
+}
+
+// urlQueryParams are the query parameters of a sink uri whose values are
+// urls, the passwords in the urls are masked.
+var urlQueryParams = map[string]struct{}{
+	"proxy": {},
+}
+
+const maskedSecret = "xxxxx"
+
 // MaskSinkURI returns a sink uri that sensitive infos has been masked.
+// The password in the user info and the sensitive query parameters are
+// masked, and the query parameters are sorted by name, so that the uri
+// is printed in the same form in logs, API responses and CLI outputs.
 func MaskSinkURI(uri string) (string, error) {
 	uriParsed, err := url.Parse(uri)
 	if err != nil {
 		log.Error("failed to parse sink URI", zap.Error(err))
 		return "", err
 	}
-	queries := uriParsed.Query()
-	if queries.Has("sasl-password") {
-		queries.Set("sasl-password", "xxxxx")
-		uriParsed.RawQuery = queries.Encode()
+	if uriParsed.RawQuery != "" {
+		params := strings.Split(uriParsed.RawQuery, "&")
+		for i, param := range params {
+			name, value, _ := strings.Cut(param, "=")
+			key, err := url.QueryUnescape(name)
+			if err != nil {
+				continue
+			}
+			if IsSensitiveQueryParam(key) {
+				params[i] = name + "=" + maskedSecret
+			} else if _, ok := urlQueryParams[strings.ToLower(key)]; ok {
+				params[i] = name + "=" + maskURLQueryParam(value)
+			}
+		}
+		sort.SliceStable(params, func(i, j int) bool {
+			ni, _, _ := strings.Cut(params[i], "=")
+			nj, _, _ := strings.Cut(params[j], "=")
+			return ni < nj
+		})
+		uriParsed.RawQuery = strings.Join(params, "&")
 	}
 	return uriParsed.Redacted(), nil
 }
+
+// maskURLQueryParam masks the password in the url of a query parameter, the
+// value is masked entirely if it can not be parsed.
+func maskURLQueryParam(value string) string {
+	s, err := url.QueryUnescape(value)
+	if err != nil {
+		return maskedSecret
+	}
+	u, err := url.Parse(s)
+	if err != nil {
+		return maskedSecret
+	}
+	return url.QueryEscape(u.Redacted())
+}
+
+// MaskSinkURIForLog is like MaskSinkURI, but it never returns an error, so
+// that it can be used in log fields directly. The uri is replaced entirely
+// if it can not be parsed, since it may contain secrets.
+func MaskSinkURIForLog(uri string) string {
+	masked, err := MaskSinkURI(uri)
+	if err != nil {
+		return "<invalid sink uri>"
+	}
+	return masked
+}
+
+// IsSensitiveQueryParam returns true if the query parameter of a sink uri
+// contains secrets.
+func IsSensitiveQueryParam(name string) bool {
+	_, ok := sensitiveQueryParams[strings.ToLower(name)]
+	return ok
+}
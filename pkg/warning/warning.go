@@ -0,0 +1,162 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package warning
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/cdc/model"
+	"go.uber.org/zap"
+)
+
+// registry keeps the warnings of the changefeeds in this process, they are
+// persisted into the task positions by the processors.
+var registry = struct {
+	sync.Mutex
+	warnings map[model.ChangeFeedID]map[string]*model.RunningWarning
+	// loggedAt is the last time the warnings of each code are logged.
+	loggedAt map[model.ChangeFeedID]map[string]time.Time
+	// pausedSinks is the number of the paused sinks of the changefeeds.
+	pausedSinks map[model.ChangeFeedID]int
+}{
+	warnings:    make(map[model.ChangeFeedID]map[string]*model.RunningWarning),
+	loggedAt:    make(map[model.ChangeFeedID]map[string]time.Time),
+	pausedSinks: make(map[model.ChangeFeedID]int),
+}
+
+// logInterval is the min interval between the logs of the warnings of a
+// code, the ones recorded in between are only counted.
+const logInterval = 10 * time.Second
+
+// Record records a recoverable anomaly of the changefeed, the code of err
+// identifies the kind of it. The anomaly is logged as well, at most once
+// per logInterval for each code, so it can be recorded on the hot path.
+func Record(changefeedID model.ChangeFeedID, err *errors.Error, message string) {
+	code := string(err.RFCCode())
+	now := time.Now()
+
+	registry.Lock()
+	warnings, ok := registry.warnings[changefeedID]
+	if !ok {
+		warnings = make(map[string]*model.RunningWarning)
+		registry.warnings[changefeedID] = warnings
+	}
+	w, ok := warnings[code]
+	if !ok {
+		w = &model.RunningWarning{Code: code}
+		warnings[code] = w
+	}
+	w.Message = message
+	w.Count++
+	w.LastSeen = now.UnixMilli()
+	count := w.Count
+
+	loggedAt, ok := registry.loggedAt[changefeedID]
+	if !ok {
+		loggedAt = make(map[string]time.Time)
+		registry.loggedAt[changefeedID] = loggedAt
+	}
+	shouldLog := now.Sub(loggedAt[code]) >= logInterval
+	if shouldLog {
+		loggedAt[code] = now
+	}
+	registry.Unlock()
+
+	if shouldLog {
+		log.Warn("changefeed warning",
+			zap.String("namespace", changefeedID.Namespace),
+			zap.String("changefeed", changefeedID.ID),
+			zap.String("code", code),
+			zap.String("message", message),
+			zap.Uint64("count", count))
+	}
+}
+
+// Warnings returns the copies of the warnings of the changefeed recorded in
+// this process, sorted by the codes.
+func Warnings(changefeedID model.ChangeFeedID) []*model.RunningWarning {
+	registry.Lock()
+	defer registry.Unlock()
+	warnings := registry.warnings[changefeedID]
+	ret := make([]*model.RunningWarning, 0, len(warnings))
+	for _, w := range warnings {
+		warning := *w
+		ret = append(ret, &warning)
+	}
+	sort.Slice(ret, func(i, j int) bool { return ret[i].Code < ret[j].Code })
+	return ret
+}
+
+// Remove removes the warnings of the changefeed recorded in this process.
+func Remove(changefeedID model.ChangeFeedID) {
+	registry.Lock()
+	defer registry.Unlock()
+	delete(registry.warnings, changefeedID)
+	delete(registry.loggedAt, changefeedID)
+}
+
+// SetSinkPaused records that a sink of the changefeed is paused or resumed,
+// each pause must be paired with a resume.
+func SetSinkPaused(changefeedID model.ChangeFeedID, paused bool) {
+	registry.Lock()
+	defer registry.Unlock()
+	if paused {
+		registry.pausedSinks[changefeedID]++
+		return
+	}
+	if registry.pausedSinks[changefeedID] <= 1 {
+		delete(registry.pausedSinks, changefeedID)
+		return
+	}
+	registry.pausedSinks[changefeedID]--
+}
+
+// SinkPaused returns whether any sink of the changefeed in this process is
+// paused.
+func SinkPaused(changefeedID model.ChangeFeedID) bool {
+	registry.Lock()
+	defer registry.Unlock()
+	return registry.pausedSinks[changefeedID] > 0
+}
+
+// Merge merges the warnings reported by different processors, the ones of
+// the same code are counted together and the latest message is kept.
+func Merge(warningsList ...[]*model.RunningWarning) []*model.RunningWarning {
+	merged := make(map[string]*model.RunningWarning)
+	for _, warnings := range warningsList {
+		for _, w := range warnings {
+			m, ok := merged[w.Code]
+			if !ok {
+				warning := *w
+				merged[w.Code] = &warning
+				continue
+			}
+			m.Count += w.Count
+			if w.LastSeen > m.LastSeen {
+				m.LastSeen = w.LastSeen
+				m.Message = w.Message
+			}
+		}
+	}
+	ret := make([]*model.RunningWarning, 0, len(merged))
+	for _, w := range merged {
+		ret = append(ret, w)
+	}
+	sort.Slice(ret, func(i, j int) bool { return ret[i].Code < ret[j].Code })
+	return ret
+}
@@ -0,0 +1,98 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package warning
+
+import (
+	"testing"
+
+	"github.com/pingcap/tiflow/cdc/model"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecord(t *testing.T) {
+	t.Parallel()
+
+	id := model.DefaultChangeFeedID("test-record")
+	defer Remove(id)
+	require.Empty(t, Warnings(id))
+
+	Record(id, cerror.ErrMySQLSafeMode, "table t1")
+	Record(id, cerror.ErrMySQLSafeMode, "table t2")
+	Record(id, cerror.ErrKafkaRefreshTopicMetadata, "broker down")
+	warnings := Warnings(id)
+	require.Len(t, warnings, 2)
+	require.Equal(t, "CDC:ErrKafkaRefreshTopicMetadata", warnings[0].Code)
+	require.Equal(t, uint64(1), warnings[0].Count)
+	require.Equal(t, "CDC:ErrMySQLSafeMode", warnings[1].Code)
+	require.Equal(t, uint64(2), warnings[1].Count)
+	require.Equal(t, "table t2", warnings[1].Message)
+	require.NotZero(t, warnings[1].LastSeen)
+
+	// the returned warnings are copies.
+	warnings[1].Count = 100
+	require.Equal(t, uint64(2), Warnings(id)[1].Count)
+
+	// The warnings of a code are logged at most once in the log interval.
+	registry.Lock()
+	loggedAt := registry.loggedAt[id]["CDC:ErrMySQLSafeMode"]
+	registry.Unlock()
+	require.False(t, loggedAt.IsZero())
+	Record(id, cerror.ErrMySQLSafeMode, "table t3")
+	registry.Lock()
+	require.Equal(t, loggedAt, registry.loggedAt[id]["CDC:ErrMySQLSafeMode"])
+	registry.Unlock()
+	require.Equal(t, uint64(3), Warnings(id)[1].Count)
+
+	Remove(id)
+	require.Empty(t, Warnings(id))
+}
+
+func TestSinkPaused(t *testing.T) {
+	t.Parallel()
+
+	id := model.DefaultChangeFeedID("test-sink-paused")
+	require.False(t, SinkPaused(id))
+	SetSinkPaused(id, true)
+	SetSinkPaused(id, true)
+	require.True(t, SinkPaused(id))
+	SetSinkPaused(id, false)
+	require.True(t, SinkPaused(id))
+	SetSinkPaused(id, false)
+	require.False(t, SinkPaused(id))
+	// An unpaired resume is ignored.
+	SetSinkPaused(id, false)
+	SetSinkPaused(id, true)
+	require.True(t, SinkPaused(id))
+	SetSinkPaused(id, false)
+}
+
+func TestMerge(t *testing.T) {
+	t.Parallel()
+
+	merged := Merge(
+		[]*model.RunningWarning{
+			{Code: "b", Message: "b1", Count: 1, LastSeen: 10},
+			{Code: "a", Message: "a1", Count: 2, LastSeen: 20},
+		},
+		nil,
+		[]*model.RunningWarning{
+			{Code: "a", Message: "a2", Count: 3, LastSeen: 30},
+		},
+	)
+	require.Equal(t, []*model.RunningWarning{
+		{Code: "a", Message: "a2", Count: 5, LastSeen: 30},
+		{Code: "b", Message: "b1", Count: 1, LastSeen: 10},
+	}, merged)
+}